@@ -0,0 +1,90 @@
+// Package benchmark compares the metrics of one repository against the
+// distribution of the same metrics across a portfolio of repositories, so
+// that reports can say where a repository stands ("more dependencies than
+// 80% of the organization") rather than give bare numbers.
+package benchmark
+
+import (
+	"math"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/trends"
+)
+
+// DefaultMetrics are the metrics compared when none are selected.
+var DefaultMetrics = []string{trends.MetricCommentRatio, trends.MetricDeps, trends.MetricTestRatio, trends.MetricCurrency}
+
+// Comparison places the value of one metric in the portfolio distribution.
+type Comparison struct {
+	Metric     string  `json:"metric"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"` // share of the portfolio below the value, ties counting half, 0-100
+	Min        float64 `json:"min"`
+	P25        float64 `json:"p25"`
+	Median     float64 `json:"median"`
+	P75        float64 `json:"p75"`
+	Max        float64 `json:"max"`
+	Repos      int     `json:"repos"` // portfolio repositories having the metric
+}
+
+// Compare compares the metric values of a repository against those of the
+// portfolio. Metrics the repository, or every portfolio repository, lacks
+// are skipped.
+func Compare(subject map[string]float64, portfolio []map[string]float64, metrics []string) []*Comparison {
+	comparisons := []*Comparison{}
+	for _, metric := range metrics {
+		value, ok := subject[metric]
+		if !ok {
+			continue
+		}
+		var distribution []float64
+		for _, repo := range portfolio {
+			if v, ok := repo[metric]; ok {
+				distribution = append(distribution, v)
+			}
+		}
+		if len(distribution) == 0 {
+			continue
+		}
+		comparisons = append(comparisons, compare(metric, value, distribution))
+	}
+	return comparisons
+}
+
+func compare(metric string, value float64, distribution []float64) *Comparison {
+	sort.Float64s(distribution)
+	below, equal := 0, 0
+	for _, v := range distribution {
+		switch {
+		case v < value:
+			below++
+		case v == value:
+			equal++
+		}
+	}
+	n := len(distribution)
+	return &Comparison{
+		Metric:     metric,
+		Value:      value,
+		Percentile: round((float64(below) + float64(equal)/2) / float64(n) * 100),
+		Min:        distribution[0],
+		P25:        round(quantile(distribution, 0.25)),
+		Median:     round(quantile(distribution, 0.5)),
+		P75:        round(quantile(distribution, 0.75)),
+		Max:        distribution[n-1],
+		Repos:      n,
+	}
+}
+
+// quantile interpolates the q quantile of sorted values linearly between
+// the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,33 @@
+package benchmark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	portfolio := []map[string]float64{
+		{"deps": 10, "comment_ratio": 0.1},
+		{"deps": 20, "comment_ratio": 0.2},
+		{"deps": 30},
+		{"deps": 40, "comment_ratio": 0.3},
+		{"deps": 50},
+	}
+	comparisons := Compare(map[string]float64{"deps": 30, "comment_ratio": 0.35}, portfolio, []string{"comment_ratio", "deps", "currency"})
+
+	require.Len(t, comparisons, 2)
+	assert.Equal(t, &Comparison{Metric: "comment_ratio", Value: 0.35, Percentile: 100, Min: 0.1, P25: 0.15, Median: 0.2, P75: 0.25, Max: 0.3, Repos: 3}, comparisons[0])
+	assert.Equal(t, &Comparison{Metric: "deps", Value: 30, Percentile: 50, Min: 10, P25: 20, Median: 30, P75: 40, Max: 50, Repos: 5}, comparisons[1])
+}
+
+func TestCompareSkipsMissingMetrics(t *testing.T) {
+	portfolio := []map[string]float64{{"deps": 4}}
+	assert.Empty(t, Compare(map[string]float64{"currency": 0.8}, portfolio, []string{"currency", "deps"}))
+
+	comparisons := Compare(map[string]float64{"deps": 1}, portfolio, []string{"deps"})
+	require.Len(t, comparisons, 1)
+	assert.Equal(t, 0.0, comparisons[0].Percentile)
+	assert.Equal(t, 4.0, comparisons[0].Median)
+}
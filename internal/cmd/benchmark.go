@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/benchmark"
+	"github.com/petrarca/tech-stack-analyzer/internal/currency"
+	currencycache "github.com/petrarca/tech-stack-analyzer/internal/currency/cache"
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/store"
+	"github.com/petrarca/tech-stack-analyzer/internal/trends"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	benchmarkFormat        string
+	benchmarkOutput        string
+	benchmarkStore         string
+	benchmarkRepo          string
+	benchmarkMetrics       string
+	benchmarkCurrencyCache string
+)
+
+var benchmarkCmd = &cobra.Command{
+	Use:   "benchmark [scan-output.json]",
+	Short: "Compare a repository's metrics against the portfolio of stored scans",
+	Long: `Benchmark places the metrics of one repository in the distribution of the
+same metrics across a portfolio: the latest scan of every other job in a
+daemon results database. Each metric reports the repository's value, its
+percentile in the portfolio and the portfolio's quartiles.
+
+The repository is a scan output file, or, with --repo, the latest stored scan
+of a daemon job or root ID.
+
+Metrics (default: comment_ratio, deps, test_ratio, currency):
+  loc, files, deps, techs, components   as in 'trends'
+  comment_ratio   comments per line of code of programming languages
+  test_ratio      test files per programming-language file
+  currency        share of resolved direct dependencies on their latest version
+
+Currency uses the latest versions already in the currency cache (filled by
+'currency' and scan --resolve-currency); benchmark makes no network requests
+and leaves the metric out when there is no cache.
+
+Examples:
+  stack-analyzer benchmark result.json --store results.db
+  stack-analyzer benchmark --store results.db --repo myapp -f text
+  stack-analyzer benchmark result.json --store results.db --metric deps,techs,loc`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBenchmark,
+}
+
+func init() {
+	rootCmd.AddCommand(benchmarkCmd)
+	setupOutputFlags(benchmarkCmd, &benchmarkFormat, &benchmarkOutput)
+	benchmarkCmd.Flags().StringVar(&benchmarkStore, "store", "", "Daemon results database (SQLite) holding the portfolio")
+	benchmarkCmd.Flags().StringVar(&benchmarkRepo, "repo", "", "Benchmark the latest stored scan of this daemon job or root ID instead of a file")
+	benchmarkCmd.Flags().StringVar(&benchmarkMetrics, "metric", "", "Comma-separated metrics: "+strings.Join(trends.Metrics, ", ")+" (default: "+strings.Join(benchmark.DefaultMetrics, ", ")+")")
+	benchmarkCmd.Flags().StringVar(&benchmarkCurrencyCache, "currency-cache", "", "Currency cache DB path for the currency metric (default: STACK_ANALYZER_CURRENCY_CACHE env var, else the OS cache dir)")
+	_ = benchmarkCmd.MarkFlagRequired("store")
+	registerCompletions(benchmarkCmd, map[string]completionFunc{"metric": completeValues(trends.Metrics...)})
+}
+
+func runBenchmark(_ *cobra.Command, args []string) error {
+	if (len(args) == 1) == (benchmarkRepo != "") {
+		return fmt.Errorf("give either a scan output file or --repo")
+	}
+	metrics := benchmark.DefaultMetrics
+	if benchmarkMetrics != "" {
+		var err error
+		if metrics, err = trends.ParseMetrics(benchmarkMetrics); err != nil {
+			return err
+		}
+	}
+	resolver, closeCache, err := openCurrencyReader(benchmarkCurrencyCache, metrics)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+
+	var subject *benchmarkSubject
+	if len(args) == 1 {
+		subject, err = readBenchmarkFile(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	subject, portfolio, err := readPortfolio(benchmarkStore, subject, benchmarkRepo)
+	if err != nil {
+		return err
+	}
+	result := &BenchmarkResult{Repo: subject.label, RootID: subject.root.ID, Name: subject.root.Name, Portfolio: len(portfolio)}
+	values := trends.Measure(subject.root, metrics, resolver)
+	measured := make([]map[string]float64, len(portfolio))
+	for i, root := range portfolio {
+		measured[i] = trends.Measure(root, metrics, resolver)
+	}
+	result.Comparisons = benchmark.Compare(values, measured, metrics)
+	OutputToFile(result, benchmarkFormat, benchmarkOutput)
+	return nil
+}
+
+// benchmarkSubject is the repository benchmarked: a scan output file, or a
+// stored scan labelled with its job.
+type benchmarkSubject struct {
+	label string
+	root  *types.Payload
+}
+
+func readBenchmarkFile(path string) (*benchmarkSubject, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scan output: %w", err)
+	}
+	root, err := decodeScan(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	return &benchmarkSubject{label: path, root: root}, nil
+}
+
+func decodeScan(data []byte) (*types.Payload, error) {
+	data, err := migrate.ToCurrent(data)
+	if err != nil {
+		return nil, err
+	}
+	var root types.Payload
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// readPortfolio reads the latest stored scan of every job. The scan of the
+// job or root ID repo becomes the subject when no file was given; scans of
+// the subject's root ID are left out of the portfolio.
+func readPortfolio(path string, subject *benchmarkSubject, repo string) (*benchmarkSubject, []*types.Payload, error) {
+	var portfolio []*types.Payload
+	err := daemon.ReadResults(path, true, func(stored daemon.StoredResult) error {
+		root, err := decodeScan(stored.Data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping the %s result of job %s: %v\n", stored.Started.Format(time.RFC3339), stored.Job, err)
+			return nil
+		}
+		if subject == nil && (repo == stored.Job || repo == root.ID) {
+			subject = &benchmarkSubject{label: stored.Job, root: root}
+		}
+		portfolio = append(portfolio, root)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if subject == nil {
+		return nil, nil, fmt.Errorf("no stored scans of %s in %s", repo, path)
+	}
+	portfolio = slices.DeleteFunc(portfolio, func(root *types.Payload) bool { return root.ID == subject.root.ID })
+	if len(portfolio) == 0 {
+		return nil, nil, fmt.Errorf("no other repositories in %s to compare with", path)
+	}
+	return subject, portfolio, nil
+}
+
+// openCurrencyReader opens the currency cache read-only when the currency
+// metric is selected. Without the metric, or without a cache, the resolver
+// is nil and the metric is left out; the cache is never created.
+func openCurrencyReader(flagPath string, metrics []string) (currency.CurrencyResolver, func(), error) {
+	noop := func() {}
+	if !slices.Contains(metrics, trends.MetricCurrency) {
+		return nil, noop, nil
+	}
+	path, _, err := store.ResolvePath(flagPath)
+	if err != nil {
+		return nil, noop, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if !settings.Quiet {
+			fmt.Fprintf(os.Stderr, "No currency cache at %s; the currency metric is left out (run 'currency' first)\n", path)
+		}
+		return nil, noop, nil
+	}
+	st, err := store.Open(path, 5000)
+	if err != nil {
+		return nil, noop, fmt.Errorf("open currency cache: %w", err)
+	}
+	return currencycache.NewReadOnly(st), func() { _ = st.Close() }, nil
+}
+
+// BenchmarkResult is the output of benchmark.
+type BenchmarkResult struct {
+	Repo        string                  `json:"repo"` // scan output file or daemon job
+	RootID      string                  `json:"root_id"`
+	Name        string                  `json:"name"`
+	Portfolio   int                     `json:"portfolio"` // repositories compared with
+	Comparisons []*benchmark.Comparison `json:"comparisons"`
+}
+
+func (r *BenchmarkResult) ToJSON() interface{} {
+	return r
+}
+
+// ToText writes one line per metric with the value, its percentile and the
+// portfolio quartiles.
+func (r *BenchmarkResult) ToText(w io.Writer) {
+	fmt.Fprintf(w, "%s (%s) against %d repositories\n\n", r.Name, r.Repo, r.Portfolio)
+	fmt.Fprintf(w, "  %-14s %10s %11s %10s %10s %10s\n", "metric", "value", "percentile", "p25", "median", "p75")
+	for _, c := range r.Comparisons {
+		fmt.Fprintf(w, "  %-14s %10s %10.0f%% %10s %10s %10s\n", c.Metric, formatMetric(c.Value), c.Percentile,
+			formatMetric(c.P25), formatMetric(c.Median), formatMetric(c.P75))
+	}
+}
+
+// formatMetric prints counts without decimals and ratios with two.
+func formatMetric(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
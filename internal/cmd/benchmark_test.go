@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/benchmark"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchmarkResultText(t *testing.T) {
+	result := &BenchmarkResult{Repo: "myapp", RootID: "a1b2c3d4e5f6", Name: "myapp", Portfolio: 4, Comparisons: []*benchmark.Comparison{
+		{Metric: "deps", Value: 42, Percentile: 87.5, P25: 12, Median: 20.5, P75: 31},
+	}}
+
+	var text bytes.Buffer
+	result.ToText(&text)
+	assert.Contains(t, text.String(), "myapp (myapp) against 4 repositories\n")
+	assert.Contains(t, text.String(), "  deps                   42         88%         12      20.50         31\n")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	currencycache "github.com/petrarca/tech-stack-analyzer/internal/currency/cache"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsecache"
 	"github.com/petrarca/tech-stack-analyzer/internal/store"
 )
 
@@ -15,7 +16,12 @@ var cacheClearExpiredOnly bool
 var cacheClearCmd = &cobra.Command{
 	Use:   "clear",
 	Short: "Remove cached entries (all, or --expired-only)",
-	Args:  cobra.NoArgs,
+	Long: `Remove cached entries from the shared cache, or only those past their TTL.
+
+Examples:
+  stack-analyzer cache clear
+  stack-analyzer cache clear --expired-only`,
+	Args: cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		return runCacheClear()
 	},
@@ -23,7 +29,7 @@ var cacheClearCmd = &cobra.Command{
 
 func init() {
 	cacheClearCmd.Flags().BoolVar(&cacheClearExpiredOnly, "expired-only", false,
-		"Remove only entries past their TTL (keep fresh entries)")
+		"Remove only entries past their TTL and parse cache entries of other analyzer builds (keep fresh entries)")
 }
 
 func runCacheClear() error {
@@ -47,7 +53,7 @@ func runCacheClear() error {
 	}
 	defer func() { _ = st.Close() }()
 
-	var n int64
+	var n, parsed int64
 	if cacheClearExpiredOnly {
 		n, err = currencycache.ClearExpired(st)
 	} else {
@@ -56,10 +62,20 @@ func runCacheClear() error {
 	if err != nil {
 		return err
 	}
+	// Parse cache entries never expire; only those of other analyzer builds
+	// are stale.
+	if cacheClearExpiredOnly {
+		parsed, err = parsecache.ClearStale(st)
+	} else {
+		parsed, err = parsecache.ClearAll(st)
+	}
+	if err != nil {
+		return err
+	}
 	scope := "all"
 	if cacheClearExpiredOnly {
 		scope = "expired"
 	}
-	fmt.Fprintf(os.Stdout, "Removed %d %s currency entries.\n", n, scope)
+	fmt.Fprintf(os.Stdout, "Removed %d %s currency entries and %d parse cache entries.\n", n, scope, parsed)
 	return nil
 }
@@ -13,7 +13,12 @@ import (
 var cacheInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show the cache location, size, and record counts",
-	Args:  cobra.NoArgs,
+	Long: `Show where the shared cache is, how large it is and how many records it holds.
+
+Examples:
+  stack-analyzer cache info
+  stack-analyzer cache info --currency-cache ./cache.db`,
+	Args: cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		return runCacheInfo()
 	},
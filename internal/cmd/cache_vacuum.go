@@ -11,7 +11,11 @@ import (
 var cacheVacuumCmd = &cobra.Command{
 	Use:   "vacuum",
 	Short: "Reclaim space after deletions (SQLite VACUUM)",
-	Args:  cobra.NoArgs,
+	Long: `Rebuild the cache file to return the space of deleted entries to the disk.
+
+Examples:
+  stack-analyzer cache clear --expired-only && stack-analyzer cache vacuum`,
+	Args: cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		return runCacheVacuum()
 	},
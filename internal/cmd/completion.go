@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+)
+
+// completionCmd writes the completion script of a shell. The scripts call
+// back into the binary, so flag values such as --aggregate fields, rule tech
+// names and output formats complete from the running version.
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script of a shell. Besides commands and flags it
+completes flag values: --aggregate and --also-aggregate fields, rule tech names
+for --rules and --fail-on tech:, detector names, and the output formats of
+-o path:format.
+
+Examples:
+  # bash (needs bash-completion)
+  source <(stack-analyzer completion bash)
+  stack-analyzer completion bash > /etc/bash_completion.d/stack-analyzer
+
+  # zsh
+  stack-analyzer completion zsh > "${fpath[1]}/_stack-analyzer"
+
+  # fish
+  stack-analyzer completion fish > ~/.config/fish/completions/stack-analyzer.fish
+
+  # PowerShell
+  stack-analyzer completion powershell | Out-String | Invoke-Expression`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell %q: use bash, zsh, fish or powershell", args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// completionFunc completes the value of a flag or argument; see
+// cobra.Command.RegisterFlagCompletionFunc.
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// aggregateFields are the fields --aggregate and --also-aggregate accept.
+var aggregateFields = []string{"tech", "techs", "reason", "languages", "licenses", "dependencies", "git", "components", "all"}
+
+// logLevels are the levels --log-level accepts.
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "fatal"}
+
+// completeValues completes a flag taking one of a fixed set of values.
+func completeValues(values ...string) completionFunc {
+	return func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeList completes a comma-separated list flag: each candidate is the
+// list typed so far followed by a value not in it yet, so the shell keeps the
+// earlier values when one is chosen.
+func completeList(values func() []string) completionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		typed, last := "", toComplete
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			typed, last = toComplete[:i+1], toComplete[i+1:]
+		}
+		listed := make(map[string]bool)
+		for _, value := range strings.Split(typed, ",") {
+			listed[value] = true
+		}
+		var candidates []string
+		for _, value := range values() {
+			if !listed[value] && strings.HasPrefix(value, last) {
+				candidates = append(candidates, typed+value)
+			}
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completeOutputTarget completes a path[:format] output target: the file
+// path first, then the format after the last colon.
+func completeOutputTarget(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	i := strings.LastIndex(toComplete, ":")
+	if i < 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	var candidates []string
+	for name := range outputFormatNames {
+		if strings.HasPrefix(name, toComplete[i+1:]) {
+			candidates = append(candidates, toComplete[:i+1]+name)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFailOn completes a --fail-on condition: its kind first, then the
+// techs, license categories or audit severities of that kind.
+func completeFailOn(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kind, _, found := strings.Cut(toComplete, ":")
+	if !found {
+		return []string{"tech:", "license:", "audit:"}, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	var values []string
+	switch kind {
+	case "tech":
+		values = ruleTechNames()
+	case "license":
+		values = licenseCategoryNames()
+	case "audit":
+		values = []string{scanner.AuditSeverityError, scanner.AuditSeverityWarning, scanner.AuditSeverityInfo}
+	}
+	var candidates []string
+	for _, value := range values {
+		if condition := kind + ":" + value; strings.HasPrefix(condition, toComplete) {
+			candidates = append(candidates, condition)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRuleTech completes the tech name argument of a command.
+func completeRuleTech(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return ruleTechNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ruleTechNames returns the tech names of the embedded rules, sorted.
+func ruleTechNames() []string {
+	loaded, err := rules.LoadEmbeddedRules()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(loaded))
+	for _, rule := range loaded {
+		names = append(names, rule.Tech)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func aggregateFieldNames() []string { return aggregateFields }
+
+func omittableFieldNames() []string {
+	names := make([]string, 0, len(omittableFields))
+	for name := range omittableFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func licenseCategoryNames() []string {
+	return []string{
+		string(license.CategoryForbidden), string(license.CategoryRestricted), string(license.CategoryReciprocal),
+		string(license.CategoryNotice), string(license.CategoryPermissive), string(license.CategoryUnencumbered),
+		string(license.CategoryUnknown),
+	}
+}
+
+func migrateVersionNames() []string {
+	names := make([]string, len(migrate.Versions))
+	for i, v := range migrate.Versions {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// registerCompletions registers the value completions of the flags a command
+// has, by flag name. Flags the command does not have are skipped, so the
+// shared entries serve every command.
+func registerCompletions(cmd *cobra.Command, funcs map[string]completionFunc) {
+	for name, fn := range funcs {
+		if cmd.Flags().Lookup(name) != nil {
+			_ = cmd.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+}
+
+// logFlagCompletions completes the logging flags the scanning commands share.
+var logFlagCompletions = map[string]completionFunc{
+	"log-level":  completeValues(logLevels...),
+	"log-format": completeValues("text", "json"),
+}
+
+// registerScanCompletions registers the completions of the scan flags.
+func registerScanCompletions(cmd *cobra.Command) {
+	registerCompletions(cmd, logFlagCompletions)
+	registerCompletions(cmd, map[string]completionFunc{
+		"output":             completeOutputTarget,
+		"aggregate":          completeList(aggregateFieldNames),
+		"also-aggregate":     completeList(aggregateFieldNames),
+		"rules":              completeList(ruleTechNames),
+		"detectors":          completeList(components.DetectorNames),
+		"disable-detectors":  completeList(components.DetectorNames),
+		"dependency-graph":   completeValues("off", "direct", "full"),
+		"maven-graph-source": completeValues("repo", "deps-dev", "none"),
+		"omit-fields":        completeList(omittableFieldNames),
+		"sbom-format":        completeValues("cyclonedx", "spdx"),
+		"resolve-implied":    completeValues("keep", "collapse", "add"),
+		"reasons":            completeValues("full", "summary", "none"),
+		"component-naming":   completeList(func() []string { return []string{"manifest", "directory", "repo-path"} }),
+		"fail-on":            completeFailOn,
+	})
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteList(t *testing.T) {
+	complete := completeList(aggregateFieldNames)
+
+	got, directive := complete(scanCmd, nil, "tech,l")
+	assert.Equal(t, []string{"tech,languages", "tech,licenses"}, got)
+	assert.NotZero(t, directive&cobra.ShellCompDirectiveNoSpace, "a list is continued after a value")
+
+	got, _ = complete(scanCmd, nil, "tech,")
+	assert.NotContains(t, got, "tech,tech", "values already listed are not offered again")
+	assert.Contains(t, got, "tech,techs")
+}
+
+func TestCompleteFailOn(t *testing.T) {
+	got, _ := completeFailOn(scanCmd, nil, "")
+	assert.Equal(t, []string{"tech:", "license:", "audit:"}, got)
+
+	got, _ = completeFailOn(scanCmd, nil, "license:re")
+	assert.Equal(t, []string{"license:restricted", "license:reciprocal"}, got)
+
+	got, _ = completeFailOn(scanCmd, nil, "tech:postgres")
+	assert.Contains(t, got, "tech:postgresql")
+}
+
+func TestCompleteOutputTarget(t *testing.T) {
+	got, directive := completeOutputTarget(scanCmd, nil, "out")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive, "paths complete as files")
+
+	got, _ = completeOutputTarget(scanCmd, nil, "sbom.json:cy")
+	assert.Equal(t, []string{"sbom.json:cyclonedx"}, got)
+}
+
+// TestScanFlagCompletion runs the completion request a shell script sends.
+func TestScanFlagCompletion(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{cobra.ShellCompRequestCmd, "scan", "--rules", "nodej"})
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "nodejs\n")
+}
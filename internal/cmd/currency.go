@@ -43,7 +43,11 @@ refreshes the artifact, re-fetching only stale entries (or all of them with
 
 Input is the AGGREGATE file only (deduped, with reliable direct/transitive
 flags). Ecosystems deps.dev does not cover are recorded as unsupported; packages
-deps.dev does not know (e.g. internal/private) are recorded as unknown.`,
+deps.dev does not know (e.g. internal/private) are recorded as unknown.
+
+Examples:
+  stack-analyzer currency stack-analysis-agg.json
+  stack-analyzer currency stack-analysis-agg.json --force -o currency.json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		return runCurrency(args[0])
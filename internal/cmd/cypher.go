@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/cypher"
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
+)
+
+var cypherOutput string
+
+// cypherCmd exports a previously written scan output as Cypher statements,
+// like sbomCmd a pure transformation of the output file.
+var cypherCmd = &cobra.Command{
+	Use:   "cypher <scan-output.json>",
+	Short: "Export a scan output as Cypher statements for a graph database",
+	Long: `Export a scan output JSON as Cypher statements that build a property graph
+in Neo4j (or another openCypher database): components, technologies, licenses
+and packages as nodes, and the component tree, tech usage, dependencies,
+package-to-package edges and inter-component references as relationships.
+
+The statements MERGE on node keys, so importing a newer scan of the same
+project updates the graph. The input must be a full scan output (not
+--aggregate).
+
+Examples:
+  stack-analyzer cypher result.json -o graph.cypher
+  cypher-shell -u neo4j -f graph.cypher`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runCypher(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cypherCmd)
+	cypherCmd.Flags().StringVarP(&cypherOutput, "output", "o", "", "Output file path (default: stdout)")
+}
+
+func runCypher(inputPath string) error {
+	result, err := loadFullScanOutput(inputPath)
+	if err != nil {
+		return err
+	}
+	allRules, err := rules.LoadEmbeddedRules()
+	if err != nil {
+		return fmt.Errorf("load rules: %w", err)
+	}
+	categories := make(map[string]string, len(allRules))
+	for _, rule := range allRules {
+		categories[rule.Tech] = rule.Type
+	}
+
+	var buf bytes.Buffer
+	if err := cypher.Write(&buf, result, cypher.Options{TechCategories: categories}); err != nil {
+		return err
+	}
+	if cypherOutput == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(cypherOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write Cypher statements: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Cypher statements written to %s\n", cypherOutput)
+	return nil
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+)
+
+var (
+	daemonConfigPath string
+	daemonListen     string
+	daemonOnce       bool
+	daemonRulesPoll  time.Duration
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run scans on cron schedules and store the results",
+	Long: `Daemon runs the scan jobs of a configuration file on their cron schedules,
+stores each result in a directory, a SQLite database and/or posts it to an HTTP
+endpoint, and reports the state of every job on a status endpoint.
+
+Jobs run one at a time. On SIGINT/SIGTERM the daemon finishes the running
+scan and exits; a second signal exits immediately.
+
+Custom rules directories (STACK_ANALYZER_RULES_DIR, or rules_dir in a job's
+scan configuration) are watched for changes: edited rules apply from the next
+scan on, without a restart. Rules that fail to load are logged and the
+previous ones kept.
+
+Example configuration:
+
+  listen: "127.0.0.1:8080"      # GET /status, GET /healthz
+  results:
+    dir: /var/lib/stack-analyzer/results
+    sqlite: /var/lib/stack-analyzer/results.db
+    http:
+      url: https://collector.example.com/scans
+      headers:
+        Authorization: "Bearer ${COLLECTOR_TOKEN}"
+  jobs:
+    - name: myapp
+      schedule: "0 2 * * *"      # cron, @daily, or "@every 6h"
+      paths: [/repos/myapp]
+      run_on_start: true
+
+Examples:
+  stack-analyzer daemon --config daemon.yml
+  stack-analyzer daemon --config daemon.yml --listen :9090
+  stack-analyzer daemon --config daemon.yml --once`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.Flags().StringVar(&daemonConfigPath, "config", "", "Daemon configuration file (jobs, schedules, results)")
+	daemonCmd.Flags().StringVar(&daemonListen, "listen", "", "Address of the status endpoint, overriding the configuration (e.g. :8080)")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "Run every job once, store the results and exit (non-zero when a job fails)")
+	daemonCmd.Flags().DurationVar(&daemonRulesPoll, "rules-poll", 5*time.Second, "How often to check the custom rules directories for changes (0 disables reloading)")
+	daemonCmd.Flags().String("log-level", "info", "Log level: debug, info, warn, error")
+	daemonCmd.Flags().String("log-format", "text", "Log format: text or json")
+	daemonCmd.Flags().String("log-file", "", "Log file path (default: stderr)")
+	_ = daemonCmd.MarkFlagRequired("config")
+	registerCompletions(daemonCmd, logFlagCompletions)
+}
+
+func runDaemon(cmd *cobra.Command, _ []string) error {
+	cfg, err := daemon.LoadConfig(daemonConfigPath)
+	if err != nil {
+		return err
+	}
+	if daemonListen != "" {
+		cfg.Listen = daemonListen
+	}
+	logger := daemonLogger(cmd)
+
+	sinks, closeSinks, err := daemon.NewSinks(cfg.Results)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeSinks() }()
+
+	d := daemon.New(cfg, func(_ context.Context, job daemon.Job) ([]byte, error) {
+		return scanJob(job, logger)
+	}, sinks, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Restore the default signal handling once cancelled, so a second
+	// signal stops a running scan.
+	context.AfterFunc(ctx, stop)
+
+	if daemonOnce {
+		if failed := d.RunAll(ctx); failed > 0 {
+			return fmt.Errorf("%d of %d jobs failed", failed, len(cfg.Jobs))
+		}
+		return nil
+	}
+
+	if daemonRulesPoll > 0 {
+		go scanner.WatchRules(ctx, daemonRulesPoll, logger)
+	}
+	if cfg.Listen != "" {
+		srv := &http.Server{Addr: cfg.Listen, Handler: d.Handler(time.Now()), ReadHeaderTimeout: 10 * time.Second}
+		go serveStatus(srv, logger)
+		defer shutdownStatus(srv)
+	}
+	logger.Info("Daemon started", "jobs", len(cfg.Jobs), "listen", cfg.Listen)
+	return d.Run(ctx)
+}
+
+// daemonLogger configures logging from the daemon's log flags. Unlike the
+// scan command, the daemon logs at info level by default, so every run is
+// recorded.
+func daemonLogger(cmd *cobra.Command) *slog.Logger {
+	s := config.DefaultSettings()
+	logLevel, _ := cmd.Flags().GetString("log-level")
+	if level, err := parseLogLevel(logLevel); err == nil {
+		s.LogLevel = level
+	}
+	s.LogFormat, _ = cmd.Flags().GetString("log-format")
+	s.LogFile, _ = cmd.Flags().GetString("log-file")
+	return s.ConfigureLogger()
+}
+
+func serveStatus(srv *http.Server, logger *slog.Logger) {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("Status endpoint failed", "listen", srv.Addr, "error", err)
+	}
+}
+
+func shutdownStatus(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/aggregator"
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+	gitpkg "github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// scanJob scans the paths of a daemon job as one project, as the scan
+// command would with the job's options, and returns the output JSON.
+// Failures are returned rather than exiting, so one bad job does not stop
+// the daemon. Jobs must not run concurrently: the components layer holds
+// the resolution settings globally.
+func scanJob(job daemon.Job, logger *slog.Logger) ([]byte, error) {
+	s, scanCfg, err := jobSettings(job)
+	if err != nil {
+		return nil, err
+	}
+	basePath, relPaths, err := resolveJobPaths(job.Paths)
+	if err != nil {
+		return nil, err
+	}
+	configureComponents(s, logger)
+	payload, err := scanDirectory(basePath, relPaths, s, scanCfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return generateOutput(payload, s.Aggregate, s.PrettyPrint, s.OmitFields)
+}
+
+// scanDirectory scans basePath, or only relPaths under it when given, with
+// the project configuration merged with scanCfg, and finalizes the payload
+// as the scan command does. It leaves the components layer alone, so
+// several directories can be scanned concurrently once it is configured.
+func scanDirectory(basePath string, relPaths []string, s *config.Settings, scanCfg *config.ScanConfigFile, logger *slog.Logger) (*types.Payload, error) {
+	projectConfig, err := config.LoadConfig(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("load project configuration: %w", err)
+	}
+	mergedConfig := projectConfig
+	if scanCfg != nil {
+		mergedConfig = scanCfg.GetMergedConfig(projectConfig)
+	}
+	excludes := mergedConfig.MergeExcludes(s.ExcludePatterns)
+	rootID := jobRootID(s.RootID, mergedConfig.RootID, basePath, relPaths)
+
+	codeStatsAnalyzer := buildCodeStatsAnalyzer(s)
+	sc, err := scanner.NewScannerWithOptionsAndLogger(basePath, excludes, true, false, false, false, false, codeStatsAnalyzer, logger, rootID, mergedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create scanner: %w", err)
+	}
+	if len(relPaths) > 0 {
+		sc.SetIncludePaths(relPaths)
+	}
+	if err := applyScannerSettings(sc, s); err != nil {
+		return nil, err
+	}
+	payload, err := sc.Scan()
+	if err != nil {
+		return nil, err
+	}
+
+	finalizeCodeStats(payload, codeStatsAnalyzer, s.ComponentStatsDepth, sc.ResolveSubsystemKeyFromPath, s.SubsystemGroups)
+	enhanceSinglePayload(payload, mergedConfig)
+	payload.PrimaryTechs = computePrimaryTechsFromPayload(payload)
+	payload.Ecosystems = aggregator.ComputeEcosystemsFromPayload(payload)
+	return payload, nil
+}
+
+// jobSettings builds the settings of one job run: the environment, then
+// the job's options, then its scan configuration for options left unset.
+func jobSettings(job daemon.Job) (*config.Settings, *config.ScanConfigFile, error) {
+	s := config.LoadSettingsFromEnvironment()
+	s.ExcludePatterns = append(s.ExcludePatterns, job.Exclude...)
+	if job.Aggregate != "" {
+		s.Aggregate = job.Aggregate
+	}
+	if job.RootID != "" {
+		s.RootID = job.RootID
+	}
+	if job.Config == "" {
+		return s, nil, validateSettings(s)
+	}
+	scanCfg, err := config.LoadScanConfig(job.Config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load scan configuration: %w", err)
+	}
+	scanCfg.MergeWithSettings(s)
+	return s, scanCfg, validateSettings(s)
+}
+
+// jobRootID returns the root ID of a job's scan: the job's, else the
+// project configuration's; several paths get an ID derived from them, and a
+// single path the scanner's default.
+func jobRootID(jobID, configID, basePath string, relPaths []string) string {
+	switch {
+	case jobID != "":
+		return jobID
+	case configID != "":
+		return configID
+	case len(relPaths) > 0:
+		return gitpkg.GenerateRootIDFromMultiPaths(basePath, relPaths)
+	}
+	return ""
+}
+
+// resolveJobPaths returns the directory to scan and, for several paths,
+// the paths relative to their common parent. Unlike the scan command, a
+// job with paths sharing only a system directory fails instead of exiting.
+func resolveJobPaths(paths []string) (basePath string, relPaths []string, err error) {
+	absPaths := make([]string, 0, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return "", nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot access path: %w", err)
+		}
+		if !info.IsDir() {
+			return "", nil, fmt.Errorf("path %s is not a directory", abs)
+		}
+		absPaths = append(absPaths, abs)
+	}
+	if len(absPaths) == 1 {
+		return absPaths[0], nil, nil
+	}
+	basePath = computeCommonParent(absPaths)
+	if isSystemRoot(basePath) {
+		return "", nil, fmt.Errorf("paths share only %q as common parent", basePath)
+	}
+	for _, abs := range absPaths {
+		rel, err := filepath.Rel(basePath, abs)
+		if err != nil {
+			return "", nil, err
+		}
+		relPaths = append(relPaths, rel)
+	}
+	return basePath, relPaths, nil
+}
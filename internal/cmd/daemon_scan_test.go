@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+)
+
+func TestScanJobScansPathsAsOneProject(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		pkg := `{"name":"` + name + `","dependencies":{"express":"^4.18.0"}}`
+		if err := os.WriteFile(filepath.Join(dir, name, "package.json"), []byte(pkg), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	job := daemon.Job{Name: "myapp", Paths: []string{filepath.Join(dir, "api"), filepath.Join(dir, "web")}, RootID: "myapp-root"}
+	data, err := scanJob(job, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("scanJob: %v", err)
+	}
+	var out struct {
+		ID       string `json:"id"`
+		Children []struct {
+			Name string `json:"name"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if out.ID != "myapp-root" {
+		t.Errorf("root id = %q, want myapp-root", out.ID)
+	}
+	if len(out.Children) != 2 {
+		t.Errorf("got %d children, want 2", len(out.Children))
+	}
+}
+
+func TestResolveJobPathsErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "pom.xml")
+	if err := os.WriteFile(file, []byte("<project/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, paths := range [][]string{{filepath.Join(dir, "missing")}, {file}} {
+		if _, _, err := resolveJobPaths(paths); err == nil {
+			t.Errorf("resolveJobPaths(%v): expected error", paths)
+		}
+	}
+}
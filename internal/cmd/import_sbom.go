@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/sbom"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importSBOMInto   string
+	importSBOMOutput string
+	importSBOMPretty bool
+)
+
+// importSBOMCmd ingests third-party SBOMs so externally built artifacts can
+// appear in the same report as the scanned source tree.
+var importSBOMCmd = &cobra.Command{
+	Use:   "import-sbom <file.cdx.json|file.spdx.json>...",
+	Short: "Ingest CycloneDX/SPDX SBOMs into a scan result",
+	Long: `Ingest one or more third-party CycloneDX or SPDX JSON documents and emit them
+in the scan output format.
+
+Each SBOM becomes a component of type "sbom": its packages become dependencies
+(typed from their Package URLs), its dependency relationships become
+dependency edges, and document details are recorded under properties.sbom.
+
+With --into, the imported components are merged as children into an existing
+scan output, so the result can be fed to the sbom, summary, or aggregate
+tooling like any other scan. Without --into, a new root component holds them.
+
+Examples:
+  stack-analyzer import-sbom vendor.cdx.json -o imported.json
+  stack-analyzer import-sbom vendor.cdx.json appliance.spdx.json --into stack-analysis.json -o merged.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runImportSBOM(args)
+	},
+}
+
+func runImportSBOM(files []string) error {
+	var target *types.Payload
+	if importSBOMInto != "" {
+		data, err := os.ReadFile(importSBOMInto)
+		if err != nil {
+			return fmt.Errorf("read scan output: %w", err)
+		}
+		if data, err = migrate.ToCurrent(data); err != nil {
+			return err
+		}
+		target = &types.Payload{}
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+		}
+	}
+
+	imported, err := importSBOMFiles(files)
+	if err != nil {
+		return err
+	}
+
+	out, err := marshalJSON(sbom.MergeImported(target, imported), importSBOMPretty)
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+
+	if importSBOMOutput == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(importSBOMOutput, out, 0644); err != nil {
+		return fmt.Errorf("write result: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d SBOM(s) into %s\n", len(imported), importSBOMOutput)
+	return nil
+}
+
+// importSBOMFiles reads and imports each SBOM file as a component.
+func importSBOMFiles(files []string) ([]*types.Payload, error) {
+	imported := make([]*types.Payload, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read SBOM %s: %w", file, err)
+		}
+		p, err := sbom.Import(data, file)
+		if err != nil {
+			return nil, fmt.Errorf("import SBOM %s: %w", file, err)
+		}
+		imported = append(imported, p)
+	}
+	return imported, nil
+}
+
+func init() {
+	rootCmd.AddCommand(importSBOMCmd)
+	importSBOMCmd.Flags().StringVar(&importSBOMInto, "into", "", "Existing scan output JSON to merge the imported components into (default: a new root component).")
+	importSBOMCmd.Flags().StringVarP(&importSBOMOutput, "output", "o", "", "Output file path (default: stdout).")
+	importSBOMCmd.Flags().BoolVar(&importSBOMPretty, "pretty", true, "Pretty-print the JSON output.")
+}
@@ -16,8 +16,12 @@ var showComponents bool
 var categoriesCmd = &cobra.Command{
 	Use:   "categories",
 	Short: "List all technology categories",
-	Long:  `List all technology categories with their descriptions.`,
-	Run:   runTypes,
+	Long: `List all technology categories with their descriptions.
+
+Examples:
+  stack-analyzer info categories
+  stack-analyzer info categories -f yaml`,
+	Run: runTypes,
 }
 
 func init() {
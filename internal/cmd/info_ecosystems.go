@@ -16,8 +16,12 @@ var ecosystemsOutput string
 var ecosystemsCmd = &cobra.Command{
 	Use:   "ecosystems",
 	Short: "List all technology ecosystem definitions",
-	Long:  `Display all technology ecosystem definitions with their detection signals (component types, techs, languages).`,
-	Run:   runEcosystems,
+	Long: `Display all technology ecosystem definitions with their detection signals (component types, techs, languages).
+
+Examples:
+  stack-analyzer info ecosystems
+  stack-analyzer info ecosystems -f text`,
+	Run: runEcosystems,
 }
 
 func init() {
@@ -36,6 +36,8 @@ func GroupTechsByCategory(allRules []types.Rule) map[string][]types.TechInfo {
 			Category:      rule.Type,
 			Description:   rule.Description,
 			IsPrimaryTech: rule.IsPrimaryTech,
+			Implies:       rule.Implies,
+			Supersedes:    rule.Supersedes,
 		}
 		if len(rule.Aliases) > 0 {
 			techInfo.Aliases = rule.Aliases
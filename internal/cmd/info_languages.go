@@ -17,8 +17,12 @@ var languagesOutput string
 var languagesCmd = &cobra.Command{
 	Use:   "languages",
 	Short: "List all languages known to go-enry",
-	Long:  `List all programming languages, data formats, markup, and prose languages from go-enry (GitHub Linguist).`,
-	Run:   runLanguages,
+	Long: `List all programming languages, data formats, markup, and prose languages from go-enry (GitHub Linguist).
+
+Examples:
+  stack-analyzer info languages
+  stack-analyzer info languages -f text -o languages.txt`,
+	Run: runLanguages,
 }
 
 func init() {
@@ -17,9 +17,14 @@ var ruleOutput string
 var ruleCmd = &cobra.Command{
 	Use:   "rule [tech-name]",
 	Short: "Show rule details for a specific technology",
-	Long:  `Display the complete rule definition for a given technology name.`,
-	Args:  cobra.ExactArgs(1),
-	Run:   runRule,
+	Long: `Display the complete rule definition for a given technology name.
+
+Examples:
+  stack-analyzer info rule postgresql
+  stack-analyzer info rule nextjs -f yaml`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRuleTech,
+	Run:               runRule,
 }
 
 func init() {
@@ -15,8 +15,12 @@ var techTaxonomyOutput string
 var techTaxonomyCmd = &cobra.Command{
 	Use:   "tech-taxonomy",
 	Short: "Show technology taxonomy grouped by categories",
-	Long:  `Display technologies grouped by their categories in a hierarchical format.`,
-	Run:   runTechTaxonomy,
+	Long: `Display technologies grouped by their categories in a hierarchical format.
+
+Examples:
+  stack-analyzer info tech-taxonomy
+  stack-analyzer info tech-taxonomy -f text`,
+	Run: runTechTaxonomy,
 }
 
 func init() {
@@ -15,8 +15,12 @@ var techsOutput string
 var techsCmd = &cobra.Command{
 	Use:   "techs",
 	Short: "List all available technologies",
-	Long:  `List all technology names from the embedded rules.`,
-	Run:   runTechs,
+	Long: `List all technology names from the embedded rules.
+
+Examples:
+  stack-analyzer info techs
+  stack-analyzer info techs -f text | grep -i postgres`,
+	Run: runTechs,
 }
 
 func init() {
@@ -64,6 +68,8 @@ func runTechs(cmd *cobra.Command, args []string) {
 			Tech:          techKey,
 			Category:      rule.Type,
 			IsPrimaryTech: rule.IsPrimaryTech,
+			Implies:       rule.Implies,
+			Supersedes:    rule.Supersedes,
 		}
 		if rule.Description != "" {
 			info.Description = rule.Description
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/validation"
+)
+
+// initConfigFile is the project configuration init writes.
+const initConfigFile = ".stack-analyzer.yml"
+
+var (
+	initYes    bool
+	initDryRun bool
+	initForce  bool
+	initNoScan bool
+	initTeam   string
+	initOwner  string
+)
+
+// initCmd scaffolds the project configuration of a repository from what it
+// finds in it.
+var initCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Propose and write a .stack-analyzer.yml for a repository",
+	Long: `Inspect a repository and propose a .stack-analyzer.yml for it:
+
+  exclude     build output and installed dependency directories (dist,
+              target, node_modules, ...) not excluded by .gitignore
+  properties  team and owner, asked for when run in a terminal
+  suppress    techs a scan finds only in example or fixture directories
+              (examples/, testdata/, fixtures/, ...)
+
+The proposal is printed with the reason for each entry and written after
+confirmation. Without a terminal, --yes writes it and --dry-run only prints it.
+An existing .stack-analyzer.yml is kept unless --force is given.
+
+Examples:
+  stack-analyzer init
+  stack-analyzer init ./myapp --team payments --owner payments@example.com --yes
+  stack-analyzer init --dry-run --no-scan`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		interactive := isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+		return runInit(root, interactive, bufio.NewReader(os.Stdin), os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Write the proposal without asking for confirmation or properties")
+	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "Print the proposal without writing it")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing .stack-analyzer.yml")
+	initCmd.Flags().BoolVar(&initNoScan, "no-scan", false, "Do not scan the repository; propose excludes and properties only")
+	initCmd.Flags().StringVar(&initTeam, "team", "", "Team property of the configuration")
+	initCmd.Flags().StringVar(&initOwner, "owner", "", "Owner property of the configuration")
+}
+
+func runInit(root string, interactive bool, in *bufio.Reader, out io.Writer) error {
+	absRoot, target, err := initTarget(root)
+	if err != nil {
+		return err
+	}
+	proposal, err := proposeInit(absRoot, !initNoScan)
+	if err != nil {
+		return err
+	}
+	ask := interactive && !initYes && !initDryRun
+	proposal.Config.Properties = initProperties(ask, in, out)
+
+	data, err := marshalInitConfig(&proposal.Config)
+	if err != nil {
+		return err
+	}
+	printProposal(out, proposal, data)
+	if initDryRun {
+		return nil
+	}
+	return writeInitConfig(target, data, interactive, in, out)
+}
+
+// writeInitConfig writes the proposal, after confirmation unless --yes.
+func writeInitConfig(target string, data []byte, interactive bool, in *bufio.Reader, out io.Writer) error {
+	if !initYes {
+		if !interactive {
+			return fmt.Errorf("not running in a terminal: use --yes to write %s or --dry-run to print it", initConfigFile)
+		}
+		if !confirm(in, out, fmt.Sprintf("Write %s?", target)) {
+			fmt.Fprintln(out, "Nothing written.")
+			return nil
+		}
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", target, err)
+	}
+	fmt.Fprintf(out, "Wrote %s\n", target)
+	return nil
+}
+
+// initTarget resolves the repository directory and the configuration file
+// to write in it, which must not exist yet unless --force or --dry-run.
+func initTarget(root string) (absRoot, target string, err error) {
+	absRoot, err = filepath.Abs(root)
+	if err != nil {
+		return "", "", err
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return "", "", fmt.Errorf("not a directory: %s", root)
+	}
+	target = filepath.Join(absRoot, initConfigFile)
+	if _, err := os.Stat(target); err == nil && !initForce && !initDryRun {
+		return "", "", fmt.Errorf("%s already exists; use --force to replace it", target)
+	}
+	return absRoot, target, nil
+}
+
+// proposeInit inspects a repository for the entries of its configuration,
+// scanning it for the suppressions when scan is set.
+func proposeInit(root string, scan bool) (*initProposal, error) {
+	proposal := &initProposal{}
+	excludes, notes := suggestExcludes(provider.NewFSProvider(root), root)
+	proposal.Config.Exclude = excludes
+	proposal.Notes = append(proposal.Notes, notes...)
+	if !scan {
+		return proposal, nil
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := scanner.NewScannerWithOptionsAndLogger(root, excludes, true, false, false, false, false, nil, logger, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create scanner: %w", err)
+	}
+	payload, err := s.Scan()
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", root, err)
+	}
+	suppress, notes := suggestSuppressions(payload)
+	proposal.Config.Suppress = suppress
+	proposal.Notes = append(proposal.Notes, notes...)
+	return proposal, nil
+}
+
+// initProperties returns the team and owner properties, from the flags or,
+// when ask is set, from prompts defaulting to them.
+func initProperties(ask bool, in *bufio.Reader, out io.Writer) map[string]interface{} {
+	team, owner := initTeam, initOwner
+	if ask {
+		team = prompt(in, out, "Team", team)
+		owner = prompt(in, out, "Owner", owner)
+	}
+	properties := make(map[string]interface{})
+	if team != "" {
+		properties["team"] = team
+	}
+	if owner != "" {
+		properties["owner"] = owner
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// marshalInitConfig renders the configuration as YAML and checks it against
+// the schema the scanner validates .stack-analyzer.yml with.
+func marshalInitConfig(cfg *config.ScanConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# Configuration of stack-analyzer for this repository; see docs/configuration.md\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateYAML("stack-analyzer-yml.json", buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("proposed configuration is invalid: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func printProposal(out io.Writer, proposal *initProposal, data []byte) {
+	if len(proposal.Notes) == 0 {
+		fmt.Fprintln(out, "No excludes or suppressions to propose.")
+	} else {
+		fmt.Fprintln(out, "Proposed:")
+		for _, note := range proposal.Notes {
+			fmt.Fprintf(out, "  - %s\n", note)
+		}
+	}
+	fmt.Fprintf(out, "\n%s\n", data)
+}
+
+// prompt asks for a value, returning def for an empty answer.
+func prompt(in *bufio.Reader, out io.Writer, label, def string) string {
+	if def != "" {
+		fmt.Fprintf(out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(out, "%s (empty to skip): ", label)
+	}
+	answer, _ := in.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// confirm asks a yes/no question, defaulting to no.
+func confirm(in *bufio.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// initMaxDepth is how deep init looks for build output directories.
+const initMaxDepth = 3
+
+// initBuildDirs are directory names holding build output, dependencies
+// installed by a package manager or caches rather than source.
+var initBuildDirs = map[string]bool{
+	"dist": true, "build": true, "out": true, "target": true, "bin": true, "obj": true,
+	"coverage": true, "node_modules": true, "bower_components": true,
+	"__pycache__": true, "venv": true, "site-packages": true,
+}
+
+// initSampleDirs are directory names holding examples and test fixtures,
+// whose techs are usually not techs of the project itself.
+var initSampleDirs = map[string]bool{
+	"example": true, "examples": true, "sample": true, "samples": true,
+	"demo": true, "demos": true, "fixtures": true, "testdata": true,
+}
+
+// initProposal is the .stack-analyzer.yml init proposes and, per entry, why
+// it was proposed.
+type initProposal struct {
+	Config config.ScanConfig
+	Notes  []string
+}
+
+// suggestExcludes proposes an exclude pattern for every build output
+// directory found that the root .gitignore does not exclude already. Hidden
+// directories are skipped, as the scanner skips them by default.
+func suggestExcludes(p types.Provider, root string) (excludes, notes []string) {
+	finder := buildDirFinder{provider: p, ignored: git.NewGitignoreStack(), found: make(map[string]string)}
+	if content, err := p.ReadFile(filepath.Join(root, ".gitignore")); err == nil {
+		finder.ignored.Push(root, strings.Split(string(content), "\n"))
+	}
+	finder.walk(root, "", 1)
+
+	for name, at := range finder.found {
+		excludes = append(excludes, name)
+		notes = append(notes, fmt.Sprintf("exclude %s: build output or installed dependencies, found at %s and not in .gitignore", name, at))
+	}
+	sort.Strings(excludes)
+	sort.Strings(notes)
+	return excludes, notes
+}
+
+// buildDirFinder walks a repository for build output directories.
+type buildDirFinder struct {
+	provider types.Provider
+	ignored  *git.GitignoreStack
+	found    map[string]string // directory name -> first path found at
+}
+
+func (f *buildDirFinder) walk(dir, rel string, depth int) {
+	files, err := f.provider.ListDir(dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		relPath := path.Join(rel, file.Name)
+		if file.Type != "dir" || strings.HasPrefix(file.Name, ".") || f.ignored.ShouldExclude(file.Name, relPath, true) {
+			continue
+		}
+		if !initBuildDirs[file.Name] {
+			if depth < initMaxDepth {
+				f.walk(filepath.Join(dir, file.Name), relPath, depth+1)
+			}
+			continue
+		}
+		if _, seen := f.found[file.Name]; !seen {
+			f.found[file.Name] = relPath
+		}
+	}
+}
+
+// suggestSuppressions proposes a suppress entry for every tech the scan
+// found only in example or fixture directories, one per such directory.
+func suggestSuppressions(root *types.Payload) (suppress []config.SuppressRule, notes []string) {
+	dirs := make(map[string][]string) // tech -> component directories
+	walkComponents(root, func(p *types.Payload) {
+		for _, tech := range slices.Concat(p.Tech, p.Techs) {
+			dirs[tech] = append(dirs[tech], strings.Trim(p.SourceDir, "/"))
+		}
+	})
+	for tech, techDirs := range dirs {
+		prefixes := make(map[string]bool)
+		for _, dir := range techDirs {
+			prefix := samplePrefix(dir)
+			if prefix == "" {
+				prefixes = nil
+				break
+			}
+			prefixes[prefix] = true
+		}
+		for prefix := range prefixes {
+			suppress = append(suppress, config.SuppressRule{Tech: tech, Path: prefix, Reason: "only detected in example or fixture code"})
+			notes = append(notes, fmt.Sprintf("suppress %s under %s: only detected there", tech, prefix))
+		}
+	}
+	sort.Slice(suppress, func(i, j int) bool {
+		if suppress[i].Tech != suppress[j].Tech {
+			return suppress[i].Tech < suppress[j].Tech
+		}
+		return suppress[i].Path < suppress[j].Path
+	})
+	sort.Strings(notes)
+	return suppress, notes
+}
+
+// samplePrefix returns the leading part of a directory up to its first
+// example or fixture directory, or "" when it has none.
+func samplePrefix(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	segments := strings.Split(dir, "/")
+	for i, segment := range segments {
+		if initSampleDirs[segment] {
+			return strings.Join(segments[:i+1], "/")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestExcludes(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"dist", "web/build", "target", ".cache/build", "src/main"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("target/\n"), 0o644))
+
+	excludes, notes := suggestExcludes(provider.NewFSProvider(root), root)
+	assert.Equal(t, []string{"build", "dist"}, excludes)
+	require.Len(t, notes, 2)
+	assert.Contains(t, notes[0], "web/build")
+}
+
+func TestSuggestSuppressions(t *testing.T) {
+	root := &types.Payload{
+		SourceDir: "/",
+		Techs:     []string{"nodejs"},
+		Children: []*types.Payload{
+			{SourceDir: "/examples/mongo", Techs: []string{"nodejs", "mongodb"}},
+			{SourceDir: "/services/api/testdata/legacy", Techs: []string{"mysql"}},
+			{SourceDir: "/services/api", Techs: []string{"redis"}},
+			{SourceDir: "/demo/cache", Techs: []string{"redis"}},
+		},
+	}
+	suppress, notes := suggestSuppressions(root)
+	assert.Equal(t, []config.SuppressRule{
+		{Tech: "mongodb", Path: "examples", Reason: "only detected in example or fixture code"},
+		{Tech: "mysql", Path: "services/api/testdata", Reason: "only detected in example or fixture code"},
+	}, suppress)
+	assert.Len(t, notes, 2)
+}
+
+func TestRunInit(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dist"), 0o755))
+	initNoScan, initTeam = true, "payments"
+	t.Cleanup(func() { initNoScan, initTeam, initYes, initDryRun = false, "", false, false })
+
+	var out bytes.Buffer
+	err := runInit(root, false, bufio.NewReader(strings.NewReader("")), &out)
+	require.Error(t, err, "writing without a terminal needs --yes")
+	_, err = os.Stat(filepath.Join(root, initConfigFile))
+	assert.True(t, os.IsNotExist(err))
+
+	initDryRun = true
+	out.Reset()
+	require.NoError(t, runInit(root, false, bufio.NewReader(strings.NewReader("")), &out))
+	assert.Contains(t, out.String(), "team: payments")
+	_, err = os.Stat(filepath.Join(root, initConfigFile))
+	assert.True(t, os.IsNotExist(err))
+
+	initDryRun = false
+	out.Reset()
+	require.NoError(t, runInit(root, true, bufio.NewReader(strings.NewReader("platform\nops@example.com\ny\n")), &out))
+	cfg, err := config.LoadConfig(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dist"}, cfg.Exclude)
+	assert.Equal(t, map[string]interface{}{"team": "platform", "owner": "ops@example.com"}, cfg.Properties)
+
+	initYes = true
+	require.Error(t, runInit(root, false, bufio.NewReader(strings.NewReader("")), &out), "an existing configuration needs --force")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+)
+
+var (
+	migrateTo     string
+	migrateOutput string
+	migrateList   bool
+)
+
+// migrateCmd converts a stored scan output to another version of the output
+// schema, like sbomCmd a pure transformation of the output file.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate <scan-output.json>",
+	Short: "Convert a scan output to another output schema version",
+	Long: `Convert a scan output JSON, full or aggregated, to another version of the
+output schema, so scans stored by earlier releases stay usable by tools
+expecting the current structure, or to produce a newer structure. The version
+of the input is read from metadata.specVersion; outputs without one are v0.
+
+Versions (see --list):
+  v0   outputs written before specVersion existed (read only)
+  v1   specVersion 0.1, written by scan: dependencies as arrays
+  v2   specVersion 0.2: dependencies as objects with named fields
+
+Fields a migration does not touch are kept as they are. An input already at
+the target version needs no migration: the --output file is not written and
+stdout gets the input unchanged. The commands reading
+scan outputs (sbom, cypher, ui, query, scan --baseline) migrate their input to
+the current version themselves.
+
+Examples:
+  stack-analyzer migrate old.json -o current.json
+  stack-analyzer migrate result.json --to v2 -o result.v2.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if migrateList {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		if migrateList {
+			printSchemaVersions()
+			return nil
+		}
+		return runMigrate(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVar(&migrateTo, "to", migrate.Current().Name, "Output schema version to convert to (e.g. v2, or a specVersion such as 0.2)")
+	migrateCmd.Flags().StringVarP(&migrateOutput, "output", "o", "", "Output file path (default: stdout)")
+	migrateCmd.Flags().BoolVar(&migrateList, "list", false, "List the output schema versions")
+	registerCompletions(migrateCmd, map[string]completionFunc{"to": completeValues(migrateVersionNames()...)})
+}
+
+func runMigrate(inputPath string) error {
+	to, err := migrate.Lookup(migrateTo)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("read scan output: %w", err)
+	}
+	doc, err := migrate.Decode(data)
+	if err != nil {
+		return err
+	}
+	if from, err := migrate.Detect(doc); err != nil {
+		return err
+	} else if from.Name == to.Name {
+		fmt.Fprintf(os.Stderr, "%s is already at %s; no migration needed\n", inputPath, to.Name)
+		if migrateOutput == "" {
+			_, err = os.Stdout.Write(data)
+		}
+		return err
+	}
+	from, err := migrate.Migrate(doc, to)
+	if err != nil {
+		return err
+	}
+	out, err := marshalJSON(doc, true)
+	if err != nil {
+		return fmt.Errorf("encode scan output: %w", err)
+	}
+	return writeMigrated(out, inputPath, from, to)
+}
+
+// writeMigrated writes a migrated scan output to --output, or stdout.
+func writeMigrated(out []byte, inputPath string, from, to migrate.Version) error {
+	if migrateOutput == "" {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(migrateOutput, out, 0644); err != nil {
+		return fmt.Errorf("write scan output: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Migrated %s from %s to %s: %s\n", inputPath, from.Name, to.Name, migrateOutput)
+	return nil
+}
+
+func printSchemaVersions() {
+	current := migrate.Current()
+	for _, v := range migrate.Versions {
+		specVersion := v.Spec
+		if specVersion == "" {
+			specVersion = "-"
+		}
+		marker := ""
+		if v.Name == current.Name {
+			marker = " (written by scan)"
+		}
+		fmt.Printf("%-4s %-5s %s%s\n", v.Name, specVersion, v.Description, marker)
+	}
+}
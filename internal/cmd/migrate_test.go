@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMigrateSameVersion(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "scan.json")
+	if err := os.WriteFile(input, []byte(`{"name": "app", "metadata": {"specVersion": "0.1"}, "dependencies": [["npm", "express", "4.18.2", "prod", true, {}]]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(output, []byte("previous"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(to, out string) { migrateTo, migrateOutput = to, out }(migrateTo, migrateOutput)
+	migrateOutput = output
+
+	migrateTo = "v1"
+	if err := runMigrate(input); err != nil {
+		t.Fatalf("runMigrate: %v", err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "previous" {
+		t.Errorf("output of a same-version migration = %q, want it untouched", data)
+	}
+
+	migrateTo = "v2"
+	if err := runMigrate(input); err != nil {
+		t.Fatalf("runMigrate: %v", err)
+	}
+	if data, _ := os.ReadFile(output); !strings.Contains(string(data), `"specVersion": "0.2"`) {
+		t.Errorf("output of a v1 to v2 migration = %q, want specVersion 0.2", data)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+var (
+	networkOffline   bool
+	networkRateLimit float64
+	networkProxy     string
+)
+
+// addNetworkFlags registers the network flags shared by every command.
+func addNetworkFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&networkOffline, "offline", false, "Disable all network access; features that need it fail instead (env: STACK_ANALYZER_OFFLINE)")
+	cmd.PersistentFlags().Float64Var(&networkRateLimit, "rate-limit", 0, "Maximum HTTP requests per second across all network features, 0 for unlimited (env: STACK_ANALYZER_RATE_LIMIT)")
+	cmd.PersistentFlags().StringVar(&networkProxy, "proxy", "", "Proxy URL for all HTTP requests (default: HTTPS_PROXY / HTTP_PROXY; env: STACK_ANALYZER_PROXY)")
+}
+
+// configureNetwork applies the environment, then the flags, to the shared
+// network layer.
+func configureNetwork(cmd *cobra.Command) error {
+	cfg, err := httpclient.LoadConfigFromEnvironment()
+	if err != nil {
+		return err
+	}
+	flags := cmd.Flags()
+	if flags.Changed("offline") {
+		cfg.Offline = networkOffline
+	}
+	if flags.Changed("rate-limit") {
+		cfg.RateLimit = networkRateLimit
+	}
+	if flags.Changed("proxy") {
+		cfg.Proxy = networkProxy
+	}
+	return httpclient.Configure(cfg)
+}
+
+// validateSettings validates s and, in offline mode, rejects the options
+// that only work with network access.
+func validateSettings(s *config.Settings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if !httpclient.Offline() {
+		return nil
+	}
+	var online []string
+	for _, opt := range []struct {
+		flag string
+		set  bool
+	}{
+		{"--deps-dev", s.UseDepsDev},
+		{"--maven-central", s.UseMavenCentral},
+		{"--maven-repo-url", s.MavenRepoURL != ""},
+		{"--resolve-currency", s.ResolveCurrency},
+	} {
+		if opt.set {
+			online = append(online, opt.flag)
+		}
+	}
+	if len(online) > 0 {
+		return fmt.Errorf("network access is disabled by --offline but needed by %s", strings.Join(online, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+func TestValidateSettingsOffline(t *testing.T) {
+	require.NoError(t, httpclient.Configure(httpclient.Config{Offline: true}))
+	t.Cleanup(func() { _ = httpclient.Configure(httpclient.Config{}) })
+
+	s := config.DefaultSettings()
+	assert.NoError(t, validateSettings(s))
+
+	s.UseDepsDev = true
+	s.ResolveCurrency = true
+	err := validateSettings(s)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--deps-dev, --resolve-currency")
+}
+
+func TestValidateSettingsOnline(t *testing.T) {
+	s := config.DefaultSettings()
+	s.UseDepsDev = true
+	assert.NoError(t, validateSettings(s))
+}
@@ -72,6 +72,7 @@ func OutputToFile(o Outputter, format string, outputFile string) {
 // setupFormatFlag configures format flag and validation for a command
 func setupFormatFlag(cmd *cobra.Command, formatPtr *string) {
 	cmd.Flags().StringVarP(formatPtr, "format", "f", "json", "Output format: json, yaml, or text")
+	registerCompletions(cmd, map[string]completionFunc{"format": completeValues("json", "yaml", "text")})
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		*formatPtr = util.NormalizeFormat(*formatPtr)
 		return util.ValidateOutputFormat(*formatPtr)
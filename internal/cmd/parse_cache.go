@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsecache"
+	"github.com/petrarca/tech-stack-analyzer/internal/store"
+)
+
+// parseCacheBacking is the persistent parse cache, opened on first use and
+// kept for the process (the daemon reuses it across runs).
+var parseCacheBacking struct {
+	once    sync.Once
+	backing *parsecache.StoreBacking
+}
+
+// configureParseCache gives the components layer a fresh parse cache,
+// backed by the shared cache DB when --parse-cache is set. The store is only
+// opened when asked for, so plain scans never create it.
+func configureParseCache(s *config.Settings, logger *slog.Logger) {
+	var backing parsecache.Backing
+	if s.ParseCache {
+		if b := openParseCacheBacking(s.CurrencyCache, logger); b != nil {
+			backing = b
+		}
+	}
+	components.SetParseCache(parsecache.New(backing))
+}
+
+func openParseCacheBacking(cachePath string, logger *slog.Logger) *parsecache.StoreBacking {
+	parseCacheBacking.once.Do(func() {
+		path, _, err := store.ResolvePath(cachePath)
+		if err != nil {
+			logger.Warn("Parse cache disabled", "error", err)
+			return
+		}
+		st, err := store.Open(path, 5000)
+		if err != nil {
+			logger.Warn("Parse cache disabled", "error", err)
+			return
+		}
+		b, err := parsecache.NewStoreBacking(st)
+		if err != nil {
+			_ = st.Close()
+			logger.Warn("Parse cache disabled", "error", err)
+			return
+		}
+		parseCacheBacking.backing = b
+	})
+	return parseCacheBacking.backing
+}
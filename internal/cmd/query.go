@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/query"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	queryFormat    string
+	queryOutput    string
+	queryWhoUses   string
+	queryWhereTech string
+	queryStore     string
+	queryLatest    bool
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query [scan-output.json] [expression]",
+	Short: "Query a scan output without jq",
+	Long: `Query evaluates a path expression over a scan output JSON, or answers one of
+the canned questions:
+
+  --who-uses <package>   the components depending on a package, with versions
+  --where-tech <tech>    the components using a technology, with the reasons
+
+Expressions chain steps: name or .name selects a field, ..name the field at
+any depth, [N] an array element, [] every element, and [?condition] the
+elements matching a condition. Dependencies can be filtered by their type,
+name, version, scope, direct and metadata fields.
+
+With --store, the canned queries run over every scan result stored in a
+daemon results database instead of one file, reporting the job and scan time
+of each answer -- e.g. to find every repository that ever depended on a
+vulnerable package.
+
+Examples:
+  stack-analyzer query result.json '..dependencies[?name==log4j-core]'
+  stack-analyzer query result.json '..children[?contains(techs, react)].name' -f text
+  stack-analyzer query result.json 'code_stats.total'
+  stack-analyzer query result.json --who-uses log4j-core
+  stack-analyzer query result.json --where-tech postgresql -f text
+  stack-analyzer query --store results.db --who-uses openssl --latest`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: runQuery,
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	setupOutputFlags(queryCmd, &queryFormat, &queryOutput)
+	queryCmd.Flags().StringVar(&queryWhoUses, "who-uses", "", "List the components depending on a package (name, or its last ':'/'/' segment)")
+	queryCmd.Flags().StringVar(&queryWhereTech, "where-tech", "", "List the components using a technology")
+	queryCmd.Flags().StringVar(&queryStore, "store", "", "Run --who-uses or --where-tech over the scan results of a daemon results database (SQLite)")
+	queryCmd.Flags().BoolVar(&queryLatest, "latest", false, "With --store, query only the latest result of each job")
+}
+
+func runQuery(_ *cobra.Command, args []string) error {
+	if queryStore != "" {
+		return runStoredQuery(args)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("give a scan output file, or --store with a results database")
+	}
+	expression := ""
+	if len(args) == 2 {
+		expression = args[1]
+	}
+	if countSet(expression, queryWhoUses, queryWhereTech) != 1 {
+		return fmt.Errorf("give exactly one of an expression, --who-uses or --where-tech")
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("read scan output: %w", err)
+	}
+	if data, err = migrate.ToCurrent(data); err != nil {
+		return err
+	}
+
+	var result Outputter
+	if expression != "" {
+		result, err = evalQuery(data, expression)
+	} else {
+		result, err = cannedQuery(data)
+	}
+	if err != nil {
+		return err
+	}
+	OutputToFile(result, queryFormat, queryOutput)
+	return nil
+}
+
+// runStoredQuery answers --who-uses or --where-tech over the results of a
+// daemon results database. Results that do not parse are skipped with a
+// warning, so one bad row does not hide the others.
+func runStoredQuery(args []string) error {
+	if len(args) > 0 || countSet(queryWhoUses, queryWhereTech) != 1 {
+		return fmt.Errorf("--store takes no file or expression; give one of --who-uses or --where-tech")
+	}
+	result := newCannedResult()
+	err := daemon.ReadResults(queryStore, queryLatest, func(stored daemon.StoredResult) error {
+		var root types.Payload
+		data, err := migrate.ToCurrent(stored.Data)
+		if err == nil {
+			err = json.Unmarshal(data, &root)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping the %s result of job %s: %v\n", stored.Started.Format(time.RFC3339), stored.Job, err)
+			return nil
+		}
+		result.add(&root, stored.Job, stored.Started.Format(time.RFC3339))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	OutputToFile(result, queryFormat, queryOutput)
+	return nil
+}
+
+func countSet(values ...string) int {
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func evalQuery(data []byte, expression string) (Outputter, error) {
+	q, err := query.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := query.Document(data)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryResult{Values: q.Eval(doc)}, nil
+}
+
+func cannedQuery(data []byte) (Outputter, error) {
+	var root types.Payload
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	result := newCannedResult()
+	result.add(&root, "", "")
+	return result, nil
+}
+
+// cannedResult collects the answers of --who-uses or --where-tech over one
+// or more scan outputs; job and scanned identify a stored result.
+type cannedResult interface {
+	Outputter
+	add(root *types.Payload, job, scanned string)
+}
+
+func newCannedResult() cannedResult {
+	if queryWhoUses != "" {
+		return &WhoUsesResult{Package: queryWhoUses, Usages: []ScanUsage{}}
+	}
+	return &WhereTechResult{Tech: queryWhereTech, Components: []ScanTechUsage{}}
+}
+
+// scanLabel prefixes text lines of stored results with their job and scan
+// time.
+func scanLabel(job, scanned string) string {
+	if job == "" {
+		return ""
+	}
+	return job + " " + scanned + ": "
+}
+
+// QueryResult is the output of an expression: the selected values.
+type QueryResult struct {
+	Values []interface{}
+}
+
+func (r *QueryResult) ToJSON() interface{} {
+	return r.Values
+}
+
+// ToText writes one value per line: strings as they are, other values as
+// compact JSON.
+func (r *QueryResult) ToText(w io.Writer) {
+	for _, v := range r.Values {
+		if s, ok := v.(string); ok {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		data, _ := marshalJSON(v, false)
+		fmt.Fprintln(w, string(data))
+	}
+}
+
+// ScanUsage is a --who-uses answer; Job and Scanned are set for stored
+// results.
+type ScanUsage struct {
+	Job     string `json:"job,omitempty"`
+	Scanned string `json:"scanned,omitempty"` // start time of the scan, RFC 3339
+	query.Usage
+}
+
+// WhoUsesResult is the output of --who-uses.
+type WhoUsesResult struct {
+	Package string      `json:"package"`
+	Usages  []ScanUsage `json:"usages"`
+}
+
+func (r *WhoUsesResult) add(root *types.Payload, job, scanned string) {
+	for _, u := range query.WhoUses(root, r.Package) {
+		r.Usages = append(r.Usages, ScanUsage{Job: job, Scanned: scanned, Usage: u})
+	}
+}
+
+func (r *WhoUsesResult) ToJSON() interface{} {
+	return r
+}
+
+func (r *WhoUsesResult) ToText(w io.Writer) {
+	for _, u := range r.Usages {
+		kind := "transitive"
+		if u.Direct {
+			kind = "direct"
+		}
+		fmt.Fprintf(w, "%s%s (%s): %s %s %s, %s", scanLabel(u.Job, u.Scanned), u.Component, u.SourceDir, u.Type, u.Name, u.Version, kind)
+		if u.Scope != "" {
+			fmt.Fprintf(w, ", %s", u.Scope)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "\nTotal: %d usages of %s\n", len(r.Usages), r.Package)
+}
+
+// ScanTechUsage is a --where-tech answer; Job and Scanned are set for stored
+// results.
+type ScanTechUsage struct {
+	Job     string `json:"job,omitempty"`
+	Scanned string `json:"scanned,omitempty"` // start time of the scan, RFC 3339
+	query.TechUsage
+}
+
+// WhereTechResult is the output of --where-tech.
+type WhereTechResult struct {
+	Tech       string          `json:"tech"`
+	Components []ScanTechUsage `json:"components"`
+}
+
+func (r *WhereTechResult) add(root *types.Payload, job, scanned string) {
+	for _, c := range query.WhereTech(root, r.Tech) {
+		r.Components = append(r.Components, ScanTechUsage{Job: job, Scanned: scanned, TechUsage: c})
+	}
+}
+
+func (r *WhereTechResult) ToJSON() interface{} {
+	return r
+}
+
+func (r *WhereTechResult) ToText(w io.Writer) {
+	for _, c := range r.Components {
+		primary := ""
+		if c.Primary {
+			primary = " [primary]"
+		}
+		fmt.Fprintf(w, "%s%s (%s)%s\n", scanLabel(c.Job, c.Scanned), c.Component, c.SourceDir, primary)
+		for _, reason := range c.Reasons {
+			fmt.Fprintf(w, "  %s\n", reason)
+		}
+	}
+	fmt.Fprintf(w, "\nTotal: %d components using %s\n", len(r.Components), r.Tech)
+}
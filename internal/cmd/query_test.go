@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhoUsesResultAcrossStoredScans(t *testing.T) {
+	root := types.NewPayloadWithPath("myapp", "/")
+	root.Dependencies = []types.Dependency{{Type: "conan", Name: "openssl", Version: "3.0.1", Direct: true}}
+
+	result := &WhoUsesResult{Package: "openssl", Usages: []ScanUsage{}}
+	result.add(root, "myapp", "2026-03-09T02:00:00Z")
+	result.add(root, "myapp", "2026-03-10T02:00:00Z")
+	require.Len(t, result.Usages, 2)
+
+	data, err := json.Marshal(result.Usages[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"job":"myapp","scanned":"2026-03-09T02:00:00Z","component":"myapp","component_id":"`+root.ID+`",`+
+		`"source_dir":"/","type":"conan","name":"openssl","version":"3.0.1","direct":true}`, string(data))
+
+	var text bytes.Buffer
+	result.ToText(&text)
+	assert.Contains(t, text.String(), "myapp 2026-03-10T02:00:00Z: myapp (/): conan openssl 3.0.1, direct\n")
+	assert.Contains(t, text.String(), "Total: 2 usages of openssl")
+}
@@ -17,6 +17,9 @@ used in your codebase by analyzing configuration files, dependencies, and code p
 It provides comprehensive analysis with 700+ technology rules covering databases,
 frameworks, tools, cloud services, and more.`,
 	Version: version.Full(),
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		return configureNetwork(cmd)
+	},
 }
 
 // Execute runs the root command
@@ -28,5 +31,5 @@ func Execute() {
 }
 
 func init() {
-	// Global flags can be added here if needed
+	addNetworkFlags(rootCmd)
 }
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd is the parent for the commands maintaining the embedded ruleset.
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Evaluate the embedded detection rules",
+	Long: `Evaluate the embedded detection rules against real code bases, to find the
+rules worth pruning or improving. To look up a single rule, use 'info rule'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesCoverageCmd)
+	rulesCmd.AddCommand(rulesVerifyCmd)
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	coverageFormat   string
+	coverageOutput   string
+	coverageTop      int
+	coverageExcludes []string
+	coverageQuiet    bool
+)
+
+var rulesCoverageCmd = &cobra.Command{
+	Use:   "coverage <paths...>",
+	Short: "Report which rules match across a corpus of code bases",
+	Long: `Scan each path as a code base of its own and report, over all of them,
+which rules never matched, which matched most, and the average strength of
+the evidence behind each rule's matches (the confidence of the techs it
+detected, see --min-confidence of scan).
+
+A rule matches once per component it detects its tech in. Techs detected by
+component detectors without a rule of their own are not reported.
+
+Examples:
+  stack-analyzer rules coverage ~/src/*
+  stack-analyzer rules coverage --top 50 -f json -o coverage.json repo-a repo-b`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runRulesCoverage,
+}
+
+func init() {
+	setupOutputFlags(rulesCoverageCmd, &coverageFormat, &coverageOutput)
+	rulesCoverageCmd.Flags().IntVar(&coverageTop, "top", 20, "Number of most matched rules to list in the text report (0 lists all)")
+	rulesCoverageCmd.Flags().StringSliceVar(&coverageExcludes, "exclude", nil, "Patterns to exclude (supports glob patterns)")
+	rulesCoverageCmd.Flags().BoolVarP(&coverageQuiet, "quiet", "q", false, "Do not list the paths on stderr as they are scanned")
+}
+
+// RuleCoverage is how often one rule matched across the corpus.
+type RuleCoverage struct {
+	Tech          string  `json:"tech"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Components    int     `json:"components"`     // components the rule's tech was detected in
+	Paths         int     `json:"paths"`          // scanned paths with at least one of them
+	AvgConfidence float64 `json:"avg_confidence"` // mean confidence of the tech over its components
+	confidenceSum float64
+	lastPathIndex int
+}
+
+// CoverageResult is the output of the rules coverage command.
+type CoverageResult struct {
+	Paths        []string       `json:"paths"`
+	Rules        int            `json:"rules"`
+	Matched      []RuleCoverage `json:"matched"`       // most matched first
+	NeverMatched []string       `json:"never_matched"` // techs of the rules that never matched
+	top          int
+}
+
+// ruleCoverage collects the matches of the rules over the scanned paths.
+type ruleCoverage struct {
+	rules map[string]*RuleCoverage
+	paths []string
+}
+
+func newRuleCoverage(allRules []types.Rule) *ruleCoverage {
+	c := &ruleCoverage{rules: make(map[string]*RuleCoverage, len(allRules))}
+	for _, rule := range allRules {
+		c.rules[rule.Tech] = &RuleCoverage{Tech: rule.Tech, Name: rule.Name, Category: rule.Type, lastPathIndex: -1}
+	}
+	return c
+}
+
+// add counts the techs of every component of one scanned path.
+func (c *ruleCoverage) add(path string, p *types.Payload) {
+	c.paths = append(c.paths, path)
+	c.addComponent(len(c.paths)-1, p)
+}
+
+func (c *ruleCoverage) addComponent(pathIndex int, p *types.Payload) {
+	seen := make(map[string]bool)
+	for _, tech := range slices.Concat(p.Tech, p.Techs) {
+		rule, ok := c.rules[tech]
+		if !ok || seen[tech] {
+			continue
+		}
+		seen[tech] = true
+		rule.Components++
+		rule.confidenceSum += componentConfidence(p, tech)
+		if rule.lastPathIndex != pathIndex {
+			rule.lastPathIndex = pathIndex
+			rule.Paths++
+		}
+	}
+	for _, child := range p.Children {
+		c.addComponent(pathIndex, child)
+	}
+}
+
+// componentConfidence returns the confidence the scan recorded for a tech of
+// a component; techs without a score count as file evidence, as the scanner
+// scores them.
+func componentConfidence(p *types.Payload, tech string) float64 {
+	if score, ok := p.Confidence[tech]; ok {
+		return score
+	}
+	return scanner.ConfidenceFile
+}
+
+// result ranks the rules: the matched ones by components, then paths, then
+// tech; the others by tech.
+func (c *ruleCoverage) result() *CoverageResult {
+	result := &CoverageResult{Paths: c.paths, Rules: len(c.rules), Matched: []RuleCoverage{}, NeverMatched: []string{}}
+	for _, rule := range c.rules {
+		if rule.Components == 0 {
+			result.NeverMatched = append(result.NeverMatched, rule.Tech)
+			continue
+		}
+		rule.AvgConfidence = math.Round(rule.confidenceSum/float64(rule.Components)*100) / 100
+		result.Matched = append(result.Matched, *rule)
+	}
+	sort.Slice(result.Matched, func(i, j int) bool {
+		a, b := result.Matched[i], result.Matched[j]
+		if a.Components != b.Components {
+			return a.Components > b.Components
+		}
+		if a.Paths != b.Paths {
+			return a.Paths > b.Paths
+		}
+		return a.Tech < b.Tech
+	})
+	sort.Strings(result.NeverMatched)
+	return result
+}
+
+func (r *CoverageResult) ToJSON() interface{} {
+	return r
+}
+
+func (r *CoverageResult) ToText(w io.Writer) {
+	fmt.Fprintf(w, "Rules matched: %d of %d across %d paths\n", len(r.Matched), r.Rules, len(r.Paths))
+
+	matched := r.Matched
+	if r.top > 0 && len(matched) > r.top {
+		matched = matched[:r.top]
+	}
+	if len(matched) > 0 {
+		fmt.Fprintf(w, "\nMost matched:\n")
+		fmt.Fprintf(w, "  %-30s %10s %6s %10s\n", "RULE", "COMPONENTS", "PATHS", "AVG CONF")
+		for _, rule := range matched {
+			fmt.Fprintf(w, "  %-30s %10d %6d %10.2f\n", rule.Tech, rule.Components, rule.Paths, rule.AvgConfidence)
+		}
+		if len(r.Matched) > len(matched) {
+			fmt.Fprintf(w, "  ... and %d more (--top 0 lists all)\n", len(r.Matched)-len(matched))
+		}
+	}
+
+	if len(r.NeverMatched) > 0 {
+		fmt.Fprintf(w, "\nNever matched (%d):\n", len(r.NeverMatched))
+		for _, tech := range r.NeverMatched {
+			fmt.Fprintf(w, "  %s\n", tech)
+		}
+	}
+}
+
+func runRulesCoverage(cmd *cobra.Command, args []string) {
+	logger := settings.ConfigureLogger()
+	allRules, _ := LoadRulesAndCategories()
+	coverage := newRuleCoverage(allRules)
+
+	for i, arg := range args {
+		absPath, err := filepath.Abs(strings.TrimSpace(arg))
+		if err != nil {
+			logger.Error("Invalid path", "path", arg, "error", err)
+			os.Exit(1)
+		}
+		if !coverageQuiet {
+			fmt.Fprintf(os.Stderr, "Scanning %d/%d: %s\n", i+1, len(args), absPath)
+		}
+		payload, err := scanCoveragePath(absPath, logger)
+		if err != nil {
+			logger.Error("Failed to scan path", "path", absPath, "error", err)
+			os.Exit(1)
+		}
+		coverage.add(arg, payload)
+	}
+
+	result := coverage.result()
+	result.top = coverageTop
+	OutputToFile(result, coverageFormat, coverageOutput)
+}
+
+// scanCoveragePath scans one code base of the corpus without progress output
+// or code statistics, which the coverage does not need.
+func scanCoveragePath(absPath string, logger *slog.Logger) (*types.Payload, error) {
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory")
+	}
+	s, err := scanner.NewScannerWithOptionsAndLogger(absPath, coverageExcludes, true, false, false, false, false, nil, logger, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.Scan()
+}
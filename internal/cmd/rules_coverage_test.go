@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestRuleCoverage(t *testing.T) {
+	coverage := newRuleCoverage([]types.Rule{
+		{Tech: "nodejs", Name: "Node.js", Type: "runtime"},
+		{Tech: "postgresql", Name: "PostgreSQL", Type: "db"},
+		{Tech: "redis", Name: "Redis", Type: "db"},
+	})
+
+	first := types.NewPayload("main", []string{"/"})
+	first.Techs = []string{"nodejs"}
+	first.SetTechConfidence("nodejs", 0.95)
+	api := types.NewPayload("api", []string{"/api"})
+	api.Tech = []string{"nodejs"}
+	api.Techs = []string{"nodejs", "postgresql", "custom"}
+	api.SetTechConfidence("nodejs", 0.75)
+	api.SetTechConfidence("postgresql", 0.4)
+	first.Children = []*types.Payload{api}
+	coverage.add("repo-a", first)
+
+	second := types.NewPayload("main", []string{"/"})
+	second.Techs = []string{"nodejs"}
+	coverage.add("repo-b", second)
+
+	result := coverage.result()
+	if result.Rules != 3 || !reflect.DeepEqual(result.Paths, []string{"repo-a", "repo-b"}) {
+		t.Errorf("rules=%d paths=%v", result.Rules, result.Paths)
+	}
+	if !reflect.DeepEqual(result.NeverMatched, []string{"redis"}) {
+		t.Errorf("never matched = %v, want [redis]", result.NeverMatched)
+	}
+	if len(result.Matched) != 2 {
+		t.Fatalf("matched = %+v, want nodejs and postgresql", result.Matched)
+	}
+	// The unscored nodejs of repo-b counts as file evidence (0.85).
+	if got := result.Matched[0]; got.Tech != "nodejs" || got.Components != 3 || got.Paths != 2 || got.AvgConfidence != 0.85 {
+		t.Errorf("nodejs = %+v", got)
+	}
+	if got := result.Matched[1]; got.Tech != "postgresql" || got.Components != 1 || got.Paths != 1 || got.AvgConfidence != 0.4 {
+		t.Errorf("postgresql = %+v", got)
+	}
+
+	result.top = 1
+	var buf bytes.Buffer
+	result.ToText(&buf)
+	out := buf.String()
+	for _, want := range []string{"Rules matched: 2 of 3 across 2 paths", "nodejs", "... and 1 more", "Never matched (1):\n  redis"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text report lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// fixtureExpectationFile names the file marking a directory as a rules
+// fixture and holding what a scan of it must find.
+const fixtureExpectationFile = "expected.json"
+
+var (
+	verifyRulesDir string
+	verifyUpdate   bool
+)
+
+var rulesVerifyCmd = &cobra.Command{
+	Use:   "verify <fixtures-dir>...",
+	Short: "Check the rules against fixture directories with expected results",
+	Long: `Scan every fixture directory and compare what the rules find with the
+fixture's expected.json. A fixture is any directory holding an expected.json;
+the directories below it belong to the fixture.
+
+expected.json lists the techs the scan must detect, over all components, and
+optionally the dependencies as type:name:
+
+  {
+    "techs": ["express", "nodejs"],
+    "dependencies": ["npm:express"]
+  }
+
+Both lists must match exactly: techs or dependencies the scan finds but the
+fixture does not list are reported as unexpected. Without a dependencies list
+the dependencies are not checked.
+
+With --rules-dir, custom rules are loaded on top of the embedded ones, as by
+scan --rules-dir. --update writes the scan results into the expected.json
+files instead of comparing them, to record new fixtures or accept changes.
+
+The command exits with status 1 if any fixture does not match.
+
+Examples:
+  stack-analyzer rules verify fixtures/
+  stack-analyzer rules verify --rules-dir my-rules/ my-rules/fixtures/
+  stack-analyzer rules verify --update fixtures/new-framework`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runRulesVerify,
+}
+
+func init() {
+	rulesVerifyCmd.Flags().StringVar(&verifyRulesDir, "rules-dir", "", "Directory of custom YAML rules loaded on top of the embedded rules")
+	rulesVerifyCmd.Flags().BoolVar(&verifyUpdate, "update", false, "Write the scan results into the expected.json files instead of comparing them")
+}
+
+// fixtureExpectation is the content of a fixture's expected.json.
+type fixtureExpectation struct {
+	Techs        []string `json:"techs"`
+	Dependencies []string `json:"dependencies,omitempty"` // type:name; nil skips the check
+}
+
+// fixtureResult is the outcome of verifying one fixture.
+type fixtureResult struct {
+	Dir                    string
+	Err                    error
+	MissingTechs           []string
+	UnexpectedTechs        []string
+	MissingDependencies    []string
+	UnexpectedDependencies []string
+}
+
+func (r *fixtureResult) passed() bool {
+	return r.Err == nil && len(r.MissingTechs)+len(r.UnexpectedTechs)+len(r.MissingDependencies)+len(r.UnexpectedDependencies) == 0
+}
+
+func runRulesVerify(cmd *cobra.Command, args []string) {
+	logger := settings.ConfigureLogger()
+	scanner.SetRulesDir(verifyRulesDir)
+
+	var fixtures []string
+	for _, arg := range args {
+		found, err := findFixtures(arg)
+		if err != nil {
+			logger.Error("Failed to find fixtures", "path", arg, "error", err)
+			os.Exit(1)
+		}
+		fixtures = append(fixtures, found...)
+	}
+	if len(fixtures) == 0 {
+		logger.Error("No fixtures found: no directory holds an " + fixtureExpectationFile)
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, dir := range fixtures {
+		var result fixtureResult
+		if verifyUpdate {
+			result = updateFixture(dir, logger)
+		} else {
+			result = verifyFixture(dir, logger)
+		}
+		writeFixtureResult(os.Stdout, &result, verifyUpdate)
+		if !result.passed() {
+			failed++
+		}
+	}
+	fmt.Printf("\n%d fixtures, %d failed\n", len(fixtures), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// findFixtures lists the fixture directories at or below root.
+func findFixtures(root string) ([]string, error) {
+	var fixtures []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(path, fixtureExpectationFile)); err == nil {
+			fixtures = append(fixtures, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return fixtures, err
+}
+
+// verifyFixture scans a fixture and compares the result with its
+// expected.json.
+func verifyFixture(dir string, logger *slog.Logger) fixtureResult {
+	result := fixtureResult{Dir: dir}
+	expected, err := readFixtureExpectation(dir)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	payload, err := scanFixture(dir, logger)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.MissingTechs, result.UnexpectedTechs = diffSorted(normalizedList(expected.Techs), payloadTechs(payload))
+	if expected.Dependencies != nil {
+		result.MissingDependencies, result.UnexpectedDependencies = diffSorted(normalizedList(expected.Dependencies), payloadDependencies(payload))
+	}
+	return result
+}
+
+// updateFixture records the scan result of a fixture in its expected.json,
+// keeping the dependencies unchecked if they were.
+func updateFixture(dir string, logger *slog.Logger) fixtureResult {
+	result := fixtureResult{Dir: dir}
+	expected, err := readFixtureExpectation(dir)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	payload, err := scanFixture(dir, logger)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	expected.Techs = payloadTechs(payload)
+	if expected.Techs == nil {
+		expected.Techs = []string{}
+	}
+	if expected.Dependencies != nil {
+		expected.Dependencies = payloadDependencies(payload)
+	}
+	data, err := marshalJSON(expected, true)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, fixtureExpectationFile), append(data, '\n'), 0644)
+	}
+	result.Err = err
+	return result
+}
+
+func readFixtureExpectation(dir string) (*fixtureExpectation, error) {
+	data, err := os.ReadFile(filepath.Join(dir, fixtureExpectationFile))
+	if err != nil {
+		return nil, err
+	}
+	var expected fixtureExpectation
+	if err := json.Unmarshal(data, &expected); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", fixtureExpectationFile, err)
+	}
+	return &expected, nil
+}
+
+// scanFixture scans a fixture directory, leaving out its expected.json.
+func scanFixture(dir string, logger *slog.Logger) (*types.Payload, error) {
+	absPath, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	s, err := scanner.NewScannerWithOptionsAndLogger(absPath, []string{fixtureExpectationFile}, true, false, false, false, false, nil, logger, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.Scan()
+}
+
+// payloadTechs lists the techs of all components, sorted.
+func payloadTechs(p *types.Payload) []string {
+	techs := make(map[string]bool)
+	walkComponents(p, func(c *types.Payload) {
+		for _, tech := range slices.Concat(c.Tech, c.Techs) {
+			techs[tech] = true
+		}
+	})
+	return sortedKeys(techs)
+}
+
+// payloadDependencies lists the dependencies of all components as
+// type:name, sorted.
+func payloadDependencies(p *types.Payload) []string {
+	deps := make(map[string]bool)
+	walkComponents(p, func(c *types.Payload) {
+		for _, dep := range c.Dependencies {
+			deps[dep.Type+":"+dep.Name] = true
+		}
+	})
+	return sortedKeys(deps)
+}
+
+func walkComponents(p *types.Payload, visit func(*types.Payload)) {
+	visit(p)
+	for _, child := range p.Children {
+		walkComponents(child, visit)
+	}
+}
+
+// normalizedList returns the entries of an expected.json list sorted and
+// without duplicates.
+func normalizedList(list []string) []string {
+	set := make(map[string]bool, len(list))
+	for _, entry := range list {
+		set[strings.TrimSpace(entry)] = true
+	}
+	return sortedKeys(set)
+}
+
+// diffSorted returns the entries of expected missing from actual and the
+// entries of actual not in expected; both lists are sorted.
+func diffSorted(expected, actual []string) (missing, unexpected []string) {
+	for _, entry := range expected {
+		if _, found := slices.BinarySearch(actual, entry); !found {
+			missing = append(missing, entry)
+		}
+	}
+	for _, entry := range actual {
+		if _, found := slices.BinarySearch(expected, entry); !found {
+			unexpected = append(unexpected, entry)
+		}
+	}
+	return missing, unexpected
+}
+
+func writeFixtureResult(w io.Writer, r *fixtureResult, updated bool) {
+	switch {
+	case r.Err != nil:
+		fmt.Fprintf(w, "ERROR %s: %v\n", r.Dir, r.Err)
+		return
+	case updated:
+		fmt.Fprintf(w, "UPDATED %s\n", r.Dir)
+		return
+	case r.passed():
+		fmt.Fprintf(w, "PASS %s\n", r.Dir)
+		return
+	}
+	fmt.Fprintf(w, "FAIL %s\n", r.Dir)
+	writeFixtureMismatches(w, "missing tech", r.MissingTechs)
+	writeFixtureMismatches(w, "unexpected tech", r.UnexpectedTechs)
+	writeFixtureMismatches(w, "missing dependency", r.MissingDependencies)
+	writeFixtureMismatches(w, "unexpected dependency", r.UnexpectedDependencies)
+}
+
+func writeFixtureMismatches(w io.Writer, label string, entries []string) {
+	for _, entry := range entries {
+		fmt.Fprintf(w, "  %s: %s\n", label, entry)
+	}
+}
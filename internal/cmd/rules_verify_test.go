@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFixture(t *testing.T, dir, expected string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"name": "api", "dependencies": {"express": "^4.18.0"}}`
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, fixtureExpectationFile), []byte(expected), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyFixture(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "pass"), `{"techs": ["nodejs", "express", "npm"], "dependencies": ["npm:express"]}`)
+	writeFixture(t, filepath.Join(root, "fail"), `{"techs": ["nodejs", "react"]}`)
+	writeFixture(t, filepath.Join(root, "fail", "nested"), `{"techs": []}`)
+
+	fixtures, err := findFixtures(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "fail"), filepath.Join(root, "pass")}
+	if !reflect.DeepEqual(fixtures, want) {
+		t.Fatalf("fixtures = %v, want %v (no nested fixtures)", fixtures, want)
+	}
+
+	logger := slog.New(slog.DiscardHandler)
+	if result := verifyFixture(filepath.Join(root, "pass"), logger); !result.passed() {
+		t.Errorf("pass fixture failed: %+v", result)
+	}
+
+	result := verifyFixture(filepath.Join(root, "fail"), logger)
+	if result.passed() || result.Err != nil {
+		t.Fatalf("fail fixture passed or errored: %+v", result)
+	}
+	if !reflect.DeepEqual(result.MissingTechs, []string{"react"}) {
+		t.Errorf("missing techs = %v, want [react]", result.MissingTechs)
+	}
+	if !reflect.DeepEqual(result.UnexpectedTechs, []string{"express", "npm"}) {
+		t.Errorf("unexpected techs = %v, want [express npm]", result.UnexpectedTechs)
+	}
+	if result.MissingDependencies != nil || result.UnexpectedDependencies != nil {
+		t.Errorf("dependencies checked without a dependencies list: %+v", result)
+	}
+}
+
+func TestUpdateFixture(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "node")
+	writeFixture(t, dir, `{"techs": [], "dependencies": []}`)
+
+	logger := slog.New(slog.DiscardHandler)
+	if result := updateFixture(dir, logger); result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	expected, err := readFixtureExpectation(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected.Techs, []string{"express", "nodejs", "npm"}) || !reflect.DeepEqual(expected.Dependencies, []string{"npm:express"}) {
+		t.Errorf("updated expectation = %+v", expected)
+	}
+	if result := verifyFixture(dir, logger); !result.passed() {
+		t.Errorf("updated fixture does not pass: %+v", result)
+	}
+}
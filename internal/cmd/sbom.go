@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
 	"github.com/petrarca/tech-stack-analyzer/internal/progress"
 	"github.com/petrarca/tech-stack-analyzer/internal/sbom"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
@@ -67,7 +68,12 @@ without re-scanning.
 
 The input must be a full scan output that still contains the "dependencies"
 field (i.e. produced without --omit-fields dependencies and without --aggregate
-stripping them).`,
+stripping them).
+
+Examples:
+  stack-analyzer sbom stack-analysis.json -o sbom.cdx.json
+  stack-analyzer sbom stack-analysis.json --format spdx -o sbom.spdx.json
+  stack-analyzer sbom stack-analysis.json --resolve-transitive --deps-dev`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		return runSBOM(args[0])
@@ -85,6 +91,9 @@ func runSBOM(inputPath string) error {
 	if err != nil {
 		return fmt.Errorf("read scan output: %w", err)
 	}
+	if data, err = migrate.ToCurrent(data); err != nil {
+		return err
+	}
 
 	var payload types.Payload
 	if err := json.Unmarshal(data, &payload); err != nil {
@@ -147,7 +156,7 @@ func resolveTransitiveFromCoordinates(payload *types.Payload) {
 	components.SetDepsDevEndpoint(sbomDepsDevEndpoint)
 	components.SetUseMavenCentral(sbomMavenCentral)
 	components.SetMavenGraphSource(sbomMavenGraphSrc)
-	applyMavenSettings(logger)
+	applyMavenSettings(settings, logger)
 
 	provider := noFileProvider{base: "sbom:" + payload.Name}
 
@@ -224,4 +233,9 @@ func init() {
 	sbomCmd.Flags().BoolVar(&sbomMavenLocalRepo, "maven-local-repo", false, "Read the local ~/.m2/repository cache for transitive POM resolution.")
 	sbomCmd.Flags().StringVar(&sbomMavenLocalDir, "maven-local-repo-dir", "", "Override the local Maven repository path.")
 	sbomCmd.Flags().BoolVarP(&sbomQuiet, "quiet", "q", false, "Suppress progress output.")
+	registerCompletions(sbomCmd, map[string]completionFunc{
+		"format":             completeValues("cyclonedx", "spdx"),
+		"dependency-graph":   completeValues("direct", "full"),
+		"maven-graph-source": completeValues("repo", "deps-dev", "none"),
+	})
 }
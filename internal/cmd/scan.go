@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,18 +11,21 @@ import (
 	"log/slog"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/aggregator"
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
 	"github.com/petrarca/tech-stack-analyzer/internal/config"
 	gitpkg "github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
-	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	settings       *config.Settings
 	scanConfig     *config.ScanConfigFile
 	scanConfigPath string
+	scanInvocation *metadata.InvocationInfo // command line, recorded before flags are resolved
 )
 
 var scanCmd = &cobra.Command{
@@ -36,8 +41,12 @@ Examples:
   stack-analyzer scan /path/to/project
   stack-analyzer scan /path/to/pom.xml
   stack-analyzer scan /path/to/proj1 /path/to/proj2
+  stack-analyzer scan /path/to/repo --path services/api --path services/web
+  stack-analyzer scan /path/to/repo --changed-since origin/main --baseline main-scan.json
+  stack-analyzer scan --parallel 8 /repos/*
   stack-analyzer scan --config scan-config.yml /path/to/project
   stack-analyzer scan --config '{"scan":{"output":{"file":"$BUILD_DIR/scan-results.json"},"properties":{"build":"'$BUILD_NUMBER'"}}}' /path/to/project
+  stack-analyzer scan -o result.json -o sbom.cdx.json:cyclonedx -o summary.md:markdown /path/to/project
   stack-analyzer scan --aggregate techs,languages /path/to/project
   stack-analyzer scan --aggregate all /path/to/project
   stack-analyzer scan --exclude vendor,node_modules /path/to/project
@@ -51,7 +60,6 @@ func init() {
 
 	settings = config.LoadSettingsFromEnvironment()
 
-	outputFile := settings.OutputFile
 	aggregate := settings.Aggregate
 	prettyPrint := settings.PrettyPrint
 	verbose := settings.Verbose
@@ -62,7 +70,7 @@ func init() {
 	logFormat := settings.LogFormat
 	logFile := settings.LogFile
 
-	scanCmd.Flags().StringVarP(&settings.OutputFile, "output", "o", outputFile, "Output file path (default: stack-analysis.json)")
+	scanCmd.Flags().StringArrayVarP(&settings.Outputs, "output", "o", nil, "Output file path (default: stack-analysis.json; '-' for stdout). Repeat to write several outputs from the one scan, each path[:format] with format json (scan output, default), cyclonedx, spdx, text or markdown (summary report), e.g. -o result.json -o sbom.cdx.json:cyclonedx -o summary.md:markdown")
 	scanCmd.Flags().StringVar(&settings.Aggregate, "aggregate", aggregate, "Aggregate fields: tech,techs,languages,licenses,dependencies,git,all")
 	scanCmd.Flags().BoolVar(&settings.PrettyPrint, "pretty", prettyPrint, "Pretty print JSON output")
 	scanCmd.Flags().BoolVarP(&settings.Quiet, "quiet", "q", false, "Suppress all progress output")
@@ -71,7 +79,12 @@ func init() {
 	scanCmd.Flags().BoolVar(&settings.TraceTimings, "trace-timings", traceTimings, "Show timing information for each directory (requires --verbose or --debug)")
 	scanCmd.Flags().BoolVar(&settings.TraceRules, "trace-rules", traceRules, "Show detailed rule matching information (requires --verbose or --debug)")
 	scanCmd.Flags().StringSliceVar(&settings.ExcludePatterns, "exclude", settings.ExcludePatterns, "Patterns to exclude (supports glob patterns, can be specified multiple times)")
+	scanCmd.Flags().StringVar(&settings.ChangedSince, "changed-since", settings.ChangedSince, "Only rescan the directories changed since this git ref (e.g. origin/main) and merge them into --baseline")
+	scanCmd.Flags().StringVar(&settings.Baseline, "baseline", settings.Baseline, "Full scan output of the same root that --changed-since merges the rescanned directories into")
+	scanCmd.Flags().StringSliceVar(&settings.IncludePaths, "path", settings.IncludePaths, "Only scan this subtree of the scan root, relative to it (repeatable); the result keeps the root's git identity and root ID")
 	scanCmd.Flags().StringSliceVar(&settings.FilterRules, "rules", settings.FilterRules, "Only use these rules (comma-separated tech names, e.g., c,cplusplus,nodejs - for debugging)")
+	scanCmd.Flags().StringSliceVar(&settings.Detectors, "detectors", settings.Detectors, "Only run these component detectors (comma-separated, e.g. nodejs,python; default: all)")
+	scanCmd.Flags().StringSliceVar(&settings.DisableDetectors, "disable-detectors", settings.DisableDetectors, "Component detectors not to run (comma-separated, e.g. docker)")
 	scanCmd.Flags().BoolVar(&settings.NoCodeStats, "no-code-stats", settings.NoCodeStats, "Disable code statistics (lines of code, comments, blanks, complexity)")
 	scanCmd.Flags().StringVar(&settings.DependencyGraph, "dependency-graph", settings.DependencyGraph, "Emit package-to-package dependency edges: off (default), direct (root->direct only), or full (transitive graph; can be large)")
 	scanCmd.Flags().BoolVar(&settings.UseDepsDev, "deps-dev", settings.UseDepsDev, "Enable online deps.dev resolution for transitive dependency graphs (default false; sends public package coordinates over the network). Requires --dependency-graph direct|full to have effect.")
@@ -98,6 +111,31 @@ func init() {
 	scanCmd.Flags().StringVar(&settings.CurrencyCache, "currency-cache", "", "Override the currency cache DB path (default: STACK_ANALYZER_CURRENCY_CACHE or the OS cache dir).")
 	scanCmd.Flags().IntVar(&settings.CurrencyTTLHours, "currency-ttl", 24, "Per-entry currency cache TTL in hours.")
 	scanCmd.Flags().BoolVar(&settings.HarvestLicenseCaches, "harvest-licenses", false, "Also harvest per-dependency licenses from out-of-tree global package caches (e.g. ~/.nuget/packages, honoring NUGET_PACKAGES). In-tree sources (a node_modules under the scan root) are always harvested regardless of this flag. Reads outside the scanned tree, so it is opt-in.")
+	scanCmd.Flags().IntVar(&settings.Parallel, "parallel", settings.Parallel, "Scan up to N paths of a multi-path scan concurrently and merge the results (default 1: one scanner walks all paths)")
+	scanCmd.Flags().BoolVar(&settings.InspectArchives, "inspect-archives", settings.InspectArchives, "Open vendored binary archives (*.jar/*.war/*.ear manifests and pom.properties, *.whl METADATA, *.nupkg nuspec) and report the packages embedded in them as dependencies. Archives over 128 MiB are skipped.")
+	scanCmd.Flags().StringVar(&settings.Reasons, "reasons", settings.Reasons, "Detection reasons in the output: full (default, every distinct reason), summary (at most 5 per tech, the first of each kind of evidence, the rest counted in reason_overflow) or none")
+	scanCmd.Flags().StringVar(&settings.ResolveImplied, "resolve-implied", settings.ResolveImplied, "Techs implied by another tech of the same component (a rule's implies list, e.g. react for nextjs): keep (default, list as detected), collapse (drop them in favor of the implying tech), or add (also list implied techs that were not detected)")
+	scanCmd.Flags().StringVar(&settings.ComponentNaming, "component-naming", settings.ComponentNaming, "Component naming sources, comma-separated and tried in order: manifest (name from the manifest), directory (directory name), repo-path (git repository name and path) (default: manifest,directory,repo-path)")
+	scanCmd.Flags().BoolVar(&settings.MergeImplicit, "merge-implicit", settings.MergeImplicit, "Fold implicit components (created for a single tech, without dependencies) into their parent's techs")
+	scanCmd.Flags().BoolVar(&settings.ConfigAudit, "config-audit", settings.ConfigAudit, "Audit configuration hygiene per component (12-factor): environment variable reads and hardcoded host:port values in code, dotenv files and secrets committed in configuration, missing .env.example; adds a config_audit section with findings")
+	scanCmd.Flags().StringVar(&settings.RulesDir, "rules-dir", settings.RulesDir, "Directory of custom YAML rules loaded on top of the embedded rules; a custom rule replaces the embedded rule of the same tech")
+	scanCmd.Flags().IntVar(&settings.MaxFileSizeMB, "max-file-size-mb", settings.MaxFileSizeMB, "Do not read files larger than this many megabytes for content matching, code stats and the per-file sections; they are still listed and detected by name (default 0 = 10; negative lifts the limit)")
+	scanCmd.Flags().StringSliceVar(&settings.SkipExtensions, "skip-extensions", settings.SkipExtensions, "Extensions of files never read for content (comma-separated, e.g. .png,.zip; replaces the default media, archive, font and executable types; \"none\" skips no type)")
+	scanCmd.Flags().IntVar(&settings.CodeStatsSampleMB, "code-stats-sample-mb", settings.CodeStatsSampleMB, "Estimate the code stats of files larger than this many megabytes from their first and last 256 KB, marked \"estimated\", including files over --max-file-size-mb that are otherwise left out (default 0 = off)")
+	scanCmd.Flags().IntVar(&settings.SlowDirThresholdMs, "slow-dir-threshold-ms", settings.SlowDirThresholdMs, "List the directories whose own processing (rules and files, not subdirectories) takes at least this many milliseconds after the scan (default 0 = 500; negative turns the report off)")
+	scanCmd.Flags().BoolVar(&settings.Adoption, "adoption", settings.Adoption, "Read sampled commits of the git history to date when each component's techs and direct dependencies first appeared and when dependency versions changed; adds an adoption section")
+	scanCmd.Flags().IntVar(&settings.AdoptionSamples, "adoption-samples", settings.AdoptionSamples, "Commits sampled for --adoption, evenly spaced and including the first commit and HEAD (default 0 = 20)")
+	scanCmd.Flags().BoolVar(&settings.AdoptionTags, "adoption-tags", settings.AdoptionTags, "Sample the tagged commits for --adoption instead of the history of HEAD")
+	scanCmd.Flags().BoolVar(&settings.Vendored, "vendored", settings.Vendored, "Compare each component's declared direct dependencies with the versions in its vendor directories (Go vendor/modules.txt, node_modules, Python site-packages); adds a vendored section listing version mismatches and declared dependencies missing from the vendor directory")
+	scanCmd.Flags().BoolVar(&settings.JavaImports, "java-imports", settings.JavaImports, "Read the import statements of Java and Kotlin sources to detect frameworks and JDK APIs used without a declared dependency (provided-scope APIs, Ant builds) from the rules' java.import patterns")
+	scanCmd.Flags().StringSliceVar(&settings.FailOn, "fail-on", settings.FailOn, "Exit with code 2 after writing the output when the result matches a condition (repeatable or comma-separated): tech:<key> (the tech is detected), license:<category> (a license of that risk category, e.g. forbidden) or audit:<severity> (a --config-audit finding, e.g. error)")
+	scanCmd.Flags().BoolVar(&settings.ResultSummary, "result-summary", settings.ResultSummary, "Write a one-line JSON summary of the outcome (status, exit code, counts, policy violations) as the last line on stderr")
+	scanCmd.Flags().BoolVar(&settings.LicenseTextHash, "license-text-hash", settings.LicenseTextHash, "Record the SHA-256 of each license file's normalized text, and report license files matching no known license as LicenseRef-custom, so identical custom licenses can be grouped across scans")
+	scanCmd.Flags().BoolVar(&settings.NoticeText, "notice-text", settings.NoticeText, "Keep the text of NOTICE and third-party notice files (cut at 64 KiB) in the attribution sections, not only their licenses and text hash")
+	scanCmd.Flags().IntVar(&settings.MergeImplicitMin, "merge-implicit-min", settings.MergeImplicitMin, "With --merge-implicit, only fold the implicit components of a parent having at least this many of them (default 0 folds all)")
+	scanCmd.Flags().Float64Var(&settings.MinConfidence, "min-confidence", settings.MinConfidence, "Drop techs whose evidence scores below this confidence (0-1): dependency 0.95, manifest file 0.85, content 0.75, implied 0.5, extension only 0.4, .env variable only 0.3; kinds of evidence combine (default 0 keeps all)")
+	scanCmd.Flags().BoolVar(&settings.ParseCache, "parse-cache", settings.ParseCache, "Keep parsed lock files (package-lock.json, pnpm-lock.yaml, yarn.lock, uv.lock, poetry.lock, Cargo.lock) in the shared cache DB, keyed by content hash, so unchanged ones are not parsed again by later scans. Identical lock files within a scan are always parsed once.")
+	registerScanCompletions(scanCmd)
 }
 
 // configureLogging sets up logging based on command flags.
@@ -142,7 +180,9 @@ func configureExcludePatterns(cmd *cobra.Command) {
 
 func runScan(cmd *cobra.Command, args []string) {
 	logger := configureLogging(cmd)
+	scanInvocation = newInvocation(cmd, args)
 	scanConfig = loadAndMergeScanConfig(logger)
+	validateOutputs(logger)
 
 	// If no CLI paths provided, use paths from config file (scan.paths)
 	if len(args) == 0 && scanConfig != nil && len(scanConfig.Scan.Paths) > 0 {
@@ -164,9 +204,14 @@ func runSinglePathScan(args []string, cmd *cobra.Command, logger *slog.Logger) {
 	absPath, isFile := resolveScanPath(args, logger)
 	configureExcludePatterns(cmd)
 	setupScanSettings(logger)
+	settings.IncludePaths = resolveIncludePaths(absPath, isFile, settings.IncludePaths, logger)
+	baseline, changed := resolveChangedSince(absPath, isFile, logger)
 
 	_, mergedConfig := loadAndMergeProjectConfig(absPath, logger)
-	payload := runScanner(absPath, isFile, mergedConfig, logger, nil)
+	var payload interface{} = baseline
+	if changed {
+		payload = runScanner(absPath, isFile, mergedConfig, logger, nil, baseline)
+	}
 
 	// Recompute primary_techs after enhancement so config-injected techs are included.
 	enhanceSinglePayload(payload, mergedConfig)
@@ -175,7 +220,9 @@ func runSinglePathScan(args []string, cmd *cobra.Command, logger *slog.Logger) {
 		p.Ecosystems = aggregator.ComputeEcosystemsFromPayload(p)
 	}
 
+	recordInvocation(payload)
 	generateAndWriteOutput(payload, logger)
+	finishScan(payload)
 }
 
 // runMultiPathScan scans multiple directories as a single unified project.
@@ -183,6 +230,10 @@ func runMultiPathScan(args []string, cmd *cobra.Command, logger *slog.Logger) {
 	commonParent, relPaths, absPaths := resolveMultiScanPaths(args, logger)
 	configureExcludePatterns(cmd)
 	setupScanSettings(logger)
+	if len(settings.IncludePaths) > 0 || settings.ChangedSince != "" {
+		logger.Error("--path and --changed-since select subtrees of a single scan root; give one path to scan")
+		failScan()
+	}
 
 	_, mergedConfig := loadAndMergeProjectConfig(commonParent, logger)
 	loadAndMergeScanConfig(logger)
@@ -206,7 +257,62 @@ func runMultiPathScan(args []string, cmd *cobra.Command, logger *slog.Logger) {
 	)
 
 	codeStatsAnalyzer := buildCodeStatsAnalyzer(settings)
+	configureComponents(settings, logger)
+
+	var s *scanner.Scanner
+	var payload *types.Payload
+	if settings.Parallel > 1 && len(relPaths) > 1 {
+		s, payload = scanPathsInParallel(commonParent, relPaths, rootID, mergedConfig, codeStatsAnalyzer, logger)
+	} else {
+		s = newMultiPathScanner(commonParent, relPaths, rootID, mergedConfig, codeStatsAnalyzer, logger)
+		var err error
+		payload, err = s.Scan()
+		if err != nil {
+			failScanOn(logger, "Failed to scan", err)
+		}
+	}
 
+	finalizeCodeStats(payload, codeStatsAnalyzer, settings.ComponentStatsDepth, s.ResolveSubsystemKeyFromPath, settings.SubsystemGroups)
+
+	// Enhance before computing primary_techs so config techs are included.
+	enhanceSinglePayload(payload, mergedConfig)
+	payload.PrimaryTechs = computePrimaryTechsFromPayload(payload)
+	payload.Ecosystems = aggregator.ComputeEcosystemsFromPayload(payload)
+
+	recordInvocation(payload)
+	generateAndWriteOutput(payload, logger)
+	finishScan(payload)
+}
+
+// newInvocation records the command line of a scan with the flags given
+// explicitly, as typed.
+func newInvocation(cmd *cobra.Command, args []string) *metadata.InvocationInfo {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			flags[f.Name] = strings.Join(slice.GetSlice(), ",")
+			return
+		}
+		flags[f.Name] = f.Value.String()
+	})
+	return metadata.NewInvocationInfo(cmd.CommandPath(), args, flags)
+}
+
+// recordInvocation stores the command line of the scan in the metadata of
+// its result.
+func recordInvocation(payload interface{}) {
+	p, ok := payload.(*types.Payload)
+	if !ok || scanInvocation == nil {
+		return
+	}
+	if meta, ok := p.Metadata.(*metadata.ScanMetadata); ok {
+		meta.SetInvocation(scanInvocation)
+	}
+}
+
+// newMultiPathScanner creates a scanner rooted at the common parent that only
+// walks the given relative paths.
+func newMultiPathScanner(commonParent string, relPaths []string, rootID string, mergedConfig *config.ScanConfig, codeStatsAnalyzer codestats.Analyzer, logger *slog.Logger) *scanner.Scanner {
 	s, err := scanner.NewScannerWithOptionsAndLogger(
 		commonParent,
 		settings.ExcludePatterns,
@@ -221,34 +327,14 @@ func runMultiPathScan(args []string, cmd *cobra.Command, logger *slog.Logger) {
 		mergedConfig,
 	)
 	if err != nil {
-		logger.Error("Failed to create scanner", "error", err)
-		os.Exit(1)
+		failScanOn(logger, "Failed to create scanner", err)
 	}
-	s.SetSubsystemDepth(settings.SubsystemDepth)
-	s.SetSubsystemGroups(settings.SubsystemGroups)
 	s.SetIncludePaths(relPaths)
-	components.SetDependencyGraphMode(types.ParseDependencyGraphMode(settings.DependencyGraph))
-	components.SetUseDepsDev(settings.UseDepsDev)
-	components.SetDepsDevEndpoint(settings.DepsDevEndpoint)
-	components.SetHarvestLicenseCaches(settings.HarvestLicenseCaches)
-	components.SetUseMavenCentral(settings.UseMavenCentral)
-	components.SetMavenGraphSource(settings.MavenGraphSource)
-	applyMavenSettings(logger)
-
-	payload, err := s.Scan()
-	if err != nil {
-		logger.Error("Failed to scan", "error", err)
-		os.Exit(1)
+	if err := applyScannerSettings(s, settings); err != nil {
+		logger.Error("Invalid detector selection", "error", err)
+		failScan()
 	}
-
-	finalizeCodeStats(payload, codeStatsAnalyzer, settings.ComponentStatsDepth, s.ResolveSubsystemKeyFromPath, settings.SubsystemGroups)
-
-	// Enhance before computing primary_techs so config techs are included.
-	enhanceSinglePayload(payload, mergedConfig)
-	payload.PrimaryTechs = computePrimaryTechsFromPayload(payload)
-	payload.Ecosystems = aggregator.ComputeEcosystemsFromPayload(payload)
-
-	generateAndWriteOutput(payload, logger)
+	return s
 }
 
 // resolveScanPath resolves and validates the scan path from args.
@@ -262,19 +348,60 @@ func resolveScanPath(args []string, logger *slog.Logger) (absPath string, isFile
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		logger.Error("Invalid path", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 
-	fileInfo, err := os.Stat(absPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			logger.Error("Path does not exist", "path", absPath)
-		} else {
-			logger.Error("Cannot access path", "path", absPath, "error", err)
+	fileInfo := statScanPath(absPath, logger)
+	return absPath, !fileInfo.IsDir()
+}
+
+// statScanPath returns the file info of a scan path, failing the scan when
+// the path does not exist or cannot be accessed.
+func statScanPath(path string, logger *slog.Logger) os.FileInfo {
+	info, err := os.Stat(path)
+	if err == nil {
+		return info
+	}
+	msg := "Cannot access path"
+	if os.IsNotExist(err) {
+		msg = "Path does not exist"
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+	failScanOn(logger, msg, &types.ProviderError{Op: "stat", Path: path, Err: err})
+	return nil
+}
+
+// resolveIncludePaths checks the --path subtrees of the scan root and
+// returns them cleaned and relative to it. A path selecting the whole root
+// lifts the restriction.
+func resolveIncludePaths(root string, isFile bool, paths []string, logger *slog.Logger) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	if isFile {
+		logger.Error("--path selects subtrees of a directory, not of a file", "path", root)
+		failScan()
+	}
+	var resolved []string
+	for _, p := range paths {
+		rel := filepath.Clean(strings.TrimSpace(p))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			logger.Error("--path must be relative to the scan root and stay inside it", "path", p)
+			failScan()
+		}
+		if info, err := os.Stat(filepath.Join(root, rel)); err != nil || !info.IsDir() {
+			logger.Error("--path is not a directory under the scan root", "path", p, "root", root)
+			failScan()
 		}
-		os.Exit(1)
+		if rel == "." {
+			return nil
+		}
+		resolved = append(resolved, rel)
 	}
-	return absPath, !fileInfo.IsDir()
+	return resolved
 }
 
 // computeCommonParent returns the deepest common parent directory of the given absolute paths.
@@ -338,20 +465,12 @@ func resolveMultiScanPaths(args []string, logger *slog.Logger) (commonParent str
 		abs, err := filepath.Abs(arg)
 		if err != nil {
 			logger.Error("Invalid path", "path", arg, "error", err)
-			os.Exit(1)
-		}
-		info, err := os.Stat(abs)
-		if os.IsNotExist(err) {
-			logger.Error("Path does not exist", "path", abs)
-			os.Exit(1)
-		}
-		if err != nil {
-			logger.Error("Cannot access path", "path", abs, "error", err)
-			os.Exit(1)
+			failScan()
 		}
+		info := statScanPath(abs, logger)
 		if !info.IsDir() {
 			logger.Error("Multi-path scan requires directories, not files", "path", abs)
-			os.Exit(1)
+			failScan()
 		}
 		absPaths = append(absPaths, abs)
 	}
@@ -361,7 +480,7 @@ func resolveMultiScanPaths(args []string, logger *slog.Logger) (commonParent str
 	if isSystemRoot(commonParent) {
 		fmt.Fprintf(os.Stderr, "Error: the specified paths share only %q as common parent.\n", commonParent)
 		fmt.Fprintf(os.Stderr, "Multi-path scanning requires paths that share a project-level common parent.\n")
-		os.Exit(1)
+		failScan()
 	}
 
 	relPaths = make([]string, 0, len(absPaths))
@@ -369,12 +488,17 @@ func resolveMultiScanPaths(args []string, logger *slog.Logger) (commonParent str
 		rel, err := filepath.Rel(commonParent, abs)
 		if err != nil {
 			logger.Error("Cannot compute relative path", "base", commonParent, "target", abs, "error", err)
-			os.Exit(1)
+			failScan()
 		}
 		relPaths = append(relPaths, rel)
 	}
 
-	// Warn when one input path is an ancestor of another.
+	warnNestedPaths(absPaths)
+	return commonParent, relPaths, absPaths
+}
+
+// warnNestedPaths warns when one input path is an ancestor of another.
+func warnNestedPaths(absPaths []string) {
 	for i, a := range absPaths {
 		for j, b := range absPaths {
 			if i != j && strings.HasPrefix(b+string(filepath.Separator), a+string(filepath.Separator)) {
@@ -382,6 +506,4 @@ func resolveMultiScanPaths(args []string, logger *slog.Logger) (commonParent str
 			}
 		}
 	}
-
-	return commonParent, relPaths, absPaths
 }
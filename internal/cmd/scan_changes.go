@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	gitpkg "github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// resolveChangedSince prepares a --changed-since scan: it loads the
+// baseline and restricts the scan to the directories changed since the ref
+// (see scanner.ChangedScanRoots). It returns nil when --changed-since is
+// not set or a file of the scan root changed, in which case the scan is a
+// full one. changed reports whether anything changed at all.
+func resolveChangedSince(root string, isFile bool, logger *slog.Logger) (baseline *types.Payload, changed bool) {
+	if settings.ChangedSince == "" {
+		if settings.Baseline != "" {
+			logger.Error("--baseline is only used with --changed-since")
+			failScan()
+		}
+		return nil, true
+	}
+	if err := checkChangedSinceSettings(isFile); err != nil {
+		logger.Error(err.Error())
+		failScan()
+	}
+	baseline, err := loadBaseline(settings.Baseline)
+	if err != nil {
+		logger.Error("Cannot use the baseline", "path", settings.Baseline, "error", err)
+		failScan()
+	}
+	files, err := gitpkg.ChangedPaths(root, settings.ChangedSince)
+	if err != nil {
+		logger.Error("Cannot list changed files", "since", settings.ChangedSince, "error", err)
+		failScan()
+	}
+
+	roots, full := scanner.ChangedScanRoots(baseline, files)
+	logger.Debug("Changed-paths scan", "since", settings.ChangedSince, "files", len(files), "roots", roots, "full", full)
+	if full {
+		if !settings.Quiet {
+			fmt.Fprintf(os.Stderr, "Files of the scan root changed since %s; scanning everything\n", settings.ChangedSince)
+		}
+		return nil, true
+	}
+	if !settings.Quiet {
+		fmt.Fprintf(os.Stderr, "Rescanning %d of the directories changed since %s: %s\n", len(roots), settings.ChangedSince, strings.Join(roots, ", "))
+	}
+	settings.IncludePaths = roots
+	return baseline, len(roots) > 0
+}
+
+func checkChangedSinceSettings(isFile bool) error {
+	switch {
+	case settings.Baseline == "":
+		return fmt.Errorf("--changed-since needs --baseline, the full scan output to merge the changes into")
+	case isFile:
+		return fmt.Errorf("--changed-since scans a directory, not a file")
+	case len(settings.IncludePaths) > 0:
+		return fmt.Errorf("--changed-since chooses the paths to scan itself; it cannot be combined with --path")
+	}
+	return nil
+}
+
+// loadBaseline reads a full scan output, as written by scan without
+// --aggregate.
+func loadBaseline(path string) (*types.Payload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = migrate.ToCurrent(data); err != nil {
+		return nil, err
+	}
+	var baseline types.Payload
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	if baseline.ID == "" {
+		return nil, fmt.Errorf("not a full scan output (no root id; aggregated outputs cannot be merged into)")
+	}
+	if baseline.Languages == nil {
+		baseline.Languages = make(map[string]int)
+	}
+	return &baseline, nil
+}
+
+// mergeIntoBaseline merges the result of a --changed-since scan into its
+// baseline, which must come from a scan of the same root. Without a
+// baseline it returns partial.
+func mergeIntoBaseline(s *scanner.Scanner, baseline, partial *types.Payload, logger *slog.Logger) *types.Payload {
+	if baseline == nil {
+		return partial
+	}
+	if baseline.ID != partial.ID {
+		logger.Error("The baseline was not produced by a scan of this root; use --root-id when the scans set one",
+			"baseline_root_id", baseline.ID, "root_id", partial.ID)
+		failScan()
+	}
+	return s.MergeChanges(baseline, partial, settings.IncludePaths)
+}
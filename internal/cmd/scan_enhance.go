@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"log/slog"
 
@@ -30,10 +31,10 @@ const (
 // settings.xml path is per-scan configurable so different projects can use
 // their own; it defaults to ~/.m2/settings.xml and is silently skipped when
 // absent.
-func applyMavenSettings(logger *slog.Logger) {
-	components.SetUseMavenLocalRepo(settings.MavenLocalRepo)
-	components.SetMavenLocalRepoDir(settings.MavenLocalRepoDir)
-	components.SetMavenRepoURL(settings.MavenRepoURL)
+func applyMavenSettings(s *config.Settings, logger *slog.Logger) {
+	components.SetUseMavenLocalRepo(s.MavenLocalRepo)
+	components.SetMavenLocalRepoDir(s.MavenLocalRepoDir)
+	components.SetMavenRepoURL(s.MavenRepoURL)
 	if tok := strings.TrimSpace(os.Getenv(mavenTokenEnvVar)); tok != "" {
 		components.SetMavenRepoToken(tok)
 	}
@@ -41,17 +42,57 @@ func applyMavenSettings(logger *slog.Logger) {
 		components.SetMavenRepoUser(user)
 	}
 
-	settingsPath := settings.MavenSettings
+	settingsPath := s.MavenSettings
 	if settingsPath == "" {
 		settingsPath = mavenresolve.DefaultSettingsPath()
 	}
-	if s, err := mavenresolve.LoadSettings(settingsPath); err != nil {
+	if ms, err := mavenresolve.LoadSettings(settingsPath); err != nil {
 		logger.Warn("Failed to load Maven settings.xml", "path", settingsPath, "error", err)
 	} else {
-		components.SetMavenSettings(s)
+		components.SetMavenSettings(ms)
 	}
 }
 
+// configureComponents pushes the dependency resolution settings of a
+// directory scan into the components layer, and the custom rules directory
+// into the scanner, both of which hold them globally.
+func configureComponents(s *config.Settings, logger *slog.Logger) {
+	scanner.SetRulesDir(s.RulesDir)
+	components.SetDependencyGraphMode(types.ParseDependencyGraphMode(s.DependencyGraph))
+	components.SetUseDepsDev(s.UseDepsDev)
+	components.SetDepsDevEndpoint(s.DepsDevEndpoint)
+	components.SetHarvestLicenseCaches(s.HarvestLicenseCaches)
+	components.SetInspectArchives(s.InspectArchives)
+	components.SetUseMavenCentral(s.UseMavenCentral)
+	components.SetMavenGraphSource(s.MavenGraphSource)
+	applyMavenSettings(s, logger)
+	configureParseCache(s, logger)
+}
+
+// applyScannerSettings applies the settings held per scanner: subsystems,
+// tech resolution, reasons, naming, file skipping, adoption and the
+// detector selection, whose error it returns. Include paths are left to the
+// caller.
+func applyScannerSettings(sc *scanner.Scanner, s *config.Settings) error {
+	sc.SetSubsystemDepth(s.SubsystemDepth)
+	sc.SetSubsystemGroups(s.SubsystemGroups)
+	sc.SetImpliedTechs(s.ResolveImplied)
+	sc.SetReasonMode(s.Reasons)
+	sc.SetComponentNaming(s.ComponentNaming)
+	sc.SetMergeImplicit(s.MergeImplicit, s.MergeImplicitMin)
+	sc.SetMinConfidence(s.MinConfidence)
+	sc.SetLicenseTextHash(s.LicenseTextHash)
+	sc.SetConfigAudit(s.ConfigAudit)
+	sc.SetNoticeText(s.NoticeText)
+	sc.SetSlowDirThreshold(time.Duration(s.SlowDirThresholdMs) * time.Millisecond)
+	sc.SetSkipPolicy(int64(s.MaxFileSizeMB)<<20, s.SkipExtensions)
+	sc.SetCodeStatsSampling(int64(s.CodeStatsSampleMB) << 20)
+	sc.SetAdoption(s.Adoption, s.AdoptionSamples, s.AdoptionTags)
+	sc.SetVendored(s.Vendored)
+	sc.SetJavaImports(s.JavaImports)
+	return sc.SetDetectors(s.Detectors, s.DisableDetectors)
+}
+
 // loadAndMergeScanConfig loads scan configuration and merges with settings.
 func loadAndMergeScanConfig(logger *slog.Logger) *config.ScanConfigFile {
 	if scanConfigPath == "" {
@@ -61,7 +102,7 @@ func loadAndMergeScanConfig(logger *slog.Logger) *config.ScanConfigFile {
 	scanConfig, err := config.LoadScanConfig(scanConfigPath)
 	if err != nil {
 		logger.Error("Failed to load scan configuration", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 
 	scanConfig.MergeWithSettings(settings)
@@ -76,12 +117,12 @@ func setupScanSettings(logger *slog.Logger) {
 
 	if settings.Quiet && (settings.Verbose || settings.Debug) {
 		logger.Error("Cannot use --quiet with --verbose or --debug.")
-		os.Exit(1)
+		failScan()
 	}
 
 	if settings.Verbose && settings.Debug {
 		logger.Error("Cannot use --verbose and --debug together. Choose one.")
-		os.Exit(1)
+		failScan()
 	}
 
 	if (settings.TraceRules || settings.TraceTimings) && !settings.Verbose && !settings.Debug {
@@ -97,12 +138,12 @@ func setupScanSettings(logger *slog.Logger) {
 		fmt.Fprintf(os.Stderr, "  stack-analyzer scan . --verbose %s  # Human-readable output\n", strings.Join(flags, " "))
 		fmt.Fprintf(os.Stderr, "  stack-analyzer scan . --debug %s    # Machine-readable CSV output\n", strings.Join(flags, " "))
 		fmt.Fprintf(os.Stderr, "\nSee --help for more information.\n")
-		os.Exit(1)
+		failScan()
 	}
 
-	if err := settings.Validate(); err != nil {
+	if err := validateSettings(settings); err != nil {
 		logger.Error("Invalid settings", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 }
 
@@ -112,7 +153,7 @@ func loadAndMergeProjectConfig(absPath string, logger *slog.Logger) (*config.Sca
 	projectConfig, err := config.LoadConfig(absPath)
 	if err != nil {
 		logger.Error("Failed to load project configuration", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 
 	var mergedConfig *config.ScanConfig
@@ -134,23 +175,16 @@ func loadAndMergeProjectConfig(absPath string, logger *slog.Logger) (*config.Sca
 // runScanner creates and runs the scanner, finalises code stats and primary_techs.
 // runScanner creates, configures, and runs the scanner for a single path.
 // If obsCollector is non-nil, it is attached to the scanner to collect
-// file-level observations during the scan.
-func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, logger *slog.Logger, obsCollector *scanner.ObservationCollector) interface{} {
+// file-level observations during the scan. If baseline is non-nil (a
+// --changed-since scan), the result is merged into it.
+func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, logger *slog.Logger, obsCollector *scanner.ObservationCollector, baseline *types.Payload) interface{} {
 	scannerPath := absPath
 	if isFile {
 		scannerPath = filepath.Dir(absPath)
 	}
 
 	if !settings.Quiet {
-		if isFile {
-			fmt.Fprintf(os.Stderr, "Scanning file: %s\n", absPath)
-		} else {
-			fmt.Fprintf(os.Stderr, "Scanning: %s\n", scannerPath)
-		}
-
-		if len(settings.FilterRules) > 0 {
-			fmt.Fprintf(os.Stderr, "Active rules: %s\n", strings.Join(settings.FilterRules, ", "))
-		}
+		announceScan(absPath, scannerPath, isFile)
 	}
 
 	logger.Debug("Initializing scanner",
@@ -159,20 +193,17 @@ func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, lo
 		"code_stats", !settings.NoCodeStats)
 
 	codeStatsAnalyzer := buildCodeStatsAnalyzer(settings)
+	configureComponents(settings, logger)
 
 	s, err := scanner.NewScannerWithOptionsAndLogger(scannerPath, settings.ExcludePatterns, settings.Quiet, settings.Verbose, settings.Debug, settings.TraceTimings, settings.TraceRules, codeStatsAnalyzer, logger, settings.RootID, mergedConfig)
 	if err != nil {
-		logger.Error("Failed to create scanner", "error", err)
-		os.Exit(1)
-	}
-	s.SetSubsystemDepth(settings.SubsystemDepth)
-	s.SetSubsystemGroups(settings.SubsystemGroups)
-	components.SetDependencyGraphMode(types.ParseDependencyGraphMode(settings.DependencyGraph))
-	components.SetUseDepsDev(settings.UseDepsDev)
-	components.SetDepsDevEndpoint(settings.DepsDevEndpoint)
-	components.SetUseMavenCentral(settings.UseMavenCentral)
-	components.SetMavenGraphSource(settings.MavenGraphSource)
-	applyMavenSettings(logger)
+		failScanOn(logger, "Failed to create scanner", err)
+	}
+	s.SetIncludePaths(settings.IncludePaths)
+	if err := applyScannerSettings(s, settings); err != nil {
+		logger.Error("Invalid detector selection", "error", err)
+		failScan()
+	}
 	if obsCollector != nil {
 		s.SetObservationCollector(obsCollector)
 	}
@@ -187,18 +218,31 @@ func runScanner(absPath string, isFile bool, mergedConfig *config.ScanConfig, lo
 	}
 
 	if err != nil {
-		logger.Error("Failed to scan", "error", err)
-		os.Exit(1)
+		failScanOn(logger, "Failed to scan", err)
 	}
 
 	if p, ok := payload.(*types.Payload); ok {
 		finalizeCodeStats(p, codeStatsAnalyzer, settings.ComponentStatsDepth, s.ResolveSubsystemKeyFromPath, settings.SubsystemGroups)
+		p = mergeIntoBaseline(s, baseline, p, logger)
+		payload = p
 		p.PrimaryTechs = computePrimaryTechsFromPayload(p)
 	}
 
 	return payload
 }
 
+// announceScan prints the path about to be scanned and the active rules.
+func announceScan(absPath, scannerPath string, isFile bool) {
+	if isFile {
+		fmt.Fprintf(os.Stderr, "Scanning file: %s\n", absPath)
+	} else {
+		fmt.Fprintf(os.Stderr, "Scanning: %s\n", scannerPath)
+	}
+	if len(settings.FilterRules) > 0 {
+		fmt.Fprintf(os.Stderr, "Active rules: %s\n", strings.Join(settings.FilterRules, ", "))
+	}
+}
+
 // enhanceSinglePayload adds configuration-driven data to the payload (properties, techs).
 // Must run before computePrimaryTechsFromPayload so config-injected techs are included.
 func enhanceSinglePayload(payload interface{}, mergedConfig *config.ScanConfig) {
@@ -242,6 +286,7 @@ func applyConfigTechs(p *types.Payload, techs []config.ConfigTech) {
 	for _, configTech := range techs {
 		techKey, reason := resolveConfigTech(configTech, ruleMap)
 		p.AddTech(techKey, reason)
+		p.SetTechConfidence(techKey, 1) // declared by the user
 	}
 }
 
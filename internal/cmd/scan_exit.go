@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Exit codes of the scan command. Scripts wrapping a scan branch on them
+// instead of parsing the output; see docs/usage.md.
+const (
+	exitOK              = 0 // output written, nothing to report
+	exitScanError       = 1 // the scan failed or its output could not be written
+	exitPolicyViolation = 2 // output written, a --fail-on condition matched
+	exitPartialResult   = 3 // output written, some directories were not fully analyzed
+)
+
+// resultSummary is the one-line outcome of a scan written to stderr with
+// --result-summary.
+type resultSummary struct {
+	Status         string   `json:"status"` // ok, error, policy_violation or partial
+	ExitCode       int      `json:"exit_code"`
+	Output         string   `json:"output,omitempty"`
+	Files          int      `json:"files"`
+	Components     int      `json:"components"`
+	Techs          int      `json:"techs"`
+	DurationMs     int64    `json:"duration_ms"`
+	DetectorErrors int      `json:"detector_errors,omitempty"`
+	ErrorCategory  string   `json:"error_category,omitempty"` // path_not_found, parse, provider or internal
+	Violations     []string `json:"violations,omitempty"`
+}
+
+// failScan ends a scan that failed with exitScanError, after its summary
+// line when one was asked for. The error itself has been logged.
+func failScan() {
+	if settings.ResultSummary {
+		writeResultSummary(os.Stderr, resultSummary{Status: "error", ExitCode: exitScanError})
+	}
+	os.Exit(exitScanError)
+}
+
+// failScanOn logs a scan failing on err with the category of the error,
+// telling a missing path or unparseable input from a failure of the
+// environment or a bug, and ends it like failScan.
+func failScanOn(logger *slog.Logger, msg string, err error, args ...any) {
+	category := types.ErrorCategory(err)
+	logger.Error(msg, append(args, "error", err, "category", category)...)
+	if settings.ResultSummary {
+		writeResultSummary(os.Stderr, resultSummary{Status: "error", ExitCode: exitScanError, ErrorCategory: category})
+	}
+	os.Exit(exitScanError)
+}
+
+// finishScan ends a scan whose output was written: with exitPolicyViolation
+// when a --fail-on condition matched, otherwise with exitPartialResult when a
+// component detector failed on some directory.
+func finishScan(payload interface{}) {
+	summary := summarizeResult(payload, settings.FailOn)
+	summary.Output = settings.OutputFile
+	if settings.ResultSummary {
+		writeResultSummary(os.Stderr, summary)
+	}
+	if summary.ExitCode != exitOK {
+		os.Exit(summary.ExitCode)
+	}
+}
+
+// summarizeResult reads the outcome of a scan from its result.
+func summarizeResult(payload interface{}, failOn []string) resultSummary {
+	summary := resultSummary{Status: "ok"}
+	p, ok := payload.(*types.Payload)
+	if !ok {
+		return summary
+	}
+	if meta, ok := p.Metadata.(*metadata.ScanMetadata); ok {
+		summary.Files = meta.FileCount
+		summary.Components = meta.ComponentCount
+		summary.Techs = meta.TechsCount
+		summary.DurationMs = meta.DurationMs
+		for _, stat := range meta.DetectorStats {
+			summary.DetectorErrors += stat.Errors
+		}
+	}
+	summary.Violations = policyViolations(p, failOn)
+	switch {
+	case len(summary.Violations) > 0:
+		summary.Status, summary.ExitCode = "policy_violation", exitPolicyViolation
+	case summary.DetectorErrors > 0:
+		summary.Status, summary.ExitCode = "partial", exitPartialResult
+	}
+	return summary
+}
+
+func writeResultSummary(w io.Writer, summary resultSummary) {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+// policyViolations returns the --fail-on conditions the result matches,
+// each with the first component path it matched at, sorted.
+func policyViolations(root *types.Payload, failOn []string) []string {
+	if len(failOn) == 0 {
+		return nil
+	}
+	found := make(map[string]string)
+	walkComponents(root, func(p *types.Payload) {
+		for _, condition := range failOn {
+			condition = strings.TrimSpace(condition)
+			if _, seen := found[condition]; !seen && matchesCondition(p, condition) {
+				found[condition] = componentPath(p)
+			}
+		}
+	})
+	violations := make([]string, 0, len(found))
+	for condition, path := range found {
+		violations = append(violations, condition+" at "+path)
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// matchesCondition reports whether a component matches a validated
+// "kind:value" policy condition.
+func matchesCondition(p *types.Payload, condition string) bool {
+	kind, value, _ := strings.Cut(condition, ":")
+	switch kind {
+	case "tech":
+		return detectsTech(p, value)
+	case "license":
+		return hasLicenseCategory(p, license.Category(value))
+	case "audit":
+		return hasAuditFinding(p, value)
+	}
+	return false
+}
+
+func detectsTech(p *types.Payload, tech string) bool {
+	return slices.Contains(p.Techs, tech) || slices.Contains(p.Tech, tech)
+}
+
+// hasLicenseCategory reports whether a license of the component, or the
+// harvested license of one of its dependencies, is of the category.
+func hasLicenseCategory(p *types.Payload, category license.Category) bool {
+	for _, l := range p.Licenses {
+		c := license.Category(l.Category)
+		if c == "" {
+			c = license.CategoryForExpressionString(l.LicenseName)
+		}
+		if c == category {
+			return true
+		}
+	}
+	for _, dep := range p.Dependencies {
+		if lic := license.DependencyLicense(dep); lic != "" && license.CategoryForExpressionString(lic) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAuditFinding reports whether the config audit of the component has a
+// finding of the severity. The section is read through JSON so a section
+// carried over from a --baseline output counts as well.
+func hasAuditFinding(p *types.Payload, severity string) bool {
+	section, ok := p.Properties["config_audit"]
+	if !ok {
+		return false
+	}
+	data, err := json.Marshal(section)
+	if err != nil {
+		return false
+	}
+	var audit scanner.ConfigAuditInfo
+	if json.Unmarshal(data, &audit) != nil {
+		return false
+	}
+	for _, finding := range audit.Findings {
+		if finding.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
+
+func componentPath(p *types.Payload) string {
+	if p.SourceDir != "" {
+		return p.SourceDir
+	}
+	if len(p.Path) > 0 {
+		return p.Path[0]
+	}
+	return "/"
+}
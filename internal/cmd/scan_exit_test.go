@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeResult(t *testing.T) {
+	dep := types.Dependency{Type: "npm", Name: "left-pad", Version: "1.0.0"}
+	license.SetDependencyLicense(&dep, "AGPL-3.0-only")
+	api := &types.Payload{
+		SourceDir:    "/api",
+		Techs:        []string{"nodejs", "mongodb"},
+		Dependencies: []types.Dependency{dep},
+		Properties: map[string]interface{}{
+			"config_audit": &scanner.ConfigAuditInfo{Findings: []scanner.ConfigFinding{{Check: "committed-secrets", Severity: "error"}}},
+		},
+	}
+	root := &types.Payload{
+		Path:     []string{"/"},
+		Metadata: &metadata.ScanMetadata{FileCount: 12, ComponentCount: 2, TechsCount: 3, DurationMs: 40},
+		Children: []*types.Payload{api},
+	}
+
+	summary := summarizeResult(root, nil)
+	assert.Equal(t, resultSummary{Status: "ok", Files: 12, Components: 2, Techs: 3, DurationMs: 40}, summary)
+
+	summary = summarizeResult(root, []string{"tech:mongodb", "tech:redis", "license:forbidden", "audit:error", "audit:warning"})
+	assert.Equal(t, "policy_violation", summary.Status)
+	assert.Equal(t, exitPolicyViolation, summary.ExitCode)
+	assert.Equal(t, []string{"audit:error at /api", "license:forbidden at /api", "tech:mongodb at /api"}, summary.Violations)
+
+	root.Metadata.(*metadata.ScanMetadata).DetectorStats = []metadata.DetectorStat{{Detector: "nodejs", Errors: 2}}
+	summary = summarizeResult(root, []string{"tech:redis"})
+	assert.Equal(t, "partial", summary.Status)
+	assert.Equal(t, exitPartialResult, summary.ExitCode)
+	assert.Equal(t, 2, summary.DetectorErrors)
+}
+
+func TestWriteResultSummary(t *testing.T) {
+	var buf bytes.Buffer
+	writeResultSummary(&buf, resultSummary{Status: "error", ExitCode: exitScanError})
+	assert.Equal(t, `{"status":"error","exit_code":1,"files":0,"components":0,"techs":0,"duration_ms":0}`+"\n", buf.String())
+}
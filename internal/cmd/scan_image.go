@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/image"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// Registry credentials are read from the environment (never a flag) so they
+// do not end up in shell history or the output.
+const (
+	registryUserEnvVar  = "STACK_ANALYZER_REGISTRY_USER"
+	registryTokenEnvVar = "STACK_ANALYZER_REGISTRY_TOKEN"
+)
+
+// ImageComponentType marks the root component of a scanned container image.
+const ImageComponentType = "image"
+
+// imageExcludes skips image paths that hold no project content but are large.
+var imageExcludes = []string{"proc", "sys", "dev", "usr/share/doc", "usr/share/man", "usr/share/locale", "usr/share/i18n", "var/cache"}
+
+var (
+	scanImageOutput   string
+	scanImagePretty   bool
+	scanImagePlatform string
+	scanImageQuiet    bool
+)
+
+// scanImageCmd scans the filesystem of a container image.
+var scanImageCmd = &cobra.Command{
+	Use:   "scan-image <image-ref|image.tar>",
+	Short: "Scan a container image (registry reference or docker save / OCI tarball)",
+	Long: `Pull an OCI/Docker image from its registry, or read a 'docker save' or OCI
+layout tarball, unpack its layers, and scan the resulting filesystem with the
+same rules and dependency detection as 'scan'.
+
+Installed OS packages are read from the apk, dpkg, and rpm (sqlite) databases
+and reported as dependencies of type apk, deb, and rpm. The root component is
+tagged with type "image" and image details are recorded under
+properties.image.
+
+Private registries: set STACK_ANALYZER_REGISTRY_USER and
+STACK_ANALYZER_REGISTRY_TOKEN. Anonymous pulls need no configuration.
+
+Examples:
+  stack-analyzer scan-image alpine:3.20
+  stack-analyzer scan-image registry.example.com/myorg/myapp:1.4.0 -o myapp-image.json
+  stack-analyzer scan-image ./myapp.tar --platform linux/arm64`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runScanImage(args[0])
+	},
+}
+
+func runScanImage(ref string) error {
+	img, err := image.Open(context.Background(), ref, image.Options{
+		Platform: scanImagePlatform,
+		Username: strings.TrimSpace(os.Getenv(registryUserEnvVar)),
+		Token:    strings.TrimSpace(os.Getenv(registryTokenEnvVar)),
+	})
+	if err != nil {
+		return fmt.Errorf("open image: %w", err)
+	}
+
+	root, err := os.MkdirTemp("", "stack-analyzer-image-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(root) }()
+
+	if !scanImageQuiet {
+		fmt.Fprintf(os.Stderr, "Unpacking %s (%d layers)\n", img.Info.Reference, img.Info.Layers)
+	}
+	if err := img.Extract(root); err != nil {
+		return fmt.Errorf("unpack image: %w", err)
+	}
+
+	payload, err := scanImageRoot(root)
+	if err != nil {
+		return err
+	}
+	tagImagePayload(payload, root, img.Info)
+
+	out, err := marshalJSON(payload, scanImagePretty)
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+	if scanImageOutput == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(scanImageOutput, out, 0644); err != nil {
+		return fmt.Errorf("write result: %w", err)
+	}
+	if !scanImageQuiet {
+		fmt.Fprintf(os.Stderr, "Results written to %s\n", scanImageOutput)
+	}
+	return nil
+}
+
+// scanImageRoot runs the regular scanner over the unpacked image filesystem.
+// Code statistics are skipped: an image is mostly third-party binaries.
+func scanImageRoot(root string) (*types.Payload, error) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	s, err := scanner.NewScannerWithOptionsAndLogger(root, imageExcludes, scanImageQuiet, false, false, false, false, nil, logger, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create scanner: %w", err)
+	}
+	payload, err := s.Scan()
+	if err != nil {
+		return nil, fmt.Errorf("scan image: %w", err)
+	}
+	return payload, nil
+}
+
+// tagImagePayload turns the scan root into the image component: it is named
+// after the image repository, typed "image", and carries the OS packages and
+// the image details.
+func tagImagePayload(payload *types.Payload, root string, info image.Info) {
+	distro := image.ReadDistro(root)
+	info.Distro, info.DistroVer = distro.ID, distro.VersionID
+
+	payload.Name = imageName(info.Reference)
+	payload.SetComponentType(ImageComponentType)
+	for _, dep := range image.OSPackages(root, distro) {
+		payload.AddDependency(dep)
+	}
+	if info.OS == "linux" {
+		payload.AddTech("os_linux", "image os: linux")
+	}
+	if payload.Properties == nil {
+		payload.Properties = make(map[string]interface{})
+	}
+	payload.Properties["image"] = info
+	payload.PrimaryTechs = computePrimaryTechsFromPayload(payload)
+}
+
+// imageName derives a component name from an image reference or tarball path
+// ("registry.example.com/myorg/myapp:1.4" -> "myapp").
+func imageName(ref string) string {
+	name := path.Base(strings.ReplaceAll(ref, "\\", "/"))
+	name, _, _ = strings.Cut(name, "@")
+	name, _, _ = strings.Cut(name, ":")
+	return strings.TrimSuffix(name, ".tar")
+}
+
+func init() {
+	rootCmd.AddCommand(scanImageCmd)
+	scanImageCmd.Flags().StringVarP(&scanImageOutput, "output", "o", "", "Output file path (default: stdout).")
+	scanImageCmd.Flags().BoolVar(&scanImagePretty, "pretty", true, "Pretty-print the JSON output.")
+	scanImageCmd.Flags().StringVar(&scanImagePlatform, "platform", "linux/amd64", "Platform to select from a multi-platform image (os/arch[/variant]).")
+	scanImageCmd.Flags().BoolVarP(&scanImageQuiet, "quiet", "q", false, "Suppress progress output.")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/aggregator"
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/orgscan"
+)
+
+// Code host tokens are read from the environment by default, so they stay
+// out of shell history; --token overrides them.
+var (
+	githubTokenEnvVars    = []string{"STACK_ANALYZER_GITHUB_TOKEN", "GITHUB_TOKEN"}
+	gitlabTokenEnvVars    = []string{"STACK_ANALYZER_GITLAB_TOKEN", "GITLAB_TOKEN"}
+	bitbucketTokenEnvVars = []string{"STACK_ANALYZER_BITBUCKET_TOKEN", "BITBUCKET_TOKEN"}
+)
+
+// bitbucketUserEnvVar holds the Bitbucket user name for app passwords.
+const bitbucketUserEnvVar = "STACK_ANALYZER_BITBUCKET_USER"
+
+var (
+	scanOrgGitHub          string
+	scanOrgGitLab          string
+	scanOrgBitbucket       string
+	scanOrgToken           string
+	scanOrgAPIURL          string
+	scanOrgIncludeArchived bool
+	scanOrgIncludeForks    bool
+	scanOrgLanguages       []string
+	scanOrgTopics          []string
+	scanOrgConcurrency     int
+	scanOrgWorkDir         string
+	scanOrgOutput          string
+	scanOrgAggregate       string
+	scanOrgExclude         []string
+	scanOrgConfig          string
+	scanOrgNoCodeStats     bool
+	scanOrgPretty          bool
+	scanOrgQuiet           bool
+)
+
+var scanOrgCmd = &cobra.Command{
+	Use:   "scan-org",
+	Short: "Scan every repository of a GitHub organization, GitLab group or Bitbucket workspace into a portfolio",
+	Long: `Scan-org lists the repositories of a GitHub organization (or user), a GitLab
+group (with subgroups) or a Bitbucket workspace, shallow clones each one, scans
+the clones concurrently and writes a portfolio: one result per repository plus
+the repositories using each technology.
+
+Archived repositories and forks are skipped unless included. --language and
+--topic keep only repositories with one of the given primary languages or
+topics.
+
+Self-hosted instances are selected with --api-url: GitHub Enterprise Server
+(https://<host>/api/v3), GitLab self-managed (https://<host>/api/v4) or
+Bitbucket Server / Data Center (https://<host>, where --bitbucket is the
+project key).
+
+The token is read from STACK_ANALYZER_GITHUB_TOKEN / GITHUB_TOKEN,
+STACK_ANALYZER_GITLAB_TOKEN / GITLAB_TOKEN or STACK_ANALYZER_BITBUCKET_TOKEN /
+BITBUCKET_TOKEN; --token overrides it (but ends up in shell history). For a
+Bitbucket app password, set the user name in STACK_ANALYZER_BITBUCKET_USER.
+Without a token only public repositories are listed.
+
+Clones go to a temporary directory and are removed after scanning. With
+--workdir they are kept there and updated by later runs.
+
+Examples:
+  stack-analyzer scan-org --github myorg
+  stack-analyzer scan-org --github myorg --language go --language python --concurrency 8
+  stack-analyzer scan-org --github myorg --topic platform --aggregate techs,languages -o portfolio.json
+  stack-analyzer scan-org --github myorg --api-url https://github.example.com/api/v3 --workdir /var/cache/myorg
+  stack-analyzer scan-org --gitlab myorg/platform --api-url https://gitlab.example.com/api/v4
+  stack-analyzer scan-org --bitbucket myworkspace`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runScanOrg()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanOrgCmd)
+	scanOrgCmd.Flags().StringVar(&scanOrgGitHub, "github", "", "GitHub organization or user to scan")
+	scanOrgCmd.Flags().StringVar(&scanOrgGitLab, "gitlab", "", "GitLab group (including subgroups) or user to scan")
+	scanOrgCmd.Flags().StringVar(&scanOrgBitbucket, "bitbucket", "", "Bitbucket Cloud workspace, or project key on Bitbucket Server, to scan")
+	scanOrgCmd.Flags().StringVar(&scanOrgToken, "token", "", "API token (default: the code host's token environment variable)")
+	scanOrgCmd.Flags().StringVar(&scanOrgAPIURL, "api-url", "", "API base URL of a self-hosted instance (e.g. https://github.example.com/api/v3, https://gitlab.example.com/api/v4, https://bitbucket.example.com)")
+	scanOrgCmd.Flags().BoolVar(&scanOrgIncludeArchived, "include-archived", false, "Also scan archived repositories")
+	scanOrgCmd.Flags().BoolVar(&scanOrgIncludeForks, "include-forks", false, "Also scan forks")
+	scanOrgCmd.Flags().StringSliceVar(&scanOrgLanguages, "language", nil, "Only scan repositories with this primary language (repeatable)")
+	scanOrgCmd.Flags().StringSliceVar(&scanOrgTopics, "topic", nil, "Only scan repositories with this topic (repeatable)")
+	scanOrgCmd.Flags().IntVar(&scanOrgConcurrency, "concurrency", 4, "Repositories cloned and scanned at once")
+	scanOrgCmd.Flags().StringVar(&scanOrgWorkDir, "workdir", "", "Keep clones in this directory and update them on later runs (default: temporary, removed after scanning)")
+	scanOrgCmd.Flags().StringVarP(&scanOrgOutput, "output", "o", "portfolio.json", "Output file path ('-' for stdout)")
+	scanOrgCmd.Flags().StringVar(&scanOrgAggregate, "aggregate", "", "Aggregate each repository's result: tech,techs,languages,licenses,dependencies,git,all (default: full scan tree)")
+	scanOrgCmd.Flags().StringSliceVar(&scanOrgExclude, "exclude", nil, "Patterns to exclude in every repository (same semantics as scan)")
+	scanOrgCmd.Flags().StringVar(&scanOrgConfig, "config", "", "Scan configuration file path or inline JSON, applied to every repository")
+	scanOrgCmd.Flags().BoolVar(&scanOrgNoCodeStats, "no-code-stats", false, "Disable code statistics")
+	scanOrgCmd.Flags().BoolVar(&scanOrgPretty, "pretty", true, "Pretty print JSON output")
+	scanOrgCmd.Flags().BoolVarP(&scanOrgQuiet, "quiet", "q", false, "Suppress progress output")
+	scanOrgCmd.MarkFlagsMutuallyExclusive("github", "gitlab", "bitbucket")
+	scanOrgCmd.MarkFlagsOneRequired("github", "gitlab", "bitbucket")
+	registerCompletions(scanOrgCmd, map[string]completionFunc{"aggregate": completeList(aggregateFieldNames)})
+}
+
+func runScanOrg() error {
+	lister, err := scanOrgLister()
+	if err != nil {
+		return err
+	}
+	s, scanCfg, err := scanOrgSettings()
+	if err != nil {
+		return err
+	}
+	logger := s.ConfigureLogger()
+	configureComponents(s, logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if !scanOrgQuiet {
+		fmt.Fprintf(os.Stderr, "Listing repositories of %s on %s\n", lister.Organization(), lister.Provider())
+	}
+	portfolio, err := orgscan.Run(ctx, lister, orgscan.Filter{
+		IncludeArchived: scanOrgIncludeArchived,
+		IncludeForks:    scanOrgIncludeForks,
+		Languages:       scanOrgLanguages,
+		Topics:          scanOrgTopics,
+	}, orgscan.Options{
+		Concurrency: scanOrgConcurrency,
+		WorkDir:     scanOrgWorkDir,
+		OnDone:      scanOrgProgress,
+	}, func(_ orgscan.Repository, dir string) (*orgscan.ScanOutput, error) {
+		return scanOrgRepository(dir, s, scanCfg, logger)
+	})
+	if err != nil {
+		return err
+	}
+	return writeScanOrgOutput(portfolio)
+}
+
+// scanOrgLister returns the lister for the selected code host.
+func scanOrgLister() (orgscan.Lister, error) {
+	switch {
+	case scanOrgGitHub != "":
+		return orgscan.NewGitHubLister(scanOrgGitHub, scanOrgTokenValue(githubTokenEnvVars), scanOrgAPIURL), nil
+	case scanOrgGitLab != "":
+		l := orgscan.NewGitLabLister(scanOrgGitLab, scanOrgTokenValue(gitlabTokenEnvVars), scanOrgAPIURL)
+		// GitLab only reports languages per project; look them up when filtering.
+		l.FetchLanguages = len(scanOrgLanguages) > 0
+		return l, nil
+	case scanOrgBitbucket != "":
+		user := strings.TrimSpace(os.Getenv(bitbucketUserEnvVar))
+		return orgscan.NewBitbucketLister(scanOrgBitbucket, user, scanOrgTokenValue(bitbucketTokenEnvVars), scanOrgAPIURL), nil
+	}
+	return nil, errors.New("specify the organization to scan with --github, --gitlab or --bitbucket")
+}
+
+// scanOrgTokenValue returns --token, else the first of the environment
+// variables that is set.
+func scanOrgTokenValue(envVars []string) string {
+	if scanOrgToken != "" {
+		return scanOrgToken
+	}
+	for _, name := range envVars {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// scanOrgSettings builds the settings shared by the scans of all
+// repositories: the environment, the flags, then the scan configuration.
+func scanOrgSettings() (*config.Settings, *config.ScanConfigFile, error) {
+	s := config.LoadSettingsFromEnvironment()
+	s.ExcludePatterns = append(s.ExcludePatterns, scanOrgExclude...)
+	s.NoCodeStats = s.NoCodeStats || scanOrgNoCodeStats
+	if scanOrgAggregate != "" {
+		s.Aggregate = scanOrgAggregate
+	}
+	if scanOrgConfig == "" {
+		return s, nil, validateSettings(s)
+	}
+	scanCfg, err := config.LoadScanConfig(scanOrgConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load scan configuration: %w", err)
+	}
+	scanCfg.MergeWithSettings(s)
+	return s, scanCfg, validateSettings(s)
+}
+
+// scanOrgRepository scans one clone and returns its output, unindented as
+// the portfolio is formatted as a whole.
+func scanOrgRepository(dir string, s *config.Settings, scanCfg *config.ScanConfigFile, logger *slog.Logger) (*orgscan.ScanOutput, error) {
+	payload, err := scanDirectory(dir, nil, s, scanCfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	data, err := generateOutput(payload, s.Aggregate, false, s.OmitFields)
+	if err != nil {
+		return nil, err
+	}
+	return &orgscan.ScanOutput{
+		Result:       data,
+		Techs:        aggregator.NewAggregator([]string{"techs"}).Aggregate(payload).Techs,
+		PrimaryTechs: payload.PrimaryTechs,
+	}, nil
+}
+
+func scanOrgProgress(done, total int, r *orgscan.RepoResult) {
+	if scanOrgQuiet {
+		return
+	}
+	elapsed := (time.Duration(r.DurationMS) * time.Millisecond).Truncate(100 * time.Millisecond)
+	if r.Status != "ok" {
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s failed after %s: %s\n", done, total, r.FullName, elapsed, r.Error)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[%d/%d] %s scanned in %s\n", done, total, r.FullName, elapsed)
+}
+
+func writeScanOrgOutput(p *orgscan.Portfolio) error {
+	data, err := marshalJSON(p, scanOrgPretty)
+	if err != nil {
+		return fmt.Errorf("marshal portfolio: %w", err)
+	}
+	if scanOrgOutput == "" || scanOrgOutput == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(scanOrgOutput, data, 0644); err != nil {
+		return fmt.Errorf("write portfolio: %w", err)
+	}
+	if !scanOrgQuiet {
+		fmt.Fprintf(os.Stderr, "Scanned %d of %d repositories (%d skipped, %d failed); portfolio written to %s\n",
+			p.Summary.Scanned, p.Summary.Listed, p.Summary.Skipped, p.Summary.Failed, scanOrgOutput)
+	}
+	return nil
+}
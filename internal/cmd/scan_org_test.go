@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/orgscan"
+)
+
+// TestScanOrgRepositoriesConcurrently scans several clones at once with
+// shared settings, as scan-org does.
+func TestScanOrgRepositoriesConcurrently(t *testing.T) {
+	manifests := map[string]string{
+		"api": `{"name":"api","dependencies":{"express":"^4.18.0"}}`,
+		"web": `{"name":"web","dependencies":{"react":"^18.2.0"}}`,
+	}
+	s := config.DefaultSettings()
+	s.Aggregate = "techs"
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	outputs := make(map[string]*orgscan.ScanOutput)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, manifest := range manifests {
+		dir := filepath.Join(t.TempDir(), name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(manifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := scanOrgRepository(dir, s, nil, logger)
+			if err != nil {
+				t.Errorf("scan %s: %v", name, err)
+				return
+			}
+			mu.Lock()
+			outputs[name] = out
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for name, tech := range map[string]string{"api": "express", "web": "react"} {
+		out := outputs[name]
+		if out == nil {
+			t.Fatalf("no output for %s", name)
+		}
+		if !slices.Contains(out.Techs, tech) {
+			t.Errorf("%s techs = %v, want %s", name, out.Techs, tech)
+		}
+		var agg struct {
+			Techs []string `json:"techs"`
+		}
+		if err := json.Unmarshal(out.Result, &agg); err != nil || !slices.Contains(agg.Techs, tech) {
+			t.Errorf("%s result is not the techs aggregate: %s", name, out.Result)
+		}
+	}
+}
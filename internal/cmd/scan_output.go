@@ -22,12 +22,19 @@ func generateAndWriteOutput(payload interface{}, logger *slog.Logger) {
 		"also_sbom", settings.AlsoSBOM,
 		"pretty_print", settings.PrettyPrint)
 
+	// Several --output targets (or one with a format) share this payload.
+	if len(settings.Outputs) > 0 {
+		writeOutputTargets(payload, logger)
+		writeCompanionOutputs(payload, logger)
+		return
+	}
+
 	// --sbom makes the CycloneDX SBOM the primary output instead of the scan tree.
 	if settings.SBOM {
-		sbomData, err := generateSBOM(payload, settings.PrettyPrint)
+		sbomData, err := generateSBOM(payload, settings.SBOMFormat, settings.PrettyPrint)
 		if err != nil {
 			logger.Error("Failed to marshal SBOM", "error", err)
-			os.Exit(1)
+			failScan()
 		}
 		writeOutput(sbomData)
 		return
@@ -36,11 +43,17 @@ func generateAndWriteOutput(payload interface{}, logger *slog.Logger) {
 	jsonData, err := generateOutput(payload, settings.Aggregate, settings.PrettyPrint, settings.OmitFields)
 	if err != nil {
 		logger.Error("Failed to marshal JSON", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 
 	writeOutput(jsonData)
+	writeCompanionOutputs(payload, logger)
+}
 
+// writeCompanionOutputs writes the files derived from the primary output
+// filename: the --also-sbom SBOM, the --resolve-currency report and the
+// --also-aggregate output.
+func writeCompanionOutputs(payload interface{}, logger *slog.Logger) {
 	// Produce a CycloneDX SBOM companion file when --also-sbom is set.
 	if settings.AlsoSBOM {
 		writeAlsoSBOM(payload, logger)
@@ -64,10 +77,10 @@ func generateAndWriteOutput(payload interface{}, logger *slog.Logger) {
 
 func writeAlsoSBOM(payload interface{}, logger *slog.Logger) {
 	sbomFile := sbomOutputFile(settings.OutputFile)
-	sbomData, err := generateSBOM(payload, settings.PrettyPrint)
+	sbomData, err := generateSBOM(payload, settings.SBOMFormat, settings.PrettyPrint)
 	if err != nil {
 		logger.Error("Failed to marshal SBOM", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 	if sbomFile == "" {
 		logger.Debug("Skipping SBOM output: primary output is stdout")
@@ -75,7 +88,7 @@ func writeAlsoSBOM(payload interface{}, logger *slog.Logger) {
 	}
 	if err = os.WriteFile(sbomFile, sbomData, 0644); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to write SBOM output file: %v\n", err)
-		os.Exit(1)
+		failScan()
 	}
 	if !settings.Quiet {
 		fmt.Fprintf(os.Stderr, "SBOM written to %s\n", sbomFile)
@@ -87,7 +100,7 @@ func writeAlsoAggregate(payload interface{}, logger *slog.Logger) {
 	aggData, err := generateOutput(payload, settings.AlsoAggregate, settings.PrettyPrint, nil)
 	if err != nil {
 		logger.Error("Failed to marshal aggregate JSON", "error", err)
-		os.Exit(1)
+		failScan()
 	}
 	if aggFile == "" {
 		// stdout mode — two JSON blobs cannot be written to stdout.
@@ -96,7 +109,7 @@ func writeAlsoAggregate(payload interface{}, logger *slog.Logger) {
 	}
 	if err = os.WriteFile(aggFile, aggData, 0644); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to write aggregate output file: %v\n", err)
-		os.Exit(1)
+		failScan()
 	}
 	if !settings.Quiet {
 		fmt.Fprintf(os.Stderr, "Aggregate results written to %s\n", aggFile)
@@ -175,14 +188,14 @@ func sbomFileInfix(format string) string {
 	return "cdx"
 }
 
-// generateSBOM builds an SBOM from the payload in the configured format and
+// generateSBOM builds an SBOM from the payload in the given format and
 // marshals it. Defaults to CycloneDX when no format is set.
-func generateSBOM(payload interface{}, prettyPrint bool) ([]byte, error) {
+func generateSBOM(payload interface{}, format string, prettyPrint bool) ([]byte, error) {
 	p, ok := payload.(*types.Payload)
 	if !ok {
 		return nil, fmt.Errorf("SBOM output requires a scan payload")
 	}
-	if strings.EqualFold(settings.SBOMFormat, "spdx") {
+	if strings.EqualFold(format, "spdx") {
 		doc := sbom.SPDXFromPayload(p)
 		sbom.SPDXStamp(doc) // per-emission documentNamespace + timestamp
 		return marshalJSON(doc, prettyPrint)
@@ -192,40 +205,31 @@ func generateSBOM(payload interface{}, prettyPrint bool) ([]byte, error) {
 	return marshalJSON(bom, prettyPrint)
 }
 
+// omittableFields clears each field --omit-fields accepts on one component.
+var omittableFields = map[string]func(p *types.Payload){
+	"reason":            func(p *types.Payload) { p.Reason, p.ReasonOverflow = nil, nil },
+	"confidence":        func(p *types.Payload) { p.Confidence = nil },
+	"component_type":    func(p *types.Payload) { p.Kind = "" },
+	"path":              func(p *types.Payload) { p.Path = nil },
+	"edges":             func(p *types.Payload) { p.Edges = nil },
+	"licenses":          func(p *types.Payload) { p.Licenses = nil },
+	"dependencies":      func(p *types.Payload) { p.Dependencies = nil },
+	"component_refs":    func(p *types.Payload) { p.ComponentRefs = nil },
+	"properties":        func(p *types.Payload) { p.Properties = nil },
+	"code_stats":        func(p *types.Payload) { p.CodeStats = nil },
+	"primary_languages": func(p *types.Payload) { p.PrimaryLanguages = nil },
+	"primary_techs":     func(p *types.Payload) { p.PrimaryTechs = nil },
+}
+
 // stripFields recursively removes the specified fields from a payload tree.
 func stripFields(p *types.Payload, fields map[string]bool) {
 	if p == nil {
 		return
 	}
-	if fields["reason"] {
-		p.Reason = nil
-	}
-	if fields["path"] {
-		p.Path = nil
-	}
-	if fields["edges"] {
-		p.Edges = nil
-	}
-	if fields["licenses"] {
-		p.Licenses = nil
-	}
-	if fields["dependencies"] {
-		p.Dependencies = nil
-	}
-	if fields["component_refs"] {
-		p.ComponentRefs = nil
-	}
-	if fields["properties"] {
-		p.Properties = nil
-	}
-	if fields["code_stats"] {
-		p.CodeStats = nil
-	}
-	if fields["primary_languages"] {
-		p.PrimaryLanguages = nil
-	}
-	if fields["primary_techs"] {
-		p.PrimaryTechs = nil
+	for field, strip := range omittableFields {
+		if fields[field] {
+			strip(p)
+		}
 	}
 	for _, child := range p.Children {
 		stripFields(child, fields)
@@ -297,13 +301,18 @@ func generateOutput(payload interface{}, aggregateFields string, prettyPrint boo
 
 // writeOutput writes JSON data to the configured output file or stdout.
 func writeOutput(jsonData []byte) {
-	if settings.OutputFile != "" {
-		if err := os.WriteFile(settings.OutputFile, jsonData, 0644); err != nil {
+	writeOutputTo(settings.OutputFile, jsonData)
+}
+
+// writeOutputTo writes output data to a file, or to stdout when path is empty.
+func writeOutputTo(path string, data []byte) {
+	if path != "" {
+		if err := os.WriteFile(path, data, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to write output file: %v\n", err)
-			os.Exit(1)
+			failScan()
 		}
-		fmt.Fprintf(os.Stderr, "Results written to %s\n", settings.OutputFile)
+		fmt.Fprintf(os.Stderr, "Results written to %s\n", path)
 	} else {
-		fmt.Println(string(jsonData))
+		fmt.Println(string(data))
 	}
 }
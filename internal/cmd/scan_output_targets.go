@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Formats of the outputs --output writes, given as path:format.
+const (
+	outputFormatJSON      = "json"      // the scan output (full or --aggregate)
+	outputFormatCycloneDX = "cyclonedx" // CycloneDX 1.7 SBOM
+	outputFormatSPDX      = "spdx"      // SPDX 2.3 SBOM
+	outputFormatText      = "text"      // the summary report
+	outputFormatMarkdown  = "markdown"  // the summary report in Markdown
+)
+
+// outputFormatNames maps the format suffixes --output accepts to formats.
+var outputFormatNames = map[string]string{
+	"json":      outputFormatJSON,
+	"cyclonedx": outputFormatCycloneDX,
+	"cdx":       outputFormatCycloneDX,
+	"spdx":      outputFormatSPDX,
+	"text":      outputFormatText,
+	"txt":       outputFormatText,
+	"markdown":  outputFormatMarkdown,
+	"md":        outputFormatMarkdown,
+}
+
+// outputFormatOrder is the order the formats are rendered in. The scan JSON
+// comes last because --omit-fields strips the fields from the payload itself,
+// and the SBOM and the summary still need them.
+var outputFormatOrder = []string{
+	outputFormatCycloneDX, outputFormatSPDX, outputFormatText, outputFormatMarkdown, outputFormatJSON,
+}
+
+// formatSuffix matches what could be meant as a format after the last colon
+// of an --output value, as opposed to the rest of a path (C:\out.json).
+var formatSuffix = regexp.MustCompile(`^[A-Za-z]+$`)
+
+// outputTarget is one output of a scan.
+type outputTarget struct {
+	Path     string // empty for stdout
+	Format   string
+	explicit bool // the format was given as a suffix
+}
+
+// parseOutputTarget parses an --output value, "path" or "path:format". A path
+// without a format gets the primary output's format: the scan JSON, or the
+// SBOM with --sbom.
+func parseOutputTarget(spec string) (outputTarget, error) {
+	target := outputTarget{Path: spec, Format: defaultOutputFormat()}
+	if i := strings.LastIndex(spec, ":"); i >= 0 && formatSuffix.MatchString(spec[i+1:]) {
+		format, ok := outputFormatNames[strings.ToLower(spec[i+1:])]
+		if !ok {
+			return target, fmt.Errorf("unknown output format %q in %q. Valid formats: json, cyclonedx, spdx, text, markdown", spec[i+1:], spec)
+		}
+		target.Path, target.Format, target.explicit = spec[:i], format, true
+	}
+	switch target.Path {
+	case "":
+		return target, fmt.Errorf("output %q has no path (use '-' for stdout)", spec)
+	case "-":
+		target.Path = ""
+	}
+	return target, nil
+}
+
+func defaultOutputFormat() string {
+	if !settings.SBOM {
+		return outputFormatJSON
+	}
+	if strings.EqualFold(settings.SBOMFormat, "spdx") {
+		return outputFormatSPDX
+	}
+	return outputFormatCycloneDX
+}
+
+// parseOutputTargets parses the --output values; at most one of them can go
+// to stdout.
+func parseOutputTargets(specs []string) ([]outputTarget, error) {
+	targets := make([]outputTarget, 0, len(specs))
+	stdout := 0
+	for _, spec := range specs {
+		target, err := parseOutputTarget(spec)
+		if err != nil {
+			return nil, err
+		}
+		if target.Path == "" {
+			stdout++
+		}
+		targets = append(targets, target)
+	}
+	if stdout > 1 {
+		return nil, fmt.Errorf("only one output can be written to stdout ('-')")
+	}
+	return targets, nil
+}
+
+// resolveOutputs applies the --output values. A single one without a format
+// is the primary output file, as before outputs could be repeated. Otherwise
+// generateAndWriteOutput writes every target, and the first scan JSON file
+// among them becomes the primary output the companion files (-agg, .cdx,
+// .currency) are named after.
+func resolveOutputs() error {
+	if len(settings.Outputs) == 0 {
+		return nil
+	}
+	targets, err := parseOutputTargets(settings.Outputs)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 1 && !targets[0].explicit {
+		settings.OutputFile = settings.Outputs[0]
+		settings.Outputs = nil
+		return nil
+	}
+	settings.OutputFile = ""
+	for _, target := range targets {
+		if target.Format == outputFormatJSON && target.Path != "" {
+			settings.OutputFile = target.Path
+			break
+		}
+	}
+	return nil
+}
+
+// validateOutputs resolves the --output targets of the scan command, failing
+// the scan on an invalid one.
+func validateOutputs(logger *slog.Logger) {
+	if err := resolveOutputs(); err != nil {
+		logger.Error("Invalid output", "error", err)
+		failScan()
+	}
+}
+
+// writeOutputTargets renders each requested format once and writes it to
+// every target asking for it.
+func writeOutputTargets(payload interface{}, logger *slog.Logger) {
+	targets, err := parseOutputTargets(settings.Outputs)
+	if err != nil {
+		logger.Error("Invalid output", "error", err)
+		failScan()
+	}
+	for _, format := range outputFormatOrder {
+		var data []byte
+		for _, target := range targets {
+			if target.Format != format {
+				continue
+			}
+			if data == nil {
+				data = renderOutputFormat(payload, format, logger)
+			}
+			writeOutputTo(target.Path, data)
+		}
+	}
+}
+
+func renderOutputFormat(payload interface{}, format string, logger *slog.Logger) []byte {
+	data, err := renderOutput(payload, format)
+	if err != nil {
+		logger.Error("Failed to render output", "format", format, "error", err)
+		failScan()
+	}
+	return data
+}
+
+// renderOutput renders the payload in one output format.
+func renderOutput(payload interface{}, format string) ([]byte, error) {
+	switch format {
+	case outputFormatCycloneDX, outputFormatSPDX:
+		return generateSBOM(payload, format, settings.PrettyPrint)
+	case outputFormatText, outputFormatMarkdown:
+		p, ok := payload.(*types.Payload)
+		if !ok {
+			return nil, fmt.Errorf("summary output requires a scan payload")
+		}
+		var buf bytes.Buffer
+		if format == outputFormatMarkdown {
+			writeMarkdownSummary(&buf, p)
+		} else {
+			printSummary(&buf, p)
+		}
+		return buf.Bytes(), nil
+	}
+	return generateOutput(payload, settings.Aggregate, settings.PrettyPrint, settings.OmitFields)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestParseOutputTarget(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantPath   string
+		wantFormat string
+		wantErr    bool
+	}{
+		{"result.json", "result.json", outputFormatJSON, false},
+		{"sbom.cdx.json:cyclonedx", "sbom.cdx.json", outputFormatCycloneDX, false},
+		{"sbom.json:CDX", "sbom.json", outputFormatCycloneDX, false},
+		{"summary.md:markdown", "summary.md", outputFormatMarkdown, false},
+		{"-:text", "", outputFormatText, false},
+		{"-", "", outputFormatJSON, false},
+		{`C:\out\result.json`, `C:\out\result.json`, outputFormatJSON, false},
+		{"result.json:xml", "", "", true},
+		{":json", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseOutputTarget(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseOutputTarget(%q) = %+v, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOutputTarget(%q): %v", tt.spec, err)
+			}
+			if got.Path != tt.wantPath || got.Format != tt.wantFormat {
+				t.Errorf("parseOutputTarget(%q) = %q, %q; want %q, %q", tt.spec, got.Path, got.Format, tt.wantPath, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestParseOutputTargetsRejectsTwoStdout(t *testing.T) {
+	if _, err := parseOutputTargets([]string{"-", "-:markdown"}); err == nil {
+		t.Fatal("expected an error for two stdout outputs")
+	}
+}
+
+func TestResolveOutputs(t *testing.T) {
+	savedFile, savedOutputs := settings.OutputFile, settings.Outputs
+	defer func() { settings.OutputFile, settings.Outputs = savedFile, savedOutputs }()
+
+	// A single output without a format stays the primary output file.
+	settings.Outputs = []string{"result.json"}
+	if err := resolveOutputs(); err != nil {
+		t.Fatal(err)
+	}
+	if settings.OutputFile != "result.json" || settings.Outputs != nil {
+		t.Errorf("single output: OutputFile=%q Outputs=%v", settings.OutputFile, settings.Outputs)
+	}
+
+	// Several outputs: the first scan JSON file names the companion files.
+	settings.Outputs = []string{"sbom.cdx.json:cyclonedx", "-", "result.json", "copy.json"}
+	if err := resolveOutputs(); err != nil {
+		t.Fatal(err)
+	}
+	if settings.OutputFile != "result.json" || len(settings.Outputs) != 4 {
+		t.Errorf("several outputs: OutputFile=%q Outputs=%v", settings.OutputFile, settings.Outputs)
+	}
+
+	// No scan JSON file among them: no primary output file.
+	settings.Outputs = []string{"summary.md:md"}
+	if err := resolveOutputs(); err != nil {
+		t.Fatal(err)
+	}
+	if settings.OutputFile != "" {
+		t.Errorf("summary only: OutputFile=%q, want empty", settings.OutputFile)
+	}
+}
+
+func TestRenderOutputMarkdownSummary(t *testing.T) {
+	p := types.NewPayload("main", []string{"/"})
+	p.Techs = []string{"nodejs", "postgresql"}
+	p.PrimaryTechs = []string{"nodejs"}
+	child := types.NewPayload("api", []string{"/services/api/package.json"})
+	child.SourceDir = "/services/api"
+	p.Children = []*types.Payload{child}
+
+	data, err := renderOutput(p, outputFormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"# Codebase Summary",
+		"## Technologies",
+		"- **Also:** Postgres",
+		"| Directory | Components |",
+		"| services | 1 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown summary lacks %q:\n%s", want, out)
+		}
+	}
+}
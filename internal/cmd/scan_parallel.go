@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/progress"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// pathScan is the outcome of scanning one path of a parallel multi-path scan.
+type pathScan struct {
+	path     string
+	scanner  *scanner.Scanner
+	payload  *types.Payload
+	duration time.Duration
+	err      error
+}
+
+// scanPathsInParallel scans each path with its own scanner, at most
+// settings.Parallel at a time, and merges the results into one project.
+// Progress lines of the scans are prefixed with their path. The scanners
+// share the code statistics analyzer, which locks its counts, so code_stats
+// cover all paths; each resolves the dependency graphs queued by its own walk.
+func scanPathsInParallel(commonParent string, relPaths []string, rootID string, mergedConfig *config.ScanConfig, codeStatsAnalyzer codestats.Analyzer, logger *slog.Logger) (*scanner.Scanner, *types.Payload) {
+	start := time.Now()
+	mux := progress.NewMultiplexer(os.Stderr)
+	scans := make([]pathScan, len(relPaths))
+	for i, rel := range relPaths {
+		s := newMultiPathScanner(commonParent, []string{rel}, rootID, mergedConfig, codeStatsAnalyzer, logger)
+		if !settings.Quiet {
+			s.SetProgressHandler(parallelProgressHandler(mux.Writer(rel)))
+		}
+		scans[i] = pathScan{path: rel, scanner: s}
+	}
+
+	slots := make(chan struct{}, settings.Parallel)
+	var wg sync.WaitGroup
+	for i := range scans {
+		wg.Add(1)
+		go func(scan *pathScan) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			t := time.Now()
+			scan.payload, scan.err = scan.scanner.Scan()
+			scan.duration = time.Since(t)
+		}(&scans[i])
+	}
+	wg.Wait()
+
+	results := make([]*types.Payload, 0, len(scans))
+	for _, scan := range scans {
+		if scan.err != nil {
+			failScanOn(logger, "Failed to scan", scan.err, "path", scan.path)
+		}
+		results = append(results, scan.payload)
+	}
+
+	elapsed := time.Since(start)
+	if !settings.Quiet {
+		writeParallelSummary(os.Stderr, scans, elapsed)
+	}
+	first := scans[0].scanner
+	return first, first.MergeScans(results, elapsed)
+}
+
+// parallelProgressHandler picks the progress handler for one scan of a
+// parallel run, as the scanner would for a single scan. The summary line is
+// not redrawn in place, since several scans share the terminal.
+func parallelProgressHandler(w io.Writer) progress.Handler {
+	switch {
+	case settings.Debug:
+		return progress.NewTreeHandler(w)
+	case settings.Verbose:
+		return progress.NewSimpleHandler(w)
+	default:
+		return progress.NewSummaryHandler(w, false)
+	}
+}
+
+// writeParallelSummary prints the files, components and duration of each
+// path, and the totals against the wall-clock time of the run. Must be
+// called before the results are merged, while each root still carries its
+// own metadata.
+func writeParallelSummary(w io.Writer, scans []pathScan, elapsed time.Duration) {
+	width := 0
+	for _, scan := range scans {
+		width = max(width, len(scan.path))
+	}
+	var files, comps int
+	var busy time.Duration
+	for _, scan := range scans {
+		f, c := scanCounts(scan.payload)
+		files += f
+		comps += c
+		busy += scan.duration
+		fmt.Fprintf(w, "  %-*s  %7d files  %5d components  %s\n", width, scan.path, f, c, scan.duration.Truncate(100*time.Millisecond))
+	}
+	fmt.Fprintf(w, "Scanned %d paths: %d files, %d components in %s (%s of scanning, up to %d in parallel)\n",
+		len(scans), files, comps, elapsed.Truncate(100*time.Millisecond), busy.Truncate(100*time.Millisecond), settings.Parallel)
+}
+
+// scanCounts returns the files and components of one path's scan. The root
+// component is shared by all paths and not counted.
+func scanCounts(payload *types.Payload) (files, comps int) {
+	if meta, ok := payload.Metadata.(*metadata.ScanMetadata); ok {
+		return meta.FileCount, max(meta.ComponentCount-1, 0)
+	}
+	return 0, 0
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// TestScanPathsInParallel scans two paths at once with the dependency graph
+// on, so each scanner resolves the graphs queued during its own walk; run it
+// with -race.
+func TestScanPathsInParallel(t *testing.T) {
+	saved := *settings
+	settings.Parallel, settings.Quiet = 2, true
+	components.SetDependencyGraphMode(types.DependencyGraphFull)
+	components.SetUseDepsDev(false)
+	defer func() {
+		*settings = saved
+		components.SetDependencyGraphMode(types.DependencyGraphOff)
+	}()
+
+	dir := t.TempDir()
+	for name, dep := range map[string]string{"api": "express", "web": "react"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		manifest := `{"name":"` + name + `","dependencies":{"` + dep + `":"1.0.0"}}`
+		lock := `{"name":"` + name + `","lockfileVersion":3,"packages":{"":{"name":"` + name + `","dependencies":{"` + dep + `":"1.0.0"}},` +
+			`"node_modules/` + dep + `":{"version":"1.0.0","dependencies":{"ms":"2.1.3"}},"node_modules/ms":{"version":"2.1.3"}}}`
+		for file, content := range map[string]string{"package.json": manifest, "package-lock.json": lock} {
+			if err := os.WriteFile(filepath.Join(dir, name, file), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	_, payload := scanPathsInParallel(dir, []string{"api", "web"}, "", nil, buildCodeStatsAnalyzer(settings), logger)
+
+	graphs := map[string]int{}
+	for _, child := range payload.Children {
+		graphs[child.Name] = len(child.DependencyEdges)
+	}
+	if len(graphs) != 2 || graphs["api"] == 0 || graphs["web"] == 0 {
+		t.Errorf("expected both paths with their dependency graph, got %v", graphs)
+	}
+}
+
+func TestWriteParallelSummary(t *testing.T) {
+	saved := settings.Parallel
+	settings.Parallel = 4
+	defer func() { settings.Parallel = saved }()
+
+	scan := func(path string, files, comps int, d time.Duration) pathScan {
+		payload := types.NewPayloadWithPath("main", "/")
+		meta := &metadata.ScanMetadata{}
+		meta.SetFileCounts(files, comps)
+		payload.Metadata = meta
+		return pathScan{path: path, payload: payload, duration: d}
+	}
+	var buf bytes.Buffer
+	writeParallelSummary(&buf, []pathScan{
+		scan("api", 120, 4, 2*time.Second),
+		scan("frontend", 80, 3, 3*time.Second),
+	}, 3*time.Second)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected two path lines and a total, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "  api       ") || !strings.Contains(lines[0], "120 files") {
+		t.Errorf("unexpected path line %q", lines[0])
+	}
+	expected := "Scanned 2 paths: 200 files, 5 components in 3s (5s of scanning, up to 4 in parallel)"
+	if lines[2] != expected {
+		t.Errorf("got %q, want %q", lines[2], expected)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -54,6 +55,7 @@ func init() {
 	summaryCmd.Flags().BoolVarP(&settings.Verbose, "verbose", "v", false, "Show scan progress with simple output")
 	summaryCmd.Flags().BoolVarP(&settings.Debug, "debug", "d", false, "Show scan progress with tree structure")
 	summaryCmd.Flags().StringSliceVar(&settings.ExcludePatterns, "exclude", settings.ExcludePatterns, "Patterns to exclude (supports glob patterns)")
+	summaryCmd.Flags().StringVar(&settings.RulesDir, "rules-dir", settings.RulesDir, "Directory of custom YAML rules loaded on top of the embedded rules")
 	summaryCmd.Flags().BoolVar(&settings.NoCodeStats, "no-code-stats", settings.NoCodeStats, "Disable code statistics")
 	summaryCmd.Flags().IntVar(&settings.ComponentStatsDepth, "component-stats-depth", 0, "Include code_stats on components up to this tree depth")
 	summaryCmd.Flags().IntVar(&settings.SubsystemDepth, "subsystem-depth", 0, "Produce subsystem_stats per depth-N path prefix")
@@ -61,6 +63,7 @@ func init() {
 	summaryCmd.Flags().String("log-level", settings.LogLevel.String(), "Log level: trace, debug, error, fatal")
 	summaryCmd.Flags().String("log-format", settings.LogFormat, "Log format: text or json")
 	summaryCmd.Flags().String("log-file", settings.LogFile, "Log file path")
+	registerCompletions(summaryCmd, logFlagCompletions)
 }
 
 func runSummary(cmd *cobra.Command, args []string) {
@@ -84,7 +87,7 @@ func runSummary(cmd *cobra.Command, args []string) {
 	}
 
 	if p, ok := payload.(*types.Payload); ok {
-		printSummary(p)
+		printSummary(os.Stdout, p)
 	}
 }
 
@@ -94,7 +97,7 @@ func runSinglePathSummary(args []string, cmd *cobra.Command, logger *slog.Logger
 	setupScanSettings(logger)
 
 	_, mergedConfig := loadAndMergeProjectConfig(absPath, logger)
-	payload := runScanner(absPath, isFile, mergedConfig, logger, scanner.NewObservationCollector(absPath))
+	payload := runScanner(absPath, isFile, mergedConfig, logger, scanner.NewObservationCollector(absPath), nil)
 
 	enhanceSinglePayload(payload, mergedConfig)
 	if p, ok := payload.(*types.Payload); ok {
@@ -122,6 +125,7 @@ func runMultiPathSummary(args []string, cmd *cobra.Command, logger *slog.Logger)
 	}
 
 	codeStatsAnalyzer := buildCodeStatsAnalyzer(settings)
+	configureComponents(settings, logger)
 	s, err := scanner.NewScannerWithOptionsAndLogger(
 		commonParent, settings.ExcludePatterns,
 		settings.Quiet, settings.Verbose, settings.Debug,
@@ -132,9 +136,11 @@ func runMultiPathSummary(args []string, cmd *cobra.Command, logger *slog.Logger)
 		logger.Error("Failed to create scanner", "error", err)
 		os.Exit(1)
 	}
-	s.SetSubsystemDepth(settings.SubsystemDepth)
-	s.SetSubsystemGroups(settings.SubsystemGroups)
 	s.SetIncludePaths(relPaths)
+	if err := applyScannerSettings(s, settings); err != nil {
+		logger.Error("Invalid detector selection", "error", err)
+		os.Exit(1)
+	}
 	s.SetObservationCollector(scanner.NewObservationCollector(commonParent))
 
 	payload, err := s.Scan()
@@ -151,109 +157,119 @@ func runMultiPathSummary(args []string, cmd *cobra.Command, logger *slog.Logger)
 	return payload
 }
 
-// printSummary renders the payload as a human-readable text report to stdout.
-func printSummary(p *types.Payload) {
+// printSummary renders the payload as a human-readable text report.
+func printSummary(w io.Writer, p *types.Payload) {
 	techNames := loadTechDisplayNames()
 
-	fmt.Println("Codebase Summary")
-	fmt.Println(strings.Repeat("=", 70))
+	fmt.Fprintln(w, "Codebase Summary")
+	fmt.Fprintln(w, strings.Repeat("=", 70))
 
-	printMetadata(p)
-	printCodeStats(p)
-	printLanguages(p)
-	printTechnologies(p, techNames)
-	printStructure(p)
-	printSubsystems(p)
-	printObservations(p)
+	printMetadata(w, p)
+	printCodeStats(w, p)
+	printLanguages(w, p)
+	printTechnologies(w, p, techNames)
+	printStructure(w, p)
+	printSubsystems(w, p)
+	printObservations(w, p)
 }
 
-func printMetadata(p *types.Payload) {
+func printMetadata(w io.Writer, p *types.Payload) {
 	meta, ok := p.Metadata.(*metadata.ScanMetadata)
 	if !ok {
 		return
 	}
-	fmt.Printf("\n  Scan path:      %s\n", meta.ScanPath)
-	fmt.Printf("  Components:     %d\n", meta.ComponentCount)
-	fmt.Printf("  Languages:      %d\n", meta.LanguageCount)
-	fmt.Printf("  Technologies:   %d\n", meta.TechsCount)
+	fmt.Fprintf(w, "\n  Scan path:      %s\n", meta.ScanPath)
+	fmt.Fprintf(w, "  Components:     %d\n", meta.ComponentCount)
+	fmt.Fprintf(w, "  Languages:      %d\n", meta.LanguageCount)
+	fmt.Fprintf(w, "  Technologies:   %d\n", meta.TechsCount)
 	if meta.DurationMs > 0 {
-		fmt.Printf("  Scan time:      %.1fs\n", float64(meta.DurationMs)/1000)
+		fmt.Fprintf(w, "  Scan time:      %.1fs\n", float64(meta.DurationMs)/1000)
 	}
 }
 
-func printCodeStats(p *types.Payload) {
+func printCodeStats(w io.Writer, p *types.Payload) {
 	cs, ok := codeStatsFromPayload(p)
 	if !ok {
 		return
 	}
-	fmt.Println()
-	fmt.Println("Code Statistics")
-	fmt.Println(strings.Repeat("-", 70))
-	fmt.Printf("  %-20s  %10s  %10s  %10s  %10s\n", "Type", "Files", "Code LoC", "Comments", "Blanks")
-	printTypeBucketRow("Programming", cs.ByType.Programming)
-	printTypeBucketRow("Markup", cs.ByType.Markup)
-	printTypeBucketRow("Data", cs.ByType.Data)
-	printTypeBucketRow("Prose", cs.ByType.Prose)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Code Statistics")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+	fmt.Fprintf(w, "  %-20s  %10s  %10s  %10s  %10s\n", "Type", "Files", "Code LoC", "Comments", "Blanks")
+	printTypeBucketRow(w, "Programming", cs.ByType.Programming)
+	printTypeBucketRow(w, "Markup", cs.ByType.Markup)
+	printTypeBucketRow(w, "Data", cs.ByType.Data)
+	printTypeBucketRow(w, "Prose", cs.ByType.Prose)
 	if cs.Unanalyzed.Total.Files > 0 {
-		fmt.Printf("  %-20s  %10s  %10s  (lines only)\n",
+		fmt.Fprintf(w, "  %-20s  %10s  %10s  (lines only)\n",
 			"Other (unanalyzed)", fmtInt(int64(cs.Unanalyzed.Total.Files)), fmtInt(cs.Unanalyzed.Total.Lines))
 	}
-	fmt.Printf("  %-20s  %10s  %10s  %10s  %10s\n",
+	fmt.Fprintf(w, "  %-20s  %10s  %10s  %10s  %10s\n",
 		"Total (analyzed)", fmtInt(int64(cs.Total.Files)), fmtInt(cs.Total.Code), fmtInt(cs.Total.Comments), fmtInt(cs.Total.Blanks))
 }
 
-func printTypeBucketRow(label string, tb *codestats.TypeBucket) {
+func printTypeBucketRow(w io.Writer, label string, tb *codestats.TypeBucket) {
 	if tb == nil || tb.Total.Files == 0 {
 		return
 	}
-	fmt.Printf("  %-20s  %10s  %10s  %10s  %10s\n",
+	fmt.Fprintf(w, "  %-20s  %10s  %10s  %10s  %10s\n",
 		label, fmtInt(int64(tb.Total.Files)), fmtInt(tb.Total.Code), fmtInt(tb.Total.Comments), fmtInt(tb.Total.Blanks))
 }
 
-func printLanguages(p *types.Payload) {
+func printLanguages(w io.Writer, p *types.Payload) {
 	cs, ok := codeStatsFromPayload(p)
 	if !ok {
 		return
 	}
-	fmt.Println()
-	fmt.Println("Languages (top 15 by Code LoC)")
-	fmt.Println(strings.Repeat("-", 70))
-	fmt.Printf("  %-25s  %10s  %10s\n", "Language", "Files", "Code LoC")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Languages (top 15 by Code LoC)")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+	fmt.Fprintf(w, "  %-25s  %10s  %10s\n", "Language", "Files", "Code LoC")
 
 	langs := cs.Analyzed.ByLanguage
 	limit := min(15, len(langs))
 	for i := 0; i < limit; i++ {
 		l := langs[i]
-		fmt.Printf("  %-25s  %10s  %10s\n", l.Language, fmtInt(int64(l.Files)), fmtInt(l.Code))
+		fmt.Fprintf(w, "  %-25s  %10s  %10s\n", l.Language, fmtInt(int64(l.Files)), fmtInt(l.Code))
 	}
 	if len(langs) > limit {
-		fmt.Printf("  ... and %d more\n", len(langs)-limit)
+		fmt.Fprintf(w, "  ... and %d more\n", len(langs)-limit)
 	}
 	if len(p.PrimaryLanguages) > 0 {
 		parts := make([]string, 0, len(p.PrimaryLanguages))
 		for _, pl := range p.PrimaryLanguages {
 			parts = append(parts, fmt.Sprintf("%s (%.0f%%)", pl.Language, pl.Pct*100))
 		}
-		fmt.Printf("\n  Primary: %s\n", strings.Join(parts, ", "))
+		fmt.Fprintf(w, "\n  Primary: %s\n", strings.Join(parts, ", "))
 	}
 }
 
-func printTechnologies(p *types.Payload, techNames map[string]string) {
+func printTechnologies(w io.Writer, p *types.Payload, techNames map[string]string) {
 	if len(p.Techs) == 0 {
 		return
 	}
-	fmt.Println()
-	fmt.Println("Technologies")
-	fmt.Println(strings.Repeat("-", 70))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Technologies")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
 
 	if len(p.PrimaryTechs) > 0 {
 		names := make([]string, 0, len(p.PrimaryTechs))
 		for _, t := range p.PrimaryTechs {
 			names = append(names, displayName(t, techNames))
 		}
-		fmt.Printf("  Primary:  %s\n", strings.Join(names, ", "))
+		fmt.Fprintf(w, "  Primary:  %s\n", strings.Join(names, ", "))
+	}
+	if secondary := secondaryTechs(p, techNames); len(secondary) > 0 {
+		fmt.Fprintf(w, "  Also:     %s\n", strings.Join(secondary, ", "))
 	}
+	if ecosystems := ecosystemCounts(p); len(ecosystems) > 0 {
+		fmt.Fprintf(w, "  Ecosystems: %s\n", strings.Join(ecosystems, ", "))
+	}
+}
 
+// secondaryTechs returns the display names of the techs that are neither
+// primary nor a language, sorted.
+func secondaryTechs(p *types.Payload, techNames map[string]string) []string {
 	primarySet := make(map[string]bool, len(p.PrimaryTechs))
 	for _, t := range p.PrimaryTechs {
 		primarySet[t] = true
@@ -264,35 +280,33 @@ func printTechnologies(p *types.Payload, techNames map[string]string) {
 			secondary = append(secondary, displayName(t, techNames))
 		}
 	}
-	if len(secondary) > 0 {
-		sort.Strings(secondary)
-		fmt.Printf("  Also:     %s\n", strings.Join(secondary, ", "))
-	}
-
-	if len(p.Ecosystems) > 0 {
-		parts := make([]string, 0, len(p.Ecosystems))
-		for _, e := range p.Ecosystems {
-			parts = append(parts, fmt.Sprintf("%s (%d)", e.Ecosystem, e.Components))
-		}
-		fmt.Printf("  Ecosystems: %s\n", strings.Join(parts, ", "))
-	}
+	sort.Strings(secondary)
+	return secondary
 }
 
-func printStructure(p *types.Payload) {
-	if len(p.Children) == 0 {
-		return
+// ecosystemCounts formats the ecosystems with their component counts.
+func ecosystemCounts(p *types.Payload) []string {
+	parts := make([]string, 0, len(p.Ecosystems))
+	for _, e := range p.Ecosystems {
+		parts = append(parts, fmt.Sprintf("%s (%d)", e.Ecosystem, e.Components))
 	}
-	fmt.Println()
-	fmt.Println("Component Tree")
-	fmt.Println(strings.Repeat("-", 70))
+	return parts
+}
 
-	type dirInfo struct {
-		name       string
-		components int
-		files      int
-		codeLines  int64
-	}
+// dirInfo is one row of the component tree: a top-level directory with the
+// components below it.
+type dirInfo struct {
+	name       string
+	components int
+	files      int
+	codeLines  int64
+}
 
+// topLevelDirs groups the components under the top-level directories they
+// live in, the directory with the most components first. withStats reports
+// whether any component carried code_stats (scans without
+// --component-stats-depth have none).
+func topLevelDirs(p *types.Payload) (dirs []*dirInfo, withStats bool) {
 	dirMap := make(map[string]*dirInfo)
 	for _, child := range p.Children {
 		dir := topLevelDir(child)
@@ -308,40 +322,59 @@ func printStructure(p *types.Payload) {
 		if cs, ok := codeStatsFromPayload(child); ok {
 			info.files += cs.Total.Files
 			info.codeLines += cs.Total.Code
+			withStats = true
 		}
 	}
 
-	dirs := make([]*dirInfo, 0, len(dirMap))
+	dirs = make([]*dirInfo, 0, len(dirMap))
 	for _, info := range dirMap {
 		dirs = append(dirs, info)
 	}
 	sort.Slice(dirs, func(i, j int) bool {
 		return dirs[i].components > dirs[j].components
 	})
+	return dirs, withStats
+}
 
-	fmt.Printf("  %-35s  %10s  %10s  %10s\n", "Directory", "Components", "Files", "Code LoC")
+func printStructure(w io.Writer, p *types.Payload) {
+	if len(p.Children) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Component Tree")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
+
+	dirs, withStats := topLevelDirs(p)
+	if !withStats {
+		fmt.Fprintf(w, "  %-35s  %10s\n", "Directory", "Components")
+		for _, d := range dirs {
+			fmt.Fprintf(w, "  %-35s  %10d\n", d.name, d.components)
+		}
+		return
+	}
+	fmt.Fprintf(w, "  %-35s  %10s  %10s  %10s\n", "Directory", "Components", "Files", "Code LoC")
 	for _, d := range dirs {
-		fmt.Printf("  %-35s  %10d  %10s  %10s\n",
+		fmt.Fprintf(w, "  %-35s  %10d  %10s  %10s\n",
 			d.name, d.components, fmtInt(int64(d.files)), fmtInt(d.codeLines))
 	}
 }
 
-func printSubsystems(p *types.Payload) {
+func printSubsystems(w io.Writer, p *types.Payload) {
 	if len(p.SubsystemStats) == 0 {
 		return
 	}
-	fmt.Println()
-	fmt.Println("Subsystems")
-	fmt.Println(strings.Repeat("-", 70))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Subsystems")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
 	for _, ss := range p.SubsystemStats {
 		if cs, ok := ss.CodeStats.(*codestats.CodeStats); ok {
-			fmt.Printf("  %-25s  %4d components  %s files  %s LoC\n",
+			fmt.Fprintf(w, "  %-25s  %4d components  %s files  %s LoC\n",
 				ss.Path, ss.ComponentCount, fmtInt(int64(cs.Total.Files)), fmtInt(cs.Total.Code))
 		} else {
-			fmt.Printf("  %-25s  %4d components\n", ss.Path, ss.ComponentCount)
+			fmt.Fprintf(w, "  %-25s  %4d components\n", ss.Path, ss.ComponentCount)
 		}
 		if ss.Description != "" {
-			fmt.Printf("    %s\n", ss.Description)
+			fmt.Fprintf(w, "    %s\n", ss.Description)
 		}
 	}
 }
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// writeMarkdownSummary renders the payload as the summary report in Markdown:
+// the sections of the text report, with tables where the text report aligns
+// columns.
+func writeMarkdownSummary(w io.Writer, p *types.Payload) {
+	techNames := loadTechDisplayNames()
+
+	fmt.Fprintln(w, "# Codebase Summary")
+	markdownMetadata(w, p)
+	markdownCodeStats(w, p)
+	markdownLanguages(w, p)
+	markdownTechnologies(w, p, techNames)
+	markdownStructure(w, p)
+	markdownSubsystems(w, p)
+	markdownObservations(w, p)
+}
+
+func markdownMetadata(w io.Writer, p *types.Payload) {
+	meta, ok := p.Metadata.(*metadata.ScanMetadata)
+	if !ok {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Scan path:** `%s`\n", meta.ScanPath)
+	fmt.Fprintf(w, "- **Components:** %d\n", meta.ComponentCount)
+	fmt.Fprintf(w, "- **Languages:** %d\n", meta.LanguageCount)
+	fmt.Fprintf(w, "- **Technologies:** %d\n", meta.TechsCount)
+	if meta.DurationMs > 0 {
+		fmt.Fprintf(w, "- **Scan time:** %.1fs\n", float64(meta.DurationMs)/1000)
+	}
+}
+
+func markdownCodeStats(w io.Writer, p *types.Payload) {
+	cs, ok := codeStatsFromPayload(p)
+	if !ok {
+		return
+	}
+	fmt.Fprintln(w, "\n## Code Statistics")
+	fmt.Fprintln(w)
+	markdownRow(w, "Type", "Files", "Code LoC", "Comments", "Blanks")
+	markdownRow(w, "---", "---:", "---:", "---:", "---:")
+	markdownTypeBucketRow(w, "Programming", cs.ByType.Programming)
+	markdownTypeBucketRow(w, "Markup", cs.ByType.Markup)
+	markdownTypeBucketRow(w, "Data", cs.ByType.Data)
+	markdownTypeBucketRow(w, "Prose", cs.ByType.Prose)
+	if cs.Unanalyzed.Total.Files > 0 {
+		markdownRow(w, "Other (unanalyzed, lines only)",
+			fmtInt(int64(cs.Unanalyzed.Total.Files)), fmtInt(cs.Unanalyzed.Total.Lines), "", "")
+	}
+	markdownRow(w, "**Total (analyzed)**",
+		fmtInt(int64(cs.Total.Files)), fmtInt(cs.Total.Code), fmtInt(cs.Total.Comments), fmtInt(cs.Total.Blanks))
+}
+
+func markdownTypeBucketRow(w io.Writer, label string, tb *codestats.TypeBucket) {
+	if tb == nil || tb.Total.Files == 0 {
+		return
+	}
+	markdownRow(w, label,
+		fmtInt(int64(tb.Total.Files)), fmtInt(tb.Total.Code), fmtInt(tb.Total.Comments), fmtInt(tb.Total.Blanks))
+}
+
+func markdownLanguages(w io.Writer, p *types.Payload) {
+	cs, ok := codeStatsFromPayload(p)
+	if !ok {
+		return
+	}
+	fmt.Fprintln(w, "\n## Languages (top 15 by Code LoC)")
+	fmt.Fprintln(w)
+	markdownRow(w, "Language", "Files", "Code LoC")
+	markdownRow(w, "---", "---:", "---:")
+
+	langs := cs.Analyzed.ByLanguage
+	limit := min(15, len(langs))
+	for _, l := range langs[:limit] {
+		markdownRow(w, l.Language, fmtInt(int64(l.Files)), fmtInt(l.Code))
+	}
+	if len(langs) > limit {
+		fmt.Fprintf(w, "\n... and %d more\n", len(langs)-limit)
+	}
+	if len(p.PrimaryLanguages) > 0 {
+		parts := make([]string, 0, len(p.PrimaryLanguages))
+		for _, pl := range p.PrimaryLanguages {
+			parts = append(parts, fmt.Sprintf("%s (%.0f%%)", pl.Language, pl.Pct*100))
+		}
+		fmt.Fprintf(w, "\n**Primary:** %s\n", strings.Join(parts, ", "))
+	}
+}
+
+func markdownTechnologies(w io.Writer, p *types.Payload, techNames map[string]string) {
+	if len(p.Techs) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\n## Technologies")
+	fmt.Fprintln(w)
+	if len(p.PrimaryTechs) > 0 {
+		names := make([]string, 0, len(p.PrimaryTechs))
+		for _, t := range p.PrimaryTechs {
+			names = append(names, displayName(t, techNames))
+		}
+		fmt.Fprintf(w, "- **Primary:** %s\n", strings.Join(names, ", "))
+	}
+	if secondary := secondaryTechs(p, techNames); len(secondary) > 0 {
+		fmt.Fprintf(w, "- **Also:** %s\n", strings.Join(secondary, ", "))
+	}
+	if ecosystems := ecosystemCounts(p); len(ecosystems) > 0 {
+		fmt.Fprintf(w, "- **Ecosystems:** %s\n", strings.Join(ecosystems, ", "))
+	}
+}
+
+func markdownStructure(w io.Writer, p *types.Payload) {
+	if len(p.Children) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\n## Component Tree")
+	fmt.Fprintln(w)
+
+	dirs, withStats := topLevelDirs(p)
+	if !withStats {
+		markdownRow(w, "Directory", "Components")
+		markdownRow(w, "---", "---:")
+		for _, d := range dirs {
+			markdownRow(w, d.name, fmt.Sprintf("%d", d.components))
+		}
+		return
+	}
+	markdownRow(w, "Directory", "Components", "Files", "Code LoC")
+	markdownRow(w, "---", "---:", "---:", "---:")
+	for _, d := range dirs {
+		markdownRow(w, d.name, fmt.Sprintf("%d", d.components), fmtInt(int64(d.files)), fmtInt(d.codeLines))
+	}
+}
+
+func markdownSubsystems(w io.Writer, p *types.Payload) {
+	if len(p.SubsystemStats) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\n## Subsystems")
+	fmt.Fprintln(w)
+	markdownRow(w, "Path", "Components", "Files", "Code LoC", "Description")
+	markdownRow(w, "---", "---:", "---:", "---:", "---")
+	for _, ss := range p.SubsystemStats {
+		files, code := "", ""
+		if cs, ok := ss.CodeStats.(*codestats.CodeStats); ok {
+			files, code = fmtInt(int64(cs.Total.Files)), fmtInt(cs.Total.Code)
+		}
+		markdownRow(w, ss.Path, fmt.Sprintf("%d", ss.ComponentCount), files, code, ss.Description)
+	}
+}
+
+func markdownObservations(w io.Writer, p *types.Payload) {
+	obs := collectObservations(p)
+	if len(obs) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\n## Observations")
+	fmt.Fprintln(w)
+	for _, o := range obs {
+		fmt.Fprintf(w, "- %s\n", o)
+	}
+}
+
+// markdownRow writes one table row, escaping the pipes in its cells.
+func markdownRow(w io.Writer, cells ...string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -26,16 +27,16 @@ var commonLangs = map[string]bool{
 }
 
 // printObservations prints the Observations section if any signals are found.
-func printObservations(p *types.Payload) {
+func printObservations(w io.Writer, p *types.Payload) {
 	obs := collectObservations(p)
 	if len(obs) == 0 {
 		return
 	}
-	fmt.Println()
-	fmt.Println("Observations")
-	fmt.Println(strings.Repeat("-", 70))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Observations")
+	fmt.Fprintln(w, strings.Repeat("-", 70))
 	for _, o := range obs {
-		fmt.Printf("  - %s\n", o)
+		fmt.Fprintf(w, "  - %s\n", o)
 	}
 }
 
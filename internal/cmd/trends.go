@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/currency"
+	"github.com/petrarca/tech-stack-analyzer/internal/daemon"
+	"github.com/petrarca/tech-stack-analyzer/internal/trends"
+)
+
+var (
+	trendsFormat  string
+	trendsOutput  string
+	trendsStore   string
+	trendsRepo    string
+	trendsMetrics string
+	trendsCache   string
+)
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends",
+	Short: "Output time series of metrics across stored scans",
+	Long: `Trends reads the scan results stored in a daemon results database and outputs
+one time series per repository of the selected metrics, oldest scan first,
+for dashboards that should not need custom SQL.
+
+Metrics:
+  loc            lines of code (code_stats total)
+  files          files counted by code_stats
+  deps           distinct dependencies of all components
+  techs          distinct technologies of all components
+  components     components, the root included
+  comment_ratio  comments per line of code of programming languages
+  test_ratio     test files per programming-language file
+  currency       share of resolved direct dependencies on their latest version
+
+Currency uses the latest versions already in the currency cache (filled by
+'currency' and scan --resolve-currency); trends makes no network requests and
+leaves the metric out when there is no cache. Metrics that cannot be computed
+for a scan, such as ratios without code statistics, are left out of its point.
+
+A repository is identified by the daemon job that scanned it and the root ID
+of its scans; --repo selects one by either. Results that do not parse are
+skipped with a warning.
+
+Examples:
+  stack-analyzer trends --store results.db
+  stack-analyzer trends --store results.db --repo myapp --metric loc,deps,techs
+  stack-analyzer trends --store results.db --repo myapp -f csv -o myapp.csv`,
+	Args: cobra.NoArgs,
+	RunE: runTrends,
+}
+
+func init() {
+	rootCmd.AddCommand(trendsCmd)
+	trendsCmd.Flags().StringVar(&trendsStore, "store", "", "Daemon results database (SQLite) to read the stored scans from")
+	trendsCmd.Flags().StringVar(&trendsRepo, "repo", "", "Only the scans of this daemon job or root ID")
+	trendsCmd.Flags().StringVar(&trendsMetrics, "metric", "", "Comma-separated metrics: "+strings.Join(trends.Metrics, ", ")+" (default: all)")
+	trendsCmd.Flags().StringVar(&trendsCache, "currency-cache", "", "Currency cache DB path for the currency metric (default: STACK_ANALYZER_CURRENCY_CACHE env var, else the OS cache dir)")
+	trendsCmd.Flags().StringVarP(&trendsFormat, "format", "f", "json", "Output format: json, yaml, csv, or text")
+	trendsCmd.Flags().StringVarP(&trendsOutput, "output", "o", "", "Output file path (default: stdout)")
+	_ = trendsCmd.MarkFlagRequired("store")
+	registerCompletions(trendsCmd, map[string]completionFunc{
+		"format": completeValues("json", "yaml", "csv", "text"),
+		"metric": completeValues(trends.Metrics...),
+	})
+}
+
+func runTrends(_ *cobra.Command, _ []string) error {
+	metrics, err := trends.ParseMetrics(trendsMetrics)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(trendsFormat)
+	if !slices.Contains([]string{"json", "yaml", "csv", "text"}, format) {
+		return fmt.Errorf("invalid format: %s. Valid formats are: json, yaml, csv, text", trendsFormat)
+	}
+	resolver, closeCache, err := openCurrencyReader(trendsCache, metrics)
+	if err != nil {
+		return err
+	}
+	defer closeCache()
+	result, err := readTrends(trendsStore, trendsRepo, metrics, resolver)
+	if err != nil {
+		return err
+	}
+	if trendsRepo != "" && len(result.Series) == 0 {
+		return fmt.Errorf("no stored scans of %s in %s", trendsRepo, trendsStore)
+	}
+	if format == "csv" {
+		return writeTrendsCSV(result.Trends, trendsOutput)
+	}
+	OutputToFile(result, format, trendsOutput)
+	return nil
+}
+
+// readTrends measures the stored results of a daemon results database, of
+// the job or root ID repo when set; r resolves latest versions for the
+// currency metric and may be nil.
+func readTrends(path, repo string, metrics []string, r currency.CurrencyResolver) (*TrendsResult, error) {
+	result := &TrendsResult{Trends: trends.New(metrics)}
+	result.Currency = r
+	err := daemon.ReadResults(path, false, func(stored daemon.StoredResult) error {
+		root, err := decodeScan(stored.Data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping the %s result of job %s: %v\n", stored.Started.Format(time.RFC3339), stored.Job, err)
+			return nil
+		}
+		if repo == "" || repo == stored.Job || repo == root.ID {
+			result.Add(stored.Job, stored.Started, root)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Sort()
+	return result, nil
+}
+
+func writeTrendsCSV(t *trends.Trends, outputFile string) error {
+	var buf bytes.Buffer
+	if err := t.WriteCSV(&buf); err != nil {
+		return err
+	}
+	if outputFile == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	if !settings.Quiet {
+		fmt.Fprintf(os.Stderr, "Results written to %s\n", outputFile)
+	}
+	return nil
+}
+
+// TrendsResult is the output of trends.
+type TrendsResult struct {
+	*trends.Trends
+}
+
+func (r *TrendsResult) ToJSON() interface{} {
+	return r.Trends
+}
+
+// ToText writes a table per series: one line per scan with its metrics.
+func (r *TrendsResult) ToText(w io.Writer) {
+	for i, series := range r.Series {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s, root %s)\n", series.Job, series.Name, series.RootID)
+		fmt.Fprintf(w, "  %-25s", "scanned")
+		for _, metric := range r.Metrics {
+			fmt.Fprintf(w, " %12s", metric)
+		}
+		fmt.Fprintln(w)
+		for _, point := range series.Points {
+			fmt.Fprintf(w, "  %-25s", point.Scanned.Format(time.RFC3339))
+			for _, metric := range r.Metrics {
+				fmt.Fprintf(w, " %12s", textValue(point.Values, metric))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	fmt.Fprintf(w, "\nTotal: %d series\n", len(r.Series))
+}
+
+// textValue formats a metric for the text table, empty for a metric left out.
+func textValue(values map[string]float64, metric string) string {
+	value, ok := values[metric]
+	if !ok {
+		return ""
+	}
+	return formatMetric(value)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/trends"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendsResultText(t *testing.T) {
+	root := types.NewPayloadWithPath("myapp", "/")
+	root.ID = "a1b2c3d4e5f6"
+	root.Techs = []string{"golang", "postgresql"}
+	result := &TrendsResult{Trends: trends.New([]string{trends.MetricTechs, trends.MetricComponents})}
+	result.Add("myapp", time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC), root)
+
+	var text bytes.Buffer
+	result.ToText(&text)
+	assert.Contains(t, text.String(), "myapp (myapp, root a1b2c3d4e5f6)\n")
+	assert.Contains(t, text.String(), "  2026-03-09T02:00:00Z                 2            1\n")
+	assert.Contains(t, text.String(), "Total: 1 series")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/migrate"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/petrarca/tech-stack-analyzer/internal/ui"
+)
+
+var uiListen string
+
+var uiCmd = &cobra.Command{
+	Use:   "ui <scan-output.json>",
+	Short: "Browse a scan output in a local web interface",
+	Long: `UI serves a local web interface for a scan output JSON: the component tree,
+a search over the technologies and dependencies of every component, and charts
+of the code statistics of the selected component.
+
+The interface is embedded in the binary and makes no network requests beyond
+the local server. It listens on the loopback interface by default; stop it
+with Ctrl+C.
+
+Examples:
+  stack-analyzer ui result.json
+  stack-analyzer ui result.json --listen 127.0.0.1:9000`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runUI(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+	uiCmd.Flags().StringVar(&uiListen, "listen", "127.0.0.1:8090", "Address to serve the interface on")
+}
+
+func runUI(inputPath string) error {
+	result, err := loadFullScanOutput(inputPath)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", uiListen)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", uiListen, err)
+	}
+	srv := &http.Server{Handler: ui.NewServer(result).Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownStatus(srv)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving %s on http://%s/ (Ctrl+C to stop)\n", inputPath, listener.Addr())
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// loadFullScanOutput reads a full scan output; aggregated outputs have no
+// component tree.
+func loadFullScanOutput(path string) (*types.Payload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scan output: %w", err)
+	}
+	if data, err = migrate.ToCurrent(data); err != nil {
+		return nil, err
+	}
+	var result types.Payload
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	if result.ID == "" {
+		return nil, fmt.Errorf("%s is not a full scan output (aggregated outputs have no component tree)", path)
+	}
+	return &result, nil
+}
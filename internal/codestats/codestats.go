@@ -25,6 +25,7 @@ type Stats struct {
 	Blanks     int64 `json:"blanks"`
 	Complexity int64 `json:"complexity"`
 	Files      int   `json:"files"`
+	Estimated  bool  `json:"estimated,omitempty"` // Some files were sampled rather than read in full
 }
 
 // LanguageStats holds stats for a specific language (includes language name for sorted output)
@@ -36,19 +37,36 @@ type LanguageStats struct {
 	Blanks     int64  `json:"blanks"`
 	Complexity int64  `json:"complexity"`
 	Files      int    `json:"files"`
+	Estimated  bool   `json:"estimated,omitempty"`
 }
 
 // OtherStats holds statistics for files SCC cannot analyze (just line counts)
 type OtherStats struct {
-	Lines int64 `json:"lines"`
-	Files int   `json:"files"`
+	Lines     int64 `json:"lines"`
+	Files     int   `json:"files"`
+	Estimated bool  `json:"estimated,omitempty"` // Some files were sampled rather than read in full
+}
+
+// markEstimated flags the stats as estimated when a sampled file was added.
+func (s *Stats) markEstimated(estimated bool) {
+	if estimated {
+		s.Estimated = true
+	}
+}
+
+// markEstimated flags the stats as estimated when a sampled file was added.
+func (s *OtherStats) markEstimated(estimated bool) {
+	if estimated {
+		s.Estimated = true
+	}
 }
 
 // OtherLanguageStats holds stats for unanalyzed language (includes language name)
 type OtherLanguageStats struct {
-	Language string `json:"language"`
-	Lines    int64  `json:"lines"`
-	Files    int    `json:"files"`
+	Language  string `json:"language"`
+	Lines     int64  `json:"lines"`
+	Files     int    `json:"files"`
+	Estimated bool   `json:"estimated,omitempty"`
 }
 
 // AnalyzedBucket holds stats for SCC-analyzed files (full code/comments/blanks breakdown)
@@ -96,10 +114,11 @@ type ByType struct {
 
 // CodeStats holds aggregated code statistics
 type CodeStats struct {
-	Total      Stats            `json:"total"`      // Grand total (analyzed only)
-	ByType     ByType           `json:"by_type"`    // Stats grouped by language type (metrics in programming section)
-	Analyzed   AnalyzedBucket   `json:"analyzed"`   // SCC-recognized languages
-	Unanalyzed UnanalyzedBucket `json:"unanalyzed"` // Files SCC can't parse
+	Total      Stats            `json:"total"`         // Grand total (analyzed only)
+	ByType     ByType           `json:"by_type"`       // Stats grouped by language type (metrics in programming section)
+	Analyzed   AnalyzedBucket   `json:"analyzed"`      // SCC-recognized languages
+	Unanalyzed UnanalyzedBucket `json:"unanalyzed"`    // Files SCC can't parse
+	HDL        *HDLStats        `json:"hdl,omitempty"` // Design units of VHDL/Verilog/SystemVerilog files
 }
 
 // Analyzer interface for code statistics collection.
@@ -134,6 +153,13 @@ type SubsystemAnalyzer interface {
 	SubsystemKeys() []string
 }
 
+// SampleAnalyzer is an optional interface for estimating the stats of files
+// too large to be analyzed in full from a sample of their content.
+// Satisfied by the SCC analyzer.
+type SampleAnalyzer interface {
+	ProcessSample(filename, language, typeOverride string, sample []byte, fileSize int64, componentKey, subsystemKey string)
+}
+
 // AnalyzerConfig holds all configuration for creating an Analyzer.
 type AnalyzerConfig struct {
 	PerComponent     bool    // Enable per-component stats tracking
@@ -200,6 +226,7 @@ type sccAnalyzer struct {
 	subsystemStats   map[string]*statsBucket // Stats by subsystem key (reuses statsBucket struct)
 	// language label → resolved type (honours reclassify overrides; used by buildByType)
 	languageType map[string]string
+	hdl          HDLStats // Design units of HDL files
 	// Primary language configuration
 	primaryThreshold float64 // Minimum percentage for primary languages
 	maxPrimaryLangs  int     // Maximum number of primary languages to show
@@ -213,6 +240,7 @@ type statsBucket struct {
 	otherByLanguage map[string]*OtherStats // Non-SCC languages
 	byType          map[string]*Stats      // By type aggregation (programming, data, markup, prose)
 	languageType    map[string]string      // language label → resolved type (honours reclassify overrides)
+	hdl             HDLStats               // Design units of HDL files
 }
 
 func (a *sccAnalyzer) IsEnabled() bool { return true }
@@ -224,7 +252,7 @@ func (a *sccAnalyzer) buildAnalyzedSlice() []LanguageStats {
 		result = append(result, LanguageStats{
 			Language: lang, Lines: stats.Lines, Code: stats.Code,
 			Comments: stats.Comments, Blanks: stats.Blanks,
-			Complexity: stats.Complexity, Files: stats.Files,
+			Complexity: stats.Complexity, Files: stats.Files, Estimated: stats.Estimated,
 		})
 	}
 	sort.Slice(result, func(i, j int) bool { return result[i].Lines > result[j].Lines })
@@ -235,7 +263,7 @@ func (a *sccAnalyzer) buildAnalyzedSlice() []LanguageStats {
 func (a *sccAnalyzer) buildUnanalyzedSlice() []OtherLanguageStats {
 	result := make([]OtherLanguageStats, 0, len(a.otherByLanguage))
 	for lang, stats := range a.otherByLanguage {
-		result = append(result, OtherLanguageStats{Language: lang, Lines: stats.Lines, Files: stats.Files})
+		result = append(result, OtherLanguageStats{Language: lang, Lines: stats.Lines, Files: stats.Files, Estimated: stats.Estimated})
 	}
 	sort.Slice(result, func(i, j int) bool { return result[i].Lines > result[j].Lines })
 	return result
@@ -357,6 +385,7 @@ func (a *sccAnalyzer) GetStats() *CodeStats {
 		ByType:     a.buildByType(analyzed, unanalyzed, metrics),
 		Analyzed:   AnalyzedBucket{Total: a.total, ByLanguage: analyzed},
 		Unanalyzed: UnanalyzedBucket{Total: a.otherTotal, ByLanguage: unanalyzed},
+		HDL:        hdlOrNil(a.hdl),
 	}
 }
 
@@ -364,58 +393,107 @@ func (a *sccAnalyzer) GetStats() *CodeStats {
 // typeOverride: if non-empty, overrides enry.GetLanguageType() for by-type aggregation.
 // componentKey and subsystemKey are optional — empty string means skip that bucket.
 func (a *sccAnalyzer) ProcessFile(filename, language, typeOverride string, content []byte, componentKey, subsystemKey string) {
+	filejob, sccLang, ok := a.countFile(filename, language, typeOverride, content)
+	if !ok {
+		return
+	}
+	a.addFileStats(filejob, countHDLUnits(language, filejob.Content), language, sccLang, typeOverride, componentKey, subsystemKey, false)
+}
+
+// ProcessSample estimates the stats of a file of fileSize bytes from a
+// sample of its content, scaling the counts of the sample to the file size.
+// The stats it is added to are marked estimated; HDL design units are not
+// counted from samples.
+func (a *sccAnalyzer) ProcessSample(filename, language, typeOverride string, sample []byte, fileSize int64, componentKey, subsystemKey string) {
+	if len(sample) == 0 {
+		return
+	}
+	filejob, sccLang, ok := a.countFile(filename, language, typeOverride, sample)
+	if !ok {
+		return
+	}
+	scaleFileJob(filejob, float64(fileSize)/float64(len(sample)))
+	filejob.Bytes = fileSize
+	a.addFileStats(filejob, nil, language, sccLang, typeOverride, componentKey, subsystemKey, true)
+}
+
+// countFile runs SCC on the content of a file, reporting false when the
+// file is not counted.
+func (a *sccAnalyzer) countFile(filename, language, typeOverride string, content []byte) (*processor.FileJob, string, bool) {
 	// Skip entirely when there is neither a language label nor a type override.
 	if language == "" && typeOverride == "" {
-		return
+		return nil, "", false
 	}
 	// For type-only reclassify rules (language == "", typeOverride != ""), processFileCommon
 	// would exit early on the empty-language guard. We call it only when language is known;
 	// otherwise we create a minimal filejob directly so the file still gets line-counted.
-	var filejob *processor.FileJob
-	var sccLang string
 	if language != "" {
-		var ok bool
-		filejob, sccLang, ok = a.processFileCommon(filename, language, content)
-		if !ok {
-			return
-		}
-	} else {
-		// Type-only: count lines via SCC but do not record per-language stats.
-		if len(content) == 0 {
-			var err error
-			content, err = os.ReadFile(filename)
-			if err != nil {
-				return
-			}
-		}
-		initOnce.Do(func() { processor.ProcessConstants() })
-		sccLangs, _ := processor.DetectLanguage(filename)
-		if len(sccLangs) > 0 {
-			sccLang = sccLangs[0]
-		}
-		filejob = &processor.FileJob{
-			Filename: filename,
-			Language: sccLang,
-			Content:  content,
-			Bytes:    int64(len(content)),
+		return a.processFileCommon(filename, language, content)
+	}
+	// Type-only: count lines via SCC but do not record per-language stats.
+	if len(content) == 0 {
+		var err error
+		content, err = os.ReadFile(filename)
+		if err != nil {
+			return nil, "", false
 		}
-		processor.CountStats(filejob)
 	}
+	initOnce.Do(func() { processor.ProcessConstants() })
+	var sccLang string
+	sccLangs, _ := processor.DetectLanguage(filename)
+	if len(sccLangs) > 0 {
+		sccLang = sccLangs[0]
+	}
+	filejob := &processor.FileJob{
+		Filename: filename,
+		Language: sccLang,
+		Content:  content,
+		Bytes:    int64(len(content)),
+	}
+	processor.CountStats(filejob)
+	return filejob, sccLang, true
+}
 
+// scaleFileJob multiplies the counts of a file job by factor.
+func scaleFileJob(filejob *processor.FileJob, factor float64) {
+	scale := func(n int64) int64 { return int64(math.Round(float64(n) * factor)) }
+	filejob.Lines = scale(filejob.Lines)
+	filejob.Code = scale(filejob.Code)
+	filejob.Comment = scale(filejob.Comment)
+	filejob.Blank = scale(filejob.Blank)
+	filejob.Complexity = scale(filejob.Complexity)
+}
+
+// addFileStats adds a counted file to the global, component and
+// subsystem buckets under the analyzer mutex, marking them estimated when
+// the file was sampled.
+func (a *sccAnalyzer) addFileStats(filejob *processor.FileJob, hdl *HDLStats, language, sccLang, typeOverride, componentKey, subsystemKey string, estimated bool) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	// Always add to global stats
-	a.addToGlobalStatsUnsafe(filejob, language, sccLang, typeOverride)
+	a.addToGlobalStatsUnsafe(filejob, language, sccLang, typeOverride, estimated)
+	if hdl != nil {
+		a.hdl.add(hdl)
+	}
 
 	// Optionally add to component bucket
 	if a.perComponentEnabled && componentKey != "" {
-		a.addToBucketUnsafe(filejob, language, sccLang, typeOverride, componentKey, a.componentBuckets)
+		a.addToBucketUnsafe(filejob, language, sccLang, typeOverride, componentKey, a.componentBuckets, estimated)
+		addHDLUnsafe(a.componentBuckets[componentKey], hdl)
 	}
 
 	// Optionally add to subsystem bucket
 	if a.subsystemEnabled && subsystemKey != "" {
-		a.addToBucketUnsafe(filejob, language, sccLang, typeOverride, subsystemKey, a.subsystemStats)
+		a.addToBucketUnsafe(filejob, language, sccLang, typeOverride, subsystemKey, a.subsystemStats, estimated)
+		addHDLUnsafe(a.subsystemStats[subsystemKey], hdl)
+	}
+}
+
+// addHDLUnsafe adds HDL design units to a bucket (caller must hold mutex).
+func addHDLUnsafe(bucket *statsBucket, hdl *HDLStats) {
+	if hdl != nil {
+		bucket.hdl.add(hdl)
 	}
 }
 
@@ -468,7 +546,7 @@ func resolveTypeName(language, typeOverride string) string {
 }
 
 // addToGlobalStatsUnsafe adds file job results to global statistics (caller must hold mutex)
-func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, language string, sccLang string, typeOverride string) {
+func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, language string, sccLang string, typeOverride string, estimated bool) {
 	// This is the existing global aggregation logic from ProcessFile
 	// Determine if SCC recognized this file
 	sccRecognized := sccLang != ""
@@ -481,6 +559,7 @@ func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, languag
 		a.total.Blanks += filejob.Blank
 		a.total.Complexity += filejob.Complexity
 		a.total.Files++
+		a.total.markEstimated(estimated)
 
 		// Only record per-language stats when we have a language label
 		// (type-only reclassify rules leave language empty intentionally)
@@ -495,6 +574,7 @@ func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, languag
 			a.codeByLanguage[language].Blanks += filejob.Blank
 			a.codeByLanguage[language].Complexity += filejob.Complexity
 			a.codeByLanguage[language].Files++
+			a.codeByLanguage[language].markEstimated(estimated)
 			// Record resolved type for buildByType (honours reclassify overrides)
 			a.languageType[language] = typeName
 		}
@@ -510,11 +590,13 @@ func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, languag
 			a.byType[typeName].Blanks += filejob.Blank
 			a.byType[typeName].Complexity += filejob.Complexity
 			a.byType[typeName].Files++
+			a.byType[typeName].markEstimated(estimated)
 		}
 	} else {
 		// Other bucket: files SCC can't analyze (just line counts)
 		a.otherTotal.Lines += filejob.Lines
 		a.otherTotal.Files++
+		a.otherTotal.markEstimated(estimated)
 
 		typeName := resolveTypeName(language, typeOverride)
 		if language != "" {
@@ -523,6 +605,7 @@ func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, languag
 			}
 			a.otherByLanguage[language].Lines += filejob.Lines
 			a.otherByLanguage[language].Files++
+			a.otherByLanguage[language].markEstimated(estimated)
 			a.languageType[language] = typeName
 		}
 		if typeName != "unknown" {
@@ -531,13 +614,14 @@ func (a *sccAnalyzer) addToGlobalStatsUnsafe(filejob *processor.FileJob, languag
 			}
 			a.byType[typeName].Lines += filejob.Lines
 			a.byType[typeName].Files++
+			a.byType[typeName].markEstimated(estimated)
 		}
 	}
 }
 
 // addToBucketUnsafe adds file job results to a keyed stats bucket (caller must hold mutex).
 // statsMap is either statsBucket or subsystemStats — same logic, different map.
-func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language string, sccLang string, typeOverride string, key string, statsMap map[string]*statsBucket) {
+func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language string, sccLang string, typeOverride string, key string, statsMap map[string]*statsBucket, estimated bool) {
 	if _, ok := statsMap[key]; !ok {
 		statsMap[key] = &statsBucket{
 			codeByLanguage:  make(map[string]*Stats),
@@ -560,6 +644,7 @@ func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language str
 		compStats.total.Blanks += filejob.Blank
 		compStats.total.Complexity += filejob.Complexity
 		compStats.total.Files++
+		compStats.total.markEstimated(estimated)
 
 		typeName := resolveTypeName(language, typeOverride)
 		if language != "" {
@@ -572,6 +657,7 @@ func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language str
 			compStats.codeByLanguage[language].Blanks += filejob.Blank
 			compStats.codeByLanguage[language].Complexity += filejob.Complexity
 			compStats.codeByLanguage[language].Files++
+			compStats.codeByLanguage[language].markEstimated(estimated)
 			compStats.languageType[language] = typeName
 		}
 
@@ -586,11 +672,13 @@ func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language str
 			compStats.byType[typeName].Blanks += filejob.Blank
 			compStats.byType[typeName].Complexity += filejob.Complexity
 			compStats.byType[typeName].Files++
+			compStats.byType[typeName].markEstimated(estimated)
 		}
 	} else {
 		// Other bucket: files SCC can't analyze (just line counts)
 		compStats.otherTotal.Lines += filejob.Lines
 		compStats.otherTotal.Files++
+		compStats.otherTotal.markEstimated(estimated)
 
 		typeName := resolveTypeName(language, typeOverride)
 		if language != "" {
@@ -599,6 +687,7 @@ func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language str
 			}
 			compStats.otherByLanguage[language].Lines += filejob.Lines
 			compStats.otherByLanguage[language].Files++
+			compStats.otherByLanguage[language].markEstimated(estimated)
 			compStats.languageType[language] = typeName
 		}
 
@@ -610,6 +699,7 @@ func (a *sccAnalyzer) addToBucketUnsafe(filejob *processor.FileJob, language str
 			}
 			compStats.byType[typeName].Lines += filejob.Lines
 			compStats.byType[typeName].Files++
+			compStats.byType[typeName].markEstimated(estimated)
 		}
 	}
 }
@@ -661,12 +751,12 @@ func (a *sccAnalyzer) buildCodeStatsFromComponentStats(compStats *statsBucket) *
 		analyzed = append(analyzed, LanguageStats{
 			Language: lang, Lines: stats.Lines, Code: stats.Code,
 			Comments: stats.Comments, Blanks: stats.Blanks,
-			Complexity: stats.Complexity, Files: stats.Files,
+			Complexity: stats.Complexity, Files: stats.Files, Estimated: stats.Estimated,
 		})
 	}
 	unanalyzed := make([]OtherLanguageStats, 0, len(compStats.otherByLanguage))
 	for lang, stats := range compStats.otherByLanguage {
-		unanalyzed = append(unanalyzed, OtherLanguageStats{Language: lang, Lines: stats.Lines, Files: stats.Files})
+		unanalyzed = append(unanalyzed, OtherLanguageStats{Language: lang, Lines: stats.Lines, Files: stats.Files, Estimated: stats.Estimated})
 	}
 	sort.Slice(analyzed, func(i, j int) bool { return analyzed[i].Lines > analyzed[j].Lines })
 	sort.Slice(unanalyzed, func(i, j int) bool { return unanalyzed[i].Lines > unanalyzed[j].Lines })
@@ -676,6 +766,7 @@ func (a *sccAnalyzer) buildCodeStatsFromComponentStats(compStats *statsBucket) *
 		ByType:     byType,
 		Analyzed:   AnalyzedBucket{Total: compStats.total, ByLanguage: analyzed},
 		Unanalyzed: UnanalyzedBucket{Total: compStats.otherTotal, ByLanguage: unanalyzed},
+		HDL:        hdlOrNil(compStats.hdl),
 	}
 }
 
@@ -0,0 +1,31 @@
+package codestats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessSample(t *testing.T) {
+	a := NewAnalyzer(AnalyzerConfig{PerComponent: true})
+	a.ProcessFile("/repo/main.go", "Go", "", []byte("package main\n\nfunc main() {}\n"), "/", "")
+	sample := bytes.Repeat([]byte("var x = 1\n\n"), 100)
+	a.(SampleAnalyzer).ProcessSample("/repo/gen.go", "Go", "", sample, int64(len(sample))*10, "/", "")
+
+	stats := a.GetStats()
+	require.Len(t, stats.Analyzed.ByLanguage, 1)
+	goStats := stats.Analyzed.ByLanguage[0]
+	assert.Equal(t, int64(3+2000), goStats.Lines, "sample counts are scaled to the file size")
+	assert.Equal(t, int64(2+1000), goStats.Code)
+	assert.Equal(t, 2, goStats.Files)
+	assert.True(t, goStats.Estimated)
+	assert.True(t, stats.Total.Estimated)
+	assert.True(t, stats.ByType.Programming.Total.Estimated)
+	assert.True(t, a.GetComponentStats("/").Total.Estimated)
+
+	full := NewAnalyzer(AnalyzerConfig{})
+	full.ProcessFile("/repo/main.go", "Go", "", []byte("package main\n"), "", "")
+	assert.False(t, full.GetStats().Total.Estimated)
+}
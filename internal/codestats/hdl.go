@@ -0,0 +1,74 @@
+package codestats
+
+import "regexp"
+
+// HDLStats counts the design units of hardware description language sources.
+// VHDL files contribute entities, architectures and packages; Verilog and
+// SystemVerilog files contribute modules, interfaces, packages and classes.
+type HDLStats struct {
+	Files         int `json:"files"`
+	Entities      int `json:"entities,omitempty"`
+	Architectures int `json:"architectures,omitempty"`
+	Packages      int `json:"packages,omitempty"`
+	Modules       int `json:"modules,omitempty"`
+	Interfaces    int `json:"interfaces,omitempty"`
+	Classes       int `json:"classes,omitempty"`
+}
+
+var (
+	vhdlCommentRegex      = regexp.MustCompile(`--[^\n]*`)
+	vhdlEntityRegex       = regexp.MustCompile(`(?im)^\s*entity\s+\w+\s+is\b`)
+	vhdlArchitectureRegex = regexp.MustCompile(`(?im)^\s*architecture\s+\w+\s+of\s+\w+\s+is\b`)
+	vhdlPackageRegex      = regexp.MustCompile(`(?im)^\s*package\s+\w+\s+is\b`)
+
+	verilogCommentRegex   = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	verilogModuleRegex    = regexp.MustCompile(`(?m)^\s*(?:extern\s+)?(?:macro)?module\s+(?:automatic\s+|static\s+)?\w+`)
+	verilogInterfaceRegex = regexp.MustCompile(`(?m)^\s*interface\s+(?:automatic\s+|static\s+)?\w+`)
+	verilogPackageRegex   = regexp.MustCompile(`(?m)^\s*package\s+(?:automatic\s+|static\s+)?\w+\s*;`)
+	verilogClassRegex     = regexp.MustCompile(`(?m)^\s*(?:virtual\s+)?class\s+\w+`)
+)
+
+// countHDLUnits returns the design units declared in an HDL file, or nil for
+// other languages. Comments are stripped first so commented-out declarations
+// are not counted.
+func countHDLUnits(language string, content []byte) *HDLStats {
+	switch language {
+	case "VHDL":
+		src := vhdlCommentRegex.ReplaceAll(content, nil)
+		return &HDLStats{
+			Files:         1,
+			Entities:      len(vhdlEntityRegex.FindAllIndex(src, -1)),
+			Architectures: len(vhdlArchitectureRegex.FindAllIndex(src, -1)),
+			Packages:      len(vhdlPackageRegex.FindAllIndex(src, -1)),
+		}
+	case "Verilog", "SystemVerilog":
+		src := verilogCommentRegex.ReplaceAll(content, nil)
+		return &HDLStats{
+			Files:      1,
+			Modules:    len(verilogModuleRegex.FindAllIndex(src, -1)),
+			Interfaces: len(verilogInterfaceRegex.FindAllIndex(src, -1)),
+			Packages:   len(verilogPackageRegex.FindAllIndex(src, -1)),
+			Classes:    len(verilogClassRegex.FindAllIndex(src, -1)),
+		}
+	}
+	return nil
+}
+
+// add accumulates the counts of other.
+func (s *HDLStats) add(other *HDLStats) {
+	s.Files += other.Files
+	s.Entities += other.Entities
+	s.Architectures += other.Architectures
+	s.Packages += other.Packages
+	s.Modules += other.Modules
+	s.Interfaces += other.Interfaces
+	s.Classes += other.Classes
+}
+
+// hdlOrNil returns a copy of s for output, or nil when no HDL file was seen.
+func hdlOrNil(s HDLStats) *HDLStats {
+	if s.Files == 0 {
+		return nil
+	}
+	return &s
+}
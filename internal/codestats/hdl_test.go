@@ -0,0 +1,75 @@
+package codestats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountHDLUnitsVHDL(t *testing.T) {
+	src := []byte(`library ieee;
+use ieee.std_logic_1164.all;
+
+package util_pkg is
+  constant WIDTH : integer := 8;
+end package;
+
+-- entity old_counter is
+entity counter is
+  port (clk : in std_logic);
+end entity;
+
+architecture rtl of counter is
+begin
+end architecture;
+
+architecture sim of counter is
+begin
+end architecture;
+`)
+	got := countHDLUnits("VHDL", src)
+	assert.Equal(t, &HDLStats{Files: 1, Entities: 1, Architectures: 2, Packages: 1}, got)
+}
+
+func TestCountHDLUnitsSystemVerilog(t *testing.T) {
+	src := []byte(`package bus_pkg;
+endpackage
+
+interface bus_if (input logic clk);
+endinterface
+
+/* module disabled (input a);
+endmodule */
+module top #(parameter W = 8) (input logic clk);
+endmodule
+
+// module legacy;
+virtual class base_seq;
+endclass
+
+class my_seq extends base_seq;
+endclass
+`)
+	got := countHDLUnits("SystemVerilog", src)
+	assert.Equal(t, &HDLStats{Files: 1, Modules: 1, Interfaces: 1, Packages: 1, Classes: 2}, got)
+}
+
+func TestCountHDLUnitsOtherLanguage(t *testing.T) {
+	assert.Nil(t, countHDLUnits("Go", []byte("package main\n")))
+}
+
+func TestProcessFileAggregatesHDL(t *testing.T) {
+	a := NewAnalyzer(AnalyzerConfig{PerComponent: true})
+	a.ProcessFile("/p/top.v", "Verilog", "", []byte("module top (input a);\nendmodule\n"), "comp", "")
+	a.ProcessFile("/p/alu.v", "Verilog", "", []byte("module alu (input a);\nendmodule\nmodule add (input a);\nendmodule\n"), "comp", "")
+	a.ProcessFile("/p/main.go", "Go", "", []byte("package main\n"), "comp", "")
+
+	assert.Equal(t, &HDLStats{Files: 2, Modules: 3}, a.GetStats().HDL)
+	assert.Equal(t, &HDLStats{Files: 2, Modules: 3}, a.GetComponentStats("comp").HDL)
+}
+
+func TestGetStatsOmitsHDLWithoutHDLFiles(t *testing.T) {
+	a := NewAnalyzer(AnalyzerConfig{})
+	a.ProcessFile("/p/main.go", "Go", "", []byte("package main\n"), "", "")
+	assert.Nil(t, a.GetStats().HDL)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	_ "embed"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 
@@ -23,6 +24,8 @@ type ScanConfig struct {
 	Exclude    []string               `yaml:"exclude,omitempty"`
 	Techs      []ConfigTech           `yaml:"techs,omitempty"`
 	Reclassify []ReclassifyRule       `yaml:"reclassify,omitempty"`
+	Suppress   []SuppressRule         `yaml:"suppress,omitempty"`
+	Scopes     ScopeOverrides         `yaml:"scopes,omitempty"`
 	RootID     string                 `yaml:"root_id,omitempty"` // Override random root ID for deterministic scans
 }
 
@@ -42,6 +45,38 @@ type ReclassifyRule struct {
 	Type     string `yaml:"type,omitempty"`     // Override language type: programming, data, markup, prose
 }
 
+// SuppressRule excludes the detections of a tech under a path prefix, for
+// false positives no rule condition can tell apart.
+type SuppressRule struct {
+	Tech   string `yaml:"tech"`             // Tech key to suppress (e.g. "reactnative")
+	Path   string `yaml:"path,omitempty"`   // Path prefix relative to the scan root; empty = everywhere
+	Reason string `yaml:"reason,omitempty"` // Why the detection is wrong (documentation only)
+}
+
+// ScopeOverrides re-map dependency scopes per ecosystem (dependency type,
+// e.g. "maven"): each entry maps a native scope, such as Maven "provided",
+// or a normalized scope, such as npm "optional", to the normalized scope to
+// report instead.
+type ScopeOverrides map[string]map[string]string
+
+// merge returns the overrides with those of other added, other taking
+// precedence for a scope both map.
+func (o ScopeOverrides) merge(other ScopeOverrides) ScopeOverrides {
+	if len(o) == 0 && len(other) == 0 {
+		return nil
+	}
+	merged := make(ScopeOverrides, len(o)+len(other))
+	for _, overrides := range []ScopeOverrides{o, other} {
+		for ecosystem, scopes := range overrides {
+			if merged[ecosystem] == nil {
+				merged[ecosystem] = make(map[string]string, len(scopes))
+			}
+			maps.Copy(merged[ecosystem], scopes)
+		}
+	}
+	return merged
+}
+
 // LoadConfig attempts to load .stack-analyzer.yml from the scan root
 // Returns nil if file doesn't exist (not an error)
 func LoadConfig(scanPath string) (*ScanConfig, error) {
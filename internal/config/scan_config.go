@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/validation"
@@ -16,6 +17,7 @@ import (
 type ScanOptions struct {
 	// Output settings
 	OutputFile    string   `yaml:"output_file,omitempty" json:"output_file,omitempty" default:"stack-analysis.json"`
+	Outputs       []string `yaml:"outputs,omitempty" json:"outputs,omitempty"` // outputs written from the one scan, "path" or "path:format" (matches repeated --output)
 	PrettyPrint   bool     `yaml:"pretty,omitempty" json:"pretty,omitempty" default:"true"`
 	Paths         []string `yaml:"paths,omitempty" json:"paths,omitempty"`
 	Aggregate     string   `yaml:"aggregate,omitempty" json:"aggregate,omitempty" default:""`
@@ -26,11 +28,16 @@ type ScanOptions struct {
 
 	// Scan behavior
 	ExcludePatterns          []string `yaml:"exclude_patterns,omitempty" json:"exclude_patterns,omitempty"`
+	IncludePaths             []string `yaml:"include_paths,omitempty" json:"include_paths,omitempty"` // only scan these subtrees of the scan root (matches --path)
+	ChangedSince             string   `yaml:"changed_since,omitempty" json:"changed_since,omitempty"` // git ref; rescan only what changed since it (matches --changed-since)
+	Baseline                 string   `yaml:"baseline,omitempty" json:"baseline,omitempty"`           // full scan output the changes are merged into (matches --baseline)
 	Verbose                  bool     `yaml:"verbose,omitempty" json:"verbose,omitempty" default:"false"`
 	Debug                    bool     `yaml:"debug,omitempty" json:"debug,omitempty" default:"false"`
 	TraceTimings             bool     `yaml:"trace_timings,omitempty" json:"trace_timings,omitempty" default:"false"`
 	TraceRules               bool     `yaml:"trace_rules,omitempty" json:"trace_rules,omitempty" default:"false"`
 	FilterRules              []string `yaml:"filter_rules,omitempty" json:"filter_rules,omitempty"`
+	Detectors                []string `yaml:"detectors,omitempty" json:"detectors,omitempty"`                 // only run these component detectors (matches --detectors)
+	DisableDetectors         []string `yaml:"disable_detectors,omitempty" json:"disable_detectors,omitempty"` // component detectors not to run (matches --disable-detectors)
 	NoCodeStats              bool     `yaml:"no_code_stats,omitempty" json:"no_code_stats,omitempty" default:"false"`
 	ComponentStatsDepth      int      `yaml:"component_stats_depth,omitempty" json:"component_stats_depth,omitempty" default:"0"`
 	SubsystemDepth           int      `yaml:"subsystem_depth,omitempty" json:"subsystem_depth,omitempty" default:"0"`
@@ -45,6 +52,30 @@ type ScanOptions struct {
 	MavenLocalRepoDir        string   `yaml:"maven_local_repo_dir,omitempty" json:"maven_local_repo_dir,omitempty"`       // override local Maven repo path (empty = Maven default resolution)
 	MavenRepoURL             string   `yaml:"maven_repo_url,omitempty" json:"maven_repo_url,omitempty"`                   // remote Maven repo base for BOM/parent POM fetch (empty = Maven Central). Token via STACK_ANALYZER_MAVEN_TOKEN env, never in config
 	MavenSettings            string   `yaml:"maven_settings,omitempty" json:"maven_settings,omitempty"`                   // path to a Maven settings.xml (repos + credentials); empty = ~/.m2/settings.xml. Per-scan override
+	InspectArchives          bool     `yaml:"inspect_archives,omitempty" json:"inspect_archives,omitempty"`               // open vendored jar/war/ear, wheel, nupkg archives for embedded package metadata (default false)
+	Parallel                 int      `yaml:"parallel,omitempty" json:"parallel,omitempty" default:"1"`                   // paths of a multi-path scan scanned concurrently (default 1)
+	ParseCache               bool     `yaml:"parse_cache,omitempty" json:"parse_cache,omitempty"`                         // keep parsed lock files in the shared cache DB across scans (default false)
+	ResolveImplied           string   `yaml:"resolve_implied,omitempty" json:"resolve_implied,omitempty" default:"keep"`  // keep | collapse | add
+	Reasons                  string   `yaml:"reasons,omitempty" json:"reasons,omitempty" default:"full"`                  // full | summary | none
+	MinConfidence            float64  `yaml:"min_confidence,omitempty" json:"min_confidence,omitempty"`                   // drop techs scoring below this confidence (0-1; default 0 keeps all)
+	ComponentNaming          string   `yaml:"component_naming,omitempty" json:"component_naming,omitempty"`               // naming sources in order: manifest, directory, repo-path (default all three)
+	MergeImplicit            bool     `yaml:"merge_implicit,omitempty" json:"merge_implicit,omitempty"`                   // fold implicit components into their parent's techs (default false)
+	MergeImplicitMin         int      `yaml:"merge_implicit_min,omitempty" json:"merge_implicit_min,omitempty"`           // implicit siblings needed before folding (default 0 = any)
+	LicenseTextHash          bool     `yaml:"license_text_hash,omitempty" json:"license_text_hash,omitempty"`             // hash license file texts to group custom licenses (default false)
+	ConfigAudit              bool     `yaml:"config_audit,omitempty" json:"config_audit,omitempty"`                       // audit configuration hygiene per component (default false)
+	NoticeText               bool     `yaml:"notice_text,omitempty" json:"notice_text,omitempty"`                         // keep notice file texts in attribution sections (default false)
+	SlowDirThresholdMs       int      `yaml:"slow_dir_threshold_ms,omitempty" json:"slow_dir_threshold_ms,omitempty"`     // report directories slower than this after the scan (default 0 = 500, negative = off)
+	MaxFileSizeMB            int      `yaml:"max_file_size_mb,omitempty" json:"max_file_size_mb,omitempty"`               // files larger than this are not read for content (default 0 = 10, negative = no limit)
+	SkipExtensions           []string `yaml:"skip_extensions,omitempty" json:"skip_extensions,omitempty"`                 // extensions of files not read for content (default media, archives, fonts, executables)
+	CodeStatsSampleMB        int      `yaml:"code_stats_sample_mb,omitempty" json:"code_stats_sample_mb,omitempty"`       // estimate code stats of larger files from a sample (default 0 = off)
+	RulesDir                 string   `yaml:"rules_dir,omitempty" json:"rules_dir,omitempty"`                             // custom YAML rules loaded on top of the embedded rules
+	Adoption                 bool     `yaml:"adoption,omitempty" json:"adoption,omitempty"`                               // date techs and dependencies from sampled git history (default false)
+	AdoptionSamples          int      `yaml:"adoption_samples,omitempty" json:"adoption_samples,omitempty"`               // commits sampled for the adoption timeline (default 0 = 20)
+	AdoptionTags             bool     `yaml:"adoption_tags,omitempty" json:"adoption_tags,omitempty"`                     // sample tagged commits instead of HEAD's history (default false)
+	Vendored                 bool     `yaml:"vendored,omitempty" json:"vendored,omitempty"`                               // compare declared dependencies with vendor directories (default false)
+	JavaImports              bool     `yaml:"java_imports,omitempty" json:"java_imports,omitempty"`                       // detect techs from Java and Kotlin import statements (default false)
+	FailOn                   []string `yaml:"fail_on,omitempty" json:"fail_on,omitempty"`                                 // policy conditions making the scan exit with code 2 (matches --fail-on)
+	ResultSummary            bool     `yaml:"result_summary,omitempty" json:"result_summary,omitempty"`                   // write a one-line JSON outcome summary to stderr (default false)
 }
 
 // SubsystemGroup defines a named group of path prefixes for subsystem stats rollup.
@@ -70,6 +101,12 @@ type ScanConfigFile struct {
 	// Root-level language reclassification rules (consistent with .stack-analyzer.yml)
 	Reclassify []ReclassifyRule `yaml:"reclassify,omitempty" json:"reclassify,omitempty"`
 
+	// Root-level tech suppressions (consistent with .stack-analyzer.yml)
+	Suppress []SuppressRule `yaml:"suppress,omitempty" json:"suppress,omitempty"`
+
+	// Root-level dependency scope overrides (consistent with .stack-analyzer.yml)
+	Scopes ScopeOverrides `yaml:"scopes,omitempty" json:"scopes,omitempty"`
+
 	// Optional named subsystem groups for subsystem_stats rollup.
 	// Keys are group names (e.g. "core-platform"), values define paths and description.
 	// When present, overrides --subsystem-depth — one stat entry per named group.
@@ -183,6 +220,8 @@ func (c *ScanConfigFile) GetMergedConfig(projectConfig *ScanConfig) *ScanConfig
 		Exclude:    make([]string, 0),
 		Techs:      make([]ConfigTech, 0),
 		Reclassify: make([]ReclassifyRule, 0),
+		Suppress:   slices.Clone(c.Suppress),
+		Scopes:     c.Scopes.merge(nil),
 	}
 
 	// Copy from root-level scan config (new flattened structure)
@@ -220,6 +259,8 @@ func (c *ScanConfigFile) GetMergedConfig(projectConfig *ScanConfig) *ScanConfig
 			// Prepend so project rules take priority (first-match-wins)
 			merged.Reclassify = append(projectConfig.Reclassify, merged.Reclassify...)
 		}
+		merged.Suppress = append(merged.Suppress, projectConfig.Suppress...)
+		merged.Scopes = merged.Scopes.merge(projectConfig.Scopes)
 	}
 
 	return merged
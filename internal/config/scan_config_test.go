@@ -230,3 +230,31 @@ func TestExpandEnvVars_UnsetVar(t *testing.T) {
 		t.Errorf("Paths[0]: got %q, want %q", cfg.Scan.Paths[0], "/repo")
 	}
 }
+
+func TestGetMergedConfig_SuppressIsMerged(t *testing.T) {
+	// Suppressions from both configs apply.
+	cfg := &ScanConfigFile{Suppress: []SuppressRule{{Tech: "jquery", Path: "legacy"}}}
+	proj := &ScanConfig{Suppress: []SuppressRule{{Tech: "php"}}}
+
+	got := cfg.GetMergedConfig(proj)
+
+	want := []SuppressRule{{Tech: "jquery", Path: "legacy"}, {Tech: "php"}}
+	if diff := cmp.Diff(want, got.Suppress); diff != "" {
+		t.Errorf("Suppress mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetMergedConfig_ScopesProjectWins(t *testing.T) {
+	cfg := &ScanConfigFile{Scopes: ScopeOverrides{"maven": {"provided": "build", "test": "test"}}}
+	proj := &ScanConfig{Scopes: ScopeOverrides{"maven": {"provided": "prod"}, "npm": {"optional": "prod"}}}
+
+	got := cfg.GetMergedConfig(proj)
+
+	want := ScopeOverrides{"maven": {"provided": "prod", "test": "test"}, "npm": {"optional": "prod"}}
+	if diff := cmp.Diff(want, got.Scopes); diff != "" {
+		t.Errorf("Scopes mismatch (-want +got):\n%s", diff)
+	}
+	if cfg.Scopes["maven"]["provided"] != "build" {
+		t.Error("merging must not modify the scan config")
+	}
+}
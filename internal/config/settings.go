@@ -10,6 +10,7 @@ import (
 
 	"log/slog"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
 	"github.com/petrarca/tech-stack-analyzer/internal/store"
 )
 
@@ -18,17 +19,23 @@ import (
 type Settings struct {
 	// Output settings
 	OutputFile  string
+	Outputs     []string // Outputs written from the one scan, each "path" or "path:format" (--output repeated)
 	PrettyPrint bool
 	Aggregate   string
 
 	// Scan behavior
 	ExcludePatterns          []string
+	IncludePaths             []string // Only scan these subtrees, relative to the scan root (--path); the root keeps its git identity and root ID
+	ChangedSince             string   // Git ref; rescan only the directories changed since it and merge them into Baseline
+	Baseline                 string   // Full scan output --changed-since merges the rescanned directories into
 	Quiet                    bool
 	Verbose                  bool
 	Debug                    bool
 	TraceTimings             bool
 	TraceRules               bool
 	FilterRules              []string                  // Only use these rules (for debugging)
+	Detectors                []string                  // Only run these component detectors (empty = all registered)
+	DisableDetectors         []string                  // Component detectors not to run
 	NoCodeStats              bool                      // Disable code statistics (enabled by default)
 	ComponentStatsDepth      int                       // Collect and include code_stats on components up to this tree depth (0=none, 1=top-level, 2=two levels)
 	SubsystemDepth           int                       // Collect and include subsystem_stats rolled up per depth-N path prefix (0=none, 1=top-level folders)
@@ -48,6 +55,30 @@ type Settings struct {
 	MavenRepoToken           string                    // Token for an authenticated remote Maven repo; sourced from the environment, never persisted
 	MavenRepoUser            string                    // Username for Basic auth against the remote Maven repo; sourced from the environment
 	HarvestLicenseCaches     bool                      // Read out-of-tree global package caches (e.g. ~/.nuget/packages) for per-dependency license harvesting (in-tree sources are always read)
+	InspectArchives          bool                      // Open vendored jar/war/ear, wheel, and nupkg archives to extract embedded package metadata (default false)
+	Parallel                 int                       // Paths of a multi-path scan scanned concurrently (0 or 1 = one scanner walks all paths)
+	ParseCache               bool                      // Keep parsed lock files in the shared cache DB so unchanged ones are not parsed again by later scans
+	ResolveImplied           string                    // Techs implied by another tech of the same component (rule "implies"): "keep" (default), "collapse" or "add"
+	Reasons                  string                    // Detection reasons in the output: "full" (default), "summary" (capped per tech) or "none"
+	MinConfidence            float64                   // Drop techs whose evidence scores below this confidence (0-1; 0 = keep all)
+	ComponentNaming          string                    // Comma-separated component naming sources, tried in order: manifest, directory, repo-path (empty = all three)
+	MergeImplicit            bool                      // Fold implicit components (a tech alone, no dependencies) into their parent's techs
+	MergeImplicitMin         int                       // Implicit sibling components a parent needs before they are folded (0 or 1 = any)
+	LicenseTextHash          bool                      // Hash license file texts and report unidentified license files as LicenseRef-custom
+	ConfigAudit              bool                      // Audit configuration hygiene (env reads, hardcoded endpoints, committed secrets) per component
+	NoticeText               bool                      // Keep the text of NOTICE and third-party notice files in attribution sections
+	SlowDirThresholdMs       int                       // Directories whose own processing takes this many milliseconds are reported after the scan (0 = 500, negative = off)
+	MaxFileSizeMB            int                       // Files larger than this many megabytes are not read for content (0 = 10, negative = no limit)
+	SkipExtensions           []string                  // Extensions of files not read for content (empty = media, archives, fonts and executables; "none" = no type)
+	CodeStatsSampleMB        int                       // Files larger than this many megabytes have their code stats estimated from a sample (0 = off)
+	Adoption                 bool                      // Date each component's techs and direct dependencies from sampled git history
+	AdoptionSamples          int                       // Commits sampled for the adoption timeline (0 = 20)
+	AdoptionTags             bool                      // Sample tagged commits instead of the history of HEAD for the adoption timeline
+	Vendored                 bool                      // Compare declared dependency versions with vendor directories (vendor/, node_modules, site-packages)
+	JavaImports              bool                      // Detect techs from the import statements of Java and Kotlin sources
+	RulesDir                 string                    // Directory of custom YAML rules loaded on top of the embedded rules
+	FailOn                   []string                  // Policy conditions ("tech:<key>", "license:<category>", "audit:<severity>") that make a scan exit with code 2
+	ResultSummary            bool                      // Write a one-line JSON summary of the scan outcome to stderr
 	OmitFields               []string                  // Fields to omit from full output (e.g. "reason", "path", "edges")
 	AlsoAggregate            string                    // Also produce an aggregate output alongside the full output (e.g. "tech,techs,languages")
 	SBOM                     bool                      // Emit an SBOM as the primary output instead of the scan tree
@@ -99,6 +130,12 @@ func LoadSettingsFromEnvironment() *Settings {
 	applyIntEnv(settings)
 	applyListEnv(settings)
 
+	if v := os.Getenv("STACK_ANALYZER_MIN_CONFIDENCE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			settings.MinConfidence = f
+		}
+	}
+
 	// Log level needs parsing/validation (invalid values keep the default).
 	if logLevel := os.Getenv("STACK_ANALYZER_LOG_LEVEL"); logLevel != "" {
 		if level, err := parseLogLevel(logLevel); err == nil {
@@ -121,6 +158,12 @@ func applyStringEnv(s *Settings) {
 		{store.EnvCachePath, &s.CurrencyCache},
 		{"STACK_ANALYZER_LOG_FORMAT", &s.LogFormat},
 		{"STACK_ANALYZER_LOG_FILE", &s.LogFile},
+		{"STACK_ANALYZER_RESOLVE_IMPLIED", &s.ResolveImplied},
+		{"STACK_ANALYZER_REASONS", &s.Reasons},
+		{"STACK_ANALYZER_COMPONENT_NAMING", &s.ComponentNaming},
+		{"STACK_ANALYZER_RULES_DIR", &s.RulesDir},
+		{"STACK_ANALYZER_CHANGED_SINCE", &s.ChangedSince},
+		{"STACK_ANALYZER_BASELINE", &s.Baseline},
 	}
 	for _, e := range strs {
 		if v := os.Getenv(e.env); v != "" {
@@ -142,6 +185,16 @@ func applyBoolEnv(s *Settings) {
 		{"STACK_ANALYZER_NO_CODE_STATS", &s.NoCodeStats},
 		{"STACK_ANALYZER_TRACE_TIMINGS", &s.TraceTimings},
 		{"STACK_ANALYZER_TRACE_RULES", &s.TraceRules},
+		{"STACK_ANALYZER_PARSE_CACHE", &s.ParseCache},
+		{"STACK_ANALYZER_MERGE_IMPLICIT", &s.MergeImplicit},
+		{"STACK_ANALYZER_LICENSE_TEXT_HASH", &s.LicenseTextHash},
+		{"STACK_ANALYZER_CONFIG_AUDIT", &s.ConfigAudit},
+		{"STACK_ANALYZER_NOTICE_TEXT", &s.NoticeText},
+		{"STACK_ANALYZER_ADOPTION", &s.Adoption},
+		{"STACK_ANALYZER_ADOPTION_TAGS", &s.AdoptionTags},
+		{"STACK_ANALYZER_VENDORED", &s.Vendored},
+		{"STACK_ANALYZER_JAVA_IMPORTS", &s.JavaImports},
+		{"STACK_ANALYZER_RESULT_SUMMARY", &s.ResultSummary},
 	}
 	for _, e := range bools {
 		if v := os.Getenv(e.env); v != "" {
@@ -161,6 +214,12 @@ func applyIntEnv(s *Settings) {
 	}{
 		{"STACK_ANALYZER_COMPONENT_STATS_DEPTH", &s.ComponentStatsDepth},
 		{"STACK_ANALYZER_SUBSYSTEM_DEPTH", &s.SubsystemDepth},
+		{"STACK_ANALYZER_PARALLEL", &s.Parallel},
+		{"STACK_ANALYZER_MERGE_IMPLICIT_MIN", &s.MergeImplicitMin},
+		{"STACK_ANALYZER_SLOW_DIR_THRESHOLD_MS", &s.SlowDirThresholdMs},
+		{"STACK_ANALYZER_MAX_FILE_SIZE_MB", &s.MaxFileSizeMB},
+		{"STACK_ANALYZER_CODE_STATS_SAMPLE_MB", &s.CodeStatsSampleMB},
+		{"STACK_ANALYZER_ADOPTION_SAMPLES", &s.AdoptionSamples},
 	}
 	for _, e := range ints {
 		if v := os.Getenv(e.env); v != "" {
@@ -179,6 +238,11 @@ func applyListEnv(s *Settings) {
 	}{
 		{"STACK_ANALYZER_FILTER_RULES", &s.FilterRules},
 		{"STACK_ANALYZER_EXCLUDE", &s.ExcludePatterns},
+		{"STACK_ANALYZER_INCLUDE_PATHS", &s.IncludePaths},
+		{"STACK_ANALYZER_DETECTORS", &s.Detectors},
+		{"STACK_ANALYZER_DISABLE_DETECTORS", &s.DisableDetectors},
+		{"STACK_ANALYZER_FAIL_ON", &s.FailOn},
+		{"STACK_ANALYZER_SKIP_EXTENSIONS", &s.SkipExtensions},
 	}
 	for _, e := range lists {
 		if v := os.Getenv(e.env); v != "" {
@@ -259,14 +323,34 @@ func (s *Settings) Validate() error {
 	if err := s.validateURLs(); err != nil {
 		return err
 	}
+	if err := s.validateComponentNaming(); err != nil {
+		return err
+	}
+	if err := s.validateRanges(); err != nil {
+		return err
+	}
+	if err := s.validateFailOn(); err != nil {
+		return err
+	}
+	return s.validateAggregate()
+}
+
+// validateRanges checks the numeric options with bounds.
+func (s *Settings) validateRanges() error {
+	if s.MergeImplicitMin < 0 {
+		return fmt.Errorf("invalid merge-implicit-min %d: must not be negative", s.MergeImplicitMin)
+	}
+	if s.MinConfidence < 0 || s.MinConfidence > 1 {
+		return fmt.Errorf("invalid min-confidence %g: must be between 0 and 1", s.MinConfidence)
+	}
 	if s.ResolveCurrency && s.CurrencyTTLHours <= 0 {
 		return fmt.Errorf("invalid currency-ttl %d: must be a positive number of hours", s.CurrencyTTLHours)
 	}
-	return s.validateAggregate()
+	return nil
 }
 
-// validateEnums checks the fixed-vocabulary options (dependency-graph mode and
-// SBOM format).
+// validateEnums checks the fixed-vocabulary options (dependency-graph mode,
+// SBOM format, resolve-implied and reasons modes).
 func (s *Settings) validateEnums() error {
 	if s.DependencyGraph != "" {
 		switch s.DependencyGraph {
@@ -282,6 +366,59 @@ func (s *Settings) validateEnums() error {
 			return fmt.Errorf("invalid sbom-format '%s'. Valid values: cyclonedx, spdx", s.SBOMFormat)
 		}
 	}
+	switch s.ResolveImplied {
+	case "", "keep", "collapse", "add":
+	default:
+		return fmt.Errorf("invalid resolve-implied mode '%s'. Valid values: keep, collapse, add", s.ResolveImplied)
+	}
+	switch s.Reasons {
+	case "", "full", "summary", "none":
+	default:
+		return fmt.Errorf("invalid reasons mode '%s'. Valid values: full, summary, none", s.Reasons)
+	}
+	return nil
+}
+
+// validateComponentNaming checks that the component naming sources are
+// known and listed once.
+func (s *Settings) validateComponentNaming() error {
+	seen := make(map[string]bool)
+	for _, source := range strings.Split(s.ComponentNaming, ",") {
+		source = strings.TrimSpace(source)
+		switch {
+		case source == "" && s.ComponentNaming == "":
+		case source != "manifest" && source != "directory" && source != "repo-path":
+			return fmt.Errorf("invalid component-naming source '%s'. Valid values: manifest, directory, repo-path", source)
+		case seen[source]:
+			return fmt.Errorf("invalid component-naming: '%s' is listed twice", source)
+		}
+		seen[source] = true
+	}
+	return nil
+}
+
+// validateFailOn checks that each policy condition is a known kind with a
+// value, and that license categories and audit severities exist.
+func (s *Settings) validateFailOn() error {
+	for _, condition := range s.FailOn {
+		kind, value, _ := strings.Cut(strings.TrimSpace(condition), ":")
+		if value == "" {
+			return fmt.Errorf("invalid fail-on condition '%s': expected tech:<key>, license:<category> or audit:<severity>", condition)
+		}
+		switch kind {
+		case "tech":
+		case "license":
+			if !license.IsCategory(license.Category(value)) {
+				return fmt.Errorf("invalid fail-on license category '%s'. Valid values: forbidden, restricted, reciprocal, notice, permissive, unencumbered, unknown", value)
+			}
+		case "audit":
+			if value != "error" && value != "warning" && value != "info" {
+				return fmt.Errorf("invalid fail-on audit severity '%s'. Valid values: error, warning, info", value)
+			}
+		default:
+			return fmt.Errorf("invalid fail-on condition '%s': kind must be tech, license or audit", condition)
+		}
+	}
 	return nil
 }
 
@@ -79,6 +79,7 @@ func TestLoadSettings_AllEnvironmentVariables(t *testing.T) {
 	t.Setenv("STACK_ANALYZER_TRACE_RULES", "true")
 	t.Setenv("STACK_ANALYZER_FILTER_RULES", "nodejs, rust , go")
 	t.Setenv("STACK_ANALYZER_EXCLUDE", "vendor, build")
+	t.Setenv("STACK_ANALYZER_DISABLE_DETECTORS", "docker, terraform")
 	t.Setenv("STACK_ANALYZER_LOG_LEVEL", "warn")
 	t.Setenv("STACK_ANALYZER_LOG_FORMAT", "json")
 	t.Setenv("STACK_ANALYZER_LOG_FILE", "/tmp/scan.log")
@@ -98,6 +99,7 @@ func TestLoadSettings_AllEnvironmentVariables(t *testing.T) {
 	assert.True(t, s.TraceRules)
 	assert.Equal(t, []string{"nodejs", "rust", "go"}, s.FilterRules)
 	assert.Equal(t, []string{"vendor", "build"}, s.ExcludePatterns)
+	assert.Equal(t, []string{"docker", "terraform"}, s.DisableDetectors)
 	assert.Equal(t, slog.LevelWarn, s.LogLevel)
 	assert.Equal(t, "json", s.LogFormat)
 	assert.Equal(t, "/tmp/scan.log", s.LogFile)
@@ -236,6 +238,16 @@ func TestValidate_Cases(t *testing.T) {
 		{"invalid dependency-graph mode", func(s *Settings) { s.DependencyGraph = "bogus" }, true},
 		{"valid sbom format", func(s *Settings) { s.SBOMFormat = "CycloneDX" }, false},
 		{"invalid sbom format", func(s *Settings) { s.SBOMFormat = "xml" }, true},
+		{"valid resolve-implied mode", func(s *Settings) { s.ResolveImplied = "collapse" }, false},
+		{"invalid resolve-implied mode", func(s *Settings) { s.ResolveImplied = "drop" }, true},
+		{"valid min-confidence", func(s *Settings) { s.MinConfidence = 0.5 }, false},
+		{"min-confidence above 1", func(s *Settings) { s.MinConfidence = 1.5 }, true},
+		{"valid merge-implicit-min", func(s *Settings) { s.MergeImplicit = true; s.MergeImplicitMin = 3 }, false},
+		{"negative merge-implicit-min", func(s *Settings) { s.MergeImplicitMin = -1 }, true},
+		{"valid component naming", func(s *Settings) { s.ComponentNaming = "directory, repo-path" }, false},
+		{"unknown component naming source", func(s *Settings) { s.ComponentNaming = "manifest,package" }, true},
+		{"repeated component naming source", func(s *Settings) { s.ComponentNaming = "directory,directory" }, true},
+		{"empty component naming source", func(s *Settings) { s.ComponentNaming = "manifest,,directory" }, true},
 		{"valid deps-dev endpoint", func(s *Settings) { s.DepsDevEndpoint = "https://api.deps.dev" }, false},
 		{"invalid deps-dev endpoint", func(s *Settings) { s.DepsDevEndpoint = "ftp://x" }, true},
 		{"currency ttl must be positive", func(s *Settings) { s.ResolveCurrency = true; s.CurrencyTTLHours = 0 }, true},
@@ -244,6 +256,11 @@ func TestValidate_Cases(t *testing.T) {
 		{"invalid maven repo url", func(s *Settings) { s.MavenRepoURL = "not a url" }, true},
 		{"valid aggregate fields", func(s *Settings) { s.Aggregate = "tech, techs, all" }, false},
 		{"invalid aggregate field", func(s *Settings) { s.Aggregate = "tech, bogus" }, true},
+		{"valid fail-on conditions", func(s *Settings) { s.FailOn = []string{"tech:mongodb", "license:forbidden", "audit:error"} }, false},
+		{"fail-on condition without value", func(s *Settings) { s.FailOn = []string{"tech"} }, true},
+		{"unknown fail-on kind", func(s *Settings) { s.FailOn = []string{"dependency:lodash"} }, true},
+		{"unknown fail-on license category", func(s *Settings) { s.FailOn = []string{"license:copyleft"} }, true},
+		{"unknown fail-on audit severity", func(s *Settings) { s.FailOn = []string{"audit:critical"} }, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -35,10 +35,11 @@ const createTable = `CREATE TABLE IF NOT EXISTS currency (
 
 // Resolver decorates an inner currency.CurrencyResolver with the SQLite cache.
 type Resolver struct {
-	inner currency.CurrencyResolver
-	db    *sql.DB
-	ttl   time.Duration
-	force bool // when true, skip cache reads (re-fetch), still write back
+	inner    currency.CurrencyResolver
+	db       *sql.DB
+	ttl      time.Duration
+	force    bool // when true, skip cache reads (re-fetch), still write back
+	readOnly bool // answer from the cache alone, expired entries included
 }
 
 // New builds a cache-backed resolver over s, wrapping inner. ttl is the per-entry
@@ -55,10 +56,25 @@ func New(s *store.Store, inner currency.CurrencyResolver, ttl time.Duration, for
 	return &Resolver{inner: inner, db: s.DB(), ttl: ttl, force: force}, nil
 }
 
+// NewReadOnly builds a resolver answering from the cache alone, for reports
+// over latest versions earlier runs resolved: expired entries still answer,
+// a miss is currency.ErrNotFound, and nothing is fetched or written. The
+// currency table is not created; a cache without one misses every lookup.
+func NewReadOnly(s *store.Store) *Resolver {
+	return &Resolver{db: s.DB(), readOnly: true}
+}
+
 // LatestVersion implements currency.CurrencyResolver with a read-through,
 // write-back cache. A cached negative (not_found) within TTL returns
 // currency.ErrNotFound without hitting the network.
 func (r *Resolver) LatestVersion(system, name string) (currency.LatestInfo, error) {
+	if r.readOnly {
+		info, notFound, hit := r.read(system, name)
+		if !hit || notFound {
+			return currency.LatestInfo{}, currency.ErrNotFound
+		}
+		return info, nil
+	}
 	if !r.force {
 		if info, notFound, hit := r.read(system, name); hit {
 			resolvestats.AddCurrencyCacheHit()
@@ -99,7 +115,7 @@ func (r *Resolver) read(system, name string) (currency.LatestInfo, bool, bool) {
 	if err := row.Scan(&latest, &deprecated, &published, &notFound, &fetchedAt, &ttlSeconds); err != nil {
 		return currency.LatestInfo{}, false, false // miss (incl. sql.ErrNoRows)
 	}
-	if time.Now().Unix() > fetchedAt+ttlSeconds {
+	if !r.readOnly && time.Now().Unix() > fetchedAt+ttlSeconds {
 		return currency.LatestInfo{}, false, false // expired -> treat as miss
 	}
 	return currency.LatestInfo{
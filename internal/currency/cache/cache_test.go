@@ -134,3 +134,30 @@ func TestCacheKeepsStaleOnError(t *testing.T) {
 		t.Errorf("stale entry rows = %d, want 1 (error must not delete the entry)", n)
 	}
 }
+
+func TestCacheReadOnly(t *testing.T) {
+	s := openStore(t)
+	if _, err := NewReadOnly(s).LatestVersion("npm", "x"); !errors.Is(err, currency.ErrNotFound) {
+		t.Fatalf("without a currency table: want ErrNotFound, got %v", err)
+	}
+
+	inner := &countingResolver{info: currency.LatestInfo{Latest: "2.0.0"}}
+	r, _ := New(s, inner, time.Second, false)
+	_, _ = r.LatestVersion("npm", "x")
+	if _, err := s.DB().Exec(`UPDATE currency SET fetched_at = fetched_at - 3600`); err != nil {
+		t.Fatalf("expire entry: %v", err)
+	}
+
+	ro := NewReadOnly(s)
+	if info, err := ro.LatestVersion("npm", "x"); err != nil || info.Latest != "2.0.0" {
+		t.Fatalf("expired entry: %+v %v, want 2.0.0", info, err)
+	}
+	if _, err := ro.LatestVersion("npm", "y"); !errors.Is(err, currency.ErrNotFound) {
+		t.Fatalf("miss: want ErrNotFound, got %v", err)
+	}
+	var rows int
+	_ = s.DB().QueryRow(`SELECT COUNT(*) FROM currency`).Scan(&rows)
+	if rows != 1 {
+		t.Errorf("currency rows = %d, want 1 (read-only misses must not be cached)", rows)
+	}
+}
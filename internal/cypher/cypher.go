@@ -0,0 +1,250 @@
+// Package cypher exports a scan output as Cypher statements building a
+// property graph in Neo4j or another openCypher database: components,
+// technologies, licenses and packages become nodes, and containment, tech
+// usage, dependencies and inter-component references relationships.
+package cypher
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Node labels and their key properties.
+const (
+	labelComponent = "Component"
+	labelTech      = "Tech"
+	labelPackage   = "Package"
+	labelLicense   = "License"
+)
+
+var nodeKeys = []struct{ label, key string }{
+	{labelComponent, "id"},
+	{labelTech, "name"},
+	{labelPackage, "id"},
+	{labelLicense, "id"},
+}
+
+// Options tune the export.
+type Options struct {
+	// TechCategories maps techs to their rule category (e.g. "db"), stored
+	// on Tech nodes.
+	TechCategories map[string]string
+}
+
+// prop is a node or relationship property; empty strings are left out.
+type prop struct {
+	name  string
+	value interface{}
+}
+
+type exporter struct {
+	w    *bufio.Writer
+	opts Options
+	seen map[string]bool // label + key of the nodes written
+}
+
+// Write writes the statements for the scan output rooted at root. They MERGE
+// on the node keys (Component.id, Tech.name, Package.id, License.id), so
+// importing a newer scan of the same project updates the graph rather than
+// duplicating it. Relationships:
+//
+//	(Component)-[:CONTAINS]->(Component)        component tree
+//	(Component)-[:USES_TECH {primary}]->(Tech)
+//	(Component)-[:LICENSED_UNDER]->(License)
+//	(Component)-[:DEPENDS_ON {direct, scope}]->(Package)
+//	(Package)-[:DEPENDS_ON {scope}]->(Package)  lockfile dependency graph
+//	(Component)-[:REFERENCES {package}]->(Component)  inter-component dependencies
+//	(Component)-[:LINKS_TO]->(Component)        edges, e.g. to a database
+func Write(w io.Writer, root *types.Payload, opts Options) error {
+	e := &exporter{w: bufio.NewWriter(w), opts: opts, seen: make(map[string]bool)}
+	fmt.Fprintf(e.w, "// Property graph of the %s scan, generated by stack-analyzer\n", root.Name)
+	for _, nk := range nodeKeys {
+		fmt.Fprintf(e.w, "CREATE CONSTRAINT %s_%s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE;\n",
+			strings.ToLower(nk.label), nk.key, nk.label, nk.key)
+	}
+	walk(root, e.writeNodes)
+	walk(root, e.writeRelationships)
+	return e.w.Flush()
+}
+
+func walk(p *types.Payload, visit func(*types.Payload)) {
+	visit(p)
+	for _, child := range p.Children {
+		walk(child, visit)
+	}
+}
+
+func (e *exporter) writeNodes(p *types.Payload) {
+	e.node(labelComponent, p.ID, prop{"name", p.Name}, prop{"type", p.ComponentType},
+		prop{"source_dir", p.SourceDir}, prop{"tech", p.Tech})
+	for _, tech := range p.Techs {
+		e.node(labelTech, tech, prop{"category", e.opts.TechCategories[tech]})
+	}
+	for _, license := range p.Licenses {
+		e.node(labelLicense, license.LicenseName, prop{"category", license.Category})
+	}
+	for _, dep := range p.Dependencies {
+		e.node(labelPackage, packageID(dep.Type, dep.Name, dep.Version),
+			prop{"ecosystem", dep.Type}, prop{"name", dep.Name}, prop{"version", dep.Version})
+	}
+}
+
+func (e *exporter) writeRelationships(p *types.Payload) {
+	component := nodeRef{labelComponent, p.ID}
+	for _, child := range p.Children {
+		e.relationship(component, "CONTAINS", nodeRef{labelComponent, child.ID})
+	}
+	for _, tech := range p.Techs {
+		e.relationship(component, "USES_TECH", nodeRef{labelTech, tech}, prop{"primary", slices.Contains(p.Tech, tech)})
+	}
+	for _, license := range p.Licenses {
+		e.relationship(component, "LICENSED_UNDER", nodeRef{labelLicense, license.LicenseName})
+	}
+	for _, dep := range p.Dependencies {
+		e.relationship(component, "DEPENDS_ON", nodeRef{labelPackage, packageID(dep.Type, dep.Name, dep.Version)},
+			prop{"direct", dep.Direct}, prop{"scope", dep.Scope})
+	}
+	e.writePackageEdges(p)
+	for _, ref := range p.ComponentRefs {
+		e.relationship(component, "REFERENCES", nodeRef{labelComponent, ref.TargetID}, prop{"package", ref.PackageName})
+	}
+	for _, edge := range p.Edges {
+		if edge.Target != nil {
+			e.relationship(component, "LINKS_TO", nodeRef{labelComponent, edge.Target.ID})
+		}
+	}
+}
+
+// writePackageEdges writes the package-to-package edges of a component's
+// dependency graph. Edges from the project itself (".") repeat its direct
+// dependencies and are skipped. Edge endpoints carry no ecosystem; it is
+// taken from the component's dependency of that name and version, or else
+// from the depending package.
+func (e *exporter) writePackageEdges(p *types.Payload) {
+	ecosystems := make(map[string]string, len(p.Dependencies))
+	for _, dep := range p.Dependencies {
+		ecosystems[dep.Name+"@"+dep.Version] = dep.Type
+	}
+	for _, edge := range p.DependencyEdges {
+		fromEcosystem, ok := ecosystems[edge.From]
+		if edge.From == "." || !ok {
+			continue
+		}
+		toEcosystem, ok := ecosystems[edge.To]
+		if !ok {
+			toEcosystem = fromEcosystem
+		}
+		to := packageNodeID(toEcosystem, edge.To)
+		e.node(labelPackage, to, packageProps(toEcosystem, edge.To)...)
+		e.relationship(nodeRef{labelPackage, packageNodeID(fromEcosystem, edge.From)}, "DEPENDS_ON",
+			nodeRef{labelPackage, to}, prop{"scope", edge.Scope})
+	}
+}
+
+// packageID is the key of a Package node, "<ecosystem>:<name>@<version>".
+func packageID(ecosystem, name, version string) string {
+	if version == "" {
+		return ecosystem + ":" + name
+	}
+	return ecosystem + ":" + name + "@" + version
+}
+
+// packageNodeID keys a "name@version" dependency graph endpoint.
+func packageNodeID(ecosystem, nameAtVersion string) string {
+	name, version := splitNameVersion(nameAtVersion)
+	return packageID(ecosystem, name, version)
+}
+
+func packageProps(ecosystem, nameAtVersion string) []prop {
+	name, version := splitNameVersion(nameAtVersion)
+	return []prop{{"ecosystem", ecosystem}, {"name", name}, {"version", version}}
+}
+
+// splitNameVersion splits at the last '@' past the first character, so
+// scoped npm names ("@types/node@20.1.0") keep their '@'.
+func splitNameVersion(s string) (name, version string) {
+	if i := strings.LastIndex(s, "@"); i > 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+type nodeRef struct{ label, key string }
+
+func (r nodeRef) pattern(variable string) string {
+	return fmt.Sprintf("(%s:%s {%s: %s})", variable, r.label, keyProperty(r.label), literal(r.key))
+}
+
+func keyProperty(label string) string {
+	for _, nk := range nodeKeys {
+		if nk.label == label {
+			return nk.key
+		}
+	}
+	return "id"
+}
+
+// node writes a MERGE for a node once per export.
+func (e *exporter) node(label, key string, props ...prop) {
+	if key == "" || e.seen[label+"\x00"+key] {
+		return
+	}
+	e.seen[label+"\x00"+key] = true
+	fmt.Fprintf(e.w, "MERGE %s%s;\n", nodeRef{label, key}.pattern("n"), setClause("n", props))
+}
+
+func (e *exporter) relationship(from nodeRef, relType string, to nodeRef, props ...prop) {
+	if from.key == "" || to.key == "" {
+		return
+	}
+	fmt.Fprintf(e.w, "MATCH %s, %s MERGE (a)-[r:%s]->(b)%s;\n", from.pattern("a"), to.pattern("b"), relType, setClause("r", props))
+}
+
+// setClause returns " SET v += {...}" for the non-empty properties.
+func setClause(variable string, props []prop) string {
+	var fields []string
+	for _, p := range props {
+		if value := literal(p.value); value != "" {
+			fields = append(fields, p.name+": "+value)
+		}
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return " SET " + variable + " += {" + strings.Join(fields, ", ") + "}"
+}
+
+// literal renders a Cypher literal, or "" for empty strings and lists.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return ""
+		}
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		if len(v) == 0 {
+			return ""
+		}
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = quote(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func quote(s string) string {
+	return "'" + quoteReplacer.Replace(s) + "'"
+}
@@ -0,0 +1,80 @@
+package cypher
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayload() *types.Payload {
+	root := types.NewPayloadWithPath("myapp", "/")
+	root.ID = "root"
+	db := types.NewPayloadWithPath("postgresql", "/docker-compose.yml")
+	db.ID = "db"
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.ID = "web"
+	web.ComponentType = "nodejs"
+	web.Tech = []string{"nodejs"}
+	web.Techs = []string{"nodejs", "react"}
+	web.Licenses = []types.License{{LicenseName: "MIT", Category: "permissive"}}
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: "prod", Direct: true},
+		{Type: "npm", Name: "@types/react", Version: "18.2.1", Scope: "dev", Direct: true},
+		{Type: "npm", Name: "loose-envify", Version: "1.4.0", Direct: false},
+	}
+	web.DependencyEdges = []types.DependencyEdge{
+		{From: ".", To: "react@18.2.0"},
+		{From: "react@18.2.0", To: "loose-envify@1.4.0", Scope: "prod"},
+		{From: "loose-envify@1.4.0", To: "js-tokens@4.0.0"},
+	}
+	web.ComponentRefs = []types.ComponentRef{{TargetID: "api", PackageName: "@myorg/api-client"}}
+	web.Edges = []types.Edge{{Target: db}}
+	root.AddChild(web)
+	root.AddChild(db)
+	return root
+}
+
+func export(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testPayload(), Options{TechCategories: map[string]string{"react": "ui"}}))
+	return buf.String()
+}
+
+func TestWriteNodes(t *testing.T) {
+	out := export(t)
+	assert.Contains(t, out, "CREATE CONSTRAINT component_id IF NOT EXISTS FOR (n:Component) REQUIRE n.id IS UNIQUE;\n")
+	assert.Contains(t, out, "MERGE (n:Component {id: 'web'}) SET n += {name: 'web', type: 'nodejs', source_dir: '/web', tech: ['nodejs']};\n")
+	assert.Contains(t, out, "MERGE (n:Tech {name: 'react'}) SET n += {category: 'ui'};\n")
+	assert.Contains(t, out, "MERGE (n:Tech {name: 'nodejs'});\n")
+	assert.Contains(t, out, "MERGE (n:License {id: 'MIT'}) SET n += {category: 'permissive'};\n")
+	assert.Contains(t, out, "MERGE (n:Package {id: 'npm:@types/react@18.2.1'}) SET n += {ecosystem: 'npm', name: '@types/react', version: '18.2.1'};\n")
+	assert.Equal(t, 1, strings.Count(out, "MERGE (n:Package {id: 'npm:react@18.2.0'})"), "nodes are written once")
+}
+
+func TestWriteRelationships(t *testing.T) {
+	out := export(t)
+	for _, want := range []string{
+		"MATCH (a:Component {id: 'root'}), (b:Component {id: 'web'}) MERGE (a)-[r:CONTAINS]->(b);",
+		"MATCH (a:Component {id: 'web'}), (b:Tech {name: 'nodejs'}) MERGE (a)-[r:USES_TECH]->(b) SET r += {primary: true};",
+		"MATCH (a:Component {id: 'web'}), (b:Tech {name: 'react'}) MERGE (a)-[r:USES_TECH]->(b) SET r += {primary: false};",
+		"MATCH (a:Component {id: 'web'}), (b:License {id: 'MIT'}) MERGE (a)-[r:LICENSED_UNDER]->(b);",
+		"MATCH (a:Component {id: 'web'}), (b:Package {id: 'npm:react@18.2.0'}) MERGE (a)-[r:DEPENDS_ON]->(b) SET r += {direct: true, scope: 'prod'};",
+		"MATCH (a:Package {id: 'npm:react@18.2.0'}), (b:Package {id: 'npm:loose-envify@1.4.0'}) MERGE (a)-[r:DEPENDS_ON]->(b) SET r += {scope: 'prod'};",
+		"MATCH (a:Component {id: 'web'}), (b:Component {id: 'api'}) MERGE (a)-[r:REFERENCES]->(b) SET r += {package: '@myorg/api-client'};",
+		"MATCH (a:Component {id: 'web'}), (b:Component {id: 'db'}) MERGE (a)-[r:LINKS_TO]->(b);",
+	} {
+		assert.Contains(t, out, want+"\n")
+	}
+	assert.Contains(t, out, "MERGE (n:Package {id: 'npm:js-tokens@4.0.0'}) SET n += {ecosystem: 'npm', name: 'js-tokens', version: '4.0.0'};\n",
+		"graph endpoints missing from the dependency list take the ecosystem of the depending package")
+	assert.NotContains(t, out, "{id: '.'}")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `'it\'s a \\ path\n'`, quote("it's a \\ path\n"))
+}
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the daemon configuration file: the scan jobs, where results
+// are stored and the address of the status endpoint.
+type Config struct {
+	// Listen is the address of the status endpoint (e.g. ":8080" or
+	// "127.0.0.1:9090"). Empty disables it.
+	Listen  string        `yaml:"listen"`
+	Results ResultsConfig `yaml:"results"`
+	Jobs    []Job         `yaml:"jobs"`
+}
+
+// ResultsConfig lists the sinks every successful scan is stored in. At
+// least one is required.
+type ResultsConfig struct {
+	// Dir receives <job>-<timestamp>.json per run and <job>-latest.json.
+	Dir string `yaml:"dir"`
+	// SQLite is a database file; runs are appended to its scan_results table.
+	SQLite string `yaml:"sqlite"`
+	// HTTP posts each result as JSON to a URL.
+	HTTP *HTTPConfig `yaml:"http"`
+}
+
+// HTTPConfig is the endpoint results are published to. Header values may
+// reference environment variables ($VAR or ${VAR}) so tokens stay out of the
+// configuration file.
+type HTTPConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// Job is one scheduled scan.
+type Job struct {
+	Name     string `yaml:"name"`
+	Schedule string `yaml:"schedule"`
+	// Paths are scanned as one project, as by "scan path...".
+	Paths []string `yaml:"paths"`
+	// Config is a scan configuration file or inline JSON, as for "scan --config".
+	Config    string   `yaml:"config"`
+	Exclude   []string `yaml:"exclude"`
+	Aggregate string   `yaml:"aggregate"`
+	RootID    string   `yaml:"root_id"`
+	// RunOnStart runs the job when the daemon starts, before its first
+	// scheduled time.
+	RunOnStart bool `yaml:"run_on_start"`
+
+	schedule *Schedule
+}
+
+// LoadConfig reads and validates a daemon configuration file. Unknown keys
+// are rejected.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // config path is chosen by the operator
+	if err != nil {
+		return nil, fmt.Errorf("read daemon config: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a daemon configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parse daemon config: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("daemon config: no jobs")
+	}
+	if err := c.Results.validate(); err != nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for i := range c.Jobs {
+		job := &c.Jobs[i]
+		if err := job.validate(); err != nil {
+			return err
+		}
+		if names[job.Name] {
+			return fmt.Errorf("daemon config: duplicate job %q", job.Name)
+		}
+		names[job.Name] = true
+	}
+	return nil
+}
+
+func (r ResultsConfig) validate() error {
+	if r.Dir == "" && r.SQLite == "" && r.HTTP == nil {
+		return fmt.Errorf("daemon config: results needs at least one of dir, sqlite or http")
+	}
+	if r.HTTP == nil {
+		return nil
+	}
+	u, err := url.Parse(r.HTTP.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("daemon config: results.http.url must be an http(s) URL")
+	}
+	if u.User != nil {
+		return fmt.Errorf("daemon config: results.http.url must not contain credentials; use headers with environment variables")
+	}
+	return nil
+}
+
+func (j *Job) validate() error {
+	if j.Name == "" || strings.ContainsAny(j.Name, `/\`) || j.Name == "." || j.Name == ".." {
+		return fmt.Errorf("daemon config: job name %q must be non-empty and not contain path separators", j.Name)
+	}
+	if len(j.Paths) == 0 {
+		return fmt.Errorf("daemon config: job %q has no paths", j.Name)
+	}
+	schedule, err := ParseSchedule(j.Schedule)
+	if err != nil {
+		return fmt.Errorf("daemon config: job %q: %w", j.Name, err)
+	}
+	j.schedule = schedule
+	return nil
+}
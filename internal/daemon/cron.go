@@ -0,0 +1,200 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule decides when a job runs next: a five-field cron expression
+// (minute hour day-of-month month day-of-week), one of the macros @hourly,
+// @daily, @midnight, @weekly, @monthly, @yearly and @annually, or a fixed
+// interval written "@every <duration>" (e.g. "@every 6h").
+type Schedule struct {
+	expr  string
+	every time.Duration
+
+	minute, hour, dom, month, dow uint64 // bit i set: value i allowed
+	domStar, dowStar              bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// cronField describes the range and value names of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day-of-month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: monthNames}
+	// Day-of-week accepts 7 as well as 0 for Sunday.
+	dowField = cronField{name: "day-of-week", min: 0, max: 7, names: dayNames}
+)
+
+// ParseSchedule parses a cron expression, macro or @every interval.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || d < time.Minute {
+			return nil, fmt.Errorf("schedule %q: @every needs a duration of at least 1m", expr)
+		}
+		return &Schedule{expr: expr, every: d}, nil
+	}
+	spec := expr
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+	s := &Schedule{expr: expr, domStar: strings.HasPrefix(fields[2], "*"), dowStar: strings.HasPrefix(fields[4], "*")}
+	targets := []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, f := range []cronField{minuteField, hourField, domField, monthField, dowField} {
+		bits, err := f.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", expr, err)
+		}
+		*targets[i] = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// parse returns the allowed values of a comma-separated list of values,
+// ranges (a-b) and steps (*/n, a-b/n, a/n).
+func (f cronField) parse(spec string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(spec, ",") {
+		lo, hi, step, err := f.parseRange(part)
+		if err != nil {
+			return 0, err
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) parseRange(part string) (lo, hi, step int, err error) {
+	rng, stepSpec, hasStep := strings.Cut(part, "/")
+	if step, err = f.step(stepSpec, hasStep); err != nil {
+		return 0, 0, 0, err
+	}
+	switch {
+	case rng == "*":
+		return f.min, f.max, step, nil
+	case strings.Contains(rng, "-"):
+		from, to, _ := strings.Cut(rng, "-")
+		if lo, err = f.value(from); err != nil {
+			return 0, 0, 0, err
+		}
+		if hi, err = f.value(to); err != nil {
+			return 0, 0, 0, err
+		}
+	default:
+		if lo, err = f.value(rng); err != nil {
+			return 0, 0, 0, err
+		}
+		hi = lo
+		if hasStep {
+			hi = f.max
+		}
+	}
+	if lo > hi {
+		return 0, 0, 0, fmt.Errorf("%s: invalid range %q", f.name, rng)
+	}
+	return lo, hi, step, nil
+}
+
+func (f cronField) step(spec string, present bool) (int, error) {
+	if !present {
+		return 1, nil
+	}
+	step, err := strconv.Atoi(spec)
+	if err != nil || step < 1 {
+		return 0, fmt.Errorf("%s: invalid step %q", f.name, spec)
+	}
+	return step, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: %q is not in %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t the schedule fires, in t's location.
+// Cron schedules fire on whole minutes. A cron expression that never
+// matches (e.g. 30 February) returns the zero time.
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	// Every valid date recurs within five years (29 February included).
+	limit := next.AddDate(5, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case !has(s.month, int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !s.dayMatches(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !has(s.hour, next.Hour()):
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+		case !has(s.minute, next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the cron rule for the two day fields: when both are
+// restricted, a day matching either one is enough.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domOK := has(s.dom, t.Day())
+	dowOK := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
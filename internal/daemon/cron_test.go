@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	s, err := ParseSchedule(expr)
+	require.NoError(t, err, expr)
+	return s
+}
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNext(t *testing.T) {
+	tests := []struct {
+		expr, from, want string
+	}{
+		{"*/15 * * * *", "2026-03-10 10:07", "2026-03-10 10:15"},
+		{"0 2 * * *", "2026-03-10 10:07", "2026-03-11 02:00"},
+		{"0 2 * * *", "2026-03-10 01:59", "2026-03-10 02:00"},
+		{"30 9 * * mon-fri", "2026-03-13 10:00", "2026-03-16 09:30"}, // Friday -> Monday
+		{"0 0 1 jan *", "2026-03-10 00:00", "2027-01-01 00:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+		{"0 12 * * 7", "2026-03-10 00:00", "2026-03-15 12:00"}, // 7 is Sunday
+		{"0 8,18 * * *", "2026-03-10 09:00", "2026-03-10 18:00"},
+		{"0 0 13 * fri", "2026-03-10 00:00", "2026-03-13 00:00"}, // day-of-month or day-of-week
+		{"@weekly", "2026-03-10 00:00", "2026-03-15 00:00"},
+		{"@hourly", "2026-03-10 10:00", "2026-03-10 11:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got := mustParse(t, tt.expr).Next(at(tt.from))
+			assert.Equal(t, at(tt.want), got)
+		})
+	}
+}
+
+func TestScheduleEvery(t *testing.T) {
+	s := mustParse(t, "@every 6h")
+	assert.Equal(t, at("2026-03-10 16:07"), s.Next(at("2026-03-10 10:07")))
+	assert.Equal(t, "@every 6h", s.String())
+}
+
+func TestScheduleNeverFires(t *testing.T) {
+	assert.True(t, mustParse(t, "0 0 30 2 *").Next(at("2026-03-10 00:00")).IsZero())
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "@every 10s", "@every soon"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,217 @@
+// Package daemon runs scans on cron schedules. It reads a configuration of
+// jobs (paths and a schedule each), runs due jobs one at a time, stores the
+// results in the configured sinks (files, a SQLite database, an HTTP
+// endpoint) and reports the state of every job on a status endpoint.
+//
+// The daemon does not scan by itself: the caller supplies a ScanFunc, so the
+// package stays independent of the scan command's flags and output options.
+package daemon
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ScanFunc scans the paths of a job and returns the output JSON.
+type ScanFunc func(ctx context.Context, job Job) ([]byte, error)
+
+// JobStatus is the state of a job as reported by the status endpoint.
+type JobStatus struct {
+	Name       string     `json:"name"`
+	Schedule   string     `json:"schedule"`
+	Paths      []string   `json:"paths"`
+	Running    bool       `json:"running"`
+	NextRun    *time.Time `json:"next_run,omitempty"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	LastStatus string     `json:"last_status,omitempty"` // ok or error
+	LastError  string     `json:"last_error,omitempty"`
+	DurationMS int64      `json:"last_duration_ms,omitempty"`
+	Runs       int        `json:"runs"`
+	Failures   int        `json:"failures"`
+}
+
+// Daemon schedules and runs the jobs of a configuration.
+type Daemon struct {
+	jobs   []Job
+	scan   ScanFunc
+	sinks  []Sink
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	status map[string]*JobStatus
+	next   map[string]time.Time
+}
+
+// New creates a daemon for the jobs of cfg, storing results in sinks.
+func New(cfg *Config, scan ScanFunc, sinks []Sink, logger *slog.Logger) *Daemon {
+	d := &Daemon{
+		jobs:   cfg.Jobs,
+		scan:   scan,
+		sinks:  sinks,
+		logger: logger,
+		now:    time.Now,
+		status: make(map[string]*JobStatus),
+		next:   make(map[string]time.Time),
+	}
+	for _, job := range cfg.Jobs {
+		d.status[job.Name] = &JobStatus{Name: job.Name, Schedule: job.Schedule, Paths: job.Paths}
+	}
+	return d
+}
+
+// Run schedules the jobs until ctx is cancelled. Due jobs run one at a
+// time, in configuration order; a run that ends after the job's next
+// scheduled time skips the missed times rather than catching up.
+func (d *Daemon) Run(ctx context.Context) error {
+	d.scheduleAll(d.now())
+	for {
+		wait := time.Until(d.earliest())
+		timer := time.NewTimer(max(wait, 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		for _, job := range d.jobs {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if d.due(job.Name) {
+				d.RunJob(ctx, job)
+				d.schedule(job, d.now())
+			}
+		}
+	}
+}
+
+// RunAll runs every job once, in configuration order, and returns the
+// number of failed runs.
+func (d *Daemon) RunAll(ctx context.Context) int {
+	failed := 0
+	for _, job := range d.jobs {
+		if !d.RunJob(ctx, job) {
+			failed++
+		}
+	}
+	return failed
+}
+
+// RunJob scans a job and stores the result in every sink. It reports
+// whether the scan and all sinks succeeded; a failing sink does not stop
+// the others.
+func (d *Daemon) RunJob(ctx context.Context, job Job) bool {
+	started := d.now()
+	d.update(job.Name, func(s *JobStatus) { s.Running = true })
+	d.logger.Info("Scan started", "job", job.Name, "paths", job.Paths)
+
+	data, err := d.scan(ctx, job)
+	duration := d.now().Sub(started)
+	if err == nil {
+		err = d.store(ctx, Result{Job: job.Name, Started: started, Duration: duration, Data: data})
+	}
+
+	d.update(job.Name, func(s *JobStatus) {
+		s.Running = false
+		s.LastRun = &started
+		s.DurationMS = duration.Milliseconds()
+		s.Runs++
+		s.LastStatus, s.LastError = "ok", ""
+		if err != nil {
+			s.Failures++
+			s.LastStatus, s.LastError = "error", err.Error()
+		}
+	})
+	if err != nil {
+		d.logger.Error("Scan failed", "job", job.Name, "duration", duration, "error", err)
+		return false
+	}
+	d.logger.Info("Scan finished", "job", job.Name, "duration", duration, "bytes", len(data))
+	return true
+}
+
+// store passes the result to every sink and returns the first error.
+func (d *Daemon) store(ctx context.Context, result Result) error {
+	var first error
+	for _, sink := range d.sinks {
+		if err := sink.Store(ctx, result); err != nil {
+			d.logger.Error("Failed to store result", "job", result.Job, "sink", sink.Name(), "error", err)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// Status returns the state of every job, in configuration order.
+func (d *Daemon) Status() []JobStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]JobStatus, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		s := *d.status[job.Name]
+		if next, ok := d.next[job.Name]; ok && !next.IsZero() {
+			s.NextRun = &next
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// scheduleAll sets the first run of every job; jobs with run_on_start run
+// right away.
+func (d *Daemon) scheduleAll(now time.Time) {
+	for _, job := range d.jobs {
+		if job.RunOnStart {
+			d.mu.Lock()
+			d.next[job.Name] = now
+			d.mu.Unlock()
+			continue
+		}
+		d.schedule(job, now)
+	}
+}
+
+func (d *Daemon) schedule(job Job, after time.Time) {
+	next := job.schedule.Next(after)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.next[job.Name] = next
+	if next.IsZero() {
+		d.logger.Warn("Schedule never fires", "job", job.Name, "schedule", job.Schedule)
+	}
+}
+
+// earliest returns the next time any job is due. Jobs whose schedule never
+// fires are ignored; with none left, it is a day from now.
+func (d *Daemon) earliest() time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var earliest time.Time
+	for _, next := range d.next {
+		if !next.IsZero() && (earliest.IsZero() || next.Before(earliest)) {
+			earliest = next
+		}
+	}
+	if earliest.IsZero() {
+		return d.now().Add(24 * time.Hour)
+	}
+	return earliest
+}
+
+func (d *Daemon) due(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	next := d.next[name]
+	return !next.IsZero() && !next.After(d.now())
+}
+
+func (d *Daemon) update(name string, fn func(*JobStatus)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn(d.status[name])
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T, jobs ...string) *Config {
+	t.Helper()
+	cfg := &Config{Results: ResultsConfig{Dir: t.TempDir()}}
+	for _, name := range jobs {
+		cfg.Jobs = append(cfg.Jobs, Job{Name: name, Schedule: "@daily", Paths: []string{"/repos/" + name}})
+	}
+	require.NoError(t, cfg.validate())
+	return cfg
+}
+
+func quietLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestRunJobStoresResultAndStatus(t *testing.T) {
+	cfg := testConfig(t, "myapp", "broken")
+	scan := func(_ context.Context, job Job) ([]byte, error) {
+		if job.Name == "broken" {
+			return nil, errors.New("path missing")
+		}
+		return []byte(`{"name":"myapp"}`), nil
+	}
+	d := New(cfg, scan, []Sink{&FileSink{Dir: cfg.Results.Dir}}, quietLogger())
+	d.now = func() time.Time { return time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC) }
+
+	assert.Equal(t, 1, d.RunAll(context.Background()))
+
+	data, err := os.ReadFile(filepath.Join(cfg.Results.Dir, "myapp-latest.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"myapp"}`, string(data))
+	assert.FileExists(t, filepath.Join(cfg.Results.Dir, "myapp-20260310T020000Z.json"))
+
+	status := d.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, "ok", status[0].LastStatus)
+	assert.Equal(t, 1, status[0].Runs)
+	assert.Equal(t, "error", status[1].LastStatus)
+	assert.Equal(t, "path missing", status[1].LastError)
+	assert.Equal(t, 1, status[1].Failures)
+}
+
+func TestRunOnStartAndShutdown(t *testing.T) {
+	cfg := testConfig(t, "myapp")
+	cfg.Jobs[0].RunOnStart = true
+	ctx, cancel := context.WithCancel(context.Background())
+	scan := func(context.Context, Job) ([]byte, error) {
+		cancel()
+		return []byte(`{}`), nil
+	}
+	d := New(cfg, scan, []Sink{&FileSink{Dir: cfg.Results.Dir}}, quietLogger())
+
+	done := make(chan error)
+	go func() { done <- d.Run(ctx) }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+	status := d.Status()[0]
+	assert.Equal(t, 1, status.Runs)
+	require.NotNil(t, status.NextRun)
+	assert.True(t, status.NextRun.After(time.Now()))
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Setenv("STACK_ANALYZER_TEST_TOKEN", "s3cret")
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPSink(&HTTPConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer ${STACK_ANALYZER_TEST_TOKEN}"}})
+	err := sink.Store(context.Background(), Result{Job: "myapp", Started: time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC), Data: []byte(`{}`)})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "Bearer s3cret", got.Header.Get("Authorization"))
+	assert.Equal(t, "myapp", got.Header.Get("X-Stack-Analyzer-Job"))
+	assert.Equal(t, "2026-03-10T02:00:00Z", got.Header.Get("X-Stack-Analyzer-Started"))
+	assert.Equal(t, "{}", string(body))
+}
+
+func TestHTTPSinkRejectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "token s3cret invalid", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	err := NewHTTPSink(&HTTPConfig{URL: srv.URL}).Store(context.Background(), Result{Job: "myapp", Data: []byte(`{}`)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.NotContains(t, err.Error(), "s3cret")
+}
+
+func TestStatusHandler(t *testing.T) {
+	cfg := testConfig(t, "myapp")
+	d := New(cfg, nil, nil, quietLogger())
+	d.schedule(cfg.Jobs[0], time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC))
+	h := d.Handler(time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp statusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Jobs, 1)
+	assert.Equal(t, "@daily", resp.Jobs[0].Schedule)
+	assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), resp.Jobs[0].NextRun.UTC())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, "ok\n", rec.Body.String())
+}
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/store"
+)
+
+// StoredResult is a scan result read back from a results database.
+type StoredResult struct {
+	Job     string
+	Started time.Time
+	Data    []byte // scan output JSON
+}
+
+const (
+	selectResults = `SELECT job, started_at, result FROM scan_results ORDER BY job, started_at`
+	selectLatest  = `SELECT job, started_at, result FROM scan_results r
+		WHERE started_at = (SELECT MAX(started_at) FROM scan_results WHERE job = r.job)
+		ORDER BY job`
+)
+
+// ReadResults calls visit for the results of the database at path written
+// by an SQLiteSink, by job and oldest first, or only the latest of each job
+// with latestOnly. It does not create a missing database.
+func ReadResults(path string, latestOnly bool, visit func(StoredResult) error) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("results database: %w", err)
+	}
+	st, err := store.Open(path, 5000)
+	if err != nil {
+		return fmt.Errorf("open results database: %w", err)
+	}
+	defer func() { _ = st.Close() }()
+
+	query := selectResults
+	if latestOnly {
+		query = selectLatest
+	}
+	rows, err := st.DB().Query(query)
+	if err != nil {
+		return fmt.Errorf("results database: %w (is it a daemon results database?)", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		result, err := scanResult(rows)
+		if err != nil {
+			return err
+		}
+		if err := visit(result); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func scanResult(rows *sql.Rows) (StoredResult, error) {
+	var result StoredResult
+	var started, data string
+	if err := rows.Scan(&result.Job, &started, &data); err != nil {
+		return result, fmt.Errorf("results database: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339, started)
+	if err != nil {
+		return result, fmt.Errorf("results database: job %s: started_at: %w", result.Job, err)
+	}
+	result.Started = t
+	result.Data = []byte(data)
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+	"github.com/petrarca/tech-stack-analyzer/internal/store"
+)
+
+// Result is the output of one successful scan run.
+type Result struct {
+	Job      string
+	Started  time.Time
+	Duration time.Duration
+	Data     []byte // scan output JSON
+}
+
+// Sink stores scan results.
+type Sink interface {
+	Name() string
+	Store(ctx context.Context, result Result) error
+}
+
+// resultTimeFormat names result files; it sorts chronologically and is
+// valid in file names on all platforms.
+const resultTimeFormat = "20060102T150405Z"
+
+// FileSink writes each result to <dir>/<job>-<timestamp>.json and replaces
+// <dir>/<job>-latest.json.
+type FileSink struct {
+	Dir string
+}
+
+// Name implements Sink.
+func (s *FileSink) Name() string { return "file" }
+
+// Store implements Sink. The latest file is replaced by rename, so readers
+// never see a partial result.
+func (s *FileSink) Store(_ context.Context, result Result) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	stamped := filepath.Join(s.Dir, result.Job+"-"+result.Started.UTC().Format(resultTimeFormat)+".json")
+	if err := os.WriteFile(stamped, result.Data, 0o644); err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.Dir, "."+result.Job+"-latest.json.tmp")
+	if err := os.WriteFile(tmp, result.Data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.Dir, result.Job+"-latest.json"))
+}
+
+const createResultsTable = `CREATE TABLE IF NOT EXISTS scan_results (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	job         TEXT NOT NULL,
+	started_at  TEXT NOT NULL,
+	duration_ms INTEGER NOT NULL,
+	result      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS scan_results_job ON scan_results (job, started_at);`
+
+// SQLiteSink appends results to the scan_results table of a store
+// database, which it owns.
+type SQLiteSink struct {
+	store *store.Store
+}
+
+// NewSQLiteSink opens (or creates) the database at path.
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	st, err := store.Open(path, 5000)
+	if err != nil {
+		return nil, fmt.Errorf("open results database: %w", err)
+	}
+	if _, err := st.DB().Exec(createResultsTable); err != nil {
+		_ = st.Close()
+		return nil, fmt.Errorf("results database: create table: %w", err)
+	}
+	return &SQLiteSink{store: st}, nil
+}
+
+// Name implements Sink.
+func (s *SQLiteSink) Name() string { return "sqlite" }
+
+// Store implements Sink.
+func (s *SQLiteSink) Store(ctx context.Context, result Result) error {
+	_, err := s.store.DB().ExecContext(ctx,
+		`INSERT INTO scan_results (job, started_at, duration_ms, result) VALUES (?, ?, ?, ?)`,
+		result.Job, result.Started.UTC().Format(time.RFC3339), result.Duration.Milliseconds(), string(result.Data))
+	return err
+}
+
+// Close closes the database.
+func (s *SQLiteSink) Close() error {
+	return s.store.Close()
+}
+
+// HTTPSink posts each result to a URL. The job name and start time are
+// sent as the X-Stack-Analyzer-Job and X-Stack-Analyzer-Started headers.
+type HTTPSink struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// NewHTTPSink creates a sink for cfg, expanding environment variables in
+// the header values.
+func NewHTTPSink(cfg *HTTPConfig) *HTTPSink {
+	headers := make(map[string]string, len(cfg.Headers))
+	for k, v := range cfg.Headers {
+		headers[k] = os.ExpandEnv(v)
+	}
+	return &HTTPSink{URL: cfg.URL, Headers: headers, Client: httpclient.New(2 * time.Minute)}
+}
+
+// Name implements Sink.
+func (s *HTTPSink) Name() string { return "http" }
+
+// Store implements Sink. Any status other than 2xx is an error; the
+// response body is not included, as it may echo the request.
+func (s *HTTPSink) Store(ctx context.Context, result Result) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(result.Data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Stack-Analyzer-Job", result.Job)
+	req.Header.Set("X-Stack-Analyzer-Started", result.Started.UTC().Format(time.RFC3339))
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("publish to %s: %s", s.URL, resp.Status)
+	}
+	return nil
+}
+
+// NewSinks creates the sinks of a results configuration. The returned close
+// function releases the SQLite database, if any.
+func NewSinks(cfg ResultsConfig) ([]Sink, func() error, error) {
+	var sinks []Sink
+	closeFn := func() error { return nil }
+	if cfg.Dir != "" {
+		sinks = append(sinks, &FileSink{Dir: cfg.Dir})
+	}
+	if cfg.SQLite != "" {
+		s, err := NewSQLiteSink(cfg.SQLite)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, s)
+		closeFn = s.Close
+	}
+	if cfg.HTTP != nil {
+		sinks = append(sinks, NewHTTPSink(cfg.HTTP))
+	}
+	return sinks, closeFn, nil
+}
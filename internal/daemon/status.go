@@ -0,0 +1,30 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// statusResponse is the body of GET /status.
+type statusResponse struct {
+	Started time.Time   `json:"started"`
+	Jobs    []JobStatus `json:"jobs"`
+}
+
+// Handler returns the status endpoint: GET /status reports every job as
+// JSON, GET /healthz answers "ok" while the daemon is running.
+func (d *Daemon) Handler(started time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(statusResponse{Started: started, Jobs: d.Status()})
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ChangedPaths returns the files changed between ref and HEAD of the
+// repository holding path, as slash-separated paths relative to path. The
+// comparison starts at the merge base of ref and HEAD, as "git diff
+// ref...HEAD" does, so changes made on ref's branch meanwhile are not
+// reported. Files outside path are left out; renamed files are reported
+// under both names.
+func ChangedPaths(path, ref string) ([]string, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("open git repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("open git worktree: %w", err)
+	}
+	prefix, err := filepath.Rel(worktree.Filesystem.Root(), path)
+	if err != nil {
+		return nil, fmt.Errorf("locate %s in its repository: %w", path, err)
+	}
+
+	from, to, err := comparedTrees(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s against HEAD: %w", ref, err)
+	}
+	return changedUnder(changes, filepath.ToSlash(prefix)), nil
+}
+
+// comparedTrees returns the trees of the merge base of ref and HEAD, or of
+// ref when they share no history, and of HEAD.
+func comparedTrees(repo *git.Repository, ref string) (*object.Tree, *object.Tree, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	base, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read commit %s: %w", ref, err)
+	}
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve HEAD: %w", err)
+	}
+	head, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("read HEAD commit: %w", err)
+	}
+	if bases, err := base.MergeBase(head); err == nil && len(bases) > 0 {
+		base = bases[0]
+	}
+
+	from, err := base.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read tree of %s: %w", ref, err)
+	}
+	to, err := head.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read tree of HEAD: %w", err)
+	}
+	return from, to, nil
+}
+
+// changedUnder lists the files of changes below the slash-separated prefix
+// ("." for the repository root), relative to it and sorted.
+func changedUnder(changes object.Changes, prefix string) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			rel, ok := strings.CutPrefix(name, prefix+"/")
+			if prefix == "." {
+				rel, ok = name, true
+			}
+			if name == "" || !ok || seen[rel] {
+				continue
+			}
+			seen[rel] = true
+			paths = append(paths, rel)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
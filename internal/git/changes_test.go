@@ -0,0 +1,61 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commitFiles(t *testing.T, repo *git.Repository, root string, files map[string]string) {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+	}
+	_, err = worktree.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "dev", Email: "dev@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+}
+
+func TestChangedPaths(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+	commitFiles(t, repo, root, map[string]string{
+		"README.md":                 "# myapp\n",
+		"services/api/package.json": `{"name":"api"}`,
+		"services/web/go.mod":       "module example.com/web\n",
+	})
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("base"), head.Hash())))
+
+	commitFiles(t, repo, root, map[string]string{
+		"services/api/package.json": `{"name":"api","version":"2.0.0"}`,
+		"docs/guide.md":             "guide\n",
+	})
+
+	changed, err := ChangedPaths(root, "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/guide.md", "services/api/package.json"}, changed)
+
+	changed, err = ChangedPaths(filepath.Join(root, "services"), "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/package.json"}, changed, "paths are relative to the scanned directory")
+
+	_, err = ChangedPaths(root, "no-such-ref")
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Revision is a commit sampled from the history of a repository, with the
+// tree of files it holds.
+type Revision struct {
+	Hash string
+	Time time.Time // commit time
+	Tag  string    // tag pointing at the commit, if any
+	Tree *object.Tree
+}
+
+// SampleHistory returns up to limit commits of the repository holding path,
+// oldest first, and the root of its worktree. Commits are taken evenly
+// spaced from the first-parent history of HEAD, or from the tagged commits
+// when tags is set; the oldest one and HEAD are always included.
+func SampleHistory(path string, limit int, tags bool) (string, []Revision, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", nil, fmt.Errorf("open git repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", nil, fmt.Errorf("open git worktree: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve HEAD: %w", err)
+	}
+	tagged, err := tagsByCommit(repo)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var commits []*object.Commit
+	if tags {
+		commits, err = taggedCommits(repo, tagged, head.Hash())
+	} else {
+		commits, err = firstParentHistory(repo, head.Hash())
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	var revisions []Revision
+	for _, commit := range sampleEvenly(commits, limit) {
+		tree, err := commit.Tree()
+		if err != nil {
+			return "", nil, fmt.Errorf("read tree of %s: %w", commit.Hash, err)
+		}
+		revisions = append(revisions, Revision{
+			Hash: commit.Hash.String(),
+			Time: commit.Committer.When,
+			Tag:  tagged[commit.Hash],
+			Tree: tree,
+		})
+	}
+	return worktree.Filesystem.Root(), revisions, nil
+}
+
+// firstParentHistory returns the commits reached from head by following
+// first parents, oldest first.
+func firstParentHistory(repo *git.Repository, head plumbing.Hash) ([]*object.Commit, error) {
+	commit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("read HEAD commit: %w", err)
+	}
+	var commits []*object.Commit
+	for {
+		commits = append(commits, commit)
+		if commit.NumParents() == 0 {
+			break
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return nil, fmt.Errorf("read parent of %s: %w", commits[len(commits)-1].Hash, err)
+		}
+	}
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// taggedCommits returns the tagged commits and HEAD, oldest first.
+func taggedCommits(repo *git.Repository, tagged map[plumbing.Hash]string, head plumbing.Hash) ([]*object.Commit, error) {
+	var commits []*object.Commit
+	for hash := range tagged {
+		if hash == head {
+			continue
+		}
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("read tagged commit %s: %w", hash, err)
+		}
+		commits = append(commits, commit)
+	}
+	sort.Slice(commits, func(i, j int) bool { return commits[i].Committer.When.Before(commits[j].Committer.When) })
+	commit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("read HEAD commit: %w", err)
+	}
+	return append(commits, commit), nil
+}
+
+// tagsByCommit maps commits to a tag pointing at them, the first by name when
+// several do. Tags of other objects than commits are left out.
+func tagsByCommit(repo *git.Repository) (map[plumbing.Hash]string, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	tagged := make(map[plumbing.Hash]string)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repo.TagObject(hash); err == nil {
+			commit, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = commit.Hash
+		} else if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return err
+		}
+		if name := ref.Name().Short(); tagged[hash] == "" || name < tagged[hash] {
+			tagged[hash] = name
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	return tagged, nil
+}
+
+// sampleEvenly picks up to limit commits evenly spaced over commits, keeping
+// the first and the last.
+func sampleEvenly(commits []*object.Commit, limit int) []*object.Commit {
+	if limit <= 0 || len(commits) <= limit {
+		return commits
+	}
+	if limit == 1 {
+		return commits[len(commits)-1:]
+	}
+	sampled := make([]*object.Commit, 0, limit)
+	for i := 0; i < limit; i++ {
+		sampled = append(sampled, commits[i*(len(commits)-1)/(limit-1)])
+	}
+	return sampled
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleHistory(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+	var hashes []string
+	for i := 0; i < 5; i++ {
+		commitFiles(t, repo, root, map[string]string{"VERSION": fmt.Sprintf("%d\n", i)})
+		head, err := repo.Head()
+		require.NoError(t, err)
+		hashes = append(hashes, head.Hash().String())
+		if i == 1 {
+			_, err = repo.CreateTag("v0.1.0", head.Hash(), nil)
+			require.NoError(t, err)
+		}
+	}
+
+	gotRoot, revisions, err := SampleHistory(root, 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, root, gotRoot)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, []string{hashes[0], hashes[2], hashes[4]}, []string{revisions[0].Hash, revisions[1].Hash, revisions[2].Hash})
+
+	_, revisions, err = SampleHistory(root, 10, true)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2, "the tagged commit and HEAD")
+	assert.Equal(t, hashes[1], revisions[0].Hash)
+	assert.Equal(t, "v0.1.0", revisions[0].Tag)
+	assert.Equal(t, hashes[4], revisions[1].Hash)
+
+	file, err := revisions[0].Tree.File("VERSION")
+	require.NoError(t, err)
+	content, err := file.Contents()
+	require.NoError(t, err)
+	assert.Equal(t, "1\n", content)
+}
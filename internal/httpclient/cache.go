@@ -0,0 +1,166 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCacheEntry is the largest response body kept; larger ones, such
+	// as image layers, are streamed through.
+	maxCacheEntry = 4 << 20
+	// maxCacheSize bounds the cache; the oldest entries are evicted first.
+	maxCacheSize = 64 << 20
+)
+
+// cache keeps successful GET responses in memory for a TTL.
+type cache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	order   []string // insertion order, for eviction
+	size    int
+}
+
+type cacheEntry struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{ttl: ttl, entries: make(map[string]*cacheEntry)}
+}
+
+// cacheable reports whether a request may be answered from the cache.
+func cacheable(req *http.Request) bool {
+	return req.Method == http.MethodGet && req.Header.Get("Range") == "" &&
+		!hasDirective(req.Header, "no-cache") && !hasDirective(req.Header, "no-store")
+}
+
+// cacheKey identifies a request by URL and headers, so responses fetched
+// with one set of credentials are never served to another. The headers are
+// hashed to keep credentials out of the key.
+func cacheKey(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name + ":" + strings.Join(req.Header.Values(name), ",") + "\n"))
+	}
+	return req.URL.String() + " " + hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached response for key, or nil.
+func (c *cache) get(key string, req *http.Request) *http.Response {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || time.Now().After(e.expires) {
+		return nil
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// store keeps resp when it is a small, reusable 200 and returns a response
+// equivalent to resp for the caller.
+func (c *cache) store(key string, resp *http.Response) *http.Response {
+	ttl := c.responseTTL(resp)
+	if ttl <= 0 || resp.ContentLength > maxCacheEntry {
+		return resp
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCacheEntry+1))
+	if err != nil || len(body) > maxCacheEntry {
+		// Too large or failed: hand back what was read plus the rest.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.entries[key]; ok {
+		c.size -= len(old.body)
+	} else {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = &cacheEntry{header: resp.Header.Clone(), body: body, expires: time.Now().Add(ttl)}
+	c.size += len(body)
+	c.evict()
+	return resp
+}
+
+// evict drops the oldest entries while the cache is over its size.
+func (c *cache) evict() {
+	for c.size > maxCacheSize && len(c.order) > 0 {
+		key := c.order[0]
+		c.order = c.order[1:]
+		if e, ok := c.entries[key]; ok {
+			c.size -= len(e.body)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// responseTTL is how long resp may be reused: the configured TTL, capped
+// by max-age, and zero for anything but a plain 200 or a response the
+// server marks as not reusable.
+func (c *cache) responseTTL(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Set-Cookie") != "" ||
+		hasDirective(resp.Header, "no-store") || hasDirective(resp.Header, "no-cache") {
+		return 0
+	}
+	ttl := c.ttl
+	if age, ok := maxAge(resp.Header); ok && age < ttl {
+		ttl = age
+	}
+	return ttl
+}
+
+func hasDirective(h http.Header, name string) bool {
+	for _, d := range strings.Split(h.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(d), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func maxAge(h http.Header) (time.Duration, bool) {
+	for _, d := range strings.Split(h.Get("Cache-Control"), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(d), "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		if secs, err := strconv.Atoi(value); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,147 @@
+// Package httpclient is the network layer shared by every feature that
+// talks to a remote service: dependency resolution, currency lookups,
+// registry pulls, organization listing and clones, and result sinks. All
+// clients go through one transport, so a single configuration applies a
+// global rate limit, a proxy and a response cache, and the offline mode
+// refuses every request before it leaves the process.
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrOffline is returned for every request while offline mode is on.
+var ErrOffline = errors.New("network access is disabled (--offline)")
+
+// DefaultCacheTTL is how long GET responses are reused unless configured
+// otherwise.
+const DefaultCacheTTL = 10 * time.Minute
+
+// Config configures the shared transport.
+type Config struct {
+	// Offline refuses every request with ErrOffline.
+	Offline bool
+	// RateLimit is the number of requests per second across all clients;
+	// 0 is unlimited.
+	RateLimit float64
+	// Proxy is the proxy URL for all requests. When empty, HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY apply.
+	Proxy string
+	// CacheTTL is how long successful GET responses are reused; 0 disables
+	// the cache. Responses marked no-store or no-cache are never reused.
+	CacheTTL time.Duration
+}
+
+// current is the transport of every client; Configure replaces it.
+var current atomic.Pointer[transport]
+
+func init() {
+	current.Store(newTransport(Config{}, nil))
+}
+
+// LoadConfigFromEnvironment reads the configuration from
+// STACK_ANALYZER_OFFLINE, STACK_ANALYZER_RATE_LIMIT, STACK_ANALYZER_PROXY and
+// STACK_ANALYZER_HTTP_CACHE_TTL (a duration such as 5m, or 0 to disable).
+func LoadConfigFromEnvironment() (Config, error) {
+	cfg := Config{
+		Offline:  strings.EqualFold(os.Getenv("STACK_ANALYZER_OFFLINE"), "true"),
+		Proxy:    os.Getenv("STACK_ANALYZER_PROXY"),
+		CacheTTL: DefaultCacheTTL,
+	}
+	if v := os.Getenv("STACK_ANALYZER_RATE_LIMIT"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid STACK_ANALYZER_RATE_LIMIT %q: %w", v, err)
+		}
+		cfg.RateLimit = rate
+	}
+	if v := os.Getenv("STACK_ANALYZER_HTTP_CACHE_TTL"); v != "" {
+		ttl, err := parseTTL(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid STACK_ANALYZER_HTTP_CACHE_TTL %q: %w", v, err)
+		}
+		cfg.CacheTTL = ttl
+	}
+	return cfg, nil
+}
+
+func parseTTL(v string) (time.Duration, error) {
+	if v == "0" {
+		return 0, nil
+	}
+	return time.ParseDuration(v)
+}
+
+// Configure validates cfg and applies it to every client, including those
+// created before the call.
+func Configure(cfg Config) error {
+	if cfg.RateLimit < 0 {
+		return fmt.Errorf("invalid rate limit %g: must not be negative", cfg.RateLimit)
+	}
+	if cfg.CacheTTL < 0 {
+		return fmt.Errorf("invalid HTTP cache TTL %s: must not be negative", cfg.CacheTTL)
+	}
+	var proxy *url.URL
+	if cfg.Proxy != "" {
+		u, err := parseProxy(cfg.Proxy)
+		if err != nil {
+			return err
+		}
+		proxy = u
+	}
+	current.Store(newTransport(cfg, proxy))
+	return nil
+}
+
+// parseProxy accepts http, https and socks5 proxy URLs. Errors never echo
+// the URL, which may carry credentials.
+func parseProxy(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.New("invalid proxy URL")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New("invalid proxy URL: scheme must be http, https or socks5")
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid proxy URL: missing host")
+	}
+	return u, nil
+}
+
+// Offline reports whether offline mode is on.
+func Offline() bool {
+	return current.Load().cfg.Offline
+}
+
+// New returns a client with the given timeout (0 for none) using the
+// shared transport and its response cache.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: roundTripper{cached: true}}
+}
+
+// NewUncached is New without the response cache, for protocols whose GET
+// responses must not be reused, such as git fetches.
+func NewUncached(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: roundTripper{}}
+}
+
+// roundTripper sends each request through the transport current at the
+// time, so a later Configure also covers existing clients.
+type roundTripper struct {
+	cached bool
+}
+
+func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return current.Load().roundTrip(req, r.cached)
+}
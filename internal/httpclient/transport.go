@@ -0,0 +1,110 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// transport applies one Config: offline mode, then the cache, then the rate
+// limit in front of a standard HTTP transport.
+type transport struct {
+	cfg     Config
+	base    http.RoundTripper
+	limiter *limiter
+	cache   *cache
+}
+
+func newTransport(cfg Config, proxy *url.URL) *transport {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != nil {
+		base.Proxy = http.ProxyURL(proxy)
+	}
+	t := &transport{cfg: cfg, base: base}
+	if cfg.RateLimit > 0 {
+		t.limiter = newLimiter(cfg.RateLimit)
+	}
+	if cfg.CacheTTL > 0 {
+		t.cache = newCache(cfg.CacheTTL)
+	}
+	return t
+}
+
+func (t *transport) roundTrip(req *http.Request, cached bool) (*http.Response, error) {
+	if t.cfg.Offline {
+		closeBody(req)
+		return nil, ErrOffline
+	}
+	c := t.cache
+	if !cached || !cacheable(req) {
+		c = nil
+	}
+	var key string
+	if c != nil {
+		key = cacheKey(req)
+		if resp := c.get(key, req); resp != nil {
+			return resp, nil
+		}
+	}
+	if err := t.limiter.wait(req.Context()); err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || c == nil {
+		return resp, err
+	}
+	return c.store(key, resp), nil
+}
+
+// closeBody honours the RoundTripper contract of closing the request body
+// even when the request is not sent.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// limiter spaces requests to a rate, allowing bursts of up to one second's
+// worth (GCRA: tat is the theoretical arrival time of the next request).
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	tau      time.Duration
+	tat      time.Time
+}
+
+func newLimiter(rate float64) *limiter {
+	interval := time.Duration(float64(time.Second) / rate)
+	burst := max(int(rate), 1)
+	return &limiter{interval: interval, tau: time.Duration(burst-1) * interval}
+}
+
+// wait blocks until the next request may be sent. A nil limiter never
+// waits.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.tat.Before(now) {
+		l.tat = now
+	}
+	delay := l.tat.Sub(now) - l.tau
+	l.tat = l.tat.Add(l.interval)
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
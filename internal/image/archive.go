@@ -0,0 +1,134 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// dockerSaveEntry is one image in a `docker save` manifest.json.
+type dockerSaveEntry struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// OpenArchive opens a `docker save` tarball (manifest.json) or an OCI image
+// layout tarball (index.json + blobs/). Layers are streamed from the tarball on
+// demand, so the file is not copied.
+func OpenArchive(file string) (*Image, error) {
+	a := &tarArchive{file: file}
+	if data, err := a.read("manifest.json"); err == nil {
+		return a.openDockerSave(data)
+	}
+	data, err := a.read("index.json")
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a docker save nor an OCI layout tarball", file)
+	}
+	return a.openOCILayout(data)
+}
+
+func (a *tarArchive) openDockerSave(data []byte) (*Image, error) {
+	var entries []dockerSaveEntry
+	if err := json.Unmarshal(data, &entries); err != nil || len(entries) == 0 {
+		return nil, fmt.Errorf("parse docker save manifest.json: invalid or empty")
+	}
+	entry := entries[0]
+	img := &Image{Info: Info{Reference: a.file, Source: "archive", Layers: len(entry.Layers)}}
+	if len(entry.RepoTags) > 0 {
+		img.Info.Reference = entry.RepoTags[0]
+	}
+	cfg, err := a.read(entry.Config)
+	if err != nil {
+		return nil, fmt.Errorf("read image config: %w", err)
+	}
+	if err := img.applyConfig(cfg); err != nil {
+		return nil, err
+	}
+	for _, layer := range entry.Layers {
+		img.layers = append(img.layers, a.opener(layer))
+	}
+	return img, nil
+}
+
+func (a *tarArchive) openOCILayout(index []byte) (*Image, error) {
+	fetch := func(digest string) ([]byte, error) { return a.read(blobPath(digest)) }
+	m, digest, err := resolveManifest(index, fetch, "")
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{Info: Info{Reference: a.file, Source: "archive", Digest: digest, Layers: len(m.Layers)}}
+	cfg, err := fetch(m.Config.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("read image config: %w", err)
+	}
+	if err := img.applyConfig(cfg); err != nil {
+		return nil, err
+	}
+	for _, layer := range m.Layers {
+		img.layers = append(img.layers, a.opener(blobPath(layer.Digest)))
+	}
+	return img, nil
+}
+
+// blobPath maps a digest ("sha256:abc") to its OCI layout location.
+func blobPath(digest string) string {
+	algo, hex, _ := strings.Cut(digest, ":")
+	return path.Join("blobs", algo, hex)
+}
+
+// tarArchive gives named access to the members of an image tarball.
+type tarArchive struct {
+	file string
+}
+
+// read returns a small member (manifest, index, or config) in full.
+func (a *tarArchive) read(name string) ([]byte, error) {
+	rc, err := a.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxManifestOrConfigBytes))
+}
+
+func (a *tarArchive) opener(name string) layerOpener {
+	return func() (io.ReadCloser, error) {
+		rc, err := a.open(name)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(rc)
+	}
+}
+
+// open positions a reader at the named member. Names are compared after
+// cleaning so "./manifest.json" and "manifest.json" are the same member.
+func (a *tarArchive) open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(a.file)
+	if err != nil {
+		return nil, err
+	}
+	want := path.Clean(name)
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Clean(hdr.Name) == want {
+			return readCloser{Reader: tr, closers: []io.Closer{f}}, nil
+		}
+	}
+	_ = f.Close()
+	return nil, fmt.Errorf("%s: member %s not found", a.file, name)
+}
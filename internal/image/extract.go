@@ -0,0 +1,156 @@
+package image
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxExtractedFileSize bounds a single extracted file. Larger files (database
+// dumps, model weights, bundled toolchains) are skipped: the detectors only
+// need manifests and source, and skipping keeps the unpacked image small.
+const maxExtractedFileSize = 64 << 20 // 64 MiB
+
+// Whiteout markers used by OCI/AUFS layers to delete lower-layer content.
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// Extract applies every layer, base first, into dir so the result is the
+// image's final filesystem. Symlinks, devices and other special files are not
+// materialized: they carry no content for the detectors and a symlink could
+// point outside dir.
+func (img *Image) Extract(dir string) error {
+	for i, open := range img.layers {
+		if err := applyLayer(dir, open); err != nil {
+			return fmt.Errorf("layer %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func applyLayer(dir string, open layerOpener) error {
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := applyEntry(dir, hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// applyEntry materializes one layer entry. Entries whose cleaned path would
+// leave dir are ignored.
+func applyEntry(dir string, hdr *tar.Header, r io.Reader) error {
+	rel, ok := safeRelPath(hdr.Name)
+	if !ok {
+		return nil
+	}
+	target := filepath.Join(dir, filepath.FromSlash(rel))
+	base := path.Base(rel)
+
+	switch {
+	case base == whiteoutOpaque:
+		return clearDir(filepath.Dir(target))
+	case strings.HasPrefix(base, whiteoutPrefix):
+		return removeWhiteout(filepath.Dir(target), strings.TrimPrefix(base, whiteoutPrefix))
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if fi, err := os.Lstat(target); err == nil && !fi.IsDir() {
+			_ = os.Remove(target)
+		}
+		return os.MkdirAll(target, 0o755)
+	case tar.TypeReg:
+		if hdr.Size > maxExtractedFileSize {
+			return nil
+		}
+		return writeFile(target, r)
+	case tar.TypeLink:
+		return linkFile(dir, target, hdr.Linkname)
+	}
+	return nil
+}
+
+// safeRelPath cleans a layer path to a relative slash path inside the root.
+func safeRelPath(name string) (string, bool) {
+	rel := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if rel == "" || rel == "." {
+		return "", false
+	}
+	return rel, true
+}
+
+func writeFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	// Replace whatever a lower layer left (file or directory).
+	_ = os.RemoveAll(target)
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, io.LimitReader(r, maxExtractedFileSize)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// linkFile materializes a hard link by copying its target, which must already
+// exist inside the root.
+func linkFile(dir, target, linkname string) error {
+	rel, ok := safeRelPath(linkname)
+	if !ok {
+		return nil
+	}
+	src, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil // target skipped (too large or special); nothing to copy
+	}
+	defer src.Close()
+	return writeFile(target, src)
+}
+
+// removeWhiteout deletes the lower-layer entry hidden by a ".wh.<name>" marker.
+func removeWhiteout(parent, name string) error {
+	if name == "" || name == "." || name == ".." {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(parent, name))
+}
+
+// clearDir removes the contents of a directory (opaque whiteout) but keeps
+// the directory itself.
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
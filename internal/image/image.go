@@ -0,0 +1,140 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Info describes the scanned image. It is recorded under properties.image of
+// the image component.
+type Info struct {
+	Reference    string   `json:"reference"`
+	Source       string   `json:"source"` // "registry" or "archive"
+	Digest       string   `json:"digest,omitempty"`
+	OS           string   `json:"os,omitempty"`
+	Architecture string   `json:"architecture,omitempty"`
+	Layers       int      `json:"layers"`
+	Distro       string   `json:"distro,omitempty"`
+	DistroVer    string   `json:"distro_version,omitempty"`
+	Entrypoint   []string `json:"entrypoint,omitempty"`
+	Cmd          []string `json:"cmd,omitempty"`
+	ExposedPorts []string `json:"exposed_ports,omitempty"`
+}
+
+// layerOpener opens one (possibly compressed) layer tar stream.
+type layerOpener func() (io.ReadCloser, error)
+
+// Image is an opened image: its metadata and a way to stream each layer in
+// order, base layer first.
+type Image struct {
+	Info   Info
+	layers []layerOpener
+}
+
+// Options configures how images are fetched.
+type Options struct {
+	// Platform selects the manifest from a multi-platform index ("os/arch" or
+	// "os/arch/variant"). Empty uses linux/amd64.
+	Platform string
+	// Username/Token authenticate against a private registry. Both are read from
+	// the environment by the caller and never written to the output.
+	Username string
+	Token    string
+	// Client overrides the HTTP client (nil uses a default with a timeout).
+	Client httpDoer
+}
+
+// imageConfig is the subset of the OCI image configuration we read.
+type imageConfig struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Config       struct {
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+	} `json:"config"`
+}
+
+// Open resolves an image: an existing local file is read as a `docker save` or
+// OCI layout tarball, anything else is pulled from its registry.
+func Open(ctx context.Context, ref string, opts Options) (*Image, error) {
+	if st, err := os.Stat(ref); err == nil && !st.IsDir() {
+		return OpenArchive(ref)
+	}
+	parsed, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	return Pull(ctx, parsed, opts)
+}
+
+// applyConfig copies the image configuration into the image info.
+func (img *Image) applyConfig(data []byte) error {
+	var cfg imageConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("parse image config: %w", err)
+	}
+	img.Info.OS = cfg.OS
+	img.Info.Architecture = cfg.Architecture
+	img.Info.Entrypoint = cfg.Config.Entrypoint
+	img.Info.Cmd = cfg.Config.Cmd
+	for port := range cfg.Config.ExposedPorts {
+		img.Info.ExposedPorts = append(img.Info.ExposedPorts, port)
+	}
+	sort.Strings(img.Info.ExposedPorts)
+	return nil
+}
+
+// decompress wraps a layer stream, transparently un-gzipping it. Layers may be
+// stored compressed or not regardless of what the file name or media type
+// claims, so the gzip magic is sniffed.
+func decompress(rc io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(rc)
+	magic, err := br.Peek(4)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = rc.Close()
+		return nil, err
+	}
+	if bytes.HasPrefix(magic, zstdMagic) {
+		_ = rc.Close()
+		return nil, errors.New("zstd-compressed layers are not supported")
+	}
+	if bytes.HasPrefix(magic, gzipMagic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+		return readCloser{Reader: gz, closers: []io.Closer{gz, rc}}, nil
+	}
+	return readCloser{Reader: br, closers: []io.Closer{rc}}, nil
+}
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// readCloser reads from Reader and closes every underlying stream.
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r readCloser) Close() error {
+	var first error
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
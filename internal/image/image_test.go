@@ -0,0 +1,292 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tarEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+func buildTar(t *testing.T, entries []tarEntry, gz bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w = &buf
+	var gzw *gzip.Writer
+	tw := tar.NewWriter(w)
+	if gz {
+		gzw = gzip.NewWriter(w)
+		tw = tar.NewWriter(gzw)
+	}
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.body)), Typeflag: e.typeflag, Linkname: e.linkname}
+		if e.typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte(e.body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	if gzw != nil {
+		require.NoError(t, gzw.Close())
+	}
+	return buf.Bytes()
+}
+
+const testConfig = `{"os":"linux","architecture":"amd64","config":{"Entrypoint":["/app/server"],"ExposedPorts":{"8080/tcp":{}}}}`
+
+func baseLayer(t *testing.T) []byte {
+	return buildTar(t, []tarEntry{
+		{name: "etc/", typeflag: tar.TypeDir},
+		{name: "etc/os-release", body: "ID=alpine\nVERSION_ID=3.20.0\n"},
+		{name: "lib/apk/db/installed", body: "P:musl\nV:1.2.5-r0\nL:MIT\n\nP:busybox\nV:1.36.1-r29\nL:GPL-2.0-only\n"},
+		{name: "app/old.txt", body: "removed later"},
+		{name: "cache/", typeflag: tar.TypeDir},
+		{name: "cache/stale", body: "x"},
+		{name: "../escape.txt", body: "must stay inside the root"},
+		{name: "app/link", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+	}, true)
+}
+
+func appLayer(t *testing.T) []byte {
+	return buildTar(t, []tarEntry{
+		{name: "app/.wh.old.txt"},
+		{name: "cache/.wh..wh..opq"},
+		{name: "app/package.json", body: `{"name":"myapp","dependencies":{"express":"4.18.2"}}`},
+		{name: "app/package-copy.json", typeflag: tar.TypeLink, linkname: "app/package.json"},
+	}, false)
+}
+
+func writeDockerSave(t *testing.T) string {
+	t.Helper()
+	manifest := `[{"Config":"config.json","RepoTags":["registry.example.com/myorg/myapp:1.0"],"Layers":["l1/layer.tar","l2/layer.tar"]}]`
+	archive := buildTar(t, []tarEntry{
+		{name: "manifest.json", body: manifest},
+		{name: "config.json", body: testConfig},
+		{name: "l1/layer.tar", body: string(baseLayer(t))},
+		{name: "l2/layer.tar", body: string(appLayer(t))},
+	}, false)
+	file := filepath.Join(t.TempDir(), "myapp.tar")
+	require.NoError(t, os.WriteFile(file, archive, 0o644))
+	return file
+}
+
+func assertMissing(t *testing.T, file, msg string) {
+	t.Helper()
+	_, err := os.Lstat(file)
+	assert.True(t, os.IsNotExist(err), msg)
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Reference
+	}{
+		{"alpine", Reference{Registry: "docker.io", Repository: "library/alpine", Tag: "latest"}},
+		{"myorg/myapp:1.0", Reference{Registry: "docker.io", Repository: "myorg/myapp", Tag: "1.0"}},
+		{"registry.example.com:5000/myorg/myapp:2.1", Reference{Registry: "registry.example.com:5000", Repository: "myorg/myapp", Tag: "2.1"}},
+		{"localhost/myapp@sha256:abc", Reference{Registry: "localhost", Repository: "myapp", Digest: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseReference(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := ParseReference("MyOrg/App")
+	assert.Error(t, err, "uppercase repositories are invalid")
+	_, err = ParseReference("")
+	assert.Error(t, err)
+}
+
+func TestOpenArchive_DockerSaveAndExtract(t *testing.T) {
+	img, err := Open(context.Background(), writeDockerSave(t), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "registry.example.com/myorg/myapp:1.0", img.Info.Reference)
+	assert.Equal(t, "archive", img.Info.Source)
+	assert.Equal(t, "linux", img.Info.OS)
+	assert.Equal(t, 2, img.Info.Layers)
+	assert.Equal(t, []string{"/app/server"}, img.Info.Entrypoint)
+	assert.Equal(t, []string{"8080/tcp"}, img.Info.ExposedPorts)
+
+	root := t.TempDir()
+	require.NoError(t, img.Extract(root))
+
+	assert.FileExists(t, filepath.Join(root, "app", "package.json"))
+	assert.FileExists(t, filepath.Join(root, "app", "package-copy.json"), "hard links are materialized as copies")
+	assertMissing(t, filepath.Join(root, "app", "old.txt"), "whiteout removes lower-layer file")
+	assertMissing(t, filepath.Join(root, "cache", "stale"), "opaque whiteout clears the directory")
+	info, err := os.Stat(filepath.Join(root, "cache"))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assertMissing(t, filepath.Join(filepath.Dir(root), "escape.txt"), "entries cannot escape the root")
+	assert.FileExists(t, filepath.Join(root, "escape.txt"))
+	assertMissing(t, filepath.Join(root, "app", "link"), "symlinks are not materialized")
+
+	distro := ReadDistro(root)
+	assert.Equal(t, Distro{ID: "alpine", VersionID: "3.20.0"}, distro)
+	deps := OSPackages(root, distro)
+	require.Len(t, deps, 2)
+	assert.Equal(t, "busybox", deps[0].Name)
+	assert.Equal(t, "apk", deps[1].Type)
+	assert.Equal(t, "musl", deps[1].Name)
+	assert.Equal(t, "1.2.5-r0", deps[1].Version)
+	assert.Equal(t, "alpine", deps[1].Metadata["distro"])
+	assert.Equal(t, "MIT", license.DependencyLicense(deps[1]))
+}
+
+func TestOpenArchive_NotAnImage(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "other.tar")
+	require.NoError(t, os.WriteFile(file, buildTar(t, []tarEntry{{name: "README", body: "x"}}, false), 0o644))
+	_, err := OpenArchive(file)
+	assert.Error(t, err)
+}
+
+func TestDpkgPackages(t *testing.T) {
+	root := t.TempDir()
+	status := "Package: libc6\nStatus: install ok installed\nVersion: 2.36-9\nDescription: GNU C Library\n multi-line\n\n" +
+		"Package: oldpkg\nStatus: deinstall ok config-files\nVersion: 1.0\n"
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "var/lib/dpkg/status.d"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, dpkgStatusPath), []byte(status), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, dpkgStatusDir, "tzdata"), []byte("Package: tzdata\nVersion: 2024a-0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, dpkgStatusDir, "tzdata.md5sums"), []byte("abc  usr/share/zoneinfo\n"), 0o644))
+
+	deps := OSPackages(root, Distro{ID: "debian"})
+	require.Len(t, deps, 2)
+	assert.Equal(t, "libc6", deps[0].Name)
+	assert.Equal(t, "2.36-9", deps[0].Version)
+	assert.Equal(t, "deb", deps[0].Type)
+	assert.Equal(t, "tzdata", deps[1].Name)
+	assert.Equal(t, "/var/lib/dpkg/status.d/tzdata", deps[1].Metadata["source"])
+}
+
+// rpmHeader builds a minimal rpm header blob with string and int32 entries.
+func rpmHeader(strs map[uint32]string, epoch uint32) []byte {
+	var index, data bytes.Buffer
+	entry := func(tag, typ uint32, off int) {
+		for _, v := range []uint32{tag, typ, uint32(off), 1} {
+			_ = binary.Write(&index, binary.BigEndian, v)
+		}
+	}
+	for tag, s := range strs {
+		entry(tag, rpmTypeString, data.Len())
+		data.WriteString(s)
+		data.WriteByte(0)
+	}
+	if epoch > 0 {
+		entry(rpmTagEpoch, rpmTypeInt32, data.Len())
+		_ = binary.Write(&data, binary.BigEndian, epoch)
+	}
+	count := len(strs)
+	if epoch > 0 {
+		count++
+	}
+	var blob bytes.Buffer
+	_ = binary.Write(&blob, binary.BigEndian, uint32(count))
+	_ = binary.Write(&blob, binary.BigEndian, uint32(data.Len()))
+	blob.Write(index.Bytes())
+	blob.Write(data.Bytes())
+	return blob.Bytes()
+}
+
+func TestParseRpmHeader(t *testing.T) {
+	blob := rpmHeader(map[uint32]string{
+		rpmTagName: "openssl-libs", rpmTagVersion: "3.0.7", rpmTagRelease: "27.el9", rpmTagLicense: "Apache-2.0",
+	}, 1)
+	dep, ok := parseRpmHeader(blob)
+	require.True(t, ok)
+	assert.Equal(t, "rpm", dep.Type)
+	assert.Equal(t, "openssl-libs", dep.Name)
+	assert.Equal(t, "1:3.0.7-27.el9", dep.Version)
+	assert.Equal(t, "Apache-2.0", license.DependencyLicense(dep))
+
+	_, ok = parseRpmHeader(rpmHeader(map[uint32]string{rpmTagName: "gpg-pubkey"}, 0))
+	assert.False(t, ok, "gpg-pubkey entries are keys, not packages")
+	_, ok = parseRpmHeader([]byte{0, 0, 0, 9})
+	assert.False(t, ok, "truncated header")
+}
+
+func TestPull_TokenHandshakeAndPlatformIndex(t *testing.T) {
+	layer := buildTar(t, []tarEntry{{name: "app/go.mod", body: "module example.com/myapp\n"}}, true)
+	index := `{"mediaType":"` + mediaTypeOCIIndex + `","manifests":[
+	  {"digest":"sha256:arm","platform":{"os":"linux","architecture":"arm64"}},
+	  {"digest":"sha256:amd","platform":{"os":"linux","architecture":"amd64"}}]}`
+	amd := `{"mediaType":"` + mediaTypeOCIManifest + `","config":{"digest":"sha256:cfg"},"layers":[{"digest":"sha256:layer"}]}`
+
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "repository:myorg/myapp:pull", r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "t0k"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0k" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="registry.example.com"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/v2/myorg/myapp") {
+		case "/manifests/1.0":
+			_, _ = w.Write([]byte(index))
+		case "/manifests/sha256:amd":
+			_, _ = w.Write([]byte(amd))
+		case "/blobs/sha256:cfg":
+			_, _ = w.Write([]byte(testConfig))
+		case "/blobs/sha256:layer":
+			_, _ = w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ref, err := ParseReference(strings.TrimPrefix(srv.URL, "https://") + "/myorg/myapp:1.0")
+	require.NoError(t, err)
+	img, err := Pull(context.Background(), ref, Options{Client: srv.Client()})
+	require.NoError(t, err)
+	assert.Equal(t, "registry", img.Info.Source)
+	assert.Equal(t, "sha256:amd", img.Info.Digest)
+	assert.Equal(t, "amd64", img.Info.Architecture)
+
+	root := t.TempDir()
+	require.NoError(t, img.Extract(root))
+	assert.FileExists(t, filepath.Join(root, "app", "go.mod"))
+
+	_, err = Pull(context.Background(), ref, Options{Client: srv.Client(), Platform: "windows/amd64"})
+	assert.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	got := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:myorg/myapp:pull"`)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:myorg/myapp:pull",
+	}, got)
+	assert.Empty(t, parseChallenge(`Basic realm="x"`))
+}
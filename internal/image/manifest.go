@@ -0,0 +1,99 @@
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Manifest media types understood when resolving an image.
+const (
+	mediaTypeOCIIndex        = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest     = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList      = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest  = "application/vnd.docker.distribution.manifest.v2+json"
+	defaultPlatform          = "linux/amd64"
+	maxManifestIndirection   = 4
+	maxManifestOrConfigBytes = 8 << 20 // 8 MiB
+)
+
+// descriptor references a blob by digest.
+type descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	Platform  *platform `json:"platform,omitempty"`
+}
+
+type platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// manifest is either an image manifest (Config + Layers) or an index /
+// manifest list (Manifests); which one is decided by the fields present.
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+// fetchFunc returns the content addressed by a digest.
+type fetchFunc func(digest string) ([]byte, error)
+
+// resolveManifest follows index entries down to a single-platform image
+// manifest, choosing the entry that matches the requested platform.
+func resolveManifest(data []byte, fetch fetchFunc, wantPlatform string) (*manifest, string, error) {
+	digest := ""
+	for range maxManifestIndirection {
+		var m manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, "", fmt.Errorf("parse manifest: %w", err)
+		}
+		if len(m.Manifests) == 0 {
+			if m.Config.Digest == "" {
+				return nil, "", fmt.Errorf("manifest has no config (unsupported schema)")
+			}
+			return &m, digest, nil
+		}
+		d, err := selectPlatform(m.Manifests, wantPlatform)
+		if err != nil {
+			return nil, "", err
+		}
+		if data, err = fetch(d.Digest); err != nil {
+			return nil, "", err
+		}
+		digest = d.Digest
+	}
+	return nil, "", fmt.Errorf("manifest index nesting exceeds %d levels", maxManifestIndirection)
+}
+
+// selectPlatform picks the index entry for os/arch[/variant]. Entries without
+// a platform (e.g. a single-image OCI layout) match when they are the only one.
+func selectPlatform(entries []descriptor, want string) (descriptor, error) {
+	if want == "" {
+		want = defaultPlatform
+	}
+	parts := strings.SplitN(want, "/", 3)
+	if len(parts) < 2 {
+		return descriptor{}, fmt.Errorf("invalid platform %q (want os/arch[/variant])", want)
+	}
+	for _, e := range entries {
+		if platformMatches(e.Platform, parts) {
+			return e, nil
+		}
+	}
+	if len(entries) == 1 && entries[0].Platform == nil {
+		return entries[0], nil
+	}
+	return descriptor{}, fmt.Errorf("no image for platform %s", want)
+}
+
+func platformMatches(p *platform, want []string) bool {
+	if p == nil || p.OS != want[0] || p.Architecture != want[1] {
+		return false
+	}
+	return len(want) < 3 || p.Variant == want[2]
+}
@@ -0,0 +1,288 @@
+package image
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver for rpmdb.sqlite
+)
+
+// Package database locations inside an image root.
+const (
+	apkInstalledPath = "lib/apk/db/installed"
+	dpkgStatusPath   = "var/lib/dpkg/status"
+	dpkgStatusDir    = "var/lib/dpkg/status.d"
+	rpmSqlitePath    = "var/lib/rpm/rpmdb.sqlite"
+)
+
+// Distro identifies the image's Linux distribution from os-release.
+type Distro struct {
+	ID        string
+	VersionID string
+}
+
+// ReadDistro reads /etc/os-release (or /usr/lib/os-release) under root.
+func ReadDistro(root string) Distro {
+	for _, p := range []string{"etc/os-release", "usr/lib/os-release"} {
+		data, err := os.ReadFile(filepath.Join(root, p))
+		if err != nil {
+			continue
+		}
+		var d Distro
+		for _, line := range strings.Split(string(data), "\n") {
+			key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(value, `"'`)
+			switch key {
+			case "ID":
+				d.ID = value
+			case "VERSION_ID":
+				d.VersionID = value
+			}
+		}
+		return d
+	}
+	return Distro{}
+}
+
+// OSPackages reads the installed-package databases of apk, dpkg and rpm under
+// root. Missing databases are skipped; only the rpm sqlite backend (RHEL 9+,
+// Fedora 36+, recent SUSE) is supported, not the older Berkeley DB format.
+func OSPackages(root string, distro Distro) []types.Dependency {
+	var deps []types.Dependency
+	deps = append(deps, apkPackages(root)...)
+	deps = append(deps, dpkgPackages(root)...)
+	deps = append(deps, rpmPackages(root)...)
+	for i := range deps {
+		if distro.ID != "" {
+			deps[i].Metadata["distro"] = distro.ID
+		}
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Type != deps[j].Type {
+			return deps[i].Type < deps[j].Type
+		}
+		return deps[i].Name < deps[j].Name
+	})
+	return deps
+}
+
+func newOSPackage(depType, name, version, source string) types.Dependency {
+	return types.Dependency{
+		Type:     depType,
+		Name:     name,
+		Version:  version,
+		Scope:    types.ScopeSystem,
+		Direct:   true,
+		Metadata: types.NewMetadata(source),
+	}
+}
+
+// apkPackages parses the Alpine installed database: blank-line separated
+// records of single-letter keys (P: name, V: version, L: license).
+func apkPackages(root string) []types.Dependency {
+	var deps []types.Dependency
+	for _, rec := range readRecords(filepath.Join(root, apkInstalledPath), ":") {
+		if rec["P"] == "" {
+			continue
+		}
+		dep := newOSPackage(parsers.DependencyTypeApk, rec["P"], rec["V"], "/"+apkInstalledPath)
+		if rec["L"] != "" {
+			license.SetDependencyLicense(&dep, rec["L"])
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// dpkgPackages parses /var/lib/dpkg/status plus the per-package files in
+// status.d/ that distroless images use instead.
+func dpkgPackages(root string) []types.Dependency {
+	files := []string{filepath.Join(root, dpkgStatusPath)}
+	if entries, err := os.ReadDir(filepath.Join(root, dpkgStatusDir)); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() && !strings.HasSuffix(e.Name(), ".md5sums") {
+				files = append(files, filepath.Join(root, dpkgStatusDir, e.Name()))
+			}
+		}
+	}
+
+	var deps []types.Dependency
+	seen := make(map[string]bool)
+	for _, file := range files {
+		source := "/" + filepath.ToSlash(strings.TrimPrefix(file, root+string(filepath.Separator)))
+		for _, rec := range readRecords(file, ": ") {
+			if !dpkgInstalled(rec) || seen[rec["Package"]] {
+				continue
+			}
+			seen[rec["Package"]] = true
+			deps = append(deps, newOSPackage(parsers.DependencyTypeDeb, rec["Package"], rec["Version"], source))
+		}
+	}
+	return deps
+}
+
+// dpkgInstalled reports whether a status record is an installed package.
+// status.d records have no Status field and are always installed.
+func dpkgInstalled(rec map[string]string) bool {
+	if rec["Package"] == "" {
+		return false
+	}
+	status, ok := rec["Status"]
+	return !ok || strings.HasSuffix(status, " installed")
+}
+
+// readRecords splits a key/value database into blank-line separated records.
+// Continuation lines (leading whitespace) are ignored.
+func readRecords(file, sep string) []map[string]string {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var records []map[string]string
+	rec := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			records = appendRecord(records, rec)
+			rec = make(map[string]string)
+			continue
+		}
+		if key, value, ok := strings.Cut(line, sep); ok && !strings.HasPrefix(line, " ") {
+			rec[key] = strings.TrimSpace(value)
+		}
+	}
+	return appendRecord(records, rec)
+}
+
+func appendRecord(records []map[string]string, rec map[string]string) []map[string]string {
+	if len(rec) == 0 {
+		return records
+	}
+	return append(records, rec)
+}
+
+// rpmPackages reads the rpm sqlite database (Packages table of header blobs).
+func rpmPackages(root string) []types.Dependency {
+	path := filepath.Join(root, rpmSqlitePath)
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", path))
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT blob FROM Packages")
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var deps []types.Dependency
+	for rows.Next() {
+		var blob []byte
+		if rows.Scan(&blob) != nil {
+			continue
+		}
+		if dep, ok := parseRpmHeader(blob); ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// rpm header tags and types used when decoding a package header.
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagLicense = 1014
+
+	rpmTypeInt32   = 4
+	rpmTypeString  = 6
+	rpmTypeI18NStr = 9
+)
+
+// parseRpmHeader decodes the package identity and license from an rpm header
+// blob. The gpg-pubkey pseudo-packages rpm uses to store keys are skipped.
+func parseRpmHeader(blob []byte) (types.Dependency, bool) {
+	fields, ok := rpmHeaderFields(blob)
+	name := fields[rpmTagName]
+	if !ok || name == "" || name == "gpg-pubkey" {
+		return types.Dependency{}, false
+	}
+	version := fields[rpmTagVersion]
+	if rel := fields[rpmTagRelease]; rel != "" {
+		version += "-" + rel
+	}
+	if epoch := fields[rpmTagEpoch]; epoch != "" && epoch != "0" {
+		version = epoch + ":" + version
+	}
+	dep := newOSPackage(parsers.DependencyTypeRpm, name, version, "/"+rpmSqlitePath)
+	if lic := fields[rpmTagLicense]; lic != "" {
+		license.SetDependencyLicense(&dep, lic)
+	}
+	return dep, true
+}
+
+// rpmHeaderFields decodes an rpm header blob as stored in rpmdb.sqlite: il
+// (entry count), dl (data length), il 16-byte index entries (tag, type,
+// offset, count), then the data store. Returns tag -> first value.
+func rpmHeaderFields(blob []byte) (map[uint32]string, bool) {
+	if len(blob) < 8 {
+		return nil, false
+	}
+	il := int(binary.BigEndian.Uint32(blob[0:4]))
+	dl := int(binary.BigEndian.Uint32(blob[4:8]))
+	dataStart := 8 + il*16
+	if il <= 0 || dl < 0 || dataStart+dl > len(blob) {
+		return nil, false
+	}
+	data := blob[dataStart : dataStart+dl]
+
+	fields := make(map[uint32]string)
+	for i := 0; i < il; i++ {
+		entry := blob[8+i*16 : 8+(i+1)*16]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		typ := binary.BigEndian.Uint32(entry[4:8])
+		off := int(binary.BigEndian.Uint32(entry[8:12]))
+		if off >= 0 && off < len(data) {
+			fields[tag] = rpmValue(data[off:], typ)
+		}
+	}
+	return fields, true
+}
+
+// rpmValue renders the first value of an rpm header entry as a string.
+func rpmValue(data []byte, typ uint32) string {
+	switch typ {
+	case rpmTypeString, rpmTypeI18NStr:
+		if end := strings.IndexByte(string(data), 0); end >= 0 {
+			return string(data[:end])
+		}
+	case rpmTypeInt32:
+		if len(data) >= 4 {
+			return fmt.Sprint(binary.BigEndian.Uint32(data[:4]))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+// Package image reads container images -- from an OCI/Docker registry or from a
+// `docker save` / OCI layout tarball -- and unpacks their layers into a
+// directory so the regular scanner can analyze the image filesystem. It also
+// reads the OS package databases (apk, dpkg, rpm) that the file-based
+// detectors do not cover.
+package image
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dockerHubRegistry = "docker.io"
+	dockerHubEndpoint = "registry-1.docker.io"
+	defaultTag        = "latest"
+)
+
+// Reference is a parsed image reference: registry/repository[:tag][@digest].
+type Reference struct {
+	Registry   string // e.g. docker.io, ghcr.io, registry.example.com:5000
+	Repository string // e.g. library/alpine, myorg/myapp
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference using Docker's conventions: a
+// first path component containing '.' or ':' (or equal to "localhost") is a
+// registry host; otherwise Docker Hub is implied and single-component names
+// live under "library/".
+func ParseReference(ref string) (Reference, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.ContainsAny(ref, " \t\n") {
+		return Reference{}, fmt.Errorf("invalid image reference %q", ref)
+	}
+
+	var r Reference
+	name := r.splitTagDigest(ref)
+	r.splitRegistry(name)
+	if r.Repository == "" || strings.ToLower(r.Repository) != r.Repository {
+		return Reference{}, fmt.Errorf("invalid image repository %q", r.Repository)
+	}
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = defaultTag
+	}
+	return r, nil
+}
+
+// splitTagDigest records the tag and digest and returns the remaining name.
+// A tag separator is a ':' after the last '/', so registry ports are kept.
+func (r *Reference) splitTagDigest(ref string) string {
+	if name, digest, ok := strings.Cut(ref, "@"); ok {
+		ref, r.Digest = name, digest
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref, r.Tag = ref[:i], ref[i+1:]
+	}
+	return ref
+}
+
+// splitRegistry separates the registry host from the repository path.
+func (r *Reference) splitRegistry(name string) {
+	first, rest, hasSlash := strings.Cut(name, "/")
+	if hasSlash && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		r.Registry, r.Repository = first, rest
+	} else {
+		r.Registry, r.Repository = dockerHubRegistry, name
+	}
+	if r.Registry == dockerHubRegistry && !strings.Contains(r.Repository, "/") {
+		r.Repository = "library/" + r.Repository
+	}
+}
+
+// String renders the reference in its fully qualified form.
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// manifestRef returns the tag or digest used to fetch the manifest; a digest
+// wins because it pins the exact content.
+func (r Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// endpoint returns the registry API host ("docker.io" is served elsewhere).
+func (r Reference) endpoint() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubEndpoint
+	}
+	return r.Registry
+}
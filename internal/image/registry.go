@@ -0,0 +1,224 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+// userAgent identifies the client to registries.
+const userAgent = "tech-stack-analyzer (+https://github.com/petrarca/tech-stack-analyzer)"
+
+// manifestAccept lists every manifest media type we can resolve.
+var manifestAccept = strings.Join([]string{
+	mediaTypeOCIIndex, mediaTypeOCIManifest, mediaTypeDockerList, mediaTypeDockerManifest,
+}, ", ")
+
+// httpDoer is the minimal HTTP client interface (satisfied by *http.Client),
+// injectable for testing.
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// registryClient speaks the OCI distribution API for one repository. It
+// performs the anonymous (or credentialed) bearer-token handshake on the first
+// 401 and reuses the token afterwards.
+type registryClient struct {
+	ctx      context.Context
+	ref      Reference
+	base     string
+	http     httpDoer
+	username string
+	secret   string
+	token    string
+}
+
+// Pull resolves a reference against its registry. Manifests and the config are
+// fetched eagerly; layer blobs are streamed when the image is extracted.
+func Pull(ctx context.Context, ref Reference, opts Options) (*Image, error) {
+	client := opts.Client
+	if client == nil {
+		client = httpclient.New(10 * time.Minute)
+	}
+	rc := &registryClient{
+		ctx:      ctx,
+		ref:      ref,
+		base:     "https://" + ref.endpoint() + "/v2/" + ref.Repository,
+		http:     client,
+		username: opts.Username,
+		secret:   opts.Token,
+	}
+
+	data, err := rc.get("/manifests/"+ref.manifestRef(), manifestAccept)
+	if err != nil {
+		return nil, fmt.Errorf("fetch manifest for %s: %w", ref, err)
+	}
+	fetch := func(digest string) ([]byte, error) {
+		return rc.get("/manifests/"+digest, manifestAccept)
+	}
+	m, digest, err := resolveManifest(data, fetch, opts.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if digest == "" {
+		digest = ref.Digest
+	}
+
+	img := &Image{Info: Info{Reference: ref.String(), Source: "registry", Digest: digest, Layers: len(m.Layers)}}
+	cfg, err := rc.get("/blobs/"+m.Config.Digest, "")
+	if err != nil {
+		return nil, fmt.Errorf("fetch image config: %w", err)
+	}
+	if err := img.applyConfig(cfg); err != nil {
+		return nil, err
+	}
+	for _, layer := range m.Layers {
+		img.layers = append(img.layers, rc.blobOpener(layer.Digest))
+	}
+	return img, nil
+}
+
+// get fetches a small document (manifest or config) in full.
+func (c *registryClient) get(path, accept string) ([]byte, error) {
+	resp, err := c.do(path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(io.LimitReader(resp.Body, maxManifestOrConfigBytes))
+}
+
+func (c *registryClient) blobOpener(digest string) layerOpener {
+	return func() (io.ReadCloser, error) {
+		resp, err := c.do("/blobs/"+digest, "")
+		if err != nil {
+			return nil, fmt.Errorf("fetch layer %s: %w", digest, err)
+		}
+		return decompress(resp.Body)
+	}
+}
+
+// do performs a GET, authenticating once on a 401 challenge. The caller closes
+// the body of a successful response.
+func (c *registryClient) do(path, accept string) (*http.Response, error) {
+	resp, err := c.request(path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := c.authenticate(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.request(path, accept); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) request(path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, c.base+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.http.Do(req)
+}
+
+// authenticate answers a `Bearer realm=...,service=...,scope=...` challenge
+// by requesting a pull token from the realm, sending Basic credentials when
+// they were configured.
+func (c *registryClient) authenticate(challenge string) error {
+	params := parseChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("registry requires authentication (unsupported challenge)")
+	}
+	q := url.Values{}
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + c.ref.Repository + ":pull"
+	}
+	q.Set("scope", scope)
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if c.secret != "" {
+		req.SetBasicAuth(c.username, c.secret)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry token request returned %s", resp.Status)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tok); err != nil {
+		return fmt.Errorf("parse registry token: %w", err)
+	}
+	c.token = firstNonEmpty(tok.Token, tok.AccessToken)
+	if c.token == "" {
+		return fmt.Errorf("registry returned an empty token")
+	}
+	return nil
+}
+
+// parseChallenge parses the key="value" pairs of a WWW-Authenticate Bearer
+// challenge. Only the Bearer scheme is supported.
+func parseChallenge(header string) map[string]string {
+	params := make(map[string]string)
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return params
+	}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return params
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
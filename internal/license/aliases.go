@@ -1,5 +1,7 @@
 package license
 
+import "strings"
+
 // declaredLicenseAliases maps common non-SPDX declared license strings to their
 // canonical SPDX identifier. Keys are lower-cased; the Normalizer lowercases its
 // input before lookup. The curated map in NewNormalizer takes precedence on any
@@ -121,3 +123,30 @@ var declaredLicenseAliases = map[string]string{
 	"the vim license":             "Vim",
 	"beerware":                    "Beerware",
 }
+
+// licenseExceptionAliases maps common spellings of SPDX license exceptions
+// (the right-hand side of WITH) to their canonical identifier. Keys are
+// lower-cased.
+var licenseExceptionAliases = map[string]string{
+	"classpath-exception":            "Classpath-exception-2.0",
+	"classpath exception":            "Classpath-exception-2.0",
+	"classpath-exception-2.0":        "Classpath-exception-2.0",
+	"gcc-exception":                  "GCC-exception-3.1",
+	"gcc-exception-3.1":              "GCC-exception-3.1",
+	"gcc-exception-2.0":              "GCC-exception-2.0",
+	"llvm-exception":                 "LLVM-exception",
+	"autoconf-exception":             "Autoconf-exception-3.0",
+	"autoconf-exception-3.0":         "Autoconf-exception-3.0",
+	"openjdk-assembly-exception-1.0": "OpenJDK-assembly-exception-1.0",
+	"universal-foss-exception-1.0":   "Universal-FOSS-exception-1.0",
+}
+
+// NormalizeException returns the canonical SPDX identifier of a license
+// exception, or the exception as given when it is not a known spelling.
+func NormalizeException(exception string) string {
+	exception = strings.TrimSpace(exception)
+	if canonical, ok := licenseExceptionAliases[strings.ToLower(exception)]; ok {
+		return canonical
+	}
+	return exception
+}
@@ -103,6 +103,12 @@ func buildLicenseCategories() map[string]Category {
 	return out
 }
 
+// IsCategory reports whether c is one of the risk categories.
+func IsCategory(c Category) bool {
+	_, ok := categorySeverity[c]
+	return ok
+}
+
 // CategoryOf returns the risk category of a single normalized SPDX license id.
 func CategoryOf(licenseID string) Category {
 	if cat, ok := licenseCategories[strings.ToLower(strings.TrimSpace(licenseID))]; ok {
@@ -1,6 +1,10 @@
 package license
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
 
 // This file implements a small SPDX license-expression parser supporting the
 // AND, OR and WITH operators and parenthesized grouping, per the SPDX license
@@ -173,3 +177,41 @@ func (p *exprParser) parsePrimary() Expression {
 	}
 	return SimpleExpr{License: p.next()}
 }
+
+// ExpressionStructure returns expr as the license expression of the output,
+// with its license ids and exceptions normalized. Runs of the same operator
+// become one node: "MIT OR ISC OR Apache-2.0" has three operands.
+func (n *Normalizer) ExpressionStructure(expr Expression) *types.LicenseExpression {
+	switch e := expr.(type) {
+	case SimpleExpr:
+		node := &types.LicenseExpression{License: n.Normalize(e.License)}
+		if e.Exception != "" {
+			node.Exception = NormalizeException(e.Exception)
+		}
+		return node
+	case CompoundExpr:
+		node := &types.LicenseExpression{Operator: string(e.Op)}
+		for _, side := range []Expression{e.Left, e.Right} {
+			operand := n.ExpressionStructure(side)
+			if operand.Operator == node.Operator {
+				node.Operands = append(node.Operands, operand.Operands...)
+			} else {
+				node.Operands = append(node.Operands, *operand)
+			}
+		}
+		return node
+	}
+	return nil
+}
+
+// isCompound reports whether expr joins several licenses or carries an
+// exception, that is whether it says more than a license id.
+func isCompound(expr Expression) bool {
+	switch e := expr.(type) {
+	case SimpleExpr:
+		return e.Exception != ""
+	case CompoundExpr:
+		return true
+	}
+	return false
+}
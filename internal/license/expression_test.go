@@ -3,6 +3,8 @@ package license
 import (
 	"reflect"
 	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
 func TestParseExpression_Licenses(t *testing.T) {
@@ -73,3 +75,24 @@ func TestExpression_String(t *testing.T) {
 		t.Errorf("String() = %q, want (MIT OR Apache-2.0)", s)
 	}
 }
+
+func TestExpressionStructure(t *testing.T) {
+	n := NewNormalizer()
+
+	got := n.ExpressionStructure(ParseExpression("MIT OR ISC OR apache-2"))
+	want := &types.LicenseExpression{Operator: "OR", Operands: []types.LicenseExpression{
+		{License: "MIT"}, {License: "ISC"}, {License: "Apache-2.0"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattened OR = %+v, want %+v", got, want)
+	}
+
+	got = n.ExpressionStructure(ParseExpression("(MIT OR BSD-2-Clause) AND GPL-2.0-only WITH Classpath-exception"))
+	want = &types.LicenseExpression{Operator: "AND", Operands: []types.LicenseExpression{
+		{Operator: "OR", Operands: []types.LicenseExpression{{License: "MIT"}, {License: "BSD-2-Clause"}}},
+		{License: "GPL-2.0-only", Exception: "Classpath-exception-2.0"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nested = %+v, want %+v", got, want)
+	}
+}
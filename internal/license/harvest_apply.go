@@ -52,11 +52,17 @@ func annotateDependencyLicense(dep *types.Dependency, byEcosystem map[string]Har
 	if lic == "" {
 		return false
 	}
+	SetDependencyLicense(dep, lic)
+	return true
+}
+
+// SetDependencyLicense records a license on a dependency's metadata under the
+// same key the harvesters use, so the SBOM builder surfaces it.
+func SetDependencyLicense(dep *types.Dependency, lic string) {
 	if dep.Metadata == nil {
 		dep.Metadata = make(map[string]interface{})
 	}
 	dep.Metadata[dependencyLicenseKey] = lic
-	return true
 }
 
 // dependencyHasLicense reports whether a dependency already carries a license in
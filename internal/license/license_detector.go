@@ -1,22 +1,36 @@
 package license
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math"
+	"regexp"
+	"strings"
 
 	"github.com/go-enry/go-license-detector/v4/licensedb"
 	"github.com/go-enry/go-license-detector/v4/licensedb/filer"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
+// CustomLicenseRef names the license of a license file matching no known
+// license; its text hash tells different custom licenses apart.
+const CustomLicenseRef = "LicenseRef-custom"
+
+// licenseFileName matches the names of files holding a license text.
+var licenseFileName = regexp.MustCompile(`(?i)^((un)?licen[cs]e|copying)([-_][a-z0-9.-]+)?(\.(txt|md|rst|markdown))?$`)
+
 // LicenseDetector handles file-based license detection
-type LicenseDetector struct{}
+type LicenseDetector struct {
+	hashTexts bool
+}
 
 // LicenseMatch represents a detected license with metadata
 type LicenseMatch struct {
 	License    string
 	Confidence float32
 	File       string
+	TextHash   string // set when license texts are hashed
 }
 
 // NewLicenseDetector creates a new license detector
@@ -24,6 +38,13 @@ func NewLicenseDetector() *LicenseDetector {
 	return &LicenseDetector{}
 }
 
+// SetTextHash enables hashing the text of license files (--license-text-hash).
+// License files matching no known license are then reported as
+// CustomLicenseRef.
+func (d *LicenseDetector) SetTextHash(enabled bool) {
+	d.hashTexts = enabled
+}
+
 // DetectLicensesInDirectory detects licenses from LICENSE files in a directory
 // Returns a list of detected licenses with metadata (confidence > 0.9)
 func (d *LicenseDetector) DetectLicensesInDirectory(dirPath string) []LicenseMatch {
@@ -39,25 +60,79 @@ func (d *LicenseDetector) DetectLicensesInDirectory(dirPath string) []LicenseMat
 
 	// Detect licenses
 	matches, err := licensedb.Detect(fs)
-	if err != nil {
+	if err != nil && !d.hashTexts {
 		return nil
 	}
 
 	// Extract license matches with high confidence (> 0.9)
 	var licenses []LicenseMatch
+	identified := make(map[string]bool)
 	for licenseID, match := range matches {
 		if match.Confidence > 0.9 {
 			licenses = append(licenses, LicenseMatch{
 				License:    licenseID,
 				Confidence: match.Confidence,
 				File:       match.File,
+				TextHash:   d.textHash(fs, match.File),
 			})
+			identified[match.File] = true
 		}
 	}
+	if d.hashTexts {
+		licenses = append(licenses, customLicenses(fs, identified)...)
+	}
 
 	return licenses
 }
 
+func (d *LicenseDetector) textHash(fs filer.Filer, file string) string {
+	if !d.hashTexts || file == "" {
+		return ""
+	}
+	content, err := fs.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	return LicenseTextHash(content)
+}
+
+// customLicenses returns the license files of the directory no known
+// license was identified in.
+func customLicenses(fs filer.Filer, identified map[string]bool) []LicenseMatch {
+	entries, err := fs.ReadDir("")
+	if err != nil {
+		return nil
+	}
+	var licenses []LicenseMatch
+	for _, entry := range entries {
+		if entry.IsDir || identified[entry.Name] || !licenseFileName.MatchString(entry.Name) {
+			continue
+		}
+		content, err := fs.ReadFile(entry.Name)
+		if err != nil || len(strings.TrimSpace(string(content))) == 0 {
+			continue
+		}
+		licenses = append(licenses, LicenseMatch{License: CustomLicenseRef, File: entry.Name, TextHash: LicenseTextHash(content)})
+	}
+	return licenses
+}
+
+// LicenseTextHash returns the hex SHA-256 of a license text with its
+// copyright lines dropped, case folded and whitespace collapsed, so copies
+// differing only in holder, year or layout hash the same.
+func LicenseTextHash(content []byte) string {
+	var words []string
+	for _, line := range strings.Split(strings.ToLower(string(content)), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "copyright") || strings.HasPrefix(line, "(c)") || strings.HasPrefix(line, "\u00a9") {
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:])
+}
+
 // AddLicensesToPayload detects and adds licenses from the current directory to the payload
 func (d *LicenseDetector) AddLicensesToPayload(payload *types.Payload, dirPath string) {
 	licenseMatches := d.DetectLicensesInDirectory(dirPath)
@@ -81,10 +156,17 @@ func (d *LicenseDetector) AddLicensesToPayload(payload *types.Payload, dirPath s
 				DetectionType: "file_based",
 				SourceFile:    match.File,
 				Confidence:    math.Round(float64(match.Confidence)*100) / 100,
+				TextHash:      match.TextHash,
 			})
 			// Add reason to _license category
-			payload.AddLicenseReason(fmt.Sprintf("license detected: %s (confidence: %.2f, file: %s)",
-				match.License, match.Confidence, match.File))
+			payload.AddLicenseReason(licenseReason(match))
 		}
 	}
 }
+
+func licenseReason(match LicenseMatch) string {
+	if match.License == CustomLicenseRef {
+		return fmt.Sprintf("license not identified: %s (text hash: %.12s)", match.File, match.TextHash)
+	}
+	return fmt.Sprintf("license detected: %s (confidence: %.2f, file: %s)", match.License, match.Confidence, match.File)
+}
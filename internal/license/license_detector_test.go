@@ -0,0 +1,42 @@
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-enry/go-license-detector/v4/licensedb/filer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseTextHash_IgnoresCopyrightAndLayout(t *testing.T) {
+	a := LicenseTextHash([]byte("Copyright (c) 2023 Example Corp\n\nPermission is granted\nto use this software.\n"))
+	b := LicenseTextHash([]byte("COPYRIGHT 2025 Other Holder\nPermission is   granted to use\n  this software.\n"))
+	c := LicenseTextHash([]byte("Permission is granted to modify this software.\n"))
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Len(t, a, 64)
+}
+
+func TestCustomLicenses(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"LICENSE.txt": "Myorg internal license: use inside myorg only.\n",
+		"COPYING":     "Permission is granted to use this software.\n",
+		"license.go":  "package license\n",
+		"README.md":   "# myapp\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	fs, err := filer.FromDirectory(dir)
+	require.NoError(t, err)
+
+	found := customLicenses(newSafeFiler(fs, dir), map[string]bool{"COPYING": true})
+
+	require.Len(t, found, 1, "identified and non-license files are skipped")
+	assert.Equal(t, CustomLicenseRef, found[0].License)
+	assert.Equal(t, "LICENSE.txt", found[0].File)
+	assert.Equal(t, LicenseTextHash([]byte("Myorg internal license: use inside myorg only.\n")), found[0].TextHash)
+}
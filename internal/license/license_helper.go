@@ -22,6 +22,10 @@ func ProcessLicenseExpression(rawLicense string, sourceFile string, payload *typ
 
 	normalizer := NewNormalizer()
 	licenses := normalizer.ParseLicenseExpression(rawLicense)
+	var structure *types.LicenseExpression
+	if parsed := ParseExpression(declaredExpression(rawLicense)); isCompound(parsed) {
+		structure = normalizer.ExpressionStructure(parsed)
+	}
 
 	if len(licenses) == 0 {
 		payload.AddReason(fmt.Sprintf("license ignored: %q (invalid expression from %s)", rawLicense, sourceFile))
@@ -33,6 +37,7 @@ func ProcessLicenseExpression(rawLicense string, sourceFile string, payload *typ
 			LicenseName: licenses[0],
 			SourceFile:  sourceFile,
 			Confidence:  1.0,
+			Expression:  structure,
 		}
 
 		if licenses[0] == rawLicense {
@@ -54,6 +59,7 @@ func ProcessLicenseExpression(rawLicense string, sourceFile string, payload *typ
 				SourceFile:      sourceFile,
 				Confidence:      1.0,
 				OriginalLicense: rawLicense,
+				Expression:      structure,
 			}
 			AddLicenseToPayload(payload, licenseObj)
 			payload.AddReason(reason)
@@ -88,3 +88,25 @@ func TestAddLicenseToPayload_SetsCategory(t *testing.T) {
 		t.Errorf("MIT category = %q, want notice", cat["MIT"])
 	}
 }
+
+func TestProcessLicenseExpression_KeepsExpressionStructure(t *testing.T) {
+	payload := &types.Payload{Reason: make(map[string][]string)}
+	ProcessLicenseExpression("MIT OR Apache-2.0", "Cargo.toml", payload)
+
+	require.Len(t, payload.Licenses, 2)
+	for _, lic := range payload.Licenses {
+		require.NotNil(t, lic.Expression)
+		assert.Equal(t, "OR", lic.Expression.Operator)
+		assert.Len(t, lic.Expression.Operands, 2)
+	}
+
+	payload = &types.Payload{Reason: make(map[string][]string)}
+	ProcessLicenseExpression("GPL-2.0-only WITH Classpath-exception", "pom.xml", payload)
+	require.Len(t, payload.Licenses, 1)
+	assert.Equal(t, "GPL-2.0-only", payload.Licenses[0].LicenseName)
+	assert.Equal(t, &types.LicenseExpression{License: "GPL-2.0-only", Exception: "Classpath-exception-2.0"}, payload.Licenses[0].Expression)
+
+	payload = &types.Payload{Reason: make(map[string][]string)}
+	ProcessLicenseExpression("MIT", "package.json", payload)
+	assert.Nil(t, payload.Licenses[0].Expression, "a lone license has no expression")
+}
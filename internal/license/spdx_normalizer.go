@@ -273,10 +273,7 @@ func (n *Normalizer) ParseLicenseExpression(expr string) []string {
 	if strings.TrimSpace(expr) == "" {
 		return nil
 	}
-	expr = strings.ReplaceAll(expr, "||", " OR ")
-	expr = strings.ReplaceAll(expr, "&&", " AND ")
-
-	parsed := ParseExpression(expr)
+	parsed := ParseExpression(declaredExpression(expr))
 	if parsed == nil {
 		return nil
 	}
@@ -297,6 +294,13 @@ func (n *Normalizer) ParseLicenseExpression(expr string) []string {
 	return licenses
 }
 
+// declaredExpression rewrites the "||" and "&&" some manifests use for
+// SPDX's OR and AND.
+func declaredExpression(expr string) string {
+	expr = strings.ReplaceAll(expr, "||", " OR ")
+	return strings.ReplaceAll(expr, "&&", " AND ")
+}
+
 // isReservedOperatorWord reports whether s is a bare SPDX operator keyword
 // (which is not a license identifier).
 func isReservedOperatorWord(s string) bool {
@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/version"
+)
+
+// ToolInfo is the build of the scanner that produced an output.
+type ToolInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// EnvironmentInfo is the machine and, in a CI pipeline, the job a scan ran
+// in.
+type EnvironmentInfo struct {
+	Hostname string  `json:"hostname,omitempty"`
+	OS       string  `json:"os"`
+	Arch     string  `json:"arch"`
+	CI       *CIInfo `json:"ci,omitempty"`
+}
+
+// CIInfo identifies the CI job a scan ran in, from the variables the CI
+// system sets.
+type CIInfo struct {
+	Provider   string `json:"provider"`
+	RunID      string `json:"run_id,omitempty"` // workflow run, pipeline or build
+	Job        string `json:"job,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// InvocationInfo is the command line a scan was started with: the command,
+// its arguments and the flags given explicitly.
+type InvocationInfo struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args,omitempty"`
+	Flags   map[string]string `json:"flags,omitempty"`
+}
+
+// ciProviders are the CI systems recognized, by the variable marking a job
+// of theirs and the variables holding the job details.
+var ciProviders = []struct {
+	name, marker                        string
+	runID, job, repository, ref, commit string
+	url                                 string
+}{
+	{"github-actions", "GITHUB_ACTIONS", "GITHUB_RUN_ID", "GITHUB_JOB", "GITHUB_REPOSITORY", "GITHUB_REF", "GITHUB_SHA", ""},
+	{"gitlab-ci", "GITLAB_CI", "CI_PIPELINE_ID", "CI_JOB_ID", "CI_PROJECT_PATH", "CI_COMMIT_REF_NAME", "CI_COMMIT_SHA", "CI_JOB_URL"},
+	{"azure-pipelines", "TF_BUILD", "BUILD_BUILDID", "SYSTEM_JOBDISPLAYNAME", "BUILD_REPOSITORY_NAME", "BUILD_SOURCEBRANCH", "BUILD_SOURCEVERSION", ""},
+	{"jenkins", "JENKINS_URL", "BUILD_ID", "JOB_NAME", "", "GIT_BRANCH", "GIT_COMMIT", "BUILD_URL"},
+	{"circleci", "CIRCLECI", "CIRCLE_WORKFLOW_ID", "CIRCLE_JOB", "CIRCLE_PROJECT_REPONAME", "CIRCLE_BRANCH", "CIRCLE_SHA1", "CIRCLE_BUILD_URL"},
+	{"bitbucket-pipelines", "BITBUCKET_BUILD_NUMBER", "BITBUCKET_BUILD_NUMBER", "BITBUCKET_STEP_UUID", "BITBUCKET_REPO_FULL_NAME", "BITBUCKET_BRANCH", "BITBUCKET_COMMIT", ""},
+}
+
+// NewInvocationInfo records a command line. Flag values that are URLs are
+// kept without their userinfo so credentials do not end up in an output.
+func NewInvocationInfo(command string, args []string, flags map[string]string) *InvocationInfo {
+	invocation := &InvocationInfo{Command: command, Args: args}
+	if len(flags) > 0 {
+		invocation.Flags = make(map[string]string, len(flags))
+		for name, value := range flags {
+			if strings.Contains(value, "://") {
+				value = withoutUserinfo(value)
+			}
+			invocation.Flags[name] = value
+		}
+	}
+	return invocation
+}
+
+// currentTool returns the build of the running scanner.
+func currentTool() *ToolInfo {
+	return &ToolInfo{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildDate: version.Date,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// currentEnvironment returns the machine the scanner runs on and its CI job.
+func currentEnvironment() *EnvironmentInfo {
+	hostname, _ := os.Hostname()
+	return &EnvironmentInfo{
+		Hostname: hostname,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		CI:       detectCI(os.Getenv),
+	}
+}
+
+// detectCI returns the CI job the variables of getenv describe, or nil
+// outside CI. CI=true without a known system is reported as "unknown".
+func detectCI(getenv func(string) string) *CIInfo {
+	for _, p := range ciProviders {
+		if getenv(p.marker) == "" {
+			continue
+		}
+		ci := &CIInfo{
+			Provider:   p.name,
+			RunID:      lookup(getenv, p.runID),
+			Job:        lookup(getenv, p.job),
+			Repository: lookup(getenv, p.repository),
+			Ref:        lookup(getenv, p.ref),
+			Commit:     lookup(getenv, p.commit),
+			URL:        withoutUserinfo(lookup(getenv, p.url)),
+		}
+		if p.name == "github-actions" && ci.RunID != "" && ci.Repository != "" {
+			ci.URL = withoutUserinfo(getenv("GITHUB_SERVER_URL") + "/" + ci.Repository + "/actions/runs/" + ci.RunID)
+		}
+		return ci
+	}
+	if getenv("CI") == "true" {
+		return &CIInfo{Provider: "unknown"}
+	}
+	return nil
+}
+
+func lookup(getenv func(string) string, name string) string {
+	if name == "" {
+		return ""
+	}
+	return getenv(name)
+}
+
+// withoutUserinfo drops credentials from a URL; values that do not parse as
+// URLs are dropped entirely.
+func withoutUserinfo(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	return u.String()
+}
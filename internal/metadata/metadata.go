@@ -1,7 +1,11 @@
 package metadata
 
 import (
+	"cmp"
+	"math"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -18,9 +22,39 @@ type ScanMetadata struct {
 	LanguageCount  int                    `json:"language_count,omitempty"` // Number of distinct programming languages
 	TechCount      int                    `json:"tech_count,omitempty"`     // Number of primary technologies
 	TechsCount     int                    `json:"techs_count,omitempty"`    // Number of all detected technologies
+	DetectorStats  []DetectorStat         `json:"detector_stats,omitempty"` // Per component detector work, slowest first
+	SkippedFiles   *SkippedFiles          `json:"skipped_files,omitempty"`  // Files not read for content, by reason
+	Tool           *ToolInfo              `json:"tool,omitempty"`           // Scanner build
+	Environment    *EnvironmentInfo       `json:"environment,omitempty"`    // Machine and CI job the scan ran in
+	Invocation     *InvocationInfo        `json:"invocation,omitempty"`     // Command line the scan was started with
+	RulesHash      string                 `json:"rules_hash,omitempty"`     // Digest of the detection rules in effect
 	Properties     map[string]interface{} `json:"properties,omitempty"`
 }
 
+// DetectorStat records the work of one component detector over a scan.
+type DetectorStat struct {
+	Detector   string  `json:"detector"`
+	Calls      int     `json:"calls"`                // Directories the detector ran on
+	Components int     `json:"components"`           // Components it returned
+	DurationMs float64 `json:"duration_ms"`          // Total time spent in it
+	Errors     int     `json:"errors,omitempty"`     // Runs that failed (the detector panicked)
+	LastError  string  `json:"last_error,omitempty"` // Directory and error of the last failure
+}
+
+// SkippedFiles counts the files whose content a scan did not read for
+// content matching, code statistics and the per-file sections.
+type SkippedFiles struct {
+	Binary   int   `json:"binary,omitempty"`    // Content sniffed as binary
+	TooLarge int   `json:"too_large,omitempty"` // Larger than the maximum file size
+	Type     int   `json:"type,omitempty"`      // Extension skipped by type (media, archives, fonts, executables)
+	Bytes    int64 `json:"bytes"`               // Combined size of the skipped files
+}
+
+// Total returns the number of skipped files.
+func (s SkippedFiles) Total() int {
+	return s.Binary + s.TooLarge + s.Type
+}
+
 // NewScanMetadata creates a new scan metadata instance
 func NewScanMetadata(scanPath string, version string) *ScanMetadata {
 	absPath, _ := filepath.Abs(scanPath)
@@ -30,6 +64,8 @@ func NewScanMetadata(scanPath string, version string) *ScanMetadata {
 		Timestamp:   time.Now().UTC().Format(time.RFC3339),
 		ScanPath:    absPath,
 		SpecVersion: version,
+		Tool:        currentTool(),
+		Environment: currentEnvironment(),
 	}
 }
 
@@ -62,6 +98,58 @@ func (m *ScanMetadata) SetProperties(properties map[string]interface{}) {
 	}
 }
 
+// AddDetectorStats adds per-detector stats, summing those of detectors
+// already recorded, as when the scans of several paths are merged. The
+// stats are kept slowest first.
+func (m *ScanMetadata) AddDetectorStats(stats []DetectorStat) {
+	for _, stat := range stats {
+		i := slices.IndexFunc(m.DetectorStats, func(d DetectorStat) bool { return d.Detector == stat.Detector })
+		if i < 0 {
+			m.DetectorStats = append(m.DetectorStats, stat)
+			continue
+		}
+		d := &m.DetectorStats[i]
+		d.Calls += stat.Calls
+		d.Components += stat.Components
+		d.DurationMs = math.Round((d.DurationMs+stat.DurationMs)*1000) / 1000
+		d.Errors += stat.Errors
+		if stat.LastError != "" {
+			d.LastError = stat.LastError
+		}
+	}
+	slices.SortStableFunc(m.DetectorStats, func(a, b DetectorStat) int {
+		if c := cmp.Compare(b.DurationMs, a.DurationMs); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Detector, b.Detector)
+	})
+}
+
+// AddSkippedFiles adds counts of skipped files to those already recorded,
+// as when the scans of several paths are merged.
+func (m *ScanMetadata) AddSkippedFiles(skipped SkippedFiles) {
+	if skipped.Total() == 0 {
+		return
+	}
+	if m.SkippedFiles == nil {
+		m.SkippedFiles = &SkippedFiles{}
+	}
+	m.SkippedFiles.Binary += skipped.Binary
+	m.SkippedFiles.TooLarge += skipped.TooLarge
+	m.SkippedFiles.Type += skipped.Type
+	m.SkippedFiles.Bytes += skipped.Bytes
+}
+
+// SetRulesHash sets the digest of the detection rules in effect
+func (m *ScanMetadata) SetRulesHash(hash string) {
+	m.RulesHash = hash
+}
+
+// SetInvocation sets the command line the scan was started with
+func (m *ScanMetadata) SetInvocation(invocation *InvocationInfo) {
+	m.Invocation = invocation
+}
+
 // SetFormat sets the output format type
 func (m *ScanMetadata) SetFormat(format string) {
 	m.Format = format
@@ -0,0 +1,33 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDetectorStats(t *testing.T) {
+	m := &ScanMetadata{}
+	m.AddDetectorStats([]DetectorStat{
+		{Detector: "nodejs", Calls: 10, Components: 2, DurationMs: 1.5},
+		{Detector: "python", Calls: 10, DurationMs: 0.25},
+	})
+	m.AddDetectorStats([]DetectorStat{
+		{Detector: "python", Calls: 4, Components: 1, DurationMs: 2, Errors: 1, LastError: "/app: boom"},
+	})
+
+	assert.Equal(t, []DetectorStat{
+		{Detector: "python", Calls: 14, Components: 1, DurationMs: 2.25, Errors: 1, LastError: "/app: boom"},
+		{Detector: "nodejs", Calls: 10, Components: 2, DurationMs: 1.5},
+	}, m.DetectorStats, "stats of the same detector are summed, slowest first")
+}
+
+func TestAddSkippedFiles(t *testing.T) {
+	m := &ScanMetadata{}
+	m.AddSkippedFiles(SkippedFiles{})
+	assert.Nil(t, m.SkippedFiles, "nothing skipped, nothing recorded")
+
+	m.AddSkippedFiles(SkippedFiles{Binary: 2, Bytes: 100})
+	m.AddSkippedFiles(SkippedFiles{Binary: 1, TooLarge: 1, Type: 3, Bytes: 50})
+	assert.Equal(t, &SkippedFiles{Binary: 3, TooLarge: 1, Type: 3, Bytes: 150}, m.SkippedFiles)
+}
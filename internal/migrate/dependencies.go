@@ -0,0 +1,96 @@
+package migrate
+
+// dependencyFields are the positions of a dependency array.
+var dependencyFields = []string{"type", "name", "version", "scope", "direct", "metadata"}
+
+// dependencyDefault is the value of a missing dependency field, as the
+// readers of v0 outputs assumed it.
+func dependencyDefault(i int) interface{} {
+	switch dependencyFields[i] {
+	case "direct":
+		return false
+	case "metadata":
+		return map[string]interface{}{}
+	}
+	return ""
+}
+
+func mapDependencies(node map[string]interface{}, convert func(interface{}) interface{}) {
+	deps, ok := node["dependencies"].([]interface{})
+	if !ok {
+		return
+	}
+	for i, dep := range deps {
+		deps[i] = convert(dep)
+	}
+}
+
+// completeDependencies (v0 to v1) pads dependency arrays to their 6
+// elements and turns object dependencies into arrays, moving the deprecated
+// source_file into metadata.source.
+func completeDependencies(node map[string]interface{}) {
+	mapDependencies(node, func(dep interface{}) interface{} {
+		switch dep := dep.(type) {
+		case []interface{}:
+			for len(dep) < len(dependencyFields) {
+				dep = append(dep, dependencyDefault(len(dep)))
+			}
+			return dep
+		case map[string]interface{}:
+			if source, ok := dep["source_file"]; ok {
+				metadata, _ := dep["metadata"].(map[string]interface{})
+				if metadata == nil {
+					metadata = make(map[string]interface{})
+				}
+				if _, exists := metadata["source"]; !exists {
+					metadata["source"] = source
+				}
+				dep["metadata"] = metadata
+				delete(dep, "source_file")
+			}
+			return dependencyArray(dep)
+		}
+		return dep
+	})
+}
+
+// dependenciesToObjects (v1 to v2) turns dependency arrays into objects.
+func dependenciesToObjects(node map[string]interface{}) {
+	mapDependencies(node, func(dep interface{}) interface{} {
+		fields, ok := dep.([]interface{})
+		if !ok {
+			return dep
+		}
+		obj := make(map[string]interface{}, len(dependencyFields))
+		for i, name := range dependencyFields {
+			if i < len(fields) {
+				obj[name] = fields[i]
+			} else {
+				obj[name] = dependencyDefault(i)
+			}
+		}
+		return obj
+	})
+}
+
+// dependenciesToArrays (v2 to v1) turns dependency objects back into arrays.
+func dependenciesToArrays(node map[string]interface{}) {
+	mapDependencies(node, func(dep interface{}) interface{} {
+		if obj, ok := dep.(map[string]interface{}); ok {
+			return dependencyArray(obj)
+		}
+		return dep
+	})
+}
+
+func dependencyArray(obj map[string]interface{}) []interface{} {
+	fields := make([]interface{}, len(dependencyFields))
+	for i, name := range dependencyFields {
+		if value, ok := obj[name]; ok && value != nil {
+			fields[i] = value
+		} else {
+			fields[i] = dependencyDefault(i)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,174 @@
+// Package migrate converts scan outputs between versions of the output
+// schema, so scans stored by older (or newer) releases stay usable when the
+// payload structure changes. Outputs are migrated as generic JSON, keeping
+// the fields a migration does not touch as they are.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/spec"
+)
+
+// Version is a version of the output schema.
+type Version struct {
+	Name        string // "v1", as given to migrate --to
+	Spec        string // metadata.specVersion; empty for outputs written before it existed
+	Description string
+}
+
+// Versions are the output schema versions, oldest first. Each one after the
+// first is reached from the one before by a migration step.
+var Versions = []Version{
+	{Name: "v0", Description: "outputs without metadata.specVersion; dependency arrays may be shorter than 6 elements and dependency objects may carry source_file"},
+	{Name: "v1", Spec: "0.1", Description: "dependencies as [type, name, version, scope, direct, metadata] arrays"},
+	{Name: "v2", Spec: "0.2", Description: "dependencies as objects with type, name, version, scope, direct and metadata fields"},
+}
+
+// step converts a document from Versions[i] to Versions[i+1] (up) and back
+// (down). A nil down cannot be undone.
+type step struct {
+	up, down func(node map[string]interface{})
+}
+
+var steps = []step{
+	{up: completeDependencies},
+	{up: dependenciesToObjects, down: dependenciesToArrays},
+}
+
+// Current returns the version scans write.
+func Current() Version {
+	v, _ := Lookup(spec.Version)
+	return v
+}
+
+// Lookup finds a version by name ("v2", case-insensitive) or specVersion
+// ("0.2").
+func Lookup(name string) (Version, error) {
+	for _, v := range Versions {
+		if strings.EqualFold(name, v.Name) || (v.Spec != "" && name == v.Spec) {
+			return v, nil
+		}
+	}
+	return Version{}, fmt.Errorf("unknown output schema version %q. Valid versions: %s", name, versionNames())
+}
+
+func versionNames() string {
+	names := make([]string, len(Versions))
+	for i, v := range Versions {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func index(v Version) int {
+	for i, known := range Versions {
+		if known.Name == v.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Detect returns the version of a decoded scan output, from its
+// metadata.specVersion. It fails for versions this release does not know,
+// written by a newer one.
+func Detect(doc map[string]interface{}) (Version, error) {
+	metadata, _ := doc["metadata"].(map[string]interface{})
+	specVersion, _ := metadata["specVersion"].(string)
+	if specVersion == "" {
+		return Versions[0], nil
+	}
+	for _, v := range Versions {
+		if v.Spec == specVersion {
+			return v, nil
+		}
+	}
+	return Version{}, fmt.Errorf("scan output has specVersion %s, which this release does not know (newest: %s); upgrade stack-analyzer to read it",
+		specVersion, Versions[len(Versions)-1].Spec)
+}
+
+// Migrate converts a decoded scan output to version to, in place, and
+// returns the version it had.
+func Migrate(doc map[string]interface{}, to Version) (Version, error) {
+	from, err := Detect(doc)
+	if err != nil {
+		return Version{}, err
+	}
+	target := index(to)
+	if target <= 0 {
+		return from, fmt.Errorf("cannot migrate to %s: it is not an output schema version that can be written", to.Name)
+	}
+	for i := index(from); i < target; i++ {
+		walkNodes(doc, steps[i].up)
+	}
+	for i := index(from); i > target; i-- {
+		walkNodes(doc, steps[i-1].down)
+	}
+	setSpecVersion(doc, to.Spec)
+	return from, nil
+}
+
+// ToCurrent converts a scan output JSON to the version scans write, returning
+// data as is when it already has that version. Readers of stored scan outputs
+// call it before decoding them.
+func ToCurrent(data []byte) ([]byte, error) {
+	var header struct {
+		Metadata struct {
+			SpecVersion string `json:"specVersion"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	if header.Metadata.SpecVersion == spec.Version {
+		return data, nil
+	}
+	doc, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(doc, Current()); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// Decode decodes a scan output JSON for Migrate, keeping numbers as written.
+func Decode(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse scan output (expected a stack-analyzer scan JSON): %w", err)
+	}
+	return doc, nil
+}
+
+func setSpecVersion(doc map[string]interface{}, specVersion string) {
+	metadata, ok := doc["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+		doc["metadata"] = metadata
+	}
+	metadata["specVersion"] = specVersion
+}
+
+// walkNodes applies a step to the root of an output and to every component
+// below it. Only component dependencies change between versions, so
+// properties sections with fields of the same name are left alone.
+func walkNodes(node map[string]interface{}, apply func(map[string]interface{})) {
+	if apply == nil {
+		return
+	}
+	apply(node)
+	children, _ := node["children"].([]interface{})
+	for _, child := range children {
+		if childNode, ok := child.(map[string]interface{}); ok {
+			walkNodes(childNode, apply)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package migrate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/spec"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const v0Output = `{
+  "id": "root",
+  "name": "myapp",
+  "dependencies": [["npm", "express", "4.18.2"]],
+  "children": [
+    {
+      "id": "api",
+      "name": "api",
+      "dependencies": [{"type": "maven", "name": "org.example:core", "version": "1.0", "direct": true, "source_file": "pom.xml"}],
+      "properties": {"packaging": [{"dependencies": [["deb", "libc6"]]}]}
+    }
+  ]
+}`
+
+const v1Output = `{
+  "id": "root",
+  "name": "myapp",
+  "metadata": {"specVersion": "0.1", "file_count": 12},
+  "dependencies": [["npm", "express", "4.18.2", "prod", true, {"source": "package.json"}]]
+}`
+
+func decode(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	doc, err := Decode([]byte(data))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"v2", "V2", "0.2"} {
+		v, err := Lookup(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "v2", v.Name)
+	}
+	_, err := Lookup("v9")
+	assert.ErrorContains(t, err, "Valid versions: v0, v1, v2")
+	assert.Equal(t, spec.Version, Current().Spec)
+}
+
+func TestMigrateV0ToV1(t *testing.T) {
+	doc := decode(t, v0Output)
+	from, err := Migrate(doc, Current())
+	require.NoError(t, err)
+	assert.Equal(t, "v0", from.Name)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var root types.Payload
+	require.NoError(t, json.Unmarshal(data, &root))
+	assert.Equal(t, []types.Dependency{{Type: "npm", Name: "express", Version: "4.18.2"}}, root.Dependencies)
+	assert.Equal(t, []types.Dependency{{Type: "maven", Name: "org.example:core", Version: "1.0", Direct: true,
+		Metadata: map[string]interface{}{"source": "pom.xml"}}}, root.Children[0].Dependencies)
+	assert.Equal(t, "0.1", doc["metadata"].(map[string]interface{})["specVersion"])
+
+	// Only component dependencies are migrated.
+	packaging := doc["children"].([]interface{})[0].(map[string]interface{})["properties"].(map[string]interface{})["packaging"]
+	assert.Len(t, packaging.([]interface{})[0].(map[string]interface{})["dependencies"].([]interface{})[0], 2)
+}
+
+func TestMigrateV1ToV2AndBack(t *testing.T) {
+	doc := decode(t, v1Output)
+	v2, _ := Lookup("v2")
+	_, err := Migrate(doc, v2)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"type": "npm", "name": "express", "version": "4.18.2", "scope": "prod", "direct": true,
+		"metadata": map[string]interface{}{"source": "package.json"},
+	}}, doc["dependencies"])
+	assert.Equal(t, "0.2", doc["metadata"].(map[string]interface{})["specVersion"])
+	assert.Equal(t, json.Number("12"), doc["metadata"].(map[string]interface{})["file_count"])
+
+	from, err := Migrate(doc, Current())
+	require.NoError(t, err)
+	assert.Equal(t, "v2", from.Name)
+	assert.Equal(t, decode(t, v1Output), doc)
+}
+
+func TestMigrateRejectsV0TargetAndUnknownVersions(t *testing.T) {
+	v0, _ := Lookup("v0")
+	_, err := Migrate(decode(t, v1Output), v0)
+	assert.ErrorContains(t, err, "cannot migrate to v0")
+
+	_, err = Migrate(decode(t, `{"metadata": {"specVersion": "7.0"}}`), Current())
+	assert.ErrorContains(t, err, "specVersion 7.0")
+}
+
+func TestToCurrent(t *testing.T) {
+	data := []byte(v1Output)
+	out, err := ToCurrent(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, out, "current outputs are returned as is")
+
+	out, err = ToCurrent([]byte(v0Output))
+	require.NoError(t, err)
+	var root types.Payload
+	require.NoError(t, json.Unmarshal(out, &root))
+	assert.Equal(t, "express", root.Dependencies[0].Name)
+
+	_, err = ToCurrent([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// userAgent identifies the client to code host APIs.
+const userAgent = "tech-stack-analyzer (+https://github.com/petrarca/tech-stack-analyzer)"
+
+// errNotFound is returned by the listing of an unknown organization or user.
+var errNotFound = errors.New("not found")
+
+// getJSON fetches rawURL and decodes the JSON response into into. authorize
+// adds the host's headers. A 404 is errNotFound; other failures report the
+// status only, as response bodies may echo the request.
+func getJSON(ctx context.Context, client *http.Client, rawURL string, authorize func(*http.Request), into any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	authorize(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode != http.StatusOK:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("API request failed: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// sameOrigin reports whether next has the scheme and host of base, so
+// pagination links are only followed, with the token, to the API itself.
+func sameOrigin(base, next string) bool {
+	b, err1 := url.Parse(base)
+	n, err2 := url.Parse(next)
+	return err1 == nil && err2 == nil && b.Scheme == n.Scheme && b.Host == n.Host
+}
+
+// stripUserinfo removes credentials or user names embedded in a URL.
+func stripUserinfo(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+	return u.String()
+}
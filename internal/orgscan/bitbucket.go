@@ -0,0 +1,209 @@
+package orgscan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+// DefaultBitbucketAPI is the API of Bitbucket Cloud.
+const DefaultBitbucketAPI = "https://api.bitbucket.org/2.0"
+
+// bitbucketPageSize is the largest page both Bitbucket APIs allow.
+const bitbucketPageSize = 100
+
+// BitbucketLister lists the repositories of a Bitbucket Cloud workspace or,
+// on a Bitbucket Server / Data Center instance, of a project.
+type BitbucketLister struct {
+	Workspace string // workspace slug (Cloud) or project key (Server)
+	// Username selects HTTP basic auth with Token as an app password;
+	// without it, Token is sent as a bearer access token.
+	Username string
+	Token    string
+	BaseURL  string // API base; DefaultBitbucketAPI when empty
+	// Server selects the Bitbucket Server / Data Center REST API
+	// (https://<host>/rest/api/1.0).
+	Server bool
+	Client *http.Client
+}
+
+// NewBitbucketLister creates a lister for workspace. A baseURL other than
+// Bitbucket Cloud's is a Bitbucket Server / Data Center instance; its
+// /rest/api/1.0 path is added when missing.
+func NewBitbucketLister(workspace, username, token, baseURL string) *BitbucketLister {
+	l := &BitbucketLister{
+		Workspace: workspace,
+		Username:  username,
+		Token:     token,
+		BaseURL:   DefaultBitbucketAPI,
+		Client:    httpclient.New(60 * time.Second),
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if baseURL != "" && !sameOrigin(DefaultBitbucketAPI, baseURL) {
+		l.Server = true
+		l.BaseURL = baseURL
+		if !strings.HasSuffix(baseURL, "/rest/api/1.0") {
+			l.BaseURL += "/rest/api/1.0"
+		}
+	}
+	return l
+}
+
+// Provider implements Lister.
+func (l *BitbucketLister) Provider() string { return "bitbucket" }
+
+// Organization implements Lister.
+func (l *BitbucketLister) Organization() string { return l.Workspace }
+
+// CloneAuth implements Lister. An app password clones with its user name,
+// an access token as the x-token-auth user.
+func (l *BitbucketLister) CloneAuth() (string, string) {
+	switch {
+	case l.Token == "":
+		return "", ""
+	case l.Username != "":
+		return l.Username, l.Token
+	default:
+		return "x-token-auth", l.Token
+	}
+}
+
+// bitbucketLink is an entry of a Bitbucket links object.
+type bitbucketLink struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+// bitbucketCloudPage is a page of the Bitbucket Cloud repository listing.
+type bitbucketCloudPage struct {
+	Values []struct {
+		Slug       string `json:"slug"`
+		FullName   string `json:"full_name"`
+		Language   string `json:"language"`
+		IsPrivate  bool   `json:"is_private"`
+		MainBranch *struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+		Parent *struct{} `json:"parent"`
+		Links  struct {
+			HTML  bitbucketLink   `json:"html"`
+			Clone []bitbucketLink `json:"clone"`
+		} `json:"links"`
+	} `json:"values"`
+	Next string `json:"next"`
+}
+
+// bitbucketServerPage is a page of the Bitbucket Server repository listing.
+type bitbucketServerPage struct {
+	Values []struct {
+		Slug     string    `json:"slug"`
+		Public   bool      `json:"public"`
+		Archived bool      `json:"archived"`
+		Origin   *struct{} `json:"origin"`
+		Project  struct {
+			Key string `json:"key"`
+		} `json:"project"`
+		Links struct {
+			Self  []bitbucketLink `json:"self"`
+			Clone []bitbucketLink `json:"clone"`
+		} `json:"links"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// ListRepositories implements Lister.
+func (l *BitbucketLister) ListRepositories(ctx context.Context) ([]Repository, error) {
+	list := l.listCloud
+	if l.Server {
+		list = l.listServer
+	}
+	repos, err := list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list Bitbucket repositories of %s: %w", l.Workspace, err)
+	}
+	return repos, nil
+}
+
+// listCloud follows the next links of the workspace listing. Bitbucket
+// Cloud has no archived repositories or topics.
+func (l *BitbucketLister) listCloud(ctx context.Context) ([]Repository, error) {
+	var repos []Repository
+	next := fmt.Sprintf("%s/repositories/%s?pagelen=%d", l.BaseURL, url.PathEscape(l.Workspace), bitbucketPageSize)
+	for next != "" {
+		if !sameOrigin(l.BaseURL, next) {
+			return nil, fmt.Errorf("pagination link leaves the API host")
+		}
+		var page bitbucketCloudPage
+		if err := l.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, v := range page.Values {
+			repo := Repository{
+				Name: v.Slug, FullName: v.FullName, URL: v.Links.HTML.Href, CloneURL: stripUserinfo(cloneLink(v.Links.Clone, "https")),
+				Language: v.Language, Private: v.IsPrivate, Fork: v.Parent != nil,
+			}
+			if v.MainBranch != nil {
+				repo.DefaultBranch = v.MainBranch.Name
+			}
+			repos = append(repos, repo)
+		}
+		next = page.Next
+	}
+	return repos, nil
+}
+
+// listServer pages through the repositories of a project. The listing
+// has no languages or default branches.
+func (l *BitbucketLister) listServer(ctx context.Context) ([]Repository, error) {
+	var repos []Repository
+	for start := 0; ; {
+		var page bitbucketServerPage
+		path := fmt.Sprintf("%s/projects/%s/repos?limit=%d&start=%d", l.BaseURL, url.PathEscape(l.Workspace), bitbucketPageSize, start)
+		if err := l.get(ctx, path, &page); err != nil {
+			return nil, err
+		}
+		for _, v := range page.Values {
+			repo := Repository{
+				Name: v.Slug, FullName: v.Project.Key + "/" + v.Slug, CloneURL: stripUserinfo(cloneLink(v.Links.Clone, "http")),
+				Archived: v.Archived, Fork: v.Origin != nil, Private: !v.Public,
+			}
+			if len(v.Links.Self) > 0 {
+				repo.URL = v.Links.Self[0].Href
+			}
+			repos = append(repos, repo)
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return repos, nil
+		}
+		start = page.NextPageStart
+	}
+}
+
+// cloneLink returns the clone URL with the given name (https on Cloud,
+// http on Server, which covers HTTPS too).
+func cloneLink(links []bitbucketLink, name string) string {
+	for _, link := range links {
+		if link.Name == name {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+func (l *BitbucketLister) get(ctx context.Context, rawURL string, into any) error {
+	return getJSON(ctx, l.Client, rawURL, func(req *http.Request) {
+		switch {
+		case l.Token == "":
+		case l.Username != "":
+			req.SetBasicAuth(l.Username, l.Token)
+		default:
+			req.Header.Set("Authorization", "Bearer "+l.Token)
+		}
+	}, into)
+}
@@ -0,0 +1,127 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bitbucketCloudRepo(i int) map[string]any {
+	name := fmt.Sprintf("repo%03d", i)
+	repo := map[string]any{
+		"slug": name, "full_name": "myworkspace/" + name, "language": "go", "is_private": true,
+		"mainbranch": map[string]any{"name": "main"},
+		"links": map[string]any{
+			"html": map[string]any{"href": "https://bitbucket.org/myworkspace/" + name},
+			"clone": []map[string]any{
+				{"name": "https", "href": "https://someone@bitbucket.org/myworkspace/" + name + ".git"},
+				{"name": "ssh", "href": "git@bitbucket.org:myworkspace/" + name + ".git"},
+			},
+		},
+	}
+	if i == 1 {
+		repo["parent"] = map[string]any{"full_name": "upstream/" + name}
+	}
+	return repo
+}
+
+func TestBitbucketCloudListerFollowsNextLinks(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/myworkspace", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		resp := map[string]any{"values": []map[string]any{bitbucketCloudRepo(2 * page), bitbucketCloudRepo(2*page + 1)}}
+		if page < 2 {
+			resp["next"] = fmt.Sprintf("%s/repositories/myworkspace?pagelen=100&page=%d", srv.URL, page+1)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	l := NewBitbucketLister("myworkspace", "", "test-token", "")
+	l.BaseURL = srv.URL
+	repos, err := l.ListRepositories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repos, 6)
+	assert.Equal(t, Repository{
+		Name: "repo000", FullName: "myworkspace/repo000", URL: "https://bitbucket.org/myworkspace/repo000",
+		CloneURL: "https://bitbucket.org/myworkspace/repo000.git", DefaultBranch: "main", Language: "go", Private: true,
+	}, repos[0])
+	assert.True(t, repos[1].Fork)
+	assert.Equal(t, "repo005", repos[5].Name)
+}
+
+func TestBitbucketCloudListerRejectsForeignNextLink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"values": []map[string]any{bitbucketCloudRepo(0)},
+			"next":   "https://attacker.example.com/repositories/myworkspace?page=2",
+		})
+	}))
+	defer srv.Close()
+
+	l := NewBitbucketLister("myworkspace", "", "test-token", "")
+	l.BaseURL = srv.URL
+	_, err := l.ListRepositories(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "leaves the API host")
+}
+
+func TestBitbucketServerListerPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/projects/PLAT/repos", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "jdoe:test-token", user+":"+pass)
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		var values []map[string]any
+		for i := start; i < min(start+2, 5); i++ {
+			name := fmt.Sprintf("repo%03d", i)
+			values = append(values, map[string]any{
+				"slug": name, "public": i == 0, "archived": i == 4, "project": map[string]any{"key": "PLAT"},
+				"links": map[string]any{
+					"self":  []map[string]any{{"href": "https://bitbucket.example.com/projects/PLAT/repos/" + name + "/browse"}},
+					"clone": []map[string]any{{"name": "http", "href": "https://jdoe@bitbucket.example.com/scm/plat/" + name + ".git"}},
+				},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"values": values, "isLastPage": start+2 >= 5, "nextPageStart": start + 2})
+	}))
+	defer srv.Close()
+
+	l := NewBitbucketLister("PLAT", "jdoe", "test-token", srv.URL)
+	require.True(t, l.Server)
+	repos, err := l.ListRepositories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repos, 5)
+	assert.Equal(t, Repository{
+		Name: "repo000", FullName: "PLAT/repo000", URL: "https://bitbucket.example.com/projects/PLAT/repos/repo000/browse",
+		CloneURL: "https://bitbucket.example.com/scm/plat/repo000.git",
+	}, repos[0])
+	assert.True(t, repos[1].Private)
+	assert.True(t, repos[4].Archived)
+}
+
+func TestNewBitbucketListerBaseURL(t *testing.T) {
+	assert.False(t, NewBitbucketLister("ws", "", "", "").Server)
+	assert.False(t, NewBitbucketLister("ws", "", "", DefaultBitbucketAPI+"/").Server)
+	assert.Equal(t, "https://bitbucket.example.com/rest/api/1.0", NewBitbucketLister("PLAT", "", "", "https://bitbucket.example.com/").BaseURL)
+	assert.Equal(t, "https://bitbucket.example.com/rest/api/1.0", NewBitbucketLister("PLAT", "", "", "https://bitbucket.example.com/rest/api/1.0").BaseURL)
+}
+
+func TestBitbucketCloneAuth(t *testing.T) {
+	user, pass := NewBitbucketLister("ws", "", "", "").CloneAuth()
+	assert.Empty(t, user+pass)
+	user, _ = NewBitbucketLister("ws", "", "test-token", "").CloneAuth()
+	assert.Equal(t, "x-token-auth", user)
+	user, pass = NewBitbucketLister("ws", "jdoe", "test-token", "").CloneAuth()
+	assert.Equal(t, "jdoe:test-token", user+":"+pass)
+}
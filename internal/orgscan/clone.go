@@ -0,0 +1,91 @@
+package orgscan
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+// cloneRepository clones for Run; tests replace it.
+var cloneRepository = Clone
+
+func init() {
+	// Clones use the shared network layer, so offline mode, the proxy and
+	// the rate limit cover them as well. Git responses are never cached.
+	t := githttp.NewClient(httpclient.NewUncached(0))
+	client.InstallProtocol("https", t)
+	client.InstallProtocol("http", t)
+}
+
+// Clone shallow-clones the default branch of repo into dir. An existing
+// clone in dir is updated instead; one that cannot be updated is cloned
+// again. Credentials are passed as HTTP basic auth, never in the URL.
+func Clone(ctx context.Context, repo Repository, dir, username, password string) error {
+	var auth transport.AuthMethod
+	if password != "" {
+		auth = &githttp.BasicAuth{Username: username, Password: password}
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		if updateClone(ctx, dir, auth) == nil {
+			return nil
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	_, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL:          repo.CloneURL,
+		Auth:         auth,
+		Depth:        1,
+		SingleBranch: true,
+		Tags:         git.NoTags,
+	})
+	return err
+}
+
+func updateClone(ctx context.Context, dir string, auth transport.AuthMethod) error {
+	r, err := git.PlainOpen(dir)
+	if err != nil {
+		return err
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+	err = w.PullContext(ctx, &git.PullOptions{Auth: auth, Depth: 1, SingleBranch: true, Force: true})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// cloneDirName returns the directory name of a repository's clone: its
+// full name with every character other than letters, digits, '.', '-' and
+// '_' replaced, so names cannot escape the work directory.
+func cloneDirName(repo Repository) string {
+	name := repo.FullName
+	if name == "" {
+		name = repo.Name
+	}
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+	safe = strings.TrimLeft(safe, ".")
+	if safe == "" {
+		return "_"
+	}
+	return safe
+}
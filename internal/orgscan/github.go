@@ -0,0 +1,115 @@
+package orgscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+// DefaultGitHubAPI is the API of github.com. GitHub Enterprise Server
+// serves it under https://<host>/api/v3.
+const DefaultGitHubAPI = "https://api.github.com"
+
+// githubPageSize is the largest page the repository listing allows.
+const githubPageSize = 100
+
+// GitHubLister lists the repositories of a GitHub organization, or of a
+// user when no organization has the name.
+type GitHubLister struct {
+	Org     string
+	Token   string // optional; needed for private repositories
+	BaseURL string // API base; DefaultGitHubAPI when empty
+	Client  *http.Client
+}
+
+// NewGitHubLister creates a lister for org on the API at baseURL (empty for
+// github.com).
+func NewGitHubLister(org, token, baseURL string) *GitHubLister {
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPI
+	}
+	return &GitHubLister{
+		Org:     org,
+		Token:   token,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  httpclient.New(60 * time.Second),
+	}
+}
+
+// Provider implements Lister.
+func (l *GitHubLister) Provider() string { return "github" }
+
+// Organization implements Lister.
+func (l *GitHubLister) Organization() string { return l.Org }
+
+// CloneAuth implements Lister. GitHub accepts a token as the password of
+// any user name.
+func (l *GitHubLister) CloneAuth() (string, string) {
+	if l.Token == "" {
+		return "", ""
+	}
+	return "x-access-token", l.Token
+}
+
+// githubRepo is a repository of the GitHub REST API.
+type githubRepo struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	HTMLURL       string   `json:"html_url"`
+	CloneURL      string   `json:"clone_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Language      string   `json:"language"`
+	Topics        []string `json:"topics"`
+	Archived      bool     `json:"archived"`
+	Fork          bool     `json:"fork"`
+	Private       bool     `json:"private"`
+}
+
+// ListRepositories implements Lister. It pages through the organization's
+// repositories, falling back to the user's when there is no such
+// organization.
+func (l *GitHubLister) ListRepositories(ctx context.Context) ([]Repository, error) {
+	repos, err := l.list(ctx, "/orgs/"+url.PathEscape(l.Org)+"/repos?type=all")
+	if errors.Is(err, errNotFound) {
+		repos, err = l.list(ctx, "/users/"+url.PathEscape(l.Org)+"/repos?type=owner")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list GitHub repositories of %s: %w", l.Org, err)
+	}
+	return repos, nil
+}
+
+func (l *GitHubLister) list(ctx context.Context, path string) ([]Repository, error) {
+	var repos []Repository
+	for page := 1; ; page++ {
+		var batch []githubRepo
+		if err := l.get(ctx, fmt.Sprintf("%s&per_page=%d&page=%d", path, githubPageSize, page), &batch); err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			repos = append(repos, Repository{
+				Name: r.Name, FullName: r.FullName, URL: r.HTMLURL, CloneURL: r.CloneURL,
+				DefaultBranch: r.DefaultBranch, Language: r.Language, Topics: r.Topics,
+				Archived: r.Archived, Fork: r.Fork, Private: r.Private,
+			})
+		}
+		if len(batch) < githubPageSize {
+			return repos, nil
+		}
+	}
+}
+
+func (l *GitHubLister) get(ctx context.Context, path string, into any) error {
+	return getJSON(ctx, l.Client, l.BaseURL+path, func(req *http.Request) {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if l.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+l.Token)
+		}
+	}, into)
+}
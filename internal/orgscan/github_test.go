@@ -0,0 +1,80 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func githubServer(t *testing.T, repos int, orgExists bool) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	list := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		var batch []map[string]any
+		for i := (page - 1) * perPage; i < min(page*perPage, repos); i++ {
+			name := fmt.Sprintf("repo%03d", i)
+			batch = append(batch, map[string]any{
+				"name": name, "full_name": "myorg/" + name, "html_url": "https://github.com/myorg/" + name,
+				"clone_url": "https://github.com/myorg/" + name + ".git", "default_branch": "main",
+				"language": "Go", "topics": []string{"platform"}, "archived": i == 0,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(batch)
+	}
+	if orgExists {
+		mux.HandleFunc("GET /orgs/myorg/repos", list)
+	}
+	mux.HandleFunc("GET /users/myorg/repos", list)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGitHubListerPaginates(t *testing.T) {
+	srv := githubServer(t, 150, true)
+	repos, err := NewGitHubLister("myorg", "test-token", srv.URL).ListRepositories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repos, 150)
+	assert.Equal(t, Repository{
+		Name: "repo000", FullName: "myorg/repo000", URL: "https://github.com/myorg/repo000",
+		CloneURL: "https://github.com/myorg/repo000.git", DefaultBranch: "main", Language: "Go",
+		Topics: []string{"platform"}, Archived: true,
+	}, repos[0])
+	assert.Equal(t, "repo149", repos[149].Name)
+}
+
+func TestGitHubListerFallsBackToUser(t *testing.T) {
+	srv := githubServer(t, 3, false)
+	repos, err := NewGitHubLister("myorg", "test-token", srv.URL).ListRepositories(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repos, 3)
+}
+
+func TestGitHubListerErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	_, err := NewGitHubLister("myorg", "test-token", srv.URL).ListRepositories(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.NotContains(t, err.Error(), "test-token")
+}
+
+func TestGitHubCloneAuth(t *testing.T) {
+	user, pass := NewGitHubLister("myorg", "", "").CloneAuth()
+	assert.Empty(t, user+pass)
+	user, pass = NewGitHubLister("myorg", "test-token", "").CloneAuth()
+	assert.Equal(t, "x-access-token", user)
+	assert.Equal(t, "test-token", pass)
+}
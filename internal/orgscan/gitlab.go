@@ -0,0 +1,142 @@
+package orgscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
+)
+
+// DefaultGitLabAPI is the API of gitlab.com. Self-managed instances serve
+// it under https://<host>/api/v4.
+const DefaultGitLabAPI = "https://gitlab.com/api/v4"
+
+// gitlabPageSize is the largest page the project listing allows.
+const gitlabPageSize = 100
+
+// GitLabLister lists the projects of a GitLab group and its subgroups, or
+// of a user when no group has the name.
+type GitLabLister struct {
+	Group   string // group path, e.g. myorg or myorg/platform
+	Token   string // optional; needed for private projects
+	BaseURL string // API base; DefaultGitLabAPI when empty
+	// FetchLanguages looks up each project's main language, which the
+	// listing does not include; it costs one request per project.
+	FetchLanguages bool
+	Client         *http.Client
+}
+
+// NewGitLabLister creates a lister for group on the API at baseURL (empty
+// for gitlab.com).
+func NewGitLabLister(group, token, baseURL string) *GitLabLister {
+	if baseURL == "" {
+		baseURL = DefaultGitLabAPI
+	}
+	return &GitLabLister{
+		Group:   group,
+		Token:   token,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client:  httpclient.New(60 * time.Second),
+	}
+}
+
+// Provider implements Lister.
+func (l *GitLabLister) Provider() string { return "gitlab" }
+
+// Organization implements Lister.
+func (l *GitLabLister) Organization() string { return l.Group }
+
+// CloneAuth implements Lister. GitLab takes a token as the password of the
+// oauth2 user.
+func (l *GitLabLister) CloneAuth() (string, string) {
+	if l.Token == "" {
+		return "", ""
+	}
+	return "oauth2", l.Token
+}
+
+// gitlabProject is a project of the GitLab REST API.
+type gitlabProject struct {
+	ID                int64    `json:"id"`
+	Path              string   `json:"path"`
+	PathWithNamespace string   `json:"path_with_namespace"`
+	WebURL            string   `json:"web_url"`
+	HTTPURLToRepo     string   `json:"http_url_to_repo"`
+	DefaultBranch     string   `json:"default_branch"`
+	Topics            []string `json:"topics"`
+	Archived          bool     `json:"archived"`
+	Visibility        string   `json:"visibility"`
+	ForkedFrom        *struct {
+		ID int64 `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+// ListRepositories implements Lister. It pages through the projects of the
+// group including subgroups, falling back to the user's projects when there
+// is no such group.
+func (l *GitLabLister) ListRepositories(ctx context.Context) ([]Repository, error) {
+	projects, err := l.list(ctx, "/groups/"+url.PathEscape(l.Group)+"/projects?include_subgroups=true")
+	if errors.Is(err, errNotFound) {
+		projects, err = l.list(ctx, "/users/"+url.PathEscape(l.Group)+"/projects?")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list GitLab projects of %s: %w", l.Group, err)
+	}
+	repos := make([]Repository, 0, len(projects))
+	for _, p := range projects {
+		repo := Repository{
+			Name: p.Path, FullName: p.PathWithNamespace, URL: p.WebURL, CloneURL: stripUserinfo(p.HTTPURLToRepo),
+			DefaultBranch: p.DefaultBranch, Topics: p.Topics, Archived: p.Archived,
+			Fork: p.ForkedFrom != nil, Private: p.Visibility != "public",
+		}
+		if l.FetchLanguages {
+			if repo.Language, err = l.language(ctx, p.ID); err != nil {
+				return nil, fmt.Errorf("languages of GitLab project %s: %w", p.PathWithNamespace, err)
+			}
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+func (l *GitLabLister) list(ctx context.Context, path string) ([]gitlabProject, error) {
+	var projects []gitlabProject
+	for page := 1; ; page++ {
+		var batch []gitlabProject
+		if err := l.get(ctx, fmt.Sprintf("%s&per_page=%d&page=%d", path, gitlabPageSize, page), &batch); err != nil {
+			return nil, err
+		}
+		projects = append(projects, batch...)
+		if len(batch) < gitlabPageSize {
+			return projects, nil
+		}
+	}
+}
+
+// language returns the language with the largest share of a project.
+func (l *GitLabLister) language(ctx context.Context, id int64) (string, error) {
+	var shares map[string]float64
+	if err := l.get(ctx, fmt.Sprintf("/projects/%d/languages", id), &shares); err != nil {
+		return "", err
+	}
+	main, best := "", 0.0
+	for lang, share := range shares {
+		if share > best || share == best && lang < main {
+			main, best = lang, share
+		}
+	}
+	return main, nil
+}
+
+func (l *GitLabLister) get(ctx context.Context, path string, into any) error {
+	return getJSON(ctx, l.Client, l.BaseURL+path, func(req *http.Request) {
+		if l.Token != "" {
+			req.Header.Set("PRIVATE-TOKEN", l.Token)
+		}
+	}, into)
+}
@@ -0,0 +1,93 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gitlabServer(t *testing.T, projects int, groupExists bool) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	list := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.Header.Get("PRIVATE-TOKEN"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		var batch []map[string]any
+		for i := (page - 1) * perPage; i < min(page*perPage, projects); i++ {
+			name := fmt.Sprintf("repo%03d", i)
+			p := map[string]any{
+				"id": i, "path": name, "path_with_namespace": "myorg/platform/" + name,
+				"web_url":          "https://gitlab.example.com/myorg/platform/" + name,
+				"http_url_to_repo": "https://user@gitlab.example.com/myorg/platform/" + name + ".git",
+				"default_branch":   "main", "topics": []string{"platform"}, "archived": i == 0, "visibility": "internal",
+			}
+			if i == 1 {
+				p["forked_from_project"] = map[string]any{"id": 42}
+			}
+			batch = append(batch, p)
+		}
+		_ = json.NewEncoder(w).Encode(batch)
+	}
+	if groupExists {
+		mux.HandleFunc("GET /groups/{group}/projects", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "myorg/platform", r.PathValue("group"))
+			assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+			list(w, r)
+		})
+	}
+	mux.HandleFunc("GET /users/{user}/projects", list)
+	mux.HandleFunc("GET /projects/{id}/languages", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]float64{"Go": 61.5, "Shell": 38.5})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGitLabListerPaginates(t *testing.T) {
+	srv := gitlabServer(t, 150, true)
+	repos, err := NewGitLabLister("myorg/platform", "test-token", srv.URL).ListRepositories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repos, 150)
+	assert.Equal(t, Repository{
+		Name: "repo000", FullName: "myorg/platform/repo000", URL: "https://gitlab.example.com/myorg/platform/repo000",
+		CloneURL: "https://gitlab.example.com/myorg/platform/repo000.git", DefaultBranch: "main",
+		Topics: []string{"platform"}, Archived: true, Private: true,
+	}, repos[0])
+	assert.True(t, repos[1].Fork)
+	assert.False(t, repos[2].Fork)
+	assert.Equal(t, "repo149", repos[149].Name)
+}
+
+func TestGitLabListerFallsBackToUser(t *testing.T) {
+	srv := gitlabServer(t, 3, false)
+	repos, err := NewGitLabLister("myorg", "test-token", srv.URL).ListRepositories(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, repos, 3)
+}
+
+func TestGitLabListerFetchesLanguages(t *testing.T) {
+	srv := gitlabServer(t, 2, true)
+	l := NewGitLabLister("myorg/platform", "test-token", srv.URL)
+	l.FetchLanguages = true
+	repos, err := l.ListRepositories(context.Background())
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, "Go", repos[0].Language)
+}
+
+func TestGitLabCloneAuth(t *testing.T) {
+	user, pass := NewGitLabLister("myorg", "", "").CloneAuth()
+	assert.Empty(t, user+pass)
+	user, pass = NewGitLabLister("myorg", "test-token", "").CloneAuth()
+	assert.Equal(t, "oauth2", user)
+	assert.Equal(t, "test-token", pass)
+}
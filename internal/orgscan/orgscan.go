@@ -0,0 +1,77 @@
+// Package orgscan scans every repository of a code hosting organization:
+// it lists the repositories through the host's API, filters them, shallow
+// clones each one and scans the clones concurrently into one portfolio
+// result. Listing is behind the Lister interface so hosts can be added
+// without touching the clone and scan pipeline.
+package orgscan
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Repository is a repository as listed by a code host.
+type Repository struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	URL           string   `json:"url"`
+	CloneURL      string   `json:"-"`
+	DefaultBranch string   `json:"default_branch,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Topics        []string `json:"topics,omitempty"`
+	Archived      bool     `json:"archived,omitempty"`
+	Fork          bool     `json:"fork,omitempty"`
+	Private       bool     `json:"private,omitempty"`
+}
+
+// Lister lists the repositories of an organization on one code host.
+type Lister interface {
+	// Provider names the code host (github, gitlab or bitbucket).
+	Provider() string
+	// Organization is the organization, group or user being listed.
+	Organization() string
+	ListRepositories(ctx context.Context) ([]Repository, error)
+	// CloneAuth returns the HTTP basic auth credentials for cloning, or
+	// empty strings for anonymous clones.
+	CloneAuth() (username, password string)
+}
+
+// Filter selects the repositories to scan. Archived repositories and forks
+// are skipped unless included; languages and topics, when given, must
+// match (any of them, case-insensitively).
+type Filter struct {
+	IncludeArchived bool
+	IncludeForks    bool
+	Languages       []string
+	Topics          []string
+}
+
+// Matches reports whether repo passes the filter.
+func (f Filter) Matches(repo Repository) bool {
+	if repo.Archived && !f.IncludeArchived || repo.Fork && !f.IncludeForks {
+		return false
+	}
+	if len(f.Languages) > 0 && !containsFold(f.Languages, repo.Language) {
+		return false
+	}
+	if len(f.Topics) > 0 && !slices.ContainsFunc(repo.Topics, func(t string) bool { return containsFold(f.Topics, t) }) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the repositories passing the filter, in order.
+func (f Filter) Apply(repos []Repository) []Repository {
+	var out []Repository
+	for _, repo := range repos {
+		if f.Matches(repo) {
+			out = append(out, repo)
+		}
+	}
+	return out
+}
+
+func containsFold(values []string, v string) bool {
+	return slices.ContainsFunc(values, func(s string) bool { return strings.EqualFold(s, v) })
+}
@@ -0,0 +1,202 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Portfolio is the result of scanning an organization: one entry per
+// scanned repository and the repositories using each tech.
+type Portfolio struct {
+	Provider     string       `json:"provider"`
+	Organization string       `json:"organization"`
+	ScannedAt    time.Time    `json:"scanned_at"`
+	DurationMS   int64        `json:"duration_ms"`
+	Summary      Summary      `json:"summary"`
+	Techs        []TechUsage  `json:"techs"`
+	Repositories []RepoResult `json:"repositories"`
+}
+
+// Summary counts the repositories of a portfolio.
+type Summary struct {
+	Listed  int `json:"listed"`  // returned by the host
+	Skipped int `json:"skipped"` // excluded by the filter
+	Scanned int `json:"scanned"`
+	Failed  int `json:"failed"` // clone or scan failed
+}
+
+// TechUsage lists the repositories a tech was detected in.
+type TechUsage struct {
+	Tech         string   `json:"tech"`
+	Count        int      `json:"count"`
+	Repositories []string `json:"repositories"`
+}
+
+// RepoResult is the outcome of scanning one repository.
+type RepoResult struct {
+	Repository
+	Status       string          `json:"status"` // ok or error
+	Error        string          `json:"error,omitempty"`
+	DurationMS   int64           `json:"duration_ms"`
+	PrimaryTechs []string        `json:"primary_techs,omitempty"`
+	Result       json.RawMessage `json:"result,omitempty"`
+
+	techs []string
+}
+
+// ScanOutput is what a ScanFunc reports for one repository: the scan
+// output JSON and the techs for the portfolio rollup.
+type ScanOutput struct {
+	Result       json.RawMessage
+	Techs        []string
+	PrimaryTechs []string
+}
+
+// ScanFunc scans the clone of a repository in dir.
+type ScanFunc func(repo Repository, dir string) (*ScanOutput, error)
+
+// Options control the clone and scan pipeline.
+type Options struct {
+	// Concurrency is the number of repositories cloned and scanned at once.
+	Concurrency int
+	// WorkDir keeps the clones, which later runs update. When empty, a
+	// temporary directory is used and each clone removed after its scan.
+	WorkDir string
+	// OnDone is called after each repository, from the scanning goroutine.
+	OnDone func(done, total int, result *RepoResult)
+}
+
+// Run lists the repositories of lister, clones and scans those passing
+// filter and returns the portfolio. Failing repositories are recorded with
+// their error; only a failed listing fails the run.
+func Run(ctx context.Context, lister Lister, filter Filter, opts Options, scan ScanFunc) (*Portfolio, error) {
+	start := time.Now()
+	listed, err := lister.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repos := filter.Apply(listed)
+
+	workDir, cleanup, err := prepareWorkDir(opts.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	results := make([]RepoResult, len(repos))
+	username, password := lister.CloneAuth()
+	var mu sync.Mutex
+	done := 0
+	runConcurrently(len(repos), max(opts.Concurrency, 1), func(i int) {
+		results[i] = scanRepository(ctx, repos[i], filepath.Join(workDir, cloneDirName(repos[i])), opts.WorkDir == "", username, password, scan)
+		if opts.OnDone != nil {
+			mu.Lock()
+			done++
+			opts.OnDone(done, len(repos), &results[i])
+			mu.Unlock()
+		}
+	})
+
+	p := &Portfolio{
+		Provider:     lister.Provider(),
+		Organization: lister.Organization(),
+		ScannedAt:    start.UTC(),
+		DurationMS:   time.Since(start).Milliseconds(),
+		Summary:      Summary{Listed: len(listed), Skipped: len(listed) - len(repos)},
+		Repositories: results,
+	}
+	for _, r := range results {
+		if r.Status == "ok" {
+			p.Summary.Scanned++
+		} else {
+			p.Summary.Failed++
+		}
+	}
+	p.Techs = techUsage(results)
+	return p, nil
+}
+
+// prepareWorkDir returns the directory clones go to and a cleanup
+// function removing it when it is temporary.
+func prepareWorkDir(dir string) (string, func(), error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", nil, err
+		}
+		return dir, func() {}, nil
+	}
+	tmp, err := os.MkdirTemp("", "stack-analyzer-org-")
+	if err != nil {
+		return "", nil, err
+	}
+	return tmp, func() { _ = os.RemoveAll(tmp) }, nil
+}
+
+// runConcurrently calls fn for 0..n-1, at most limit at a time.
+func runConcurrently(n, limit int, fn func(i int)) {
+	slots := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func scanRepository(ctx context.Context, repo Repository, dir string, removeClone bool, username, password string, scan ScanFunc) (result RepoResult) {
+	start := time.Now()
+	result = RepoResult{Repository: repo, Status: "error"}
+	defer func() { result.DurationMS = time.Since(start).Milliseconds() }()
+	if removeClone {
+		defer func() { _ = os.RemoveAll(dir) }()
+	}
+
+	if err := cloneRepository(ctx, repo, dir, username, password); err != nil {
+		result.Error = fmt.Sprintf("clone: %v", err)
+		return result
+	}
+	out, err := scan(repo, dir)
+	if err != nil {
+		result.Error = fmt.Sprintf("scan: %v", err)
+		return result
+	}
+	result.Status = "ok"
+	result.Result = out.Result
+	result.PrimaryTechs = out.PrimaryTechs
+	result.techs = out.Techs
+	return result
+}
+
+// techUsage lists, per tech, the repositories it was detected in; most
+// used techs first.
+func techUsage(results []RepoResult) []TechUsage {
+	byTech := make(map[string][]string)
+	for _, r := range results {
+		for _, tech := range r.techs {
+			byTech[tech] = append(byTech[tech], r.FullName)
+		}
+	}
+	usage := make([]TechUsage, 0, len(byTech))
+	for tech, repos := range byTech {
+		sort.Strings(repos)
+		usage = append(usage, TechUsage{Tech: tech, Count: len(repos), Repositories: repos})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Tech < usage[j].Tech
+	})
+	return usage
+}
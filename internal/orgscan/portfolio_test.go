@@ -0,0 +1,125 @@
+package orgscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLister struct {
+	repos []Repository
+}
+
+func (l *fakeLister) Provider() string     { return "github" }
+func (l *fakeLister) Organization() string { return "myorg" }
+func (l *fakeLister) CloneAuth() (string, string) {
+	return "x-access-token", "test-token"
+}
+func (l *fakeLister) ListRepositories(context.Context) ([]Repository, error) {
+	return l.repos, nil
+}
+
+func repo(name, language string, topics ...string) Repository {
+	return Repository{Name: name, FullName: "myorg/" + name, CloneURL: "https://github.com/myorg/" + name + ".git", Language: language, Topics: topics}
+}
+
+func TestFilter(t *testing.T) {
+	archived := repo("old", "Go")
+	archived.Archived = true
+	fork := repo("fork", "Go")
+	fork.Fork = true
+	repos := []Repository{repo("api", "Go", "platform"), repo("web", "TypeScript", "frontend"), archived, fork}
+
+	names := func(f Filter) []string {
+		var out []string
+		for _, r := range f.Apply(repos) {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"api", "web"}, names(Filter{}))
+	assert.Equal(t, []string{"api", "web", "old", "fork"}, names(Filter{IncludeArchived: true, IncludeForks: true}))
+	assert.Equal(t, []string{"api"}, names(Filter{Languages: []string{"go"}}))
+	assert.Equal(t, []string{"web"}, names(Filter{Topics: []string{"Frontend", "mobile"}}))
+}
+
+func TestRunBuildsPortfolio(t *testing.T) {
+	var mu sync.Mutex
+	var cloneDirs []string
+	cloneRepository = func(_ context.Context, r Repository, dir, user, pass string) error {
+		assert.Equal(t, "x-access-token", user)
+		assert.Equal(t, "test-token", pass)
+		if r.Name == "broken" {
+			return errors.New("repository not found")
+		}
+		mu.Lock()
+		cloneDirs = append(cloneDirs, dir)
+		mu.Unlock()
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		return os.WriteFile(filepath.Join(dir, "README.md"), []byte(r.Name), 0o644)
+	}
+	t.Cleanup(func() { cloneRepository = Clone })
+
+	archived := repo("old", "Go")
+	archived.Archived = true
+	lister := &fakeLister{repos: []Repository{repo("api", "Go"), repo("web", "TypeScript"), repo("broken", "Go"), archived}}
+	scan := func(r Repository, dir string) (*ScanOutput, error) {
+		content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+		require.NoError(t, err)
+		techs := map[string][]string{"api": {"golang", "docker"}, "web": {"nodejs", "docker"}}[string(content)]
+		return &ScanOutput{Result: json.RawMessage(`{"name":"` + r.Name + `"}`), Techs: techs, PrimaryTechs: techs[:1]}, nil
+	}
+
+	var progress []string
+	p, err := Run(context.Background(), lister, Filter{}, Options{Concurrency: 2, OnDone: func(_, _ int, r *RepoResult) {
+		progress = append(progress, r.Name)
+	}}, scan)
+	require.NoError(t, err)
+
+	assert.Equal(t, Summary{Listed: 4, Skipped: 1, Scanned: 2, Failed: 1}, p.Summary)
+	assert.ElementsMatch(t, []string{"api", "web", "broken"}, progress)
+	require.Len(t, p.Repositories, 3)
+	assert.Equal(t, "ok", p.Repositories[0].Status)
+	assert.JSONEq(t, `{"name":"api"}`, string(p.Repositories[0].Result))
+	assert.Equal(t, "error", p.Repositories[2].Status)
+	assert.Equal(t, "clone: repository not found", p.Repositories[2].Error)
+	assert.Equal(t, []TechUsage{
+		{Tech: "docker", Count: 2, Repositories: []string{"myorg/api", "myorg/web"}},
+		{Tech: "golang", Count: 1, Repositories: []string{"myorg/api"}},
+		{Tech: "nodejs", Count: 1, Repositories: []string{"myorg/web"}},
+	}, p.Techs)
+
+	for _, dir := range cloneDirs {
+		_, err := os.Stat(filepath.Dir(dir))
+		assert.True(t, os.IsNotExist(err), "temporary work directory is removed")
+	}
+}
+
+func TestRunKeepsClonesInWorkDir(t *testing.T) {
+	cloneRepository = func(_ context.Context, _ Repository, dir, _, _ string) error {
+		return os.MkdirAll(dir, 0o755)
+	}
+	t.Cleanup(func() { cloneRepository = Clone })
+
+	workDir := t.TempDir()
+	_, err := Run(context.Background(), &fakeLister{repos: []Repository{repo("api", "Go")}}, Filter{}, Options{WorkDir: workDir},
+		func(Repository, string) (*ScanOutput, error) { return &ScanOutput{}, nil })
+	require.NoError(t, err)
+	info, err := os.Stat(filepath.Join(workDir, "myorg_api"))
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
+
+func TestCloneDirName(t *testing.T) {
+	assert.Equal(t, "myorg_api", cloneDirName(Repository{FullName: "myorg/api"}))
+	assert.Equal(t, "_.._etc", cloneDirName(Repository{FullName: "../../etc"}))
+	assert.Equal(t, "api.v2", cloneDirName(Repository{Name: "api.v2"}))
+	assert.Equal(t, "_", cloneDirName(Repository{Name: ".."}))
+}
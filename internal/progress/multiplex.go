@@ -0,0 +1,64 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Multiplexer interleaves the progress output of concurrent scans on one
+// writer. Each scan writes through its own labeled writer; output is emitted
+// a whole line at a time, prefixed with the label, so lines of different
+// scans never mix.
+type Multiplexer struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewMultiplexer creates a multiplexer writing to writer.
+func NewMultiplexer(writer io.Writer) *Multiplexer {
+	return &Multiplexer{writer: writer}
+}
+
+// Writer returns the writer for one scan. Its output is buffered until a
+// newline; carriage returns start the line over, so live-updating output
+// keeps only its final state.
+func (m *Multiplexer) Writer(label string) io.Writer {
+	return &labeledWriter{mux: m, prefix: []byte("[" + label + "] ")}
+}
+
+type labeledWriter struct {
+	mux    *Multiplexer
+	prefix []byte
+	line   []byte
+}
+
+func (w *labeledWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		switch b {
+		case '\r':
+			w.line = w.line[:0]
+		case '\n':
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		default:
+			w.line = append(w.line, b)
+		}
+	}
+	return len(p), nil
+}
+
+// flush writes the buffered line with its prefix. Blank lines are dropped.
+func (w *labeledWriter) flush() error {
+	defer func() { w.line = w.line[:0] }()
+	if len(bytes.TrimSpace(w.line)) == 0 {
+		return nil
+	}
+	w.mux.mu.Lock()
+	defer w.mux.mu.Unlock()
+	out := make([]byte, 0, len(w.prefix)+len(w.line)+1)
+	out = append(append(append(out, w.prefix...), w.line...), '\n')
+	_, err := w.mux.writer.Write(out)
+	return err
+}
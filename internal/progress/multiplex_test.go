@@ -0,0 +1,53 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMultiplexerPrefixesWholeLines(t *testing.T) {
+	var buf bytes.Buffer
+	mux := NewMultiplexer(&buf)
+	api := mux.Writer("api")
+	web := mux.Writer("web")
+
+	fmt.Fprint(api, "scanning ")
+	fmt.Fprint(web, "done\n")
+	fmt.Fprint(api, "files\n\n")
+	fmt.Fprint(web, "\r\033[2Kspinner\r  finished\n")
+
+	expected := "[web] done\n[api] scanning files\n[web]   finished\n"
+	if buf.String() != expected {
+		t.Errorf("got %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestMultiplexerConcurrentScans(t *testing.T) {
+	var buf bytes.Buffer
+	mux := NewMultiplexer(&buf)
+	var wg sync.WaitGroup
+	for _, label := range []string{"api", "web", "worker"} {
+		wg.Add(1)
+		go func(label string) {
+			defer wg.Done()
+			handler := NewSummaryHandler(mux.Writer(label), false)
+			handler.Handle(Event{Type: EventScanStart})
+			handler.Handle(Event{Type: EventScanComplete, FileCount: 3, DirCount: 1, Duration: time.Second})
+		}(label)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected one completion line per scan, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "[") || !strings.Contains(line, "files") {
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+}
@@ -179,6 +179,26 @@ func (p *Progress) Info(message string) {
 	})
 }
 
+// DirectoryTotal reports how many directories the walk will enter, so a
+// handler can show the share done and estimate the time left.
+func (p *Progress) DirectoryTotal(dirs int) {
+	p.Report(Event{Type: EventDirectoryTotal, DirCount: dirs})
+}
+
+// WantsDirectoryTotal reports whether the handler shows an estimate based on
+// DirectoryTotal. Counting the directories costs a walk of its own, so the
+// scanner only does it when the estimate is displayed.
+func (p *Progress) WantsDirectoryTotal() bool {
+	h, ok := p.handler.(interface{ WantsDirectoryTotal() bool })
+	return p.enabled && ok && h.WantsDirectoryTotal()
+}
+
+// SlowDirectories reports, after the scan, the directories whose own
+// processing took at least threshold, slowest first.
+func (p *Progress) SlowDirectories(threshold time.Duration, timings []TimingEntry) {
+	p.Report(Event{Type: EventSlowDirectories, Duration: threshold, Timings: timings})
+}
+
 // ResolveStart reports the start of the dependency-resolution phase.
 func (p *Progress) ResolveStart() {
 	p.Report(Event{Type: EventResolveStart})
@@ -254,3 +274,11 @@ func (p *Progress) RuleResultWithPath(tech string, matched bool, reason string,
 		Path:    path,
 	})
 }
+
+// SetHandler replaces the handler and enables reporting. Used to route the
+// output of a scan through a different handler after the scanner is built,
+// e.g. a labeled writer of a Multiplexer for concurrent scans.
+func (p *Progress) SetHandler(handler Handler) {
+	p.handler = handler
+	p.enabled = true
+}
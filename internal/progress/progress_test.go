@@ -2,6 +2,7 @@ package progress
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -256,6 +257,88 @@ func TestConvenienceMethods(t *testing.T) {
 	}
 }
 
+func TestEstimateRemaining(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		done    int
+		total   int
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"quarter done", 10 * time.Second, 25, 100, 30 * time.Second, true},
+		{"warming up", time.Second, 50, 100, 0, false},
+		{"no total", 10 * time.Second, 25, 0, 0, false},
+		{"count passed", 10 * time.Second, 120, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := estimateRemaining(tt.elapsed, tt.done, tt.total)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("estimateRemaining(%s, %d, %d) = %s, %v; want %s, %v",
+					tt.elapsed, tt.done, tt.total, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSummaryHandlerDirectoryTotal(t *testing.T) {
+	buf := &bytes.Buffer{}
+	p := New(true, NewSummaryHandler(buf, true))
+	if !p.WantsDirectoryTotal() {
+		t.Fatal("expected a TTY summary handler to want the directory total")
+	}
+	if New(true, NewSummaryHandler(buf, false)).WantsDirectoryTotal() {
+		t.Error("expected a non-TTY summary handler not to want the directory total")
+	}
+	if New(true, NewSimpleHandler(buf)).WantsDirectoryTotal() {
+		t.Error("expected the simple handler not to want the directory total")
+	}
+
+	handler := NewSummaryHandler(buf, true)
+	handler.Handle(Event{Type: EventScanStart})
+	handler.scanStart = time.Now().Add(-10 * time.Second)
+	handler.Handle(Event{Type: EventDirectoryTotal, DirCount: 40})
+	for i := 0; i < 10; i++ {
+		handler.Handle(Event{Type: EventEnterDirectory})
+	}
+	buf.Reset()
+	handler.render()
+	line := buf.String()
+	if !strings.Contains(line, "10/40") {
+		t.Errorf("expected dirs against the total, got %q", line)
+	}
+	if !strings.Contains(line, "~30s left") {
+		t.Errorf("expected an estimate of the time left, got %q", line)
+	}
+}
+
+func TestWriteSlowDirectories(t *testing.T) {
+	timings := make([]TimingEntry, 12)
+	for i := range timings {
+		timings[i] = TimingEntry{Path: fmt.Sprintf("dir%d", i), Duration: time.Duration(12-i) * time.Second}
+	}
+	buf := &bytes.Buffer{}
+	writeSlowDirectories(buf, Event{Type: EventSlowDirectories, Duration: time.Second, Timings: timings})
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "  Slow directories (own processing >= 1s): 12\n      12.00s  dir0\n") {
+		t.Errorf("unexpected header or first entry:\n%s", out)
+	}
+	if !strings.Contains(out, "dir9\n") || strings.Contains(out, "dir10") {
+		t.Errorf("expected the first 10 directories only:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "    ... and 2 more\n") {
+		t.Errorf("expected the rest to be counted:\n%s", out)
+	}
+
+	buf.Reset()
+	writeSlowDirectories(buf, Event{Type: EventSlowDirectories, Duration: time.Second})
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without slow directories, got %q", buf.String())
+	}
+}
+
 func BenchmarkSimpleHandler(b *testing.B) {
 	buf := &bytes.Buffer{}
 	handler := NewSimpleHandler(buf)
@@ -63,6 +63,15 @@ func (h *SimpleHandler) Handle(event Event) {
 		h.handleRuleResult(event)
 	case EventFileProcessingEnd:
 		// File processing completed - no output needed for timing.
+	default:
+		h.handleSlowDir(event)
+	}
+}
+
+// handleSlowDir prints the slow directories reported after the scan.
+func (h *SimpleHandler) handleSlowDir(event Event) {
+	if event.Type == EventSlowDirectories {
+		writeSlowDirectories(h.writer, event)
 	}
 }
 
@@ -18,6 +18,7 @@ type SummaryHandler struct {
 	mu          sync.Mutex
 	scanStart   time.Time
 	dirCount    int
+	dirTotal    int // directories the walk will enter (EventDirectoryTotal); 0 = unknown
 	fileCount   int
 	compCount   int
 	spinIdx     int
@@ -78,6 +79,27 @@ func renderBar(frac float64, width int) string {
 		frac*100)
 }
 
+// etaWarmup is how long the walk runs before the time left is estimated; the
+// first directories say little about the rate of the rest.
+const etaWarmup = 2 * time.Second
+
+// WantsDirectoryTotal reports that the progress line estimates the time left
+// from the directory count, when it is displayed at all (on a TTY).
+func (h *SummaryHandler) WantsDirectoryTotal() bool {
+	return h.isTTY
+}
+
+// estimateRemaining extrapolates the time left from the share of the
+// directories walked so far. Directories differ widely in cost, so it is a
+// rough figure; there is none before etaWarmup or once the count is passed
+// (the count ignores nested .gitignore files).
+func estimateRemaining(elapsed time.Duration, done, total int) (time.Duration, bool) {
+	if done <= 0 || done >= total || elapsed < etaWarmup {
+		return 0, false
+	}
+	return time.Duration(float64(elapsed) * float64(total-done) / float64(done)), true
+}
+
 // NewSummaryHandler creates a handler that shows a single updating progress line.
 func NewSummaryHandler(writer io.Writer, isTTY bool) *SummaryHandler {
 	return &SummaryHandler{
@@ -112,16 +134,8 @@ func (h *SummaryHandler) Handle(event Event) {
 		h.scanStart = time.Now()
 		h.lastRender = time.Now()
 
-	case EventEnterDirectory:
-		h.dirCount++
-		h.throttledRender()
-
-	case EventFileProcessingStart:
-		h.fileCount++
-		h.throttledRender()
-
-	case EventComponentDetected:
-		h.compCount++
+	case EventEnterDirectory, EventFileProcessingStart, EventComponentDetected, EventDirectoryTotal:
+		h.count(event)
 		h.throttledRender()
 
 	case EventInfo:
@@ -146,6 +160,23 @@ func (h *SummaryHandler) Handle(event Event) {
 
 	case EventScanComplete:
 		h.renderComplete(event)
+
+	case EventSlowDirectories:
+		writeSlowDirectories(h.writer, event)
+	}
+}
+
+// count updates the scan counts from a walk event.
+func (h *SummaryHandler) count(event Event) {
+	switch event.Type {
+	case EventEnterDirectory:
+		h.dirCount++
+	case EventFileProcessingStart:
+		h.fileCount++
+	case EventComponentDetected:
+		h.compCount++
+	case EventDirectoryTotal:
+		h.dirTotal = event.DirCount
 	}
 }
 
@@ -171,19 +202,7 @@ func (h *SummaryHandler) render() {
 	}
 	elapsed := time.Since(h.scanStart).Truncate(time.Second)
 
-	// Show the scan counts (dirs/files/components) when a scan ran, then append
-	// the resolution status. For a resolution-only run (no scan walked), omit the
-	// zero scan counts entirely so the line reads "spinner  resolving ...".
-	var parts []string
-	if h.dirCount > 0 || h.fileCount > 0 || h.compCount > 0 {
-		parts = append(parts, h.countStyle.Render(fmt.Sprintf("%d", h.dirCount))+" "+h.labelStyle.Render("dirs"))
-		if h.fileCount > 0 {
-			parts = append(parts, h.countStyle.Render(fmt.Sprintf("%d", h.fileCount))+" "+h.labelStyle.Render("files"))
-		}
-		if h.compCount > 0 {
-			parts = append(parts, h.compStyle.Render(fmt.Sprintf("%d", h.compCount))+" "+h.labelStyle.Render("components"))
-		}
-	}
+	parts := h.scanCounts()
 
 	// Optional progress bar (only when a fraction was provided, e.g. currency).
 	bar := ""
@@ -200,6 +219,9 @@ func (h *SummaryHandler) render() {
 		line = fmt.Sprintf("  %s  %s%s", spinner, bar,
 			h.dimStyle.Render(fmt.Sprintf("(%s)", elapsed)))
 	}
+	if remaining, ok := estimateRemaining(elapsed, h.dirCount, h.dirTotal); ok && !h.resolving {
+		line += h.dimStyle.Render(fmt.Sprintf("  ~%s left", remaining.Round(time.Second)))
+	}
 	if h.resolving && h.resolveInfo != "" {
 		line += h.dimStyle.Render("  ·  ") + h.labelStyle.Render(h.resolveInfo)
 	}
@@ -208,6 +230,27 @@ func (h *SummaryHandler) render() {
 	fmt.Fprintf(h.writer, "\r\033[2K%s", line)
 }
 
+// scanCounts renders the scan counts (dirs/files/components) when a scan ran,
+// the dirs against the counted total when known. For a resolution-only run
+// (no scan walked) it is empty, so the line reads "spinner  resolving ...".
+func (h *SummaryHandler) scanCounts() []string {
+	if h.dirCount == 0 && h.fileCount == 0 && h.compCount == 0 {
+		return nil
+	}
+	dirs := fmt.Sprintf("%d", h.dirCount)
+	if h.dirTotal > 0 && h.dirCount <= h.dirTotal && !h.resolving {
+		dirs = fmt.Sprintf("%d/%d", h.dirCount, h.dirTotal)
+	}
+	parts := []string{h.countStyle.Render(dirs) + " " + h.labelStyle.Render("dirs")}
+	if h.fileCount > 0 {
+		parts = append(parts, h.countStyle.Render(fmt.Sprintf("%d", h.fileCount))+" "+h.labelStyle.Render("files"))
+	}
+	if h.compCount > 0 {
+		parts = append(parts, h.compStyle.Render(fmt.Sprintf("%d", h.compCount))+" "+h.labelStyle.Render("components"))
+	}
+	return parts
+}
+
 // renderResolveComplete prints the final line for the dependency-resolution
 // phase (a checkmark + the resolution metrics + elapsed time).
 func (h *SummaryHandler) renderResolveComplete(event Event) {
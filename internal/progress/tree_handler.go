@@ -59,6 +59,15 @@ func (h *TreeHandler) Handle(event Event) {
 		h.handleRuleCheck(event, indent, prefix)
 	case EventRuleResult:
 		h.handleRuleResult(event, indent)
+	default:
+		h.handleSlowDir(event)
+	}
+}
+
+// handleSlowDir prints the slow directories reported after the scan.
+func (h *TreeHandler) handleSlowDir(event Event) {
+	if event.Type == EventSlowDirectories {
+		writeSlowDirectories(h.writer, event)
 	}
 }
 
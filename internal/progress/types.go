@@ -1,6 +1,8 @@
 package progress
 
 import (
+	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
@@ -32,6 +34,8 @@ const (
 	EventResolveStart    // dependency-resolution phase begins
 	EventResolveProgress // periodic resolution status (Info carries the metrics)
 	EventResolveComplete // resolution phase done (Info = metrics, Duration = elapsed)
+	EventDirectoryTotal  // directories the walk will enter, counted ahead of it (DirCount)
+	EventSlowDirectories // after the scan: directories over the threshold (Duration), slowest first (Timings)
 )
 
 // Event represents something that happened during scanning
@@ -48,6 +52,7 @@ type Event struct {
 	Timestamp time.Time // For timing calculations
 	Matched   bool      // For rule matching results
 	Details   []string  // For detailed rule check information
+	Timings   []TimingEntry
 }
 
 // Reporter is the interface the scanner uses to report events
@@ -75,6 +80,25 @@ type RuleEntry struct {
 	Matched bool
 }
 
+// maxSlowDirectories is how many slow directories the post-scan report lists.
+const maxSlowDirectories = 10
+
+// writeSlowDirectories renders the post-scan report of the directories whose
+// own processing took at least the threshold.
+func writeSlowDirectories(w io.Writer, event Event) {
+	if len(event.Timings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  Slow directories (own processing >= %s): %d\n", event.Duration, len(event.Timings))
+	for i, timing := range event.Timings {
+		if i == maxSlowDirectories {
+			fmt.Fprintf(w, "    ... and %d more\n", len(event.Timings)-maxSlowDirectories)
+			break
+		}
+		fmt.Fprintf(w, "    %7.2fs  %s\n", timing.Duration.Seconds(), shortenPath(timing.Path, 60))
+	}
+}
+
 // getTimingIcon returns the appropriate icon for a duration
 func getTimingIcon(seconds float64) string {
 	if seconds >= 10.0 {
@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"errors"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,7 +29,7 @@ func (p *FSProvider) ListDir(path string) ([]types.File, error) {
 
 	entries, err := os.ReadDir(fullPath)
 	if err != nil {
-		return nil, err
+		return nil, providerError("list", fullPath, err)
 	}
 
 	files := make([]types.File, 0, len(entries))
@@ -66,7 +69,7 @@ func (p *FSProvider) Open(path string) (string, error) {
 
 	content, err := os.ReadFile(fullPath)
 	if err != nil {
-		return "", err
+		return "", providerError("read", fullPath, err)
 	}
 
 	return string(NormalizeToUTF8(content)), nil
@@ -77,11 +80,28 @@ func (p *FSProvider) ReadFile(path string) ([]byte, error) {
 	fullPath := p.getFullPath(path)
 	content, err := os.ReadFile(fullPath)
 	if err != nil {
-		return nil, err
+		return nil, providerError("read", fullPath, err)
 	}
 	return NormalizeToUTF8(content), nil
 }
 
+// ReadFileRange reads up to length bytes of a file from offset, without
+// normalizing their encoding
+func (p *FSProvider) ReadFileRange(path string, offset, length int64) ([]byte, error) {
+	fullPath := p.getFullPath(path)
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, providerError("read", fullPath, err)
+	}
+	defer file.Close()
+	buf := make([]byte, length)
+	n, err := file.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, providerError("read", fullPath, err)
+	}
+	return buf[:n], nil
+}
+
 // Exists checks if a file or directory exists
 func (p *FSProvider) Exists(path string) (bool, error) {
 	fullPath := p.getFullPath(path)
@@ -92,7 +112,7 @@ func (p *FSProvider) Exists(path string) (bool, error) {
 	if os.IsNotExist(err) {
 		return false, nil
 	}
-	return false, err
+	return false, providerError("stat", fullPath, err)
 }
 
 // IsDir checks if a path is a directory
@@ -100,7 +120,7 @@ func (p *FSProvider) IsDir(path string) (bool, error) {
 	fullPath := p.getFullPath(path)
 	info, err := os.Stat(fullPath)
 	if err != nil {
-		return false, err
+		return false, providerError("stat", fullPath, err)
 	}
 	return info.IsDir(), nil
 }
@@ -122,3 +142,13 @@ func (p *FSProvider) getFullPath(path string) string {
 func (p *FSProvider) GetBasePath() string {
 	return p.rootPath
 }
+
+// providerError wraps the error of an operation on a path. The path error
+// of the os package is unwrapped, as the provider error names the path.
+func providerError(op, path string, err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		err = pathErr.Err
+	}
+	return &types.ProviderError{Op: op, Path: path, Err: err}
+}
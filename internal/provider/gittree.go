@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// GitTreeProvider implements the Provider interface over the tree of a git
+// commit, to read a repository as it was at a past revision. Paths are the
+// file system paths the files have in the repository's worktree.
+type GitTreeProvider struct {
+	tree     *object.Tree
+	repoRoot string
+	basePath string
+}
+
+// NewGitTreeProvider creates a provider reading tree, a commit tree of the
+// repository whose worktree is at repoRoot. basePath is the scanned path
+// within the worktree.
+func NewGitTreeProvider(tree *object.Tree, repoRoot, basePath string) *GitTreeProvider {
+	return &GitTreeProvider{tree: tree, repoRoot: repoRoot, basePath: basePath}
+}
+
+// ListDir returns the contents of a directory
+func (p *GitTreeProvider) ListDir(path string) ([]types.File, error) {
+	dir, err := p.dir(path)
+	if err != nil {
+		return nil, providerError("list", path, err)
+	}
+	files := make([]types.File, 0, len(dir.Entries))
+	for _, entry := range dir.Entries {
+		file := types.File{Name: entry.Name, Path: filepath.Join(path, entry.Name), Type: "file"}
+		switch {
+		case entry.Mode == filemode.Dir:
+			file.Type = "dir"
+		case entry.Mode == filemode.Submodule:
+			continue
+		default:
+			file.Size, _ = dir.Size(entry.Name)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Open returns the content of a file as UTF-8 string
+func (p *GitTreeProvider) Open(path string) (string, error) {
+	content, err := p.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// ReadFile reads file content as bytes, normalizing encoding to UTF-8
+func (p *GitTreeProvider) ReadFile(path string) ([]byte, error) {
+	rel, err := p.relPath(path)
+	if err != nil {
+		return nil, providerError("read", path, err)
+	}
+	file, err := p.tree.File(rel)
+	if err != nil {
+		return nil, providerError("read", path, os.ErrNotExist)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, providerError("read", path, err)
+	}
+	return NormalizeToUTF8([]byte(content)), nil
+}
+
+// Exists checks if a file or directory exists
+func (p *GitTreeProvider) Exists(path string) (bool, error) {
+	rel, err := p.relPath(path)
+	if err != nil {
+		return false, nil
+	}
+	if rel == "." {
+		return true, nil
+	}
+	_, err = p.tree.FindEntry(rel)
+	return err == nil, nil
+}
+
+// IsDir checks if a path is a directory
+func (p *GitTreeProvider) IsDir(path string) (bool, error) {
+	rel, err := p.relPath(path)
+	if err != nil {
+		return false, providerError("stat", path, err)
+	}
+	if rel == "." {
+		return true, nil
+	}
+	entry, err := p.tree.FindEntry(rel)
+	if err != nil {
+		return false, providerError("stat", path, os.ErrNotExist)
+	}
+	return entry.Mode == filemode.Dir, nil
+}
+
+// GetBasePath returns the base path for this provider
+func (p *GitTreeProvider) GetBasePath() string {
+	return p.basePath
+}
+
+// dir returns the tree of a directory.
+func (p *GitTreeProvider) dir(path string) (*object.Tree, error) {
+	rel, err := p.relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if rel == "." {
+		return p.tree, nil
+	}
+	dir, err := p.tree.Tree(rel)
+	if err != nil {
+		return nil, os.ErrNotExist
+	}
+	return dir, nil
+}
+
+// relPath converts a path to the slash-separated path within the commit
+// tree. Relative paths are relative to the scanned path; paths outside the
+// repository do not exist in it.
+func (p *GitTreeProvider) relPath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.basePath, path)
+	}
+	rel, err := filepath.Rel(p.repoRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", os.ErrNotExist
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -21,6 +21,11 @@ func Build(dep types.Dependency) string {
 	}
 
 	namespace, name := splitNamespace(ptype, dep.Name)
+	if osPackageTypes[ptype] {
+		// Distribution packages are namespaced by the distro (pkg:deb/debian/curl),
+		// which the image scanner records in metadata.
+		namespace, _ = dep.Metadata["distro"].(string)
+	}
 	if name == "" {
 		return ""
 	}
@@ -55,6 +60,7 @@ func purlType(depType string) string {
 		"npm": true, "maven": true, "pypi": true, "nuget": true,
 		"cargo": true, "golang": true, "gem": true, "composer": true,
 		"pub": true, "conan": true, "docker": true, "golang-direct": true,
+		"deb": true, "rpm": true, "apk": true, "alpm": true,
 	}
 	if known[depType] {
 		return depType
@@ -98,3 +104,58 @@ func encodeSegment(seg string) string {
 	}
 	return strings.Join(parts, "/")
 }
+
+// osPackageTypes are PURL types whose namespace is a distribution or vendor
+// rather than part of the package name (pkg:deb/debian/curl -> "curl").
+var osPackageTypes = map[string]bool{
+	"deb": true, "rpm": true, "apk": true, "alpm": true,
+}
+
+// Parse decodes a Package URL into a dependency (type, name, version). It is
+// the inverse of Build for the ecosystems Build supports; other PURL types are
+// kept verbatim as the dependency type. Qualifiers and subpath are dropped.
+// Returns false when the string is not a PURL.
+func Parse(s string) (types.Dependency, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(s), "pkg:")
+	if !ok {
+		return types.Dependency{}, false
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	rest = strings.TrimLeft(rest, "/")
+
+	ptype, path, ok := strings.Cut(rest, "/")
+	if !ok || ptype == "" || path == "" {
+		return types.Dependency{}, false
+	}
+
+	var version string
+	if i := strings.LastIndex(path, "@"); i > 0 {
+		version, _ = url.PathUnescape(path[i+1:])
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if dec, err := url.PathUnescape(seg); err == nil {
+			segments[i] = dec
+		}
+	}
+	ptype = strings.ToLower(ptype)
+	return types.Dependency{Type: ptype, Name: joinName(ptype, segments), Version: version}, true
+}
+
+// joinName reassembles a dependency name from PURL namespace/name segments
+// using the ecosystem's native notation (the inverse of splitNamespace).
+func joinName(ptype string, segments []string) string {
+	name := segments[len(segments)-1]
+	namespace := strings.Join(segments[:len(segments)-1], "/")
+	switch {
+	case namespace == "" || osPackageTypes[ptype]:
+		return name
+	case ptype == "maven":
+		return namespace + ":" + name
+	default:
+		return namespace + "/" + name
+	}
+}
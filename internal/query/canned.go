@@ -0,0 +1,95 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Usage is a dependency of a component found by WhoUses.
+type Usage struct {
+	Component   string `json:"component"`
+	ComponentID string `json:"component_id"`
+	SourceDir   string `json:"source_dir,omitempty"`
+	Type        string `json:"type"` // dependency ecosystem, e.g. "npm", "maven"
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Direct      bool   `json:"direct"`
+}
+
+// TechUsage is a component found by WhereTech.
+type TechUsage struct {
+	Component   string   `json:"component"`
+	ComponentID string   `json:"component_id"`
+	SourceDir   string   `json:"source_dir,omitempty"`
+	Primary     bool     `json:"primary"` // among the component's primary techs
+	Reasons     []string `json:"reasons,omitempty"`
+}
+
+// WhoUses returns the dependencies named name of every component, in tree
+// order (see MatchesPackage).
+func WhoUses(root *types.Payload, name string) []Usage {
+	usages := []Usage{}
+	walk(root, func(p *types.Payload) {
+		for _, dep := range p.Dependencies {
+			if MatchesPackage(dep.Name, name) {
+				usages = append(usages, Usage{
+					Component: p.Name, ComponentID: p.ID, SourceDir: p.SourceDir,
+					Type: dep.Type, Name: dep.Name, Version: dep.Version, Scope: dep.Scope, Direct: dep.Direct,
+				})
+			}
+		}
+	})
+	return usages
+}
+
+// MatchesPackage reports whether a dependency name is name, ignoring case, or
+// ends with it after a ':' or '/', so "log4j-core" matches the Maven
+// "org.apache.logging.log4j:log4j-core" and "yaml.v3" the Go module
+// "gopkg.in/yaml.v3".
+func MatchesPackage(depName, name string) bool {
+	depName, name = strings.ToLower(depName), strings.ToLower(name)
+	if depName == name {
+		return true
+	}
+	if len(depName) <= len(name) || !strings.HasSuffix(depName, name) {
+		return false
+	}
+	sep := depName[len(depName)-len(name)-1]
+	return sep == ':' || sep == '/'
+}
+
+// WhereTech returns the components whose techs include tech, ignoring case,
+// in tree order.
+func WhereTech(root *types.Payload, tech string) []TechUsage {
+	usages := []TechUsage{}
+	walk(root, func(p *types.Payload) {
+		for _, t := range p.Techs {
+			if strings.EqualFold(t, tech) {
+				usages = append(usages, TechUsage{
+					Component: p.Name, ComponentID: p.ID, SourceDir: p.SourceDir,
+					Primary: containsFold(p.Tech, t), Reasons: p.Reason[t],
+				})
+				break
+			}
+		}
+	})
+	return usages
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func walk(p *types.Payload, visit func(*types.Payload)) {
+	visit(p)
+	for _, child := range p.Children {
+		walk(child, visit)
+	}
+}
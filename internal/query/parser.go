@@ -0,0 +1,282 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// identDelimiters end field names; literalDelimiters end bare-word literals,
+// which may hold dots ("2.17.1", "org.apache.logging.log4j:log4j-core").
+const (
+	identDelimiters   = " \t\n[]().,&|=!<>'\""
+	literalDelimiters = " \t\n[](),&|=!<>'\""
+)
+
+// comparisonOps are tried in order, so two-character operators win.
+var comparisonOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) done() bool {
+	p.skipSpace()
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and the token when the input continues with it.
+func (p *parser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(token string) error {
+	if !p.consume(token) {
+		return p.errorf("expected %q", token)
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("query: %s at offset %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *parser) parseStep(first bool) (step, error) {
+	switch {
+	case p.consume(".."):
+		name, err := p.parseIdent()
+		return descendStep{name}, err
+	case p.consume("."):
+		name, err := p.parseIdent()
+		return fieldStep{name}, err
+	case p.consume("["):
+		return p.parseBracket()
+	case first:
+		name, err := p.parseIdent()
+		return fieldStep{name}, err
+	}
+	return nil, p.errorf("expected '.', '..' or '['")
+}
+
+// parseIdent reads a field name, bare or double-quoted.
+func (p *parser) parseIdent() (string, error) {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		return p.parseQuoted()
+	}
+	if word := p.readWord(identDelimiters); word != "" {
+		return word, nil
+	}
+	return "", p.errorf("expected a field name")
+}
+
+// readWord reads up to the next of delimiters.
+func (p *parser) readWord(delimiters string) string {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(delimiters, rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// parseQuoted reads a single- or double-quoted string; a backslash escapes
+// the next character.
+func (p *parser) parseQuoted() (string, error) {
+	quote := p.s[p.pos]
+	var b strings.Builder
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch c := p.s[p.pos]; {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.s):
+			p.pos++
+			b.WriteByte(p.s[p.pos])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseBracket() (step, error) {
+	switch {
+	case p.consume("]"):
+		return flattenStep{}, nil
+	case p.consume("*"):
+		return flattenStep{}, p.expect("]")
+	case p.consume("?"):
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterStep{cond}, p.expect("]")
+	}
+	p.skipSpace()
+	word := p.readWord(identDelimiters)
+	index, err := strconv.Atoi(word)
+	if err != nil {
+		return nil, p.errorf("expected an index, '*' or '?', got %q", word)
+	}
+	return indexStep{index}, p.expect("]")
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.consume("||") {
+		var right expr
+		right, err = p.parseAnd()
+		left = logicalExpr{left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.consume("&&") {
+		var right expr
+		right, err = p.parseUnary()
+		left = logicalExpr{and: true, left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	switch {
+	case p.consume("!"):
+		operand, err := p.parseUnary()
+		return notExpr{operand}, err
+	case p.consume("("):
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return cond, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range comparisonOps {
+		if p.consume(op) {
+			right, err := p.parseOperand(true)
+			return compareExpr{op: op, left: left, right: right}, err
+		}
+	}
+	return left, nil
+}
+
+// parseOperand reads a quoted string, contains(...), a path relative to the
+// element (@, @.name, or on the left-hand side name.name), or a literal. A
+// bare word on the right-hand side is a literal.
+func (p *parser) parseOperand(rhs bool) (expr, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("expected an operand")
+	}
+	if c := p.s[p.pos]; c == '\'' || c == '"' {
+		s, err := p.parseQuoted()
+		return literalExpr{s}, err
+	}
+	if p.consume("contains(") {
+		return p.parseContains()
+	}
+	if p.atElementRef() {
+		p.pos++
+		return p.parsePath(nil)
+	}
+	if rhs {
+		return p.parseLiteral()
+	}
+	word := p.readWord(identDelimiters)
+	switch {
+	case word == "":
+		return nil, p.errorf("expected an operand")
+	case isKeywordOrNumber(word):
+		return literalExpr{literal(word)}, nil
+	}
+	return p.parsePath([]string{word})
+}
+
+// atElementRef reports whether the input continues with @ as a reference to
+// the element, rather than a word such as an npm scope ("@types/node").
+func (p *parser) atElementRef() bool {
+	if p.s[p.pos] != '@' {
+		return false
+	}
+	next := p.pos + 1
+	return next == len(p.s) || strings.ContainsRune(identDelimiters, rune(p.s[next]))
+}
+
+// parsePath reads the .name suffixes of a path.
+func (p *parser) parsePath(fields []string) (expr, error) {
+	for p.pos < len(p.s) && p.s[p.pos] == '.' {
+		p.pos++
+		name, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, name)
+	}
+	return pathExpr{fields}, nil
+}
+
+func (p *parser) parseContains() (expr, error) {
+	haystack, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	needle, err := p.parseOperand(true)
+	if err != nil {
+		return nil, err
+	}
+	return containsExpr{haystack: haystack, needle: needle}, p.expect(")")
+}
+
+func (p *parser) parseLiteral() (expr, error) {
+	word := p.readWord(literalDelimiters)
+	if word == "" {
+		return nil, p.errorf("expected a value")
+	}
+	return wordExpr{word}, nil
+}
+
+func isKeywordOrNumber(word string) bool {
+	_, isString := literal(word).(string)
+	return !isString
+}
+
+// literal converts a bare word to a number, true, false, null or a string.
+func literal(word string) interface{} {
+	switch word {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if f, err := strconv.ParseFloat(word, 64); err == nil {
+		return f
+	}
+	return word
+}
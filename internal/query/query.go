@@ -0,0 +1,357 @@
+// Package query answers questions about scan outputs without external tools:
+// a small JMESPath-like path language evaluated over the output JSON, and
+// canned queries for the common ones (which components use a dependency or a
+// technology).
+package query
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Query is a parsed path expression. An expression is a chain of steps, each
+// applied to every value the previous one produced:
+//
+//	name, .name      the field of an object
+//	..name           the field of the value and of every object below it
+//	[N]              the element N of an array, from the end when negative
+//	[] or [*]        every element of an array (or value of an object)
+//	[?condition]     the elements of an array matching the condition
+//
+// Conditions compare paths relative to the element (name, metadata.source,
+// @ for the element itself) with ==, !=, <, <=, > and >=, combine with &&, ||,
+// ! and parentheses, and test membership with contains(path, value). The
+// right-hand side of a comparison is a literal: a quoted string, a number,
+// true, false, null, or a bare word taken as a string, so
+// dependencies[?name==log4j-core] needs no quotes.
+type Query struct {
+	steps []step
+}
+
+// Parse parses a path expression.
+func Parse(expr string) (*Query, error) {
+	p := &parser{s: strings.TrimSpace(expr)}
+	if p.s == "" {
+		return nil, errors.New("query: empty expression")
+	}
+	q := &Query{}
+	for !p.done() {
+		st, err := p.parseStep(len(q.steps) == 0)
+		if err != nil {
+			return nil, err
+		}
+		q.steps = append(q.steps, st)
+	}
+	return q, nil
+}
+
+// Eval evaluates the query over a decoded JSON document and returns the
+// values it selects, in document order.
+func (q *Query) Eval(doc interface{}) []interface{} {
+	values := []interface{}{doc}
+	for _, st := range q.steps {
+		values = st.apply(values)
+	}
+	if values == nil {
+		values = []interface{}{}
+	}
+	return values
+}
+
+// Document decodes a scan output for querying. Dependencies, written as
+// [type, name, version, scope, direct, metadata] arrays, become objects with
+// those fields, so conditions can name them.
+func Document(data []byte) (interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse scan output: %w", err)
+	}
+	normalizeDependencies(doc)
+	return doc, nil
+}
+
+var dependencyFields = []string{"type", "name", "version", "scope", "direct", "metadata"}
+
+func normalizeDependencies(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if deps, ok := child.([]interface{}); ok && key == "dependencies" {
+				for i, dep := range deps {
+					deps[i] = dependencyObject(dep)
+				}
+			}
+			normalizeDependencies(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			normalizeDependencies(child)
+		}
+	}
+}
+
+func dependencyObject(dep interface{}) interface{} {
+	fields, ok := dep.([]interface{})
+	if !ok {
+		return dep
+	}
+	obj := make(map[string]interface{}, len(fields))
+	for i, value := range fields {
+		if i < len(dependencyFields) {
+			obj[dependencyFields[i]] = value
+		}
+	}
+	return obj
+}
+
+type step interface {
+	apply(in []interface{}) []interface{}
+}
+
+type fieldStep struct{ name string }
+
+func (s fieldStep) apply(in []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range in {
+		if m, ok := v.(map[string]interface{}); ok {
+			if field, ok := m[s.name]; ok {
+				out = append(out, field)
+			}
+		}
+	}
+	return out
+}
+
+type descendStep struct{ name string }
+
+func (s descendStep) apply(in []interface{}) []interface{} {
+	var out []interface{}
+	var visit func(v interface{})
+	visit = func(v interface{}) {
+		if m, ok := v.(map[string]interface{}); ok {
+			if field, ok := m[s.name]; ok {
+				out = append(out, field)
+			}
+		}
+		for _, child := range elements(v) {
+			visit(child)
+		}
+	}
+	for _, v := range in {
+		visit(v)
+	}
+	return out
+}
+
+type indexStep struct{ index int }
+
+func (s indexStep) apply(in []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range in {
+		arr, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+		i := s.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i >= 0 && i < len(arr) {
+			out = append(out, arr[i])
+		}
+	}
+	return out
+}
+
+type flattenStep struct{}
+
+func (flattenStep) apply(in []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range in {
+		out = append(out, elements(v)...)
+	}
+	return out
+}
+
+type filterStep struct{ cond expr }
+
+func (s filterStep) apply(in []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range in {
+		arr, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, elem := range arr {
+			if truthy(s.cond.eval(elem)) {
+				out = append(out, elem)
+			}
+		}
+	}
+	return out
+}
+
+// elements returns the elements of an array, or the values of an object in
+// key order.
+func elements(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = v[key]
+		}
+		return values
+	}
+	return nil
+}
+
+// expr is a condition term evaluated against an array element.
+type expr interface {
+	eval(elem interface{}) interface{}
+}
+
+type pathExpr struct{ fields []string }
+
+func (e pathExpr) eval(elem interface{}) interface{} {
+	v := elem
+	for _, field := range e.fields {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[field]
+	}
+	return v
+}
+
+type literalExpr struct{ value interface{} }
+
+func (e literalExpr) eval(interface{}) interface{} { return e.value }
+
+// wordExpr is a bare word on the right-hand side of a comparison. Compared
+// with a string it is that string, so name==2.0 matches the version "2.0";
+// otherwise it is read as a number, true, false or null.
+type wordExpr struct{ word string }
+
+func (e wordExpr) eval(interface{}) interface{} { return literal(e.word) }
+
+// operand evaluates e against an element, reading a bare word as a string
+// when the other operand is one.
+func operand(e expr, elem, other interface{}) interface{} {
+	if w, ok := e.(wordExpr); ok {
+		if _, isString := other.(string); isString {
+			return w.word
+		}
+	}
+	return e.eval(elem)
+}
+
+type compareExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e compareExpr) eval(elem interface{}) interface{} {
+	left := e.left.eval(elem)
+	right := operand(e.right, elem, left)
+	switch e.op {
+	case "==":
+		return reflect.DeepEqual(left, right)
+	case "!=":
+		return !reflect.DeepEqual(left, right)
+	}
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// compare orders two numbers or two strings.
+func compare(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+	return 0, false
+}
+
+type logicalExpr struct {
+	and         bool
+	left, right expr
+}
+
+func (e logicalExpr) eval(elem interface{}) interface{} {
+	if e.and {
+		return truthy(e.left.eval(elem)) && truthy(e.right.eval(elem))
+	}
+	return truthy(e.left.eval(elem)) || truthy(e.right.eval(elem))
+}
+
+type notExpr struct{ operand expr }
+
+func (e notExpr) eval(elem interface{}) interface{} { return !truthy(e.operand.eval(elem)) }
+
+// containsExpr tests whether an array holds a value or a string holds a
+// substring.
+type containsExpr struct{ haystack, needle expr }
+
+func (e containsExpr) eval(elem interface{}) interface{} {
+	switch haystack := e.haystack.eval(elem).(type) {
+	case []interface{}:
+		for _, v := range haystack {
+			if reflect.DeepEqual(v, operand(e.needle, elem, v)) {
+				return true
+			}
+		}
+	case string:
+		if s, ok := operand(e.needle, elem, haystack).(string); ok {
+			return strings.Contains(haystack, s)
+		}
+	}
+	return false
+}
+
+// truthy follows JMESPath: false, null, and empty strings, arrays and
+// objects are false.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOutput = `{
+  "id": "root",
+  "name": "myapp",
+  "techs": ["docker"],
+  "dependencies": [["docker", "nginx", "1.25", "", true, {}]],
+  "code_stats": {"total": {"code": 1200}},
+  "children": [
+    {
+      "id": "api",
+      "name": "api",
+      "tech": ["java"],
+      "techs": ["java", "spring"],
+      "dependencies": [
+        ["maven", "org.apache.logging.log4j:log4j-core", "2.14.1", "prod", true, {"source": "pom.xml"}],
+        ["maven", "junit:junit", "4.13", "test", true, {}]
+      ],
+      "children": [
+        {"id": "worker", "name": "worker", "techs": ["java"], "dependencies": [["maven", "org.apache.logging.log4j:log4j-core", "2.17.1", "prod", false, {}]], "children": []}
+      ]
+    }
+  ]
+}`
+
+func eval(t *testing.T, expr string) []interface{} {
+	t.Helper()
+	doc, err := Document([]byte(testOutput))
+	require.NoError(t, err)
+	q, err := Parse(expr)
+	require.NoError(t, err, expr)
+	return q.Eval(doc)
+}
+
+func TestEval(t *testing.T) {
+	assert.Equal(t, []interface{}{"myapp"}, eval(t, "name"))
+	assert.Equal(t, []interface{}{1200.0}, eval(t, "code_stats.total.code"))
+	assert.Equal(t, []interface{}{"api"}, eval(t, "children[0].name"))
+	assert.Equal(t, []interface{}{"worker"}, eval(t, "children[-1].children[*].name"))
+	assert.Equal(t, []interface{}{"api", "worker"}, eval(t, "..children[].name"))
+	assert.Equal(t, []interface{}{"docker", "java", "spring", "java"}, eval(t, "..techs[]"))
+	assert.Empty(t, eval(t, "missing.field"))
+}
+
+func TestEvalFilters(t *testing.T) {
+	assert.Equal(t, []interface{}{"2.14.1", "2.17.1"}, eval(t, "..dependencies[?name==org.apache.logging.log4j:log4j-core].version"),
+		"dependency arrays become objects and bare words are strings")
+	assert.Equal(t, []interface{}{"junit:junit"}, eval(t, "..dependencies[?scope=='test'].name"))
+	assert.Equal(t, []interface{}{"2.14.1"}, eval(t, `..dependencies[?contains(name, log4j) && direct==true].version`))
+	assert.Equal(t, []interface{}{"2.17.1"}, eval(t, `..dependencies[?version>=2.15 && !(scope==test)].version`))
+	assert.Equal(t, []interface{}{"1.25"}, eval(t, "dependencies[?version==1.25].version"), "a number-like word compares with a string as a string")
+	assert.Equal(t, []interface{}{"api"}, eval(t, "..children[?contains(techs, spring)].name"))
+	assert.Equal(t, []interface{}{"spring"}, eval(t, "..techs[?@==spring]"))
+	assert.Equal(t, []interface{}{"pom.xml"}, eval(t, "..dependencies[?metadata.source].metadata.source"))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "name.", "children[", "children[x]", "dependencies[?name==]", "a[?'unterminated]", "a b"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestMatchesPackage(t *testing.T) {
+	assert.True(t, MatchesPackage("org.apache.logging.log4j:log4j-core", "log4j-core"))
+	assert.True(t, MatchesPackage("gopkg.in/yaml.v3", "yaml.v3"))
+	assert.True(t, MatchesPackage("OpenSSL", "openssl"))
+	assert.False(t, MatchesPackage("pyopenssl", "openssl"))
+	assert.False(t, MatchesPackage("log4j", "log4j-core"))
+}
+
+func TestWhoUsesAndWhereTech(t *testing.T) {
+	root := types.NewPayloadWithPath("myapp", "/")
+	api := types.NewPayloadWithPath("api", "/api/pom.xml")
+	api.Tech = []string{"java"}
+	api.Techs = []string{"java", "spring"}
+	api.Reason = map[string][]string{"spring": {"matched dependency: org.springframework:spring-core"}}
+	api.Dependencies = []types.Dependency{{Type: "maven", Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Direct: true}}
+	root.AddChild(api)
+
+	usages := WhoUses(root, "log4j-core")
+	require.Len(t, usages, 1)
+	assert.Equal(t, Usage{Component: "api", ComponentID: api.ID, SourceDir: api.SourceDir, Type: "maven",
+		Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1", Direct: true}, usages[0])
+	assert.Empty(t, WhoUses(root, "openssl"))
+
+	techs := WhereTech(root, "Spring")
+	require.Len(t, techs, 1)
+	assert.False(t, techs[0].Primary)
+	assert.Equal(t, []string{"matched dependency: org.springframework:spring-core"}, techs[0].Reasons)
+	assert.True(t, WhereTech(root, "java")[0].Primary)
+}
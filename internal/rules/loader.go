@@ -6,8 +6,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"gopkg.in/yaml.v3"
 )
@@ -15,10 +19,65 @@ import (
 //go:embed all:techs
 var coreRulesFS embed.FS
 
-// LoadEmbeddedRules loads all rules from the embedded filesystem
+// embeddedRules memoizes the parsed embedded rules: they never change
+// within a process, and daemon and multi-path runs create many scanners.
+var embeddedRules struct {
+	once  sync.Once
+	rules []types.Rule
+	err   error
+}
+
+// LoadEmbeddedRules loads all rules from the embedded filesystem. The rule
+// files are parsed once per process; every call returns its own slice, but
+// the rules share their nested slices and maps and must not be modified.
 func LoadEmbeddedRules() ([]types.Rule, error) {
-	var rules []types.Rule
+	embeddedRules.once.Do(func() {
+		embeddedRules.rules, embeddedRules.err = parseEmbeddedRules()
+	})
+	if embeddedRules.err != nil {
+		return nil, embeddedRules.err
+	}
+	return slices.Clone(embeddedRules.rules), nil
+}
+
+// parseEmbeddedRules parses the embedded rule files concurrently, keeping
+// the order of the directory walk.
+func parseEmbeddedRules() ([]types.Rule, error) {
+	paths, err := embeddedRulePaths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk embedded rules: %w", err)
+	}
 
+	rules := make([]types.Rule, len(paths))
+	errs := make([]error, len(paths))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(paths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = parseEmbeddedRule(paths[i], &rules[i])
+			}
+		}()
+	}
+	for i := range paths {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk embedded rules: %w", err)
+		}
+	}
+	return rules, nil
+}
+
+// embeddedRulePaths lists the embedded rule files in walk order.
+func embeddedRulePaths() ([]string, error) {
+	var paths []string
 	err := fs.WalkDir(coreRulesFS, "techs", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -34,39 +93,62 @@ func LoadEmbeddedRules() ([]types.Rule, error) {
 		}
 
 		// Only load YAML files
-		if !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") {
-			return nil
-		}
-
-		content, err := coreRulesFS.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read rule file %s: %w", path, err)
+		if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+			paths = append(paths, path)
 		}
+		return nil
+	})
+	return paths, err
+}
 
-		var rule types.Rule
-		if err := yaml.Unmarshal(content, &rule); err != nil {
-			return fmt.Errorf("failed to parse rule file %s: %w", path, err)
-		}
+// parseEmbeddedRule reads, parses and validates the rule file at path.
+func parseEmbeddedRule(path string, rule *types.Rule) error {
+	content, err := coreRulesFS.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read rule file %s: %w", path, err)
+	}
 
-		// Derive type from folder if not specified
-		if rule.Type == "" {
-			rule.Type = deriveTypeFromPath(path)
-		}
+	if err := yaml.Unmarshal(content, rule); err != nil {
+		return fmt.Errorf("failed to parse rule file %s: %w", path, err)
+	}
 
-		// Validate rule
-		if err := validateRule(&rule); err != nil {
-			return fmt.Errorf("invalid rule in %s: %w", path, err)
-		}
+	// Derive type from folder if not specified
+	if rule.Type == "" {
+		rule.Type = deriveTypeFromPath(path)
+	}
 
-		rules = append(rules, rule)
-		return nil
-	})
+	// Validate rule
+	if err := validateRule(rule); err != nil {
+		return fmt.Errorf("invalid rule in %s: %w", path, err)
+	}
+	return nil
+}
 
+// LoadRules loads the embedded rules and, if rulesDir is set, the rules of
+// that directory on top of them: an external rule replaces the embedded rule
+// of the same tech, other external rules are added.
+func LoadRules(rulesDir string) ([]types.Rule, error) {
+	loaded, err := LoadEmbeddedRules()
+	if err != nil || rulesDir == "" {
+		return loaded, err
+	}
+	external, err := LoadExternalRules(rulesDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to walk embedded rules: %w", err)
+		return nil, err
 	}
-
-	return rules, nil
+	index := make(map[string]int, len(loaded))
+	for i, rule := range loaded {
+		index[rule.Tech] = i
+	}
+	for _, rule := range external {
+		if i, ok := index[rule.Tech]; ok {
+			loaded[i] = rule
+			continue
+		}
+		index[rule.Tech] = len(loaded)
+		loaded = append(loaded, rule)
+	}
+	return loaded, nil
 }
 
 // LoadExternalRules loads rules from an external directory
@@ -139,15 +221,69 @@ func validateRule(rule *types.Rule) error {
 		return fmt.Errorf("type is required")
 	}
 
-	// Validate dependencies
-	for i, dep := range rule.Dependencies {
+	if err := validateDependencies(rule.Dependencies); err != nil {
+		return err
+	}
+
+	// Validate tech relations
+	if err := validateRelations("implies", rule.Tech, rule.Implies); err != nil {
+		return err
+	}
+	if err := validateRelations("supersedes", rule.Tech, rule.Supersedes); err != nil {
+		return err
+	}
+	return validateUnless(rule.Unless)
+}
+
+// validateDependencies checks that dependencies name a type and a package,
+// and that their version constraints parse.
+func validateDependencies(deps []types.Dependency) error {
+	for i, dep := range deps {
 		if dep.Type == "" {
 			return fmt.Errorf("dependency %d: type is required", i)
 		}
 		if dep.Name == "" {
 			return fmt.Errorf("dependency %d: name is required", i)
 		}
+		if dep.Version != "" {
+			if _, err := semver.ParseConstraint(dep.Version); err != nil {
+				return fmt.Errorf("dependency %d: %w", i, err)
+			}
+		}
 	}
+	return nil
+}
 
+// validateUnless checks that a rule's unless conditions can be evaluated
+// for a directory: file patterns are valid and every content pattern says
+// which files of the directory it reads.
+func validateUnless(unless *types.RuleUnless) error {
+	if unless == nil {
+		return nil
+	}
+	for i, pattern := range unless.Files {
+		if _, err := filepath.Match(pattern, ""); pattern == "" || err != nil {
+			return fmt.Errorf("unless file %d: invalid pattern %q", i, pattern)
+		}
+	}
+	for i, content := range unless.Content {
+		if len(content.Files) == 0 && len(content.Extensions) == 0 {
+			return fmt.Errorf("unless content %d: files or extensions are required", i)
+		}
+	}
+	return nil
+}
+
+// validateRelations checks that a rule's implies or supersedes list names
+// other techs.
+func validateRelations(field, tech string, related []string) error {
+	for i, other := range related {
+		if other == "" {
+			return fmt.Errorf("%s %d: tech is required", field, i)
+		}
+		if other == tech {
+			return fmt.Errorf("%s %d: a tech cannot relate to itself", field, i)
+		}
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
@@ -55,3 +57,85 @@ func TestRuleStructure(t *testing.T) {
 
 	t.Logf("Rule structure validation passed for %d rules", len(rules))
 }
+
+func TestLoadEmbeddedRulesReturnsIndependentSlices(t *testing.T) {
+	first, err := LoadEmbeddedRules()
+	require.NoError(t, err)
+	second, err := LoadEmbeddedRules()
+	require.NoError(t, err)
+	require.Equal(t, len(first), len(second))
+
+	// Rules keep the order of the rule files.
+	paths, err := embeddedRulePaths()
+	require.NoError(t, err)
+	require.Len(t, first, len(paths))
+	require.Equal(t, deriveTypeFromPath(paths[0]), first[0].Type)
+
+	// Replacing an element of one result leaves later results untouched.
+	tech := first[0].Tech
+	first[0] = types.Rule{Tech: "replaced"}
+	third, err := LoadEmbeddedRules()
+	require.NoError(t, err)
+	require.Equal(t, tech, third[0].Tech)
+}
+
+func TestValidateRuleRejectsSelfRelations(t *testing.T) {
+	rule := types.Rule{Tech: "nextjs", Name: "Next.js", Type: "fullstack_framework", Implies: []string{"react"}}
+	require.NoError(t, validateRule(&rule))
+
+	rule.Supersedes = []string{"nextjs"}
+	require.ErrorContains(t, validateRule(&rule), "supersedes 0")
+
+	rule.Supersedes = nil
+	rule.Implies = []string{""}
+	require.ErrorContains(t, validateRule(&rule), "implies 0: tech is required")
+}
+
+func TestValidateRuleChecksUnless(t *testing.T) {
+	rule := types.Rule{Tech: "react", Name: "React", Type: "ui_framework", Unless: &types.RuleUnless{
+		Files:   []string{"*.uproject"},
+		Content: []types.ContentRule{{Pattern: "react-native", Files: []string{"app.json"}}},
+	}}
+	require.NoError(t, validateRule(&rule))
+
+	rule.Unless.Files = []string{"[app"}
+	require.ErrorContains(t, validateRule(&rule), "unless file 0: invalid pattern")
+
+	rule.Unless.Files = nil
+	rule.Unless.Content = []types.ContentRule{{Pattern: "react-native"}}
+	require.ErrorContains(t, validateRule(&rule), "unless content 0: files or extensions are required")
+}
+
+func TestValidateRuleChecksVersionConstraints(t *testing.T) {
+	rule := types.Rule{Tech: "vue2", Name: "Vue.js 2", Type: "web_framework",
+		Dependencies: []types.Dependency{{Type: "npm", Name: "vue", Version: "<3"}}}
+	require.NoError(t, validateRule(&rule))
+
+	rule.Dependencies[0].Version = "below 3"
+	require.ErrorContains(t, validateRule(&rule), "dependency 0: invalid version constraint")
+}
+
+func TestLoadRulesWithExternalDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "framework"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "framework", "myapp-ui.yaml"),
+		[]byte("tech: myapp-ui\nname: MyApp UI\ndependencies:\n  - type: npm\n    name: \"@myorg/ui\"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cargo.yaml"),
+		[]byte("tech: cargo\nname: Cargo (custom)\ntype: package_manager\n"), 0o644))
+
+	embedded, err := LoadEmbeddedRules()
+	require.NoError(t, err)
+	loaded, err := LoadRules(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded, len(embedded)+1)
+
+	byTech := make(map[string]types.Rule)
+	for _, rule := range loaded {
+		byTech[rule.Tech] = rule
+	}
+	require.Equal(t, "framework", byTech["myapp-ui"].Type, "type derived from the folder")
+	require.Equal(t, "Cargo (custom)", byTech["cargo"].Name, "external rule replaces the embedded one")
+
+	_, err = LoadRules(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
@@ -7,6 +7,10 @@
 // components. Non-package types (docker, terraform, githubAction, regex,
 // and similar matching domains) are skipped because they are not packages
 // resolvable against advisory databases.
+//
+// The package also ingests third-party CycloneDX/SPDX documents back into the
+// scan output format (see Import), so externally built artifacts can be
+// reported alongside the scanned source tree.
 package sbom
 
 import (
@@ -56,7 +60,12 @@ var purlTypes = map[string]bool{
 	"swift":     true,
 	"cpan":      true,
 	"cran":      true,
+	"julia":     true,
 	"docker":    true,
+	"deb":       true, // OS packages (scan-image, packaging recipes)
+	"rpm":       true,
+	"apk":       true,
+	"alpm":      true,
 }
 
 // BOM is the top-level CycloneDX document. Field order follows the CycloneDX
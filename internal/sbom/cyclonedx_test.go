@@ -80,6 +80,11 @@ func TestBuildPURL(t *testing.T) {
 			dep:  types.Dependency{Type: "cargo", Name: "mycrate", Version: "git:https://example.com/repo.git#main"},
 			want: "pkg:cargo/mycrate",
 		},
+		{
+			name: "deb package namespaced by distro",
+			dep:  types.Dependency{Type: "deb", Name: "curl", Version: "7.88.1-10", Metadata: map[string]interface{}{"distro": "debian"}},
+			want: "pkg:deb/debian/curl@7.88.1-10",
+		},
 		{
 			name: "non-package type yields no purl",
 			dep:  types.Dependency{Type: "terraform", Name: "myprovider", Version: "1.0.0"},
@@ -0,0 +1,387 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/purl"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ImportedComponentType is the component type assigned to payloads built from
+// an ingested third-party SBOM.
+const ImportedComponentType = "sbom"
+
+// ImportInfo describes an ingested SBOM document. It is attached to the
+// imported component under properties["sbom"].
+type ImportInfo struct {
+	File           string `json:"file"`
+	Format         string `json:"format"` // "cyclonedx" or "spdx"
+	SpecVersion    string `json:"spec_version,omitempty"`
+	SerialNumber   string `json:"serial_number,omitempty"`
+	ComponentCount int    `json:"component_count"`
+}
+
+// importProbe detects the document format from its top-level markers.
+type importProbe struct {
+	BOMFormat   string `json:"bomFormat"`
+	SPDXVersion string `json:"spdxVersion"`
+}
+
+// Import parses a CycloneDX or SPDX JSON document into a Payload-compatible
+// component: every package with a resolvable identity becomes a dependency
+// (typed from its PURL), and declared dependency relationships become
+// dependency edges. file is recorded as the component path and as the
+// dependencies' metadata source.
+func Import(data []byte, file string) (*types.Payload, error) {
+	var probe importProbe
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parse SBOM: %w", err)
+	}
+	switch {
+	case strings.EqualFold(probe.BOMFormat, bomFormat):
+		return importCycloneDX(data, file)
+	case strings.HasPrefix(probe.SPDXVersion, "SPDX-"):
+		return importSPDX(data, file)
+	default:
+		return nil, errors.New("unsupported SBOM: expected a CycloneDX (bomFormat) or SPDX (spdxVersion) JSON document")
+	}
+}
+
+// newImportedPayload creates the component that carries an imported SBOM.
+func newImportedPayload(name, file string, info ImportInfo) *types.Payload {
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file), ".json")
+		for _, suffix := range []string{".cdx", ".spdx", ".bom"} {
+			name = strings.TrimSuffix(name, suffix)
+		}
+	}
+	p := types.NewPayloadWithPath(name, "/"+strings.TrimPrefix(filepath.ToSlash(file), "/"))
+	p.SetComponentType(ImportedComponentType)
+	p.Properties["sbom"] = info
+	return p
+}
+
+// importedDependency converts a package identity into a dependency. The PURL
+// is authoritative; without one the package is kept as a "generic" entry.
+func importedDependency(purlStr, name, version, file string) (types.Dependency, bool) {
+	dep, ok := purl.Parse(purlStr)
+	if !ok {
+		if name == "" {
+			return types.Dependency{}, false
+		}
+		dep = types.Dependency{Type: "generic", Name: name}
+	}
+	if dep.Version == "" {
+		dep.Version = version
+	}
+	dep.Metadata = types.NewMetadata(path.Base(filepath.ToSlash(file)))
+	return dep, true
+}
+
+// edgeNode returns the "name@version" node identity used by dependency edges.
+func edgeNode(dep types.Dependency) string {
+	if dep.Version == "" {
+		return dep.Name
+	}
+	return dep.Name + "@" + dep.Version
+}
+
+// cdxImportBOM is the CycloneDX view needed for ingestion.
+type cdxImportBOM struct {
+	SpecVersion  string `json:"specVersion"`
+	SerialNumber string `json:"serialNumber"`
+	Metadata     struct {
+		Component *cdxImportComponent `json:"component"`
+	} `json:"metadata"`
+	Components   []cdxImportComponent `json:"components"`
+	Dependencies []struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	} `json:"dependencies"`
+}
+
+type cdxImportComponent struct {
+	BOMRef   string `json:"bom-ref"`
+	Group    string `json:"group"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Scope    string `json:"scope"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []cdxImportComponent `json:"components"`
+}
+
+// licenseString returns the component's SPDX id, expression, or license name.
+func (c cdxImportComponent) licenseString() string {
+	for _, l := range c.Licenses {
+		for _, s := range []string{l.License.ID, l.Expression, l.License.Name} {
+			if s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func importCycloneDX(data []byte, file string) (*types.Payload, error) {
+	var bom cdxImportBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, fmt.Errorf("parse CycloneDX: %w", err)
+	}
+
+	rootRef, rootName := "", ""
+	if bom.Metadata.Component != nil {
+		rootRef, rootName = bom.Metadata.Component.BOMRef, bom.Metadata.Component.Name
+	}
+
+	byRef := make(map[string]types.Dependency)
+	var deps []types.Dependency
+	for _, c := range flattenCycloneDX(bom.Components) {
+		dep, ok := cycloneDXDependency(c, file)
+		if !ok {
+			continue
+		}
+		deps = append(deps, dep)
+		if c.BOMRef != "" {
+			byRef[c.BOMRef] = dep
+		}
+	}
+
+	info := ImportInfo{File: file, Format: "cyclonedx", SpecVersion: bom.SpecVersion, SerialNumber: bom.SerialNumber, ComponentCount: len(deps)}
+	payload := newImportedPayload(rootName, file, info)
+
+	var direct map[string]bool
+	payload.DependencyEdges, direct = cycloneDXEdges(bom, byRef, rootRef)
+	payload.Dependencies = markDirect(deps, byRef, direct)
+	return payload, nil
+}
+
+// cycloneDXEdges converts the dependencies section into edges keyed by
+// "name@version"; edges from the document subject become "." (direct) edges.
+// It also returns the set of directly depended-on bom-refs.
+func cycloneDXEdges(bom cdxImportBOM, byRef map[string]types.Dependency, rootRef string) ([]types.DependencyEdge, map[string]bool) {
+	var edges []types.DependencyEdge
+	direct := make(map[string]bool)
+	for _, d := range bom.Dependencies {
+		from := "."
+		if d.Ref != rootRef {
+			source, known := byRef[d.Ref]
+			if !known {
+				continue
+			}
+			from = edgeNode(source)
+		}
+		for _, to := range d.DependsOn {
+			target, ok := byRef[to]
+			if !ok {
+				continue
+			}
+			if from == "." {
+				direct[to] = true
+			}
+			edges = append(edges, types.DependencyEdge{From: from, To: edgeNode(target), Source: "sbom"})
+		}
+	}
+	return edges, direct
+}
+
+// cycloneDXDependency maps a CycloneDX component to a dependency.
+func cycloneDXDependency(c cdxImportComponent, file string) (types.Dependency, bool) {
+	name := c.Name
+	if c.Group != "" {
+		name = c.Group + "/" + c.Name
+	}
+	dep, ok := importedDependency(c.PURL, name, c.Version, file)
+	if !ok {
+		return dep, false
+	}
+	switch c.Scope {
+	case "required":
+		dep.Scope = types.ScopeProd
+	case "optional":
+		dep.Scope = types.ScopeOptional
+	}
+	if lic := c.licenseString(); lic != "" {
+		license.SetDependencyLicense(&dep, lic)
+	}
+	return dep, true
+}
+
+// flattenCycloneDX returns all components including nested sub-components.
+func flattenCycloneDX(components []cdxImportComponent) []cdxImportComponent {
+	var out []cdxImportComponent
+	for _, c := range components {
+		out = append(out, c)
+		out = append(out, flattenCycloneDX(c.Components)...)
+	}
+	return out
+}
+
+// markDirect flags dependencies as direct. When the document states no
+// relationships from its subject, every package is treated as direct.
+func markDirect(deps []types.Dependency, byRef map[string]types.Dependency, directRefs map[string]bool) []types.Dependency {
+	directNodes := make(map[string]bool, len(directRefs))
+	for ref := range directRefs {
+		directNodes[edgeNode(byRef[ref])] = true
+	}
+	for i := range deps {
+		deps[i].Direct = len(directNodes) == 0 || directNodes[edgeNode(deps[i])]
+	}
+	if deps == nil {
+		return make([]types.Dependency, 0)
+	}
+	return deps
+}
+
+// spdxImportDocument is the SPDX 2.x view needed for ingestion.
+type spdxImportDocument struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	Packages          []struct {
+		SPDXID           string            `json:"SPDXID"`
+		Name             string            `json:"name"`
+		VersionInfo      string            `json:"versionInfo"`
+		LicenseConcluded string            `json:"licenseConcluded"`
+		LicenseDeclared  string            `json:"licenseDeclared"`
+		ExternalRefs     []SPDXExternalRef `json:"externalRefs"`
+	} `json:"packages"`
+	Relationships []SPDXRelationship `json:"relationships"`
+}
+
+func importSPDX(data []byte, file string) (*types.Payload, error) {
+	var doc spdxImportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse SPDX: %w", err)
+	}
+
+	roots := make(map[string]bool)
+	for _, rel := range doc.Relationships {
+		if rel.SPDXElementID == spdxDocumentID && rel.RelationshipType == "DESCRIBES" {
+			roots[rel.RelatedSPDXElement] = true
+		}
+	}
+
+	deps, byRef, rootName := spdxDependencies(doc, roots, file)
+
+	info := ImportInfo{File: file, Format: "spdx", SpecVersion: doc.SPDXVersion, SerialNumber: doc.DocumentNamespace, ComponentCount: len(deps)}
+	payload := newImportedPayload(rootName, file, info)
+
+	var direct map[string]bool
+	payload.DependencyEdges, direct = spdxEdges(doc.Relationships, byRef, roots)
+	payload.Dependencies = markDirect(deps, byRef, direct)
+	return payload, nil
+}
+
+// spdxDependencies converts the document's packages into dependencies,
+// skipping the described root packages (whose name names the component).
+func spdxDependencies(doc spdxImportDocument, roots map[string]bool, file string) ([]types.Dependency, map[string]types.Dependency, string) {
+	byRef := make(map[string]types.Dependency)
+	var deps []types.Dependency
+	rootName := doc.Name
+	for _, pkg := range doc.Packages {
+		if roots[pkg.SPDXID] {
+			rootName = pkg.Name
+			continue
+		}
+		dep, ok := importedDependency(spdxPURL(pkg.ExternalRefs), pkg.Name, pkg.VersionInfo, file)
+		if !ok {
+			continue
+		}
+		if lic := spdxLicense(pkg.LicenseConcluded, pkg.LicenseDeclared); lic != "" {
+			license.SetDependencyLicense(&dep, lic)
+		}
+		deps = append(deps, dep)
+		byRef[pkg.SPDXID] = dep
+	}
+	return deps, byRef, rootName
+}
+
+// spdxEdges converts dependency relationships into edges keyed by
+// "name@version"; relationships from a described root become "." (direct)
+// edges. It also returns the set of directly depended-on SPDX ids.
+func spdxEdges(relationships []SPDXRelationship, byRef map[string]types.Dependency, roots map[string]bool) ([]types.DependencyEdge, map[string]bool) {
+	var edges []types.DependencyEdge
+	direct := make(map[string]bool)
+	for _, rel := range relationships {
+		from, to, ok := spdxDependencyPair(rel)
+		if !ok {
+			continue
+		}
+		target, known := byRef[to]
+		if !known {
+			continue
+		}
+		edgeFrom := "."
+		if roots[from] {
+			direct[to] = true
+		} else if source, ok := byRef[from]; ok {
+			edgeFrom = edgeNode(source)
+		} else {
+			continue
+		}
+		edges = append(edges, types.DependencyEdge{From: edgeFrom, To: edgeNode(target), Source: "sbom"})
+	}
+	return edges, direct
+}
+
+// spdxDependencyPair normalizes SPDX dependency relationships to a
+// (depending, depended-on) pair. CONTAINS is treated as a dependency so that
+// documents which only state containment still yield direct packages.
+func spdxDependencyPair(rel SPDXRelationship) (string, string, bool) {
+	switch rel.RelationshipType {
+	case "DEPENDS_ON", "CONTAINS":
+		return rel.SPDXElementID, rel.RelatedSPDXElement, true
+	case "DEPENDENCY_OF", "CONTAINED_BY":
+		return rel.RelatedSPDXElement, rel.SPDXElementID, true
+	default:
+		return "", "", false
+	}
+}
+
+// spdxPURL returns the package's PURL external reference, if any.
+func spdxPURL(refs []SPDXExternalRef) string {
+	for _, ref := range refs {
+		if ref.ReferenceType == "purl" {
+			return ref.ReferenceLocator
+		}
+	}
+	return ""
+}
+
+// spdxLicense prefers the concluded license over the declared one, ignoring
+// the NOASSERTION/NONE placeholders.
+func spdxLicense(concluded, declared string) string {
+	for _, l := range []string{concluded, declared} {
+		if l != "" && l != spdxNoAssertion && l != "NONE" {
+			return l
+		}
+	}
+	return ""
+}
+
+// MergeImported attaches imported SBOM components to a scan result (or to a
+// fresh root when target is nil) and assigns deterministic IDs to the new
+// components without changing existing ones.
+func MergeImported(target *types.Payload, imported []*types.Payload) *types.Payload {
+	if target == nil {
+		target = types.NewPayloadWithPath("main", "/")
+	}
+	for _, p := range imported {
+		target.AddChild(p)
+	}
+	target.AssignIDs(target.ID)
+	return target
+}
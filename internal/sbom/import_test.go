@@ -0,0 +1,194 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/purl"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+const sampleCycloneDX = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "serialNumber": "urn:uuid:00000000-0000-4000-8000-000000000000",
+  "metadata": {"component": {"bom-ref": "app", "name": "vendor-agent", "type": "application"}},
+  "components": [
+    {"bom-ref": "a", "name": "spring-core", "group": "org.springframework", "version": "6.1.0",
+     "purl": "pkg:maven/org.springframework/spring-core@6.1.0", "scope": "required",
+     "licenses": [{"license": {"id": "Apache-2.0"}}],
+     "components": [
+       {"bom-ref": "b", "name": "spring-jcl", "version": "6.1.0", "purl": "pkg:maven/org.springframework/spring-jcl@6.1.0"}
+     ]},
+    {"bom-ref": "c", "name": "ui-kit", "version": "2.0.0", "purl": "pkg:npm/%40myorg/ui-kit@2.0.0"},
+    {"bom-ref": "d", "name": "firmware-blob", "version": "7"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["a", "c"]},
+    {"ref": "a", "dependsOn": ["b"]}
+  ]
+}`
+
+func TestImport_CycloneDX(t *testing.T) {
+	p, err := Import([]byte(sampleCycloneDX), "vendor/agent.cdx.json")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if p.Name != "vendor-agent" || p.ComponentType != ImportedComponentType {
+		t.Errorf("component = %q (%s), want vendor-agent (sbom)", p.Name, p.ComponentType)
+	}
+	if len(p.Path) != 1 || p.Path[0] != "/vendor/agent.cdx.json" {
+		t.Errorf("path = %v", p.Path)
+	}
+
+	byName := map[string]types.Dependency{}
+	for _, d := range p.Dependencies {
+		byName[d.Name] = d
+	}
+	if len(byName) != 4 {
+		t.Fatalf("dependencies = %d, want 4 (nested included): %+v", len(byName), p.Dependencies)
+	}
+	core := byName["org.springframework:spring-core"]
+	if core.Type != "maven" || core.Version != "6.1.0" || core.Scope != types.ScopeProd || !core.Direct {
+		t.Errorf("spring-core = %+v", core)
+	}
+	if lic := license.DependencyLicense(core); lic != "Apache-2.0" {
+		t.Errorf("spring-core license = %q, want Apache-2.0", lic)
+	}
+	if byName["org.springframework:spring-jcl"].Direct {
+		t.Error("spring-jcl is only reachable through spring-core and must be transitive")
+	}
+	if d := byName["@myorg/ui-kit"]; d.Type != "npm" || !d.Direct {
+		t.Errorf("ui-kit = %+v", d)
+	}
+	if d := byName["firmware-blob"]; d.Type != "generic" || d.Version != "7" {
+		t.Errorf("component without PURL = %+v, want generic", d)
+	}
+
+	wantEdges := map[types.DependencyEdge]bool{
+		{From: ".", To: "org.springframework:spring-core@6.1.0", Source: "sbom"}:                                    true,
+		{From: ".", To: "@myorg/ui-kit@2.0.0", Source: "sbom"}:                                                      true,
+		{From: "org.springframework:spring-core@6.1.0", To: "org.springframework:spring-jcl@6.1.0", Source: "sbom"}: true,
+	}
+	if len(p.DependencyEdges) != len(wantEdges) {
+		t.Fatalf("edges = %+v", p.DependencyEdges)
+	}
+	for _, e := range p.DependencyEdges {
+		if !wantEdges[e] {
+			t.Errorf("unexpected edge %+v", e)
+		}
+	}
+
+	info, ok := p.Properties["sbom"].(ImportInfo)
+	if !ok || info.Format != "cyclonedx" || info.SpecVersion != "1.5" || info.ComponentCount != 4 {
+		t.Errorf("properties.sbom = %+v", p.Properties["sbom"])
+	}
+}
+
+func TestImport_SPDX(t *testing.T) {
+	doc := `{
+  "spdxVersion": "SPDX-2.3",
+  "name": "vendor-doc",
+  "documentNamespace": "https://example.com/spdx/vendor",
+  "packages": [
+    {"SPDXID": "SPDXRef-root", "name": "payments-gateway", "versionInfo": "3.0"},
+    {"SPDXID": "SPDXRef-1", "name": "requests", "versionInfo": "2.31.0", "licenseConcluded": "NOASSERTION", "licenseDeclared": "Apache-2.0",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/requests@2.31.0"}]},
+    {"SPDXID": "SPDXRef-2", "name": "urllib3", "versionInfo": "2.0.7",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/urllib3@2.0.7"}]}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-root", "relationshipType": "DESCRIBES"},
+    {"spdxElementId": "SPDXRef-root", "relatedSpdxElement": "SPDXRef-1", "relationshipType": "DEPENDS_ON"},
+    {"spdxElementId": "SPDXRef-2", "relatedSpdxElement": "SPDXRef-1", "relationshipType": "DEPENDENCY_OF"}
+  ]
+}`
+	p, err := Import([]byte(doc), "payments.spdx.json")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if p.Name != "payments-gateway" {
+		t.Errorf("name = %q, want the described package", p.Name)
+	}
+	if len(p.Dependencies) != 2 {
+		t.Fatalf("dependencies = %+v, want 2 (root package excluded)", p.Dependencies)
+	}
+	for _, d := range p.Dependencies {
+		switch d.Name {
+		case "requests":
+			if !d.Direct || license.DependencyLicense(d) != "Apache-2.0" {
+				t.Errorf("requests = %+v", d)
+			}
+		case "urllib3":
+			if d.Direct {
+				t.Errorf("urllib3 should be transitive: %+v", d)
+			}
+		default:
+			t.Errorf("unexpected dependency %+v", d)
+		}
+	}
+	if len(p.DependencyEdges) != 2 {
+		t.Errorf("edges = %+v, want root->requests and requests->urllib3", p.DependencyEdges)
+	}
+}
+
+func TestImport_Unsupported(t *testing.T) {
+	if _, err := Import([]byte(`{"name":"x"}`), "x.json"); err == nil {
+		t.Error("expected error for a document that is neither CycloneDX nor SPDX")
+	}
+	if _, err := Import([]byte(`not json`), "x.json"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+// An SBOM emitted by this tool must ingest back to the same package set.
+func TestImport_RoundTrip(t *testing.T) {
+	payload := types.NewPayload("myapp", nil)
+	payload.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "@myorg/mylib", Version: "1.2.3", Scope: types.ScopeProd},
+		{Type: "maven", Name: "com.example:other", Version: "4.5.6"},
+		{Type: "golang", Name: "github.com/myorg/myapp", Version: "v1.0.0"},
+	}
+	data, err := json.Marshal(FromPayload(payload))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	imported, err := Import(data, "myapp.cdx.json")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := map[string]bool{}
+	for _, d := range payload.Dependencies {
+		want[purl.Build(d)] = true
+	}
+	for _, d := range imported.Dependencies {
+		if !want[purl.Build(d)] {
+			t.Errorf("round-trip produced unexpected %+v", d)
+		}
+		delete(want, purl.Build(d))
+	}
+	if len(want) != 0 {
+		t.Errorf("round-trip lost %v", want)
+	}
+}
+
+func TestMergeImported_KeepsExistingIDs(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.AddChild(types.NewPayloadWithPath("api", "/api/go.mod"))
+	root.AssignIDs("root-id")
+	existingID := root.Children[0].ID
+
+	imported, err := Import([]byte(sampleCycloneDX), "vendor/agent.cdx.json")
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	merged := MergeImported(root, []*types.Payload{imported})
+
+	if merged.ID != "root-id" || merged.Children[0].ID != existingID {
+		t.Errorf("existing IDs changed: root=%s child=%s", merged.ID, merged.Children[0].ID)
+	}
+	if len(merged.Children) != 2 || merged.Children[1].ID == "" {
+		t.Errorf("imported component not attached with an ID: %+v", merged.Children)
+	}
+}
@@ -0,0 +1,216 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DefaultAdoptionSamples is the number of commits the adoption timeline
+// samples when no other number is set.
+const DefaultAdoptionSamples = 20
+
+// AdoptionInfo is the adoption section of a component: when its techs and
+// direct dependencies first appeared in the sampled history of the
+// repository, and when the dependency versions changed.
+type AdoptionInfo struct {
+	Revisions    int                  `json:"revisions"` // commits sampled
+	Techs        []TechAdoption       `json:"techs,omitempty"`
+	Dependencies []DependencyAdoption `json:"dependencies,omitempty"`
+}
+
+// AdoptionRevision identifies a sampled commit.
+type AdoptionRevision struct {
+	Commit string `json:"commit"`
+	Date   string `json:"date"`
+	Tag    string `json:"tag,omitempty"`
+}
+
+// TechAdoption is the first sampled commit a tech of a component was
+// detected at.
+type TechAdoption struct {
+	Tech      string           `json:"tech"`
+	FirstSeen AdoptionRevision `json:"first_seen"`
+}
+
+// DependencyAdoption is the first sampled commit declaring a dependency of a
+// component, and the versions it was declared with since.
+type DependencyAdoption struct {
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	FirstSeen AdoptionRevision  `json:"first_seen"`
+	Versions  []AdoptionVersion `json:"versions,omitempty"`
+}
+
+// AdoptionVersion is a version of a dependency and the first sampled commit
+// declaring it.
+type AdoptionVersion struct {
+	Version string `json:"version"`
+	AdoptionRevision
+}
+
+// SetAdoption enables the adoption timeline (--adoption): up to samples
+// commits of the history of HEAD, or of the tagged commits with tags, are
+// read to date the techs and direct dependencies of every component. Zero
+// or fewer samples use DefaultAdoptionSamples.
+func (s *Scanner) SetAdoption(enabled bool, samples int, tags bool) {
+	if samples <= 0 {
+		samples = DefaultAdoptionSamples
+	}
+	s.adoptionSamples = 0
+	if enabled {
+		s.adoptionSamples = samples
+	}
+	s.adoptionTags = tags
+}
+
+// adoptionHistory collects what the component detectors found in one
+// component directory over the sampled revisions.
+type adoptionHistory struct {
+	techs map[string]AdoptionRevision
+	deps  map[string]*DependencyAdoption // by type:name
+}
+
+func (h *adoptionHistory) add(rev AdoptionRevision, detected *types.Payload) {
+	for _, tech := range slices.Concat(detected.Tech, detected.Techs) {
+		if _, seen := h.techs[tech]; !seen {
+			h.techs[tech] = rev
+		}
+	}
+	for _, dep := range detected.Dependencies {
+		if !dep.Direct {
+			continue
+		}
+		key := dep.Type + ":" + dep.Name
+		adoption := h.deps[key]
+		if adoption == nil {
+			adoption = &DependencyAdoption{Type: dep.Type, Name: dep.Name, FirstSeen: rev}
+			h.deps[key] = adoption
+		}
+		last := len(adoption.Versions) - 1
+		if dep.Version != "" && (last < 0 || adoption.Versions[last].Version != dep.Version) {
+			adoption.Versions = append(adoption.Versions, AdoptionVersion{Version: dep.Version, AdoptionRevision: rev})
+		}
+	}
+}
+
+// attachAdoption adds an "adoption" property to every manifest component
+// whose techs or dependencies were found in the sampled history.
+func (s *Scanner) attachAdoption(payload *types.Payload) {
+	if s.adoptionSamples == 0 {
+		return
+	}
+	basePath := s.provider.GetBasePath()
+	repoRoot, revisions, err := git.SampleHistory(basePath, s.adoptionSamples, s.adoptionTags)
+	if err != nil {
+		s.log().Warn("Adoption timeline skipped", "error", err)
+		return
+	}
+
+	dirs := componentsByDir(payload)
+	histories := s.adoptionHistories(dirs, repoRoot, revisions)
+	for dir, owners := range dirs {
+		for _, p := range owners {
+			if info := histories[dir].info(p, len(revisions)); info != nil {
+				if p.Properties == nil {
+					p.Properties = make(map[string]interface{})
+				}
+				p.Properties["adoption"] = info
+			}
+		}
+	}
+}
+
+// componentsByDir groups the manifest components by their directory.
+func componentsByDir(payload *types.Payload) map[string][]*types.Payload {
+	dirs := make(map[string][]*types.Payload)
+	walkPayloads(payload, func(p *types.Payload) {
+		if p.ComponentType != "" && p.SourceDir != "" {
+			dirs[p.SourceDir] = append(dirs[p.SourceDir], p)
+		}
+	})
+	return dirs
+}
+
+// adoptionHistories runs the component detectors on every component
+// directory at every sampled revision, oldest first.
+func (s *Scanner) adoptionHistories(dirs map[string][]*types.Payload, repoRoot string, revisions []git.Revision) map[string]*adoptionHistory {
+	basePath := s.provider.GetBasePath()
+	histories := make(map[string]*adoptionHistory, len(dirs))
+	for dir := range dirs {
+		histories[dir] = &adoptionHistory{techs: make(map[string]AdoptionRevision), deps: make(map[string]*DependencyAdoption)}
+	}
+	for _, revision := range revisions {
+		rev := AdoptionRevision{Commit: revision.Hash, Date: revision.Time.UTC().Format("2006-01-02"), Tag: revision.Tag}
+		treeProvider := provider.NewGitTreeProvider(revision.Tree, repoRoot, basePath)
+		for dir, history := range histories {
+			for _, detected := range s.detectAtRevision(treeProvider, filepath.Join(basePath, filepath.FromSlash(dir))) {
+				history.add(rev, detected)
+			}
+		}
+	}
+	return histories
+}
+
+// detectAtRevision runs the component detectors on a directory as a past
+// revision holds it. A detector failing on old content is skipped.
+func (s *Scanner) detectAtRevision(treeProvider types.Provider, dir string) []*types.Payload {
+	files, err := treeProvider.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	var detected []*types.Payload
+	for _, detector := range s.componentDetectors() {
+		for _, component := range s.runDetectorAt(detector, treeProvider, files, dir) {
+			s.depDetector.ApplyMatchesToPayload(component, s.depDetector.MatchVersionedDependencies(component.Dependencies))
+			detected = append(detected, component)
+		}
+	}
+	components.DropDeferredGraphs(detected)
+	return detected
+}
+
+func (s *Scanner) runDetectorAt(detector components.Detector, treeProvider types.Provider, files []types.File, dir string) (detected []*types.Payload) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log().Debug("Component detector failed on past revision", "detector", detector.Name(), "path", s.relativeDir(dir), "error", fmt.Sprint(r))
+			detected = nil
+		}
+	}()
+	return detector.Detect(files, dir, treeProvider.GetBasePath(), treeProvider, s.depDetector)
+}
+
+// info builds the adoption section of a component from the history of its
+// directory, keeping the techs and direct dependencies it has now.
+func (h *adoptionHistory) info(p *types.Payload, revisions int) *AdoptionInfo {
+	info := &AdoptionInfo{Revisions: revisions}
+	seen := make(map[string]bool)
+	for _, tech := range slices.Concat(p.Tech, p.Techs) {
+		if rev, ok := h.techs[tech]; ok && !seen[tech] {
+			seen[tech] = true
+			info.Techs = append(info.Techs, TechAdoption{Tech: tech, FirstSeen: rev})
+		}
+	}
+	for _, dep := range p.Dependencies {
+		key := dep.Type + ":" + dep.Name
+		if adoption, ok := h.deps[key]; ok && dep.Direct && !seen[key] {
+			seen[key] = true
+			info.Dependencies = append(info.Dependencies, *adoption)
+		}
+	}
+	if len(info.Techs)+len(info.Dependencies) == 0 {
+		return nil
+	}
+	sort.Slice(info.Techs, func(i, j int) bool { return info.Techs[i].Tech < info.Techs[j].Tech })
+	sort.Slice(info.Dependencies, func(i, j int) bool {
+		a, b := info.Dependencies[i], info.Dependencies[j]
+		return a.Type+":"+a.Name < b.Type+":"+b.Name
+	})
+	return info
+}
@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachAdoption(t *testing.T) {
+	root := t.TempDir()
+	repo, err := gogit.PlainInit(root, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	commit := func(when time.Time, manifest string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte(manifest), 0o644))
+		_, err := worktree.Add("package.json")
+		require.NoError(t, err)
+		_, err = worktree.Commit("update", &gogit.CommitOptions{
+			Author: &object.Signature{Name: "dev", Email: "dev@example.com", When: when},
+		})
+		require.NoError(t, err)
+	}
+	commit(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), `{"name": "myapp", "dependencies": {"express": "^4.17.0"}}`)
+	first, err := repo.Head()
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", first.Hash(), nil)
+	require.NoError(t, err)
+	commit(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), `{"name": "myapp", "dependencies": {"express": "^4.18.0", "pg": "^8.11.0"}}`)
+	second, err := repo.Head()
+	require.NoError(t, err)
+
+	scanner, err := NewScanner(root)
+	require.NoError(t, err)
+	scanner.SetAdoption(true, 0, false)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "adoption")
+	require.NotNil(t, component, "expected a component with an adoption section")
+	info, ok := component.Properties["adoption"].(*AdoptionInfo)
+	require.True(t, ok)
+	assert.Equal(t, 2, info.Revisions)
+
+	v1 := AdoptionRevision{Commit: first.Hash().String(), Date: "2023-03-01", Tag: "v1.0.0"}
+	head := AdoptionRevision{Commit: second.Hash().String(), Date: "2024-06-01"}
+	techs := make(map[string]AdoptionRevision)
+	for _, tech := range info.Techs {
+		techs[tech.Tech] = tech.FirstSeen
+	}
+	assert.Equal(t, v1, techs["nodejs"])
+	assert.Equal(t, head, techs["postgresql"])
+	assert.Equal(t, []DependencyAdoption{
+		{Type: "npm", Name: "express", FirstSeen: v1, Versions: []AdoptionVersion{
+			{Version: "^4.17.0", AdoptionRevision: v1},
+			{Version: "^4.18.0", AdoptionRevision: head},
+		}},
+		{Type: "npm", Name: "pg", FirstSeen: head, Versions: []AdoptionVersion{
+			{Version: "^8.11.0", AdoptionRevision: head},
+		}},
+	}, info.Dependencies)
+}
+
+func TestAdoptionDisabledByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(`{"name": "myapp"}`), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+	assert.Nil(t, findComponentWithProperty(result, "adoption"))
+}
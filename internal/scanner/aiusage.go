@@ -0,0 +1,327 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+const (
+	// maxAIModelRefs caps the model references collected per component.
+	maxAIModelRefs = 50
+	// maxAIScanSize skips large files when looking for model references.
+	maxAIScanSize = 1 << 20
+)
+
+// Model reference kinds reported in AIModelRef.Kind.
+const (
+	aiModelKindHuggingFace = "huggingface"
+	aiModelKindLLM         = "llm"
+)
+
+// aiModelFileFormats maps local model file extensions to their format.
+var aiModelFileFormats = map[string]string{
+	".onnx":        "onnx",
+	".gguf":        "gguf",
+	".safetensors": "safetensors",
+}
+
+// aiReferenceExtensions are the code and config files searched for model
+// references.
+var aiReferenceExtensions = map[string]bool{
+	".py": true, ".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".java": true, ".kt": true, ".cs": true,
+	".go": true, ".rb": true, ".yaml": true, ".yml": true, ".json": true, ".toml": true,
+}
+
+var (
+	// hfModelLoadRegex matches Hugging Face model ids passed to loaders:
+	// from_pretrained("org/model"), hf_hub_download(repo_id="org/model"),
+	// SentenceTransformer("org/model"), pipeline(..., model="org/model").
+	hfModelLoadRegex = regexp.MustCompile(`(?:from_pretrained|hf_hub_download|snapshot_download|SentenceTransformer)\(\s*(?:repo_id\s*=\s*|pretrained_model_name_or_path\s*=\s*)?["']([A-Za-z0-9][\w.-]*/[\w.-]+)["']|pipeline\([^)\n]*\bmodel\s*=\s*["']([A-Za-z0-9][\w.-]*/[\w.-]+)["']`)
+	// hfModelConfigRegex matches Hugging Face model ids in configuration keys.
+	hfModelConfigRegex = regexp.MustCompile(`\b(?:model_name_or_path|base_model|hf_model|model_id)["']?\s*[:=]\s*["']?([A-Za-z0-9][\w.-]*/[\w.-]+)`)
+	// llmModelRegex matches hosted LLM model names assigned to a model
+	// parameter (model="gpt-4o", model: claude-3-5-sonnet-latest).
+	llmModelRegex = regexp.MustCompile(`\bmodel["']?\s*[:=]\s*["']?((?:gpt-[\w.-]+|o[134](?:-mini|-preview)?|claude-[\w.-]+|gemini-[\w.-]+|mistral-[\w.-]+|command-[\w.-]+|deepseek-[\w.-]+|grok-[\w.-]+|llama[\w.:-]*))`)
+)
+
+// aiEndpointHosts maps LLM provider API hosts (exact or as a domain suffix
+// when starting with ".") to the provider tech.
+var aiEndpointHosts = []struct {
+	host, provider string
+}{
+	{"api.openai.com", "openai"},
+	{".openai.azure.com", "azure.openai"},
+	{"api.anthropic.com", "anthropic"},
+	{"generativelanguage.googleapis.com", "geminiai"},
+	{"api.mistral.ai", "mistralai"},
+	{"api.cohere.ai", "cohereai"},
+	{"api.cohere.com", "cohereai"},
+	{"api.groq.com", "groq"},
+	{"api.deepseek.com", "deepseek"},
+	{"api.x.ai", "xai"},
+	{"api.perplexity.ai", "perplexityai"},
+	{"api.replicate.com", "replicate"},
+	{"api-inference.huggingface.co", "huggingface"},
+	{"router.huggingface.co", "huggingface"},
+	{"openrouter.ai", "openrouter"},
+	{"api.together.xyz", "together"},
+}
+
+// bedrockHostRegex matches AWS Bedrock runtime endpoints.
+var bedrockHostRegex = regexp.MustCompile(`^bedrock(-runtime)?\.[a-z0-9-]+\.amazonaws\.com$`)
+
+// ollamaDefaultPort is the port of a local Ollama server.
+const ollamaDefaultPort = "11434"
+
+// AIUsageInfo is the ai_usage section of a component: the AI providers and
+// frameworks it uses, the models it references and the SDK versions.
+type AIUsageInfo struct {
+	Providers  []string      `json:"providers,omitempty"`
+	Frameworks []string      `json:"frameworks,omitempty"`
+	Models     []AIModelRef  `json:"models,omitempty"`
+	ModelFiles []AIModelFile `json:"model_files,omitempty"`
+	Endpoints  []AIEndpoint  `json:"endpoints,omitempty"`
+	SDKs       []AISDK       `json:"sdks,omitempty"`
+}
+
+// AIModelRef is a model referenced in code or configuration.
+type AIModelRef struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	File string `json:"file"`
+}
+
+// AIModelFile is a model stored in the repository.
+type AIModelFile struct {
+	File      string `json:"file"`
+	Format    string `json:"format"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// AIEndpoint is an LLM provider endpoint configured in a dotenv file. Only
+// the host is kept.
+type AIEndpoint struct {
+	Provider string `json:"provider"`
+	Host     string `json:"host"`
+	File     string `json:"file"`
+}
+
+// AISDK is a declared dependency on an AI provider SDK or framework.
+type AISDK struct {
+	Tech    string `json:"tech"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// aiUsageFiles accumulates the model references, model files and endpoints
+// seen for one component.
+type aiUsageFiles struct {
+	models     map[string]AIModelRef
+	modelFiles []AIModelFile
+	endpoints  map[string]AIEndpoint
+}
+
+// recordAIUsage collects model references and dotenv LLM endpoints of a
+// component.
+func (s *Scanner) recordAIUsage(ctx *types.Payload, filePath string, content []byte) {
+	name := filepath.Base(filePath)
+	isDotenv := name == ".env" || strings.HasPrefix(name, ".env.")
+	if !isDotenv && !mayReferenceModels(name, content) {
+		return
+	}
+
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	relPath := "/" + filepath.ToSlash(rel)
+	usage := s.aiUsageFor(ctx)
+	if !isDotenv {
+		usage.addModelRefs(string(content), relPath)
+		return
+	}
+	for _, ep := range findAIEndpoints(content) {
+		ep.File = relPath
+		usage.endpoints[ep.Provider+"|"+ep.Host] = ep
+	}
+}
+
+// recordAIModelFile collects a local model file of a component with its
+// size as listed, since model files are usually too large to be read.
+func (s *Scanner) recordAIModelFile(ctx *types.Payload, filePath string, size int64) {
+	format, isModelFile := aiModelFileFormats[strings.ToLower(filepath.Ext(filePath))]
+	if !isModelFile {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	usage := s.aiUsageFor(ctx)
+	usage.modelFiles = append(usage.modelFiles, AIModelFile{File: "/" + filepath.ToSlash(rel), Format: format, SizeBytes: size})
+}
+
+// mayReferenceModels reports whether a file is a code or config file worth
+// searching for model references.
+func mayReferenceModels(name string, content []byte) bool {
+	return aiReferenceExtensions[strings.ToLower(filepath.Ext(name))] &&
+		len(content) <= maxAIScanSize && bytes.Contains(content, []byte("model"))
+}
+
+func (s *Scanner) aiUsageFor(ctx *types.Payload) *aiUsageFiles {
+	if s.aiUsage == nil {
+		s.aiUsage = make(map[*types.Payload]*aiUsageFiles)
+	}
+	usage, ok := s.aiUsage[ctx]
+	if !ok {
+		usage = &aiUsageFiles{models: make(map[string]AIModelRef), endpoints: make(map[string]AIEndpoint)}
+		s.aiUsage[ctx] = usage
+	}
+	return usage
+}
+
+func (u *aiUsageFiles) addModelRefs(content, file string) {
+	add := func(id, kind string) {
+		if _, seen := u.models[id]; !seen && len(u.models) < maxAIModelRefs {
+			u.models[id] = AIModelRef{ID: id, Kind: kind, File: file}
+		}
+	}
+	for _, m := range hfModelLoadRegex.FindAllStringSubmatch(content, -1) {
+		add(m[1]+m[2], aiModelKindHuggingFace)
+	}
+	for _, m := range hfModelConfigRegex.FindAllStringSubmatch(content, -1) {
+		add(m[1], aiModelKindHuggingFace)
+	}
+	for _, m := range llmModelRegex.FindAllStringSubmatch(content, -1) {
+		add(m[1], aiModelKindLLM)
+	}
+}
+
+// findAIEndpoints returns the LLM provider endpoints configured in a dotenv
+// file. Values that are not URLs are ignored; only hosts are reported.
+func findAIEndpoints(content []byte) []AIEndpoint {
+	var endpoints []AIEndpoint
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		_, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(value), `"'`))
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if provider := aiEndpointProvider(u); provider != "" {
+			endpoints = append(endpoints, AIEndpoint{Provider: provider, Host: strings.ToLower(u.Host)})
+		}
+	}
+	return endpoints
+}
+
+func aiEndpointProvider(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	for _, known := range aiEndpointHosts {
+		if host == known.host || (strings.HasPrefix(known.host, ".") && strings.HasSuffix(host, known.host)) {
+			return known.provider
+		}
+	}
+	if bedrockHostRegex.MatchString(host) {
+		return "aws.bedrock"
+	}
+	if u.Port() == ollamaDefaultPort {
+		return "ollama"
+	}
+	return ""
+}
+
+// attachAIUsage adds an "ai_usage" property to every component using AI
+// providers or frameworks, referencing models or holding model files.
+func (s *Scanner) attachAIUsage(payload *types.Payload) {
+	aiTechs := make(map[string]string)
+	for _, rule := range s.rules {
+		if rule.Type == "ai" || rule.Type == "ai_service" {
+			aiTechs[rule.Tech] = rule.Type
+		}
+	}
+	s.walkAIUsage(payload, aiTechs)
+}
+
+func (s *Scanner) walkAIUsage(payload *types.Payload, aiTechs map[string]string) {
+	if info := s.aiUsageInfo(payload, aiTechs); info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["ai_usage"] = info
+	}
+	for _, child := range payload.Children {
+		s.walkAIUsage(child, aiTechs)
+	}
+}
+
+// aiUsageInfo builds the ai_usage section of one component, or nil when it
+// shows no AI usage.
+func (s *Scanner) aiUsageInfo(payload *types.Payload, aiTechs map[string]string) *AIUsageInfo {
+	info := &AIUsageInfo{}
+	providers := make(map[string]bool)
+	for _, tech := range payload.Techs {
+		switch aiTechs[tech] {
+		case "ai_service":
+			providers[tech] = true
+		case "ai":
+			info.Frameworks = append(info.Frameworks, tech)
+		}
+	}
+	if usage := s.aiUsage[payload]; usage != nil {
+		info.ModelFiles = usage.modelFiles
+		for _, model := range usage.models {
+			info.Models = append(info.Models, model)
+		}
+		for _, ep := range usage.endpoints {
+			info.Endpoints = append(info.Endpoints, ep)
+			providers[ep.Provider] = true
+		}
+	}
+	info.Providers = sortedSet(providers)
+	info.SDKs = s.aiSDKs(payload, aiTechs)
+	if len(info.Providers)+len(info.Frameworks)+len(info.Models)+len(info.ModelFiles)+len(info.SDKs) == 0 {
+		return nil
+	}
+	info.sort()
+	return info
+}
+
+// aiSDKs returns the component's direct dependencies that match AI rules.
+func (s *Scanner) aiSDKs(payload *types.Payload, aiTechs map[string]string) []AISDK {
+	if s.depDetector == nil {
+		return nil
+	}
+	var sdks []AISDK
+	for _, dep := range payload.Dependencies {
+		if !dep.Direct {
+			continue
+		}
+		for tech := range s.depDetector.MatchDependencies([]string{dep.Name}, dep.Type) {
+			if aiTechs[tech] != "" {
+				sdks = append(sdks, AISDK{Tech: tech, Name: dep.Name, Version: dep.Version})
+			}
+		}
+	}
+	sort.Slice(sdks, func(i, j int) bool { return sdks[i].Name < sdks[j].Name })
+	return sdks
+}
+
+func (i *AIUsageInfo) sort() {
+	sort.Strings(i.Frameworks)
+	sort.Slice(i.Models, func(a, b int) bool { return i.Models[a].ID < i.Models[b].ID })
+	sort.Slice(i.ModelFiles, func(a, b int) bool { return i.ModelFiles[a].File < i.ModelFiles[b].File })
+	sort.Slice(i.Endpoints, func(a, b int) bool {
+		return i.Endpoints[a].Provider+i.Endpoints[a].Host < i.Endpoints[b].Provider+i.Endpoints[b].Host
+	})
+}
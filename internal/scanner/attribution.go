@@ -0,0 +1,211 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxNoticeText is the length of notice file text kept in the output with
+// --notice-text.
+const maxNoticeText = 64 * 1024
+
+// copyrightHeaderLines is how many leading lines of a source file are read
+// for copyright statements.
+const copyrightHeaderLines = 20
+
+var (
+	noticeFileName      = regexp.MustCompile(`(?i)^notices?$`)
+	thirdPartyFileName  = regexp.MustCompile(`(?i)^third[-_]?party([-_ ]?(notices?|licen[cs]es?))?$`)
+	licenseTextFileName = regexp.MustCompile(`(?i)^((un)?licen[cs]e|copying|copyright|authors)([-_][a-z0-9.-]+)?$`)
+	copyrightYear       = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+	commentMarkers      = regexp.MustCompile(`^(//+|/\*+|\*+|#+|--+|;+|<!--|%+|'|rem\b)\s*`)
+	spdxIdentifier      = regexp.MustCompile(`(?m)SPDX-License-Identifier:\s*([^\r\n*]+?)\s*(?:\*/|-->)?\s*$`)
+	licensedUnder       = regexp.MustCompile(`(?i)licensed under (?:the )?((?:[\w.-]+ ){0,3}licen[cs]e(?:,? version \d+(?:\.\d+)?)?)`)
+)
+
+// noticeNormalizer maps the license names of notice files to SPDX ids.
+var noticeNormalizer = license.NewNormalizer()
+
+// AttributionInfo is the attribution section of a component: the copyright
+// statements of its files and its NOTICE and third-party notice files, as
+// attribution documents are generated from.
+type AttributionInfo struct {
+	Copyrights []string     `json:"copyrights,omitempty"` // distinct statements, in the order found
+	Notices    []NoticeFile `json:"notices,omitempty"`
+}
+
+// NoticeFile is a NOTICE or third-party notices file, identified by the
+// hash of its text, with the licenses it names.
+type NoticeFile struct {
+	File      string   `json:"file"`                // relative to the scan root
+	Kind      string   `json:"kind"`                // "notice" or "third_party"
+	Licenses  []string `json:"licenses,omitempty"`  // SPDX ids named in the text
+	TextHash  string   `json:"text_hash"`           // "sha256:" and the hex SHA-256 of the file
+	Text      string   `json:"text,omitempty"`      // with --notice-text
+	Truncated bool     `json:"truncated,omitempty"` // text cut at 64 KiB
+}
+
+// SetNoticeText keeps the text of notice files in the attribution section
+// (--notice-text), cut at 64 KiB.
+func (s *Scanner) SetNoticeText(enabled bool) {
+	s.noticeText = enabled
+}
+
+// recordAttribution collects the notice text and copyright statements of a
+// file.
+func (s *Scanner) recordAttribution(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil || len(content) == 0 {
+		return
+	}
+	copyrights, notice := fileAttribution("/"+filepath.ToSlash(rel), content, s.noticeText)
+	if notice == nil && len(copyrights) == 0 {
+		return
+	}
+
+	if s.attribution == nil {
+		s.attribution = make(map[*types.Payload]*AttributionInfo)
+	}
+	info := s.attribution[ctx]
+	if info == nil {
+		info = &AttributionInfo{}
+		s.attribution[ctx] = info
+	}
+	for _, statement := range copyrights {
+		if !slices.Contains(info.Copyrights, statement) {
+			info.Copyrights = append(info.Copyrights, statement)
+		}
+	}
+	if notice != nil {
+		info.Notices = append(info.Notices, *notice)
+	}
+}
+
+// fileAttribution returns the copyright statements of a file, all of a
+// notice or license file's and those of a source file's leading comments,
+// and the file itself when it is a notice file, with its text when withText.
+func fileAttribution(rel string, content []byte, withText bool) ([]string, *NoticeFile) {
+	switch kind := attributionKind(path.Base(rel)); kind {
+	case "notice", "third_party":
+		return copyrightStatements(string(content), -1, false), newNoticeFile(rel, kind, content, withText)
+	case "license":
+		return copyrightStatements(string(content), -1, false), nil
+	}
+	return copyrightStatements(string(content), copyrightHeaderLines, true), nil
+}
+
+// attributionKind classifies a file by name: "notice", "third_party",
+// "license" (license, copying, copyright and authors files) or "".
+func attributionKind(name string) string {
+	stem := name
+	switch strings.ToLower(path.Ext(name)) {
+	case ".txt", ".md", ".rst", ".markdown", ".html":
+		stem = strings.TrimSuffix(name, path.Ext(name))
+	}
+	switch {
+	case noticeFileName.MatchString(stem):
+		return "notice"
+	case thirdPartyFileName.MatchString(stem):
+		return "third_party"
+	case licenseTextFileName.MatchString(stem):
+		return "license"
+	}
+	return ""
+}
+
+func newNoticeFile(rel, kind string, content []byte, withText bool) *NoticeFile {
+	notice := &NoticeFile{File: rel, Kind: kind, Licenses: noticeLicenses(string(content)), TextHash: noticeHash(content)}
+	if !withText {
+		return notice
+	}
+	notice.Text = strings.TrimSpace(string(content))
+	if len(notice.Text) > maxNoticeText {
+		notice.Text = strings.ToValidUTF8(notice.Text[:maxNoticeText], "")
+		notice.Truncated = true
+	}
+	return notice
+}
+
+// noticeHash returns the SHA-256 of a notice file, telling identical notices
+// apart without keeping their text.
+func noticeHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// noticeLicenses returns the SPDX ids a notice text names, in
+// SPDX-License-Identifier lines or as "licensed under the <license>", in the
+// order found.
+func noticeLicenses(text string) []string {
+	var ids []string
+	for _, m := range spdxIdentifier.FindAllStringSubmatch(text, -1) {
+		for _, id := range noticeNormalizer.ParseLicenseExpression(m[1]) {
+			ids = appendUnique(ids, id)
+		}
+	}
+	for _, m := range licensedUnder.FindAllStringSubmatch(text, -1) {
+		if id := noticeNormalizer.Normalize(m[1]); noticeNormalizer.IsSPDXValid(id) {
+			ids = appendUnique(ids, id)
+		}
+	}
+	return ids
+}
+
+// copyrightStatements returns the copyright statements ("Copyright (c) 2024
+// Myorg", "(c) 2024 Myorg", "© 2024 Myorg") of the first maxLines lines of
+// text (all when negative), with comment markers stripped. With
+// commentsOnly, only lines carrying a comment marker are read, as in source
+// file headers. Statements need a year, which skips license templates such
+// as "Copyright [yyyy] [name of copyright owner]".
+func copyrightStatements(text string, maxLines int, commentsOnly bool) []string {
+	var lines []string
+	if maxLines < 0 {
+		lines = strings.Split(text, "\n")
+	} else {
+		lines = strings.SplitN(text, "\n", maxLines+1)
+		lines = lines[:min(len(lines), maxLines)]
+	}
+	var statements []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		stripped := commentMarkers.ReplaceAllString(line, "")
+		if commentsOnly && stripped == line {
+			continue
+		}
+		stripped = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(stripped, "*/"), "-->"))
+		if isCopyrightStatement(stripped) {
+			statements = append(statements, strings.Join(strings.Fields(stripped), " "))
+		}
+	}
+	return statements
+}
+
+func isCopyrightStatement(line string) bool {
+	lower := strings.ToLower(line)
+	if !strings.HasPrefix(lower, "copyright") && !strings.HasPrefix(lower, "(c) ") && !strings.HasPrefix(line, "©") {
+		return false
+	}
+	return copyrightYear.MatchString(line)
+}
+
+// attachAttribution adds an "attribution" property to every component
+// holding copyright statements or notice files.
+func (s *Scanner) attachAttribution(payload *types.Payload) {
+	if info := s.attribution[payload]; info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["attribution"] = info
+	}
+	for _, child := range payload.Children {
+		s.attachAttribution(child)
+	}
+}
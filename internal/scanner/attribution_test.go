@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachAttribution(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("NOTICE", "MyApp\nCopyright 2020-2024 Myorg Inc.\n")
+	write("LICENSE", "MIT License\n\nCopyright (c) 2024 Myorg Inc.\n\nPermission is hereby granted ... The above copyright notice shall be included.\n")
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "4.18.0"}}`)
+	write("api/THIRD_PARTY_NOTICES.txt", "express\nCopyright (c) 2009-2014 TJ Holowaychuk\n")
+	write("api/src/vendor.js", "/*\n * Copyright (c) 2015 Example Author\n */\nconst copyright = 'Copyright 2016 not a comment';\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	root, ok := result.Properties["attribution"].(*AttributionInfo)
+	require.True(t, ok, "expected an attribution section on the root")
+	assert.Equal(t, []string{"Copyright (c) 2024 Myorg Inc.", "Copyright 2020-2024 Myorg Inc."}, root.Copyrights)
+	require.Len(t, root.Notices, 1)
+	assert.Equal(t, NoticeFile{File: "/NOTICE", Kind: "notice", TextHash: noticeHash([]byte("MyApp\nCopyright 2020-2024 Myorg Inc.\n"))}, root.Notices[0])
+
+	api := findByName(result, "api")
+	require.NotNil(t, api)
+	info, ok := api.Properties["attribution"].(*AttributionInfo)
+	require.True(t, ok, "expected an attribution section on the api component")
+	assert.Equal(t, []string{"Copyright (c) 2009-2014 TJ Holowaychuk", "Copyright (c) 2015 Example Author"}, info.Copyrights)
+	require.Len(t, info.Notices, 1)
+	assert.Equal(t, "third_party", info.Notices[0].Kind)
+	assert.Equal(t, "/api/THIRD_PARTY_NOTICES.txt", info.Notices[0].File)
+}
+
+func TestAttachAttributionNoticeText(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "NOTICE"), []byte("MyApp\nCopyright 2020-2024 Myorg Inc.\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	scanner.SetNoticeText(true)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	root, ok := result.Properties["attribution"].(*AttributionInfo)
+	require.True(t, ok, "expected an attribution section on the root")
+	require.Len(t, root.Notices, 1)
+	assert.Equal(t, "MyApp\nCopyright 2020-2024 Myorg Inc.", root.Notices[0].Text)
+	assert.NotEmpty(t, root.Notices[0].TextHash)
+}
+
+func TestNoticeLicenses(t *testing.T) {
+	text := "MyApp\nThis product is licensed under the Apache License, Version 2.0.\n\n" +
+		"express\n// SPDX-License-Identifier: MIT OR Apache-2.0\n" +
+		"left-pad\nLicensed under the ISC License.\n" +
+		"is-even\nLicensed under the Whatever License.\n"
+	assert.Equal(t, []string{"MIT", "Apache-2.0", "ISC"}, noticeLicenses(text), "names of unknown licenses are dropped")
+	assert.Empty(t, noticeLicenses("MyApp\nCopyright 2024 Myorg Inc.\n"))
+}
+
+func TestCopyrightStatements(t *testing.T) {
+	assert.Equal(t, []string{"© 2023 Myorg", "(c) 2021 Example Author"},
+		copyrightStatements("© 2023 Myorg\n(c) 2021 Example Author\nCopyright [yyyy] [name of copyright owner]\n", -1, false))
+	assert.Equal(t, []string{"Copyright 2024 Myorg"},
+		copyrightStatements("#!/bin/sh\n# Copyright 2024   Myorg\necho 'Copyright 2025 Myorg'\n", copyrightHeaderLines, true))
+	assert.Empty(t, copyrightStatements("\n\n// Copyright 2024 Myorg\n", 2, true), "only the leading lines are read")
+}
@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ChangedScanRoots returns the directories to rescan, relative to the scan
+// root, for files changed since a baseline scan: for each file the directory
+// of the deepest manifest component of the baseline owning it, or the
+// file's own directory when only the root does. full reports that a file of
+// the scan root itself changed, which only a full scan accounts for.
+func ChangedScanRoots(baseline *types.Payload, changed []string) (roots []string, full bool) {
+	var componentDirs []string
+	walkPayloads(baseline, func(p *types.Payload) {
+		if dir := strings.Trim(p.SourceDir, "/"); p != baseline && p.ComponentType != "" && dir != "" {
+			componentDirs = append(componentDirs, dir)
+		}
+	})
+
+	var dirs []string
+	for _, file := range changed {
+		dir := path.Dir(filepath.ToSlash(file))
+		if dir == "." {
+			return nil, true
+		}
+		dirs = append(dirs, owningDir(dir, componentDirs))
+	}
+
+	slices.Sort(dirs)
+	for _, dir := range slices.Compact(dirs) {
+		if !underAnyPrefix(dir, roots) {
+			roots = append(roots, dir)
+		}
+	}
+	for i, root := range roots {
+		roots[i] = filepath.FromSlash(root)
+	}
+	return roots, false
+}
+
+// owningDir returns the deepest of componentDirs holding dir, or dir.
+func owningDir(dir string, componentDirs []string) string {
+	owner := ""
+	for _, componentDir := range componentDirs {
+		if underAnyPrefix(dir, []string{componentDir}) && len(componentDir) > len(owner) {
+			owner = componentDir
+		}
+	}
+	if owner == "" {
+		return dir
+	}
+	return owner
+}
+
+// MergeChanges replaces the components of the baseline below the rescanned
+// roots by those of partial, the result of a scan restricted to them (see
+// SetIncludePaths), attaching each to the deepest manifest component of the
+// baseline owning its directory. The root gains the techs, dependencies and
+// reasons partial found outside any component, and partial's metadata and
+// git identity. Its languages and code statistics keep the baseline's,
+// counted over the whole tree.
+func (s *Scanner) MergeChanges(baseline, partial *types.Payload, roots []string) *types.Payload {
+	prefixes := make([]string, len(roots))
+	for i, root := range roots {
+		prefixes[i] = filepath.ToSlash(root)
+	}
+	removed := make(map[string]bool)
+	pruneComponentsUnder(baseline, prefixes, removed)
+	walkPayloads(baseline, func(p *types.Payload) {
+		p.Edges = slices.DeleteFunc(p.Edges, func(e types.Edge) bool { return e.Target == nil || removed[e.Target.ID] })
+	})
+
+	for _, child := range partial.Children {
+		owner := componentOwning(baseline, strings.Trim(child.SourceDir, "/"))
+		owner.Children = append(owner.Children, child)
+	}
+	languages := baseline.Languages
+	baseline.Combine(partial)
+	baseline.Languages = languages
+	baseline.Edges = append(baseline.Edges, partial.Edges...)
+	baseline.DependencyEdges = append(baseline.DependencyEdges, partial.DependencyEdges...)
+	if partial.Git != nil {
+		baseline.Git = partial.Git
+	}
+
+	walkPayloads(baseline, func(p *types.Payload) { p.ComponentRefs = nil })
+	s.resolveComponentRefs(baseline)
+	if meta, ok := partial.Metadata.(*metadata.ScanMetadata); ok {
+		meta.SetFileCounts(s.countFilesAndComponents(baseline))
+		meta.SetLanguageCount(s.countLanguages(baseline))
+		meta.SetTechCounts(s.countTechs(baseline))
+		baseline.Metadata = meta
+	}
+	return baseline
+}
+
+// pruneComponentsUnder removes the components of p whose directory is below
+// one of prefixes, recording the IDs of them and their descendants.
+func pruneComponentsUnder(p *types.Payload, prefixes []string, removed map[string]bool) {
+	p.Children = slices.DeleteFunc(p.Children, func(child *types.Payload) bool {
+		if dir := strings.Trim(child.SourceDir, "/"); dir != "" && underAnyPrefix(dir, prefixes) {
+			walkPayloads(child, func(gone *types.Payload) { removed[gone.ID] = true })
+			return true
+		}
+		pruneComponentsUnder(child, prefixes, removed)
+		return false
+	})
+}
+
+// componentOwning returns the deepest manifest component below p whose
+// directory holds dir, or p when there is none.
+func componentOwning(p *types.Payload, dir string) *types.Payload {
+	for _, child := range p.Children {
+		childDir := strings.Trim(child.SourceDir, "/")
+		if child.ComponentType != "" && childDir != "" && childDir != dir && underAnyPrefix(dir, []string{childDir}) {
+			return componentOwning(child, dir)
+		}
+	}
+	return p
+}
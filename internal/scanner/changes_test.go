@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedScanRoots(t *testing.T) {
+	baseline := types.NewPayloadWithPath("main", "/")
+	api := types.NewPayloadWithPath("api", "/services/api/package.json")
+	api.ComponentType = "nodejs"
+	worker := types.NewPayloadWithPath("worker", "/services/api/worker/package.json")
+	worker.ComponentType = "nodejs"
+	api.AddChild(worker)
+	db := types.NewPayloadWithPath("postgresql", "/deploy/docker-compose.yml")
+	baseline.AddChild(api)
+	baseline.AddChild(db)
+
+	roots, full := ChangedScanRoots(baseline, []string{
+		"services/api/src/index.js",
+		"services/api/worker/package.json",
+		"services/new/go.mod",
+		"deploy/docker-compose.yml",
+	})
+	assert.False(t, full)
+	assert.Equal(t, []string{"deploy", "services/api", "services/new"}, toSlashAll(roots),
+		"changed files map to their deepest manifest component, or their own directory")
+
+	roots, full = ChangedScanRoots(baseline, nil)
+	assert.False(t, full)
+	assert.Empty(t, roots)
+
+	_, full = ChangedScanRoots(baseline, []string{"services/api/index.js", "package.json"})
+	assert.True(t, full, "a file of the scan root changed")
+}
+
+func toSlashAll(paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = filepath.ToSlash(p)
+	}
+	return out
+}
+
+func writeChangesTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestMergeChanges(t *testing.T) {
+	root := t.TempDir()
+	writeChangesTestFiles(t, root, map[string]string{
+		"services/api/package.json": `{"name": "api", "dependencies": {"express": "4.18.0"}}`,
+		"services/web/package.json": `{"name": "web", "dependencies": {"react": "18.2.0"}}`,
+	})
+	full, err := NewScanner(root)
+	require.NoError(t, err)
+	baseline, err := full.Scan()
+	require.NoError(t, err)
+	webID := findByName(baseline, "web").ID
+
+	writeChangesTestFiles(t, root, map[string]string{
+		"services/api/package.json": `{"name": "api", "dependencies": {"fastify": "4.0.0"}}`,
+	})
+	roots, _ := ChangedScanRoots(baseline, []string{"services/api/package.json"})
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	s.SetIncludePaths(roots)
+	partial, err := s.Scan()
+	require.NoError(t, err)
+
+	merged := s.MergeChanges(baseline, partial, roots)
+
+	techs := collectAllTechs(merged)
+	assert.Contains(t, techs, "fastify")
+	assert.NotContains(t, techs, "express", "the rescanned component replaces the baseline's")
+	assert.Contains(t, techs, "react", "components outside the changed directories are kept")
+	assert.Equal(t, webID, findByName(merged, "web").ID)
+
+	var apis int
+	walkPayloads(merged, func(p *types.Payload) {
+		if p.Name == "api" {
+			apis++
+		}
+	})
+	assert.Equal(t, 1, apis)
+}
+
+func findByName(root *types.Payload, name string) *types.Payload {
+	var found *types.Payload
+	walkPayloads(root, func(p *types.Payload) {
+		if p.Name == name && found == nil {
+			found = p
+		}
+	})
+	return found
+}
@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"bytes"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// codeStatsSampleChunk is the size of the head and of the tail of a file
+// sampled for code statistics.
+const codeStatsSampleChunk = 256 << 10
+
+// SetCodeStatsSampling sets the size above which the code statistics of a
+// file are estimated from its first and last 256 KB, scaled to its size and
+// marked estimated, instead of counted from its full content. Files too
+// large to be read for content (SetSkipPolicy) are then sampled rather than
+// left out of the statistics. Zero or a negative size turns sampling off.
+func (s *Scanner) SetCodeStatsSampling(threshold int64) {
+	s.sampleThreshold = threshold
+}
+
+// codeStatsSample returns the sample the code statistics of a file are
+// estimated from, nil when they are counted in full or not at all. A file
+// read for content is sampled from it; a file too large to be read is
+// sampled through the provider unless its head is binary.
+func (s *Scanner) codeStatsSample(filePath string, file types.File, content []byte, skipReason string) []byte {
+	if s.sampleThreshold <= 0 || file.Size <= s.sampleThreshold || file.Size <= 2*codeStatsSampleChunk {
+		return nil
+	}
+	switch skipReason {
+	case "":
+		if len(content) <= 2*codeStatsSampleChunk {
+			return nil
+		}
+		return joinSample(content[:codeStatsSampleChunk], content[len(content)-codeStatsSampleChunk:])
+	case skipReasonTooLarge:
+		head, err := s.readFileRange(filePath, 0, codeStatsSampleChunk)
+		if err != nil || enry.IsBinary(head) {
+			return nil
+		}
+		tail, err := s.readFileRange(filePath, file.Size-codeStatsSampleChunk, codeStatsSampleChunk)
+		if err != nil {
+			return nil
+		}
+		return joinSample(head, tail)
+	}
+	return nil
+}
+
+// joinSample joins the head and the tail of a file, cut to whole lines.
+func joinSample(head, tail []byte) []byte {
+	if i := bytes.LastIndexByte(head, '\n'); i >= 0 {
+		head = head[:i+1]
+	}
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	sample := make([]byte, 0, len(head)+len(tail))
+	return append(append(sample, head...), tail...)
+}
+
+// readFileRange reads part of a file through the provider, reading all of
+// it when the provider cannot read ranges.
+func (s *Scanner) readFileRange(filePath string, offset, length int64) ([]byte, error) {
+	if reader, ok := s.provider.(types.RangeReader); ok {
+		return reader.ReadFileRange(filePath, offset, length)
+	}
+	content, err := s.provider.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	start := min(offset, int64(len(content)))
+	return content[start:min(start+length, int64(len(content)))], nil
+}
+
+// collectSampledCodeStats adds the code statistics estimated from a sample
+// of a file, reporting false when the analyzer cannot estimate them.
+func (s *Scanner) collectSampledCodeStats(filePath, language, typeOverride string, sample []byte, fileSize int64, ctx *types.Payload) bool {
+	analyzer, ok := s.codeStats.(codestats.SampleAnalyzer)
+	if !ok {
+		return false
+	}
+	compKey := ctx.ComponentPath()
+	analyzer.ProcessSample(filePath, language, typeOverride, sample, fileSize, compKey, s.resolveSubsystemKey(compKey, filePath))
+	return true
+}
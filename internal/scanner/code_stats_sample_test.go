@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestCodeStatsSampling(t *testing.T) {
+	tempDir := t.TempDir()
+	line := []byte("var generated = []int{1, 2, 3}\n")
+	write := func(name string, lines int) {
+		content := append([]byte("package gen\n"), bytes.Repeat(line, lines)...)
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), content, 0o644))
+	}
+	write("small.go", 10)
+	write("large.go", 40000) // ~1.2 MB: read, but sampled for code stats
+	write("huge.go", 80000)  // ~2.5 MB: too large to be read, sampled through the provider
+	write("huge.bin", 80000) // a skipped type, never sampled
+
+	stats := codestats.NewAnalyzer(codestats.AnalyzerConfig{})
+	s, err := NewScannerWithOptions(tempDir, nil, false, false, false, false, stats)
+	require.NoError(t, err)
+	s.SetSkipPolicy(2<<20, nil)
+	s.SetCodeStatsSampling(1 << 20)
+	_, err = s.Scan()
+	require.NoError(t, err)
+
+	total := stats.GetStats().Total
+	assert.True(t, total.Estimated)
+	assert.Equal(t, 3, total.Files, "files of skipped types are not sampled")
+	assert.InDelta(t, 1+10+1+40000+1+80000, total.Lines, 200, "sampled counts are scaled to the file sizes")
+}
+
+func TestCodeStatsSampleOff(t *testing.T) {
+	s := &Scanner{}
+	file := types.File{Name: "large.go", Size: 4 << 20}
+	assert.Nil(t, s.codeStatsSample("/repo/large.go", file, make([]byte, 4<<20), ""))
+
+	s.SetCodeStatsSampling(1 << 20)
+	assert.Len(t, s.codeStatsSample("/repo/large.go", file, make([]byte, 4<<20), ""), 2*codeStatsSampleChunk)
+	assert.Nil(t, s.codeStatsSample("/repo/logo.png", file, nil, skipReasonType))
+}
+
+func TestJoinSample(t *testing.T) {
+	assert.Equal(t, "a\nb\nd\ne", string(joinSample([]byte("a\nb\nc"), []byte("x\nd\ne"))))
+	assert.Equal(t, "minifiedtail", string(joinSample([]byte("minified"), []byte("tail"))))
+}
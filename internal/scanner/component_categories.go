@@ -1,18 +1,40 @@
 package scanner
 
-import "github.com/petrarca/tech-stack-analyzer/internal/types"
+import (
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
 
 // ComponentTypes defines which technology types should create components vs just be listed as dependencies
 // This classification determines whether a detected technology appears in the 'tech' field (primary technologies)
 // or only in the 'techs' array (all technologies including tools/libraries)
 
-var categoriesConfig *types.CategoriesConfig
+var (
+	categoriesConfig *types.CategoriesConfig
+	categoriesOnce   sync.Once
+	categoriesErr    error
+)
 
 // SetCategoriesConfig sets the global categories configuration
 func SetCategoriesConfig(config *types.CategoriesConfig) {
 	categoriesConfig = config
 }
 
+// loadCategoriesConfig sets the global categories configuration from the
+// embedded categories.yaml the first time a scanner is created, so scanners
+// created concurrently (org and daemon scans) do not race on it.
+func loadCategoriesConfig() error {
+	categoriesOnce.Do(func() {
+		var loaded *types.CategoriesConfig
+		if loaded, categoriesErr = config.LoadCategoriesConfig(); categoriesErr == nil {
+			SetCategoriesConfig(loaded)
+		}
+	})
+	return categoriesErr
+}
+
 // ShouldCreateComponent determines if a rule should create a component
 // Returns true if component should be created, false otherwise
 func ShouldCreateComponent(rule types.Rule) bool {
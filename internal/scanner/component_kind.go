@@ -0,0 +1,198 @@
+package scanner
+
+import (
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Component kinds, the values of the component_type field.
+const (
+	ComponentKindService  = "service"
+	ComponentKindCLI      = "cli"
+	ComponentKindLibrary  = "library"
+	ComponentKindFrontend = "frontend"
+	ComponentKindInfra    = "infra"
+)
+
+// infraLanguages are the programming languages of infrastructure code.
+var infraLanguages = map[string]bool{"HCL": true, "Bicep": true}
+
+// artifactEvidence is what the files of a component say it builds.
+type artifactEvidence struct {
+	executable []string
+	library    []string
+	container  []string
+}
+
+// recordArtifact collects the evidence of what a component builds:
+// executables, libraries and container images. Only the files in the
+// component's own folder count, so that a manifest not making a component
+// of its own says nothing about the component above it.
+func (s *Scanner) recordArtifact(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	if path.Dir(rel) != componentDir(ctx) {
+		return
+	}
+	evidence, ok := parsers.NewComponentKindParser().ParseArtifact(rel, content)
+	if !ok {
+		return
+	}
+	if s.artifacts == nil {
+		s.artifacts = make(map[*types.Payload]*artifactEvidence)
+	}
+	recorded := s.artifacts[ctx]
+	if recorded == nil {
+		recorded = &artifactEvidence{}
+		s.artifacts[ctx] = recorded
+	}
+	recorded.executable = appendNonEmpty(recorded.executable, evidence.Executable)
+	recorded.library = appendNonEmpty(recorded.library, evidence.Library)
+	recorded.container = appendNonEmpty(recorded.container, evidence.Container)
+}
+
+// componentDir returns the folder of a component's manifest, "/" for the
+// root.
+func componentDir(p *types.Payload) string {
+	if manifest := p.ComponentPath(); manifest != "" {
+		return path.Dir(manifest)
+	}
+	return "/"
+}
+
+func appendNonEmpty(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	return appendUnique(values, value)
+}
+
+// classifyComponents sets the component_type of every component the
+// evidence is conclusive for, with the reason for it.
+func (s *Scanner) classifyComponents(payload *types.Payload) {
+	techTypes := make(map[string]string)
+	for _, rule := range s.rules {
+		if rule.Type != "ui" || ShouldAddPrimaryTech(rule) {
+			techTypes[rule.Tech] = rule.Type
+		}
+	}
+	walkPayloads(payload, func(p *types.Payload) {
+		if kind, reason := s.componentKind(p, techTypes); kind != "" {
+			p.Kind = kind
+			p.AddReason("component type " + kind + ": " + reason)
+		}
+	})
+}
+
+// componentKind classifies a component from, in order: a server framework
+// packaged as a container or not published as a library (service), a
+// command or program entry point (cli), a web UI framework (frontend), a
+// published package (library) and infrastructure code alone (infra).
+func (s *Scanner) componentKind(p *types.Payload, techTypes map[string]string) (string, string) {
+	evidence := s.componentArtifacts(p)
+	fullstack := componentTechOfType(p, techTypes, "fullstack_framework")
+	if reason := serviceReason(componentTechOfType(p, techTypes, "backend_framework", "appserver"), fullstack, evidence); reason != "" {
+		return ComponentKindService, reason
+	}
+	if len(evidence.executable) > 0 {
+		return ComponentKindCLI, evidence.executable[0]
+	}
+	if frontend := firstNonEmptyString(fullstack, componentTechOfType(p, techTypes, "web_framework", "ui")); frontend != "" {
+		return ComponentKindFrontend, frontend
+	}
+	if len(evidence.library) > 0 {
+		return ComponentKindLibrary, evidence.library[0]
+	}
+	if language := infraLanguage(p); language != "" {
+		return ComponentKindInfra, "only " + language + " code"
+	}
+	return "", ""
+}
+
+// serviceReason returns why a component with the server or full-stack
+// framework is a service: it is packaged as a container image, or, for a
+// server framework, it is not published as a library either.
+func serviceReason(server, fullstack string, evidence artifactEvidence) string {
+	framework := firstNonEmptyString(server, fullstack)
+	switch {
+	case framework != "" && len(evidence.container) > 0:
+		return evidence.container[0] + " and " + framework
+	case server != "" && len(evidence.library) == 0:
+		return server
+	}
+	return ""
+}
+
+// componentArtifacts returns the evidence recorded for a component, with
+// the targets of Rust crates and the main packages of child components of
+// its ecosystem, such as the commands under cmd/ of a Go module.
+func (s *Scanner) componentArtifacts(p *types.Payload) artifactEvidence {
+	evidence := artifactEvidence{}
+	if recorded := s.artifacts[p]; recorded != nil {
+		evidence = *recorded
+	}
+	rust, _ := p.Properties["rust"].(map[string]interface{})
+	targets, _ := rust["targets"].([]parsers.CargoArtifact)
+	for _, target := range targets {
+		if target.Kind == "bin" {
+			evidence.executable = appendUnique(slices.Clone(evidence.executable), "Cargo bin target "+target.Name)
+		} else {
+			evidence.library = appendUnique(slices.Clone(evidence.library), "Cargo lib target "+target.Name)
+		}
+	}
+	if len(evidence.executable) > 0 || p.ComponentType == "" {
+		return evidence
+	}
+	for _, child := range p.Children {
+		if recorded := s.artifacts[child]; recorded != nil && child.ComponentType == p.ComponentType && len(recorded.executable) > 0 {
+			evidence.executable = []string{recorded.executable[0] + " in " + child.Name}
+			break
+		}
+	}
+	return evidence
+}
+
+// componentTechOfType returns the first primary tech, else tech, of the
+// component whose rule has one of the types.
+func componentTechOfType(p *types.Payload, techTypes map[string]string, ruleTypes ...string) string {
+	for _, tech := range slices.Concat(p.Tech, p.Techs) {
+		if slices.Contains(ruleTypes, techTypes[tech]) {
+			return tech
+		}
+	}
+	return ""
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// infraLanguage returns the infrastructure language of a component whose
+// only programming language it is.
+func infraLanguage(p *types.Payload) string {
+	infra := ""
+	for _, language := range slices.Sorted(maps.Keys(p.Languages)) {
+		if enry.GetLanguageType(language) != enry.Programming {
+			continue
+		}
+		if !infraLanguages[language] {
+			return ""
+		}
+		infra = language
+	}
+	return infra
+}
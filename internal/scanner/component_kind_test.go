@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyComponents(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Dockerfile", "FROM node:20\nCMD [\"node\", \"server.js\"]\n")
+	write("web/package.json", `{"name": "web", "private": true, "dependencies": {"react": "^18.2.0"}}`)
+	write("lib/package.json", `{"name": "@myorg/lib", "main": "index.js", "dependencies": {"lodash": "^4.17.21"}}`)
+	write("tool/package.json", `{"name": "tool", "bin": {"tool": "./cli.js"}, "dependencies": {"commander": "^11.0.0"}}`)
+	write("gocli/go.mod", "module example.com/gocli\n\ngo 1.22\n")
+	write("gocli/cmd/main.go", "package main\n\nfunc main() {}\n")
+	write("infra/main.tf", "resource \"aws_s3_bucket\" \"assets\" {}\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	kinds := make(map[string]string)
+	walkPayloads(result, func(p *types.Payload) {
+		if p.Kind != "" {
+			kinds[p.Name] = p.Kind
+		}
+	})
+	// The root's own files are the Terraform configuration.
+	assert.Equal(t, map[string]string{
+		"main":       ComponentKindInfra,
+		"api":        ComponentKindService,
+		"web":        ComponentKindFrontend,
+		"@myorg/lib": ComponentKindLibrary,
+		"tool":       ComponentKindCLI,
+		"gocli":      ComponentKindCLI,
+		"cmd":        ComponentKindCLI,
+	}, kinds)
+
+	for _, child := range result.Children {
+		if child.Name == "api" {
+			assert.Contains(t, child.Reason["_"], "component type service: Dockerfile and express")
+		}
+	}
+}
+
+func TestComponentKindInfra(t *testing.T) {
+	p := types.NewPayloadWithPath("main", "/")
+	p.Languages = map[string]int{"HCL": 4, "Markdown": 1}
+	kind, reason := (&Scanner{}).componentKind(p, nil)
+	assert.Equal(t, ComponentKindInfra, kind)
+	assert.Equal(t, "only HCL code", reason)
+
+	p.Languages["Shell"] = 1
+	kind, _ = (&Scanner{}).componentKind(p, nil)
+	assert.Empty(t, kind)
+}
@@ -1,6 +1,8 @@
 package scanner
 
 import (
+	"maps"
+
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
@@ -59,10 +61,13 @@ func (s *Scanner) resolveComponentRefs(root *types.Payload) {
 	s.resolveComponentRefsRecursive(root, registry)
 }
 
-// resolveComponentRefsRecursive walks the tree and resolves component references
+// resolveComponentRefsRecursive walks the tree and resolves component references.
+// A dependency provided by another component of the scan, such as a workspace
+// package or a module of the same Maven build, is marked internal and links
+// the two components with an edge.
 func (s *Scanner) resolveComponentRefsRecursive(payload *types.Payload, registry *ComponentRegistry) {
 	// Resolve dependencies for current component
-	for _, dep := range payload.Dependencies {
+	for i, dep := range payload.Dependencies {
 		// Try to find a matching component
 		targetComponent := s.findMatchingComponent(dep, registry)
 		if targetComponent != nil && targetComponent.ID != payload.ID {
@@ -72,6 +77,10 @@ func (s *Scanner) resolveComponentRefsRecursive(payload *types.Payload, registry
 				PackageName: dep.Name,
 			}
 			payload.ComponentRefs = append(payload.ComponentRefs, compRef)
+			markInternalDependency(&payload.Dependencies[i])
+			if !hasEdgeTo(payload, targetComponent) {
+				payload.AddEdges(targetComponent)
+			}
 		}
 	}
 
@@ -112,3 +121,15 @@ func (s *Scanner) findMatchingComponent(dep types.Dependency, registry *Componen
 
 	return nil
 }
+
+// markInternalDependency flags a dependency provided by a component of the
+// scan with metadata.internal. The metadata map is copied first, as parsers
+// may share one between dependencies.
+func markInternalDependency(dep *types.Dependency) {
+	metadata := maps.Clone(dep.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata["internal"] = true
+	dep.Metadata = metadata
+}
@@ -1,6 +1,8 @@
 package scanner
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
@@ -96,3 +98,44 @@ func TestComponentRegistryNpmNodejs(t *testing.T) {
 	require.NotNil(t, found, "Expected to find my-awesome-package in registry")
 	require.Equal(t, "pkg-id", found.ID)
 }
+
+func TestResolveComponentRefsLinksInternalDependencies(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("packages/api/package.json", `{"name": "@myorg/api", "dependencies": {"@myorg/shared": "workspace:*", "express": "^4.18.0"}}`)
+	write("packages/shared/package.json", `{"name": "@myorg/shared", "dependencies": {"lodash": "^4.17.21"}}`)
+
+	s, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := s.Scan()
+	require.NoError(t, err)
+
+	var api, shared *types.Payload
+	var walk func(p *types.Payload)
+	walk = func(p *types.Payload) {
+		switch p.Name {
+		case "@myorg/api":
+			api = p
+		case "@myorg/shared":
+			shared = p
+		}
+		for _, child := range p.Children {
+			walk(child)
+		}
+	}
+	walk(result)
+	require.NotNil(t, api)
+	require.NotNil(t, shared)
+
+	internal := map[string]bool{}
+	for _, dep := range api.Dependencies {
+		internal[dep.Name] = dep.Metadata["internal"] == true
+	}
+	require.Equal(t, map[string]bool{"@myorg/shared": true, "express": false}, internal)
+	require.True(t, hasEdgeTo(api, shared), "expected an edge from api to shared")
+	require.False(t, hasEdgeTo(shared, api))
+}
@@ -0,0 +1,112 @@
+// Package archive implements inspection of vendored binary archives (jar/war/ear,
+// Python wheels, NuGet packages) as a plugin-based component detector.
+package archive
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxArchiveSize bounds the archives that are read into memory. Larger files
+// (installers, full distributions) are skipped.
+const maxArchiveSize = 128 << 20 // 128 MiB
+
+var normalizer = license.NewNormalizer()
+
+// Detector implements binary archive inspection. It is opt-in
+// (components.InspectArchives) because it reads binary files that the rest of
+// the scan ignores.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string {
+	return "archive"
+}
+
+// Detect opens the archives in the current directory and reports the packages
+// embedded in them as dependencies of the enclosing component. Returns a
+// virtual component (merged into parent) when at least one archive yields
+// package metadata.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	if !components.InspectArchives() {
+		return nil
+	}
+
+	var payload *types.Payload
+	parser := parsers.NewArchiveParser()
+	for _, file := range files {
+		if parsers.ArchiveFormat(file.Name) == "" || file.Size > maxArchiveSize {
+			continue
+		}
+		info := d.inspect(parser, file, currentPath, basePath, provider)
+		if info == nil {
+			continue
+		}
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", info.File)
+		}
+		addArchive(payload, info, depDetector)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+// inspect reads and parses a single archive, returning nil when it cannot be
+// read or carries no package metadata.
+func (d *Detector) inspect(parser *parsers.ArchiveParser, file types.File, currentPath, basePath string, provider types.Provider) *parsers.ArchiveInfo {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	info, err := parser.ParseArchive(file.Name, content)
+	if err != nil || info == nil {
+		return nil
+	}
+	info.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+	return info
+}
+
+// addArchive records an inspected archive on the payload: each embedded
+// package becomes a dependency sourced from the archive, matched against the
+// ecosystem's rules, and the archive itself is listed under
+// properties.archives.
+func addArchive(payload *types.Payload, info *parsers.ArchiveInfo, depDetector components.DependencyDetector) {
+	names := make([]string, 0, len(info.Packages))
+	for _, pkg := range info.Packages {
+		dep := types.Dependency{
+			Type:     info.Ecosystem,
+			Name:     pkg.Name,
+			Version:  pkg.Version,
+			Scope:    types.ScopeProd,
+			Direct:   pkg.Nested == "",
+			Metadata: types.NewMetadata(filepath.Base(info.File)),
+		}
+		if pkg.Nested != "" {
+			dep.Metadata["nested"] = pkg.Nested
+		}
+		if pkg.License != "" {
+			license.SetDependencyLicense(&dep, normalizer.Normalize(pkg.License))
+		}
+		payload.AddDependency(dep)
+		names = append(names, pkg.Name)
+	}
+
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, info.Ecosystem))
+
+	if existing, ok := payload.Properties["archives"].([]interface{}); ok {
+		payload.Properties["archives"] = append(existing, info)
+	} else {
+		payload.Properties["archives"] = []interface{}{info}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
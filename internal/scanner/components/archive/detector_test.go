@@ -0,0 +1,173 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	result := make(map[string][]string)
+	for _, dep := range dependencies {
+		if depType == "maven" && dep == "org.springframework:spring-core" {
+			result["spring"] = append(result["spring"], "matched dependency: "+dep)
+		}
+	}
+	return result
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func buildZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func withInspectArchives(t *testing.T, enable bool) {
+	t.Helper()
+	prev := components.InspectArchives()
+	components.SetInspectArchives(enable)
+	t.Cleanup(func() { components.SetInspectArchives(prev) })
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "archive", (&Detector{}).Name())
+}
+
+func TestDetector_Disabled(t *testing.T) {
+	withInspectArchives(t, false)
+
+	jar := buildZip(t, map[string]string{
+		"META-INF/maven/org.springframework/spring-core/pom.properties": "groupId=org.springframework\nartifactId=spring-core\nversion=6.1.0\n",
+	})
+	provider := &MockProvider{files: map[string][]byte{"/project/lib/spring-core-6.1.0.jar": jar}}
+	files := []types.File{{Name: "spring-core-6.1.0.jar", Size: int64(len(jar))}}
+
+	assert.Nil(t, (&Detector{}).Detect(files, "/project/lib", "/project", provider, &MockDependencyDetector{}))
+}
+
+func TestDetector_VendoredArchives(t *testing.T) {
+	withInspectArchives(t, true)
+
+	jar := buildZip(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nBundle-License: Apache-2.0\n",
+		"META-INF/maven/org.springframework/spring-core/pom.properties": "groupId=org.springframework\nartifactId=spring-core\nversion=6.1.0\n",
+	})
+	wheel := buildZip(t, map[string]string{
+		"mylib-0.3.0.dist-info/METADATA": "Metadata-Version: 2.1\nName: mylib\nVersion: 0.3.0\nLicense-Expression: MIT\n",
+	})
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/lib/spring-core-6.1.0.jar":        jar,
+		"/project/lib/mylib-0.3.0-py3-none-any.whl": wheel,
+		"/project/lib/broken.jar":                   []byte("not a zip"),
+		"/project/lib/README.md":                    []byte("# vendored"),
+	}}
+	files := []types.File{
+		{Name: "spring-core-6.1.0.jar", Size: int64(len(jar))},
+		{Name: "mylib-0.3.0-py3-none-any.whl", Size: int64(len(wheel))},
+		{Name: "broken.jar", Size: 9},
+		{Name: "README.md", Size: 10},
+	}
+
+	results := (&Detector{}).Detect(files, "/project/lib", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "spring")
+	require.Len(t, payload.Dependencies, 2)
+
+	core := payload.Dependencies[0]
+	assert.Equal(t, "maven", core.Type)
+	assert.Equal(t, "org.springframework:spring-core", core.Name)
+	assert.Equal(t, "6.1.0", core.Version)
+	assert.True(t, core.Direct)
+	assert.Equal(t, "spring-core-6.1.0.jar", core.Metadata["source"])
+	assert.Equal(t, "Apache-2.0", license.DependencyLicense(core))
+
+	wheelDep := payload.Dependencies[1]
+	assert.Equal(t, "pypi", wheelDep.Type)
+	assert.Equal(t, "mylib", wheelDep.Name)
+	assert.Equal(t, "MIT", license.DependencyLicense(wheelDep))
+
+	archives, ok := payload.Properties["archives"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, archives, 2)
+	info := archives[0].(*parsers.ArchiveInfo)
+	assert.Equal(t, "/lib/spring-core-6.1.0.jar", info.File)
+	assert.Equal(t, "jar", info.Format)
+}
+
+func TestDetector_SkipsOversizedArchives(t *testing.T) {
+	withInspectArchives(t, true)
+
+	provider := &MockProvider{files: map[string][]byte{}}
+	files := []types.File{{Name: "huge.war", Size: maxArchiveSize + 1}}
+
+	assert.Nil(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
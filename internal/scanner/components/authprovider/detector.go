@@ -0,0 +1,102 @@
+// Package authprovider implements identity provider configuration detection
+// (Keycloak, Auth0, Okta, Cognito, Firebase Auth) as a plugin-based
+// component detector.
+package authprovider
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// appConfigRegex matches application configuration files that commonly
+	// hold OIDC issuer URLs (Spring application.yml, .NET appsettings.json).
+	appConfigRegex = regexp.MustCompile(`^(application(-[\w-]+)?\.(ya?ml|properties)|appsettings(\.[\w-]+)?\.json)$`)
+	// realmExportRegex matches Keycloak realm export file names.
+	realmExportRegex = regexp.MustCompile(`(?i)^([\w-]+-)?realm(-export)?\.json$`)
+)
+
+// Detector implements identity provider configuration detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "authprovider"
+}
+
+// Detect finds identity provider evidence in configuration: issuer URLs and
+// tenant domains in dotenv and application config files, Keycloak adapter
+// configs and realm exports. Each provider found is added as a tech and its
+// evidence (provider host, realm, file) is recorded under
+// properties.auth_evidence, which the scanner folds into the component's
+// security section. Returns a virtual component (merged into parent) when
+// evidence is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	for _, file := range files {
+		if !isCandidate(file.Name) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		evidence := findEvidence(file.Name, content)
+		if len(evidence) == 0 {
+			continue
+		}
+
+		relFile := types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", relFile)
+		}
+		for _, ev := range evidence {
+			ev.File = relFile
+			payload.AddTech(ev.Provider, "matched file: "+relFile)
+			addEvidence(payload, ev)
+		}
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+func isCandidate(name string) bool {
+	return name == ".env" || strings.HasPrefix(name, ".env.") || name == "keycloak.json" ||
+		appConfigRegex.MatchString(name) || realmExportRegex.MatchString(name)
+}
+
+// findEvidence extracts the identity provider references of one file.
+func findEvidence(name string, content []byte) []parsers.AuthEvidence {
+	if name == "keycloak.json" {
+		if ev, ok := parsers.ParseKeycloakAdapterConfig(content); ok {
+			return []parsers.AuthEvidence{ev}
+		}
+		return nil
+	}
+	if realmExportRegex.MatchString(name) {
+		if realm, ok := parsers.ParseKeycloakRealm(content); ok {
+			return []parsers.AuthEvidence{{Provider: parsers.AuthProviderKeycloak, Realm: realm}}
+		}
+	}
+	return parsers.FindAuthProviders(string(content))
+}
+
+func addEvidence(payload *types.Payload, ev parsers.AuthEvidence) {
+	if existing, ok := payload.Properties["auth_evidence"].([]interface{}); ok {
+		payload.Properties["auth_evidence"] = append(existing, ev)
+	} else {
+		payload.Properties["auth_evidence"] = []interface{}{ev}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,98 @@
+package authprovider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "authprovider", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.env.example":       []byte("AUTH0_DOMAIN=myapp.us.auth0.com\nPORT=3000\n"),
+		"/project/application.yml":    []byte("spring:\n  security:\n    oauth2:\n      resourceserver:\n        jwt:\n          issuer-uri: https://sso.example.com/realms/myrealm\n"),
+		"/project/myrealm-realm.json": []byte(`{"realm": "myrealm", "clients": []}`),
+		"/project/config.yml":         []byte("issuer: https://dev-1.okta.com\n"),
+	}}
+	files := []types.File{{Name: ".env.example"}, {Name: "application.yml"}, {Name: "myrealm-realm.json"}, {Name: "config.yml"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.ElementsMatch(t, []string{"auth0", "keycloak"}, payload.Techs)
+	assert.Contains(t, payload.Reason["auth0"], "matched file: /.env.example")
+
+	evidence, ok := payload.Properties["auth_evidence"].([]interface{})
+	require.True(t, ok)
+	assert.ElementsMatch(t, []interface{}{
+		parsers.AuthEvidence{Provider: "auth0", Host: "myapp.us.auth0.com", File: "/.env.example"},
+		parsers.AuthEvidence{Provider: "keycloak", Host: "sso.example.com", Realm: "myrealm", File: "/application.yml"},
+		parsers.AuthEvidence{Provider: "keycloak", Realm: "myrealm", File: "/myrealm-realm.json"},
+	}, evidence)
+}
+
+func TestDetector_NoEvidence(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/project/.env": []byte("PORT=3000\n")}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: ".env"}}, "/project", "/project", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,199 @@
+// Package cms detects CMS installations (WordPress, Drupal) and reports their
+// installed plugins, themes and modules as dependencies of the CMS component.
+package cms
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// cmsNames are the component names of the CMS components.
+var cmsNames = map[string]string{
+	parsers.CMSWordPress: "WordPress",
+	parsers.CMSDrupal:    "Drupal",
+}
+
+// Detector implements CMS installation detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "cms" }
+
+// Detect finds composer-managed Drupal and WordPress (Bedrock) sites from
+// composer.json, and classic WordPress installs from their wp-content
+// directory. Extensions carry a kind (plugin, theme, module, ...) in
+// metadata.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewCMSParser()
+	for _, file := range files {
+		if file.Name == "composer.json" {
+			if payload := d.detectComposerSite(currentPath, basePath, provider, depDetector, parser); payload != nil {
+				return []*types.Payload{payload}
+			}
+		}
+	}
+	for _, file := range files {
+		if file.Name == "wp-content" && file.Type == "dir" {
+			if payload := d.detectWordPress(currentPath, basePath, provider, depDetector, parser); payload != nil {
+				return []*types.Payload{payload}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Detector) detectComposerSite(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector, parser *parsers.CMSParser) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "composer.json"))
+	if err != nil {
+		return nil
+	}
+	var lock []byte
+	if components.UseLockFiles() {
+		lock, _ = provider.ReadFile(filepath.Join(currentPath, "composer.lock"))
+	}
+	site := parser.ParseComposerSite(content, lock)
+	if site == nil {
+		return nil
+	}
+	payload := newCMSPayload(site.CMS, types.CalculateRelativePath("composer.json", currentPath, basePath), site.CoreVersion)
+	applyDependencies(payload, site.Dependencies, parsers.DependencyTypePHP, depDetector)
+	return payload
+}
+
+func (d *Detector) detectWordPress(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector, parser *parsers.CMSParser) *types.Payload {
+	coreVersion := ""
+	if content, err := provider.ReadFile(filepath.Join(currentPath, "wp-includes", "version.php")); err == nil {
+		coreVersion = parser.ParseWordPressVersion(string(content))
+	}
+	contentDir := filepath.Join(currentPath, "wp-content")
+	var deps []types.Dependency
+	deps = append(deps, plugins(filepath.Join(contentDir, "plugins"), "plugin", provider, parser)...)
+	deps = append(deps, plugins(filepath.Join(contentDir, "mu-plugins"), "mu-plugin", provider, parser)...)
+	deps = append(deps, themes(filepath.Join(contentDir, "themes"), provider, parser)...)
+	if coreVersion == "" && len(deps) == 0 {
+		return nil
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+
+	payload := newCMSPayload(parsers.CMSWordPress, types.CalculateRelativePath("wp-content", currentPath, basePath), coreVersion)
+	applyDependencies(payload, deps, parsers.DependencyTypeWordPress, depDetector)
+	return payload
+}
+
+func newCMSPayload(cms, path, coreVersion string) *types.Payload {
+	payload := types.NewPayloadWithPath(cmsNames[cms], path)
+	payload.SetComponentType(cms)
+	payload.AddPrimaryTech(cms)
+	if coreVersion != "" {
+		payload.SetComponentProperty(cms, "core_version", coreVersion)
+	}
+	return payload
+}
+
+func applyDependencies(payload *types.Payload, deps []types.Dependency, depType string, depDetector components.DependencyDetector) {
+	if len(deps) == 0 {
+		return
+	}
+	names := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, depType))
+	payload.Dependencies = deps
+}
+
+// plugins lists the plugins of a plugins directory: subdirectories whose
+// main PHP file has a "Plugin Name" header, and single-file plugins.
+func plugins(dir, kind string, provider types.Provider, parser *parsers.CMSParser) []types.Dependency {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	var deps []types.Dependency
+	for _, entry := range entries {
+		var header map[string]string
+		slug := entry.Name
+		if entry.Type == "dir" {
+			header = pluginHeader(filepath.Join(dir, entry.Name), entry.Name, provider, parser)
+		} else if strings.HasSuffix(entry.Name, ".php") {
+			slug = strings.TrimSuffix(entry.Name, ".php")
+			header = readHeader(filepath.Join(dir, entry.Name), "Plugin Name", provider, parser)
+		}
+		if header != nil {
+			deps = append(deps, extensionDependency(slug, kind, header["Plugin Name"], header))
+		}
+	}
+	return deps
+}
+
+// pluginHeader returns the header of a plugin directory's main file:
+// <slug>.php by convention, else the first PHP file with a Plugin Name.
+func pluginHeader(dir, slug string, provider types.Provider, parser *parsers.CMSParser) map[string]string {
+	if header := readHeader(filepath.Join(dir, slug+".php"), "Plugin Name", provider, parser); header != nil {
+		return header
+	}
+	entries, _ := provider.ListDir(dir)
+	for _, entry := range entries {
+		if entry.Type != "dir" && strings.HasSuffix(entry.Name, ".php") && entry.Name != slug+".php" {
+			if header := readHeader(filepath.Join(dir, entry.Name), "Plugin Name", provider, parser); header != nil {
+				return header
+			}
+		}
+	}
+	return nil
+}
+
+// themes lists the theme directories whose style.css has a "Theme Name"
+// header.
+func themes(dir string, provider types.Provider, parser *parsers.CMSParser) []types.Dependency {
+	entries, err := provider.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	var deps []types.Dependency
+	for _, entry := range entries {
+		if entry.Type != "dir" {
+			continue
+		}
+		if header := readHeader(filepath.Join(dir, entry.Name, "style.css"), "Theme Name", provider, parser); header != nil {
+			deps = append(deps, extensionDependency(entry.Name, "theme", header["Theme Name"], header))
+		}
+	}
+	return deps
+}
+
+// readHeader returns the file header when it has the required field.
+func readHeader(path, required string, provider types.Provider, parser *parsers.CMSParser) map[string]string {
+	content, err := provider.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	header := parser.ParseWordPressHeader(string(content))
+	if header[required] == "" {
+		return nil
+	}
+	return header
+}
+
+func extensionDependency(slug, kind, title string, header map[string]string) types.Dependency {
+	dep := types.Dependency{
+		Type:     parsers.DependencyTypeWordPress,
+		Name:     slug,
+		Version:  header["Version"],
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: types.NewMetadata("wp-content"),
+	}
+	dep.Metadata["kind"] = kind
+	dep.Metadata["title"] = title
+	return dep
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,195 @@
+package cms
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// ListDir lists the direct children of path: files, and directories for
+// deeper paths.
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	seen := make(map[string]bool)
+	var files []types.File
+	for name := range m.files {
+		rel, err := filepath.Rel(path, name)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		first, rest, nested := strings.Cut(filepath.ToSlash(rel), "/")
+		if seen[first] || rest == "" && nested {
+			continue
+		}
+		seen[first] = true
+		fileType := "file"
+		if nested {
+			fileType = "dir"
+		}
+		files = append(files, types.File{Name: first, Type: fileType})
+	}
+	return files, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Detect_WordPressInstall(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/site/wp-includes/version.php": []byte("<?php\n$wp_version = '6.4.2';\n$wp_db_version = 56657;\n"),
+		"/site/wp-content/plugins/akismet/akismet.php": []byte(`<?php
+/**
+ * @package Akismet
+ */
+/*
+Plugin Name: Akismet Anti-spam: Spam Protection
+Version: 5.3
+Author: Automattic
+*/
+`),
+		"/site/wp-content/plugins/woocommerce/woocommerce.php": []byte(`<?php
+/**
+ * Plugin Name: WooCommerce
+ * Version: 8.4.0
+ */
+`),
+		"/site/wp-content/plugins/contact-form/main.php": []byte("<?php\n/*\n * Plugin Name: Contact Form\n * Version: 1.0.1\n */\n"),
+		"/site/wp-content/plugins/hello.php":             []byte("<?php\n/*\nPlugin Name: Hello Dolly\nVersion: 1.7.2\n*/\n"),
+		"/site/wp-content/plugins/index.php":             []byte("<?php\n// Silence is golden.\n"),
+		"/site/wp-content/themes/twentytwentyfour/style.css": []byte(`/*
+Theme Name: Twenty Twenty-Four
+Version: 1.0
+*/
+`),
+		"/site/wp-content/themes/index.php": []byte("<?php\n"),
+	}}
+	files := []types.File{
+		{Name: "wp-config.php", Type: "file"},
+		{Name: "wp-content", Type: "dir"},
+		{Name: "wp-includes", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/site", "/site", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "WordPress", payload.Name)
+	assert.Equal(t, "wordpress", payload.ComponentType)
+	assert.Equal(t, "6.4.2", payload.Properties["wordpress"].(map[string]interface{})["core_version"])
+
+	got := make(map[string]string)
+	for _, dep := range payload.Dependencies {
+		assert.Equal(t, "wordpress", dep.Type)
+		got[dep.Name] = dep.Version + " " + dep.Metadata["kind"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		"akismet":          "5.3 plugin",
+		"woocommerce":      "8.4.0 plugin",
+		"contact-form":     "1.0.1 plugin",
+		"hello":            "1.7.2 plugin",
+		"twentytwentyfour": "1.0 theme",
+	}, got)
+}
+
+func TestDetector_Detect_DrupalComposer(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/site/composer.json": []byte(`{
+  "name": "myorg/mysite",
+  "type": "project",
+  "require": {
+    "composer/installers": "^2.0",
+    "drupal/core-recommended": "^10.2",
+    "drupal/core-composer-scaffold": "^10.2",
+    "drupal/admin_toolbar": "^3.4",
+    "drupal/gin": "^3.0",
+    "drush/drush": "^12"
+  }
+}`),
+		"/site/composer.lock": []byte(`{"packages": [
+  {"name": "drupal/core", "version": "10.2.1", "type": "drupal-core"},
+  {"name": "drupal/core-recommended", "version": "10.2.1", "type": "metapackage"},
+  {"name": "drupal/core-composer-scaffold", "version": "10.2.1", "type": "composer-plugin"},
+  {"name": "drupal/admin_toolbar", "version": "3.4.2", "type": "drupal-module"},
+  {"name": "drupal/gin", "version": "3.0.0-rc8", "type": "drupal-theme"},
+  {"name": "drupal/gin_toolbar", "version": "1.0.0-rc5", "type": "drupal-module"},
+  {"name": "drush/drush", "version": "12.4.3", "type": "library"}
+]}`),
+	}}
+	files := []types.File{{Name: "composer.json", Type: "file"}, {Name: "composer.lock", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/site", "/site", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "Drupal", payload.Name)
+	assert.Equal(t, "/composer.json", payload.Path[0])
+	assert.Equal(t, "10.2.1", payload.Properties["drupal"].(map[string]interface{})["core_version"])
+
+	var got []string
+	for _, dep := range payload.Dependencies {
+		got = append(got, dep.Name+"@"+dep.Version+" "+dep.Metadata["kind"].(string))
+	}
+	assert.Equal(t, []string{
+		"drupal/admin_toolbar@3.4.2 module",
+		"drupal/gin@3.0.0-rc8 theme",
+		"drupal/gin_toolbar@1.0.0-rc5 module",
+	}, got)
+	assert.False(t, payload.Dependencies[2].Direct)
+}
+
+func TestDetector_Detect_PlainComposerProject(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/app/composer.json": []byte(`{"name": "myorg/myapp", "require": {"laravel/framework": "^10.0"}}`),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "composer.json", Type: "file"}}, "/app", "/app", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
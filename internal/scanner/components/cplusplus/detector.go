@@ -11,17 +11,26 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
-// Detector implements C++ component detection via conanfile.py and .vcxproj
+// Detector implements C++ component detection via conanfile.py, the native
+// build and package manifests (CMake, Meson, conanfile.txt, vcpkg) and .vcxproj
 type Detector struct{}
 
 func (d *Detector) Name() string {
 	return "cpp"
 }
 
-// Detect scans for C++ projects with conanfile.py or .vcxproj files
+// Detect scans for C++ projects with conanfile.py, native build manifests or
+// .vcxproj files
 func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
 	var payloads []*types.Payload
 	payloads = append(payloads, d.detectConanProjects(files, currentPath, basePath, provider, depDetector)...)
+	var conan *types.Payload
+	if len(payloads) > 0 {
+		conan = payloads[0]
+	}
+	if native := d.detectNativeBuild(files, currentPath, basePath, provider, depDetector, conan); native != nil {
+		payloads = append(payloads, native)
+	}
 	payloads = append(payloads, d.detectVcxprojProjects(files, currentPath, basePath, provider, depDetector)...)
 	return payloads
 }
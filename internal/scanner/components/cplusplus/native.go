@@ -0,0 +1,186 @@
+package cplusplus
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// nativeManifest is the manifest of a C/C++ build system or package manager:
+// the tech it stands for and the type of the dependencies it declares.
+type nativeManifest struct {
+	file    string
+	tech    string
+	depType string
+	parse   func(content []byte) nativeProject
+}
+
+// nativeProject is what a native manifest declares. component reports
+// whether the manifest makes its directory a component.
+type nativeProject struct {
+	name         string
+	properties   map[string]interface{}
+	dependencies []types.Dependency
+	component    bool
+}
+
+// nativeManifests are read in this order; the first one making a component
+// names it.
+var nativeManifests = []nativeManifest{
+	{file: "CMakeLists.txt", tech: "cmake", depType: parsers.DependencyTypeCMake, parse: parseCMakeManifest},
+	{file: "meson.build", tech: "meson", depType: parsers.DependencyTypeMeson, parse: parseMesonManifest},
+	{file: "conanfile.txt", tech: "conan", depType: parsers.DependencyTypeConan, parse: parseConanfileTxt},
+	{file: "vcpkg.json", tech: "vcpkg", depType: parsers.DependencyTypeVcpkg, parse: parseVcpkgManifest},
+}
+
+// parsedManifest is a native manifest found in a directory with its content.
+type parsedManifest struct {
+	manifest nativeManifest
+	project  nativeProject
+}
+
+// detectNativeBuild reports the C/C++ project a directory holds a
+// CMakeLists.txt with a project(), a meson.build with a project(), a
+// conanfile.txt or a vcpkg.json for, with the dependencies of all of them.
+// When a conanfile.py component was detected in the directory, the
+// manifests are merged into it and no component is returned.
+func (d *Detector) detectNativeBuild(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector, conan *types.Payload) *types.Payload {
+	found := readNativeManifests(files, currentPath, provider)
+	payload := conan
+	if payload == nil {
+		for _, parsed := range found {
+			if parsed.project.component {
+				payload = newNativePayload(parsed, currentPath, basePath)
+				break
+			}
+		}
+	}
+	if payload == nil {
+		return nil
+	}
+	for _, parsed := range found {
+		addNativeManifest(payload, parsed, depDetector)
+	}
+	applyConanLock(payload, currentPath, provider)
+	if payload == conan {
+		return nil
+	}
+	return payload
+}
+
+func readNativeManifests(files []types.File, currentPath string, provider types.Provider) []parsedManifest {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file.Name] = true
+	}
+	var found []parsedManifest
+	for _, manifest := range nativeManifests {
+		if !present[manifest.file] {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, manifest.file))
+		if err != nil {
+			continue
+		}
+		found = append(found, parsedManifest{manifest: manifest, project: manifest.parse(content)})
+	}
+	return found
+}
+
+func newNativePayload(parsed parsedManifest, currentPath, basePath string) *types.Payload {
+	name := parsed.project.name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := types.NewPayloadWithPath(name, relativeFilePath(parsed.manifest.file, currentPath, basePath))
+	payload.SetComponentType("cplusplus")
+	payload.AddPrimaryTech("cplusplus")
+	return payload
+}
+
+// addNativeManifest adds the tech, the properties and the dependencies of a
+// native manifest to a component, matching the dependencies against the
+// rules of their type.
+func addNativeManifest(payload *types.Payload, parsed parsedManifest, depDetector components.DependencyDetector) {
+	manifest, project := parsed.manifest, parsed.project
+	payload.AddTech(manifest.tech, "matched file: "+manifest.file)
+	if len(project.properties) > 0 {
+		payload.Properties[manifest.tech] = project.properties
+	}
+	if len(project.dependencies) == 0 {
+		return
+	}
+	names := make([]string, 0, len(project.dependencies))
+	for _, dep := range project.dependencies {
+		names = append(names, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, manifest.depType))
+	payload.Dependencies = append(payload.Dependencies, project.dependencies...)
+}
+
+// applyConanLock sets the versions conan.lock pins on the Conan dependencies
+// declared without one or with a version range.
+func applyConanLock(payload *types.Payload, currentPath string, provider types.Provider) {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "conan.lock"))
+	if err != nil {
+		return
+	}
+	if versions := parsers.ConanLockVersions(content); len(versions) > 0 {
+		parsers.ApplyConanLockVersions(payload.Dependencies, versions)
+	}
+}
+
+// parseCMakeManifest reads a CMakeLists.txt. Only a project() makes a
+// component, and Zephyr applications are left to the firmware detector.
+func parseCMakeManifest(content []byte) nativeProject {
+	cmake := parsers.ParseCMakeLists(string(content))
+	project := nativeProject{name: cmake.Name, dependencies: cmake.Dependencies, component: cmake.Name != ""}
+	for _, dep := range cmake.Dependencies {
+		if dep.Name == "Zephyr" {
+			project.component = false
+		}
+	}
+	if cmake.Name != "" {
+		project.properties = map[string]interface{}{"project": cmake.Name}
+		if cmake.Version != "" {
+			project.properties["version"] = cmake.Version
+		}
+		if len(cmake.Languages) > 0 {
+			project.properties["languages"] = cmake.Languages
+		}
+	}
+	return project
+}
+
+func parseMesonManifest(content []byte) nativeProject {
+	meson := parsers.ParseMesonBuild(string(content))
+	project := nativeProject{name: meson.Name, dependencies: meson.Dependencies, component: meson.Name != ""}
+	if meson.Name != "" {
+		project.properties = map[string]interface{}{"project": meson.Name}
+		if meson.Version != "" {
+			project.properties["version"] = meson.Version
+		}
+	}
+	return project
+}
+
+func parseConanfileTxt(content []byte) nativeProject {
+	return nativeProject{dependencies: parsers.NewConanParser().ParseConanfileTxt(string(content)), component: true}
+}
+
+func parseVcpkgManifest(content []byte) nativeProject {
+	manifest, deps, err := parsers.ParseVcpkgJSON(content)
+	if err != nil {
+		return nativeProject{}
+	}
+	project := nativeProject{name: manifest.Name, dependencies: deps, component: true}
+	if manifest.Name != "" {
+		project.properties = map[string]interface{}{"name": manifest.Name}
+		if version := manifest.ProjectVersion(); version != "" {
+			project.properties["version"] = version
+		}
+	}
+	return project
+}
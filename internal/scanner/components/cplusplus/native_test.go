@@ -0,0 +1,66 @@
+package cplusplus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestDetector_Detect_NativeBuild(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/test/base/app/CMakeLists.txt": `project(myapp VERSION 2.0 LANGUAGES CXX)
+find_package(OpenSSL 3.0 REQUIRED)`,
+		"/test/base/app/vcpkg.json":    `{"name": "myapp", "dependencies": ["fmt"]}`,
+		"/test/base/app/conanfile.txt": "[requires]\nzlib/[>=1.2 <2]\n",
+		"/test/base/app/conan.lock":    `{"version": "0.5", "requires": ["zlib/1.3.1#abc%1700000000.0"]}`,
+	}}
+	files := []types.File{{Name: "CMakeLists.txt"}, {Name: "vcpkg.json"}, {Name: "conanfile.txt"}, {Name: "conan.lock"}, {Name: "main.cpp"}}
+
+	results := (&Detector{}).Detect(files, "/test/base/app", "/test/base", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	p := results[0]
+	assert.Equal(t, "myapp", p.Name)
+	assert.Equal(t, "cplusplus", p.ComponentType)
+	assert.Equal(t, []string{"app/CMakeLists.txt"}, p.Path)
+	for _, tech := range []string{"cmake", "vcpkg", "conan"} {
+		assert.True(t, hasTech(p.Techs, tech), "expected %s in techs", tech)
+	}
+	assert.Equal(t, map[string]interface{}{"project": "myapp", "version": "2.0", "languages": []string{"CXX"}}, p.Properties["cmake"])
+
+	versions := make(map[string]string)
+	for _, dep := range p.Dependencies {
+		versions[dep.Type+":"+dep.Name] = dep.Version
+	}
+	assert.Equal(t, map[string]string{"cmake:OpenSSL": "3.0", "vcpkg:fmt": "", "conan:zlib": "1.3.1"}, versions)
+}
+
+func TestDetector_Detect_NativeBuildWithoutProject(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/test/base/app/CMakeLists.txt": `add_subdirectory(src)`,
+		"/test/base/zephyr/CMakeLists.txt": `find_package(Zephyr REQUIRED HINTS $ENV{ZEPHYR_BASE})
+project(blinky)`,
+	}}
+	detector := &Detector{}
+	assert.Empty(t, detector.Detect([]types.File{{Name: "CMakeLists.txt"}}, "/test/base/app", "/test/base", provider, &MockDependencyDetector{}),
+		"a CMakeLists.txt without project() is not a component")
+	assert.Empty(t, detector.Detect([]types.File{{Name: "CMakeLists.txt"}}, "/test/base/zephyr", "/test/base", provider, &MockDependencyDetector{}),
+		"Zephyr applications are left to the firmware detector")
+}
+
+func TestDetector_Detect_NativeBuildMergedIntoConanfilePy(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/test/base/app/conanfile.py": `class AppRecipe(ConanFile): pass`,
+		"/test/base/app/CMakeLists.txt": `project(app)
+find_package(GTest)`,
+	}}
+	files := []types.File{{Name: "conanfile.py"}, {Name: "CMakeLists.txt"}}
+
+	results := (&Detector{}).Detect(files, "/test/base/app", "/test/base", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.True(t, hasTech(results[0].Techs, "cmake"))
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "GTest", results[0].Dependencies[0].Name)
+}
@@ -0,0 +1,146 @@
+// Package devenv implements detection of development environment
+// definitions (devcontainers, version manager files, editor
+// recommendations) as a plugin-based component detector.
+package devenv
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements development environment detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "devenv"
+}
+
+// Detect reads the development environment files in the current directory
+// and reports the pinned toolchains under properties.dev_environment of the
+// enclosing component. A devcontainer base image is also reported as a
+// docker dependency. Returns a virtual component (merged into parent) when
+// at least one file is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	parser := parsers.NewDevEnvParser()
+	for _, file := range files {
+		content, ok := d.read(file, currentPath, provider)
+		if !ok {
+			continue
+		}
+		env := parseEnvironment(parser, file.Name, content)
+		if env == nil {
+			continue
+		}
+		env.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", env.File)
+		}
+		addEnvironment(payload, parser, env, depDetector)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+// read returns the content of a development environment file, or false for
+// any other file. devcontainer.json and extensions.json only count inside
+// .devcontainer/ and .vscode/ respectively.
+func (d *Detector) read(file types.File, currentPath string, provider types.Provider) (string, bool) {
+	if !isEnvironmentFile(file.Name, currentPath) {
+		return "", false
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+func isEnvironmentFile(name, currentPath string) bool {
+	dir := filepath.Base(currentPath)
+	switch name {
+	case "devcontainer.json":
+		// .devcontainer/devcontainer.json or .devcontainer/<name>/devcontainer.json
+		return dir == ".devcontainer" || filepath.Base(filepath.Dir(currentPath)) == ".devcontainer"
+	case "extensions.json":
+		return dir == ".vscode"
+	case ".devcontainer.json", ".tool-versions", "mise.toml", ".mise.toml", ".sdkmanrc", "rust-toolchain.toml":
+		return true
+	}
+	return parsers.IsVersionFile(name)
+}
+
+// parseEnvironment dispatches a file to its parser. Returns nil when the file
+// pins nothing.
+func parseEnvironment(parser *parsers.DevEnvParser, name, content string) *parsers.DevEnvironment {
+	switch name {
+	case "devcontainer.json", ".devcontainer.json":
+		env, err := parser.ParseDevcontainer(content)
+		if err != nil {
+			return nil
+		}
+		return env
+	case "extensions.json":
+		extensions, err := parser.ParseVSCodeExtensions(content)
+		return environmentOrNil(parsers.DevEnvKindVSCode, nil, extensions, err)
+	case ".tool-versions":
+		return environmentOrNil(parsers.DevEnvKindAsdf, parser.ParseToolVersions(content), nil, nil)
+	case "mise.toml", ".mise.toml":
+		tools, err := parser.ParseMiseToml(content)
+		return environmentOrNil(parsers.DevEnvKindMise, tools, nil, err)
+	case ".sdkmanrc":
+		return environmentOrNil(parsers.DevEnvKindSdkman, parser.ParseSdkmanrc(content), nil, nil)
+	case "rust-toolchain.toml":
+		tool, ok := parser.ParseRustToolchain(content)
+		return singleTool(parsers.DevEnvKindRustToolchain, tool, ok)
+	}
+	tool, ok := parser.ParseVersionFile(name, content)
+	return singleTool(parsers.DevEnvKindVersionFile, tool, ok)
+}
+
+func environmentOrNil(kind string, tools []parsers.ToolVersion, extensions []string, err error) *parsers.DevEnvironment {
+	if err != nil || (len(tools) == 0 && len(extensions) == 0) {
+		return nil
+	}
+	return &parsers.DevEnvironment{Kind: kind, Tools: tools, Extensions: extensions}
+}
+
+func singleTool(kind string, tool parsers.ToolVersion, ok bool) *parsers.DevEnvironment {
+	if !ok {
+		return nil
+	}
+	return &parsers.DevEnvironment{Kind: kind, Tools: []parsers.ToolVersion{tool}}
+}
+
+// addEnvironment records a parsed environment on the payload. Devcontainers
+// and VS Code recommendations add their tech; version manager files are
+// already matched by their rules.
+func addEnvironment(payload *types.Payload, parser *parsers.DevEnvParser, env *parsers.DevEnvironment, depDetector components.DependencyDetector) {
+	switch env.Kind {
+	case parsers.DevEnvKindDevcontainer:
+		payload.AddTech("devcontainer", "matched file: "+env.File)
+		if dep, ok := parser.DevcontainerDependency(env); ok {
+			payload.AddDependency(dep)
+			depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies([]string{dep.Name}, parsers.DependencyTypeDocker))
+		}
+	case parsers.DevEnvKindVSCode:
+		payload.AddTech("vscode", "matched file: "+env.File)
+	}
+
+	if existing, ok := payload.Properties["dev_environment"].([]interface{}); ok {
+		payload.Properties["dev_environment"] = append(existing, env)
+	} else {
+		payload.Properties["dev_environment"] = []interface{}{env}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,145 @@
+package devenv
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func environments(t *testing.T, payload *types.Payload) []*parsers.DevEnvironment {
+	t.Helper()
+	entries, ok := payload.Properties["dev_environment"].([]interface{})
+	require.True(t, ok)
+	envs := make([]*parsers.DevEnvironment, len(entries))
+	for i, e := range entries {
+		envs[i] = e.(*parsers.DevEnvironment)
+	}
+	return envs
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "devenv", (&Detector{}).Name())
+}
+
+func TestDetector_Devcontainer(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.devcontainer/devcontainer.json": []byte(`{"image": "mcr.microsoft.com/devcontainers/python:3.12"}`),
+	}}
+	files := []types.File{{Name: "devcontainer.json"}, {Name: "Dockerfile"}}
+
+	results := (&Detector{}).Detect(files, "/project/.devcontainer", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "devcontainer")
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "docker", payload.Dependencies[0].Type)
+	assert.Equal(t, types.ScopeDev, payload.Dependencies[0].Scope)
+
+	envs := environments(t, payload)
+	require.Len(t, envs, 1)
+	assert.Equal(t, "/.devcontainer/devcontainer.json", envs[0].File)
+}
+
+func TestDetector_VersionFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.tool-versions":    []byte("nodejs 20.11.0\n"),
+		"/project/.python-version":   []byte("3.12.2\n"),
+		"/project/mise.toml":         []byte("[tools]\ngo = \"1.22\"\n"),
+		"/project/devcontainer.json": []byte(`{"image": "ignored"}`),
+		"/project/extensions.json":   []byte(`{"recommendations": ["golang.go"]}`),
+	}}
+	files := []types.File{
+		{Name: ".tool-versions"}, {Name: ".python-version"}, {Name: "mise.toml"},
+		{Name: "devcontainer.json"}, {Name: "extensions.json"}, {Name: "go.mod"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Empty(t, payload.Dependencies)
+	assert.NotContains(t, payload.Techs, "devcontainer", "devcontainer.json outside .devcontainer is not read")
+
+	envs := environments(t, payload)
+	require.Len(t, envs, 3)
+	assert.Equal(t, parsers.DevEnvKindAsdf, envs[0].Kind)
+	assert.Equal(t, []parsers.ToolVersion{{Tool: "node", Version: "20.11.0"}}, envs[0].Tools)
+	assert.Equal(t, parsers.DevEnvKindVersionFile, envs[1].Kind)
+	assert.Equal(t, "python", envs[1].Tools[0].Tool)
+	assert.Equal(t, parsers.DevEnvKindMise, envs[2].Kind)
+}
+
+func TestDetector_VSCodeRecommendations(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.vscode/extensions.json": []byte("{\n  // recommended\n  \"recommendations\": [\"golang.go\"],\n}\n"),
+	}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "extensions.json"}}, "/project/.vscode", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Techs, "vscode")
+	assert.Equal(t, []string{"golang.go"}, environments(t, results[0])[0].Extensions)
+}
+
+func TestDetector_NothingPinned(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/project/.nvmrc": []byte("\n")}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: ".nvmrc"}}, "/project", "/project", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,154 @@
+// Package docs detects documentation toolchains: MkDocs, Sphinx,
+// Docusaurus and Antora sites, AsciiDoctor projects and LaTeX documents.
+// Each directory's toolchains are merged into the enclosing component under
+// the "documentation" property.
+package docs
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// siteGenerators are the tools detected by their ssg rules; adding their
+// tech again from a virtual payload would create a second implicit component.
+var siteGenerators = map[string]bool{"mkdocs": true, "docusaurus": true}
+
+// docusaurusConfigs are the file names of a Docusaurus site configuration.
+var docusaurusConfigs = []string{"docusaurus.config.js", "docusaurus.config.ts", "docusaurus.config.mjs", "docusaurus.config.cjs"}
+
+// Detector implements documentation toolchain detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "documentation" }
+
+// Detect returns a virtual payload with the documentation toolchains
+// configured in the directory, their techs and the MkDocs theme and plugins
+// as dependencies.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	scan := &dirScan{
+		parser:      parsers.NewDocsParser(),
+		provider:    provider,
+		currentPath: currentPath,
+		basePath:    basePath,
+		present:     make(map[string]bool, len(files)),
+	}
+	for _, file := range files {
+		scan.present[file.Name] = true
+	}
+
+	scan.detectMkDocs()
+	scan.detectConfig("conf.py", func(content []byte) *parsers.DocsToolchain {
+		return scan.parser.ParseSphinxConf(string(content))
+	})
+	for _, name := range docusaurusConfigs {
+		scan.detectConfig(name, func(content []byte) *parsers.DocsToolchain {
+			return scan.parser.ParseDocusaurusConfig(string(content))
+		})
+	}
+	scan.detectConfig("antora-playbook.yml", scan.parser.ParseAntora)
+	scan.detectConfig("antora.yml", scan.parser.ParseAntora)
+	scan.detectConfig(".asciidoctorconfig", func([]byte) *parsers.DocsToolchain {
+		return &parsers.DocsToolchain{Tool: "asciidoctor"}
+	})
+	scan.detectLaTeX(files)
+
+	if len(scan.toolchains) == 0 {
+		return nil
+	}
+	payload := types.NewPayloadWithPath("virtual", scan.toolchains[0].(*parsers.DocsToolchain).File)
+	for _, toolchain := range scan.toolchains {
+		if tool := toolchain.(*parsers.DocsToolchain); !siteGenerators[tool.Tool] {
+			payload.AddTech(tool.Tool, "documentation toolchain: "+tool.File)
+		}
+	}
+	payload.Properties["documentation"] = scan.toolchains
+	if len(scan.deps) > 0 {
+		names := make([]string, 0, len(scan.deps))
+		for _, dep := range scan.deps {
+			names = append(names, dep.Name)
+		}
+		depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, parsers.DependencyTypeMkDocs))
+		payload.Dependencies = scan.deps
+	}
+	return []*types.Payload{payload}
+}
+
+// dirScan collects the toolchains of one directory.
+type dirScan struct {
+	parser      *parsers.DocsParser
+	provider    types.Provider
+	currentPath string
+	basePath    string
+	present     map[string]bool
+	toolchains  []interface{}
+	deps        []types.Dependency
+}
+
+func (s *dirScan) read(name string) []byte {
+	content, err := s.provider.ReadFile(filepath.Join(s.currentPath, name))
+	if err != nil {
+		return nil
+	}
+	return content
+}
+
+func (s *dirScan) add(toolchain *parsers.DocsToolchain, name string) {
+	toolchain.File = types.CalculateRelativePath(name, s.currentPath, s.basePath)
+	s.toolchains = append(s.toolchains, toolchain)
+}
+
+func (s *dirScan) detectMkDocs() {
+	for _, name := range []string{"mkdocs.yml", "mkdocs.yaml"} {
+		if !s.present[name] {
+			continue
+		}
+		if toolchain, deps := s.parser.ParseMkDocs(s.read(name)); toolchain != nil {
+			s.add(toolchain, name)
+			s.deps = append(s.deps, deps...)
+		}
+	}
+}
+
+// detectConfig reports the toolchain a configuration file declares, if
+// the file is present and parse accepts it.
+func (s *dirScan) detectConfig(name string, parse func(content []byte) *parsers.DocsToolchain) {
+	if !s.present[name] {
+		return
+	}
+	if toolchain := parse(s.read(name)); toolchain != nil {
+		s.add(toolchain, name)
+	}
+}
+
+// detectLaTeX reports the main documents among the .tex files. A latexmkrc
+// in the directory sets the engine of documents without a magic comment.
+func (s *dirScan) detectLaTeX(files []types.File) {
+	engine := ""
+	for _, name := range []string{"latexmkrc", ".latexmkrc"} {
+		if s.present[name] && engine == "" {
+			engine = s.parser.LatexmkEngine(string(s.read(name)))
+		}
+	}
+	for _, file := range files {
+		if file.Type == "dir" || !strings.EqualFold(filepath.Ext(file.Name), ".tex") {
+			continue
+		}
+		toolchain := s.parser.ParseLaTeX(string(s.read(file.Name)))
+		if toolchain == nil {
+			continue
+		}
+		if toolchain.Engine == "" {
+			toolchain.Engine = engine
+		}
+		s.add(toolchain, file.Name)
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,128 @@
+package docs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Detect_Sites(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/docs/mkdocs.yml": []byte("site_name: Docs\ntheme: material\nplugins:\n  - search\n"),
+		"/repo/docs/conf.py":    []byte("project = 'myapp'\nhtml_theme = 'furo'\n"),
+	}}
+	files := []types.File{{Name: "mkdocs.yml", Type: "file"}, {Name: "conf.py", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/docs", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "sphinx")
+	assert.NotContains(t, payload.Techs, "mkdocs", "site generators are left to their ssg rules")
+
+	toolchains := payload.Properties["documentation"].([]interface{})
+	require.Len(t, toolchains, 2)
+	mkdocs := toolchains[0].(*parsers.DocsToolchain)
+	assert.Equal(t, "/docs/mkdocs.yml", mkdocs.File)
+	assert.Equal(t, "material", mkdocs.Theme)
+	sphinx := toolchains[1].(*parsers.DocsToolchain)
+	assert.Equal(t, "/docs/conf.py", sphinx.File)
+	assert.Equal(t, "furo", sphinx.Theme)
+
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "material", payload.Dependencies[0].Name)
+	assert.Equal(t, "search", payload.Dependencies[1].Name)
+}
+
+func TestDetector_Detect_LaTeX(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/paper/main.tex":   []byte("\\documentclass{article}\n\\begin{document}\\input{intro}\\end{document}\n"),
+		"/repo/paper/intro.tex":  []byte("\\section{Intro}\n"),
+		"/repo/paper/.latexmkrc": []byte("$pdf_mode = 5;\n"),
+		"/repo/paper/slides.TEX": []byte("% !TEX program = lualatex\n\\documentclass{beamer}\n"),
+		"/repo/paper/refs.bib":   []byte("@book{x}\n"),
+	}}
+	files := []types.File{
+		{Name: "main.tex", Type: "file"}, {Name: "intro.tex", Type: "file"}, {Name: ".latexmkrc", Type: "file"},
+		{Name: "slides.TEX", Type: "file"}, {Name: "refs.bib", Type: "file"}, {Name: "figures", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo/paper", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Techs, "latex")
+
+	toolchains := results[0].Properties["documentation"].([]interface{})
+	require.Len(t, toolchains, 2)
+	main := toolchains[0].(*parsers.DocsToolchain)
+	assert.Equal(t, "/paper/main.tex", main.File)
+	assert.Equal(t, "xelatex", main.Engine, "engine from latexmkrc")
+	slides := toolchains[1].(*parsers.DocsToolchain)
+	assert.Equal(t, "beamer", slides.DocumentClass)
+	assert.Equal(t, "lualatex", slides.Engine, "magic comment wins over latexmkrc")
+}
+
+func TestDetector_Detect_NoToolchain(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/app/conf.py": []byte("bind = '0.0.0.0:8000'\n"),
+	}}
+	files := []types.File{{Name: "conf.py", Type: "file"}}
+	assert.Nil(t, (&Detector{}).Detect(files, "/repo/app", "/repo", provider, &MockDependencyDetector{}))
+}
@@ -0,0 +1,140 @@
+// Package erlang detects Erlang/OTP rebar3 projects (rebar.config +
+// rebar.lock).
+package erlang
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Erlang/rebar3 component detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "erlang" }
+
+// Detect scans for rebar3 projects (rebar.config).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name == "rebar.config" {
+			if payload := d.detectRebar(file, currentPath, basePath, provider, depDetector); payload != nil {
+				return []*types.Payload{payload}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Detector) detectRebar(file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewRebarParser()
+	config := parser.ParseRebarConfig(string(content))
+
+	projectName := appName(currentPath, provider, parser)
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+	if relativeFilePath == "." {
+		relativeFilePath = "/"
+	} else {
+		relativeFilePath = "/" + relativeFilePath
+	}
+
+	payload := types.NewPayloadWithPath(projectName, relativeFilePath)
+	payload.SetComponentType("erlang")
+	payload.AddPrimaryTech("erlang")
+	payload.SetComponentProperty("erlang", "app_name", projectName)
+	payload.AddTech("rebar3", "matched file: rebar.config")
+	if config.MinimumOTPVersion != "" {
+		payload.SetComponentProperty("erlang", "minimum_otp_vsn", config.MinimumOTPVersion)
+	}
+	if config.Release != nil {
+		payload.SetComponentProperty("erlang", "release", config.Release)
+	}
+
+	dependencies := config.Dependencies
+	if locked := readRebarLock(currentPath, provider, parser); locked != nil {
+		dependencies = pinLockedVersions(dependencies, locked)
+	}
+	if len(dependencies) > 0 {
+		depNames := make([]string, 0, len(dependencies))
+		for _, dep := range dependencies {
+			depNames = append(depNames, dep.Name)
+		}
+		depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, parsers.DependencyTypeElixir))
+		payload.Dependencies = dependencies
+	}
+
+	return payload
+}
+
+// appName returns the OTP application name from src/*.app.src, or "".
+func appName(currentPath string, provider types.Provider, parser *parsers.RebarParser) string {
+	srcDir := filepath.Join(currentPath, "src")
+	entries, err := provider.ListDir(srcDir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name, ".app.src") {
+			continue
+		}
+		if content, err := provider.ReadFile(filepath.Join(srcDir, entry.Name)); err == nil {
+			return parser.ParseAppSrcName(string(content))
+		}
+	}
+	return ""
+}
+
+// readRebarLock returns the packages of rebar.lock, or nil when there is
+// none or lock files are disabled.
+func readRebarLock(currentPath string, provider types.Provider, parser *parsers.RebarParser) []types.Dependency {
+	if !components.UseLockFiles() {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "rebar.lock"))
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	return parser.ParseRebarLock(string(content))
+}
+
+// pinLockedVersions replaces declared versions with the locked ones and
+// appends the transitive packages of the lockfile.
+func pinLockedVersions(declared, locked []types.Dependency) []types.Dependency {
+	lockedVersions := make(map[string]string, len(locked))
+	for _, dep := range locked {
+		lockedVersions[dep.Name] = dep.Version
+	}
+	seen := make(map[string]bool, len(declared))
+	for i := range declared {
+		seen[declared[i].Name] = true
+		if version, ok := lockedVersions[declared[i].Name]; ok {
+			declared[i].Version = version
+		}
+	}
+	for _, dep := range locked {
+		if !seen[dep.Name] {
+			declared = append(declared, dep)
+		}
+	}
+	return declared
+}
+
+func init() {
+	components.Register(&Detector{})
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      parsers.DependencyTypeElixir,
+		ExtractPackageNames: providers.SinglePropertyExtractor("erlang", "app_name"),
+	})
+}
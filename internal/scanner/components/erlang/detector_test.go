@@ -0,0 +1,125 @@
+package erlang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	var files []types.File
+	for name := range m.files {
+		if filepath.Dir(name) == path {
+			files = append(files, types.File{Name: filepath.Base(name)})
+		}
+	}
+	return files, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "erlang", (&Detector{}).Name())
+}
+
+func TestDetector_RebarProject(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/rebar.config": []byte(`{deps, [cowboy, {jsx, "3.1.0"}]}.
+{relx, [{release, {myrel, "1.0.0"}, [my_app, sasl]}, {include_erts, true}]}.
+{minimum_otp_vsn, "26"}.
+`),
+		"/project/rebar.lock": []byte(`{"1.2.0", [
+{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.10.0">>},0},
+{<<"cowlib">>,{pkg,<<"cowlib">>,<<"2.12.1">>},1},
+{<<"jsx">>,{pkg,<<"jsx">>,<<"3.1.0">>},0}]}.
+`),
+		"/project/src/my_app.app.src": []byte(`{application, my_app, [{vsn, "1.0.0"}]}.`),
+	}}
+	files := []types.File{{Name: "rebar.config"}, {Name: "rebar.lock"}, {Name: "src"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "my_app", payload.Name)
+	assert.Equal(t, "erlang", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "rebar3")
+
+	props := payload.Properties["erlang"].(map[string]interface{})
+	assert.Equal(t, "26", props["minimum_otp_vsn"])
+	release := props["release"].(*parsers.RelxRelease)
+	assert.Equal(t, "myrel", release.Name)
+	require.NotNil(t, release.IncludeErts)
+	assert.True(t, *release.IncludeErts)
+	assert.Nil(t, release.DevMode)
+
+	versions := map[string]string{}
+	for _, dep := range payload.Dependencies {
+		versions[dep.Name] = dep.Version
+	}
+	assert.Equal(t, map[string]string{"cowboy": "2.10.0", "jsx": "3.1.0", "cowlib": "2.12.1"}, versions)
+}
+
+func TestDetector_RebarConfigOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/apps/worker/rebar.config": []byte(`{deps, [{gun, "2.0.1"}]}.`),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "rebar.config"}}, "/project/apps/worker", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "worker", results[0].Name)
+	assert.Equal(t, []string{"/apps/worker/rebar.config"}, results[0].Path)
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "2.0.1", results[0].Dependencies[0].Version)
+}
@@ -0,0 +1,217 @@
+// Package firmware detects embedded firmware projects: PlatformIO projects
+// (platformio.ini), Arduino libraries (library.properties), Zephyr
+// applications and west workspaces (west.yml, prj.conf) and CMake toolchain
+// files that cross-compile for ARM.
+package firmware
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// zephyrPackageRegex matches find_package(Zephyr ...) in CMakeLists.txt.
+	zephyrPackageRegex = regexp.MustCompile(`(?i)find_package\s*\(\s*Zephyr\b`)
+	// cmakeBoardRegex matches set(BOARD name) in CMakeLists.txt.
+	cmakeBoardRegex = regexp.MustCompile(`set\s*\(\s*BOARD\s+"?([\w@/.-]+)`)
+	// cmakeProjectRegex matches the project() name in CMakeLists.txt.
+	cmakeProjectRegex = regexp.MustCompile(`(?i)\bproject\s*\(\s*"?([\w.-]+)`)
+)
+
+// Detector implements embedded firmware project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "firmware" }
+
+// Detect reports platformio, arduino and zephyr components, and merges ARM
+// CMake toolchain files into the enclosing component under cmake_toolchains.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewFirmwareParser()
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file.Name] = true
+	}
+
+	var results []*types.Payload
+	if present["platformio.ini"] {
+		results = appendPayload(results, detectPlatformIO(currentPath, basePath, provider, parser, depDetector))
+	}
+	if present["library.properties"] {
+		results = appendPayload(results, detectArduinoLibrary(currentPath, basePath, provider, parser, depDetector))
+	}
+	if present["west.yml"] || present["prj.conf"] && present["CMakeLists.txt"] {
+		results = appendPayload(results, detectZephyr(present, currentPath, basePath, provider, parser, depDetector))
+	}
+	return appendPayload(results, detectToolchains(files, currentPath, basePath, provider, parser))
+}
+
+func appendPayload(results []*types.Payload, payload *types.Payload) []*types.Payload {
+	if payload != nil {
+		results = append(results, payload)
+	}
+	return results
+}
+
+func detectPlatformIO(currentPath, basePath string, provider types.Provider, parser *parsers.FirmwareParser, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "platformio.ini"))
+	if err != nil {
+		return nil
+	}
+	project := parser.ParsePlatformIO(string(content))
+
+	payload := newFirmwarePayload(filepath.Base(currentPath), types.CalculateRelativePath("platformio.ini", currentPath, basePath), "platformio")
+	if len(project.DefaultEnvs) > 0 {
+		payload.SetComponentProperty("platformio", "default_envs", project.DefaultEnvs)
+	}
+	if len(project.Environments) > 0 {
+		payload.SetComponentProperty("platformio", "environments", project.Environments)
+	}
+	for _, env := range project.Environments {
+		for _, framework := range env.Frameworks {
+			if framework == "arduino" || framework == "zephyr" {
+				payload.AddTech(framework, "platformio.ini framework: "+framework)
+			}
+		}
+	}
+	applyDependencies(payload, project.Dependencies, parsers.DependencyTypePlatformIO, depDetector)
+	return payload
+}
+
+func detectArduinoLibrary(currentPath, basePath string, provider types.Provider, parser *parsers.FirmwareParser, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "library.properties"))
+	if err != nil {
+		return nil
+	}
+	lib := parser.ParseLibraryProperties(string(content))
+	if lib == nil {
+		return nil
+	}
+	payload := newFirmwarePayload(lib.Name, types.CalculateRelativePath("library.properties", currentPath, basePath), "arduino")
+	if lib.Version != "" {
+		payload.SetComponentProperty("arduino", "version", lib.Version)
+	}
+	payload.SetComponentProperty("arduino", "architectures", lib.Architectures)
+	applyDependencies(payload, lib.Dependencies, parsers.DependencyTypeArduino, depDetector)
+	return payload
+}
+
+// detectZephyr reports a Zephyr application (prj.conf next to a
+// CMakeLists.txt that loads the Zephyr package) or west workspace manifest,
+// with the boards the application is configured for and the west projects
+// as dependencies.
+func detectZephyr(present map[string]bool, currentPath, basePath string, provider types.Provider, parser *parsers.FirmwareParser, depDetector components.DependencyDetector) *types.Payload {
+	var cmake string
+	if present["CMakeLists.txt"] {
+		content, _ := provider.ReadFile(filepath.Join(currentPath, "CMakeLists.txt"))
+		cmake = string(content)
+	}
+	isApp := present["prj.conf"] && zephyrPackageRegex.MatchString(cmake)
+	if !isApp && !present["west.yml"] {
+		return nil
+	}
+
+	manifest := "prj.conf"
+	var deps []types.Dependency
+	if present["west.yml"] {
+		manifest = "west.yml"
+		content, _ := provider.ReadFile(filepath.Join(currentPath, "west.yml"))
+		deps = parser.ParseWestManifest(content)
+	}
+	name := filepath.Base(currentPath)
+	if m := cmakeProjectRegex.FindStringSubmatch(cmake); isApp && m != nil {
+		name = m[1]
+	}
+
+	payload := newFirmwarePayload(name, types.CalculateRelativePath(manifest, currentPath, basePath), "zephyr")
+	if boards := zephyrBoards(cmake, currentPath, provider); len(boards) > 0 {
+		payload.SetComponentProperty("zephyr", "boards", boards)
+	}
+	applyDependencies(payload, deps, parsers.DependencyTypeWest, depDetector)
+	return payload
+}
+
+// zephyrBoards returns the boards set in CMakeLists.txt and those with an
+// overlay or Kconfig fragment in the boards/ folder.
+func zephyrBoards(cmake, currentPath string, provider types.Provider) []string {
+	seen := make(map[string]bool)
+	if m := cmakeBoardRegex.FindStringSubmatch(cmake); m != nil {
+		seen[m[1]] = true
+	}
+	entries, _ := provider.ListDir(filepath.Join(currentPath, "boards"))
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name)
+		if entry.Type != "dir" && (ext == ".overlay" || ext == ".conf") {
+			seen[strings.TrimSuffix(entry.Name, ext)] = true
+		}
+	}
+	boards := make([]string, 0, len(seen))
+	for board := range seen {
+		boards = append(boards, board)
+	}
+	sort.Strings(boards)
+	return boards
+}
+
+// detectToolchains returns a virtual payload with the ARM toolchain files
+// among the .cmake files of the directory. Only files named like toolchain
+// files are read.
+func detectToolchains(files []types.File, currentPath, basePath string, provider types.Provider, parser *parsers.FirmwareParser) *types.Payload {
+	var toolchains []interface{}
+	for _, file := range files {
+		if !isToolchainFileName(file.Name) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		if toolchain := parser.ParseCMakeToolchain(string(content)); toolchain != nil {
+			toolchain.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+			toolchains = append(toolchains, toolchain)
+		}
+	}
+	if len(toolchains) == 0 {
+		return nil
+	}
+	payload := types.NewPayloadWithPath("virtual", toolchains[0].(*parsers.CMakeToolchain).File)
+	payload.Properties["cmake_toolchains"] = toolchains
+	return payload
+}
+
+func isToolchainFileName(name string) bool {
+	lower := strings.ToLower(name)
+	if !strings.HasSuffix(lower, ".cmake") {
+		return false
+	}
+	return strings.Contains(lower, "toolchain") || strings.Contains(lower, "arm") || strings.Contains(lower, "cortex")
+}
+
+func newFirmwarePayload(name, path, componentType string) *types.Payload {
+	payload := types.NewPayloadWithPath(name, path)
+	payload.SetComponentType(componentType)
+	payload.AddPrimaryTech(componentType)
+	return payload
+}
+
+func applyDependencies(payload *types.Payload, deps []types.Dependency, depType string, depDetector components.DependencyDetector) {
+	if len(deps) == 0 {
+		return
+	}
+	names := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, depType))
+	payload.Dependencies = deps
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,142 @@
+package firmware
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Detect_PlatformIO(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/node/platformio.ini": []byte(`[env:nano]
+platform = atmelavr
+board = nanoatmega328
+framework = arduino
+lib_deps = bblanchon/ArduinoJson@6.21.3
+`),
+	}}
+	files := []types.File{{Name: "platformio.ini", Type: "file"}, {Name: "src", Type: "dir"}}
+
+	results := (&Detector{}).Detect(files, "/repo/node", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "node", payload.Name)
+	assert.Equal(t, "platformio", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "arduino")
+	envs := payload.Properties["platformio"].(map[string]interface{})["environments"].([]parsers.PlatformIOEnv)
+	require.Len(t, envs, 1)
+	assert.Equal(t, "nanoatmega328", envs[0].Board)
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "6.21.3", payload.Dependencies[0].Version)
+}
+
+func TestDetector_Detect_ArduinoLibrary(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/library.properties": []byte("name=MySensor\nversion=1.4.0\narchitectures=*\ndepends=Wire\n"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "library.properties", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "MySensor", results[0].Name)
+	assert.Equal(t, "arduino", results[0].ComponentType)
+	assert.Equal(t, []string{"*"}, results[0].Properties["arduino"].(map[string]interface{})["architectures"])
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, parsers.DependencyTypeArduino, results[0].Dependencies[0].Type)
+}
+
+func TestDetector_Detect_ZephyrApp(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/app/CMakeLists.txt": []byte("find_package(Zephyr REQUIRED HINTS $ENV{ZEPHYR_BASE})\nset(BOARD nrf52840dk/nrf52840)\nproject(blinky)\n"),
+		"/repo/app/prj.conf":       []byte("CONFIG_GPIO=y\n"),
+	}}
+	files := []types.File{{Name: "CMakeLists.txt", Type: "file"}, {Name: "prj.conf", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/app", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "blinky", results[0].Name)
+	assert.Equal(t, "zephyr", results[0].ComponentType)
+	assert.Equal(t, []string{"/app/prj.conf"}, results[0].Path)
+	assert.Equal(t, []string{"nrf52840dk/nrf52840"}, results[0].Properties["zephyr"].(map[string]interface{})["boards"])
+}
+
+func TestDetector_Detect_NotZephyr(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/CMakeLists.txt": []byte("project(myapp)\n"),
+		"/repo/prj.conf":       []byte("key=value\n"),
+	}}
+	files := []types.File{{Name: "CMakeLists.txt", Type: "file"}, {Name: "prj.conf", Type: "file"}}
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{}))
+}
+
+func TestDetector_Detect_ArmToolchain(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/cmake/arm-toolchain.cmake": []byte("set(CMAKE_SYSTEM_NAME Generic)\nset(CMAKE_C_COMPILER arm-none-eabi-gcc)\n"),
+		"/repo/cmake/FindFoo.cmake":       []byte("set(CMAKE_C_COMPILER arm-none-eabi-gcc)\n"),
+	}}
+	files := []types.File{{Name: "arm-toolchain.cmake", Type: "file"}, {Name: "FindFoo.cmake", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/cmake", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "virtual", results[0].Name)
+	toolchains := results[0].Properties["cmake_toolchains"].([]interface{})
+	require.Len(t, toolchains, 1)
+	assert.Equal(t, "/cmake/arm-toolchain.cmake", toolchains[0].(*parsers.CMakeToolchain).File)
+	assert.Equal(t, "arm-none-eabi", toolchains[0].(*parsers.CMakeToolchain).Triple)
+}
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
@@ -15,8 +16,8 @@ type LockfileGraphProducer = parsers.LockfileProducer
 
 // graphRequest is a deferred dependency-graph resolution captured during the
 // scan walk and executed afterwards, in a dedicated resolution phase. Detectors
-// register one per component (via AttachLockfileGraph*); the scanner drains the
-// queue once the walk is complete (ResolveDeferredGraphs).
+// register one per component (via AttachLockfileGraph*); each scanner drains the
+// requests of its provider once its walk is complete (ResolveDeferredGraphs).
 //
 // Deferring keeps the (fast, local) scan walk separate from the (slow, network-
 // bound) dependency resolution: detection produces components with their
@@ -66,15 +67,23 @@ func AttachLockfileGraphWithFallback(payload *types.Payload, currentPath string,
 	graphQueueMu.Unlock()
 }
 
-// ResolveDeferredGraphs executes all deferred graph-resolution requests
-// registered during the scan walk, then clears the queue. This is the
-// dependency-resolution phase: it runs once, after detection, so the network-
-// bound resolution is isolated from the file walk. Returns the number of
-// requests processed.
-func ResolveDeferredGraphs() int {
+// ResolveDeferredGraphs executes the deferred graph-resolution requests
+// registered during the scan walk of the given provider, then removes them
+// from the queue. This is the dependency-resolution phase: it runs once,
+// after detection, so the network-bound resolution is isolated from the file
+// walk. Requests of other providers are left queued, so scanners walking
+// concurrently each resolve only their own. Returns the number of requests
+// processed.
+func ResolveDeferredGraphs(provider types.Provider) int {
 	graphQueueMu.Lock()
-	queue := graphQueue
-	graphQueue = nil
+	var queue []graphRequest
+	graphQueue = slices.DeleteFunc(graphQueue, func(r graphRequest) bool {
+		if r.provider != provider {
+			return false
+		}
+		queue = append(queue, r)
+		return true
+	})
 	graphQueueMu.Unlock()
 
 	for _, r := range queue {
@@ -83,6 +92,17 @@ func ResolveDeferredGraphs() int {
 	return len(queue)
 }
 
+// DropDeferredGraphs removes the deferred graph resolutions registered for
+// payloads without running them, for components detected only to be
+// compared (such as those of past revisions) whose graph is not needed.
+func DropDeferredGraphs(payloads []*types.Payload) {
+	graphQueueMu.Lock()
+	defer graphQueueMu.Unlock()
+	graphQueue = slices.DeleteFunc(graphQueue, func(r graphRequest) bool {
+		return slices.Contains(payloads, r.payload)
+	})
+}
+
 // mavenGraphFallbackHook builds the Maven transitive-graph fallback resolver
 // (repo crawl / deps.dev hybrid per --maven-graph-source). It is registered by
 // the java detector via RegisterMavenGraphFallback so this package can build it
@@ -97,7 +117,7 @@ func RegisterMavenGraphFallback(fn func(provider types.Provider) resolver.Depend
 
 // mavenEcosystems are the payload ComponentType values whose transitive graph
 // can use the Maven repo-crawl fallback (they share Maven coordinates).
-var mavenEcosystems = map[string]bool{"maven": true, "gradle": true, "java": true}
+var mavenEcosystems = map[string]bool{"maven": true, "gradle": true, "java": true, "ant": true, "ivy": true}
 
 // ResolvePayloadGraphOnline resolves the transitive dependency graph for an
 // already-built payload tree WITHOUT access to the original source files --
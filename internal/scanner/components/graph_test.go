@@ -0,0 +1,39 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// namedProvider is a provider distinguishable by address, as the providers of
+// two scanners are.
+type namedProvider struct {
+	stubNoFileProvider
+	name string
+}
+
+func TestResolveDeferredGraphsPerProvider(t *testing.T) {
+	SetDependencyGraphMode(types.DependencyGraphFull)
+	SetUseDepsDev(false)
+	SetMavenGraphSource("none")
+	defer func() {
+		SetDependencyGraphMode(types.DependencyGraphOff)
+		SetMavenGraphSource("")
+	}()
+
+	api, web := &namedProvider{name: "api"}, &namedProvider{name: "web"}
+	AttachLockfileGraph(types.NewPayload("api", nil), "/api", api, nil)
+	AttachLockfileGraph(types.NewPayload("web", nil), "/web", web, nil)
+	AttachLockfileGraph(types.NewPayload("worker", nil), "/worker", api, nil)
+
+	if n := ResolveDeferredGraphs(api); n != 2 {
+		t.Errorf("expected the 2 requests of the api provider, got %d", n)
+	}
+	if n := ResolveDeferredGraphs(api); n != 0 {
+		t.Errorf("expected the api requests to be drained, got %d", n)
+	}
+	if n := ResolveDeferredGraphs(web); n != 1 {
+		t.Errorf("expected the web request to be left queued, got %d", n)
+	}
+}
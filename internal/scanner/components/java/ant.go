@@ -0,0 +1,115 @@
+package java
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// detectAnt creates an Ant payload from a build.xml and the dependencies of
+// the ivy.xml next to it. A directory with only an ivy.xml is an Ivy
+// component. Legacy builds keeping their jars in lib/ declare no
+// dependencies; their imports can still be read with --java-imports.
+func (d *Detector) detectAnt(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	project, isAnt, hasIvy := findAntBuild(files, currentPath, provider)
+	if !isAnt && !hasIvy {
+		return nil
+	}
+
+	manifest, componentType := "build.xml", "ant"
+	if !isAnt {
+		manifest, componentType = "ivy.xml", "ivy"
+	}
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, manifest))
+	payload := types.NewPayloadWithPath(filepath.Base(currentPath), "/"+filepath.ToSlash(relativeFilePath))
+	payload.SetComponentType(componentType)
+	payload.AddPrimaryTech("java")
+
+	if isAnt {
+		payload.AddTech("ant", "matched file: build.xml")
+		if project.Name != "" {
+			payload.Name = project.Name
+		}
+		payload.Properties["ant"] = antProperties(project)
+	}
+	if hasIvy {
+		d.addIvyInfo(payload, currentPath, provider, depDetector)
+	}
+	return payload
+}
+
+// findAntBuild parses the Ant build.xml among files and reports whether
+// there is an ivy.xml.
+func findAntBuild(files []types.File, currentPath string, provider types.Provider) (project parsers.AntProject, isAnt, hasIvy bool) {
+	for _, file := range files {
+		switch file.Name {
+		case "build.xml":
+			if content, err := provider.ReadFile(filepath.Join(currentPath, file.Name)); err == nil {
+				project, isAnt = parsers.ParseAntBuild(content)
+			}
+		case "ivy.xml":
+			hasIvy = true
+		}
+	}
+	return project, isAnt, hasIvy
+}
+
+// antProperties returns the ant section of a payload: the project name, its
+// default target and the names of its targets.
+func antProperties(project parsers.AntProject) map[string]interface{} {
+	targets := make([]string, 0, len(project.Targets))
+	for _, target := range project.Targets {
+		targets = append(targets, target.Name)
+	}
+	info := map[string]interface{}{"targets": targets}
+	if project.Name != "" {
+		info["name"] = project.Name
+	}
+	if project.Default != "" {
+		info["default"] = project.Default
+	}
+	return info
+}
+
+// ivyProperties returns the set fields of the module info of an ivy.xml.
+func ivyProperties(info parsers.IvyInfo) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for key, value := range map[string]string{"organisation": info.Organisation, "module": info.Module, "revision": info.Revision} {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+// addIvyInfo adds the ivy tech, the module info and the dependencies of the
+// ivy.xml in currentPath to a payload. The dependencies use Maven
+// coordinates, so they are matched against the maven rules.
+func (d *Detector) addIvyInfo(payload *types.Payload, currentPath string, provider types.Provider, depDetector components.DependencyDetector) {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "ivy.xml"))
+	if err != nil {
+		return
+	}
+	info, dependencies, err := parsers.ParseIvy(content)
+	if err != nil {
+		return
+	}
+	payload.AddTech("ivy", "matched file: ivy.xml")
+	if payload.ComponentType == "ivy" && info.Module != "" {
+		payload.Name = d.formatProjectName(info.Organisation, info.Module)
+	}
+	if info.Organisation != "" || info.Module != "" {
+		payload.Properties["ivy"] = ivyProperties(info)
+	}
+	if len(dependencies) == 0 {
+		return
+	}
+	names := make([]string, 0, len(dependencies))
+	for _, dep := range dependencies {
+		names = append(names, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, parsers.DependencyTypeMaven))
+	payload.Dependencies = append(payload.Dependencies, dependencies...)
+}
@@ -0,0 +1,71 @@
+package java
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_AntIvyProject(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/build.xml": `<?xml version="1.0"?>
+<!DOCTYPE project [<!ENTITY common SYSTEM "common.xml">]>
+<project name="legacy-app" default="dist" xmlns:ivy="antlib:org.apache.ivy.ant">
+  &common;
+  <target name="resolve"><ivy:retrieve/></target>
+  <target name="compile" depends="resolve"><javac srcdir="src" destdir="build"/></target>
+  <target name="dist" depends="compile"><jar destfile="dist/legacy-app.jar" basedir="build"/></target>
+</project>`,
+			"/project/ivy.xml": `<ivy-module version="2.0">
+  <info organisation="com.example" module="legacy-app" revision="1.4"/>
+  <dependencies>
+    <dependency org="org.springframework" name="spring-core" rev="5.3.39" conf="compile->default"/>
+    <dependency org="junit" name="junit" rev="4.13.2" conf="test->default"/>
+  </dependencies>
+</ivy-module>`,
+		},
+	}
+	depDetector := &MockDependencyDetector{
+		matchedTechs: map[string][]string{"spring": {"spring matched: ^org\\.springframework:.*"}},
+	}
+	files := []types.File{{Name: "build.xml"}, {Name: "ivy.xml"}, {Name: "common.xml"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, depDetector)
+
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "legacy-app", payload.Name)
+	assert.Equal(t, "ant", payload.ComponentType)
+	assert.Equal(t, "/build.xml", payload.Path[0])
+	assert.Contains(t, payload.Tech, "java")
+	assert.Contains(t, payload.Techs, "ant")
+	assert.Contains(t, payload.Techs, "ivy")
+	assert.Contains(t, payload.Techs, "spring")
+	assert.Equal(t, map[string]interface{}{"name": "legacy-app", "default": "dist", "targets": []string{"resolve", "compile", "dist"}},
+		payload.Properties["ant"])
+	assert.Equal(t, map[string]interface{}{"organisation": "com.example", "module": "legacy-app", "revision": "1.4"}, payload.Properties["ivy"])
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "org.springframework:spring-core", payload.Dependencies[0].Name)
+	assert.Equal(t, "maven", payload.Dependencies[0].Type)
+	assert.Equal(t, types.ScopeProd, payload.Dependencies[0].Scope)
+	assert.Equal(t, types.ScopeDev, payload.Dependencies[1].Scope)
+}
+
+func TestIvyProperties_OmitsEmptyFields(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"organisation": "com.example", "module": "legacy-app"},
+		ivyProperties(parsers.IvyInfo{Organisation: "com.example", Module: "legacy-app"}))
+}
+
+func TestDetector_Detect_PhingBuildIsNotAnt(t *testing.T) {
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/build.xml": `<project name="site" default="test"><target name="test"><phpunit/></target></project>`,
+		},
+	}
+	results := (&Detector{}).Detect([]types.File{{Name: "build.xml"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
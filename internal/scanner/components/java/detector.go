@@ -28,9 +28,12 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 	// Check for Maven first
 	payload = d.detectMaven(files, currentPath, basePath, provider, depDetector)
 
-	// If no Maven found, check for Gradle
+	// If no Maven found, check for Gradle, then for an Ant or Ivy build
 	if payload == nil {
 		payload = d.detectGradleOnly(files, currentPath, basePath, provider, depDetector)
+		if payload == nil {
+			payload = d.detectAnt(files, currentPath, basePath, provider, depDetector)
+		}
 	} else {
 		// Maven found - also add Gradle info if present
 		d.addGradleInfoToMaven(payload, files, currentPath, basePath, provider, depDetector)
@@ -0,0 +1,139 @@
+// Package julia detects Julia projects (Project.toml + Manifest.toml).
+package julia
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// projectFiles are the Julia project file names, in lookup order. Pkg
+// prefers JuliaProject.toml when both exist; the manifest name follows it.
+var projectFiles = []struct {
+	project  string
+	manifest string
+}{
+	{"JuliaProject.toml", "JuliaManifest.toml"},
+	{"Project.toml", "Manifest.toml"},
+}
+
+// Detector implements Julia component detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "julia" }
+
+// Detect scans for Julia projects (Project.toml).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[file.Name] = true
+	}
+	for _, f := range projectFiles {
+		if !names[f.project] {
+			continue
+		}
+		if payload := d.detectProject(f.project, f.manifest, currentPath, basePath, provider, depDetector); payload != nil {
+			return []*types.Payload{payload}
+		}
+	}
+	return nil
+}
+
+func (d *Detector) detectProject(projectFile, manifestFile, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, projectFile))
+	if err != nil {
+		return nil
+	}
+	parser := parsers.NewJuliaParser()
+	project, err := parser.ParseProject(string(content))
+	if err != nil {
+		return nil
+	}
+
+	projectName := project.Name
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, projectFile))
+	if relativeFilePath == "." {
+		relativeFilePath = "/"
+	} else {
+		relativeFilePath = "/" + relativeFilePath
+	}
+
+	payload := types.NewPayloadWithPath(projectName, relativeFilePath)
+	payload.SetComponentType("julia")
+	payload.AddPrimaryTech("julia")
+	payload.SetComponentProperty("julia", "package_name", projectName)
+	if project.Version != "" {
+		payload.SetComponentProperty("julia", "version", project.Version)
+	}
+	if project.JuliaCompat != "" {
+		payload.SetComponentProperty("julia", "julia_compat", project.JuliaCompat)
+	}
+
+	dependencies := project.Dependencies
+	if manifest := readManifest(currentPath, manifestFile, provider, parser); manifest != nil {
+		if manifest.JuliaVersion != "" {
+			payload.SetComponentProperty("julia", "julia_version", manifest.JuliaVersion)
+		}
+		dependencies = pinManifestVersions(dependencies, manifest)
+	}
+
+	applyDependencies(payload, dependencies, depDetector)
+
+	return payload
+}
+
+func applyDependencies(payload *types.Payload, dependencies []types.Dependency, depDetector components.DependencyDetector) {
+	if len(dependencies) == 0 {
+		return
+	}
+	depNames := make([]string, 0, len(dependencies))
+	for _, dep := range dependencies {
+		depNames = append(depNames, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, parsers.DependencyTypeJulia))
+	payload.Dependencies = dependencies
+}
+
+// readManifest returns the parsed manifest next to the project file, or nil
+// when there is none or lock files are disabled.
+func readManifest(currentPath, manifestFile string, provider types.Provider, parser *parsers.JuliaParser) *parsers.JuliaManifest {
+	if !components.UseLockFiles() {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, manifestFile))
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	manifest, err := parser.ParseManifest(string(content))
+	if err != nil {
+		return nil
+	}
+	return manifest
+}
+
+// pinManifestVersions replaces [compat] bounds with the resolved manifest
+// versions and appends the transitive packages of the manifest.
+func pinManifestVersions(declared []types.Dependency, manifest *parsers.JuliaManifest) []types.Dependency {
+	for i := range declared {
+		if version, ok := manifest.Versions[declared[i].Name]; ok {
+			declared[i].Version = version
+		}
+	}
+	return append(declared, manifest.ResolvedDependencies(declared)...)
+}
+
+func init() {
+	components.Register(&Detector{})
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      parsers.DependencyTypeJulia,
+		ExtractPackageNames: providers.SinglePropertyExtractor("julia", "package_name"),
+	})
+}
@@ -0,0 +1,131 @@
+package julia
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "julia", (&Detector{}).Name())
+}
+
+func TestDetector_ProjectWithManifest(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/Project.toml": []byte(`name = "MyAnalysis"
+version = "0.2.0"
+
+[deps]
+DataFrames = "a93c6f00-e57d-5684-b7b6-d8193f3e46c0"
+
+[compat]
+DataFrames = "1"
+julia = "1.9"
+`),
+		"/project/Manifest.toml": []byte(`julia_version = "1.10.2"
+manifest_format = "2.0"
+
+[[deps.DataFrames]]
+version = "1.6.1"
+
+[[deps.Tables]]
+version = "1.11.1"
+`),
+	}}
+	files := []types.File{{Name: "Project.toml"}, {Name: "Manifest.toml"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "MyAnalysis", payload.Name)
+	assert.Equal(t, "julia", payload.ComponentType)
+
+	props := payload.Properties["julia"].(map[string]interface{})
+	assert.Equal(t, "0.2.0", props["version"])
+	assert.Equal(t, "1.9", props["julia_compat"])
+	assert.Equal(t, "1.10.2", props["julia_version"])
+
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "DataFrames", payload.Dependencies[0].Name)
+	assert.Equal(t, "1.6.1", payload.Dependencies[0].Version)
+	assert.True(t, payload.Dependencies[0].Direct)
+	assert.Equal(t, "Tables", payload.Dependencies[1].Name)
+	assert.False(t, payload.Dependencies[1].Direct)
+}
+
+func TestDetector_ProjectWithoutName(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/env/Project.toml": []byte("[deps]\nPlots = \"91a5bcdd-55d7-5caf-9e0b-520d859cae80\"\n"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "Project.toml"}}, "/project/env", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "env", results[0].Name)
+	assert.Equal(t, []string{"/env/Project.toml"}, results[0].Path)
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "Plots", results[0].Dependencies[0].Name)
+}
+
+func TestDetector_InvalidProject(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/Project.toml": []byte("not = [valid"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "Project.toml"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Nil(t, results)
+}
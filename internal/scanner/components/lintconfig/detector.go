@@ -0,0 +1,61 @@
+// Package lintconfig implements linter and formatter configuration detection
+// as a plugin-based component detector.
+package lintconfig
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements linter/formatter configuration detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "lintconfig"
+}
+
+// Detect fingerprints linter and formatter configuration files (.eslintrc*,
+// prettier config, ruff.toml, .golangci.yml, .editorconfig, ...) and records
+// them under properties.lint_config. The tools themselves are matched by
+// their rules. Returns a virtual component (merged into parent) when at least
+// one configuration file is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	for _, file := range files {
+		tool, ok := parsers.LintConfigTool(file.Name)
+		if !ok {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+
+		cfg := &parsers.LintConfig{
+			File:        types.CalculateRelativePath(file.Name, currentPath, basePath),
+			Tool:        tool,
+			Fingerprint: parsers.ConfigFingerprint(content),
+		}
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", cfg.File)
+		}
+		if existing, ok := payload.Properties["lint_config"].([]interface{}); ok {
+			payload.Properties["lint_config"] = append(existing, cfg)
+		} else {
+			payload.Properties["lint_config"] = []interface{}{cfg}
+		}
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
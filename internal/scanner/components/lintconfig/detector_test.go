@@ -0,0 +1,103 @@
+package lintconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "lintconfig", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.editorconfig":  []byte("root = true\n"),
+		"/project/.golangci.yml":  []byte("linters:\n  enable: [gocyclo]\n"),
+		"/project/.eslintrc.json": []byte(`{"extends": "eslint:recommended"}`),
+	}}
+	files := []types.File{{Name: ".editorconfig"}, {Name: ".golangci.yml"}, {Name: ".eslintrc.json"}, {Name: "main.go"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+
+	configs, ok := payload.Properties["lint_config"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, configs, 3)
+
+	tools := map[string]*parsers.LintConfig{}
+	for _, c := range configs {
+		cfg := c.(*parsers.LintConfig)
+		tools[cfg.Tool] = cfg
+	}
+	require.Contains(t, tools, "golangcilint")
+	assert.Equal(t, "/.golangci.yml", tools["golangcilint"].File)
+	assert.Equal(t, parsers.ConfigFingerprint([]byte("linters:\n  enable: [gocyclo]\n")), tools["golangcilint"].Fingerprint)
+	assert.Contains(t, tools, "editorconfig")
+	assert.Contains(t, tools, "eslint")
+}
+
+func TestDetector_NoConfigFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/project/main.go": []byte("package main\n")}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: "main.go"}}, "/project", "/project", provider, &MockDependencyDetector{}))
+}
@@ -3,6 +3,7 @@ package nodejs
 import (
 	"encoding/json"
 	"path/filepath"
+	"sort"
 
 	licensenormalizer "github.com/petrarca/tech-stack-analyzer/internal/license"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
@@ -28,7 +29,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 			continue
 		}
 
-		payload := d.processPackageJSON(file, currentPath, basePath, provider, depDetector)
+		payload := d.processPackageJSON(file, files, currentPath, basePath, provider, depDetector)
 		if payload != nil {
 			payloads = append(payloads, payload)
 		}
@@ -38,7 +39,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 }
 
 // processPackageJSON processes a single package.json file and returns a payload
-func (d *Detector) processPackageJSON(file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+func (d *Detector) processPackageJSON(file types.File, files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
 	// Read package.json
 	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
 	if err != nil {
@@ -51,6 +52,7 @@ func (d *Detector) processPackageJSON(file types.File, currentPath, basePath str
 		Dependencies    map[string]string `json:"dependencies"`
 		DevDependencies map[string]string `json:"devDependencies"`
 		License         string            `json:"license"`
+		Scripts         map[string]string `json:"scripts"`
 	}
 
 	if err := json.Unmarshal(content, &packageJSON); err != nil {
@@ -90,6 +92,16 @@ func (d *Detector) processPackageJSON(file types.File, currentPath, basePath str
 	// Process dependencies using priority-based extraction (lock files first)
 	d.processDependenciesWithPriority(currentPath, basePath, provider, depDetector, payload)
 
+	// Tools invoked from scripts (npx, dlx or global installs) may not be
+	// declared as dependencies at all
+	matchScriptTechs(packageJSON.Scripts, depDetector, payload)
+
+	// React Native / Expo apps: framework versions and native modules
+	detectReactNative(files, currentPath, provider, packageJSON.Dependencies, payload)
+
+	// Electron desktop apps: packager and installer targets per platform
+	detectElectron(files, currentPath, provider, content, allDeclared(packageJSON.Dependencies, packageJSON.DevDependencies), payload)
+
 	// Process license
 	d.processLicense(&packageJSON, payload)
 
@@ -203,7 +215,8 @@ func (d *Detector) tryAncestorLock(currentPath, basePath string, provider types.
 			if err != nil || len(lockContent) == 0 {
 				continue
 			}
-			if deps := lf.parse(lockContent, packageContent); len(deps) > 0 {
+			parse := func() []types.Dependency { return lf.parse(lockContent, packageContent) }
+			if deps := components.CachedDependencies(lf.name, parse, lockContent, packageContent); len(deps) > 0 {
 				markResolvedFromAncestorLock(deps)
 				return deps
 			}
@@ -243,15 +256,19 @@ func (d *Detector) tryPackageLock(currentPath string, provider types.Provider) [
 
 	// Read package.json to determine scope information
 	packageContent, err := provider.ReadFile(filepath.Join(currentPath, "package.json"))
-	var packageJSON *parsers.PackageJSON
 	var packageJSONContent []byte
 	if err == nil && len(packageContent) > 0 {
-		parser := parsers.NewNodeJSParser()
-		packageJSON, _ = parser.ParsePackageJSON(packageContent)
 		packageJSONContent = packageContent // Pass raw content for peer/optional detection
 	}
 
-	return parsers.ParsePackageLockWithOptions(lockContent, packageJSON, packageJSONContent, parsers.ParsePackageLockOptions{})
+	// Giant lock files dominate some scans; parse each distinct one once.
+	return components.CachedDependencies("package-lock.json", func() []types.Dependency {
+		var packageJSON *parsers.PackageJSON
+		if len(packageJSONContent) > 0 {
+			packageJSON, _ = parsers.NewNodeJSParser().ParsePackageJSON(packageJSONContent)
+		}
+		return parsers.ParsePackageLockWithOptions(lockContent, packageJSON, packageJSONContent, parsers.ParsePackageLockOptions{})
+	}, lockContent, packageJSONContent)
 }
 
 func (d *Detector) tryPnpmLock(currentPath string, provider types.Provider) []types.Dependency {
@@ -259,7 +276,9 @@ func (d *Detector) tryPnpmLock(currentPath string, provider types.Provider) []ty
 	if err != nil || len(pnpmContent) == 0 {
 		return nil
 	}
-	return parsers.ParsePnpmLock(pnpmContent)
+	return components.CachedDependencies("pnpm-lock.yaml", func() []types.Dependency {
+		return parsers.ParsePnpmLock(pnpmContent)
+	}, pnpmContent)
 }
 
 func (d *Detector) tryYarnLock(currentPath string, provider types.Provider) []types.Dependency {
@@ -273,13 +292,13 @@ func (d *Detector) tryYarnLock(currentPath string, provider types.Provider) []ty
 		return nil
 	}
 
-	nodejsParser := parsers.NewNodeJSParser()
-	pkg, err := nodejsParser.ParsePackageJSON(packageContent)
-	if err != nil {
-		return nil
-	}
-
-	return parsers.ParseYarnLock(yarnContent, pkg)
+	return components.CachedDependencies("yarn.lock", func() []types.Dependency {
+		pkg, err := parsers.NewNodeJSParser().ParsePackageJSON(packageContent)
+		if err != nil {
+			return nil
+		}
+		return parsers.ParseYarnLock(yarnContent, pkg)
+	}, yarnContent, packageContent)
 }
 
 func (d *Detector) tryPackageJSON(currentPath string, provider types.Provider) []types.Dependency {
@@ -313,12 +332,35 @@ func (d *Detector) matchAndAddTechs(dependencies []types.Dependency, depDetector
 	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, "npm"))
 }
 
+// matchScriptTechs matches the commands run by package.json scripts against
+// the rules' cli entries. Each match is added with the reason
+// "invoked-by-script: <script name>"; script-invoked tools are not promoted
+// to primary techs.
+func matchScriptTechs(scripts map[string]string, depDetector components.DependencyDetector, payload *types.Payload) {
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		commands := parsers.CommandNames(scripts[name])
+		if len(commands) == 0 {
+			continue
+		}
+		for tech := range depDetector.MatchDependencies(commands, parsers.DependencyTypeCLI) {
+			payload.AddTech(tech, "invoked-by-script: "+name)
+		}
+	}
+}
+
 // processLicense handles license processing for package.json
 func (d *Detector) processLicense(packageJSON *struct {
 	Name            string            `json:"name"`
 	Dependencies    map[string]string `json:"dependencies"`
 	DevDependencies map[string]string `json:"devDependencies"`
 	License         string            `json:"license"`
+	Scripts         map[string]string `json:"scripts"`
 }, payload *types.Payload) {
 	licensenormalizer.ProcessLicenseExpression(packageJSON.License, "package.json", payload)
 }
@@ -514,3 +514,62 @@ func TestDetector_Detect_RelativePathHandling(t *testing.T) {
 	assert.Equal(t, "path-test-app", payload.Name)
 	assert.Equal(t, "/subdir/package.json", payload.Path[0], "Should handle relative paths correctly")
 }
+
+// cliDependencyDetector matches only cli lookups, mapping command -> tech
+type cliDependencyDetector struct {
+	MockDependencyDetector
+	commands map[string]string
+}
+
+func (m *cliDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	matched := map[string][]string{}
+	if depType != "cli" {
+		return matched
+	}
+	for _, dep := range dependencies {
+		if tech, ok := m.commands[dep]; ok {
+			matched[tech] = append(matched[tech], tech+" matched: "+dep)
+		}
+	}
+	return matched
+}
+
+func TestDetector_Detect_ScriptInvokedTools(t *testing.T) {
+	detector := &Detector{}
+
+	packageJsonContent := `{
+  "name": "script-app",
+  "dependencies": {
+    "express": "^4.18.0"
+  },
+  "scripts": {
+    "build": "cross-env NODE_ENV=production webpack --mode production && tsc -p .",
+    "lint": "npx eslint src",
+    "format": "pnpm dlx prettier --write .",
+    "e2e": "playwright test",
+    "start": "node server.js"
+  }
+}`
+
+	provider := &MockProvider{
+		files: map[string]string{
+			"/project/package.json": packageJsonContent,
+		},
+	}
+	depDetector := &cliDependencyDetector{commands: map[string]string{
+		"webpack": "webpack", "tsc": "typescript", "eslint": "eslint",
+		"prettier": "prettier", "playwright": "playwright", "npx": "npm", "pnpm": "pnpm",
+	}}
+
+	results := detector.Detect([]types.File{{Name: "package.json"}}, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	for _, tech := range []string{"webpack", "typescript", "eslint", "prettier", "playwright", "npm", "pnpm"} {
+		assert.Contains(t, payload.Techs, tech)
+	}
+	assert.Equal(t, []string{"invoked-by-script: build"}, payload.Reason["typescript"])
+	assert.Equal(t, []string{"invoked-by-script: lint"}, payload.Reason["eslint"])
+	assert.Equal(t, []string{"invoked-by-script: format"}, payload.Reason["prettier"])
+	assert.NotContains(t, payload.Tech, "typescript", "script-invoked tools are not primary techs")
+}
@@ -0,0 +1,112 @@
+package nodejs
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// electronBuilderFiles are the standalone electron-builder configuration
+// files, in the order electron-builder looks for them.
+var electronBuilderFiles = []string{
+	"electron-builder.yml", "electron-builder.yaml", "electron-builder.json",
+	"electron-builder.json5",
+}
+
+// forgeConfigFiles are the Electron Forge configuration files.
+var forgeConfigFiles = []string{"forge.config.js", "forge.config.ts", "forge.config.cjs", "forge.config.mjs"}
+
+// electronPackageJSON is the part of package.json read for Electron apps.
+type electronPackageJSON struct {
+	ProductName string          `json:"productName"`
+	Version     string          `json:"version"`
+	Build       json.RawMessage `json:"build"`
+	Config      struct {
+		Forge json.RawMessage `json:"forge"`
+	} `json:"config"`
+}
+
+// detectElectron adds a "desktop" property to a package.json component that
+// depends on electron (usually a devDependency), with the packager
+// (electron-builder or Electron Forge) and the installer targets it is
+// configured to build per platform.
+func detectElectron(files []types.File, currentPath string, provider types.Provider, content []byte, declared map[string]string, payload *types.Payload) {
+	if _, ok := declared["electron"]; !ok {
+		return
+	}
+	var pkg electronPackageJSON
+	_ = json.Unmarshal(content, &pkg)
+
+	app := &parsers.DesktopApp{
+		Framework:        parsers.DesktopFrameworkElectron,
+		FrameworkVersion: dependencyVersion(payload, declared, "electron"),
+		ProductName:      pkg.ProductName,
+		Version:          pkg.Version,
+	}
+	parser := parsers.NewDesktopParser()
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file.Name] = true
+	}
+	if !electronBuilderConfig(app, parser, currentPath, provider, present, pkg.Build) {
+		forgeConfig(app, parser, currentPath, provider, present, pkg.Config.Forge)
+	}
+	payload.Properties["desktop"] = app
+}
+
+// electronBuilderConfig reads the electron-builder configuration file, or
+// else the "build" section of package.json. Reports whether one was found.
+func electronBuilderConfig(app *parsers.DesktopApp, parser *parsers.DesktopParser, currentPath string, provider types.Provider, present map[string]bool, build json.RawMessage) bool {
+	for _, name := range electronBuilderFiles {
+		if !present[name] {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, name))
+		if err != nil {
+			continue
+		}
+		app.Packager, app.Config = "electron-builder", name
+		app.Targets = parser.ParseElectronBuilder(content)
+		return true
+	}
+	if len(build) == 0 || build[0] != '{' {
+		return false
+	}
+	app.Packager, app.Config = "electron-builder", "package.json"
+	app.Targets = parser.ParseElectronBuilder(build)
+	return true
+}
+
+// forgeConfig reads the Electron Forge configuration file, or else the
+// config.forge object of package.json.
+func forgeConfig(app *parsers.DesktopApp, parser *parsers.DesktopParser, currentPath string, provider types.Provider, present map[string]bool, forge json.RawMessage) {
+	for _, name := range forgeConfigFiles {
+		if !present[name] {
+			continue
+		}
+		if content, err := provider.ReadFile(filepath.Join(currentPath, name)); err == nil {
+			app.Packager, app.Config = "electron-forge", name
+			app.Targets = parser.ParseForgeMakers(string(content))
+			return
+		}
+	}
+	if len(forge) > 0 && forge[0] == '{' {
+		app.Packager, app.Config = "electron-forge", "package.json"
+		app.Targets = parser.ParseForgeMakers(string(forge))
+	}
+}
+
+// allDeclared merges dependencies and devDependencies (electron is
+// normally a devDependency since it is bundled, not required at runtime).
+func allDeclared(deps, devDeps map[string]string) map[string]string {
+	merged := make(map[string]string, len(deps)+len(devDeps))
+	for name, version := range devDeps {
+		merged[name] = version
+	}
+	for name, version := range deps {
+		merged[name] = version
+	}
+	return merged
+}
@@ -0,0 +1,69 @@
+package nodejs
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_ElectronBuilder(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{
+  "name": "myapp",
+  "productName": "MyApp",
+  "version": "2.0.0",
+  "dependencies": {"electron-updater": "^6.1.7"},
+  "devDependencies": {"electron": "^28.1.0", "electron-builder": "^24.9.1"}
+}`,
+		"/project/electron-builder.yml": "appId: com.example.myapp\nmac:\n  target: [dmg]\nwin:\n  target: nsis\n",
+	}}
+	files := []types.File{
+		{Name: "package.json", Type: "file"},
+		{Name: "electron-builder.yml", Type: "file"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	app, ok := results[0].Properties["desktop"].(*parsers.DesktopApp)
+	require.True(t, ok, "expected desktop property")
+
+	assert.Equal(t, parsers.DesktopFrameworkElectron, app.Framework)
+	assert.Equal(t, "^28.1.0", app.FrameworkVersion)
+	assert.Equal(t, "electron-builder", app.Packager)
+	assert.Equal(t, "electron-builder.yml", app.Config)
+	assert.Equal(t, "MyApp", app.ProductName)
+	assert.Equal(t, map[string][]string{"macos": {"dmg"}, "windows": {"nsis"}}, app.Targets)
+}
+
+func TestDetector_Detect_ElectronForgeInPackageJSON(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{
+  "name": "myapp",
+  "dependencies": {"electron-squirrel-startup": "^1.0.0"},
+  "devDependencies": {"electron": "28.1.0", "@electron-forge/cli": "^7.2.0"},
+  "config": {"forge": {"makers": [{"name": "@electron-forge/maker-squirrel"}, {"name": "@electron-forge/maker-deb"}]}}
+}`,
+	}}
+	files := []types.File{{Name: "package.json", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	app, ok := results[0].Properties["desktop"].(*parsers.DesktopApp)
+	require.True(t, ok, "expected desktop property")
+
+	assert.Equal(t, "electron-forge", app.Packager)
+	assert.Equal(t, "package.json", app.Config)
+	assert.Equal(t, map[string][]string{"windows": {"squirrel"}, "linux": {"deb"}}, app.Targets)
+}
+
+func TestDetector_Detect_NoElectron(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{"name": "web", "dependencies": {"react": "18.2.0"}, "build": {"mac": {}}}`,
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "package.json", Type: "file"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.NotContains(t, results[0].Properties, "desktop")
+}
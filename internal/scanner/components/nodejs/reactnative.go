@@ -0,0 +1,175 @@
+package nodejs
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ReactNativeInfo summarizes a React Native or Expo app: the framework
+// versions, how the native projects are managed and the dependencies that
+// ship native code (as opposed to plain JavaScript packages).
+type ReactNativeInfo struct {
+	Version       string         `json:"version,omitempty"`
+	ExpoSDK       string         `json:"expo_sdk,omitempty"`
+	Workflow      string         `json:"workflow"`
+	Platforms     []string       `json:"platforms,omitempty"`
+	Metro         bool           `json:"metro,omitempty"`
+	EAS           bool           `json:"eas,omitempty"`
+	NativeModules []NativeModule `json:"native_modules,omitempty"`
+}
+
+// NativeModule is a runtime dependency that contains native (Android/iOS)
+// code.
+type NativeModule struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// expoWordRegex matches "expo" as a word (an expo key, "expo" or
+// "expo/config" import), not as part of "export".
+var expoWordRegex = regexp.MustCompile(`\bexpo\b`)
+
+// nonNativeRNPackages match the native module naming conventions but are
+// JavaScript only.
+var nonNativeRNPackages = map[string]bool{
+	"react-native-web": true,
+}
+
+// detectReactNative adds a "react_native" property to a package.json
+// component that depends on react-native or expo, or whose app.json has an
+// "expo" section. Android/iOS project folders next to package.json mean the
+// bare workflow; without them an Expo app is managed (prebuild generates the
+// native projects).
+func detectReactNative(files []types.File, currentPath string, provider types.Provider, declared map[string]string, payload *types.Payload) {
+	present := make(map[string]string, len(files)) // name -> "file" or "dir"
+	for _, file := range files {
+		present[file.Name] = "file"
+		if file.Type == "dir" {
+			present[file.Name] = "dir"
+		}
+	}
+
+	expoConfig := readExpoConfig(currentPath, provider, present)
+	_, hasRN := declared["react-native"]
+	_, hasExpo := declared["expo"]
+	if !hasRN && !hasExpo && expoConfig == nil {
+		return
+	}
+
+	isExpo := hasExpo || expoConfig != nil
+	info := &ReactNativeInfo{
+		Version:   dependencyVersion(payload, declared, "react-native"),
+		Workflow:  "bare",
+		Platforms: nativePlatforms(present),
+		Metro:     present["metro.config.js"] != "" || present["metro.config.cjs"] != "",
+		EAS:       present["eas.json"] != "",
+	}
+	if isExpo {
+		info.ExpoSDK = expoSDKVersion(expoConfig, dependencyVersion(payload, declared, "expo"))
+		if len(info.Platforms) == 0 {
+			info.Workflow = "managed"
+		}
+		payload.AddTech("expojs", "matched file: "+expoConfigFile(present))
+	}
+	info.NativeModules = nativeModules(payload, declared)
+	payload.Properties["react_native"] = info
+}
+
+// nativePlatforms returns the native project folders present.
+func nativePlatforms(present map[string]string) []string {
+	var platforms []string
+	for _, platform := range []string{"android", "ios"} {
+		if present[platform] == "dir" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// expoAppConfig is the part of app.json read for Expo apps.
+type expoAppConfig struct {
+	SDKVersion string `json:"sdkVersion"`
+}
+
+// readExpoConfig returns the "expo" section of app.json, or nil when there
+// is none. A dynamic app.config.js/ts that mentions expo counts as an Expo
+// config without a section (other frameworks use the same file names).
+func readExpoConfig(currentPath string, provider types.Provider, present map[string]string) *expoAppConfig {
+	if present["app.json"] != "" {
+		content, _ := provider.ReadFile(filepath.Join(currentPath, "app.json"))
+		var app struct {
+			Expo *expoAppConfig `json:"expo"`
+		}
+		if json.Unmarshal(content, &app) == nil && app.Expo != nil {
+			return app.Expo
+		}
+	}
+	for _, name := range []string{"app.config.ts", "app.config.js"} {
+		if present[name] == "" {
+			continue
+		}
+		if content, err := provider.ReadFile(filepath.Join(currentPath, name)); err == nil && expoWordRegex.Match(content) {
+			return &expoAppConfig{}
+		}
+	}
+	return nil
+}
+
+func expoConfigFile(present map[string]string) string {
+	for _, name := range []string{"app.json", "app.config.ts", "app.config.js", "eas.json"} {
+		if present[name] != "" {
+			return name
+		}
+	}
+	return "package.json"
+}
+
+// expoSDKVersion returns the SDK version pinned in app.json, or else the
+// major version of the expo package (expo 50.0.x is SDK 50).
+func expoSDKVersion(config *expoAppConfig, expoVersion string) string {
+	if config != nil && config.SDKVersion != "" {
+		return config.SDKVersion
+	}
+	major, _, _ := strings.Cut(strings.TrimLeft(expoVersion, "^~>=< v"), ".")
+	return major
+}
+
+// dependencyVersion returns the resolved version of a direct dependency,
+// falling back to the range declared in package.json.
+func dependencyVersion(payload *types.Payload, declared map[string]string, name string) string {
+	for _, dep := range payload.Dependencies {
+		if dep.Name == name && dep.Version != "" {
+			return dep.Version
+		}
+	}
+	return declared[name]
+}
+
+// nativeModules lists the runtime dependencies that follow the native
+// module naming conventions: react-native-*, @react-native-*/..., */react-native*
+// and expo-* packages.
+func nativeModules(payload *types.Payload, declared map[string]string) []NativeModule {
+	var modules []NativeModule
+	for name := range declared {
+		if isNativeModule(name) {
+			modules = append(modules, NativeModule{Name: name, Version: dependencyVersion(payload, declared, name)})
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Name < modules[j].Name })
+	return modules
+}
+
+func isNativeModule(name string) bool {
+	if nonNativeRNPackages[name] {
+		return false
+	}
+	return strings.HasPrefix(name, "react-native-") ||
+		strings.HasPrefix(name, "@react-native-") ||
+		strings.Contains(name, "/react-native") ||
+		strings.HasPrefix(name, "expo-")
+}
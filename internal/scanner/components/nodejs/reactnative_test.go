@@ -0,0 +1,99 @@
+package nodejs
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_ReactNativeBare(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{
+  "name": "mobile-app",
+  "dependencies": {
+    "react": "18.2.0",
+    "react-native": "0.73.4",
+    "react-native-screens": "^3.29.0",
+    "@react-native-async-storage/async-storage": "^1.21.0",
+    "@sentry/react-native": "^5.15.0",
+    "react-native-web": "~0.19.6",
+    "axios": "^1.6.0"
+  }
+}`,
+	}}
+	files := []types.File{
+		{Name: "package.json", Type: "file"},
+		{Name: "metro.config.js", Type: "file"},
+		{Name: "android", Type: "dir"},
+		{Name: "ios", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	info, ok := results[0].Properties["react_native"].(*ReactNativeInfo)
+	require.True(t, ok, "expected react_native property")
+
+	assert.Equal(t, "0.73.4", info.Version)
+	assert.Equal(t, "bare", info.Workflow)
+	assert.Equal(t, []string{"android", "ios"}, info.Platforms)
+	assert.True(t, info.Metro)
+	assert.Empty(t, info.ExpoSDK)
+
+	var names []string
+	for _, m := range info.NativeModules {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"@react-native-async-storage/async-storage", "@sentry/react-native", "react-native-screens"}, names)
+}
+
+func TestDetector_Detect_ExpoManaged(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{
+  "name": "expo-app",
+  "dependencies": {"expo": "~50.0.6", "react-native": "0.73.4", "expo-camera": "~14.0.5"}
+}`,
+		"/project/app.json": `{"expo": {"name": "expo-app", "slug": "expo-app"}}`,
+	}}
+	files := []types.File{
+		{Name: "package.json", Type: "file"},
+		{Name: "app.json", Type: "file"},
+		{Name: "eas.json", Type: "file"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	info := results[0].Properties["react_native"].(*ReactNativeInfo)
+	assert.Equal(t, "50", info.ExpoSDK)
+	assert.Equal(t, "managed", info.Workflow)
+	assert.True(t, info.EAS)
+	require.Len(t, info.NativeModules, 1)
+	assert.Equal(t, "expo-camera", info.NativeModules[0].Name)
+	assert.Contains(t, results[0].Techs, "expojs")
+	assert.Equal(t, []string{"matched file: app.json"}, results[0].Reason["expojs"])
+}
+
+func TestDetector_Detect_ExpoSDKFromAppJSON(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json": `{"name": "legacy", "dependencies": {"expo": "^49.0.0"}}`,
+		"/project/app.json":     `{"expo": {"sdkVersion": "49.0.0"}}`,
+	}}
+	files := []types.File{{Name: "package.json"}, {Name: "app.json"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "49.0.0", results[0].Properties["react_native"].(*ReactNativeInfo).ExpoSDK)
+}
+
+func TestDetector_Detect_WebAppNotReactNative(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/package.json":  `{"name": "web", "dependencies": {"nuxt": "^3.10.0"}}`,
+		"/project/app.config.ts": `export default defineAppConfig({ title: 'web' })`,
+	}}
+	files := []types.File{{Name: "package.json"}, {Name: "app.config.ts"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.NotContains(t, results[0].Properties, "react_native")
+}
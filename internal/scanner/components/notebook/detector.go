@@ -0,0 +1,98 @@
+// Package notebook implements Jupyter notebook detection as a plugin-based
+// component detector.
+package notebook
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// pythonModulePackages maps import names to PyPI package names where they
+// differ.
+var pythonModulePackages = map[string]string{
+	"sklearn":  "scikit-learn",
+	"cv2":      "opencv-python",
+	"PIL":      "pillow",
+	"yaml":     "pyyaml",
+	"bs4":      "beautifulsoup4",
+	"dateutil": "python-dateutil",
+}
+
+// Detector implements Jupyter notebook detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "notebook"
+}
+
+// Detect parses .ipynb files and records their kernel, cell counts and
+// imports under properties.notebooks. Packages imported or installed in code
+// cells are matched against the rules' pypi (Python kernels) or cran (R
+// kernels) dependencies, with an "imported-by-notebook" reason. Returns a
+// virtual component (merged into parent) when at least one notebook parses.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	parser := parsers.NewNotebookParser()
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, ".ipynb") {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		nb, err := parser.ParseNotebook(content)
+		if err != nil {
+			continue
+		}
+
+		nb.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", nb.File)
+		}
+		payload.AddTech("jupyter", "matched file: "+nb.File)
+		addImportedTechs(payload, nb, depDetector)
+		if existing, ok := payload.Properties["notebooks"].([]interface{}); ok {
+			payload.Properties["notebooks"] = append(existing, nb)
+		} else {
+			payload.Properties["notebooks"] = []interface{}{nb}
+		}
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+// addImportedTechs adds the techs whose rules match a notebook import.
+// Imported packages are not promoted to primary techs.
+func addImportedTechs(payload *types.Payload, nb *parsers.Notebook, depDetector components.DependencyDetector) {
+	if len(nb.Imports) == 0 {
+		return
+	}
+	depType := parsers.DependencyTypePython
+	packages := make([]string, 0, len(nb.Imports))
+	for _, name := range nb.Imports {
+		if pkg, ok := pythonModulePackages[name]; ok {
+			name = pkg
+		}
+		packages = append(packages, name)
+	}
+	if nb.Language == "r" {
+		depType = parsers.DependencyTypeR
+		packages = nb.Imports
+	}
+	for tech := range depDetector.MatchDependencies(packages, depType) {
+		payload.AddTech(tech, "imported-by-notebook: "+nb.File)
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,101 @@
+package notebook
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// pypiDependencyDetector matches a fixed set of PyPI packages to techs
+type pypiDependencyDetector struct{}
+
+func (m *pypiDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	techs := map[string]string{"scikit-learn": "scikitlearn", "torch": "pytorch"}
+	matched := map[string][]string{}
+	if depType != "pypi" {
+		return matched
+	}
+	for _, dep := range dependencies {
+		if tech, ok := techs[dep]; ok {
+			matched[tech] = []string{tech + " matched: " + dep}
+		}
+	}
+	return matched
+}
+
+func (m *pypiDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *pypiDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "notebook", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/notebooks/train.ipynb": []byte(`{"cells": [{"cell_type": "code", "source": ["import torch\n", "from sklearn import svm\n"]}],
+  "metadata": {"kernelspec": {"name": "python3", "language": "python"}}}`),
+		"/project/notebooks/broken.ipynb": []byte("{"),
+	}}
+	files := []types.File{{Name: "train.ipynb"}, {Name: "broken.ipynb"}, {Name: "README.md"}}
+
+	results := (&Detector{}).Detect(files, "/project/notebooks", "/project", provider, &pypiDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.ElementsMatch(t, []string{"jupyter", "pytorch", "scikitlearn"}, payload.Techs)
+	assert.Equal(t, []string{"imported-by-notebook: /notebooks/train.ipynb"}, payload.Reason["pytorch"])
+	assert.Empty(t, payload.Tech, "imported packages are not primary techs")
+
+	notebooks, ok := payload.Properties["notebooks"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, notebooks, 1)
+	nb := notebooks[0].(*parsers.Notebook)
+	assert.Equal(t, "/notebooks/train.ipynb", nb.File)
+	assert.Equal(t, 2, nb.CodeLines)
+}
+
+func TestDetector_NoNotebooks(t *testing.T) {
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: "main.py"}}, "/project", "/project", &MockProvider{}, &pypiDependencyDetector{}))
+}
@@ -0,0 +1,83 @@
+// Package notification implements notification provider configuration
+// detection (email, SMS and push senders) as a plugin-based component
+// detector.
+package notification
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// configFileRegex matches application configuration files that hold mail and
+// messaging settings (Spring application.yml, .NET appsettings.json, Django
+// settings.py, Laravel config/mail.php, Rails environment configs).
+var configFileRegex = regexp.MustCompile(`^(application(-[\w-]+)?\.(ya?ml|properties)|appsettings(\.[\w-]+)?\.json|settings\.py|mail\.php|services\.php|(development|production|staging)\.rb)$`)
+
+// Detector implements notification provider configuration detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "notification"
+}
+
+// Detect finds notification provider settings in dotenv and application
+// config files: provider API and SMTP endpoints and the sender domains of
+// From addresses. Each provider found through an endpoint is added as a tech,
+// and the evidence is recorded under properties.notification_evidence, which
+// the scanner folds into the component's notifications section. Returns a
+// virtual component (merged into parent) when evidence is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	for _, file := range files {
+		if !isCandidate(file.Name) {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		evidence := parsers.FindNotificationConfig(string(content))
+		if len(evidence) == 0 {
+			continue
+		}
+
+		relFile := types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", relFile)
+		}
+		for _, ev := range evidence {
+			ev.File = relFile
+			if ev.Endpoint != "" {
+				payload.AddTech(ev.Provider, "matched file: "+relFile)
+			}
+			addEvidence(payload, ev)
+		}
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+func isCandidate(name string) bool {
+	return name == ".env" || strings.HasPrefix(name, ".env.") || configFileRegex.MatchString(name)
+}
+
+func addEvidence(payload *types.Payload, ev parsers.NotificationEvidence) {
+	if existing, ok := payload.Properties["notification_evidence"].([]interface{}); ok {
+		payload.Properties["notification_evidence"] = append(existing, ev)
+	} else {
+		payload.Properties["notification_evidence"] = []interface{}{ev}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "notification", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/.env":            []byte("MAIL_HOST=smtp.sendgrid.net\nSENDGRID_FROM_EMAIL=noreply@example.com\n"),
+		"/project/application.yml": []byte("spring:\n  mail:\n    host: email-smtp.eu-west-1.amazonaws.com\n"),
+		"/project/config.yml":      []byte("host: api.twilio.com\n"),
+	}}
+	files := []types.File{{Name: ".env"}, {Name: "application.yml"}, {Name: "config.yml"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.ElementsMatch(t, []string{"sendgrid", "aws.ses"}, payload.Techs)
+	assert.Contains(t, payload.Reason["sendgrid"], "matched file: /.env")
+
+	evidence, ok := payload.Properties["notification_evidence"].([]interface{})
+	require.True(t, ok)
+	assert.ElementsMatch(t, []interface{}{
+		parsers.NotificationEvidence{Provider: "sendgrid", Endpoint: "smtp.sendgrid.net", File: "/.env"},
+		parsers.NotificationEvidence{Provider: "sendgrid", SenderDomain: "example.com", File: "/.env"},
+		parsers.NotificationEvidence{Provider: "aws.ses", Endpoint: "email-smtp.eu-west-1.amazonaws.com", File: "/application.yml"},
+	}, evidence)
+}
+
+func TestDetector_NoEvidence(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/project/.env": []byte("PORT=3000\nADMIN_EMAIL=admin@example.com\n")}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: ".env"}}, "/project", "/project", provider, &MockDependencyDetector{}))
+}
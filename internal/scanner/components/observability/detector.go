@@ -0,0 +1,178 @@
+// Package observability implements observability configuration detection
+// (OpenTelemetry Collector, Prometheus, Grafana provisioning and logging
+// frameworks) as a plugin-based component detector.
+package observability
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	prometheusFileRegex = regexp.MustCompile(`^prometheus([-_.][\w-]+)?\.ya?ml$`)
+	otelFileRegex       = regexp.MustCompile(`(?i)(otel|collector).*\.ya?ml$`)
+	winstonFileRegex    = regexp.MustCompile(`^(logger|logging|log|winston)(\.config)?\.[cm]?[jt]s$`)
+)
+
+// kindTechs maps each configuration kind to the tech it evidences.
+var kindTechs = map[string]string{
+	parsers.ObservabilityKindOtelCollector: "opentelemetry",
+	parsers.ObservabilityKindPrometheus:    "prometheus",
+	parsers.ObservabilityKindGrafana:       "grafana",
+	parsers.ObservabilityKindLogback:       "logback",
+	parsers.ObservabilityKindLog4j:         "log4j",
+	parsers.ObservabilityKindWinston:       "winston",
+}
+
+// backendTechs maps collector component types and Grafana datasource types
+// to the tech of the backend they talk to.
+var backendTechs = map[string]string{
+	"prometheus":            "prometheus",
+	"prometheusremotewrite": "prometheus",
+	"zipkin":                "zipkin",
+	"datadog":               "datadog",
+	"loki":                  "loki",
+	"jaeger":                "jaeger",
+	"elasticsearch":         "elasticsearch",
+}
+
+// Detector implements observability configuration detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "observability"
+}
+
+// Detect summarizes OpenTelemetry Collector configs, prometheus.yml scrape
+// configs, Grafana provisioning files and logging configurations (logback,
+// log4j/log4j2, winston transports) under properties.observability. The
+// configured tool and the backends it sends telemetry to are added as techs.
+// Returns a virtual component (merged into parent) when at least one
+// configuration is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	parser := parsers.NewObservabilityParser()
+	inGrafanaProvisioning := isGrafanaProvisioningDir(currentPath)
+	for _, file := range files {
+		parse := configParser(file.Name, inGrafanaProvisioning)
+		if parse == nil {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		cfg := parse(parser, string(content))
+		if cfg == nil {
+			continue
+		}
+
+		cfg.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", cfg.File)
+		}
+		addTechs(payload, cfg)
+		if existing, ok := payload.Properties["observability"].([]interface{}); ok {
+			payload.Properties["observability"] = append(existing, cfg)
+		} else {
+			payload.Properties["observability"] = []interface{}{cfg}
+		}
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+// parseFunc parses one observability configuration; nil means the file is
+// not such a configuration or could not be parsed.
+type parseFunc func(parser *parsers.ObservabilityParser, content string) *parsers.ObservabilityConfig
+
+// namedConfigs maps logging configuration file names to their parser.
+var namedConfigs = map[string]parseFunc{
+	"logback.xml":        parseLogback,
+	"logback-spring.xml": parseLogback,
+	"logback-test.xml":   parseLogback,
+	"log4j.xml": func(p *parsers.ObservabilityParser, c string) *parsers.ObservabilityConfig {
+		return orNil(p.ParseLogbackXML(parsers.ObservabilityKindLog4j, c))
+	},
+	"log4j2.xml":        parseLog4j2,
+	"log4j2-spring.xml": parseLog4j2,
+	"log4j2.properties": (*parsers.ObservabilityParser).ParseLog4jProperties,
+	"log4j.properties":  (*parsers.ObservabilityParser).ParseLog4jProperties,
+}
+
+// configParser returns the parser for a file name, or nil when the file is
+// not an observability configuration.
+func configParser(name string, inGrafanaProvisioning bool) parseFunc {
+	if parse, ok := namedConfigs[name]; ok {
+		return parse
+	}
+	isYAML := strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")
+	switch {
+	case inGrafanaProvisioning && isYAML:
+		return func(p *parsers.ObservabilityParser, c string) *parsers.ObservabilityConfig {
+			return orNil(p.ParseGrafanaProvisioning(c))
+		}
+	case prometheusFileRegex.MatchString(name):
+		return func(p *parsers.ObservabilityParser, c string) *parsers.ObservabilityConfig {
+			return orNil(p.ParsePrometheusConfig(c))
+		}
+	case otelFileRegex.MatchString(name):
+		return func(p *parsers.ObservabilityParser, c string) *parsers.ObservabilityConfig {
+			return orNil(p.ParseOtelCollector(c))
+		}
+	case winstonFileRegex.MatchString(name):
+		return (*parsers.ObservabilityParser).ParseWinston
+	}
+	return nil
+}
+
+func parseLogback(p *parsers.ObservabilityParser, content string) *parsers.ObservabilityConfig {
+	return orNil(p.ParseLogbackXML(parsers.ObservabilityKindLogback, content))
+}
+
+func parseLog4j2(p *parsers.ObservabilityParser, content string) *parsers.ObservabilityConfig {
+	return orNil(p.ParseLog4j2XML(content))
+}
+
+// orNil drops the configuration of a file that failed to parse.
+func orNil(cfg *parsers.ObservabilityConfig, err error) *parsers.ObservabilityConfig {
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// isGrafanaProvisioningDir reports whether dir is a Grafana provisioning
+// datasources or dashboards directory.
+func isGrafanaProvisioningDir(dir string) bool {
+	base := filepath.Base(dir)
+	return (base == "datasources" || base == "dashboards") && filepath.Base(filepath.Dir(dir)) == "provisioning"
+}
+
+// addTechs adds the tech of the configuration kind and of every known
+// backend referenced by its receivers, exporters or datasources.
+func addTechs(payload *types.Payload, cfg *parsers.ObservabilityConfig) {
+	reason := "matched file: " + cfg.File
+	payload.AddTech(kindTechs[cfg.Kind], reason)
+
+	ids := append(append(append([]string{}, cfg.Receivers...), cfg.Exporters...), cfg.Datasources...)
+	for _, id := range ids {
+		componentType, _, _ := strings.Cut(id, "/")
+		if tech, ok := backendTechs[componentType]; ok {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
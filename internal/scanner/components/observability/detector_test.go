@@ -0,0 +1,120 @@
+package observability
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "observability", (&Detector{}).Name())
+}
+
+func TestDetector_Detect(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/otel-collector.yaml": []byte("receivers:\n  otlp: {}\nexporters:\n  zipkin:\n    endpoint: http://zipkin.example.com\n  datadog: {}\nservice:\n  pipelines:\n    traces:\n      receivers: [otlp]\n      exporters: [zipkin, datadog]\n"),
+		"/project/prometheus.yml":      []byte("scrape_configs:\n  - job_name: myapp\n"),
+		"/project/logback-spring.xml":  []byte(`<configuration><appender name="C" class="ch.qos.logback.core.ConsoleAppender"/></configuration>`),
+		"/project/docker-compose.yml":  []byte("services:\n  app:\n    image: myapp\n"),
+	}}
+	files := []types.File{{Name: "otel-collector.yaml"}, {Name: "prometheus.yml"}, {Name: "logback-spring.xml"}, {Name: "docker-compose.yml"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.ElementsMatch(t, []string{"opentelemetry", "zipkin", "datadog", "prometheus", "logback"}, payload.Techs)
+	assert.Contains(t, payload.Reason["zipkin"], "matched file: /otel-collector.yaml")
+
+	configs, ok := payload.Properties["observability"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, configs, 3)
+	kinds := map[string]*parsers.ObservabilityConfig{}
+	for _, c := range configs {
+		cfg := c.(*parsers.ObservabilityConfig)
+		kinds[cfg.Kind] = cfg
+	}
+	assert.Equal(t, []string{"traces"}, kinds[parsers.ObservabilityKindOtelCollector].Signals)
+	assert.Equal(t, []string{"myapp"}, kinds[parsers.ObservabilityKindPrometheus].ScrapeJobs)
+	assert.Equal(t, "/logback-spring.xml", kinds[parsers.ObservabilityKindLogback].File)
+}
+
+func TestDetector_GrafanaProvisioning(t *testing.T) {
+	dir := "/project/grafana/provisioning/datasources"
+	provider := &MockProvider{files: map[string][]byte{
+		dir + "/datasources.yaml": []byte("apiVersion: 1\ndatasources:\n  - name: Loki\n    type: loki\n"),
+	}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "datasources.yaml"}}, dir, "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.ElementsMatch(t, []string{"grafana", "loki"}, results[0].Techs)
+
+	// The same file outside a provisioning directory is not a Grafana config
+	results = (&Detector{}).Detect([]types.File{{Name: "datasources.yaml"}}, "/project/config", "/project", &MockProvider{files: map[string][]byte{
+		"/project/config/datasources.yaml": []byte("datasources:\n  - type: loki\n"),
+	}}, &MockDependencyDetector{})
+	assert.Nil(t, results)
+}
+
+func TestDetector_NoConfigFiles(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/project/collector.yaml": []byte("name: myapp\n")}}
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: "collector.yaml"}, {Name: "main.go"}}, "/project", "/project", provider, &MockDependencyDetector{}))
+}
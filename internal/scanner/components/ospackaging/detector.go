@@ -0,0 +1,140 @@
+// Package ospackaging implements detection of distribution packaging recipes
+// kept in a repository (Debian control, RPM spec, Alpine APKBUILD, Arch
+// PKGBUILD, Homebrew formulae) as a plugin-based component detector.
+package ospackaging
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/license"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// formatTechs maps a recipe format to the packaging tech it implies.
+var formatTechs = map[string]string{
+	parsers.PackagingFormatDebian:   "dpkg",
+	parsers.PackagingFormatRPM:      "rpm",
+	parsers.PackagingFormatAPKBUILD: "apk",
+	parsers.PackagingFormatPKGBUILD: "pacman",
+	parsers.PackagingFormatHomebrew: "homebrew",
+}
+
+// formatDependencyTypes maps a recipe format to the type of its dependencies.
+var formatDependencyTypes = map[string]string{
+	parsers.PackagingFormatDebian:   parsers.DependencyTypeDeb,
+	parsers.PackagingFormatRPM:      parsers.DependencyTypeRpm,
+	parsers.PackagingFormatAPKBUILD: parsers.DependencyTypeApk,
+	parsers.PackagingFormatPKGBUILD: parsers.DependencyTypeAlpm,
+	parsers.PackagingFormatHomebrew: parsers.DependencyTypeHomebrew,
+}
+
+// Detector implements distribution packaging recipe detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "ospackaging"
+}
+
+// Detect parses the packaging recipes in the current directory and reports
+// their declared dependencies and packaging tech on the enclosing component.
+// Returns a virtual component (merged into parent) when at least one recipe
+// is found.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	parser := parsers.NewOSPackagingParser()
+	for _, file := range files {
+		info := d.parse(parser, file, files, currentPath, provider)
+		if info == nil {
+			continue
+		}
+		info.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", info.File)
+		}
+		addRecipe(payload, info, depDetector)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+// parse dispatches a file to the matching recipe parser. Returns nil for
+// files that are not packaging recipes.
+func (d *Detector) parse(parser *parsers.OSPackagingParser, file types.File, files []types.File, currentPath string, provider types.Provider) *parsers.PackagingInfo {
+	dir := filepath.Base(currentPath)
+	switch {
+	case file.Name == "control" && dir == "debian":
+		return d.parseDebian(parser, files, currentPath, provider)
+	case strings.HasSuffix(file.Name, ".spec"):
+		return parseContent(provider, currentPath, file.Name, parser.ParseRPMSpec)
+	case file.Name == "APKBUILD":
+		return parseContent(provider, currentPath, file.Name, parser.ParseAPKBUILD)
+	case file.Name == "PKGBUILD":
+		return parseContent(provider, currentPath, file.Name, parser.ParsePKGBUILD)
+	case strings.HasSuffix(file.Name, ".rb") && (dir == "Formula" || dir == "HomebrewFormula"):
+		return parseContent(provider, currentPath, file.Name, func(content string) *parsers.PackagingInfo {
+			return parser.ParseHomebrewFormula(file.Name, content)
+		})
+	}
+	return nil
+}
+
+// parseDebian parses debian/control and takes the version from the newest
+// debian/changelog entry when present.
+func (d *Detector) parseDebian(parser *parsers.OSPackagingParser, files []types.File, currentPath string, provider types.Provider) *parsers.PackagingInfo {
+	info := parseContent(provider, currentPath, "control", parser.ParseDebianControl)
+	if info == nil {
+		return nil
+	}
+	for _, f := range files {
+		if f.Name != "changelog" {
+			continue
+		}
+		if content, err := provider.ReadFile(filepath.Join(currentPath, f.Name)); err == nil {
+			_, info.Version = parser.ParseDebianChangelog(string(content))
+		}
+	}
+	return info
+}
+
+func parseContent(provider types.Provider, currentPath, fileName string, parse func(string) *parsers.PackagingInfo) *parsers.PackagingInfo {
+	content, err := provider.ReadFile(filepath.Join(currentPath, fileName))
+	if err != nil {
+		return nil
+	}
+	return parse(string(content))
+}
+
+// addRecipe records a parsed recipe on the payload: the packaging tech, the
+// declared dependencies (matched against the rules of their type), the
+// recipe's license, and an entry under properties.packaging.
+func addRecipe(payload *types.Payload, info *parsers.PackagingInfo, depDetector components.DependencyDetector) {
+	payload.AddTech(formatTechs[info.Format], "matched file: "+info.File)
+
+	names := make([]string, 0, len(info.Dependencies))
+	for _, dep := range info.Dependencies {
+		payload.AddDependency(dep)
+		names = append(names, dep.Name)
+	}
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, formatDependencyTypes[info.Format]))
+
+	if info.License != "" {
+		license.ProcessLicenseExpression(info.License, filepath.Base(info.File), payload)
+	}
+
+	if existing, ok := payload.Properties["packaging"].([]interface{}); ok {
+		payload.Properties["packaging"] = append(existing, info)
+	} else {
+		payload.Properties["packaging"] = []interface{}{info}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
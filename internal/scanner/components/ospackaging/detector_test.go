@@ -0,0 +1,147 @@
+package ospackaging
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	result := make(map[string][]string)
+	for _, dep := range dependencies {
+		if depType == "deb" && dep == "postgresql-client" {
+			result["postgresql"] = append(result["postgresql"], "matched dependency: "+dep)
+		}
+	}
+	return result
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "ospackaging", (&Detector{}).Name())
+}
+
+func TestDetector_DebianDirectory(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/debian/control":   []byte("Source: myapp\nBuild-Depends: debhelper-compat (= 13)\n\nPackage: myapp\nDepends: postgresql-client\n"),
+		"/project/debian/changelog": []byte("myapp (1.2.0-1) unstable; urgency=low\n"),
+	}}
+	files := []types.File{{Name: "control"}, {Name: "changelog"}, {Name: "rules"}}
+
+	results := (&Detector{}).Detect(files, "/project/debian", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "dpkg")
+	assert.Contains(t, payload.Techs, "postgresql")
+	require.Len(t, payload.Dependencies, 2)
+	assert.Equal(t, "deb", payload.Dependencies[0].Type)
+
+	packaging, ok := payload.Properties["packaging"].([]interface{})
+	require.True(t, ok)
+	info := packaging[0].(*parsers.PackagingInfo)
+	assert.Equal(t, "/debian/control", info.File)
+	assert.Equal(t, "1.2.0-1", info.Version)
+}
+
+func TestDetector_ControlOutsideDebianIgnored(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/control": []byte("Source: myapp\n"),
+	}}
+	files := []types.File{{Name: "control"}}
+
+	assert.Nil(t, (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{}))
+}
+
+func TestDetector_RecipesInOneDirectory(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/packaging/myapp.spec": []byte("Name: myapp\nVersion: 1.2.0\nLicense: MIT\nRequires: openssl-libs\n"),
+		"/project/packaging/build.spec": []byte("a = Analysis(['main.py'])\n"),
+		"/project/packaging/APKBUILD":   []byte("pkgname=myapp\npkgver=1.2.0\npkgrel=0\ndepends=\"openssl\"\n"),
+	}}
+	files := []types.File{{Name: "myapp.spec"}, {Name: "build.spec"}, {Name: "APKBUILD"}}
+
+	results := (&Detector{}).Detect(files, "/project/packaging", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	assert.Contains(t, payload.Techs, "rpm")
+	assert.Contains(t, payload.Techs, "apk")
+	require.Len(t, payload.Licenses, 1)
+	assert.Equal(t, "MIT", payload.Licenses[0].LicenseName)
+
+	packaging := payload.Properties["packaging"].([]interface{})
+	assert.Len(t, packaging, 2, "the PyInstaller spec is not a recipe")
+}
+
+func TestDetector_HomebrewFormula(t *testing.T) {
+	formula := "class Myapp < Formula\n  url \"https://example.com/myapp-1.2.0.tar.gz\"\n  depends_on \"go\" => :build\nend\n"
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/Formula/myapp.rb": []byte(formula),
+		"/project/lib/myapp.rb":     []byte(formula),
+	}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "myapp.rb"}}, "/project/Formula", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Techs, "homebrew")
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "homebrew", results[0].Dependencies[0].Type)
+	assert.Equal(t, types.ScopeBuild, results[0].Dependencies[0].Scope)
+
+	assert.Nil(t, (&Detector{}).Detect([]types.File{{Name: "myapp.rb"}}, "/project/lib", "/project", provider, &MockDependencyDetector{}),
+		"Ruby files outside a Formula directory are not formulae")
+}
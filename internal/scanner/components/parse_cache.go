@@ -0,0 +1,37 @@
+package components
+
+import (
+	"sync"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsecache"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	parseCacheMu sync.RWMutex
+	parseCache   *parsecache.Cache // nil parses every time
+)
+
+// SetParseCache sets the cache of parsed lock files shared by all detectors.
+func SetParseCache(c *parsecache.Cache) {
+	parseCacheMu.Lock()
+	defer parseCacheMu.Unlock()
+	parseCache = c
+}
+
+// ParseCache returns the configured parse cache, or nil.
+func ParseCache() *parsecache.Cache {
+	parseCacheMu.RLock()
+	defer parseCacheMu.RUnlock()
+	return parseCache
+}
+
+// CachedDependencies returns parse's result through the parse cache. parser
+// names the parse (and its options); inputs are every file content it reads.
+func CachedDependencies(parser string, parse func() []types.Dependency, inputs ...[]byte) []types.Dependency {
+	c := ParseCache()
+	if c == nil {
+		return parse()
+	}
+	return c.Dependencies(parsecache.Key(parser, inputs...), parse)
+}
@@ -266,7 +266,9 @@ func extractDependenciesWithPriority(currentPath, projectName, pyprojectContent
 
 	// Priority 1: Check for uv.lock
 	if uvLockContent, err := provider.ReadFile(filepath.Join(currentPath, "uv.lock")); err == nil && len(uvLockContent) > 0 {
-		deps := parsers.ParseUvLock(uvLockContent, projectName)
+		deps := components.CachedDependencies("uv.lock", func() []types.Dependency {
+			return parsers.ParseUvLock(uvLockContent, projectName)
+		}, uvLockContent, []byte(projectName))
 		if len(deps) > 0 {
 			return deps
 		}
@@ -274,7 +276,9 @@ func extractDependenciesWithPriority(currentPath, projectName, pyprojectContent
 
 	// Priority 2: Check for poetry.lock
 	if poetryLockContent, err := provider.ReadFile(filepath.Join(currentPath, "poetry.lock")); err == nil && len(poetryLockContent) > 0 {
-		deps := parsers.ParsePoetryLock(poetryLockContent, pyprojectContent)
+		deps := components.CachedDependencies("poetry.lock", func() []types.Dependency {
+			return parsers.ParsePoetryLock(poetryLockContent, pyprojectContent)
+		}, poetryLockContent, []byte(pyprojectContent))
 		if len(deps) > 0 {
 			return deps
 		}
@@ -1,7 +1,8 @@
-// Package r detects R projects (renv.lock).
+// Package r detects R projects (DESCRIPTION, renv.lock) and Shiny apps.
 package r
 
 import (
+	"bytes"
 	"path/filepath"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
@@ -16,54 +17,155 @@ type Detector struct{}
 // Name returns the detector name.
 func (d *Detector) Name() string { return "r" }
 
-// Detect scans for R projects (renv.lock).
+// Detect scans for R projects. A DESCRIPTION file (R package) takes
+// precedence over renv.lock (renv project); a directory with only a Shiny app
+// (app.R, or server.R plus ui.R) is an R component as well.
 func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	names := make(map[string]bool, len(files))
 	for _, file := range files {
-		if file.Name == "renv.lock" {
-			if payload := d.detectRenv(file, currentPath, basePath, provider, depDetector); payload != nil {
-				return []*types.Payload{payload}
-			}
+		names[file.Name] = true
+	}
+
+	var payload *types.Payload
+	switch {
+	case names["DESCRIPTION"]:
+		payload = d.detectDescription(currentPath, basePath, provider, depDetector)
+	case names["renv.lock"]:
+		payload = d.detectRenv(currentPath, basePath, provider, depDetector)
+	}
+
+	shinyFile := findShinyApp(names, currentPath, provider)
+	if shinyFile == "" {
+		if payload == nil {
+			return nil
 		}
+		return []*types.Payload{payload}
+	}
+	if payload == nil {
+		payload = newRPayload(filepath.Base(currentPath), types.CalculateRelativePath(shinyFile, currentPath, basePath))
 	}
-	return nil
+	payload.AddTech("shiny", "matched file: "+shinyFile)
+	payload.SetComponentProperty("r", "shiny_app", true)
+	return []*types.Payload{payload}
 }
 
-func (d *Detector) detectRenv(file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
-	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+// detectDescription creates the component of an R package. Dependencies come
+// from DESCRIPTION; versions are pinned from renv.lock when present, and
+// packages only in the lockfile are added as well.
+func (d *Detector) detectDescription(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "DESCRIPTION"))
 	if err != nil {
 		return nil
 	}
+	desc := parsers.NewDescriptionParser().ParseDescription(string(content))
+
+	projectName := desc.Package
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+	payload := newRPayload(projectName, types.CalculateRelativePath("DESCRIPTION", currentPath, basePath))
+	if desc.Version != "" {
+		payload.SetComponentProperty("r", "version", desc.Version)
+	}
+	if desc.RVersion != "" {
+		payload.SetComponentProperty("r", "r_version", desc.RVersion)
+	}
 
-	projectName := filepath.Base(currentPath)
+	dependencies := desc.Dependencies
+	if locked := readRenvLock(currentPath, provider); locked != nil {
+		payload.AddTech("renv", "matched file: renv.lock")
+		dependencies = pinLockedVersions(dependencies, locked)
+	}
+	applyDependencies(payload, dependencies, depDetector)
+
+	components.AttachLockfileGraph(payload, currentPath, provider, lockfileGraphProducers)
+	return payload
+}
 
-	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
-	if relativeFilePath == "." {
-		relativeFilePath = "/"
-	} else {
-		relativeFilePath = "/" + relativeFilePath
+func (d *Detector) detectRenv(currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "renv.lock"))
+	if err != nil {
+		return nil
 	}
 
+	payload := newRPayload(filepath.Base(currentPath), types.CalculateRelativePath("renv.lock", currentPath, basePath))
+	payload.AddTech("renv", "matched file: renv.lock")
+	applyDependencies(payload, parsers.NewRenvParser().ParseRenvLock(string(content)), depDetector)
+
+	// Attach the dependency graph (no-op unless the mode is on).
+	components.AttachLockfileGraph(payload, currentPath, provider, lockfileGraphProducers)
+
+	return payload
+}
+
+func newRPayload(projectName, relativeFilePath string) *types.Payload {
 	payload := types.NewPayloadWithPath(projectName, relativeFilePath)
 	payload.SetComponentType("r")
 	payload.AddPrimaryTech("r")
 	payload.SetComponentProperty("r", "package_name", projectName)
-	payload.AddTech("renv", "matched file: renv.lock")
-
-	dependencies := parsers.NewRenvParser().ParseRenvLock(string(content))
+	return payload
+}
 
-	var depNames []string
+func applyDependencies(payload *types.Payload, dependencies []types.Dependency, depDetector components.DependencyDetector) {
+	if len(dependencies) == 0 {
+		return
+	}
+	depNames := make([]string, 0, len(dependencies))
 	for _, dep := range dependencies {
 		depNames = append(depNames, dep.Name)
 	}
-	if len(dependencies) > 0 {
-		depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, parsers.DependencyTypeR))
-		payload.Dependencies = dependencies
+	depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, parsers.DependencyTypeR))
+	payload.Dependencies = dependencies
+}
+
+// readRenvLock returns the packages of renv.lock next to DESCRIPTION, or nil
+// when there is none or lock files are disabled.
+func readRenvLock(currentPath string, provider types.Provider) []types.Dependency {
+	if !components.UseLockFiles() {
+		return nil
+	}
+	content, err := provider.ReadFile(filepath.Join(currentPath, "renv.lock"))
+	if err != nil || len(content) == 0 {
+		return nil
 	}
+	return parsers.NewRenvParser().ParseRenvLock(string(content))
+}
 
-	// Attach the dependency graph (no-op unless the mode is on).
-	components.AttachLockfileGraph(payload, currentPath, provider, lockfileGraphProducers)
+// pinLockedVersions replaces declared constraints with the locked versions
+// and appends locked packages DESCRIPTION does not declare (transitive).
+func pinLockedVersions(declared, locked []types.Dependency) []types.Dependency {
+	lockedVersions := make(map[string]string, len(locked))
+	for _, dep := range locked {
+		lockedVersions[dep.Name] = dep.Version
+	}
+	seen := make(map[string]bool, len(declared))
+	for i := range declared {
+		seen[declared[i].Name] = true
+		if version, ok := lockedVersions[declared[i].Name]; ok {
+			declared[i].Version = version
+		}
+	}
+	for _, dep := range locked {
+		if !seen[dep.Name] {
+			declared = append(declared, dep)
+		}
+	}
+	return declared
+}
 
-	return payload
+// findShinyApp returns the file that marks a Shiny app directory: app.R
+// calling into shiny, or a server.R/ui.R pair. Returns "" otherwise.
+func findShinyApp(names map[string]bool, currentPath string, provider types.Provider) string {
+	if names["app.R"] {
+		content, err := provider.ReadFile(filepath.Join(currentPath, "app.R"))
+		if err == nil && bytes.Contains(content, []byte("shiny")) {
+			return "app.R"
+		}
+	}
+	if names["server.R"] && names["ui.R"] {
+		return "server.R"
+	}
+	return ""
 }
 
 // lockfileGraphProducers lists the R lockfile. renv.lock states the package
@@ -0,0 +1,138 @@
+package r
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "r", (&Detector{}).Name())
+}
+
+func TestDetector_DescriptionWithRenvLock(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/DESCRIPTION": []byte("Package: mypkg\nVersion: 1.0.0\nDepends: R (>= 4.2)\nImports: dplyr (>= 1.1.0)\nSuggests: testthat\n"),
+		"/project/renv.lock": []byte(`{"Packages": {
+			"dplyr": {"Package": "dplyr", "Version": "1.1.4"},
+			"rlang": {"Package": "rlang", "Version": "1.1.3"}
+		}}`),
+	}}
+	files := []types.File{{Name: "DESCRIPTION"}, {Name: "renv.lock"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "mypkg", payload.Name)
+	assert.Contains(t, payload.Techs, "renv")
+
+	props := payload.Properties["r"].(map[string]interface{})
+	assert.Equal(t, "1.0.0", props["version"])
+	assert.Equal(t, ">= 4.2", props["r_version"])
+
+	versions := map[string]string{}
+	for _, dep := range payload.Dependencies {
+		versions[dep.Name] = dep.Version
+	}
+	assert.Equal(t, map[string]string{"dplyr": "1.1.4", "testthat": "", "rlang": "1.1.3"}, versions)
+}
+
+func TestDetector_RenvLockOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/analysis/renv.lock": []byte(`{"Packages": {"ggplot2": {"Package": "ggplot2", "Version": "3.4.4"}}}`),
+	}}
+	files := []types.File{{Name: "renv.lock"}}
+
+	results := (&Detector{}).Detect(files, "/project/analysis", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "analysis", results[0].Name)
+	require.Len(t, results[0].Dependencies, 1)
+	assert.Equal(t, "3.4.4", results[0].Dependencies[0].Version)
+}
+
+func TestDetector_ShinyApp(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/dashboard/app.R": []byte("library(shiny)\nshinyApp(ui, server)\n"),
+	}}
+	files := []types.File{{Name: "app.R"}}
+
+	results := (&Detector{}).Detect(files, "/project/dashboard", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "dashboard", payload.Name)
+	assert.Equal(t, []string{"/dashboard/app.R"}, payload.Path)
+	assert.Contains(t, payload.Techs, "shiny")
+	assert.Equal(t, true, payload.Properties["r"].(map[string]interface{})["shiny_app"])
+}
+
+func TestDetector_ServerAndUIPair(t *testing.T) {
+	files := []types.File{{Name: "server.R"}, {Name: "ui.R"}}
+	results := (&Detector{}).Detect(files, "/project", "/project", &MockProvider{files: map[string][]byte{}}, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Techs, "shiny")
+}
+
+func TestDetector_AppRWithoutShiny(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/app.R": []byte("print('hello')\n"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "app.R"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Nil(t, results)
+}
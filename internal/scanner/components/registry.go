@@ -1,6 +1,9 @@
 package components
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
@@ -12,6 +15,7 @@ var (
 	mu                  sync.RWMutex
 	useLockFiles        = true                     // Default to true
 	dependencyGraphMode = types.DependencyGraphOff // Default off (graph can be large)
+	inspectArchives     = false                    // Default off (archives can be large)
 )
 
 // Register adds a component detector to the registry
@@ -28,6 +32,59 @@ func GetDetectors() []Detector {
 	return detectors
 }
 
+// SelectDetectors returns the registered detectors named in only, or all of
+// them when only is empty, less the ones named in disabled, in registration
+// order. Names are matched ignoring case; an unknown name is an error, so a
+// typo does not silently leave every detector running.
+func SelectDetectors(only, disabled []string) ([]Detector, error) {
+	registered := GetDetectors()
+	known := make(map[string]bool, len(registered))
+	for _, d := range registered {
+		known[d.Name()] = true
+	}
+	onlySet, err := detectorNames(only, known)
+	if err != nil {
+		return nil, err
+	}
+	disabledSet, err := detectorNames(disabled, known)
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]Detector, 0, len(registered))
+	for _, d := range registered {
+		if (len(onlySet) == 0 || onlySet[d.Name()]) && !disabledSet[d.Name()] {
+			selected = append(selected, d)
+		}
+	}
+	return selected, nil
+}
+
+func detectorNames(names []string, known map[string]bool) (map[string]bool, error) {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("unknown component detector '%s'. Valid detectors: %s", name, strings.Join(DetectorNames(), ", "))
+		}
+		set[name] = true
+	}
+	return set, nil
+}
+
+// DetectorNames returns the names of the registered detectors, sorted.
+func DetectorNames() []string {
+	registered := GetDetectors()
+	names := make([]string, len(registered))
+	for i, d := range registered {
+		names[i] = d.Name()
+	}
+	slices.Sort(names)
+	return names
+}
+
 // SetUseLockFiles sets whether lock files should be used for dependency resolution
 func SetUseLockFiles(use bool) {
 	mu.Lock()
@@ -56,3 +113,18 @@ func DependencyGraphMode() types.DependencyGraphMode {
 	defer mu.RUnlock()
 	return dependencyGraphMode
 }
+
+// SetInspectArchives sets whether vendored binary archives (jar/war/ear, wheel,
+// nupkg) should be opened to extract their embedded package metadata.
+func SetInspectArchives(enable bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	inspectArchives = enable
+}
+
+// InspectArchives returns whether vendored binary archives should be inspected.
+func InspectArchives() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return inspectArchives
+}
@@ -0,0 +1,215 @@
+// Package ros detects ROS (Robot Operating System) packages (package.xml)
+// and colcon/catkin workspaces.
+package ros
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// workspaceMarkers are files that only exist at the root of a ROS workspace.
+var workspaceMarkers = []string{"colcon.meta", "colcon_defaults.yaml", ".catkin_workspace", ".catkin_tools"}
+
+// interfaceFolders maps the interface definition folders of a package to
+// the file extension of their definitions.
+var interfaceFolders = map[string]string{"msg": ".msg", "srv": ".srv", "action": ".action"}
+
+// Detector implements ROS package and workspace detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "ros" }
+
+// Detect reports a ros component for a ROS package.xml and a ros_workspace
+// component for a workspace root (a src folder next to colcon or catkin
+// workspace files or a .repos file).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewROSParser()
+	present := make(map[string]string, len(files)) // name -> "file" or "dir"
+	for _, file := range files {
+		present[file.Name] = file.Type
+	}
+	if present["package.xml"] == "file" {
+		if payload := detectPackage(files, present, currentPath, basePath, provider, parser, depDetector); payload != nil {
+			return []*types.Payload{payload}
+		}
+		return nil
+	}
+	if marker := workspaceMarker(files, present); marker != "" {
+		return []*types.Payload{detectWorkspace(files, present, marker, currentPath, basePath, provider, parser)}
+	}
+	return nil
+}
+
+func detectPackage(files []types.File, present map[string]string, currentPath, basePath string, provider types.Provider, parser *parsers.ROSParser, depDetector components.DependencyDetector) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "package.xml"))
+	if err != nil {
+		return nil
+	}
+	pkg := parser.ParsePackageXML(content)
+	if pkg == nil {
+		return nil
+	}
+
+	payload := types.NewPayloadWithPath(pkg.Name, types.CalculateRelativePath("package.xml", currentPath, basePath))
+	payload.SetComponentType("ros")
+	payload.AddPrimaryTech("ros")
+	payload.SetComponentProperty("ros", "package_format", pkg.Format)
+	if pkg.Version != "" {
+		payload.SetComponentProperty("ros", "version", pkg.Version)
+	}
+	if pkg.BuildType != "" {
+		payload.SetComponentProperty("ros", "build_type", pkg.BuildType)
+		addBuildTypeTech(payload, pkg.BuildType)
+	}
+	distributions := findDistributions(files, present, currentPath, provider, parser)
+	setROSVersion(payload, pkg.ROSVersions, distributions)
+	if interfaces := packageInterfaces(present, currentPath, provider); len(interfaces) > 0 {
+		payload.SetComponentProperty("ros", "interfaces", interfaces)
+	}
+	if pkg.Interface {
+		payload.SetComponentProperty("ros", "interface_package", true)
+	}
+
+	if len(pkg.Dependencies) > 0 {
+		names := make([]string, 0, len(pkg.Dependencies))
+		for _, dep := range pkg.Dependencies {
+			names = append(names, dep.Name)
+		}
+		depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(names, parsers.DependencyTypeROS))
+		payload.Dependencies = pkg.Dependencies
+	}
+	return payload
+}
+
+func addBuildTypeTech(payload *types.Payload, buildType string) {
+	switch {
+	case buildType == "catkin":
+		payload.AddTech("catkin", "package.xml build type: catkin")
+	case strings.HasPrefix(buildType, "ament_"):
+		payload.AddTech("ament", "package.xml build type: "+buildType)
+	}
+}
+
+// setROSVersion sets the ROS version(s) of the package, falling back to the
+// versions of the distributions it is built for, and the distributions.
+func setROSVersion(payload *types.Payload, versions, distributions []string) {
+	if len(versions) == 0 {
+		seen := make(map[string]bool)
+		for _, distribution := range distributions {
+			if version := parsers.ROSDistributionVersion(distribution); !seen[version] {
+				seen[version] = true
+				versions = append(versions, version)
+			}
+		}
+		sort.Strings(versions)
+	}
+	if len(versions) > 0 {
+		payload.SetComponentProperty("ros", "ros_version", strings.Join(versions, ", "))
+	}
+	if len(distributions) > 0 {
+		payload.SetComponentProperty("ros", "distributions", distributions)
+	}
+}
+
+// workspaceMarker returns the file that marks the directory as a workspace
+// root, or "" when it is not one. A workspace has a src folder.
+func workspaceMarker(files []types.File, present map[string]string) string {
+	if present["src"] != "dir" {
+		return ""
+	}
+	for _, name := range workspaceMarkers {
+		if present[name] != "" {
+			return name
+		}
+	}
+	for _, file := range files {
+		if ext := filepath.Ext(file.Name); file.Type == "file" && (ext == ".repos" || ext == ".rosinstall") {
+			return file.Name
+		}
+	}
+	return ""
+}
+
+func detectWorkspace(files []types.File, present map[string]string, marker, currentPath, basePath string, provider types.Provider, parser *parsers.ROSParser) *types.Payload {
+	payload := types.NewPayloadWithPath(filepath.Base(currentPath), types.CalculateRelativePath(marker, currentPath, basePath))
+	payload.SetComponentType("ros_workspace")
+	payload.AddPrimaryTech("ros")
+	if present[".catkin_workspace"] != "" || present[".catkin_tools"] != "" {
+		payload.AddTech("catkin", "matched file: "+marker)
+	} else {
+		payload.AddTech("colcon", "matched file: "+marker)
+	}
+	setROSVersion(payload, nil, findDistributions(files, present, currentPath, provider, parser))
+	return payload
+}
+
+// findDistributions returns the ROS distributions named in the Dockerfiles,
+// .repos/.rosinstall files and GitHub workflows of the directory.
+func findDistributions(files []types.File, present map[string]string, currentPath string, provider types.Provider, parser *parsers.ROSParser) []string {
+	seen := make(map[string]bool)
+	var distributions []string
+	for _, path := range distributionSources(files, present, currentPath, provider) {
+		content, err := provider.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, distribution := range parser.FindDistributions(string(content)) {
+			if !seen[distribution] {
+				seen[distribution] = true
+				distributions = append(distributions, distribution)
+			}
+		}
+	}
+	sort.Strings(distributions)
+	return distributions
+}
+
+func distributionSources(files []types.File, present map[string]string, currentPath string, provider types.Provider) []string {
+	var paths []string
+	for _, file := range files {
+		ext := filepath.Ext(file.Name)
+		if file.Type == "file" && (strings.HasPrefix(file.Name, "Dockerfile") || ext == ".repos" || ext == ".rosinstall") {
+			paths = append(paths, filepath.Join(currentPath, file.Name))
+		}
+	}
+	if present[".github"] != "dir" {
+		return paths
+	}
+	workflows := filepath.Join(currentPath, ".github", "workflows")
+	entries, _ := provider.ListDir(workflows)
+	for _, entry := range entries {
+		if ext := filepath.Ext(entry.Name); ext == ".yml" || ext == ".yaml" {
+			paths = append(paths, filepath.Join(workflows, entry.Name))
+		}
+	}
+	return paths
+}
+
+// packageInterfaces lists the message, service and action definitions of
+// the msg, srv and action folders, by folder.
+func packageInterfaces(present map[string]string, currentPath string, provider types.Provider) map[string][]string {
+	interfaces := make(map[string][]string)
+	for folder, ext := range interfaceFolders {
+		if present[folder] != "dir" {
+			continue
+		}
+		entries, _ := provider.ListDir(filepath.Join(currentPath, folder))
+		for _, entry := range entries {
+			if filepath.Ext(entry.Name) == ext {
+				interfaces[folder] = append(interfaces[folder], strings.TrimSuffix(entry.Name, ext))
+			}
+		}
+		sort.Strings(interfaces[folder])
+	}
+	return interfaces
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,145 @@
+package ros
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	var entries []types.File
+	for name := range m.files {
+		if filepath.Dir(name) == path {
+			entries = append(entries, types.File{Name: filepath.Base(name), Path: name, Type: "file"})
+		}
+	}
+	return entries, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+const testPackageXML = `<?xml version="1.0"?>
+<package format="3">
+  <name>my_robot</name>
+  <version>0.2.0</version>
+  <buildtool_depend>ament_cmake</buildtool_depend>
+  <depend>rclcpp</depend>
+  <test_depend>ament_lint_auto</test_depend>
+  <export><build_type>ament_cmake</build_type></export>
+</package>`
+
+func TestDetector_Detect_Package(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/my_robot/package.xml":        []byte(testPackageXML),
+		"/repo/my_robot/Dockerfile":         []byte("FROM ros:jazzy\n"),
+		"/repo/my_robot/msg/Status.msg":     []byte("uint8 level\n"),
+		"/repo/my_robot/srv/Reset.srv":      []byte("---\nbool ok\n"),
+		"/repo/my_robot/srv/README.md":      []byte("docs\n"),
+		"/repo/my_robot/action/Move.action": []byte("---\n---\n"),
+	}}
+	files := []types.File{
+		{Name: "package.xml", Type: "file"},
+		{Name: "Dockerfile", Type: "file"},
+		{Name: "msg", Type: "dir"},
+		{Name: "srv", Type: "dir"},
+		{Name: "action", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo/my_robot", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "my_robot", payload.Name)
+	assert.Equal(t, "ros", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "ament")
+	props := payload.Properties["ros"].(map[string]interface{})
+	assert.Equal(t, "3", props["package_format"])
+	assert.Equal(t, "ament_cmake", props["build_type"])
+	assert.Equal(t, "2", props["ros_version"])
+	assert.Equal(t, []string{"jazzy"}, props["distributions"])
+	assert.Equal(t, map[string][]string{"msg": {"Status"}, "srv": {"Reset"}, "action": {"Move"}}, props["interfaces"])
+	assert.Len(t, payload.Dependencies, 3)
+}
+
+func TestDetector_Detect_Workspace(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/ws/.github/workflows/ci.yml": []byte("env:\n  ROS_DISTRO: noetic\n"),
+	}}
+	files := []types.File{
+		{Name: "src", Type: "dir"},
+		{Name: ".catkin_workspace", Type: "file"},
+		{Name: ".github", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/ws", "/", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "ws", payload.Name)
+	assert.Equal(t, "ros_workspace", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "catkin")
+	props := payload.Properties["ros"].(map[string]interface{})
+	assert.Equal(t, "1", props["ros_version"])
+	assert.Equal(t, []string{"noetic"}, props["distributions"])
+}
+
+func TestDetector_Detect_NotROS(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/package.xml": []byte(`<Package xmlns="http://soap.sforce.com/2006/04/metadata"><version>59.0</version></Package>`),
+	}}
+	files := []types.File{{Name: "package.xml", Type: "file"}, {Name: "src", Type: "dir"}}
+	assert.Empty(t, (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{}))
+
+	// A src folder alone is not a workspace.
+	assert.Empty(t, (&Detector{}).Detect([]types.File{{Name: "src", Type: "dir"}}, "/repo", "/repo", provider, &MockDependencyDetector{}))
+}
@@ -22,7 +22,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 	// Check for Cargo.toml
 	for _, file := range files {
 		if file.Name == "Cargo.toml" {
-			payload := d.detectCargoToml(file, currentPath, basePath, provider, depDetector)
+			payload := d.detectCargoToml(file, files, currentPath, basePath, provider, depDetector)
 			if payload != nil {
 				results = append(results, payload)
 			}
@@ -32,7 +32,7 @@ func (d *Detector) Detect(files []types.File, currentPath, basePath string, prov
 	return results
 }
 
-func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+func (d *Detector) detectCargoToml(file types.File, files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
 	content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
 	if err != nil {
 		return nil
@@ -94,6 +94,14 @@ func (d *Detector) detectCargoToml(file types.File, currentPath, basePath string
 		d.processLicense(license, payload)
 	}
 
+	// Features, build targets and workspace members
+	addCargoTargets(payload, string(content), currentPath, provider)
+
+	// Tauri apps: bundle settings of the desktop app
+	if payload.Name != "virtual" {
+		detectTauri(files, currentPath, provider, payload)
+	}
+
 	return payload
 }
 
@@ -119,7 +127,9 @@ func (d *Detector) extractDependenciesWithPriority(currentPath, cargoTomlContent
 
 	// Priority 1: Check for Cargo.lock
 	if lockContent, err := provider.ReadFile(filepath.Join(currentPath, "Cargo.lock")); err == nil && len(lockContent) > 0 {
-		deps := parsers.ParseCargoLock(lockContent, cargoTomlContent)
+		deps := components.CachedDependencies("Cargo.lock", func() []types.Dependency {
+			return parsers.ParseCargoLock(lockContent, cargoTomlContent)
+		}, lockContent, []byte(cargoTomlContent))
 		if len(deps) > 0 {
 			return deps
 		}
@@ -0,0 +1,58 @@
+package rust
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// addCargoTargets records in the rust properties of a crate the features it
+// declares, those enabled by default and the libraries and binaries it
+// builds. The members of a workspace root go to a cargo_workspace property.
+func addCargoTargets(payload *types.Payload, content, currentPath string, provider types.Provider) {
+	manifest, err := parsers.ParseCargoManifest(content)
+	if err != nil {
+		return
+	}
+	if manifest.Workspace != nil && len(manifest.Workspace.Members) > 0 {
+		payload.Properties["cargo_workspace"] = manifest.Workspace
+	}
+	if payload.Name == "virtual" {
+		return
+	}
+	if features := manifest.FeatureNames(); len(features) > 0 {
+		payload.SetComponentProperty("rust", "features", features)
+		payload.SetComponentProperty("rust", "default_features", manifest.DefaultFeatures())
+	}
+	if artifacts := manifest.Artifacts(rustTargetSources(currentPath, provider)); len(artifacts) > 0 {
+		payload.SetComponentProperty("rust", "targets", artifacts)
+	}
+}
+
+// rustTargetSources lists the sources Cargo discovers targets from, relative
+// to the crate: the .rs files of src/ and src/bin/, and the main.rs of the
+// directories of src/bin/.
+func rustTargetSources(currentPath string, provider types.Provider) []string {
+	var sources []string
+	for _, dir := range []string{"src", "src/bin"} {
+		files, err := provider.ListDir(filepath.Join(currentPath, filepath.FromSlash(dir)))
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			switch {
+			case file.Type == "file" && strings.HasSuffix(file.Name, ".rs"):
+				sources = append(sources, path.Join(dir, file.Name))
+			case file.Type == "dir" && dir == "src/bin":
+				main := path.Join(dir, file.Name, "main.rs")
+				if exists, _ := provider.Exists(filepath.Join(currentPath, filepath.FromSlash(main))); exists {
+					sources = append(sources, main)
+				}
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,59 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_CargoTargets(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/mock/project/Cargo.toml": `[package]
+name = "my-app"
+version = "0.1.0"
+
+[features]
+default = ["tls"]
+tls = ["dep:rustls"]
+cli = []
+
+[lib]
+crate-type = ["cdylib", "rlib"]
+
+[[bin]]
+name = "my-app-cli"
+path = "src/cli.rs"
+required-features = ["cli"]
+`,
+	}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "Cargo.toml"}}, "/mock/project", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	rust := results[0].Properties["rust"].(map[string]interface{})
+	assert.Equal(t, "my-app", rust["crate_name"])
+	assert.Equal(t, []string{"cli", "tls"}, rust["features"])
+	assert.Equal(t, []string{"tls"}, rust["default_features"])
+	assert.Equal(t, []parsers.CargoArtifact{
+		{Kind: "lib", Name: "my_app", Path: "src/lib.rs", CrateTypes: []string{"cdylib", "rlib"}},
+		{Kind: "bin", Name: "my-app-cli", Path: "src/cli.rs", RequiredFeatures: []string{"cli"}},
+	}, rust["targets"])
+}
+
+func TestDetector_Detect_CargoWorkspaceMembers(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/mock/Cargo.toml": `[workspace]
+members = ["crates/*", "tools/gen"]
+exclude = ["crates/legacy"]
+resolver = "2"
+`,
+	}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "Cargo.toml"}}, "/mock", "/mock", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, &parsers.CargoWorkspace{
+		Members: []string{"crates/*", "tools/gen"}, Exclude: []string{"crates/legacy"}, Resolver: "2",
+	}, results[0].Properties["cargo_workspace"])
+}
@@ -0,0 +1,50 @@
+package rust
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// tauriConfigFiles are the Tauri configuration files, next to the Cargo.toml
+// of the Rust side of the app (conventionally src-tauri/).
+var tauriConfigFiles = []string{"tauri.conf.json", "tauri.conf.json5", "Tauri.toml"}
+
+// detectTauri adds a "desktop" property to the crate of a Tauri app with the
+// bundle settings of its configuration. The scanner later links the
+// component of the JavaScript frontend to this one.
+func detectTauri(files []types.File, currentPath string, provider types.Provider, payload *types.Payload) {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file.Name] = true
+	}
+	parser := parsers.NewDesktopParser()
+	for _, name := range tauriConfigFiles {
+		if !present[name] {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, name))
+		if err != nil {
+			continue
+		}
+		var app *parsers.DesktopApp
+		if name == "Tauri.toml" {
+			app = parser.ParseTauriToml(string(content))
+		} else {
+			app = parser.ParseTauriConfig(content)
+		}
+		if app == nil {
+			continue
+		}
+		app.Config = name
+		for _, dep := range payload.Dependencies {
+			if dep.Name == "tauri" {
+				app.FrameworkVersion = dep.Version
+			}
+		}
+		payload.Properties["desktop"] = app
+		payload.AddTech("tauri", "matched file: "+name)
+		return
+	}
+}
@@ -0,0 +1,45 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect_Tauri(t *testing.T) {
+	provider := &MockProvider{files: map[string]string{
+		"/project/src-tauri/Cargo.toml": `[package]
+name = "myapp"
+version = "0.1.0"
+
+[dependencies]
+tauri = { version = "2.1.1", features = [] }
+serde = "1.0"
+`,
+		"/project/src-tauri/tauri.conf.json": `{
+  "productName": "myapp",
+  "identifier": "com.example.myapp",
+  "build": {"frontendDist": "../dist"},
+  "bundle": {"targets": ["msi", "dmg"]}
+}`,
+	}}
+	files := []types.File{
+		{Name: "Cargo.toml", Type: "file"},
+		{Name: "tauri.conf.json", Type: "file"},
+	}
+
+	results := (&Detector{}).Detect(files, "/project/src-tauri", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	app, ok := results[0].Properties["desktop"].(*parsers.DesktopApp)
+	require.True(t, ok, "expected desktop property")
+
+	assert.Equal(t, parsers.DesktopFrameworkTauri, app.Framework)
+	assert.Equal(t, "tauri.conf.json", app.Config)
+	assert.Equal(t, "2.1.1", app.FrameworkVersion)
+	assert.Equal(t, "../dist", app.FrontendDist)
+	assert.Equal(t, map[string][]string{"windows": {"msi"}, "macos": {"dmg"}}, app.Targets)
+	assert.Contains(t, results[0].Techs, "tauri")
+}
@@ -0,0 +1,115 @@
+// Package salesforce detects Salesforce projects: Salesforce DX projects
+// (sfdx-project.json) and Metadata API source folders (package.xml).
+package salesforce
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// metadataFolders are Metadata API source folders; a package.xml next to one
+// of them is the root of a (pre-DX) Salesforce project.
+var metadataFolders = map[string]bool{
+	"classes": true, "triggers": true, "objects": true, "lwc": true, "aura": true, "pages": true,
+}
+
+// Detector implements Salesforce project detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "salesforce" }
+
+// Detect reports a salesforce component for sfdx-project.json (package
+// directories, source API version, namespace) and for a Metadata API source
+// folder. Other package.xml manifests (e.g. manifest/package.xml of a DX
+// project) are merged into the enclosing component under
+// salesforce_manifests.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewSalesforceParser()
+	hasMetadataFolders := false
+	for _, file := range files {
+		if file.Type == "dir" && metadataFolders[file.Name] {
+			hasMetadataFolders = true
+		}
+	}
+	for _, file := range files {
+		if file.Name == "sfdx-project.json" {
+			if payload := detectSFDXProject(currentPath, basePath, provider, parser); payload != nil {
+				return []*types.Payload{payload}
+			}
+		}
+	}
+	for _, file := range files {
+		if file.Name == "package.xml" {
+			if payload := detectManifest(currentPath, basePath, provider, parser, hasMetadataFolders); payload != nil {
+				return []*types.Payload{payload}
+			}
+		}
+	}
+	return nil
+}
+
+func detectSFDXProject(currentPath, basePath string, provider types.Provider, parser *parsers.SalesforceParser) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "sfdx-project.json"))
+	if err != nil {
+		return nil
+	}
+	project := parser.ParseSFDXProject(content)
+	if project == nil {
+		return nil
+	}
+	name := project.Name
+	if name == "" {
+		name = filepath.Base(currentPath)
+	}
+	payload := newSalesforcePayload(name, types.CalculateRelativePath("sfdx-project.json", currentPath, basePath))
+	payload.AddTech("sfdx", "matched file: sfdx-project.json")
+	if project.SourceAPIVersion != "" {
+		payload.SetComponentProperty("salesforce", "api_version", project.SourceAPIVersion)
+	}
+	if project.Namespace != "" {
+		payload.SetComponentProperty("salesforce", "namespace", project.Namespace)
+	}
+	payload.SetComponentProperty("salesforce", "package_directories", project.PackageDirectories)
+	return payload
+}
+
+// detectManifest returns a salesforce component for a Metadata API source
+// folder, or else a virtual payload carrying the manifest.
+func detectManifest(currentPath, basePath string, provider types.Provider, parser *parsers.SalesforceParser, sourceFolder bool) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "package.xml"))
+	if err != nil {
+		return nil
+	}
+	manifest := parser.ParsePackageXML(content)
+	if manifest == nil {
+		return nil
+	}
+	manifest.File = types.CalculateRelativePath("package.xml", currentPath, basePath)
+
+	if !sourceFolder {
+		payload := types.NewPayloadWithPath("virtual", manifest.File)
+		payload.Properties["salesforce_manifests"] = []interface{}{manifest}
+		return payload
+	}
+	payload := newSalesforcePayload(filepath.Base(currentPath), manifest.File)
+	if manifest.APIVersion != "" {
+		payload.SetComponentProperty("salesforce", "api_version", manifest.APIVersion)
+	}
+	payload.Properties["salesforce_manifests"] = []interface{}{manifest}
+	return payload
+}
+
+func newSalesforcePayload(name, path string) *types.Payload {
+	payload := types.NewPayloadWithPath(name, path)
+	payload.SetComponentType("salesforce")
+	payload.AddPrimaryTech("salesforce")
+	return payload
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,130 @@
+package salesforce
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Detect_SFDXProject(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/sfdx-project.json": []byte(`{
+  "packageDirectories": [{"path": "force-app", "default": true}],
+  "name": "myapp",
+  "sourceApiVersion": "59.0"
+}`),
+	}}
+	files := []types.File{
+		{Name: "sfdx-project.json", Type: "file"},
+		{Name: "force-app", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "myapp", payload.Name)
+	assert.Equal(t, "salesforce", payload.ComponentType)
+	assert.Contains(t, payload.Techs, "sfdx")
+	props := payload.Properties["salesforce"].(map[string]interface{})
+	assert.Equal(t, "59.0", props["api_version"])
+	dirs := props["package_directories"].([]parsers.SFDXPackageDirectory)
+	require.Len(t, dirs, 1)
+	assert.Equal(t, "force-app", dirs[0].Path)
+}
+
+const testManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types><members>*</members><name>ApexClass</name></types>
+    <version>57.0</version>
+</Package>`
+
+func TestDetector_Detect_MetadataSourceFolder(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/repo/src/package.xml": []byte(testManifest)}}
+	files := []types.File{
+		{Name: "package.xml", Type: "file"},
+		{Name: "classes", Type: "dir"},
+	}
+
+	results := (&Detector{}).Detect(files, "/repo/src", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "src", results[0].Name)
+	assert.Equal(t, "57.0", results[0].Properties["salesforce"].(map[string]interface{})["api_version"])
+}
+
+func TestDetector_Detect_ManifestOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/repo/manifest/package.xml": []byte(testManifest)}}
+	files := []types.File{{Name: "package.xml", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/manifest", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "virtual", results[0].Name)
+	manifests := results[0].Properties["salesforce_manifests"].([]interface{})
+	require.Len(t, manifests, 1)
+	manifest := manifests[0].(*parsers.SalesforceManifest)
+	assert.Equal(t, "/manifest/package.xml", manifest.File)
+	assert.Equal(t, []string{"ApexClass"}, manifest.Types)
+}
+
+func TestDetector_Detect_NotSalesforce(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{"/repo/package.xml": []byte(`<package format="3"><name>my_robot</name></package>`)}}
+	results := (&Detector{}).Detect([]types.File{{Name: "package.xml", Type: "file"}}, "/repo", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
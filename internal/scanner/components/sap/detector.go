@@ -0,0 +1,94 @@
+// Package sap detects SAP development artifacts: abapGit repositories
+// (.abapgit.xml) and SAPUI5/OpenUI5 apps (manifest.json with a "sap.app"
+// section) with the OData services they consume.
+package sap
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements SAP artifact detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "sap" }
+
+// Detect reports an abap component for an abapGit repository. A UI5
+// manifest is merged into the enclosing component (usually the package.json
+// of the app) under sapui5_apps.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	parser := parsers.NewSAPParser()
+	var results []*types.Payload
+	for _, file := range files {
+		var payload *types.Payload
+		switch file.Name {
+		case ".abapgit.xml":
+			payload = detectAbapGit(currentPath, basePath, provider, parser)
+		case "manifest.json":
+			payload = detectUI5Manifest(currentPath, basePath, provider, parser)
+		}
+		if payload != nil {
+			results = append(results, payload)
+		}
+	}
+	return results
+}
+
+func detectAbapGit(currentPath, basePath string, provider types.Provider, parser *parsers.SAPParser) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, ".abapgit.xml"))
+	if err != nil {
+		return nil
+	}
+	repo := parser.ParseAbapGitXML(content)
+	if repo == nil {
+		return nil
+	}
+	payload := types.NewPayloadWithPath(filepath.Base(currentPath), types.CalculateRelativePath(".abapgit.xml", currentPath, basePath))
+	payload.SetComponentType("abap")
+	payload.AddPrimaryTech("abap")
+	payload.AddTech("abapgit", "matched file: .abapgit.xml")
+	if repo.MasterLanguage != "" {
+		payload.SetComponentProperty("abap", "master_language", repo.MasterLanguage)
+	}
+	if repo.StartingFolder != "" {
+		payload.SetComponentProperty("abap", "starting_folder", repo.StartingFolder)
+	}
+	if repo.FolderLogic != "" {
+		payload.SetComponentProperty("abap", "folder_logic", repo.FolderLogic)
+	}
+	return payload
+}
+
+// detectUI5Manifest returns a virtual payload carrying the UI5 app, with the
+// sapui5 tech and, when the app consumes OData services, the odata tech.
+func detectUI5Manifest(currentPath, basePath string, provider types.Provider, parser *parsers.SAPParser) *types.Payload {
+	content, err := provider.ReadFile(filepath.Join(currentPath, "manifest.json"))
+	if err != nil {
+		return nil
+	}
+	app := parser.ParseUI5Manifest(content)
+	if app == nil {
+		return nil
+	}
+	app.File = types.CalculateRelativePath("manifest.json", currentPath, basePath)
+
+	payload := types.NewPayloadWithPath("virtual", app.File)
+	payload.AddTech("sapui5", "matched file: manifest.json (sap.app)")
+	for _, source := range app.DataSources {
+		if strings.HasPrefix(source.Type, "OData") || source.Type == "" && strings.Contains(source.URI, "/odata") {
+			payload.AddTech("odata", "manifest.json data source: "+source.Name)
+			break
+		}
+	}
+	payload.Properties["sapui5_apps"] = []interface{}{app}
+	return payload
+}
+
+func init() {
+	components.Register(&Detector{})
+}
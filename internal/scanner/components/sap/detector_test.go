@@ -0,0 +1,129 @@
+package sap
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Detect_AbapGit(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/abap/.abapgit.xml": []byte(`<?xml version="1.0" encoding="utf-8"?>
+<asx:abap xmlns:asx="http://www.sap.com/abapxml" version="1.0">
+ <asx:values>
+  <DATA>
+   <MASTER_LANGUAGE>E</MASTER_LANGUAGE>
+   <STARTING_FOLDER>/src/</STARTING_FOLDER>
+   <FOLDER_LOGIC>PREFIX</FOLDER_LOGIC>
+  </DATA>
+ </asx:values>
+</asx:abap>`),
+	}}
+	files := []types.File{{Name: ".abapgit.xml", Type: "file"}, {Name: "src", Type: "dir"}}
+
+	results := (&Detector{}).Detect(files, "/repo/abap", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "abap", payload.Name)
+	assert.Equal(t, "abap", payload.ComponentType)
+	assert.Equal(t, []string{"/abap/.abapgit.xml"}, payload.Path)
+	assert.Contains(t, payload.Techs, "abapgit")
+	props := payload.Properties["abap"].(map[string]interface{})
+	assert.Equal(t, "E", props["master_language"])
+	assert.Equal(t, "/src/", props["starting_folder"])
+}
+
+func TestDetector_Detect_UI5Manifest(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/webapp/manifest.json": []byte(`{
+  "sap.app": {
+    "id": "com.example.orders",
+    "type": "application",
+    "dataSources": {
+      "mainService": {"uri": "/sap/opu/odata/sap/ZORDERS_SRV/", "type": "OData", "settings": {"odataVersion": "2.0"}}
+    }
+  },
+  "sap.ui5": {"dependencies": {"minUI5Version": "1.120.0", "libs": {"sap.m": {}}}}
+}`),
+	}}
+	files := []types.File{{Name: "manifest.json", Type: "file"}}
+
+	results := (&Detector{}).Detect(files, "/repo/webapp", "/repo", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "virtual", payload.Name)
+	assert.Contains(t, payload.Techs, "sapui5")
+	assert.Contains(t, payload.Techs, "odata")
+	apps := payload.Properties["sapui5_apps"].([]interface{})
+	require.Len(t, apps, 1)
+	app := apps[0].(*parsers.UI5App)
+	assert.Equal(t, "/webapp/manifest.json", app.File)
+	assert.Equal(t, "com.example.orders", app.ID)
+	assert.Equal(t, "1.120.0", app.MinUI5Version)
+}
+
+func TestDetector_Detect_WebAppManifest(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/repo/public/manifest.json": []byte(`{"name": "My App", "short_name": "myapp", "start_url": "/"}`),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "manifest.json", Type: "file"}}, "/repo/public", "/repo", provider, &MockDependencyDetector{})
+	assert.Empty(t, results)
+}
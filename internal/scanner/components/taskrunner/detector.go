@@ -0,0 +1,108 @@
+// Package taskrunner implements Makefile, Taskfile and justfile detection as
+// a plugin-based component detector.
+package taskrunner
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// runnerFiles maps task runner file names to their runner.
+var runnerFiles = map[string]string{
+	"Makefile":           parsers.TaskRunnerMake,
+	"makefile":           parsers.TaskRunnerMake,
+	"GNUmakefile":        parsers.TaskRunnerMake,
+	"Taskfile.yml":       parsers.TaskRunnerTask,
+	"Taskfile.yaml":      parsers.TaskRunnerTask,
+	"taskfile.yml":       parsers.TaskRunnerTask,
+	"taskfile.yaml":      parsers.TaskRunnerTask,
+	"Taskfile.dist.yml":  parsers.TaskRunnerTask,
+	"Taskfile.dist.yaml": parsers.TaskRunnerTask,
+	"justfile":           parsers.TaskRunnerJust,
+	"Justfile":           parsers.TaskRunnerJust,
+	".justfile":          parsers.TaskRunnerJust,
+}
+
+// Detector implements task runner detection
+type Detector struct{}
+
+// Name returns the detector name
+func (d *Detector) Name() string {
+	return "taskrunner"
+}
+
+// Detect lists the targets of Makefiles, Taskfiles and justfiles under
+// properties.tasks and matches the commands their recipes invoke against
+// the rules' cli entries. Tools found this way are added with an
+// "invoked-by-task" reason. Returns a virtual component (merged into parent)
+// when at least one file defines targets.
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	var payload *types.Payload
+	parser := parsers.NewTaskRunnerParser()
+	for _, file := range files {
+		runner, ok := runnerFiles[file.Name]
+		if !ok {
+			continue
+		}
+		content, err := provider.ReadFile(filepath.Join(currentPath, file.Name))
+		if err != nil {
+			continue
+		}
+		tf := parse(parser, runner, string(content))
+		if tf == nil || len(tf.Targets) == 0 {
+			continue
+		}
+		tf.File = types.CalculateRelativePath(file.Name, currentPath, basePath)
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", tf.File)
+		}
+		addInvokedTechs(payload, tf, depDetector)
+		addTaskFile(payload, tf)
+	}
+
+	if payload == nil {
+		return nil
+	}
+	return []*types.Payload{payload}
+}
+
+func parse(parser *parsers.TaskRunnerParser, runner, content string) *parsers.TaskRunnerFile {
+	switch runner {
+	case parsers.TaskRunnerMake:
+		return parser.ParseMakefile(content)
+	case parsers.TaskRunnerJust:
+		return parser.ParseJustfile(content)
+	}
+	tf, err := parser.ParseTaskfile(content)
+	if err != nil {
+		return nil
+	}
+	return tf
+}
+
+// addInvokedTechs adds the techs whose cli rules match an invoked command,
+// with the reason naming the runner and target ("invoked-by-task: make
+// deploy"). Invoked tools are not promoted to primary techs.
+func addInvokedTechs(payload *types.Payload, tf *parsers.TaskRunnerFile, depDetector components.DependencyDetector) {
+	for _, inv := range tf.Invocations {
+		matches := depDetector.MatchDependencies([]string{inv.Command}, parsers.DependencyTypeCLI)
+		for tech := range matches {
+			payload.AddTech(tech, "invoked-by-task: "+tf.Runner+" "+inv.Target)
+		}
+	}
+}
+
+func addTaskFile(payload *types.Payload, tf *parsers.TaskRunnerFile) {
+	if existing, ok := payload.Properties["tasks"].([]interface{}); ok {
+		payload.Properties["tasks"] = append(existing, tf)
+	} else {
+		payload.Properties["tasks"] = []interface{}{tf}
+	}
+}
+
+func init() {
+	components.Register(&Detector{})
+}
@@ -0,0 +1,130 @@
+package taskrunner
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct {
+	cli map[string]string // command -> tech
+}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	matched := map[string][]string{}
+	if depType != "cli" {
+		return matched
+	}
+	for _, dep := range dependencies {
+		if tech, ok := m.cli[dep]; ok {
+			matched[tech] = append(matched[tech], tech+" matched: "+dep)
+		}
+	}
+	return matched
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "taskrunner", (&Detector{}).Name())
+}
+
+func TestDetector_Makefile(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/Makefile": []byte("build:\n\tdocker build -t myorg/myapp .\n\ndeploy: build\n\tkubectl apply -f k8s/\n\tdocker push myorg/myapp\n"),
+	}}
+	depDetector := &MockDependencyDetector{cli: map[string]string{"docker": "docker", "kubectl": "kubernetes"}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "Makefile"}, {Name: "main.go"}}, "/project", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	payload := results[0]
+
+	assert.Equal(t, "virtual", payload.Name)
+	assert.ElementsMatch(t, []string{"docker", "kubernetes"}, payload.Techs)
+	assert.ElementsMatch(t, []string{"invoked-by-task: make build", "invoked-by-task: make deploy"}, payload.Reason["docker"])
+	assert.Equal(t, []string{"invoked-by-task: make deploy"}, payload.Reason["kubernetes"])
+
+	tasks, ok := payload.Properties["tasks"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, tasks, 1)
+	tf := tasks[0].(*parsers.TaskRunnerFile)
+	assert.Equal(t, "/Makefile", tf.File)
+	assert.Equal(t, []string{"build", "deploy"}, tf.Targets)
+}
+
+func TestDetector_TaskfileAndJustfile(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/sub/Taskfile.yml": []byte("version: '3'\ntasks:\n  plan:\n    cmds:\n      - terraform plan\n"),
+		"/project/sub/justfile":     []byte("test:\n    cargo test\n"),
+	}}
+	depDetector := &MockDependencyDetector{cli: map[string]string{"terraform": "terraform"}}
+
+	results := (&Detector{}).Detect([]types.File{{Name: "Taskfile.yml"}, {Name: "justfile"}}, "/project/sub", "/project", provider, depDetector)
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"terraform"}, results[0].Techs)
+	assert.Equal(t, []string{"invoked-by-task: task plan"}, results[0].Reason["terraform"])
+
+	tasks := results[0].Properties["tasks"].([]interface{})
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "/sub/Taskfile.yml", tasks[0].(*parsers.TaskRunnerFile).File)
+	assert.Equal(t, parsers.TaskRunnerJust, tasks[1].(*parsers.TaskRunnerFile).Runner)
+}
+
+func TestDetector_NoTargets(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/Makefile":     []byte("VERSION := 1.0\ninclude common.mk\n"),
+		"/project/Taskfile.yml": []byte("tasks: [unclosed"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "Makefile"}, {Name: "Taskfile.yml"}}, "/project", "/project", provider, &MockDependencyDetector{})
+	assert.Nil(t, results)
+}
@@ -0,0 +1,79 @@
+// Package zig detects Zig projects (build.zig + build.zig.zon).
+package zig
+
+import (
+	"path/filepath"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/providers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Detector implements Zig component detection.
+type Detector struct{}
+
+// Name returns the detector name.
+func (d *Detector) Name() string { return "zig" }
+
+// Detect scans for Zig projects (build.zig).
+func (d *Detector) Detect(files []types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) []*types.Payload {
+	for _, file := range files {
+		if file.Name == "build.zig" {
+			return []*types.Payload{d.detectBuildZig(file, currentPath, basePath, provider, depDetector)}
+		}
+	}
+	return nil
+}
+
+// detectBuildZig creates the component of a build.zig project. Name,
+// version, minimum Zig version and dependencies come from build.zig.zon when
+// it exists; a plain build.zig project has none of them.
+func (d *Detector) detectBuildZig(file types.File, currentPath, basePath string, provider types.Provider, depDetector components.DependencyDetector) *types.Payload {
+	pkg := &parsers.ZigPackage{}
+	if content, err := provider.ReadFile(filepath.Join(currentPath, "build.zig.zon")); err == nil {
+		pkg = parsers.NewZigParser().ParseBuildZigZon(string(content))
+	}
+
+	projectName := pkg.Name
+	if projectName == "" {
+		projectName = filepath.Base(currentPath)
+	}
+
+	relativeFilePath, _ := filepath.Rel(basePath, filepath.Join(currentPath, file.Name))
+	if relativeFilePath == "." {
+		relativeFilePath = "/"
+	} else {
+		relativeFilePath = "/" + relativeFilePath
+	}
+
+	payload := types.NewPayloadWithPath(projectName, relativeFilePath)
+	payload.SetComponentType("zig")
+	payload.AddPrimaryTech("zig")
+	payload.SetComponentProperty("zig", "package_name", projectName)
+	if pkg.Version != "" {
+		payload.SetComponentProperty("zig", "version", pkg.Version)
+	}
+	if pkg.MinimumZigVersion != "" {
+		payload.SetComponentProperty("zig", "minimum_zig_version", pkg.MinimumZigVersion)
+	}
+
+	if len(pkg.Dependencies) > 0 {
+		depNames := make([]string, 0, len(pkg.Dependencies))
+		for _, dep := range pkg.Dependencies {
+			depNames = append(depNames, dep.Name)
+		}
+		depDetector.ApplyMatchesToPayload(payload, depDetector.MatchDependencies(depNames, parsers.DependencyTypeZig))
+		payload.Dependencies = pkg.Dependencies
+	}
+
+	return payload
+}
+
+func init() {
+	components.Register(&Detector{})
+	providers.Register(&providers.PackageProvider{
+		DependencyType:      parsers.DependencyTypeZig,
+		ExtractPackageNames: providers.SinglePropertyExtractor("zig", "package_name"),
+	})
+}
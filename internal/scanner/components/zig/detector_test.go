@@ -0,0 +1,113 @@
+package zig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProvider implements types.Provider for testing
+type MockProvider struct {
+	files map[string][]byte
+}
+
+func (m *MockProvider) ReadFile(path string) ([]byte, error) {
+	if content, exists := m.files[path]; exists {
+		return content, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (m *MockProvider) ListDir(path string) ([]types.File, error) {
+	return nil, nil
+}
+
+func (m *MockProvider) Open(path string) (string, error) {
+	if content, exists := m.files[path]; exists {
+		return string(content), nil
+	}
+	return "", os.ErrNotExist
+}
+
+func (m *MockProvider) Exists(path string) (bool, error) {
+	_, exists := m.files[path]
+	return exists, nil
+}
+
+func (m *MockProvider) IsDir(path string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockProvider) GetBasePath() string {
+	return "/mock"
+}
+
+// MockDependencyDetector implements components.DependencyDetector for testing
+type MockDependencyDetector struct{}
+
+func (m *MockDependencyDetector) MatchDependencies(dependencies []string, depType string) map[string][]string {
+	return map[string][]string{}
+}
+
+func (m *MockDependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
+}
+
+func (m *MockDependencyDetector) ApplyMatchesToPayload(payload *types.Payload, matches map[string][]string) {
+	for tech, reasons := range matches {
+		for _, reason := range reasons {
+			payload.AddTech(tech, reason)
+		}
+	}
+}
+
+func TestDetector_Name(t *testing.T) {
+	assert.Equal(t, "zig", (&Detector{}).Name())
+}
+
+func TestDetector_BuildZigWithZon(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/build.zig": []byte("const std = @import(\"std\");\n"),
+		"/project/build.zig.zon": []byte(`.{
+    .name = "myapp",
+    .version = "0.1.0",
+    .minimum_zig_version = "0.13.0",
+    .dependencies = .{
+        .httpz = .{ .url = "https://example.com/httpz-1.2.0.tar.gz", .hash = "1220bbbb" },
+    },
+}`),
+	}}
+	files := []types.File{{Name: "build.zig"}, {Name: "build.zig.zon"}}
+
+	results := (&Detector{}).Detect(files, "/project", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	payload := results[0]
+	assert.Equal(t, "myapp", payload.Name)
+	assert.Equal(t, "zig", payload.ComponentType)
+
+	props := payload.Properties["zig"].(map[string]interface{})
+	assert.Equal(t, "0.1.0", props["version"])
+	assert.Equal(t, "0.13.0", props["minimum_zig_version"])
+
+	require.Len(t, payload.Dependencies, 1)
+	assert.Equal(t, "httpz", payload.Dependencies[0].Name)
+	assert.Equal(t, "1.2.0", payload.Dependencies[0].Version)
+}
+
+func TestDetector_BuildZigOnly(t *testing.T) {
+	provider := &MockProvider{files: map[string][]byte{
+		"/project/tools/build.zig": []byte("const std = @import(\"std\");\n"),
+	}}
+	results := (&Detector{}).Detect([]types.File{{Name: "build.zig"}}, "/project/tools", "/project", provider, &MockDependencyDetector{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "tools", results[0].Name)
+	assert.Equal(t, []string{"/tools/build.zig"}, results[0].Path)
+	assert.Empty(t, results[0].Dependencies)
+}
+
+func TestDetector_ZonWithoutBuildZig(t *testing.T) {
+	results := (&Detector{}).Detect([]types.File{{Name: "build.zig.zon"}}, "/project", "/project", &MockProvider{files: map[string][]byte{}}, &MockDependencyDetector{})
+	assert.Nil(t, results)
+}
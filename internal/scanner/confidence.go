@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Confidence of each kind of evidence, strongest first. A tech backed by
+// several kinds of evidence scores higher than by any one of them alone.
+const (
+	ConfidenceDependency = 0.95 // a declared dependency, image, action or invoked command matched a rule
+	ConfidenceFile       = 0.85 // a manifest or config file matched, or a detector parsed one
+	ConfidenceContent    = 0.75 // file content matched a rule's pattern
+	ConfidenceImplied    = 0.5  // implied by another detected tech (--resolve-implied add)
+	ConfidenceExtension  = 0.4  // only a file extension matched
+	ConfidenceDotenv     = 0.3  // only an environment variable name in a dotenv file matched
+)
+
+// contentReasonPrefixes start the reasons of content matchers (regex,
+// json-path, yaml-path and xml-path).
+var contentReasonPrefixes = []string{"content matched: ", "json path ", "yaml-path ", "matched xml-path "}
+
+// dependencyReasonPrefixes start the reasons of detectors matching
+// dependency-like evidence other than through DependencyDetector.
+var dependencyReasonPrefixes = []string{"matched: ", "invoked-by-", "imported-by-", "github action matched"}
+
+// evidenceConfidence returns the confidence of the evidence a detection
+// reason records. Reasons not naming a kind of evidence come from component
+// detectors parsing manifests and count as file evidence.
+func evidenceConfidence(reason string) float64 {
+	switch {
+	case strings.HasPrefix(reason, "implied by "):
+		return ConfidenceImplied
+	case strings.Contains(reason, " matched env: "):
+		return ConfidenceDotenv
+	case strings.HasPrefix(reason, "matched extension: "):
+		return ConfidenceExtension
+	case hasAnyPrefix(reason, contentReasonPrefixes):
+		return ConfidenceContent
+	case strings.HasPrefix(reason, "matched file: "):
+		return ConfidenceFile
+	case hasAnyPrefix(reason, dependencyReasonPrefixes), strings.Contains(reason, " matched: "):
+		return ConfidenceDependency
+	}
+	return ConfidenceFile
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	return slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(s, prefix) })
+}
+
+// techConfidence combines the evidence of a tech's reasons: each kind of
+// evidence counts once, as independent chances of the detection being
+// right. Techs without reasons were detected by a component detector.
+func techConfidence(reasons []string) float64 {
+	if len(reasons) == 0 {
+		return ConfidenceFile
+	}
+	seen := make(map[float64]bool)
+	miss := 1.0
+	for _, reason := range reasons {
+		c := evidenceConfidence(reason)
+		if !seen[c] {
+			seen[c] = true
+			miss *= 1 - c
+		}
+	}
+	return math.Round((1-miss)*100) / 100
+}
+
+// SetMinConfidence sets the --min-confidence threshold; techs scoring
+// below it are dropped from the output. Zero keeps every tech.
+func (s *Scanner) SetMinConfidence(min float64) {
+	s.minConfidence = min
+}
+
+// scoreTechs records the confidence of every tech of every component not
+// scored yet and drops the techs scoring below the minimum confidence.
+// Components left with nothing but their reasons, as the implicit ones
+// created for a dropped tech are, are removed along with the edges to them.
+// It reports whether p lost all its techs to the threshold.
+func (s *Scanner) scoreTechs(p *types.Payload) bool {
+	for _, tech := range slices.Concat(p.Techs, p.Tech) {
+		if _, scored := p.Confidence[tech]; !scored {
+			p.SetTechConfidence(tech, techConfidence(p.Reason[tech]))
+		}
+	}
+	hadTechs := len(p.Techs)+len(p.Tech) > 0
+	for tech, score := range p.Confidence {
+		if score < s.minConfidence {
+			p.RemoveTech(tech)
+		}
+	}
+
+	var removed []*types.Payload
+	p.Children = slices.DeleteFunc(p.Children, func(child *types.Payload) bool {
+		if s.scoreTechs(child) && isHollowComponent(child) {
+			removed = append(removed, child)
+			return true
+		}
+		return false
+	})
+	if len(removed) > 0 {
+		p.Edges = slices.DeleteFunc(p.Edges, func(e types.Edge) bool { return slices.Contains(removed, e.Target) })
+	}
+	return hadTechs && len(p.Techs)+len(p.Tech) == 0
+}
+
+// isHollowComponent reports whether p has no children, dependencies or
+// languages of its own.
+func isHollowComponent(p *types.Payload) bool {
+	return len(p.Children) == 0 && len(p.Dependencies) == 0 && len(p.Languages) == 0
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvidenceConfidence(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   float64
+	}{
+		{"react matched: ^react$", ConfidenceDependency},
+		{"matched: postgres", ConfidenceDependency},
+		{"invoked-by-task: make lint", ConfidenceDependency},
+		{"github action matched", ConfidenceDependency},
+		{"matched file: package.json", ConfidenceFile},
+		{"framework: net8.0", ConfidenceFile},
+		{"content matched: import\\s+react", ConfidenceContent},
+		{"json path $.dependencies.react matched: 18", ConfidenceContent},
+		{"matched extension: .py", ConfidenceExtension},
+		{"redis matched env: REDIS_URL", ConfidenceDotenv},
+		{"implied by nextjs", ConfidenceImplied},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, evidenceConfidence(tt.reason), tt.reason)
+	}
+}
+
+func TestTechConfidence_CombinesKindsOfEvidenceOnce(t *testing.T) {
+	assert.Equal(t, ConfidenceFile, techConfidence(nil))
+	assert.Equal(t, ConfidenceDotenv, techConfidence([]string{"redis matched env: REDIS_URL", "redis matched env: REDIS_HOST"}))
+	// 1 - (1-0.4)*(1-0.3)
+	assert.Equal(t, 0.58, techConfidence([]string{"matched extension: .rb", "ruby matched env: RUBY_VERSION"}))
+}
+
+func TestScoreTechs_DropsTechsBelowMinimum(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.AddTech("python", "matched extension: .py")
+	root.AddTech("django", "django matched: ^django$")
+	db := types.NewPayload("Redis", root.Path)
+	db.AddPrimaryTech("redis")
+	db.SetTechConfidence("redis", ConfidenceDotenv)
+	root.AddChild(db)
+	root.AddEdges(db)
+	app := types.NewPayloadWithPath("app", "/package.json")
+	app.AddTech("nodejs", "matched file: package.json")
+	root.AddChild(app)
+
+	s := &Scanner{}
+	s.SetMinConfidence(0.5)
+	s.scoreTechs(root)
+
+	assert.Equal(t, []string{"django"}, root.Techs)
+	assert.Equal(t, map[string]float64{"django": ConfidenceDependency}, root.Confidence)
+	assert.Equal(t, []*types.Payload{app}, root.Children, "hollow component of a dropped tech is removed")
+	assert.Empty(t, root.Edges)
+	assert.Equal(t, map[string]float64{"nodejs": ConfidenceFile}, app.Confidence)
+}
+
+func TestScoreTechs_KeepsEverythingWithoutMinimum(t *testing.T) {
+	p := types.NewPayloadWithPath("main", "/")
+	p.AddTech("python", "matched extension: .py")
+
+	(&Scanner{}).scoreTechs(p)
+
+	assert.Equal(t, []string{"python"}, p.Techs)
+	assert.Equal(t, ConfidenceExtension, p.Confidence["python"])
+}
@@ -0,0 +1,383 @@
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Config audit checks, the ids of the findings of the config_audit section.
+const (
+	AuditCommittedSecret   = "committed-secret"
+	AuditCommittedEnvFile  = "committed-env-file"
+	AuditHardcodedEndpoint = "hardcoded-endpoint"
+	AuditMissingEnvExample = "missing-env-example"
+	AuditDeadConfig        = "dead-config"
+)
+
+// Config audit finding severities.
+const (
+	AuditSeverityError   = "error"
+	AuditSeverityWarning = "warning"
+	AuditSeverityInfo    = "info"
+)
+
+// maxAuditLocations caps the hardcoded endpoints and committed secrets
+// listed per component; findings still count all of them.
+const maxAuditLocations = 20
+
+// maxAuditFileSize skips larger files, which are data rather than code or
+// configuration.
+const maxAuditFileSize = 512 * 1024
+
+// auditCodeExtensions are the source files checked for environment reads and
+// hardcoded endpoints.
+var auditCodeExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".jsx": true, ".ts": true, ".tsx": true, ".py": true,
+	".java": true, ".kt": true, ".scala": true, ".cs": true, ".go": true, ".rb": true, ".php": true,
+	".rs": true, ".swift": true,
+}
+
+// auditConfigExtensions are the configuration files checked for committed
+// secrets, along with dotenv files and private keys.
+var auditConfigExtensions = map[string]bool{
+	".yml": true, ".yaml": true, ".properties": true, ".json": true, ".toml": true, ".ini": true,
+	".conf": true, ".cfg": true, ".xml": true, ".pem": true, ".key": true,
+}
+
+// envReadRegex matches reads of environment variables across languages.
+var envReadRegex = regexp.MustCompile(`process\.env\b|import\.meta\.env\b|os\.environ\b|os\.getenv\(|os\.(Getenv|LookupEnv)\(|` +
+	`System\.getenv\(|Environment\.GetEnvironmentVariable\(|\bENV(\[|\.fetch\()|\bgetenv\(|\benv::var\(|` +
+	`ProcessInfo\.processInfo\.environment|\benv\(\s*['"]`)
+
+// endpointLiteralRegex matches string literals starting with a host and
+// port, with an optional scheme and userinfo: "localhost:5432",
+// "http://10.0.0.5:8080/api". Hosts are localhost, IPv4 addresses and dotted
+// names.
+var endpointLiteralRegex = regexp.MustCompile(`["'` + "`" + `](?:[a-zA-Z][a-zA-Z0-9+.-]*://)?(?:[^\s"'@/]+@)?` +
+	`(localhost|\d{1,3}(?:\.\d{1,3}){3}|[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)+):(\d{2,5})\b`)
+
+// secretAssignmentRegex matches a key naming a secret assigned a value in
+// YAML, properties, INI, TOML, JSON or dotenv syntax.
+var secretAssignmentRegex = regexp.MustCompile(`(?i)^\s*(?:export\s+)?["']?([\w.-]*(?:password|passwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credentials?)[\w.-]*)["']?\s*[:=]\s*["']?([^\s"'#,}]+)`)
+
+// secretReferenceSuffixes are key endings of settings that name or locate a
+// secret rather than hold it, such as secretName or token_url.
+var secretReferenceSuffixes = []string{"name", "ref", "file", "path", "url", "uri", "endpoint", "env", "type", "length", "policy", "header", "expiry", "ttl"}
+
+// secretPlaceholders are value fragments of example or templated values.
+var secretPlaceholders = []string{"${", "{{", "%(", "<", "changeme", "change_me", "example", "xxx", "your", "placeholder", "***", "todo", "dummy", "secret"}
+
+var privateKeyMarker = []byte("PRIVATE KEY-----")
+
+// ConfigAuditInfo is the config_audit section of a component: how it reads
+// its configuration and the 12-factor hygiene problems found, summarized as
+// findings.
+type ConfigAuditInfo struct {
+	EnvReads           int                `json:"env_reads"` // source files reading environment variables
+	HardcodedEndpoints []ConfigAuditMatch `json:"hardcoded_endpoints,omitempty"`
+	CommittedSecrets   []ConfigAuditMatch `json:"committed_secrets,omitempty"`
+	EnvFiles           []string           `json:"env_files,omitempty"` // dotenv files holding real values
+	EnvExample         bool               `json:"env_example"`         // a dotenv template such as .env.example exists
+	DeadConfig         []DeadConfigEntry  `json:"dead_config,omitempty"`
+	Findings           []ConfigFinding    `json:"findings,omitempty"`
+
+	endpoints, secrets int // totals, including those over maxAuditLocations
+}
+
+// ConfigAuditMatch locates a hardcoded endpoint (Value is host:port) or a
+// committed secret (Key is the setting; its value is never reported).
+type ConfigAuditMatch struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// DeadConfigEntry is a dotenv variable configuring a tech for which the
+// scan found no other evidence: no dependency, file or code anywhere in the
+// repository.
+type DeadConfigEntry struct {
+	File     string `json:"file"`
+	Variable string `json:"variable"`
+	Tech     string `json:"tech"`
+}
+
+// ConfigFinding is an actionable result of a config audit check.
+type ConfigFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// SetConfigAudit enables the config audit (--config-audit), which adds a
+// config_audit section to components.
+func (s *Scanner) SetConfigAudit(enabled bool) {
+	s.auditConfig = enabled
+}
+
+// recordConfigAudit checks a file for the config audit of its component:
+// environment reads and hardcoded endpoints in code, committed secrets in
+// configuration, and dotenv files. Test files are not audited.
+func (s *Scanner) recordConfigAudit(ctx *types.Payload, filePath string, content []byte) {
+	if !s.auditConfig || len(content) > maxAuditFileSize {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	name := filepath.Base(filePath)
+	segments := strings.Split(rel, "/")
+	if testFileRegex.MatchString(name) || testDirOf(segments[:len(segments)-1], true) != "" {
+		return
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	switch {
+	case auditCodeExtensions[ext]:
+		s.auditCode(ctx, rel, content)
+	case strings.HasPrefix(name, ".env"):
+		s.auditDotenv(ctx, rel, name, content)
+	case auditConfigExtensions[ext] && !strings.Contains(name, "lock"):
+		s.configAuditFor(ctx).addSecrets(rel, content)
+	}
+}
+
+func (s *Scanner) configAuditFor(ctx *types.Payload) *ConfigAuditInfo {
+	if s.configAudit == nil {
+		s.configAudit = make(map[*types.Payload]*ConfigAuditInfo)
+	}
+	info := s.configAudit[ctx]
+	if info == nil {
+		info = &ConfigAuditInfo{}
+		s.configAudit[ctx] = info
+	}
+	return info
+}
+
+func (s *Scanner) auditCode(ctx *types.Payload, file string, content []byte) {
+	readsEnv := envReadRegex.Match(content)
+	hasEndpoint := endpointLiteralRegex.Match(content)
+	if !readsEnv && !hasEndpoint {
+		return
+	}
+	info := s.configAuditFor(ctx)
+	if readsEnv {
+		info.EnvReads++
+	}
+	if hasEndpoint {
+		info.addEndpoints(file, content)
+	}
+}
+
+func (s *Scanner) auditDotenv(ctx *types.Payload, file, name string, content []byte) {
+	environment, _, ok := parsers.DotenvEnvironment(name)
+	if !ok {
+		return
+	}
+	info := s.configAuditFor(ctx)
+	if environment == parsers.EnvironmentExample {
+		info.EnvExample = true
+		return
+	}
+	info.EnvFiles = append(info.EnvFiles, file)
+	info.addSecrets(file, content)
+}
+
+// addEndpoints records the host:port literals of code lines, skipping
+// comment lines.
+func (info *ConfigAuditInfo) addEndpoints(file string, content []byte) {
+	for i, line := range strings.Split(string(content), "\n") {
+		if isCommentLine(line) {
+			continue
+		}
+		for _, m := range endpointLiteralRegex.FindAllStringSubmatch(line, -1) {
+			info.endpoints++
+			if len(info.HardcodedEndpoints) < maxAuditLocations {
+				info.HardcodedEndpoints = append(info.HardcodedEndpoints, ConfigAuditMatch{File: file, Line: i + 1, Value: m[1] + ":" + m[2]})
+			}
+		}
+	}
+}
+
+// addSecrets records the settings of a configuration file that hold a
+// secret-looking value, and private keys.
+func (info *ConfigAuditInfo) addSecrets(file string, content []byte) {
+	if bytes.Contains(content, privateKeyMarker) {
+		info.addSecret(ConfigAuditMatch{File: file, Line: lineOf(content, privateKeyMarker), Key: "private-key"})
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		m := secretAssignmentRegex.FindStringSubmatch(line)
+		if m != nil && !isSecretReference(m[1]) && looksLikeSecret(m[2]) {
+			info.addSecret(ConfigAuditMatch{File: file, Line: i + 1, Key: m[1]})
+		}
+	}
+}
+
+func (info *ConfigAuditInfo) addSecret(match ConfigAuditMatch) {
+	info.secrets++
+	if len(info.CommittedSecrets) < maxAuditLocations {
+		info.CommittedSecrets = append(info.CommittedSecrets, match)
+	}
+}
+
+func isCommentLine(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "*") || strings.HasPrefix(line, "/*")
+}
+
+func isSecretReference(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range secretReferenceSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeSecret reports whether a value could be a real credential: at
+// least 8 characters mixing letters with digits or symbols, and no
+// placeholder or reference syntax. Plain words ("Password" labels in
+// translation files) and booleans are not.
+func looksLikeSecret(value string) bool {
+	lower := strings.ToLower(value)
+	if len(value) < 8 || strings.HasPrefix(value, "$") {
+		return false
+	}
+	for _, placeholder := range secretPlaceholders {
+		if strings.Contains(lower, placeholder) {
+			return false
+		}
+	}
+	var letters, others bool
+	for _, r := range value {
+		if unicode.IsLetter(r) {
+			letters = true
+		} else {
+			others = true
+		}
+	}
+	return letters && others
+}
+
+func lineOf(content, marker []byte) int {
+	return bytes.Count(content[:bytes.Index(content, marker)], []byte("\n")) + 1
+}
+
+// attachConfigAudit adds a "config_audit" property to every component with
+// configuration evidence when the audit is enabled.
+func (s *Scanner) attachConfigAudit(payload *types.Payload) {
+	if !s.auditConfig {
+		return
+	}
+	s.walkConfigAudit(payload, envOnlyTechs(payload))
+}
+
+func (s *Scanner) walkConfigAudit(payload *types.Payload, envOnly map[string]bool) {
+	if dead := deadConfig(payload, envOnly); len(dead) > 0 {
+		s.configAuditFor(payload).DeadConfig = dead
+	}
+	if info := s.configAudit[payload]; info != nil {
+		info.Findings = info.findings()
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["config_audit"] = info
+	}
+	for _, child := range payload.Children {
+		s.walkConfigAudit(child, envOnly)
+	}
+}
+
+// envOnlyTechs returns the techs that the whole scan detected from dotenv
+// variables only. Such configuration is likely left over from a tech the
+// code no longer uses. Only Techs and their reasons count: the
+// components made for such techs (a Redis component next to the code) list
+// them in Tech without evidence of their own.
+func envOnlyTechs(root *types.Payload) map[string]bool {
+	envOnly := make(map[string]bool)
+	evidenced := make(map[string]bool)
+	walkPayloads(root, func(p *types.Payload) {
+		for _, tech := range p.Techs {
+			if len(p.Reason[tech]) == 0 {
+				evidenced[tech] = true
+			}
+			for _, reason := range p.Reason[tech] {
+				if evidenceConfidence(reason) == ConfidenceDotenv {
+					envOnly[tech] = true
+				} else {
+					evidenced[tech] = true
+				}
+			}
+		}
+	})
+	for tech := range evidenced {
+		delete(envOnly, tech)
+	}
+	return envOnly
+}
+
+// deadConfig returns the variables of a component's dotenv files that
+// configure techs detected from dotenv variables only.
+func deadConfig(payload *types.Payload, envOnly map[string]bool) []DeadConfigEntry {
+	if len(envOnly) == 0 {
+		return nil
+	}
+	files, _ := payload.Properties["env_files"].([]interface{})
+	var dead []DeadConfigEntry
+	for _, item := range files {
+		file, ok := item.(*parsers.EnvFileInfo)
+		if !ok {
+			continue
+		}
+		for _, variable := range file.Variables {
+			if envOnly[variable.Tech] {
+				dead = append(dead, DeadConfigEntry{File: file.File, Variable: variable.Name, Tech: variable.Tech})
+			}
+		}
+	}
+	return dead
+}
+
+// findings turns the audit evidence into actionable findings, most severe
+// first.
+func (info *ConfigAuditInfo) findings() []ConfigFinding {
+	var findings []ConfigFinding
+	if info.secrets > 0 {
+		findings = append(findings, ConfigFinding{AuditCommittedSecret, AuditSeverityError,
+			fmt.Sprintf("%d secret value(s) committed in configuration; read them from the environment or a secret store and rotate them", info.secrets)})
+	}
+	if len(info.EnvFiles) > 0 {
+		findings = append(findings, ConfigFinding{AuditCommittedEnvFile, AuditSeverityWarning,
+			fmt.Sprintf("%d dotenv file(s) with real values in the repository (%s); add them to .gitignore and commit a .env.example instead", len(info.EnvFiles), strings.Join(info.EnvFiles, ", "))})
+	}
+	if info.endpoints > 0 {
+		findings = append(findings, ConfigFinding{AuditHardcodedEndpoint, AuditSeverityWarning,
+			fmt.Sprintf("%d hardcoded host:port value(s) in code; read them from environment variables", info.endpoints)})
+	}
+	if len(info.DeadConfig) > 0 {
+		findings = append(findings, ConfigFinding{AuditDeadConfig, AuditSeverityInfo,
+			fmt.Sprintf("%d dotenv variable(s) configure technologies with no dependency or code in the repository (%s); remove them if they are stale", len(info.DeadConfig), strings.Join(info.deadConfigTechs(), ", "))})
+	}
+	if (info.EnvReads > 0 || len(info.EnvFiles) > 0) && !info.EnvExample {
+		findings = append(findings, ConfigFinding{AuditMissingEnvExample, AuditSeverityInfo,
+			"configuration is read from the environment but no .env.example documents the variables; add one"})
+	}
+	return findings
+}
+
+func (info *ConfigAuditInfo) deadConfigTechs() []string {
+	techs := make(map[string]bool)
+	for _, entry := range info.DeadConfig {
+		techs[entry.Tech] = true
+	}
+	return sortedSet(techs)
+}
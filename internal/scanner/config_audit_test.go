@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachConfigAudit(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp", "dependencies": {"express": "^4.18.0"}}`)
+	write("src/server.js", "const port = process.env.PORT || 3000;\n")
+	write("src/cache.js", "// connects to 'localhost:6379' in development\nconst redis = createClient({ url: 'redis://user:pw@localhost:6379/0' });\n")
+	write("src/cache.test.js", "const url = 'http://127.0.0.1:4000';\n")
+	write("config/application.yml", "db:\n  password: s3cr3t-Pa55\n  passwordFile: /run/secrets/db\n  token: ${API_TOKEN}\nlabels:\n  password: Password\n")
+	write(".env", "API_KEY=ak_live_12345678\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	scanner.SetConfigAudit(true)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "config_audit")
+	require.NotNil(t, component, "expected a component with a config_audit section")
+	info, ok := component.Properties["config_audit"].(*ConfigAuditInfo)
+	require.True(t, ok)
+	assert.Equal(t, 1, info.EnvReads)
+	assert.Equal(t, []ConfigAuditMatch{{File: "/src/cache.js", Line: 2, Value: "localhost:6379"}}, info.HardcodedEndpoints)
+	assert.Equal(t, []ConfigAuditMatch{
+		{File: "/.env", Line: 1, Key: "API_KEY"},
+		{File: "/config/application.yml", Line: 2, Key: "password"},
+	}, info.CommittedSecrets)
+	assert.Equal(t, []string{"/.env"}, info.EnvFiles)
+	assert.False(t, info.EnvExample)
+
+	var checks []string
+	for _, finding := range info.Findings {
+		checks = append(checks, finding.Check)
+	}
+	assert.Equal(t, []string{AuditCommittedSecret, AuditCommittedEnvFile, AuditHardcodedEndpoint, AuditMissingEnvExample}, checks)
+}
+
+func TestConfigAuditDeadConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp", "dependencies": {"pg": "^8.11.0"}}`)
+	write(".env.example", "POSTGRES_HOST=localhost\nREDIS_URL=redis://localhost:6379\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	scanner.SetConfigAudit(true)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "config_audit")
+	require.NotNil(t, component, "expected a component with a config_audit section")
+	info, ok := component.Properties["config_audit"].(*ConfigAuditInfo)
+	require.True(t, ok)
+	assert.Equal(t, []DeadConfigEntry{{File: "/.env.example", Variable: "REDIS_URL", Tech: "redis"}}, info.DeadConfig)
+	require.NotEmpty(t, info.Findings)
+	assert.Equal(t, AuditDeadConfig, info.Findings[0].Check)
+	assert.Equal(t, AuditSeverityInfo, info.Findings[0].Severity)
+	assert.Contains(t, info.Findings[0].Message, "(redis)")
+}
+
+func TestConfigAuditDisabledByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "app.py"), []byte("HOST = 'db.example.com:5432'\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+	assert.Nil(t, findComponentWithProperty(result, "config_audit"))
+}
+
+func TestLooksLikeSecret(t *testing.T) {
+	for value, want := range map[string]bool{
+		"s3cr3t-Pa55":      true,
+		"ak_live_12345678": true,
+		"Password":         false,
+		"short1":           false,
+		"${DB_PASSWORD}":   false,
+		"changeme123":      false,
+		"<your-token>":     false,
+		"$DB_PASSWORD":     false,
+	} {
+		assert.Equal(t, want, looksLikeSecret(value), value)
+	}
+}
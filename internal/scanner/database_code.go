@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxSQLClassifyBytes caps the part of a SQL file that is classified; data
+// dumps can be very large and their statements past this point are rarely
+// schema objects.
+const maxSQLClassifyBytes = 2 << 20
+
+// sqlCodeExtensions are the extensions of SQL scripts and Oracle PL/SQL
+// source files (packages, procedures, functions, triggers, types).
+var sqlCodeExtensions = map[string]bool{
+	".sql": true, ".ddl": true, ".pls": true, ".plsql": true, ".pks": true, ".pkb": true, ".pck": true,
+	".prc": true, ".fnc": true, ".trg": true, ".tps": true, ".tpb": true,
+}
+
+// DatabaseCodeInfo is the database_code section of a component: its SQL
+// files by kind and dialect, and the objects they create per schema.
+type DatabaseCodeInfo struct {
+	Files           int                       `json:"files"`
+	DDLFiles        int                       `json:"ddl_files,omitempty"`
+	DMLFiles        int                       `json:"dml_files,omitempty"`
+	ProceduralFiles int                       `json:"procedural_files,omitempty"`
+	Dialects        map[string]int            `json:"dialects,omitempty"`
+	Schemas         map[string]map[string]int `json:"schemas,omitempty"` // schema -> object type -> count
+}
+
+// recordDatabaseCode classifies a SQL file of a component.
+func (s *Scanner) recordDatabaseCode(ctx *types.Payload, filePath string, content []byte) {
+	if !sqlCodeExtensions[strings.ToLower(filepath.Ext(filePath))] {
+		return
+	}
+	if len(content) > maxSQLClassifyBytes {
+		content = content[:maxSQLClassifyBytes]
+	}
+	file := parsers.NewSQLCodeParser().Classify(string(content))
+	if file.Kind == "" {
+		return
+	}
+
+	if s.databaseCode == nil {
+		s.databaseCode = make(map[*types.Payload]*DatabaseCodeInfo)
+	}
+	info := s.databaseCode[ctx]
+	if info == nil {
+		info = &DatabaseCodeInfo{Dialects: make(map[string]int), Schemas: make(map[string]map[string]int)}
+		s.databaseCode[ctx] = info
+	}
+	info.add(file)
+}
+
+func (info *DatabaseCodeInfo) add(file *parsers.SQLFile) {
+	info.Files++
+	switch file.Kind {
+	case parsers.SQLKindDDL:
+		info.DDLFiles++
+	case parsers.SQLKindDML:
+		info.DMLFiles++
+	case parsers.SQLKindProcedural:
+		info.ProceduralFiles++
+	}
+	if file.Dialect != "" {
+		info.Dialects[file.Dialect]++
+	}
+	for _, object := range file.Objects {
+		if info.Schemas[object.Schema] == nil {
+			info.Schemas[object.Schema] = make(map[string]int)
+		}
+		info.Schemas[object.Schema][object.Type]++
+	}
+}
+
+// attachDatabaseCode adds a "database_code" property to every component
+// holding SQL scripts or stored code.
+func (s *Scanner) attachDatabaseCode(payload *types.Payload) {
+	if info := s.databaseCode[payload]; info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["database_code"] = info
+	}
+	for _, child := range payload.Children {
+		s.attachDatabaseCode(child)
+	}
+}
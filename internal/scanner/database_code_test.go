@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachDatabaseCode(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("db/schema.sql", "CREATE TABLE sales.orders (id NUMBER(10), note VARCHAR2(200));\nCREATE SEQUENCE sales.order_seq;\n")
+	write("db/order_api.pkb", "CREATE OR REPLACE PACKAGE BODY sales.order_api AS\n  PROCEDURE close_order IS BEGIN NULL; END;\nEND order_api;\n/\n")
+	write("db/seed.sql", "INSERT INTO sales.orders (id) VALUES (1);\n")
+	write("db/notes.sql", "-- TODO\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	info, ok := result.Properties["database_code"].(*DatabaseCodeInfo)
+	require.True(t, ok, "expected a database_code section on the root component")
+	assert.Equal(t, 3, info.Files)
+	assert.Equal(t, 1, info.DDLFiles)
+	assert.Equal(t, 1, info.DMLFiles)
+	assert.Equal(t, 1, info.ProceduralFiles)
+	assert.Equal(t, 2, info.Dialects["plsql"])
+	assert.Equal(t, map[string]map[string]int{
+		"sales": {"table": 1, "sequence": 1, "package_body": 1},
+	}, info.Schemas)
+}
@@ -2,8 +2,12 @@ package scanner
 
 import (
 	"regexp"
+	"regexp/syntax"
+	"slices"
 	"strings"
+	"sync"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
@@ -12,12 +16,209 @@ type DependencyMatcher struct {
 	Regex *regexp.Regexp
 	Tech  string
 	Type  string
+	match *regexp.Regexp // Regex, ignoring case in case-insensitive ecosystems
+
+	// Version restricts the match to dependency versions in a range; nil
+	// matches any version. See MatchVersionedDependencies.
+	Version *semver.Constraint
+}
+
+// caseInsensitiveTypes are the dependency types whose package names match
+// rule patterns ignoring case: NuGet and PyPI names are case-insensitive,
+// and Maven and Gradle coordinates are spelled in varying case in build
+// files, so they are treated the same.
+var caseInsensitiveTypes = map[string]bool{
+	"nuget":  true,
+	"pypi":   true,
+	"maven":  true,
+	"gradle": true,
 }
 
 // DependencyDetector handles dependency-based technology detection
 type DependencyDetector struct {
-	matchers map[string][]*DependencyMatcher // keyed by dependency type (npm, python, etc.)
-	rules    []types.Rule                    // Store rules for primary tech checking
+	matchers map[string]*matcherSet // keyed by dependency type (npm, python, etc.)
+	rules    []types.Rule           // Store rules for primary tech checking
+}
+
+// matcherSet holds the dependency patterns of one dependency type. The
+// patterns are compiled on first use, so a scan only pays for the
+// ecosystems it actually encounters; the map of sets itself is read-only
+// after construction and safe to share between scan workers.
+//
+// Compiling also indexes the patterns, so a package name is only checked
+// against the patterns that can match it: exact names are looked up in a
+// map, and prefixes (@angular/*) and regexes anchored at the start
+// (/^@angular\//) are filed in a trie under their literal prefix. Only the
+// remaining regexes are run on every name.
+type matcherSet struct {
+	once      sync.Once
+	foldCase  bool // match ignoring case (caseInsensitiveTypes)
+	pending   []pendingMatcher
+	matchers  []*DependencyMatcher
+	exact     map[string][]int // package name -> indexes into matchers
+	prefixes  prefixTrie       // prefixes and anchored regexes by literal prefix
+	unindexed []int            // regexes without a literal prefix
+	versioned []int            // patterns with a version constraint, matched apart
+}
+
+// pendingMatcher is a dependency pattern waiting to be compiled.
+type pendingMatcher struct {
+	tech, depType, pattern, version string
+}
+
+// get returns the compiled matchers, compiling and indexing them on the
+// first call. Invalid patterns and version constraints are skipped.
+func (s *matcherSet) get() []*DependencyMatcher {
+	s.once.Do(func() {
+		s.matchers = make([]*DependencyMatcher, 0, len(s.pending))
+		s.exact = make(map[string][]int)
+		for _, p := range s.pending {
+			matcher, err := s.compile(p)
+			if err != nil {
+				continue
+			}
+			if matcher.Version != nil {
+				s.versioned = append(s.versioned, len(s.matchers))
+			} else {
+				s.index(p.pattern, len(s.matchers))
+			}
+			s.matchers = append(s.matchers, matcher)
+		}
+		s.pending = nil
+	})
+	return s.matchers
+}
+
+func (s *matcherSet) compile(p pendingMatcher) (*DependencyMatcher, error) {
+	regex, err := compileDependencyPattern(p.pattern)
+	if err != nil {
+		return nil, err
+	}
+	m := &DependencyMatcher{Regex: regex, Tech: p.tech, Type: p.depType, match: regex}
+	if s.foldCase {
+		if m.match, err = regexp.Compile("(?i)" + regex.String()); err != nil {
+			return nil, err
+		}
+	}
+	if p.version != "" {
+		if m.Version, err = semver.ParseConstraint(p.version); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// index files the matcher i compiled from pattern.
+func (s *matcherSet) index(pattern string, i int) {
+	var prefix string
+	switch kind, text := parseDependencyPattern(pattern); kind {
+	case patternExact:
+		key := s.key(text)
+		s.exact[key] = append(s.exact[key], i)
+		return
+	case patternPrefix:
+		prefix = text
+	case patternRegex:
+		prefix = anchoredPrefix(text)
+	}
+	if prefix == "" {
+		s.unindexed = append(s.unindexed, i)
+		return
+	}
+	s.prefixes.insert(s.key(prefix), i)
+}
+
+// key is the form of a name or prefix looked up in the indexes.
+func (s *matcherSet) key(name string) string {
+	if s.foldCase {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// candidates returns the indexes of the matchers that can match pkg, in
+// pattern order. Exact names match without running their regex; the others
+// still have to be checked.
+func (s *matcherSet) candidates(pkg string) (exact, regexes []int) {
+	s.get()
+	key := s.key(pkg)
+	regexes = s.prefixes.collect(key, nil)
+	if len(s.unindexed) > 0 {
+		regexes = append(regexes, s.unindexed...)
+	}
+	slices.Sort(regexes)
+	return s.exact[key], regexes
+}
+
+// matches returns the matchers matching pkg, in pattern order.
+func (s *matcherSet) matches(pkg string) []*DependencyMatcher {
+	exact, regexes := s.candidates(pkg)
+	if len(exact) == 0 && len(regexes) == 0 {
+		return nil
+	}
+	indexes := slices.Clone(exact)
+	for _, i := range regexes {
+		if s.matchers[i].match.MatchString(pkg) {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(exact) > 0 && len(indexes) > len(exact) {
+		slices.Sort(indexes)
+	}
+	matched := make([]*DependencyMatcher, len(indexes))
+	for j, i := range indexes {
+		matched[j] = s.matchers[i]
+	}
+	return matched
+}
+
+// prefixTrie maps literal prefixes to the matchers filed under them.
+type prefixTrie struct {
+	children map[byte]*prefixTrie
+	matchers []int
+}
+
+func (t *prefixTrie) insert(prefix string, i int) {
+	node := t
+	for j := 0; j < len(prefix); j++ {
+		if node.children == nil {
+			node.children = make(map[byte]*prefixTrie)
+		}
+		child, ok := node.children[prefix[j]]
+		if !ok {
+			child = &prefixTrie{}
+			node.children[prefix[j]] = child
+		}
+		node = child
+	}
+	node.matchers = append(node.matchers, i)
+}
+
+// collect appends the matchers filed under every prefix of s.
+func (t *prefixTrie) collect(s string, out []int) []int {
+	node := t
+	for j := 0; j < len(s); j++ {
+		if node = node.children[s[j]]; node == nil {
+			break
+		}
+		out = append(out, node.matchers...)
+	}
+	return out
+}
+
+// anchoredPrefix returns the literal text every match of the regex must
+// start with, or "" when the regex is not anchored at the start of the name,
+// starts with something other than a literal, or ignores case.
+func anchoredPrefix(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil || re.Op != syntax.OpConcat || len(re.Sub) < 2 {
+		return ""
+	}
+	first, second := re.Sub[0], re.Sub[1]
+	if first.Op != syntax.OpBeginText || second.Op != syntax.OpLiteral || second.Flags&syntax.FoldCase != 0 {
+		return ""
+	}
+	return string(second.Rune)
 }
 
 // depTypeAliases maps a dependency type to additional types whose matchers
@@ -37,34 +238,27 @@ var depTypeAliases = map[string][]string{
 	"maven":  {"gradle"},
 }
 
-// NewDependencyDetector creates a new dependency detector
+// NewDependencyDetector creates a new dependency detector. Patterns are
+// grouped by dependency type here but only compiled when a type is first
+// matched.
 func NewDependencyDetector(rules []types.Rule) *DependencyDetector {
 	detector := &DependencyDetector{
-		matchers: make(map[string][]*DependencyMatcher),
+		matchers: make(map[string]*matcherSet),
 		rules:    rules,
 	}
 
-	// Compile all dependency patterns from rules
 	for _, rule := range rules {
 		for _, dep := range rule.Dependencies {
-			regex, err := compileDependencyPattern(dep.Name)
-			if err != nil {
-				continue // Skip invalid regex
-			}
-			matcher := &DependencyMatcher{
-				Regex: regex,
-				Tech:  rule.Tech,
-				Type:  dep.Type,
-			}
+			p := pendingMatcher{tech: rule.Tech, depType: dep.Type, pattern: dep.Name, version: dep.Version}
 
 			// Register under the canonical type
-			detector.matchers[dep.Type] = append(detector.matchers[dep.Type], matcher)
+			detector.register(dep.Type, p)
 
 			// Also register under alias types so callers querying e.g.
 			// "gradle" automatically hit "maven" rules and vice versa,
 			// without needing to duplicate entries in every rule YAML.
 			for _, alias := range depTypeAliases[dep.Type] {
-				detector.matchers[alias] = append(detector.matchers[alias], matcher)
+				detector.register(alias, p)
 			}
 		}
 	}
@@ -72,12 +266,46 @@ func NewDependencyDetector(rules []types.Rule) *DependencyDetector {
 	return detector
 }
 
+// register queues p for compilation under depType.
+func (d *DependencyDetector) register(depType string, p pendingMatcher) {
+	set, ok := d.matchers[depType]
+	if !ok {
+		set = &matcherSet{foldCase: caseInsensitiveTypes[depType]}
+		d.matchers[depType] = set
+	}
+	set.pending = append(set.pending, p)
+}
+
+// Kinds of dependency patterns.
+const (
+	patternExact  = iota // a package name
+	patternPrefix        // a name prefix followed by '*', e.g. @angular/*
+	patternRegex         // a regex wrapped in forward slashes
+)
+
+// parseDependencyPattern returns the kind of a rule's dependency name and its
+// text: the name, the prefix without the '*', or the regex without the
+// slashes.
+func parseDependencyPattern(name string) (kind int, text string) {
+	switch {
+	case strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/"):
+		return patternRegex, name[1 : len(name)-1]
+	case strings.HasSuffix(name, "*"):
+		return patternPrefix, strings.TrimSuffix(name, "*")
+	}
+	return patternExact, name
+}
+
 // compileDependencyPattern compiles a dependency name to a regex. Names
-// wrapped in forward slashes (/pattern/) are treated as raw regex patterns;
-// anything else is compiled as an exact match.
+// wrapped in forward slashes (/pattern/) are treated as raw regex patterns,
+// names ending with '*' match the names starting with the text before it,
+// and anything else is compiled as an exact match.
 func compileDependencyPattern(name string) (*regexp.Regexp, error) {
-	if strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/") {
-		return regexp.Compile(name[1 : len(name)-1])
+	switch kind, text := parseDependencyPattern(name); kind {
+	case patternRegex:
+		return regexp.Compile(text)
+	case patternPrefix:
+		return regexp.Compile("^" + regexp.QuoteMeta(text))
 	}
 	return regexp.Compile("^" + regexp.QuoteMeta(name) + "$")
 }
@@ -86,26 +314,69 @@ func compileDependencyPattern(name string) (*regexp.Regexp, error) {
 func (d *DependencyDetector) MatchDependencies(packages []string, depType string) map[string][]string {
 	matched := make(map[string][]string)
 
-	matchers, exists := d.matchers[depType]
-	if !exists {
+	set, ok := d.matchers[depType]
+	if !ok {
 		return matched
 	}
 
 	for _, pkg := range packages {
-		for _, matcher := range matchers {
-			if matcher.Regex.MatchString(pkg) {
-				if _, exists := matched[matcher.Tech]; !exists {
-					matched[matcher.Tech] = []string{}
-				}
-				matched[matcher.Tech] = append(matched[matcher.Tech],
-					matcher.Tech+" matched: "+matcher.Regex.String())
-			}
+		for _, matcher := range set.matches(pkg) {
+			matched[matcher.Tech] = append(matched[matcher.Tech],
+				matcher.Tech+" matched: "+matcher.Regex.String())
 		}
 	}
 
 	return matched
 }
 
+// MatchVersionedDependencies matches dependencies against the patterns with
+// a version constraint, which MatchDependencies leaves out as it only has
+// package names. A rule can so tell major versions of a framework apart
+// (vue <3 is Vue 2). Versions are compared in the dependency's ecosystem;
+// ranges are checked by the version they start at.
+func (d *DependencyDetector) MatchVersionedDependencies(deps []types.Dependency) map[string][]string {
+	matched := make(map[string][]string)
+	for _, dep := range deps {
+		set, ok := d.matchers[dep.Type]
+		if !ok {
+			continue
+		}
+		matchers := set.get()
+		for _, i := range set.versioned {
+			m := matchers[i]
+			if m.match.MatchString(dep.Name) && m.Version.Allows(semver.ForDependencyType(dep.Type), dep.Version) {
+				matched[m.Tech] = append(matched[m.Tech],
+					m.Tech+" matched: "+m.Regex.String()+" "+dep.Version+" ("+m.Version.String()+")")
+			}
+		}
+	}
+	return matched
+}
+
+// PackagesForTech returns the names of the dependencies that match one of
+// tech's dependency patterns, in order of first appearance.
+func (d *DependencyDetector) PackagesForTech(deps []types.Dependency, tech string) []string {
+	var packages []string
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		if seen[dep.Name] {
+			continue
+		}
+		set, ok := d.matchers[dep.Type]
+		if !ok {
+			continue
+		}
+		for _, matcher := range set.matches(dep.Name) {
+			if matcher.Tech == tech {
+				packages = append(packages, dep.Name)
+				seen[dep.Name] = true
+				break
+			}
+		}
+	}
+	return packages
+}
+
 // AddPrimaryTechIfNeeded checks if a tech should be primary and adds it if needed
 func (d *DependencyDetector) AddPrimaryTechIfNeeded(payload *types.Payload, tech string) {
 	// Find the rule for this tech
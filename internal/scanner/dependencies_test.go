@@ -1,10 +1,14 @@
 package scanner
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestDepTypeAliases_GradleMatchesMavenRules verifies that a dependency
@@ -106,3 +110,215 @@ func TestDepTypeAliases_ExplicitBothTypesNoDuplicate(t *testing.T) {
 	}
 	assert.Equal(t, 1, h2Count, "h2 must appear exactly once in payload.Techs")
 }
+
+// TestDependencyDetector_CompilesPatternsPerTypeOnFirstUse verifies that
+// patterns are only compiled for the dependency types that get matched, and
+// that concurrent first use compiles them once.
+func TestDependencyDetector_CompilesPatternsPerTypeOnFirstUse(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "react", Dependencies: []types.Dependency{{Type: "npm", Name: "react"}}},
+		{Tech: "django", Dependencies: []types.Dependency{{Type: "python", Name: "/^django$/"}}},
+		{Tech: "broken", Dependencies: []types.Dependency{{Type: "npm", Name: "/([/"}}},
+	}
+	d := NewDependencyDetector(rules)
+	assert.Nil(t, d.matchers["npm"].matchers, "npm patterns compiled before use")
+	assert.Nil(t, d.matchers["python"].matchers, "python patterns compiled before use")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Contains(t, d.MatchDependencies([]string{"react"}, "npm"), "react")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, d.matchers["npm"].matchers, 1, "invalid pattern should be skipped")
+	assert.Nil(t, d.matchers["python"].matchers, "unused type should stay uncompiled")
+	assert.Empty(t, d.MatchDependencies([]string{"react"}, "cargo"))
+}
+
+func TestAnchoredPrefix(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{`^@angular/`, "@angular/"},
+		{`^org\.springframework\.boot:.*$`, "org.springframework.boot:"},
+		{`^ab?c`, "a"},
+		{`tomcat`, ""},
+		{`(?i)^react`, ""},
+		{`(?m)^react`, ""},
+		{`^(react|preact)`, ""},
+		{`^[a-z]+`, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, anchoredPrefix(tt.pattern), tt.pattern)
+	}
+}
+
+// bruteForceMatch is MatchDependencies without the indexes: every compiled
+// pattern of the type without a version constraint is run on every package.
+func bruteForceMatch(d *DependencyDetector, packages []string, depType string) map[string][]string {
+	matched := make(map[string][]string)
+	set, ok := d.matchers[depType]
+	if !ok {
+		return matched
+	}
+	for _, pkg := range packages {
+		for _, m := range set.get() {
+			if m.Version == nil && m.match.MatchString(pkg) {
+				matched[m.Tech] = append(matched[m.Tech], m.Tech+" matched: "+m.Regex.String())
+			}
+		}
+	}
+	return matched
+}
+
+// dependencyNamesFromRules returns, per dependency type, names built from the
+// embedded rules: the exact names, and for regexes their prefix with and
+// without a suffix, plus some that match nothing.
+func dependencyNamesFromRules(t testing.TB) map[string][]string {
+	loaded, err := rules.LoadEmbeddedRules()
+	require.NoError(t, err)
+	names := make(map[string][]string)
+	for _, rule := range loaded {
+		for _, dep := range rule.Dependencies {
+			name := dep.Name
+			switch kind, text := parseDependencyPattern(dep.Name); kind {
+			case patternPrefix:
+				name = text
+			case patternRegex:
+				name = anchoredPrefix(text)
+			}
+			names[dep.Type] = append(names[dep.Type], name, name+"-extra", name+":core", "x"+name, "unknown-package")
+		}
+	}
+	return names
+}
+
+func TestMatchDependencies_SameAsMatchingEveryPattern(t *testing.T) {
+	loaded, err := rules.LoadEmbeddedRules()
+	require.NoError(t, err)
+	d := NewDependencyDetector(loaded)
+	for depType, names := range dependencyNamesFromRules(t) {
+		assert.Equal(t, bruteForceMatch(d, names, depType), d.MatchDependencies(names, depType), depType)
+	}
+}
+
+func TestMatchDependencies_KeepsPatternOrderAcrossIndexes(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "react", Dependencies: []types.Dependency{
+			{Type: "npm", Name: "/react/"},
+			{Type: "npm", Name: "react"},
+			{Type: "npm", Name: "/^rea/"},
+		}},
+	}
+	d := NewDependencyDetector(rules)
+	assert.Equal(t, map[string][]string{"react": {
+		"react matched: react", "react matched: ^react$", "react matched: ^rea",
+	}}, d.MatchDependencies([]string{"react"}, "npm"))
+}
+
+// BenchmarkMatchDependencies matches a large npm dependency list, most of
+// it unknown to the rules, against the embedded rules.
+func BenchmarkMatchDependencies(b *testing.B) {
+	loaded, err := rules.LoadEmbeddedRules()
+	require.NoError(b, err)
+	d := NewDependencyDetector(loaded)
+	packages := dependencyNamesFromRules(b)["npm"]
+	for i := len(packages); i < 10000; i++ {
+		packages = append(packages, fmt.Sprintf("@myorg/package-%d", i))
+	}
+	d.MatchDependencies(packages[:1], "npm") // compile outside the timing
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.MatchDependencies(packages, "npm")
+	}
+}
+
+// BenchmarkMatchDependenciesBruteForce is BenchmarkMatchDependencies
+// without the index, for comparison.
+func BenchmarkMatchDependenciesBruteForce(b *testing.B) {
+	loaded, err := rules.LoadEmbeddedRules()
+	require.NoError(b, err)
+	d := NewDependencyDetector(loaded)
+	packages := dependencyNamesFromRules(b)["npm"]
+	for i := len(packages); i < 10000; i++ {
+		packages = append(packages, fmt.Sprintf("@myorg/package-%d", i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bruteForceMatch(d, packages, "npm")
+	}
+}
+
+func TestMatchDependencies_IgnoresCaseInCaseInsensitiveEcosystems(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "newtonsoft", Dependencies: []types.Dependency{{Type: "nuget", Name: "Newtonsoft.Json"}}},
+		{Tech: "efcore", Dependencies: []types.Dependency{{Type: "nuget", Name: "/^Microsoft\\.EntityFrameworkCore/"}}},
+		{Tech: "django", Dependencies: []types.Dependency{{Type: "pypi", Name: "django"}}},
+		{Tech: "react", Dependencies: []types.Dependency{{Type: "npm", Name: "react"}}},
+	}
+	d := NewDependencyDetector(rules)
+
+	matched := d.MatchDependencies([]string{"newtonsoft.json", "microsoft.entityframeworkcore.sqlserver"}, "nuget")
+	assert.Equal(t, []string{"newtonsoft matched: ^Newtonsoft\\.Json$"}, matched["newtonsoft"], "reasons keep the rule's pattern")
+	assert.Contains(t, matched, "efcore")
+	assert.Contains(t, d.MatchDependencies([]string{"Django"}, "pypi"), "django")
+	assert.Empty(t, d.MatchDependencies([]string{"React"}, "npm"), "npm names are case-sensitive")
+}
+
+func TestMatchDependencies_PrefixPatterns(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "angular", Dependencies: []types.Dependency{{Type: "npm", Name: "@angular/*"}}},
+		{Tech: "springboot", Dependencies: []types.Dependency{{Type: "maven", Name: "org.springframework.boot:*"}}},
+	}
+	d := NewDependencyDetector(rules)
+
+	matched := d.MatchDependencies([]string{"@angular/core", "@angular/router", "@angularx/core", "angular"}, "npm")
+	assert.Equal(t, map[string][]string{"angular": {"angular matched: ^@angular/", "angular matched: ^@angular/"}}, matched)
+	assert.Contains(t, d.MatchDependencies([]string{"Org.SpringFramework.Boot:spring-boot-starter-web"}, "gradle"), "springboot")
+	assert.Equal(t, []string{"@angular/core"}, d.PackagesForTech([]types.Dependency{
+		{Type: "npm", Name: "@angular/core"}, {Type: "npm", Name: "rxjs"},
+	}, "angular"))
+}
+
+func TestMatchVersionedDependencies(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "vue", Dependencies: []types.Dependency{{Type: "npm", Name: "vue"}}},
+		{Tech: "vue2", Dependencies: []types.Dependency{{Type: "npm", Name: "vue", Version: "<3"}}},
+		{Tech: "springboot2", Dependencies: []types.Dependency{{Type: "maven", Name: "org.springframework.boot:*", Version: "<3"}}},
+	}
+	d := NewDependencyDetector(rules)
+
+	assert.Equal(t, map[string][]string{"vue": {"vue matched: ^vue$"}}, d.MatchDependencies([]string{"vue"}, "npm"),
+		"name-only matching leaves version-constrained patterns out")
+	assert.Equal(t, map[string][]string{"vue2": {"vue2 matched: ^vue$ ^2.6.14 (<3)"}},
+		d.MatchVersionedDependencies([]types.Dependency{{Type: "npm", Name: "vue", Version: "^2.6.14"}}))
+	assert.Empty(t, d.MatchVersionedDependencies([]types.Dependency{{Type: "npm", Name: "vue", Version: "3.4.21"}}))
+	assert.Contains(t, d.MatchVersionedDependencies([]types.Dependency{
+		{Type: "gradle", Name: "org.springframework.boot:spring-boot-starter-web", Version: "2.7.18"},
+	}), "springboot2")
+}
+
+func TestScanDetectsVersionConstrainedTechs(t *testing.T) {
+	root := t.TempDir()
+	writeChangesTestFiles(t, root, map[string]string{
+		"legacy/package.json": `{"name": "legacy", "dependencies": {"vue": "^2.7.14"}}`,
+		"app/package.json":    `{"name": "app", "dependencies": {"vue": "^3.4.21"}}`,
+	})
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	legacy, app := findByName(payload, "legacy"), findByName(payload, "app")
+	require.NotNil(t, legacy)
+	require.NotNil(t, app)
+	assert.Contains(t, legacy.Techs, "vue2")
+	assert.Contains(t, legacy.Techs, "vue")
+	assert.NotContains(t, app.Techs, "vue2")
+	assert.Contains(t, app.Techs, "vue")
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DeploymentInfo is the deployment section of a component: the platforms
+// it is deployed to and the configuration files saying so.
+type DeploymentInfo struct {
+	Targets   []*DeploymentTarget `json:"targets"`
+	Platforms []string            `json:"platforms"`
+}
+
+// DeploymentTarget is a deployment of a component to a platform.
+type DeploymentTarget struct {
+	Platform  string   `json:"platform"`
+	Name      string   `json:"name,omitempty"`
+	Runtime   string   `json:"runtime,omitempty"`
+	Regions   []string `json:"regions,omitempty"`
+	Workloads []string `json:"workloads,omitempty"`
+	Files     []string `json:"files"`
+}
+
+// deploymentTargets are the deployment targets of a component by platform
+// and name.
+type deploymentTargets map[string]*DeploymentTarget
+
+// deploymentFiles maps the names of platform configuration files to their
+// parser.
+var deploymentFiles = map[string]func(*parsers.DeploymentParser, []byte) (parsers.Deployment, bool){
+	"Procfile":      (*parsers.DeploymentParser).ParseProcfile,
+	"heroku.yml":    (*parsers.DeploymentParser).ParseHerokuYML,
+	"fly.toml":      (*parsers.DeploymentParser).ParseFlyToml,
+	"vercel.json":   (*parsers.DeploymentParser).ParseVercelJSON,
+	"netlify.toml":  (*parsers.DeploymentParser).ParseNetlifyToml,
+	"app.yaml":      (*parsers.DeploymentParser).ParseAppEngine,
+	"render.yaml":   (*parsers.DeploymentParser).ParseRenderYAML,
+	"railway.json":  (*parsers.DeploymentParser).ParseRailway,
+	"railway.toml":  (*parsers.DeploymentParser).ParseRailway,
+	"wrangler.toml": (*parsers.DeploymentParser).ParseWranglerToml,
+}
+
+// recordDeployment collects the deployment a platform configuration file,
+// Kubernetes manifest or ECS task definition describes, and adds the
+// platform as a tech of the component.
+func (s *Scanner) recordDeployment(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	deployment, ok := parseDeploymentFile(rel, content)
+	if !ok {
+		return
+	}
+	if s.deployments == nil {
+		s.deployments = make(map[*types.Payload]deploymentTargets)
+	}
+	targets := s.deployments[ctx]
+	if targets == nil {
+		targets = make(deploymentTargets)
+		s.deployments[ctx] = targets
+	}
+	key := deployment.Platform + "|" + deployment.Name
+	target := targets[key]
+	if target == nil {
+		target = &DeploymentTarget{Platform: deployment.Platform, Name: deployment.Name, Runtime: deployment.Runtime}
+		targets[key] = target
+	}
+	for _, region := range deployment.Regions {
+		target.Regions = appendUnique(target.Regions, region)
+	}
+	for _, workload := range deployment.Workloads {
+		target.Workloads = appendUnique(target.Workloads, workload)
+	}
+	target.Files = appendUnique(target.Files, rel)
+	ctx.AddTech(deployment.Platform, "deployment config: "+rel)
+}
+
+// parseDeploymentFile dispatches a file to the deployment parser for its
+// kind: a platform configuration file, a Kubernetes manifest declaring
+// workloads (outside GitHub workflows) or an ECS task definition.
+func parseDeploymentFile(rel string, content []byte) (parsers.Deployment, bool) {
+	parser := parsers.NewDeploymentParser()
+	name := path.Base(rel)
+	ext := strings.ToLower(path.Ext(name))
+	if parse, ok := deploymentFiles[name]; ok {
+		return parse(parser, content)
+	}
+	switch {
+	case isYAML(ext) && !strings.Contains(rel, "/.github/") && parser.IsKubernetesWorkload(content):
+		return parser.ParseKubernetesWorkloads(content)
+	case ext == ".json" && parser.IsECSTaskDefinition(content):
+		return parser.ParseECSTaskDefinition(content)
+	}
+	return parsers.Deployment{}, false
+}
+
+// attachDeployment adds a "deployment" property to every component with
+// deployment targets.
+func (s *Scanner) attachDeployment(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		targets := s.deployments[p]
+		if len(targets) == 0 {
+			return
+		}
+		info := &DeploymentInfo{Platforms: []string{}}
+		for _, target := range targets {
+			sort.Strings(target.Files)
+			info.Targets = append(info.Targets, target)
+			info.Platforms = appendUnique(info.Platforms, target.Platform)
+		}
+		sort.Slice(info.Targets, func(i, j int) bool {
+			if info.Targets[i].Platform != info.Targets[j].Platform {
+				return info.Targets[i].Platform < info.Targets[j].Platform
+			}
+			return info.Targets[i].Name < info.Targets[j].Name
+		})
+		sort.Strings(info.Platforms)
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["deployment"] = info
+	})
+}
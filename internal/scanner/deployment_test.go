@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachDeployment(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Procfile", "web: node server.js\n")
+	write("api/fly.toml", "app = \"myapp-api\"\nprimary_region = \"fra\"\n")
+	write("deploy/api.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api\n")
+	write("deploy/worker.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: worker\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	root, ok := result.Properties["deployment"].(*DeploymentInfo)
+	require.True(t, ok)
+	require.Len(t, root.Targets, 1)
+	assert.Equal(t, &DeploymentTarget{
+		Platform: "kubernetes", Workloads: []string{"Deployment/api", "Deployment/worker"}, Files: []string{"/deploy/api.yaml", "/deploy/worker.yaml"},
+	}, sortedWorkloads(root.Targets[0]))
+	assert.Contains(t, result.Techs, "kubernetes")
+
+	var api *DeploymentInfo
+	for _, child := range result.Children {
+		if child.Name == "api" {
+			api, _ = child.Properties["deployment"].(*DeploymentInfo)
+			assert.Contains(t, child.Techs, "heroku")
+		}
+	}
+	require.NotNil(t, api)
+	assert.Equal(t, []string{"flyio", "heroku"}, api.Platforms)
+	assert.Equal(t, "myapp-api", api.Targets[0].Name)
+	assert.Equal(t, []string{"fra"}, api.Targets[0].Regions)
+	assert.Equal(t, []string{"web"}, api.Targets[1].Workloads)
+}
+
+// sortedWorkloads sorts the workloads of a target read from several files
+// in walk order.
+func sortedWorkloads(target *DeploymentTarget) *DeploymentTarget {
+	sorted := *target
+	sorted.Workloads = append([]string(nil), target.Workloads...)
+	sort.Strings(sorted.Workloads)
+	return &sorted
+}
@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DesignSystemInfo is the design_system section of a frontend component:
+// the design systems its UI dependencies belong to and its accessibility
+// tooling, so components can be audited for a consistent UI stack.
+type DesignSystemInfo struct {
+	Systems       []DesignSystem      `json:"systems,omitempty"`
+	Accessibility map[string][]string `json:"accessibility,omitempty"` // kind (testing, linting, components) -> techs
+}
+
+// DesignSystem is a design system a component uses, with the packages that
+// pulled it in.
+type DesignSystem struct {
+	Tech     string   `json:"tech"`
+	Name     string   `json:"name"`
+	Family   string   `json:"family"` // material, ant, chakra, ... or an in-house name
+	Packages []string `json:"packages,omitempty"`
+}
+
+// designSystemRules holds the rules that classify a tech as a design system
+// (properties.design_system: <family>) or as accessibility tooling
+// (properties.accessibility: <kind>).
+type designSystemRules struct {
+	systems       map[string]types.Rule
+	accessibility map[string]string
+}
+
+func newDesignSystemRules(rules []types.Rule) *designSystemRules {
+	r := &designSystemRules{systems: make(map[string]types.Rule), accessibility: make(map[string]string)}
+	for _, rule := range rules {
+		if family := ruleProperty(rule, "design_system"); family != "" {
+			r.systems[rule.Tech] = rule
+		}
+		if kind := ruleProperty(rule, "accessibility"); kind != "" {
+			r.accessibility[rule.Tech] = kind
+		}
+	}
+	return r
+}
+
+func ruleProperty(rule types.Rule, key string) string {
+	if value, ok := rule.Properties[key]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+// attachDesignSystems adds a "design_system" property to every component
+// that uses a design system or accessibility tooling.
+func (s *Scanner) attachDesignSystems(payload *types.Payload) {
+	rules := newDesignSystemRules(s.rules)
+	if len(rules.systems) > 0 || len(rules.accessibility) > 0 {
+		s.walkDesignSystems(payload, rules)
+	}
+}
+
+func (s *Scanner) walkDesignSystems(payload *types.Payload, rules *designSystemRules) {
+	if info := s.designSystemInfo(payload, rules); info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["design_system"] = info
+	}
+	for _, child := range payload.Children {
+		s.walkDesignSystems(child, rules)
+	}
+}
+
+func (s *Scanner) designSystemInfo(payload *types.Payload, rules *designSystemRules) *DesignSystemInfo {
+	info := &DesignSystemInfo{}
+	for _, tech := range payload.Techs {
+		if rule, ok := rules.systems[tech]; ok {
+			info.Systems = append(info.Systems, DesignSystem{
+				Tech:     tech,
+				Name:     rule.Name,
+				Family:   ruleProperty(rule, "design_system"),
+				Packages: s.depDetector.PackagesForTech(payload.Dependencies, tech),
+			})
+		}
+		if kind, ok := rules.accessibility[tech]; ok {
+			if info.Accessibility == nil {
+				info.Accessibility = make(map[string][]string)
+			}
+			info.Accessibility[kind] = append(info.Accessibility[kind], tech)
+		}
+	}
+	if len(info.Systems) == 0 && len(info.Accessibility) == 0 {
+		return nil
+	}
+	sort.Slice(info.Systems, func(i, j int) bool { return info.Systems[i].Tech < info.Systems[j].Tech })
+	for _, techs := range info.Accessibility {
+		sort.Strings(techs)
+	}
+	return info
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachDesignSystems(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("web/package.json", `{
+		"name": "web",
+		"dependencies": {"react": "^18.0.0", "@mui/material": "^5.0.0", "@mui/icons-material": "^5.0.0", "antd": "^5.0.0"},
+		"devDependencies": {"jest-axe": "^8.0.0", "eslint-plugin-jsx-a11y": "^6.0.0"}
+	}`)
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.0.0"}}`)
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*DesignSystemInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["design_system"].(*DesignSystemInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+	assert.NotContains(t, byName, "api")
+
+	web := byName["web"]
+	require.NotNil(t, web, "expected a design_system section on the web component")
+	require.Len(t, web.Systems, 2)
+	assert.Equal(t, "antd", web.Systems[0].Tech)
+	assert.Equal(t, "ant", web.Systems[0].Family)
+	assert.Equal(t, []string{"antd"}, web.Systems[0].Packages)
+	assert.Equal(t, "materialui", web.Systems[1].Tech)
+	assert.Equal(t, "material", web.Systems[1].Family)
+	assert.Contains(t, web.Systems[1].Packages, "@mui/material")
+	assert.Equal(t, []string{"axe"}, web.Accessibility["testing"])
+	assert.Equal(t, []string{"jsx-a11y"}, web.Accessibility["linting"])
+}
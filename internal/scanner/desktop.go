@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// linkDesktopApps adds an edge from the JavaScript frontend of each Tauri
+// app to its Rust backend. The Rust crate (src-tauri/) is normally nested in
+// the frontend's package.json directory, so the frontend is its nearest
+// nodejs ancestor; otherwise a nodejs sibling (e.g. ui/ next to src-tauri/).
+func (s *Scanner) linkDesktopApps(payload *types.Payload) {
+	walkDesktopApps(payload, nil, nil)
+}
+
+func walkDesktopApps(payload, parent, frontend *types.Payload) {
+	if app, ok := payload.Properties["desktop"].(*parsers.DesktopApp); ok && app.Framework == parsers.DesktopFrameworkTauri {
+		target := frontend
+		if target == nil && parent != nil {
+			target = nodejsChild(parent)
+		}
+		if target != nil && !hasEdgeTo(target, payload) {
+			target.AddEdges(payload)
+		}
+	}
+	if payload.ComponentType == "nodejs" {
+		frontend = payload
+	}
+	for _, child := range payload.Children {
+		walkDesktopApps(child, payload, frontend)
+	}
+}
+
+// nodejsChild returns the first nodejs component directly under parent.
+func nodejsChild(parent *types.Payload) *types.Payload {
+	for _, child := range parent.Children {
+		if child.ComponentType == "nodejs" {
+			return child
+		}
+	}
+	return nil
+}
+
+func hasEdgeTo(from, to *types.Payload) bool {
+	for _, edge := range from.Edges {
+		if edge.Target == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkDesktopApps_Tauri(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp-ui", "dependencies": {"@tauri-apps/api": "^2.0.0", "react": "^18.2.0"}}`)
+	write("src-tauri/Cargo.toml", "[package]\nname = \"myapp\"\nversion = \"0.1.0\"\n\n[dependencies]\ntauri = \"2.1.1\"\n")
+	write("src-tauri/tauri.conf.json", `{"productName": "myapp", "identifier": "com.example.myapp", "build": {"frontendDist": "../dist"}}`)
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	backend := findComponentWithProperty(result, "desktop")
+	require.NotNil(t, backend, "expected the Tauri crate to carry a desktop section")
+	app, ok := backend.Properties["desktop"].(*parsers.DesktopApp)
+	require.True(t, ok)
+	assert.Equal(t, parsers.DesktopFrameworkTauri, app.Framework)
+
+	frontend := findComponentByType(result, "nodejs")
+	require.NotNil(t, frontend)
+	var targets []*types.Payload
+	for _, edge := range frontend.Edges {
+		targets = append(targets, edge.Target)
+	}
+	assert.Contains(t, targets, backend, "expected an edge from the frontend to the Tauri backend")
+}
+
+func TestLinkDesktopApps_SiblingFrontend(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	ui := types.NewPayloadWithPath("myapp-ui", "/ui/package.json")
+	ui.SetComponentType("nodejs")
+	backend := types.NewPayloadWithPath("myapp", "/src-tauri/Cargo.toml")
+	backend.SetComponentType("rust")
+	backend.Properties["desktop"] = &parsers.DesktopApp{Framework: parsers.DesktopFrameworkTauri}
+	root.AddChild(ui)
+	root.AddChild(backend)
+
+	(&Scanner{}).linkDesktopApps(root)
+	(&Scanner{}).linkDesktopApps(root)
+
+	require.Len(t, ui.Edges, 1, "linking twice must not duplicate the edge")
+	assert.True(t, ui.Edges[0].Target == backend)
+}
+
+func findComponentByType(payload *types.Payload, componentType string) *types.Payload {
+	if payload.ComponentType == componentType {
+		return payload
+	}
+	for _, child := range payload.Children {
+		if found := findComponentByType(child, componentType); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// detectorStat accumulates the work of one component detector.
+type detectorStat struct {
+	calls      int
+	components int
+	duration   time.Duration
+	errors     int
+	lastError  string
+}
+
+// runDetector runs a component detector on a directory, recording its time
+// and the components it returned. A detector that panics is logged and
+// recorded as failed, and the scan goes on without its components for the
+// directory.
+func (s *Scanner) runDetector(detector components.Detector, files []types.File, currentPath string) (detected []*types.Payload) {
+	if s.detectorStats == nil {
+		s.detectorStats = make(map[string]*detectorStat)
+	}
+	stat := s.detectorStats[detector.Name()]
+	if stat == nil {
+		stat = &detectorStat{}
+		s.detectorStats[detector.Name()] = stat
+	}
+
+	start := time.Now()
+	defer func() {
+		stat.calls++
+		stat.duration += time.Since(start)
+		if r := recover(); r != nil {
+			dir := s.relativeDir(currentPath)
+			stat.errors++
+			stat.lastError = fmt.Sprintf("%s: %v", dir, r)
+			s.log().Warn("Component detector failed", "detector", detector.Name(), "path", dir, "error", r)
+			detected = nil
+		}
+		stat.components += len(detected)
+	}()
+	return detector.Detect(files, currentPath, s.provider.GetBasePath(), s.provider, s.depDetector)
+}
+
+func (s *Scanner) relativeDir(path string) string {
+	if rel, err := filepath.Rel(s.cachedBasePath, path); err == nil {
+		return "/" + filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func (s *Scanner) log() *slog.Logger {
+	if s.logger == nil {
+		return slog.Default()
+	}
+	return s.logger
+}
+
+// recordDetectorStats adds the detector stats of the scan to its metadata
+// and logs them at debug level.
+func (s *Scanner) recordDetectorStats(meta *metadata.ScanMetadata) {
+	stats := make([]metadata.DetectorStat, 0, len(s.detectorStats))
+	for name, stat := range s.detectorStats {
+		stats = append(stats, metadata.DetectorStat{
+			Detector:   name,
+			Calls:      stat.calls,
+			Components: stat.components,
+			DurationMs: float64(stat.duration.Microseconds()) / 1000,
+			Errors:     stat.errors,
+			LastError:  stat.lastError,
+		})
+	}
+	meta.AddDetectorStats(stats)
+	for _, stat := range meta.DetectorStats {
+		s.log().Debug("Component detector stats", "detector", stat.Detector, "calls", stat.Calls,
+			"components", stat.Components, "duration_ms", stat.DurationMs, "errors", stat.Errors)
+	}
+}
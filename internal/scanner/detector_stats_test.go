@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDetector returns one component, or panics on directories named "bad".
+type stubDetector struct{}
+
+func (stubDetector) Name() string { return "stub" }
+
+func (stubDetector) Detect(_ []types.File, currentPath, _ string, _ types.Provider, _ components.DependencyDetector) []*types.Payload {
+	if filepath.Base(currentPath) == "bad" {
+		var m map[string]int
+		m["boom"]++ // assignment to nil map
+	}
+	return []*types.Payload{types.NewPayloadWithPath("stub", "/")}
+}
+
+func TestRunDetectorRecordsStatsAndRecoversPanics(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	s.cachedBasePath = root
+
+	assert.Len(t, s.runDetector(stubDetector{}, nil, filepath.Join(root, "good")), 1)
+	assert.Nil(t, s.runDetector(stubDetector{}, nil, filepath.Join(root, "src", "bad")), "a panicking detector returns no components")
+
+	meta := &metadata.ScanMetadata{}
+	s.recordDetectorStats(meta)
+	require.Len(t, meta.DetectorStats, 1)
+	stat := meta.DetectorStats[0]
+	assert.Equal(t, "stub", stat.Detector)
+	assert.Equal(t, 2, stat.Calls)
+	assert.Equal(t, 1, stat.Components)
+	assert.Equal(t, 1, stat.Errors)
+	assert.Contains(t, stat.LastError, "/src/bad: assignment to entry in nil map")
+}
+
+func TestScanRecordsDetectorStats(t *testing.T) {
+	root := t.TempDir()
+	writeChangesTestFiles(t, root, map[string]string{"web/package.json": `{"name": "web", "dependencies": {"react": "18.2.0"}}`})
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	meta, ok := payload.Metadata.(*metadata.ScanMetadata)
+	require.True(t, ok)
+	require.NotEmpty(t, meta.DetectorStats)
+	var nodejs *metadata.DetectorStat
+	for i := range meta.DetectorStats {
+		if meta.DetectorStats[i].Detector == "nodejs" {
+			nodejs = &meta.DetectorStats[i]
+		}
+	}
+	require.NotNil(t, nodejs)
+	assert.Equal(t, 1, nodejs.Components)
+	assert.Positive(t, nodejs.Calls)
+}
+
+func TestSetDetectors(t *testing.T) {
+	root := t.TempDir()
+	writeChangesTestFiles(t, root, map[string]string{"web/package.json": `{"name": "web", "dependencies": {"react": "18.2.0"}}`})
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+
+	err = s.SetDetectors([]string{"nodejs", "no-such-detector"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown component detector 'no-such-detector'")
+
+	require.NoError(t, s.SetDetectors([]string{"NodeJS", "python"}, []string{"python"}))
+	payload, err := s.Scan()
+	require.NoError(t, err)
+	meta := payload.Metadata.(*metadata.ScanMetadata)
+	require.Len(t, meta.DetectorStats, 1, "only the selected detectors run")
+	assert.Equal(t, "nodejs", meta.DetectorStats[0].Detector)
+
+	s, err = NewScanner(root)
+	require.NoError(t, err)
+	require.NoError(t, s.SetDetectors(nil, []string{"nodejs"}))
+	payload, err = s.Scan()
+	require.NoError(t, err)
+	assert.Nil(t, findByName(payload, "web"), "a disabled detector finds no components")
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DocumentationInfo summarizes the documentation toolchains of a repository.
+type DocumentationInfo struct {
+	Tools    []string                 `json:"tools"`
+	Projects []*parsers.DocsToolchain `json:"projects"`
+}
+
+// attachDocumentation moves the documentation toolchains found in the
+// components to a single "documentation" summary on the root.
+func attachDocumentation(root *types.Payload) {
+	var projects []*parsers.DocsToolchain
+	collectDocumentation(root, &projects)
+	if len(projects) == 0 {
+		return
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].File < projects[j].File })
+
+	seen := make(map[string]bool)
+	info := &DocumentationInfo{Projects: projects}
+	for _, project := range projects {
+		if !seen[project.Tool] {
+			seen[project.Tool] = true
+			info.Tools = append(info.Tools, project.Tool)
+		}
+	}
+	sort.Strings(info.Tools)
+	root.Properties["documentation"] = info
+}
+
+func collectDocumentation(payload *types.Payload, projects *[]*parsers.DocsToolchain) {
+	if entries, ok := payload.Properties["documentation"].([]interface{}); ok {
+		for _, entry := range entries {
+			if project, ok := entry.(*parsers.DocsToolchain); ok {
+				*projects = append(*projects, project)
+			}
+		}
+		delete(payload.Properties, "documentation")
+	}
+	for _, child := range payload.Children {
+		collectDocumentation(child, projects)
+	}
+}
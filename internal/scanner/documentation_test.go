@@ -0,0 +1,42 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachDocumentation(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("mkdocs.yml", "site_name: My App\ntheme:\n  name: material\nplugins:\n  - search\n")
+	write("docs/source/conf.py", "project = 'myapp'\nextensions = ['sphinx.ext.autodoc']\nhtml_theme = 'furo'\n")
+	write("paper/main.tex", "\\documentclass{article}\n\\begin{document}\\end{document}\n")
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/docs/conf.py", "html_theme = 'alabaster'\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	info, ok := result.Properties["documentation"].(*DocumentationInfo)
+	require.True(t, ok, "expected a documentation summary on the root component")
+	assert.Equal(t, []string{"latex", "mkdocs", "sphinx"}, info.Tools)
+	require.Len(t, info.Projects, 4)
+	assert.Equal(t, "/api/docs/conf.py", info.Projects[0].File)
+	assert.Equal(t, "/docs/source/conf.py", info.Projects[1].File)
+	assert.Equal(t, "/mkdocs.yml", info.Projects[2].File)
+	assert.Equal(t, "/paper/main.tex", info.Projects[3].File)
+
+	for _, child := range result.Children {
+		assert.NotContains(t, child.Properties, "documentation", "toolchains are summarized on the root only")
+	}
+}
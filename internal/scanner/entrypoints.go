@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// recordEntrypoints collects the entrypoints a file declares: main functions
+// outside tests, package.json bin, main and module fields, the ENTRYPOINT
+// and CMD of Dockerfiles and Procfile process types. A package.json only
+// counts in the folder of its component, as one not making a component of
+// its own is build tooling.
+func (s *Scanner) recordEntrypoints(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	entrypoints := parseEntrypoints(rel, content, path.Dir(rel) == componentDir(ctx))
+	if len(entrypoints) == 0 {
+		return
+	}
+	if s.entrypoints == nil {
+		s.entrypoints = make(map[*types.Payload][]parsers.Entrypoint)
+	}
+	s.entrypoints[ctx] = append(s.entrypoints[ctx], entrypoints...)
+}
+
+// parseEntrypoints dispatches a file to the entrypoints parser for its kind.
+func parseEntrypoints(rel string, content []byte, ownFolder bool) []parsers.Entrypoint {
+	parser := parsers.NewEntrypointsParser()
+	name := path.Base(rel)
+	switch {
+	case name == "package.json":
+		if ownFolder {
+			return parser.ParsePackageJSON(rel, content)
+		}
+	case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile."):
+		return parser.ParseDockerfile(rel, content)
+	case name == "Procfile":
+		return parser.ParseProcfile(rel, content)
+	case !isTestPath(rel):
+		if entrypoint, ok := parser.ParseMainFunction(rel, content); ok {
+			return []parsers.Entrypoint{entrypoint}
+		}
+	}
+	return nil
+}
+
+// isTestPath reports whether a file is named like a test or lies in a test
+// folder.
+func isTestPath(rel string) bool {
+	segments := strings.Split(strings.TrimPrefix(rel, "/"), "/")
+	namedLikeTest := testFileRegex.MatchString(segments[len(segments)-1])
+	return namedLikeTest || testDirOf(segments[:len(segments)-1], false) != ""
+}
+
+// attachEntrypoints adds an "entrypoints" property listing the entrypoints
+// of every component having any, ordered by declaring file.
+func (s *Scanner) attachEntrypoints(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		entrypoints := s.entrypoints[p]
+		if len(entrypoints) == 0 {
+			return
+		}
+		sort.SliceStable(entrypoints, func(i, j int) bool { return entrypoints[i].File < entrypoints[j].File })
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["entrypoints"] = entrypoints
+	})
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachEntrypoints(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("api/package.json", `{"name": "api", "main": "server.js", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Dockerfile", "FROM node:20-slim\nCMD [\"node\", \"server.js\"]\n")
+	write("api/Procfile", "web: node server.js\n")
+	write("tools/package.json", `{"name": "tools", "bin": "cli.js", "devDependencies": {"eslint": "^9.0.0"}}`)
+	write("scripts/seed.py", "if __name__ == \"__main__\":\n    seed()\n")
+	write("tests/conftest.py", "if __name__ == \"__main__\":\n    pass\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	// The tools package.json makes no component and test files are skipped.
+	assert.Equal(t, []parsers.Entrypoint{{Kind: parsers.EntrypointKindMain, File: "/scripts/seed.py"}}, result.Properties["entrypoints"])
+
+	var api []parsers.Entrypoint
+	for _, child := range result.Children {
+		if child.Name == "api" {
+			api, _ = child.Properties["entrypoints"].([]parsers.Entrypoint)
+		}
+	}
+	assert.Equal(t, []parsers.Entrypoint{
+		{Kind: parsers.EntrypointKindDockerCmd, Command: "node server.js", File: "/api/Dockerfile"},
+		{Kind: parsers.EntrypointKindProcfile, Name: "web", Command: "node server.js", File: "/api/Procfile"},
+		{Kind: parsers.EntrypointKindNpmMain, Path: "server.js", File: "/api/package.json"},
+	}, api)
+}
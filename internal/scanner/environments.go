@@ -0,0 +1,236 @@
+package scanner
+
+import (
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Kinds of differences between environments.
+const (
+	EnvironmentDriftTech    = "tech"
+	EnvironmentDriftService = "service"
+)
+
+// EnvironmentsInfo is the environments section of a component: the
+// environments it has configuration for, and the techs and services found
+// in some of them but not in others.
+type EnvironmentsInfo struct {
+	Environments []*EnvironmentInfo  `json:"environments"`
+	Drift        []*EnvironmentDrift `json:"drift,omitempty"`
+}
+
+// EnvironmentInfo is the configuration of one environment.
+type EnvironmentInfo struct {
+	Name     string   `json:"name"`
+	Sources  []string `json:"sources"` // kinds of configuration: dotenv, spring, kubernetes, terraform, compose
+	Techs    []string `json:"techs,omitempty"`
+	Services []string `json:"services,omitempty"`
+	Files    []string `json:"files"`
+
+	services map[string][]string // services by kind of configuration
+}
+
+// EnvironmentDrift is a tech or service configured for some environments
+// only.
+type EnvironmentDrift struct {
+	Kind         string   `json:"kind"`
+	Name         string   `json:"name"`
+	Source       string   `json:"source,omitempty"` // kind of configuration declaring a service
+	Environments []string `json:"environments"`     // environments configuring it
+	Missing      []string `json:"missing"`          // environments that do not
+}
+
+// componentEnvironments are the environments of a component by name.
+type componentEnvironments map[string]*EnvironmentInfo
+
+// recordEnvironments collects the configuration files specific to one
+// environment: dotenv files, Spring profiles, Compose and Terraform variable
+// files named after an environment, and the Kubernetes and Terraform files
+// of Kustomize overlays and environment folders.
+func (s *Scanner) recordEnvironments(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	config, ok := parseEnvironmentFile(rel, content)
+	if !ok {
+		return
+	}
+	environment := s.environment(ctx, config.Environment)
+	environment.Sources = appendUnique(environment.Sources, config.Kind)
+	environment.Files = appendUnique(environment.Files, rel)
+	for _, tech := range append(config.Techs, s.environmentTechs(config)...) {
+		environment.Techs = appendUnique(environment.Techs, tech)
+	}
+	for _, service := range config.Services {
+		environment.Services = appendUnique(environment.Services, service)
+		environment.services[config.Kind] = appendUnique(environment.services[config.Kind], service)
+	}
+}
+
+// environment returns the named environment of a component, adding it on
+// first use.
+func (s *Scanner) environment(ctx *types.Payload, name string) *EnvironmentInfo {
+	if s.environments == nil {
+		s.environments = make(map[*types.Payload]componentEnvironments)
+	}
+	environments := s.environments[ctx]
+	if environments == nil {
+		environments = make(componentEnvironments)
+		s.environments[ctx] = environments
+	}
+	environment := environments[name]
+	if environment == nil {
+		environment = &EnvironmentInfo{Name: name, services: make(map[string][]string)}
+		environments[name] = environment
+	}
+	return environment
+}
+
+// parseEnvironmentFile dispatches a file to the parser for its kind of
+// per-environment configuration.
+func parseEnvironmentFile(rel string, content []byte) (parsers.EnvironmentConfig, bool) {
+	if name, _, ok := parsers.DotenvEnvironment(path.Base(rel)); ok {
+		environment, ok := parsers.NormalizeEnvironment(name)
+		return parsers.EnvironmentConfig{Environment: environment, Kind: parsers.EnvironmentKindDotenv}, ok
+	}
+	parser := parsers.NewEnvironmentsParser()
+	for _, parse := range []func(string, []byte) (parsers.EnvironmentConfig, bool){
+		parser.ParseSpringProfile, parser.ParseComposeEnvironment, parser.ParseTfvars, parser.ParseEnvironmentDirectory,
+	} {
+		if config, ok := parse(rel, content); ok {
+			return config, true
+		}
+	}
+	return parsers.EnvironmentConfig{}, false
+}
+
+// environmentTechs matches the images and Terraform resources of an
+// environment's configuration against the rules.
+func (s *Scanner) environmentTechs(config parsers.EnvironmentConfig) []string {
+	if s.depDetector == nil {
+		return nil
+	}
+	var techs []string
+	for tech := range s.depDetector.MatchDependencies(config.Images, "docker") {
+		techs = append(techs, tech)
+	}
+	for tech := range s.depDetector.MatchDependencies(config.Resources, "terraform.resource") {
+		techs = append(techs, tech)
+	}
+	sort.Strings(techs)
+	return techs
+}
+
+// attachEnvironments adds an "environments" property to every component
+// with per-environment configuration. The techs of dotenv variables are
+// taken from the component's env_files.
+func (s *Scanner) attachEnvironments(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		s.addEnvFileTechs(p)
+		environments := s.environments[p]
+		if len(environments) == 0 {
+			return
+		}
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["environments"] = environmentsInfo(environments)
+	})
+}
+
+func (s *Scanner) addEnvFileTechs(p *types.Payload) {
+	files, _ := p.Properties["env_files"].([]interface{})
+	for _, file := range files {
+		info, ok := file.(*parsers.EnvFileInfo)
+		if !ok {
+			continue
+		}
+		name, ok := parsers.NormalizeEnvironment(info.Environment)
+		if !ok {
+			continue
+		}
+		environment := s.environment(p, name)
+		environment.Sources = appendUnique(environment.Sources, parsers.EnvironmentKindDotenv)
+		environment.Files = appendUnique(environment.Files, info.File)
+		for _, variable := range info.Variables {
+			environment.Techs = appendUnique(environment.Techs, variable.Tech)
+		}
+	}
+}
+
+func environmentsInfo(environments componentEnvironments) *EnvironmentsInfo {
+	info := &EnvironmentsInfo{}
+	for _, environment := range environments {
+		sort.Strings(environment.Sources)
+		sort.Strings(environment.Techs)
+		sort.Strings(environment.Services)
+		sort.Strings(environment.Files)
+		info.Environments = append(info.Environments, environment)
+	}
+	sort.Slice(info.Environments, func(i, j int) bool { return info.Environments[i].Name < info.Environments[j].Name })
+	if len(info.Environments) < 2 {
+		return info
+	}
+	info.Drift = environmentDrift(info.Environments, func(e *EnvironmentInfo) []string { return e.Techs })
+	for _, kind := range environmentServiceKinds(info.Environments) {
+		for _, drift := range environmentDrift(info.Environments, func(e *EnvironmentInfo) []string { return e.services[kind] }) {
+			drift.Kind, drift.Source = EnvironmentDriftService, kind
+			info.Drift = append(info.Drift, drift)
+		}
+	}
+	return info
+}
+
+// environmentServiceKinds returns the kinds of configuration declaring
+// services in any environment.
+func environmentServiceKinds(environments []*EnvironmentInfo) []string {
+	var kinds []string
+	for _, environment := range environments {
+		for kind := range environment.services {
+			kinds = appendUnique(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// environmentDrift returns the techs, or the services of one kind of
+// configuration, found in some environments but not in all. Only the
+// environments having any are compared, so that an environment without
+// Kubernetes overlays does not lack every workload.
+func environmentDrift(environments []*EnvironmentInfo, values func(*EnvironmentInfo) []string) []*EnvironmentDrift {
+	var compared []*EnvironmentInfo
+	byValue := make(map[string][]string)
+	for _, environment := range environments {
+		if len(values(environment)) == 0 {
+			continue
+		}
+		compared = append(compared, environment)
+		for _, value := range values(environment) {
+			byValue[value] = append(byValue[value], environment.Name)
+		}
+	}
+	var drift []*EnvironmentDrift
+	for _, value := range slices.Sorted(maps.Keys(byValue)) {
+		present := byValue[value]
+		if len(present) == len(compared) {
+			continue
+		}
+		entry := &EnvironmentDrift{Kind: EnvironmentDriftTech, Name: value, Environments: present, Missing: []string{}}
+		for _, environment := range compared {
+			if !slices.Contains(present, environment.Name) {
+				entry.Missing = append(entry.Missing, environment.Name)
+			}
+		}
+		drift = append(drift, entry)
+	}
+	return drift
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachEnvironments(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("k8s/overlays/dev/debug.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: debug\n")
+	write("k8s/overlays/dev/db.yaml", "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  template:\n    spec:\n      containers:\n        - name: db\n          image: clickhouse/clickhouse-server:24\n")
+	write("k8s/overlays/prod/db.yaml", "apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\nspec:\n  template:\n    spec:\n      containers:\n        - name: db\n          image: postgres:16\n")
+	write("infra/staging.tfvars", "instance_type = \"t3.small\"\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	info, ok := result.Properties["environments"].(*EnvironmentsInfo)
+	require.True(t, ok)
+	require.Len(t, info.Environments, 3)
+	dev, prod, staging := info.Environments[0], info.Environments[1], info.Environments[2]
+	assert.Equal(t, "development", dev.Name)
+	assert.Equal(t, []string{"clickhouse"}, dev.Techs)
+	assert.Equal(t, []string{"Deployment/debug", "StatefulSet/db"}, dev.Services)
+	assert.Equal(t, []string{"/k8s/overlays/dev/db.yaml", "/k8s/overlays/dev/debug.yaml"}, dev.Files)
+	assert.Equal(t, "production", prod.Name)
+	assert.Equal(t, []string{"postgresql"}, prod.Techs)
+	assert.Equal(t, &EnvironmentInfo{Name: "staging", Sources: []string{"terraform"}, Files: []string{"/infra/staging.tfvars"}, services: map[string][]string{}}, staging)
+
+	assert.Equal(t, []*EnvironmentDrift{
+		{Kind: EnvironmentDriftTech, Name: "clickhouse", Environments: []string{"development"}, Missing: []string{"production"}},
+		{Kind: EnvironmentDriftTech, Name: "postgresql", Environments: []string{"production"}, Missing: []string{"development"}},
+		{Kind: EnvironmentDriftService, Name: "Deployment/debug", Source: "kubernetes", Environments: []string{"development"}, Missing: []string{"production"}},
+	}, info.Drift)
+}
+
+func TestAttachEnvironmentsDotenv(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env.production"), []byte("DATABASE_URL=\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env.example"), []byte("DATABASE_URL=\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	info, ok := result.Properties["environments"].(*EnvironmentsInfo)
+	require.True(t, ok)
+	require.Len(t, info.Environments, 1)
+	assert.Equal(t, "production", info.Environments[0].Name)
+	assert.Equal(t, []string{"dotenv"}, info.Environments[0].Sources)
+	assert.Equal(t, []string{"/.env.production"}, info.Environments[0].Files)
+	assert.Empty(t, info.Drift)
+}
@@ -0,0 +1,284 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+const (
+	// maxExternalAPIURLs caps the URL evidence collected per component.
+	maxExternalAPIURLs = 50
+	// maxExternalAPIScanSize skips large files when looking for API URLs.
+	maxExternalAPIScanSize = 1 << 20
+)
+
+// Evidence kinds reported in ExternalAPIEvidence.Type.
+const (
+	externalAPIEvidenceDependency = "dependency"
+	externalAPIEvidenceURL        = "url"
+)
+
+// externalAPIExtensions are the code and config files searched for API base
+// URLs.
+var externalAPIExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".jsx": true, ".ts": true, ".tsx": true, ".py": true, ".java": true,
+	".kt": true, ".scala": true, ".cs": true, ".go": true, ".rb": true, ".php": true, ".rs": true, ".swift": true,
+	".yaml": true, ".yml": true, ".json": true, ".toml": true, ".properties": true, ".xml": true, ".ini": true,
+	".conf": true,
+}
+
+// externalAPILockFiles are lock files with a searched extension: the
+// registry URLs they list are never API calls of the component.
+var externalAPILockFiles = map[string]bool{
+	"package-lock.json": true, "npm-shrinkwrap.json": true, "pnpm-lock.yaml": true,
+	"packages.lock.json": true, "project.assets.json": true,
+}
+
+// apiURLRegex matches host names written as URLs or as quoted strings, with
+// the path following them: "https://api.example.com/v1", 'api.example.com'.
+var apiURLRegex = regexp.MustCompile(`(?i)(?:https?://|["'\x60])((?:[a-z0-9-]+\.)+[a-z]{2,})(?::\d+)?(/[\w./%-]*)?`)
+
+// ExternalAPIsInfo is the external_apis section of a component: the SaaS
+// APIs it calls, found through their SDKs and their base URLs.
+type ExternalAPIsInfo struct {
+	Providers []ExternalAPIProvider `json:"providers"`
+}
+
+// ExternalAPIProvider is one SaaS API a component calls, with the evidence
+// it was found from.
+type ExternalAPIProvider struct {
+	Provider string                `json:"provider"`
+	Name     string                `json:"name"`
+	Evidence []ExternalAPIEvidence `json:"evidence"`
+}
+
+// ExternalAPIEvidence is an SDK dependency (Value is type:name) or a base
+// URL named in a file (Value is the API host and path).
+type ExternalAPIEvidence struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	File  string `json:"file,omitempty"`
+}
+
+// apiHostPattern is one entry of a rule's api_hosts property: a host, or
+// any subdomain of a domain when written as *.domain, optionally followed
+// by the path the API lives under (slack.com/api).
+type apiHostPattern struct {
+	tech     string
+	domain   string
+	wildcard bool
+	path     string
+}
+
+// matches reports whether a URL's host and path belong to the API.
+func (p apiHostPattern) matches(host, path string) bool {
+	if p.wildcard {
+		if !strings.HasSuffix(host, "."+p.domain) {
+			return false
+		}
+	} else if host != p.domain {
+		return false
+	}
+	return p.path == "" || path == p.path || strings.HasPrefix(path, p.path+"/")
+}
+
+// apiHostIndex holds the api_hosts of the rules and the provider names.
+type apiHostIndex struct {
+	patterns []apiHostPattern
+	names    map[string]string
+	domains  [][]byte // distinct domains of the patterns
+	tlds     [][]byte // distinct top-level domains of the patterns, with the dot
+}
+
+// newAPIHostIndex reads the api_hosts property of the rules.
+func newAPIHostIndex(rules []types.Rule) *apiHostIndex {
+	index := &apiHostIndex{names: make(map[string]string)}
+	for _, rule := range rules {
+		values, _ := rule.Properties["api_hosts"].([]interface{})
+		for _, value := range values {
+			index.patterns = append(index.patterns, parseAPIHost(rule.Tech, fmt.Sprint(value)))
+			index.names[rule.Tech] = rule.Name
+		}
+	}
+	domains, tlds := make(map[string]bool), make(map[string]bool)
+	for _, pattern := range index.patterns {
+		if !domains[pattern.domain] {
+			domains[pattern.domain] = true
+			index.domains = append(index.domains, []byte(pattern.domain))
+		}
+		if tld := pattern.domain[max(strings.LastIndex(pattern.domain, "."), 0):]; !tlds[tld] {
+			tlds[tld] = true
+			index.tlds = append(index.tlds, []byte(tld))
+		}
+	}
+	return index
+}
+
+func parseAPIHost(tech, value string) apiHostPattern {
+	pattern := apiHostPattern{tech: tech}
+	host, path, _ := strings.Cut(strings.ToLower(value), "/")
+	if path != "" {
+		pattern.path = "/" + strings.TrimSuffix(path, "/")
+	}
+	pattern.domain, pattern.wildcard = strings.CutPrefix(host, "*.")
+	return pattern
+}
+
+// mentionsDomain is a cheap pre-check before running apiURLRegex. Files
+// naming none of the few top-level domains skip the search for each domain.
+func (idx *apiHostIndex) mentionsDomain(content []byte) bool {
+	return containsAnyBytes(content, idx.tlds) && containsAnyBytes(content, idx.domains)
+}
+
+// match returns the tech of the API a URL's host and path belong to, and
+// the host and path reported as evidence.
+func (idx *apiHostIndex) match(host, path string) (string, string, bool) {
+	for _, pattern := range idx.patterns {
+		if pattern.matches(host, path) {
+			return pattern.tech, host + pattern.path, true
+		}
+	}
+	return "", "", false
+}
+
+func (s *Scanner) apiHostIndex() *apiHostIndex {
+	if s.apiHosts == nil {
+		s.apiHosts = newAPIHostIndex(s.rules)
+	}
+	return s.apiHosts
+}
+
+// recordExternalAPIs remembers the SaaS API base URLs named in a code or
+// config file of a component.
+func (s *Scanner) recordExternalAPIs(ctx *types.Payload, filePath string, content []byte) {
+	relPath, ok := s.externalAPIScanPath(filePath, content)
+	if !ok || len(s.externalAPIs[ctx]) >= maxExternalAPIURLs {
+		return
+	}
+	index := s.apiHostIndex()
+	if !index.mentionsDomain(content) {
+		return
+	}
+	for _, m := range apiURLRegex.FindAllSubmatch(content, -1) {
+		tech, value, ok := index.match(strings.ToLower(string(m[1])), string(m[2]))
+		if !ok {
+			continue
+		}
+		s.addExternalAPIURL(ctx, externalAPIRecord{tech: tech, evidence: ExternalAPIEvidence{
+			Type: externalAPIEvidenceURL, Value: value, File: relPath,
+		}})
+	}
+}
+
+// externalAPIScanPath returns the path, relative to the scan root, of a file
+// searched for API URLs: a code or config file of at most 1 MiB that is
+// neither a lock file nor vendored. Binary files never get here, their
+// content sections are not recorded.
+func (s *Scanner) externalAPIScanPath(filePath string, content []byte) (string, bool) {
+	if !externalAPIExtensions[strings.ToLower(filepath.Ext(filePath))] || len(content) > maxExternalAPIScanSize ||
+		externalAPILockFiles[filepath.Base(filePath)] {
+		return "", false
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	if enry.IsVendor(rel) && !isDotDir(rel) {
+		return "", false
+	}
+	return "/" + rel, true
+}
+
+// externalAPIRecord is a base URL of a provider's API found in a file.
+type externalAPIRecord struct {
+	tech     string
+	evidence ExternalAPIEvidence
+}
+
+func (s *Scanner) addExternalAPIURL(ctx *types.Payload, record externalAPIRecord) {
+	if s.externalAPIs == nil {
+		s.externalAPIs = make(map[*types.Payload][]externalAPIRecord)
+	}
+	for _, existing := range s.externalAPIs[ctx] {
+		if existing == record {
+			return
+		}
+	}
+	if len(s.externalAPIs[ctx]) < maxExternalAPIURLs {
+		s.externalAPIs[ctx] = append(s.externalAPIs[ctx], record)
+	}
+}
+
+// attachExternalAPIs adds an "external_apis" property to every component
+// depending on the SDK of a SaaS API or naming its base URL.
+func (s *Scanner) attachExternalAPIs(payload *types.Payload) {
+	index := s.apiHostIndex()
+	if len(index.names) == 0 {
+		return
+	}
+	walkPayloads(payload, func(p *types.Payload) {
+		if info := s.externalAPIsInfo(p, index); info != nil {
+			if p.Properties == nil {
+				p.Properties = make(map[string]interface{})
+			}
+			p.Properties["external_apis"] = info
+		}
+	})
+}
+
+// externalAPIsInfo builds the external_apis section of one component, or nil
+// when it calls no known SaaS API.
+func (s *Scanner) externalAPIsInfo(payload *types.Payload, index *apiHostIndex) *ExternalAPIsInfo {
+	evidence := make(map[string][]ExternalAPIEvidence)
+	for _, record := range s.apiSDKs(payload, index) {
+		evidence[record.tech] = append(evidence[record.tech], record.evidence)
+	}
+	for _, record := range s.externalAPIs[payload] {
+		evidence[record.tech] = append(evidence[record.tech], record.evidence)
+	}
+	if len(evidence) == 0 {
+		return nil
+	}
+	info := &ExternalAPIsInfo{}
+	for tech, items := range evidence {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Type != items[j].Type {
+				return items[i].Type < items[j].Type
+			}
+			return items[i].Value+items[i].File < items[j].Value+items[j].File
+		})
+		info.Providers = append(info.Providers, ExternalAPIProvider{Provider: tech, Name: index.names[tech], Evidence: items})
+	}
+	sort.Slice(info.Providers, func(i, j int) bool { return info.Providers[i].Provider < info.Providers[j].Provider })
+	return info
+}
+
+// apiSDKs returns the component's direct dependencies that match the rule
+// of a provider with api_hosts.
+func (s *Scanner) apiSDKs(payload *types.Payload, index *apiHostIndex) []externalAPIRecord {
+	if s.depDetector == nil {
+		return nil
+	}
+	var records []externalAPIRecord
+	for _, dep := range payload.Dependencies {
+		if !dep.Direct {
+			continue
+		}
+		for tech := range s.depDetector.MatchDependencies([]string{dep.Name}, dep.Type) {
+			if _, ok := index.names[tech]; ok {
+				records = append(records, externalAPIRecord{tech: tech, evidence: ExternalAPIEvidence{
+					Type: externalAPIEvidenceDependency, Value: dep.Type + ":" + dep.Name,
+				}})
+			}
+		}
+	}
+	return records
+}
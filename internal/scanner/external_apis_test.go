@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachExternalAPIs(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp", "dependencies": {"stripe": "^14.0.0", "express": "^4.18.0"}}`)
+	write("src/notify.js", "await fetch('https://slack.com/api/chat.postMessage', { method: 'POST' });\n")
+	write("config/default.yaml", "storage:\n  endpoint: https://storage.googleapis.com/myapp-assets\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "external_apis")
+	require.NotNil(t, component, "expected a component with an external_apis section")
+	info, ok := component.Properties["external_apis"].(*ExternalAPIsInfo)
+	require.True(t, ok)
+	assert.Equal(t, []ExternalAPIProvider{
+		{Provider: "gcp", Name: "GCP", Evidence: []ExternalAPIEvidence{
+			{Type: "url", Value: "storage.googleapis.com", File: "/config/default.yaml"},
+		}},
+		{Provider: "slack", Name: "Slack", Evidence: []ExternalAPIEvidence{
+			{Type: "url", Value: "slack.com/api", File: "/src/notify.js"},
+		}},
+		{Provider: "stripe", Name: "Stripe", Evidence: []ExternalAPIEvidence{
+			{Type: "dependency", Value: "npm:stripe"},
+		}},
+	}, info.Providers)
+}
+
+func TestRecordExternalAPIs(t *testing.T) {
+	rules := []types.Rule{
+		{Tech: "slack", Name: "Slack", Properties: map[string]interface{}{"api_hosts": []interface{}{"slack.com/api"}}},
+		{Tech: "gcp", Name: "GCP", Properties: map[string]interface{}{"api_hosts": []interface{}{"*.googleapis.com"}}},
+		{Tech: "stripe", Name: "Stripe", Properties: map[string]interface{}{"api_hosts": []interface{}{"api.stripe.com"}}},
+	}
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"url with path", "client.py", `requests.post("https://slack.com/api/chat.postMessage")`, "slack.com/api"},
+		{"quoted host", "settings.json", `{"host": "api.stripe.com"}`, "api.stripe.com"},
+		{"wildcard subdomain", "app.go", `const base = "https://bigquery.googleapis.com/v2"`, "bigquery.googleapis.com"},
+		{"other path on host", "client.py", `url = "https://slack.com/intl/en-gb"`, ""},
+		{"wildcard needs subdomain", "app.go", `"https://googleapis.com"`, ""},
+		{"lookalike host", "app.js", `fetch("https://api.stripe.com.example.com/v1")`, ""},
+		{"not code or config", "README.md", "Calls https://api.stripe.com", ""},
+		{"lock file", "package-lock.json", `{"resolved": "https://api.stripe.com/v1"}`, ""},
+		{"vendored", "vendor/stripe/client.go", `const base = "https://api.stripe.com/v1"`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{cachedBasePath: "/repo", rules: rules}
+			ctx := types.NewPayloadWithPath("main", "/")
+			s.recordExternalAPIs(ctx, "/repo/src/"+tt.file, []byte(tt.content))
+			var got string
+			if records := s.externalAPIs[ctx]; len(records) > 0 {
+				got = records[0].evidence.Value
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// BenchmarkRecordExternalAPIs searches source files for the api_hosts of the
+// embedded rules, as done for every code and config file of a scan. Most
+// files name no API host.
+func BenchmarkRecordExternalAPIs(b *testing.B) {
+	loaded, err := rules.LoadEmbeddedRules()
+	require.NoError(b, err)
+	var files [][]byte
+	for i := 0; i < 100; i++ {
+		var sb strings.Builder
+		for line := 0; line < 200; line++ {
+			fmt.Fprintf(&sb, "\tresult%d := compute(input, %d) // step %d of the pipeline\n", line, i, line)
+		}
+		if i%10 == 0 {
+			sb.WriteString(`const base = "https://api.stripe.com/v1"` + "\n")
+		}
+		files = append(files, []byte(sb.String()))
+	}
+	s := &Scanner{cachedBasePath: "/repo", rules: loaded}
+	s.apiHostIndex() // build outside the timing
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx := types.NewPayloadWithPath("main", "/")
+		for j, content := range files {
+			s.recordExternalAPIs(ctx, fmt.Sprintf("/repo/src/file%d.go", j), content)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// springActuatorDependency is the starter that exposes the Spring Boot
+// health endpoint.
+const springActuatorDependency = "spring-boot-starter-actuator"
+
+// HealthInfo is the health section of a component: the health, liveness,
+// readiness and startup checks it declares, and their kinds.
+type HealthInfo struct {
+	Checks []parsers.HealthCheck `json:"checks"`
+	Kinds  []string              `json:"kinds"`
+}
+
+// healthRecords are the health checks found in the files of a component.
+type healthRecords struct {
+	checks []parsers.HealthCheck
+	spring bool // a Spring Boot file configures the management endpoints
+}
+
+// recordHealth collects the health checks a file declares: the probes of
+// Kubernetes workloads, the Spring Boot Actuator health endpoint as
+// configured, and health routes registered in source files outside tests.
+func (s *Scanner) recordHealth(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	checks, spring := parseHealthFile(rel, content)
+	if len(checks) == 0 && !spring {
+		return
+	}
+	if s.health == nil {
+		s.health = make(map[*types.Payload]*healthRecords)
+	}
+	records := s.health[ctx]
+	if records == nil {
+		records = &healthRecords{}
+		s.health[ctx] = records
+	}
+	records.checks = append(records.checks, checks...)
+	records.spring = records.spring || spring
+}
+
+// The parsers keep no state, so one of each serves all files.
+var (
+	healthParser           = parsers.NewHealthParser()
+	healthDeploymentParser = parsers.NewDeploymentParser()
+)
+
+// parseHealthFile dispatches a file to the health parser for its kind,
+// reporting whether it is a Spring Boot file configuring the management
+// endpoints.
+func parseHealthFile(rel string, content []byte) ([]parsers.HealthCheck, bool) {
+	ext := strings.ToLower(path.Ext(rel))
+	if config, ok := healthParser.ParseSpringActuator(rel, content); ok {
+		return config.Checks(rel), true
+	}
+	switch {
+	case isYAML(ext) && !strings.Contains(rel, "/.github/") && healthParser.HasKubernetesProbes(content) &&
+		healthDeploymentParser.IsKubernetesWorkload(content):
+		return healthParser.ParseKubernetesProbes(rel, content), false
+	case !isTestPath(rel):
+		return healthParser.ParseHealthRoutes(rel, content), false
+	}
+	return nil, false
+}
+
+// attachHealth adds a "health" property to every component declaring
+// health checks. A component depending on the Spring Boot Actuator without
+// configuring it exposes the default /actuator/health endpoint.
+func (s *Scanner) attachHealth(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		var checks []parsers.HealthCheck
+		records := s.health[p]
+		if records != nil {
+			checks = records.checks
+		}
+		if (records == nil || !records.spring) && hasSpringActuator(p) {
+			checks = append(checks, parsers.SpringActuatorConfig{}.Checks(p.ComponentPath())...)
+		}
+		if len(checks) == 0 {
+			return
+		}
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["health"] = newHealthInfo(checks)
+	})
+}
+
+func hasSpringActuator(p *types.Payload) bool {
+	for _, dep := range p.Dependencies {
+		if strings.HasSuffix(dep.Name, springActuatorDependency) {
+			return true
+		}
+	}
+	return false
+}
+
+// newHealthInfo orders checks by file and drops the ones several files
+// declare alike, such as the actuator endpoint of every Spring profile.
+func newHealthInfo(checks []parsers.HealthCheck) *HealthInfo {
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].File < checks[j].File })
+	info := &HealthInfo{Checks: make([]parsers.HealthCheck, 0, len(checks))}
+	seen := make(map[parsers.HealthCheck]bool)
+	for _, check := range checks {
+		key := check
+		key.File = ""
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		info.Checks = append(info.Checks, check)
+		info.Kinds = appendUnique(info.Kinds, check.Kind)
+	}
+	sort.Strings(info.Kinds)
+	return info
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachHealth(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("orders/pom.xml", `<project>
+  <groupId>com.example</groupId>
+  <artifactId>orders</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.boot</groupId>
+      <artifactId>spring-boot-starter-actuator</artifactId>
+    </dependency>
+  </dependencies>
+</project>`)
+	write("web/package.json", `{"name": "web", "dependencies": {"express": "^4.18.0"}}`)
+	write("web/server.js", "app.get('/healthz', (req, res) => res.sendStatus(200))\n")
+	write("web/server.test.js", "request(app).get('/healthz')\n")
+	write("deploy/web.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\nspec:\n  template:\n    spec:\n      containers:\n        - name: web\n          readinessProbe:\n            httpGet:\n              path: /healthz\n              port: 3000\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	assert.Equal(t, &HealthInfo{
+		Checks: []parsers.HealthCheck{{Source: parsers.HealthSourceKubernetes, Kind: parsers.HealthKindReadiness, Type: "http", Path: "/healthz", Port: "3000", Workload: "Deployment/web/web", File: "/deploy/web.yaml"}},
+		Kinds:  []string{parsers.HealthKindReadiness},
+	}, result.Properties["health"])
+
+	health := make(map[string]interface{})
+	for _, child := range result.Children {
+		health[child.Name] = child.Properties["health"]
+	}
+	// The actuator dependency exposes the default endpoint; tests are skipped.
+	assert.Equal(t, &HealthInfo{
+		Checks: []parsers.HealthCheck{{Source: parsers.HealthSourceSpring, Kind: parsers.HealthKindHealth, Type: "http", Path: "/actuator/health", File: "/orders/pom.xml"}},
+		Kinds:  []string{parsers.HealthKindHealth},
+	}, health["com.example:orders"])
+	assert.Equal(t, &HealthInfo{
+		Checks: []parsers.HealthCheck{{Source: parsers.HealthSourceRoute, Kind: parsers.HealthKindHealth, Type: "http", Path: "/healthz", File: "/web/server.js"}},
+		Kinds:  []string{parsers.HealthKindHealth},
+	}, health["web"])
+}
+
+func TestNewHealthInfoDropsDuplicates(t *testing.T) {
+	info := newHealthInfo([]parsers.HealthCheck{
+		{Source: parsers.HealthSourceSpring, Kind: parsers.HealthKindHealth, Path: "/actuator/health", File: "/application-prod.yml"},
+		{Source: parsers.HealthSourceSpring, Kind: parsers.HealthKindHealth, Path: "/actuator/health", File: "/application.yml"},
+		{Source: parsers.HealthSourceRoute, Kind: parsers.HealthKindLiveness, Path: "/livez", File: "/app.py"},
+	})
+	assert.Equal(t, []parsers.HealthCheck{
+		{Source: parsers.HealthSourceRoute, Kind: parsers.HealthKindLiveness, Path: "/livez", File: "/app.py"},
+		{Source: parsers.HealthSourceSpring, Kind: parsers.HealthKindHealth, Path: "/actuator/health", File: "/application-prod.yml"},
+	}, info.Checks)
+	assert.Equal(t, []string{parsers.HealthKindHealth, parsers.HealthKindLiveness}, info.Kinds)
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"slices"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Modes of --resolve-implied: how techs implied by another detected tech of
+// the same component (a rule's implies list) are reported.
+const (
+	ImpliedKeep     = "keep"     // list every detected tech as is (default)
+	ImpliedCollapse = "collapse" // drop techs implied by another detected tech
+	ImpliedAdd      = "add"      // also list implied techs that were not detected themselves
+)
+
+// techRelations holds the implies and supersedes relations declared by the
+// rules, keyed by the declaring tech.
+type techRelations struct {
+	implies    map[string][]string
+	supersedes map[string][]string
+}
+
+func newTechRelations(rules []types.Rule) techRelations {
+	r := techRelations{implies: map[string][]string{}, supersedes: map[string][]string{}}
+	for _, rule := range rules {
+		if len(rule.Implies) > 0 {
+			r.implies[rule.Tech] = append(r.implies[rule.Tech], rule.Implies...)
+		}
+		if len(rule.Supersedes) > 0 {
+			r.supersedes[rule.Tech] = append(r.supersedes[rule.Tech], rule.Supersedes...)
+		}
+	}
+	return r
+}
+
+// impliedBy returns the techs tech implies, directly or through other
+// implied techs (blitzjs implies nextjs, which implies react).
+func (r techRelations) impliedBy(tech string) []string {
+	var implied []string
+	seen := map[string]bool{tech: true}
+	queue := []string{tech}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, other := range r.implies[next] {
+			if !seen[other] {
+				seen[other] = true
+				implied = append(implied, other)
+				queue = append(queue, other)
+			}
+		}
+	}
+	return implied
+}
+
+// SetImpliedTechs sets the --resolve-implied mode; empty keeps every
+// detected tech.
+func (s *Scanner) SetImpliedTechs(mode string) {
+	s.impliedMode = mode
+}
+
+// resolveTechRelations applies the rules' supersedes relations, and the
+// implies relations according to the --resolve-implied mode, to every
+// component. Relations only hold within a component: a React frontend next
+// to a Next.js app is still reported.
+func (s *Scanner) resolveTechRelations(root *types.Payload) {
+	rel := newTechRelations(s.rules)
+	if len(rel.implies) == 0 && len(rel.supersedes) == 0 {
+		return
+	}
+	var walk func(p *types.Payload)
+	walk = func(p *types.Payload) {
+		s.resolveComponentTechs(p, rel)
+		for _, child := range p.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+}
+
+func (s *Scanner) resolveComponentTechs(p *types.Payload, rel techRelations) {
+	replaceRelated(p, func(tech string) []string { return rel.supersedes[tech] })
+	switch s.impliedMode {
+	case ImpliedCollapse:
+		replaceRelated(p, rel.impliedBy)
+	case ImpliedAdd:
+		for _, tech := range slices.Clone(p.Techs) {
+			s.addImpliedTechs(p, tech, rel.impliedBy(tech))
+		}
+	}
+}
+
+// replaceRelated lets every tech of p replace the techs related to it. A
+// tech replaced earlier no longer replaces others.
+func replaceRelated(p *types.Payload, related func(tech string) []string) {
+	for _, tech := range slices.Clone(p.Techs) {
+		if !slices.Contains(p.Techs, tech) {
+			continue
+		}
+		for _, other := range related(tech) {
+			replaceTech(p, other, tech)
+		}
+	}
+}
+
+// replaceTech removes tech from p when present, attributing its reasons to
+// by, which also takes its place among the primary techs.
+func replaceTech(p *types.Payload, tech, by string) {
+	if !slices.Contains(p.Techs, tech) {
+		return
+	}
+	for _, reason := range p.Reason[tech] {
+		p.AddTech(by, reason)
+	}
+	if p.HasPrimaryTech(tech) {
+		p.AddPrimaryTech(by)
+	}
+	p.RemoveTech(tech)
+}
+
+// addImpliedTechs lists the techs implied by tech that p does not list yet.
+func (s *Scanner) addImpliedTechs(p *types.Payload, tech string, implied []string) {
+	for _, other := range implied {
+		if slices.Contains(p.Techs, other) {
+			continue
+		}
+		p.AddTech(other, "implied by "+tech)
+		s.depDetector.AddPrimaryTechIfNeeded(p, other)
+	}
+}
@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func impliedTestScanner(mode string) *Scanner {
+	isPrimary := true
+	rules := []types.Rule{
+		{Tech: "react", IsPrimaryTech: &isPrimary},
+		{Tech: "nextjs", Implies: []string{"react"}},
+		{Tech: "blitzjs", Implies: []string{"nextjs"}},
+		{Tech: "remixrun", Supersedes: []string{"remixrouter"}},
+	}
+	s := &Scanner{rules: rules, depDetector: NewDependencyDetector(rules)}
+	s.SetImpliedTechs(mode)
+	return s
+}
+
+func impliedTestPayload(techs ...string) *types.Payload {
+	p := types.NewPayloadWithPath("app", "/package.json")
+	for _, tech := range techs {
+		p.AddTech(tech, tech+" matched")
+	}
+	return p
+}
+
+func TestResolveTechRelations_KeepListsImpliedTechs(t *testing.T) {
+	root := impliedTestPayload()
+	app := impliedTestPayload("react", "nextjs")
+	root.AddChild(app)
+
+	impliedTestScanner("").resolveTechRelations(root)
+
+	assert.Equal(t, []string{"react", "nextjs"}, app.Techs)
+}
+
+func TestResolveTechRelations_CollapseDropsImpliedTechs(t *testing.T) {
+	app := impliedTestPayload("react", "nextjs", "blitzjs")
+	app.AddPrimaryTech("react")
+
+	impliedTestScanner(ImpliedCollapse).resolveTechRelations(app)
+
+	assert.Equal(t, []string{"blitzjs"}, app.Techs)
+	assert.Equal(t, []string{"blitzjs"}, app.Tech, "implying tech takes the primary place")
+	assert.ElementsMatch(t, []string{"react matched", "nextjs matched", "blitzjs matched"}, app.Reason["blitzjs"])
+	assert.NotContains(t, app.Reason, "react")
+}
+
+func TestResolveTechRelations_CollapseOnlyWithinComponent(t *testing.T) {
+	root := impliedTestPayload("nextjs")
+	frontend := impliedTestPayload("react")
+	root.AddChild(frontend)
+
+	impliedTestScanner(ImpliedCollapse).resolveTechRelations(root)
+
+	assert.Equal(t, []string{"react"}, frontend.Techs)
+}
+
+func TestResolveTechRelations_AddListsUndetectedImpliedTechs(t *testing.T) {
+	app := impliedTestPayload("blitzjs")
+
+	impliedTestScanner(ImpliedAdd).resolveTechRelations(app)
+
+	assert.Equal(t, []string{"blitzjs", "nextjs", "react"}, app.Techs)
+	assert.Equal(t, []string{"implied by blitzjs"}, app.Reason["react"])
+	assert.Equal(t, []string{"react"}, app.Tech, "implied primary techs are promoted")
+}
+
+func TestResolveTechRelations_SupersedesAppliesInEveryMode(t *testing.T) {
+	for _, mode := range []string{"", ImpliedKeep, ImpliedCollapse, ImpliedAdd} {
+		app := impliedTestPayload("remixrouter", "remixrun")
+
+		impliedTestScanner(mode).resolveTechRelations(app)
+
+		assert.Equal(t, []string{"remixrun"}, app.Techs, "mode %q", mode)
+		assert.Contains(t, app.Reason["remixrun"], "remixrouter matched", "mode %q", mode)
+	}
+}
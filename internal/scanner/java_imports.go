@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// javaImportRuleType is the rule dependency type matched against the names
+// Java and Kotlin sources import, such as javax.servlet.* or
+// java.net.http.HttpClient.
+const javaImportRuleType = "java.import"
+
+// javaImportLanguages maps the source extensions read for imports to the
+// language named in the detection reason.
+var javaImportLanguages = map[string]string{
+	".java": "java",
+	".kt":   "kotlin",
+}
+
+// SetJavaImports enables the detection of techs from the import statements
+// of Java and Kotlin sources (--java-imports).
+func (s *Scanner) SetJavaImports(enabled bool) {
+	if !enabled {
+		s.javaImports = nil
+		return
+	}
+	s.javaImports = make(map[*types.Payload]map[string]string)
+}
+
+// recordJavaImports collects the names a Java or Kotlin source imports for
+// its component, with the first file importing each.
+func (s *Scanner) recordJavaImports(ctx *types.Payload, filePath string, content []byte) {
+	if s.javaImports == nil || javaImportLanguages[filepath.Ext(filePath)] == "" {
+		return
+	}
+	names := javaImportNames(content)
+	if len(names) == 0 {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	imports := s.javaImports[ctx]
+	if imports == nil {
+		imports = make(map[string]string)
+		s.javaImports[ctx] = imports
+	}
+	for _, name := range names {
+		if _, seen := imports[name]; !seen {
+			imports[name] = "/" + filepath.ToSlash(rel)
+		}
+	}
+}
+
+// javaImportNames returns the names imported by a Java or Kotlin source. Only
+// the header is read: lines are matched by prefix, without parsing, up to the
+// first line that is not a comment, annotation, package or import statement.
+// Static imports name their class, and wildcard imports their package.
+func javaImportNames(content []byte) []string {
+	var names []string
+	inComment := false
+	lines := bufio.NewScanner(bytes.NewReader(content))
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if inComment || strings.HasPrefix(line, "/*") {
+			inComment = !strings.Contains(line, "*/")
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "@") || strings.HasPrefix(line, "package ") {
+			continue
+		}
+		rest, ok := strings.CutPrefix(line, "import ")
+		if !ok {
+			break
+		}
+		if name := javaImportName(rest); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// javaImportName returns the name of an import statement without its
+// keyword: "static org.junit.Assert.assertEquals;" is org.junit.Assert and
+// "kotlinx.coroutines.*" kotlinx.coroutines. A Kotlin alias is dropped.
+func javaImportName(statement string) string {
+	static := strings.HasPrefix(statement, "static ")
+	statement = strings.TrimSpace(strings.TrimPrefix(statement, "static "))
+	name, _, _ := strings.Cut(strings.TrimRight(statement, "; "), " ")
+	if strings.HasSuffix(name, ".*") {
+		return strings.TrimSuffix(name, ".*")
+	}
+	if static {
+		if i := strings.LastIndex(name, "."); i > 0 {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// attachJavaImports adds to every component the techs whose java.import
+// patterns match a name its sources import, with the first import and file
+// matching as the reason. Imports do not make a tech primary.
+func (s *Scanner) attachJavaImports(payload *types.Payload) {
+	if len(s.javaImports) == 0 {
+		return
+	}
+	walkPayloads(payload, func(p *types.Payload) {
+		imports := s.javaImports[p]
+		names := make([]string, 0, len(imports))
+		for name := range imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		added := make(map[string]bool)
+		for _, name := range names {
+			for tech := range s.depDetector.MatchDependencies([]string{name}, javaImportRuleType) {
+				if !added[tech] {
+					added[tech] = true
+					file := imports[name]
+					p.AddTech(tech, "imported-by-"+javaImportLanguages[filepath.Ext(file)]+": "+name+" ("+file+")")
+				}
+			}
+		}
+	})
+}
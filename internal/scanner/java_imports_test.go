@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJavaImportNames(t *testing.T) {
+	source := `/*
+ * Copyright (c) 2024 Example Corp.
+ */
+// Servlet entry point
+package com.example.web;
+
+import java.io.IOException;
+import java.net.http.*;
+import static org.junit.Assert.assertEquals;
+import javax.servlet.http.HttpServlet;
+
+@WebServlet("/hello")
+public class Hello extends HttpServlet {
+    // import org.example.NotAnImport;
+}
+import org.example.AfterTheClass;
+`
+	assert.Equal(t, []string{"java.io.IOException", "java.net.http", "org.junit.Assert", "javax.servlet.http.HttpServlet"},
+		javaImportNames([]byte(source)))
+
+	kotlin := "@file:JvmName(\"App\")\npackage com.example\n\nimport io.ktor.server.application.Application as App\nimport kotlinx.coroutines.*\n\nfun main() {}\n"
+	assert.Equal(t, []string{"io.ktor.server.application.Application", "kotlinx.coroutines"}, javaImportNames([]byte(kotlin)))
+}
+
+func TestScan_JavaImports(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src", "com", "example")
+	require.NoError(t, os.MkdirAll(src, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "build.xml"), []byte(`<project name="myapp" default="jar"/>`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "Hello.java"), []byte(`package com.example;
+
+import java.net.http.HttpClient;
+import javax.servlet.http.HttpServlet;
+
+public class Hello extends HttpServlet {}
+`), 0o644))
+
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+	assert.NotContains(t, payload.Techs, "servlet", "imports are only read when enabled")
+
+	s.SetJavaImports(true)
+	payload, err = s.Scan()
+	require.NoError(t, err)
+	assert.Contains(t, payload.Techs, "servlet")
+	assert.Contains(t, payload.Techs, "javahttpclient")
+	assert.Equal(t, []string{"imported-by-java: javax.servlet.http.HttpServlet (/src/com/example/Hello.java)"}, payload.Reason["servlet"])
+}
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// catalogExtensions are the extensions of files that may be message
+// catalogs; JSON and YAML files only count inside locale folders.
+var catalogExtensions = map[string]bool{
+	".po": true, ".pot": true, ".properties": true, ".json": true, ".yml": true, ".yaml": true,
+}
+
+// LocalizationInfo is the localization section of a component: its message
+// catalogs by format and the locales they provide, so translation coverage
+// can be compared across locales.
+type LocalizationInfo struct {
+	Files     int              `json:"files"`
+	Formats   map[string]int   `json:"formats"`             // catalog files per format
+	Templates int              `json:"templates,omitempty"` // gettext .pot templates
+	Locales   []LocaleCoverage `json:"locales"`
+
+	byLocale         map[string]*LocaleCoverage
+	templateMessages int
+}
+
+// LocaleCoverage counts the translated messages of one locale. Coverage is
+// relative to the locale or gettext template with the most messages in the
+// component.
+type LocaleCoverage struct {
+	Locale   string  `json:"locale"`
+	Files    int     `json:"files"`
+	Messages int     `json:"messages"`
+	Coverage float64 `json:"coverage"`
+}
+
+// recordLocalization counts a message catalog of a component.
+func (s *Scanner) recordLocalization(ctx *types.Payload, filePath string, content []byte) {
+	if !catalogExtensions[strings.ToLower(filepath.Ext(filePath))] {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	catalogs := parsers.NewI18nParser().ParseCatalog(filepath.ToSlash(rel), content)
+	if len(catalogs) == 0 {
+		return
+	}
+
+	if s.localization == nil {
+		s.localization = make(map[*types.Payload]*LocalizationInfo)
+	}
+	info := s.localization[ctx]
+	if info == nil {
+		info = &LocalizationInfo{Formats: make(map[string]int), byLocale: make(map[string]*LocaleCoverage)}
+		s.localization[ctx] = info
+	}
+	info.add(catalogs)
+}
+
+func (info *LocalizationInfo) add(catalogs []parsers.LocaleCatalog) {
+	info.Files++
+	info.Formats[catalogs[0].Format]++
+	for _, catalog := range catalogs {
+		if catalog.Locale == "" {
+			info.Templates++
+			info.templateMessages = max(info.templateMessages, catalog.Messages)
+			continue
+		}
+		locale := info.byLocale[catalog.Locale]
+		if locale == nil {
+			locale = &LocaleCoverage{Locale: catalog.Locale}
+			info.byLocale[catalog.Locale] = locale
+		}
+		locale.Files++
+		locale.Messages += catalog.Messages
+	}
+}
+
+// finish sorts the locales and computes their coverage.
+func (info *LocalizationInfo) finish() {
+	most := info.templateMessages
+	for _, locale := range info.byLocale {
+		most = max(most, locale.Messages)
+	}
+	info.Locales = make([]LocaleCoverage, 0, len(info.byLocale))
+	for _, locale := range info.byLocale {
+		if most > 0 {
+			locale.Coverage = math.Round(float64(locale.Messages)/float64(most)*100) / 100
+		}
+		info.Locales = append(info.Locales, *locale)
+	}
+	sort.Slice(info.Locales, func(i, j int) bool { return info.Locales[i].Locale < info.Locales[j].Locale })
+}
+
+// attachLocalization adds a "localization" property to every component
+// holding message catalogs.
+func (s *Scanner) attachLocalization(payload *types.Payload) {
+	if info := s.localization[payload]; info != nil {
+		info.finish()
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["localization"] = info
+	}
+	for _, child := range payload.Children {
+		s.attachLocalization(child)
+	}
+}
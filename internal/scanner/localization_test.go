@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachLocalization(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("web/package.json", `{"name": "web", "dependencies": {"i18next": "^23.0.0"}}`)
+	write("web/src/locales/en/common.json", `{"title": "Hi", "nav": {"home": "Home", "about": "About"}}`)
+	write("web/src/locales/en/errors.json", `{"notFound": "Not found"}`)
+	write("web/src/locales/de/common.json", `{"title": "Hallo", "nav": {"home": "Start"}}`)
+	write("web/tsconfig.json", `{"compilerOptions": {}}`)
+	write("py/requirements.txt", "flask\n")
+	write("py/locale/app.pot", "msgid \"\"\nmsgstr \"\"\n\nmsgid \"Hello\"\nmsgstr \"\"\n\nmsgid \"Bye\"\nmsgstr \"\"\n")
+	write("py/locale/fr/LC_MESSAGES/app.po", "msgid \"Hello\"\nmsgstr \"Bonjour\"\n\nmsgid \"Bye\"\nmsgstr \"\"\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*LocalizationInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["localization"].(*LocalizationInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+
+	web := byName["web"]
+	require.NotNil(t, web, "expected a localization section on the web component")
+	assert.Equal(t, 3, web.Files)
+	assert.Equal(t, map[string]int{"json": 3}, web.Formats)
+	assert.Equal(t, []LocaleCoverage{
+		{Locale: "de", Files: 1, Messages: 2, Coverage: 0.5},
+		{Locale: "en", Files: 2, Messages: 4, Coverage: 1},
+	}, web.Locales)
+
+	py := byName["py"]
+	require.NotNil(t, py, "expected a localization section on the py component")
+	assert.Equal(t, 1, py.Templates)
+	assert.Equal(t, []LocaleCoverage{{Locale: "fr", Files: 1, Messages: 1, Coverage: 0.5}}, py.Locales, "coverage against the template")
+}
@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	cobolProgramRegex = regexp.MustCompile(`(?im)^.{0,7}\s*PROGRAM-ID\b`)
+	cobolCICSRegex    = regexp.MustCompile(`(?i)\bEXEC\s+CICS\b`)
+	cobolSQLRegex     = regexp.MustCompile(`(?i)\bEXEC\s+SQL\b`)
+	// jclJobRegex matches a JOB statement: //JOBNAME JOB ...
+	jclJobRegex  = regexp.MustCompile(`(?m)^//[A-Z@#$][A-Z0-9@#$]{0,7}\s+JOB\b`)
+	jclProcRegex = regexp.MustCompile(`(?m)^//[A-Z@#$][A-Z0-9@#$]{0,7}\s+PROC\b`)
+	// db2DDLRegex matches statements only DB2 DDL uses (other databases have
+	// tablespaces too, but not created IN a database).
+	db2DDLRegex      = regexp.MustCompile(`(?i)\bCREATE\s+(STOGROUP|BUFFERPOOL)\b|\bCREATE\s+(LOB\s+)?TABLESPACE\s+\w+\s+IN\s+\w+|\bUSING\s+STOGROUP\b|\bCCSID\s+(EBCDIC|ASCII|UNICODE)\b|\bSET\s+CURRENT\s+SQLID\b`)
+	createTableRegex = regexp.MustCompile(`(?i)\bCREATE\s+TABLE\b`)
+)
+
+// MainframeInfo is the mainframe section of a component: counts of the
+// COBOL, JCL and DB2 assets found in it, for modernization assessments.
+type MainframeInfo struct {
+	CobolPrograms int `json:"cobol_programs,omitempty"`
+	Copybooks     int `json:"copybooks,omitempty"`
+	CICSPrograms  int `json:"cics_programs,omitempty"` // programs with EXEC CICS
+	SQLPrograms   int `json:"sql_programs,omitempty"`  // programs with embedded SQL
+	JCLJobs       int `json:"jcl_jobs,omitempty"`
+	JCLProcs      int `json:"jcl_procs,omitempty"`
+	DB2DDLFiles   int `json:"db2_ddl_files,omitempty"`
+	DB2Tables     int `json:"db2_tables,omitempty"`
+}
+
+// mainframeRecorders count one kind of mainframe asset per file extension;
+// they report whether the file was such an asset.
+var mainframeRecorders = map[string]func(info *MainframeInfo, content []byte) bool{
+	".cbl": recordCobol, ".cob": recordCobol, ".cobol": recordCobol, ".ccp": recordCobol, ".cpy": recordCobol, ".copy": recordCobol,
+	".jcl": recordJCL, ".proc": recordJCL, ".prc": recordJCL,
+	".sql": recordDB2DDL, ".ddl": recordDB2DDL, ".db2": recordDB2DDL,
+}
+
+// recordMainframeAsset counts a COBOL program or copybook, a JCL member or a
+// DB2 DDL script for the component the file belongs to.
+func (s *Scanner) recordMainframeAsset(ctx *types.Payload, filePath string, content []byte) {
+	record, ok := mainframeRecorders[strings.ToLower(filepath.Ext(filePath))]
+	if !ok {
+		return
+	}
+	info, known := s.mainframe[ctx]
+	if !known {
+		info = &MainframeInfo{}
+	}
+	if !record(info, content) || known {
+		return
+	}
+	if s.mainframe == nil {
+		s.mainframe = make(map[*types.Payload]*MainframeInfo)
+	}
+	s.mainframe[ctx] = info
+}
+
+// recordCobol counts a file with a PROGRAM-ID as a program, any other COBOL
+// source as a copybook.
+func recordCobol(info *MainframeInfo, content []byte) bool {
+	if !cobolProgramRegex.Match(content) {
+		info.Copybooks++
+		return true
+	}
+	info.CobolPrograms++
+	if cobolCICSRegex.Match(content) {
+		info.CICSPrograms++
+	}
+	if cobolSQLRegex.Match(content) {
+		info.SQLPrograms++
+	}
+	return true
+}
+
+func recordJCL(info *MainframeInfo, content []byte) bool {
+	jobs := len(jclJobRegex.FindAllIndex(content, -1))
+	procs := len(jclProcRegex.FindAllIndex(content, -1))
+	info.JCLJobs += jobs
+	info.JCLProcs += procs
+	return jobs+procs > 0
+}
+
+func recordDB2DDL(info *MainframeInfo, content []byte) bool {
+	if !db2DDLRegex.Match(content) {
+		return false
+	}
+	info.DB2DDLFiles++
+	info.DB2Tables += len(createTableRegex.FindAllIndex(content, -1))
+	return true
+}
+
+// attachMainframe adds a "mainframe" property to every component holding
+// mainframe assets.
+func (s *Scanner) attachMainframe(payload *types.Payload) {
+	if info := s.mainframe[payload]; info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["mainframe"] = info
+	}
+	for _, child := range payload.Children {
+		s.attachMainframe(child)
+	}
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachMainframe(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("cobol/ACCTUPD.cbl", `       IDENTIFICATION DIVISION.
+       PROGRAM-ID. ACCTUPD.
+       PROCEDURE DIVISION.
+           EXEC SQL
+               UPDATE ACCOUNT SET BALANCE = :WS-BAL
+           END-EXEC.
+           EXEC CICS RETURN END-EXEC.
+`)
+	write("cobol/RPTGEN.cob", "       IDENTIFICATION DIVISION.\n       PROGRAM-ID. RPTGEN.\n       PROCEDURE DIVISION.\n           STOP RUN.\n")
+	write("copybook/ACCTREC.cpy", "       01  ACCOUNT-RECORD.\n           05  ACCT-ID      PIC 9(10).\n")
+	write("jcl/NIGHTLY.jcl", "//NIGHTLY  JOB (ACCT),'BATCH',CLASS=A\n//STEP1    EXEC PGM=RPTGEN\n//SYSOUT   DD SYSOUT=*\n")
+	write("jcl/BACKUP.proc", "//BACKUP   PROC\n//STEP1    EXEC PGM=IEBGENER\n")
+	write("ddl/account.sql", "CREATE TABLESPACE ACCTTS IN ACCTDB USING STOGROUP SG1;\nCREATE TABLE ACCOUNT (ID INTEGER) IN ACCTDB.ACCTTS CCSID EBCDIC;\n")
+	write("ddl/postgres.sql", "CREATE TABLE users (id serial primary key);\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	info, ok := result.Properties["mainframe"].(*MainframeInfo)
+	require.True(t, ok, "expected a mainframe section on the root component")
+	assert.Equal(t, &MainframeInfo{
+		CobolPrograms: 2,
+		Copybooks:     1,
+		CICSPrograms:  1,
+		SQLPrograms:   1,
+		JCLJobs:       1,
+		JCLProcs:      1,
+		DB2DDLFiles:   1,
+		DB2Tables:     1,
+	}, info)
+	assert.Contains(t, result.Techs, "jcl")
+	assert.Contains(t, result.Techs, "cics")
+	assert.Contains(t, result.Techs, "db2")
+}
+
+func TestRecordDB2DDL_OtherDatabases(t *testing.T) {
+	for _, ddl := range []string{
+		"CREATE TABLESPACE fastspace LOCATION '/ssd1/postgresql/data';",
+		"CREATE TABLESPACE tbs_01 DATAFILE 'tbs_f2.dbf' SIZE 40M ONLINE;",
+		"CREATE TABLE orders (id int);",
+	} {
+		info := &MainframeInfo{}
+		assert.False(t, recordDB2DDL(info, []byte(ddl)), ddl)
+	}
+}
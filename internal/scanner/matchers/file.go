@@ -36,8 +36,18 @@ func ClearFileMatchers() {
 	})
 }
 
-// BuildFileMatchersFromRules creates file matchers from rules
+// BuildFileMatchersFromRules creates file matchers from rules and adds them
+// to the registry
 func BuildFileMatchersFromRules(rules []types.Rule) {
+	for _, matcher := range NewFileMatchers(rules) {
+		RegisterFileMatcher(matcher)
+	}
+}
+
+// NewFileMatchers creates the file matchers of rules without registering
+// them, for a scanner keeping its own set of rules.
+func NewFileMatchers(rules []types.Rule) []FileMatcher {
+	var fileMatchers []FileMatcher
 	for _, rule := range rules {
 		if len(rule.Files) == 0 {
 			continue
@@ -49,8 +59,9 @@ func BuildFileMatchersFromRules(rules []types.Rule) {
 		}
 
 		// Create matcher for this rule
-		RegisterFileMatcher(createFileMatcherForRule(rule))
+		fileMatchers = append(fileMatchers, createFileMatcherForRule(rule))
 	}
+	return fileMatchers
 }
 
 // createFileMatcherForRule creates a file matcher function for a specific rule
@@ -182,6 +193,11 @@ func globToRegex(glob string) string {
 // MatchFiles runs all file matchers and returns matched techs
 // Returns a map of tech -> reasons
 func MatchFiles(files []types.File, currentPath, basePath string) map[string][]string {
+	return MatchFilesWith(fileMatchers, files, currentPath, basePath)
+}
+
+// MatchFilesWith runs the given file matchers instead of the registered ones
+func MatchFilesWith(fileMatchers []FileMatcher, files []types.File, currentPath, basePath string) map[string][]string {
 	matched := make(map[string][]string)
 
 	for _, matcher := range fileMatchers {
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/blobcache"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/resolvestats"
 )
@@ -84,7 +85,7 @@ func NewRemoteSource(opts RemoteOptions) *RemoteSource {
 	baseURL = strings.TrimRight(baseURL, "/")
 	client := opts.Client
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = httpclient.New(30 * time.Second)
 	}
 	cache := opts.Cache
 	if cache == nil {
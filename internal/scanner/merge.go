@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// MergeScans combines the results of scanners that walked disjoint include
+// paths (see SetIncludePaths) of the same base directory into the first
+// result. Root techs, languages, dependencies and properties are combined
+// and the children appended in order. Component names, IDs, component
+// references and the metadata counts are then recomputed over the merged
+// tree, so names shared across paths are disambiguated and references
+// between components of different paths resolve as in a single scan;
+// duration is the wall-clock time of all scans and the detector stats are
+// summed.
+//
+// Post-processing that links components (proxies, desktop apps, network
+// exposure) has run per scan and does not cross paths.
+func (s *Scanner) MergeScans(results []*types.Payload, duration time.Duration) *types.Payload {
+	if len(results) == 0 {
+		return nil
+	}
+	root := results[0]
+	for _, other := range results[1:] {
+		root.Combine(other)
+		root.Children = append(root.Children, other.Children...)
+		root.Edges = append(root.Edges, other.Edges...)
+		root.DependencyEdges = append(root.DependencyEdges, other.DependencyEdges...)
+	}
+
+	s.consolidateReasons(root)
+	s.nameComponents(root)
+	root.AssignIDs(root.ID)
+	walkPayloads(root, func(p *types.Payload) { p.ComponentRefs = nil })
+	s.resolveComponentRefs(root)
+
+	if meta, ok := root.Metadata.(*metadata.ScanMetadata); ok {
+		for _, other := range results[1:] {
+			if otherMeta, ok := other.Metadata.(*metadata.ScanMetadata); ok {
+				meta.AddDetectorStats(otherMeta.DetectorStats)
+				if otherMeta.SkippedFiles != nil {
+					meta.AddSkippedFiles(*otherMeta.SkippedFiles)
+				}
+			}
+		}
+		meta.SetDuration(duration)
+		meta.SetFileCounts(s.countFilesAndComponents(root))
+		meta.SetLanguageCount(s.countLanguages(root))
+		meta.SetTechCounts(s.countTechs(root))
+	}
+	return root
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"slices"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// SetMergeImplicit enables the --merge-implicit pass: a component's implicit
+// children are folded into its techs once it has at least minSiblings of
+// them (0 or 1: any number).
+func (s *Scanner) SetMergeImplicit(enabled bool, minSiblings int) {
+	s.mergeImplicit = enabled
+	s.mergeImplicitMin = minSiblings
+}
+
+// isImplicitComponent reports whether p is a component created for a tech
+// alone: no manifest type, nothing below it, no dependencies, languages or
+// properties of its own and no repository of its own.
+func isImplicitComponent(p *types.Payload) bool {
+	return p.ComponentType == "" && isHollowComponent(p) && len(p.Properties) == 0 && p.Git == nil
+}
+
+// mergeImplicitComponents folds the implicit children of every component
+// having at least the minimum number of them into the component's techs,
+// keeping their reasons and confidence, and drops the edges to them.
+func (s *Scanner) mergeImplicitComponents(root *types.Payload) {
+	if !s.mergeImplicit {
+		return
+	}
+	folded := make(map[*types.Payload]bool)
+	walkPayloads(root, func(p *types.Payload) {
+		implicit := slices.DeleteFunc(slices.Clone(p.Children), func(child *types.Payload) bool { return !isImplicitComponent(child) })
+		if len(implicit) == 0 || len(implicit) < s.mergeImplicitMin {
+			return
+		}
+		for _, child := range implicit {
+			foldTechs(p, child)
+			folded[child] = true
+		}
+		p.Children = slices.DeleteFunc(p.Children, func(child *types.Payload) bool { return folded[child] })
+	})
+	if len(folded) == 0 {
+		return
+	}
+	walkPayloads(root, func(p *types.Payload) {
+		p.Edges = slices.DeleteFunc(p.Edges, func(e types.Edge) bool { return folded[e.Target] })
+	})
+}
+
+// foldTechs lists the techs of child in p, with child's reasons, and with
+// its confidence where p has not scored the tech itself.
+func foldTechs(p, child *types.Payload) {
+	for _, tech := range slices.Concat(child.Tech, child.Techs) {
+		reasons := child.Reason[tech]
+		if len(reasons) == 0 {
+			reasons = child.Reason["_"]
+		}
+		p.AddTech(tech, "")
+		for _, reason := range reasons {
+			p.AddTech(tech, reason)
+		}
+		if _, scored := p.Confidence[tech]; !scored {
+			if c, ok := child.Confidence[tech]; ok {
+				p.SetTechConfidence(tech, c)
+			}
+		}
+	}
+}
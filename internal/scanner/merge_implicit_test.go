@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func implicitTestComponent(name, tech string) *types.Payload {
+	p := types.NewPayloadWithPath(name, "/backend/package.json")
+	p.AddPrimaryTech(tech)
+	p.AddReason("matched file: /backend")
+	p.SetTechConfidence(tech, 0.95)
+	return p
+}
+
+func implicitTestTree() (root, app, redis, compose *types.Payload) {
+	root = types.NewPayloadWithPath("main", "/")
+	app = types.NewPayloadWithPath("backend", "/backend/package.json")
+	app.SetComponentType("nodejs")
+	app.AddTech("redis", "matched: redis")
+	redis = implicitTestComponent("Redis", "redis")
+	compose = implicitTestComponent("cache", "redis")
+	compose.Dependencies = []types.Dependency{{Type: "docker", Name: "redis"}}
+	app.AddChild(redis)
+	app.AddChild(compose)
+	app.AddChild(implicitTestComponent("PostgreSQL", "postgresql"))
+	app.AddEdges(redis)
+	root.AddChild(app)
+	return root, app, redis, compose
+}
+
+func TestMergeImplicitComponents_FoldsIntoParent(t *testing.T) {
+	root, app, _, compose := implicitTestTree()
+	s := &Scanner{}
+	s.SetMergeImplicit(true, 0)
+
+	s.mergeImplicitComponents(root)
+
+	assert.Equal(t, []*types.Payload{compose}, app.Children, "components with dependencies are kept")
+	assert.Empty(t, app.Edges)
+	assert.ElementsMatch(t, []string{"redis", "postgresql"}, app.Techs)
+	assert.Equal(t, []string{"matched file: /backend"}, app.Reason["postgresql"])
+	assert.Equal(t, 0.95, app.Confidence["postgresql"])
+	assert.Equal(t, []*types.Payload{app}, root.Children)
+}
+
+func TestMergeImplicitComponents_MinimumSiblings(t *testing.T) {
+	root, app, _, _ := implicitTestTree()
+	s := &Scanner{}
+	s.SetMergeImplicit(true, 3)
+
+	s.mergeImplicitComponents(root)
+
+	assert.Len(t, app.Children, 3, "two implicit components are below the minimum")
+}
+
+func TestMergeImplicitComponents_Disabled(t *testing.T) {
+	root, app, redis, _ := implicitTestTree()
+
+	(&Scanner{}).mergeImplicitComponents(root)
+
+	assert.Contains(t, app.Children, redis)
+	assert.Len(t, app.Edges, 1)
+}
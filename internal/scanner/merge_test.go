@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeScans(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"api", "web"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "api", "package.json"),
+		[]byte(`{"name": "myapp-api", "dependencies": {"express": "^4.18.0", "myapp-shared": "1.0.0"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "web", "package.json"),
+		[]byte(`{"name": "myapp-shared", "dependencies": {"react": "^18.2.0"}}`), 0o644))
+
+	paths := []string{"api", "web"}
+	results := make([]*types.Payload, len(paths))
+	scanners := make([]*Scanner, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		s, err := NewScannerWithOptionsAndRootID(tempDir, nil, false, false, false, false, nil, "myapp")
+		require.NoError(t, err)
+		s.SetIncludePaths([]string{path})
+		scanners[i] = s
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = scanners[i].Scan()
+		}(i)
+	}
+	wg.Wait()
+	require.NotNil(t, results[0])
+	require.NotNil(t, results[1])
+
+	merged := scanners[0].MergeScans(results, time.Second)
+	require.Len(t, merged.Children, 2)
+	api, web := merged.Children[0], merged.Children[1]
+	assert.Equal(t, "myapp-api", api.Name)
+	assert.Equal(t, "myapp-shared", web.Name)
+
+	// References across paths resolve on the merged tree.
+	assert.Contains(t, api.ComponentRefs, types.ComponentRef{TargetID: web.ID, PackageName: "myapp-shared"})
+
+	meta, ok := merged.Metadata.(*metadata.ScanMetadata)
+	require.True(t, ok)
+	assert.Equal(t, 3, meta.ComponentCount)
+	assert.Equal(t, int64(1000), meta.DurationMs)
+}
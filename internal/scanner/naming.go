@@ -0,0 +1,173 @@
+package scanner
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Sources of --component-naming, tried in the configured order until one
+// names the component.
+const (
+	NamingManifest  = "manifest"  // the name the detector read from the manifest
+	NamingDirectory = "directory" // the name of the directory the component owns
+	NamingRepoPath  = "repo-path" // the git repository name followed by the directory's path
+)
+
+// DefaultComponentNaming is the order of naming sources when none is
+// configured.
+const DefaultComponentNaming = NamingManifest + "," + NamingDirectory + "," + NamingRepoPath
+
+// placeholderNames are what detectors fall back to when a manifest names
+// nothing; the manifest source skips them.
+var placeholderNames = map[string]bool{"": true, "virtual": true, ".": true, "/": true, "unknown": true, "unnamed": true, "undefined": true}
+
+// SetComponentNaming sets the --component-naming sources, comma-separated;
+// empty uses DefaultComponentNaming.
+func (s *Scanner) SetComponentNaming(sources string) {
+	s.componentNaming = nil
+	for _, source := range strings.Split(sources, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			s.componentNaming = append(s.componentNaming, source)
+		}
+	}
+}
+
+// nameComponents names every component detected from a manifest (one with
+// a component type) from the first naming source that yields a name, then
+// disambiguates names shared by several components. Implicit components,
+// such as databases and compose services, keep the names of their techs.
+func (s *Scanner) nameComponents(root *types.Payload) {
+	sources := s.componentNaming
+	if len(sources) == 0 {
+		sources = strings.Split(DefaultComponentNaming, ",")
+	}
+	basePath := s.provider.GetBasePath()
+	var named []*types.Payload
+	var walk func(p *types.Payload, repo string)
+	walk = func(p *types.Payload, repo string) {
+		for _, child := range p.Children {
+			childRepo := repo
+			if child.Git != nil {
+				childRepo = repoName(child.Git, repo)
+			}
+			if child.ComponentType != "" {
+				child.Name = componentName(child, sources, basePath, childRepo)
+				named = append(named, child)
+			}
+			walk(child, childRepo)
+		}
+	}
+	walk(root, repoName(root.Git, filepath.Base(basePath)))
+	disambiguateNames(named)
+}
+
+// componentName returns the name of p from the first source yielding one;
+// p keeps its name when none does.
+func componentName(p *types.Payload, sources []string, basePath, repo string) string {
+	dir := strings.Trim(p.SourceDir, "/")
+	for _, source := range sources {
+		switch source {
+		case NamingManifest:
+			if !placeholderNames[strings.ToLower(p.Name)] {
+				return p.Name
+			}
+		case NamingDirectory:
+			if dir == "" {
+				return filepath.Base(basePath)
+			}
+			return filepath.Base(dir)
+		case NamingRepoPath:
+			if dir == "" {
+				return repo
+			}
+			return repo + "/" + dir
+		}
+	}
+	return p.Name
+}
+
+// repoName returns the repository name of a git remote URL
+// (https://example.com/myorg/myapp.git or git@example.com:myorg/myapp.git
+// give myapp), or fallback when there is no remote.
+func repoName(info *git.GitInfo, fallback string) string {
+	if info == nil || info.RemoteURL == "" {
+		return fallback
+	}
+	url := strings.TrimSuffix(strings.TrimRight(info.RemoteURL, "/"), ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	if url == "" {
+		return fallback
+	}
+	return url
+}
+
+// disambiguateNames appends to the names shared by several components the
+// shortest trailing part of their directories telling them apart
+// ("api (billing)", "api (orders)"), and their type when they share the
+// directory as well.
+func disambiguateNames(components []*types.Payload) {
+	byName := make(map[string][]*types.Payload)
+	var names []string
+	for _, p := range components {
+		if len(byName[p.Name]) == 0 {
+			names = append(names, p.Name)
+		}
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+	for _, name := range names {
+		group := byName[name]
+		if len(group) < 2 {
+			continue
+		}
+		labels := distinguishingDirs(group)
+		if !allDistinct(labels) {
+			for i, p := range group {
+				labels[i] += ", " + p.ComponentType
+			}
+		}
+		for i, p := range group {
+			p.Name = name + " (" + labels[i] + ")"
+		}
+	}
+}
+
+// distinguishingDirs returns, for each component, the fewest trailing
+// segments of its directory ("." for the scan root) that tell the different
+// directories of the group apart.
+func distinguishingDirs(group []*types.Payload) []string {
+	dirs := make([]string, len(group))
+	segments := make([][]string, len(group))
+	depth := 1
+	for i, p := range group {
+		dirs[i] = strings.Trim(p.SourceDir, "/")
+		if dirs[i] == "" {
+			dirs[i] = "."
+		}
+		segments[i] = strings.Split(dirs[i], "/")
+		depth = max(depth, len(segments[i]))
+	}
+	labels := make([]string, len(group))
+	for n := 1; n <= depth; n++ {
+		for i, segs := range segments {
+			labels[i] = strings.Join(segs[max(0, len(segs)-n):], "/")
+		}
+		if distinctCount(labels) == distinctCount(dirs) {
+			break
+		}
+	}
+	return labels
+}
+
+func allDistinct(labels []string) bool {
+	return distinctCount(labels) == len(labels)
+}
+
+func distinctCount(values []string) int {
+	return len(slices.Compact(slices.Sorted(slices.Values(values))))
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/git"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func namingTestComponent(name, manifest, componentType string) *types.Payload {
+	p := types.NewPayloadWithPath(name, manifest)
+	p.SetComponentType(componentType)
+	return p
+}
+
+func namingTestScanner(sources string) *Scanner {
+	s := &Scanner{provider: provider.NewFSProvider("/work/myapp")}
+	s.SetComponentNaming(sources)
+	return s
+}
+
+func TestNameComponents_FallsBackFromPlaceholderNames(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	web := namingTestComponent("web-ui", "/web/package.json", "nodejs")
+	unnamed := namingTestComponent("virtual", "/tools/lint/package.json", "nodejs")
+	atRoot := namingTestComponent("", "/go.mod", "golang")
+	root.AddChild(web)
+	root.AddChild(unnamed)
+	root.AddChild(atRoot)
+
+	namingTestScanner("").nameComponents(root)
+
+	assert.Equal(t, "main", root.Name, "the root keeps its name")
+	assert.Equal(t, "web-ui", web.Name)
+	assert.Equal(t, "lint", unnamed.Name)
+	assert.Equal(t, "myapp", atRoot.Name, "a root-level component takes the scan root's name")
+}
+
+func TestNameComponents_RepoPathUsesRemoteName(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Git = &git.GitInfo{RemoteURL: "git@example.com:myorg/platform.git"}
+	api := namingTestComponent("api", "/services/api/pom.xml", "maven")
+	root.AddChild(api)
+
+	namingTestScanner("repo-path").nameComponents(root)
+
+	assert.Equal(t, "platform/services/api", api.Name)
+}
+
+func TestNameComponents_KeepsImplicitComponentNames(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	db := types.NewPayloadWithPath("PostgreSQL", "/")
+	root.AddChild(db)
+
+	namingTestScanner("directory").nameComponents(root)
+
+	assert.Equal(t, "PostgreSQL", db.Name)
+}
+
+func TestNameComponents_DisambiguatesCollisions(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	billing := namingTestComponent("api", "/services/billing/api/package.json", "nodejs")
+	orders := namingTestComponent("api", "/services/orders/api/package.json", "nodejs")
+	ordersPy := namingTestComponent("api", "/services/orders/api/pyproject.toml", "python")
+	other := namingTestComponent("worker", "/worker/package.json", "nodejs")
+	for _, c := range []*types.Payload{billing, orders, ordersPy, other} {
+		root.AddChild(c)
+	}
+
+	namingTestScanner("").nameComponents(root)
+
+	assert.Equal(t, "api (billing/api, nodejs)", billing.Name)
+	assert.Equal(t, "api (orders/api, nodejs)", orders.Name)
+	assert.Equal(t, "api (orders/api, python)", ordersPy.Name)
+	assert.Equal(t, "worker", other.Name)
+}
+
+func TestDistinguishingDirs_ShortestSuffix(t *testing.T) {
+	group := []*types.Payload{
+		namingTestComponent("api", "/services/billing/api/package.json", "nodejs"),
+		namingTestComponent("api", "/services/orders/api/package.json", "nodejs"),
+		namingTestComponent("api", "/package.json", "nodejs"),
+	}
+
+	assert.Equal(t, []string{"billing/api", "orders/api", "."}, distinguishingDirs(group))
+}
+
+func TestRepoName(t *testing.T) {
+	assert.Equal(t, "myapp", repoName(&git.GitInfo{RemoteURL: "https://example.com/myorg/myapp.git"}, "dir"))
+	assert.Equal(t, "myapp", repoName(&git.GitInfo{RemoteURL: "https://example.com/myorg/myapp/"}, "dir"))
+	assert.Equal(t, "dir", repoName(&git.GitInfo{}, "dir"))
+	assert.Equal(t, "dir", repoName(nil, "dir"))
+}
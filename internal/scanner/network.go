@@ -0,0 +1,217 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	composeFileRegex = regexp.MustCompile(`^(?:docker-)?compose(?:[.-][\w.-]+)?\.ya?ml$`)
+	springFileRegex  = regexp.MustCompile(`^application(?:-[\w-]+)?\.(?:properties|ya?ml)$`)
+)
+
+// NetworkInfo is the network section of a component: the ports it is
+// declared to listen on, merged across Dockerfiles, Compose files,
+// Kubernetes Services and Ingresses, proxy and server framework
+// configuration.
+type NetworkInfo struct {
+	Ports   []NetworkPort `json:"ports"`
+	Public  bool          `json:"public"`  // at least one port is reachable from outside
+	Ingress bool          `json:"ingress"` // at least one port is routed to by an Ingress
+}
+
+// NetworkPort is a port and protocol with the exposure its sources declare.
+type NetworkPort struct {
+	Port      int      `json:"port"`
+	Protocol  string   `json:"protocol"`
+	Published []int    `json:"published,omitempty"` // host, node or service ports mapped to it
+	Public    bool     `json:"public"`
+	Ingress   bool     `json:"ingress"`
+	Hosts     []string `json:"hosts,omitempty"` // Ingress hosts
+	Sources   []string `json:"sources"`
+}
+
+// networkRecord is a port read from a file in dir (slash-separated, rooted
+// at the scan root).
+type networkRecord struct {
+	parsers.ExposedPort
+	dir string
+}
+
+// recordNetwork reads the ports declared by container, orchestration and
+// server framework configuration files.
+func (s *Scanner) recordNetwork(ctx *types.Payload, filePath string, content []byte) {
+	ports := s.parseNetworkFile(filepath.Base(filePath), content)
+	if len(ports) == 0 {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filepath.Dir(filePath))
+	if err != nil {
+		return
+	}
+	dir := path.Clean("/" + filepath.ToSlash(rel))
+	if s.network == nil {
+		s.network = make(map[*types.Payload][]networkRecord)
+	}
+	for _, port := range ports {
+		s.network[ctx] = append(s.network[ctx], networkRecord{ExposedPort: port, dir: dir})
+	}
+}
+
+func (s *Scanner) parseNetworkFile(name string, content []byte) []parsers.ExposedPort {
+	parser := parsers.NewNetworkParser()
+	ext := strings.ToLower(filepath.Ext(name))
+	switch {
+	case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile."):
+		return parser.ParseDockerfile(string(content))
+	case composeFileRegex.MatchString(name):
+		return parser.ParseCompose(content)
+	case springFileRegex.MatchString(name):
+		return parser.ParseSpringConfig(ext != ".properties", content)
+	case name == "launchSettings.json":
+		return parser.ParseLaunchSettings(content)
+	case name == "puma.rb":
+		return parser.ParsePuma(string(content))
+	case (ext == ".yaml" || ext == ".yml") && parser.IsKubernetesManifest(content):
+		ports, backends := parser.ParseKubernetes(content)
+		s.ingressBackends = append(s.ingressBackends, backends...)
+		return ports
+	}
+	return nil
+}
+
+// attachNetwork adds a "network" property to every component with declared
+// ports. Compose services are attributed to the component in their build
+// context or named after them, Kubernetes Services to the component named
+// after them, and proxy listeners to the proxy component; other ports stay
+// on the component holding the file.
+func (s *Scanner) attachNetwork(root *types.Payload) {
+	if len(s.network) == 0 && len(s.proxies) == 0 {
+		return
+	}
+	index := newServiceIndex(root)
+	ingress := s.ingressByService()
+	infos := make(networkInfos)
+	for ctx, records := range s.network {
+		for _, record := range records {
+			port := record.networkPort()
+			if backend, ok := ingress[record.Service]; ok && record.Source == parsers.NetworkSourceKubernetes {
+				port.Ingress, port.Public, port.Hosts = true, true, backend.Hosts
+			}
+			infos.add(index.owner(ctx, record), port)
+		}
+	}
+	for ctx, configs := range s.proxies {
+		infos.addProxyListeners(ctx, configs)
+	}
+
+	for target, info := range infos {
+		info.finish()
+		if target.Properties == nil {
+			target.Properties = make(map[string]interface{})
+		}
+		target.Properties["network"] = info
+	}
+}
+
+// networkInfos collects the network sections by component.
+type networkInfos map[*types.Payload]*NetworkInfo
+
+func (n networkInfos) add(target *types.Payload, port NetworkPort) {
+	if n[target] == nil {
+		n[target] = &NetworkInfo{}
+	}
+	n[target].add(port)
+}
+
+// addProxyListeners adds the listeners of the proxy configurations held by
+// ctx to their proxy components. Listeners not bound to a loopback address
+// are public.
+func (n networkInfos) addProxyListeners(ctx *types.Payload, configs []*parsers.ProxyConfig) {
+	for _, config := range configs {
+		source := proxyComponent(ctx, config.Server)
+		for _, listener := range config.Listeners {
+			public := listener.Address == "" || !localHosts[listener.Address]
+			n.add(source, NetworkPort{Port: listener.Port, Protocol: "tcp", Public: public, Sources: []string{config.Server}})
+		}
+	}
+}
+
+// ingressByService merges the Ingress backends of all manifests by Service.
+func (s *Scanner) ingressByService() map[string]parsers.IngressBackend {
+	byService := make(map[string]parsers.IngressBackend)
+	for _, backend := range s.ingressBackends {
+		merged := byService[backend.Service]
+		merged.Service = backend.Service
+		merged.Hosts = append(merged.Hosts, backend.Hosts...)
+		merged.TLS = merged.TLS || backend.TLS
+		byService[backend.Service] = merged
+	}
+	return byService
+}
+
+func (r networkRecord) networkPort() NetworkPort {
+	port := NetworkPort{Port: r.Port, Protocol: r.Protocol, Public: r.Public, Sources: []string{r.Source}}
+	if r.Published > 0 {
+		port.Published = []int{r.Published}
+	}
+	return port
+}
+
+// owner returns the component a port record belongs to.
+func (idx *serviceIndex) owner(ctx *types.Payload, record networkRecord) *types.Payload {
+	if record.Build != "" {
+		if component, ok := idx.byDir[path.Join(record.dir, record.Build)]; ok {
+			return component
+		}
+	}
+	if record.Service != "" {
+		if candidates := idx.byName[strings.ToLower(record.Service)]; len(candidates) == 1 {
+			return candidates[0]
+		}
+	}
+	return ctx
+}
+
+func (info *NetworkInfo) add(port NetworkPort) {
+	for i := range info.Ports {
+		existing := &info.Ports[i]
+		if existing.Port == port.Port && existing.Protocol == port.Protocol {
+			existing.Published = append(existing.Published, port.Published...)
+			existing.Public = existing.Public || port.Public
+			existing.Ingress = existing.Ingress || port.Ingress
+			existing.Hosts = append(existing.Hosts, port.Hosts...)
+			existing.Sources = append(existing.Sources, port.Sources...)
+			return
+		}
+	}
+	info.Ports = append(info.Ports, port)
+}
+
+// finish sorts the ports and their lists and sets the component-level flags.
+func (info *NetworkInfo) finish() {
+	sort.Slice(info.Ports, func(i, j int) bool {
+		if info.Ports[i].Port != info.Ports[j].Port {
+			return info.Ports[i].Port < info.Ports[j].Port
+		}
+		return info.Ports[i].Protocol < info.Ports[j].Protocol
+	})
+	for i := range info.Ports {
+		port := &info.Ports[i]
+		slices.Sort(port.Published)
+		port.Published = slices.Compact(port.Published)
+		slices.Sort(port.Hosts)
+		port.Hosts = slices.Compact(port.Hosts)
+		slices.Sort(port.Sources)
+		port.Sources = slices.Compact(port.Sources)
+		info.Public = info.Public || port.Public
+		info.Ingress = info.Ingress || port.Ingress
+	}
+}
@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachNetwork(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Dockerfile", "FROM node:20\nEXPOSE 3000\n")
+	write("docker-compose.yml", "services:\n  api:\n    build: ./api\n    ports:\n      - \"8080:3000\"\n  cache:\n    image: redis:7\n    ports:\n      - \"127.0.0.1:6379:6379\"\n")
+	write("k8s/api.yaml", `apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  ports:
+    - port: 80
+      targetPort: 3000
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: api
+spec:
+  rules:
+    - host: api.example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: api
+                port:
+                  number: 80
+`)
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*NetworkInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["network"].(*NetworkInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+
+	api := byName["api"]
+	require.NotNil(t, api, "expected a network section on the api component")
+	assert.True(t, api.Public)
+	assert.True(t, api.Ingress)
+	assert.Equal(t, []NetworkPort{{
+		Port:      3000,
+		Protocol:  "tcp",
+		Published: []int{80, 8080},
+		Public:    true,
+		Ingress:   true,
+		Hosts:     []string{"api.example.com"},
+		Sources:   []string{"compose", "dockerfile", "kubernetes"},
+	}}, api.Ports)
+
+	cache := byName["cache"]
+	require.NotNil(t, cache, "expected a network section on the cache service")
+	assert.False(t, cache.Public)
+	assert.Equal(t, []NetworkPort{{Port: 6379, Protocol: "tcp", Sources: []string{"compose"}}}, cache.Ports)
+}
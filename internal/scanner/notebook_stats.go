@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// notebookKernelLanguages maps notebook kernel languages to the language
+// name and file extension their code cells are counted as.
+var notebookKernelLanguages = map[string]struct{ language, ext string }{
+	"python": {"Python", ".py"},
+	"r":      {"R", ".r"},
+	"julia":  {"Julia", ".jl"},
+	"scala":  {"Scala", ".scala"},
+}
+
+// collectNotebookStats counts the code cells of a Jupyter notebook in the
+// code statistics under the kernel language, instead of the notebook JSON.
+// Returns false when the file is not a notebook with a known kernel language,
+// so the caller falls back to regular statistics.
+func (s *Scanner) collectNotebookStats(filePath, typeOverride string, content []byte, ctx *types.Payload) bool {
+	if !strings.HasSuffix(filePath, ".ipynb") {
+		return false
+	}
+	nb, err := parsers.NewNotebookParser().ParseNotebook(content)
+	if err != nil {
+		return false
+	}
+	kernel, ok := notebookKernelLanguages[nb.Language]
+	if !ok {
+		return false
+	}
+	// The kernel extension lets the line counter apply the kernel language's
+	// comment syntax.
+	s.collectCodeStats(filePath+kernel.ext, kernel.language, typeOverride, []byte(nb.Code), ctx)
+	return true
+}
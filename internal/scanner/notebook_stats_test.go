@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingStats records the files passed to ProcessFile
+type recordingStats struct {
+	codestats.Analyzer
+	files     []string
+	languages []string
+	contents  []string
+}
+
+func (r *recordingStats) ProcessFile(filename, language, _ string, content []byte, _, _ string) {
+	r.files = append(r.files, filename)
+	r.languages = append(r.languages, language)
+	r.contents = append(r.contents, string(content))
+}
+
+func TestCollectNotebookStats(t *testing.T) {
+	stats := &recordingStats{}
+	s := &Scanner{codeStats: stats}
+	ctx := types.NewPayloadWithPath("main", "/")
+
+	notebook := []byte(`{"cells": [{"cell_type": "markdown", "source": "# Title"}, {"cell_type": "code", "source": ["x = 1\n", "print(x)"]}],
+  "metadata": {"language_info": {"name": "python"}}}`)
+	assert.True(t, s.collectNotebookStats("/repo/analysis.ipynb", "", notebook, ctx))
+	assert.Equal(t, []string{"/repo/analysis.ipynb.py"}, stats.files)
+	assert.Equal(t, []string{"Python"}, stats.languages)
+	assert.Equal(t, []string{"x = 1\nprint(x)"}, stats.contents)
+
+	assert.False(t, s.collectNotebookStats("/repo/main.py", "", []byte("x = 1"), ctx))
+	assert.False(t, s.collectNotebookStats("/repo/broken.ipynb", "", []byte("{"), ctx))
+	assert.False(t, s.collectNotebookStats("/repo/sql.ipynb", "", []byte(`{"cells": [], "metadata": {"language_info": {"name": "sql"}}}`), ctx))
+}
@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// NotificationsInfo is the notifications section of a component: the email,
+// SMS and push providers it sends through, with the sender domains and
+// endpoints its configuration names.
+type NotificationsInfo struct {
+	Providers     []NotificationProviderInfo `json:"providers"`
+	SenderDomains []string                   `json:"sender_domains,omitempty"` // From addresses not tied to a provider
+}
+
+// NotificationProviderInfo describes one notification provider. Channels
+// come from the provider's rule; sender domains, endpoints and files from
+// configuration evidence.
+type NotificationProviderInfo struct {
+	Provider      string   `json:"provider"`
+	Channels      []string `json:"channels,omitempty"` // email, sms, voice, push, chat
+	SenderDomains []string `json:"sender_domains,omitempty"`
+	Endpoints     []string `json:"endpoints,omitempty"`
+	Files         []string `json:"files,omitempty"`
+}
+
+// attachNotifications adds a "notifications" property to every component
+// using a notification provider, folding in the configuration evidence
+// recorded by the notification detector.
+func (s *Scanner) attachNotifications(payload *types.Payload) {
+	channels := make(map[string][]string)
+	for _, rule := range s.rules {
+		if rule.Type == "notification" {
+			channels[rule.Tech] = ruleChannels(rule)
+		}
+	}
+	walkPayloads(payload, func(p *types.Payload) {
+		if info := notificationsInfo(p, channels); info != nil {
+			if p.Properties == nil {
+				p.Properties = make(map[string]interface{})
+			}
+			p.Properties["notifications"] = info
+		}
+		delete(p.Properties, "notification_evidence")
+	})
+}
+
+func ruleChannels(rule types.Rule) []string {
+	values, _ := rule.Properties["channels"].([]interface{})
+	var channels []string
+	for _, value := range values {
+		channels = append(channels, fmt.Sprint(value))
+	}
+	return channels
+}
+
+// notificationsInfo builds the notifications section of one component, or
+// nil when it uses no notification provider.
+func notificationsInfo(payload *types.Payload, channels map[string][]string) *NotificationsInfo {
+	providers := make(map[string]*notificationSet)
+	get := func(name string) *notificationSet {
+		if providers[name] == nil {
+			providers[name] = &notificationSet{senders: map[string]bool{}, endpoints: map[string]bool{}, files: map[string]bool{}}
+		}
+		return providers[name]
+	}
+	for _, tech := range payload.Techs {
+		if _, ok := channels[tech]; ok {
+			get(tech)
+		}
+	}
+	evidence, _ := payload.Properties["notification_evidence"].([]interface{})
+	for _, item := range evidence {
+		if ev, ok := item.(parsers.NotificationEvidence); ok {
+			get(ev.Provider).add(ev)
+		}
+	}
+	// Sender domains not tied to a provider are reported on the section.
+	unattributed := providers[""]
+	delete(providers, "")
+	if len(providers) == 0 {
+		return nil
+	}
+
+	info := &NotificationsInfo{}
+	if unattributed != nil {
+		info.SenderDomains = sortedSet(unattributed.senders)
+	}
+	for name, set := range providers {
+		info.Providers = append(info.Providers, NotificationProviderInfo{
+			Provider:      name,
+			Channels:      channels[name],
+			SenderDomains: sortedSet(set.senders),
+			Endpoints:     sortedSet(set.endpoints),
+			Files:         sortedSet(set.files),
+		})
+	}
+	sort.Slice(info.Providers, func(i, j int) bool {
+		return info.Providers[i].Provider < info.Providers[j].Provider
+	})
+	return info
+}
+
+type notificationSet struct {
+	senders, endpoints, files map[string]bool
+}
+
+func (n *notificationSet) add(ev parsers.NotificationEvidence) {
+	if ev.SenderDomain != "" {
+		n.senders[ev.SenderDomain] = true
+	}
+	if ev.Endpoint != "" {
+		n.endpoints[ev.Endpoint] = true
+	}
+	if ev.File != "" {
+		n.files[ev.File] = true
+	}
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachNotifications(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"),
+		[]byte(`{"name": "myapp", "dependencies": {"@sendgrid/mail": "^8.1.0", "twilio": "^5.0.0"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env.example"),
+		[]byte("SENDGRID_FROM_EMAIL=noreply@example.com\nMAIL_FROM_ADDRESS=hello@mail.example.com\nTWILIO_API_URL=https://api.twilio.com\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	// Configuration evidence merges into the directory's parent component;
+	// the npm dependencies stay with myapp.
+	root, ok := result.Properties["notifications"].(*NotificationsInfo)
+	require.True(t, ok, "expected a notifications section on the root component")
+	assert.NotContains(t, result.Properties, "notification_evidence")
+	assert.Equal(t, []string{"mail.example.com"}, root.SenderDomains)
+	assert.Equal(t, []NotificationProviderInfo{
+		{Provider: "sendgrid", Channels: []string{"email"}, SenderDomains: []string{"example.com"}, Files: []string{"/.env.example"}},
+		{Provider: "twilio", Channels: []string{"sms", "voice"}, Endpoints: []string{"api.twilio.com"}, Files: []string{"/.env.example"}},
+	}, root.Providers)
+
+	var app *NotificationsInfo
+	for _, child := range result.Children {
+		if child.Name == "myapp" {
+			app, _ = child.Properties["notifications"].(*NotificationsInfo)
+		}
+	}
+	require.NotNil(t, app)
+	assert.Equal(t, []NotificationProviderInfo{
+		{Provider: "sendgrid", Channels: []string{"email"}},
+		{Provider: "twilio", Channels: []string{"sms", "voice"}},
+	}, app.Providers)
+}
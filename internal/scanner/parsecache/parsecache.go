@@ -0,0 +1,164 @@
+// Package parsecache caches the dependency lists parsed from lock files,
+// keyed by a hash of the parser's inputs. Identical files within a scan (a
+// vendored copy of the same lock file, or a workspace lock read by every
+// member) are parsed once; with a Backing store, unchanged files are not
+// parsed again by later scans either.
+//
+// Keys include the analyzer version, so a release with changed parsers never
+// reads entries written by another one.
+package parsecache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/petrarca/tech-stack-analyzer/internal/version"
+)
+
+// maxEntries bounds the in-memory layer; the oldest entries are evicted
+// first, so long-running processes do not grow without limit.
+const maxEntries = 512
+
+// Backing persists encoded dependency lists across scans. Implementations
+// must be safe for concurrent use; failures degrade to misses.
+type Backing interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, data []byte)
+}
+
+// Stats counts how parse requests were served.
+type Stats struct {
+	MemoryHits int64 // served from the in-memory layer
+	StoreHits  int64 // served from the backing store
+	Parsed     int64 // parsed from the file contents
+}
+
+// Cache is a concurrency-safe parse cache. A nil *Cache parses every time.
+type Cache struct {
+	backing Backing
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	order   []string
+
+	memoryHits, storeHits, parsed atomic.Int64
+}
+
+// entry is a parse result; ready is closed once deps is set, so concurrent
+// requests for the same key wait for the first parse instead of repeating it.
+type entry struct {
+	ready chan struct{}
+	deps  []types.Dependency
+}
+
+// New returns a cache over backing, which may be nil for an in-memory cache.
+func New(backing Backing) *Cache {
+	return &Cache{backing: backing, entries: make(map[string]*entry)}
+}
+
+// Key identifies a parse by parser name and the exact contents of every
+// input it reads (e.g. the lock file and its manifest).
+func Key(parser string, inputs ...[]byte) string {
+	h := sha256.New()
+	h.Write([]byte(buildID + "\x00" + parser + "\x00"))
+	var size [8]byte
+	for _, in := range inputs {
+		binary.BigEndian.PutUint64(size[:], uint64(len(in)))
+		h.Write(size[:])
+		h.Write(in)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Dependencies returns the dependencies for key, calling parse only when
+// neither layer has them. Every caller receives its own copy, so callers
+// may modify the result.
+func (c *Cache) Dependencies(key string, parse func() []types.Dependency) []types.Dependency {
+	if c == nil {
+		return parse()
+	}
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.ready
+		c.memoryHits.Add(1)
+		return cloneDependencies(e.deps)
+	}
+	e := &entry{ready: make(chan struct{})}
+	c.entries[key] = e
+	c.order = append(c.order, key)
+	c.evict()
+	c.mu.Unlock()
+
+	defer close(e.ready)
+	e.deps = c.load(key, parse)
+	return cloneDependencies(e.deps)
+}
+
+// Stats returns the counters since the cache was created.
+func (c *Cache) Stats() Stats {
+	return Stats{MemoryHits: c.memoryHits.Load(), StoreHits: c.storeHits.Load(), Parsed: c.parsed.Load()}
+}
+
+// load reads key from the backing store, or parses and stores it.
+func (c *Cache) load(key string, parse func() []types.Dependency) []types.Dependency {
+	if c.backing != nil {
+		if data, ok := c.backing.Get(key); ok {
+			var deps []types.Dependency
+			if json.Unmarshal(data, &deps) == nil {
+				c.storeHits.Add(1)
+				return deps
+			}
+		}
+	}
+	deps := parse()
+	c.parsed.Add(1)
+	if c.backing != nil {
+		if data, err := json.Marshal(deps); err == nil {
+			c.backing.Put(key, data)
+		}
+	}
+	return deps
+}
+
+// evict drops the oldest entries beyond maxEntries. Callers hold c.mu.
+func (c *Cache) evict() {
+	for len(c.order) > maxEntries {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// cloneDependencies copies deps and their metadata maps.
+func cloneDependencies(deps []types.Dependency) []types.Dependency {
+	if deps == nil {
+		return nil
+	}
+	out := make([]types.Dependency, len(deps))
+	for i, d := range deps {
+		d.Metadata = maps.Clone(d.Metadata)
+		out[i] = d
+	}
+	return out
+}
+
+// buildID identifies the analyzer build for cache keys. Development builds
+// carry no release version, so the VCS revision stands in for it.
+var buildID = func() string {
+	id := version.Full()
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" || s.Key == "vcs.modified" {
+				id += " " + s.Value
+			}
+		}
+	}
+	return id
+}()
@@ -0,0 +1,110 @@
+package parsecache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// mapBacking is an in-memory Backing.
+type mapBacking struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *mapBacking) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.data[key]
+	return d, ok
+}
+
+func (m *mapBacking) Put(key string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = data
+}
+
+func sampleDeps() []types.Dependency {
+	return []types.Dependency{
+		{Type: "npm", Name: "express", Version: "4.18.2", Scope: "prod", Direct: true, Metadata: map[string]interface{}{"source": "package-lock.json", "optional": true}},
+		{Type: "npm", Name: "debug", Version: "2.6.9", Scope: "prod"},
+	}
+}
+
+func TestKey(t *testing.T) {
+	k := Key("package-lock.json", []byte("lock"), []byte("pkg"))
+	assert.Equal(t, k, Key("package-lock.json", []byte("lock"), []byte("pkg")))
+	assert.NotEqual(t, k, Key("yarn.lock", []byte("lock"), []byte("pkg")))
+	assert.NotEqual(t, k, Key("package-lock.json", []byte("lock"), []byte("other")))
+	// Input boundaries are part of the key.
+	assert.NotEqual(t, Key("p", []byte("ab"), []byte("c")), Key("p", []byte("a"), []byte("bc")))
+}
+
+func TestDependenciesParsesOncePerKey(t *testing.T) {
+	c := New(nil)
+	var calls atomic.Int32
+	parse := func() []types.Dependency {
+		calls.Add(1)
+		return sampleDeps()
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Len(t, c.Dependencies("k", parse), 2)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load())
+	assert.Equal(t, Stats{MemoryHits: 7, Parsed: 1}, c.Stats())
+}
+
+func TestDependenciesReturnsCopies(t *testing.T) {
+	c := New(nil)
+	first := c.Dependencies("k", sampleDeps)
+	first[0].Metadata["source"] = "workspace-lock"
+	first[1].Version = "changed"
+
+	second := c.Dependencies("k", sampleDeps)
+	assert.Equal(t, sampleDeps(), second)
+}
+
+func TestDependenciesUsesBacking(t *testing.T) {
+	backing := &mapBacking{data: map[string][]byte{}}
+	key := Key("package-lock.json", []byte("lock"))
+	require.Equal(t, sampleDeps(), New(backing).Dependencies(key, sampleDeps))
+	require.Len(t, backing.data, 1)
+
+	// A later scan reads the stored result instead of parsing.
+	later := New(backing)
+	deps := later.Dependencies(key, func() []types.Dependency {
+		t.Fatal("parsed although the backing store has the result")
+		return nil
+	})
+	assert.Equal(t, "express", deps[0].Name)
+	assert.Equal(t, true, deps[0].Metadata["optional"])
+	assert.Equal(t, "package-lock.json", deps[0].Metadata["source"])
+	assert.Equal(t, Stats{StoreHits: 1}, later.Stats())
+}
+
+func TestDependenciesEvictsOldestEntries(t *testing.T) {
+	c := New(nil)
+	for i := range maxEntries + 1 {
+		c.Dependencies(Key("p", []byte{byte(i), byte(i >> 8)}), sampleDeps)
+	}
+	assert.Len(t, c.entries, maxEntries)
+	_, ok := c.entries[Key("p", []byte{0, 0})]
+	assert.False(t, ok)
+}
+
+func TestNilCacheParses(t *testing.T) {
+	var c *Cache
+	assert.Equal(t, sampleDeps(), c.Dependencies("k", sampleDeps))
+}
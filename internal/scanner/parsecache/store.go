@@ -0,0 +1,71 @@
+package parsecache
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/store"
+)
+
+const createTable = `CREATE TABLE IF NOT EXISTS parse_cache (
+	key        TEXT PRIMARY KEY,
+	build      TEXT NOT NULL,
+	deps       BLOB NOT NULL,
+	stored_at  INTEGER NOT NULL
+) WITHOUT ROWID;`
+
+// StoreBacking is a Backing in the `parse_cache` table of the shared store.
+// Entries are content-addressed and never expire; ClearStale removes those
+// written by other analyzer builds.
+type StoreBacking struct {
+	db *sql.DB
+}
+
+// NewStoreBacking creates the parse_cache table in s if needed.
+func NewStoreBacking(s *store.Store) (*StoreBacking, error) {
+	if _, err := s.DB().Exec(createTable); err != nil {
+		return nil, fmt.Errorf("parse cache: create table: %w", err)
+	}
+	return &StoreBacking{db: s.DB()}, nil
+}
+
+// Get implements Backing.
+func (b *StoreBacking) Get(key string) ([]byte, bool) {
+	var data []byte
+	if err := b.db.QueryRow(`SELECT deps FROM parse_cache WHERE key=?`, key).Scan(&data); err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put implements Backing. A failed write only costs a later re-parse.
+func (b *StoreBacking) Put(key string, data []byte) {
+	_, _ = b.db.Exec(
+		`INSERT INTO parse_cache(key, build, deps, stored_at) VALUES(?,?,?,?)
+		 ON CONFLICT(key) DO UPDATE SET deps=excluded.deps, stored_at=excluded.stored_at`,
+		key, buildID, data, time.Now().Unix())
+}
+
+// ClearAll removes every parse cache entry and returns how many there were.
+func ClearAll(s *store.Store) (int64, error) {
+	return deleteEntries(s, `DELETE FROM parse_cache`)
+}
+
+// ClearStale removes the entries written by other analyzer builds, which
+// this build can never read.
+func ClearStale(s *store.Store) (int64, error) {
+	return deleteEntries(s, `DELETE FROM parse_cache WHERE build <> ?`, buildID)
+}
+
+func deleteEntries(s *store.Store, query string, args ...any) (int64, error) {
+	if _, err := s.DB().Exec(createTable); err != nil {
+		return 0, err
+	}
+	res, err := s.DB().Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("parse cache: clear: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,119 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// AntProject is the <project> element of an Ant build.xml.
+type AntProject struct {
+	XMLName xml.Name    `xml:"project"`
+	Name    string      `xml:"name,attr"`
+	Default string      `xml:"default,attr"`
+	Targets []AntTarget `xml:"target"`
+}
+
+// AntTarget is a <target> of an Ant build.
+type AntTarget struct {
+	Name string `xml:"name,attr"`
+}
+
+// phingTaskRegex matches tasks only Phing, the PHP port of Ant, defines.
+// Phing build files are build.xml too.
+var phingTaskRegex = regexp.MustCompile(`<(?:phingcall|phpunit|phplint|phpcodesniffer|phpmd|composer)\b`)
+
+// ParseAntBuild parses an Ant build.xml. It reports false for XML that is not
+// an Ant project: another root element, no targets, or a Phing build. The
+// XML is read leniently, as builds often include files through entities
+// declared in their DOCTYPE.
+func ParseAntBuild(content []byte) (AntProject, bool) {
+	var project AntProject
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	if err := decoder.Decode(&project); err != nil || len(project.Targets) == 0 {
+		return AntProject{}, false
+	}
+	if phingTaskRegex.Match(content) {
+		return AntProject{}, false
+	}
+	return project, true
+}
+
+// IvyModule is the <ivy-module> element of an Apache Ivy ivy.xml.
+type IvyModule struct {
+	XMLName      xml.Name        `xml:"ivy-module"`
+	Info         IvyInfo         `xml:"info"`
+	Dependencies IvyDependencies `xml:"dependencies"`
+}
+
+// IvyInfo identifies the module an ivy.xml describes.
+type IvyInfo struct {
+	Organisation string `xml:"organisation,attr"`
+	Module       string `xml:"module,attr"`
+	Revision     string `xml:"revision,attr"`
+}
+
+// IvyDependencies is the <dependencies> section of an ivy.xml.
+type IvyDependencies struct {
+	DefaultConf  string          `xml:"defaultconf,attr"`
+	Dependencies []IvyDependency `xml:"dependency"`
+}
+
+// IvyDependency is a <dependency> of an ivy.xml.
+type IvyDependency struct {
+	Org  string `xml:"org,attr"`
+	Name string `xml:"name,attr"`
+	Rev  string `xml:"rev,attr"`
+	Conf string `xml:"conf,attr"`
+}
+
+// ParseIvy parses an ivy.xml into its module info and its dependencies.
+// Ivy resolves Maven artifacts by organisation and module, so dependencies
+// are reported as maven dependencies named "org:name" and match the maven
+// rules. The scope is that of the first configuration a dependency is
+// declared in, read as a Maven scope: compile, runtime, provided, test and
+// system keep their meaning, other configurations such as "default" are
+// prod. The configuration is kept as the native scope.
+func ParseIvy(content []byte) (IvyInfo, []types.Dependency, error) {
+	var module IvyModule
+	if err := xml.Unmarshal(content, &module); err != nil {
+		return IvyInfo{}, nil, types.NewParseError("ivy.xml", err)
+	}
+	var deps []types.Dependency
+	for _, d := range module.Dependencies.Dependencies {
+		if d.Org == "" || d.Name == "" {
+			continue
+		}
+		conf := d.Conf
+		if conf == "" {
+			conf = module.Dependencies.DefaultConf
+		}
+		conf = ivyMasterConf(conf)
+		metadata := map[string]interface{}{"source": "ivy.xml"}
+		if conf != "" {
+			metadata[MetadataNativeScope] = conf
+		}
+		deps = append(deps, types.Dependency{
+			Type:     DependencyTypeMaven,
+			Name:     d.Org + ":" + d.Name,
+			Version:  d.Rev,
+			Scope:    NormalizeScope(DependencyTypeMaven, conf),
+			Direct:   true,
+			Metadata: metadata,
+		})
+	}
+	return module.Info, deps, nil
+}
+
+// ivyMasterConf returns the first configuration of the module a conf mapping
+// such as "compile,runtime->default;test->*" puts a dependency in.
+func ivyMasterConf(mapping string) string {
+	conf, _, _ := strings.Cut(mapping, ";")
+	conf, _, _ = strings.Cut(conf, "->")
+	conf, _, _ = strings.Cut(conf, ",")
+	return strings.TrimSpace(conf)
+}
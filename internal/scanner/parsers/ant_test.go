@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAntBuild(t *testing.T) {
+	project, ok := ParseAntBuild([]byte(`<project name="myapp" default="jar" basedir=".">
+  <property file="build.properties"/>
+  <target name="compile"><javac srcdir="src" destdir="classes"/></target>
+  <target name="jar" depends="compile"/>
+</project>`))
+	require.True(t, ok)
+	assert.Equal(t, "myapp", project.Name)
+	assert.Equal(t, "jar", project.Default)
+	assert.Len(t, project.Targets, 2)
+
+	_, ok = ParseAntBuild([]byte(`<project name="myapp"/>`))
+	assert.False(t, ok, "a project without targets is not an Ant build")
+	_, ok = ParseAntBuild([]byte(`<Project Sdk="Microsoft.NET.Sdk"><Target Name="Build"/></Project>`))
+	assert.False(t, ok)
+}
+
+func TestParseIvy(t *testing.T) {
+	info, deps, err := ParseIvy([]byte(`<ivy-module version="2.0">
+  <info organisation="com.example" module="myapp" revision="2.1"/>
+  <dependencies defaultconf="default">
+    <dependency org="commons-lang" name="commons-lang" rev="2.6"/>
+    <dependency org="javax.servlet" name="servlet-api" rev="2.5" conf="provided->default"/>
+    <dependency org="junit" name="junit" rev="4.+" conf="test,it->*"/>
+    <dependency name="no-org" rev="1.0"/>
+  </dependencies>
+</ivy-module>`))
+	require.NoError(t, err)
+	assert.Equal(t, IvyInfo{Organisation: "com.example", Module: "myapp", Revision: "2.1"}, info)
+	require.Len(t, deps, 3)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeMaven, Name: "commons-lang:commons-lang", Version: "2.6", Scope: types.ScopeProd, Direct: true,
+		Metadata: map[string]interface{}{"source": "ivy.xml", MetadataNativeScope: "default"},
+	}, deps[0])
+	assert.Equal(t, types.ScopeProd, deps[1].Scope)
+	assert.Equal(t, "provided", NativeScope(deps[1]))
+	assert.Equal(t, types.ScopeDev, deps[2].Scope)
+	assert.Equal(t, "4.+", deps[2].Version)
+
+	_, _, err = ParseIvy([]byte(`<ivy-module`))
+	assert.Error(t, err)
+}
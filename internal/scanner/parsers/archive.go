@@ -0,0 +1,443 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Limits applied while reading archive entries. Metadata files are small;
+// anything larger is not what we are looking for. Nested jars (WEB-INF/lib,
+// BOOT-INF/lib, ear lib/) are opened one level deep and only up to a size cap
+// so a vendored fat archive cannot blow up memory.
+const (
+	maxArchiveMetadataEntry = 1 << 20  // 1 MiB
+	maxNestedArchiveEntry   = 32 << 20 // 32 MiB
+)
+
+var (
+	// META-INF/maven/<groupId>/<artifactId>/pom.properties
+	pomPropertiesRegex = regexp.MustCompile(`(?:^|/)META-INF/maven/[^/]+/[^/]+/pom\.properties$`)
+	// <name>-<version>.dist-info/METADATA at the archive root
+	wheelMetadataRegex = regexp.MustCompile(`^[^/]+\.dist-info/METADATA$`)
+	// Nested library jars inside war/ear/Spring Boot archives
+	nestedJarRegex = regexp.MustCompile(`^(?:WEB-INF/lib|BOOT-INF/lib|lib)/[^/]+\.jar$`)
+	// Requirement name at the start of a Requires-Dist value
+	requiresDistNameRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)`)
+)
+
+// ArchiveInfo describes the package metadata embedded in a prebuilt archive
+// (jar/war/ear, Python wheel, NuGet package) vendored into the tree.
+type ArchiveInfo struct {
+	File      string            `json:"file"`
+	Format    string            `json:"format"`    // jar, war, ear, wheel, nupkg
+	Ecosystem string            `json:"ecosystem"` // maven, pypi, nuget
+	Name      string            `json:"name,omitempty"`
+	Version   string            `json:"version,omitempty"`
+	License   string            `json:"license,omitempty"`
+	Requires  []string          `json:"requires,omitempty"`
+	Packages  []ArchivePackage  `json:"packages,omitempty"`
+	Manifest  map[string]string `json:"manifest,omitempty"`
+}
+
+// ArchivePackage is a single package identified inside an archive. Shaded and
+// fat jars embed several; wheels and nupkgs describe exactly one.
+type ArchivePackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	License string `json:"license,omitempty"`
+	Nested  string `json:"nested,omitempty"` // nested archive entry the package was found in
+}
+
+// ArchiveParser extracts embedded package metadata from zip-based archives.
+type ArchiveParser struct{}
+
+// NewArchiveParser creates a new archive parser.
+func NewArchiveParser() *ArchiveParser {
+	return &ArchiveParser{}
+}
+
+// ArchiveFormat returns the archive format for a file name, or "" when the
+// file is not an inspectable archive.
+func ArchiveFormat(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jar":
+		return "jar"
+	case ".war":
+		return "war"
+	case ".ear":
+		return "ear"
+	case ".whl":
+		return "wheel"
+	case ".nupkg":
+		return "nupkg"
+	}
+	return ""
+}
+
+// ParseArchive opens the archive content and extracts its embedded package
+// metadata. Returns an error when the content is not a readable zip and nil
+// when the archive carries no package metadata.
+func (p *ArchiveParser) ParseArchive(name string, content []byte) (*ArchiveInfo, error) {
+	format := ArchiveFormat(name)
+	if format == "" {
+		return nil, fmt.Errorf("unsupported archive type: %s", name)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, &types.ParseError{File: name, Format: "zip", Err: err}
+	}
+
+	info := &ArchiveInfo{Format: format}
+	switch format {
+	case "wheel":
+		info.Ecosystem = DependencyTypePython
+		p.parseWheel(zr, info)
+	case "nupkg":
+		info.Ecosystem = DependencyTypeNuget
+		p.parseNupkg(zr, info)
+	default:
+		info.Ecosystem = DependencyTypeMaven
+		p.parseJava(zr, info, true)
+		p.applyJarIdentity(info, path.Base(name))
+	}
+
+	if len(info.Packages) == 0 {
+		return nil, nil
+	}
+	return info, nil
+}
+
+// parseJava reads the JAR manifest and every embedded pom.properties. When
+// descend is set, nested library jars are inspected one level deep.
+func (p *ArchiveParser) parseJava(zr *zip.Reader, info *ArchiveInfo, descend bool) {
+	var own []ArchivePackage
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "META-INF/MANIFEST.MF":
+			if content, ok := readZipEntry(f, maxArchiveMetadataEntry); ok {
+				info.Manifest = parseJarManifest(content)
+			}
+		case pomPropertiesRegex.MatchString(f.Name):
+			if pkg, ok := parsePomPropertiesEntry(f); ok {
+				own = append(own, pkg)
+			}
+		case descend && nestedJarRegex.MatchString(f.Name):
+			info.Packages = append(info.Packages, p.nestedJarPackages(f)...)
+		}
+	}
+
+	sortArchivePackages(own)
+	info.Packages = append(own, info.Packages...)
+}
+
+// applyJarIdentity sets the archive's own name/version/license. Shaded jars
+// carry several top-level pom.properties; the one whose artifactId matches the
+// file name is the archive itself. Jars not built by Maven fall back to the
+// manifest so they are still reported as a package.
+func (p *ArchiveParser) applyJarIdentity(info *ArchiveInfo, fileName string) {
+	info.License = info.Manifest["Bundle-License"]
+	if idx := primaryJarPackage(info.Packages, fileName); idx >= 0 {
+		primary := info.Packages[idx]
+		copy(info.Packages[1:idx+1], info.Packages[:idx])
+		primary.License = info.License
+		info.Packages[0] = primary
+		info.Name, info.Version = primary.Name, primary.Version
+		return
+	}
+
+	name := firstNonEmpty(info.Manifest["Bundle-SymbolicName"], info.Manifest["Implementation-Title"], info.Manifest["Automatic-Module-Name"])
+	// Bundle-SymbolicName may carry directives ("org.example.lib;singleton:=true")
+	name = strings.TrimSpace(strings.SplitN(name, ";", 2)[0])
+	if name == "" {
+		return
+	}
+	info.Name = name
+	info.Version = firstNonEmpty(info.Manifest["Bundle-Version"], info.Manifest["Implementation-Version"])
+	info.Packages = append([]ArchivePackage{{Name: info.Name, Version: info.Version, License: info.License}}, info.Packages...)
+}
+
+// primaryJarPackage returns the index of the top-level (non-nested) package
+// describing the jar itself, or -1 when there is none.
+func primaryJarPackage(pkgs []ArchivePackage, fileName string) int {
+	first := -1
+	for i, pkg := range pkgs {
+		if pkg.Nested != "" {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		artifact := pkg.Name[strings.LastIndex(pkg.Name, ":")+1:]
+		if strings.HasPrefix(fileName, artifact+"-") || strings.TrimSuffix(fileName, path.Ext(fileName)) == artifact {
+			return i
+		}
+	}
+	return first
+}
+
+// nestedJarPackages opens a library jar stored inside a war/ear/fat jar and
+// returns the packages it describes, tagged with the nested entry name.
+func (p *ArchiveParser) nestedJarPackages(f *zip.File) []ArchivePackage {
+	content, ok := readZipEntry(f, maxNestedArchiveEntry)
+	if !ok {
+		return nil
+	}
+	zr, err := zip.NewReader(bytes.NewReader([]byte(content)), int64(len(content)))
+	if err != nil {
+		return nil
+	}
+	nested := &ArchiveInfo{}
+	p.parseJava(zr, nested, false)
+	p.applyJarIdentity(nested, path.Base(f.Name))
+	for i := range nested.Packages {
+		nested.Packages[i].Nested = f.Name
+	}
+	return nested.Packages
+}
+
+// parsePomPropertiesEntry reads a pom.properties entry into a Maven package
+// (groupId:artifactId).
+func parsePomPropertiesEntry(f *zip.File) (ArchivePackage, bool) {
+	content, ok := readZipEntry(f, maxArchiveMetadataEntry)
+	if !ok {
+		return ArchivePackage{}, false
+	}
+	props := parseProperties(content)
+	group, artifact := props["groupId"], props["artifactId"]
+	if group == "" || artifact == "" {
+		return ArchivePackage{}, false
+	}
+	return ArchivePackage{Name: group + ":" + artifact, Version: props["version"]}, true
+}
+
+// parseWheel reads <name>-<version>.dist-info/METADATA from a wheel.
+func (p *ArchiveParser) parseWheel(zr *zip.Reader, info *ArchiveInfo) {
+	for _, f := range zr.File {
+		if !wheelMetadataRegex.MatchString(f.Name) {
+			continue
+		}
+		content, ok := readZipEntry(f, maxArchiveMetadataEntry)
+		if !ok {
+			return
+		}
+		meta := parseCoreMetadata(content)
+		info.Name = meta.name
+		info.Version = meta.version
+		info.License = meta.license
+		info.Requires = meta.requires
+		if info.Name != "" {
+			info.Packages = []ArchivePackage{{Name: info.Name, Version: info.Version, License: info.License}}
+		}
+		return
+	}
+}
+
+// coreMetadata holds the fields of a Python core metadata (METADATA) file we use.
+type coreMetadata struct {
+	name, version, license, licenseText string
+	requires                            []string
+}
+
+// parseCoreMetadata parses the RFC 822-style header block of a wheel METADATA
+// file. Only unconditional Requires-Dist entries (no environment marker or
+// extra) are kept, since those are what the wheel always pulls in.
+func parseCoreMetadata(content string) coreMetadata {
+	var meta coreMetadata
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break // end of headers; the body is the long description
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && !isINIContinuation(line) {
+			meta.set(key, strings.TrimSpace(value))
+		}
+	}
+	// The free-text License field sometimes holds the whole license body; only
+	// trust a short single-line value.
+	if meta.license == "" && len(meta.licenseText) <= 64 {
+		meta.license = meta.licenseText
+	}
+	return meta
+}
+
+// set applies a single METADATA header.
+func (m *coreMetadata) set(key, value string) {
+	switch key {
+	case "Name":
+		m.name = value
+	case "Version":
+		m.version = value
+	case "License-Expression":
+		m.license = value
+	case "License":
+		m.licenseText = value
+	case "Requires-Dist":
+		if req := requiresDistName(value); req != "" {
+			m.requires = append(m.requires, req)
+		}
+	}
+}
+
+// requiresDistName returns the distribution name of an unconditional
+// Requires-Dist value, or "" when it is gated by an environment marker.
+func requiresDistName(value string) string {
+	if strings.Contains(value, ";") {
+		return ""
+	}
+	m := requiresDistNameRegex.FindStringSubmatch(value)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// nuspecPackage models the fields of a .nuspec manifest we extract.
+type nuspecPackage struct {
+	Metadata struct {
+		ID      string `xml:"id"`
+		Version string `xml:"version"`
+		License struct {
+			Type string `xml:"type,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"license"`
+		Dependencies struct {
+			Direct []nuspecDependency `xml:"dependency"`
+			Groups []struct {
+				Dependencies []nuspecDependency `xml:"dependency"`
+			} `xml:"group"`
+		} `xml:"dependencies"`
+	} `xml:"metadata"`
+}
+
+type nuspecDependency struct {
+	ID string `xml:"id,attr"`
+}
+
+// parseNupkg reads the root-level .nuspec manifest from a NuGet package.
+func (p *ArchiveParser) parseNupkg(zr *zip.Reader, info *ArchiveInfo) {
+	for _, f := range zr.File {
+		if strings.Contains(f.Name, "/") || !strings.HasSuffix(strings.ToLower(f.Name), ".nuspec") {
+			continue
+		}
+		content, ok := readZipEntry(f, maxArchiveMetadataEntry)
+		if !ok {
+			return
+		}
+		var spec nuspecPackage
+		if err := xml.Unmarshal([]byte(content), &spec); err != nil || spec.Metadata.ID == "" {
+			return
+		}
+		info.Name = spec.Metadata.ID
+		info.Version = spec.Metadata.Version
+		if spec.Metadata.License.Type == "expression" {
+			info.License = strings.TrimSpace(spec.Metadata.License.Text)
+		}
+		info.Requires = nuspecRequires(spec)
+		info.Packages = []ArchivePackage{{Name: info.Name, Version: info.Version, License: info.License}}
+		return
+	}
+}
+
+// nuspecRequires collects the distinct dependency ids across all target
+// framework groups.
+func nuspecRequires(spec nuspecPackage) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(deps []nuspecDependency) {
+		for _, d := range deps {
+			if d.ID != "" && !seen[d.ID] {
+				seen[d.ID] = true
+				ids = append(ids, d.ID)
+			}
+		}
+	}
+	add(spec.Metadata.Dependencies.Direct)
+	for _, g := range spec.Metadata.Dependencies.Groups {
+		add(g.Dependencies)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// parseJarManifest parses META-INF/MANIFEST.MF main attributes, joining
+// continuation lines (which begin with a single space).
+func parseJarManifest(content string) map[string]string {
+	attrs := make(map[string]string)
+	var lastKey string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if line == "" {
+			break // main section ends at the first blank line
+		}
+		if strings.HasPrefix(line, " ") && lastKey != "" {
+			attrs[lastKey] += line[1:]
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lastKey = strings.TrimSpace(key)
+		attrs[lastKey] = strings.TrimSpace(value)
+	}
+	return attrs
+}
+
+// parseProperties parses a Java .properties file (key=value, # comments).
+func parseProperties(content string) map[string]string {
+	props := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return props
+}
+
+// readZipEntry reads a zip entry when its uncompressed size is within limit.
+func readZipEntry(f *zip.File, limit int64) (string, bool) {
+	if f.UncompressedSize64 > uint64(limit) {
+		return "", false
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return "", false
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func sortArchivePackages(pkgs []ArchivePackage) {
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return pkgs[i].Name < pkgs[j].Name
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildZip creates an in-memory zip archive from name -> content entries.
+func buildZip(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestArchiveFormat(t *testing.T) {
+	tests := map[string]string{
+		"lib/mylib-1.0.jar":                 "jar",
+		"deploy/myapp.WAR":                  "war",
+		"myapp.ear":                         "ear",
+		"wheels/mylib-1.0-py3-none-any.whl": "wheel",
+		"packages/MyOrg.Shared.1.2.0.nupkg": "nupkg",
+		"src/Main.java":                     "",
+		"dist/mylib-1.0.tar.gz":             "",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, ArchiveFormat(name), name)
+	}
+}
+
+func TestParseArchive_JarWithPomProperties(t *testing.T) {
+	content := buildZip(t, map[string]string{
+		"META-INF/MANIFEST.MF":                            "Manifest-Version: 1.0\r\nBundle-License: Apache-2.0\r\nImplementation-Title: my\r\n lib\r\n",
+		"META-INF/maven/com.example/mylib/pom.properties": "#Generated by Maven\ngroupId=com.example\nartifactId=mylib\nversion=1.4.0\n",
+		// shaded dependency
+		"META-INF/maven/com.example/aaa-shaded/pom.properties": "groupId=com.example\nartifactId=aaa-shaded\nversion=0.9\n",
+		"com/example/Lib.class":                                "\xca\xfe\xba\xbe",
+	})
+
+	info, err := NewArchiveParser().ParseArchive("vendor/mylib-1.4.0.jar", content)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+
+	assert.Equal(t, "jar", info.Format)
+	assert.Equal(t, DependencyTypeMaven, info.Ecosystem)
+	assert.Equal(t, "com.example:mylib", info.Name, "primary package matches the file name, not sort order")
+	assert.Equal(t, "1.4.0", info.Version)
+	assert.Equal(t, "Apache-2.0", info.License)
+	assert.Equal(t, "mylib", info.Manifest["Implementation-Title"], "continuation lines are joined")
+	require.Len(t, info.Packages, 2)
+	assert.Equal(t, "com.example:mylib", info.Packages[0].Name)
+	assert.Equal(t, "Apache-2.0", info.Packages[0].License)
+	assert.Equal(t, "com.example:aaa-shaded", info.Packages[1].Name)
+}
+
+func TestParseArchive_JarManifestFallback(t *testing.T) {
+	content := buildZip(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nBundle-SymbolicName: org.example.tools;singleton:=true\nBundle-Version: 3.2.1\n",
+	})
+
+	info, err := NewArchiveParser().ParseArchive("tools.jar", content)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "org.example.tools", info.Name)
+	assert.Equal(t, "3.2.1", info.Version)
+	require.Len(t, info.Packages, 1)
+}
+
+func TestParseArchive_WarNestedJars(t *testing.T) {
+	nested := buildZip(t, map[string]string{
+		"META-INF/maven/com.example/util/pom.properties": "groupId=com.example\nartifactId=util\nversion=2.0.0\n",
+	})
+	content := buildZip(t, map[string]string{
+		"META-INF/maven/com.example/myapp/pom.properties": "groupId=com.example\nartifactId=myapp\nversion=1.0.0\n",
+		"WEB-INF/lib/util-2.0.0.jar":                      string(nested),
+		"WEB-INF/classes/app.properties":                  "x=y\n",
+	})
+
+	info, err := NewArchiveParser().ParseArchive("myapp.war", content)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "war", info.Format)
+	assert.Equal(t, "com.example:myapp", info.Name)
+	require.Len(t, info.Packages, 2)
+	assert.Equal(t, ArchivePackage{Name: "com.example:util", Version: "2.0.0", Nested: "WEB-INF/lib/util-2.0.0.jar"}, info.Packages[1])
+}
+
+func TestParseArchive_Wheel(t *testing.T) {
+	metadata := "Metadata-Version: 2.1\nName: mylib\nVersion: 0.3.0\nLicense: MIT\n" +
+		"Requires-Dist: requests (>=2.0)\nRequires-Dist: pytest ; extra == 'test'\nRequires-Dist: urllib3>=2\n\nLong description\nName: ignored\n"
+	content := buildZip(t, map[string]string{
+		"mylib/__init__.py":              "",
+		"mylib-0.3.0.dist-info/METADATA": metadata,
+	})
+
+	info, err := NewArchiveParser().ParseArchive("mylib-0.3.0-py3-none-any.whl", content)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, DependencyTypePython, info.Ecosystem)
+	assert.Equal(t, "mylib", info.Name)
+	assert.Equal(t, "0.3.0", info.Version)
+	assert.Equal(t, "MIT", info.License)
+	assert.Equal(t, []string{"requests", "urllib3"}, info.Requires)
+}
+
+func TestParseArchive_Nupkg(t *testing.T) {
+	nuspec := `<?xml version="1.0"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>MyOrg.Shared</id>
+    <version>1.2.0</version>
+    <license type="expression">MIT</license>
+    <dependencies>
+      <group targetFramework="net8.0"><dependency id="Newtonsoft.Json" version="13.0.3" /></group>
+      <group targetFramework="netstandard2.0"><dependency id="Newtonsoft.Json" version="13.0.3" /><dependency id="Polly" version="8.0.0" /></group>
+    </dependencies>
+  </metadata>
+</package>`
+	content := buildZip(t, map[string]string{
+		"MyOrg.Shared.nuspec":         nuspec,
+		"lib/net8.0/MyOrg.Shared.dll": "MZ",
+	})
+
+	info, err := NewArchiveParser().ParseArchive("MyOrg.Shared.1.2.0.nupkg", content)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, DependencyTypeNuget, info.Ecosystem)
+	assert.Equal(t, "MyOrg.Shared", info.Name)
+	assert.Equal(t, "1.2.0", info.Version)
+	assert.Equal(t, "MIT", info.License)
+	assert.Equal(t, []string{"Newtonsoft.Json", "Polly"}, info.Requires)
+}
+
+func TestParseArchive_NoMetadataOrInvalid(t *testing.T) {
+	parser := NewArchiveParser()
+
+	info, err := parser.ParseArchive("plain.jar", buildZip(t, map[string]string{"a/B.class": "x"}))
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	_, err = parser.ParseArchive("broken.whl", []byte("not a zip"))
+	assert.Error(t, err)
+}
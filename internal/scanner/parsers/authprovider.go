@@ -0,0 +1,106 @@
+package parsers
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Identity provider tech keys reported in AuthEvidence.Provider.
+const (
+	AuthProviderKeycloak     = "keycloak"
+	AuthProviderAuth0        = "auth0"
+	AuthProviderOkta         = "okta"
+	AuthProviderCognito      = "aws.cognito"
+	AuthProviderFirebaseAuth = "firebase.auth"
+)
+
+// AuthEvidence records an identity provider referenced by a configuration
+// file: the provider host (tenant domain or issuer host) and, for Keycloak,
+// the realm.
+type AuthEvidence struct {
+	Provider string `json:"provider"`
+	Host     string `json:"host,omitempty"`
+	Realm    string `json:"realm,omitempty"`
+	File     string `json:"file"`
+}
+
+// authHostPatterns match provider hosts in configuration values. The first
+// submatch is the host. URLs are matched from the host on, so userinfo
+// ("user:pass@") is never captured.
+var authHostPatterns = []struct {
+	provider string
+	regex    *regexp.Regexp
+}{
+	{AuthProviderAuth0, regexp.MustCompile(`(?i)\b([a-z0-9][a-z0-9-]*(?:\.[a-z]{2})?\.auth0\.com)\b`)},
+	{AuthProviderOkta, regexp.MustCompile(`(?i)\b([a-z0-9][a-z0-9-]*\.(?:okta|oktapreview|okta-emea)\.com)\b`)},
+	{AuthProviderCognito, regexp.MustCompile(`(?i)\b(cognito-idp\.[a-z0-9-]+\.amazonaws\.com)\b`)},
+	{AuthProviderCognito, regexp.MustCompile(`(?i)\b([a-z0-9][a-z0-9-]*\.auth\.[a-z0-9-]+\.amazoncognito\.com)\b`)},
+	{AuthProviderFirebaseAuth, regexp.MustCompile(`(?i)\b(securetoken\.google\.com)/`)},
+	{AuthProviderFirebaseAuth, regexp.MustCompile(`(?i)auth_?domain["']?\s*[:=]\s*["']?([a-z0-9-]+\.firebaseapp\.com)\b`)},
+}
+
+// keycloakIssuerRegex matches Keycloak realm URLs (https://sso.example.com/realms/myrealm,
+// with or without the legacy /auth prefix) and captures host and realm.
+var keycloakIssuerRegex = regexp.MustCompile(`https?://(?:[^/\s"'@]*@)?([A-Za-z0-9.-]+(?::\d+)?)(?:/auth)?/realms/([A-Za-z0-9_.-]+)`)
+
+// FindAuthProviders returns the identity providers referenced in a
+// configuration file (dotenv, application.yml, appsettings.json, ...), one
+// entry per distinct provider and host. File is left for the caller to set.
+func FindAuthProviders(content string) []AuthEvidence {
+	var found []AuthEvidence
+	seen := make(map[string]bool)
+	add := func(ev AuthEvidence) {
+		key := ev.Provider + "|" + ev.Host + "|" + ev.Realm
+		if !seen[key] {
+			seen[key] = true
+			found = append(found, ev)
+		}
+	}
+
+	for _, m := range keycloakIssuerRegex.FindAllStringSubmatch(content, -1) {
+		add(AuthEvidence{Provider: AuthProviderKeycloak, Host: strings.ToLower(m[1]), Realm: m[2]})
+	}
+	for _, p := range authHostPatterns {
+		for _, m := range p.regex.FindAllStringSubmatch(content, -1) {
+			add(AuthEvidence{Provider: p.provider, Host: strings.ToLower(m[1])})
+		}
+	}
+	return found
+}
+
+// ParseKeycloakRealm returns the realm name of a Keycloak realm export
+// (realm.json, myrealm-realm.json), or false when the document is not one.
+func ParseKeycloakRealm(content []byte) (string, bool) {
+	var export struct {
+		Realm   string        `json:"realm"`
+		Clients []interface{} `json:"clients"`
+		Roles   interface{}   `json:"roles"`
+		Users   []interface{} `json:"users"`
+	}
+	if err := json.Unmarshal(content, &export); err != nil || export.Realm == "" {
+		return "", false
+	}
+	if export.Clients == nil && export.Roles == nil && export.Users == nil {
+		return "", false
+	}
+	return export.Realm, true
+}
+
+// ParseKeycloakAdapterConfig parses a Keycloak adapter keycloak.json
+// ("auth-server-url" and "realm"). Only the host of the server URL is kept.
+func ParseKeycloakAdapterConfig(content []byte) (AuthEvidence, bool) {
+	var adapter struct {
+		Realm     string `json:"realm"`
+		ServerURL string `json:"auth-server-url"`
+	}
+	if err := json.Unmarshal(content, &adapter); err != nil || adapter.Realm == "" {
+		return AuthEvidence{}, false
+	}
+	ev := AuthEvidence{Provider: AuthProviderKeycloak, Realm: adapter.Realm}
+	if u, err := url.Parse(adapter.ServerURL); err == nil {
+		ev.Host = strings.ToLower(u.Host)
+	}
+	return ev, true
+}
@@ -0,0 +1,190 @@
+package parsers
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// CargoManifest is the part of a Cargo.toml describing what a crate ships:
+// its features, its library and binary targets and, in a workspace root,
+// the workspace members.
+type CargoManifest struct {
+	Package struct {
+		Name     string `toml:"name"`
+		Autobins *bool  `toml:"autobins"`
+	} `toml:"package"`
+	Features  map[string][]string `toml:"features"`
+	Lib       *CargoTarget        `toml:"lib"`
+	Bins      []CargoTarget       `toml:"bin"`
+	Workspace *CargoWorkspace     `toml:"workspace"`
+}
+
+// CargoTarget is the [lib] or a [[bin]] section of a Cargo.toml.
+type CargoTarget struct {
+	Name             string   `toml:"name"`
+	Path             string   `toml:"path"`
+	CrateType        []string `toml:"crate-type"`
+	ProcMacro        bool     `toml:"proc-macro"`
+	RequiredFeatures []string `toml:"required-features"`
+}
+
+// CargoWorkspace is the [workspace] section of a Cargo.toml.
+type CargoWorkspace struct {
+	Members        []string `toml:"members" json:"members,omitempty"`
+	DefaultMembers []string `toml:"default-members" json:"default_members,omitempty"`
+	Exclude        []string `toml:"exclude" json:"exclude,omitempty"`
+	Resolver       string   `toml:"resolver" json:"resolver,omitempty"`
+}
+
+// CargoArtifact is a library or binary a crate builds.
+type CargoArtifact struct {
+	Kind             string   `json:"kind"` // lib or bin
+	Name             string   `json:"name"`
+	Path             string   `json:"path"`
+	CrateTypes       []string `json:"crate_types,omitempty"`
+	RequiredFeatures []string `json:"required_features,omitempty"`
+}
+
+// ParseCargoManifest decodes the features, targets and workspace of a
+// Cargo.toml.
+func ParseCargoManifest(content string) (CargoManifest, error) {
+	var manifest CargoManifest
+	_, err := toml.Decode(content, &manifest)
+	return manifest, types.NewParseError("Cargo.toml", err)
+}
+
+// FeatureNames returns the features the crate declares, without "default".
+func (m CargoManifest) FeatureNames() []string {
+	names := make([]string, 0, len(m.Features))
+	for name := range m.Features {
+		if name != "default" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultFeatures returns the features enabled when the crate is built
+// without feature flags: those of the "default" feature and the features
+// they enable in turn. Optional dependencies ("dep:name") and features of
+// dependencies ("name/feature") are not features of the crate itself.
+func (m CargoManifest) DefaultFeatures() []string {
+	enabled := make(map[string]bool)
+	pending := append([]string(nil), m.Features["default"]...)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, declared := m.Features[name]; !declared || enabled[name] || name == "default" {
+			continue
+		}
+		enabled[name] = true
+		pending = append(pending, m.Features[name]...)
+	}
+	names := make([]string, 0, len(enabled))
+	for name := range enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Artifacts returns the library and binaries the crate builds, from its
+// [lib] and [[bin]] sections and the target sources Cargo discovers:
+// src/lib.rs, src/main.rs, src/bin/<name>.rs and src/bin/<name>/main.rs.
+// sources lists the .rs files present under src/, relative to the crate.
+func (m CargoManifest) Artifacts(sources []string) []CargoArtifact {
+	present := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		present[source] = true
+	}
+	var artifacts []CargoArtifact
+	if lib := m.libArtifact(present); lib != nil {
+		artifacts = append(artifacts, *lib)
+	}
+	return append(artifacts, m.binArtifacts(sources, present)...)
+}
+
+func (m CargoManifest) libArtifact(present map[string]bool) *CargoArtifact {
+	if m.Lib == nil && !present["src/lib.rs"] {
+		return nil
+	}
+	lib := CargoArtifact{Kind: "lib", Name: strings.ReplaceAll(m.Package.Name, "-", "_"), Path: "src/lib.rs", CrateTypes: []string{"lib"}}
+	if m.Lib == nil {
+		return &lib
+	}
+	if m.Lib.Name != "" {
+		lib.Name = m.Lib.Name
+	}
+	if m.Lib.Path != "" {
+		lib.Path = m.Lib.Path
+	}
+	if m.Lib.ProcMacro {
+		lib.CrateTypes = []string{"proc-macro"}
+	} else if len(m.Lib.CrateType) > 0 {
+		lib.CrateTypes = m.Lib.CrateType
+	}
+	return &lib
+}
+
+// binArtifacts returns the [[bin]] targets, followed by the discovered ones
+// not declared, unless autobins is off.
+func (m CargoManifest) binArtifacts(sources []string, present map[string]bool) []CargoArtifact {
+	var bins []CargoArtifact
+	declared := make(map[string]bool)
+	for _, bin := range m.Bins {
+		if bin.Name == "" {
+			continue
+		}
+		artifact := CargoArtifact{Kind: "bin", Name: bin.Name, Path: bin.Path, RequiredFeatures: bin.RequiredFeatures}
+		if artifact.Path == "" {
+			artifact.Path = m.defaultBinPath(bin.Name, present)
+		}
+		declared[artifact.Name], declared[artifact.Path] = true, true
+		bins = append(bins, artifact)
+	}
+	if m.Package.Autobins != nil && !*m.Package.Autobins {
+		return bins
+	}
+	for _, artifact := range discoveredBins(m.Package.Name, sources) {
+		if !declared[artifact.Name] && !declared[artifact.Path] {
+			bins = append(bins, artifact)
+		}
+	}
+	return bins
+}
+
+// defaultBinPath is where Cargo looks for a [[bin]] without a path.
+func (m CargoManifest) defaultBinPath(name string, present map[string]bool) string {
+	if name == m.Package.Name && present["src/main.rs"] {
+		return "src/main.rs"
+	}
+	if present["src/bin/"+name+"/main.rs"] {
+		return "src/bin/" + name + "/main.rs"
+	}
+	return "src/bin/" + name + ".rs"
+}
+
+// discoveredBins returns the binaries Cargo infers from the target sources.
+func discoveredBins(packageName string, sources []string) []CargoArtifact {
+	var bins []CargoArtifact
+	for _, source := range sources {
+		var name string
+		switch {
+		case source == "src/main.rs":
+			name = packageName
+		case path.Dir(source) == "src/bin":
+			name = strings.TrimSuffix(path.Base(source), ".rs")
+		case path.Base(source) == "main.rs" && path.Dir(path.Dir(source)) == "src/bin":
+			name = path.Base(path.Dir(source))
+		}
+		if name != "" {
+			bins = append(bins, CargoArtifact{Kind: "bin", Name: name, Path: source})
+		}
+	}
+	return bins
+}
@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCargoManifestFeatures(t *testing.T) {
+	manifest, err := ParseCargoManifest(`[package]
+name = "myapp"
+
+[features]
+default = ["std", "serde/derive", "dep:log"]
+std = ["alloc"]
+alloc = []
+full = ["std", "async"]
+async = ["tokio?/rt"]
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alloc", "async", "full", "std"}, manifest.FeatureNames())
+	assert.Equal(t, []string{"alloc", "std"}, manifest.DefaultFeatures())
+}
+
+func TestCargoManifestArtifacts(t *testing.T) {
+	sources := []string{"src/lib.rs", "src/main.rs", "src/bin/migrate.rs", "src/bin/server/main.rs", "src/util.rs"}
+
+	manifest, err := ParseCargoManifest(`[package]
+name = "my-service"
+
+[[bin]]
+name = "server"
+required-features = ["http"]
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []CargoArtifact{
+		{Kind: "lib", Name: "my_service", Path: "src/lib.rs", CrateTypes: []string{"lib"}},
+		{Kind: "bin", Name: "server", Path: "src/bin/server/main.rs", RequiredFeatures: []string{"http"}},
+		{Kind: "bin", Name: "my-service", Path: "src/main.rs"},
+		{Kind: "bin", Name: "migrate", Path: "src/bin/migrate.rs"},
+	}, manifest.Artifacts(sources))
+
+	manifest, err = ParseCargoManifest(`[package]
+name = "derive-helpers"
+autobins = false
+
+[lib]
+proc-macro = true
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []CargoArtifact{
+		{Kind: "lib", Name: "derive_helpers", Path: "src/lib.rs", CrateTypes: []string{"proc-macro"}},
+	}, manifest.Artifacts(sources))
+}
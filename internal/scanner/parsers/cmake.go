@@ -0,0 +1,181 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// cmakeCommandRegex matches the CMake commands declaring a project or a
+	// dependency, with their arguments. Arguments holding parentheses are
+	// not supported, which these commands rarely have.
+	cmakeCommandRegex = regexp.MustCompile(`(?i)\b(project|find_package|FetchContent_Declare|CPMAddPackage)\s*\(([^()]*)\)`)
+	// cmakeArgRegex splits command arguments, keeping quoted ones whole.
+	cmakeArgRegex = regexp.MustCompile(`"[^"]*"|[^\s"]+`)
+	// cmakeVersionRegex matches a version argument of find_package.
+	cmakeVersionRegex = regexp.MustCompile(`^\d+(\.\d+)*$`)
+)
+
+// CMakeProject is what a CMakeLists.txt declares: the project() name,
+// version and languages, and the packages it finds or fetches.
+type CMakeProject struct {
+	Name         string
+	Version      string
+	Languages    []string
+	Dependencies []types.Dependency
+}
+
+// ParseCMakeLists reads the project() command and the dependencies of a
+// CMakeLists.txt: find_package(Name [version]) packages, FetchContent_Declare
+// content with its GIT_TAG as version, and CPM.cmake CPMAddPackage packages.
+// The commands are matched as written, without evaluating variables or
+// conditions; each package is reported once.
+func ParseCMakeLists(content string) CMakeProject {
+	var project CMakeProject
+	seen := make(map[string]bool)
+	for _, m := range cmakeCommandRegex.FindAllStringSubmatch(stripLineComments(content, '"'), -1) {
+		args := cmakeArgs(m[2])
+		if len(args) == 0 {
+			continue
+		}
+		if strings.EqualFold(m[1], "project") {
+			if project.Name == "" {
+				project.Name, project.Version, project.Languages = cmakeProjectInfo(args)
+			}
+			continue
+		}
+		dep := cmakeCommandDependency(strings.ToLower(m[1]), args)
+		if dep.Name == "" || strings.Contains(dep.Name, "${") || seen[dep.Name] {
+			continue
+		}
+		seen[dep.Name] = true
+		project.Dependencies = append(project.Dependencies, dep)
+	}
+	return project
+}
+
+// cmakeCommandDependency returns the dependency a find_package,
+// FetchContent_Declare or CPMAddPackage command declares.
+func cmakeCommandDependency(command string, args []string) types.Dependency {
+	switch command {
+	case "find_package":
+		dep := cmakeDependency(args[0], "")
+		if len(args) > 1 && cmakeVersionRegex.MatchString(args[1]) {
+			dep.Version = args[1]
+		}
+		return dep
+	case "fetchcontent_declare":
+		dep := cmakeDependency(args[0], cmakeKeyword(args, "GIT_TAG"))
+		dep.Metadata["fetch"] = "FetchContent"
+		setCMakeURL(dep, cmakeKeyword(args, "GIT_REPOSITORY"), cmakeKeyword(args, "URL"))
+		return dep
+	}
+	return cpmDependency(args)
+}
+
+func cmakeDependency(name, version string) types.Dependency {
+	return types.Dependency{
+		Type:     DependencyTypeCMake,
+		Name:     name,
+		Version:  version,
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: types.NewMetadata(MetadataSourceCMakeLists),
+	}
+}
+
+// cmakeProjectInfo returns the name, VERSION and LANGUAGES of project().
+// Languages may also follow the name directly: project(myapp C CXX).
+func cmakeProjectInfo(args []string) (name, version string, languages []string) {
+	name = args[0]
+	keyword := "LANGUAGES"
+	for _, arg := range args[1:] {
+		switch arg {
+		case "VERSION", "LANGUAGES", "DESCRIPTION", "HOMEPAGE_URL":
+			keyword = arg
+			continue
+		}
+		switch keyword {
+		case "VERSION":
+			version = arg
+		case "LANGUAGES":
+			languages = append(languages, arg)
+		}
+	}
+	return name, version, languages
+}
+
+// cpmDependency reads CPMAddPackage("gh:owner/repo#tag") or
+// CPMAddPackage(NAME name VERSION version GITHUB_REPOSITORY owner/repo).
+func cpmDependency(args []string) types.Dependency {
+	if len(args) == 1 {
+		source, version, _ := strings.Cut(args[0], "#")
+		if before, after, found := strings.Cut(source, "@"); found && version == "" {
+			source, version = before, after
+		}
+		dep := cmakeDependency(source[strings.LastIndex(source, "/")+1:], version)
+		dep.Metadata["fetch"] = "CPM"
+		return dep
+	}
+	version := cmakeKeyword(args, "VERSION")
+	if version == "" {
+		version = cmakeKeyword(args, "GIT_TAG")
+	}
+	dep := cmakeDependency(cmakeKeyword(args, "NAME"), version)
+	dep.Metadata["fetch"] = "CPM"
+	if repo := cmakeKeyword(args, "GITHUB_REPOSITORY"); repo != "" {
+		setCMakeURL(dep, "https://github.com/"+repo, "")
+	} else {
+		setCMakeURL(dep, cmakeKeyword(args, "GIT_REPOSITORY"), cmakeKeyword(args, "URL"))
+	}
+	return dep
+}
+
+// setCMakeURL records where fetched content comes from, without the
+// credentials a URL may hold.
+func setCMakeURL(dep types.Dependency, urls ...string) {
+	for _, raw := range urls {
+		if clean, host := SanitizeRegistryURL(raw); host != "" && !strings.Contains(raw, "${") {
+			dep.Metadata["url"] = clean
+			return
+		}
+	}
+}
+
+// cmakeKeyword returns the argument following a keyword, or "".
+func cmakeKeyword(args []string, keyword string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == keyword {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func cmakeArgs(raw string) []string {
+	args := cmakeArgRegex.FindAllString(raw, -1)
+	for i, arg := range args {
+		args[i] = strings.Trim(arg, `"`)
+	}
+	return args
+}
+
+// stripLineComments removes '#' line comments, leaving '#' inside strings
+// delimited by quote, such as a CPM "gh:owner/repo#tag", in place.
+func stripLineComments(content string, quote rune) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		inQuote := false
+		for j, c := range line {
+			if c == quote {
+				inQuote = !inQuote
+			} else if c == '#' && !inQuote {
+				lines[i] = line[:j]
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
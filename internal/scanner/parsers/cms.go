@@ -0,0 +1,192 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// CMS frameworks reported by the CMS detector.
+const (
+	CMSWordPress = "wordpress"
+	CMSDrupal    = "drupal"
+)
+
+var (
+	// wpHeaderRegex matches a "Field: value" line of a plugin or theme file
+	// header (inside the leading comment, optionally prefixed by " * ").
+	wpHeaderRegex = regexp.MustCompile(`(?m)^[\s*#@/]*([A-Za-z][A-Za-z ]+?):[ \t]*(.+?)\s*(?:\*/)?$`)
+	// wpVersionRegex matches $wp_version in wp-includes/version.php.
+	wpVersionRegex = regexp.MustCompile(`\$wp_version\s*=\s*['"]([^'"]+)['"]`)
+)
+
+// corePackages are the composer packages that install the CMS core.
+var corePackages = map[string]string{
+	"drupal/core":                CMSDrupal,
+	"drupal/core-recommended":    CMSDrupal,
+	"roots/wordpress":            CMSWordPress,
+	"roots/wordpress-no-content": CMSWordPress,
+	"johnpbloch/wordpress":       CMSWordPress,
+	"johnpbloch/wordpress-core":  CMSWordPress,
+}
+
+// cmsPackageKinds maps composer package types (composer/installers) to the
+// extension kind reported in dependency metadata.
+var cmsPackageKinds = map[string]string{
+	"drupal-module":      "module",
+	"drupal-theme":       "theme",
+	"drupal-profile":     "profile",
+	"drupal-library":     "library",
+	"drupal-drush":       "drush",
+	"wordpress-plugin":   "plugin",
+	"wordpress-theme":    "theme",
+	"wordpress-muplugin": "mu-plugin",
+	"wordpress-dropin":   "dropin",
+}
+
+// CMSSite is a CMS installation: its core version and the installed
+// extensions (plugins, themes, modules) as dependencies.
+type CMSSite struct {
+	CMS          string
+	CoreVersion  string
+	Dependencies []types.Dependency
+}
+
+// CMSParser parses WordPress plugin/theme headers and composer-managed
+// Drupal and WordPress sites.
+type CMSParser struct{}
+
+// NewCMSParser creates a new CMS parser.
+func NewCMSParser() *CMSParser {
+	return &CMSParser{}
+}
+
+// ParseWordPressHeader returns the fields of the file header of a
+// WordPress plugin (main PHP file) or theme (style.css), e.g. "Plugin Name",
+// "Theme Name" and "Version". Only the leading 8 KB are read, as WordPress
+// does.
+func (p *CMSParser) ParseWordPressHeader(content string) map[string]string {
+	if len(content) > 8192 {
+		content = content[:8192]
+	}
+	fields := make(map[string]string)
+	for _, m := range wpHeaderRegex.FindAllStringSubmatch(content, -1) {
+		key := strings.TrimSpace(m[1])
+		if _, seen := fields[key]; !seen {
+			fields[key] = strings.TrimSpace(m[2])
+		}
+	}
+	return fields
+}
+
+// ParseWordPressVersion returns $wp_version from wp-includes/version.php.
+func (p *CMSParser) ParseWordPressVersion(content string) string {
+	return firstSubmatch(wpVersionRegex, content)
+}
+
+// ParseComposerSite returns the CMS site of a composer.json that requires a
+// Drupal or WordPress core package, or nil. Extensions are the drupal/*
+// packages and the wpackagist-plugin/* and wpackagist-theme/* packages (or,
+// when composer.lock is given, every package whose composer type is a CMS
+// extension type); composer.lock pins their versions and kinds.
+func (p *CMSParser) ParseComposerSite(composerJSON, composerLock []byte) *CMSSite {
+	var manifest ComposerJSON
+	if json.Unmarshal(composerJSON, &manifest) != nil {
+		return nil
+	}
+	site := &CMSSite{}
+	for name, constraint := range manifest.Require {
+		if cms, ok := corePackages[name]; ok && site.CMS == "" {
+			site.CMS, site.CoreVersion = cms, constraint
+		}
+	}
+	if site.CMS == "" {
+		return nil
+	}
+
+	locked := lockedCMSPackages(composerLock)
+	for name, constraint := range manifest.Require {
+		if _, core := corePackages[name]; !core && isCMSExtension(name, locked[name].Type) {
+			site.Dependencies = append(site.Dependencies, cmsDependency(name, constraint, locked[name]))
+		}
+	}
+	site.addLocked(locked, manifest.Require)
+	sort.Slice(site.Dependencies, func(i, j int) bool { return site.Dependencies[i].Name < site.Dependencies[j].Name })
+	return site
+}
+
+// addLocked pins the core version from composer.lock and appends the
+// extensions installed as dependencies of other packages (not declared in
+// composer.json).
+func (site *CMSSite) addLocked(locked map[string]composerLockPackage, declared map[string]string) {
+	for name, pkg := range locked {
+		if cms, core := corePackages[name]; core {
+			if cms == site.CMS {
+				site.CoreVersion = pkg.Version
+			}
+			continue
+		}
+		if _, ok := declared[name]; !ok && cmsPackageKinds[pkg.Type] != "" {
+			dep := cmsDependency(name, "", pkg)
+			dep.Direct = false
+			site.Dependencies = append(site.Dependencies, dep)
+		}
+	}
+}
+
+// lockedCMSPackages returns the packages of composer.lock by name.
+func lockedCMSPackages(content []byte) map[string]composerLockPackage {
+	locked := make(map[string]composerLockPackage)
+	var lock composerLock
+	if len(content) == 0 || json.Unmarshal(content, &lock) != nil {
+		return locked
+	}
+	for _, pkg := range lock.Packages {
+		locked[pkg.Name] = pkg
+	}
+	return locked
+}
+
+// isCMSExtension reports whether a composer package is a CMS extension,
+// from its locked composer type or else its vendor prefix (drupal/core-*
+// packages are composer tooling for the core, not extensions).
+func isCMSExtension(name, lockedType string) bool {
+	if lockedType != "" {
+		return cmsPackageKinds[lockedType] != ""
+	}
+	if strings.HasPrefix(name, "drupal/core-") {
+		return false
+	}
+	return strings.HasPrefix(name, "drupal/") || strings.HasPrefix(name, "wpackagist-plugin/") ||
+		strings.HasPrefix(name, "wpackagist-theme/")
+}
+
+func cmsDependency(name, constraint string, locked composerLockPackage) types.Dependency {
+	dep := types.Dependency{
+		Type:     DependencyTypePHP,
+		Name:     name,
+		Version:  constraint,
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: types.NewMetadata(MetadataSourceComposerJSON),
+	}
+	if locked.Version != "" {
+		dep.Version = locked.Version
+		dep.Metadata["source"] = MetadataSourceComposerLock
+	}
+	kind := cmsPackageKinds[locked.Type]
+	switch {
+	case kind != "":
+	case strings.HasPrefix(name, "wpackagist-plugin/"):
+		kind = "plugin"
+	case strings.HasPrefix(name, "wpackagist-theme/"):
+		kind = "theme"
+	}
+	if kind != "" {
+		dep.Metadata["kind"] = kind
+	}
+	return dep
+}
@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWordPressHeader(t *testing.T) {
+	plugin := `<?php
+/**
+ * Plugin Name:       My Plugin
+ * Plugin URI:        https://example.com/my-plugin
+ * Version:           1.2.3
+ * Requires at least: 6.0
+ */`
+	header := NewCMSParser().ParseWordPressHeader(plugin)
+	assert.Equal(t, "My Plugin", header["Plugin Name"])
+	assert.Equal(t, "1.2.3", header["Version"])
+	assert.Equal(t, "6.0", header["Requires at least"])
+
+	theme := "/*\nTheme Name: My Theme\nVersion: 2.0 */\nbody { margin: 0; }\n"
+	header = NewCMSParser().ParseWordPressHeader(theme)
+	assert.Equal(t, "My Theme", header["Theme Name"])
+	assert.Equal(t, "2.0", header["Version"])
+}
+
+func TestParseWordPressVersion(t *testing.T) {
+	assert.Equal(t, "6.4.2", NewCMSParser().ParseWordPressVersion("<?php\n$wp_version = '6.4.2';\n"))
+	assert.Empty(t, NewCMSParser().ParseWordPressVersion("<?php\n"))
+}
+
+func TestParseComposerSite_BedrockWithoutLock(t *testing.T) {
+	composerJSON := `{"require": {
+  "roots/wordpress": "6.4.2",
+  "wpackagist-plugin/akismet": "^5.3",
+  "wpackagist-theme/twentytwentyfour": "^1.0",
+  "vlucas/phpdotenv": "^5.5"
+}}`
+	site := NewCMSParser().ParseComposerSite([]byte(composerJSON), nil)
+	require.NotNil(t, site)
+	assert.Equal(t, CMSWordPress, site.CMS)
+	assert.Equal(t, "6.4.2", site.CoreVersion)
+	require.Len(t, site.Dependencies, 2)
+	assert.Equal(t, "wpackagist-plugin/akismet", site.Dependencies[0].Name)
+	assert.Equal(t, "^5.3", site.Dependencies[0].Version)
+	assert.Equal(t, "plugin", site.Dependencies[0].Metadata["kind"])
+	assert.Equal(t, "theme", site.Dependencies[1].Metadata["kind"])
+
+	assert.Nil(t, NewCMSParser().ParseComposerSite([]byte(`{"require": {"symfony/console": "^6.0"}}`), nil))
+}
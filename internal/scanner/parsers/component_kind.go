@@ -0,0 +1,180 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	goMainPackageRegex     = regexp.MustCompile(`(?m)^package\s+main\s*$`)
+	setupConsoleScriptsRe  = regexp.MustCompile(`console_scripts|scripts\s*=`)
+	gemspecExecutablesRe   = regexp.MustCompile(`\.executables\s*=`)
+	csprojOutputTypeRegex  = regexp.MustCompile(`<OutputType>\s*(\w+)\s*</OutputType>`)
+	csprojPackableRegex    = regexp.MustCompile(`<PackageId>[^<]+</PackageId>|<IsPackable>\s*true\s*</IsPackable>`)
+	csprojWebSdkRegex      = regexp.MustCompile(`Sdk="Microsoft\.NET\.Sdk\.(?:Web|Worker)"`)
+	pomPackagingRegex      = regexp.MustCompile(`<packaging>\s*([\w-]+)\s*</packaging>`)
+	dockerfileNameRegex    = regexp.MustCompile(`(?i)^(?:Dockerfile|Containerfile)(?:\..+)?$|\.dockerfile$`)
+	pyprojectProjectTables = []string{"project", "tool.poetry"}
+)
+
+// ArtifactEvidence is what a manifest or source file says about the artifact
+// its component builds. Each field names the evidence, empty when the file
+// says nothing of that kind.
+type ArtifactEvidence struct {
+	Executable string // an installable command or program entry point, e.g. "package.json bin"
+	Library    string // a package published for other code to import, e.g. "package.json exports"
+	Container  string // a container image build, e.g. "Dockerfile"
+}
+
+// ComponentKindParser reads the evidence of what a component builds from
+// package manifests, Go sources and Dockerfiles.
+type ComponentKindParser struct{}
+
+// NewComponentKindParser creates a new component kind evidence parser.
+func NewComponentKindParser() *ComponentKindParser {
+	return &ComponentKindParser{}
+}
+
+// artifactFiles maps manifest names to their parser.
+var artifactFiles = map[string]func([]byte) ArtifactEvidence{
+	"package.json":   packageJSONArtifact,
+	"go.mod":         func([]byte) ArtifactEvidence { return ArtifactEvidence{Library: "go.mod"} },
+	"pyproject.toml": pyprojectArtifact,
+	"setup.py":       setupPyArtifact,
+	"pom.xml":        pomArtifact,
+}
+
+// ParseArtifact returns the evidence of the file at rel, a slash-separated
+// path, if it holds any.
+func (p *ComponentKindParser) ParseArtifact(rel string, content []byte) (ArtifactEvidence, bool) {
+	name := path.Base(rel)
+	var evidence ArtifactEvidence
+	switch {
+	case artifactFiles[name] != nil:
+		evidence = artifactFiles[name](content)
+	case dockerfileNameRegex.MatchString(name):
+		evidence.Container = name
+	case strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go"):
+		if goMainPackageRegex.Match(content) {
+			evidence.Executable = "package main"
+		}
+	case strings.HasSuffix(name, ".gemspec"):
+		evidence = gemspecArtifact(content)
+	case strings.HasSuffix(name, ".csproj"):
+		evidence = csprojArtifact(content)
+	}
+	return evidence, evidence != ArtifactEvidence{}
+}
+
+// packageJSONArtifact reads the bin entries and, for packages not marked
+// private, the entry points of a package.json.
+func packageJSONArtifact(content []byte) ArtifactEvidence {
+	var pkg struct {
+		Private bool            `json:"private"`
+		Bin     json.RawMessage `json:"bin"`
+		Main    string          `json:"main"`
+		Module  string          `json:"module"`
+		Exports json.RawMessage `json:"exports"`
+		Types   string          `json:"types"`
+	}
+	var evidence ArtifactEvidence
+	if json.Unmarshal(content, &pkg) != nil {
+		return evidence
+	}
+	if bin := strings.TrimSpace(string(pkg.Bin)); bin != "" && bin != `""` && bin != "{}" && bin != "null" {
+		evidence.Executable = "package.json bin"
+	}
+	if pkg.Private {
+		return evidence
+	}
+	switch {
+	case len(pkg.Exports) > 0:
+		evidence.Library = "package.json exports"
+	case firstNonEmpty(pkg.Main, pkg.Module, pkg.Types) != "":
+		evidence.Library = "package.json main"
+	}
+	return evidence
+}
+
+// pyprojectArtifact reads the scripts and the project table of a
+// pyproject.toml, PEP 621 or Poetry.
+func pyprojectArtifact(content []byte) ArtifactEvidence {
+	var doc map[string]interface{}
+	var evidence ArtifactEvidence
+	if _, err := toml.Decode(string(content), &doc); err != nil {
+		return evidence
+	}
+	for _, table := range pyprojectProjectTables {
+		project, ok := lookupTable(doc, table)
+		if !ok {
+			continue
+		}
+		if scripts, ok := project["scripts"].(map[string]interface{}); ok && len(scripts) > 0 {
+			evidence.Executable = "pyproject.toml [" + table + ".scripts]"
+		}
+		if name, _ := project["name"].(string); name != "" {
+			evidence.Library = "pyproject.toml [" + table + "]"
+		}
+	}
+	return evidence
+}
+
+// lookupTable returns the table at a dotted key of a decoded TOML document.
+func lookupTable(doc map[string]interface{}, key string) (map[string]interface{}, bool) {
+	table := doc
+	for _, part := range strings.Split(key, ".") {
+		next, ok := table[part].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		table = next
+	}
+	return table, true
+}
+
+func setupPyArtifact(content []byte) ArtifactEvidence {
+	if setupConsoleScriptsRe.Match(content) {
+		return ArtifactEvidence{Executable: "setup.py scripts", Library: "setup.py"}
+	}
+	return ArtifactEvidence{Library: "setup.py"}
+}
+
+func gemspecArtifact(content []byte) ArtifactEvidence {
+	evidence := ArtifactEvidence{Library: "gemspec"}
+	if gemspecExecutablesRe.Match(content) {
+		evidence.Executable = "gemspec executables"
+	}
+	return evidence
+}
+
+// csprojArtifact reads the output type of an SDK-style project. Web and
+// worker projects are left to the frameworks they use.
+func csprojArtifact(content []byte) ArtifactEvidence {
+	var evidence ArtifactEvidence
+	if csprojWebSdkRegex.Match(content) {
+		return evidence
+	}
+	if m := csprojOutputTypeRegex.FindSubmatch(content); m != nil && strings.HasSuffix(strings.ToLower(string(m[1])), "exe") {
+		evidence.Executable = "csproj OutputType " + string(m[1])
+	} else if csprojPackableRegex.Match(content) {
+		evidence.Library = "csproj PackageId"
+	}
+	return evidence
+}
+
+// pomArtifact treats a Maven module packaged as a jar, the default, as a
+// library; aggregator poms and web archives say nothing.
+func pomArtifact(content []byte) ArtifactEvidence {
+	packaging := "jar"
+	if m := pomPackagingRegex.FindSubmatch(content); m != nil {
+		packaging = string(m[1])
+	}
+	if packaging != "jar" {
+		return ArtifactEvidence{}
+	}
+	return ArtifactEvidence{Library: "pom.xml jar packaging"}
+}
@@ -0,0 +1,44 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArtifact(t *testing.T) {
+	parser := NewComponentKindParser()
+	tests := []struct {
+		name    string
+		rel     string
+		content string
+		want    ArtifactEvidence
+		ok      bool
+	}{
+		{"npm bin", "/tool/package.json", `{"name": "tool", "bin": {"tool": "./cli.js"}, "main": "index.js"}`, ArtifactEvidence{Executable: "package.json bin", Library: "package.json main"}, true},
+		{"npm exports", "/lib/package.json", `{"name": "@myorg/lib", "exports": {".": "./index.js"}}`, ArtifactEvidence{Library: "package.json exports"}, true},
+		{"npm private", "/web/package.json", `{"name": "web", "private": true, "main": "index.js"}`, ArtifactEvidence{}, false},
+		{"go main", "/cmd/myapp/main.go", "// Command myapp.\npackage main\n\nfunc main() {}\n", ArtifactEvidence{Executable: "package main"}, true},
+		{"go package", "/internal/store.go", "package store\n", ArtifactEvidence{}, false},
+		{"go test main", "/main_test.go", "package main\n", ArtifactEvidence{}, false},
+		{"go module", "/go.mod", "module example.com/myapp\n", ArtifactEvidence{Library: "go.mod"}, true},
+		{"pep 621 scripts", "/pyproject.toml", "[project]\nname = \"myapp\"\n\n[project.scripts]\nmyapp = \"myapp.cli:main\"\n", ArtifactEvidence{Executable: "pyproject.toml [project.scripts]", Library: "pyproject.toml [project]"}, true},
+		{"poetry", "/pyproject.toml", "[tool.poetry]\nname = \"mylib\"\n", ArtifactEvidence{Library: "pyproject.toml [tool.poetry]"}, true},
+		{"tool config only", "/pyproject.toml", "[tool.ruff]\nline-length = 100\n", ArtifactEvidence{}, false},
+		{"setup.py scripts", "/setup.py", "setup(name='myapp', entry_points={'console_scripts': ['myapp=myapp:main']})\n", ArtifactEvidence{Executable: "setup.py scripts", Library: "setup.py"}, true},
+		{"gemspec", "/mygem.gemspec", "Gem::Specification.new do |spec|\n  spec.executables = ['mygem']\nend\n", ArtifactEvidence{Executable: "gemspec executables", Library: "gemspec"}, true},
+		{"csproj exe", "/Tool/Tool.csproj", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><OutputType>Exe</OutputType></PropertyGroup></Project>`, ArtifactEvidence{Executable: "csproj OutputType Exe"}, true},
+		{"csproj package", "/Lib/Lib.csproj", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><PackageId>MyOrg.Lib</PackageId></PropertyGroup></Project>`, ArtifactEvidence{Library: "csproj PackageId"}, true},
+		{"csproj web", "/Api/Api.csproj", `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><OutputType>Exe</OutputType></PropertyGroup></Project>`, ArtifactEvidence{}, false},
+		{"pom jar", "/pom.xml", "<project><artifactId>mylib</artifactId></project>", ArtifactEvidence{Library: "pom.xml jar packaging"}, true},
+		{"pom aggregator", "/pom.xml", "<project><packaging>pom</packaging></project>", ArtifactEvidence{}, false},
+		{"dockerfile", "/api/Dockerfile.prod", "FROM node:20\n", ArtifactEvidence{Container: "Dockerfile.prod"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parser.ParseArtifact(tt.rel, []byte(tt.content))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -17,6 +17,7 @@ type composerLock struct {
 type composerLockPackage struct {
 	Name    string            `json:"name"`
 	Version string            `json:"version"`
+	Type    string            `json:"type"` // composer package type, e.g. drupal-module
 	Require map[string]string `json:"require"`
 }
 
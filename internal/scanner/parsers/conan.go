@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"bufio"
+	"encoding/json"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -141,6 +142,70 @@ func (p *ConanParser) ExtractDependenciesFromFiles(conanContent string, packages
 	return dependencies
 }
 
+// ParseConanfileTxt extracts the dependencies of a conanfile.txt. Packages of
+// the [requires] section are production dependencies, those of
+// [tool_requires] and the older [build_requires] development ones.
+func (p *ConanParser) ParseConanfileTxt(content string) []types.Dependency {
+	var dependencies []types.Dependency
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line
+			continue
+		}
+		if line == "" || !strings.Contains(line, "/") {
+			continue
+		}
+		switch section {
+		case "[requires]":
+			dependencies = append(dependencies, p.ParseConanDependency(line, types.ScopeProd))
+		case "[tool_requires]", "[build_requires]":
+			dependencies = append(dependencies, p.ParseConanDependency(line, types.ScopeDev))
+		}
+	}
+	return dependencies
+}
+
+// ConanLockVersions returns the version conan.lock pins for each package,
+// from the graph nodes of a v1 lockfile or the requires lists of a v2 one.
+// It returns nil when the lockfile does not parse.
+func ConanLockVersions(content []byte) map[string]string {
+	var lock conanLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil
+	}
+	versions := make(map[string]string)
+	refs := append(lock.Requires, lock.BuildRequires...)
+	for _, node := range lock.GraphLock.Nodes {
+		refs = append(refs, node.Ref)
+	}
+	for _, ref := range refs {
+		// A v2 ref may carry a timestamp after the revision: name/1.0#rrev%ts.
+		if name, version, ok := strings.Cut(conanRefNode(ref), "@"); ok {
+			versions[name] = version
+		}
+	}
+	return versions
+}
+
+// ApplyConanLockVersions sets the locked version of the Conan dependencies
+// declared without a version or with a version range such as "[>=1.2 <2]",
+// keeping the declared range.
+func ApplyConanLockVersions(dependencies []types.Dependency, versions map[string]string) {
+	for i := range dependencies {
+		dep := &dependencies[i]
+		locked := versions[dep.Name]
+		if dep.Type != DependencyTypeConan || locked == "" || (dep.Version != "" && !strings.HasPrefix(dep.Version, "[")) {
+			continue
+		}
+		declared := dep.Version
+		dep.Version = locked
+		dep.SetDeclaredVersion(declared)
+	}
+}
+
 func init() {
 	// Auto-register this parser
 }
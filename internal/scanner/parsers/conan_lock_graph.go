@@ -9,11 +9,13 @@ import (
 
 // conanLock is the JSON view of conan.lock. v1 expresses a real graph under
 // graph_lock.nodes (node-id -> {ref, requires:[node-ids]}); node "0" is the
-// root. v2 carries only a flat requires list (no edges).
+// root. v2 carries only flat requires and build_requires lists (no edges).
 type conanLock struct {
 	GraphLock struct {
 		Nodes map[string]conanLockNode `json:"nodes"`
 	} `json:"graph_lock"`
+	Requires      []string `json:"requires"`
+	BuildRequires []string `json:"build_requires"`
 }
 
 type conanLockNode struct {
@@ -211,3 +211,56 @@ func TestConanParser_parseConanDependency(t *testing.T) {
 		})
 	}
 }
+
+func TestConanParser_ParseConanfileTxt(t *testing.T) {
+	deps := NewConanParser().ParseConanfileTxt(`[requires]
+zlib/1.3.1
+boost/[>=1.80 <2.0] # any 1.x from 1.80
+
+[tool_requires]
+cmake/3.27.9
+
+[generators]
+CMakeDeps
+`)
+	if len(deps) != 3 {
+		t.Fatalf("got %d dependencies, want 3: %+v", len(deps), deps)
+	}
+	if deps[0].Name != "zlib" || deps[0].Version != "1.3.1" || deps[0].Scope != types.ScopeProd {
+		t.Errorf("zlib = %+v", deps[0])
+	}
+	if deps[1].Version != "[>=1.80 <2.0]" {
+		t.Errorf("boost version = %q", deps[1].Version)
+	}
+	if deps[2].Name != "cmake" || deps[2].Scope != types.ScopeDev {
+		t.Errorf("cmake = %+v", deps[2])
+	}
+}
+
+func TestConanLockVersions(t *testing.T) {
+	v2 := ConanLockVersions([]byte(`{"version": "0.5",
+  "requires": ["zlib/1.3.1#f52e03ae3d251dec704634230cd806a2%1708593606.497", "boost/1.84.0#4b8c9c2a%1708593600.0"],
+  "build_requires": ["cmake/3.27.9#e6e5f1a0%1700000000.0"]}`))
+	want := map[string]string{"zlib": "1.3.1", "boost": "1.84.0", "cmake": "3.27.9"}
+	for name, version := range want {
+		if v2[name] != version {
+			t.Errorf("v2 %s = %q, want %q", name, v2[name], version)
+		}
+	}
+
+	v1 := ConanLockVersions([]byte(`{"graph_lock": {"nodes": {
+  "0": {"ref": "", "requires": ["1"]},
+  "1": {"ref": "openssl/3.2.1@myorg/stable#rrev"}}}}`))
+	if v1["openssl"] != "3.2.1" {
+		t.Errorf("v1 openssl = %q, want 3.2.1", v1["openssl"])
+	}
+
+	deps := NewConanParser().ParseConanfileTxt("[requires]\nboost/[>=1.80 <2.0]\nzlib/1.2.13\n")
+	ApplyConanLockVersions(deps, v2)
+	if deps[0].Version != "1.84.0" || deps[0].Metadata[types.MetadataKeyDeclared] != "[>=1.80 <2.0]" {
+		t.Errorf("boost = %+v, want the locked version and the declared range", deps[0])
+	}
+	if deps[1].Version != "1.2.13" {
+		t.Errorf("zlib = %q, a pinned version is kept", deps[1].Version)
+	}
+}
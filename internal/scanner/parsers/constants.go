@@ -47,6 +47,12 @@ const (
 	// C/C++ ecosystem (PURL: conan)
 	DependencyTypeConan = "conan"
 
+	// C/C++ packages found by CMake find_package/FetchContent, requested
+	// from vcpkg or looked up by Meson dependency() (no PURL type)
+	DependencyTypeCMake = "cmake"
+	DependencyTypeVcpkg = "vcpkg"
+	DependencyTypeMeson = "meson"
+
 	// iOS/macOS ecosystem (PURL: cocoapods)
 	DependencyTypeCocoapods = "cocoapods"
 
@@ -65,15 +71,50 @@ const (
 	// R ecosystem (PURL: cran)
 	DependencyTypeR = "cran"
 
+	// Julia ecosystem (PURL: julia)
+	DependencyTypeJulia = "julia"
+
+	// Zig package manager, build.zig.zon (no PURL type)
+	DependencyTypeZig = "zig"
+
+	// WordPress plugins and themes installed under wp-content (no PURL type)
+	DependencyTypeWordPress = "wordpress"
+
+	// Embedded firmware libraries: PlatformIO registry, Arduino Library
+	// Manager and Zephyr west projects (no PURL type)
+	DependencyTypePlatformIO = "platformio"
+	DependencyTypeArduino    = "arduino"
+	DependencyTypeWest       = "west"
+
+	// ROS packages, by rosdep key (no PURL type)
+	DependencyTypeROS = "ros"
+
+	// MkDocs themes and plugins, named as in mkdocs.yml (no PURL type)
+	DependencyTypeMkDocs = "mkdocs"
+
 	// Infrastructure as Code (no PURL type)
 	DependencyTypeTerraform = "terraform"
 
 	// CI/CD (no PURL type)
 	DependencyTypeGitHubAction = "githubAction"
 
+	// Command-line tools invoked by task runners and scripts (rule matching
+	// only; never emitted as a dependency)
+	DependencyTypeCLI = "cli"
+
 	// Containers (PURL: docker)
 	DependencyTypeDocker = "docker"
 
+	// Distribution packages (PURL: deb, rpm, apk, alpm); the distribution,
+	// when known, is carried in metadata.distro and becomes the PURL namespace
+	DependencyTypeDeb  = "deb"
+	DependencyTypeRpm  = "rpm"
+	DependencyTypeApk  = "apk"
+	DependencyTypeAlpm = "alpm"
+
+	// Homebrew formulae (no PURL type)
+	DependencyTypeHomebrew = "homebrew"
+
 	// Other (no PURL type)
 	DependencyTypeDelphi = "delphi"
 )
@@ -123,6 +164,9 @@ const (
 	MetadataSourceConanfile   = "conanfile.txt"
 	MetadataSourceConanfilePy = "conanfile.py"
 	MetadataSourceVcxproj     = ".vcxproj"
+	MetadataSourceCMakeLists  = "CMakeLists.txt"
+	MetadataSourceVcpkgJSON   = "vcpkg.json"
+	MetadataSourceMesonBuild  = "meson.build"
 
 	// Delphi ecosystem
 	MetadataSourceDproj = ".dproj"
@@ -140,4 +184,12 @@ const (
 	// Containers
 	MetadataSourceDockerfile    = "Dockerfile"
 	MetadataSourceDockerCompose = "docker-compose.yml"
+	MetadataSourceDevcontainer  = "devcontainer.json"
+
+	// Distribution packaging recipes
+	MetadataSourceDebianControl   = "debian/control"
+	MetadataSourceRPMSpec         = ".spec"
+	MetadataSourceAPKBUILD        = "APKBUILD"
+	MetadataSourcePKGBUILD        = "PKGBUILD"
+	MetadataSourceHomebrewFormula = "Formula"
 )
@@ -0,0 +1,256 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Deployment platforms, named after the techs of their rules.
+const (
+	DeploymentPlatformHeroku            = "heroku"
+	DeploymentPlatformFly               = "flyio"
+	DeploymentPlatformVercel            = "vercel"
+	DeploymentPlatformNetlify           = "netlify"
+	DeploymentPlatformAppEngine         = "gcp.appengine"
+	DeploymentPlatformKubernetes        = "kubernetes"
+	DeploymentPlatformECS               = "aws.ecs"
+	DeploymentPlatformRender            = "render"
+	DeploymentPlatformRailway           = "railway"
+	DeploymentPlatformCloudflareWorkers = "cloudflare.workers"
+)
+
+var (
+	procfileLineRegex       = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*\S`)
+	kubernetesWorkloadRegex = regexp.MustCompile(`(?m)^kind:\s*["']?(?:Deployment|StatefulSet|DaemonSet|Job|CronJob|Pod)\b`)
+	ecsTaskDefinitionRegex  = regexp.MustCompile(`"containerDefinitions"\s*:`)
+)
+
+// kubernetesWorkloadKinds are the kinds of Kubernetes objects running
+// containers.
+var kubernetesWorkloadKinds = map[string]bool{
+	"Deployment": true, "StatefulSet": true, "DaemonSet": true, "Job": true, "CronJob": true, "Pod": true,
+}
+
+// Deployment is a platform a configuration file deploys to, with what the
+// file says about the deployment.
+type Deployment struct {
+	Platform  string
+	Name      string   // app, service or task family
+	Runtime   string   // App Engine runtime or ECS launch types
+	Regions   []string // regions the app runs in
+	Workloads []string // process types, services, Kubernetes workloads (Kind/name) or containers
+}
+
+// DeploymentParser reads the deployment configuration of hosting
+// platforms.
+type DeploymentParser struct{}
+
+// NewDeploymentParser creates a new deployment configuration parser.
+func NewDeploymentParser() *DeploymentParser {
+	return &DeploymentParser{}
+}
+
+// ParseProcfile reads the process types of a Procfile, deployed to Heroku.
+func (p *DeploymentParser) ParseProcfile(content []byte) (Deployment, bool) {
+	deployment := Deployment{Platform: DeploymentPlatformHeroku}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if m := procfileLineRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			deployment.Workloads = appendNew(deployment.Workloads, m[1])
+		}
+	}
+	return deployment, len(deployment.Workloads) > 0
+}
+
+// ParseHerokuYML reads the process types a heroku.yml builds images for.
+func (p *DeploymentParser) ParseHerokuYML(content []byte) (Deployment, bool) {
+	var config struct {
+		Build struct {
+			Docker map[string]interface{} `yaml:"docker"`
+		} `yaml:"build"`
+	}
+	if yaml.Unmarshal(content, &config) != nil {
+		return Deployment{}, false
+	}
+	return Deployment{Platform: DeploymentPlatformHeroku, Runtime: "container", Workloads: sortedKeys(config.Build.Docker)}, true
+}
+
+// ParseFlyToml reads the app name, primary region and process groups of a
+// fly.toml.
+func (p *DeploymentParser) ParseFlyToml(content []byte) (Deployment, bool) {
+	var config struct {
+		App           string                 `toml:"app"`
+		PrimaryRegion string                 `toml:"primary_region"`
+		Processes     map[string]interface{} `toml:"processes"`
+	}
+	if _, err := toml.Decode(string(content), &config); err != nil {
+		return Deployment{}, false
+	}
+	deployment := Deployment{Platform: DeploymentPlatformFly, Name: config.App, Workloads: sortedKeys(config.Processes)}
+	deployment.Regions = appendNew(deployment.Regions, config.PrimaryRegion)
+	return deployment, true
+}
+
+// ParseVercelJSON reads the project name and function regions of a
+// vercel.json.
+func (p *DeploymentParser) ParseVercelJSON(content []byte) (Deployment, bool) {
+	var config struct {
+		Name    string   `json:"name"`
+		Regions []string `json:"regions"`
+	}
+	if json.Unmarshal(content, &config) != nil {
+		return Deployment{}, false
+	}
+	return Deployment{Platform: DeploymentPlatformVercel, Name: config.Name, Regions: config.Regions}, true
+}
+
+// ParseNetlifyToml accepts a netlify.toml; the site is named in Netlify,
+// not in the file.
+func (p *DeploymentParser) ParseNetlifyToml(content []byte) (Deployment, bool) {
+	var config map[string]interface{}
+	if _, err := toml.Decode(string(content), &config); err != nil {
+		return Deployment{}, false
+	}
+	return Deployment{Platform: DeploymentPlatformNetlify}, true
+}
+
+// ParseAppEngine reads the service and runtime of an App Engine app.yaml.
+// A file without a top-level runtime, or declaring a Kubernetes object, is
+// not an App Engine configuration.
+func (p *DeploymentParser) ParseAppEngine(content []byte) (Deployment, bool) {
+	var config struct {
+		APIVersion string `yaml:"apiVersion"`
+		Runtime    string `yaml:"runtime"`
+		Service    string `yaml:"service"`
+		Module     string `yaml:"module"`
+		Env        string `yaml:"env"`
+	}
+	if yaml.Unmarshal(content, &config) != nil || config.Runtime == "" || config.APIVersion != "" {
+		return Deployment{}, false
+	}
+	deployment := Deployment{Platform: DeploymentPlatformAppEngine, Name: firstNonEmpty(config.Service, config.Module, "default"), Runtime: config.Runtime}
+	if config.Env == "flex" || config.Env == "flexible" {
+		deployment.Runtime += " (flexible)"
+	}
+	return deployment, true
+}
+
+// IsKubernetesWorkload reports whether a YAML manifest declares a workload:
+// a Deployment, StatefulSet, DaemonSet, Job, CronJob or Pod.
+func (p *DeploymentParser) IsKubernetesWorkload(content []byte) bool {
+	return kubernetesWorkloadRegex.Match(content)
+}
+
+// ParseKubernetesWorkloads reads the workloads of a (multi-document)
+// manifest as Kind/name, with their namespace as the deployment name when
+// all of them share one. Parsing stops at the first document that is not
+// valid YAML (templated Helm charts).
+func (p *DeploymentParser) ParseKubernetesWorkloads(content []byte) (Deployment, bool) {
+	deployment := Deployment{Platform: DeploymentPlatformKubernetes}
+	namespaces := make(map[string]bool)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if decoder.Decode(&obj) != nil {
+			break
+		}
+		if kubernetesWorkloadKinds[obj.Kind] && obj.Metadata.Name != "" {
+			deployment.Workloads = appendNew(deployment.Workloads, obj.Kind+"/"+obj.Metadata.Name)
+			namespaces[obj.Metadata.Namespace] = true
+		}
+	}
+	if len(namespaces) == 1 {
+		for namespace := range namespaces {
+			deployment.Name = namespace
+		}
+	}
+	return deployment, len(deployment.Workloads) > 0
+}
+
+// IsECSTaskDefinition reports whether a JSON file may be an ECS task
+// definition.
+func (p *DeploymentParser) IsECSTaskDefinition(content []byte) bool {
+	return ecsTaskDefinitionRegex.Match(content)
+}
+
+// ParseECSTaskDefinition reads the family, launch types and containers of
+// an ECS task definition, as registered or as returned by describe-task-
+// definition.
+func (p *DeploymentParser) ParseECSTaskDefinition(content []byte) (Deployment, bool) {
+	type taskDefinition struct {
+		Family                  string   `json:"family"`
+		RequiresCompatibilities []string `json:"requiresCompatibilities"`
+		ContainerDefinitions    []struct {
+			Name string `json:"name"`
+		} `json:"containerDefinitions"`
+	}
+	var wrapper struct {
+		taskDefinition
+		TaskDefinition *taskDefinition `json:"taskDefinition"`
+	}
+	if json.Unmarshal(content, &wrapper) != nil {
+		return Deployment{}, false
+	}
+	task := wrapper.taskDefinition
+	if wrapper.TaskDefinition != nil {
+		task = *wrapper.TaskDefinition
+	}
+	deployment := Deployment{Platform: DeploymentPlatformECS, Name: task.Family, Runtime: strings.Join(task.RequiresCompatibilities, ",")}
+	for _, container := range task.ContainerDefinitions {
+		deployment.Workloads = appendNew(deployment.Workloads, container.Name)
+	}
+	return deployment, len(task.ContainerDefinitions) > 0
+}
+
+// ParseRenderYAML reads the services of a render.yaml blueprint as
+// type/name.
+func (p *DeploymentParser) ParseRenderYAML(content []byte) (Deployment, bool) {
+	var blueprint struct {
+		Services []struct {
+			Type   string `yaml:"type"`
+			Name   string `yaml:"name"`
+			Region string `yaml:"region"`
+		} `yaml:"services"`
+	}
+	if yaml.Unmarshal(content, &blueprint) != nil {
+		return Deployment{}, false
+	}
+	deployment := Deployment{Platform: DeploymentPlatformRender}
+	for _, service := range blueprint.Services {
+		workload := service.Name
+		if service.Type != "" {
+			workload = service.Type + "/" + service.Name
+		}
+		deployment.Workloads = appendNew(deployment.Workloads, workload)
+		deployment.Regions = appendNew(deployment.Regions, service.Region)
+	}
+	return deployment, true
+}
+
+// ParseRailway accepts a railway.json or railway.toml.
+func (p *DeploymentParser) ParseRailway(content []byte) (Deployment, bool) {
+	return Deployment{Platform: DeploymentPlatformRailway}, true
+}
+
+// ParseWranglerToml reads the Worker name of a wrangler.toml.
+func (p *DeploymentParser) ParseWranglerToml(content []byte) (Deployment, bool) {
+	var config struct {
+		Name string `toml:"name"`
+	}
+	if _, err := toml.Decode(string(content), &config); err != nil {
+		return Deployment{}, false
+	}
+	return Deployment{Platform: DeploymentPlatformCloudflareWorkers, Name: config.Name}, true
+}
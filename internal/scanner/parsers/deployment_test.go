@@ -0,0 +1,100 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcfile(t *testing.T) {
+	deployment, ok := NewDeploymentParser().ParseProcfile([]byte("web: gunicorn myapp.wsgi\nworker: celery -A myapp worker\n# comment\nrelease: python manage.py migrate\n"))
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformHeroku, Workloads: []string{"web", "worker", "release"}}, deployment)
+
+	_, ok = NewDeploymentParser().ParseProcfile([]byte("\n"))
+	assert.False(t, ok)
+}
+
+func TestParseFlyToml(t *testing.T) {
+	deployment, ok := NewDeploymentParser().ParseFlyToml([]byte(`app = "myapp"
+primary_region = "fra"
+
+[processes]
+app = "node server.js"
+worker = "node worker.js"
+
+[http_service]
+internal_port = 8080
+`))
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformFly, Name: "myapp", Regions: []string{"fra"}, Workloads: []string{"app", "worker"}}, deployment)
+}
+
+func TestParseAppEngine(t *testing.T) {
+	parser := NewDeploymentParser()
+	deployment, ok := parser.ParseAppEngine([]byte("runtime: python312\nservice: api\nenv: flex\n"))
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformAppEngine, Name: "api", Runtime: "python312 (flexible)"}, deployment)
+
+	deployment, ok = parser.ParseAppEngine([]byte("runtime: go122\n"))
+	require.True(t, ok)
+	assert.Equal(t, "default", deployment.Name)
+
+	_, ok = parser.ParseAppEngine([]byte("name: myapp\nversion: 1.0\n"))
+	assert.False(t, ok)
+}
+
+func TestParseKubernetesWorkloads(t *testing.T) {
+	parser := NewDeploymentParser()
+	content := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: shop
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+  namespace: shop
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  namespace: shop
+`)
+	require.True(t, parser.IsKubernetesWorkload(content))
+	deployment, ok := parser.ParseKubernetesWorkloads(content)
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformKubernetes, Name: "shop", Workloads: []string{"Deployment/api", "StatefulSet/db"}}, deployment)
+
+	assert.False(t, parser.IsKubernetesWorkload([]byte("apiVersion: v1\nkind: ConfigMap\n")))
+}
+
+func TestParseECSTaskDefinition(t *testing.T) {
+	parser := NewDeploymentParser()
+	content := []byte(`{"taskDefinition": {"family": "myapp", "requiresCompatibilities": ["FARGATE"], "containerDefinitions": [{"name": "app"}, {"name": "sidecar"}]}}`)
+	require.True(t, parser.IsECSTaskDefinition(content))
+	deployment, ok := parser.ParseECSTaskDefinition(content)
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformECS, Name: "myapp", Runtime: "FARGATE", Workloads: []string{"app", "sidecar"}}, deployment)
+
+	deployment, ok = parser.ParseECSTaskDefinition([]byte(`{"family": "worker", "containerDefinitions": [{"name": "worker"}]}`))
+	require.True(t, ok)
+	assert.Equal(t, "worker", deployment.Name)
+}
+
+func TestParseRenderYAML(t *testing.T) {
+	deployment, ok := NewDeploymentParser().ParseRenderYAML([]byte(`services:
+  - type: web
+    name: api
+    region: frankfurt
+  - type: worker
+    name: jobs
+    region: frankfurt
+`))
+	require.True(t, ok)
+	assert.Equal(t, Deployment{Platform: DeploymentPlatformRender, Regions: []string{"frankfurt"}, Workloads: []string{"web/api", "worker/jobs"}}, deployment)
+}
@@ -0,0 +1,234 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Desktop application frameworks reported in DesktopApp.Framework.
+const (
+	DesktopFrameworkElectron = "electron"
+	DesktopFrameworkTauri    = "tauri"
+)
+
+// forgeMakerRegex matches Electron Forge maker package names.
+var forgeMakerRegex = regexp.MustCompile(`@electron-forge/maker-([a-z0-9-]+)`)
+
+// DesktopApp describes how a desktop application is packaged: the framework,
+// its packaging configuration and the installer targets per platform
+// (macos, windows, linux).
+type DesktopApp struct {
+	Framework        string              `json:"framework"`
+	FrameworkVersion string              `json:"framework_version,omitempty"`
+	Config           string              `json:"config,omitempty"`
+	Packager         string              `json:"packager,omitempty"`
+	ProductName      string              `json:"product_name,omitempty"`
+	Version          string              `json:"version,omitempty"`
+	Identifier       string              `json:"identifier,omitempty"`
+	Targets          map[string][]string `json:"targets,omitempty"`
+	FrontendDist     string              `json:"frontend_dist,omitempty"`
+	DevURL           string              `json:"dev_url,omitempty"`
+}
+
+// DesktopParser parses Electron and Tauri packaging configurations.
+type DesktopParser struct{}
+
+// NewDesktopParser creates a new desktop packaging configuration parser.
+func NewDesktopParser() *DesktopParser {
+	return &DesktopParser{}
+}
+
+// electronPlatforms maps electron-builder platform keys to platform names.
+var electronPlatforms = map[string]string{"mac": "macos", "win": "windows", "linux": "linux"}
+
+// forgeMakerPlatforms maps Electron Forge makers to the platform they build
+// installers for.
+var forgeMakerPlatforms = map[string]string{
+	"squirrel": "windows", "wix": "windows", "appx": "windows", "msix": "windows",
+	"dmg": "macos", "pkg": "macos", "zip": "macos",
+	"deb": "linux", "rpm": "linux", "flatpak": "linux", "snap": "linux",
+}
+
+// tauriBundlePlatforms maps Tauri bundle targets to platforms.
+var tauriBundlePlatforms = map[string]string{
+	"deb": "linux", "rpm": "linux", "appimage": "linux",
+	"msi": "windows", "nsis": "windows",
+	"app": "macos", "dmg": "macos",
+}
+
+// ParseElectronBuilder parses an electron-builder configuration (YAML, JSON,
+// JSON5 or the "build" section of package.json) and returns the targets per
+// platform. A platform section without a target list means the
+// electron-builder defaults and is reported with no targets.
+func (p *DesktopParser) ParseElectronBuilder(content []byte) map[string][]string {
+	config := decodeLooseJSON(content)
+	if config == nil {
+		return nil
+	}
+	return electronBuilderTargets(config)
+}
+
+func electronBuilderTargets(config map[string]interface{}) map[string][]string {
+	targets := make(map[string][]string)
+	for key, platform := range electronPlatforms {
+		section, ok := config[key]
+		if !ok {
+			continue
+		}
+		if options, ok := section.(map[string]interface{}); ok {
+			section = options["target"]
+		}
+		targets[platform] = append([]string{}, builderTargetNames(section)...)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets
+}
+
+// builderTargetNames reads a target value: "dmg", ["dmg", "zip"] or
+// [{target: "nsis", arch: [...]}].
+func builderTargetNames(value interface{}) []string {
+	var names []string
+	switch v := value.(type) {
+	case string:
+		names = append(names, v)
+	case []interface{}:
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				item = m["target"]
+			}
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ParseForgeMakers returns the installer targets per platform of an
+// Electron Forge configuration (forge.config.js/ts or the config.forge
+// section of package.json), from the @electron-forge/maker-* packages it
+// references.
+func (p *DesktopParser) ParseForgeMakers(content string) map[string][]string {
+	targets := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, m := range forgeMakerRegex.FindAllStringSubmatch(content, -1) {
+		platform, ok := forgeMakerPlatforms[m[1]]
+		if !ok || seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		targets[platform] = append(targets[platform], m[1])
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return targets
+}
+
+// ParseTauriConfig parses tauri.conf.json (or .json5), in both the v1
+// layout (package/tauri.bundle/build.distDir) and the v2 layout (top-level
+// productName/identifier, bundle, build.frontendDist). Returns nil when the
+// content is not a Tauri configuration.
+func (p *DesktopParser) ParseTauriConfig(content []byte) *DesktopApp {
+	config := decodeLooseJSON(content)
+	if config == nil {
+		return nil
+	}
+	return tauriApp(config)
+}
+
+// ParseTauriToml parses the TOML variant of the Tauri configuration
+// (Tauri.toml).
+func (p *DesktopParser) ParseTauriToml(content string) *DesktopApp {
+	var config map[string]interface{}
+	if _, err := toml.Decode(content, &config); err != nil {
+		return nil
+	}
+	// TOML tables decode as map[string]interface{} and arrays as
+	// []interface{}, the same shapes as the JSON layout.
+	return tauriApp(config)
+}
+
+func tauriApp(config map[string]interface{}) *DesktopApp {
+	pkg := stringMap(config["package"])   // v1
+	tauri := stringMap(config["tauri"])   // v1
+	bundle := stringMap(config["bundle"]) // v2
+	build := stringMap(config["build"])   // v1 and v2
+	if len(bundle) == 0 {
+		bundle = stringMap(tauri["bundle"])
+	}
+	app := &DesktopApp{
+		Framework:    DesktopFrameworkTauri,
+		ProductName:  firstString(config["productName"], pkg["productName"]),
+		Version:      firstString(config["version"], pkg["version"]),
+		Identifier:   firstString(config["identifier"], bundle["identifier"]),
+		FrontendDist: firstString(build["frontendDist"], build["distDir"]),
+		DevURL:       firstString(build["devUrl"], build["devPath"]),
+		Targets:      tauriTargets(bundle["targets"]),
+	}
+	if app.ProductName == "" && app.Identifier == "" && len(build) == 0 && len(bundle) == 0 {
+		return nil
+	}
+	return app
+}
+
+// tauriTargets groups the bundle targets by platform. "all" (the default)
+// is reported as such.
+func tauriTargets(value interface{}) map[string][]string {
+	names := builderTargetNames(value)
+	if len(names) == 0 {
+		return nil
+	}
+	targets := make(map[string][]string)
+	for _, name := range names {
+		platform, ok := tauriBundlePlatforms[strings.ToLower(name)]
+		if !ok {
+			platform = name // "all"
+		}
+		targets[platform] = append(targets[platform], name)
+	}
+	for _, list := range targets {
+		sort.Strings(list)
+	}
+	return targets
+}
+
+// decodeLooseJSON decodes JSON, or else JSON5-style content (comments,
+// unquoted keys) and YAML, into a map. YAML flow syntax covers most JSON5,
+// and electron-builder YAML configurations decode the same way.
+func decodeLooseJSON(content []byte) map[string]interface{} {
+	var config map[string]interface{}
+	if json.Unmarshal(content, &config) == nil {
+		return config
+	}
+	text := string(content)
+	if strings.HasPrefix(strings.TrimSpace(text), "{") {
+		text = stripZonComments(text) // JSON5 // comments (YAML uses #)
+	}
+	if err := yaml.Unmarshal([]byte(text), &config); err != nil {
+		return nil
+	}
+	return config
+}
+
+func stringMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// firstString returns the first non-empty string value.
+func firstString(values ...interface{}) string {
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
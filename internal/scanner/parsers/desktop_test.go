@@ -0,0 +1,121 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseElectronBuilder(t *testing.T) {
+	parser := NewDesktopParser()
+
+	yamlConfig := `appId: com.example.myapp
+productName: MyApp
+publish:
+  provider: generic
+  url: https://downloads.example.com/myapp
+mac:
+  target:
+    - dmg
+    - zip
+win:
+  target:
+    - target: nsis
+      arch: [x64, arm64]
+linux: {}
+`
+	assert.Equal(t, map[string][]string{
+		"macos":   {"dmg", "zip"},
+		"windows": {"nsis"},
+		"linux":   {},
+	}, parser.ParseElectronBuilder([]byte(yamlConfig)))
+
+	jsonConfig := `{"appId": "com.example.myapp", "mac": {"target": "dmg"}}`
+	assert.Equal(t, map[string][]string{"macos": {"dmg"}}, parser.ParseElectronBuilder([]byte(jsonConfig)))
+
+	json5Config := `{
+  // packaged for Linux only
+  appId: "com.example.myapp",
+  linux: {target: ["AppImage", "deb"]},
+}`
+	assert.Equal(t, map[string][]string{"linux": {"AppImage", "deb"}}, parser.ParseElectronBuilder([]byte(json5Config)))
+
+	assert.Nil(t, parser.ParseElectronBuilder([]byte(`{"appId": "com.example.myapp"}`)))
+}
+
+func TestParseForgeMakers(t *testing.T) {
+	config := `module.exports = {
+  packagerConfig: { asar: true },
+  makers: [
+    { name: '@electron-forge/maker-squirrel', config: {} },
+    { name: '@electron-forge/maker-zip', platforms: ['darwin'] },
+    { name: '@electron-forge/maker-deb', config: {} },
+    { name: '@electron-forge/maker-rpm', config: {} },
+  ],
+  plugins: [{ name: '@electron-forge/plugin-auto-unpack-natives', config: {} }],
+};`
+	assert.Equal(t, map[string][]string{
+		"windows": {"squirrel"},
+		"macos":   {"zip"},
+		"linux":   {"deb", "rpm"},
+	}, NewDesktopParser().ParseForgeMakers(config))
+	assert.Nil(t, NewDesktopParser().ParseForgeMakers(`module.exports = {}`))
+}
+
+func TestParseTauriConfig_V2(t *testing.T) {
+	config := `{
+  "$schema": "https://schema.tauri.app/config/2",
+  "productName": "myapp",
+  "version": "0.1.0",
+  "identifier": "com.example.myapp",
+  "build": {"frontendDist": "../dist", "devUrl": "http://localhost:1420"},
+  "bundle": {"active": true, "targets": ["deb", "appimage", "msi", "dmg"]}
+}`
+	app := NewDesktopParser().ParseTauriConfig([]byte(config))
+	require.NotNil(t, app)
+	assert.Equal(t, DesktopFrameworkTauri, app.Framework)
+	assert.Equal(t, "myapp", app.ProductName)
+	assert.Equal(t, "0.1.0", app.Version)
+	assert.Equal(t, "com.example.myapp", app.Identifier)
+	assert.Equal(t, "../dist", app.FrontendDist)
+	assert.Equal(t, "http://localhost:1420", app.DevURL)
+	assert.Equal(t, map[string][]string{
+		"linux":   {"appimage", "deb"},
+		"windows": {"msi"},
+		"macos":   {"dmg"},
+	}, app.Targets)
+}
+
+func TestParseTauriConfig_V1(t *testing.T) {
+	config := `{
+  "package": {"productName": "myapp", "version": "1.2.0"},
+  "build": {"distDir": "../build", "devPath": "http://localhost:3000"},
+  "tauri": {"bundle": {"identifier": "com.example.myapp", "targets": "all"}}
+}`
+	app := NewDesktopParser().ParseTauriConfig([]byte(config))
+	require.NotNil(t, app)
+	assert.Equal(t, "myapp", app.ProductName)
+	assert.Equal(t, "1.2.0", app.Version)
+	assert.Equal(t, "com.example.myapp", app.Identifier)
+	assert.Equal(t, "../build", app.FrontendDist)
+	assert.Equal(t, map[string][]string{"all": {"all"}}, app.Targets)
+
+	assert.Nil(t, NewDesktopParser().ParseTauriConfig([]byte(`{"name": "not-tauri"}`)))
+}
+
+func TestParseTauriToml(t *testing.T) {
+	config := `productName = "myapp"
+identifier = "com.example.myapp"
+
+[build]
+frontendDist = "../dist"
+
+[bundle]
+targets = ["nsis"]
+`
+	app := NewDesktopParser().ParseTauriToml(config)
+	require.NotNil(t, app)
+	assert.Equal(t, "myapp", app.ProductName)
+	assert.Equal(t, map[string][]string{"windows": {"nsis"}}, app.Targets)
+}
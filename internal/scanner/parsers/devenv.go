@@ -0,0 +1,383 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Development environment kinds reported in DevEnvironment.Kind.
+const (
+	DevEnvKindDevcontainer  = "devcontainer"
+	DevEnvKindAsdf          = "asdf"
+	DevEnvKindMise          = "mise"
+	DevEnvKindSdkman        = "sdkman"
+	DevEnvKindVersionFile   = "version_file"
+	DevEnvKindRustToolchain = "rust_toolchain"
+	DevEnvKindVSCode        = "vscode"
+)
+
+// versionFileTools maps single-tool version files to the tool they pin.
+var versionFileTools = map[string]string{
+	".nvmrc":             "node",
+	".node-version":      "node",
+	".python-version":    "python",
+	".java-version":      "java",
+	".ruby-version":      "ruby",
+	".go-version":        "go",
+	".php-version":       "php",
+	".terraform-version": "terraform",
+	"rust-toolchain":     "rust",
+}
+
+// toolAliases normalizes tool names that differ between version managers.
+var toolAliases = map[string]string{
+	"nodejs": "node",
+	"golang": "go",
+}
+
+// DevEnvironment describes one development environment definition: a
+// devcontainer, a version manager file, or editor recommendations.
+type DevEnvironment struct {
+	File         string        `json:"file"`
+	Kind         string        `json:"kind"`
+	Tools        []ToolVersion `json:"tools,omitempty"`
+	Image        string        `json:"image,omitempty"`
+	Dockerfile   string        `json:"dockerfile,omitempty"`
+	ComposeFiles []string      `json:"compose_files,omitempty"`
+	Features     []string      `json:"features,omitempty"`
+	Extensions   []string      `json:"extensions,omitempty"`
+}
+
+// ToolVersion is a toolchain pinned by a development environment file.
+type ToolVersion struct {
+	Tool    string `json:"tool"`
+	Version string `json:"version"`
+}
+
+// DevEnvParser handles development environment files: devcontainer.json,
+// .tool-versions (asdf), mise.toml, .sdkmanrc, rust-toolchain(.toml),
+// single-tool version files (.nvmrc, .python-version, ...) and
+// .vscode/extensions.json.
+type DevEnvParser struct{}
+
+// NewDevEnvParser creates a new development environment parser
+func NewDevEnvParser() *DevEnvParser {
+	return &DevEnvParser{}
+}
+
+// IsVersionFile reports whether fileName is a single-tool version file.
+func IsVersionFile(fileName string) bool {
+	_, ok := versionFileTools[fileName]
+	return ok
+}
+
+// devcontainerConfig is the subset of devcontainer.json that is reported.
+type devcontainerConfig struct {
+	Image string `json:"image"`
+	Build struct {
+		Dockerfile string `json:"dockerfile"`
+	} `json:"build"`
+	DockerComposeFile interface{}                       `json:"dockerComposeFile"`
+	Features          map[string]map[string]interface{} `json:"features"`
+	Customizations    struct {
+		VSCode struct {
+			Extensions []string `json:"extensions"`
+		} `json:"vscode"`
+	} `json:"customizations"`
+}
+
+// ParseDevcontainer parses a devcontainer.json (JSON with comments). Features
+// are listed by reference; a feature with a "version" option also pins that
+// tool.
+func (p *DevEnvParser) ParseDevcontainer(content string) (*DevEnvironment, error) {
+	var cfg devcontainerConfig
+	if err := json.Unmarshal([]byte(StripJSONComments(content)), &cfg); err != nil {
+		return nil, types.NewParseError("devcontainer.json", err)
+	}
+
+	env := &DevEnvironment{
+		Kind:         DevEnvKindDevcontainer,
+		Image:        cfg.Image,
+		Dockerfile:   cfg.Build.Dockerfile,
+		ComposeFiles: stringOrList(cfg.DockerComposeFile),
+		Extensions:   cfg.Customizations.VSCode.Extensions,
+	}
+	for ref := range cfg.Features {
+		env.Features = append(env.Features, ref)
+	}
+	sort.Strings(env.Features)
+	for _, ref := range env.Features {
+		if tool, ok := featureTool(ref, cfg.Features[ref]); ok {
+			env.Tools = append(env.Tools, tool)
+		}
+	}
+	return env, nil
+}
+
+// featureTool derives the pinned tool from a feature reference such as
+// "ghcr.io/devcontainers/features/node:1" with options {"version": "20"}.
+// The reference tag versions the feature itself, not the tool.
+func featureTool(ref string, options map[string]interface{}) (ToolVersion, bool) {
+	name, _, _ := strings.Cut(path.Base(ref), ":")
+	version, _ := options["version"].(string)
+	if version == "" || version == "latest" || version == "none" {
+		return ToolVersion{}, false
+	}
+	return ToolVersion{Tool: normalizeTool(name), Version: version}, true
+}
+
+// DevcontainerDependency returns the devcontainer base image as a docker
+// dependency (scope dev), or false when the container is built instead.
+func (p *DevEnvParser) DevcontainerDependency(env *DevEnvironment) (types.Dependency, bool) {
+	if env == nil || env.Image == "" {
+		return types.Dependency{}, false
+	}
+	parts := strings.Split(env.Image, ":")
+	version := "latest"
+	if len(parts) > 1 {
+		version = parts[1]
+	}
+	return types.Dependency{
+		Type:     DependencyTypeDocker,
+		Name:     parts[0],
+		Version:  version,
+		Scope:    types.ScopeDev,
+		Direct:   true,
+		Metadata: types.NewMetadata(MetadataSourceDevcontainer),
+	}, true
+}
+
+// ParseToolVersions parses an asdf .tool-versions file. When several versions
+// are listed for a tool, the first (the default) is reported.
+func (p *DevEnvParser) ParseToolVersions(content string) []ToolVersion {
+	var tools []ToolVersion
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		tools = append(tools, ToolVersion{Tool: normalizeTool(fields[0]), Version: fields[1]})
+	}
+	return tools
+}
+
+// ParseMiseToml parses the [tools] table of mise.toml. Values may be a
+// version string, a list (first entry is the default) or a table with a
+// "version" key.
+func (p *DevEnvParser) ParseMiseToml(content string) ([]ToolVersion, error) {
+	var cfg struct {
+		Tools map[string]interface{} `toml:"tools"`
+	}
+	if _, err := toml.Decode(content, &cfg); err != nil {
+		return nil, types.NewParseError("mise.toml", err)
+	}
+
+	names := make([]string, 0, len(cfg.Tools))
+	for name := range cfg.Tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tools []ToolVersion
+	for _, name := range names {
+		if version := miseVersion(cfg.Tools[name]); version != "" {
+			tools = append(tools, ToolVersion{Tool: normalizeTool(name), Version: version})
+		}
+	}
+	return tools, nil
+}
+
+func miseVersion(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			return miseVersion(v[0])
+		}
+	case map[string]interface{}:
+		return miseVersion(v["version"])
+	}
+	return ""
+}
+
+// ParseSdkmanrc parses an SDKMAN .sdkmanrc (candidate=version lines).
+func (p *DevEnvParser) ParseSdkmanrc(content string) []ToolVersion {
+	var tools []ToolVersion
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if tool, version, ok := strings.Cut(line, "="); ok {
+			tools = append(tools, ToolVersion{Tool: strings.TrimSpace(tool), Version: strings.TrimSpace(version)})
+		}
+	}
+	return tools
+}
+
+// ParseRustToolchain parses rust-toolchain.toml ([toolchain] channel).
+func (p *DevEnvParser) ParseRustToolchain(content string) (ToolVersion, bool) {
+	var cfg struct {
+		Toolchain struct {
+			Channel string `toml:"channel"`
+		} `toml:"toolchain"`
+	}
+	if _, err := toml.Decode(content, &cfg); err != nil || cfg.Toolchain.Channel == "" {
+		return ToolVersion{}, false
+	}
+	return ToolVersion{Tool: "rust", Version: cfg.Toolchain.Channel}, true
+}
+
+// ParseVersionFile parses a single-tool version file (.nvmrc,
+// .python-version, legacy rust-toolchain, ...): the first non-comment line
+// is the version.
+func (p *DevEnvParser) ParseVersionFile(fileName, content string) (ToolVersion, bool) {
+	tool, ok := versionFileTools[fileName]
+	if !ok {
+		return ToolVersion{}, false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return ToolVersion{Tool: tool, Version: line}, true
+		}
+	}
+	return ToolVersion{}, false
+}
+
+// ParseVSCodeExtensions parses .vscode/extensions.json recommendations.
+func (p *DevEnvParser) ParseVSCodeExtensions(content string) ([]string, error) {
+	var cfg struct {
+		Recommendations []string `json:"recommendations"`
+	}
+	if err := json.Unmarshal([]byte(StripJSONComments(content)), &cfg); err != nil {
+		return nil, types.NewParseError("extensions.json", err)
+	}
+	return cfg.Recommendations, nil
+}
+
+func normalizeTool(name string) string {
+	if alias, ok := toolAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// stringOrList reads a JSON value that is either a string or a list of
+// strings.
+func stringOrList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var out []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// StripJSONComments converts JSON with comments (JSONC, as used by
+// devcontainer.json, tsconfig.json and VS Code settings) to plain JSON:
+// line and block comments and trailing commas are removed; string contents
+// are left untouched.
+func StripJSONComments(content string) string {
+	s := &jsoncStripper{src: content}
+	for s.pos < len(s.src) {
+		s.step()
+	}
+	return dropTrailingCommas(s.out.String())
+}
+
+// jsoncStripper removes comments while tracking string state.
+type jsoncStripper struct {
+	src      string
+	pos      int
+	out      strings.Builder
+	inString bool
+}
+
+func (s *jsoncStripper) step() {
+	c := s.src[s.pos]
+	switch {
+	case s.inString:
+		s.stringByte(c)
+	case c == '"':
+		s.inString = true
+		s.emit(1)
+	case strings.HasPrefix(s.src[s.pos:], "//"):
+		s.skipUntil("\n", false)
+	case strings.HasPrefix(s.src[s.pos:], "/*"):
+		s.skipUntil("*/", true)
+	default:
+		s.emit(1)
+	}
+}
+
+func (s *jsoncStripper) stringByte(c byte) {
+	switch c {
+	case '\\':
+		s.emit(2)
+	case '"':
+		s.inString = false
+		s.emit(1)
+	default:
+		s.emit(1)
+	}
+}
+
+// emit copies n bytes (bounded by the input) to the output.
+func (s *jsoncStripper) emit(n int) {
+	end := min(s.pos+n, len(s.src))
+	s.out.WriteString(s.src[s.pos:end])
+	s.pos = end
+}
+
+// skipUntil drops input up to the terminator; a block comment's terminator is
+// dropped too, a line comment's newline is kept.
+func (s *jsoncStripper) skipUntil(term string, consume bool) {
+	i := strings.Index(s.src[s.pos+2:], term)
+	if i < 0 {
+		s.pos = len(s.src)
+		return
+	}
+	s.pos += 2 + i
+	if consume {
+		s.pos += len(term)
+	}
+}
+
+// dropTrailingCommas removes commas directly followed (ignoring whitespace)
+// by a closing bracket or brace, outside strings.
+func dropTrailingCommas(src string) string {
+	var out strings.Builder
+	inString, escaped := false, false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if inString {
+			inString, escaped = c != '"' || escaped, c == '\\' && !escaped
+		} else if c == '"' {
+			inString = true
+		} else if c == ',' && closesNext(src[i+1:]) {
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+func closesNext(rest string) bool {
+	rest = strings.TrimLeft(rest, " \t\r\n")
+	return strings.HasPrefix(rest, "}") || strings.HasPrefix(rest, "]")
+}
@@ -0,0 +1,122 @@
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDevcontainer(t *testing.T) {
+	content := `// For format details, see https://containers.dev/implementors/json_reference/
+{
+	"name": "myapp",
+	"image": "mcr.microsoft.com/devcontainers/go:1.22-bookworm",
+	/* pinned toolchains */
+	"features": {
+		"ghcr.io/devcontainers/features/node:1": { "version": "20" },
+		"ghcr.io/devcontainers/features/docker-in-docker:2": {},
+	},
+	"customizations": {
+		"vscode": {
+			"extensions": ["golang.go", "dbaeumer.vscode-eslint"],
+		},
+	},
+	"postCreateCommand": "echo // not a comment",
+}
+`
+	parser := NewDevEnvParser()
+	env, err := parser.ParseDevcontainer(content)
+	require.NoError(t, err)
+	assert.Equal(t, DevEnvKindDevcontainer, env.Kind)
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/go:1.22-bookworm", env.Image)
+	assert.Equal(t, []string{
+		"ghcr.io/devcontainers/features/docker-in-docker:2",
+		"ghcr.io/devcontainers/features/node:1",
+	}, env.Features)
+	assert.Equal(t, []ToolVersion{{Tool: "node", Version: "20"}}, env.Tools)
+	assert.Equal(t, []string{"golang.go", "dbaeumer.vscode-eslint"}, env.Extensions)
+
+	dep, ok := parser.DevcontainerDependency(env)
+	require.True(t, ok)
+	assert.Equal(t, DependencyTypeDocker, dep.Type)
+	assert.Equal(t, "mcr.microsoft.com/devcontainers/go", dep.Name)
+	assert.Equal(t, "1.22-bookworm", dep.Version)
+	assert.Equal(t, types.ScopeDev, dep.Scope)
+}
+
+func TestParseDevcontainer_ComposeBased(t *testing.T) {
+	env, err := NewDevEnvParser().ParseDevcontainer(`{"dockerComposeFile": ["../docker-compose.yml", "docker-compose.dev.yml"], "service": "app"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"../docker-compose.yml", "docker-compose.dev.yml"}, env.ComposeFiles)
+	_, ok := NewDevEnvParser().DevcontainerDependency(env)
+	assert.False(t, ok)
+
+	_, err = NewDevEnvParser().ParseDevcontainer("{ not json")
+	assert.Error(t, err)
+}
+
+func TestStripJSONComments(t *testing.T) {
+	in := `{"url": "https://example.com/a,b", /* c */ "s": "quote \" // kept", "list": [1, 2,], // end
+}`
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(StripJSONComments(in)), &out))
+	assert.Equal(t, "https://example.com/a,b", out["url"])
+	assert.Equal(t, `quote " // kept`, out["s"])
+	assert.Len(t, out["list"], 2)
+}
+
+func TestParseToolVersions(t *testing.T) {
+	tools := NewDevEnvParser().ParseToolVersions("nodejs 20.11.0 18.19.0\n# comment\ngolang 1.22.1 # pinned\npython 3.12.2\n\n")
+	assert.Equal(t, []ToolVersion{
+		{Tool: "node", Version: "20.11.0"},
+		{Tool: "go", Version: "1.22.1"},
+		{Tool: "python", Version: "3.12.2"},
+	}, tools)
+}
+
+func TestParseMiseToml(t *testing.T) {
+	content := `[env]
+NODE_ENV = "development"
+
+[tools]
+node = "20"
+python = ["3.12", "3.11"]
+go = { version = "1.22" }
+`
+	tools, err := NewDevEnvParser().ParseMiseToml(content)
+	require.NoError(t, err)
+	assert.Equal(t, []ToolVersion{
+		{Tool: "go", Version: "1.22"},
+		{Tool: "node", Version: "20"},
+		{Tool: "python", Version: "3.12"},
+	}, tools)
+}
+
+func TestParseSdkmanrcAndRustToolchain(t *testing.T) {
+	parser := NewDevEnvParser()
+	assert.Equal(t, []ToolVersion{{Tool: "java", Version: "21.0.2-tem"}, {Tool: "gradle", Version: "8.6"}},
+		parser.ParseSdkmanrc("# Enable auto-env\njava=21.0.2-tem\ngradle=8.6\n"))
+
+	tool, ok := parser.ParseRustToolchain("[toolchain]\nchannel = \"1.76.0\"\ncomponents = [\"clippy\"]\n")
+	require.True(t, ok)
+	assert.Equal(t, ToolVersion{Tool: "rust", Version: "1.76.0"}, tool)
+}
+
+func TestParseVersionFile(t *testing.T) {
+	parser := NewDevEnvParser()
+	tool, ok := parser.ParseVersionFile(".nvmrc", "lts/iron\n")
+	require.True(t, ok)
+	assert.Equal(t, ToolVersion{Tool: "node", Version: "lts/iron"}, tool)
+
+	tool, ok = parser.ParseVersionFile(".python-version", "3.12.2\n3.11.8\n")
+	require.True(t, ok)
+	assert.Equal(t, "3.12.2", tool.Version)
+
+	_, ok = parser.ParseVersionFile(".python-version", "\n")
+	assert.False(t, ok)
+	_, ok = parser.ParseVersionFile("VERSION", "1.0.0")
+	assert.False(t, ok)
+}
@@ -2,9 +2,11 @@ package parsers
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
 )
 
 // DockerComposeParser handles docker-compose.yml/yaml parsing
@@ -193,3 +195,65 @@ func (p *DockerComposeParser) parseImage(image string) (string, string) {
 	}
 	return name, version
 }
+
+// ComposeEnvFile is a file referenced by the env_file of Compose services.
+type ComposeEnvFile struct {
+	Path     string   // as written, relative to the Compose file
+	Services []string // services loading it, sorted
+}
+
+// ParseEnvFiles returns the env_file references of Compose services, in
+// short ("env_file: .env", a list of paths) or long ({path, required})
+// syntax, sorted by path.
+func (p *DockerComposeParser) ParseEnvFiles(content []byte) []ComposeEnvFile {
+	var doc struct {
+		Services map[string]struct {
+			EnvFile yaml.Node `yaml:"env_file"`
+		} `yaml:"services"`
+	}
+	if yaml.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+	services := make(map[string][]string)
+	for name, service := range doc.Services {
+		for _, path := range composeEnvFilePaths(&service.EnvFile) {
+			services[path] = append(services[path], name)
+		}
+	}
+	files := make([]ComposeEnvFile, 0, len(services))
+	for path, names := range services {
+		sort.Strings(names)
+		files = append(files, ComposeEnvFile{Path: path, Services: names})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+func composeEnvFilePaths(node *yaml.Node) []string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return nonEmptyPath(node.Value)
+	case yaml.SequenceNode:
+		var paths []string
+		for _, entry := range node.Content {
+			if entry.Kind == yaml.MappingNode {
+				var long struct {
+					Path string `yaml:"path"`
+				}
+				_ = entry.Decode(&long)
+				paths = append(paths, nonEmptyPath(long.Path)...)
+			} else {
+				paths = append(paths, nonEmptyPath(entry.Value)...)
+			}
+		}
+		return paths
+	}
+	return nil
+}
+
+func nonEmptyPath(s string) []string {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil
+	}
+	return []string{s}
+}
@@ -454,3 +454,34 @@ func TestDockerComposeParser_ErrorHandling(t *testing.T) {
 	assert.Equal(t, "web", services[0].Name)
 	assert.Equal(t, "db", services[1].Name)
 }
+
+func TestDockerComposeParser_ParseEnvFiles(t *testing.T) {
+	parser := NewDockerComposeParser()
+
+	content := `services:
+  web:
+    image: nginx:latest
+    env_file: .env
+  api:
+    build: ./api
+    env_file:
+      - .env
+      - ./config/api.env
+  worker:
+    build: ./worker
+    env_file:
+      - path: ./config/worker.env
+        required: false
+  db:
+    image: postgres:16
+`
+
+	files := parser.ParseEnvFiles([]byte(content))
+	assert.Equal(t, []ComposeEnvFile{
+		{Path: "./config/api.env", Services: []string{"api"}},
+		{Path: "./config/worker.env", Services: []string{"worker"}},
+		{Path: ".env", Services: []string{"api", "web"}},
+	}, files)
+
+	assert.Empty(t, parser.ParseEnvFiles([]byte("services: [")), "Should ignore invalid YAML")
+}
@@ -0,0 +1,194 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	sphinxMarkerRegex     = regexp.MustCompile(`(?m)\bsphinx\b|^\s*html_theme\s*=`)
+	sphinxThemeRegex      = regexp.MustCompile(`(?m)^\s*html_theme\s*=\s*['"]([^'"]+)['"]`)
+	sphinxProjectRegex    = regexp.MustCompile(`(?m)^\s*project\s*=\s*u?['"]([^'"]+)['"]`)
+	sphinxExtListRegex    = regexp.MustCompile(`(?ms)^\s*extensions\s*(?:\+?=\s*\[|\.extend\(\s*\[)(.*?)\]`)
+	sphinxExtAppendRegex  = regexp.MustCompile(`(?m)^\s*extensions\.append\(\s*['"]([\w.-]+)['"]`)
+	pythonStringRegex     = regexp.MustCompile(`['"]([\w.-]+)['"]`)
+	pythonCommentRegex    = regexp.MustCompile(`#[^\n]*`)
+	docusaurusPkgRegex    = regexp.MustCompile(`['"]((?:@docusaurus/(?:preset|theme|plugin)-[\w-]+)|(?:@[\w-]+/)?docusaurus-(?:preset|theme|plugin)-[\w-]+)['"]`)
+	docusaurusClassic     = regexp.MustCompile(`presets\s*:\s*\[\s*\[\s*['"]classic['"]`)
+	docusaurusTitleRegex  = regexp.MustCompile(`(?m)^\s*title\s*:\s*['"]([^'"]+)['"]`)
+	latexClassRegex       = regexp.MustCompile(`(?m)^[^%\n]*\\documentclass\s*(?:\[[^\]]*\])?\s*\{([^}]+)\}`)
+	latexMagicEngineRegex = regexp.MustCompile(`(?im)^%\s*!\s*TeX\s+(?:TS-)?program\s*=\s*(\w+)`)
+	latexmkPdfModeRegex   = regexp.MustCompile(`(?m)^\s*\$pdf_mode\s*=\s*(\d)`)
+)
+
+// latexmkEngines maps latexmk $pdf_mode values to the engine they select.
+var latexmkEngines = map[string]string{"1": "pdflatex", "4": "lualatex", "5": "xelatex"}
+
+// DocsToolchain is a documentation project: the tool that builds it, its
+// configuration file and the theme and extensions it loads.
+type DocsToolchain struct {
+	Tool          string   `json:"tool"` // mkdocs, sphinx, docusaurus, antora, asciidoctor, latex
+	File          string   `json:"file"`
+	Name          string   `json:"name,omitempty"` // site, project or component name
+	Theme         string   `json:"theme,omitempty"`
+	Plugins       []string `json:"plugins,omitempty"`        // MkDocs plugins, Sphinx extensions, Docusaurus presets/themes/plugins
+	DocumentClass string   `json:"document_class,omitempty"` // LaTeX
+	Engine        string   `json:"engine,omitempty"`         // LaTeX: pdflatex, xelatex, lualatex
+	Bibliography  string   `json:"bibliography,omitempty"`   // LaTeX: bibtex, biblatex
+}
+
+// DocsParser parses the configuration of documentation toolchains.
+type DocsParser struct{}
+
+// NewDocsParser creates a new documentation toolchain parser.
+func NewDocsParser() *DocsParser {
+	return &DocsParser{}
+}
+
+// ParseMkDocs parses an mkdocs.yml. The theme and plugins are returned as
+// dependencies of type "mkdocs", named as in the configuration. Python tags
+// (!!python/name:...) are left undecoded.
+func (p *DocsParser) ParseMkDocs(content []byte) (*DocsToolchain, []types.Dependency) {
+	var config struct {
+		SiteName string    `yaml:"site_name"`
+		Theme    yaml.Node `yaml:"theme"`
+		Plugins  yaml.Node `yaml:"plugins"`
+	}
+	if yaml.Unmarshal(content, &config) != nil {
+		return nil, nil
+	}
+	docs := &DocsToolchain{Tool: "mkdocs", Name: config.SiteName, Theme: mkdocsTheme(&config.Theme)}
+	for _, plugin := range config.Plugins.Content {
+		if name := mkdocsPluginName(plugin); name != "" {
+			docs.Plugins = append(docs.Plugins, name)
+		}
+	}
+
+	var deps []types.Dependency
+	if docs.Theme != "" {
+		deps = append(deps, mkdocsDependency(docs.Theme))
+	}
+	for _, plugin := range docs.Plugins {
+		deps = append(deps, mkdocsDependency(plugin))
+	}
+	return docs, deps
+}
+
+// mkdocsTheme reads "theme: name" or "theme: {name: ...}".
+func mkdocsTheme(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "name" {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// mkdocsPluginName reads a plugins entry: "- search" or "- search: {...}".
+func mkdocsPluginName(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+		return node.Content[0].Value
+	}
+	return ""
+}
+
+func mkdocsDependency(name string) types.Dependency {
+	return types.Dependency{
+		Type:     DependencyTypeMkDocs,
+		Name:     name,
+		Scope:    types.ScopeBuild,
+		Direct:   true,
+		Metadata: types.NewMetadata("mkdocs.yml"),
+	}
+}
+
+// ParseSphinxConf parses a Sphinx conf.py. Returns nil for other conf.py
+// files (Gunicorn, Celery, ...).
+func (p *DocsParser) ParseSphinxConf(content string) *DocsToolchain {
+	if !sphinxMarkerRegex.MatchString(content) {
+		return nil
+	}
+	docs := &DocsToolchain{
+		Tool:  "sphinx",
+		Name:  firstSubmatch(sphinxProjectRegex, content),
+		Theme: firstSubmatch(sphinxThemeRegex, content),
+	}
+	code := pythonCommentRegex.ReplaceAllString(content, "")
+	var extensions []string
+	for _, list := range sphinxExtListRegex.FindAllStringSubmatch(code, -1) {
+		for _, m := range pythonStringRegex.FindAllStringSubmatch(list[1], -1) {
+			extensions = append(extensions, m[1])
+		}
+	}
+	for _, m := range sphinxExtAppendRegex.FindAllStringSubmatch(code, -1) {
+		extensions = append(extensions, m[1])
+	}
+	docs.Plugins = sortedUnique(extensions)
+	return docs
+}
+
+// ParseDocusaurusConfig parses a docusaurus.config.{js,ts,mjs}, reporting
+// the site title and the presets, themes and plugins it loads.
+func (p *DocsParser) ParseDocusaurusConfig(content string) *DocsToolchain {
+	docs := &DocsToolchain{Tool: "docusaurus", Name: firstSubmatch(docusaurusTitleRegex, content)}
+	var packages []string
+	for _, m := range docusaurusPkgRegex.FindAllStringSubmatch(content, -1) {
+		packages = append(packages, m[1])
+	}
+	if docusaurusClassic.MatchString(content) {
+		packages = append(packages, "@docusaurus/preset-classic")
+	}
+	docs.Plugins = sortedUnique(packages)
+	return docs
+}
+
+// ParseAntora parses an Antora playbook (antora-playbook.yml, named after
+// site.title) or component descriptor (antora.yml, named after name).
+func (p *DocsParser) ParseAntora(content []byte) *DocsToolchain {
+	var config struct {
+		Name string `yaml:"name"`
+		Site struct {
+			Title string `yaml:"title"`
+		} `yaml:"site"`
+	}
+	if yaml.Unmarshal(content, &config) != nil {
+		return nil
+	}
+	return &DocsToolchain{Tool: "antora", Name: firstNonEmpty(config.Site.Title, config.Name)}
+}
+
+// ParseLaTeX parses a LaTeX source file. Returns nil unless the file is a
+// main document (has a \documentclass). The engine comes from a
+// "% !TEX program" magic comment.
+func (p *DocsParser) ParseLaTeX(content string) *DocsToolchain {
+	class := firstSubmatch(latexClassRegex, content)
+	if class == "" {
+		return nil
+	}
+	docs := &DocsToolchain{
+		Tool:          "latex",
+		DocumentClass: strings.TrimSpace(class),
+		Engine:        strings.ToLower(firstSubmatch(latexMagicEngineRegex, content)),
+	}
+	switch {
+	case strings.Contains(content, `\addbibresource`):
+		docs.Bibliography = "biblatex"
+	case strings.Contains(content, `\bibliography{`):
+		docs.Bibliography = "bibtex"
+	}
+	return docs
+}
+
+// LatexmkEngine returns the engine a latexmkrc selects with $pdf_mode.
+func (p *DocsParser) LatexmkEngine(content string) string {
+	return latexmkEngines[firstSubmatch(latexmkPdfModeRegex, content)]
+}
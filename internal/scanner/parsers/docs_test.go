@@ -0,0 +1,113 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsParser_ParseMkDocs(t *testing.T) {
+	content := `site_name: My App
+theme:
+  name: material
+  features: [navigation.tabs]
+plugins:
+  - search
+  - mkdocstrings:
+      handlers:
+        python: {}
+markdown_extensions:
+  - pymdownx.emoji:
+      emoji_index: !!python/name:material.extensions.emoji.twemoji
+`
+	docs, deps := NewDocsParser().ParseMkDocs([]byte(content))
+	require.NotNil(t, docs)
+	assert.Equal(t, "mkdocs", docs.Tool)
+	assert.Equal(t, "My App", docs.Name)
+	assert.Equal(t, "material", docs.Theme)
+	assert.Equal(t, []string{"search", "mkdocstrings"}, docs.Plugins)
+
+	require.Len(t, deps, 3)
+	assert.Equal(t, DependencyTypeMkDocs, deps[0].Type)
+	assert.Equal(t, "material", deps[0].Name)
+	assert.Equal(t, types.ScopeBuild, deps[0].Scope)
+	assert.Equal(t, "mkdocstrings", deps[2].Name)
+}
+
+func TestDocsParser_ParseMkDocs_ThemeShorthand(t *testing.T) {
+	docs, deps := NewDocsParser().ParseMkDocs([]byte("site_name: Docs\ntheme: readthedocs\n"))
+	require.NotNil(t, docs)
+	assert.Equal(t, "readthedocs", docs.Theme)
+	assert.Empty(t, docs.Plugins)
+	require.Len(t, deps, 1)
+}
+
+func TestDocsParser_ParseSphinxConf(t *testing.T) {
+	content := `import os
+project = 'myapp'
+extensions = [
+    'sphinx.ext.autodoc',  # API docs
+    "myst_parser",
+    # 'sphinx.ext.todo',
+]
+extensions.append("sphinx_copybutton")
+html_theme = 'furo'
+`
+	docs := NewDocsParser().ParseSphinxConf(content)
+	require.NotNil(t, docs)
+	assert.Equal(t, "sphinx", docs.Tool)
+	assert.Equal(t, "myapp", docs.Name)
+	assert.Equal(t, "furo", docs.Theme)
+	assert.Equal(t, []string{"myst_parser", "sphinx.ext.autodoc", "sphinx_copybutton"}, docs.Plugins)
+}
+
+func TestDocsParser_ParseSphinxConf_OtherConfPy(t *testing.T) {
+	assert.Nil(t, NewDocsParser().ParseSphinxConf("bind = '0.0.0.0:8000'\nworkers = 4\n"))
+}
+
+func TestDocsParser_ParseDocusaurusConfig(t *testing.T) {
+	content := `const config = {
+  title: 'My Site',
+  presets: [['classic', {docs: {}}]],
+  themes: ['@docusaurus/theme-mermaid'],
+  plugins: ['@docusaurus/plugin-ideal-image', 'docusaurus-plugin-sass'],
+};`
+	docs := NewDocsParser().ParseDocusaurusConfig(content)
+	assert.Equal(t, "My Site", docs.Name)
+	assert.Equal(t, []string{
+		"@docusaurus/plugin-ideal-image",
+		"@docusaurus/preset-classic",
+		"@docusaurus/theme-mermaid",
+		"docusaurus-plugin-sass",
+	}, docs.Plugins)
+}
+
+func TestDocsParser_ParseAntora(t *testing.T) {
+	parser := NewDocsParser()
+	assert.Equal(t, "Guide", parser.ParseAntora([]byte("site:\n  title: Guide\n")).Name)
+	assert.Equal(t, "api", parser.ParseAntora([]byte("name: api\nversion: '1.0'\n")).Name)
+}
+
+func TestDocsParser_ParseLaTeX(t *testing.T) {
+	parser := NewDocsParser()
+	docs := parser.ParseLaTeX("% !TEX program = XeLaTeX\n\\documentclass[11pt,a4paper]{article}\n\\addbibresource{refs.bib}\n")
+	require.NotNil(t, docs)
+	assert.Equal(t, "article", docs.DocumentClass)
+	assert.Equal(t, "xelatex", docs.Engine)
+	assert.Equal(t, "biblatex", docs.Bibliography)
+
+	docs = parser.ParseLaTeX("\\documentclass{book}\n\\bibliography{refs}\n")
+	require.NotNil(t, docs)
+	assert.Equal(t, "bibtex", docs.Bibliography)
+	assert.Empty(t, docs.Engine)
+
+	assert.Nil(t, parser.ParseLaTeX("\\section{Intro}\n% \\documentclass{article}\n"))
+}
+
+func TestDocsParser_LatexmkEngine(t *testing.T) {
+	parser := NewDocsParser()
+	assert.Equal(t, "lualatex", parser.LatexmkEngine("$pdf_mode = 4;\n"))
+	assert.Equal(t, "", parser.LatexmkEngine("$out_dir = 'build';\n"))
+}
@@ -2,43 +2,195 @@ package parsers
 
 import (
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
 
-// DotenvDetector handles .env.example file detection
+// DotenvDetector handles dotenv file detection: .env, its templates
+// (.env.example), environment-specific files (.env.production) with their
+// .local overlays, and the env files Compose services load via env_file.
 type DotenvDetector struct {
 	provider types.Provider
 	rules    []types.Rule
+	compose  *DockerComposeParser
 }
 
+// EnvFileInfo lists the variables of a dotenv file that matched a rule and
+// the environment the file configures. Only variable names are kept, never
+// their values.
+type EnvFileInfo struct {
+	File        string        `json:"file"`
+	Environment string        `json:"environment"`
+	Local       bool          `json:"local,omitempty"`    // a .local overlay, not meant to be committed
+	Services    []string      `json:"services,omitempty"` // Compose services loading it via env_file
+	Variables   []EnvVariable `json:"variables"`
+}
+
+// EnvVariable is a dotenv variable and the tech it was matched to.
+type EnvVariable struct {
+	Name string `json:"name"`
+	Tech string `json:"tech"`
+}
+
+// Environments of dotenv files that are not specific to one.
+const (
+	EnvironmentDefault = "default" // .env, and env files named after nothing else
+	EnvironmentExample = "example" // templates such as .env.example
+)
+
+// dotenvTemplates are the .env.<suffix> names of templates; their matches keep
+// the plain "matched env" reason.
+var dotenvTemplates = map[string]bool{"example": true, "sample": true, "template": true, "dist": true}
+
+// dotenvIgnored are .env.<suffix> names of files that do not hold variables
+// for an environment: backups, editor files and encrypted vaults.
+var dotenvIgnored = map[string]bool{"bak": true, "backup": true, "old": true, "orig": true, "swp": true, "tmp": true, "vault": true}
+
+var composeFileRegex = regexp.MustCompile(`^(?:docker-)?compose(?:[.-][\w.-]+)?\.ya?ml$`)
+
 // NewDotenvDetector creates a new dotenv detector
 func NewDotenvDetector(provider types.Provider, rules []types.Rule) *DotenvDetector {
 	return &DotenvDetector{
 		provider: provider,
 		rules:    rules,
+		compose:  NewDockerComposeParser(),
 	}
 }
 
-// DetectInDotEnv detects technologies from .env.example files
-// Returns a virtual payload that gets merged into the parent
+// envFile is a dotenv file to scan.
+type envFile struct {
+	path        string
+	environment string
+	local       bool
+	services    []string
+}
+
+// DetectInDotEnv detects technologies from the dotenv files of a directory
+// and those its Compose files reference. Returns a virtual payload that gets
+// merged into the parent, with an "env_files" property attributing matched
+// variables to the environment of their file.
 func (d *DotenvDetector) DetectInDotEnv(files []types.File, currentPath string, basePath string) *types.Payload {
-	file := d.findDotenvFile(files)
-	if file == nil {
-		return nil
+	var payload *types.Payload
+	for _, file := range d.findEnvFiles(files, currentPath, basePath) {
+		content, err := d.provider.ReadFile(file.path)
+		if err != nil {
+			continue
+		}
+		relativeFilePath := d.getRelativeFilePath(basePath, filepath.Dir(file.path), filepath.Base(file.path))
+		if payload == nil {
+			payload = types.NewPayloadWithPath("virtual", relativeFilePath)
+		} else {
+			payload.AddPath(relativeFilePath)
+		}
+		info := &EnvFileInfo{File: relativeFilePath, Environment: file.environment, Local: file.local, Services: file.services}
+		d.scanEnvVariables(string(content), payload, info)
+		if len(info.Variables) > 0 {
+			existing, _ := payload.Properties["env_files"].([]interface{})
+			payload.Properties["env_files"] = append(existing, info)
+		}
 	}
+	return payload
+}
 
-	content, err := d.provider.ReadFile(filepath.Join(currentPath, file.Name))
+// findEnvFiles returns the dotenv files of a directory, the template first,
+// followed by the Compose env_file references found nowhere else.
+func (d *DotenvDetector) findEnvFiles(files []types.File, currentPath, basePath string) []*envFile {
+	var found []*envFile
+	if template := d.findDotenvFile(files); template != nil {
+		found = append(found, &envFile{path: filepath.Join(currentPath, template.Name), environment: EnvironmentExample})
+	}
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, ".env") || file.Name == ".env.example" {
+			continue
+		}
+		if environment, local, ok := DotenvEnvironment(file.Name); ok {
+			found = append(found, &envFile{path: filepath.Join(currentPath, file.Name), environment: environment, local: local})
+		}
+	}
+	for _, file := range files {
+		if composeFileRegex.MatchString(file.Name) {
+			found = d.addComposeEnvFiles(found, filepath.Join(currentPath, file.Name), basePath)
+		}
+	}
+	return found
+}
+
+// addComposeEnvFiles adds the env files referenced by a Compose file, or the
+// services loading them to the files already found. References leaving the
+// scanned tree are ignored.
+func (d *DotenvDetector) addComposeEnvFiles(found []*envFile, composePath, basePath string) []*envFile {
+	content, err := d.provider.ReadFile(composePath)
 	if err != nil {
-		return nil
+		return found
 	}
+	for _, ref := range d.compose.ParseEnvFiles(content) {
+		path := filepath.Join(filepath.Dir(composePath), filepath.FromSlash(ref.Path))
+		if rel, err := filepath.Rel(basePath, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if existing := findEnvFile(found, path); existing != nil {
+			existing.services = mergeServices(existing.services, ref.Services)
+			continue
+		}
+		environment, local, _ := DotenvEnvironment(filepath.Base(path))
+		found = append(found, &envFile{path: path, environment: environment, local: local, services: ref.Services})
+	}
+	return found
+}
 
-	relativeFilePath := d.getRelativeFilePath(basePath, currentPath, file.Name)
-	payload := types.NewPayloadWithPath("virtual", relativeFilePath)
+func findEnvFile(files []*envFile, path string) *envFile {
+	for _, file := range files {
+		if file.path == path {
+			return file
+		}
+	}
+	return nil
+}
 
-	d.scanEnvVariables(string(content), payload)
-	return payload
+func mergeServices(services, more []string) []string {
+	for _, service := range more {
+		if !slices.Contains(services, service) {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
+// DotenvEnvironment returns the environment of a dotenv file from its name:
+// .env is the default one, .env.production and production.env are
+// "production", and a .local suffix marks an overlay of the environment
+// before it (.env.local overlays the default one). ok is false for names that
+// are not dotenv files, which are still given the default environment.
+func DotenvEnvironment(name string) (environment string, local bool, ok bool) {
+	var suffix string
+	switch {
+	case name == ".env":
+		return EnvironmentDefault, false, true
+	case strings.HasPrefix(name, ".env."):
+		suffix = strings.TrimPrefix(name, ".env.")
+	case strings.HasSuffix(name, ".env") && len(name) > len(".env"):
+		return strings.TrimSuffix(name, ".env"), false, true
+	default:
+		return EnvironmentDefault, false, false
+	}
+	if dotenvTemplates[suffix] {
+		return EnvironmentExample, false, true
+	}
+	if dotenvIgnored[suffix] {
+		return EnvironmentDefault, false, false
+	}
+	if suffix == "local" {
+		return EnvironmentDefault, true, true
+	}
+	if environment, isLocal := strings.CutSuffix(suffix, ".local"); isLocal {
+		return environment, true, true
+	}
+	return suffix, false, true
 }
 
 func (d *DotenvDetector) findDotenvFile(files []types.File) *types.File {
@@ -59,14 +211,14 @@ func (d *DotenvDetector) getRelativeFilePath(basePath, currentPath, fileName str
 	return "/" + relativeFilePath
 }
 
-func (d *DotenvDetector) scanEnvVariables(content string, payload *types.Payload) {
+func (d *DotenvDetector) scanEnvVariables(content string, payload *types.Payload, file *EnvFileInfo) {
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
 		varName := d.extractVarName(line)
 		if varName == "" {
 			continue
 		}
-		d.matchVarAgainstRules(varName, payload)
+		d.matchVarAgainstRules(varName, payload, file)
 	}
 }
 
@@ -80,28 +232,41 @@ func (d *DotenvDetector) extractVarName(line string) string {
 	if len(parts) < 2 {
 		return ""
 	}
-	return strings.TrimSpace(parts[0])
+	return strings.TrimSpace(strings.TrimPrefix(parts[0], "export "))
 }
 
-func (d *DotenvDetector) matchVarAgainstRules(varName string, payload *types.Payload) {
+func (d *DotenvDetector) matchVarAgainstRules(varName string, payload *types.Payload, file *EnvFileInfo) {
 	lowerVarName := strings.ToLower(varName)
 	for _, rule := range d.rules {
-		if d.matchesRule(lowerVarName, varName, rule, payload) {
+		if d.matchesRule(lowerVarName, varName, rule, payload, file) {
 			break
 		}
 	}
 }
 
-func (d *DotenvDetector) matchesRule(lowerVarName, varName string, rule types.Rule, payload *types.Payload) bool {
+func (d *DotenvDetector) matchesRule(lowerVarName, varName string, rule types.Rule, payload *types.Payload, file *EnvFileInfo) bool {
 	if len(rule.DotEnv) == 0 {
 		return false
 	}
 
 	for _, pattern := range rule.DotEnv {
 		if strings.Contains(lowerVarName, strings.ToLower(pattern)) {
-			payload.AddTech(rule.Tech, rule.Tech+" matched env: "+varName)
+			payload.AddTech(rule.Tech, rule.Tech+" matched env: "+varName+file.reasonSuffix())
+			file.Variables = append(file.Variables, EnvVariable{Name: varName, Tech: rule.Tech})
 			return true
 		}
 	}
 	return false
 }
+
+// reasonSuffix names the environment a variable was found for; template
+// matches have none.
+func (f *EnvFileInfo) reasonSuffix() string {
+	switch {
+	case f.Environment == EnvironmentExample:
+		return ""
+	case f.Local:
+		return " (" + f.Environment + ", local)"
+	}
+	return " (" + f.Environment + ")"
+}
@@ -311,7 +311,7 @@ func TestDotenvDetector_matchesRule(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			payload := types.NewPayloadWithPath("test", "/test")
-			result := detector.matchesRule(strings.ToLower(tt.varName), tt.varName, tt.rule, payload)
+			result := detector.matchesRule(strings.ToLower(tt.varName), tt.varName, tt.rule, payload, &EnvFileInfo{Environment: EnvironmentExample})
 
 			assert.Equal(t, tt.shouldMatch, result, "Should match rule correctly")
 
@@ -524,3 +524,88 @@ func TestDotenvDetector_ErrorHandling(t *testing.T) {
 	assert.Nil(t, payload, "Should return nil when file read fails")
 	provider.AssertExpectations(t)
 }
+
+func TestDotenvEnvironment(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		local       bool
+		ok          bool
+	}{
+		{".env", EnvironmentDefault, false, true},
+		{".env.example", EnvironmentExample, false, true},
+		{".env.sample", EnvironmentExample, false, true},
+		{".env.production", "production", false, true},
+		{".env.local", EnvironmentDefault, true, true},
+		{".env.production.local", "production", true, true},
+		{"staging.env", "staging", false, true},
+		{".env.bak", EnvironmentDefault, false, false},
+		{".env.vault", EnvironmentDefault, false, false},
+		{"config.yml", EnvironmentDefault, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environment, local, ok := DotenvEnvironment(tt.name)
+			assert.Equal(t, tt.environment, environment)
+			assert.Equal(t, tt.local, local)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestDotenvDetector_DetectInDotEnv_Environments(t *testing.T) {
+	provider := &MockDotenvProvider{}
+	provider.On("ReadFile", "/project/.env.example").Return([]byte("DATABASE_URL=\nREDIS_URL=\n"), nil)
+	provider.On("ReadFile", "/project/.env.production").Return([]byte("DATABASE_URL=postgresql://db.example.com/myapp\n"), nil)
+	provider.On("ReadFile", "/project/.env.production.local").Return([]byte("export MYSQL_HOST=localhost\n"), nil)
+	provider.On("ReadFile", "/project/docker-compose.yml").Return([]byte(`services:
+  api:
+    env_file: [.env.production, ./deploy/worker.env]
+  worker:
+    env_file: ./deploy/worker.env
+  escape:
+    env_file: ../outside/.env
+`), nil)
+	provider.On("ReadFile", "/project/deploy/worker.env").Return([]byte("REDIS_HOST=cache\n"), nil)
+
+	rules := []types.Rule{
+		{Tech: "postgresql", DotEnv: []string{"DATABASE"}},
+		{Tech: "redis", DotEnv: []string{"REDIS"}},
+		{Tech: "mysql", DotEnv: []string{"MYSQL"}},
+	}
+	detector := NewDotenvDetector(provider, rules)
+	payload := detector.DetectInDotEnv([]types.File{
+		{Name: "docker-compose.yml"},
+		{Name: ".env.production"},
+		{Name: ".env.production.local"},
+		{Name: ".env.example"},
+		{Name: ".env.bak"},
+	}, "/project", "/project")
+
+	require.NotNil(t, payload)
+	assert.Equal(t, []string{"/.env.example", "/.env.production", "/.env.production.local", "/deploy/worker.env"}, payload.Path)
+	assert.ElementsMatch(t, []string{"postgresql", "redis", "mysql"}, payload.Techs)
+	assert.Equal(t, []string{
+		"postgresql matched env: DATABASE_URL",
+		"postgresql matched env: DATABASE_URL (production)",
+	}, payload.Reason["postgresql"])
+	assert.Contains(t, payload.Reason["mysql"], "mysql matched env: MYSQL_HOST (production, local)")
+
+	assert.Equal(t, []interface{}{
+		&EnvFileInfo{File: "/.env.example", Environment: EnvironmentExample, Variables: []EnvVariable{
+			{Name: "DATABASE_URL", Tech: "postgresql"}, {Name: "REDIS_URL", Tech: "redis"},
+		}},
+		&EnvFileInfo{File: "/.env.production", Environment: "production", Services: []string{"api"}, Variables: []EnvVariable{
+			{Name: "DATABASE_URL", Tech: "postgresql"},
+		}},
+		&EnvFileInfo{File: "/.env.production.local", Environment: "production", Local: true, Variables: []EnvVariable{
+			{Name: "MYSQL_HOST", Tech: "mysql"},
+		}},
+		&EnvFileInfo{File: "/deploy/worker.env", Environment: "worker", Services: []string{"api", "worker"}, Variables: []EnvVariable{
+			{Name: "REDIS_HOST", Tech: "redis"},
+		}},
+	}, payload.Properties["env_files"])
+
+	provider.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kinds of entrypoints.
+const (
+	EntrypointKindMain             = "main"              // a main function or __main__ guard in a source file
+	EntrypointKindNpmBin           = "npm-bin"           // a package.json bin entry
+	EntrypointKindNpmMain          = "npm-main"          // the package.json main field
+	EntrypointKindNpmModule        = "npm-module"        // the package.json module field
+	EntrypointKindDockerEntrypoint = "docker-entrypoint" // the ENTRYPOINT of a Dockerfile's final stage
+	EntrypointKindDockerCmd        = "docker-cmd"        // the CMD of a Dockerfile's final stage
+	EntrypointKindProcfile         = "procfile"          // a Procfile process type
+)
+
+// mainFunctionPatterns find the program entry point of source files by
+// extension.
+var mainFunctionPatterns = map[string]*regexp.Regexp{
+	".go":   regexp.MustCompile(`(?m)^package\s+main\s*$[\s\S]*^func\s+main\s*\(\s*\)`),
+	".py":   regexp.MustCompile(`(?m)^if\s+__name__\s*==\s*["']__main__["']\s*:`),
+	".java": regexp.MustCompile(`\bpublic\s+static\s+void\s+main\s*\(\s*(?:final\s+)?String`),
+	".kt":   regexp.MustCompile(`(?m)^fun\s+main\s*\(`),
+	".rs":   regexp.MustCompile(`(?m)^(?:#\[[^\]]+\]\s*)*(?:async\s+)?fn\s+main\s*\(\s*\)`),
+	".cs":   regexp.MustCompile(`\bstatic\s+(?:async\s+)?(?:void|int|Task(?:<int>)?)\s+Main\s*\(`),
+}
+
+var (
+	dockerfileInstructionRegex = regexp.MustCompile(`(?i)^(FROM|ENTRYPOINT|CMD)\s+(.*)$`)
+	procfileCommandRegex       = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(\S.*)$`)
+)
+
+// Entrypoint is a way a component is started: a main function, a package
+// entry point or executable, or the command a container or platform runs.
+type Entrypoint struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name,omitempty"`    // executable or process type
+	Path    string `json:"path,omitempty"`    // file the entrypoint runs, as declared
+	Command string `json:"command,omitempty"` // command line run by a container or platform
+	File    string `json:"file"`              // file declaring the entrypoint
+}
+
+// EntrypointsParser reads the entrypoints declared by source files,
+// package.json, Dockerfiles and Procfiles.
+type EntrypointsParser struct{}
+
+// NewEntrypointsParser creates a new entrypoints parser.
+func NewEntrypointsParser() *EntrypointsParser {
+	return &EntrypointsParser{}
+}
+
+// ParseMainFunction reports a source file at rel defining the program entry
+// point of its language: a Go main package's main, a Python __main__ guard,
+// a Java, Kotlin, Rust or C# main function.
+func (p *EntrypointsParser) ParseMainFunction(rel string, content []byte) (Entrypoint, bool) {
+	pattern := mainFunctionPatterns[path.Ext(rel)]
+	if pattern == nil || !pattern.Match(content) {
+		return Entrypoint{}, false
+	}
+	return Entrypoint{Kind: EntrypointKindMain, File: rel}, true
+}
+
+// ParsePackageJSON reads the bin, main and module fields of a package.json
+// at rel. A bin given as a string is named after the package, without its
+// scope.
+func (p *EntrypointsParser) ParsePackageJSON(rel string, content []byte) []Entrypoint {
+	var pkg struct {
+		Name   string          `json:"name"`
+		Bin    json.RawMessage `json:"bin"`
+		Main   string          `json:"main"`
+		Module string          `json:"module"`
+	}
+	if json.Unmarshal(content, &pkg) != nil {
+		return nil
+	}
+	entrypoints := packageBins(rel, pkg.Name, pkg.Bin)
+	if pkg.Main != "" {
+		entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindNpmMain, Path: pkg.Main, File: rel})
+	}
+	if pkg.Module != "" {
+		entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindNpmModule, Path: pkg.Module, File: rel})
+	}
+	return entrypoints
+}
+
+func packageBins(rel, name string, raw json.RawMessage) []Entrypoint {
+	var single string
+	if json.Unmarshal(raw, &single) == nil && single != "" {
+		return []Entrypoint{{Kind: EntrypointKindNpmBin, Name: path.Base(name), Path: single, File: rel}}
+	}
+	var bins map[string]string
+	if json.Unmarshal(raw, &bins) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(bins))
+	for bin := range bins {
+		names = append(names, bin)
+	}
+	sort.Strings(names)
+	entrypoints := make([]Entrypoint, 0, len(names))
+	for _, bin := range names {
+		entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindNpmBin, Name: bin, Path: bins[bin], File: rel})
+	}
+	return entrypoints
+}
+
+// ParseDockerfile reads the ENTRYPOINT and CMD of the final stage of a
+// Dockerfile at rel, the last of each counting as Docker does. Exec form
+// arrays are joined with spaces.
+func (p *EntrypointsParser) ParseDockerfile(rel string, content []byte) []Entrypoint {
+	var entrypoint, cmd string
+	for _, line := range dockerfileLines(content) {
+		m := dockerfileInstructionRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		switch strings.ToUpper(m[1]) {
+		case "FROM":
+			entrypoint, cmd = "", ""
+		case "ENTRYPOINT":
+			entrypoint = dockerCommand(m[2])
+		case "CMD":
+			cmd = dockerCommand(m[2])
+		}
+	}
+	var entrypoints []Entrypoint
+	if entrypoint != "" {
+		entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindDockerEntrypoint, Command: entrypoint, File: rel})
+	}
+	if cmd != "" {
+		entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindDockerCmd, Command: cmd, File: rel})
+	}
+	return entrypoints
+}
+
+// dockerfileLines returns the instructions of a Dockerfile with continued
+// lines joined and comments dropped.
+func dockerfileLines(content []byte) []string {
+	var lines []string
+	var current strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\") + " ")
+			continue
+		}
+		current.WriteString(line)
+		lines = append(lines, strings.Join(strings.Fields(current.String()), " "))
+		current.Reset()
+	}
+	return lines
+}
+
+// dockerCommand returns the command line of an exec form array or a shell
+// form instruction.
+func dockerCommand(arg string) string {
+	var args []string
+	if json.Unmarshal([]byte(arg), &args) == nil {
+		return strings.Join(args, " ")
+	}
+	return strings.TrimSpace(arg)
+}
+
+// ParseProcfile reads the process types of a Procfile at rel with their
+// commands.
+func (p *EntrypointsParser) ParseProcfile(rel string, content []byte) []Entrypoint {
+	var entrypoints []Entrypoint
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if m := procfileCommandRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text())); m != nil {
+			entrypoints = append(entrypoints, Entrypoint{Kind: EntrypointKindProcfile, Name: m[1], Command: strings.TrimSpace(m[2]), File: rel})
+		}
+	}
+	return entrypoints
+}
@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMainFunction(t *testing.T) {
+	parser := NewEntrypointsParser()
+	tests := []struct {
+		rel     string
+		content string
+		ok      bool
+	}{
+		{"/cmd/myapp/main.go", "package main\n\nimport \"os\"\n\nfunc main() {\n\tos.Exit(run())\n}\n", true},
+		{"/internal/app.go", "package app\n\nfunc main() {}\n", false},
+		{"/tools/migrate.py", "def run():\n    pass\n\nif __name__ == '__main__':\n    run()\n", true},
+		{"/src/main/java/com/example/App.java", "public class App {\n    public static void main(String[] args) {}\n}\n", true},
+		{"/src/main/kotlin/App.kt", "fun main(args: Array<String>) {}\n", true},
+		{"/src/main.rs", "#[tokio::main]\nasync fn main() {}\n", true},
+		{"/Program.cs", "class Program { static async Task<int> Main(string[] args) => 0; }\n", true},
+		{"/lib/util.js", "function main() {}\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			got, ok := parser.ParseMainFunction(tt.rel, []byte(tt.content))
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, Entrypoint{Kind: EntrypointKindMain, File: tt.rel}, got)
+			}
+		})
+	}
+}
+
+func TestParsePackageJSONEntrypoints(t *testing.T) {
+	parser := NewEntrypointsParser()
+	assert.Equal(t, []Entrypoint{
+		{Kind: EntrypointKindNpmBin, Name: "mycli", Path: "./bin/mycli.js", File: "/package.json"},
+		{Kind: EntrypointKindNpmBin, Name: "mycli-init", Path: "./bin/init.js", File: "/package.json"},
+		{Kind: EntrypointKindNpmMain, Path: "dist/index.cjs", File: "/package.json"},
+		{Kind: EntrypointKindNpmModule, Path: "dist/index.mjs", File: "/package.json"},
+	}, parser.ParsePackageJSON("/package.json", []byte(`{
+		"name": "@myorg/mycli",
+		"bin": {"mycli-init": "./bin/init.js", "mycli": "./bin/mycli.js"},
+		"main": "dist/index.cjs",
+		"module": "dist/index.mjs"
+	}`)))
+
+	assert.Equal(t, []Entrypoint{{Kind: EntrypointKindNpmBin, Name: "mycli", Path: "cli.js", File: "/tool/package.json"}},
+		parser.ParsePackageJSON("/tool/package.json", []byte(`{"name": "@myorg/mycli", "bin": "cli.js"}`)))
+	assert.Empty(t, parser.ParsePackageJSON("/package.json", []byte(`{"name": "web", "private": true}`)))
+}
+
+func TestParseDockerfileEntrypoints(t *testing.T) {
+	content := `FROM node:20 AS build
+CMD ["npm", "test"]
+
+FROM node:20-slim
+# start the server
+ENTRYPOINT ["docker-entrypoint.sh"]
+CMD node server.js \
+    --port=8080
+`
+	assert.Equal(t, []Entrypoint{
+		{Kind: EntrypointKindDockerEntrypoint, Command: "docker-entrypoint.sh", File: "/Dockerfile"},
+		{Kind: EntrypointKindDockerCmd, Command: "node server.js --port=8080", File: "/Dockerfile"},
+	}, NewEntrypointsParser().ParseDockerfile("/Dockerfile", []byte(content)))
+
+	assert.Empty(t, NewEntrypointsParser().ParseDockerfile("/Dockerfile", []byte("FROM nginx:alpine\nCOPY dist /usr/share/nginx/html\n")))
+}
+
+func TestParseProcfileEntrypoints(t *testing.T) {
+	assert.Equal(t, []Entrypoint{
+		{Kind: EntrypointKindProcfile, Name: "web", Command: "gunicorn myapp.wsgi --log-file -", File: "/Procfile"},
+		{Kind: EntrypointKindProcfile, Name: "release", Command: "python manage.py migrate", File: "/Procfile"},
+	}, NewEntrypointsParser().ParseProcfile("/Procfile", []byte("web: gunicorn myapp.wsgi --log-file -\n\nrelease: python manage.py migrate\n")))
+}
@@ -0,0 +1,287 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of per-environment configuration.
+const (
+	EnvironmentKindDotenv     = "dotenv"
+	EnvironmentKindSpring     = "spring"
+	EnvironmentKindKubernetes = "kubernetes"
+	EnvironmentKindTerraform  = "terraform"
+	EnvironmentKindCompose    = "compose"
+)
+
+var (
+	springProfileRegex   = regexp.MustCompile(`^(?:application|bootstrap)-([\w.-]+)\.(?:ya?ml|properties)$`)
+	composeOverrideRegex = regexp.MustCompile(`^(?:docker-)?compose[.-]([\w-]+)\.ya?ml$`)
+	tfvarsRegex          = regexp.MustCompile(`^([\w-]+)\.tfvars(?:\.json)?$`)
+	// environmentDirRegex matches a directory holding the configuration of
+	// one environment: Kustomize overlays and environment folders.
+	environmentDirRegex   = regexp.MustCompile(`/(?:overlays|environments|envs|env|stages)/([\w.-]+)/`)
+	containerImageRegex   = regexp.MustCompile(`(?m)^\s*(?:-\s*)?(?:image|newName):\s*["']?([^\s"'#]+)`)
+	terraformModuleRegex  = regexp.MustCompile(`(?m)^module\s+"([^"]+)"`)
+	jdbcURLRegex          = regexp.MustCompile(`jdbc:(\w+):`)
+	connectionSchemeRegex = regexp.MustCompile(`\b(mongodb|redis|rediss|amqps?|cassandra)(?:\+srv)?://`)
+)
+
+// environmentAliases map the short names of common environments to the
+// name they are reported under, so that .env.production and overlays/prod
+// are the same environment.
+var environmentAliases = map[string]string{
+	"dev": "development", "develop": "development",
+	"prod": "production", "prd": "production",
+	"stage": "staging", "stg": "staging",
+	"testing": "test",
+}
+
+// sharedEnvironmentNames name configuration shared by all environments or
+// applying to none in particular.
+var sharedEnvironmentNames = map[string]bool{
+	"base": true, "common": true, "shared": true, "default": true, "defaults": true, "override": true,
+	"local": true, "example": true, "sample": true, "template": true, "modules": true, "components": true,
+}
+
+// connectionTechs are the techs named by JDBC drivers and connection URL
+// schemes.
+var connectionTechs = map[string]string{
+	"postgresql": "postgresql", "postgres": "postgresql", "mysql": "mysql", "mariadb": "mariadb",
+	"h2": "h2", "sqlserver": "mssql", "oracle": "oracle", "sqlite": "sqlite",
+	"mongodb": "mongodb", "redis": "redis", "rediss": "redis", "amqp": "rabbitmq", "amqps": "rabbitmq",
+	"cassandra": "apache_cassandra",
+}
+
+// springSettingTechs are the Spring Boot setting prefixes configuring a
+// tech.
+var springSettingTechs = []struct{ prefix, tech string }{
+	{"spring.kafka.", "apache_kafka"},
+	{"spring.rabbitmq.", "rabbitmq"},
+	{"spring.data.redis.", "redis"},
+	{"spring.redis.", "redis"},
+	{"spring.data.mongodb.", "mongodb"},
+	{"spring.elasticsearch.", "elasticsearch"},
+	{"spring.data.elasticsearch.", "elasticsearch"},
+	{"spring.cassandra.", "apache_cassandra"},
+	{"spring.data.cassandra.", "apache_cassandra"},
+}
+
+// EnvironmentConfig is what a configuration file of one environment says
+// about it. Images and Terraform resources are matched to techs by the
+// scanner.
+type EnvironmentConfig struct {
+	Environment string
+	Kind        string
+	Techs       []string // named by connection URLs and settings
+	Images      []string // container images, without tag or digest
+	Resources   []string // Terraform resource types
+	Services    []string // Compose services, Kubernetes workloads (Kind/name) or Terraform modules (module.name)
+}
+
+// EnvironmentsParser reads the configuration files specific to one
+// environment.
+type EnvironmentsParser struct{}
+
+// NewEnvironmentsParser creates a new per-environment configuration parser.
+func NewEnvironmentsParser() *EnvironmentsParser {
+	return &EnvironmentsParser{}
+}
+
+// NormalizeEnvironment returns the name an environment is reported under,
+// and false for names of shared configuration such as base or common.
+func NormalizeEnvironment(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if name == "" || sharedEnvironmentNames[name] {
+		return "", false
+	}
+	if alias, ok := environmentAliases[name]; ok {
+		return alias, true
+	}
+	return name, true
+}
+
+// ParseSpringProfile reads the techs configured by a Spring profile file,
+// application-<profile>.yml or .properties.
+func (p *EnvironmentsParser) ParseSpringProfile(rel string, content []byte) (EnvironmentConfig, bool) {
+	m := springProfileRegex.FindStringSubmatch(path.Base(rel))
+	if m == nil {
+		return EnvironmentConfig{}, false
+	}
+	environment, ok := NormalizeEnvironment(m[1])
+	if !ok {
+		return EnvironmentConfig{}, false
+	}
+	config := EnvironmentConfig{Environment: environment, Kind: EnvironmentKindSpring}
+	var settings map[string]string
+	if strings.HasSuffix(rel, ".properties") {
+		settings = parseProperties(string(content))
+	} else {
+		settings = flattenYAMLSettings(content)
+	}
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		config.Techs = appendNew(config.Techs, springSettingTech(key))
+		config.Techs = appendNew(config.Techs, connectionTech(settings[key]))
+	}
+	return config, true
+}
+
+func springSettingTech(key string) string {
+	for _, setting := range springSettingTechs {
+		if strings.HasPrefix(key, setting.prefix) {
+			return setting.tech
+		}
+	}
+	return ""
+}
+
+// connectionTech returns the tech of a JDBC or connection URL.
+func connectionTech(value string) string {
+	if m := jdbcURLRegex.FindStringSubmatch(value); m != nil {
+		return connectionTechs[strings.ToLower(m[1])]
+	}
+	if m := connectionSchemeRegex.FindStringSubmatch(value); m != nil {
+		return connectionTechs[m[1]]
+	}
+	return ""
+}
+
+// flattenYAMLSettings returns the settings of a YAML document as dotted
+// keys, scalar values as text and lists of scalars comma-separated.
+// Documents after the first are ignored.
+func flattenYAMLSettings(content []byte) map[string]string {
+	var doc map[string]interface{}
+	if yaml.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+	settings := make(map[string]string)
+	flattenSettings("", doc, settings)
+	return settings
+}
+
+func flattenSettings(prefix string, value interface{}, settings map[string]string) {
+	key := strings.TrimSuffix(prefix, ".")
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for child, childValue := range v {
+			flattenSettings(prefix+child+".", childValue, settings)
+		}
+	case []interface{}:
+		for _, child := range v {
+			if _, nested := child.(map[string]interface{}); nested || settings[key] == "" {
+				flattenSettings(prefix, child, settings)
+			} else {
+				settings[key] += "," + fmt.Sprint(child)
+			}
+		}
+	case nil:
+		settings[key] = ""
+	default:
+		settings[key] = fmt.Sprint(v)
+	}
+}
+
+// ParseComposeEnvironment reads the services and images of a Compose file
+// for one environment, such as docker-compose.prod.yml.
+func (p *EnvironmentsParser) ParseComposeEnvironment(rel string, content []byte) (EnvironmentConfig, bool) {
+	m := composeOverrideRegex.FindStringSubmatch(path.Base(rel))
+	if m == nil {
+		return EnvironmentConfig{}, false
+	}
+	environment, ok := NormalizeEnvironment(m[1])
+	if !ok {
+		return EnvironmentConfig{}, false
+	}
+	config := EnvironmentConfig{Environment: environment, Kind: EnvironmentKindCompose}
+	for _, service := range NewDockerComposeParser().ParseDockerCompose(string(content)) {
+		config.Services = appendNew(config.Services, service.Name)
+		config.Images = appendNew(config.Images, imageName(service.Image))
+	}
+	return config, true
+}
+
+// ParseTfvars accepts a Terraform variable file named after an environment,
+// such as prod.tfvars. terraform.tfvars and *.auto.tfvars apply to every
+// workspace and are skipped.
+func (p *EnvironmentsParser) ParseTfvars(rel string, content []byte) (EnvironmentConfig, bool) {
+	m := tfvarsRegex.FindStringSubmatch(path.Base(rel))
+	if m == nil || m[1] == "terraform" {
+		return EnvironmentConfig{}, false
+	}
+	environment, ok := NormalizeEnvironment(m[1])
+	if !ok {
+		return EnvironmentConfig{}, false
+	}
+	return EnvironmentConfig{Environment: environment, Kind: EnvironmentKindTerraform}, true
+}
+
+// ParseEnvironmentDirectory reads a file of an environment folder: Terraform
+// configuration (resource types and modules) or Kubernetes manifests and
+// Kustomize overlays (workloads and images). Other files are skipped.
+func (p *EnvironmentsParser) ParseEnvironmentDirectory(rel string, content []byte) (EnvironmentConfig, bool) {
+	m := environmentDirRegex.FindStringSubmatch(rel)
+	if m == nil {
+		return EnvironmentConfig{}, false
+	}
+	environment, ok := NormalizeEnvironment(m[1])
+	if !ok {
+		return EnvironmentConfig{}, false
+	}
+	name := path.Base(rel)
+	switch ext := path.Ext(name); {
+	case ext == ".tf":
+		return p.parseTerraformEnvironment(environment, content), true
+	case ext == ".tfvars" || name == "terragrunt.hcl":
+		return EnvironmentConfig{Environment: environment, Kind: EnvironmentKindTerraform}, true
+	case ext == ".yaml" || ext == ".yml":
+		return p.parseKubernetesEnvironment(environment, content)
+	}
+	return EnvironmentConfig{}, false
+}
+
+func (p *EnvironmentsParser) parseTerraformEnvironment(environment string, content []byte) EnvironmentConfig {
+	config := EnvironmentConfig{Environment: environment, Kind: EnvironmentKindTerraform}
+	for _, resource := range NewTerraformParser().ParseTerraformResources(string(content)) {
+		config.Resources = appendNew(config.Resources, resource.Type)
+	}
+	for _, m := range terraformModuleRegex.FindAllSubmatch(content, -1) {
+		config.Services = appendNew(config.Services, "module."+string(m[1]))
+	}
+	return config
+}
+
+// parseKubernetesEnvironment reads a kustomization or Kubernetes manifest;
+// other YAML files are skipped.
+func (p *EnvironmentsParser) parseKubernetesEnvironment(environment string, content []byte) (EnvironmentConfig, bool) {
+	if !bytes.Contains(content, []byte("kind:")) && !bytes.Contains(content, []byte("resources:")) {
+		return EnvironmentConfig{}, false
+	}
+	config := EnvironmentConfig{Environment: environment, Kind: EnvironmentKindKubernetes}
+	if workloads, ok := NewDeploymentParser().ParseKubernetesWorkloads(content); ok {
+		config.Services = workloads.Workloads
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if m := containerImageRegex.FindStringSubmatch(scanner.Text()); m != nil {
+			config.Images = appendNew(config.Images, imageName(m[1]))
+		}
+	}
+	return config, true
+}
+
+// imageName strips the tag and digest of an image reference.
+func imageName(reference string) string {
+	reference, _, _ = strings.Cut(reference, "@")
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		reference = reference[:i]
+	}
+	return reference
+}
@@ -0,0 +1,84 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEnvironment(t *testing.T) {
+	for name, want := range map[string]string{"prod": "production", "Dev": "development", "stg": "staging", "qa": "qa"} {
+		got, ok := NormalizeEnvironment(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, got, name)
+	}
+	for _, name := range []string{"base", "common", "default", "example", ""} {
+		_, ok := NormalizeEnvironment(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestParseSpringProfile(t *testing.T) {
+	parser := NewEnvironmentsParser()
+	config, ok := parser.ParseSpringProfile("/src/main/resources/application-prod.properties", []byte(
+		"spring.datasource.url=jdbc:postgresql://db.example.com:5432/myapp\nspring.kafka.bootstrap-servers=kafka.example.com:9092\nserver.port=8080\n"))
+	require.True(t, ok)
+	assert.Equal(t, EnvironmentConfig{Environment: "production", Kind: EnvironmentKindSpring, Techs: []string{"postgresql", "apache_kafka"}}, config)
+
+	config, ok = parser.ParseSpringProfile("/application-dev.yml", []byte("spring:\n  datasource:\n    url: jdbc:h2:mem:test\n  data:\n    mongodb:\n      uri: mongodb://localhost/myapp\n"))
+	require.True(t, ok)
+	assert.Equal(t, "development", config.Environment)
+	assert.ElementsMatch(t, []string{"h2", "mongodb"}, config.Techs)
+
+	_, ok = parser.ParseSpringProfile("/application.yml", []byte("spring: {}\n"))
+	assert.False(t, ok)
+}
+
+func TestParseComposeEnvironment(t *testing.T) {
+	parser := NewEnvironmentsParser()
+	config, ok := parser.ParseComposeEnvironment("/docker-compose.prod.yml", []byte("services:\n  db:\n    image: postgres:16\n  cache:\n    image: redis@sha256:abc\n"))
+	require.True(t, ok)
+	assert.Equal(t, "production", config.Environment)
+	assert.ElementsMatch(t, []string{"db", "cache"}, config.Services)
+	assert.ElementsMatch(t, []string{"postgres", "redis"}, config.Images)
+
+	_, ok = parser.ParseComposeEnvironment("/docker-compose.override.yml", []byte("services: {}\n"))
+	assert.False(t, ok)
+}
+
+func TestParseTfvars(t *testing.T) {
+	parser := NewEnvironmentsParser()
+	config, ok := parser.ParseTfvars("/infra/staging.tfvars", nil)
+	require.True(t, ok)
+	assert.Equal(t, EnvironmentConfig{Environment: "staging", Kind: EnvironmentKindTerraform}, config)
+
+	_, ok = parser.ParseTfvars("/infra/terraform.tfvars", nil)
+	assert.False(t, ok)
+	_, ok = parser.ParseTfvars("/infra/common.auto.tfvars", nil)
+	assert.False(t, ok)
+}
+
+func TestParseEnvironmentDirectory(t *testing.T) {
+	parser := NewEnvironmentsParser()
+	config, ok := parser.ParseEnvironmentDirectory("/k8s/overlays/prod/kustomization.yaml", []byte(
+		"resources:\n  - ../../base\nimages:\n  - name: myorg/api\n    newName: ghcr.io/myorg/api\n    newTag: v1.2.0\n"))
+	require.True(t, ok)
+	assert.Equal(t, EnvironmentConfig{Environment: "production", Kind: EnvironmentKindKubernetes, Images: []string{"ghcr.io/myorg/api"}}, config)
+
+	config, ok = parser.ParseEnvironmentDirectory("/k8s/overlays/dev/worker.yaml", []byte(
+		"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: worker\nspec:\n  template:\n    spec:\n      containers:\n        - name: worker\n          image: myorg/worker:dev\n"))
+	require.True(t, ok)
+	assert.Equal(t, []string{"Deployment/worker"}, config.Services)
+	assert.Equal(t, []string{"myorg/worker"}, config.Images)
+
+	config, ok = parser.ParseEnvironmentDirectory("/infra/environments/prod/main.tf", []byte(
+		"resource \"aws_db_instance\" \"db\" {\n  engine = \"postgres\"\n}\n\nmodule \"api\" {\n  source = \"../../modules/api\"\n}\n"))
+	require.True(t, ok)
+	assert.Equal(t, EnvironmentConfig{Environment: "production", Kind: EnvironmentKindTerraform, Resources: []string{"aws_db_instance"}, Services: []string{"module.api"}}, config)
+
+	_, ok = parser.ParseEnvironmentDirectory("/k8s/overlays/base/kustomization.yaml", []byte("resources: []\n"))
+	assert.False(t, ok)
+	_, ok = parser.ParseEnvironmentDirectory("/k8s/overlays/prod/README.md", []byte("# prod\n"))
+	assert.False(t, ok)
+}
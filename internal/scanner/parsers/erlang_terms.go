@@ -0,0 +1,196 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Erlang term values produced by parseErlangTerms. Lists are []interface{};
+// strings and binaries (<<"...">>) are both plain Go strings.
+type (
+	erlAtom  string        // bare or quoted atom, or a number literal
+	erlTuple []interface{} // {a, b, ...}
+)
+
+// erlTermReader reads the dot-terminated Erlang terms of files such as
+// rebar.config and rebar.lock. It covers the subset these files use: atoms,
+// numbers, strings, binaries, tuples and lists.
+type erlTermReader struct {
+	src string
+	pos int
+}
+
+// parseErlangTerms parses all top-level terms. On a syntax error it returns
+// the terms read so far together with the error.
+func parseErlangTerms(content string) ([]interface{}, error) {
+	r := &erlTermReader{src: content}
+	var terms []interface{}
+	for {
+		r.skipSpace()
+		if r.pos >= len(r.src) {
+			return terms, nil
+		}
+		term, err := r.term()
+		if err != nil {
+			return terms, err
+		}
+		r.skipSpace()
+		if !r.consume('.') {
+			return terms, fmt.Errorf("expected '.' at offset %d", r.pos)
+		}
+		terms = append(terms, term)
+	}
+}
+
+func (r *erlTermReader) term() (interface{}, error) {
+	r.skipSpace()
+	if r.pos >= len(r.src) {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	switch c := r.src[r.pos]; {
+	case c == '{':
+		r.pos++
+		items, err := r.sequence('}')
+		return erlTuple(items), err
+	case c == '[':
+		r.pos++
+		return r.sequence(']')
+	case c == '"':
+		return r.quoted('"')
+	case c == '\'':
+		s, err := r.quoted('\'')
+		return erlAtom(s), err
+	case strings.HasPrefix(r.src[r.pos:], "<<"):
+		return r.binary()
+	case isErlWordChar(c) || c == '-':
+		return r.word(), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at offset %d", c, r.pos)
+	}
+}
+
+// sequence reads comma-separated terms up to the closing character.
+func (r *erlTermReader) sequence(closing byte) ([]interface{}, error) {
+	items := []interface{}{}
+	r.skipSpace()
+	if r.consume(closing) {
+		return items, nil
+	}
+	for {
+		item, err := r.term()
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		r.skipSpace()
+		if r.consume(closing) {
+			return items, nil
+		}
+		if !r.consume(',') {
+			return items, fmt.Errorf("expected ',' or %q at offset %d", closing, r.pos)
+		}
+	}
+}
+
+func (r *erlTermReader) quoted(quote byte) (string, error) {
+	var b strings.Builder
+	for r.pos++; r.pos < len(r.src); r.pos++ {
+		c := r.src[r.pos]
+		if c == '\\' && r.pos+1 < len(r.src) {
+			r.pos++
+			b.WriteByte(r.src[r.pos])
+			continue
+		}
+		if c == quote {
+			r.pos++
+			return b.String(), nil
+		}
+		b.WriteByte(c)
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// binary reads <<"text">> (and the empty <<>>) as a string.
+func (r *erlTermReader) binary() (interface{}, error) {
+	r.pos += 2
+	r.skipSpace()
+	text := ""
+	if r.pos < len(r.src) && r.src[r.pos] == '"' {
+		s, err := r.quoted('"')
+		if err != nil {
+			return nil, err
+		}
+		text = s
+		r.skipSpace()
+	}
+	if !strings.HasPrefix(r.src[r.pos:], ">>") {
+		return nil, fmt.Errorf("unterminated binary at offset %d", r.pos)
+	}
+	r.pos += 2
+	return text, nil
+}
+
+// word reads an unquoted atom or a number (including a fractional part).
+func (r *erlTermReader) word() erlAtom {
+	start := r.pos
+	r.pos++
+	for r.pos < len(r.src) {
+		c := r.src[r.pos]
+		isFraction := c == '.' && r.pos+1 < len(r.src) && r.src[r.pos+1] >= '0' && r.src[r.pos+1] <= '9' && r.src[start] >= '0' && r.src[start] <= '9'
+		if !isErlWordChar(c) && !isFraction {
+			break
+		}
+		r.pos++
+	}
+	return erlAtom(r.src[start:r.pos])
+}
+
+// skipSpace skips whitespace and % comments.
+func (r *erlTermReader) skipSpace() {
+	for r.pos < len(r.src) {
+		switch r.src[r.pos] {
+		case ' ', '\t', '\r', '\n':
+			r.pos++
+		case '%':
+			for r.pos < len(r.src) && r.src[r.pos] != '\n' {
+				r.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (r *erlTermReader) consume(c byte) bool {
+	if r.pos < len(r.src) && r.src[r.pos] == c {
+		r.pos++
+		return true
+	}
+	return false
+}
+
+func isErlWordChar(c byte) bool {
+	return c == '_' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// erlText returns the text of an atom or string term, "" otherwise.
+func erlText(term interface{}) string {
+	switch v := term.(type) {
+	case erlAtom:
+		return string(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// erlProplist returns the value of the first {key, Value} tuple in a list.
+func erlProplist(list interface{}, key string) (interface{}, bool) {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if t, ok := item.(erlTuple); ok && len(t) >= 2 && t[0] == erlAtom(key) {
+			return t[1], true
+		}
+	}
+	return nil, false
+}
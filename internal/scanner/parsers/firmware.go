@@ -0,0 +1,330 @@
+package parsers
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// pioInterpolationRegex matches ${section.option} references.
+	pioInterpolationRegex = regexp.MustCompile(`\$\{([\w:.-]+)\.(\w+)\}`)
+	// arduinoDependRegex matches "Name (>=1.2.0)" entries of depends=.
+	arduinoDependRegex = regexp.MustCompile(`^([^()]+?)\s*(?:\(([^)]*)\))?$`)
+
+	cmakeSystemNameRegex      = regexp.MustCompile(`(?i)set\s*\(\s*CMAKE_SYSTEM_NAME\s+"?([\w.-]+)`)
+	cmakeSystemProcessorRegex = regexp.MustCompile(`(?i)set\s*\(\s*CMAKE_SYSTEM_PROCESSOR\s+"?([\w.-]+)`)
+	crossTripleRegex          = regexp.MustCompile(`\b(arm-none-eabi|aarch64-none-elf|arm-linux-gnueabihf|aarch64-linux-gnu|arm-none-linux-gnueabihf)-`)
+	armCPURegex               = regexp.MustCompile(`-mcpu=([\w.+-]+)`)
+	armFPURegex               = regexp.MustCompile(`-mfpu=([\w.+-]+)`)
+	armTargetRegex            = regexp.MustCompile(`(?i)^(arm|aarch64|cortex)`)
+)
+
+// PlatformIOProject holds the parts of platformio.ini the scanner reports.
+type PlatformIOProject struct {
+	DefaultEnvs  []string
+	Environments []PlatformIOEnv
+	Dependencies []types.Dependency
+}
+
+// PlatformIOEnv is a build environment ([env:NAME]) of platformio.ini: the
+// development platform, board and MCU it targets and its frameworks.
+type PlatformIOEnv struct {
+	Name       string   `json:"name"`
+	Platform   string   `json:"platform,omitempty"`
+	Board      string   `json:"board,omitempty"`
+	MCU        string   `json:"mcu,omitempty"`
+	Frameworks []string `json:"frameworks,omitempty"`
+}
+
+// ArduinoLibrary is an Arduino library described by library.properties.
+type ArduinoLibrary struct {
+	Name          string
+	Version       string
+	Architectures []string
+	Dependencies  []types.Dependency
+}
+
+// CMakeToolchain is a CMake toolchain file that cross-compiles for an ARM
+// target.
+type CMakeToolchain struct {
+	File            string `json:"file"`
+	SystemName      string `json:"system_name,omitempty"`
+	SystemProcessor string `json:"system_processor,omitempty"`
+	Triple          string `json:"triple,omitempty"` // e.g. arm-none-eabi
+	CPU             string `json:"cpu,omitempty"`
+	FPU             string `json:"fpu,omitempty"`
+}
+
+// FirmwareParser parses embedded firmware project files: PlatformIO
+// projects, Arduino libraries, Zephyr west manifests and CMake toolchain
+// files.
+type FirmwareParser struct{}
+
+// NewFirmwareParser creates a new firmware project parser.
+func NewFirmwareParser() *FirmwareParser {
+	return &FirmwareParser{}
+}
+
+// ParsePlatformIO parses platformio.ini. Each [env:NAME] section inherits
+// the common [env] section and the sections named by extends; ${section.option}
+// references are resolved. lib_deps of all environments are merged into one
+// dependency list; local (file://, symlink://) libraries are skipped.
+func (p *FirmwareParser) ParsePlatformIO(content string) *PlatformIOProject {
+	sections, order := parseINISections(content)
+	project := &PlatformIOProject{DefaultEnvs: splitList(sections["platformio"]["default_envs"])}
+	seen := make(map[string]bool)
+	for _, section := range order {
+		name, ok := strings.CutPrefix(section, "env:")
+		if !ok {
+			continue
+		}
+		options := resolvePIOEnv(sections, section)
+		project.Environments = append(project.Environments, PlatformIOEnv{
+			Name:       name,
+			Platform:   options["platform"],
+			Board:      options["board"],
+			MCU:        options["board_build.mcu"],
+			Frameworks: splitList(options["framework"]),
+		})
+		for _, entry := range splitList(options["lib_deps"]) {
+			dep, ok := platformIODependency(entry)
+			if ok && !seen[dep.Name] {
+				seen[dep.Name] = true
+				project.Dependencies = append(project.Dependencies, dep)
+			}
+		}
+	}
+	return project
+}
+
+// resolvePIOEnv returns the options of an environment section merged over
+// [env] and its extends sections, with ${section.option} references resolved.
+func resolvePIOEnv(sections map[string]map[string]string, section string) map[string]string {
+	options := make(map[string]string)
+	for key, value := range sections["env"] {
+		options[key] = value
+	}
+	for _, base := range splitList(sections[section]["extends"]) {
+		for key, value := range sections[base] {
+			options[key] = value
+		}
+	}
+	for key, value := range sections[section] {
+		options[key] = value
+	}
+	for key, value := range options {
+		options[key] = interpolatePIO(sections, value, 0)
+	}
+	return options
+}
+
+func interpolatePIO(sections map[string]map[string]string, value string, depth int) string {
+	if depth > 5 || !strings.Contains(value, "${") {
+		return value
+	}
+	resolved := pioInterpolationRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		m := pioInterpolationRegex.FindStringSubmatch(ref)
+		return sections[m[1]][m[2]]
+	})
+	return interpolatePIO(sections, resolved, depth+1)
+}
+
+// platformIODependency parses a lib_deps entry: "owner/Name @ ^1.2.3",
+// "Name@1.0.0", a repository URL with an optional #tag, or "Name=URL".
+func platformIODependency(entry string) (types.Dependency, bool) {
+	dep := types.Dependency{
+		Type:     DependencyTypePlatformIO,
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: types.NewMetadata("platformio.ini"),
+	}
+	name, source, hasName := strings.Cut(entry, "=")
+	if !hasName || strings.Contains(name, "://") || !strings.Contains(source, "://") {
+		name, source = "", entry
+	}
+	if strings.HasPrefix(source, "file://") || strings.HasPrefix(source, "symlink://") {
+		return dep, false
+	}
+	if strings.Contains(source, "://") {
+		repo, tag, _ := strings.Cut(source, "#")
+		if clean, _ := SanitizeRegistryURL(repo); clean != "" {
+			dep.Metadata["git"] = clean
+		}
+		if name == "" {
+			name = strings.TrimSuffix(repo[strings.LastIndex(repo, "/")+1:], ".git")
+		}
+		dep.Name, dep.Version = strings.TrimSpace(name), tag
+		return dep, dep.Name != ""
+	}
+	spec, version, _ := strings.Cut(source, "@")
+	dep.Name, dep.Version = strings.TrimSpace(spec), strings.TrimSpace(version)
+	return dep, dep.Name != ""
+}
+
+// ParseLibraryProperties parses an Arduino library.properties file. Returns
+// nil when the file has no name or no architectures (Processing libraries
+// use the same file name). depends= entries become dependencies with their
+// version constraint.
+func (p *FirmwareParser) ParseLibraryProperties(content string) *ArduinoLibrary {
+	props, _ := parseINISections(content)
+	values := props[""]
+	if values["name"] == "" || values["architectures"] == "" {
+		return nil
+	}
+	lib := &ArduinoLibrary{
+		Name:          values["name"],
+		Version:       values["version"],
+		Architectures: splitList(values["architectures"]),
+	}
+	for _, entry := range strings.Split(values["depends"], ",") {
+		m := arduinoDependRegex.FindStringSubmatch(strings.TrimSpace(entry))
+		if m == nil || strings.TrimSpace(m[1]) == "" {
+			continue
+		}
+		lib.Dependencies = append(lib.Dependencies, types.Dependency{
+			Type:     DependencyTypeArduino,
+			Name:     strings.TrimSpace(m[1]),
+			Version:  strings.TrimSpace(m[2]),
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: types.NewMetadata("library.properties"),
+		})
+	}
+	return lib
+}
+
+// ParseWestManifest parses a Zephyr west.yml manifest and returns its
+// projects as dependencies: the revision is the version and the repository
+// URL (url, or the remote url-base plus repo-path) is kept in metadata.
+func (p *FirmwareParser) ParseWestManifest(content []byte) []types.Dependency {
+	var west struct {
+		Manifest struct {
+			Defaults struct {
+				Remote   string `yaml:"remote"`
+				Revision string `yaml:"revision"`
+			} `yaml:"defaults"`
+			Remotes []struct {
+				Name    string `yaml:"name"`
+				URLBase string `yaml:"url-base"`
+			} `yaml:"remotes"`
+			Projects []struct {
+				Name     string `yaml:"name"`
+				URL      string `yaml:"url"`
+				Remote   string `yaml:"remote"`
+				RepoPath string `yaml:"repo-path"`
+				Revision string `yaml:"revision"`
+			} `yaml:"projects"`
+		} `yaml:"manifest"`
+	}
+	if yaml.Unmarshal(content, &west) != nil {
+		return nil
+	}
+	manifest := west.Manifest
+	remotes := make(map[string]string, len(manifest.Remotes))
+	for _, remote := range manifest.Remotes {
+		remotes[remote.Name] = strings.TrimSuffix(remote.URLBase, "/")
+	}
+
+	var deps []types.Dependency
+	for _, project := range manifest.Projects {
+		dep := types.Dependency{
+			Type:     DependencyTypeWest,
+			Name:     project.Name,
+			Version:  firstNonEmpty(project.Revision, manifest.Defaults.Revision),
+			Scope:    types.ScopeProd,
+			Direct:   true,
+			Metadata: types.NewMetadata("west.yml"),
+		}
+		url := project.URL
+		if url == "" {
+			if base := remotes[firstNonEmpty(project.Remote, manifest.Defaults.Remote)]; base != "" {
+				url = base + "/" + firstNonEmpty(project.RepoPath, project.Name)
+			}
+		}
+		if clean, _ := SanitizeRegistryURL(url); url != "" && clean != "" {
+			dep.Metadata["git"] = clean
+		}
+		if dep.Name != "" {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// ParseCMakeToolchain parses a CMake toolchain file. Returns nil unless the
+// file cross-compiles for ARM: an ARM GNU toolchain prefix, an ARM system
+// processor or an ARM -mcpu flag.
+func (p *FirmwareParser) ParseCMakeToolchain(content string) *CMakeToolchain {
+	toolchain := &CMakeToolchain{
+		SystemName:      firstSubmatch(cmakeSystemNameRegex, content),
+		SystemProcessor: firstSubmatch(cmakeSystemProcessorRegex, content),
+		Triple:          firstSubmatch(crossTripleRegex, content),
+		CPU:             firstSubmatch(armCPURegex, content),
+		FPU:             firstSubmatch(armFPURegex, content),
+	}
+	if toolchain.Triple == "" && !armTargetRegex.MatchString(toolchain.SystemProcessor) && !armTargetRegex.MatchString(toolchain.CPU) {
+		return nil
+	}
+	return toolchain
+}
+
+// parseINISections parses INI content into options per section (options
+// before the first section header are under ""). Continuation lines are
+// appended to the previous value, one per line. Returns the section names in
+// file order.
+func parseINISections(content string) (map[string]map[string]string, []string) {
+	sections := map[string]map[string]string{"": {}}
+	var order []string
+	current, key := "", ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := stripINIComment(strings.TrimSpace(raw))
+		if isINIComment(line) {
+			continue
+		}
+		if key != "" && isINIContinuation(raw) {
+			sections[current][key] += "\n" + line
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current, key = strings.TrimSpace(line[1:len(line)-1]), ""
+			if sections[current] == nil {
+				sections[current] = make(map[string]string)
+				order = append(order, current)
+			}
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			key = ""
+			continue
+		}
+		key = strings.TrimSpace(name)
+		sections[current][key] = strings.TrimSpace(value)
+	}
+	return sections, order
+}
+
+// stripINIComment removes an inline "; comment" from a value.
+func stripINIComment(line string) string {
+	if i := strings.Index(line, " ;"); i >= 0 {
+		return strings.TrimSpace(line[:i])
+	}
+	return line
+}
+
+// splitList splits a comma- or newline-separated option value.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
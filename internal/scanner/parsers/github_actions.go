@@ -45,7 +45,7 @@ type GitHubActionsStep struct {
 func (p *GitHubActionsParser) ParseWorkflow(content string) (*GitHubActionsWorkflow, error) {
 	var workflow GitHubActionsWorkflow
 	if err := yaml.Unmarshal([]byte(content), &workflow); err != nil {
-		return nil, err
+		return nil, types.NewParseError("GitHub Actions workflow", err)
 	}
 	return &workflow, nil
 }
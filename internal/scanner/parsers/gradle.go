@@ -337,17 +337,7 @@ func (p *GradleParser) parseGradleDependency(line string) *types.Dependency {
 	if gradlePlatformRegex.MatchString(line) {
 		scope = types.ScopeImport
 	} else {
-		// Map Gradle dependency types to scope constants
-		switch depType {
-		case "testImplementation", "testRuntimeOnly", "testCompileOnly", "testApi":
-			scope = types.ScopeDev
-		case "compileOnly", "annotationProcessor":
-			scope = types.ScopeBuild
-		case "implementation", "compile", "api", "runtimeOnly":
-			scope = types.ScopeProd
-		default:
-			scope = types.ScopeProd
-		}
+		scope = NormalizeScope(DependencyTypeGradle, depType)
 	}
 
 	return &types.Dependency{
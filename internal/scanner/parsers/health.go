@@ -0,0 +1,286 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of health checks.
+const (
+	HealthSourceKubernetes = "kubernetes"      // a container probe of a Kubernetes workload
+	HealthSourceSpring     = "spring-actuator" // the Spring Boot Actuator health endpoint
+	HealthSourceRoute      = "route"           // a route registered in code
+)
+
+// Kinds of health checks.
+const (
+	HealthKindHealth    = "health"
+	HealthKindLiveness  = "liveness"
+	HealthKindReadiness = "readiness"
+	HealthKindStartup   = "startup"
+)
+
+// kubernetesProbes maps the probe fields of a container to their kind.
+var kubernetesProbes = []struct{ field, kind string }{
+	{"livenessProbe", HealthKindLiveness},
+	{"readinessProbe", HealthKindReadiness},
+	{"startupProbe", HealthKindStartup},
+}
+
+// kubernetesProbeFields are the probe fields a manifest must contain, next to
+// a kind, for its workloads to be read for probes.
+var kubernetesProbeFields = [][]byte{[]byte("livenessProbe"), []byte("readinessProbe"), []byte("startupProbe")}
+
+// healthRouteKeywords are the words, one of which every health route path
+// contains, looked for before running the route regexes.
+var healthRouteKeywords = [][]byte{
+	[]byte("health"), []byte("Health"), []byte("HEALTH"),
+	[]byte("live"), []byte("Live"), []byte("LIVE"),
+	[]byte("ready"), []byte("Ready"), []byte("READY"),
+	[]byte("ping"), []byte("Ping"), []byte("PING"),
+}
+
+// healthRouteExtensions are the source files searched for health routes.
+var healthRouteExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".py": true, ".go": true,
+	".java": true, ".kt": true, ".cs": true, ".rb": true, ".php": true,
+}
+
+var (
+	// healthRouteRegex matches a health path given as the first argument of
+	// a route registration: Express, Fastify and Koa routers, Flask, FastAPI,
+	// net/http, Gin, Echo, Spring mappings, ASP.NET MapGet and
+	// MapHealthChecks, Rails and Laravel routes.
+	healthRouteRegex       = regexp.MustCompile(`(?im)(?:\b(?:get|head|all|route|handlefunc|handle|getmapping|requestmapping|mapget|maphealthchecks)\s*\(\s*(?:(?:value|path)\s*=\s*)?|^\s*get\s+)["'` + "`" + `](/?[\w/-]*\b(?:health|healthz|healthcheck|health-check|livez|readyz|liveness|readiness|ready|alive|ping)\b[\w/-]*)["'` + "`" + `]`)
+	railsHealthRegex       = regexp.MustCompile(`(?m)^\s*get\s+["']/?([\w/-]+)["']\s*=>\s*["']rails/health#show["']`)
+	springApplicationRegex = regexp.MustCompile(`^(?:application|bootstrap)(?:-[\w.-]+)?\.(?:ya?ml|properties)$`)
+)
+
+// HealthCheck is a health or readiness endpoint a component declares.
+type HealthCheck struct {
+	Source   string `json:"source"`
+	Kind     string `json:"kind"`
+	Type     string `json:"type,omitempty"`     // probe type: http, tcp, grpc or exec
+	Path     string `json:"path,omitempty"`     // HTTP path
+	Port     string `json:"port,omitempty"`     // port number or name
+	Workload string `json:"workload,omitempty"` // Kubernetes workload (Kind/name) and container
+	File     string `json:"file"`
+}
+
+// SpringActuatorConfig is the health endpoint configuration of a Spring
+// Boot application file.
+type SpringActuatorConfig struct {
+	BasePath   string // management.endpoints.web.base-path
+	HealthPath string // management.endpoints.web.path-mapping.health
+	Port       string // management.server.port
+	Probes     bool   // liveness and readiness groups enabled
+	Excluded   bool   // health excluded from the web exposure
+}
+
+// HealthParser reads health checks from Kubernetes manifests, Spring Boot
+// configuration and route registrations.
+type HealthParser struct{}
+
+// NewHealthParser creates a new health check parser.
+func NewHealthParser() *HealthParser {
+	return &HealthParser{}
+}
+
+// HasKubernetesProbes reports whether a manifest may declare container
+// probes: it sets a kind and names a probe field. It is a cheap check before
+// parsing the manifest.
+func (p *HealthParser) HasKubernetesProbes(content []byte) bool {
+	return bytes.Contains(content, []byte("kind:")) && containsAny(content, kubernetesProbeFields)
+}
+
+// containsAny reports whether content contains one of the tokens.
+func containsAny(content []byte, tokens [][]byte) bool {
+	for _, token := range tokens {
+		if bytes.Contains(content, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseKubernetesProbes reads the liveness, readiness and startup probes
+// of the containers of the workloads of a manifest at rel. Parsing stops at
+// the first document that is not valid YAML.
+func (p *HealthParser) ParseKubernetesProbes(rel string, content []byte) []HealthCheck {
+	var checks []HealthCheck
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj map[string]interface{}
+		if decoder.Decode(&obj) != nil {
+			break
+		}
+		kind, _ := obj["kind"].(string)
+		if !kubernetesWorkloadKinds[kind] {
+			continue
+		}
+		name, _ := lookupMap(obj, "metadata")["name"].(string)
+		containers, _ := podSpec(obj, kind)["containers"].([]interface{})
+		for _, c := range containers {
+			container, _ := c.(map[string]interface{})
+			workload := kind + "/" + name
+			if containerName, _ := container["name"].(string); containerName != "" {
+				workload += "/" + containerName
+			}
+			checks = append(checks, containerProbes(container, workload, rel)...)
+		}
+	}
+	return checks
+}
+
+// podSpec returns the pod spec of a workload.
+func podSpec(obj map[string]interface{}, kind string) map[string]interface{} {
+	switch kind {
+	case "Pod":
+		return lookupMap(obj, "spec")
+	case "CronJob":
+		return lookupMap(obj, "spec", "jobTemplate", "spec", "template", "spec")
+	}
+	return lookupMap(obj, "spec", "template", "spec")
+}
+
+// lookupMap returns the mapping at a path of nested mappings, nil when
+// there is none.
+func lookupMap(value map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		next, ok := value[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = next
+	}
+	return value
+}
+
+func containerProbes(container map[string]interface{}, workload, rel string) []HealthCheck {
+	var checks []HealthCheck
+	for _, probe := range kubernetesProbes {
+		spec := lookupMap(container, probe.field)
+		if spec == nil {
+			continue
+		}
+		check := HealthCheck{Source: HealthSourceKubernetes, Kind: probe.kind, Workload: workload, File: rel}
+		switch {
+		case spec["httpGet"] != nil:
+			check.Type = "http"
+			check.Path, _ = lookupMap(spec, "httpGet")["path"].(string)
+			check.Port = probePort(lookupMap(spec, "httpGet"))
+		case spec["tcpSocket"] != nil:
+			check.Type, check.Port = "tcp", probePort(lookupMap(spec, "tcpSocket"))
+		case spec["grpc"] != nil:
+			check.Type, check.Port = "grpc", probePort(lookupMap(spec, "grpc"))
+		case spec["exec"] != nil:
+			check.Type = "exec"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// probePort returns the port of a probe action, a number or a named
+// container port.
+func probePort(action map[string]interface{}) string {
+	if port, ok := action["port"]; ok && port != nil {
+		return fmt.Sprint(port)
+	}
+	return ""
+}
+
+// ParseSpringActuator reads the management settings of a Spring Boot
+// application.yml or application.properties at rel, of any profile, when it
+// has any.
+func (p *HealthParser) ParseSpringActuator(rel string, content []byte) (SpringActuatorConfig, bool) {
+	if !springApplicationRegex.MatchString(path.Base(rel)) {
+		return SpringActuatorConfig{}, false
+	}
+	var settings map[string]string
+	if strings.HasSuffix(rel, ".properties") {
+		settings = parseProperties(string(content))
+	} else {
+		settings = flattenYAMLSettings(content)
+	}
+	config := SpringActuatorConfig{
+		BasePath:   settings["management.endpoints.web.base-path"],
+		HealthPath: settings["management.endpoints.web.path-mapping.health"],
+		Port:       settings["management.server.port"],
+		Probes:     settings["management.endpoint.health.probes.enabled"] == "true" || settings["management.health.probes.enabled"] == "true",
+		Excluded:   listContains(settings["management.endpoints.web.exposure.exclude"], "health"),
+	}
+	return config, config != SpringActuatorConfig{}
+}
+
+func listContains(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == value || item == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks returns the health endpoint of a Spring Boot application with
+// this configuration, declared in file, and its liveness and readiness
+// groups when probes are enabled.
+func (c SpringActuatorConfig) Checks(file string) []HealthCheck {
+	if c.Excluded {
+		return nil
+	}
+	health := path.Join("/", firstNonEmpty(c.BasePath, "/actuator"), firstNonEmpty(c.HealthPath, "health"))
+	checks := []HealthCheck{{Source: HealthSourceSpring, Kind: HealthKindHealth, Type: "http", Path: health, Port: c.Port, File: file}}
+	if c.Probes {
+		for _, kind := range []string{HealthKindLiveness, HealthKindReadiness} {
+			checks = append(checks, HealthCheck{Source: HealthSourceSpring, Kind: kind, Type: "http", Path: health + "/" + kind, Port: c.Port, File: file})
+		}
+	}
+	return checks
+}
+
+// ParseHealthRoutes reads the health routes registered in a source file at
+// rel: routes whose path names a health, liveness or readiness check, and
+// the Rails health controller.
+func (p *HealthParser) ParseHealthRoutes(rel string, content []byte) []HealthCheck {
+	if !healthRouteExtensions[path.Ext(rel)] || !containsAny(content, healthRouteKeywords) {
+		return nil
+	}
+	var checks []HealthCheck
+	seen := make(map[string]bool)
+	add := func(route string) {
+		route = "/" + strings.TrimPrefix(route, "/")
+		if !seen[route] {
+			seen[route] = true
+			checks = append(checks, HealthCheck{Source: HealthSourceRoute, Kind: HealthRouteKind(route), Type: "http", Path: route, File: rel})
+		}
+	}
+	for _, m := range healthRouteRegex.FindAllSubmatch(content, -1) {
+		add(string(m[1]))
+	}
+	for _, m := range railsHealthRegex.FindAllSubmatch(content, -1) {
+		add(string(m[1]))
+	}
+	return checks
+}
+
+// HealthRouteKind infers the kind of check a health path serves from its
+// name: /livez and /liveness are liveness checks, /readyz and /ready
+// readiness checks, anything else a general health check.
+func HealthRouteKind(route string) string {
+	name := strings.ToLower(path.Base(route))
+	switch {
+	case strings.Contains(name, "live") || strings.Contains(name, "alive"):
+		return HealthKindLiveness
+	case strings.Contains(name, "ready"):
+		return HealthKindReadiness
+	case strings.Contains(name, "start"):
+		return HealthKindStartup
+	}
+	return HealthKindHealth
+}
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKubernetesProbes(t *testing.T) {
+	parser := NewHealthParser()
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: myapp
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: 8080
+          readinessProbe:
+            tcpSocket:
+              port: http
+        - name: sidecar
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: myapp
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: job
+              startupProbe:
+                exec:
+                  command: ["true"]
+`
+	assert.Equal(t, []HealthCheck{
+		{Source: HealthSourceKubernetes, Kind: HealthKindLiveness, Type: "http", Path: "/healthz", Port: "8080", Workload: "Deployment/myapp/web", File: "/k8s/app.yaml"},
+		{Source: HealthSourceKubernetes, Kind: HealthKindReadiness, Type: "tcp", Port: "http", Workload: "Deployment/myapp/web", File: "/k8s/app.yaml"},
+		{Source: HealthSourceKubernetes, Kind: HealthKindStartup, Type: "exec", Workload: "CronJob/cleanup/job", File: "/k8s/app.yaml"},
+	}, parser.ParseKubernetesProbes("/k8s/app.yaml", []byte(manifest)))
+}
+
+func TestHasKubernetesProbes(t *testing.T) {
+	parser := NewHealthParser()
+	assert.True(t, parser.HasKubernetesProbes([]byte("kind: Deployment\nspec:\n  readinessProbe:\n    tcpSocket: {port: 80}\n")))
+	assert.False(t, parser.HasKubernetesProbes([]byte("kind: Deployment\nspec:\n  replicas: 2\n")), "no probe")
+	assert.False(t, parser.HasKubernetesProbes([]byte("livenessProbe:\n  path: /healthz\n")), "no kind")
+}
+
+func TestParseSpringActuator(t *testing.T) {
+	parser := NewHealthParser()
+
+	config, ok := parser.ParseSpringActuator("/src/main/resources/application.yml", []byte(`management:
+  server:
+    port: 9090
+  endpoints:
+    web:
+      base-path: /manage
+  endpoint:
+    health:
+      probes:
+        enabled: true
+`))
+	assert.True(t, ok)
+	assert.Equal(t, []HealthCheck{
+		{Source: HealthSourceSpring, Kind: HealthKindHealth, Type: "http", Path: "/manage/health", Port: "9090", File: "/application.yml"},
+		{Source: HealthSourceSpring, Kind: HealthKindLiveness, Type: "http", Path: "/manage/health/liveness", Port: "9090", File: "/application.yml"},
+		{Source: HealthSourceSpring, Kind: HealthKindReadiness, Type: "http", Path: "/manage/health/readiness", Port: "9090", File: "/application.yml"},
+	}, config.Checks("/application.yml"))
+
+	config, ok = parser.ParseSpringActuator("/application-prod.properties", []byte("management.endpoints.web.path-mapping.health=status\n"))
+	assert.True(t, ok)
+	assert.Equal(t, "/actuator/status", config.Checks("/application-prod.properties")[0].Path)
+
+	config, ok = parser.ParseSpringActuator("/application.properties", []byte("management.endpoints.web.exposure.exclude=env,health\n"))
+	assert.True(t, ok)
+	assert.Empty(t, config.Checks("/application.properties"))
+
+	_, ok = parser.ParseSpringActuator("/application.properties", []byte("server.port=8080\n"))
+	assert.False(t, ok)
+	_, ok = parser.ParseSpringActuator("/config.yml", []byte("management:\n  server:\n    port: 9090\n"))
+	assert.False(t, ok)
+}
+
+func TestParseHealthRoutes(t *testing.T) {
+	parser := NewHealthParser()
+	tests := []struct {
+		rel     string
+		content string
+		want    []string
+	}{
+		{"/server.js", "app.get('/health', (req, res) => res.send('ok'))\nrouter.get(\"/readyz\", ready)\napp.get('/users', list)\n", []string{"/health", "/readyz"}},
+		{"/main.py", "@app.route(\"/api/healthcheck\")\ndef healthcheck():\n    return 'ok'\n", []string{"/api/healthcheck"}},
+		{"/main.go", "mux.HandleFunc(\"/livez\", live)\nr.GET(\"/healthz\", health)\n", []string{"/livez", "/healthz"}},
+		{"/HealthController.java", "@GetMapping(value = \"/ping\")\npublic String ping() { return \"pong\"; }\n", []string{"/ping"}},
+		{"/Program.cs", "app.MapHealthChecks(\"/health/ready\");\n", []string{"/health/ready"}},
+		{"/config/routes.rb", "Rails.application.routes.draw do\n  get \"up\" => \"rails/health#show\", as: :rails_health_check\nend\n", []string{"/up"}},
+		{"/README.md", "app.get('/health')\n", nil},
+		{"/app.js", "app.get('/users', list)\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			var got []string
+			for _, check := range parser.ParseHealthRoutes(tt.rel, []byte(tt.content)) {
+				got = append(got, check.Path)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHealthRouteKind(t *testing.T) {
+	assert.Equal(t, HealthKindLiveness, HealthRouteKind("/livez"))
+	assert.Equal(t, HealthKindReadiness, HealthRouteKind("/health/ready"))
+	assert.Equal(t, HealthKindStartup, HealthRouteKind("/startupz"))
+	assert.Equal(t, HealthKindHealth, HealthRouteKind("/healthz"))
+}
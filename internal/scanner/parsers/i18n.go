@@ -0,0 +1,285 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/json"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Message catalog formats.
+const (
+	CatalogFormatGettext        = "gettext"
+	CatalogFormatResourceBundle = "resource_bundle"
+	CatalogFormatJSON           = "json"
+	CatalogFormatYAML           = "yaml"
+)
+
+// isoLanguages are the ISO 639-1 language codes. A file or folder name is
+// only taken as a locale when its language part is one of them.
+var isoLanguages = toSet(strings.Fields(`
+aa ab ae af ak am an ar as av ay az ba be bg bh bi bm bn bo br bs ca ce ch co cr cs cu cv cy
+da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu
+hy hz ia id ie ig ii ik io is it iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb
+lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny oc oj om
+or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl sm sn so sq sr ss st su sv sw
+ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo za zh zu`))
+
+// localeDirs are folder names that hold message catalogs named by locale.
+var localeDirs = toSet([]string{"locales", "locale", "i18n", "l10n", "lang", "langs", "languages", "translations", "messages"})
+
+var (
+	// localeRegex matches a locale code: language, optional script and
+	// region (en, pt_BR, zh-Hant-TW, sr_Latn).
+	localeRegex = regexp.MustCompile(`^([a-zA-Z]{2})(?:[-_]([A-Za-z]{4}))?(?:[-_]([A-Za-z]{2}|\d{3}))?$`)
+	// bundleNameRegex matches a ResourceBundle file name: base_locale.properties.
+	bundleNameRegex  = regexp.MustCompile(`^[\w.-]+?_([a-z]{2}(?:_[A-Z]{2})?)\.properties$`)
+	poLanguageRegex  = regexp.MustCompile(`(?m)^"Language:\s*([\w-]+)\\n"`)
+	propertyKeyRegex = regexp.MustCompile(`^\s*[^#!\s][^=:]*[=:]`)
+)
+
+// LocaleCatalog is one locale's share of a message catalog file.
+type LocaleCatalog struct {
+	Format   string
+	Locale   string // normalized: language lower case, region upper case (pt-BR); empty for templates
+	Messages int    // translated messages
+}
+
+// I18nParser reads message catalogs: gettext .po/.pot files, Java
+// ResourceBundle .properties files and JSON/YAML locale files (i18next,
+// react-intl, Rails config/locales).
+type I18nParser struct{}
+
+// NewI18nParser creates a new message catalog parser.
+func NewI18nParser() *I18nParser {
+	return &I18nParser{}
+}
+
+// ParseCatalog returns the locales a file provides messages for, or nil when
+// the file is not a message catalog. relPath is the slash-separated path of
+// the file, used to find the locale of files named after it.
+func (p *I18nParser) ParseCatalog(relPath string, content []byte) []LocaleCatalog {
+	name := path.Base(relPath)
+	switch strings.ToLower(path.Ext(name)) {
+	case ".po":
+		return p.parsePO(relPath, content)
+	case ".pot":
+		return []LocaleCatalog{{Format: CatalogFormatGettext, Messages: countPOMessages(content, false)}}
+	case ".properties":
+		return p.parseResourceBundle(name, content)
+	case ".json":
+		return p.parseJSON(relPath, content)
+	case ".yml", ".yaml":
+		return p.parseYAML(relPath, content)
+	}
+	return nil
+}
+
+// parsePO takes the locale from the Language header, else from the
+// .../<locale>/LC_MESSAGES/ folder or the file name (de.po).
+func (p *I18nParser) parsePO(relPath string, content []byte) []LocaleCatalog {
+	locale := NormalizeLocale(firstSubmatch(poLanguageRegex, string(content)))
+	if locale == "" {
+		dir := path.Dir(relPath)
+		if path.Base(dir) == "LC_MESSAGES" {
+			locale = NormalizeLocale(path.Base(path.Dir(dir)))
+		} else {
+			locale = NormalizeLocale(strings.TrimSuffix(path.Base(relPath), path.Ext(relPath)))
+		}
+	}
+	if locale == "" {
+		return nil
+	}
+	return []LocaleCatalog{{Format: CatalogFormatGettext, Locale: locale, Messages: countPOMessages(content, true)}}
+}
+
+// countPOMessages counts the entries of a gettext catalog, skipping the
+// header entry (empty msgid). With translatedOnly, entries with an empty
+// msgstr are skipped too.
+func countPOMessages(content []byte, translatedOnly bool) int {
+	counter := &poCounter{translatedOnly: translatedOnly}
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		counter.line(strings.TrimSpace(scanner.Text()))
+	}
+	counter.flush()
+	return counter.count
+}
+
+// poCounter tracks the entry being read by countPOMessages.
+type poCounter struct {
+	translatedOnly bool
+	count          int
+	emptyID        bool
+	emptyStr       bool
+	inStr          bool
+}
+
+func (c *poCounter) line(line string) {
+	switch {
+	case strings.HasPrefix(line, "msgid "):
+		c.flush()
+		c.emptyID = line == `msgid ""`
+	case strings.HasPrefix(line, "msgstr"):
+		c.inStr = true
+		_, value, _ := strings.Cut(line, " ")
+		c.emptyStr = value == `""`
+	case strings.HasPrefix(line, `"`) && line != `""`:
+		// Continuation line: a multi-line msgid or msgstr is not empty.
+		if c.inStr {
+			c.emptyStr = false
+		} else {
+			c.emptyID = false
+		}
+	}
+}
+
+func (c *poCounter) flush() {
+	if c.inStr && !c.emptyID && (!c.translatedOnly || !c.emptyStr) {
+		c.count++
+	}
+	c.inStr = false
+}
+
+// parseResourceBundle reads a localized ResourceBundle (messages_de.properties).
+// The base bundle has no locale in its name and is skipped.
+func (p *I18nParser) parseResourceBundle(name string, content []byte) []LocaleCatalog {
+	m := bundleNameRegex.FindStringSubmatch(name)
+	if m == nil {
+		return nil
+	}
+	locale := NormalizeLocale(m[1])
+	if locale == "" {
+		return nil
+	}
+	count := 0
+	continued := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !continued && propertyKeyRegex.MatchString(line) {
+			count++
+		}
+		continued = strings.HasSuffix(line, `\`)
+	}
+	return []LocaleCatalog{{Format: CatalogFormatResourceBundle, Locale: locale, Messages: count}}
+}
+
+// parseJSON reads an i18next or react-intl locale file: locales/de.json or
+// locales/de/<namespace>.json.
+func (p *I18nParser) parseJSON(relPath string, content []byte) []LocaleCatalog {
+	locale := pathLocale(relPath)
+	if locale == "" {
+		return nil
+	}
+	var messages interface{}
+	if json.Unmarshal(content, &messages) != nil {
+		return nil
+	}
+	return []LocaleCatalog{{Format: CatalogFormatJSON, Locale: locale, Messages: countMessages(messages)}}
+}
+
+// parseYAML reads Rails locale files (config/locales/*.yml, rooted at the
+// locale key, possibly several) and YAML files in a locale folder.
+func (p *I18nParser) parseYAML(relPath string, content []byte) []LocaleCatalog {
+	rails := strings.Contains("/"+relPath, "/config/locales/")
+	locale := pathLocale(relPath)
+	if !rails && locale == "" {
+		return nil
+	}
+	var doc map[string]interface{}
+	if yaml.Unmarshal(content, &doc) != nil || len(doc) == 0 {
+		return nil
+	}
+
+	// Only Rails files and single-rooted files are keyed by locale; other
+	// top-level keys (id, it, no, ...) may just look like one.
+	var catalogs []LocaleCatalog
+	if rails || len(doc) == 1 {
+		catalogs = localeRootedCatalogs(doc)
+	}
+	if len(catalogs) == 0 && locale != "" {
+		catalogs = append(catalogs, LocaleCatalog{Format: CatalogFormatYAML, Locale: locale, Messages: countMessages(doc)})
+	}
+	return catalogs
+}
+
+// localeRootedCatalogs returns a catalog per top-level locale key.
+func localeRootedCatalogs(doc map[string]interface{}) []LocaleCatalog {
+	var catalogs []LocaleCatalog
+	for key, messages := range doc {
+		if locale := NormalizeLocale(key); locale != "" {
+			catalogs = append(catalogs, LocaleCatalog{Format: CatalogFormatYAML, Locale: locale, Messages: countMessages(messages)})
+		}
+	}
+	return catalogs
+}
+
+// pathLocale returns the locale of a file inside a locale folder, named
+// after the locale (locales/de.json) or in a folder named after it
+// (locales/de/common.json).
+func pathLocale(relPath string) string {
+	dir := path.Dir(relPath)
+	stem := strings.TrimSuffix(path.Base(relPath), path.Ext(relPath))
+	if localeDirs[strings.ToLower(path.Base(dir))] {
+		return NormalizeLocale(stem)
+	}
+	if localeDirs[strings.ToLower(path.Base(path.Dir(dir)))] {
+		return NormalizeLocale(path.Base(dir))
+	}
+	return ""
+}
+
+// countMessages counts the strings of a nested message tree. A react-intl
+// message descriptor ({"defaultMessage": ..., "description": ...}) counts
+// as one message.
+func countMessages(node interface{}) int {
+	switch value := node.(type) {
+	case string:
+		return 1
+	case map[string]interface{}:
+		if _, ok := value["defaultMessage"].(string); ok {
+			return 1
+		}
+		count := 0
+		for _, child := range value {
+			count += countMessages(child)
+		}
+		return count
+	case []interface{}:
+		count := 0
+		for _, child := range value {
+			count += countMessages(child)
+		}
+		return count
+	}
+	return 0
+}
+
+// NormalizeLocale returns a locale code in BCP 47 form (pt-BR, zh-Hant-TW),
+// or "" when s is not a locale of a known language.
+func NormalizeLocale(s string) string {
+	m := localeRegex.FindStringSubmatch(s)
+	if m == nil || !isoLanguages[strings.ToLower(m[1])] {
+		return ""
+	}
+	parts := []string{strings.ToLower(m[1])}
+	if m[2] != "" {
+		parts = append(parts, strings.ToUpper(m[2][:1])+strings.ToLower(m[2][1:]))
+	}
+	if m[3] != "" {
+		parts = append(parts, strings.ToUpper(m[3]))
+	}
+	return strings.Join(parts, "-")
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,120 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestI18nParser_ParseCatalog_PO(t *testing.T) {
+	content := `# French translations
+msgid ""
+msgstr ""
+"Language: fr_FR\n"
+
+msgid "Hello"
+msgstr "Bonjour"
+
+msgid "Bye"
+msgstr ""
+
+msgid ""
+"A long "
+"message"
+msgstr ""
+"Un long "
+"message"
+`
+	catalogs := NewI18nParser().ParseCatalog("po/app.po", []byte(content))
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, LocaleCatalog{Format: CatalogFormatGettext, Locale: "fr-FR", Messages: 2}, catalogs[0])
+}
+
+func TestI18nParser_ParseCatalog_POLocaleFromPath(t *testing.T) {
+	content := []byte("msgid \"Hello\"\nmsgstr \"Hallo\"\n")
+	parser := NewI18nParser()
+
+	catalogs := parser.ParseCatalog("locale/de/LC_MESSAGES/django.po", content)
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, "de", catalogs[0].Locale)
+
+	catalogs = parser.ParseCatalog("po/pt_BR.po", content)
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, "pt-BR", catalogs[0].Locale)
+
+	assert.Nil(t, parser.ParseCatalog("po/messages.po", content))
+}
+
+func TestI18nParser_ParseCatalog_POT(t *testing.T) {
+	content := []byte("msgid \"\"\nmsgstr \"\"\n\nmsgid \"Hello\"\nmsgstr \"\"\n\nmsgid \"Bye\"\nmsgstr \"\"\n")
+	catalogs := NewI18nParser().ParseCatalog("locale/app.pot", content)
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, LocaleCatalog{Format: CatalogFormatGettext, Messages: 2}, catalogs[0])
+}
+
+func TestI18nParser_ParseCatalog_ResourceBundle(t *testing.T) {
+	content := []byte("# greetings\ngreeting=Hello\nfarewell = Good\\\n  bye\n\nerror.notfound: Not found\n")
+	parser := NewI18nParser()
+
+	catalogs := parser.ParseCatalog("src/main/resources/messages_en_US.properties", content)
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, LocaleCatalog{Format: CatalogFormatResourceBundle, Locale: "en-US", Messages: 3}, catalogs[0])
+
+	assert.Nil(t, parser.ParseCatalog("src/main/resources/messages.properties", content), "base bundle has no locale")
+	assert.Nil(t, parser.ParseCatalog("src/main/resources/application_dev.properties", content))
+}
+
+func TestI18nParser_ParseCatalog_JSON(t *testing.T) {
+	parser := NewI18nParser()
+
+	catalogs := parser.ParseCatalog("src/locales/de/common.json", []byte(`{"title": "Hallo", "nav": {"home": "Start", "items": ["A", "B"]}}`))
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, LocaleCatalog{Format: CatalogFormatJSON, Locale: "de", Messages: 4}, catalogs[0])
+
+	// react-intl extracted messages
+	catalogs = parser.ParseCatalog("lang/en.json", []byte(`{"app.title": {"defaultMessage": "Hello", "description": "Title"}, "app.bye": "Bye"}`))
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, 2, catalogs[0].Messages)
+
+	assert.Nil(t, parser.ParseCatalog("src/config/de.json", []byte(`{"a": "b"}`)), "not in a locale folder")
+	assert.Nil(t, parser.ParseCatalog("src/locales/package.json", []byte(`{"a": "b"}`)), "not named after a locale")
+}
+
+func TestI18nParser_ParseCatalog_RailsYAML(t *testing.T) {
+	content := []byte("en:\n  hello: Hello\n  users:\n    title: Users\nde:\n  hello: Hallo\n")
+	catalogs := NewI18nParser().ParseCatalog("config/locales/models.yml", content)
+	require.Len(t, catalogs, 2)
+	byLocale := map[string]int{}
+	for _, catalog := range catalogs {
+		assert.Equal(t, CatalogFormatYAML, catalog.Format)
+		byLocale[catalog.Locale] = catalog.Messages
+	}
+	assert.Equal(t, map[string]int{"en": 2, "de": 1}, byLocale)
+}
+
+func TestI18nParser_ParseCatalog_YAMLInLocaleFolder(t *testing.T) {
+	parser := NewI18nParser()
+	catalogs := parser.ParseCatalog("i18n/it.yml", []byte("id: Identificativo\nno: No\n"))
+	require.Len(t, catalogs, 1)
+	assert.Equal(t, LocaleCatalog{Format: CatalogFormatYAML, Locale: "it", Messages: 2}, catalogs[0], "keys that look like locales are messages")
+
+	assert.Nil(t, parser.ParseCatalog("deploy/en.yml", []byte("en:\n  a: b\n")))
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := map[string]string{
+		"en":         "en",
+		"EN":         "en",
+		"pt_BR":      "pt-BR",
+		"zh-hant-tw": "zh-Hant-TW",
+		"es-419":     "es-419",
+		"sr_Latn":    "sr-Latn",
+		"xx":         "",
+		"common":     "",
+		"":           "",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, NormalizeLocale(input), input)
+	}
+}
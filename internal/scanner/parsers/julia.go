@@ -0,0 +1,159 @@
+package parsers
+
+import (
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// juliaStdlib lists Julia standard libraries. They have UUID entries in
+// Project.toml [deps] but ship with Julia and are not registry packages.
+var juliaStdlib = map[string]bool{
+	"Artifacts": true, "Base64": true, "CRC32c": true, "Dates": true, "DelimitedFiles": true,
+	"Distributed": true, "Downloads": true, "FileWatching": true, "Future": true,
+	"InteractiveUtils": true, "LazyArtifacts": true, "LibGit2": true, "Libdl": true,
+	"LinearAlgebra": true, "Logging": true, "Markdown": true, "Mmap": true, "Pkg": true,
+	"Printf": true, "Profile": true, "REPL": true, "Random": true, "SHA": true,
+	"Serialization": true, "SharedArrays": true, "Sockets": true, "SparseArrays": true,
+	"Statistics": true, "SuiteSparse": true, "TOML": true, "Tar": true, "Test": true,
+	"UUIDs": true, "Unicode": true,
+}
+
+// JuliaProject holds the fields of a Julia Project.toml.
+type JuliaProject struct {
+	Name         string
+	Version      string
+	JuliaCompat  string // [compat] bound on Julia itself, e.g. "1.6"
+	Dependencies []types.Dependency
+}
+
+// JuliaManifest holds the fields of a Julia Manifest.toml.
+type JuliaManifest struct {
+	JuliaVersion string
+	Versions     map[string]string // package name -> resolved version
+}
+
+// JuliaParser parses Julia Project.toml and Manifest.toml files.
+type JuliaParser struct{}
+
+// NewJuliaParser creates a new Julia parser.
+func NewJuliaParser() *JuliaParser {
+	return &JuliaParser{}
+}
+
+type juliaProjectFile struct {
+	Name    string              `toml:"name"`
+	Version string              `toml:"version"`
+	Deps    map[string]string   `toml:"deps"`
+	Extras  map[string]string   `toml:"extras"`
+	Compat  map[string]string   `toml:"compat"`
+	Targets map[string][]string `toml:"targets"`
+}
+
+// ParseProject parses Project.toml. [deps] entries are prod dependencies and
+// [extras] listed in [targets] are test dependencies; the version of each is
+// its [compat] bound. Standard libraries are skipped.
+func (p *JuliaParser) ParseProject(content string) (*JuliaProject, error) {
+	var file juliaProjectFile
+	if _, err := toml.Decode(content, &file); err != nil {
+		return nil, types.NewParseError("Project.toml", err)
+	}
+	project := &JuliaProject{Name: file.Name, Version: file.Version, JuliaCompat: file.Compat["julia"]}
+
+	testDeps := make(map[string]bool)
+	for _, names := range file.Targets {
+		for _, name := range names {
+			testDeps[name] = true
+		}
+	}
+	project.Dependencies = appendJuliaDeps(project.Dependencies, file.Deps, file.Compat, nil, types.ScopeProd)
+	project.Dependencies = appendJuliaDeps(project.Dependencies, file.Extras, file.Compat, testDeps, types.ScopeTest)
+	return project, nil
+}
+
+// appendJuliaDeps appends the non-stdlib entries of a name->UUID table in
+// name order. When only is non-nil, entries not in it are skipped.
+func appendJuliaDeps(deps []types.Dependency, table, compat map[string]string, only map[string]bool, scope string) []types.Dependency {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if juliaStdlib[name] || (only != nil && !only[name]) {
+			continue
+		}
+		deps = append(deps, types.Dependency{
+			Type:       DependencyTypeJulia,
+			Name:       name,
+			Version:    compat[name],
+			Scope:      scope,
+			Direct:     true,
+			SourceFile: "Project.toml",
+		})
+	}
+	return deps
+}
+
+type juliaManifestEntry struct {
+	Version string `toml:"version"`
+}
+
+// ParseManifest parses Manifest.toml in both formats: 2.0 (packages under
+// [[deps.Name]]) and 1.0 (packages as top-level [[Name]] tables).
+// Standard libraries carry no version and are left out.
+func (p *JuliaParser) ParseManifest(content string) (*JuliaManifest, error) {
+	var v2 struct {
+		JuliaVersion   string                          `toml:"julia_version"`
+		ManifestFormat string                          `toml:"manifest_format"`
+		Deps           map[string][]juliaManifestEntry `toml:"deps"`
+	}
+	if _, err := toml.Decode(content, &v2); err != nil {
+		return nil, types.NewParseError("Manifest.toml", err)
+	}
+	entries := v2.Deps
+	if v2.ManifestFormat == "" {
+		var v1 map[string][]juliaManifestEntry
+		if _, err := toml.Decode(content, &v1); err != nil {
+			return nil, types.NewParseError("Manifest.toml", err)
+		}
+		entries = v1
+	}
+
+	manifest := &JuliaManifest{JuliaVersion: v2.JuliaVersion, Versions: make(map[string]string)}
+	for name, versions := range entries {
+		if len(versions) > 0 && versions[0].Version != "" {
+			manifest.Versions[name] = versions[0].Version
+		}
+	}
+	return manifest, nil
+}
+
+// ResolvedDependencies returns the manifest packages not declared in the
+// project as indirect dependencies, sorted by name.
+func (m *JuliaManifest) ResolvedDependencies(declared []types.Dependency) []types.Dependency {
+	seen := make(map[string]bool, len(declared))
+	for _, dep := range declared {
+		seen[dep.Name] = true
+	}
+	names := make([]string, 0, len(m.Versions))
+	for name := range m.Versions {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deps := make([]types.Dependency, 0, len(names))
+	for _, name := range names {
+		deps = append(deps, types.Dependency{
+			Type:       DependencyTypeJulia,
+			Name:       name,
+			Version:    m.Versions[name],
+			Scope:      types.ScopeProd,
+			SourceFile: "Manifest.toml",
+		})
+	}
+	return deps
+}
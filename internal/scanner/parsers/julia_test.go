@@ -0,0 +1,110 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+const juliaProjectFixture = `name = "MyAnalysis"
+uuid = "6f1a8c2e-0000-4000-8000-000000000001"
+version = "0.2.0"
+
+[deps]
+CSV = "336ed68f-0bac-5ca0-87d4-7b16caf5d00b"
+DataFrames = "a93c6f00-e57d-5684-b7b6-d8193f3e46c0"
+LinearAlgebra = "37e2e46d-f89d-539d-b4ee-838fcccc9c8e"
+
+[compat]
+CSV = "0.10"
+julia = "1.9"
+
+[extras]
+Aqua = "4c88cf16-eb10-579e-8560-4a9242c79595"
+Test = "8dfed614-e22c-5e08-85e1-65c5234f0b40"
+
+[targets]
+test = ["Aqua", "Test"]
+`
+
+const juliaManifestV2Fixture = `julia_version = "1.10.2"
+manifest_format = "2.0"
+project_hash = "0000"
+
+[[deps.CSV]]
+deps = ["DataFrames"]
+uuid = "336ed68f-0bac-5ca0-87d4-7b16caf5d00b"
+version = "0.10.14"
+
+[[deps.DataFrames]]
+uuid = "a93c6f00-e57d-5684-b7b6-d8193f3e46c0"
+version = "1.6.1"
+
+[[deps.Tables]]
+uuid = "bd369af6-aec1-5ad0-b16a-f7cc5008161c"
+version = "1.11.1"
+
+[[deps.LinearAlgebra]]
+uuid = "37e2e46d-f89d-539d-b4ee-838fcccc9c8e"
+`
+
+func TestParseJuliaProject(t *testing.T) {
+	project, err := NewJuliaParser().ParseProject(juliaProjectFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.Name != "MyAnalysis" || project.Version != "0.2.0" || project.JuliaCompat != "1.9" {
+		t.Errorf("project = %+v", project)
+	}
+
+	want := []struct{ name, version, scope string }{
+		{"CSV", "0.10", types.ScopeProd},
+		{"DataFrames", "", types.ScopeProd},
+		{"Aqua", "", types.ScopeTest},
+	}
+	if len(project.Dependencies) != len(want) {
+		t.Fatalf("got %d dependencies, want %d: %+v", len(project.Dependencies), len(want), project.Dependencies)
+	}
+	for i, w := range want {
+		dep := project.Dependencies[i]
+		if dep.Name != w.name || dep.Version != w.version || dep.Scope != w.scope || dep.Type != DependencyTypeJulia || !dep.Direct {
+			t.Errorf("dependency %d = %+v, want %s %q %s", i, dep, w.name, w.version, w.scope)
+		}
+	}
+}
+
+func TestParseJuliaManifest_V2(t *testing.T) {
+	manifest, err := NewJuliaParser().ParseManifest(juliaManifestV2Fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.JuliaVersion != "1.10.2" {
+		t.Errorf("JuliaVersion = %q, want 1.10.2", manifest.JuliaVersion)
+	}
+	if manifest.Versions["CSV"] != "0.10.14" || manifest.Versions["Tables"] != "1.11.1" {
+		t.Errorf("versions = %v", manifest.Versions)
+	}
+	if _, ok := manifest.Versions["LinearAlgebra"]; ok {
+		t.Error("stdlib without version should be skipped")
+	}
+
+	resolved := manifest.ResolvedDependencies([]types.Dependency{{Name: "CSV"}, {Name: "DataFrames"}})
+	if len(resolved) != 1 || resolved[0].Name != "Tables" || resolved[0].Direct {
+		t.Errorf("resolved = %+v, want only indirect Tables", resolved)
+	}
+}
+
+func TestParseJuliaManifest_V1(t *testing.T) {
+	content := `[[JSON]]
+deps = ["Dates"]
+uuid = "682c06a0-de6a-54ab-a142-c8b1cf79cde6"
+version = "0.21.4"
+`
+	manifest, err := NewJuliaParser().ParseManifest(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Versions["JSON"] != "0.21.4" {
+		t.Errorf("versions = %v", manifest.Versions)
+	}
+}
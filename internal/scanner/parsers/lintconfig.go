@@ -0,0 +1,81 @@
+package parsers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// LintConfig describes a linter or formatter configuration file and a
+// fingerprint of its content, so configurations can be compared across
+// repositories.
+type LintConfig struct {
+	File        string `json:"file"`
+	Tool        string `json:"tool"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// lintConfigFiles maps exact configuration file names to the tool (tech key)
+// they configure.
+var lintConfigFiles = map[string]string{
+	".editorconfig":     "editorconfig",
+	"ruff.toml":         "ruff",
+	".ruff.toml":        "ruff",
+	".flake8":           "flake8",
+	".pylintrc":         "pylint",
+	"pylintrc":          "pylint",
+	".rubocop.yml":      "rubocop",
+	"phpstan.neon":      "phpstan",
+	".clang-format":     "clangformat",
+	"_clang-format":     "clangformat",
+	"rustfmt.toml":      "rustfmt",
+	".rustfmt.toml":     "rustfmt",
+	"biome.json":        "biomejs",
+	"biome.jsonc":       "biomejs",
+	".oxlintrc.json":    "oxlint",
+	".golangci.yml":     "golangcilint",
+	".golangci.yaml":    "golangcilint",
+	".golangci.toml":    "golangcilint",
+	".golangci.json":    "golangcilint",
+	".stylelintrc":      "stylelint",
+	".stylelintrc.json": "stylelint",
+}
+
+// lintConfigPatterns cover tools with many configuration file variants.
+var lintConfigPatterns = []struct {
+	regex *regexp.Regexp
+	tool  string
+}{
+	{regexp.MustCompile(`^(\.eslintrc(\.(c?js|json|ya?ml))?|eslint\.config\.[cm]?[jt]s)$`), "eslint"},
+	{regexp.MustCompile(`^(\.prettierrc(\.(json5?|ya?ml|[cm]?js|toml))?|prettier\.config\.[cm]?js)$`), "prettier"},
+	{regexp.MustCompile(`^(\.stylelintrc\.(c?js|ya?ml)|stylelint\.config\.[cm]?js)$`), "stylelint"},
+}
+
+// LintConfigTool returns the tool configured by a file name, or false when
+// the file is not a known linter or formatter configuration.
+func LintConfigTool(fileName string) (string, bool) {
+	if tool, ok := lintConfigFiles[fileName]; ok {
+		return tool, true
+	}
+	for _, p := range lintConfigPatterns {
+		if p.regex.MatchString(fileName) {
+			return p.tool, true
+		}
+	}
+	return "", false
+}
+
+// ConfigFingerprint returns a "sha256:<hex>" fingerprint of a configuration
+// file. Line endings, trailing whitespace and trailing blank lines are
+// normalized first, so the same configuration checked out on different
+// platforms or saved by different editors fingerprints the same.
+func ConfigFingerprint(content []byte) string {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	normalized := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	sum := sha256.Sum256([]byte(normalized))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintConfigTool(t *testing.T) {
+	tests := []struct {
+		fileName string
+		tool     string
+		ok       bool
+	}{
+		{".eslintrc", "eslint", true},
+		{".eslintrc.yml", "eslint", true},
+		{"eslint.config.mjs", "eslint", true},
+		{".prettierrc.json", "prettier", true},
+		{"prettier.config.cjs", "prettier", true},
+		{"ruff.toml", "ruff", true},
+		{".golangci.yml", "golangcilint", true},
+		{".editorconfig", "editorconfig", true},
+		{".stylelintrc.yaml", "stylelint", true},
+		{".prettierignore", "", false},
+		{"eslint.json", "", false},
+		{"package.json", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			tool, ok := LintConfigTool(tt.fileName)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.tool, tool)
+		})
+	}
+}
+
+func TestConfigFingerprint(t *testing.T) {
+	base := ConfigFingerprint([]byte("root = true\n\n[*]\nindent_style = space\n"))
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, base)
+
+	// Line endings, trailing whitespace and trailing blank lines do not matter
+	assert.Equal(t, base, ConfigFingerprint([]byte("root = true\r\n\r\n[*]  \r\nindent_style = space\r\n\r\n")))
+
+	// Content changes do
+	assert.NotEqual(t, base, ConfigFingerprint([]byte("root = true\n\n[*]\nindent_style = tab\n")))
+}
@@ -253,7 +253,7 @@ func (p *MavenParser) ParsePomXMLWithBomResolver(content string, pomDir string,
 			if dep.GroupId != "" && dep.ArtifactId != "" {
 				dependencies = append(dependencies, p.newMavenDependency(
 					dep.GroupId+":"+dep.ArtifactId, dep.Version,
-					mapMavenScope(dep.Scope), properties, p.buildMavenMetadata(dep)))
+					NormalizeScope(DependencyTypeMaven, dep.Scope), properties, p.buildMavenMetadata(dep)))
 			}
 		}
 	}
@@ -263,7 +263,7 @@ func (p *MavenParser) ParsePomXMLWithBomResolver(content string, pomDir string,
 		if dep.GroupId != "" && dep.ArtifactId != "" {
 			dependencies = append(dependencies, p.newMavenDependency(
 				dep.GroupId+":"+dep.ArtifactId, dep.Version,
-				mapMavenScope(dep.Scope), properties, p.buildMavenMetadata(dep)))
+				NormalizeScope(DependencyTypeMaven, dep.Scope), properties, p.buildMavenMetadata(dep)))
 		}
 	}
 
@@ -328,22 +328,17 @@ func (p *MavenParser) buildMavenMetadata(dep MavenDependency) map[string]interfa
 		metadata["classifier"] = dep.Classifier
 	}
 
+	// Keep the declared scope, which NormalizeScope maps lossily
+	recordNativeScope(metadata, dep.Scope)
+
 	// Add optional flag if true
 	if dep.Optional {
 		metadata["optional"] = true
 	}
 
 	// Add exclusions if present
-	if len(dep.Exclusions) > 0 {
-		exclusions := make([]string, 0, len(dep.Exclusions))
-		for _, ex := range dep.Exclusions {
-			if ex.GroupId != "" && ex.ArtifactId != "" {
-				exclusions = append(exclusions, ex.GroupId+":"+ex.ArtifactId)
-			}
-		}
-		if len(exclusions) > 0 {
-			metadata["exclusions"] = exclusions
-		}
+	if exclusions := mavenExclusions(dep.Exclusions); len(exclusions) > 0 {
+		metadata["exclusions"] = exclusions
 	}
 
 	// Return nil if no metadata to add
@@ -373,22 +368,15 @@ func (p *MavenParser) parsePluginDependencies(plugins []MavenPlugin, properties
 	return dependencies
 }
 
-// mapMavenScope maps Maven scope to our scope constants
-func mapMavenScope(mavenScope string) string {
-	switch mavenScope {
-	case "test":
-		return types.ScopeDev
-	case "provided", "runtime":
-		return types.ScopeProd
-	case "system":
-		return types.ScopeSystem
-	case "import":
-		return types.ScopeImport // BOM imports
-	case "compile", "":
-		return types.ScopeProd
-	default:
-		return types.ScopeProd
+// mavenExclusions returns the groupId:artifactId of the complete exclusions.
+func mavenExclusions(exclusions []MavenExclusion) []string {
+	var names []string
+	for _, ex := range exclusions {
+		if ex.GroupId != "" && ex.ArtifactId != "" {
+			names = append(names, ex.GroupId+":"+ex.ArtifactId)
+		}
 	}
+	return names
 }
 
 // addProjectCoordinates adds project.* and pom.* properties for the given coordinates
@@ -76,7 +76,7 @@ func (p *MavenDependencyListParser) ParseDependencyList(content string, includeT
 			Type:    DependencyTypeMaven,
 			Name:    groupId + ":" + artifactId,
 			Version: version,
-			Scope:   mapMavenListScope(scope),
+			Scope:   NormalizeScope(DependencyTypeMaven, scope),
 			Direct:  false, // All deps from list are considered resolved (we don't know which are direct)
 		}
 
@@ -87,6 +87,8 @@ func (p *MavenDependencyListParser) ParseDependencyList(content string, includeT
 			metadata["type"] = depType
 		}
 
+		recordNativeScope(metadata, scope)
+
 		// Mark as resolved from dependency list
 		metadata["source"] = "dependency-list"
 
@@ -99,19 +101,3 @@ func (p *MavenDependencyListParser) ParseDependencyList(content string, includeT
 
 	return dependencies
 }
-
-// mapMavenListScope maps Maven scope from dependency list to our scope constants
-func mapMavenListScope(scope string) string {
-	switch scope {
-	case "test":
-		return types.ScopeDev
-	case "provided", "runtime", "compile":
-		return types.ScopeProd
-	case "system":
-		return types.ScopeSystem
-	case "import":
-		return types.ScopeImport
-	default:
-		return types.ScopeProd
-	}
-}
@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"regexp"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// mesonCallRegex matches the project() and dependency() calls of a
+	// meson.build with their arguments, up to the first nested parenthesis.
+	mesonCallRegex = regexp.MustCompile(`\b(project|dependency)\s*\(([^()]*)\)`)
+	// mesonStringRegex matches a single-quoted Meson string.
+	mesonStringRegex = regexp.MustCompile(`'([^']*)'`)
+	// mesonKeywordRegex matches a keyword argument with a string or boolean value.
+	mesonKeywordRegex = regexp.MustCompile(`\b(version|native)\s*:\s*(?:'([^']*)'|(true|false))`)
+)
+
+// MesonProject is what a meson.build declares: the project() name and
+// version, and the dependencies it looks up.
+type MesonProject struct {
+	Name         string
+	Version      string
+	Dependencies []types.Dependency
+}
+
+// ParseMesonBuild reads the project() call and the dependency() lookups of a
+// meson.build. A lookup names its version constraint with version:, and
+// native: true marks a dependency of the build machine, which gets the
+// build scope. Each dependency is reported once.
+func ParseMesonBuild(content string) MesonProject {
+	var project MesonProject
+	seen := make(map[string]bool)
+	for _, m := range mesonCallRegex.FindAllStringSubmatch(stripLineComments(content, '\''), -1) {
+		positional := mesonStringRegex.FindStringSubmatch(m[2])
+		if positional == nil || positional[1] == "" {
+			continue
+		}
+		keywords := mesonKeywords(m[2])
+		if m[1] == "project" {
+			if project.Name == "" {
+				project.Name, project.Version = positional[1], keywords["version"]
+			}
+			continue
+		}
+		if seen[positional[1]] {
+			continue
+		}
+		seen[positional[1]] = true
+		scope := types.ScopeProd
+		if keywords["native"] == "true" {
+			scope = types.ScopeBuild
+		}
+		project.Dependencies = append(project.Dependencies, types.Dependency{
+			Type:     DependencyTypeMeson,
+			Name:     positional[1],
+			Version:  keywords["version"],
+			Scope:    scope,
+			Direct:   true,
+			Metadata: types.NewMetadata(MetadataSourceMesonBuild),
+		})
+	}
+	return project
+}
+
+// mesonKeywords returns the version and native keyword arguments of a call.
+func mesonKeywords(args string) map[string]string {
+	keywords := make(map[string]string)
+	for _, m := range mesonKeywordRegex.FindAllStringSubmatch(args, -1) {
+		keywords[m[1]] = m[2] + m[3]
+	}
+	return keywords
+}
@@ -0,0 +1,33 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMesonBuild(t *testing.T) {
+	project := ParseMesonBuild(`project('myapp', 'cpp',
+  version : '0.3.1',
+  license : 'MIT')
+
+zlib_dep = dependency('zlib', version : '>=1.2.8')
+thread_dep = dependency('threads')
+# dependency('ignored')
+glib_dep = dependency('glib-2.0', native : true)
+zlib_again = dependency('zlib')
+boost_dep = dependency('boost', modules : ['system', 'filesystem'])
+`)
+	assert.Equal(t, "myapp", project.Name)
+	assert.Equal(t, "0.3.1", project.Version)
+	require.Len(t, project.Dependencies, 4)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeMeson, Name: "zlib", Version: ">=1.2.8", Scope: types.ScopeProd, Direct: true,
+		Metadata: types.NewMetadata(MetadataSourceMesonBuild),
+	}, project.Dependencies[0])
+	assert.Equal(t, "threads", project.Dependencies[1].Name)
+	assert.Equal(t, types.ScopeBuild, project.Dependencies[2].Scope)
+	assert.Equal(t, "boost", project.Dependencies[3].Name)
+}
@@ -0,0 +1,345 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of declared network exposure.
+const (
+	NetworkSourceDockerfile = "dockerfile"
+	NetworkSourceCompose    = "compose"
+	NetworkSourceKubernetes = "kubernetes"
+	NetworkSourceSpring     = "spring"
+	NetworkSourceASPNETCore = "aspnetcore"
+	NetworkSourcePuma       = "puma"
+)
+
+var (
+	dockerExposeRegex   = regexp.MustCompile(`(?im)^\s*EXPOSE\s+(.+)$`)
+	dockerPortRegex     = regexp.MustCompile(`^(\d+)(?:-\d+)?(?:/(tcp|udp|sctp))?$`)
+	springPortRegex     = regexp.MustCompile(`(?m)^\s*server\.port\s*[=:]\s*(\S+)`)
+	placeholderRegex    = regexp.MustCompile(`^\$\{[\w.-]+:(\d+)\}$`)
+	pumaPortRegex       = regexp.MustCompile(`(?m)^\s*port\s*\(?\s*(?:ENV\.fetch\([^)]*\)\s*\{\s*)?(\d+)`)
+	pumaBindRegex       = regexp.MustCompile(`(?m)^\s*bind\s*\(?\s*['"](?:tcp|ssl)://([^'"]+)['"]`)
+	kubernetesKindRegex = regexp.MustCompile(`(?m)^kind:\s*(?:Service|Ingress)\s*$`)
+)
+
+// loopbackHosts are addresses that only accept local connections.
+var loopbackHosts = toSet([]string{"localhost", "127.0.0.1", "::1"})
+
+// ExposedPort is a port declared by one source. Port is the port the
+// application listens on; Published is the host, node or service port it is
+// reachable on when that differs.
+type ExposedPort struct {
+	Port      int
+	Protocol  string // tcp, udp, sctp
+	Published int
+	Public    bool // reachable from outside the host or cluster
+	Source    string
+	// Service is the Compose service or Kubernetes Service declaring the
+	// port; Build is the build context of a Compose service, relative to
+	// the compose file.
+	Service string
+	Build   string
+}
+
+// IngressBackend is a Kubernetes Service an Ingress routes to.
+type IngressBackend struct {
+	Service string
+	Hosts   []string
+	TLS     bool
+}
+
+// NetworkParser reads declared ports from container, orchestration and
+// server framework configuration.
+type NetworkParser struct{}
+
+// NewNetworkParser creates a new network exposure parser.
+func NewNetworkParser() *NetworkParser {
+	return &NetworkParser{}
+}
+
+// ParseDockerfile returns the ports of EXPOSE instructions (80, 53/udp,
+// 8000-8010 counts its first port). Variables are skipped.
+func (p *NetworkParser) ParseDockerfile(content string) []ExposedPort {
+	var ports []ExposedPort
+	for _, m := range dockerExposeRegex.FindAllStringSubmatch(content, -1) {
+		for _, field := range strings.Fields(m[1]) {
+			if pm := dockerPortRegex.FindStringSubmatch(field); pm != nil {
+				port, _ := strconv.Atoi(pm[1])
+				ports = append(ports, ExposedPort{Port: port, Protocol: firstNonEmpty(pm[2], "tcp"), Source: NetworkSourceDockerfile})
+			}
+		}
+	}
+	return ports
+}
+
+type composeFile struct {
+	Services map[string]struct {
+		ContainerName string      `yaml:"container_name"`
+		Build         interface{} `yaml:"build"`
+		Ports         []yaml.Node `yaml:"ports"`
+		Expose        []yaml.Node `yaml:"expose"`
+	} `yaml:"services"`
+}
+
+// ParseCompose returns the ports of Compose services. Published ports are
+// public unless bound to a loopback address; expose entries are internal.
+func (p *NetworkParser) ParseCompose(content []byte) []ExposedPort {
+	var doc composeFile
+	if yaml.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+	var ports []ExposedPort
+	for name, service := range doc.Services {
+		base := ExposedPort{Source: NetworkSourceCompose, Service: firstNonEmpty(service.ContainerName, name), Build: composeBuildContext(service.Build)}
+		for _, node := range service.Ports {
+			if port, ok := composePort(&node, base); ok {
+				ports = append(ports, port)
+			}
+		}
+		for _, node := range service.Expose {
+			if pm := dockerPortRegex.FindStringSubmatch(node.Value); pm != nil {
+				port := base
+				port.Port, _ = strconv.Atoi(pm[1])
+				port.Protocol = firstNonEmpty(pm[2], "tcp")
+				ports = append(ports, port)
+			}
+		}
+	}
+	return ports
+}
+
+func composeBuildContext(build interface{}) string {
+	switch value := build.(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		context, _ := value["context"].(string)
+		return firstNonEmpty(context, ".")
+	}
+	return ""
+}
+
+// composePort reads a ports entry in short ("[ip:]published:target[/proto]")
+// or long ({target, published, host_ip, protocol}) syntax.
+func composePort(node *yaml.Node, port ExposedPort) (ExposedPort, bool) {
+	var hostIP, published, target, protocol string
+	if node.Kind == yaml.MappingNode {
+		var long struct {
+			Target    string `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}
+		if node.Decode(&long) != nil {
+			return port, false
+		}
+		hostIP, published, target, protocol = long.HostIP, long.Published, long.Target, long.Protocol
+	} else {
+		spec, proto, _ := strings.Cut(node.Value, "/")
+		parts := strings.Split(spec, ":")
+		target, protocol = parts[len(parts)-1], proto
+		if len(parts) > 1 {
+			published = parts[len(parts)-2]
+		}
+		if len(parts) > 2 {
+			hostIP = strings.Join(parts[:len(parts)-2], ":")
+		}
+	}
+	port.Port, _ = strconv.Atoi(strings.Split(target, "-")[0])
+	port.Published, _ = strconv.Atoi(strings.Split(published, "-")[0])
+	port.Protocol = firstNonEmpty(protocol, "tcp")
+	// A target-only entry publishes the port on a random host port.
+	port.Public = !loopbackHosts[strings.Trim(hostIP, "[]")]
+	if port.Published == port.Port {
+		port.Published = 0
+	}
+	return port, port.Port > 0
+}
+
+type kubernetesObject struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Type  string `yaml:"type"`
+		Ports []struct {
+			Port       int       `yaml:"port"`
+			TargetPort yaml.Node `yaml:"targetPort"`
+			NodePort   int       `yaml:"nodePort"`
+			Protocol   string    `yaml:"protocol"`
+		} `yaml:"ports"`
+		TLS            []interface{} `yaml:"tls"`
+		DefaultBackend *ingressPath  `yaml:"defaultBackend"`
+		Rules          []struct {
+			Host string `yaml:"host"`
+			HTTP struct {
+				Paths []ingressPath `yaml:"paths"`
+			} `yaml:"http"`
+		} `yaml:"rules"`
+	} `yaml:"spec"`
+}
+
+type ingressPath struct {
+	Backend struct {
+		Service struct {
+			Name string `yaml:"name"`
+		} `yaml:"service"`
+		ServiceName string `yaml:"serviceName"` // extensions/v1beta1
+	} `yaml:"backend"`
+}
+
+// IsKubernetesManifest reports whether a YAML file declares a Service or
+// Ingress.
+func (p *NetworkParser) IsKubernetesManifest(content []byte) bool {
+	return kubernetesKindRegex.Match(content)
+}
+
+// ParseKubernetes returns the ports of the Services and the backends of the
+// Ingresses in a (multi-document) manifest. NodePort and LoadBalancer
+// Services are public. Parsing stops at the first document that is not
+// valid YAML (templated Helm charts).
+func (p *NetworkParser) ParseKubernetes(content []byte) ([]ExposedPort, []IngressBackend) {
+	var ports []ExposedPort
+	var backends []IngressBackend
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj kubernetesObject
+		if err := decoder.Decode(&obj); err != nil {
+			if !errors.Is(err, io.EOF) {
+				break
+			}
+			return ports, backends
+		}
+		switch obj.Kind {
+		case "Service":
+			ports = append(ports, kubernetesServicePorts(&obj)...)
+		case "Ingress":
+			backends = append(backends, ingressBackends(&obj)...)
+		}
+	}
+	return ports, backends
+}
+
+func kubernetesServicePorts(obj *kubernetesObject) []ExposedPort {
+	public := obj.Spec.Type == "NodePort" || obj.Spec.Type == "LoadBalancer"
+	var ports []ExposedPort
+	for _, sp := range obj.Spec.Ports {
+		port := ExposedPort{Port: sp.Port, Protocol: strings.ToLower(firstNonEmpty(sp.Protocol, "TCP")), Public: public, Source: NetworkSourceKubernetes, Service: obj.Metadata.Name}
+		if target, err := strconv.Atoi(sp.TargetPort.Value); err == nil && target != sp.Port {
+			port.Port, port.Published = target, sp.Port
+		}
+		if sp.NodePort > 0 {
+			port.Published = sp.NodePort
+		}
+		if port.Port > 0 {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+func ingressBackends(obj *kubernetesObject) []IngressBackend {
+	hostsByService := make(map[string][]string)
+	var services []string
+	add := func(path *ingressPath, host string) {
+		name := firstNonEmpty(path.Backend.Service.Name, path.Backend.ServiceName)
+		if name == "" {
+			return
+		}
+		if _, ok := hostsByService[name]; !ok {
+			services = append(services, name)
+		}
+		hostsByService[name] = append(hostsByService[name], host)
+	}
+	if obj.Spec.DefaultBackend != nil {
+		add(obj.Spec.DefaultBackend, "")
+	}
+	for _, rule := range obj.Spec.Rules {
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i], rule.Host)
+		}
+	}
+	backends := make([]IngressBackend, 0, len(services))
+	for _, name := range services {
+		backends = append(backends, IngressBackend{Service: name, Hosts: sortedUnique(hostsByService[name]), TLS: len(obj.Spec.TLS) > 0})
+	}
+	return backends
+}
+
+// ParseSpringConfig returns the server.port of a Spring Boot
+// application.properties or application.yml (nested or as a flat
+// "server.port" key). A ${PORT:8080} placeholder yields its default.
+func (p *NetworkParser) ParseSpringConfig(yamlFile bool, content []byte) []ExposedPort {
+	value := firstSubmatch(springPortRegex, string(content))
+	if yamlFile {
+		var doc struct {
+			Server struct {
+				Port string `yaml:"port"`
+			} `yaml:"server"`
+		}
+		if yaml.Unmarshal(content, &doc) == nil && doc.Server.Port != "" {
+			value = doc.Server.Port
+		}
+	}
+	if m := placeholderRegex.FindStringSubmatch(value); m != nil {
+		value = m[1]
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port <= 0 {
+		return nil
+	}
+	return []ExposedPort{{Port: port, Protocol: "tcp", Source: NetworkSourceSpring}}
+}
+
+// ParseLaunchSettings returns the ports of the applicationUrl entries of an
+// ASP.NET Core Properties/launchSettings.json.
+func (p *NetworkParser) ParseLaunchSettings(content []byte) []ExposedPort {
+	var doc struct {
+		Profiles map[string]struct {
+			ApplicationURL string `json:"applicationUrl"`
+		} `json:"profiles"`
+	}
+	if json.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+	var ports []ExposedPort
+	for _, profile := range doc.Profiles {
+		for _, raw := range strings.Split(profile.ApplicationURL, ";") {
+			u, err := url.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				continue
+			}
+			if port, err := strconv.Atoi(u.Port()); err == nil {
+				ports = append(ports, ExposedPort{Port: port, Protocol: "tcp", Source: NetworkSourceASPNETCore})
+			}
+		}
+	}
+	return ports
+}
+
+// ParsePuma returns the port and bind settings of a Puma config/puma.rb.
+func (p *NetworkParser) ParsePuma(content string) []ExposedPort {
+	var ports []ExposedPort
+	for _, m := range pumaPortRegex.FindAllStringSubmatch(content, -1) {
+		port, _ := strconv.Atoi(m[1])
+		ports = append(ports, ExposedPort{Port: port, Protocol: "tcp", Source: NetworkSourcePuma})
+	}
+	for _, m := range pumaBindRegex.FindAllStringSubmatch(content, -1) {
+		if _, port := splitHostPort(m[1], 0); port > 0 {
+			ports = append(ports, ExposedPort{Port: port, Protocol: "tcp", Source: NetworkSourcePuma})
+		}
+	}
+	return ports
+}
@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkParser_ParseDockerfile(t *testing.T) {
+	ports := NewNetworkParser().ParseDockerfile("FROM node:20\nEXPOSE 3000 9229/tcp\nexpose 53/udp\nEXPOSE $PORT\n")
+	assert.Equal(t, []ExposedPort{
+		{Port: 3000, Protocol: "tcp", Source: NetworkSourceDockerfile},
+		{Port: 9229, Protocol: "tcp", Source: NetworkSourceDockerfile},
+		{Port: 53, Protocol: "udp", Source: NetworkSourceDockerfile},
+	}, ports)
+}
+
+func TestNetworkParser_ParseCompose(t *testing.T) {
+	content := `services:
+  api:
+    build: ./api
+    ports:
+      - "8080:3000"
+      - "127.0.0.1:9229:9229"
+  dns:
+    image: coredns/coredns
+    ports:
+      - target: 53
+        published: 5353
+        protocol: udp
+  cache:
+    image: redis:7
+    expose:
+      - "6379"
+`
+	ports := NewNetworkParser().ParseCompose([]byte(content))
+	byService := make(map[string][]ExposedPort)
+	for _, port := range ports {
+		byService[port.Service] = append(byService[port.Service], port)
+	}
+	assert.Equal(t, []ExposedPort{
+		{Port: 3000, Protocol: "tcp", Published: 8080, Public: true, Source: NetworkSourceCompose, Service: "api", Build: "./api"},
+		{Port: 9229, Protocol: "tcp", Source: NetworkSourceCompose, Service: "api", Build: "./api"},
+	}, byService["api"])
+	assert.Equal(t, []ExposedPort{{Port: 53, Protocol: "udp", Published: 5353, Public: true, Source: NetworkSourceCompose, Service: "dns"}}, byService["dns"])
+	assert.Equal(t, []ExposedPort{{Port: 6379, Protocol: "tcp", Source: NetworkSourceCompose, Service: "cache"}}, byService["cache"])
+}
+
+func TestNetworkParser_ParseKubernetes(t *testing.T) {
+	content := `apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  type: ClusterIP
+  ports:
+    - port: 80
+      targetPort: 3000
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: metrics
+spec:
+  type: NodePort
+  ports:
+    - port: 9100
+      targetPort: http-metrics
+      nodePort: 30910
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  tls:
+    - hosts: [app.example.com]
+  rules:
+    - host: app.example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: api
+                port:
+                  number: 80
+`
+	parser := NewNetworkParser()
+	require.True(t, parser.IsKubernetesManifest([]byte(content)))
+	ports, backends := parser.ParseKubernetes([]byte(content))
+	assert.Equal(t, []ExposedPort{
+		{Port: 3000, Protocol: "tcp", Published: 80, Source: NetworkSourceKubernetes, Service: "api"},
+		{Port: 9100, Protocol: "tcp", Published: 30910, Public: true, Source: NetworkSourceKubernetes, Service: "metrics"},
+	}, ports)
+	assert.Equal(t, []IngressBackend{{Service: "api", Hosts: []string{"app.example.com"}, TLS: true}}, backends)
+}
+
+func TestNetworkParser_ServerFrameworks(t *testing.T) {
+	parser := NewNetworkParser()
+	assert.Equal(t, []ExposedPort{{Port: 8081, Protocol: "tcp", Source: NetworkSourceSpring}},
+		parser.ParseSpringConfig(false, []byte("spring.application.name=myapp\nserver.port=${PORT:8081}\n")))
+	assert.Equal(t, []ExposedPort{{Port: 9090, Protocol: "tcp", Source: NetworkSourceSpring}},
+		parser.ParseSpringConfig(true, []byte("server:\n  port: 9090\n")))
+	assert.Nil(t, parser.ParseSpringConfig(true, []byte("spring:\n  application:\n    name: myapp\n")))
+
+	assert.ElementsMatch(t, []ExposedPort{
+		{Port: 7001, Protocol: "tcp", Source: NetworkSourceASPNETCore},
+		{Port: 5001, Protocol: "tcp", Source: NetworkSourceASPNETCore},
+	}, parser.ParseLaunchSettings([]byte(`{"profiles": {"MyApp": {"applicationUrl": "https://localhost:7001;http://localhost:5001"}}}`)))
+
+	assert.Equal(t, []ExposedPort{
+		{Port: 3000, Protocol: "tcp", Source: NetworkSourcePuma},
+		{Port: 9292, Protocol: "tcp", Source: NetworkSourcePuma},
+	}, parser.ParsePuma("port ENV.fetch(\"PORT\") { 3000 }\nbind \"tcp://0.0.0.0:9292\"\n"))
+}
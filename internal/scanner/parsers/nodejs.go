@@ -16,21 +16,22 @@ func NewNodeJSParser() *NodeJSParser {
 
 // PackageJSON represents the structure of package.json
 type PackageJSON struct {
-	Name            string            `json:"name"`
-	Dependencies    map[string]string `json:"dependencies"`
-	DevDependencies map[string]string `json:"devDependencies"`
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
 }
 
 // ParsePackageJSON parses package.json content and returns the parsed structure
 func (p *NodeJSParser) ParsePackageJSON(content []byte) (*PackageJSON, error) {
 	var packageJSON PackageJSON
 	if err := json.Unmarshal(content, &packageJSON); err != nil {
-		return nil, err
+		return nil, types.NewParseError("package.json", err)
 	}
 	return &packageJSON, nil
 }
 
-// ExtractDependencies extracts all dependency names from package.json (dependencies + devDependencies)
+// ExtractDependencies extracts all dependency names from package.json (dependencies + devDependencies + optionalDependencies)
 func (p *NodeJSParser) ExtractDependencies(pkg *PackageJSON) []string {
 	dependencies := make([]string, 0)
 
@@ -44,6 +45,15 @@ func (p *NodeJSParser) ExtractDependencies(pkg *PackageJSON) []string {
 		dependencies = append(dependencies, name)
 	}
 
+	// Add optional dependencies not already listed
+	for name := range pkg.OptionalDependencies {
+		_, prod := pkg.Dependencies[name]
+		_, dev := pkg.DevDependencies[name]
+		if !prod && !dev {
+			dependencies = append(dependencies, name)
+		}
+	}
+
 	return dependencies
 }
 
@@ -53,9 +63,13 @@ func (p *NodeJSParser) CreateDependencies(pkg *PackageJSON, depNames []string) [
 
 	for _, name := range depNames {
 		// Determine version and scope in single pass
+		// npm lets an optionalDependencies entry override the same name in dependencies
 		scope := types.ScopeProd
 		version := pkg.Dependencies[name]
-		if version == "" {
+		if optional, ok := pkg.OptionalDependencies[name]; ok {
+			version = optional
+			scope = types.ScopeOptional
+		} else if version == "" {
 			version = pkg.DevDependencies[name]
 			scope = types.ScopeDev
 		}
@@ -113,6 +113,7 @@ func TestParsePackageJSON(t *testing.T) {
 
 			if tt.expectError {
 				assert.Error(t, err, "Should return error for invalid JSON")
+				assert.ErrorIs(t, err, types.ErrParse)
 				assert.Nil(t, result, "Should return nil on error")
 			} else {
 				assert.NoError(t, err, "Should not return error for valid JSON")
@@ -167,6 +168,20 @@ func TestExtractDependencies(t *testing.T) {
 			},
 			expectedDeps: []string{"jest"},
 		},
+		{
+			name: "optionalDependencies",
+			packageJSON: &PackageJSON{
+				Dependencies: map[string]string{
+					"express":  "^4.18.0",
+					"fsevents": "^2.3.0",
+				},
+				OptionalDependencies: map[string]string{
+					"fsevents":   "^2.3.0",
+					"bufferutil": "^4.0.0",
+				},
+			},
+			expectedDeps: []string{"express", "fsevents", "bufferutil"},
+		},
 		{
 			name: "no dependencies",
 			packageJSON: &PackageJSON{
@@ -253,6 +268,25 @@ func TestCreateDependencies(t *testing.T) {
 				{Type: "npm", Name: "jest", Version: "^29.0.0"},
 			},
 		},
+		{
+			name: "optional dependencies",
+			packageJSON: &PackageJSON{
+				Dependencies: map[string]string{
+					"express":  "^4.18.0",
+					"fsevents": "^2.3.0",
+				},
+				OptionalDependencies: map[string]string{
+					"fsevents":   "^2.3.2",
+					"bufferutil": "^4.0.0",
+				},
+			},
+			depNames: []string{"express", "fsevents", "bufferutil"},
+			expectedDeps: []types.Dependency{
+				{Type: "npm", Name: "express", Version: "^4.18.0", Scope: types.ScopeProd},
+				{Type: "npm", Name: "fsevents", Version: "^2.3.2", Scope: types.ScopeOptional},
+				{Type: "npm", Name: "bufferutil", Version: "^4.0.0", Scope: types.ScopeOptional},
+			},
+		},
 		{
 			name: "non-existent dependency",
 			packageJSON: &PackageJSON{
@@ -285,6 +319,9 @@ func TestCreateDependencies(t *testing.T) {
 				assert.Equal(t, expectedDep.Type, result[i].Type, "Should have correct type")
 				assert.Equal(t, expectedDep.Name, result[i].Name, "Should have correct name")
 				assert.Equal(t, expectedDep.Version, result[i].Version, "Should have correct version")
+				if expectedDep.Scope != "" {
+					assert.Equal(t, expectedDep.Scope, result[i].Scope, "Should have correct scope")
+				}
 			}
 		})
 	}
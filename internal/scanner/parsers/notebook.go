@@ -0,0 +1,172 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Notebook summarizes a Jupyter notebook (.ipynb): its kernel, cell counts
+// and the packages its code cells import or install.
+type Notebook struct {
+	File          string   `json:"file"`
+	Kernel        string   `json:"kernel,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Cells         int      `json:"cells"`
+	CodeCells     int      `json:"code_cells"`
+	MarkdownCells int      `json:"markdown_cells"`
+	CodeLines     int      `json:"code_lines"`
+	Imports       []string `json:"imports,omitempty"`
+
+	// Code holds the source of all code cells, one cell after the other,
+	// for code statistics.
+	Code string `json:"-"`
+}
+
+var (
+	// pythonImportRegex matches "import a, b.c" and "from a.b import c".
+	pythonImportRegex = regexp.MustCompile(`^\s*(?:from\s+([A-Za-z_][\w.]*)\s+import\b|import\s+([A-Za-z_][\w.]*(?:\s+as\s+\w+)?(?:\s*,\s*[A-Za-z_][\w.]*(?:\s+as\s+\w+)?)*))`)
+	// notebookInstallRegex matches "!pip install x y" and "%pip install x".
+	notebookInstallRegex = regexp.MustCompile(`^\s*[!%](?:pip|pip3|conda|mamba)\s+install\s+(.+)$`)
+	// rLibraryRegex matches library(x) and require(x) calls in R kernels.
+	rLibraryRegex = regexp.MustCompile(`\b(?:library|require)\(\s*["']?([A-Za-z][\w.]*)`)
+)
+
+// NotebookParser handles Jupyter notebook parsing
+type NotebookParser struct{}
+
+// NewNotebookParser creates a new notebook parser
+func NewNotebookParser() *NotebookParser {
+	return &NotebookParser{}
+}
+
+// ParseNotebook parses a notebook in nbformat 4. Imports are the top-level
+// module names for Python kernels and package names for R kernels, plus the
+// packages installed with !pip / %pip magics. Cell outputs are ignored.
+func (p *NotebookParser) ParseNotebook(content []byte) (*Notebook, error) {
+	var nb struct {
+		Cells    []notebookCell `json:"cells"`
+		Metadata struct {
+			Kernelspec struct {
+				Name     string `json:"name"`
+				Language string `json:"language"`
+			} `json:"kernelspec"`
+			LanguageInfo struct {
+				Name string `json:"name"`
+			} `json:"language_info"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return nil, types.NewParseError("notebook", err)
+	}
+
+	notebook := &Notebook{
+		Kernel:   nb.Metadata.Kernelspec.Name,
+		Language: strings.ToLower(nb.Metadata.LanguageInfo.Name),
+		Cells:    len(nb.Cells),
+	}
+	if notebook.Language == "" {
+		notebook.Language = strings.ToLower(nb.Metadata.Kernelspec.Language)
+	}
+
+	var code []string
+	imports := make(map[string]bool)
+	for _, cell := range nb.Cells {
+		switch cell.CellType {
+		case "markdown":
+			notebook.MarkdownCells++
+		case "code":
+			notebook.CodeCells++
+			source := cell.text()
+			notebook.CodeLines += countCodeLines(source)
+			code = append(code, source)
+			collectNotebookImports(source, notebook.Language, imports)
+		}
+	}
+	notebook.Code = strings.Join(code, "\n")
+	for name := range imports {
+		notebook.Imports = append(notebook.Imports, name)
+	}
+	sort.Strings(notebook.Imports)
+	return notebook, nil
+}
+
+// notebookCell is a notebook cell; source is a string or a list of lines.
+type notebookCell struct {
+	CellType string          `json:"cell_type"`
+	Source   json.RawMessage `json:"source"`
+}
+
+func (c notebookCell) text() string {
+	var lines []string
+	if err := json.Unmarshal(c.Source, &lines); err == nil {
+		return strings.Join(lines, "")
+	}
+	var text string
+	_ = json.Unmarshal(c.Source, &text)
+	return text
+}
+
+// countCodeLines counts non-blank lines that are not magics or comments.
+func countCodeLines(source string) int {
+	count := 0
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "%") && !strings.HasPrefix(trimmed, "!") {
+			count++
+		}
+	}
+	return count
+}
+
+func collectNotebookImports(source, language string, imports map[string]bool) {
+	for _, line := range strings.Split(source, "\n") {
+		if m := notebookInstallRegex.FindStringSubmatch(line); m != nil {
+			for _, pkg := range strings.Fields(m[1]) {
+				if !strings.HasPrefix(pkg, "-") {
+					imports[requirementName(pkg)] = true
+				}
+			}
+			continue
+		}
+		if language == "r" {
+			for _, m := range rLibraryRegex.FindAllStringSubmatch(line, -1) {
+				imports[m[1]] = true
+			}
+			continue
+		}
+		if m := pythonImportRegex.FindStringSubmatch(line); m != nil {
+			for _, module := range pythonImportedModules(m[1], m[2]) {
+				imports[module] = true
+			}
+		}
+	}
+}
+
+// pythonImportedModules returns the top-level modules of an import statement.
+func pythonImportedModules(fromModule, importList string) []string {
+	if fromModule != "" {
+		return []string{strings.Split(fromModule, ".")[0]}
+	}
+	var modules []string
+	for _, part := range strings.Split(importList, ",") {
+		name := strings.Fields(strings.TrimSpace(part))
+		if len(name) > 0 {
+			modules = append(modules, strings.Split(name[0], ".")[0])
+		}
+	}
+	return modules
+}
+
+// requirementName strips version specifiers and extras from a pip
+// requirement ("pandas>=2.0", "uvicorn[standard]").
+func requirementName(requirement string) string {
+	requirement = strings.Trim(requirement, `"'`)
+	if i := strings.IndexAny(requirement, "<>=!~[;@ "); i >= 0 {
+		requirement = requirement[:i]
+	}
+	return requirement
+}
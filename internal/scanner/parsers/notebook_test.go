@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pythonNotebook = `{
+ "cells": [
+  {"cell_type": "markdown", "metadata": {}, "source": ["# Analysis\n", "Load the data."]},
+  {"cell_type": "code", "metadata": {}, "outputs": [], "source": ["%pip install pandas>=2.0 'seaborn[stats]'\n", "import numpy as np, os.path\n", "from sklearn.model_selection import train_test_split\n", "\n", "# split\n", "df = np.zeros(3)"]},
+  {"cell_type": "code", "metadata": {}, "outputs": [{"output_type": "stream", "text": ["import fake\n"]}], "source": "import torch\nx = torch.ones(2)\n"},
+  {"cell_type": "raw", "metadata": {}, "source": []}
+ ],
+ "metadata": {
+  "kernelspec": {"display_name": "Python 3", "language": "python", "name": "python3"},
+  "language_info": {"name": "python", "version": "3.11.4"}
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}`
+
+func TestNotebookParser_ParseNotebook(t *testing.T) {
+	nb, err := NewNotebookParser().ParseNotebook([]byte(pythonNotebook))
+	require.NoError(t, err)
+
+	assert.Equal(t, "python3", nb.Kernel)
+	assert.Equal(t, "python", nb.Language)
+	assert.Equal(t, 4, nb.Cells)
+	assert.Equal(t, 2, nb.CodeCells)
+	assert.Equal(t, 1, nb.MarkdownCells)
+	assert.Equal(t, 5, nb.CodeLines)
+	assert.Equal(t, []string{"numpy", "os", "pandas", "seaborn", "sklearn", "torch"}, nb.Imports)
+	assert.Contains(t, nb.Code, "x = torch.ones(2)")
+	assert.NotContains(t, nb.Code, "import fake", "outputs are not code")
+}
+
+func TestNotebookParser_RKernel(t *testing.T) {
+	content := `{"cells": [{"cell_type": "code", "source": ["library(ggplot2)\n", "require(\"dplyr\")\n"]}],
+  "metadata": {"kernelspec": {"name": "ir", "language": "R"}}}`
+	nb, err := NewNotebookParser().ParseNotebook([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "r", nb.Language)
+	assert.Equal(t, []string{"dplyr", "ggplot2"}, nb.Imports)
+}
+
+func TestNotebookParser_Invalid(t *testing.T) {
+	_, err := NewNotebookParser().ParseNotebook([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NotificationEvidence records a notification provider setting found in a
+// configuration file: the sender domain of its From address or the API or
+// SMTP endpoint it talks to. Provider is empty for sender settings that do
+// not name a provider (MAIL_FROM_ADDRESS, DEFAULT_FROM_EMAIL).
+type NotificationEvidence struct {
+	Provider     string `json:"provider,omitempty"`
+	SenderDomain string `json:"sender_domain,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	File         string `json:"file"`
+}
+
+// notificationEndpoints match provider API and SMTP hosts. The first
+// submatch is the host; URLs are matched from the host on, so userinfo is
+// never captured.
+var notificationEndpoints = []struct {
+	provider string
+	regex    *regexp.Regexp
+}{
+	{"sendgrid", regexp.MustCompile(`(?i)\b((?:api|smtp)\.sendgrid\.(?:com|net))\b`)},
+	{"mailgun", regexp.MustCompile(`(?i)\b((?:api(?:\.eu)?\.mailgun\.net)|smtp(?:\.eu)?\.mailgun\.org)\b`)},
+	{"aws.ses", regexp.MustCompile(`(?i)\b(email(?:-smtp)?\.[a-z0-9-]+\.amazonaws\.com)\b`)},
+	{"aws.sns", regexp.MustCompile(`(?i)\b(sns\.[a-z0-9-]+\.amazonaws\.com)\b`)},
+	{"mailjet", regexp.MustCompile(`(?i)\b((?:api|in-v3)\.mailjet\.com)\b`)},
+	{"brevo", regexp.MustCompile(`(?i)\b((?:api|smtp-relay)\.(?:brevo|sendinblue)\.com)\b`)},
+	{"resend", regexp.MustCompile(`(?i)\b((?:api|smtp)\.resend\.com)\b`)},
+	{"twilio", regexp.MustCompile(`(?i)\b((?:api|messaging|verify|conversations|notify)\.twilio\.com)\b`)},
+	{"firebase.messaging", regexp.MustCompile(`(?i)\b(fcm\.googleapis\.com)\b`)},
+	{"apns", regexp.MustCompile(`(?i)\b(api(?:\.sandbox|\.development)?\.push\.apple\.com)\b`)},
+}
+
+var (
+	// notificationSenderRegex matches settings holding a From or sender
+	// address ("SENDGRID_FROM_EMAIL=noreply@example.com", "from: My App
+	// <hello@example.com>"). Only the domain is captured.
+	notificationSenderRegex = regexp.MustCompile(`(?im)^\s*["']?([\w.-]*(?:from|sender)[\w.-]*)["']?\s*[:=]\s*["']?(?:[^<@"'\n]*<)?[^@\s"'<\n]*@([a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,})\b`)
+	// mailgunDomainRegex matches the sending domain of a Mailgun account.
+	mailgunDomainRegex = regexp.MustCompile(`(?im)^\s*["']?mailgun[\w.]*domain["']?\s*[:=]\s*["']?([a-z0-9-]+(?:\.[a-z0-9-]+)+)\b`)
+)
+
+// notificationKeyProviders map setting name fragments to the provider they
+// configure.
+var notificationKeyProviders = []struct {
+	fragment string
+	provider string
+}{
+	{"sendgrid", "sendgrid"}, {"mailgun", "mailgun"}, {"ses", "aws.ses"}, {"mailjet", "mailjet"},
+	{"brevo", "brevo"}, {"sendinblue", "brevo"}, {"resend", "resend"}, {"twilio", "twilio"},
+}
+
+// FindNotificationConfig returns the notification provider settings of a
+// configuration file (dotenv, application.yml, appsettings.json, ...), one
+// entry per distinct finding. File is left for the caller to set.
+func FindNotificationConfig(content string) []NotificationEvidence {
+	var found []NotificationEvidence
+	seen := make(map[NotificationEvidence]bool)
+	add := func(ev NotificationEvidence) {
+		if !seen[ev] {
+			seen[ev] = true
+			found = append(found, ev)
+		}
+	}
+
+	for _, p := range notificationEndpoints {
+		for _, m := range p.regex.FindAllStringSubmatch(content, -1) {
+			add(NotificationEvidence{Provider: p.provider, Endpoint: strings.ToLower(m[1])})
+		}
+	}
+	for _, m := range notificationSenderRegex.FindAllStringSubmatch(content, -1) {
+		add(NotificationEvidence{Provider: providerOfSetting(m[1]), SenderDomain: strings.ToLower(m[2])})
+	}
+	for _, m := range mailgunDomainRegex.FindAllStringSubmatch(content, -1) {
+		add(NotificationEvidence{Provider: "mailgun", SenderDomain: strings.ToLower(m[1])})
+	}
+	return found
+}
+
+// providerOfSetting returns the provider a setting name refers to, matching
+// whole name segments (SES_FROM, aws.ses.sender) so that fragments inside
+// other words do not count.
+func providerOfSetting(key string) string {
+	segments := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return r == '_' || r == '.' || r == '-'
+	})
+	for _, entry := range notificationKeyProviders {
+		for _, segment := range segments {
+			if segment == entry.fragment {
+				return entry.provider
+			}
+		}
+	}
+	return ""
+}
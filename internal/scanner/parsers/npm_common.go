@@ -95,25 +95,10 @@ func (f *DependencyFilter) ShouldInclude(name string) bool {
 // GetScope returns the scope for a dependency, or empty string for transitive dependencies
 func (f *DependencyFilter) GetScope(name string) string {
 	scopeInfo, exists := f.directDeps[name]
-	if !exists {
+	if !exists || scopeInfo == (DependencyScope{}) {
 		return ""
 	}
-
-	// Return scope in priority order: peer > optional > dev > prod
-	if scopeInfo.peer {
-		return types.ScopePeer
-	}
-	if scopeInfo.optional {
-		return types.ScopeOptional
-	}
-	if scopeInfo.dev {
-		return types.ScopeDev
-	}
-	if scopeInfo.prod {
-		return types.ScopeProd
-	}
-
-	return ""
+	return npmScope(scopeInfo.peer, scopeInfo.optional, scopeInfo.dev)
 }
 
 // CreateDependency creates a types.Dependency if the name should be included
@@ -152,6 +152,9 @@ func buildDependencyScopeMaps(packageJSON *PackageJSON, content []byte) dependen
 	if enhancedPkg, err := parseEnhancedPackageJSON(content); err == nil {
 		for name := range enhancedPkg.PeerDependencies {
 			maps.peerDeps[name] = true
+			if enhancedPkg.PeerDependenciesMeta[name].Optional {
+				maps.optionalDeps[name] = true
+			}
 		}
 		for name := range enhancedPkg.OptionalDependencies {
 			maps.optionalDeps[name] = true
@@ -183,7 +186,7 @@ func parsePackagesV3(packages map[string]PackageInfo, options ParsePackageLockOp
 			continue
 		}
 
-		scope := determineScopeFromLockfile(name, pkg, maps.prodDeps, maps.devDeps, maps.peerDeps, maps.optionalDeps)
+		scope := determineScopeFromLockfile(name, pkg, maps.devDeps, maps.peerDeps, maps.optionalDeps)
 		isDirect := isDirectDependency(name, maps.prodDeps, maps.devDeps, maps.peerDeps, maps.optionalDeps)
 
 		dependencies = append(dependencies, types.Dependency{
@@ -236,7 +239,7 @@ func parseTopLevelDependenciesV2(dependencies map[string]PackageInfo, maps depen
 			continue
 		}
 
-		scope := determineScopeFromLockfile(name, PackageInfo{Dev: dep.Dev, Optional: dep.Optional}, maps.prodDeps, maps.devDeps, maps.peerDeps, maps.optionalDeps)
+		scope := determineScopeFromLockfile(name, PackageInfo{Dev: dep.Dev, Optional: dep.Optional}, maps.devDeps, maps.peerDeps, maps.optionalDeps)
 		isDirect := isDirectDependency(name, maps.prodDeps, maps.devDeps, maps.peerDeps, maps.optionalDeps)
 
 		result = append(result, types.Dependency{
@@ -321,7 +324,7 @@ func parseDependenciesV2(
 		}
 
 		// Determine scope
-		scope := determineScopeFromLockfile(name, dep, prodDeps, devDeps, peerDeps, optionalDeps)
+		scope := determineScopeFromLockfile(name, dep, devDeps, peerDeps, optionalDeps)
 		isDirect := isDirectDependency(name, prodDeps, devDeps, peerDeps, optionalDeps)
 
 		dependencies = append(dependencies, types.Dependency{
@@ -383,31 +386,10 @@ func buildNPMMetadata(name string, pkg PackageInfo, peerDeps, optionalDeps map[s
 func determineScopeFromLockfile(
 	name string,
 	pkg PackageInfo,
-	prodDeps, devDeps, peerDeps, optionalDeps map[string]bool,
+	devDeps, peerDeps, optionalDeps map[string]bool,
 ) string {
-	// Check if it's a peer dependency
-	if peerDeps[name] {
-		return types.ScopePeer
-	}
-
-	// Check if it's an optional dependency
-	if optionalDeps[name] || pkg.Optional {
-		return types.ScopeOptional
-	}
-
-	// Check if it's a development dependency
-	if devDeps[name] || pkg.Dev {
-		return types.ScopeDev
-	}
-
-	// Check if it's a production dependency
-	if prodDeps[name] {
-		return types.ScopeProd
-	}
-
-	// Default to production if not explicitly classified
-	// This is a reasonable default for transitive dependencies
-	return types.ScopeProd
+	// Transitive dependencies listed in no section default to production.
+	return npmScope(peerDeps[name], optionalDeps[name] || pkg.Optional, devDeps[name] || pkg.Dev)
 }
 
 // GetLockfileVersion detects the package-lock.json version format
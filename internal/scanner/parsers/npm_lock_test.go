@@ -156,6 +156,44 @@ func TestParsePackageLock_StringDependenciesMap(t *testing.T) {
 	}
 }
 
+func TestParsePackageLock_OptionalScopes(t *testing.T) {
+	packageJSONContent := []byte(`{
+		"name": "myapp",
+		"dependencies": {"widget": "^1.2.0"},
+		"optionalDependencies": {"fsevents": "^2.3.0"},
+		"peerDependencies": {"react": ">=16.0.0", "react-dom": ">=16.0.0"},
+		"peerDependenciesMeta": {"react-dom": {"optional": true}}
+	}`)
+	packageJSON := &PackageJSON{Name: "myapp", Dependencies: map[string]string{"widget": "^1.2.0"}}
+	content := `{
+		"name": "myapp",
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "myapp"},
+			"node_modules/widget": {"version": "1.2.3"},
+			"node_modules/fsevents": {"version": "2.3.3", "optional": true},
+			"node_modules/react": {"version": "18.2.0", "peer": true},
+			"node_modules/react-dom": {"version": "18.2.0", "peer": true}
+		}
+	}`
+	deps := ParsePackageLockWithOptions([]byte(content), packageJSON, packageJSONContent, ParsePackageLockOptions{})
+
+	got := make(map[string]string, len(deps))
+	for _, d := range deps {
+		got[d.Name] = d.Scope
+	}
+	for name, scope := range map[string]string{
+		"widget":    types.ScopeProd,
+		"fsevents":  types.ScopeOptional,
+		"react":     types.ScopePeer,
+		"react-dom": types.ScopeOptional,
+	} {
+		if got[name] != scope {
+			t.Errorf("dep %s: got scope %q, want %q (all: %v)", name, got[name], scope, got)
+		}
+	}
+}
+
 func TestExtractNameFromNodeModulesPath(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -0,0 +1,262 @@
+package parsers
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Observability configuration kinds reported in ObservabilityConfig.Kind.
+const (
+	ObservabilityKindOtelCollector = "otel_collector"
+	ObservabilityKindPrometheus    = "prometheus"
+	ObservabilityKindGrafana       = "grafana_provisioning"
+	ObservabilityKindLogback       = "logback"
+	ObservabilityKindLog4j         = "log4j"
+	ObservabilityKindWinston       = "winston"
+)
+
+var (
+	// winstonTransportRegex matches transport construction such as
+	// new winston.transports.File(...) or new transports.Console(...).
+	winstonTransportRegex = regexp.MustCompile(`\btransports\.([A-Z][A-Za-z]+)\s*\(`)
+	// log4jPropertiesAppenderRegex matches log4j 2 (appender.<name>.type) and
+	// log4j 1.x (log4j.appender.<name>) appender declarations.
+	log4jPropertiesAppenderRegex = regexp.MustCompile(`^\s*(appender\.[^.=\s]+\.type|log4j\.appender\.[^.=\s]+)\s*[=:]\s*(\S+)`)
+)
+
+// ObservabilityConfig summarizes one observability configuration file:
+// which signals it handles and where telemetry is received from and sent to.
+type ObservabilityConfig struct {
+	File               string   `json:"file"`
+	Kind               string   `json:"kind"`
+	Signals            []string `json:"signals,omitempty"`
+	Receivers          []string `json:"receivers,omitempty"`
+	Exporters          []string `json:"exporters,omitempty"`
+	ScrapeJobs         []string `json:"scrape_jobs,omitempty"`
+	RuleFiles          int      `json:"rule_files,omitempty"`
+	RemoteWrite        bool     `json:"remote_write,omitempty"`
+	Datasources        []string `json:"datasources,omitempty"`
+	DashboardProviders int      `json:"dashboard_providers,omitempty"`
+	Outputs            []string `json:"outputs,omitempty"`
+}
+
+// ObservabilityParser handles OpenTelemetry Collector, Prometheus, Grafana
+// provisioning and logging framework configuration files.
+type ObservabilityParser struct{}
+
+// NewObservabilityParser creates a new observability configuration parser
+func NewObservabilityParser() *ObservabilityParser {
+	return &ObservabilityParser{}
+}
+
+// ParseOtelCollector parses an OpenTelemetry Collector configuration. Returns
+// nil when the document has no receivers or exporters (not a collector
+// configuration). Component ids keep their "type/name" form; signals are the
+// pipeline types (traces, metrics, logs).
+func (p *ObservabilityParser) ParseOtelCollector(content string) (*ObservabilityConfig, error) {
+	var cfg struct {
+		Receivers map[string]interface{} `yaml:"receivers"`
+		Exporters map[string]interface{} `yaml:"exporters"`
+		Service   struct {
+			Pipelines map[string]interface{} `yaml:"pipelines"`
+		} `yaml:"service"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, types.NewParseError("OpenTelemetry Collector config", err)
+	}
+	if len(cfg.Receivers) == 0 || len(cfg.Exporters) == 0 {
+		return nil, nil
+	}
+
+	signals := make([]string, 0, len(cfg.Service.Pipelines))
+	for id := range cfg.Service.Pipelines {
+		signal, _, _ := strings.Cut(id, "/")
+		signals = append(signals, signal)
+	}
+	return &ObservabilityConfig{
+		Kind:      ObservabilityKindOtelCollector,
+		Signals:   sortedUnique(signals),
+		Receivers: sortedKeys(cfg.Receivers),
+		Exporters: sortedKeys(cfg.Exporters),
+	}, nil
+}
+
+// ParsePrometheusConfig parses a prometheus.yml. Returns nil when it has no
+// scrape configs. Only job names are reported, never targets or credentials.
+func (p *ObservabilityParser) ParsePrometheusConfig(content string) (*ObservabilityConfig, error) {
+	var cfg struct {
+		ScrapeConfigs []struct {
+			JobName string `yaml:"job_name"`
+		} `yaml:"scrape_configs"`
+		RuleFiles   []string      `yaml:"rule_files"`
+		RemoteWrite []interface{} `yaml:"remote_write"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, types.NewParseError("Prometheus config", err)
+	}
+	if len(cfg.ScrapeConfigs) == 0 {
+		return nil, nil
+	}
+
+	jobs := make([]string, 0, len(cfg.ScrapeConfigs))
+	for _, sc := range cfg.ScrapeConfigs {
+		jobs = append(jobs, sc.JobName)
+	}
+	return &ObservabilityConfig{
+		Kind:        ObservabilityKindPrometheus,
+		Signals:     []string{"metrics"},
+		ScrapeJobs:  jobs,
+		RuleFiles:   len(cfg.RuleFiles),
+		RemoteWrite: len(cfg.RemoteWrite) > 0,
+	}, nil
+}
+
+// ParseGrafanaProvisioning parses a Grafana provisioning file (datasources or
+// dashboard providers). Returns nil when it declares neither.
+func (p *ObservabilityParser) ParseGrafanaProvisioning(content string) (*ObservabilityConfig, error) {
+	var cfg struct {
+		Datasources []struct {
+			Type string `yaml:"type"`
+		} `yaml:"datasources"`
+		Providers []interface{} `yaml:"providers"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, types.NewParseError("Grafana provisioning", err)
+	}
+	if len(cfg.Datasources) == 0 && len(cfg.Providers) == 0 {
+		return nil, nil
+	}
+
+	dsTypes := make([]string, 0, len(cfg.Datasources))
+	for _, ds := range cfg.Datasources {
+		dsTypes = append(dsTypes, ds.Type)
+	}
+	return &ObservabilityConfig{
+		Kind:               ObservabilityKindGrafana,
+		Datasources:        sortedUnique(dsTypes),
+		DashboardProviders: len(cfg.Providers),
+	}, nil
+}
+
+// ParseLogbackXML parses a logback.xml (or log4j 1.x log4j.xml, which uses
+// the same appender/class form) and reports the appender classes by simple
+// name (ConsoleAppender, RollingFileAppender, ...).
+func (p *ObservabilityParser) ParseLogbackXML(kind, content string) (*ObservabilityConfig, error) {
+	var outputs []string
+	err := walkXMLElements(content, func(el xml.StartElement, _ []string) {
+		if !strings.EqualFold(el.Name.Local, "appender") {
+			return
+		}
+		for _, attr := range el.Attr {
+			if attr.Name.Local == "class" {
+				outputs = append(outputs, simpleClassName(attr.Value))
+			}
+		}
+	})
+	if err != nil {
+		return nil, types.NewParseError(kind+" config", err)
+	}
+	return &ObservabilityConfig{Kind: kind, Signals: []string{"logs"}, Outputs: sortedUnique(outputs)}, nil
+}
+
+// ParseLog4j2XML parses a log4j2.xml: the appenders are the element names
+// directly under <Appenders> (Console, RollingFile, Kafka, ...).
+func (p *ObservabilityParser) ParseLog4j2XML(content string) (*ObservabilityConfig, error) {
+	var outputs []string
+	err := walkXMLElements(content, func(el xml.StartElement, parents []string) {
+		if len(parents) > 0 && strings.EqualFold(parents[len(parents)-1], "Appenders") {
+			outputs = append(outputs, el.Name.Local)
+		}
+	})
+	if err != nil {
+		return nil, types.NewParseError("log4j2 config", err)
+	}
+	return &ObservabilityConfig{Kind: ObservabilityKindLog4j, Signals: []string{"logs"}, Outputs: sortedUnique(outputs)}, nil
+}
+
+// ParseLog4jProperties parses log4j2.properties (appender.<name>.type) and
+// log4j 1.x log4j.properties (log4j.appender.<name>=<class>).
+func (p *ObservabilityParser) ParseLog4jProperties(content string) *ObservabilityConfig {
+	var outputs []string
+	for _, line := range strings.Split(content, "\n") {
+		if m := log4jPropertiesAppenderRegex.FindStringSubmatch(line); m != nil {
+			outputs = append(outputs, simpleClassName(m[2]))
+		}
+	}
+	return &ObservabilityConfig{Kind: ObservabilityKindLog4j, Signals: []string{"logs"}, Outputs: sortedUnique(outputs)}
+}
+
+// ParseWinston reports the winston transports constructed in a JavaScript or
+// TypeScript logger module. Returns nil when no transport is found.
+func (p *ObservabilityParser) ParseWinston(content string) *ObservabilityConfig {
+	if !strings.Contains(content, "winston") {
+		return nil
+	}
+	var outputs []string
+	for _, m := range winstonTransportRegex.FindAllStringSubmatch(content, -1) {
+		outputs = append(outputs, m[1])
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+	return &ObservabilityConfig{Kind: ObservabilityKindWinston, Signals: []string{"logs"}, Outputs: sortedUnique(outputs)}
+}
+
+// walkXMLElements calls visit for every start element with the names of its
+// ancestors.
+func walkXMLElements(content string, visit func(el xml.StartElement, parents []string)) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	var stack []string
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			visit(t, stack)
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// simpleClassName returns the last segment of a dotted class name.
+func simpleClassName(class string) string {
+	return class[strings.LastIndex(class, ".")+1:]
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedUnique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
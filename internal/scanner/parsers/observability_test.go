@@ -0,0 +1,153 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservabilityParser_ParseOtelCollector(t *testing.T) {
+	content := `receivers:
+  otlp:
+    protocols:
+      grpc:
+  prometheus/self:
+    config: {}
+processors:
+  batch:
+exporters:
+  otlphttp/tempo:
+    endpoint: https://tempo.example.com
+  prometheusremotewrite:
+    endpoint: https://mimir.example.com/api/v1/push
+  loki:
+    endpoint: https://loki.example.com
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlphttp/tempo]
+    metrics/self:
+      receivers: [prometheus/self]
+      exporters: [prometheusremotewrite]
+    logs:
+      receivers: [otlp]
+      exporters: [loki]
+`
+	cfg, err := NewObservabilityParser().ParseOtelCollector(content)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, ObservabilityKindOtelCollector, cfg.Kind)
+	assert.Equal(t, []string{"logs", "metrics", "traces"}, cfg.Signals)
+	assert.Equal(t, []string{"otlp", "prometheus/self"}, cfg.Receivers)
+	assert.Equal(t, []string{"loki", "otlphttp/tempo", "prometheusremotewrite"}, cfg.Exporters)
+}
+
+func TestObservabilityParser_ParseOtelCollector_NotCollector(t *testing.T) {
+	cfg, err := NewObservabilityParser().ParseOtelCollector("name: myapp\nreplicas: 2\n")
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestObservabilityParser_ParsePrometheusConfig(t *testing.T) {
+	content := `global:
+  scrape_interval: 15s
+rule_files:
+  - alerts.yml
+  - recording.yml
+scrape_configs:
+  - job_name: prometheus
+    static_configs:
+      - targets: ["localhost:9090"]
+  - job_name: myapp
+    basic_auth:
+      username: admin
+      password: secret
+remote_write:
+  - url: https://metrics.example.com/api/v1/write
+`
+	cfg, err := NewObservabilityParser().ParsePrometheusConfig(content)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, []string{"prometheus", "myapp"}, cfg.ScrapeJobs)
+	assert.Equal(t, 2, cfg.RuleFiles)
+	assert.True(t, cfg.RemoteWrite)
+	assert.Equal(t, []string{"metrics"}, cfg.Signals)
+}
+
+func TestObservabilityParser_ParseGrafanaProvisioning(t *testing.T) {
+	content := `apiVersion: 1
+datasources:
+  - name: Prometheus
+    type: prometheus
+    url: http://prometheus:9090
+  - name: Loki
+    type: loki
+  - name: Metrics2
+    type: prometheus
+`
+	cfg, err := NewObservabilityParser().ParseGrafanaProvisioning(content)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, []string{"loki", "prometheus"}, cfg.Datasources)
+
+	cfg, err = NewObservabilityParser().ParseGrafanaProvisioning("apiVersion: 1\nproviders:\n  - name: default\n    folder: ''\n")
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t, 1, cfg.DashboardProviders)
+}
+
+func TestObservabilityParser_ParseLogbackXML(t *testing.T) {
+	content := `<configuration>
+  <appender name="STDOUT" class="ch.qos.logback.core.ConsoleAppender">
+    <encoder><pattern>%msg%n</pattern></encoder>
+  </appender>
+  <appender name="FILE" class="ch.qos.logback.core.rolling.RollingFileAppender"/>
+  <appender name="JSON" class="net.logstash.logback.appender.LogstashTcpSocketAppender"/>
+  <root level="info"><appender-ref ref="STDOUT"/></root>
+</configuration>`
+	cfg, err := NewObservabilityParser().ParseLogbackXML(ObservabilityKindLogback, content)
+	require.NoError(t, err)
+	assert.Equal(t, ObservabilityKindLogback, cfg.Kind)
+	assert.Equal(t, []string{"ConsoleAppender", "LogstashTcpSocketAppender", "RollingFileAppender"}, cfg.Outputs)
+}
+
+func TestObservabilityParser_ParseLog4j2XML(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Configuration status="WARN">
+  <Appenders>
+    <Console name="Console" target="SYSTEM_OUT">
+      <PatternLayout pattern="%m%n"/>
+    </Console>
+    <RollingFile name="File" fileName="logs/app.log"/>
+  </Appenders>
+  <Loggers><Root level="info"/></Loggers>
+</Configuration>`
+	cfg, err := NewObservabilityParser().ParseLog4j2XML(content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Console", "RollingFile"}, cfg.Outputs)
+}
+
+func TestObservabilityParser_ParseLog4jProperties(t *testing.T) {
+	v2 := "appender.console.type = Console\nappender.console.name = STDOUT\nappender.rolling.type = RollingFile\n"
+	assert.Equal(t, []string{"Console", "RollingFile"}, NewObservabilityParser().ParseLog4jProperties(v2).Outputs)
+
+	v1 := "log4j.rootLogger=INFO, stdout\nlog4j.appender.stdout=org.apache.log4j.ConsoleAppender\nlog4j.appender.stdout.layout=org.apache.log4j.PatternLayout\n"
+	assert.Equal(t, []string{"ConsoleAppender"}, NewObservabilityParser().ParseLog4jProperties(v1).Outputs)
+}
+
+func TestObservabilityParser_ParseWinston(t *testing.T) {
+	content := `const winston = require('winston');
+module.exports = winston.createLogger({
+  transports: [
+    new winston.transports.Console(),
+    new winston.transports.File({ filename: 'error.log', level: 'error' }),
+  ],
+});`
+	cfg := NewObservabilityParser().ParseWinston(content)
+	require.NotNil(t, cfg)
+	assert.Equal(t, []string{"Console", "File"}, cfg.Outputs)
+
+	assert.Nil(t, NewObservabilityParser().ParseWinston("const pino = require('pino');\n"))
+}
@@ -0,0 +1,674 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Packaging recipe formats reported in PackagingInfo.Format.
+const (
+	PackagingFormatDebian   = "debian"
+	PackagingFormatRPM      = "rpm"
+	PackagingFormatAPKBUILD = "apkbuild"
+	PackagingFormatPKGBUILD = "pkgbuild"
+	PackagingFormatHomebrew = "homebrew"
+)
+
+// Pre-compiled regexes for distribution packaging recipes
+var (
+	// debian/changelog first entry: name (version) distribution; urgency=...
+	debianChangelogEntry = regexp.MustCompile(`^(\S+)\s+\(([^)]+)\)`)
+	// Build profile and architecture restrictions: [amd64], <!nocheck>
+	debianRestriction = regexp.MustCompile(`\[[^\]]*\]|<[^>]*>`)
+	// Relation: name[:arch] [(op version)]
+	debianRelation = regexp.MustCompile(`^([a-z0-9][a-z0-9+.\-]*)(?::[a-z0-9\-]+)?\s*(?:\(\s*([<>=]+)\s*([^)\s]+)\s*\))?$`)
+
+	// RPM spec preamble tag, with an optional qualifier: Requires(post): foo
+	rpmSpecTag = regexp.MustCompile(`^([A-Za-z]+)(?:\([^)]*\))?\s*:\s*(.*)$`)
+	// %define / %global macro definitions
+	rpmSpecDefine = regexp.MustCompile(`^%(?:define|global)\s+(\w+)\s+(.+)$`)
+	// %{name}, %{?name}, %{!?name}, %name
+	rpmSpecMacro = regexp.MustCompile(`%\{(\??!?\??)(\w+)\}|%(\w+)`)
+	// Section headers; only %package opens another preamble
+	rpmSpecSection = regexp.MustCompile(`^%(package|description|prep|build|install|check|clean|files|changelog|pre|post|preun|postun|pretrans|posttrans|trigger\w*|generate_buildrequires|conf)\b\s*(.*)$`)
+
+	// Top-level shell assignment in APKBUILD / PKGBUILD: name=value
+	shellAssignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+	// $name or ${name} reference
+	shellReference = regexp.MustCompile(`\$\{(\w+)\}|\$(\w+)`)
+
+	// Homebrew formula declarations
+	brewFormulaClass = regexp.MustCompile(`(?m)^\s*class\s+\w+\s*<\s*Formula\b`)
+	brewVersion      = regexp.MustCompile(`(?m)^\s*version\s+["']([^"']+)["']`)
+	brewURL          = regexp.MustCompile(`(?m)^\s*url\s+["']([^"']+)["']`)
+	brewURLVersion   = regexp.MustCompile(`[-_/]v?(\d+(?:\.\d+)+)(?:\.tar|\.zip|\.tgz|\.tbz|\.txz|/|$)`)
+	brewLicense      = regexp.MustCompile(`(?m)^\s*license\s+["']([^"']+)["']`)
+	brewLicenseList  = regexp.MustCompile(`(?m)^\s*license\s+(any_of|all_of):\s*\[([^\]]*)\]`)
+	brewQuoted       = regexp.MustCompile(`["']([^"']+)["']`)
+	brewDependsOn    = regexp.MustCompile(`(?m)^\s*(?:depends_on|uses_from_macos)\s+["']([^"']+)["'](?:\s*=>\s*(\[[^\]]*\]|:\w+))?`)
+)
+
+// PackagingInfo describes a distribution packaging recipe found in a
+// repository: what it builds and what it declares it needs.
+type PackagingInfo struct {
+	File     string   `json:"file"`
+	Format   string   `json:"format"`
+	Name     string   `json:"name,omitempty"`
+	Version  string   `json:"version,omitempty"`
+	License  string   `json:"license,omitempty"`
+	Packages []string `json:"packages,omitempty"` // binary (or split) packages built from the recipe
+
+	Dependencies []types.Dependency `json:"-"`
+}
+
+// OSPackagingParser handles distribution packaging recipes: Debian control
+// files, RPM spec files, Alpine APKBUILDs, Arch PKGBUILDs and Homebrew
+// formulae.
+type OSPackagingParser struct{}
+
+// NewOSPackagingParser creates a new packaging recipe parser
+func NewOSPackagingParser() *OSPackagingParser {
+	return &OSPackagingParser{}
+}
+
+// packageDeps collects declared dependencies of one recipe, deduplicated by
+// name and scope.
+type packageDeps struct {
+	depType string
+	source  string
+	distro  string // PURL namespace for distribution packages, when certain
+	seen    map[string]bool
+	list    []types.Dependency
+}
+
+func newPackageDeps(depType, source string) *packageDeps {
+	return &packageDeps{depType: depType, source: source, seen: make(map[string]bool)}
+}
+
+// add records a dependency. Names that are still unexpanded macros or
+// variables, conflicts ("!name") and rich/boolean expressions are skipped.
+func (c *packageDeps) add(name, version, scope string) {
+	if name == "" || strings.ContainsAny(name, "%$") || strings.HasPrefix(name, "!") || strings.HasPrefix(name, "(") {
+		return
+	}
+	key := name + "\x00" + scope
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	dep := types.Dependency{
+		Type:     c.depType,
+		Name:     name,
+		Version:  version,
+		Scope:    scope,
+		Direct:   true,
+		Metadata: types.NewMetadata(c.source),
+	}
+	if c.distro != "" {
+		dep.Metadata["distro"] = c.distro
+	}
+	c.list = append(c.list, dep)
+}
+
+// --- Debian ---
+
+// debianRelationFields maps control fields to dependency scopes, in the
+// order they are reported.
+var debianRelationFields = []struct{ field, scope string }{
+	{"Build-Depends", types.ScopeBuild},
+	{"Build-Depends-Indep", types.ScopeBuild},
+	{"Build-Depends-Arch", types.ScopeBuild},
+	{"Pre-Depends", types.ScopeProd},
+	{"Depends", types.ScopeProd},
+	{"Recommends", types.ScopeOptional},
+	{"Suggests", types.ScopeOptional},
+}
+
+// ParseDebianControl parses debian/control. The source paragraph's
+// Build-Depends fields are build dependencies (test when restricted to the
+// <!nocheck> profile); binary paragraphs contribute Depends/Pre-Depends as
+// runtime and Recommends/Suggests as optional dependencies. Substitution
+// variables such as ${shlibs:Depends} are skipped, and only the first of
+// several alternatives (a | b) is reported.
+func (p *OSPackagingParser) ParseDebianControl(content string) *PackagingInfo {
+	paragraphs := parseDebianParagraphs(content)
+	if len(paragraphs) == 0 || paragraphs[0]["Source"] == "" {
+		return nil
+	}
+
+	info := &PackagingInfo{Format: PackagingFormatDebian, Name: paragraphs[0]["Source"]}
+	deps := newPackageDeps(DependencyTypeDeb, MetadataSourceDebianControl)
+	for _, para := range paragraphs {
+		if pkg := para["Package"]; pkg != "" {
+			info.Packages = append(info.Packages, pkg)
+		}
+		for _, f := range debianRelationFields {
+			addDebianRelations(deps, para[f.field], f.scope)
+		}
+	}
+	info.Dependencies = deps.list
+	return info
+}
+
+// ParseDebianChangelog returns the source package name and version of the
+// most recent debian/changelog entry.
+func (p *OSPackagingParser) ParseDebianChangelog(content string) (name, version string) {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if m := debianChangelogEntry.FindStringSubmatch(line); m != nil {
+			return m[1], m[2]
+		}
+		return "", ""
+	}
+	return "", ""
+}
+
+// parseDebianParagraphs splits a deb822 document into paragraphs, folding
+// continuation lines into the preceding field.
+func parseDebianParagraphs(content string) []map[string]string {
+	var paragraphs []map[string]string
+	para := make(map[string]string)
+	lastKey := ""
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			paragraphs = appendParagraph(paragraphs, para)
+			para, lastKey = make(map[string]string), ""
+		case strings.HasPrefix(line, "#"):
+		case line[0] == ' ' || line[0] == '\t':
+			if lastKey != "" {
+				para[lastKey] += " " + strings.TrimSpace(line)
+			}
+		default:
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				lastKey = strings.TrimSpace(key)
+				para[lastKey] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return appendParagraph(paragraphs, para)
+}
+
+func appendParagraph(paragraphs []map[string]string, para map[string]string) []map[string]string {
+	if len(para) == 0 {
+		return paragraphs
+	}
+	return append(paragraphs, para)
+}
+
+// addDebianRelations parses a comma-separated relation field.
+func addDebianRelations(deps *packageDeps, field, scope string) {
+	for _, rel := range strings.Split(field, ",") {
+		relScope := scope
+		if scope == types.ScopeBuild && strings.Contains(rel, "<!nocheck>") {
+			relScope = types.ScopeTest
+		}
+		first, _, _ := strings.Cut(rel, "|")
+		first = strings.TrimSpace(debianRestriction.ReplaceAllString(first, ""))
+		if m := debianRelation.FindStringSubmatch(first); m != nil {
+			deps.add(m[1], m[2]+m[3], relScope)
+		}
+	}
+}
+
+// --- RPM ---
+
+// rpmDependencyTags maps spec preamble tags (lower case) to dependency scopes.
+var rpmDependencyTags = map[string]string{
+	"requires":      types.ScopeProd,
+	"buildrequires": types.ScopeBuild,
+	"recommends":    types.ScopeOptional,
+	"suggests":      types.ScopeOptional,
+}
+
+// rpmSpec holds the state of a spec file walk.
+type rpmSpec struct {
+	info   *PackagingInfo
+	deps   *packageDeps
+	macros map[string]string
+	inBody bool
+}
+
+// ParseRPMSpec parses an RPM .spec file: Name, Version and License from the
+// preamble, Requires (runtime), BuildRequires (build) and Recommends/Suggests
+// (optional) from the main and %package preambles. %define/%global macros and
+// the name/version macros are expanded; dependencies that still contain
+// unresolved macros are skipped. Returns nil when the file has no Name tag
+// (e.g. a PyInstaller .spec).
+func (p *OSPackagingParser) ParseRPMSpec(content string) *PackagingInfo {
+	spec := &rpmSpec{
+		info:   &PackagingInfo{Format: PackagingFormatRPM},
+		deps:   newPackageDeps(DependencyTypeRpm, MetadataSourceRPMSpec),
+		macros: make(map[string]string),
+	}
+	for _, line := range strings.Split(content, "\n") {
+		spec.line(strings.TrimSpace(line))
+	}
+	if spec.info.Name == "" {
+		return nil
+	}
+	spec.info.Packages = append([]string{spec.info.Name}, spec.info.Packages...)
+	spec.info.Dependencies = spec.deps.list
+	return spec.info
+}
+
+func (s *rpmSpec) line(line string) {
+	if m := rpmSpecSection.FindStringSubmatch(line); m != nil {
+		s.inBody = m[1] != "package"
+		if !s.inBody {
+			s.addSubpackage(m[2])
+		}
+		return
+	}
+	if s.inBody {
+		return
+	}
+	if m := rpmSpecDefine.FindStringSubmatch(line); m != nil {
+		s.macros[m[1]] = s.expand(m[2])
+		return
+	}
+	if m := rpmSpecTag.FindStringSubmatch(line); m != nil {
+		s.tag(strings.ToLower(m[1]), s.expand(strings.TrimSpace(m[2])))
+	}
+}
+
+func (s *rpmSpec) tag(tag, value string) {
+	switch tag {
+	case "name", "version", "release":
+		s.macros[tag] = value
+		if tag == "name" && s.info.Name == "" {
+			s.info.Name = value
+		} else if tag == "version" && s.info.Version == "" {
+			s.info.Version = value
+		}
+	case "license":
+		if s.info.License == "" {
+			s.info.License = value
+		}
+	default:
+		if scope, ok := rpmDependencyTags[tag]; ok {
+			for _, ref := range versionedRefs(value) {
+				s.deps.add(ref.name, ref.version, scope)
+			}
+		}
+	}
+}
+
+// addSubpackage records a "%package [-n] name" header: "-n" names the package
+// verbatim, otherwise the name is a suffix of the main package.
+func (s *rpmSpec) addSubpackage(args string) {
+	fields := strings.Fields(s.expand(args))
+	switch {
+	case len(fields) >= 2 && fields[0] == "-n":
+		s.info.Packages = append(s.info.Packages, fields[1])
+	case len(fields) >= 1 && s.info.Name != "":
+		s.info.Packages = append(s.info.Packages, s.info.Name+"-"+fields[0])
+	}
+}
+
+// expand substitutes known macros. Unknown conditional macros (%{?dist})
+// expand to nothing; other unknown macros are left in place.
+func (s *rpmSpec) expand(value string) string {
+	return rpmSpecMacro.ReplaceAllStringFunc(value, func(ref string) string {
+		m := rpmSpecMacro.FindStringSubmatch(ref)
+		name := m[2] + m[3]
+		if v, ok := s.macros[name]; ok && !strings.Contains(m[1], "!") {
+			return v
+		}
+		if strings.Contains(m[1], "?") {
+			return ""
+		}
+		return ref
+	})
+}
+
+// --- shell recipes (APKBUILD, PKGBUILD) ---
+
+// packageRef is a package name with an optional version constraint.
+type packageRef struct {
+	name    string
+	version string
+}
+
+// versionedRefs splits a dependency list ("a >= 1.0, b c>=2") into package
+// references. Operators may be attached to the name or stand alone.
+func versionedRefs(list string) []packageRef {
+	if strings.HasPrefix(strings.TrimSpace(list), "(") {
+		return nil // rpm rich dependency expression
+	}
+	fields := strings.FieldsFunc(list, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	var refs []packageRef
+	for i := 0; i < len(fields); i++ {
+		if isVersionOperator(fields[i]) {
+			if len(refs) > 0 && i+1 < len(fields) {
+				refs[len(refs)-1].version = fields[i] + fields[i+1]
+			}
+			i++
+			continue
+		}
+		refs = append(refs, splitVersionedName(fields[i]))
+	}
+	return refs
+}
+
+func isVersionOperator(s string) bool {
+	switch s {
+	case "=", "==", ">", "<", ">=", "<=", "~", "~=":
+		return true
+	}
+	return false
+}
+
+func splitVersionedName(s string) packageRef {
+	if i := strings.IndexAny(s, "<>=~"); i > 0 {
+		return packageRef{name: s[:i], version: s[i:]}
+	}
+	return packageRef{name: s}
+}
+
+// shellVars holds the top-level assignments of a shell recipe; scalars are
+// single-element values.
+type shellVars map[string][]string
+
+func (v shellVars) first(name string) string {
+	if len(v[name]) == 0 {
+		return ""
+	}
+	return v[name][0]
+}
+
+// words returns a variable's value split on whitespace, so quoted scalar
+// lists (depends="a b") and arrays (depends=(a b)) read the same way.
+func (v shellVars) words(name string) []string {
+	return strings.Fields(strings.Join(v[name], " "))
+}
+
+// parseShellAssignments reads the top-level name=value assignments of a shell
+// recipe. Quoted values and arrays may span lines; $name and ${name}
+// references to earlier assignments are expanded. Indented assignments
+// (inside functions) are ignored.
+func parseShellAssignments(content string) shellVars {
+	vars := make(shellVars)
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		m := shellAssignment.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		words, complete := shellWords(value)
+		for !complete && i+1 < len(lines) {
+			i++
+			value += "\n" + lines[i]
+			words, complete = shellWords(value)
+		}
+		for j := range words {
+			words[j] = vars.expand(words[j])
+		}
+		vars[m[1]] = words
+	}
+	return vars
+}
+
+func (v shellVars) expand(word string) string {
+	return shellReference.ReplaceAllStringFunc(word, func(ref string) string {
+		m := shellReference.FindStringSubmatch(ref)
+		if value, ok := v[m[1]+m[2]]; ok {
+			return strings.Join(value, " ")
+		}
+		return ref
+	})
+}
+
+// shellTokenizer splits a shell value into words, honoring quotes, an
+// enclosing array "( ... )" and trailing comments.
+type shellTokenizer struct {
+	words  []string
+	word   strings.Builder
+	inWord bool
+	quote  byte
+	array  bool
+	done   bool
+}
+
+// shellWords tokenizes a value; complete is false while a quote or array is
+// still open, i.e. the value continues on the next line.
+func shellWords(value string) (words []string, complete bool) {
+	t := &shellTokenizer{}
+	value = strings.TrimLeft(value, " \t")
+	if strings.HasPrefix(value, "(") {
+		t.array, value = true, value[1:]
+	}
+	for i := 0; i < len(value) && !t.done; i++ {
+		i += t.next(value[i:])
+	}
+	t.flush()
+	return t.words, t.quote == 0 && (!t.array || t.done)
+}
+
+// next consumes the byte at the head of rest and returns how many extra bytes
+// to skip (used to jump over comments).
+func (t *shellTokenizer) next(rest string) int {
+	c := rest[0]
+	switch {
+	case t.quote != 0:
+		t.inQuote(c)
+	case c == '"' || c == '\'':
+		t.quote, t.inWord = c, true
+	case c == '#' && !t.inWord:
+		return commentLength(rest)
+	case c == ')' && t.array:
+		t.flush()
+		t.done = true
+	default:
+		t.plain(c)
+	}
+	return 0
+}
+
+// plain handles an unquoted byte: whitespace ends a word (and, outside an
+// array, a newline ends the value).
+func (t *shellTokenizer) plain(c byte) {
+	if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+		t.flush()
+		t.done = !t.array && c == '\n'
+		return
+	}
+	t.word.WriteByte(c)
+	t.inWord = true
+}
+
+// commentLength returns the length of a comment up to (not including) the
+// end of its line.
+func commentLength(rest string) int {
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		return end
+	}
+	return len(rest)
+}
+
+func (t *shellTokenizer) inQuote(c byte) {
+	if c == t.quote {
+		t.quote = 0
+		return
+	}
+	t.word.WriteByte(c)
+}
+
+func (t *shellTokenizer) flush() {
+	if t.inWord {
+		t.words = append(t.words, t.word.String())
+	}
+	t.word.Reset()
+	t.inWord = false
+}
+
+// shellRecipeField maps a recipe variable to a dependency scope.
+type shellRecipeField struct{ field, scope string }
+
+var apkbuildFields = []shellRecipeField{
+	{"depends", types.ScopeProd},
+	{"makedepends", types.ScopeBuild},
+	{"makedepends_build", types.ScopeBuild},
+	{"makedepends_host", types.ScopeBuild},
+	{"checkdepends", types.ScopeTest},
+}
+
+var pkgbuildFields = []shellRecipeField{
+	{"depends", types.ScopeProd},
+	{"makedepends", types.ScopeBuild},
+	{"checkdepends", types.ScopeTest},
+	{"optdepends", types.ScopeOptional},
+}
+
+// ParseAPKBUILD parses an Alpine APKBUILD. Provider names such as
+// "so:libc.musl-x86_64.so.1" or "cmd:sh" are not packages and are skipped.
+func (p *OSPackagingParser) ParseAPKBUILD(content string) *PackagingInfo {
+	vars := parseShellAssignments(content)
+	name := vars.first("pkgname")
+	if name == "" {
+		return nil
+	}
+	info := &PackagingInfo{
+		Format:   PackagingFormatAPKBUILD,
+		Name:     name,
+		Version:  joinVersion(vars.first("pkgver"), "-r", vars.first("pkgrel")),
+		License:  strings.Join(vars.words("license"), " "),
+		Packages: []string{name},
+	}
+	for _, sub := range vars.words("subpackages") {
+		sub, _, _ = strings.Cut(sub, ":")
+		info.Packages = append(info.Packages, sub)
+	}
+
+	deps := newPackageDeps(DependencyTypeApk, MetadataSourceAPKBUILD)
+	deps.distro = "alpine"
+	for _, f := range apkbuildFields {
+		for _, ref := range versionedRefs(strings.Join(vars.words(f.field), " ")) {
+			if !strings.Contains(ref.name, ":") {
+				deps.add(ref.name, ref.version, f.scope)
+			}
+		}
+	}
+	info.Dependencies = deps.list
+	return info
+}
+
+// ParsePKGBUILD parses an Arch Linux PKGBUILD. Split packages list every
+// pkgname; optdepends entries carry a description after ':' that is dropped.
+func (p *OSPackagingParser) ParsePKGBUILD(content string) *PackagingInfo {
+	vars := parseShellAssignments(content)
+	names := vars["pkgname"]
+	if len(names) == 0 {
+		return nil
+	}
+	version := joinVersion(vars.first("pkgver"), "-", vars.first("pkgrel"))
+	if epoch := vars.first("epoch"); epoch != "" && epoch != "0" && version != "" {
+		version = epoch + ":" + version
+	}
+	info := &PackagingInfo{
+		Format:   PackagingFormatPKGBUILD,
+		Name:     firstNonEmpty(vars.first("pkgbase"), names[0]),
+		Version:  version,
+		License:  strings.Join(vars["license"], " AND "),
+		Packages: names,
+	}
+
+	deps := newPackageDeps(DependencyTypeAlpm, MetadataSourcePKGBUILD)
+	deps.distro = "arch"
+	for _, f := range pkgbuildFields {
+		for _, entry := range vars[f.field] {
+			entry, _, _ = strings.Cut(entry, ":")
+			ref := splitVersionedName(strings.TrimSpace(entry))
+			deps.add(ref.name, ref.version, f.scope)
+		}
+	}
+	info.Dependencies = deps.list
+	return info
+}
+
+func joinVersion(version, sep, release string) string {
+	if version == "" || release == "" {
+		return version
+	}
+	return version + sep + release
+}
+
+// --- Homebrew ---
+
+// ParseHomebrewFormula parses a Homebrew formula (Ruby). The formula name is
+// its file name. depends_on and uses_from_macos declarations are dependencies,
+// scoped by their tags (=> :build, :test, :optional, :recommended); symbol
+// requirements such as depends_on :macos are not packages and are skipped.
+// Returns nil when the file does not define a Formula subclass.
+func (p *OSPackagingParser) ParseHomebrewFormula(fileName, content string) *PackagingInfo {
+	if !brewFormulaClass.MatchString(content) {
+		return nil
+	}
+	info := &PackagingInfo{
+		Format:  PackagingFormatHomebrew,
+		Name:    strings.TrimSuffix(path.Base(fileName), ".rb"),
+		Version: brewFormulaVersion(content),
+		License: brewFormulaLicense(content),
+	}
+
+	deps := newPackageDeps(DependencyTypeHomebrew, MetadataSourceHomebrewFormula)
+	for _, m := range brewDependsOn.FindAllStringSubmatch(content, -1) {
+		deps.add(m[1], "", brewScope(m[2]))
+	}
+	info.Dependencies = deps.list
+	return info
+}
+
+// brewFormulaVersion returns the explicit version, or the one embedded in the
+// source URL (".../myapp-1.2.3.tar.gz").
+func brewFormulaVersion(content string) string {
+	if m := brewVersion.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	if m := brewURL.FindStringSubmatch(content); m != nil {
+		if v := brewURLVersion.FindStringSubmatch(m[1]); v != nil {
+			return v[1]
+		}
+	}
+	return ""
+}
+
+// brewFormulaLicense renders license "MIT" or license any_of: [...] /
+// all_of: [...] as an SPDX expression.
+func brewFormulaLicense(content string) string {
+	if m := brewLicense.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	m := brewLicenseList.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	var ids []string
+	for _, q := range brewQuoted.FindAllStringSubmatch(m[2], -1) {
+		ids = append(ids, q[1])
+	}
+	op := " OR "
+	if m[1] == "all_of" {
+		op = " AND "
+	}
+	return strings.Join(ids, op)
+}
+
+func brewScope(tags string) string {
+	switch {
+	case strings.Contains(tags, ":build"):
+		return types.ScopeBuild
+	case strings.Contains(tags, ":test"):
+		return types.ScopeTest
+	case strings.Contains(tags, ":optional"), strings.Contains(tags, ":recommended"):
+		return types.ScopeOptional
+	}
+	return types.ScopeProd
+}
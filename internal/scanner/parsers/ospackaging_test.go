@@ -0,0 +1,217 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// depsByName indexes dependencies by name for order-independent assertions.
+func depsByName(deps []types.Dependency) map[string]types.Dependency {
+	m := make(map[string]types.Dependency, len(deps))
+	for _, d := range deps {
+		m[d.Name] = d
+	}
+	return m
+}
+
+func TestParseDebianControl(t *testing.T) {
+	content := `Source: myapp
+Section: net
+Maintainer: Jane Doe <jane@example.com>
+Build-Depends: debhelper-compat (= 13),
+               libssl-dev (>= 3.0) [linux-any],
+               python3:any,
+               pytest <!nocheck>
+Standards-Version: 4.6.2
+
+Package: myapp
+Architecture: any
+Depends: ${shlibs:Depends}, ${misc:Depends}, libcurl4 | libcurl3, adduser
+Recommends: ca-certificates
+Description: example daemon
+ Long description line.
+
+Package: myapp-doc
+Architecture: all
+Suggests: myapp
+`
+	info := NewOSPackagingParser().ParseDebianControl(content)
+	require.NotNil(t, info)
+	assert.Equal(t, PackagingFormatDebian, info.Format)
+	assert.Equal(t, "myapp", info.Name)
+	assert.Equal(t, []string{"myapp", "myapp-doc"}, info.Packages)
+
+	deps := depsByName(info.Dependencies)
+	assert.Len(t, info.Dependencies, 8)
+	assert.Equal(t, "=13", deps["debhelper-compat"].Version)
+	assert.Equal(t, types.ScopeBuild, deps["debhelper-compat"].Scope)
+	assert.Equal(t, ">=3.0", deps["libssl-dev"].Version)
+	assert.Contains(t, deps, "python3", "architecture qualifier is stripped")
+	assert.Equal(t, types.ScopeTest, deps["pytest"].Scope)
+	assert.Equal(t, types.ScopeProd, deps["libcurl4"].Scope)
+	assert.NotContains(t, deps, "libcurl3", "only the first alternative is reported")
+	assert.Equal(t, types.ScopeOptional, deps["ca-certificates"].Scope)
+	assert.Equal(t, DependencyTypeDeb, deps["adduser"].Type)
+	assert.Equal(t, MetadataSourceDebianControl, deps["adduser"].Metadata["source"])
+
+	assert.Nil(t, NewOSPackagingParser().ParseDebianControl("Package: orphan\n"), "no source paragraph")
+}
+
+func TestParseDebianChangelog(t *testing.T) {
+	name, version := NewOSPackagingParser().ParseDebianChangelog("\nmyapp (2.4.1-1) unstable; urgency=medium\n\n  * New upstream release.\n")
+	assert.Equal(t, "myapp", name)
+	assert.Equal(t, "2.4.1-1", version)
+}
+
+func TestParseRPMSpec(t *testing.T) {
+	content := `%global srcname myapp
+%define libname lib%{srcname}
+Name:           %{srcname}
+Version:        2.4.1
+Release:        1%{?dist}
+License:        Apache-2.0
+BuildRequires:  gcc, cmake >= 3.20
+BuildRequires:  pkgconfig(openssl)
+Requires:       %{libname}%{?_isa} = %{version}-%{release}
+Requires(post): systemd
+Requires:       (python3 or python2)
+Recommends:     bash-completion
+
+%description
+Requires: not-a-tag
+
+%package devel
+Summary: Development files
+Requires: %{name} = %{version}
+
+%package -n python3-%{srcname}
+Requires: python3-requests
+
+%prep
+%autosetup
+`
+	info := NewOSPackagingParser().ParseRPMSpec(content)
+	require.NotNil(t, info)
+	assert.Equal(t, "myapp", info.Name)
+	assert.Equal(t, "2.4.1", info.Version)
+	assert.Equal(t, "Apache-2.0", info.License)
+	assert.Equal(t, []string{"myapp", "myapp-devel", "python3-myapp"}, info.Packages)
+
+	deps := depsByName(info.Dependencies)
+	assert.Equal(t, types.ScopeBuild, deps["gcc"].Scope)
+	assert.Equal(t, ">=3.20", deps["cmake"].Version)
+	assert.Contains(t, deps, "pkgconfig(openssl)")
+	assert.Equal(t, "=2.4.1-1", deps["libmyapp"].Version)
+	assert.Equal(t, types.ScopeProd, deps["systemd"].Scope)
+	assert.Equal(t, types.ScopeOptional, deps["bash-completion"].Scope)
+	assert.Contains(t, deps, "python3-requests")
+	assert.NotContains(t, deps, "not-a-tag", "body sections are not preamble")
+	assert.NotContains(t, deps, "python3", "rich dependencies are skipped")
+
+	assert.Nil(t, NewOSPackagingParser().ParseRPMSpec("a = Analysis(['main.py'])\n"), "PyInstaller spec")
+}
+
+func TestParseAPKBUILD(t *testing.T) {
+	content := `# Maintainer: Jane Doe <jane@example.com>
+pkgname=myapp
+pkgver=2.4.1
+pkgrel=0
+pkgdesc="Example daemon"
+license="MIT OR Apache-2.0"
+depends="ca-certificates !myapp-legacy so:libc.musl-x86_64.so.1"
+makedepends="
+	cargo>=1.70
+	openssl-dev
+	"
+checkdepends="python3" # used by the test suite
+subpackages="$pkgname-doc $pkgname-openrc:openrc:noarch"
+source="https://example.com/$pkgname-$pkgver.tar.gz"
+
+build() {
+	depends="ignored"
+	cargo build --release
+}
+`
+	info := NewOSPackagingParser().ParseAPKBUILD(content)
+	require.NotNil(t, info)
+	assert.Equal(t, "myapp", info.Name)
+	assert.Equal(t, "2.4.1-r0", info.Version)
+	assert.Equal(t, "MIT OR Apache-2.0", info.License)
+	assert.Equal(t, []string{"myapp", "myapp-doc", "myapp-openrc"}, info.Packages)
+
+	deps := depsByName(info.Dependencies)
+	assert.Len(t, info.Dependencies, 4)
+	assert.Equal(t, types.ScopeProd, deps["ca-certificates"].Scope)
+	assert.Equal(t, ">=1.70", deps["cargo"].Version)
+	assert.Equal(t, types.ScopeBuild, deps["openssl-dev"].Scope)
+	assert.Equal(t, types.ScopeTest, deps["python3"].Scope)
+	assert.Equal(t, DependencyTypeApk, deps["python3"].Type)
+	assert.Equal(t, "alpine", deps["python3"].Metadata["distro"])
+}
+
+func TestParsePKGBUILD(t *testing.T) {
+	content := `pkgbase=myapp
+pkgname=('myapp' 'myapp-docs')
+pkgver=2.4.1
+pkgrel=2
+epoch=1
+license=('MIT' 'Apache-2.0')
+depends=('glibc' 'openssl>=3.0')
+makedepends=(
+  'cmake'  # build system
+  "git"
+)
+optdepends=('bash-completion: tab completion (optional)')
+`
+	info := NewOSPackagingParser().ParsePKGBUILD(content)
+	require.NotNil(t, info)
+	assert.Equal(t, "myapp", info.Name)
+	assert.Equal(t, "1:2.4.1-2", info.Version)
+	assert.Equal(t, "MIT AND Apache-2.0", info.License)
+	assert.Equal(t, []string{"myapp", "myapp-docs"}, info.Packages)
+
+	deps := depsByName(info.Dependencies)
+	assert.Len(t, info.Dependencies, 5)
+	assert.Equal(t, ">=3.0", deps["openssl"].Version)
+	assert.Equal(t, types.ScopeBuild, deps["cmake"].Scope)
+	assert.Equal(t, types.ScopeBuild, deps["git"].Scope)
+	assert.Equal(t, types.ScopeOptional, deps["bash-completion"].Scope)
+	assert.Equal(t, DependencyTypeAlpm, deps["glibc"].Type)
+	assert.Equal(t, "arch", deps["glibc"].Metadata["distro"])
+}
+
+func TestParseHomebrewFormula(t *testing.T) {
+	content := `class Myapp < Formula
+  desc "Example daemon"
+  homepage "https://example.com/myapp"
+  url "https://example.com/downloads/myapp-2.4.1.tar.gz"
+  sha256 "0000000000000000000000000000000000000000000000000000000000000000"
+  license any_of: ["MIT", "Apache-2.0"]
+
+  depends_on "cmake" => :build
+  depends_on "pkgconf" => [:build, :test]
+  depends_on "openssl@3"
+  depends_on "gnupg" => :optional
+  depends_on :macos
+  uses_from_macos "zlib"
+end
+`
+	info := NewOSPackagingParser().ParseHomebrewFormula("Formula/myapp.rb", content)
+	require.NotNil(t, info)
+	assert.Equal(t, "myapp", info.Name)
+	assert.Equal(t, "2.4.1", info.Version)
+	assert.Equal(t, "MIT OR Apache-2.0", info.License)
+
+	deps := depsByName(info.Dependencies)
+	assert.Len(t, info.Dependencies, 5)
+	assert.Equal(t, types.ScopeBuild, deps["cmake"].Scope)
+	assert.Equal(t, types.ScopeBuild, deps["pkgconf"].Scope)
+	assert.Equal(t, types.ScopeProd, deps["openssl@3"].Scope)
+	assert.Equal(t, types.ScopeOptional, deps["gnupg"].Scope)
+	assert.Equal(t, types.ScopeProd, deps["zlib"].Scope)
+
+	assert.Nil(t, NewOSPackagingParser().ParseHomebrewFormula("Formula/helper.rb", "module Helper\nend\n"))
+}
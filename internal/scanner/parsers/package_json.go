@@ -10,14 +10,20 @@ import (
 // PackageJSON represents the structure of package.json
 // Enhanced version with additional fields for comprehensive dependency analysis
 type PackageJSONEnhanced struct {
-	Name                 string            `json:"name"`
-	Version              string            `json:"version"`
-	Dependencies         map[string]string `json:"dependencies"`
-	DevDependencies      map[string]string `json:"devDependencies"`
-	PeerDependencies     map[string]string `json:"peerDependencies"`
-	OptionalDependencies map[string]string `json:"optionalDependencies"`
-	Workspaces           []string          `json:"workspaces"`
-	Workspace            string            `json:"workspace"`
+	Name                 string                 `json:"name"`
+	Version              string                 `json:"version"`
+	Dependencies         map[string]string      `json:"dependencies"`
+	DevDependencies      map[string]string      `json:"devDependencies"`
+	PeerDependencies     map[string]string      `json:"peerDependencies"`
+	OptionalDependencies map[string]string      `json:"optionalDependencies"`
+	PeerDependenciesMeta map[string]npmPeerMeta `json:"peerDependenciesMeta"`
+	Workspaces           []string               `json:"workspaces"`
+	Workspace            string                 `json:"workspace"`
+}
+
+// npmPeerMeta is the peerDependenciesMeta entry of a peer dependency.
+type npmPeerMeta struct {
+	Optional bool `json:"optional"`
 }
 
 // ParsePackageJSONEnhanced parses package.json content and returns direct dependencies with semantic version constraints
@@ -37,7 +43,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "prod",
+			Scope:      NormalizeScope(DependencyTypeNpm, "dependencies"),
 		})
 	}
 
@@ -48,7 +54,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "dev",
+			Scope:      NormalizeScope(DependencyTypeNpm, "devDependencies"),
 		})
 	}
 
@@ -59,7 +65,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "peer",
+			Scope:      npmScope(true, packageJSON.PeerDependenciesMeta[name].Optional, false),
 		})
 	}
 
@@ -70,7 +76,7 @@ func ParsePackageJSONEnhanced(content []byte) []types.Dependency {
 			Name:       name,
 			Version:    parseSemanticVersion(version),
 			SourceFile: "package.json",
-			Scope:      "optional",
+			Scope:      NormalizeScope(DependencyTypeNpm, "optionalDependencies"),
 		})
 	}
 
@@ -62,6 +62,23 @@ func TestParsePackageJSONEnhanced(t *testing.T) {
 				{Type: "npm", Name: "optional-pkg", Version: "^1.0.0", SourceFile: "package.json", Scope: "optional"},
 			},
 		},
+		{
+			name: "peer dependency marked optional",
+			content: `{
+				"name": "plugin-app",
+				"peerDependencies": {
+					"react": ">=16.0.0",
+					"react-dom": ">=16.0.0"
+				},
+				"peerDependenciesMeta": {
+					"react-dom": {"optional": true}
+				}
+			}`,
+			expectedDeps: []types.Dependency{
+				{Type: "npm", Name: "react", Version: ">=16.0.0", SourceFile: "package.json", Scope: "peer"},
+				{Type: "npm", Name: "react-dom", Version: ">=16.0.0", SourceFile: "package.json", Scope: "optional"},
+			},
+		},
 		{
 			name: "package.json with workspace and git dependencies",
 			content: `{
@@ -0,0 +1,369 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Toolchains declaring target platforms.
+const (
+	PlatformToolchainGo     = "go"
+	PlatformToolchainRust   = "rust"
+	PlatformToolchainDocker = "docker"
+	PlatformToolchainDotnet = "dotnet"
+)
+
+var (
+	goosLineRegex       = regexp.MustCompile(`\bGOOS=["']?([a-z0-9]+)`)
+	goarchLineRegex     = regexp.MustCompile(`\bGOARCH=["']?([a-z0-9]+)`)
+	cargoTargetRegex    = regexp.MustCompile(`\b(?:cargo|cross)\b[^\n]*?--target[= ]["']?([\w.-]+)`)
+	rustupTargetRegex   = regexp.MustCompile(`\brustup\s+target\s+add\s+([\w. -]+)`)
+	dotnetRuntimeRegex  = regexp.MustCompile(`\bdotnet\s+(?:publish|build|run)\b[^\n]*?\s(?:-r|--runtime)[= ]["']?([\w.-]+)`)
+	dockerPlatformRegex = regexp.MustCompile(`(?:--platform[= ]|\bplatforms?:[ \t]*)["']?([\w/.-]+(?:[ \t]*,[ \t]*[\w/.-]+)*)`)
+	msbuildRIDRegex     = regexp.MustCompile(`<RuntimeIdentifiers?>([^<]+)</RuntimeIdentifiers?>`)
+)
+
+// archAliases maps the architecture names of the toolchains to the names
+// Go uses.
+var archAliases = map[string]string{
+	"amd64": "amd64", "x86_64": "amd64", "x64": "amd64", "x86-64": "amd64",
+	"arm64": "arm64", "aarch64": "arm64",
+	"386": "386", "x86": "386", "i386": "386", "i586": "386", "i686": "386",
+	"arm": "arm", "armv5te": "arm", "armv6": "arm", "armv7": "arm", "armv7a": "arm", "armel": "arm", "armhf": "arm",
+	"riscv64": "riscv64", "riscv64gc": "riscv64",
+	"ppc64le": "ppc64le", "powerpc64le": "ppc64le", "ppc64": "ppc64", "powerpc64": "ppc64",
+	"s390x": "s390x", "mips": "mips", "mipsel": "mipsle", "mipsle": "mipsle", "mips64": "mips64", "mips64le": "mips64le",
+	"loong64": "loong64", "loongarch64": "loong64",
+	"wasm": "wasm", "wasm32": "wasm",
+}
+
+// osAliases maps the operating system names of the toolchains to the names
+// Go uses.
+var osAliases = map[string]string{
+	"linux": "linux", "windows": "windows", "win": "windows",
+	"darwin": "darwin", "macos": "darwin", "osx": "darwin",
+	"ios": "ios", "android": "android", "freebsd": "freebsd", "netbsd": "netbsd", "openbsd": "openbsd",
+	"illumos": "illumos", "solaris": "solaris", "aix": "aix", "wasi": "wasip1", "wasip1": "wasip1",
+	"js": "js", "browser": "js", "none": "none",
+}
+
+// TargetPlatform is an operating system and architecture a build targets,
+// named as Go names them (linux/amd64, darwin/arm64, windows/386). Arch is
+// empty when a build names only the operating system.
+type TargetPlatform struct {
+	OS        string
+	Arch      string
+	Toolchain string // go, rust, docker or dotnet
+}
+
+// String returns "os/arch", or the OS alone.
+func (p TargetPlatform) String() string {
+	if p.Arch == "" {
+		return p.OS
+	}
+	return p.OS + "/" + p.Arch
+}
+
+// PlatformsParser reads the platforms builds target from CI workflows,
+// build scripts, Dockerfiles and toolchain configuration.
+type PlatformsParser struct{}
+
+// NewPlatformsParser creates a new target platform parser.
+func NewPlatformsParser() *PlatformsParser {
+	return &PlatformsParser{}
+}
+
+// GoPlatform returns the platform of a GOOS and GOARCH pair. GOARCH may be
+// empty.
+func GoPlatform(goos, goarch string) (TargetPlatform, bool) {
+	os, ok := osAliases[strings.ToLower(goos)]
+	if !ok {
+		return TargetPlatform{}, false
+	}
+	arch := archAliases[strings.ToLower(goarch)]
+	if goarch != "" && arch == "" {
+		return TargetPlatform{}, false
+	}
+	return TargetPlatform{OS: os, Arch: arch, Toolchain: PlatformToolchainGo}, true
+}
+
+// RustTargetPlatform returns the platform of a Rust target triple such as
+// x86_64-unknown-linux-gnu, aarch64-apple-darwin or thumbv7em-none-eabihf.
+func RustTargetPlatform(triple string) (TargetPlatform, bool) {
+	parts := strings.Split(strings.ToLower(triple), "-")
+	if len(parts) < 2 {
+		return TargetPlatform{}, false
+	}
+	arch := archAliases[parts[0]]
+	if arch == "" && (strings.HasPrefix(parts[0], "thumb") || strings.HasPrefix(parts[0], "armv")) {
+		arch = "arm"
+	}
+	if arch == "" {
+		return TargetPlatform{}, false
+	}
+	// The OS is the last known name of the vendor, OS and environment
+	// parts: aarch64-linux-android is android.
+	os := "none"
+	if arch == "wasm" {
+		os = "js"
+	}
+	for _, part := range parts[1:] {
+		if alias, ok := osAliases[strings.TrimSuffix(strings.TrimSuffix(part, "hf"), "eabi")]; ok {
+			os = alias
+		}
+	}
+	return TargetPlatform{OS: os, Arch: arch, Toolchain: PlatformToolchainRust}, true
+}
+
+// DotnetRIDPlatform returns the platform of a .NET runtime identifier such
+// as linux-x64, linux-musl-arm64, win10-x86 or osx.13-arm64. Portable RIDs
+// without an architecture (any, unix) have none.
+func DotnetRIDPlatform(rid string) (TargetPlatform, bool) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(rid)), "-")
+	if len(parts) < 2 {
+		return TargetPlatform{}, false
+	}
+	name, _, _ := strings.Cut(parts[0], ".")
+	os, ok := osAliases[strings.TrimRight(name, "0123456789")]
+	arch := archAliases[parts[len(parts)-1]]
+	if !ok || arch == "" {
+		return TargetPlatform{}, false
+	}
+	return TargetPlatform{OS: os, Arch: arch, Toolchain: PlatformToolchainDotnet}, true
+}
+
+// DockerPlatform returns the platform of an OCI platform such as
+// linux/amd64 or linux/arm/v7; the variant is dropped.
+func DockerPlatform(spec string) (TargetPlatform, bool) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(spec)), "/")
+	if len(parts) < 2 {
+		return TargetPlatform{}, false
+	}
+	os, ok := osAliases[parts[0]]
+	arch := archAliases[parts[1]]
+	if !ok || arch == "" {
+		return TargetPlatform{}, false
+	}
+	return TargetPlatform{OS: os, Arch: arch, Toolchain: PlatformToolchainDocker}, true
+}
+
+// ParseBuildCommands returns the platforms named by the build commands of
+// a script, Makefile, Dockerfile or CI configuration: GOOS and GOARCH
+// assignments on one line, cargo or cross --target and rustup target add,
+// dotnet -r/--runtime, and Docker --platform flags or platforms: settings.
+// Values built from variables are skipped.
+func (p *PlatformsParser) ParseBuildCommands(content string) []TargetPlatform {
+	var platforms platformList
+	for _, line := range strings.Split(content, "\n") {
+		if goos := goosLineRegex.FindStringSubmatch(line); goos != nil {
+			var goarch string
+			if m := goarchLineRegex.FindStringSubmatch(line); m != nil {
+				goarch = m[1]
+			}
+			platforms.add(GoPlatform(goos[1], goarch))
+		}
+	}
+	for _, m := range cargoTargetRegex.FindAllStringSubmatch(content, -1) {
+		platforms.add(RustTargetPlatform(m[1]))
+	}
+	for _, m := range rustupTargetRegex.FindAllStringSubmatch(content, -1) {
+		for _, triple := range strings.Fields(m[1]) {
+			platforms.add(RustTargetPlatform(triple))
+		}
+	}
+	for _, m := range dotnetRuntimeRegex.FindAllStringSubmatch(content, -1) {
+		platforms.add(DotnetRIDPlatform(m[1]))
+	}
+	for _, m := range dockerPlatformRegex.FindAllStringSubmatch(content, -1) {
+		for _, spec := range strings.Split(m[1], ",") {
+			platforms.add(DockerPlatform(spec))
+		}
+	}
+	return platforms
+}
+
+// platformList collects the platforms a parser reads.
+type platformList []TargetPlatform
+
+func (l *platformList) add(platform TargetPlatform, ok bool) {
+	if ok {
+		*l = append(*l, platform)
+	}
+}
+
+// workflowMatrixKeys maps the matrix variables read as target platforms to
+// the function reading their values.
+var workflowMatrixKeys = map[string]func(string) (TargetPlatform, bool){
+	"target":      RustTargetPlatform,
+	"rust-target": RustTargetPlatform,
+	"triple":      RustTargetPlatform,
+	"rid":         DotnetRIDPlatform,
+	"runtime":     DotnetRIDPlatform,
+	"platform":    DockerPlatform,
+}
+
+// ParseWorkflowMatrices returns the platforms of the build matrices of a
+// GitHub Actions workflow: the product of goos and goarch variables, Rust
+// target triples (target, rust-target, triple), .NET runtime identifiers
+// (rid, runtime) and Docker platforms (platform), in the matrix and its
+// include entries.
+func (p *PlatformsParser) ParseWorkflowMatrices(content []byte) []TargetPlatform {
+	var workflow struct {
+		Jobs map[string]struct {
+			Strategy struct {
+				Matrix map[string]interface{} `yaml:"matrix"`
+			} `yaml:"strategy"`
+		} `yaml:"jobs"`
+	}
+	if yaml.Unmarshal(content, &workflow) != nil {
+		return nil
+	}
+	var platforms platformList
+	for _, job := range workflow.Jobs {
+		matrix := job.Strategy.Matrix
+		platforms = append(platforms, matrixPlatforms(matrix)...)
+		include, _ := matrix["include"].([]interface{})
+		for _, entry := range include {
+			if values, ok := entry.(map[string]interface{}); ok {
+				platforms = append(platforms, matrixPlatforms(values)...)
+			}
+		}
+	}
+	return platforms
+}
+
+// matrixPlatforms reads the platforms of a matrix or include entry, whose
+// values are lists or single values.
+func matrixPlatforms(matrix map[string]interface{}) []TargetPlatform {
+	var platforms platformList
+	goarchs := matrixValues(matrix, "goarch")
+	if len(goarchs) == 0 {
+		goarchs = []string{""}
+	}
+	for _, goos := range matrixValues(matrix, "goos") {
+		for _, goarch := range goarchs {
+			platforms.add(GoPlatform(goos, goarch))
+		}
+	}
+	for key, parse := range workflowMatrixKeys {
+		for _, value := range matrixValues(matrix, key) {
+			platforms.add(parse(value))
+		}
+	}
+	return platforms
+}
+
+func matrixValues(matrix map[string]interface{}, key string) []string {
+	value, ok := matrix[key]
+	if !ok {
+		value = matrix[strings.ToUpper(key)]
+	}
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+// goreleaserDefaults are the goos and goarch GoReleaser builds when a build
+// sets none.
+var goreleaserDefaults = map[string][]string{
+	"goos":   {"darwin", "linux", "windows"},
+	"goarch": {"386", "amd64", "arm64"},
+}
+
+// ParseGoreleaser returns the platforms of the builds of a .goreleaser.yml:
+// the product of their goos and goarch lists, or GoReleaser's defaults,
+// without the ignored pairs and darwin/386, which Go does not support.
+func (p *PlatformsParser) ParseGoreleaser(content []byte) []TargetPlatform {
+	var config struct {
+		Builds []struct {
+			Goos   []string `yaml:"goos"`
+			Goarch []string `yaml:"goarch"`
+			Ignore []struct {
+				Goos   string `yaml:"goos"`
+				Goarch string `yaml:"goarch"`
+			} `yaml:"ignore"`
+		} `yaml:"builds"`
+	}
+	if yaml.Unmarshal(content, &config) != nil {
+		return nil
+	}
+	var platforms platformList
+	for _, build := range config.Builds {
+		ignored := map[string]bool{"darwin/386": true}
+		for _, ignore := range build.Ignore {
+			ignored[ignore.Goos+"/"+ignore.Goarch] = true
+		}
+		for _, goos := range orDefault(build.Goos, goreleaserDefaults["goos"]) {
+			for _, goarch := range orDefault(build.Goarch, goreleaserDefaults["goarch"]) {
+				if !ignored[goos+"/"+goarch] {
+					platforms.add(GoPlatform(goos, goarch))
+				}
+			}
+		}
+	}
+	return platforms
+}
+
+func orDefault(values, defaults []string) []string {
+	if len(values) == 0 {
+		return defaults
+	}
+	return values
+}
+
+// ParseRustTargets returns the targets of a rust-toolchain.toml
+// ([toolchain] targets) or a .cargo/config.toml ([build] target, a triple
+// or a list of them).
+func (p *PlatformsParser) ParseRustTargets(content string) []TargetPlatform {
+	var config struct {
+		Toolchain struct {
+			Targets []string `toml:"targets"`
+		} `toml:"toolchain"`
+		Build struct {
+			Target interface{} `toml:"target"`
+		} `toml:"build"`
+	}
+	if _, err := toml.Decode(content, &config); err != nil {
+		return nil
+	}
+	triples := config.Toolchain.Targets
+	switch target := config.Build.Target.(type) {
+	case string:
+		triples = append(triples, target)
+	case []interface{}:
+		for _, item := range target {
+			if triple, ok := item.(string); ok {
+				triples = append(triples, triple)
+			}
+		}
+	}
+	var platforms platformList
+	for _, triple := range triples {
+		platforms.add(RustTargetPlatform(triple))
+	}
+	return platforms
+}
+
+// ParseRuntimeIdentifiers returns the platforms of the RuntimeIdentifier and
+// RuntimeIdentifiers properties of an MSBuild project.
+func (p *PlatformsParser) ParseRuntimeIdentifiers(content string) []TargetPlatform {
+	var platforms platformList
+	for _, m := range msbuildRIDRegex.FindAllStringSubmatch(content, -1) {
+		for _, rid := range strings.Split(m[1], ";") {
+			platforms.add(DotnetRIDPlatform(rid))
+		}
+	}
+	return platforms
+}
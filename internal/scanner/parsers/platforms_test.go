@@ -0,0 +1,138 @@
+package parsers
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func platformNames(platforms []TargetPlatform) []string {
+	names := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		names = append(names, platform.String())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRustTargetPlatform(t *testing.T) {
+	tests := map[string]string{
+		"x86_64-unknown-linux-gnu":      "linux/amd64",
+		"aarch64-apple-darwin":          "darwin/arm64",
+		"x86_64-pc-windows-msvc":        "windows/amd64",
+		"aarch64-linux-android":         "android/arm64",
+		"armv7-unknown-linux-gnueabihf": "linux/arm",
+		"thumbv7em-none-eabihf":         "none/arm",
+		"wasm32-unknown-unknown":        "js/wasm",
+		"wasm32-wasi":                   "wasip1/wasm",
+	}
+	for triple, want := range tests {
+		platform, ok := RustTargetPlatform(triple)
+		assert.True(t, ok, triple)
+		assert.Equal(t, want, platform.String(), triple)
+	}
+	_, ok := RustTargetPlatform("stable")
+	assert.False(t, ok)
+}
+
+func TestDotnetRIDPlatform(t *testing.T) {
+	tests := map[string]string{
+		"linux-x64":        "linux/amd64",
+		"linux-musl-arm64": "linux/arm64",
+		"win10-x86":        "windows/386",
+		"osx.13-arm64":     "darwin/arm64",
+	}
+	for rid, want := range tests {
+		platform, ok := DotnetRIDPlatform(rid)
+		assert.True(t, ok, rid)
+		assert.Equal(t, want, platform.String(), rid)
+	}
+	_, ok := DotnetRIDPlatform("any")
+	assert.False(t, ok)
+}
+
+func TestDockerPlatform(t *testing.T) {
+	platform, ok := DockerPlatform("linux/arm/v7")
+	assert.True(t, ok)
+	assert.Equal(t, TargetPlatform{OS: "linux", Arch: "arm", Toolchain: PlatformToolchainDocker}, platform)
+	_, ok = DockerPlatform("$BUILDPLATFORM")
+	assert.False(t, ok)
+}
+
+func TestParseBuildCommands(t *testing.T) {
+	platforms := NewPlatformsParser().ParseBuildCommands(`build:
+	GOOS=linux GOARCH=arm64 go build -o bin/myapp ./cmd/myapp
+	GOOS=windows go build ./cmd/myapp
+	GOOS=$(OS) GOARCH=$(ARCH) go build ./cmd/myapp
+	cross build --release --target aarch64-unknown-linux-musl
+	rustup target add x86_64-apple-darwin wasm32-unknown-unknown
+	dotnet publish -c Release -r linux-x64 --self-contained
+	docker buildx build --platform linux/amd64,linux/arm64 -t myorg/myapp .
+`)
+	assert.Equal(t, []string{
+		"darwin/amd64", "js/wasm", "linux/amd64", "linux/amd64", "linux/arm64", "linux/arm64", "linux/arm64", "windows",
+	}, platformNames(platforms))
+}
+
+func TestParseWorkflowMatrices(t *testing.T) {
+	platforms := NewPlatformsParser().ParseWorkflowMatrices([]byte(`on: push
+jobs:
+  build:
+    strategy:
+      matrix:
+        goos: [linux, darwin]
+        goarch: [amd64, arm64]
+        include:
+          - goos: windows
+            goarch: amd64
+  rust:
+    strategy:
+      matrix:
+        target:
+          - x86_64-unknown-linux-gnu
+          - release
+  test:
+    strategy:
+      matrix:
+        os: [ubuntu-latest, macos-latest]
+`))
+	assert.Equal(t, []string{
+		"darwin/amd64", "darwin/arm64", "linux/amd64", "linux/amd64", "linux/arm64", "windows/amd64",
+	}, platformNames(platforms))
+}
+
+func TestParseGoreleaser(t *testing.T) {
+	parser := NewPlatformsParser()
+	platforms := parser.ParseGoreleaser([]byte(`builds:
+  - main: ./cmd/myapp
+    goos: [linux, darwin, windows]
+    goarch: [amd64, arm64]
+    ignore:
+      - goos: windows
+        goarch: arm64
+`))
+	assert.Equal(t, []string{"darwin/amd64", "darwin/arm64", "linux/amd64", "linux/arm64", "windows/amd64"}, platformNames(platforms))
+
+	defaults := parser.ParseGoreleaser([]byte("builds:\n  - main: ./cmd/myapp\n"))
+	assert.Len(t, defaults, 8)
+	assert.NotContains(t, platformNames(defaults), "darwin/386")
+}
+
+func TestParseRustTargets(t *testing.T) {
+	parser := NewPlatformsParser()
+	toolchain := parser.ParseRustTargets("[toolchain]\nchannel = \"1.80\"\ntargets = [\"wasm32-unknown-unknown\", \"aarch64-apple-ios\"]\n")
+	assert.Equal(t, []string{"ios/arm64", "js/wasm"}, platformNames(toolchain))
+
+	config := parser.ParseRustTargets("[build]\ntarget = \"thumbv7em-none-eabihf\"\n")
+	assert.Equal(t, []string{"none/arm"}, platformNames(config))
+}
+
+func TestParseRuntimeIdentifiers(t *testing.T) {
+	platforms := NewPlatformsParser().ParseRuntimeIdentifiers(`<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <RuntimeIdentifiers>win-x64;linux-x64;osx-arm64</RuntimeIdentifiers>
+  </PropertyGroup>
+</Project>`)
+	assert.Equal(t, []string{"darwin/arm64", "linux/amd64", "windows/amd64"}, platformNames(platforms))
+}
@@ -0,0 +1,386 @@
+package parsers
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Proxy servers whose configuration ParseConfig reads.
+const (
+	ProxyServerNginx = "nginx"
+	ProxyServerHttpd = "httpd"
+	ProxyServerEnvoy = "envoy"
+)
+
+// nginxSiteDirs are folders whose files are nginx server blocks, whatever
+// their extension.
+var nginxSiteDirs = toSet([]string{"sites-available", "sites-enabled", "conf.d", "nginx"})
+
+var (
+	confCommentRegex = regexp.MustCompile(`(?m)#.*$`)
+
+	nginxBlockRegex    = regexp.MustCompile(`(?m)^\s*(?:server|upstream|http)\s*\{`)
+	nginxListenRegex   = regexp.MustCompile(`(?m)(?:^|[;{])\s*listen\s+([^;]+);`)
+	nginxUpstreamRegex = regexp.MustCompile(`(?s)\bupstream\s+([\w.-]+)\s*\{(.*?)\}`)
+	nginxServerRegex   = regexp.MustCompile(`(?m)(?:^|[;{])\s*server\s+([^\s;]+)`)
+	nginxPassRegex     = regexp.MustCompile(`(?m)(?:^|[;{])\s*(proxy|grpc|fastcgi|uwsgi|scgi)_pass\s+([^\s;]+)`)
+	nginxProtocolRegex = regexp.MustCompile(`(?m)(?:^|[;{])\s*ssl_protocols\s+([^;]+);`)
+	nginxCertRegex     = regexp.MustCompile(`(?m)(?:^|[;{])\s*ssl_certificate\s`)
+
+	httpdMarkerRegex   = regexp.MustCompile(`(?im)^\s*(?:<VirtualHost\b|ProxyPass\s|ServerRoot\s|LoadModule\s)`)
+	httpdListenRegex   = regexp.MustCompile(`(?im)^\s*Listen\s+(\S+)(?:\s+(\w+))?`)
+	httpdVhostRegex    = regexp.MustCompile(`(?is)<VirtualHost\s+([^>]+)>(.*?)</VirtualHost>`)
+	httpdSSLOnRegex    = regexp.MustCompile(`(?im)^\s*SSLEngine\s+on\b`)
+	httpdPassRegex     = regexp.MustCompile(`(?im)^\s*(?:ProxyPass(?:Match)?\s+(?:\S+\s+)?|BalancerMember\s+)((?:https?|wss?|ajp|fcgi|h2c?|grpc)://\S+)`)
+	httpdProtocolRegex = regexp.MustCompile(`(?im)^\s*SSLProtocol\s+(.+)$`)
+	httpdCertRegex     = regexp.MustCompile(`(?im)^\s*SSLCertificateFile\s`)
+)
+
+// envoyExtensions are the extensions of Envoy bootstrap files.
+var envoyExtensions = toSet([]string{".yaml", ".yml", ".json"})
+
+// wildcardHosts are listen addresses that mean "all interfaces".
+var wildcardHosts = toSet([]string{"*", "0.0.0.0", "::", "_default_"})
+
+// schemePorts are the default ports of upstream URL schemes.
+var schemePorts = map[string]int{"http": 80, "ws": 80, "https": 443, "wss": 443, "grpc": 80, "h2c": 80, "h2": 443, "ajp": 8009}
+
+// ProxyConfig is the service exposure of a web server or proxy configuration
+// file: the ports it listens on, the upstreams it forwards to and its TLS
+// settings.
+type ProxyConfig struct {
+	Server    string          `json:"server"` // nginx, httpd, envoy
+	File      string          `json:"file"`
+	Listeners []ProxyListener `json:"listeners,omitempty"`
+	Upstreams []ProxyUpstream `json:"upstreams,omitempty"`
+	TLS       *ProxyTLS       `json:"tls,omitempty"`
+}
+
+// ProxyListener is a port the server accepts connections on.
+type ProxyListener struct {
+	Address string `json:"address,omitempty"`
+	Port    int    `json:"port"`
+	TLS     bool   `json:"tls,omitempty"`
+}
+
+// ProxyUpstream is a host the server forwards requests to.
+type ProxyUpstream struct {
+	Name string `json:"name,omitempty"` // nginx upstream block or Envoy cluster
+	Host string `json:"host"`
+	Port int    `json:"port,omitempty"`
+}
+
+// ProxyTLS summarizes the TLS settings of the server side.
+type ProxyTLS struct {
+	Protocols    []string `json:"protocols,omitempty"`
+	Certificates int      `json:"certificates"`
+}
+
+// ProxyParser parses nginx, Apache httpd and Envoy configuration files.
+type ProxyParser struct{}
+
+// NewProxyParser creates a new proxy configuration parser.
+func NewProxyParser() *ProxyParser {
+	return &ProxyParser{}
+}
+
+// ParseConfig returns the service exposure of a server configuration file,
+// or nil when relPath is not one or declares neither listeners nor upstreams.
+// Files are recognized by name (nginx.conf, httpd.conf, apache2.conf,
+// envoy.yaml) or, for .conf files and nginx site folders, by their
+// directives.
+func (p *ProxyParser) ParseConfig(relPath string, content []byte) *ProxyConfig {
+	var config *ProxyConfig
+	switch proxyServerOf(relPath, content) {
+	case ProxyServerNginx:
+		config = parseNginx(string(content))
+	case ProxyServerHttpd:
+		config = parseHttpd(string(content))
+	case ProxyServerEnvoy:
+		config = parseEnvoy(content)
+	}
+	if config == nil || (len(config.Listeners) == 0 && len(config.Upstreams) == 0) {
+		return nil
+	}
+	return config
+}
+
+// proxyServerOf returns the server a configuration file belongs to.
+func proxyServerOf(relPath string, content []byte) string {
+	name := strings.ToLower(path.Base(relPath))
+	ext := path.Ext(name)
+	switch {
+	case name == "nginx.conf":
+		return ProxyServerNginx
+	case name == "httpd.conf" || name == "apache2.conf":
+		return ProxyServerHttpd
+	case strings.HasPrefix(name, "envoy") && envoyExtensions[ext]:
+		return ProxyServerEnvoy
+	case ext == ".conf" || (ext == "" && nginxSiteDirs[path.Base(path.Dir(relPath))]):
+		return sniffProxyServer(content)
+	}
+	return ""
+}
+
+// sniffProxyServer tells httpd and nginx configuration files apart by their
+// directives.
+func sniffProxyServer(content []byte) string {
+	if httpdMarkerRegex.Match(content) {
+		return ProxyServerHttpd
+	}
+	if nginxBlockRegex.Match(content) {
+		return ProxyServerNginx
+	}
+	return ""
+}
+
+func parseNginx(content string) *ProxyConfig {
+	content = confCommentRegex.ReplaceAllString(content, "")
+	config := &ProxyConfig{Server: ProxyServerNginx}
+	for _, m := range nginxListenRegex.FindAllStringSubmatch(content, -1) {
+		if listener, ok := nginxListener(strings.Fields(m[1])); ok {
+			config.addListener(listener)
+		}
+	}
+
+	groups := make(map[string]bool)
+	for _, m := range nginxUpstreamRegex.FindAllStringSubmatch(content, -1) {
+		groups[m[1]] = true
+		for _, server := range nginxServerRegex.FindAllStringSubmatch(m[2], -1) {
+			if upstream, ok := passTarget(server[1]); ok {
+				upstream.Name = m[1]
+				config.addUpstream(upstream)
+			}
+		}
+	}
+	for _, m := range nginxPassRegex.FindAllStringSubmatch(content, -1) {
+		if upstream, ok := passTarget(m[2]); ok && !groups[upstream.Host] {
+			config.addUpstream(upstream)
+		}
+	}
+
+	config.TLS = tlsSettings(nginxProtocolRegex, nginxCertRegex, content)
+	return config
+}
+
+// nginxListener reads the arguments of a listen directive: an address, a
+// port or both, then flags (ssl, http2, quic, default_server, ...).
+func nginxListener(args []string) (ProxyListener, bool) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "unix:") {
+		return ProxyListener{}, false
+	}
+	listener := ProxyListener{}
+	if port, err := strconv.Atoi(args[0]); err == nil {
+		listener.Port = port
+	} else {
+		listener.Address, listener.Port = splitHostPort(args[0], 80)
+	}
+	for _, flag := range args[1:] {
+		listener.TLS = listener.TLS || flag == "ssl" || flag == "quic"
+	}
+	return listener, listener.Port > 0
+}
+
+func parseHttpd(content string) *ProxyConfig {
+	content = confCommentRegex.ReplaceAllString(content, "")
+	config := &ProxyConfig{Server: ProxyServerHttpd}
+	for _, m := range httpdListenRegex.FindAllStringSubmatch(content, -1) {
+		address, port := splitHostPort(m[1], 0)
+		if p, err := strconv.Atoi(m[1]); err == nil {
+			address, port = "", p
+		}
+		if port > 0 {
+			config.addListener(ProxyListener{Address: address, Port: port, TLS: strings.EqualFold(m[2], "https")})
+		}
+	}
+	for _, m := range httpdVhostRegex.FindAllStringSubmatch(content, -1) {
+		tls := httpdSSLOnRegex.MatchString(m[2])
+		for _, address := range strings.Fields(m[1]) {
+			if _, port := splitHostPort(address, 0); port > 0 {
+				config.addListener(ProxyListener{Port: port, TLS: tls})
+			}
+		}
+	}
+	for _, m := range httpdPassRegex.FindAllStringSubmatch(content, -1) {
+		if upstream, ok := passTarget(m[1]); ok {
+			config.addUpstream(upstream)
+		}
+	}
+	config.TLS = tlsSettings(httpdProtocolRegex, httpdCertRegex, content)
+	return config
+}
+
+// passTarget reads the target of a proxy_pass, ProxyPass or BalancerMember
+// directive: a URL (http://app:8000/api) or host:port. Targets built from
+// variables or unix sockets are skipped.
+func passTarget(target string) (ProxyUpstream, bool) {
+	if strings.Contains(target, "$") || strings.HasPrefix(target, "unix:") {
+		return ProxyUpstream{}, false
+	}
+	if !strings.Contains(target, "://") {
+		host, port := splitHostPort(target, 0)
+		return ProxyUpstream{Host: host, Port: port}, host != ""
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Hostname() == "" {
+		return ProxyUpstream{}, false
+	}
+	port, _ := strconv.Atoi(u.Port())
+	if port == 0 {
+		port = schemePorts[strings.ToLower(u.Scheme)]
+	}
+	return ProxyUpstream{Host: u.Hostname(), Port: port}, true
+}
+
+// splitHostPort splits host:port ([::1]:8080, *:443, app), using
+// defaultPort when no port is given. Wildcard addresses become "".
+func splitHostPort(s string, defaultPort int) (string, int) {
+	host, portText, err := net.SplitHostPort(s)
+	if err != nil {
+		host, portText = strings.Trim(s, "[]"), ""
+	}
+	port := defaultPort
+	if p, err := strconv.Atoi(portText); err == nil {
+		port = p
+	}
+	if wildcardHosts[host] {
+		host = ""
+	}
+	return host, port
+}
+
+// tlsSettings returns the protocols and certificate count of a
+// configuration, or nil when it configures no TLS.
+func tlsSettings(protocolRegex, certRegex *regexp.Regexp, content string) *ProxyTLS {
+	tls := &ProxyTLS{Certificates: len(certRegex.FindAllStringIndex(content, -1))}
+	for _, m := range protocolRegex.FindAllStringSubmatch(content, -1) {
+		tls.Protocols = append(tls.Protocols, strings.Fields(m[1])...)
+	}
+	tls.Protocols = sortedUnique(tls.Protocols)
+	if tls.Certificates == 0 && len(tls.Protocols) == 0 {
+		return nil
+	}
+	return tls
+}
+
+func (c *ProxyConfig) addListener(listener ProxyListener) {
+	for i, existing := range c.Listeners {
+		if existing.Address == listener.Address && existing.Port == listener.Port {
+			c.Listeners[i].TLS = existing.TLS || listener.TLS
+			return
+		}
+	}
+	c.Listeners = append(c.Listeners, listener)
+}
+
+func (c *ProxyConfig) addUpstream(upstream ProxyUpstream) {
+	for _, existing := range c.Upstreams {
+		if existing == upstream {
+			return
+		}
+	}
+	c.Upstreams = append(c.Upstreams, upstream)
+}
+
+// envoyConfig holds the parts of an Envoy bootstrap configuration that
+// describe service exposure.
+type envoyConfig struct {
+	StaticResources struct {
+		Listeners []envoyListener `yaml:"listeners"`
+		Clusters  []struct {
+			Name           string `yaml:"name"`
+			LoadAssignment struct {
+				Endpoints []struct {
+					LBEndpoints []struct {
+						Endpoint struct {
+							Address envoyAddress `yaml:"address"`
+						} `yaml:"endpoint"`
+					} `yaml:"lb_endpoints"`
+				} `yaml:"endpoints"`
+			} `yaml:"load_assignment"`
+		} `yaml:"clusters"`
+	} `yaml:"static_resources"`
+}
+
+type envoyListener struct {
+	Address      envoyAddress `yaml:"address"`
+	FilterChains []struct {
+		TransportSocket *struct {
+			Name        string `yaml:"name"`
+			TypedConfig struct {
+				CommonTLSContext envoyTLSContext `yaml:"common_tls_context"`
+			} `yaml:"typed_config"`
+		} `yaml:"transport_socket"`
+	} `yaml:"filter_chains"`
+}
+
+type envoyAddress struct {
+	SocketAddress struct {
+		Address   string `yaml:"address"`
+		PortValue int    `yaml:"port_value"`
+	} `yaml:"socket_address"`
+}
+
+type envoyTLSContext struct {
+	TLSParams struct {
+		Minimum string `yaml:"tls_minimum_protocol_version"`
+		Maximum string `yaml:"tls_maximum_protocol_version"`
+	} `yaml:"tls_params"`
+	TLSCertificates []interface{} `yaml:"tls_certificates"`
+}
+
+// parseEnvoy reads the static listeners and clusters of an Envoy bootstrap
+// file. A listener is TLS when a filter chain has a transport socket; its
+// TLS parameters feed the TLS summary.
+func parseEnvoy(content []byte) *ProxyConfig {
+	var doc envoyConfig
+	if yaml.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+	config := &ProxyConfig{Server: ProxyServerEnvoy}
+	tls := &ProxyTLS{}
+	for _, listener := range doc.StaticResources.Listeners {
+		config.addListener(envoyListenerOf(listener, tls))
+	}
+	for _, cluster := range doc.StaticResources.Clusters {
+		for _, endpoints := range cluster.LoadAssignment.Endpoints {
+			for _, lb := range endpoints.LBEndpoints {
+				socket := lb.Endpoint.Address.SocketAddress
+				if socket.Address != "" {
+					config.addUpstream(ProxyUpstream{Name: cluster.Name, Host: socket.Address, Port: socket.PortValue})
+				}
+			}
+		}
+	}
+	tls.Protocols = sortedUnique(tls.Protocols)
+	if tls.Certificates > 0 || len(tls.Protocols) > 0 {
+		config.TLS = tls
+	}
+	return config
+}
+
+// envoyListenerOf reads a listener, adding the TLS parameters of its filter
+// chains to tls.
+func envoyListenerOf(listener envoyListener, tls *ProxyTLS) ProxyListener {
+	socket := listener.Address.SocketAddress
+	entry := ProxyListener{Address: socket.Address, Port: socket.PortValue}
+	if wildcardHosts[entry.Address] {
+		entry.Address = ""
+	}
+	for _, chain := range listener.FilterChains {
+		if chain.TransportSocket == nil {
+			continue
+		}
+		entry.TLS = true
+		context := chain.TransportSocket.TypedConfig.CommonTLSContext
+		tls.Certificates += len(context.TLSCertificates)
+		tls.Protocols = append(tls.Protocols, context.TLSParams.Minimum, context.TLSParams.Maximum)
+	}
+	return entry
+}
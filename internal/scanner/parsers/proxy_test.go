@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyParser_Nginx(t *testing.T) {
+	content := `http {
+    upstream backend {
+        server api:3000 weight=5;
+        server api-2.internal:3000;
+    }
+    server {
+        listen 80;
+        listen 443 ssl http2;
+        listen [::]:443 ssl;
+        # listen 8443 ssl;
+        ssl_certificate /etc/nginx/certs/myapp.crt;
+        ssl_protocols TLSv1.2 TLSv1.3;
+
+        location /api/ { proxy_pass http://backend; }
+        location /grpc { grpc_pass grpc://worker:50051; }
+        location /auth { proxy_pass https://auth.example.com/; }
+        location /dyn  { proxy_pass http://$target; }
+        location ~ \.php$ { fastcgi_pass php:9000; }
+    }
+}
+`
+	config := NewProxyParser().ParseConfig("deploy/nginx.conf", []byte(content))
+	require.NotNil(t, config)
+	assert.Equal(t, ProxyServerNginx, config.Server)
+	assert.Equal(t, []ProxyListener{{Port: 80}, {Port: 443, TLS: true}}, config.Listeners)
+	assert.Equal(t, []ProxyUpstream{
+		{Name: "backend", Host: "api", Port: 3000},
+		{Name: "backend", Host: "api-2.internal", Port: 3000},
+		{Host: "worker", Port: 50051},
+		{Host: "auth.example.com", Port: 443},
+		{Host: "php", Port: 9000},
+	}, config.Upstreams)
+	assert.Equal(t, &ProxyTLS{Protocols: []string{"TLSv1.2", "TLSv1.3"}, Certificates: 1}, config.TLS)
+}
+
+func TestProxyParser_NginxSiteFile(t *testing.T) {
+	content := "server {\n  listen 8080;\n  location / { proxy_pass http://localhost:3000; }\n}\n"
+	config := NewProxyParser().ParseConfig("nginx/sites-enabled/default", []byte(content))
+	require.NotNil(t, config)
+	assert.Equal(t, []ProxyListener{{Port: 8080}}, config.Listeners)
+	assert.Equal(t, []ProxyUpstream{{Host: "localhost", Port: 3000}}, config.Upstreams)
+	assert.Nil(t, config.TLS)
+
+	assert.Nil(t, NewProxyParser().ParseConfig("etc/supervisord.conf", []byte("[program:app]\ncommand=run\n")))
+}
+
+func TestProxyParser_Httpd(t *testing.T) {
+	content := `Listen 80
+Listen 443 https
+<VirtualHost *:443>
+    ServerName www.example.com
+    SSLEngine on
+    SSLProtocol -all +TLSv1.2 +TLSv1.3
+    SSLCertificateFile /etc/ssl/certs/myapp.pem
+    ProxyPass /api http://api:8080/
+    ProxyPassReverse /api http://api:8080/
+    <Proxy balancer://web>
+        BalancerMember http://web1:8000
+        BalancerMember http://web2:8000
+    </Proxy>
+    ProxyPass /app balancer://web/
+</VirtualHost>
+`
+	config := NewProxyParser().ParseConfig("conf/httpd.conf", []byte(content))
+	require.NotNil(t, config)
+	assert.Equal(t, ProxyServerHttpd, config.Server)
+	assert.Equal(t, []ProxyListener{{Port: 80}, {Port: 443, TLS: true}}, config.Listeners)
+	assert.Equal(t, []ProxyUpstream{
+		{Host: "api", Port: 8080},
+		{Host: "web1", Port: 8000},
+		{Host: "web2", Port: 8000},
+	}, config.Upstreams)
+	assert.Equal(t, &ProxyTLS{Protocols: []string{"+TLSv1.2", "+TLSv1.3", "-all"}, Certificates: 1}, config.TLS)
+}
+
+func TestProxyParser_Envoy(t *testing.T) {
+	content := `static_resources:
+  listeners:
+    - name: ingress
+      address:
+        socket_address: { address: 0.0.0.0, port_value: 8443 }
+      filter_chains:
+        - transport_socket:
+            name: envoy.transport_sockets.tls
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.DownstreamTlsContext
+              common_tls_context:
+                tls_params: { tls_minimum_protocol_version: TLSv1_2 }
+                tls_certificates:
+                  - certificate_chain: { filename: /etc/envoy/cert.pem }
+                    private_key: { filename: /etc/envoy/key.pem }
+  clusters:
+    - name: orders
+      load_assignment:
+        cluster_name: orders
+        endpoints:
+          - lb_endpoints:
+              - endpoint:
+                  address:
+                    socket_address: { address: orders, port_value: 9000 }
+`
+	config := NewProxyParser().ParseConfig("envoy.yaml", []byte(content))
+	require.NotNil(t, config)
+	assert.Equal(t, ProxyServerEnvoy, config.Server)
+	assert.Equal(t, []ProxyListener{{Port: 8443, TLS: true}}, config.Listeners)
+	assert.Equal(t, []ProxyUpstream{{Name: "orders", Host: "orders", Port: 9000}}, config.Upstreams)
+	assert.Equal(t, &ProxyTLS{Protocols: []string{"TLSv1_2"}, Certificates: 1}, config.TLS)
+}
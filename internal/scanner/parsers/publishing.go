@@ -0,0 +1,390 @@
+package parsers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of publishing targets.
+const (
+	PublishKindDocker = "docker"
+	PublishKindNpm    = "npm"
+	PublishKindMaven  = "maven"
+	PublishKindPyPI   = "pypi"
+	PublishKindNuget  = "nuget"
+	PublishKindCargo  = "cargo"
+	PublishKindHelm   = "helm"
+)
+
+var (
+	lineContinuationRegex = regexp.MustCompile(`\\\r?\n\s*`)
+	dockerPushRegex       = regexp.MustCompile(`\b(?:docker|podman)\s+(?:image\s+)?push\s+(?:-{1,2}[\w-]+(?:=\S+)?\s+)*["']?([^\s"';|&]+)`)
+	dockerBuildPushRegex  = regexp.MustCompile(`\b(?:docker\s+(?:buildx\s+)?build|podman\s+build)\b.*\s--push\b`)
+	dockerTagRegex        = regexp.MustCompile(`\s(?:-t|--tag)[= ]["']?([^\s"']+)`)
+	helmPushRegex         = regexp.MustCompile(`\bhelm\s+push\s+\S+\s+["']?oci://([^\s"']+)`)
+	gradleMavenRepoRegex  = regexp.MustCompile(`(?s)\bmaven\s*(?:\(\s*)?\{(.*?)\}`)
+	gradleURLRegex        = regexp.MustCompile(`\burl\s*=?\s*(?:uri\(\s*)?["']([^"']+)["']`)
+	gradleNameRegex       = regexp.MustCompile(`\bname\s*=?\s*["']([^"']+)["']`)
+	gradleJibImageRegex   = regexp.MustCompile(`(?s)\bto\s*\{[^}]*?\bimage\s*=?\s*["']([^"']+)["']`)
+	pomJibImageRegex      = regexp.MustCompile(`<to>\s*<image>\s*([^<\s]+)\s*</image>`)
+)
+
+// PublishTarget is a registry a build publishes artifacts to. URLs are
+// sanitized; a registry or repository built from variables is left out.
+type PublishTarget struct {
+	Kind       string // docker, npm, maven, pypi, nuget, cargo or helm
+	Registry   string // host of the registry
+	Repository string // image or chart repository, or the name of a configured repository
+	URL        string
+	// Fallback is set when the command names no registry and the tool's
+	// default is assumed; the project configuration may point elsewhere.
+	Fallback bool
+}
+
+// publishCommand is a publishing command of a package manager: the
+// arguments it is called with, how they name a registry URL or a
+// configured repository, and the registry used when they name neither.
+type publishCommand struct {
+	kind       string
+	command    *regexp.Regexp
+	registry   *regexp.Regexp
+	repository *regexp.Regexp
+	fallback   string
+}
+
+var publishCommands = []publishCommand{
+	{
+		kind:     PublishKindNpm,
+		command:  regexp.MustCompile(`\b(?:npm|pnpm|yarn(?:\s+npm)?)\s+publish\b(.*)`),
+		registry: regexp.MustCompile(`--registry[= ]["']?([^\s"']+)`),
+		fallback: "registry.npmjs.org",
+	},
+	{
+		kind:       PublishKindPyPI,
+		command:    regexp.MustCompile(`\btwine\s+upload\b(.*)`),
+		registry:   regexp.MustCompile(`--repository-url[= ]["']?([^\s"']+)`),
+		repository: regexp.MustCompile(`(?:\s-r|--repository)[= ]["']?([\w.-]+)`),
+		fallback:   "pypi.org",
+	},
+	{
+		kind:       PublishKindPyPI,
+		command:    regexp.MustCompile(`\bpoetry\s+publish\b(.*)`),
+		repository: regexp.MustCompile(`(?:\s-r|--repository)[= ]["']?([\w.-]+)`),
+		fallback:   "pypi.org",
+	},
+	{
+		kind:     PublishKindPyPI,
+		command:  regexp.MustCompile(`\buv\s+publish\b(.*)`),
+		registry: regexp.MustCompile(`--publish-url[= ]["']?([^\s"']+)`),
+		fallback: "pypi.org",
+	},
+	{
+		kind:     PublishKindNuget,
+		command:  regexp.MustCompile(`\b(?:dotnet\s+nuget|nuget(?:\.exe)?)\s+push\b(.*)`),
+		registry: regexp.MustCompile(`(?:\s-s|--source|-Source)[= ]["']?([^\s"']+)`),
+		fallback: "api.nuget.org",
+	},
+	{
+		kind:       PublishKindCargo,
+		command:    regexp.MustCompile(`\bcargo\s+publish\b(.*)`),
+		repository: regexp.MustCompile(`--registry[= ]["']?([\w.-]+)`),
+		fallback:   "crates.io",
+	},
+}
+
+// knownRepositories are the registries of repositories configured under a
+// well-known name.
+var knownRepositories = map[string]string{
+	"pypi":     "pypi.org",
+	"testpypi": "test.pypi.org",
+}
+
+// PublishingParser reads where builds publish artifacts: push and publish
+// commands of CI workflows and scripts, publishing actions, and the
+// distribution settings of package manifests and build files.
+type PublishingParser struct{}
+
+// NewPublishingParser creates a new publishing target parser.
+func NewPublishingParser() *PublishingParser {
+	return &PublishingParser{}
+}
+
+// DockerImageTarget returns the registry and repository of an image
+// reference such as ghcr.io/myorg/myapp:1.0 or myorg/myapp (Docker Hub).
+// The tag and digest are dropped, and a repository built from variables is
+// left empty.
+func DockerImageTarget(reference string) (PublishTarget, bool) {
+	reference = strings.Trim(strings.TrimSpace(reference), `"'`)
+	if reference == "" || isTemplated(reference[:1]) || strings.HasPrefix(reference, "{{") {
+		return PublishTarget{}, false
+	}
+	reference, _, _ = strings.Cut(reference, "@")
+	reference = imageRepository(reference)
+	registry, repository := "docker.io", reference
+	if first, rest, ok := strings.Cut(reference, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, repository = first, rest
+	}
+	if isTemplated(registry) {
+		return PublishTarget{}, false
+	}
+	if isTemplated(repository) {
+		repository = ""
+	}
+	return PublishTarget{Kind: PublishKindDocker, Registry: strings.ToLower(registry), Repository: repository}, true
+}
+
+// isTemplated reports whether a value holds a shell, Make, CI or Maven
+// variable, or a Go template.
+func isTemplated(value string) bool {
+	return strings.ContainsAny(value, "$%") || strings.Contains(value, "{{")
+}
+
+// urlTarget returns a target for a registry URL, without credentials, query
+// and fragment.
+func urlTarget(kind, rawURL string) (PublishTarget, bool) {
+	if isTemplated(rawURL) {
+		return PublishTarget{}, false
+	}
+	clean, host := SanitizeRegistryURL(rawURL)
+	if host == "" {
+		return PublishTarget{}, false
+	}
+	return PublishTarget{Kind: kind, Registry: strings.ToLower(host), URL: clean}, true
+}
+
+// ParsePublishCommands returns the targets of the publishing commands of a
+// script, Makefile or CI configuration: docker and podman push, docker
+// build --push tags, npm, pnpm and yarn publish, twine upload, poetry and
+// uv publish, dotnet nuget push, cargo publish and helm push to an OCI
+// registry. Commands continued over several lines are joined.
+func (p *PublishingParser) ParsePublishCommands(content string) []PublishTarget {
+	content = lineContinuationRegex.ReplaceAllString(content, " ")
+	var targets publishTargets
+	for _, m := range dockerPushRegex.FindAllStringSubmatch(content, -1) {
+		targets.add(DockerImageTarget(m[1]))
+	}
+	for _, line := range dockerBuildPushRegex.FindAllString(content, -1) {
+		for _, m := range dockerTagRegex.FindAllStringSubmatch(line, -1) {
+			targets.add(DockerImageTarget(m[1]))
+		}
+	}
+	for _, m := range helmPushRegex.FindAllStringSubmatch(content, -1) {
+		targets.add(helmTarget(m[1]))
+	}
+	for _, command := range publishCommands {
+		for _, m := range command.command.FindAllStringSubmatch(content, -1) {
+			targets.add(command.target(m[1]))
+		}
+	}
+	return targets
+}
+
+// target returns the target of a publishing command called with args.
+func (c publishCommand) target(args string) (PublishTarget, bool) {
+	if c.registry != nil {
+		if m := c.registry.FindStringSubmatch(args); m != nil {
+			return urlTarget(c.kind, m[1])
+		}
+	}
+	if c.repository != nil {
+		if m := c.repository.FindStringSubmatch(args); m != nil {
+			return PublishTarget{Kind: c.kind, Registry: knownRepositories[m[1]], Repository: m[1]}, !isTemplated(m[1])
+		}
+	}
+	return PublishTarget{Kind: c.kind, Registry: c.fallback, Fallback: true}, true
+}
+
+func helmTarget(reference string) (PublishTarget, bool) {
+	registry, repository, _ := strings.Cut(reference, "/")
+	if isTemplated(registry) {
+		return PublishTarget{}, false
+	}
+	if isTemplated(repository) {
+		repository = ""
+	}
+	return PublishTarget{Kind: PublishKindHelm, Registry: strings.ToLower(registry), Repository: repository}, true
+}
+
+// publishTargets collects the targets a parser reads.
+type publishTargets []PublishTarget
+
+func (l *publishTargets) add(target PublishTarget, ok bool) {
+	if ok {
+		*l = append(*l, target)
+	}
+}
+
+// workflowStep is a step of a GitHub Actions job.
+type workflowStep struct {
+	Uses string                 `yaml:"uses"`
+	With map[string]interface{} `yaml:"with"`
+}
+
+// ParseWorkflowPublishing returns the targets of the publishing actions of
+// a GitHub Actions workflow: docker/build-push-action steps that push,
+// pypa/gh-action-pypi-publish and JS-DevTools/npm-publish, and the
+// registry-url of actions/setup-node when the workflow runs npm publish.
+func (p *PublishingParser) ParseWorkflowPublishing(content []byte) []PublishTarget {
+	var workflow struct {
+		Jobs map[string]struct {
+			Steps []workflowStep `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	if yaml.Unmarshal(content, &workflow) != nil {
+		return nil
+	}
+	npmPublish := publishCommands[0].command.Match(content)
+	var targets publishTargets
+	for _, job := range workflow.Jobs {
+		for _, step := range job.Steps {
+			action, _, _ := strings.Cut(step.Uses, "@")
+			switch action {
+			case "docker/build-push-action":
+				targets = append(targets, buildPushTargets(step.With)...)
+			case "pypa/gh-action-pypi-publish":
+				targets.add(withRegistry(step.With, PublishKindPyPI, "pypi.org", "repository-url", "repository_url"))
+			case "JS-DevTools/npm-publish":
+				targets.add(withRegistry(step.With, PublishKindNpm, "registry.npmjs.org", "registry"))
+			case "actions/setup-node":
+				if url, ok := step.With["registry-url"].(string); ok && npmPublish {
+					targets.add(urlTarget(PublishKindNpm, url))
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// buildPushTargets returns the image tags of a docker/build-push-action
+// step that pushes. A push depending on an expression counts as a push.
+func buildPushTargets(with map[string]interface{}) []PublishTarget {
+	if !isPushing(with["push"]) {
+		return nil
+	}
+	var targets publishTargets
+	for _, tag := range matrixValues(with, "tags") {
+		for _, reference := range strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == '\n' }) {
+			targets.add(DockerImageTarget(reference))
+		}
+	}
+	return targets
+}
+
+func isPushing(push interface{}) bool {
+	switch v := push.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	}
+	return false
+}
+
+// withRegistry returns the target of a publishing action, from the first
+// of keys set in its inputs, or the action's default registry.
+func withRegistry(with map[string]interface{}, kind, fallback string, keys ...string) (PublishTarget, bool) {
+	for _, key := range keys {
+		if url, ok := with[key].(string); ok && url != "" {
+			return urlTarget(kind, url)
+		}
+	}
+	return PublishTarget{Kind: kind, Registry: fallback, Fallback: true}, true
+}
+
+// ParsePublishConfig returns the registry of the publishConfig of a
+// package.json.
+func (p *PublishingParser) ParsePublishConfig(content []byte) []PublishTarget {
+	var manifest struct {
+		Private       bool `json:"private"`
+		PublishConfig struct {
+			Registry string `json:"registry"`
+		} `json:"publishConfig"`
+	}
+	if json.Unmarshal(content, &manifest) != nil || manifest.Private || manifest.PublishConfig.Registry == "" {
+		return nil
+	}
+	var targets publishTargets
+	targets.add(urlTarget(PublishKindNpm, manifest.PublishConfig.Registry))
+	return targets
+}
+
+// pomRepository is a repository of the distributionManagement of a POM.
+type pomRepository struct {
+	ID  string `xml:"id"`
+	URL string `xml:"url"`
+}
+
+// ParsePOMPublishing returns the release and snapshot repositories of the
+// distributionManagement of a pom.xml, and the image the Jib plugin pushes
+// to. A repository whose URL is a property keeps only its id.
+func (p *PublishingParser) ParsePOMPublishing(content []byte) []PublishTarget {
+	var pom struct {
+		DistributionManagement struct {
+			Repository         pomRepository `xml:"repository"`
+			SnapshotRepository pomRepository `xml:"snapshotRepository"`
+		} `xml:"distributionManagement"`
+	}
+	if xml.Unmarshal(content, &pom) != nil {
+		return nil
+	}
+	var targets publishTargets
+	for _, repository := range []pomRepository{pom.DistributionManagement.Repository, pom.DistributionManagement.SnapshotRepository} {
+		targets.add(mavenTarget(repository.ID, repository.URL))
+	}
+	for _, m := range pomJibImageRegex.FindAllSubmatch(content, -1) {
+		targets.add(DockerImageTarget(string(m[1])))
+	}
+	return targets
+}
+
+// ParseGradlePublishing returns the Maven repositories of the publishing
+// block of a build.gradle or build.gradle.kts, and the image the Jib
+// plugin pushes to.
+func (p *PublishingParser) ParseGradlePublishing(content string) []PublishTarget {
+	var targets publishTargets
+	if i := strings.Index(content, "publishing"); i >= 0 {
+		for _, m := range gradleMavenRepoRegex.FindAllStringSubmatch(content[i:], -1) {
+			var id, url string
+			if name := gradleNameRegex.FindStringSubmatch(m[1]); name != nil {
+				id = name[1]
+			}
+			if u := gradleURLRegex.FindStringSubmatch(m[1]); u != nil {
+				url = u[1]
+			}
+			targets.add(mavenTarget(id, url))
+		}
+	}
+	if m := gradleJibImageRegex.FindStringSubmatch(content); m != nil {
+		targets.add(DockerImageTarget(m[1]))
+	}
+	return targets
+}
+
+func mavenTarget(id, url string) (PublishTarget, bool) {
+	id, url = strings.TrimSpace(id), strings.TrimSpace(url)
+	if target, ok := urlTarget(PublishKindMaven, url); ok {
+		target.Repository = id
+		return target, true
+	}
+	return PublishTarget{Kind: PublishKindMaven, Repository: id}, id != "" && !isTemplated(id)
+}
+
+// ParseGoreleaserImages returns the Docker images the builds of a
+// .goreleaser.yml push.
+func (p *PublishingParser) ParseGoreleaserImages(content []byte) []PublishTarget {
+	var config goreleaserConfig
+	if yaml.Unmarshal(content, &config) != nil {
+		return nil
+	}
+	var targets publishTargets
+	for _, docker := range config.Dockers {
+		for _, template := range docker.ImageTemplates {
+			targets.add(DockerImageTarget(template))
+		}
+	}
+	return targets
+}
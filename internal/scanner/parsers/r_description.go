@@ -0,0 +1,96 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// rDescriptionFields maps DESCRIPTION dependency fields to their scope.
+// Suggests lists packages only needed for tests, examples or vignettes.
+var rDescriptionFields = []struct {
+	field string
+	scope string
+}{
+	{"Depends", types.ScopeProd},
+	{"Imports", types.ScopeProd},
+	{"LinkingTo", types.ScopeBuild},
+	{"Suggests", types.ScopeOptional},
+}
+
+// rPackageRefRegex matches one DESCRIPTION package reference: "dplyr" or
+// "dplyr (>= 1.1.0)".
+var rPackageRefRegex = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9.]*)\s*(?:\(\s*([^)]*?)\s*\))?$`)
+
+// RDescription holds the fields of an R package DESCRIPTION file.
+type RDescription struct {
+	Package      string
+	Version      string
+	RVersion     string // constraint on R itself from Depends, e.g. ">= 4.1.0"
+	Dependencies []types.Dependency
+}
+
+// DescriptionParser parses R package DESCRIPTION files (Debian control
+// file format: "Field: value" with indented continuation lines).
+type DescriptionParser struct{}
+
+// NewDescriptionParser creates a new DESCRIPTION parser.
+func NewDescriptionParser() *DescriptionParser {
+	return &DescriptionParser{}
+}
+
+// ParseDescription parses a DESCRIPTION file. Dependencies come from Depends
+// and Imports (prod), LinkingTo (build) and Suggests (optional); their
+// version is the declared constraint. R itself and base packages are not
+// dependencies; the R constraint is returned in RVersion.
+func (p *DescriptionParser) ParseDescription(content string) *RDescription {
+	fields := parseDCF(content)
+	desc := &RDescription{Package: fields["Package"], Version: fields["Version"]}
+	for _, f := range rDescriptionFields {
+		for _, ref := range strings.Split(fields[f.field], ",") {
+			m := rPackageRefRegex.FindStringSubmatch(strings.TrimSpace(ref))
+			if m == nil {
+				continue
+			}
+			if m[1] == "R" {
+				desc.RVersion = m[2]
+				continue
+			}
+			if rBasePackage(m[1]) {
+				continue
+			}
+			desc.Dependencies = append(desc.Dependencies, types.Dependency{
+				Type:       DependencyTypeR,
+				Name:       m[1],
+				Version:    m[2],
+				Scope:      f.scope,
+				Direct:     true,
+				SourceFile: "DESCRIPTION",
+			})
+		}
+	}
+	return desc
+}
+
+// parseDCF parses Debian control format fields, joining continuation lines.
+func parseDCF(content string) map[string]string {
+	fields := make(map[string]string)
+	var current string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && current != "" {
+			fields[current] += " " + strings.TrimSpace(line)
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		current = strings.TrimSpace(key)
+		fields[current] = strings.TrimSpace(value)
+	}
+	return fields
+}
@@ -0,0 +1,65 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+const descriptionFixture = `Package: mypkg
+Title: What the Package Does
+Version: 0.3.1
+Authors@R: person("Jane", "Doe", email = "jane@example.com")
+Depends:
+    R (>= 4.1.0),
+    methods
+Imports:
+    dplyr (>= 1.1.0),
+    httr,
+    stats
+LinkingTo: Rcpp
+Suggests: testthat (>= 3.0.0),
+    knitr
+License: MIT + file LICENSE
+`
+
+func TestParseDescription(t *testing.T) {
+	desc := NewDescriptionParser().ParseDescription(descriptionFixture)
+	if desc.Package != "mypkg" || desc.Version != "0.3.1" {
+		t.Errorf("package = %q %q, want mypkg 0.3.1", desc.Package, desc.Version)
+	}
+	if desc.RVersion != ">= 4.1.0" {
+		t.Errorf("RVersion = %q, want >= 4.1.0", desc.RVersion)
+	}
+
+	want := map[string]struct{ version, scope string }{
+		"dplyr":    {">= 1.1.0", types.ScopeProd},
+		"httr":     {"", types.ScopeProd},
+		"Rcpp":     {"", types.ScopeBuild},
+		"testthat": {">= 3.0.0", types.ScopeOptional},
+		"knitr":    {"", types.ScopeOptional},
+	}
+	if len(desc.Dependencies) != len(want) {
+		t.Fatalf("got %d dependencies, want %d: %+v", len(desc.Dependencies), len(want), desc.Dependencies)
+	}
+	for _, dep := range desc.Dependencies {
+		w, ok := want[dep.Name]
+		if !ok {
+			t.Errorf("unexpected dependency %q", dep.Name)
+			continue
+		}
+		if dep.Version != w.version || dep.Scope != w.scope {
+			t.Errorf("%s = (%q, %q), want (%q, %q)", dep.Name, dep.Version, dep.Scope, w.version, w.scope)
+		}
+		if dep.Type != DependencyTypeR || !dep.Direct || dep.SourceFile != "DESCRIPTION" {
+			t.Errorf("%s: unexpected type/direct/source %+v", dep.Name, dep)
+		}
+	}
+}
+
+func TestParseDescription_Empty(t *testing.T) {
+	desc := NewDescriptionParser().ParseDescription("")
+	if desc.Package != "" || len(desc.Dependencies) != 0 {
+		t.Errorf("expected empty description, got %+v", desc)
+	}
+}
@@ -0,0 +1,244 @@
+package parsers
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// RebarConfig holds the parts of a rebar3 rebar.config the scanner reports.
+type RebarConfig struct {
+	MinimumOTPVersion string
+	Release           *RelxRelease
+	Dependencies      []types.Dependency
+}
+
+// RelxRelease is the OTP release configured in the relx section.
+type RelxRelease struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+	IncludeErts  *bool    `json:"include_erts,omitempty"` // nil when not set (defaults vary by rebar3 version)
+	DevMode      *bool    `json:"dev_mode,omitempty"`
+	SysConfig    string   `json:"sys_config,omitempty"`
+	VMArgs       string   `json:"vm_args,omitempty"`
+}
+
+// RebarParser parses rebar3 rebar.config and rebar.lock files.
+type RebarParser struct{}
+
+// NewRebarParser creates a new rebar3 parser.
+func NewRebarParser() *RebarParser {
+	return &RebarParser{}
+}
+
+// ParseRebarConfig parses rebar.config. Top-level deps are prod
+// dependencies; deps of the test profile are test dependencies and those of
+// other profiles (except prod) dev dependencies. Declared versions are kept
+// as written; git dependencies carry their url (without credentials) in
+// metadata and the tag as version when pinned to one.
+func (p *RebarParser) ParseRebarConfig(content string) *RebarConfig {
+	terms, _ := parseErlangTerms(content)
+	config := &RebarConfig{}
+	if v, ok := erlProplist(terms, "minimum_otp_vsn"); ok {
+		config.MinimumOTPVersion = erlText(v)
+	}
+	if relx, ok := erlProplist(terms, "relx"); ok {
+		config.Release = parseRelx(relx)
+	}
+	if deps, ok := erlProplist(terms, "deps"); ok {
+		config.Dependencies = appendRebarDeps(config.Dependencies, deps, types.ScopeProd)
+	}
+	profiles, _ := erlProplist(terms, "profiles")
+	for _, item := range asErlList(profiles) {
+		profile, ok := item.(erlTuple)
+		if !ok || len(profile) < 2 {
+			continue
+		}
+		if deps, ok := erlProplist(profile[1], "deps"); ok {
+			config.Dependencies = appendRebarDeps(config.Dependencies, deps, rebarProfileScope(erlText(profile[0])))
+		}
+	}
+	return config
+}
+
+func rebarProfileScope(profile string) string {
+	switch profile {
+	case "test":
+		return types.ScopeTest
+	case "prod":
+		return types.ScopeProd
+	}
+	return types.ScopeDev
+}
+
+// appendRebarDeps appends the entries of a deps list: name, {name, Vsn},
+// {name, Vsn, {pkg, PkgName}}, {name, {git, Url, Ref}} or {name, {pkg, PkgName}}.
+func appendRebarDeps(deps []types.Dependency, list interface{}, scope string) []types.Dependency {
+	for _, item := range asErlList(list) {
+		dep := types.Dependency{
+			Type:     DependencyTypeElixir,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: types.NewMetadata("rebar.config"),
+		}
+		switch v := item.(type) {
+		case erlAtom:
+			dep.Name = string(v)
+		case erlTuple:
+			if len(v) == 0 {
+				continue
+			}
+			dep.Name = erlText(v[0])
+			for _, spec := range v[1:] {
+				applyRebarDepSpec(&dep, spec)
+			}
+		}
+		if dep.Name != "" {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// applyRebarDepSpec applies one element of a dependency tuple: a version
+// string, a {pkg, Name} alias or a {git, Url, Ref} source.
+func applyRebarDepSpec(dep *types.Dependency, spec interface{}) {
+	if vsn, ok := spec.(string); ok {
+		dep.Version = vsn
+		return
+	}
+	source, ok := spec.(erlTuple)
+	if !ok || len(source) < 2 {
+		return
+	}
+	switch source[0] {
+	case erlAtom("pkg"):
+		dep.Metadata["package"] = erlText(source[1])
+	case erlAtom("git"), erlAtom("git_subdir"), erlAtom("hg"):
+		if clean, _ := SanitizeRegistryURL(erlText(source[1])); clean != "" {
+			dep.Metadata["git"] = clean
+		}
+		if tag := rebarGitTag(source); tag != "" {
+			dep.Version = tag
+		}
+	}
+}
+
+// rebarGitTag returns the tag of a {git, Url, {tag, Tag}} source.
+func rebarGitTag(source erlTuple) string {
+	if len(source) < 3 {
+		return ""
+	}
+	if ref, ok := source[2].(erlTuple); ok && len(ref) == 2 && ref[0] == erlAtom("tag") {
+		return erlText(ref[1])
+	}
+	return ""
+}
+
+// parseRelx extracts the release from a relx section:
+// {release, {Name, Vsn}, [Apps]} plus the include_erts, dev_mode,
+// sys_config and vm_args options.
+func parseRelx(relx interface{}) *RelxRelease {
+	release := findRelxRelease(relx)
+	if release == nil {
+		return nil
+	}
+	if v, ok := erlProplist(relx, "include_erts"); ok {
+		includeErts := v != erlAtom("false") // true or a path to an ERTS
+		release.IncludeErts = &includeErts
+	}
+	if v, ok := erlProplist(relx, "dev_mode"); ok {
+		devMode := v == erlAtom("true")
+		release.DevMode = &devMode
+	}
+	if v, ok := erlProplist(relx, "sys_config"); ok {
+		release.SysConfig = erlText(v)
+	}
+	if v, ok := erlProplist(relx, "vm_args"); ok {
+		release.VMArgs = erlText(v)
+	}
+	return release
+}
+
+// findRelxRelease returns the first {release, {Name, Vsn}, Apps} entry.
+// Apps are atoms or {App, StartType} tuples.
+func findRelxRelease(relx interface{}) *RelxRelease {
+	for _, item := range asErlList(relx) {
+		t, ok := item.(erlTuple)
+		if !ok || len(t) < 3 || t[0] != erlAtom("release") {
+			continue
+		}
+		nameVsn, _ := t[1].(erlTuple)
+		if len(nameVsn) < 2 {
+			continue
+		}
+		release := &RelxRelease{Name: erlText(nameVsn[0]), Version: erlText(nameVsn[1])}
+		for _, app := range asErlList(t[2]) {
+			if appTuple, ok := app.(erlTuple); ok && len(appTuple) > 0 {
+				app = appTuple[0]
+			}
+			if name := erlText(app); name != "" {
+				release.Applications = append(release.Applications, name)
+			}
+		}
+		return release
+	}
+	return nil
+}
+
+// ParseRebarLock parses rebar.lock in both formats: {"1.2.0", [Entries]}
+// and the older bare [Entries]. Each entry is {<<"name">>, Source, Level};
+// level 0 entries are the direct dependencies. Git entries have no version.
+func (p *RebarParser) ParseRebarLock(content string) []types.Dependency {
+	terms, _ := parseErlangTerms(content)
+	if len(terms) == 0 {
+		return nil
+	}
+	entries := terms[0]
+	if versioned, ok := terms[0].(erlTuple); ok && len(versioned) == 2 {
+		entries = versioned[1]
+	}
+
+	var deps []types.Dependency
+	for _, item := range asErlList(entries) {
+		entry, ok := item.(erlTuple)
+		if !ok || len(entry) < 3 {
+			continue
+		}
+		source, _ := entry[1].(erlTuple)
+		if len(source) < 3 || source[0] != erlAtom("pkg") {
+			continue
+		}
+		level, _ := strconv.Atoi(erlText(entry[2]))
+		deps = append(deps, types.Dependency{
+			Type:       DependencyTypeElixir,
+			Name:       erlText(entry[0]),
+			Version:    erlText(source[2]),
+			Scope:      types.ScopeProd,
+			Direct:     level == 0,
+			SourceFile: "rebar.lock",
+		})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps
+}
+
+func asErlList(term interface{}) []interface{} {
+	list, _ := term.([]interface{})
+	return list
+}
+
+// ParseAppSrcName returns the application name of an OTP .app.src file:
+// {application, Name, [...]}.
+func (p *RebarParser) ParseAppSrcName(content string) string {
+	terms, _ := parseErlangTerms(content)
+	if len(terms) == 0 {
+		return ""
+	}
+	if t, ok := terms[0].(erlTuple); ok && len(t) >= 2 && t[0] == erlAtom("application") {
+		return erlText(t[1])
+	}
+	return ""
+}
@@ -0,0 +1,418 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/xml"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// Pre-compiled regexes for registry configuration parsing
+var (
+	npmrcScopeRegistryRegex = regexp.MustCompile(`^(@[^:\s]+):registry\s*=\s*(.+)$`)
+	npmrcRegistryRegex      = regexp.MustCompile(`^registry\s*=\s*(.+)$`)
+	pipOptionRegex          = regexp.MustCompile(`^(--index-url|-i|--extra-index-url)(?:\s*=\s*|\s+)(\S+)`)
+	gemSourceRegex          = regexp.MustCompile(`^source\s+['"]([^'"]+)['"]\s*(do)?`)
+	gemBlockStartRegex      = regexp.MustCompile(`\bdo\s*(\|[^|]*\|)?\s*$`)
+	gemNameRegex            = regexp.MustCompile(`^gem\s+['"]([^'"]+)['"]`)
+	gemInlineSourceRegex    = regexp.MustCompile(`source:\s*['"]([^'"]+)['"]`)
+	goEnvRegex              = regexp.MustCompile(`^(?:export\s+|go\s+env\s+-w\s+)?(GOPRIVATE|GONOPROXY|GOPROXY)\s*=\s*['"]?([^'"\s]*)['"]?`)
+)
+
+// publicRegistryHosts lists the hosts of the default public registries per ecosystem
+var publicRegistryHosts = map[string]bool{
+	"registry.npmjs.org":     true,
+	"registry.yarnpkg.com":   true,
+	"pypi.org":               true,
+	"pypi.python.org":        true,
+	"files.pythonhosted.org": true,
+	"rubygems.org":           true,
+	"api.nuget.org":          true,
+	"www.nuget.org":          true,
+	"proxy.golang.org":       true,
+}
+
+// Registry describes a package registry declared in a configuration file.
+// URLs are sanitized: credentials, query strings and fragments are never kept.
+type Registry struct {
+	Ecosystem    string   `json:"ecosystem"`
+	Host         string   `json:"host"`
+	URL          string   `json:"url,omitempty"`
+	Scope        string   `json:"scope,omitempty"`
+	Patterns     []string `json:"patterns,omitempty"`
+	Default      bool     `json:"default,omitempty"`
+	Private      bool     `json:"private"`
+	File         string   `json:"file,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// Matches reports whether a package is explicitly routed to this registry by
+// scope or package pattern. Default registries are not considered here.
+func (r *Registry) Matches(packageName string) bool {
+	switch r.Ecosystem {
+	case DependencyTypeNpm:
+		return r.Scope != "" && strings.HasPrefix(packageName, r.Scope+"/")
+	case DependencyTypeGolang:
+		return len(r.Patterns) > 0 && module.MatchPrefixPatterns(strings.Join(r.Patterns, ","), packageName)
+	case DependencyTypeNuget:
+		return matchNugetPatterns(r.Patterns, packageName)
+	default:
+		for _, pattern := range r.Patterns {
+			if pattern == packageName {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchNugetPatterns implements NuGet package source mapping: exact IDs or
+// prefix patterns ending in "*", compared case-insensitively.
+func matchNugetPatterns(patterns []string, packageName string) bool {
+	name := strings.ToLower(packageName)
+	for _, pattern := range patterns {
+		p := strings.ToLower(pattern)
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RegistryParser parses package registry configuration across ecosystems
+type RegistryParser struct{}
+
+// NewRegistryParser creates a new registry parser
+func NewRegistryParser() *RegistryParser {
+	return &RegistryParser{}
+}
+
+// newRegistry builds a registry entry from a raw URL, returning nil when the
+// URL has no usable host (e.g. unresolved environment placeholders).
+func newRegistry(ecosystem, rawURL string) *Registry {
+	clean, host := SanitizeRegistryURL(rawURL)
+	if host == "" {
+		return nil
+	}
+	return &Registry{
+		Ecosystem: ecosystem,
+		Host:      host,
+		URL:       clean,
+		Private:   !publicRegistryHosts[strings.ToLower(host)],
+	}
+}
+
+// SanitizeRegistryURL strips credentials, query and fragment from a registry
+// URL and returns the cleaned URL together with its host.
+func SanitizeRegistryURL(rawURL string) (string, string) {
+	rawURL = strings.Trim(strings.TrimSpace(rawURL), `"'`)
+	if rawURL == "" {
+		return "", ""
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String(), u.Host
+}
+
+// ParseNpmrc extracts the default and scoped registries from an .npmrc file.
+// Authentication lines (//host/:_authToken=...) are ignored.
+func (p *RegistryParser) ParseNpmrc(content string) []Registry {
+	var registries []Registry
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if m := npmrcScopeRegistryRegex.FindStringSubmatch(line); m != nil {
+			if r := newRegistry(DependencyTypeNpm, m[2]); r != nil {
+				r.Scope = m[1]
+				registries = append(registries, *r)
+			}
+		} else if m := npmrcRegistryRegex.FindStringSubmatch(line); m != nil {
+			if r := newRegistry(DependencyTypeNpm, m[1]); r != nil {
+				r.Default = true
+				registries = append(registries, *r)
+			}
+		}
+	}
+	return registries
+}
+
+// ParsePipConf extracts index-url and extra-index-url from pip.conf / pip.ini.
+// Continuation lines of multi-value options are supported.
+func (p *RegistryParser) ParsePipConf(content string) []Registry {
+	var registries []Registry
+	currentKey := ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if isINIComment(line) {
+			continue
+		}
+		if currentKey != "" && isINIContinuation(raw) {
+			registries = appendPipRegistry(registries, currentKey, line)
+			continue
+		}
+		currentKey = ""
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "index-url" || key == "extra-index-url" {
+			currentKey = key
+			registries = appendPipRegistry(registries, key, value)
+		}
+	}
+	return registries
+}
+
+// isINIComment reports whether a trimmed INI line is blank or a comment.
+func isINIComment(line string) bool {
+	return line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";")
+}
+
+// isINIContinuation reports whether a raw INI line continues the previous value.
+func isINIContinuation(raw string) bool {
+	return strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")
+}
+
+// ParseRequirementsIndex extracts --index-url / --extra-index-url options
+// from a requirements.txt file.
+func (p *RegistryParser) ParseRequirementsIndex(content string) []Registry {
+	var registries []Registry
+	for _, line := range strings.Split(content, "\n") {
+		m := pipOptionRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		key := "extra-index-url"
+		if m[1] != "--extra-index-url" {
+			key = "index-url"
+		}
+		registries = appendPipRegistry(registries, key, m[2])
+	}
+	return registries
+}
+
+func appendPipRegistry(registries []Registry, key, value string) []Registry {
+	for _, field := range strings.Fields(value) {
+		if r := newRegistry(DependencyTypePython, field); r != nil {
+			r.Default = key == "index-url"
+			registries = append(registries, *r)
+		}
+	}
+	return registries
+}
+
+// ParseGemfileSources extracts gem sources from a Gemfile. Top-level sources
+// are defaults; `source "..." do ... end` blocks and `source:` options route
+// the named gems to that source.
+func (p *RegistryParser) ParseGemfileSources(content string) []Registry {
+	state := &gemSourceState{index: make(map[string]int)}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		state.processLine(line)
+	}
+	return state.registries
+}
+
+// gemSourceState tracks sources and open do-blocks while reading a Gemfile
+type gemSourceState struct {
+	registries []Registry
+	index      map[string]int // sanitized URL -> registries index
+	blockStack []string       // source URL per open do-block ("" for non-source blocks)
+}
+
+func (g *gemSourceState) processLine(line string) {
+	if line == "end" {
+		if len(g.blockStack) > 0 {
+			g.blockStack = g.blockStack[:len(g.blockStack)-1]
+		}
+		return
+	}
+	if m := gemSourceRegex.FindStringSubmatch(line); m != nil {
+		i := g.add(m[1])
+		if m[2] == "do" {
+			g.blockStack = append(g.blockStack, m[1])
+		} else if i >= 0 {
+			g.registries[i].Default = true
+		}
+		return
+	}
+	if m := gemNameRegex.FindStringSubmatch(line); m != nil {
+		g.addGem(m[1], line)
+	}
+	if gemBlockStartRegex.MatchString(line) {
+		g.blockStack = append(g.blockStack, "")
+	}
+}
+
+// addGem routes a gem to its inline source or the innermost enclosing source block
+func (g *gemSourceState) addGem(name, line string) {
+	source := g.currentSource()
+	if inline := gemInlineSourceRegex.FindStringSubmatch(line); inline != nil {
+		source = inline[1]
+	}
+	if source == "" {
+		return
+	}
+	if i := g.add(source); i >= 0 {
+		g.registries[i].Patterns = append(g.registries[i].Patterns, name)
+	}
+}
+
+// add registers a source once and returns its index, or -1 for unusable URLs
+func (g *gemSourceState) add(rawURL string) int {
+	r := newRegistry(DependencyTypeRuby, rawURL)
+	if r == nil {
+		return -1
+	}
+	if i, ok := g.index[r.URL]; ok {
+		return i
+	}
+	g.registries = append(g.registries, *r)
+	g.index[r.URL] = len(g.registries) - 1
+	return len(g.registries) - 1
+}
+
+// currentSource returns the innermost enclosing source block URL
+func (g *gemSourceState) currentSource() string {
+	for i := len(g.blockStack) - 1; i >= 0; i-- {
+		if g.blockStack[i] != "" {
+			return g.blockStack[i]
+		}
+	}
+	return ""
+}
+
+// nugetConfig mirrors the parts of NuGet.config relevant to package sources
+type nugetConfig struct {
+	PackageSources struct {
+		Entries []struct {
+			XMLName xml.Name
+			Key     string `xml:"key,attr"`
+			Value   string `xml:"value,attr"`
+		} `xml:",any"`
+	} `xml:"packageSources"`
+	PackageSourceMapping struct {
+		Sources []struct {
+			Key      string `xml:"key,attr"`
+			Packages []struct {
+				Pattern string `xml:"pattern,attr"`
+			} `xml:"package"`
+		} `xml:"packageSource"`
+	} `xml:"packageSourceMapping"`
+}
+
+// ParseNugetConfig extracts package sources and package source mapping
+// patterns from a NuGet.config file. Sources without mapping patterns are
+// treated as defaults.
+func (p *RegistryParser) ParseNugetConfig(content string) []Registry {
+	var cfg nugetConfig
+	if err := xml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil
+	}
+
+	patterns := make(map[string][]string)
+	for _, source := range cfg.PackageSourceMapping.Sources {
+		for _, pkg := range source.Packages {
+			patterns[source.Key] = append(patterns[source.Key], pkg.Pattern)
+		}
+	}
+
+	var registries []Registry
+	for _, entry := range cfg.PackageSources.Entries {
+		switch entry.XMLName.Local {
+		case "clear":
+			registries = nil
+		case "add":
+			if r := newRegistry(DependencyTypeNuget, entry.Value); r != nil {
+				r.Patterns = patterns[entry.Key]
+				r.Default = len(r.Patterns) == 0
+				registries = append(registries, *r)
+			}
+		}
+	}
+	return registries
+}
+
+// ParseGoEnv extracts GOPRIVATE / GONOPROXY patterns and a non-public GOPROXY
+// from environment files (.envrc, .env, go.env). Private module patterns are
+// grouped by host.
+func (p *RegistryParser) ParseGoEnv(content string) []Registry {
+	var registries []Registry
+	byHost := make(map[string]int)
+	for _, line := range strings.Split(content, "\n") {
+		m := goEnvRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || m[2] == "" {
+			continue
+		}
+		if m[1] == "GOPROXY" {
+			registries = append(registries, goProxyRegistries(m[2])...)
+			continue
+		}
+		for _, pattern := range strings.Split(m[2], ",") {
+			registries = addGoPrivatePattern(registries, byHost, strings.TrimSpace(pattern))
+		}
+	}
+	return registries
+}
+
+// goProxyRegistries returns the proxies of a GOPROXY list, skipping the
+// "direct" and "off" keywords.
+func goProxyRegistries(value string) []Registry {
+	var registries []Registry
+	for _, entry := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' }) {
+		if entry == "direct" || entry == "off" {
+			continue
+		}
+		if r := newRegistry(DependencyTypeGolang, entry); r != nil {
+			r.Default = true
+			registries = append(registries, *r)
+		}
+	}
+	return registries
+}
+
+func addGoPrivatePattern(registries []Registry, byHost map[string]int, pattern string) []Registry {
+	if pattern == "" {
+		return registries
+	}
+	host, _, _ := strings.Cut(pattern, "/")
+	if i, ok := byHost[host]; ok {
+		for _, existing := range registries[i].Patterns {
+			if existing == pattern {
+				return registries
+			}
+		}
+		registries[i].Patterns = append(registries[i].Patterns, pattern)
+		return registries
+	}
+	byHost[host] = len(registries)
+	return append(registries, Registry{
+		Ecosystem: DependencyTypeGolang,
+		Host:      host,
+		Patterns:  []string{pattern},
+		Private:   true,
+	})
+}
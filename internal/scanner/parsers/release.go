@@ -0,0 +1,385 @@
+package parsers
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Release tools.
+const (
+	ReleaseToolGoreleaser      = "goreleaser"
+	ReleaseToolSemanticRelease = "semantic-release"
+	ReleaseToolChangesets      = "changesets"
+	ReleaseToolMavenRelease    = "maven-release"
+)
+
+// Versioning schemes of the release tools: the version comes from the git
+// tag, from the conventional commits since the last release, from the
+// changeset files of the pull requests, or from the project version the
+// release prepares.
+const (
+	VersioningGitTag              = "git-tag"
+	VersioningConventionalCommits = "conventional-commits"
+	VersioningChangesets          = "changesets"
+	VersioningPOM                 = "pom"
+)
+
+var (
+	semanticReleasePluginRegex = regexp.MustCompile(`["'\x60]((?:@[\w.-]+/)?semantic-release[\w.-]*|@semantic-release[\w-]*/[\w.-]+)["'\x60]`)
+	mavenReleasePluginRegex    = regexp.MustCompile(`<artifactId>\s*maven-release-plugin\s*</artifactId>`)
+	mavenTagNameFormatRegex    = regexp.MustCompile(`<tagNameFormat>\s*([^<]+?)\s*</tagNameFormat>`)
+	mavenDistributionRegex     = regexp.MustCompile(`(?s)<distributionManagement>.*?<repository>`)
+	mavenCentralPluginRegex    = regexp.MustCompile(`<artifactId>\s*(?:nexus-staging-maven-plugin|central-publishing-maven-plugin)\s*</artifactId>`)
+)
+
+// ReleaseConfig is how a release tool configuration versions and publishes
+// the artifacts of a project.
+type ReleaseConfig struct {
+	Tool       string   `json:"tool"`
+	File       string   `json:"file"`
+	Versioning string   `json:"versioning"`
+	TagFormat  string   `json:"tag_format,omitempty"`
+	Branches   []string `json:"branches,omitempty"`
+	Builds     []string `json:"builds,omitempty"`
+	Archives   []string `json:"archives,omitempty"`
+	Packages   []string `json:"packages,omitempty"`
+	Images     []string `json:"images,omitempty"`
+	Plugins    []string `json:"plugins,omitempty"`
+	Publishes  []string `json:"publishes,omitempty"`
+	Pending    int      `json:"pending_changesets,omitempty"`
+}
+
+// ReleaseParser reads release tool configurations: GoReleaser,
+// semantic-release, Changesets and the Maven Release Plugin.
+type ReleaseParser struct{}
+
+// NewReleaseParser creates a new release configuration parser.
+func NewReleaseParser() *ReleaseParser {
+	return &ReleaseParser{}
+}
+
+// goreleaserPublishers maps the sections of a .goreleaser.yml to the
+// channel they publish to.
+var goreleaserPublishers = map[string]string{
+	"dockers":          "docker",
+	"docker_manifests": "docker",
+	"kos":              "docker",
+	"brews":            "homebrew",
+	"homebrew_casks":   "homebrew",
+	"scoops":           "scoop",
+	"winget":           "winget",
+	"chocolateys":      "chocolatey",
+	"nix":              "nix",
+	"aurs":             "aur",
+	"snapcrafts":       "snapcraft",
+	"blobs":            "blob-storage",
+	"artifactories":    "artifactory",
+	"uploads":          "http-upload",
+	"publishers":       "custom",
+}
+
+// goreleaserConfig is the part of a .goreleaser.yml describing what is
+// built and where it goes.
+type goreleaserConfig struct {
+	Builds []struct {
+		ID     string `yaml:"id"`
+		Binary string `yaml:"binary"`
+	} `yaml:"builds"`
+	Archives []struct {
+		Format  string   `yaml:"format"`
+		Formats []string `yaml:"formats"`
+	} `yaml:"archives"`
+	Nfpms []struct {
+		Formats []string `yaml:"formats"`
+	} `yaml:"nfpms"`
+	Dockers []struct {
+		ImageTemplates []string `yaml:"image_templates"`
+	} `yaml:"dockers"`
+	Release struct {
+		Disable interface{} `yaml:"disable"`
+		GitLab  interface{} `yaml:"gitlab"`
+		Gitea   interface{} `yaml:"gitea"`
+	} `yaml:"release"`
+	GitLabURLs interface{} `yaml:"gitlab_urls"`
+	GiteaURLs  interface{} `yaml:"gitea_urls"`
+}
+
+// ParseGoreleaserRelease reads the builds, archive and package formats,
+// Docker images and publishing targets of a .goreleaser.yml. Archives
+// default to tar.gz, and releases go to GitHub unless the configuration
+// targets GitLab or Gitea or disables them.
+func (p *ReleaseParser) ParseGoreleaserRelease(content []byte) (ReleaseConfig, bool) {
+	var config goreleaserConfig
+	var sections map[string]interface{}
+	if yaml.Unmarshal(content, &config) != nil || yaml.Unmarshal(content, &sections) != nil {
+		return ReleaseConfig{}, false
+	}
+	release := ReleaseConfig{
+		Tool:       ReleaseToolGoreleaser,
+		Versioning: VersioningGitTag,
+		Builds:     config.builds(),
+		Archives:   config.archiveFormats(),
+		Packages:   config.packageFormats(),
+		Images:     config.images(),
+	}
+	if channel := goreleaserReleaseChannel(config); channel != "" {
+		release.Publishes = append(release.Publishes, channel)
+	}
+	for section, channel := range goreleaserPublishers {
+		if sections[section] != nil {
+			release.Publishes = appendNew(release.Publishes, channel)
+		}
+	}
+	sort.Strings(release.Publishes)
+	return release, true
+}
+
+func (c goreleaserConfig) builds() []string {
+	var builds []string
+	for _, build := range c.Builds {
+		builds = appendNew(builds, firstNonEmpty(build.Binary, build.ID))
+	}
+	return builds
+}
+
+func (c goreleaserConfig) archiveFormats() []string {
+	var formats []string
+	for _, archive := range c.Archives {
+		formats = appendNew(formats, archive.Format)
+		for _, format := range archive.Formats {
+			formats = appendNew(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return []string{"tar.gz"}
+	}
+	return formats
+}
+
+func (c goreleaserConfig) packageFormats() []string {
+	var formats []string
+	for _, nfpm := range c.Nfpms {
+		for _, format := range nfpm.Formats {
+			formats = appendNew(formats, format)
+		}
+	}
+	return formats
+}
+
+// images returns the repositories of the Docker image templates.
+func (c goreleaserConfig) images() []string {
+	var images []string
+	for _, docker := range c.Dockers {
+		for _, template := range docker.ImageTemplates {
+			images = appendNew(images, imageRepository(template))
+		}
+	}
+	return images
+}
+
+func goreleaserReleaseChannel(config goreleaserConfig) string {
+	if disable, ok := config.Release.Disable.(bool); ok && disable {
+		return ""
+	}
+	switch {
+	case config.Release.GitLab != nil || config.GitLabURLs != nil:
+		return "gitlab-releases"
+	case config.Release.Gitea != nil || config.GiteaURLs != nil:
+		return "gitea-releases"
+	}
+	return "github-releases"
+}
+
+// imageRepository returns an image reference without its tag, which is
+// usually a template: ghcr.io/myorg/myapp:{{ .Version }} is
+// ghcr.io/myorg/myapp.
+func imageRepository(reference string) string {
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		return reference[:i]
+	}
+	return reference
+}
+
+// semanticReleaseDefaultPlugins are the plugins semantic-release runs when
+// a configuration sets none.
+var semanticReleaseDefaultPlugins = []string{
+	"@semantic-release/commit-analyzer",
+	"@semantic-release/release-notes-generator",
+	"@semantic-release/npm",
+	"@semantic-release/github",
+}
+
+// semanticReleasePublishers maps semantic-release plugins to the channel
+// they publish to.
+var semanticReleasePublishers = map[string]string{
+	"@semantic-release/npm":                  "npm",
+	"@semantic-release/github":               "github-releases",
+	"@semantic-release/gitlab":               "gitlab-releases",
+	"@semantic-release/apm":                  "atom",
+	"semantic-release-docker":                "docker",
+	"@semantic-release-plus/docker":          "docker",
+	"@codedependant/semantic-release-docker": "docker",
+	"semantic-release-pypi":                  "pypi",
+	"semantic-release-cargo":                 "crates.io",
+	"semantic-release-helm":                  "helm",
+	"semantic-release-helm3":                 "helm",
+	"semantic-release-vsce":                  "vscode-marketplace",
+}
+
+// semanticReleaseConfig is a semantic-release configuration, or the
+// "release" key of a package.json.
+type semanticReleaseConfig struct {
+	Branches  interface{}   `json:"branches" yaml:"branches"`
+	Branch    string        `json:"branch" yaml:"branch"`
+	TagFormat string        `json:"tagFormat" yaml:"tagFormat"`
+	Plugins   []interface{} `json:"plugins" yaml:"plugins"`
+}
+
+// ParseSemanticRelease reads the release branches, tag format, plugins and
+// publishing targets of a semantic-release configuration in JSON or YAML
+// (.releaserc, .releaserc.json, .releaserc.yml). Without plugins, the
+// default plugins apply.
+func (p *ReleaseParser) ParseSemanticRelease(content []byte) (ReleaseConfig, bool) {
+	var config semanticReleaseConfig
+	unmarshal := yaml.Unmarshal
+	if strings.HasPrefix(strings.TrimSpace(string(content)), "{") {
+		unmarshal = json.Unmarshal
+	}
+	if unmarshal(content, &config) != nil {
+		return ReleaseConfig{}, false
+	}
+	return semanticRelease(config), true
+}
+
+// ParsePackageJSONRelease reads the semantic-release configuration of the
+// "release" key of a package.json.
+func (p *ReleaseParser) ParsePackageJSONRelease(content []byte) (ReleaseConfig, bool) {
+	var manifest struct {
+		Release *semanticReleaseConfig `json:"release"`
+	}
+	if json.Unmarshal(content, &manifest) != nil || manifest.Release == nil {
+		return ReleaseConfig{}, false
+	}
+	return semanticRelease(*manifest.Release), true
+}
+
+// ParseSemanticReleaseScript reads the plugins of a JavaScript
+// semantic-release configuration (release.config.js, .releaserc.cjs) from
+// the package names it mentions; branches are not evaluated.
+func (p *ReleaseParser) ParseSemanticReleaseScript(content string) ReleaseConfig {
+	config := semanticReleaseConfig{}
+	for _, m := range semanticReleasePluginRegex.FindAllStringSubmatch(content, -1) {
+		if m[1] != "semantic-release" {
+			config.Plugins = append(config.Plugins, m[1])
+		}
+	}
+	return semanticRelease(config)
+}
+
+func semanticRelease(config semanticReleaseConfig) ReleaseConfig {
+	release := ReleaseConfig{
+		Tool:       ReleaseToolSemanticRelease,
+		Versioning: VersioningConventionalCommits,
+		TagFormat:  config.TagFormat,
+		Branches:   semanticReleaseBranches(config.Branches),
+	}
+	if len(release.Branches) == 0 && config.Branch != "" {
+		release.Branches = []string{config.Branch}
+	}
+	for _, plugin := range config.Plugins {
+		release.Plugins = appendNew(release.Plugins, pluginName(plugin))
+	}
+	if len(release.Plugins) == 0 {
+		release.Plugins = semanticReleaseDefaultPlugins
+	}
+	for _, plugin := range release.Plugins {
+		release.Publishes = appendNew(release.Publishes, semanticReleasePublishers[plugin])
+	}
+	sort.Strings(release.Publishes)
+	return release
+}
+
+// semanticReleaseBranches reads branches written as a name, a list of
+// names or a list of objects with a name.
+func semanticReleaseBranches(value interface{}) []string {
+	var branches []string
+	switch v := value.(type) {
+	case string:
+		branches = append(branches, v)
+	case []interface{}:
+		for _, item := range v {
+			branches = appendNew(branches, pluginName(item))
+		}
+	}
+	return branches
+}
+
+// pluginName returns the name of a plugin or branch entry: a string, a
+// [name, options] pair or an object with a name.
+func pluginName(entry interface{}) string {
+	switch v := entry.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			name, _ := v[0].(string)
+			return name
+		}
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// ParseChangesetsConfig reads the base branch and package access of a
+// .changeset/config.json. Changesets publishes the packages to npm.
+func (p *ReleaseParser) ParseChangesetsConfig(content []byte) (ReleaseConfig, bool) {
+	var config struct {
+		BaseBranch string `json:"baseBranch"`
+	}
+	if json.Unmarshal(content, &config) != nil {
+		return ReleaseConfig{}, false
+	}
+	release := ReleaseConfig{Tool: ReleaseToolChangesets, Versioning: VersioningChangesets, Publishes: []string{"npm"}}
+	if config.BaseBranch != "" {
+		release.Branches = []string{config.BaseBranch}
+	}
+	return release, true
+}
+
+// ParseMavenRelease reads the Maven Release Plugin setup of a pom.xml: its
+// tag name format and where the distribution management deploys to.
+func (p *ReleaseParser) ParseMavenRelease(content string) (ReleaseConfig, bool) {
+	if !mavenReleasePluginRegex.MatchString(content) {
+		return ReleaseConfig{}, false
+	}
+	release := ReleaseConfig{Tool: ReleaseToolMavenRelease, Versioning: VersioningPOM}
+	if m := mavenTagNameFormatRegex.FindStringSubmatch(content); m != nil {
+		release.TagFormat = m[1]
+	}
+	if mavenCentralPluginRegex.MatchString(content) {
+		release.Publishes = append(release.Publishes, "maven-central")
+	}
+	if mavenDistributionRegex.MatchString(content) {
+		release.Publishes = append(release.Publishes, "maven-repository")
+	}
+	return release, true
+}
+
+// appendNew appends a non-empty value not in values yet.
+func appendNew(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoreleaserRelease(t *testing.T) {
+	release, ok := NewReleaseParser().ParseGoreleaserRelease([]byte(`version: 2
+builds:
+  - id: myapp
+    binary: myapp
+  - id: worker
+archives:
+  - formats: [tar.gz, zip]
+nfpms:
+  - formats: [deb, rpm]
+dockers:
+  - image_templates:
+      - "ghcr.io/myorg/myapp:{{ .Version }}"
+      - "ghcr.io/myorg/myapp:latest"
+brews:
+  - name: myapp
+`))
+	require.True(t, ok)
+	assert.Equal(t, ReleaseConfig{
+		Tool:       ReleaseToolGoreleaser,
+		Versioning: VersioningGitTag,
+		Builds:     []string{"myapp", "worker"},
+		Archives:   []string{"tar.gz", "zip"},
+		Packages:   []string{"deb", "rpm"},
+		Images:     []string{"ghcr.io/myorg/myapp"},
+		Publishes:  []string{"docker", "github-releases", "homebrew"},
+	}, release)
+}
+
+func TestParseGoreleaserReleaseDefaults(t *testing.T) {
+	parser := NewReleaseParser()
+	release, ok := parser.ParseGoreleaserRelease([]byte("project_name: myapp\ngitlab_urls:\n  api: https://gitlab.example.com/api/v4/\n"))
+	require.True(t, ok)
+	assert.Equal(t, []string{"tar.gz"}, release.Archives)
+	assert.Equal(t, []string{"gitlab-releases"}, release.Publishes)
+
+	disabled, ok := parser.ParseGoreleaserRelease([]byte("release:\n  disable: true\n"))
+	require.True(t, ok)
+	assert.Empty(t, disabled.Publishes)
+}
+
+func TestParseSemanticRelease(t *testing.T) {
+	parser := NewReleaseParser()
+	release, ok := parser.ParseSemanticRelease([]byte(`{
+	"branches": ["main", {"name": "beta", "prerelease": true}],
+	"tagFormat": "v${version}",
+	"plugins": [
+		"@semantic-release/commit-analyzer",
+		["@semantic-release/npm", {"npmPublish": true}],
+		["@codedependant/semantic-release-docker", {"dockerImage": "myapp"}],
+		"@semantic-release/github"
+	]
+}`))
+	require.True(t, ok)
+	assert.Equal(t, ReleaseConfig{
+		Tool:       ReleaseToolSemanticRelease,
+		Versioning: VersioningConventionalCommits,
+		TagFormat:  "v${version}",
+		Branches:   []string{"main", "beta"},
+		Plugins:    []string{"@semantic-release/commit-analyzer", "@semantic-release/npm", "@codedependant/semantic-release-docker", "@semantic-release/github"},
+		Publishes:  []string{"docker", "github-releases", "npm"},
+	}, release)
+
+	yamlRelease, ok := parser.ParseSemanticRelease([]byte("branch: master\n"))
+	require.True(t, ok)
+	assert.Equal(t, []string{"master"}, yamlRelease.Branches)
+	assert.Equal(t, semanticReleaseDefaultPlugins, yamlRelease.Plugins)
+	assert.Equal(t, []string{"github-releases", "npm"}, yamlRelease.Publishes)
+}
+
+func TestParseSemanticReleaseScript(t *testing.T) {
+	release := NewReleaseParser().ParseSemanticReleaseScript(`module.exports = {
+  branches: ['main'],
+  plugins: [
+    '@semantic-release/commit-analyzer',
+    ['@semantic-release/gitlab', { gitlabUrl: 'https://gitlab.example.com' }],
+    "semantic-release-pypi",
+  ],
+};`)
+	assert.Equal(t, []string{"@semantic-release/commit-analyzer", "@semantic-release/gitlab", "semantic-release-pypi"}, release.Plugins)
+	assert.Equal(t, []string{"gitlab-releases", "pypi"}, release.Publishes)
+	assert.Empty(t, release.Branches)
+}
+
+func TestParsePackageJSONRelease(t *testing.T) {
+	parser := NewReleaseParser()
+	release, ok := parser.ParsePackageJSONRelease([]byte(`{"name": "myapp", "release": {"branches": ["main"]}}`))
+	require.True(t, ok)
+	assert.Equal(t, []string{"main"}, release.Branches)
+
+	_, ok = parser.ParsePackageJSONRelease([]byte(`{"name": "myapp"}`))
+	assert.False(t, ok)
+}
+
+func TestParseChangesetsConfig(t *testing.T) {
+	release, ok := NewReleaseParser().ParseChangesetsConfig([]byte(`{"baseBranch": "main", "access": "public"}`))
+	require.True(t, ok)
+	assert.Equal(t, ReleaseConfig{
+		Tool: ReleaseToolChangesets, Versioning: VersioningChangesets, Branches: []string{"main"}, Publishes: []string{"npm"},
+	}, release)
+}
+
+func TestParseMavenRelease(t *testing.T) {
+	parser := NewReleaseParser()
+	release, ok := parser.ParseMavenRelease(`<project>
+  <build><plugins>
+    <plugin>
+      <artifactId>maven-release-plugin</artifactId>
+      <configuration><tagNameFormat>v@{project.version}</tagNameFormat></configuration>
+    </plugin>
+    <plugin><artifactId>central-publishing-maven-plugin</artifactId></plugin>
+  </plugins></build>
+</project>`)
+	require.True(t, ok)
+	assert.Equal(t, ReleaseConfig{
+		Tool: ReleaseToolMavenRelease, Versioning: VersioningPOM, TagFormat: "v@{project.version}", Publishes: []string{"maven-central"},
+	}, release)
+
+	_, ok = parser.ParseMavenRelease(`<project><artifactId>myapp</artifactId></project>`)
+	assert.False(t, ok)
+}
@@ -0,0 +1,177 @@
+package parsers
+
+import (
+	"encoding/xml"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// rosDistributions are the ROS 1 and ROS 2 distribution names.
+var rosDistributions = map[string]string{
+	"kinetic": "1", "lunar": "1", "melodic": "1", "noetic": "1",
+	"foxy": "2", "galactic": "2", "humble": "2", "iron": "2", "jazzy": "2", "kilted": "2", "rolling": "2",
+}
+
+// rosDistroRegex matches the places a ROS distribution is named: setup
+// scripts under /opt/ros, ROS_DISTRO settings, CI action inputs, ros and
+// osrf/ros image tags and branch versions of .repos files.
+var rosDistroRegex = regexp.MustCompile(`(?i)(?:/opt/ros/|\bROS_DISTRO\b["']?\s*[:=]\s*["']?|\bros[-_]distro(?:s|butions)?["']?\s*[:=]\s*["']?|required-ros-distributions:\s*|\bros:|\bversion:\s*)([a-z]+)\b`)
+
+// rosDependScopes maps package.xml dependency tags to scopes.
+var rosDependScopes = map[string]string{
+	"depend":              types.ScopeProd,
+	"run_depend":          types.ScopeProd,
+	"exec_depend":         types.ScopeProd,
+	"build_depend":        types.ScopeBuild,
+	"buildtool_depend":    types.ScopeBuild,
+	"build_export_depend": types.ScopeBuild,
+	"test_depend":         types.ScopeTest,
+	"doc_depend":          types.ScopeDev,
+}
+
+// rosScopeRank orders scopes when a key is declared by several tags; the
+// lowest rank wins.
+var rosScopeRank = map[string]int{types.ScopeProd: 0, types.ScopeBuild: 1, types.ScopeTest: 2, types.ScopeDev: 3}
+
+// rosVersionDeps are dependencies that only exist in one ROS version.
+var rosVersionDeps = map[string]string{
+	"catkin": "1", "roscpp": "1", "rospy": "1",
+	"ament_cmake": "2", "ament_python": "2", "rclcpp": "2", "rclpy": "2",
+}
+
+// ROSPackage holds the fields of a ROS package.xml the scanner reports.
+type ROSPackage struct {
+	Name         string
+	Version      string
+	Format       string
+	BuildType    string   // catkin, ament_cmake, ament_python, cmake
+	ROSVersions  []string // "1", "2" or both for packages built for either
+	Interface    bool     // member of rosidl_interface_packages
+	Dependencies []types.Dependency
+}
+
+// ROSParser parses ROS package manifests and finds ROS distribution names.
+type ROSParser struct{}
+
+// NewROSParser creates a new ROS parser.
+func NewROSParser() *ROSParser {
+	return &ROSParser{}
+}
+
+type rosPackageXML struct {
+	XMLName  xml.Name `xml:"package"`
+	Format   string   `xml:"format,attr"`
+	Name     string   `xml:"name"`
+	Version  string   `xml:"version"`
+	Groups   []string `xml:"member_of_group"`
+	Elements []struct {
+		XMLName   xml.Name
+		Condition string `xml:"condition,attr"`
+		Value     string `xml:",chardata"`
+	} `xml:",any"`
+	Export struct {
+		BuildType string `xml:"build_type"`
+	} `xml:"export"`
+}
+
+// ParsePackageXML parses a ROS package.xml (format 1, 2 or 3). Returns nil
+// for other package.xml files (Salesforce manifests, ...). Dependencies are
+// rosdep keys of type "ros", scoped by their tag; a key listed under several
+// tags keeps the broadest scope. The ROS version comes from the build tool
+// and the client libraries; a $ROS_VERSION condition limits an entry to one
+// version.
+func (p *ROSParser) ParsePackageXML(content []byte) *ROSPackage {
+	var doc rosPackageXML
+	if xml.Unmarshal(content, &doc) != nil || doc.XMLName.Space != "" || strings.TrimSpace(doc.Name) == "" {
+		return nil
+	}
+	pkg := &ROSPackage{
+		Name:      strings.TrimSpace(doc.Name),
+		Version:   strings.TrimSpace(doc.Version),
+		Format:    firstNonEmpty(doc.Format, "1"),
+		BuildType: strings.TrimSpace(doc.Export.BuildType),
+	}
+	for _, group := range doc.Groups {
+		pkg.Interface = pkg.Interface || strings.TrimSpace(group) == "rosidl_interface_packages"
+	}
+
+	versions := pkg.addDependencies(doc)
+	if pkg.BuildType == "" && versions["1"] {
+		pkg.BuildType = "catkin"
+	}
+	if strings.HasPrefix(pkg.BuildType, "ament_") {
+		versions["2"] = true
+	}
+	for version := range versions {
+		pkg.ROSVersions = append(pkg.ROSVersions, version)
+	}
+	sort.Strings(pkg.ROSVersions)
+	return pkg
+}
+
+// addDependencies adds the dependency tags of the manifest and returns the
+// ROS versions they imply.
+func (pkg *ROSPackage) addDependencies(doc rosPackageXML) map[string]bool {
+	versions := make(map[string]bool)
+	byName := make(map[string]int)
+	for _, element := range doc.Elements {
+		scope, ok := rosDependScopes[element.XMLName.Local]
+		name := strings.TrimSpace(element.Value)
+		if !ok || name == "" {
+			continue
+		}
+		if version := rosVersionOf(name, element.Condition); version != "" {
+			versions[version] = true
+		}
+		pkg.Dependencies = addROSDependency(pkg.Dependencies, byName, name, scope)
+	}
+	return versions
+}
+
+// rosVersionOf returns the ROS version a dependency implies, or the version
+// its $ROS_VERSION condition names.
+func rosVersionOf(name, condition string) string {
+	if _, version, ok := strings.Cut(strings.ReplaceAll(condition, " ", ""), "$ROS_VERSION=="); ok {
+		return version
+	}
+	return rosVersionDeps[name]
+}
+
+func addROSDependency(deps []types.Dependency, byName map[string]int, name, scope string) []types.Dependency {
+	if i, ok := byName[name]; ok {
+		if rosScopeRank[scope] < rosScopeRank[deps[i].Scope] {
+			deps[i].Scope = scope
+		}
+		return deps
+	}
+	byName[name] = len(deps)
+	return append(deps, types.Dependency{
+		Type:     DependencyTypeROS,
+		Name:     name,
+		Scope:    scope,
+		Direct:   true,
+		Metadata: types.NewMetadata("package.xml"),
+	})
+}
+
+// FindDistributions returns the ROS distribution names mentioned in a
+// Dockerfile, CI workflow or .repos file.
+func (p *ROSParser) FindDistributions(content string) []string {
+	var distributions []string
+	for _, m := range rosDistroRegex.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(m[1])
+		if _, ok := rosDistributions[name]; ok {
+			distributions = append(distributions, name)
+		}
+	}
+	return distributions
+}
+
+// ROSDistributionVersion returns the ROS version ("1" or "2") of a
+// distribution name.
+func ROSDistributionVersion(distribution string) string {
+	return rosDistributions[distribution]
+}
@@ -0,0 +1,82 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestROSParser_ParsePackageXML_Format1(t *testing.T) {
+	content := `<?xml version="1.0"?>
+<package>
+  <name>legacy_driver</name>
+  <version>1.0.3</version>
+  <buildtool_depend>catkin</buildtool_depend>
+  <build_depend>roscpp</build_depend>
+  <run_depend>roscpp</run_depend>
+  <build_depend>message_generation</build_depend>
+</package>`
+	pkg := NewROSParser().ParsePackageXML([]byte(content))
+	require.NotNil(t, pkg)
+	assert.Equal(t, "legacy_driver", pkg.Name)
+	assert.Equal(t, "1", pkg.Format)
+	assert.Equal(t, "catkin", pkg.BuildType)
+	assert.Equal(t, []string{"1"}, pkg.ROSVersions)
+	assert.Equal(t, []types.Dependency{
+		{Type: DependencyTypeROS, Name: "catkin", Scope: types.ScopeBuild, Direct: true, Metadata: types.NewMetadata("package.xml")},
+		{Type: DependencyTypeROS, Name: "roscpp", Scope: types.ScopeProd, Direct: true, Metadata: types.NewMetadata("package.xml")},
+		{Type: DependencyTypeROS, Name: "message_generation", Scope: types.ScopeBuild, Direct: true, Metadata: types.NewMetadata("package.xml")},
+	}, pkg.Dependencies)
+}
+
+func TestROSParser_ParsePackageXML_Format3(t *testing.T) {
+	content := `<?xml version="1.0"?>
+<package format="3">
+  <name>my_interfaces</name>
+  <version>0.2.0</version>
+  <buildtool_depend condition="$ROS_VERSION == 1">catkin</buildtool_depend>
+  <buildtool_depend condition="$ROS_VERSION == 2">ament_cmake</buildtool_depend>
+  <depend>std_msgs</depend>
+  <test_depend>ament_lint_auto</test_depend>
+  <doc_depend>rosdoc2</doc_depend>
+  <member_of_group>rosidl_interface_packages</member_of_group>
+  <export>
+    <build_type condition="$ROS_VERSION == 2">ament_cmake</build_type>
+  </export>
+</package>`
+	pkg := NewROSParser().ParsePackageXML([]byte(content))
+	require.NotNil(t, pkg)
+	assert.Equal(t, "3", pkg.Format)
+	assert.Equal(t, "ament_cmake", pkg.BuildType)
+	assert.Equal(t, []string{"1", "2"}, pkg.ROSVersions)
+	assert.True(t, pkg.Interface)
+	scopes := make(map[string]string)
+	for _, dep := range pkg.Dependencies {
+		scopes[dep.Name] = dep.Scope
+	}
+	assert.Equal(t, map[string]string{
+		"catkin": types.ScopeBuild, "ament_cmake": types.ScopeBuild, "std_msgs": types.ScopeProd,
+		"ament_lint_auto": types.ScopeTest, "rosdoc2": types.ScopeDev,
+	}, scopes)
+}
+
+func TestROSParser_ParsePackageXML_NotROS(t *testing.T) {
+	parser := NewROSParser()
+	assert.Nil(t, parser.ParsePackageXML([]byte(`<Package xmlns="http://soap.sforce.com/2006/04/metadata"><version>59.0</version></Package>`)))
+	assert.Nil(t, parser.ParsePackageXML([]byte(`<Export generator="IRIS" version="26"></Export>`)))
+	assert.Nil(t, parser.ParsePackageXML([]byte(`<package><description>no name</description></package>`)))
+}
+
+func TestROSParser_FindDistributions(t *testing.T) {
+	content := `FROM osrf/ros:humble-desktop
+ENV ROS_DISTRO=humble
+RUN . /opt/ros/humble/setup.sh
+# required-ros-distributions: rolling
+ARG BASE=ubuntu:jammy
+`
+	assert.Equal(t, []string{"humble", "humble", "humble", "rolling"}, NewROSParser().FindDistributions(content))
+	assert.Equal(t, []string{"iron"}, NewROSParser().FindDistributions("repositories:\n  demos:\n    type: git\n    version: iron\n"))
+	assert.Empty(t, NewROSParser().FindDistributions("version: 1.2.0\nROS_DISTRO: custom\n"))
+}
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// salesforceMetadataNamespace is the XML namespace of Metadata API manifests.
+const salesforceMetadataNamespace = "http://soap.sforce.com/2006/04/metadata"
+
+// SFDXProject holds the fields of sfdx-project.json the scanner reports.
+type SFDXProject struct {
+	Name               string                 `json:"name,omitempty"`
+	Namespace          string                 `json:"namespace,omitempty"`
+	SourceAPIVersion   string                 `json:"sourceApiVersion,omitempty"`
+	PackageDirectories []SFDXPackageDirectory `json:"packageDirectories"`
+}
+
+// SFDXPackageDirectory is one entry of packageDirectories: a source folder,
+// optionally packaged as an unlocked or managed package.
+type SFDXPackageDirectory struct {
+	Path          string   `json:"path"`
+	Package       string   `json:"package,omitempty"`
+	VersionNumber string   `json:"version_number,omitempty"`
+	Default       bool     `json:"default,omitempty"`
+	Dependencies  []string `json:"dependencies,omitempty"` // package[@version]
+}
+
+// SalesforceManifest is a Metadata API package.xml manifest.
+type SalesforceManifest struct {
+	File       string   `json:"file"`
+	APIVersion string   `json:"api_version,omitempty"`
+	Types      []string `json:"types,omitempty"`
+}
+
+// SalesforceParser parses Salesforce DX projects and Metadata API manifests.
+type SalesforceParser struct{}
+
+// NewSalesforceParser creates a new Salesforce parser.
+func NewSalesforceParser() *SalesforceParser {
+	return &SalesforceParser{}
+}
+
+// ParseSFDXProject parses sfdx-project.json. Package dependencies are
+// reported as "package@versionNumber" (versionNumber omitted when the
+// dependency is a package version alias).
+func (p *SalesforceParser) ParseSFDXProject(content []byte) *SFDXProject {
+	var raw struct {
+		Name               string `json:"name"`
+		Namespace          string `json:"namespace"`
+		SourceAPIVersion   string `json:"sourceApiVersion"`
+		PackageDirectories []struct {
+			Path          string `json:"path"`
+			Package       string `json:"package"`
+			VersionNumber string `json:"versionNumber"`
+			Default       bool   `json:"default"`
+			Dependencies  []struct {
+				Package       string `json:"package"`
+				VersionNumber string `json:"versionNumber"`
+			} `json:"dependencies"`
+		} `json:"packageDirectories"`
+	}
+	if json.Unmarshal(content, &raw) != nil || len(raw.PackageDirectories) == 0 {
+		return nil
+	}
+	project := &SFDXProject{Name: raw.Name, Namespace: raw.Namespace, SourceAPIVersion: raw.SourceAPIVersion}
+	for _, dir := range raw.PackageDirectories {
+		entry := SFDXPackageDirectory{Path: dir.Path, Package: dir.Package, VersionNumber: dir.VersionNumber, Default: dir.Default}
+		for _, dep := range dir.Dependencies {
+			name := dep.Package
+			if dep.VersionNumber != "" {
+				name += "@" + dep.VersionNumber
+			}
+			entry.Dependencies = append(entry.Dependencies, name)
+		}
+		project.PackageDirectories = append(project.PackageDirectories, entry)
+	}
+	return project
+}
+
+// ParsePackageXML parses a Metadata API package.xml manifest and returns the
+// API version and the metadata type names it lists. Returns nil for other
+// package.xml files (ROS, InterSystems, ...).
+func (p *SalesforceParser) ParsePackageXML(content []byte) *SalesforceManifest {
+	var pkg struct {
+		XMLName xml.Name `xml:"Package"`
+		Types   []struct {
+			Name string `xml:"name"`
+		} `xml:"types"`
+		Version string `xml:"version"`
+	}
+	if xml.Unmarshal(content, &pkg) != nil || pkg.XMLName.Space != salesforceMetadataNamespace {
+		return nil
+	}
+	manifest := &SalesforceManifest{APIVersion: strings.TrimSpace(pkg.Version)}
+	for _, t := range pkg.Types {
+		if name := strings.TrimSpace(t.Name); name != "" {
+			manifest.Types = append(manifest.Types, name)
+		}
+	}
+	sort.Strings(manifest.Types)
+	return manifest
+}
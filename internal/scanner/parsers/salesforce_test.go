@@ -0,0 +1,62 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSFDXProject(t *testing.T) {
+	content := `{
+  "packageDirectories": [
+    {"path": "force-app", "default": true, "package": "MyApp", "versionNumber": "1.2.0.NEXT",
+     "dependencies": [{"package": "MyBase", "versionNumber": "2.0.0.LATEST"}, {"package": "MyLib@1.0.0-3"}]},
+    {"path": "unpackaged"}
+  ],
+  "name": "myapp",
+  "namespace": "myns",
+  "sfdcLoginUrl": "https://login.salesforce.com",
+  "sourceApiVersion": "59.0",
+  "packageAliases": {"MyApp": "0Ho000000000000AAA"}
+}`
+	project := NewSalesforceParser().ParseSFDXProject([]byte(content))
+	require.NotNil(t, project)
+	assert.Equal(t, "myapp", project.Name)
+	assert.Equal(t, "myns", project.Namespace)
+	assert.Equal(t, "59.0", project.SourceAPIVersion)
+	require.Len(t, project.PackageDirectories, 2)
+	assert.Equal(t, SFDXPackageDirectory{
+		Path:          "force-app",
+		Package:       "MyApp",
+		VersionNumber: "1.2.0.NEXT",
+		Default:       true,
+		Dependencies:  []string{"MyBase@2.0.0.LATEST", "MyLib@1.0.0-3"},
+	}, project.PackageDirectories[0])
+	assert.Equal(t, "unpackaged", project.PackageDirectories[1].Path)
+
+	assert.Nil(t, NewSalesforceParser().ParseSFDXProject([]byte(`{"name": "x"}`)))
+}
+
+func TestParsePackageXML(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types>
+        <members>*</members>
+        <name>CustomObject</name>
+    </types>
+    <types>
+        <members>AccountService</members>
+        <members>AccountServiceTest</members>
+        <name>ApexClass</name>
+    </types>
+    <version>58.0</version>
+</Package>`
+	manifest := NewSalesforceParser().ParsePackageXML([]byte(content))
+	require.NotNil(t, manifest)
+	assert.Equal(t, "58.0", manifest.APIVersion)
+	assert.Equal(t, []string{"ApexClass", "CustomObject"}, manifest.Types)
+
+	ros := `<?xml version="1.0"?><package format="3"><name>my_robot</name><version>0.1.0</version></package>`
+	assert.Nil(t, NewSalesforceParser().ParsePackageXML([]byte(ros)))
+}
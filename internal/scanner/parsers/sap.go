@@ -0,0 +1,144 @@
+package parsers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// UI5App is an SAPUI5/OpenUI5 application or library described by a
+// manifest.json descriptor (the "sap.app" and "sap.ui5" sections).
+type UI5App struct {
+	File               string          `json:"file"`
+	ID                 string          `json:"id,omitempty"`
+	Type               string          `json:"type,omitempty"` // application, component, library, card
+	ApplicationVersion string          `json:"application_version,omitempty"`
+	MinUI5Version      string          `json:"min_ui5_version,omitempty"`
+	Libraries          []string        `json:"libraries,omitempty"`
+	DataSources        []SAPDataSource `json:"data_sources,omitempty"`
+}
+
+// SAPDataSource is a backend service a UI5 app consumes, usually an OData
+// service of an SAP Gateway system (/sap/opu/odata/...).
+type SAPDataSource struct {
+	Name         string `json:"name"`
+	URI          string `json:"uri,omitempty"`
+	Type         string `json:"type,omitempty"` // OData, ODataAnnotation, JSON, ...
+	ODataVersion string `json:"odata_version,omitempty"`
+}
+
+// AbapGitRepo holds the settings of an abapGit repository (.abapgit.xml).
+type AbapGitRepo struct {
+	MasterLanguage string `json:"master_language,omitempty"`
+	StartingFolder string `json:"starting_folder,omitempty"`
+	FolderLogic    string `json:"folder_logic,omitempty"`
+}
+
+// SAPParser parses SAP development artifacts: UI5 manifests and abapGit
+// repository settings.
+type SAPParser struct{}
+
+// NewSAPParser creates a new SAP parser.
+func NewSAPParser() *SAPParser {
+	return &SAPParser{}
+}
+
+// ParseUI5Manifest parses a UI5 manifest.json. Returns nil when the file has
+// no "sap.app" section (web app manifests, browser extensions, ...). Data
+// source URIs with a host are stripped of credentials and query.
+func (p *SAPParser) ParseUI5Manifest(content []byte) *UI5App {
+	var manifest struct {
+		App *struct {
+			ID                 string `json:"id"`
+			Type               string `json:"type"`
+			ApplicationVersion struct {
+				Version string `json:"version"`
+			} `json:"applicationVersion"`
+			DataSources map[string]struct {
+				URI      string `json:"uri"`
+				Type     string `json:"type"`
+				Settings struct {
+					ODataVersion string `json:"odataVersion"`
+				} `json:"settings"`
+			} `json:"dataSources"`
+		} `json:"sap.app"`
+		UI5 struct {
+			Dependencies struct {
+				MinUI5Version json.RawMessage            `json:"minUI5Version"`
+				Libs          map[string]json.RawMessage `json:"libs"`
+			} `json:"dependencies"`
+		} `json:"sap.ui5"`
+	}
+	if json.Unmarshal(content, &manifest) != nil || manifest.App == nil {
+		return nil
+	}
+	app := &UI5App{
+		ID:                 manifest.App.ID,
+		Type:               manifest.App.Type,
+		ApplicationVersion: manifest.App.ApplicationVersion.Version,
+		MinUI5Version:      minUI5Version(manifest.UI5.Dependencies.MinUI5Version),
+	}
+	for lib := range manifest.UI5.Dependencies.Libs {
+		app.Libraries = append(app.Libraries, lib)
+	}
+	sort.Strings(app.Libraries)
+	for name, source := range manifest.App.DataSources {
+		app.DataSources = append(app.DataSources, SAPDataSource{
+			Name:         name,
+			URI:          sanitizeServiceURI(source.URI),
+			Type:         source.Type,
+			ODataVersion: source.Settings.ODataVersion,
+		})
+	}
+	sort.Slice(app.DataSources, func(i, j int) bool { return app.DataSources[i].Name < app.DataSources[j].Name })
+	return app
+}
+
+// minUI5Version reads minUI5Version, a string or (since manifest version
+// 1.49) a list of versions, one per supported major release.
+func minUI5Version(raw json.RawMessage) string {
+	var version string
+	if json.Unmarshal(raw, &version) == nil {
+		return version
+	}
+	var versions []string
+	if json.Unmarshal(raw, &versions) == nil {
+		return strings.Join(versions, ", ")
+	}
+	return ""
+}
+
+// sanitizeServiceURI keeps relative service paths as they are and strips
+// credentials, query and fragment from absolute URLs.
+func sanitizeServiceURI(uri string) string {
+	if !strings.Contains(uri, "://") {
+		uri, _, _ = strings.Cut(uri, "?")
+		return uri
+	}
+	clean, _ := SanitizeRegistryURL(uri)
+	return clean
+}
+
+// ParseAbapGitXML parses the .abapgit.xml settings file of an abapGit
+// repository. Returns nil when the content is not abapGit settings.
+func (p *SAPParser) ParseAbapGitXML(content []byte) *AbapGitRepo {
+	var doc struct {
+		Values struct {
+			Data *struct {
+				MasterLanguage string `xml:"MASTER_LANGUAGE"`
+				StartingFolder string `xml:"STARTING_FOLDER"`
+				FolderLogic    string `xml:"FOLDER_LOGIC"`
+			} `xml:"DATA"`
+		} `xml:"values"`
+	}
+	if xml.Unmarshal(content, &doc) != nil || doc.Values.Data == nil {
+		return nil
+	}
+	data := doc.Values.Data
+	return &AbapGitRepo{
+		MasterLanguage: strings.TrimSpace(data.MasterLanguage),
+		StartingFolder: strings.TrimSpace(data.StartingFolder),
+		FolderLogic:    strings.TrimSpace(data.FolderLogic),
+	}
+}
@@ -0,0 +1,274 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of scheduled job definitions.
+const (
+	ScheduleSourceCrontab       = "crontab"
+	ScheduleSourceKubernetes    = "kubernetes"
+	ScheduleSourceGitHubActions = "github-actions"
+	ScheduleSourceSpring        = "spring"
+	ScheduleSourceQuartz        = "quartz"
+	ScheduleSourceCelery        = "celery"
+	ScheduleSourceTerraform     = "terraform"
+)
+
+var (
+	cronLineRegex        = regexp.MustCompile(`^((?:\S+\s+){4}\S+|@(?:yearly|annually|monthly|weekly|daily|midnight|hourly|reboot))\s+(.+)$`)
+	cronEnvRegex         = regexp.MustCompile(`^\w+\s*=`)
+	cronFieldRegex       = regexp.MustCompile(`^[\d*/,\-A-Za-z?LW#]+$`)
+	cronJobKindRegex     = regexp.MustCompile(`(?m)^kind:\s*CronJob\s*$`)
+	springScheduledRegex = regexp.MustCompile(`(?s)@Scheduled\s*\(([^)]*)\)[^(]*?\b(\w+)\s*\(`)
+	springArgRegex       = regexp.MustCompile(`\b(cron|fixedRate|fixedDelay|fixedRateString|fixedDelayString)\s*=\s*("[^"]*"|[\w.]+)`)
+	springZoneRegex      = regexp.MustCompile(`\bzone\s*=\s*"([^"]*)"`)
+	quartzCronRegex      = regexp.MustCompile(`cronSchedule\(\s*"([^"]+)"|<cron-expression>\s*([^<]+?)\s*</cron-expression>`)
+	celeryEntryRegex     = regexp.MustCompile(`(?s)['"]([^'"]+)['"]\s*:\s*\{([^{}]*?['"]task['"][^{}]*)\}`)
+	celeryTaskRegex      = regexp.MustCompile(`['"]task['"]\s*:\s*['"]([^'"]+)['"]`)
+	celeryScheduleRegex  = regexp.MustCompile(`['"]schedule['"]\s*:\s*((?:crontab|solar|timedelta|schedule)\([^)]*\)|[^,\n}]+)`)
+)
+
+// terraformScheduleAttributes are the Terraform resources that schedule work,
+// with the attributes holding the schedule and its time zone.
+var terraformScheduleAttributes = map[string][2]string{
+	"google_cloud_scheduler_job": {"schedule", "time_zone"},
+	"aws_cloudwatch_event_rule":  {"schedule_expression", ""},
+	"aws_scheduler_schedule":     {"schedule_expression", "schedule_expression_timezone"},
+}
+
+// ScheduledJob is a periodic job a repository defines.
+type ScheduledJob struct {
+	Name     string `json:"name,omitempty"`
+	Schedule string `json:"schedule"` // cron expression, @daily, rate(...), fixedRate=..., Celery schedule
+	TimeZone string `json:"time_zone,omitempty"`
+	Command  string `json:"command,omitempty"` // crontab program, Celery task
+	Source   string `json:"source"`
+	File     string `json:"file"`
+}
+
+// SchedulesParser reads scheduled job definitions.
+type SchedulesParser struct{}
+
+// NewSchedulesParser creates a new scheduled job parser.
+func NewSchedulesParser() *SchedulesParser {
+	return &SchedulesParser{}
+}
+
+// ParseCrontab reads a crontab or /etc/cron.d file. Only the program of a
+// command is kept; its arguments may carry credentials. Lines of cron.d
+// files name a user before the command, which is skipped when systemFormat
+// is set.
+func (p *SchedulesParser) ParseCrontab(content string, systemFormat bool) []ScheduledJob {
+	var jobs []ScheduledJob
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || cronEnvRegex.MatchString(line) {
+			continue
+		}
+		m := cronLineRegex.FindStringSubmatch(line)
+		if m == nil || !isCronSchedule(m[1]) {
+			continue
+		}
+		command := strings.Fields(m[2])
+		if systemFormat && len(command) > 1 {
+			command = command[1:]
+		}
+		jobs = append(jobs, ScheduledJob{Schedule: m[1], Command: cronProgram(command), Source: ScheduleSourceCrontab})
+	}
+	return jobs
+}
+
+func isCronSchedule(schedule string) bool {
+	if strings.HasPrefix(schedule, "@") {
+		return true
+	}
+	for _, field := range strings.Fields(schedule) {
+		if !cronFieldRegex.MatchString(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// cronProgram returns the program a crontab command runs, skipping leading
+// "cd dir &&" and environment assignments.
+func cronProgram(fields []string) string {
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case fields[i] == "cd" && i+2 < len(fields) && fields[i+2] == "&&":
+			i += 2
+		case !strings.Contains(fields[i], "="):
+			return fields[i]
+		}
+	}
+	return ""
+}
+
+// IsKubernetesCronJob reports whether a YAML manifest declares a CronJob.
+func (p *SchedulesParser) IsKubernetesCronJob(content []byte) bool {
+	return cronJobKindRegex.Match(content)
+}
+
+// ParseKubernetesCronJobs returns the CronJobs of a (multi-document)
+// manifest.
+func (p *SchedulesParser) ParseKubernetesCronJobs(content []byte) []ScheduledJob {
+	var jobs []ScheduledJob
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var obj struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Schedule string `yaml:"schedule"`
+				TimeZone string `yaml:"timeZone"`
+			} `yaml:"spec"`
+		}
+		// Stop at the end or at a document that is not valid YAML
+		// (templated Helm charts).
+		if decoder.Decode(&obj) != nil {
+			return jobs
+		}
+		if obj.Kind == "CronJob" && obj.Spec.Schedule != "" {
+			jobs = append(jobs, ScheduledJob{Name: obj.Metadata.Name, Schedule: obj.Spec.Schedule, TimeZone: obj.Spec.TimeZone, Source: ScheduleSourceKubernetes})
+		}
+	}
+}
+
+// ParseWorkflowSchedules returns the schedule triggers of a GitHub Actions
+// workflow, named after the workflow.
+func (p *SchedulesParser) ParseWorkflowSchedules(content []byte) []ScheduledJob {
+	var workflow struct {
+		Name string `yaml:"name"`
+		On   struct {
+			Schedule []struct {
+				Cron     string `yaml:"cron"`
+				Timezone string `yaml:"timezone"`
+			} `yaml:"schedule"`
+		} `yaml:"on"`
+	}
+	if yaml.Unmarshal(content, &workflow) != nil {
+		return nil
+	}
+	var jobs []ScheduledJob
+	for _, trigger := range workflow.On.Schedule {
+		if trigger.Cron != "" {
+			jobs = append(jobs, ScheduledJob{Name: workflow.Name, Schedule: trigger.Cron, TimeZone: trigger.Timezone, Source: ScheduleSourceGitHubActions})
+		}
+	}
+	return jobs
+}
+
+// ParseJVMSchedules returns the Spring @Scheduled methods (cron or fixed
+// rate/delay, named after the method) and Quartz cron triggers
+// (cronSchedule("...") in code, <cron-expression> in quartz_data.xml).
+func (p *SchedulesParser) ParseJVMSchedules(content string) []ScheduledJob {
+	if !strings.Contains(content, "@Scheduled") && !strings.Contains(content, "cronSchedule(") && !strings.Contains(content, "<cron-expression>") {
+		return nil
+	}
+	var jobs []ScheduledJob
+	for _, m := range springScheduledRegex.FindAllStringSubmatch(content, -1) {
+		args := springArgRegex.FindStringSubmatch(m[1])
+		if args == nil {
+			continue
+		}
+		schedule := strings.Trim(args[2], `"`)
+		if args[1] != "cron" {
+			schedule = args[1] + "=" + schedule
+		}
+		jobs = append(jobs, ScheduledJob{Name: m[2], Schedule: schedule, TimeZone: firstSubmatch(springZoneRegex, m[1]), Source: ScheduleSourceSpring})
+	}
+	for _, m := range quartzCronRegex.FindAllStringSubmatch(content, -1) {
+		jobs = append(jobs, ScheduledJob{Schedule: firstNonEmpty(m[1], m[2]), Source: ScheduleSourceQuartz})
+	}
+	return jobs
+}
+
+// IsCeleryBeatConfig reports whether Python code configures a Celery beat
+// schedule.
+func (p *SchedulesParser) IsCeleryBeatConfig(content string) bool {
+	return strings.Contains(content, "beat_schedule") || strings.Contains(content, "CELERYBEAT_SCHEDULE") || strings.Contains(content, "CELERY_BEAT_SCHEDULE")
+}
+
+// ParseCeleryBeat returns the entries of a Celery beat schedule dict. The
+// schedule is kept as written (crontab(minute=0), 300.0, timedelta(hours=1)).
+func (p *SchedulesParser) ParseCeleryBeat(content string) []ScheduledJob {
+	var jobs []ScheduledJob
+	for _, m := range celeryEntryRegex.FindAllStringSubmatch(content, -1) {
+		schedule := strings.TrimSpace(firstSubmatch(celeryScheduleRegex, m[2]))
+		if schedule == "" {
+			continue
+		}
+		jobs = append(jobs, ScheduledJob{Name: m[1], Schedule: schedule, Command: firstSubmatch(celeryTaskRegex, m[2]), Source: ScheduleSourceCelery})
+	}
+	return jobs
+}
+
+// ParseTerraformSchedules returns the cloud scheduler resources of a .tf
+// file: Google Cloud Scheduler jobs and AWS EventBridge rules and schedules,
+// named type.name. A schedule that is not a literal is
+// kept as its expression.
+func (p *SchedulesParser) ParseTerraformSchedules(content []byte) []ScheduledJob {
+	if !hasScheduleResource(content) {
+		return nil
+	}
+	file, diags := hclparse.NewParser().ParseHCL(content, "schedules.tf")
+	if diags.HasErrors() {
+		return nil
+	}
+	body, _ := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	var jobs []ScheduledJob
+	for _, block := range body.Blocks.OfType("resource") {
+		keys, ok := terraformScheduleAttributes[block.Labels[0]]
+		if !ok {
+			continue
+		}
+		attrs, _ := block.Body.JustAttributes()
+		schedule := hclAttributeText(attrs[keys[0]], content)
+		if schedule == "" {
+			continue
+		}
+		jobs = append(jobs, ScheduledJob{
+			Name:     block.Labels[0] + "." + block.Labels[1],
+			Schedule: schedule,
+			TimeZone: hclAttributeText(attrs[keys[1]], content),
+			Source:   ScheduleSourceTerraform,
+		})
+	}
+	return jobs
+}
+
+func hasScheduleResource(content []byte) bool {
+	for resourceType := range terraformScheduleAttributes {
+		if bytes.Contains(content, []byte(resourceType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hclAttributeText returns the string value of an attribute, or its source
+// text when it is not a literal.
+func hclAttributeText(attr *hcl.Attribute, content []byte) string {
+	if attr == nil {
+		return ""
+	}
+	if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.IsKnown() && !value.IsNull() && value.Type() == cty.String {
+		return value.AsString()
+	}
+	r := attr.Expr.Range()
+	return string(content[r.Start.Byte:r.End.Byte])
+}
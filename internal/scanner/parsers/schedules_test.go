@@ -0,0 +1,122 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulesParser_ParseCrontab(t *testing.T) {
+	content := `# m h dom mon dow command
+MAILTO=ops@example.com
+*/15 * * * * cd /srv/myapp && /usr/local/bin/sync.sh --token=secret
+0 3 * * 1-5 PATH=/usr/bin python3 manage.py clearsessions
+@daily /opt/myapp/backup.sh
+not a cron line
+`
+	assert.Equal(t, []ScheduledJob{
+		{Schedule: "*/15 * * * *", Command: "/usr/local/bin/sync.sh", Source: ScheduleSourceCrontab},
+		{Schedule: "0 3 * * 1-5", Command: "python3", Source: ScheduleSourceCrontab},
+		{Schedule: "@daily", Command: "/opt/myapp/backup.sh", Source: ScheduleSourceCrontab},
+	}, NewSchedulesParser().ParseCrontab(content, false))
+
+	assert.Equal(t, []ScheduledJob{{Schedule: "30 2 * * *", Command: "/usr/sbin/logrotate", Source: ScheduleSourceCrontab}},
+		NewSchedulesParser().ParseCrontab("30 2 * * * root /usr/sbin/logrotate /etc/logrotate.conf\n", true))
+}
+
+func TestSchedulesParser_ParseKubernetesCronJobs(t *testing.T) {
+	content := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+spec:
+  schedule: "0 6 * * *"
+  timeZone: Europe/Berlin
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: report
+              image: myorg/report:1.0
+`
+	parser := NewSchedulesParser()
+	assert.True(t, parser.IsKubernetesCronJob([]byte(content)))
+	assert.Equal(t, []ScheduledJob{{Name: "report", Schedule: "0 6 * * *", TimeZone: "Europe/Berlin", Source: ScheduleSourceKubernetes}},
+		parser.ParseKubernetesCronJobs([]byte(content)))
+}
+
+func TestSchedulesParser_ParseWorkflowSchedules(t *testing.T) {
+	content := "name: Nightly\non:\n  schedule:\n    - cron: '0 2 * * *'\n  workflow_dispatch: {}\njobs: {}\n"
+	assert.Equal(t, []ScheduledJob{{Name: "Nightly", Schedule: "0 2 * * *", Source: ScheduleSourceGitHubActions}},
+		NewSchedulesParser().ParseWorkflowSchedules([]byte(content)))
+	assert.Nil(t, NewSchedulesParser().ParseWorkflowSchedules([]byte("on: push\njobs: {}\n")))
+}
+
+func TestSchedulesParser_ParseJVMSchedules(t *testing.T) {
+	content := `@Component
+public class Jobs {
+    @Scheduled(cron = "0 0 * * * *", zone = "UTC")
+    public void purgeExpired() {}
+
+    @Scheduled(fixedRate = 60000)
+    void heartbeat() {}
+
+    Trigger trigger = newTrigger().withSchedule(cronSchedule("0 0/5 * * * ?")).build();
+}
+`
+	assert.Equal(t, []ScheduledJob{
+		{Name: "purgeExpired", Schedule: "0 0 * * * *", TimeZone: "UTC", Source: ScheduleSourceSpring},
+		{Name: "heartbeat", Schedule: "fixedRate=60000", Source: ScheduleSourceSpring},
+		{Schedule: "0 0/5 * * * ?", Source: ScheduleSourceQuartz},
+	}, NewSchedulesParser().ParseJVMSchedules(content))
+
+	xml := "<job-scheduling-data><schedule><trigger><cron><cron-expression>0 15 10 ? * MON-FRI</cron-expression></cron></trigger></schedule></job-scheduling-data>"
+	assert.Equal(t, []ScheduledJob{{Schedule: "0 15 10 ? * MON-FRI", Source: ScheduleSourceQuartz}}, NewSchedulesParser().ParseJVMSchedules(xml))
+}
+
+func TestSchedulesParser_ParseCeleryBeat(t *testing.T) {
+	content := `app.conf.beat_schedule = {
+    'cleanup-every-night': {
+        'task': 'myapp.tasks.cleanup',
+        'schedule': crontab(minute=0, hour=3),
+    },
+    "ping": {"task": "myapp.tasks.ping", "schedule": 30.0},
+}
+`
+	parser := NewSchedulesParser()
+	assert.True(t, parser.IsCeleryBeatConfig(content))
+	assert.Equal(t, []ScheduledJob{
+		{Name: "cleanup-every-night", Schedule: "crontab(minute=0, hour=3)", Command: "myapp.tasks.cleanup", Source: ScheduleSourceCelery},
+		{Name: "ping", Schedule: "30.0", Command: "myapp.tasks.ping", Source: ScheduleSourceCelery},
+	}, parser.ParseCeleryBeat(content))
+}
+
+func TestSchedulesParser_ParseTerraformSchedules(t *testing.T) {
+	content := `resource "google_cloud_scheduler_job" "nightly" {
+  name      = "nightly"
+  schedule  = "0 1 * * *"
+  time_zone = "Europe/Paris"
+  http_target {
+    uri = "https://example.com/run"
+  }
+}
+
+resource "aws_cloudwatch_event_rule" "hourly" {
+  schedule_expression = var.hourly_rate
+}
+
+resource "aws_s3_bucket" "data" {
+  bucket = "myapp-data"
+}
+`
+	assert.Equal(t, []ScheduledJob{
+		{Name: "google_cloud_scheduler_job.nightly", Schedule: "0 1 * * *", TimeZone: "Europe/Paris", Source: ScheduleSourceTerraform},
+		{Name: "aws_cloudwatch_event_rule.hourly", Schedule: "var.hourly_rate", Source: ScheduleSourceTerraform},
+	}, NewSchedulesParser().ParseTerraformSchedules([]byte(content)))
+}
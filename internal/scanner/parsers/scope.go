@@ -0,0 +1,126 @@
+package parsers
+
+import "github.com/petrarca/tech-stack-analyzer/internal/types"
+
+// MetadataNativeScope is the dependency metadata key holding the scope a
+// manifest declared, for ecosystems whose scopes are normalized lossily.
+// Gradle dependencies keep their configuration under "configuration".
+const MetadataNativeScope = "native_scope"
+
+// scopeMapping normalizes the native scopes of one ecosystem to the scope
+// constants of the types package.
+type scopeMapping struct {
+	native   map[string]string // native scope -> normalized scope
+	fallback string            // scope of native scopes not in native
+	implicit string            // native scope of dependencies declaring none
+}
+
+// scopeMappings are the ecosystems whose manifests name more scopes than the
+// normalized set, and npm, whose package.json sections several parsers map.
+// The other parsers map their few manifest sections (composer
+// require/require-dev, ...) one to one and set the normalized scope
+// directly. The semantics are documented in docs/output.md.
+var scopeMappings = map[string]scopeMapping{
+	DependencyTypeMaven: {
+		native: map[string]string{
+			"compile":  types.ScopeProd,
+			"runtime":  types.ScopeProd,
+			"provided": types.ScopeProd, // supplied by the runtime container, still shipped against
+			"system":   types.ScopeSystem,
+			"import":   types.ScopeImport, // dependencyManagement BOM import
+			"test":     types.ScopeDev,
+		},
+		fallback: types.ScopeProd,
+		implicit: "compile",
+	},
+	DependencyTypeGradle: {
+		native: map[string]string{
+			"implementation":      types.ScopeProd,
+			"api":                 types.ScopeProd,
+			"compile":             types.ScopeProd,
+			"runtimeOnly":         types.ScopeProd,
+			"compileOnly":         types.ScopeBuild,
+			"annotationProcessor": types.ScopeBuild,
+			"testImplementation":  types.ScopeDev,
+			"testRuntimeOnly":     types.ScopeDev,
+			"testCompileOnly":     types.ScopeDev,
+			"testApi":             types.ScopeDev,
+		},
+		fallback: types.ScopeProd,
+	},
+	DependencyTypeNpm: {
+		native: map[string]string{
+			"dependencies":         types.ScopeProd,
+			"devDependencies":      types.ScopeDev,
+			"peerDependencies":     types.ScopePeer,
+			"optionalDependencies": types.ScopeOptional,
+			"peerDependenciesMeta": types.ScopeOptional, // a peer marked "optional": true
+		},
+		fallback: types.ScopeProd,
+	},
+}
+
+// npmScope returns the normalized scope of a direct npm dependency from the
+// package.json sections listing it: a peer also optional (in
+// optionalDependencies or marked so in peerDependenciesMeta) is optional,
+// then peer, optional, dev and prod win in this order.
+func npmScope(peer, optional, dev bool) string {
+	section := "dependencies"
+	switch {
+	case peer && optional:
+		section = "peerDependenciesMeta"
+	case peer:
+		section = "peerDependencies"
+	case optional:
+		section = "optionalDependencies"
+	case dev:
+		section = "devDependencies"
+	}
+	return NormalizeScope(DependencyTypeNpm, section)
+}
+
+// NormalizeScope returns the normalized scope of a native scope of an
+// ecosystem. An empty native scope is the ecosystem's implicit one.
+func NormalizeScope(ecosystem, native string) string {
+	mapping, ok := scopeMappings[ecosystem]
+	if !ok {
+		return native
+	}
+	if native == "" {
+		native = mapping.implicit
+	}
+	if scope, ok := mapping.native[native]; ok {
+		return scope
+	}
+	return mapping.fallback
+}
+
+// recordNativeScope keeps the scope a manifest declared in a dependency's
+// metadata, unless it declared none.
+func recordNativeScope(metadata map[string]interface{}, native string) {
+	if native != "" {
+		metadata[MetadataNativeScope] = native
+	}
+}
+
+// NativeScope returns the scope a dependency's manifest declared, or "" when
+// its scope was set directly. A recorded native scope no longer normalizing
+// to the dependency's scope, such as the configuration of a Gradle platform()
+// import, is not reported.
+func NativeScope(dep types.Dependency) string {
+	mapping, ok := scopeMappings[dep.Type]
+	if !ok {
+		return ""
+	}
+	native, _ := dep.Metadata[MetadataNativeScope].(string)
+	if native == "" {
+		native, _ = dep.Metadata["configuration"].(string)
+	}
+	if native == "" {
+		native = mapping.implicit
+	}
+	if native == "" || NormalizeScope(dep.Type, native) != dep.Scope {
+		return ""
+	}
+	return native
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestScopeJSONMarshaling(t *testing.T) {
@@ -338,3 +339,43 @@ class MyProject(ConanFile):
 		}
 	}
 }
+
+func TestNormalizeScope(t *testing.T) {
+	tests := []struct {
+		ecosystem, native, want string
+	}{
+		{DependencyTypeMaven, "", types.ScopeProd},
+		{DependencyTypeMaven, "provided", types.ScopeProd},
+		{DependencyTypeMaven, "test", types.ScopeDev},
+		{DependencyTypeMaven, "import", types.ScopeImport},
+		{DependencyTypeMaven, "unheard-of", types.ScopeProd},
+		{DependencyTypeGradle, "compileOnly", types.ScopeBuild},
+		{DependencyTypeGradle, "testRuntimeOnly", types.ScopeDev},
+		{DependencyTypeGradle, "kapt", types.ScopeProd},
+		{DependencyTypeNpm, "devDependencies", types.ScopeDev},
+		{DependencyTypeNpm, "optionalDependencies", types.ScopeOptional},
+		{DependencyTypeNpm, "peerDependenciesMeta", types.ScopeOptional},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeScope(tt.ecosystem, tt.native), "%s %q", tt.ecosystem, tt.native)
+	}
+}
+
+func TestNativeScope(t *testing.T) {
+	provided := types.Dependency{Type: DependencyTypeMaven, Scope: types.ScopeProd, Metadata: map[string]interface{}{MetadataNativeScope: "provided"}}
+	assert.Equal(t, "provided", NativeScope(provided))
+
+	undeclared := types.Dependency{Type: DependencyTypeMaven, Scope: types.ScopeProd}
+	assert.Equal(t, "compile", NativeScope(undeclared), "Maven's implicit scope")
+
+	plugin := types.Dependency{Type: DependencyTypeMaven, Scope: types.ScopeBuild}
+	assert.Empty(t, NativeScope(plugin), "a scope set directly has no native scope")
+
+	platform := types.Dependency{Type: DependencyTypeGradle, Scope: types.ScopeImport, Metadata: map[string]interface{}{"configuration": "implementation"}}
+	assert.Empty(t, NativeScope(platform), "the configuration of a platform() import is not its scope")
+
+	compileOnly := types.Dependency{Type: DependencyTypeGradle, Scope: types.ScopeBuild, Metadata: map[string]interface{}{"configuration": "compileOnly"}}
+	assert.Equal(t, "compileOnly", NativeScope(compileOnly))
+
+	assert.Empty(t, NativeScope(types.Dependency{Type: DependencyTypeNpm, Scope: types.ScopeDev}))
+}
@@ -0,0 +1,141 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SQL file kinds, by the statements a file contains: stored code (procedures,
+// functions, packages, triggers) wins over DDL, DDL over DML.
+const (
+	SQLKindProcedural = "procedural"
+	SQLKindDDL        = "ddl"
+	SQLKindDML        = "dml"
+)
+
+// DefaultSQLSchema is the schema reported for objects created without a
+// schema qualifier.
+const DefaultSQLSchema = "(default)"
+
+var (
+	// sqlCreateRegex matches CREATE statements and captures the object type
+	// and the (possibly schema-qualified, quoted) object name.
+	sqlCreateRegex = regexp.MustCompile(`(?im)^\s*CREATE\s+(?:OR\s+(?:REPLACE|ALTER)\s+)?(?:(?:NO)?FORCE\s+|EDITIONABLE\s+|NONEDITIONABLE\s+|UNIQUE\s+|CLUSTERED\s+|NONCLUSTERED\s+|TEMP(?:ORARY)?\s+|GLOBAL\s+TEMPORARY\s+|DEFINER\s*=\s*\S+\s+)*` +
+		`(TABLE|VIEW|MATERIALIZED\s+VIEW|INDEX|SEQUENCE|PROCEDURE|PROC|FUNCTION|PACKAGE\s+BODY|PACKAGE|TRIGGER|TYPE\s+BODY|TYPE|SCHEMA)\s+` +
+		`(?:IF\s+NOT\s+EXISTS\s+)?((?:[\w$#]+|"[^"]+"|\[[^\]]+\]|` + "`[^`]+`" + `)(?:\s*\.\s*(?:[\w$#]+|"[^"]+"|\[[^\]]+\]|` + "`[^`]+`" + `))*)`)
+	sqlDDLRegex = regexp.MustCompile(`(?im)^\s*(CREATE|ALTER|DROP|TRUNCATE|COMMENT\s+ON|GRANT|REVOKE)\b`)
+	sqlDMLRegex = regexp.MustCompile(`(?im)^\s*(INSERT\s+INTO|UPDATE\s+\S+\s+SET|DELETE\s+FROM|MERGE\s+INTO|SELECT|WITH)\b`)
+)
+
+// sqlDialectMarkers are heuristics for the SQL dialect of a file; the
+// dialect with the most matching markers wins.
+var sqlDialectMarkers = map[string][]*regexp.Regexp{
+	"plsql": {
+		regexp.MustCompile(`(?i)\bVARCHAR2\b|\bNUMBER\s*\(|%(ROW)?TYPE\b`),
+		regexp.MustCompile(`(?i)\bCREATE\s+(OR\s+REPLACE\s+)?PACKAGE\b`),
+		regexp.MustCompile(`(?i)\bDBMS_\w+\.|\bEXCEPTION\s+WHEN\b|\bNVL\s*\(`),
+		regexp.MustCompile(`(?m)^/\s*$`),
+	},
+	"tsql": {
+		regexp.MustCompile(`(?im)^\s*GO\s*$`),
+		regexp.MustCompile(`(?i)\bNVARCHAR\b|\bIDENTITY\s*\(|\[dbo\]|\bdbo\.`),
+		regexp.MustCompile(`(?i)\bCREATE\s+(OR\s+ALTER\s+)?PROC(EDURE)?\s+\S+\s*(@|\n\s*@)|\bDECLARE\s+@\w+`),
+		regexp.MustCompile(`(?i)\bSET\s+NOCOUNT\s+ON\b|\bBEGIN\s+TRAN(SACTION)?\b`),
+	},
+	"plpgsql": {
+		regexp.MustCompile(`(?i)\bLANGUAGE\s+'?plpgsql'?`),
+		regexp.MustCompile(`\$\w*\$`),
+		regexp.MustCompile(`(?i)\bRETURNS\s+(TRIGGER|SETOF|TABLE)\b|\bSERIAL\b|::\w+`),
+	},
+	"mysql": {
+		regexp.MustCompile(`(?im)^\s*DELIMITER\s+\S+`),
+		regexp.MustCompile(`(?i)\bENGINE\s*=\s*\w+|\bAUTO_INCREMENT\b`),
+		regexp.MustCompile("`\\w+`"),
+	},
+}
+
+// SQLObject is an object created by a SQL file.
+type SQLObject struct {
+	Schema string
+	Type   string // table, view, materialized_view, index, sequence, procedure, function, package, package_body, trigger, type, type_body, schema
+	Name   string
+}
+
+// SQLFile is the classification of one SQL file.
+type SQLFile struct {
+	Kind    string // procedural, ddl, dml or "" (no recognized statements)
+	Dialect string // plsql, tsql, plpgsql, mysql or "" (generic SQL)
+	Objects []SQLObject
+}
+
+// SQLCodeParser classifies SQL scripts and lists the objects they create.
+type SQLCodeParser struct{}
+
+// NewSQLCodeParser creates a new SQL code parser.
+func NewSQLCodeParser() *SQLCodeParser {
+	return &SQLCodeParser{}
+}
+
+// Classify returns the kind, dialect and created objects of a SQL file.
+func (p *SQLCodeParser) Classify(content string) *SQLFile {
+	file := &SQLFile{Dialect: sqlDialect(content)}
+	for _, m := range sqlCreateRegex.FindAllStringSubmatch(content, -1) {
+		file.Objects = append(file.Objects, sqlObject(m[1], m[2]))
+	}
+	switch {
+	case hasProceduralObject(file.Objects):
+		file.Kind = SQLKindProcedural
+	case sqlDDLRegex.MatchString(content):
+		file.Kind = SQLKindDDL
+	case sqlDMLRegex.MatchString(content):
+		file.Kind = SQLKindDML
+	}
+	return file
+}
+
+// sqlObject normalizes the object type and splits the schema off the name.
+// For three-part names (database.schema.object) the schema is the middle
+// part.
+func sqlObject(objectType, qualifiedName string) SQLObject {
+	objectType = strings.ToLower(strings.Join(strings.Fields(objectType), "_"))
+	if objectType == "proc" {
+		objectType = "procedure"
+	}
+	parts := strings.Split(qualifiedName, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), "\"[]`")
+	}
+	object := SQLObject{Schema: DefaultSQLSchema, Type: objectType, Name: parts[len(parts)-1]}
+	if len(parts) > 1 && objectType != "schema" {
+		object.Schema = parts[len(parts)-2]
+	}
+	return object
+}
+
+func hasProceduralObject(objects []SQLObject) bool {
+	for _, object := range objects {
+		switch object.Type {
+		case "procedure", "function", "package", "package_body", "trigger", "type_body":
+			return true
+		}
+	}
+	return false
+}
+
+// sqlDialect returns the dialect with the most matching markers, or "" when
+// none matches.
+func sqlDialect(content string) string {
+	best, bestScore := "", 0
+	for _, dialect := range []string{"plsql", "tsql", "plpgsql", "mysql"} {
+		score := 0
+		for _, marker := range sqlDialectMarkers[dialect] {
+			if marker.MatchString(content) {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = dialect, score
+		}
+	}
+	return best
+}
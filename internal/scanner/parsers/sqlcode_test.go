@@ -0,0 +1,92 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLCodeParser_Classify(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kind    string
+		dialect string
+		objects []SQLObject
+	}{
+		{
+			name: "plsql package",
+			content: `CREATE OR REPLACE PACKAGE BODY billing.invoice_pkg AS
+  PROCEDURE post(p_id IN invoices.id%TYPE) IS
+    v_total NUMBER(12,2);
+  BEGIN
+    NULL;
+  EXCEPTION WHEN OTHERS THEN
+    DBMS_OUTPUT.PUT_LINE(SQLERRM);
+  END post;
+END invoice_pkg;
+/`,
+			kind:    SQLKindProcedural,
+			dialect: "plsql",
+			objects: []SQLObject{{Schema: "billing", Type: "package_body", Name: "invoice_pkg"}},
+		},
+		{
+			name: "tsql procedure",
+			content: `CREATE OR ALTER PROCEDURE [dbo].[usp_GetOrders] @CustomerId INT
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SELECT * FROM dbo.Orders WHERE CustomerId = @CustomerId;
+END
+GO`,
+			kind:    SQLKindProcedural,
+			dialect: "tsql",
+			objects: []SQLObject{{Schema: "dbo", Type: "procedure", Name: "usp_GetOrders"}},
+		},
+		{
+			name: "postgres ddl and function",
+			content: `CREATE TABLE IF NOT EXISTS app.users (id SERIAL PRIMARY KEY, email text);
+CREATE UNIQUE INDEX users_email_idx ON app.users (email);
+CREATE FUNCTION app.touch() RETURNS trigger AS $$
+BEGIN NEW.updated_at = now(); RETURN NEW; END;
+$$ LANGUAGE plpgsql;`,
+			kind:    SQLKindProcedural,
+			dialect: "plpgsql",
+			objects: []SQLObject{
+				{Schema: "app", Type: "table", Name: "users"},
+				{Schema: DefaultSQLSchema, Type: "index", Name: "users_email_idx"},
+				{Schema: "app", Type: "function", Name: "touch"},
+			},
+		},
+		{
+			name:    "mysql ddl",
+			content: "CREATE TABLE `orders` (`id` INT AUTO_INCREMENT PRIMARY KEY) ENGINE=InnoDB;\nCREATE VIEW shop.open_orders AS SELECT * FROM orders;",
+			kind:    SQLKindDDL,
+			dialect: "mysql",
+			objects: []SQLObject{
+				{Schema: DefaultSQLSchema, Type: "table", Name: "orders"},
+				{Schema: "shop", Type: "view", Name: "open_orders"},
+			},
+		},
+		{
+			name:    "seed data",
+			content: "INSERT INTO countries (code, name) VALUES ('DE', 'Germany');\nUPDATE settings SET value = '1' WHERE key = 'seeded';",
+			kind:    SQLKindDML,
+		},
+		{
+			name:    "comments only",
+			content: "-- nothing to see here\n",
+			kind:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := NewSQLCodeParser().Classify(tt.content)
+			assert.Equal(t, tt.kind, file.Kind)
+			if tt.dialect != "" {
+				assert.Equal(t, tt.dialect, file.Dialect)
+			}
+			assert.Equal(t, tt.objects, file.Objects)
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Task runners reported in TaskRunnerFile.Runner.
+const (
+	TaskRunnerMake = "make"
+	TaskRunnerTask = "task"
+	TaskRunnerJust = "just"
+)
+
+var (
+	// commandSeparatorRegex splits a shell line into simple commands.
+	commandSeparatorRegex = regexp.MustCompile(`&&|\|\||[;|]`)
+	// commandNameRegex accepts plain executable names; anything with
+	// variables, interpolation or quoting is skipped.
+	commandNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+	// envAssignmentRegex matches a leading VAR=value prefix.
+	envAssignmentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	// makeConditionalRegex matches conditional directives, which may appear
+	// inside a recipe without ending it.
+	makeConditionalRegex = regexp.MustCompile(`^(ifeq|ifneq|ifdef|ifndef|else|endif)\b`)
+	// justRecipeRegex matches a justfile recipe header: name, parameters, colon.
+	justRecipeRegex = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)[^:]*:([^=]|$)`)
+)
+
+// shellWrappers run the following word as the actual command.
+var shellWrappers = map[string]bool{
+	"sudo": true, "exec": true, "env": true, "time": true, "nohup": true, "command": true, "cross-env": true,
+}
+
+// toolRunners run a package tool named by the following word (npx eslint,
+// pnpm dlx prettier, poetry run pytest). Both the runner and the tool count.
+var toolRunners = map[string]map[string]bool{
+	"npx":    {"": true},
+	"bunx":   {"": true},
+	"npm":    {"exec": true},
+	"pnpm":   {"dlx": true, "exec": true},
+	"yarn":   {"dlx": true, "exec": true},
+	"uv":     {"run": true},
+	"poetry": {"run": true},
+	"bundle": {"exec": true},
+}
+
+// TaskRunnerFile describes a Makefile, Taskfile or justfile: its top-level
+// targets and the commands their recipes invoke.
+type TaskRunnerFile struct {
+	File        string           `json:"file"`
+	Runner      string           `json:"runner"`
+	Targets     []string         `json:"targets"`
+	Invocations []TaskInvocation `json:"-"`
+}
+
+// TaskInvocation is a command invoked by a target's recipe.
+type TaskInvocation struct {
+	Target  string
+	Command string
+}
+
+// TaskRunnerParser handles Makefiles, Taskfile.yml and justfiles.
+type TaskRunnerParser struct{}
+
+// NewTaskRunnerParser creates a new task runner parser
+func NewTaskRunnerParser() *TaskRunnerParser {
+	return &TaskRunnerParser{}
+}
+
+// ParseMakefile lists the explicit targets of a Makefile (special targets
+// such as .PHONY and pattern rules are skipped) and the commands their
+// recipes invoke.
+func (p *TaskRunnerParser) ParseMakefile(content string) *TaskRunnerFile {
+	tf := &TaskRunnerFile{Runner: TaskRunnerMake}
+	var current []string
+	inDefine := false
+	for _, line := range joinContinuations(content) {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inDefine:
+			inDefine = trimmed != "endef"
+		case strings.HasPrefix(trimmed, "define "):
+			inDefine, current = true, nil
+		case strings.HasPrefix(line, "\t"):
+			tf.addRecipeLine(current, trimmed)
+		case trimmed == "" || strings.HasPrefix(trimmed, "#") || makeConditionalRegex.MatchString(trimmed):
+		default:
+			current = tf.addMakeRule(trimmed)
+		}
+	}
+	return tf
+}
+
+// addMakeRule records the targets of a rule line and any inline recipe
+// ("target: deps ; command"). Returns the targets the following recipe lines
+// belong to, or nil for non-rule lines (assignments, directives).
+func (tf *TaskRunnerFile) addMakeRule(line string) []string {
+	idx := strings.Index(line, ":")
+	if idx <= 0 || strings.Contains(line[:idx], "=") {
+		return nil
+	}
+	rest := strings.TrimLeft(line[idx:], ":")
+	if strings.HasPrefix(rest, "=") {
+		return nil
+	}
+
+	var targets []string
+	for _, name := range strings.Fields(line[:idx]) {
+		if strings.HasPrefix(name, ".") || strings.ContainsAny(name, "%$()") {
+			continue
+		}
+		targets = append(targets, name)
+		tf.addTarget(name)
+	}
+	if _, inline, ok := strings.Cut(rest, ";"); ok {
+		tf.addRecipeLine(targets, strings.TrimSpace(inline))
+	}
+	return targets
+}
+
+// addRecipeLine records the commands of one recipe line for each target.
+// Make's echo/ignore/always-run prefixes (@, -, +) are stripped.
+func (tf *TaskRunnerFile) addRecipeLine(targets []string, line string) {
+	line = strings.TrimLeft(line, "@-+ ")
+	if strings.HasPrefix(line, "#") {
+		return
+	}
+	for _, command := range CommandNames(line) {
+		for _, target := range targets {
+			tf.Invocations = append(tf.Invocations, TaskInvocation{Target: target, Command: command})
+		}
+	}
+}
+
+func (tf *TaskRunnerFile) addTarget(name string) {
+	for _, existing := range tf.Targets {
+		if existing == name {
+			return
+		}
+	}
+	tf.Targets = append(tf.Targets, name)
+}
+
+// joinContinuations joins backslash-continued lines.
+func joinContinuations(content string) []string {
+	var lines []string
+	var pending strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.HasSuffix(line, "\\") {
+			pending.WriteString(strings.TrimSuffix(line, "\\"))
+			pending.WriteString(" ")
+			continue
+		}
+		pending.WriteString(line)
+		lines = append(lines, pending.String())
+		pending.Reset()
+	}
+	return lines
+}
+
+// ParseTaskfile lists the tasks of a Taskfile.yml (go-task) and the commands
+// in their cmd/cmds entries. Tasks are reported in name order.
+func (p *TaskRunnerParser) ParseTaskfile(content string) (*TaskRunnerFile, error) {
+	var cfg struct {
+		Tasks map[string]interface{} `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &cfg); err != nil {
+		return nil, types.NewParseError("Taskfile", err)
+	}
+
+	tf := &TaskRunnerFile{Runner: TaskRunnerTask}
+	for name := range cfg.Tasks {
+		tf.Targets = append(tf.Targets, name)
+	}
+	sort.Strings(tf.Targets)
+	for _, name := range tf.Targets {
+		for _, line := range taskCommands(cfg.Tasks[name]) {
+			tf.addRecipeLine([]string{name}, line)
+		}
+	}
+	return tf, nil
+}
+
+// taskCommands collects the shell lines of a task, which may be a single
+// command, a list of commands, or a mapping with cmd/cmds. Entries of cmds
+// may be strings or {cmd: ...} mappings; {task: ...} calls are skipped.
+func taskCommands(task interface{}) []string {
+	switch v := task.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var lines []string
+		for _, item := range v {
+			lines = append(lines, taskCommands(item)...)
+		}
+		return lines
+	case map[string]interface{}:
+		lines := taskCommands(v["cmd"])
+		return append(lines, taskCommands(v["cmds"])...)
+	}
+	return nil
+}
+
+// ParseJustfile lists the recipes of a justfile and the commands in their
+// bodies. Settings, aliases, assignments and attributes are skipped, as are
+// the bodies of shebang recipes (written in another language).
+func (p *TaskRunnerParser) ParseJustfile(content string) *TaskRunnerFile {
+	tf := &TaskRunnerFile{Runner: TaskRunnerJust}
+	var current []string
+	for _, line := range joinContinuations(content) {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+		case line[0] == ' ' || line[0] == '\t':
+			if strings.HasPrefix(trimmed, "#!") {
+				current = nil
+			}
+			tf.addRecipeLine(current, trimmed)
+		default:
+			current = tf.addJustRecipe(trimmed)
+		}
+	}
+	return tf
+}
+
+func (tf *TaskRunnerFile) addJustRecipe(line string) []string {
+	m := justRecipeRegex.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	switch m[1] {
+	case "set", "alias", "export", "import", "mod":
+		return nil
+	}
+	tf.addTarget(m[1])
+	return []string{m[1]}
+}
+
+// CommandNames returns the executables invoked by a shell line: the first
+// word of each simple command (after VAR=value prefixes and wrappers such as
+// sudo or env), plus the tool run through npx, pnpm dlx, poetry run and
+// similar runners. Paths are reduced to their base name
+// (./node_modules/.bin/eslint -> eslint); words containing variables or
+// quotes are skipped.
+func CommandNames(line string) []string {
+	var names []string
+	for _, segment := range commandSeparatorRegex.Split(line, -1) {
+		names = append(names, segmentCommands(strings.Fields(segment))...)
+	}
+	return names
+}
+
+func segmentCommands(words []string) []string {
+	for len(words) > 0 && (envAssignmentRegex.MatchString(words[0]) || shellWrappers[words[0]]) {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	name := commandName(words[0])
+	if name == "" {
+		return nil
+	}
+	names := []string{name}
+	if tool := runnerTool(name, words[1:]); tool != "" {
+		names = append(names, tool)
+	}
+	return names
+}
+
+// runnerTool returns the tool run by a package runner invocation, or "".
+func runnerTool(runner string, args []string) string {
+	subcommands, ok := toolRunners[runner]
+	if !ok {
+		return ""
+	}
+	if !subcommands[""] {
+		if len(args) == 0 || !subcommands[args[0]] {
+			return ""
+		}
+		args = args[1:]
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return commandName(arg)
+		}
+	}
+	return ""
+}
+
+func commandName(word string) string {
+	name := path.Base(word)
+	if !commandNameRegex.MatchString(name) {
+		return ""
+	}
+	return name
+}
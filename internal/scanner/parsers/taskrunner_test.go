@@ -0,0 +1,144 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commandsByTarget(tf *TaskRunnerFile) map[string][]string {
+	out := make(map[string][]string)
+	for _, inv := range tf.Invocations {
+		out[inv.Target] = append(out[inv.Target], inv.Command)
+	}
+	return out
+}
+
+func TestParseMakefile(t *testing.T) {
+	content := `IMAGE ?= myorg/myapp
+VERSION := $(shell git describe)
+export GOFLAGS := -mod=mod
+
+.PHONY: build test deploy
+
+build:
+	@go build -o bin/app ./cmd/app
+	docker build -t $(IMAGE):$(VERSION) .
+
+test: build ## run tests
+	-go test ./... && npx eslint src
+ifeq ($(CI),true)
+	CGO_ENABLED=0 sudo kubectl apply -f deploy/ \
+		--wait
+endif
+
+deploy: test
+	helm upgrade --install myapp charts/myapp | tee deploy.log
+	$(MAKE) -C docs publish
+
+%.o: %.c
+	$(CC) -c $<
+
+clean: ; rm -rf bin
+
+define HELP
+build: not a target
+endef
+`
+	tf := NewTaskRunnerParser().ParseMakefile(content)
+	assert.Equal(t, TaskRunnerMake, tf.Runner)
+	assert.Equal(t, []string{"build", "test", "deploy", "clean"}, tf.Targets)
+
+	cmds := commandsByTarget(tf)
+	assert.Equal(t, []string{"go", "docker"}, cmds["build"])
+	assert.Equal(t, []string{"go", "npx", "eslint", "kubectl"}, cmds["test"])
+	assert.Equal(t, []string{"helm", "tee"}, cmds["deploy"])
+	assert.Equal(t, []string{"rm"}, cmds["clean"])
+}
+
+func TestParseTaskfile(t *testing.T) {
+	content := `version: '3'
+tasks:
+  default:
+    cmds:
+      - task: build
+  build:
+    desc: Build the app
+    cmds:
+      - go build ./...
+      - cmd: docker compose build
+  lint: golangci-lint run
+  fmt:
+    - gofmt -w .
+  tf:
+    cmd: terraform -chdir=infra plan {{.CLI_ARGS}}
+`
+	tf, err := NewTaskRunnerParser().ParseTaskfile(content)
+	require.NoError(t, err)
+	assert.Equal(t, TaskRunnerTask, tf.Runner)
+	assert.Equal(t, []string{"build", "default", "fmt", "lint", "tf"}, tf.Targets)
+
+	cmds := commandsByTarget(tf)
+	assert.Equal(t, []string{"go", "docker"}, cmds["build"])
+	assert.Equal(t, []string{"golangci-lint"}, cmds["lint"])
+	assert.Equal(t, []string{"gofmt"}, cmds["fmt"])
+	assert.Equal(t, []string{"terraform"}, cmds["tf"])
+	assert.Empty(t, cmds["default"])
+
+	_, err = NewTaskRunnerParser().ParseTaskfile("tasks: [unclosed")
+	assert.Error(t, err)
+}
+
+func TestParseJustfile(t *testing.T) {
+	content := `set dotenv-load
+alias b := build
+version := "1.0.0"
+
+# Build everything
+build target="debug": lint
+    cargo build --profile {{target}}
+
+[private]
+lint:
+    cargo clippy
+    @pnpm dlx prettier --check .
+
+script:
+    #!/usr/bin/env python3
+    import docker
+    print("hi")
+
+export RUST_LOG := "info"
+`
+	tf := NewTaskRunnerParser().ParseJustfile(content)
+	assert.Equal(t, TaskRunnerJust, tf.Runner)
+	assert.Equal(t, []string{"build", "lint", "script"}, tf.Targets)
+
+	cmds := commandsByTarget(tf)
+	assert.Equal(t, []string{"cargo"}, cmds["build"])
+	assert.Equal(t, []string{"cargo", "pnpm", "prettier"}, cmds["lint"])
+	assert.Empty(t, cmds["script"])
+}
+
+func TestCommandNames(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"docker compose up -d", []string{"docker"}},
+		{"NODE_ENV=production ./node_modules/.bin/webpack --mode production", []string{"webpack"}},
+		{"env FOO=1 time terraform apply; echo done", []string{"terraform", "echo"}},
+		{"npx --yes playwright test || exit 1", []string{"npx", "playwright", "exit"}},
+		{"poetry run pytest -q | tee out.txt", []string{"poetry", "pytest", "tee"}},
+		{"npm run build", []string{"npm"}},
+		{"cross-env NODE_ENV=production webpack", []string{"webpack"}},
+		{"$(DOCKER) build .", nil},
+		{"\"quoted\" arg", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CommandNames(tt.line))
+		})
+	}
+}
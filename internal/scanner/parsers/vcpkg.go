@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"encoding/json"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// VcpkgManifest is the vcpkg.json manifest of a project using vcpkg in
+// manifest mode.
+type VcpkgManifest struct {
+	Name          string            `json:"name"`
+	Version       string            `json:"version"`
+	VersionSemver string            `json:"version-semver"`
+	VersionDate   string            `json:"version-date"`
+	VersionString string            `json:"version-string"`
+	Dependencies  []VcpkgDependency `json:"dependencies"`
+	Overrides     []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"overrides"`
+}
+
+// VcpkgDependency is an entry of the dependencies of vcpkg.json, written
+// either as a port name or as an object.
+type VcpkgDependency struct {
+	Name       string `json:"name"`
+	MinVersion string `json:"version>="`
+	Host       bool   `json:"host"`
+}
+
+// UnmarshalJSON accepts the "name" shorthand of a dependency.
+func (d *VcpkgDependency) UnmarshalJSON(data []byte) error {
+	var name string
+	if json.Unmarshal(data, &name) == nil {
+		*d = VcpkgDependency{Name: name}
+		return nil
+	}
+	type plain VcpkgDependency
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// ProjectVersion returns the version of the manifest, whichever of the
+// version fields holds it.
+func (m VcpkgManifest) ProjectVersion() string {
+	for _, version := range []string{m.Version, m.VersionSemver, m.VersionDate, m.VersionString} {
+		if version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+// ParseVcpkgJSON parses a vcpkg.json manifest and its dependencies. The
+// version of a dependency is the one an override pins, or else its minimum
+// version as ">=x". Host dependencies are tools used during the build and
+// get the build scope.
+func ParseVcpkgJSON(content []byte) (VcpkgManifest, []types.Dependency, error) {
+	var manifest VcpkgManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return VcpkgManifest{}, nil, types.NewParseError("vcpkg.json", err)
+	}
+	pinned := make(map[string]string, len(manifest.Overrides))
+	for _, override := range manifest.Overrides {
+		pinned[override.Name] = override.Version
+	}
+	var deps []types.Dependency
+	for _, d := range manifest.Dependencies {
+		if d.Name == "" {
+			continue
+		}
+		version := pinned[d.Name]
+		if version == "" && d.MinVersion != "" {
+			version = ">=" + d.MinVersion
+		}
+		scope := types.ScopeProd
+		if d.Host {
+			scope = types.ScopeBuild
+		}
+		deps = append(deps, types.Dependency{
+			Type:     DependencyTypeVcpkg,
+			Name:     d.Name,
+			Version:  version,
+			Scope:    scope,
+			Direct:   true,
+			Metadata: types.NewMetadata(MetadataSourceVcpkgJSON),
+		})
+	}
+	return manifest, deps, nil
+}
@@ -0,0 +1,37 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVcpkgJSON(t *testing.T) {
+	manifest, deps, err := ParseVcpkgJSON([]byte(`{
+  "name": "myapp",
+  "version-semver": "1.2.0",
+  "dependencies": [
+    "fmt",
+    { "name": "openssl", "version>=": "3.0.8" },
+    { "name": "zlib", "version>=": "1.2.13" },
+    { "name": "vcpkg-cmake", "host": true }
+  ],
+  "overrides": [ { "name": "zlib", "version": "1.3" } ]
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, "myapp", manifest.Name)
+	assert.Equal(t, "1.2.0", manifest.ProjectVersion())
+	require.Len(t, deps, 4)
+	assert.Equal(t, types.Dependency{
+		Type: DependencyTypeVcpkg, Name: "fmt", Scope: types.ScopeProd, Direct: true,
+		Metadata: types.NewMetadata(MetadataSourceVcpkgJSON),
+	}, deps[0])
+	assert.Equal(t, ">=3.0.8", deps[1].Version)
+	assert.Equal(t, "1.3", deps[2].Version, "an override pins the version")
+	assert.Equal(t, types.ScopeBuild, deps[3].Scope)
+
+	_, _, err = ParseVcpkgJSON([]byte(`{"dependencies": [`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package parsers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of a data warehouse connection.
+const (
+	WarehouseSourceDbt        = "dbt"
+	WarehouseSourceAirflow    = "airflow"
+	WarehouseSourceSQLAlchemy = "sqlalchemy"
+)
+
+// warehouseTechs maps dbt adapter types, Airflow connection types and
+// SQLAlchemy dialects (the part of the URL scheme before "+") to the tech of
+// the warehouse they connect to.
+var warehouseTechs = map[string]string{
+	"snowflake":      "snowflake",
+	"bigquery":       "gcp.bigquery",
+	"gcpbigquery":    "gcp.bigquery",
+	"redshift":       "aws.redshift",
+	"databricks":     "databricks",
+	"databricks_sql": "databricks",
+}
+
+var (
+	// airflowConnEnvRegex matches an AIRFLOW_CONN_<ID> setting in a dotenv
+	// file, Compose environment or shell script; the value is a URI or JSON.
+	airflowConnEnvRegex = regexp.MustCompile(`\bAIRFLOW_CONN_([A-Za-z0-9_]+)["']?\s*[:=]\s*(.*)`)
+	airflowConnTypeJSON = regexp.MustCompile(`"conn_type"\s*:\s*"([\w-]+)"`)
+	uriSchemeRegex      = regexp.MustCompile(`^["']?([a-zA-Z][\w-]*)(?:\+[\w-]+)?://`)
+	// sqlalchemyURLRegex matches the dialect of a SQLAlchemy URL. URLs behind
+	// a "jdbc:" prefix are JDBC URLs and not matched.
+	sqlalchemyURLRegex = regexp.MustCompile(`(?:^|[^:\w])(snowflake|bigquery|redshift|databricks)(?:\+\w+)?://`)
+)
+
+// WarehouseConnection is a connection to a data warehouse declared in a dbt
+// profile, an Airflow connection or a SQLAlchemy URL.
+type WarehouseConnection struct {
+	Tech   string // snowflake, gcp.bigquery, aws.redshift, databricks
+	Source string // dbt, airflow, sqlalchemy
+	Name   string // dbt profile.target or Airflow connection id
+}
+
+// WarehouseParser finds data warehouse connections in configuration files.
+type WarehouseParser struct{}
+
+// NewWarehouseParser creates a new data warehouse connection parser.
+func NewWarehouseParser() *WarehouseParser {
+	return &WarehouseParser{}
+}
+
+// ParseDbtProfiles returns the warehouse targets of a dbt profiles.yml,
+// named profile.target. Targets of other adapters are skipped.
+func (p *WarehouseParser) ParseDbtProfiles(content []byte) []WarehouseConnection {
+	var profiles map[string]struct {
+		Outputs map[string]struct {
+			Type string `yaml:"type"`
+		} `yaml:"outputs"`
+	}
+	if yaml.Unmarshal(content, &profiles) != nil {
+		return nil
+	}
+	var found []WarehouseConnection
+	for profile, config := range profiles {
+		for target, output := range config.Outputs {
+			if tech := warehouseTechs[strings.ToLower(output.Type)]; tech != "" {
+				found = append(found, WarehouseConnection{Tech: tech, Source: WarehouseSourceDbt, Name: profile + "." + target})
+			}
+		}
+	}
+	sortConnections(found)
+	return found
+}
+
+// ParseAirflowSettings returns the warehouse connections of an Astro CLI
+// airflow_settings.yaml (airflow.connections entries).
+func (p *WarehouseParser) ParseAirflowSettings(content []byte) []WarehouseConnection {
+	var settings struct {
+		Airflow struct {
+			Connections []struct {
+				ID   string `yaml:"conn_id"`
+				Type string `yaml:"conn_type"`
+			} `yaml:"connections"`
+		} `yaml:"airflow"`
+	}
+	if yaml.Unmarshal(content, &settings) != nil {
+		return nil
+	}
+	var found []WarehouseConnection
+	for _, conn := range settings.Airflow.Connections {
+		if tech := airflowConnTech(conn.Type); tech != "" {
+			found = append(found, WarehouseConnection{Tech: tech, Source: WarehouseSourceAirflow, Name: conn.ID})
+		}
+	}
+	return found
+}
+
+// FindConnections returns the warehouse connections of a source or
+// configuration file: AIRFLOW_CONN_<ID> settings (URI or JSON values) and
+// SQLAlchemy URLs. Lines defining an Airflow connection are not also read
+// as SQLAlchemy URLs.
+func (p *WarehouseParser) FindConnections(content string) []WarehouseConnection {
+	var found []WarehouseConnection
+	seen := make(map[WarehouseConnection]bool)
+	for _, line := range strings.Split(content, "\n") {
+		for _, conn := range lineConnections(line) {
+			if !seen[conn] {
+				seen[conn] = true
+				found = append(found, conn)
+			}
+		}
+	}
+	return found
+}
+
+func lineConnections(line string) []WarehouseConnection {
+	if m := airflowConnEnvRegex.FindStringSubmatch(line); m != nil {
+		value := strings.TrimSpace(m[2])
+		connType := firstSubmatch(airflowConnTypeJSON, value)
+		if connType == "" {
+			connType = firstSubmatch(uriSchemeRegex, value)
+		}
+		if tech := airflowConnTech(connType); tech != "" {
+			return []WarehouseConnection{{Tech: tech, Source: WarehouseSourceAirflow, Name: strings.ToLower(m[1])}}
+		}
+		return nil
+	}
+	var found []WarehouseConnection
+	for _, m := range sqlalchemyURLRegex.FindAllStringSubmatch(line, -1) {
+		found = append(found, WarehouseConnection{Tech: warehouseTechs[m[1]], Source: WarehouseSourceSQLAlchemy})
+	}
+	return found
+}
+
+// airflowConnTech returns the warehouse tech of an Airflow connection type;
+// URI schemes spell the type with dashes (databricks-sql).
+func airflowConnTech(connType string) string {
+	return warehouseTechs[strings.ReplaceAll(strings.ToLower(connType), "-", "_")]
+}
+
+func sortConnections(conns []WarehouseConnection) {
+	sort.Slice(conns, func(i, j int) bool {
+		if conns[i].Name != conns[j].Name {
+			return conns[i].Name < conns[j].Name
+		}
+		return conns[i].Tech < conns[j].Tech
+	})
+}
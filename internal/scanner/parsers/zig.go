@@ -0,0 +1,134 @@
+package parsers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+var (
+	// zonNameRegex matches the package name: .name = "app" (up to 0.13) or
+	// .name = .app (enum literal, 0.14+).
+	zonNameRegex           = regexp.MustCompile(`\.name\s*=\s*(?:"([^"]*)"|\.@?"?([\w-]+)"?)`)
+	zonVersionRegex        = regexp.MustCompile(`\.version\s*=\s*"([^"]*)"`)
+	zonMinZigVersionRegex  = regexp.MustCompile(`\.minimum_zig_version\s*=\s*"([^"]*)"`)
+	zonDependenciesRegex   = regexp.MustCompile(`\.dependencies\s*=\s*\.\{`)
+	zonDependencyRegex     = regexp.MustCompile(`\.(@"[^"]+"|[A-Za-z_][\w]*)\s*=\s*\.\{([^{}]*)\}`)
+	zonStringFieldRegex    = regexp.MustCompile(`\.(url|hash|path)\s*=\s*"([^"]*)"`)
+	zonLazyRegex           = regexp.MustCompile(`\.lazy\s*=\s*true`)
+	zigArchiveVersionRegex = regexp.MustCompile(`[/-]v?(\d+\.\d+\.\d+(?:-[\w.]+)?)\.(?:tar\.gz|tar\.xz|tgz|zip)$`)
+)
+
+// ZigPackage holds the fields of a build.zig.zon package manifest.
+type ZigPackage struct {
+	Name              string
+	Version           string
+	MinimumZigVersion string
+	Dependencies      []types.Dependency
+}
+
+// ZigParser parses build.zig.zon package manifests (Zig object notation).
+type ZigParser struct{}
+
+// NewZigParser creates a new build.zig.zon parser.
+func NewZigParser() *ZigParser {
+	return &ZigParser{}
+}
+
+// ParseBuildZigZon parses build.zig.zon. Each entry of .dependencies is a
+// direct dependency: remote ones carry their url (without credentials) and
+// content hash in metadata, local ones their path. The version is taken from
+// a release archive url when it names one; lazy dependencies are optional.
+func (p *ZigParser) ParseBuildZigZon(content string) *ZigPackage {
+	content = stripZonComments(content)
+	pkg := &ZigPackage{
+		Version:           firstSubmatch(zonVersionRegex, content),
+		MinimumZigVersion: firstSubmatch(zonMinZigVersionRegex, content),
+	}
+	if m := zonNameRegex.FindStringSubmatch(content); m != nil {
+		pkg.Name = m[1] + m[2]
+	}
+
+	for _, m := range zonDependencyRegex.FindAllStringSubmatch(zonDependenciesBlock(content), -1) {
+		pkg.Dependencies = append(pkg.Dependencies, zigDependency(strings.Trim(strings.TrimPrefix(m[1], "@"), `"`), m[2]))
+	}
+	sort.Slice(pkg.Dependencies, func(i, j int) bool { return pkg.Dependencies[i].Name < pkg.Dependencies[j].Name })
+	return pkg
+}
+
+func zigDependency(name, body string) types.Dependency {
+	dep := types.Dependency{
+		Type:     DependencyTypeZig,
+		Name:     name,
+		Scope:    types.ScopeProd,
+		Direct:   true,
+		Metadata: types.NewMetadata("build.zig.zon"),
+	}
+	for _, field := range zonStringFieldRegex.FindAllStringSubmatch(body, -1) {
+		switch field[1] {
+		case "url":
+			if clean, _ := SanitizeRegistryURL(field[2]); clean != "" {
+				dep.Metadata["url"] = clean
+				dep.Version = firstSubmatch(zigArchiveVersionRegex, clean)
+			}
+		case "hash":
+			dep.Metadata["hash"] = field[2]
+		case "path":
+			dep.Metadata["path"] = field[2]
+		}
+	}
+	if zonLazyRegex.MatchString(body) {
+		dep.Scope = types.ScopeOptional
+	}
+	return dep
+}
+
+// zonDependenciesBlock returns the body of the .dependencies struct literal.
+func zonDependenciesBlock(content string) string {
+	loc := zonDependenciesRegex.FindStringIndex(content)
+	if loc == nil {
+		return ""
+	}
+	depth := 1
+	for i := loc[1]; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[loc[1]:i]
+			}
+		}
+	}
+	return ""
+}
+
+// stripZonComments removes // line comments outside string literals.
+func stripZonComments(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		inString := false
+		for j := 0; j+1 < len(line); j++ {
+			switch {
+			case line[j] == '\\' && inString:
+				j++
+			case line[j] == '"':
+				inString = !inString
+			case !inString && line[j] == '/' && line[j+1] == '/':
+				lines[i] = line[:j]
+				j = len(line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func firstSubmatch(re *regexp.Regexp, s string) string {
+	if m := re.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxBodyLoggingFiles caps the files listed as request body logging evidence
+// per component.
+const maxBodyLoggingFiles = 10
+
+// bodyLoggingExtensions are the source file extensions checked for request
+// body logging.
+var bodyLoggingExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".py": true, ".java": true, ".kt": true,
+	".cs": true, ".go": true, ".rb": true, ".php": true,
+}
+
+// bodyLoggingPatterns are heuristics for code that logs HTTP request bodies:
+// logger calls taking the body, request logging middleware configured to
+// include the payload, and full request dumps.
+var bodyLoggingPatterns = []*regexp.Regexp{
+	// console.log(req.body), logger.info("...", request.body), log.debug(request.data)
+	regexp.MustCompile(`(?i)\b(console|log|logger|logging)\.(log|info|debug|warn|warning|error|trace)\s*\([^)\n]*\breq(uest)?\.(body|data|json|raw_post|get_json\(\))`),
+	// morgan.token('body', ...), express-winston requestWhitelist: ['body']
+	regexp.MustCompile(`morgan\.token\(\s*['"]body|requestWhitelist[^\]\n]*['"]body['"]`),
+	// Spring CommonsRequestLoggingFilter.setIncludePayload(true)
+	regexp.MustCompile(`setIncludePayload\(\s*true\s*\)`),
+	// ASP.NET Core HTTP logging of request bodies
+	regexp.MustCompile(`HttpLoggingFields\.(RequestBody|All)\b`),
+	// Go httputil.DumpRequest(r, true) includes the body
+	regexp.MustCompile(`httputil\.DumpRequest(Out)?\([^,\n]+,\s*true\s*\)`),
+	// Laravel/PSR loggers given the raw request content
+	regexp.MustCompile(`(Log::|logger\(\)->|\$logger->)\w+\([^\n]*(\$request->(getContent|all)\(\)|php://input)`),
+}
+
+// PaymentsInfo is the payments section of a component: the payment
+// processors it integrates with and, as a PCI scoping aid, whether its code
+// appears to log request bodies (which may then contain cardholder data).
+type PaymentsInfo struct {
+	Providers          []string `json:"providers"`
+	RequestBodyLogging []string `json:"request_body_logging,omitempty"`
+	PCIReview          bool     `json:"pci_review"`
+}
+
+// recordBodyLogging remembers source files of a component that appear to log
+// request bodies.
+func (s *Scanner) recordBodyLogging(ctx *types.Payload, filePath string, content []byte) {
+	if !bodyLoggingExtensions[filepath.Ext(filePath)] || !mayLogRequestBody(content) {
+		return
+	}
+	if len(s.bodyLogging[ctx]) >= maxBodyLoggingFiles {
+		return
+	}
+	for _, pattern := range bodyLoggingPatterns {
+		if !pattern.Match(content) {
+			continue
+		}
+		rel, err := filepath.Rel(s.cachedBasePath, filePath)
+		if err != nil {
+			return
+		}
+		if s.bodyLogging == nil {
+			s.bodyLogging = make(map[*types.Payload][]string)
+		}
+		s.bodyLogging[ctx] = append(s.bodyLogging[ctx], "/"+filepath.ToSlash(rel))
+		return
+	}
+}
+
+// The cheap pre-check before running the patterns: a file needs a request
+// logging setting, or both a logging call and a request body token, to be
+// searched.
+var (
+	bodyLoggingSettings = [][]byte{
+		[]byte("setIncludePayload"), []byte("HttpLoggingFields"), []byte("DumpRequest"),
+		[]byte("morgan.token"), []byte("requestWhitelist"),
+	}
+	bodyLoggingCalls  = [][]byte{[]byte("log"), []byte("Log")}
+	requestBodyTokens = [][]byte{
+		[]byte("req.body"), []byte("request.body"), []byte("req.data"), []byte("request.data"),
+		[]byte("req.json"), []byte("request.json"), []byte("raw_post"), []byte("get_json"),
+		[]byte("$request->"), []byte("php://input"),
+	}
+)
+
+// mayLogRequestBody reports whether content may match bodyLoggingPatterns.
+func mayLogRequestBody(content []byte) bool {
+	return containsAnyBytes(content, bodyLoggingSettings) ||
+		containsAnyBytes(content, bodyLoggingCalls) && containsAnyBytes(content, requestBodyTokens)
+}
+
+func containsAnyBytes(content []byte, tokens [][]byte) bool {
+	for _, token := range tokens {
+		if bytes.Contains(content, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachPayments adds a "payments" property to every component that uses a
+// payment processor.
+func (s *Scanner) attachPayments(payload *types.Payload) {
+	paymentTechs := make(map[string]bool)
+	for _, rule := range s.rules {
+		if rule.Type == "payment" {
+			paymentTechs[rule.Tech] = true
+		}
+	}
+	s.walkPayments(payload, paymentTechs)
+}
+
+func (s *Scanner) walkPayments(payload *types.Payload, paymentTechs map[string]bool) {
+	var providers []string
+	for _, tech := range payload.Techs {
+		if paymentTechs[tech] {
+			providers = append(providers, tech)
+		}
+	}
+	if len(providers) > 0 {
+		sort.Strings(providers)
+		files := s.bodyLogging[payload]
+		sort.Strings(files)
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["payments"] = &PaymentsInfo{
+			Providers:          providers,
+			RequestBodyLogging: files,
+			PCIReview:          len(files) > 0,
+		}
+	}
+	for _, child := range payload.Children {
+		s.walkPayments(child, paymentTechs)
+	}
+}
@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachPayments(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp", "dependencies": {"stripe": "^14.0.0", "braintree": "^3.0.0", "express": "^4.18.0"}}`)
+	write("src/checkout.js", "app.post('/pay', (req, res) => {\n  logger.info('checkout', req.body);\n});\n")
+	write("src/health.js", "app.get('/health', (req, res) => res.send('ok'));\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "payments")
+	require.NotNil(t, component, "expected a component with a payments section")
+	info, ok := component.Properties["payments"].(*PaymentsInfo)
+	require.True(t, ok)
+	assert.Equal(t, []string{"braintree", "stripe"}, info.Providers)
+	assert.Equal(t, []string{"/src/checkout.js"}, info.RequestBodyLogging)
+	assert.True(t, info.PCIReview)
+}
+
+func TestRecordBodyLogging(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		logs    bool
+	}{
+		{"console req.body", "app.js", "console.log(req.body)", true},
+		{"python logger", "views.py", "logger.info('payload %s', request.data)", true},
+		{"spring filter", "Config.java", "filter.setIncludePayload(true);", true},
+		{"aspnet http logging", "Program.cs", "o.LoggingFields = HttpLoggingFields.RequestBody;", true},
+		{"go request dump", "main.go", "dump, _ := httputil.DumpRequest(r, true)", true},
+		{"body parsed, not logged", "app.js", "const amount = req.body.amount;\nlogger.info('charged')", false},
+		{"go dump without body", "main.go", "httputil.DumpRequest(r, false)", false},
+		{"response body logged", "client.js", "const body = await res.text();\nconsole.log(body)", false},
+		{"not source", "notes.md", "console.log(req.body)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{cachedBasePath: "/repo"}
+			ctx := types.NewPayloadWithPath("main", "/")
+			s.recordBodyLogging(ctx, "/repo/src/"+tt.file, []byte(tt.content))
+			assert.Equal(t, tt.logs, len(s.bodyLogging[ctx]) > 0)
+		})
+	}
+}
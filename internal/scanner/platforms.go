@@ -0,0 +1,169 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// PlatformsInfo is the platforms section of a component: the operating
+// systems and architectures it is built for.
+type PlatformsInfo struct {
+	Targets []*PlatformTarget `json:"targets"`
+	OS      []string          `json:"os"`
+	Arch    []string          `json:"arch,omitempty"`
+}
+
+// PlatformTarget is a platform a component is built for, with the
+// toolchains and files declaring it.
+type PlatformTarget struct {
+	Platform   string   `json:"platform"` // os/arch, or the OS alone
+	OS         string   `json:"os"`
+	Arch       string   `json:"arch,omitempty"`
+	Toolchains []string `json:"toolchains"`
+	Sources    []string `json:"sources"`
+}
+
+// recordPlatforms collects the target platforms declared by a build
+// configuration, CI workflow or build script.
+func (s *Scanner) recordPlatforms(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	platforms := parsePlatformFile(rel, content)
+	if len(platforms) == 0 {
+		return
+	}
+	if s.platforms == nil {
+		s.platforms = make(map[*types.Payload]map[string]*PlatformTarget)
+	}
+	targets := s.platforms[ctx]
+	if targets == nil {
+		targets = make(map[string]*PlatformTarget)
+		s.platforms[ctx] = targets
+	}
+	for _, platform := range platforms {
+		key := platform.String()
+		target := targets[key]
+		if target == nil {
+			target = &PlatformTarget{Platform: key, OS: platform.OS, Arch: platform.Arch}
+			targets[key] = target
+		}
+		target.Toolchains = appendUnique(target.Toolchains, platform.Toolchain)
+		target.Sources = appendUnique(target.Sources, rel)
+	}
+}
+
+// parsePlatformFile dispatches a file to the platform parser for its kind:
+// GoReleaser configuration, Rust toolchain and Cargo configuration, MSBuild
+// projects, GitHub Actions workflows (matrices and commands), and the build
+// commands of other CI configurations, Makefiles, scripts, Dockerfiles and
+// Compose files.
+func parsePlatformFile(rel string, content []byte) []parsers.TargetPlatform {
+	parser := parsers.NewPlatformsParser()
+	name := path.Base(rel)
+	ext := strings.ToLower(path.Ext(name))
+	switch {
+	case isGoreleaserConfig(name, ext):
+		return parser.ParseGoreleaser(content)
+	case isRustTargetConfig(rel, name):
+		return parser.ParseRustTargets(string(content))
+	case isMSBuildProject(name, ext):
+		return parser.ParseRuntimeIdentifiers(string(content))
+	case isYAML(ext) && strings.Contains(rel, "/.github/workflows/"):
+		return append(parser.ParseWorkflowMatrices(content), parser.ParseBuildCommands(string(content))...)
+	case isBuildScript(name, ext):
+		return parser.ParseBuildCommands(string(content))
+	}
+	return nil
+}
+
+func isYAML(ext string) bool {
+	return ext == ".yml" || ext == ".yaml"
+}
+
+// isGoreleaserConfig reports whether a file is a GoReleaser configuration:
+// .goreleaser.yml or goreleaser.yaml.
+func isGoreleaserConfig(name, ext string) bool {
+	return isYAML(ext) && strings.TrimSuffix(strings.TrimPrefix(name, "."), ext) == "goreleaser"
+}
+
+// isRustTargetConfig reports whether a file is a rust-toolchain file or a
+// Cargo configuration (.cargo/config.toml).
+func isRustTargetConfig(rel, name string) bool {
+	if name == "rust-toolchain.toml" || name == "rust-toolchain" {
+		return true
+	}
+	return path.Base(path.Dir(rel)) == ".cargo" && (name == "config.toml" || name == "config")
+}
+
+func isMSBuildProject(name, ext string) bool {
+	return ext == ".csproj" || ext == ".fsproj" || ext == ".vbproj" || name == "Directory.Build.props"
+}
+
+// isBuildScript reports whether a file holds build commands: CI
+// configuration other than GitHub Actions, Makefiles, task runners, shell
+// scripts, Dockerfiles and Compose files.
+func isBuildScript(name, ext string) bool {
+	switch name {
+	case ".gitlab-ci.yml", "azure-pipelines.yml", "Jenkinsfile", "Makefile", "GNUmakefile", "makefile", "justfile", "Justfile", "Taskfile.yml":
+		return true
+	}
+	return ext == ".sh" || ext == ".mk" || name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile.") || composeFileRegex.MatchString(name)
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// attachPlatforms adds a "platforms" property to every component with
+// declared target platforms. A platform naming only an OS is dropped when
+// an architecture of that OS is declared too.
+func (s *Scanner) attachPlatforms(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		targets := s.platforms[p]
+		if len(targets) == 0 {
+			return
+		}
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["platforms"] = platformsInfo(targets)
+	})
+}
+
+func platformsInfo(targets map[string]*PlatformTarget) *PlatformsInfo {
+	withArch := make(map[string]bool)
+	for _, target := range targets {
+		if target.Arch != "" {
+			withArch[target.OS] = true
+		}
+	}
+	info := &PlatformsInfo{}
+	for _, target := range targets {
+		if target.Arch == "" && withArch[target.OS] {
+			continue
+		}
+		sort.Strings(target.Toolchains)
+		sort.Strings(target.Sources)
+		info.Targets = append(info.Targets, target)
+		info.OS = appendUnique(info.OS, target.OS)
+		if target.Arch != "" {
+			info.Arch = appendUnique(info.Arch, target.Arch)
+		}
+	}
+	sort.Slice(info.Targets, func(i, j int) bool { return info.Targets[i].Platform < info.Targets[j].Platform })
+	sort.Strings(info.OS)
+	sort.Strings(info.Arch)
+	return info
+}
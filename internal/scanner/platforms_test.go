@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachPlatforms(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("cli/go.mod", "module example.com/myorg/cli\n\ngo 1.22\n")
+	write("cli/.goreleaser.yaml", "builds:\n  - goos: [linux, windows]\n    goarch: [amd64]\n")
+	write("cli/Makefile", "release:\n\tGOOS=linux GOARCH=arm64 go build ./...\n\tGOOS=windows go build ./...\n")
+	write("service/service.csproj", `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <RuntimeIdentifier>linux-musl-x64</RuntimeIdentifier>
+  </PropertyGroup>
+</Project>`)
+	write("service/Dockerfile", "FROM --platform=linux/amd64 mcr.microsoft.com/dotnet/aspnet:8.0\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*PlatformsInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["platforms"].(*PlatformsInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+
+	cli := byName["cli"]
+	require.NotNil(t, cli)
+	assert.Equal(t, []string{"linux", "windows"}, cli.OS)
+	assert.Equal(t, []string{"amd64", "arm64"}, cli.Arch)
+	require.Len(t, cli.Targets, 3)
+	assert.Equal(t, "linux/amd64", cli.Targets[0].Platform)
+	assert.Equal(t, []string{"/cli/.goreleaser.yaml"}, cli.Targets[0].Sources)
+	assert.Equal(t, "linux/arm64", cli.Targets[1].Platform)
+	assert.Equal(t, []string{"/cli/Makefile"}, cli.Targets[1].Sources)
+	assert.Equal(t, "windows/amd64", cli.Targets[2].Platform)
+
+	service := byName["service"]
+	require.NotNil(t, service)
+	require.Len(t, service.Targets, 1)
+	assert.Equal(t, "linux/amd64", service.Targets[0].Platform)
+	assert.Equal(t, []string{"docker", "dotnet"}, service.Targets[0].Toolchains)
+	assert.Equal(t, []string{"/service/Dockerfile", "/service/service.csproj"}, service.Targets[0].Sources)
+}
@@ -18,11 +18,26 @@ var (
 	providersMutex   sync.RWMutex
 )
 
-// Register allows detectors to register their package extraction and matching logic
+// Register allows detectors to register their package extraction and matching logic.
+// When several detectors share a dependency type (e.g. hex for Mix and rebar3
+// projects), the package names of all their extractors are combined.
 func Register(provider *PackageProvider) {
 	providersMutex.Lock()
 	defer providersMutex.Unlock()
-	packageProviders[provider.DependencyType] = provider
+	existing, ok := packageProviders[provider.DependencyType]
+	if !ok {
+		packageProviders[provider.DependencyType] = provider
+		return
+	}
+	combined := *existing
+	first, second := existing.ExtractPackageNames, provider.ExtractPackageNames
+	combined.ExtractPackageNames = func(component *types.Payload) []string {
+		return append(first(component), second(component)...)
+	}
+	if combined.MatchFunc == nil {
+		combined.MatchFunc = provider.MatchFunc
+	}
+	packageProviders[provider.DependencyType] = &combined
 }
 
 // Get returns a registered package provider by dependency type
@@ -0,0 +1,175 @@
+package scanner
+
+import (
+	"net"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// localHosts are upstream hosts that point back at the machine the proxy
+// runs on; they are resolved by port alone.
+var localHosts = map[string]bool{"": true, "localhost": true, "127.0.0.1": true, "::1": true}
+
+// recordProxyConfig parses nginx, httpd and Envoy configuration files.
+func (s *Scanner) recordProxyConfig(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+	config := parsers.NewProxyParser().ParseConfig(rel, content)
+	if config == nil {
+		return
+	}
+	config.File = "/" + rel
+	if s.proxies == nil {
+		s.proxies = make(map[*types.Payload][]*parsers.ProxyConfig)
+	}
+	s.proxies[ctx] = append(s.proxies[ctx], config)
+}
+
+// linkProxies adds a "proxy" property to the components running a web server
+// or proxy, and an edge from each of them to the components its upstreams
+// name. The proxy component is the nginx, httpd or Envoy component detected
+// next to the configuration, else the component holding it.
+func (s *Scanner) linkProxies(root *types.Payload) {
+	if len(s.proxies) == 0 {
+		return
+	}
+	index := newServiceIndex(root)
+	bySource := make(map[*types.Payload][]*parsers.ProxyConfig)
+	var sources []*types.Payload
+	walkPayloads(root, func(payload *types.Payload) {
+		for _, config := range s.proxies[payload] {
+			source := proxyComponent(payload, config.Server)
+			if bySource[source] == nil {
+				sources = append(sources, source)
+			}
+			bySource[source] = append(bySource[source], config)
+		}
+	})
+
+	for _, source := range sources {
+		if source.Properties == nil {
+			source.Properties = make(map[string]interface{})
+		}
+		source.Properties["proxy"] = bySource[source]
+		for _, config := range bySource[source] {
+			for _, upstream := range config.Upstreams {
+				if target := index.resolve(upstream, source); target != nil && !hasEdgeTo(source, target) {
+					source.AddEdges(target)
+				}
+			}
+		}
+	}
+}
+
+// proxyComponent returns the implicit component of server created under
+// payload, or payload itself.
+func proxyComponent(payload *types.Payload, server string) *types.Payload {
+	for _, child := range payload.Children {
+		if slices.Contains(child.Techs, server) && len(child.Children) == 0 && strings.Join(child.Path, ",") == strings.Join(payload.Path, ",") {
+			return child
+		}
+	}
+	return payload
+}
+
+func walkPayloads(payload *types.Payload, visit func(*types.Payload)) {
+	visit(payload)
+	for _, child := range payload.Children {
+		walkPayloads(child, visit)
+	}
+}
+
+// serviceIndex finds the components an upstream host and port refer to: by
+// component name (package or Compose service) or folder name, and by the
+// ports the Dockerfiles in their folder expose.
+type serviceIndex struct {
+	byName map[string][]*types.Payload
+	byPort map[int][]*types.Payload
+	byDir  map[string]*types.Payload // components by the folder they own
+}
+
+func newServiceIndex(root *types.Payload) *serviceIndex {
+	index := &serviceIndex{
+		byName: make(map[string][]*types.Payload),
+		byPort: make(map[int][]*types.Payload),
+		byDir:  make(map[string]*types.Payload),
+	}
+	portsByDir := make(map[string][]int)
+	walkPayloads(root, func(payload *types.Payload) {
+		entries, _ := payload.Properties["docker"].([]interface{})
+		for _, entry := range entries {
+			if info, ok := entry.(*parsers.DockerfileInfo); ok {
+				dir := path.Dir(info.File)
+				portsByDir[dir] = append(portsByDir[dir], info.ExposedPorts...)
+			}
+		}
+	})
+	for _, child := range root.Children {
+		index.add(child, root, portsByDir)
+	}
+	return index
+}
+
+// add indexes payload and its children. Implicit components share the
+// folder of their parent and are only indexed by name.
+func (idx *serviceIndex) add(payload, parent *types.Payload, portsByDir map[string][]int) {
+	name := strings.ToLower(payload.Name)
+	idx.byName[name] = append(idx.byName[name], payload)
+	if payload.SourceDir != "" && payload.SourceDir != parent.SourceDir {
+		idx.byDir[payload.SourceDir] = payload
+		if dir := strings.ToLower(path.Base(payload.SourceDir)); dir != name {
+			idx.byName[dir] = append(idx.byName[dir], payload)
+		}
+		for _, port := range portsByDir[payload.SourceDir] {
+			idx.byPort[port] = append(idx.byPort[port], payload)
+		}
+	}
+	for _, child := range payload.Children {
+		idx.add(child, payload, portsByDir)
+	}
+}
+
+// resolve returns the component an upstream refers to, or nil when there is
+// none or the match is ambiguous. Named hosts match by their first label
+// (api.internal -> api); several matches are narrowed by port. Local hosts
+// match the single component exposing the port. IP addresses never match.
+func (idx *serviceIndex) resolve(upstream parsers.ProxyUpstream, source *types.Payload) *types.Payload {
+	host := strings.ToLower(upstream.Host)
+	var candidates []*types.Payload
+	switch {
+	case localHosts[host]:
+		candidates = idx.byPort[upstream.Port]
+	case net.ParseIP(host) != nil:
+		return nil
+	default:
+		label, _, _ := strings.Cut(host, ".")
+		candidates = idx.byName[label]
+		if len(candidates) > 1 {
+			candidates = idx.exposing(candidates, upstream.Port)
+		}
+	}
+	if len(candidates) != 1 || candidates[0] == source {
+		return nil
+	}
+	return candidates[0]
+}
+
+func (idx *serviceIndex) exposing(candidates []*types.Payload, port int) []*types.Payload {
+	var matches []*types.Payload
+	for _, candidate := range candidates {
+		for _, exposing := range idx.byPort[port] {
+			if exposing == candidate {
+				matches = append(matches, candidate)
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkProxies(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Dockerfile", "FROM node:20\nEXPOSE 3000\n")
+	write("web/package.json", `{"name": "web", "dependencies": {"react": "^18.2.0"}}`)
+	write("proxy/nginx.conf", `events {}
+http {
+  upstream backend { server api:3000; }
+  server {
+    listen 443 ssl;
+    ssl_certificate /etc/nginx/certs/myapp.crt;
+    location /api/ { proxy_pass http://backend; }
+    location / { proxy_pass http://web.internal:8080; }
+    location /docs { proxy_pass https://docs.example.com; }
+  }
+}
+`)
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	proxy := findComponentWithProperty(result, "proxy")
+	require.NotNil(t, proxy, "expected a component with a proxy section")
+	assert.Contains(t, proxy.Techs, "nginx")
+	configs, ok := proxy.Properties["proxy"].([]*parsers.ProxyConfig)
+	require.True(t, ok)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "/proxy/nginx.conf", configs[0].File)
+	assert.Equal(t, []parsers.ProxyListener{{Port: 443, TLS: true}}, configs[0].Listeners)
+
+	var targets []string
+	for _, edge := range proxy.Edges {
+		targets = append(targets, edge.Target.Name)
+	}
+	assert.ElementsMatch(t, []string{"api", "web"}, targets)
+}
+
+func TestServiceIndexResolve(t *testing.T) {
+	root := types.NewPayloadWithPath("main", "/")
+	root.Properties["docker"] = []interface{}{
+		&parsers.DockerfileInfo{File: "/api/Dockerfile", ExposedPorts: []int{3000}},
+		&parsers.DockerfileInfo{File: "/worker/Dockerfile", ExposedPorts: []int{3000}},
+		&parsers.DockerfileInfo{File: "/admin/Dockerfile", ExposedPorts: []int{4000}},
+	}
+	api := types.NewPayloadWithPath("api", "/api/package.json")
+	worker := types.NewPayloadWithPath("worker", "/worker/package.json")
+	admin := types.NewPayloadWithPath("admin-console", "/admin/package.json")
+	root.AddChild(api)
+	root.AddChild(worker)
+	root.AddChild(admin)
+
+	index := newServiceIndex(root)
+	assert.Equal(t, api, index.resolve(parsers.ProxyUpstream{Host: "api.default.svc.cluster.local", Port: 80}, root))
+	assert.Equal(t, admin, index.resolve(parsers.ProxyUpstream{Host: "localhost", Port: 4000}, root))
+	assert.Equal(t, admin, index.resolve(parsers.ProxyUpstream{Host: "admin", Port: 80}, root), "matched by folder name")
+	assert.Nil(t, index.resolve(parsers.ProxyUpstream{Host: "127.0.0.1", Port: 3000}, root), "two components expose 3000")
+	assert.Nil(t, index.resolve(parsers.ProxyUpstream{Host: "10.0.0.5", Port: 4000}, root))
+	assert.Nil(t, index.resolve(parsers.ProxyUpstream{Host: "api", Port: 3000}, api), "no edge to itself")
+}
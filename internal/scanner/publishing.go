@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// PublishingInfo is the publishing section of a component: the registries
+// its artifacts are pushed to.
+type PublishingInfo struct {
+	Targets    []*PublishingTarget `json:"targets"`
+	Registries []string            `json:"registries"`
+}
+
+// PublishingTarget is a registry a component publishes to, with the files
+// declaring it.
+type PublishingTarget struct {
+	Kind       string   `json:"kind"`
+	Registry   string   `json:"registry,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	Sources    []string `json:"sources"`
+	fallback   bool     // only named by commands using the tool's default registry
+}
+
+// publishingTargets are the publishing targets of a component by kind,
+// registry and repository.
+type publishingTargets map[string]*PublishingTarget
+
+// recordPublishing collects the publishing targets of a CI workflow, build
+// script, package manifest or build file.
+func (s *Scanner) recordPublishing(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	found := parsePublishingFile(rel, content)
+	if len(found) == 0 {
+		return
+	}
+	if s.publishing == nil {
+		s.publishing = make(map[*types.Payload]publishingTargets)
+	}
+	targets := s.publishing[ctx]
+	if targets == nil {
+		targets = make(publishingTargets)
+		s.publishing[ctx] = targets
+	}
+	for _, found := range found {
+		key := found.Kind + "|" + found.Registry + "|" + found.Repository
+		target := targets[key]
+		if target == nil {
+			target = &PublishingTarget{Kind: found.Kind, Registry: found.Registry, Repository: found.Repository, fallback: true}
+			targets[key] = target
+		}
+		if target.URL == "" {
+			target.URL = found.URL
+		}
+		target.fallback = target.fallback && found.Fallback
+		target.Sources = appendUnique(target.Sources, rel)
+	}
+}
+
+// parsePublishingFile dispatches a file to the publishing parser for its
+// kind: GitHub Actions workflows (actions and commands), other CI
+// configurations and build scripts, package.json, pom.xml, Gradle build
+// files and GoReleaser configuration.
+func parsePublishingFile(rel string, content []byte) []parsers.PublishTarget {
+	parser := parsers.NewPublishingParser()
+	name := path.Base(rel)
+	ext := strings.ToLower(path.Ext(name))
+	switch {
+	case name == "package.json":
+		return parser.ParsePublishConfig(content)
+	case name == "pom.xml":
+		return parser.ParsePOMPublishing(content)
+	case name == "build.gradle" || name == "build.gradle.kts":
+		return parser.ParseGradlePublishing(string(content))
+	case isGoreleaserConfig(name, ext):
+		return parser.ParseGoreleaserImages(content)
+	case isYAML(ext) && strings.Contains(rel, "/.github/workflows/"):
+		return append(parser.ParseWorkflowPublishing(content), parser.ParsePublishCommands(string(content))...)
+	case isBuildScript(name, ext):
+		return parser.ParsePublishCommands(string(content))
+	}
+	return nil
+}
+
+// attachPublishing adds a "publishing" property to every component with
+// publishing targets. A tool's default registry, assumed for commands that
+// name none, is dropped when the component names a registry of that kind.
+func (s *Scanner) attachPublishing(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		targets := s.publishing[p]
+		if len(targets) == 0 {
+			return
+		}
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["publishing"] = publishingInfo(targets)
+	})
+}
+
+func publishingInfo(targets publishingTargets) *PublishingInfo {
+	named := make(map[string]bool)
+	for _, target := range targets {
+		if !target.fallback {
+			named[target.Kind] = true
+		}
+	}
+	info := &PublishingInfo{Registries: []string{}}
+	for _, target := range targets {
+		if target.fallback && named[target.Kind] {
+			continue
+		}
+		sort.Strings(target.Sources)
+		info.Targets = append(info.Targets, target)
+		if target.Registry != "" {
+			info.Registries = appendUnique(info.Registries, target.Registry)
+		}
+	}
+	sort.Slice(info.Targets, func(i, j int) bool {
+		a, b := info.Targets[i], info.Targets[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Registry != b.Registry {
+			return a.Registry < b.Registry
+		}
+		return a.Repository < b.Repository
+	})
+	sort.Strings(info.Registries)
+	return info
+}
@@ -0,0 +1,47 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachPublishing(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("sdk/package.json", `{"name": "@myorg/sdk", "dependencies": {"axios": "^1.0.0"}, "publishConfig": {"registry": "https://npm.example.com/"}}`)
+	write("sdk/publish.sh", "#!/bin/sh\nnpm publish\n")
+	write("api/package.json", `{"name": "api", "dependencies": {"express": "^4.18.0"}}`)
+	write("api/Makefile", "push:\n\tdocker push ghcr.io/myorg/api:latest\n\tdocker push ghcr.io/myorg/api:$(VERSION)\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*PublishingInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["publishing"].(*PublishingInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+
+	sdk := byName["@myorg/sdk"]
+	require.NotNil(t, sdk)
+	require.Len(t, sdk.Targets, 1)
+	assert.Equal(t, "npm.example.com", sdk.Targets[0].Registry)
+	assert.Equal(t, []string{"/sdk/package.json"}, sdk.Targets[0].Sources)
+
+	api := byName["api"]
+	require.NotNil(t, api)
+	require.Len(t, api.Targets, 1)
+	assert.Equal(t, &PublishingTarget{Kind: "docker", Registry: "ghcr.io", Repository: "myorg/api", Sources: []string{"/api/Makefile"}}, api.Targets[0])
+	assert.Equal(t, []string{"ghcr.io"}, api.Registries)
+}
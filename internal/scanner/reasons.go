@@ -0,0 +1,35 @@
+package scanner
+
+import "github.com/petrarca/tech-stack-analyzer/internal/types"
+
+// Reason verbosity modes (--reasons).
+const (
+	ReasonsFull    = "full"
+	ReasonsSummary = "summary"
+	ReasonsNone    = "none"
+)
+
+// SummaryReasonLimit is the number of reasons kept per tech in summary mode.
+const SummaryReasonLimit = 5
+
+// SetReasonMode sets the --reasons verbosity of the output: full (or empty)
+// keeps every distinct reason, summary keeps SummaryReasonLimit per tech and
+// counts the rest in reason_overflow, none drops the reasons.
+func (s *Scanner) SetReasonMode(mode string) {
+	s.reasonMode = mode
+}
+
+// consolidateReasons applies the reason mode to every component. Reasons
+// repeating another but for case and spacing are dropped in every mode.
+func (s *Scanner) consolidateReasons(root *types.Payload) {
+	walkPayloads(root, func(p *types.Payload) {
+		switch s.reasonMode {
+		case ReasonsNone:
+			p.Reason, p.ReasonOverflow = nil, nil
+		case ReasonsSummary:
+			p.ConsolidateReasons(SummaryReasonLimit)
+		default:
+			p.ConsolidateReasons(0)
+		}
+	})
+}
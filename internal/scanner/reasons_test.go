@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestConsolidateReasonModes(t *testing.T) {
+	build := func() *types.Payload {
+		root := types.NewPayloadWithPath("main", "/")
+		child := types.NewPayloadWithPath("api", "/api/package.json")
+		for i := range 12 {
+			child.AddTech("typescript", fmt.Sprintf("matched extension: src/file%d.ts", i))
+		}
+		child.AddTech("typescript", "matched dependency: typescript")
+		root.AddChild(child)
+		return root
+	}
+
+	full := build()
+	(&Scanner{}).consolidateReasons(full)
+	assert.Len(t, full.Children[0].Reason["typescript"], 13)
+
+	summary := build()
+	s := &Scanner{}
+	s.SetReasonMode(ReasonsSummary)
+	s.consolidateReasons(summary)
+	api := summary.Children[0]
+	assert.Len(t, api.Reason["typescript"], SummaryReasonLimit)
+	assert.Contains(t, api.Reason["typescript"], "matched dependency: typescript")
+	assert.Equal(t, map[string]int{"typescript": 13 - SummaryReasonLimit}, api.ReasonOverflow)
+
+	none := build()
+	s.SetReasonMode(ReasonsNone)
+	s.consolidateReasons(none)
+	assert.Nil(t, none.Children[0].Reason)
+}
@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// registryConfigFile describes a file that declares package registries
+type registryConfigFile struct {
+	ecosystem string
+	localOnly bool // only applies to the component owning the directory
+	parse     func(p *parsers.RegistryParser, content string) []parsers.Registry
+}
+
+// registryConfigFiles maps lower-cased file names to their registry parser
+var registryConfigFiles = map[string]registryConfigFile{
+	".npmrc":           {ecosystem: parsers.DependencyTypeNpm, parse: (*parsers.RegistryParser).ParseNpmrc},
+	"pip.conf":         {ecosystem: parsers.DependencyTypePython, parse: (*parsers.RegistryParser).ParsePipConf},
+	"pip.ini":          {ecosystem: parsers.DependencyTypePython, parse: (*parsers.RegistryParser).ParsePipConf},
+	"requirements.txt": {ecosystem: parsers.DependencyTypePython, localOnly: true, parse: (*parsers.RegistryParser).ParseRequirementsIndex},
+	"gemfile":          {ecosystem: parsers.DependencyTypeRuby, localOnly: true, parse: (*parsers.RegistryParser).ParseGemfileSources},
+	"nuget.config":     {ecosystem: parsers.DependencyTypeNuget, parse: (*parsers.RegistryParser).ParseNugetConfig},
+	".envrc":           {ecosystem: parsers.DependencyTypeGolang, parse: (*parsers.RegistryParser).ParseGoEnv},
+	".env":             {ecosystem: parsers.DependencyTypeGolang, parse: (*parsers.RegistryParser).ParseGoEnv},
+	"go.env":           {ecosystem: parsers.DependencyTypeGolang, parse: (*parsers.RegistryParser).ParseGoEnv},
+}
+
+// registryResolver discovers registry configuration for components, caching
+// per-directory results so shared ancestor configs are read only once.
+type registryResolver struct {
+	provider types.Provider
+	basePath string
+	parser   *parsers.RegistryParser
+	dirCache map[string]map[string][]parsers.Registry // dir -> ecosystem -> registries
+}
+
+// attachRegistries adds a "registries" property to every component whose
+// dependencies resolve from a configured package registry.
+func (s *Scanner) attachRegistries(root *types.Payload, basePath string) {
+	resolver := &registryResolver{
+		provider: s.provider,
+		basePath: basePath,
+		parser:   parsers.NewRegistryParser(),
+		dirCache: make(map[string]map[string][]parsers.Registry),
+	}
+	resolver.walk(root)
+}
+
+func (r *registryResolver) walk(payload *types.Payload) {
+	if registries := r.resolve(payload); len(registries) > 0 {
+		payload.Properties["registries"] = registries
+	}
+	for _, child := range payload.Children {
+		r.walk(child)
+	}
+}
+
+// resolve returns the registries used by a component's dependencies, one
+// ecosystem at a time, using the nearest directory that configures it.
+func (r *registryResolver) resolve(payload *types.Payload) []parsers.Registry {
+	if payload.SourceDir == "" || payload.Properties == nil {
+		return nil
+	}
+	depsByType := make(map[string][]string)
+	for _, dep := range payload.Dependencies {
+		depsByType[dep.Type] = append(depsByType[dep.Type], dep.Name)
+	}
+
+	var result []parsers.Registry
+	for _, ecosystem := range []string{
+		parsers.DependencyTypeNpm, parsers.DependencyTypePython, parsers.DependencyTypeRuby,
+		parsers.DependencyTypeNuget, parsers.DependencyTypeGolang,
+	} {
+		names := depsByType[ecosystem]
+		if len(names) == 0 {
+			continue
+		}
+		result = append(result, assignRegistryDependencies(r.nearest(payload.SourceDir, ecosystem), names)...)
+	}
+	return result
+}
+
+// nearest walks from the component directory up to the scan root and returns
+// the registries of the first directory that configures the ecosystem.
+func (r *registryResolver) nearest(sourceDir, ecosystem string) []parsers.Registry {
+	dir := path.Clean("/" + filepath.ToSlash(sourceDir))
+	local := true
+	for {
+		if registries := r.dirRegistries(dir, local)[ecosystem]; len(registries) > 0 {
+			return registries
+		}
+		if dir == "/" {
+			return nil
+		}
+		dir = path.Dir(dir)
+		local = false
+	}
+}
+
+// dirRegistries returns the registries configured in a directory. Local-only
+// files (Gemfile, requirements.txt) are skipped when the directory is an ancestor.
+func (r *registryResolver) dirRegistries(dir string, local bool) map[string][]parsers.Registry {
+	cacheKey := dir
+	if !local {
+		cacheKey = "^" + dir
+	}
+	if cached, ok := r.dirCache[cacheKey]; ok {
+		return cached
+	}
+
+	result := make(map[string][]parsers.Registry)
+	absDir := filepath.Join(r.basePath, filepath.FromSlash(strings.TrimPrefix(dir, "/")))
+	files, err := r.provider.ListDir(absDir)
+	if err == nil {
+		for _, file := range files {
+			cfg, ok := registryConfigFiles[strings.ToLower(file.Name)]
+			if !ok || file.Type == "dir" || (cfg.localOnly && !local) {
+				continue
+			}
+			result[cfg.ecosystem] = append(result[cfg.ecosystem], r.readRegistries(absDir, dir, file.Name, cfg)...)
+		}
+	}
+	r.dirCache[cacheKey] = result
+	return result
+}
+
+func (r *registryResolver) readRegistries(absDir, dir, fileName string, cfg registryConfigFile) []parsers.Registry {
+	content, err := r.provider.ReadFile(filepath.Join(absDir, fileName))
+	if err != nil {
+		return nil
+	}
+	registries := cfg.parse(r.parser, string(content))
+	for i := range registries {
+		registries[i].File = path.Join(dir, fileName)
+	}
+	return registries
+}
+
+// assignRegistryDependencies routes dependency names to registries: explicit
+// scope/pattern matches first, then the default registry when it is
+// unambiguous. Only registries that are private or receive dependencies are kept.
+func assignRegistryDependencies(registries []parsers.Registry, names []string) []parsers.Registry {
+	if len(registries) == 0 {
+		return nil
+	}
+	assigned := make([]parsers.Registry, len(registries))
+	copy(assigned, registries)
+
+	defaultIdx := -1
+	for i := range assigned {
+		assigned[i].Dependencies = nil
+		if assigned[i].Default {
+			defaultIdx = pickDefault(defaultIdx, i)
+		}
+	}
+
+	for _, name := range names {
+		if i := matchingRegistry(assigned, name); i >= 0 {
+			assigned[i].Dependencies = append(assigned[i].Dependencies, name)
+		} else if defaultIdx >= 0 {
+			assigned[defaultIdx].Dependencies = append(assigned[defaultIdx].Dependencies, name)
+		}
+	}
+
+	result := make([]parsers.Registry, 0, len(assigned))
+	for _, registry := range assigned {
+		if registry.Private || len(registry.Dependencies) > 0 {
+			result = append(result, registry)
+		}
+	}
+	return result
+}
+
+// pickDefault returns the default registry index, or -2 once more than one
+// default exists and the choice becomes ambiguous.
+func pickDefault(current, candidate int) int {
+	if current == -1 {
+		return candidate
+	}
+	return -2
+}
+
+func matchingRegistry(registries []parsers.Registry, name string) int {
+	for i := range registries {
+		if registries[i].Matches(name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachRegistries(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".npmrc"),
+		[]byte("@myorg:registry=https://npm.example.com/\n//npm.example.com/:_authToken=secret\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "web"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "api"), 0o755))
+
+	root := types.NewPayloadWithPath("main", "/")
+	web := types.NewPayloadWithPath("web", "/web/package.json")
+	web.Dependencies = []types.Dependency{
+		{Type: "npm", Name: "@myorg/ui-kit"},
+		{Type: "npm", Name: "react"},
+	}
+	api := types.NewPayloadWithPath("api", "/api/go.mod")
+	api.Dependencies = []types.Dependency{{Type: "golang", Name: "github.com/example/lib"}}
+	root.Children = []*types.Payload{web, api}
+
+	s := &Scanner{provider: provider.NewFSProvider(tempDir)}
+	s.attachRegistries(root, tempDir)
+
+	registries, ok := web.Properties["registries"].([]parsers.Registry)
+	require.True(t, ok, "web component should inherit the root .npmrc")
+	require.Len(t, registries, 1)
+	assert.Equal(t, "npm.example.com", registries[0].Host)
+	assert.Equal(t, "/.npmrc", registries[0].File)
+	assert.Equal(t, []string{"@myorg/ui-kit"}, registries[0].Dependencies)
+
+	assert.NotContains(t, api.Properties, "registries")
+	assert.NotContains(t, root.Properties, "registries")
+}
+
+func TestAssignRegistryDependencies(t *testing.T) {
+	registries := []parsers.Registry{
+		{Ecosystem: "nuget", Host: "nuget.example.com", Patterns: []string{"MyOrg.*"}, Private: true},
+		{Ecosystem: "nuget", Host: "api.nuget.org", Default: true},
+	}
+	result := assignRegistryDependencies(registries, []string{"MyOrg.Shared", "Newtonsoft.Json"})
+	require.Len(t, result, 2)
+	assert.Equal(t, []string{"MyOrg.Shared"}, result[0].Dependencies)
+	assert.Equal(t, []string{"Newtonsoft.Json"}, result[1].Dependencies)
+
+	// Multiple defaults are ambiguous: unmatched dependencies stay unassigned
+	ambiguous := []parsers.Registry{
+		{Ecosystem: "nuget", Host: "a.example.com", Default: true, Private: true},
+		{Ecosystem: "nuget", Host: "b.example.com", Default: true, Private: true},
+	}
+	result = assignRegistryDependencies(ambiguous, []string{"Newtonsoft.Json"})
+	require.Len(t, result, 2)
+	assert.Empty(t, result[0].Dependencies)
+	assert.Empty(t, result[1].Dependencies)
+}
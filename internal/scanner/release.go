@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// ReleaseInfo is the release section of a component: the release tools it
+// is configured with and the channels they publish to.
+type ReleaseInfo struct {
+	Tools     []parsers.ReleaseConfig `json:"tools"`
+	Publishes []string                `json:"publishes,omitempty"`
+}
+
+// recordRelease collects the release tool configuration a file holds and
+// counts pending changesets.
+func (s *Scanner) recordRelease(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	if isPendingChangeset(rel) {
+		if s.changesets == nil {
+			s.changesets = make(map[*types.Payload]int)
+		}
+		s.changesets[ctx]++
+		return
+	}
+	release, ok := parseReleaseFile(rel, content)
+	if !ok {
+		return
+	}
+	if s.releases == nil {
+		s.releases = make(map[*types.Payload][]parsers.ReleaseConfig)
+	}
+	release.File = rel
+	s.releases[ctx] = append(s.releases[ctx], release)
+}
+
+// parseReleaseFile dispatches a file to the release parser for its kind:
+// GoReleaser configuration, semantic-release configuration (also under the
+// "release" key of package.json), the Changesets configuration and Maven
+// POMs using the Maven Release Plugin.
+func parseReleaseFile(rel string, content []byte) (parsers.ReleaseConfig, bool) {
+	parser := parsers.NewReleaseParser()
+	name := path.Base(rel)
+	ext := strings.ToLower(path.Ext(name))
+	switch {
+	case isGoreleaserConfig(name, ext):
+		return parser.ParseGoreleaserRelease(content)
+	case name == ".releaserc" || name == ".releaserc.json" || name == ".releaserc.yaml" || name == ".releaserc.yml":
+		return parser.ParseSemanticRelease(content)
+	case isSemanticReleaseScript(name):
+		return parser.ParseSemanticReleaseScript(string(content)), true
+	case name == "package.json":
+		return parser.ParsePackageJSONRelease(content)
+	case strings.HasSuffix(rel, "/.changeset/config.json"):
+		return parser.ParseChangesetsConfig(content)
+	case name == "pom.xml":
+		return parser.ParseMavenRelease(string(content))
+	}
+	return parsers.ReleaseConfig{}, false
+}
+
+func isSemanticReleaseScript(name string) bool {
+	base := strings.TrimSuffix(name, path.Ext(name))
+	switch path.Ext(name) {
+	case ".js", ".cjs", ".mjs", ".ts":
+		return base == ".releaserc" || base == "release.config"
+	}
+	return false
+}
+
+// isPendingChangeset reports whether a file is a changeset waiting to be
+// released: a Markdown file of .changeset other than its README.
+func isPendingChangeset(rel string) bool {
+	return path.Base(path.Dir(rel)) == ".changeset" && path.Ext(rel) == ".md" && !strings.EqualFold(path.Base(rel), "README.md")
+}
+
+// attachRelease adds a "release" property to every component with release
+// tool configuration, with the pending changesets set on its Changesets
+// configuration.
+func (s *Scanner) attachRelease(payload *types.Payload) {
+	walkPayloads(payload, func(p *types.Payload) {
+		tools := s.releases[p]
+		if len(tools) == 0 {
+			return
+		}
+		info := &ReleaseInfo{}
+		for _, tool := range tools {
+			if tool.Tool == parsers.ReleaseToolChangesets {
+				tool.Pending = s.changesets[p]
+			}
+			info.Tools = append(info.Tools, tool)
+			for _, channel := range tool.Publishes {
+				info.Publishes = appendUnique(info.Publishes, channel)
+			}
+		}
+		sort.Slice(info.Tools, func(i, j int) bool { return info.Tools[i].File < info.Tools[j].File })
+		sort.Strings(info.Publishes)
+		if p.Properties == nil {
+			p.Properties = make(map[string]interface{})
+		}
+		p.Properties["release"] = info
+	})
+}
@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachRelease(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("cli/go.mod", "module example.com/myorg/cli\n\ngo 1.22\n")
+	write("cli/.goreleaser.yaml", "builds:\n  - binary: myapp\ndockers:\n  - image_templates: [\"myorg/myapp:{{ .Tag }}\"]\n")
+	write("web/package.json", `{"name": "web", "dependencies": {"react": "^18.0.0"}, "release": {"branches": ["main"]}}`)
+	write("web/.changeset/config.json", `{"baseBranch": "main"}`)
+	write("web/.changeset/README.md", "# Changesets\n")
+	write("web/.changeset/brave-owls-sing.md", "---\n\"web\": minor\n---\n\nAdd a feature\n")
+	write("web/.changeset/quiet-cats-run.md", "---\n\"web\": patch\n---\n\nFix a bug\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]*ReleaseInfo)
+	for _, child := range result.Children {
+		if info, ok := child.Properties["release"].(*ReleaseInfo); ok {
+			byName[child.Name] = info
+		}
+	}
+
+	cli := byName["cli"]
+	require.NotNil(t, cli)
+	require.Len(t, cli.Tools, 1)
+	assert.Equal(t, "/cli/.goreleaser.yaml", cli.Tools[0].File)
+	assert.Equal(t, []string{"myorg/myapp"}, cli.Tools[0].Images)
+	assert.Equal(t, []string{"docker", "github-releases"}, cli.Publishes)
+
+	web := byName["web"]
+	require.NotNil(t, web)
+	require.Len(t, web.Tools, 2)
+	assert.Equal(t, parsers.ReleaseToolChangesets, web.Tools[0].Tool)
+	assert.Equal(t, 2, web.Tools[0].Pending)
+	assert.Equal(t, parsers.ReleaseToolSemanticRelease, web.Tools[1].Tool)
+	assert.Equal(t, "/web/package.json", web.Tools[1].File)
+	assert.Equal(t, []string{"github-releases", "npm"}, web.Publishes)
+}
@@ -26,6 +26,8 @@ var depsDevSystems = map[string]string{
 	"java":   "maven", // kept for detectors that set ComponentType "java"
 	"maven":  "maven", // Maven detector sets ComponentType "maven"
 	"gradle": "maven", // Gradle artifacts use Maven coordinates on deps.dev
+	"ant":    "maven", // Ivy dependencies of an Ant build use Maven coordinates
+	"ivy":    "maven",
 }
 
 // OnlineGraphResolver is the pluggable contract for resolving a package's
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/resolvestats"
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 )
@@ -55,7 +56,7 @@ func NewDepsDevFetcher(baseURL string, client HTTPDoer) OnlineGraphResolver {
 	}
 	baseURL = strings.TrimRight(baseURL, "/")
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = httpclient.New(30 * time.Second)
 	}
 	c := &depsDevClient{
 		baseURL:  baseURL,
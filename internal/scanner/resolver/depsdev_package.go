@@ -3,10 +3,11 @@ package resolver
 import (
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/httpclient"
 )
 
 // PackageInfo is the deps.dev GetPackage result distilled to what a currency
@@ -62,7 +63,7 @@ func NewPackageClient(baseURL string, client HTTPDoer) *PackageClient {
 	}
 	baseURL = strings.TrimRight(baseURL, "/")
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = httpclient.New(30 * time.Second)
 	}
 	return &PackageClient{c: &depsDevClient{baseURL: baseURL, http: client}}
 }
@@ -0,0 +1,153 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/rules"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// customRules holds the directory of custom rules scanners load on top of
+// the embedded ones, and the rules loaded from each directory used so far.
+// A scanner takes the rules current when it is created and keeps them for
+// its scan, so a reload by WatchRules never changes the rules of a running
+// scan.
+var customRules struct {
+	mu   sync.Mutex
+	dir  string
+	sets map[string]*ruleSet
+}
+
+// ruleSet is the embedded rules merged with the custom rules of one
+// directory, and the fingerprint of the rule files they were loaded from.
+type ruleSet struct {
+	rules       []types.Rule
+	fingerprint uint64
+}
+
+// SetRulesDir sets the directory of custom rules: its YAML rules replace the
+// embedded rules of the same tech and add the others. Empty uses the
+// embedded rules only.
+func SetRulesDir(dir string) {
+	customRules.mu.Lock()
+	defer customRules.mu.Unlock()
+	customRules.dir = dir
+}
+
+// currentRules returns the rules for a new scanner and the custom rules
+// directory they include. The rules of a directory are loaded once and then
+// only again when WatchRules sees its rule files change.
+func currentRules() ([]types.Rule, string, error) {
+	customRules.mu.Lock()
+	defer customRules.mu.Unlock()
+	dir := customRules.dir
+	if dir == "" {
+		loaded, err := rules.LoadEmbeddedRules()
+		return loaded, "", err
+	}
+	if set, ok := customRules.sets[dir]; ok {
+		return slices.Clone(set.rules), dir, nil
+	}
+	fingerprint, err := rulesFingerprint(dir)
+	if err != nil {
+		return nil, dir, fmt.Errorf("failed to read rules directory: %w", err)
+	}
+	loaded, err := rules.LoadRules(dir)
+	if err != nil {
+		return nil, dir, err
+	}
+	if customRules.sets == nil {
+		customRules.sets = make(map[string]*ruleSet)
+	}
+	customRules.sets[dir] = &ruleSet{rules: loaded, fingerprint: fingerprint}
+	return slices.Clone(loaded), dir, nil
+}
+
+// WatchRules polls the custom rules directories loaded so far every interval
+// until ctx is done, and reloads the rules of a directory whose rule files
+// changed. Scanners created afterwards use the new rules. Rules that fail to
+// load leave the previous ones in place until the files change again, so a
+// half-edited rule does not stop long-running processes from scanning.
+func WatchRules(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloadChangedRules(logger)
+		}
+	}
+}
+
+// reloadChangedRules reloads the rules of every loaded directory whose rule
+// files changed since they were loaded.
+func reloadChangedRules(logger *slog.Logger) {
+	customRules.mu.Lock()
+	dirs := make([]string, 0, len(customRules.sets))
+	for dir := range customRules.sets {
+		dirs = append(dirs, dir)
+	}
+	customRules.mu.Unlock()
+
+	for _, dir := range dirs {
+		reloadRules(dir, logger)
+	}
+}
+
+func reloadRules(dir string, logger *slog.Logger) {
+	fingerprint, err := rulesFingerprint(dir)
+	if err != nil {
+		logger.Warn("Cannot read rules directory, keeping the loaded rules", "rules_dir", dir, "error", err)
+		return
+	}
+	customRules.mu.Lock()
+	set := customRules.sets[dir]
+	customRules.mu.Unlock()
+	if set.fingerprint == fingerprint {
+		return
+	}
+
+	loaded, err := rules.LoadRules(dir)
+	customRules.mu.Lock()
+	defer customRules.mu.Unlock()
+	if err != nil {
+		// Remember the broken files so the error is logged once per change.
+		customRules.sets[dir] = &ruleSet{rules: set.rules, fingerprint: fingerprint}
+		logger.Error("Failed to reload rules, keeping the previous rules", "rules_dir", dir, "error", err)
+		return
+	}
+	customRules.sets[dir] = &ruleSet{rules: loaded, fingerprint: fingerprint}
+	logger.Info("Reloaded rules", "rules_dir", dir, "count", len(loaded))
+}
+
+// rulesFingerprint hashes the paths, sizes and modification times of the
+// rule files below dir.
+func rulesFingerprint(dir string) (uint64, error) {
+	h := fnv.New64a()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return h.Sum64(), err
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestRulesDirReload(t *testing.T) {
+	rulesDir := t.TempDir()
+	ruleFile := filepath.Join(rulesDir, "tool", "myapp-tool.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(ruleFile), 0o755))
+	writeRule := func(content string, mtime time.Time) {
+		require.NoError(t, os.WriteFile(ruleFile, []byte(content), 0o644))
+		require.NoError(t, os.Chtimes(ruleFile, mtime, mtime))
+	}
+	writeRule("tech: myapp-tool\nname: MyApp Tool\nfiles:\n  - myapp.toml\n", time.Now().Add(-time.Hour))
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "myapp.toml"), []byte("name = 'x'\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "myapp.ini"), []byte("[x]\n"), 0o644))
+
+	SetRulesDir(rulesDir)
+	t.Cleanup(func() { SetRulesDir("") })
+	scanTechs := func() []string {
+		s, err := NewScanner(projectDir)
+		require.NoError(t, err)
+		result, err := s.Scan()
+		require.NoError(t, err)
+		return result.Techs
+	}
+	require.Contains(t, scanTechs(), "myapp-tool")
+
+	logger := slog.New(slog.DiscardHandler)
+	writeRule("tech: myapp-tool\nname: MyApp Tool\nfiles:\n  - myapp.ini-only\n", time.Now())
+	reloadChangedRules(logger)
+	assert.NotContains(t, scanTechs(), "myapp-tool", "the old file matcher must be gone")
+
+	// A broken rule keeps the rules loaded before it.
+	writeRule("name: no tech\n", time.Now().Add(time.Minute))
+	reloadChangedRules(logger)
+	loaded, dir, err := currentRules()
+	require.NoError(t, err)
+	assert.Equal(t, rulesDir, dir)
+	i := slices.IndexFunc(loaded, func(r types.Rule) bool { return r.Tech == "myapp-tool" })
+	require.GreaterOrEqual(t, i, 0)
+	assert.Equal(t, []string{"myapp.ini-only"}, loaded[i].Files)
+}
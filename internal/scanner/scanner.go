@@ -1,15 +1,20 @@
 package scanner
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"log/slog"
 
+	"github.com/go-enry/go-enry/v2"
 	"github.com/mattn/go-isatty"
 	"github.com/petrarca/tech-stack-analyzer/internal/codestats"
 	"github.com/petrarca/tech-stack-analyzer/internal/config"
@@ -18,7 +23,6 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
 	"github.com/petrarca/tech-stack-analyzer/internal/progress"
 	"github.com/petrarca/tech-stack-analyzer/internal/provider"
-	"github.com/petrarca/tech-stack-analyzer/internal/rules"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/components"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/matchers"
 	"github.com/petrarca/tech-stack-analyzer/internal/scanner/mavenresolve"
@@ -28,27 +32,45 @@ import (
 	"github.com/petrarca/tech-stack-analyzer/internal/types"
 
 	// Import component detectors to trigger init() registration
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/archive"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/authprovider"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cms"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cocoapods"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/cplusplus"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/dart"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/delphi"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/deno"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/devenv"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/docker"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/docs"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/dotnet"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/elixir"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/erlang"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/firmware"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/githubactions"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/golang"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/java"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/julia"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/lintconfig"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/nodejs"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/notebook"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/notification"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/nx"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/observability"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ospackaging"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/perl"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/php"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/python"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/r"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ros"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/ruby"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/rust"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/salesforce"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/sap"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/swift"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/taskrunner"
 	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/terraform"
+	_ "github.com/petrarca/tech-stack-analyzer/internal/scanner/components/zig"
 )
 
 // Scanner handles the recursive directory scanning and technology detection logic
@@ -60,21 +82,67 @@ type Scanner struct {
 	licenseDetector   *license.LicenseDetector
 	langDetector      *LanguageDetector
 	contentMatcher    *matchers.ContentMatcherRegistry
+	fileMatchers      []matchers.FileMatcher
 	excludePatterns   []string
 	includePaths      []string // When set, only these relative paths under the root are scanned
 	progress          *progress.Progress
 	codeStats         CodeStatsAnalyzer
-	observations      *ObservationCollector // optional; nil = disabled
-	subsystemDepth    int                   // Depth for subsystem stats rollup (0=disabled)
-	subsystemPathMap  map[string]string     // path prefix → group name (built from SubsystemGroups config)
-	subsystemMaxDepth int                   // Maximum path depth across all subsystem group paths (loop cap)
-	cachedBasePath    string                // Cached scan root path for fast relative path computation
+	observations      *ObservationCollector                         // optional; nil = disabled
+	testFiles         map[*types.Payload]*testFileCounts            // per-component test files for the testing section
+	bodyLogging       map[*types.Payload][]string                   // per-component files logging request bodies (payments section)
+	aiUsage           map[*types.Payload]*aiUsageFiles              // per-component model references, model files and LLM endpoints
+	externalAPIs      map[*types.Payload][]externalAPIRecord        // per-component SaaS API base URLs (external_apis section)
+	apiHosts          *apiHostIndex                                 // api_hosts of the rules; built on first use
+	mainframe         map[*types.Payload]*MainframeInfo             // per-component COBOL, JCL and DB2 DDL counts
+	databaseCode      map[*types.Payload]*DatabaseCodeInfo          // per-component SQL files and created objects
+	localization      map[*types.Payload]*LocalizationInfo          // per-component message catalogs by locale
+	attribution       map[*types.Payload]*AttributionInfo           // per-component copyright statements and notice files
+	noticeText        bool                                          // --notice-text: keep notice file texts in attribution sections
+	proxies           map[*types.Payload][]*parsers.ProxyConfig     // per-component nginx, httpd and Envoy configurations
+	network           map[*types.Payload][]networkRecord            // per-component declared ports
+	ingressBackends   []parsers.IngressBackend                      // Kubernetes Services routed to by an Ingress
+	schedules         map[*types.Payload][]parsers.ScheduledJob     // per-component cron jobs, CronJobs and scheduler configuration
+	platforms         map[*types.Payload]map[string]*PlatformTarget // per-component target platforms by os/arch
+	releases          map[*types.Payload][]parsers.ReleaseConfig    // per-component release tool configurations
+	changesets        map[*types.Payload]int                        // per-component pending changeset files
+	publishing        map[*types.Payload]publishingTargets          // per-component publishing targets
+	deployments       map[*types.Payload]deploymentTargets          // per-component deployment targets
+	environments      map[*types.Payload]componentEnvironments      // per-component environment configuration
+	artifacts         map[*types.Payload]*artifactEvidence          // per-component evidence of executables, libraries and images
+	entrypoints       map[*types.Payload][]parsers.Entrypoint       // per-component main functions, executables and start commands
+	health            map[*types.Payload]*healthRecords             // per-component probes, actuator endpoints and health routes
+	configAudit       map[*types.Payload]*ConfigAuditInfo           // per-component config hygiene evidence (--config-audit)
+	auditConfig       bool                                          // --config-audit: add config_audit sections
+	adoptionSamples   int                                           // --adoption: commits sampled for the adoption timeline; 0 = off
+	adoptionTags      bool                                          // --adoption-tags: sample tagged commits instead of HEAD's history
+	vendored          bool                                          // --vendored: add vendored sections
+	javaImports       map[*types.Payload]map[string]string          // --java-imports: per-component imported names and the first file importing each; nil = off
+	slowDirThreshold  time.Duration                                 // own processing time above which a directory is reported as slow; 0 = default, <0 = off
+	slowDirs          []progress.TimingEntry                        // directories over slowDirThreshold, for the post-scan report
+	maxFileSize       int64                                         // size above which files are not read for content; 0 = default, <0 = no limit
+	skipExtensions    map[string]bool                               // extensions of files not read for content; nil = defaults
+	skippedFiles      metadata.SkippedFiles                         // files not read for content, by reason
+	sampleThreshold   int64                                         // size above which code stats are estimated from a sample; <=0 = off
+	subsystemDepth    int                                           // Depth for subsystem stats rollup (0=disabled)
+	subsystemPathMap  map[string]string                             // path prefix → group name (built from SubsystemGroups config)
+	subsystemMaxDepth int                                           // Maximum path depth across all subsystem group paths (loop cap)
+	cachedBasePath    string                                        // Cached scan root path for fast relative path computation
+	detectorStats     map[string]*detectorStat                      // per component detector timings, component counts and failures
+	detectors         []components.Detector                         // component detectors to run (SetDetectors); nil = all registered
+	logger            *slog.Logger                                  // nil = slog.Default()
 	gitignoreStack    *git.StackBasedLoader
 	gitCache          map[string]*git.GitInfo // Cache git info by repo root path
 	gitRootCache      map[string]string       // Cache path -> repo root mapping
 	rootID            string                  // Override root ID for deterministic scans
 	config            *config.ScanConfig      // Merged configuration for metadata properties
 	useLockFiles      bool                    // Use lock files for dependency resolution
+	impliedMode       string                  // --resolve-implied: keep (default), collapse or add
+	reasonMode        string                  // --reasons: full (default), summary or none
+	minConfidence     float64                 // --min-confidence: techs scoring below are dropped
+	vetoes            detectionVetoes         // Rule unless conditions and configured suppressions
+	componentNaming   []string                // --component-naming sources in order; nil = DefaultComponentNaming
+	mergeImplicit     bool                    // --merge-implicit: fold implicit components into their parent's techs
+	mergeImplicitMin  int                     // --merge-implicit-min: implicit siblings needed before folding (0 or 1 = any)
 }
 
 // CodeStatsAnalyzer is the interface used by the scanner for code statistics collection.
@@ -107,15 +175,16 @@ func NewScannerWithOptionsAndLogger(path string, excludePatterns []string, quiet
 	// Create provider for the target path
 	tInit := time.Now()
 	provider := provider.NewFSProvider(path)
+	if _, err := provider.IsDir(path); err != nil {
+		return nil, err
+	}
 
 	// Initialize all scanner components
 	components, err := initializeScannerComponents(provider, path, logger)
 	if err != nil {
 		return nil, err
 	}
-	if logger != nil {
-		logger.Debug("Scanner initialization completed", "duration", time.Since(tInit))
-	}
+	logInitDuration(logger, time.Since(tInit))
 
 	// Create progress reporter
 	var prog *progress.Progress
@@ -180,6 +249,7 @@ func NewScannerWithOptionsAndLogger(path string, excludePatterns []string, quiet
 		licenseDetector: components.licenseDetector,
 		langDetector:    langDetector,
 		contentMatcher:  components.contentMatcher,
+		fileMatchers:    components.fileMatchers,
 		excludePatterns: excludePatterns,
 		progress:        prog,
 		codeStats:       codeStats,
@@ -189,6 +259,8 @@ func NewScannerWithOptionsAndLogger(path string, excludePatterns []string, quiet
 		rootID:          rootID,
 		config:          cfg,
 		useLockFiles:    true, // Default to true
+		vetoes:          newDetectionVetoes(components.rules, cfg.Suppress),
+		logger:          logger,
 	}, nil
 }
 
@@ -273,10 +345,47 @@ func (s *Scanner) SetSubsystemGroups(groups map[string]config.SubsystemGroup) {
 	s.subsystemMaxDepth = maxDepth
 }
 
+// SetProgressHandler routes the scanner's progress events to handler, in
+// place of the one chosen from the quiet/verbose/tree options.
+func (s *Scanner) SetProgressHandler(handler progress.Handler) {
+	s.progress.SetHandler(handler)
+}
+
+// SetLicenseTextHash makes the license detector hash the text of license
+// files (--license-text-hash); see license.LicenseDetector.SetTextHash.
+func (s *Scanner) SetLicenseTextHash(enabled bool) {
+	s.licenseDetector.SetTextHash(enabled)
+}
+
+// SetDetectors restricts the component detectors run to the ones named in
+// only (all when empty), less the ones named in disabled (--detectors,
+// --disable-detectors). It fails on names of unregistered detectors.
+func (s *Scanner) SetDetectors(only, disabled []string) error {
+	if len(only) == 0 && len(disabled) == 0 {
+		s.detectors = nil
+		return nil
+	}
+	detectors, err := components.SelectDetectors(only, disabled)
+	if err != nil {
+		return err
+	}
+	s.detectors = detectors
+	return nil
+}
+
+func (s *Scanner) componentDetectors() []components.Detector {
+	if s.detectors == nil {
+		return components.GetDetectors()
+	}
+	return s.detectors
+}
+
 // SetIncludePaths restricts scanning to only the specified relative paths under the root.
 // When set, only directories whose path relative to the scan root starts with one of these
-// prefixes are recursed into. This enables multi-path scanning: the scanner is rooted at
-// a common parent directory, and only the specified subtrees are scanned.
+// prefixes are recursed into, and the files of their ancestors (the root included) are
+// not analyzed. This enables multi-path scanning, where the scanner is rooted at a common
+// parent directory, and scanning selected subtrees of one repository (--path), where the
+// root keeps the repository's git identity and root ID.
 // All paths must be relative; absolute paths are rejected.
 func (s *Scanner) SetIncludePaths(paths []string) {
 	for _, p := range paths {
@@ -323,6 +432,41 @@ func (s *Scanner) isIncludedPath(dirPath string) bool {
 	return false
 }
 
+// isWithinIncludePath reports whether the directory is one of the include
+// paths or below one, rather than only on the way to them.
+func (s *Scanner) isWithinIncludePath(dirPath string) bool {
+	if len(s.includePaths) == 0 {
+		return true
+	}
+	relPath, err := filepath.Rel(s.provider.GetBasePath(), dirPath)
+	if err != nil {
+		return true
+	}
+	for _, inc := range s.includePaths {
+		inc = filepath.Clean(inc)
+		if inc == "." || relPath == inc || strings.HasPrefix(relPath, inc+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// initBudget is the startup time a scanner is expected to need before it
+// walks the first directory. Debug output flags initializations over it, as
+// they dominate the run time of scans of small directories.
+const initBudget = 100 * time.Millisecond
+
+// logInitDuration reports the scanner initialization time against initBudget.
+func logInitDuration(logger *slog.Logger, elapsed time.Duration) {
+	if logger == nil {
+		return
+	}
+	logger.Debug("Scanner initialization completed", "duration", elapsed, "budget", initBudget)
+	if elapsed > initBudget {
+		logger.Debug("Scanner initialization exceeded its budget", "over_by", elapsed-initBudget)
+	}
+}
+
 // scannerComponents holds all initialized scanner components
 type scannerComponents struct {
 	rules           []types.Rule
@@ -330,27 +474,26 @@ type scannerComponents struct {
 	dotenvDetector  *parsers.DotenvDetector
 	licenseDetector *license.LicenseDetector
 	contentMatcher  *matchers.ContentMatcherRegistry
+	fileMatchers    []matchers.FileMatcher
 }
 
 // initializeScannerComponents handles common initialization logic
 func initializeScannerComponents(provider types.Provider, path string, logger *slog.Logger) (*scannerComponents, error) {
-	// Load embedded YAML rules
+	// Load embedded YAML rules, and the custom ones of --rules-dir
 	t1 := time.Now()
-	loadedRules, err := rules.LoadEmbeddedRules()
+	loadedRules, dir, err := currentRules()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load rules: %w", err)
 	}
 	if logger != nil {
-		logger.Debug("Loaded embedded rules", "count", len(loadedRules), "duration", time.Since(t1))
+		logger.Debug("Loaded rules", "count", len(loadedRules), "rules_dir", dir, "duration", time.Since(t1))
 	}
 
 	// Load types configuration
 	t2 := time.Now()
-	categoriesConfig, err := config.LoadCategoriesConfig()
-	if err != nil {
+	if err := loadCategoriesConfig(); err != nil {
 		return nil, fmt.Errorf("failed to load categories config: %w", err)
 	}
-	SetCategoriesConfig(categoriesConfig)
 	if logger != nil {
 		logger.Debug("Loaded categories config", "duration", time.Since(t2))
 	}
@@ -366,7 +509,7 @@ func initializeScannerComponents(provider types.Provider, path string, logger *s
 
 	// Build file matchers from rules
 	t4 := time.Now()
-	matchers.BuildFileMatchersFromRules(loadedRules)
+	fileMatchers := matchers.NewFileMatchers(loadedRules)
 	if logger != nil {
 		logger.Debug("Built file matchers", "duration", time.Since(t4))
 	}
@@ -387,6 +530,7 @@ func initializeScannerComponents(provider types.Provider, path string, logger *s
 		dotenvDetector:  dotenvDetector,
 		licenseDetector: licenseDetector,
 		contentMatcher:  contentMatcher,
+		fileMatchers:    fileMatchers,
 	}, nil
 }
 
@@ -429,6 +573,12 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	// Start recursive directory scanning from base path. Detection collects
 	// components and their declared dependencies (resolving versions inline);
 	// dependency-graph resolution is deferred to after the walk.
+	// Count the directories ahead of the walk for the progress line's estimate
+	// of the time left; only when it is shown, as the count lists them all.
+	if s.progress.WantsDirectoryTotal() {
+		s.progress.DirectoryTotal(s.countDirectories(basePath))
+	}
+
 	slog.Debug("Starting directory recursion", "path", basePath)
 	err := s.recurse(payload, basePath)
 	if err != nil {
@@ -438,12 +588,118 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	slog.Debug("Completed directory recursion")
 
 	// Resolve the deferred dependency graph now that the walk is done.
-	components.ResolveDeferredGraphs()
+	components.ResolveDeferredGraphs(s.provider)
+
+	// Re-map dependency scopes per the configuration before any section
+	// reads them.
+	s.applyScopeOverrides(payload)
+
+	// Add the techs Java and Kotlin sources import when enabled, so the
+	// sections below see them.
+	s.attachJavaImports(payload)
 
 	// Harvest per-dependency licenses from local sources (in-tree always;
 	// global caches when enabled), now that versions are resolved.
 	components.HarvestLicenses(payload, basePath)
 
+	// Record which package registries (public or private) each component's
+	// dependencies resolve from, based on registry configuration files.
+	s.attachRegistries(payload, basePath)
+
+	// Group test frameworks and coverage tools per component.
+	s.attachTesting(payload)
+
+	// Classify design systems and accessibility tooling per component.
+	s.attachDesignSystems(payload)
+
+	// Report identity providers (with their hosts) per component.
+	s.attachSecurity(payload)
+
+	// Group email, SMS and push providers with their sender configuration.
+	s.attachNotifications(payload)
+
+	// Group payment processors and flag components for PCI scoping.
+	s.attachPayments(payload)
+
+	// Summarize AI providers, models and SDK versions per component.
+	s.attachAIUsage(payload)
+
+	// Report the SaaS APIs each component calls, from SDKs and base URLs.
+	s.attachExternalAPIs(payload)
+
+	// Count COBOL programs, copybooks, JCL jobs and DB2 DDL per component.
+	s.attachMainframe(payload)
+
+	// Summarize SQL scripts and stored code per component.
+	s.attachDatabaseCode(payload)
+
+	// Inventory message catalogs and locale coverage per component.
+	s.attachLocalization(payload)
+
+	// List the scheduled jobs each component defines.
+	s.attachSchedules(payload)
+
+	// Summarize the OS and architectures each component is built for.
+	s.attachPlatforms(payload)
+
+	// Describe how each component's artifacts are versioned and published.
+	s.attachRelease(payload)
+
+	// List the registries each component publishes artifacts to.
+	s.attachPublishing(payload)
+
+	// Name the platforms each component is deployed to.
+	s.attachDeployment(payload)
+
+	// List how each component is started.
+	s.attachEntrypoints(payload)
+
+	// Inventory the health checks and probes each component declares.
+	s.attachHealth(payload)
+
+	// Compare the configuration of each component across its environments.
+	s.attachEnvironments(payload)
+
+	// Collect copyright statements and NOTICE files per component.
+	s.attachAttribution(payload)
+
+	// Summarize the documentation toolchains of the repository on the root.
+	attachDocumentation(payload)
+
+	// Link the frontend and backend components of Tauri desktop apps.
+	s.linkDesktopApps(payload)
+
+	// Report proxy listeners and upstreams, linking proxies to the app
+	// components they forward to.
+	s.linkProxies(payload)
+
+	// Inventory declared ports and their public and ingress exposure.
+	s.attachNetwork(payload)
+
+	// Audit configuration hygiene per component when enabled.
+	s.attachConfigAudit(payload)
+
+	// Reconcile declared dependencies with vendor directories when enabled.
+	s.attachVendored(payload)
+
+	// Date each component's techs and dependencies from the git history
+	// when enabled.
+	s.attachAdoption(payload)
+
+	// Apply the rules' supersedes and implies relations once all sections
+	// have seen the techs as detected.
+	s.resolveTechRelations(payload)
+	s.scoreTechs(payload)
+	s.mergeImplicitComponents(payload)
+
+	// Classify each component as a service, cli, library, frontend or
+	// infra from the techs it kept and what its files build.
+	s.classifyComponents(payload)
+
+	// Deduplicate, cap or drop the reasons per --reasons once nothing
+	// scores techs from them any more.
+	s.consolidateReasons(payload)
+
 	stopResolveReporter()
 
 	// Set scan duration
@@ -458,6 +714,11 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	techCount, techsCount := s.countTechs(payload)
 	scanMeta.SetLanguageCount(languageCount)
 	scanMeta.SetTechCounts(techCount, techsCount)
+	s.recordDetectorStats(scanMeta)
+	scanMeta.AddSkippedFiles(s.skippedFiles)
+
+	// Identify the rule set the scan ran with
+	scanMeta.SetRulesHash(s.rulesHash())
 
 	// Set custom properties from config
 	scanMeta.SetProperties(cfg.Properties)
@@ -473,6 +734,9 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 	// Attach metadata to root payload
 	payload.Metadata = scanMeta
 
+	// Name components before their IDs, which derive from the names
+	s.nameComponents(payload)
+
 	// Assign unique IDs to the entire payload tree
 	payload.AssignIDs(s.resolveRootID(basePath))
 
@@ -493,8 +757,9 @@ func (s *Scanner) Scan() (*types.Payload, error) {
 				"STACK_ANALYZER_MAVEN_TOKEN (or configure settings.xml credentials).")
 	}
 
-	// Report scan complete
+	// Report scan complete, then the directories that were slow to process
 	s.progress.ScanComplete(fileCount, componentCount, time.Since(startTime))
+	s.reportSlowDirectories()
 
 	return payload, nil
 }
@@ -627,6 +892,19 @@ func (s *Scanner) countTechs(payload *types.Payload) (int, int) {
 	return len(primaryTechs), len(allTechs)
 }
 
+// rulesHash returns a digest of the detection rules in effect, built-in and
+// custom, so outputs of the same tree can be told apart by their rule set.
+func (s *Scanner) rulesHash() string {
+	rules := slices.Clone(s.rules)
+	slices.SortStableFunc(rules, func(a, b types.Rule) int { return strings.Compare(a.Tech, b.Tech) })
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
 // collectTechs helper to recursively collect tech names
 func (s *Scanner) collectTechs(payload *types.Payload, primaryTechs, allTechs map[string]bool) {
 	for _, tech := range payload.Tech {
@@ -682,6 +960,12 @@ func (s *Scanner) ScanFile(fileName string) (*types.Payload, error) {
 		s.collectCodeStats(filePath, result.Language, result.TypeOverride, content, payload)
 	}
 
+	s.applyScopeOverrides(payload)
+	s.resolveTechRelations(payload)
+	s.scoreTechs(payload)
+	s.mergeImplicitComponents(payload)
+	s.consolidateReasons(payload)
+
 	// Add metadata for single file scan
 	scanMeta := metadata.NewScanMetadata(basePath, spec.Version)
 	fileCount, componentCount := s.countFilesAndComponents(payload)
@@ -690,11 +974,15 @@ func (s *Scanner) ScanFile(fileName string) (*types.Payload, error) {
 	techCount, techsCount := s.countTechs(payload)
 	scanMeta.SetLanguageCount(languageCount)
 	scanMeta.SetTechCounts(techCount, techsCount)
+	scanMeta.SetRulesHash(s.rulesHash())
+	s.recordDetectorStats(scanMeta)
+	scanMeta.AddSkippedFiles(s.skippedFiles)
 
 	// Attach metadata to root payload
 	payload.Metadata = scanMeta
 
-	// Assign unique IDs to the payload tree
+	// Name components, then assign unique IDs to the payload tree
+	s.nameComponents(payload)
 	payload.AssignIDs(s.resolveRootID(basePath))
 
 	return payload, nil
@@ -718,29 +1006,80 @@ func (s *Scanner) resolveRootID(basePath string) string {
 	return git.GenerateRootIDFromPath(basePath)
 }
 
-// processFile handles language detection and code statistics for a single file
-func (s *Scanner) processFile(ctx *types.Payload, dirPath string, fileName string) {
-	fileFullPath := filepath.Join(dirPath, fileName)
-	content, err := s.provider.ReadFile(fileFullPath)
-	if err != nil {
-		content = []byte{} // Empty content on error
-	}
+// processFile handles language detection and code statistics for a single
+// file. Files the skip policy excludes or sniffed as binary are detected by
+// name only and left out of the content-based sections; only those too
+// large to be read may still be sampled for code statistics.
+func (s *Scanner) processFile(ctx *types.Payload, dirPath string, file types.File) {
+	fileFullPath := filepath.Join(dirPath, file.Name)
+	content, skipReason := s.readScannedFile(fileFullPath, file)
 
 	// Detect language (with potential reclassify override)
-	result := s.langDetector.DetectLanguageWithType(fileFullPath, content)
+	detectContent := content
+	if skipReason != "" {
+		detectContent = nil
+	}
+	result := s.langDetector.DetectLanguageWithType(fileFullPath, detectContent)
 	if result.Language != "" {
 		ctx.AddLanguage(result.Language)
 	}
 
-	// Collect code statistics if enabled
-	if s.codeStats != nil {
-		s.collectCodeStats(fileFullPath, result.Language, result.TypeOverride, content, ctx)
-	}
-
 	// Collect file-level observations if enabled
 	if s.observations != nil {
 		s.observations.Observe(fileFullPath, content, result.TypeOverride)
 	}
+
+	s.recordTestFile(ctx, fileFullPath)
+	s.recordAIModelFile(ctx, fileFullPath, file.Size)
+	if s.codeStats != nil {
+		s.collectFileCodeStats(ctx, fileFullPath, file, result, content, skipReason)
+	}
+	if skipReason != "" {
+		return
+	}
+	s.recordContentSections(ctx, fileFullPath, content)
+}
+
+// recordContentSections hands the content of a file to the per-file
+// section recorders.
+func (s *Scanner) recordContentSections(ctx *types.Payload, fileFullPath string, content []byte) {
+	s.recordBodyLogging(ctx, fileFullPath, content)
+	s.recordAIUsage(ctx, fileFullPath, content)
+	s.recordJavaImports(ctx, fileFullPath, content)
+	s.recordExternalAPIs(ctx, fileFullPath, content)
+	s.recordMainframeAsset(ctx, fileFullPath, content)
+	s.recordDatabaseCode(ctx, fileFullPath, content)
+	s.recordLocalization(ctx, fileFullPath, content)
+	s.recordAttribution(ctx, fileFullPath, content)
+	s.recordProxyConfig(ctx, fileFullPath, content)
+	s.recordNetwork(ctx, fileFullPath, content)
+	s.recordSchedules(ctx, fileFullPath, content)
+	s.recordPlatforms(ctx, fileFullPath, content)
+	s.recordRelease(ctx, fileFullPath, content)
+	s.recordPublishing(ctx, fileFullPath, content)
+	s.recordDeployment(ctx, fileFullPath, content)
+	s.recordEnvironments(ctx, fileFullPath, content)
+	s.recordArtifact(ctx, fileFullPath, content)
+	s.recordEntrypoints(ctx, fileFullPath, content)
+	s.recordHealth(ctx, fileFullPath, content)
+	s.recordWarehouse(ctx, fileFullPath, content)
+	s.recordConfigAudit(ctx, fileFullPath, content)
+}
+
+// collectFileCodeStats collects the code statistics of a file: notebooks
+// count their code cells, files over the sampling threshold are estimated
+// from a sample, and files whose content is skipped are not counted.
+func (s *Scanner) collectFileCodeStats(ctx *types.Payload, filePath string, file types.File, result LanguageResult, content []byte, skipReason string) {
+	if skipReason == "" && s.collectNotebookStats(filePath, result.TypeOverride, content, ctx) {
+		return
+	}
+	if sample := s.codeStatsSample(filePath, file, content, skipReason); sample != nil &&
+		s.collectSampledCodeStats(filePath, result.Language, result.TypeOverride, sample, file.Size, ctx) {
+		return
+	}
+	if skipReason == "" {
+		s.collectCodeStats(filePath, result.Language, result.TypeOverride, content, ctx)
+	}
 }
 
 // collectCodeStats dispatches a single ProcessFile call with the resolved component and subsystem keys.
@@ -830,13 +1169,19 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 	}
 	filteredFiles := s.filterIgnoredFiles(files, filePath)
 
+	// Directories leading to the include paths only lead the way down.
+	if !s.isWithinIncludePath(filePath) {
+		s.processDirectoryEntries(payload, filePath, directoriesOnly(filteredFiles))
+		return nil
+	}
+
 	s.progress.FolderFileProcessingStart(filePath)
 
 	// Apply rules to detect technologies. This might return a different context
 	// if a component was detected.
 	t3 := time.Now()
 	ctx := s.applyRules(payload, filteredFiles, filePath)
-	if time.Since(t3) > 100*time.Millisecond {
+	if time.Since(t3) > s.slowRulesThreshold() {
 		slog.Debug("Applied rules (slow)", "path", filePath, "duration", time.Since(t3))
 	}
 
@@ -846,17 +1191,45 @@ func (s *Scanner) recurse(payload *types.Payload, filePath string) error {
 	s.licenseDetector.AddLicensesToPayload(ctx, filePath)
 
 	s.progress.FolderFileProcessingEnd(filePath)
-	if time.Since(tEnter) > 500*time.Millisecond {
-		slog.Debug("Directory processing slow", "path", filePath, "total_duration", time.Since(tEnter))
-	}
+	own := time.Since(tEnter)
 
-	s.processDirectoryEntries(ctx, filePath, filteredFiles)
+	own += s.processDirectoryEntries(ctx, filePath, filteredFiles)
+	s.recordDirTiming(filePath, own)
 
 	// Note: Do NOT combine ctx back to payload. Components remain separate with
 	// their own dependencies; extension reasons are handled by the AddTech fix.
 	return nil
 }
 
+// countDirectories counts the directories recurse will enter below basePath.
+// The exclude patterns and the root .gitignore apply, nested .gitignore files
+// do not, so the count can run over.
+func (s *Scanner) countDirectories(basePath string) int {
+	if s.gitignoreStack.LoadAndPushGitignore(basePath) {
+		defer s.gitignoreStack.PopGitignore()
+	}
+	return s.countDirectoriesIn(basePath)
+}
+
+func (s *Scanner) countDirectoriesIn(dirPath string) int {
+	files, err := s.provider.ListDir(dirPath)
+	if err != nil {
+		return 1
+	}
+	count := 1
+	for _, file := range files {
+		if file.Type == "file" {
+			continue
+		}
+		subPath := filepath.Join(dirPath, file.Name)
+		if s.shouldSkipDirectory(file.Name, dirPath, subPath) {
+			continue
+		}
+		count += s.countDirectoriesIn(subPath)
+	}
+	return count
+}
+
 // filterIgnoredFiles drops files that match active ignore patterns so rule
 // matching never sees excluded files. Directories are always kept (their own
 // exclusion is decided during recursion).
@@ -898,12 +1271,19 @@ func shouldAttributeGit(parentGit, gitInfo *git.GitInfo) bool {
 	return parentGit == nil || parentGit.RemoteURL != gitInfo.RemoteURL
 }
 
+func directoriesOnly(files []types.File) []types.File {
+	return slices.DeleteFunc(slices.Clone(files), func(f types.File) bool { return f.Type == "file" })
+}
+
 // processDirectoryEntries processes each file in the directory and recurses
-// into non-excluded subdirectories.
-func (s *Scanner) processDirectoryEntries(ctx *types.Payload, filePath string, files []types.File) {
+// into non-excluded subdirectories. It returns the time spent on the files.
+func (s *Scanner) processDirectoryEntries(ctx *types.Payload, filePath string, files []types.File) time.Duration {
+	var filesTime time.Duration
 	for _, file := range files {
 		if file.Type == "file" {
-			s.processFile(ctx, filePath, file.Name)
+			start := time.Now()
+			s.processFile(ctx, filePath, file)
+			filesTime += time.Since(start)
 			continue
 		}
 		subPath := filepath.Join(filePath, file.Name)
@@ -915,6 +1295,7 @@ func (s *Scanner) processDirectoryEntries(ctx *types.Payload, filePath string, f
 			continue
 		}
 	}
+	return filesTime
 }
 
 // applyRules applies all detection rules to the current directory's files
@@ -931,7 +1312,7 @@ func (s *Scanner) applyRules(payload *types.Payload, files []types.File, current
 	matchedTechs := s.detectByFilesAndExtensions(ctx, files, currentPath)
 
 	// 4. File-based rule detection
-	s.detectByRuleFiles(ctx, files, matchedTechs)
+	s.detectByRuleFiles(ctx, files, currentPath, matchedTechs)
 
 	return ctx
 }
@@ -941,9 +1322,12 @@ func (s *Scanner) detectComponents(payload, ctx *types.Payload, files []types.Fi
 	var virtualComponents []*types.Payload
 
 	// Collect all components from all detectors
-	for _, detector := range components.GetDetectors() {
-		detectedComponents := detector.Detect(files, currentPath, s.provider.GetBasePath(), s.provider, s.depDetector)
+	for _, detector := range s.componentDetectors() {
+		detectedComponents := s.runDetector(detector, files, currentPath)
 		for _, component := range detectedComponents {
+			s.depDetector.ApplyMatchesToPayload(component, s.depDetector.MatchVersionedDependencies(component.Dependencies))
+			s.dropVetoedTechs(component, files, currentPath)
+
 			// Note: Components should NOT get git info by default
 			// Git info is only added at directory level when component is in a different repository
 			// This prevents redundant git info for components in the same repo as their parent
@@ -966,10 +1350,20 @@ func (s *Scanner) detectComponents(payload, ctx *types.Payload, files []types.Fi
 		return ctx
 	}
 
+	// A tech detected as a named component of its own (e.g. a Drupal site
+	// next to its composer.json component) is linked to it rather than
+	// getting an implicit component as well.
+	explicit := make(map[string]*types.Payload)
+	for _, component := range namedComponents {
+		if s.isComponentTech(component.ComponentType) {
+			explicit[component.ComponentType] = component
+		}
+	}
+
 	// Add each component separately (don't merge)
 	// This preserves architectural clarity and dependency tracking
 	for _, component := range namedComponents {
-		ctx = s.addNamedComponent(payload, component, currentPath)
+		ctx = s.addNamedComponent(payload, component, currentPath, explicit)
 	}
 
 	return ctx
@@ -985,7 +1379,7 @@ func (s *Scanner) mergeVirtualPayload(target, virtual *types.Payload, currentPat
 	}
 }
 
-func (s *Scanner) addNamedComponent(payload, component *types.Payload, currentPath string) *types.Payload {
+func (s *Scanner) addNamedComponent(payload, component *types.Payload, currentPath string, explicit map[string]*types.Payload) *types.Payload {
 	payload.AddChild(component)
 
 	// Report component detection
@@ -994,6 +1388,12 @@ func (s *Scanner) addNamedComponent(payload, component *types.Payload, currentPa
 	}
 
 	for _, tech := range component.Techs {
+		if target := explicit[tech]; target != nil {
+			if target != component {
+				component.AddEdges(target)
+			}
+			continue
+		}
 		s.findImplicitComponentByTech(component, tech, currentPath, true)
 	}
 	return component
@@ -1001,6 +1401,9 @@ func (s *Scanner) addNamedComponent(payload, component *types.Payload, currentPa
 
 func (s *Scanner) detectDotenv(ctx *types.Payload, files []types.File, currentPath string) {
 	dotenvPayload := s.dotenvDetector.DetectInDotEnv(files, currentPath, s.provider.GetBasePath())
+	if dotenvPayload != nil {
+		s.dropVetoedTechs(dotenvPayload, files, currentPath)
+	}
 	s.processDetectedComponent(ctx, dotenvPayload, currentPath)
 }
 
@@ -1021,11 +1424,13 @@ func (s *Scanner) detectByFilesAndExtensions(ctx *types.Payload, files []types.F
 	matchedTechs := make(map[string]bool)
 
 	// File-based detection
-	fileMatches := matchers.MatchFiles(files, currentPath, s.provider.GetBasePath())
+	fileMatches := matchers.MatchFilesWith(s.fileMatchers, files, currentPath, s.provider.GetBasePath())
+	s.dropVetoedMatches(fileMatches, files, currentPath)
 	s.processTechMatches(ctx, fileMatches, matchedTechs, currentPath, true)
 
 	// Extension-based detection (only for rules without content requirements)
 	extensionMatches := matchers.MatchExtensions(files)
+	s.dropVetoedMatches(extensionMatches, files, currentPath)
 	s.processTechMatches(ctx, extensionMatches, matchedTechs, currentPath, false)
 
 	// Content-based detection (for rules WITH content requirements)
@@ -1045,17 +1450,19 @@ func (s *Scanner) detectByContent(ctx *types.Payload, files []types.File, curren
 			continue
 		}
 
-		if !s.shouldCheckFileContent(file) {
+		// Media, archives and oversized files are never matched
+		if !s.shouldCheckFileContent(file) || s.policySkipReason(file) != "" {
 			continue
 		}
 
 		filePath := filepath.Join(currentPath, file.Name)
 		content, err := s.provider.ReadFile(filePath)
-		if err != nil {
+		if err != nil || enry.IsBinary(content) {
 			continue
 		}
 
 		contentMatches := s.matchFileContent(file, string(content))
+		s.dropVetoedMatches(contentMatches, files, currentPath)
 		s.processContentMatches(ctx, contentMatches, matchedTechs, filePath, currentPath)
 	}
 }
@@ -1132,12 +1539,12 @@ func (s *Scanner) processTechMatches(ctx *types.Payload, matches map[string][]st
 }
 
 // detectByRuleFiles matches rules that have specific file requirements
-func (s *Scanner) detectByRuleFiles(ctx *types.Payload, files []types.File, matchedTechs map[string]bool) {
+func (s *Scanner) detectByRuleFiles(ctx *types.Payload, files []types.File, currentPath string, matchedTechs map[string]bool) {
 	for _, rule := range s.rules {
 		if len(rule.Files) == 0 || matchedTechs[rule.Tech] {
 			continue
 		}
-		if s.matchRuleFiles(rule, files) {
+		if s.matchRuleFiles(rule, files) && !s.vetoed(rule.Tech, files, currentPath) {
 			reason := fmt.Sprintf("matched file: %s", rule.Files[0])
 			// Report rule match for tracing
 			s.progress.RuleResult(rule.Tech, true, reason)
@@ -1169,6 +1576,16 @@ func (s *Scanner) findImplicitComponentByTech(payload *types.Payload, tech strin
 	}
 }
 
+// isComponentTech reports whether the rule of a tech creates components.
+func (s *Scanner) isComponentTech(tech string) bool {
+	for _, rule := range s.rules {
+		if rule.Tech == tech {
+			return ShouldCreateComponent(rule)
+		}
+	}
+	return false
+}
+
 // findImplicitComponent creates a child component for technologies that are not in the notAComponent set
 // findImplicitComponent creates a child component for rules that define component creation
 func (s *Scanner) findImplicitComponent(payload *types.Payload, rule types.Rule, currentPath string, addEdges bool) {
@@ -1177,6 +1594,11 @@ func (s *Scanner) findImplicitComponent(payload *types.Payload, rule types.Rule,
 	if !ShouldCreateComponent(rule) {
 		return
 	}
+	// A component detected as the tech itself, such as a CMake project
+	// for cplusplus, already stands for it.
+	if payload.ComponentType == rule.Tech {
+		return
+	}
 
 	// Create a new child component using parent's path (not currentPath)
 	component := types.NewPayload(rule.Name, payload.Path)
@@ -1190,6 +1612,10 @@ func (s *Scanner) findImplicitComponent(payload *types.Payload, rule types.Rule,
 
 	component.AddReason(fmt.Sprintf("matched file: %s", currentPath))
 
+	// The component stands for the evidence found in the parent, so it is
+	// exactly as certain as that evidence.
+	component.SetTechConfidence(rule.Tech, techConfidence(payload.Reason[rule.Tech]))
+
 	// Add the component as a child
 	payload.AddChild(component)
 
@@ -57,6 +57,8 @@ func TestNewScanner_InvalidPath(t *testing.T) {
 	if err != nil {
 		assert.Error(t, err, "Should return error for non-existent path")
 		assert.Contains(t, err.Error(), "no such file or directory", "Error should mention path issue")
+		assert.ErrorIs(t, err, types.ErrPathNotFound)
+		assert.Equal(t, types.ErrorCategoryPathNotFound, types.ErrorCategory(err))
 	} else {
 		// If the implementation is more permissive, at least verify it doesn't panic
 		// and handles the invalid path gracefully
@@ -309,6 +311,34 @@ requests==2.26.0`
 	t.Logf("Components test result - Techs: %v", result.Techs)
 }
 
+func TestScanner_detectComponents_NamedCMSComponent(t *testing.T) {
+	tempDir := t.TempDir()
+	composerJSON := `{"name": "myorg/mysite", "require": {"drupal/core-recommended": "^10.2", "drupal/token": "^1.13"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "composer.json"), []byte(composerJSON), 0644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	// The Drupal site is a component of its own, linked from the composer
+	// component instead of duplicated as an implicit child of it.
+	var drupal, php *types.Payload
+	for _, child := range result.Children {
+		switch child.ComponentType {
+		case "drupal":
+			drupal = child
+		case "php":
+			php = child
+		}
+	}
+	require.NotNil(t, drupal)
+	require.NotNil(t, php)
+	assert.Empty(t, php.Children)
+	require.Len(t, php.Edges, 1)
+	assert.True(t, php.Edges[0].Target == drupal)
+}
+
 func TestScanner_mergeComponents(t *testing.T) {
 	// Create temporary directory
 	tempDir, err := os.MkdirTemp("", "scanner-test-merge")
@@ -588,6 +618,33 @@ func TestScanner_MultiPathScan(t *testing.T) {
 	assert.NotContains(t, allTechs, "python", "proj3 techs should not be detected")
 }
 
+func TestScanner_IncludePathsSkipAncestorFiles(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"requirements.txt":          "flask==2.0.1\n",
+		"services/Dockerfile":       "FROM alpine\n",
+		"services/api/package.json": `{"name": "api", "dependencies": {"express": "4.18.0"}}`,
+		"services/web/go.mod":       "module example.com/web\n\ngo 1.21\n",
+	} {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	s.SetIncludePaths([]string{filepath.Join("services", "api")})
+
+	result, err := s.Scan()
+	require.NoError(t, err)
+	allTechs := collectAllTechs(result)
+
+	assert.Contains(t, allTechs, "express")
+	assert.NotContains(t, allTechs, "flask", "files of the scan root are not analyzed")
+	assert.NotContains(t, allTechs, "docker", "files of directories above the include path are not analyzed")
+	assert.NotContains(t, allTechs, "golang", "sibling subtrees are not scanned")
+}
+
 // collectAllTechs recursively collects all tech identifiers from a payload tree
 func collectAllTechs(p *types.Payload) []string {
 	techs := make([]string, 0)
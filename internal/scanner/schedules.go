@@ -0,0 +1,83 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// recordSchedules collects the scheduled jobs a file defines.
+func (s *Scanner) recordSchedules(ctx *types.Payload, filePath string, content []byte) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	rel = "/" + filepath.ToSlash(rel)
+	jobs := parseScheduleFile(rel, content)
+	if len(jobs) == 0 {
+		return
+	}
+	if s.schedules == nil {
+		s.schedules = make(map[*types.Payload][]parsers.ScheduledJob)
+	}
+	for _, job := range jobs {
+		job.File = rel
+		s.schedules[ctx] = append(s.schedules[ctx], job)
+	}
+}
+
+// parseScheduleFile dispatches a file to the schedule parser for its kind:
+// crontabs (crontab, *.cron, cron.d/*), Kubernetes CronJobs, GitHub Actions
+// workflows, Spring and Quartz code and configuration, Celery beat
+// configuration and Terraform.
+func parseScheduleFile(rel string, content []byte) []parsers.ScheduledJob {
+	parser := parsers.NewSchedulesParser()
+	switch strings.ToLower(path.Ext(rel)) {
+	case ".yml", ".yaml":
+		if strings.Contains(rel, "/.github/workflows/") {
+			return parser.ParseWorkflowSchedules(content)
+		}
+		if parser.IsKubernetesCronJob(content) {
+			return parser.ParseKubernetesCronJobs(content)
+		}
+	case ".java", ".kt", ".xml":
+		return parser.ParseJVMSchedules(string(content))
+	case ".py":
+		if parser.IsCeleryBeatConfig(string(content)) {
+			return parser.ParseCeleryBeat(string(content))
+		}
+	case ".tf":
+		return parser.ParseTerraformSchedules(content)
+	default:
+		return parseCrontabFile(parser, rel, content)
+	}
+	return nil
+}
+
+func parseCrontabFile(parser *parsers.SchedulesParser, rel string, content []byte) []parsers.ScheduledJob {
+	name := strings.ToLower(path.Base(rel))
+	switch {
+	case name == "crontab" || name == "crontab.txt" || strings.HasSuffix(name, ".cron") || strings.HasSuffix(name, ".crontab"):
+		return parser.ParseCrontab(string(content), false)
+	case path.Base(path.Dir(rel)) == "cron.d":
+		return parser.ParseCrontab(string(content), true)
+	}
+	return nil
+}
+
+// attachSchedules adds a "schedules" property listing the periodic jobs
+// defined in each component.
+func (s *Scanner) attachSchedules(payload *types.Payload) {
+	if jobs := s.schedules[payload]; len(jobs) > 0 {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["schedules"] = jobs
+	}
+	for _, child := range payload.Children {
+		s.attachSchedules(child)
+	}
+}
@@ -0,0 +1,43 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachSchedules(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write(".github/workflows/nightly.yml", "name: Nightly\non:\n  schedule:\n    - cron: '0 2 * * *'\njobs: {}\n")
+	write("worker/requirements.txt", "celery\n")
+	write("worker/celeryconfig.py", "beat_schedule = {\n    'cleanup': {'task': 'worker.tasks.cleanup', 'schedule': 3600.0},\n}\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	rootJobs, ok := result.Properties["schedules"].([]parsers.ScheduledJob)
+	require.True(t, ok, "expected the workflow schedule on the root")
+	assert.Equal(t, []parsers.ScheduledJob{{Name: "Nightly", Schedule: "0 2 * * *", Source: "github-actions", File: "/.github/workflows/nightly.yml"}}, rootJobs)
+
+	var worker *types.Payload
+	for _, child := range result.Children {
+		if _, ok := child.Properties["schedules"]; ok {
+			worker = child
+		}
+	}
+	require.NotNil(t, worker, "expected the Celery beat schedule on the worker component")
+	assert.Equal(t, []parsers.ScheduledJob{{Name: "cleanup", Schedule: "3600.0", Command: "worker.tasks.cleanup", Source: "celery", File: "/worker/celeryconfig.py"}},
+		worker.Properties["schedules"])
+}
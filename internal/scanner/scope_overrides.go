@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// applyScopeOverrides re-maps the dependency scopes of every component per
+// the scopes section of the configuration. A dependency is looked up by the
+// scope its manifest declared when that was normalized lossily (Maven
+// provided, Gradle compileOnly), otherwise by its normalized scope. The
+// edges from a component to its re-mapped direct dependencies follow.
+func (s *Scanner) applyScopeOverrides(root *types.Payload) {
+	if s.config == nil || len(s.config.Scopes) == 0 {
+		return
+	}
+	overrideScopes(root, s.config.Scopes)
+}
+
+func overrideScopes(p *types.Payload, overrides config.ScopeOverrides) {
+	remapped := make(map[string]string) // name@version -> scope
+	for i := range p.Dependencies {
+		dep := &p.Dependencies[i]
+		scope, ok := overriddenScope(*dep, overrides)
+		if !ok || scope == dep.Scope {
+			continue
+		}
+		dep.Scope = scope
+		if dep.Direct {
+			remapped[dep.Name+"@"+dep.Version] = scope
+		}
+	}
+	for i := range p.DependencyEdges {
+		if scope, ok := remapped[p.DependencyEdges[i].To]; ok && p.DependencyEdges[i].From == "." {
+			p.DependencyEdges[i].Scope = scope
+		}
+	}
+	for _, child := range p.Children {
+		overrideScopes(child, overrides)
+	}
+}
+
+// overriddenScope returns the scope the overrides map a dependency to.
+func overriddenScope(dep types.Dependency, overrides config.ScopeOverrides) (string, bool) {
+	scopes, ok := overrides[dep.Type]
+	if !ok {
+		return "", false
+	}
+	if native := parsers.NativeScope(dep); native != "" {
+		if scope, ok := scopes[native]; ok {
+			return scope, true
+		}
+	}
+	scope, ok := scopes[dep.Scope]
+	return scope, ok
+}
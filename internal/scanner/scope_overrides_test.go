@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrideScopes(t *testing.T) {
+	component := types.NewPayloadWithPath("api", "/api")
+	component.Dependencies = []types.Dependency{
+		{Type: "maven", Name: "javax.servlet:servlet-api", Version: "2.5", Scope: types.ScopeProd, Direct: true, Metadata: map[string]interface{}{"native_scope": "provided"}},
+		{Type: "maven", Name: "com.google.guava:guava", Version: "33.0", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "fsevents", Version: "2.3.3", Scope: types.ScopeOptional, Direct: true},
+	}
+	component.DependencyEdges = []types.DependencyEdge{
+		{From: ".", To: "fsevents@2.3.3", Scope: types.ScopeOptional},
+		{From: "chokidar@3.6.0", To: "fsevents@2.3.3", Scope: types.ScopeOptional},
+	}
+	root := types.NewPayloadWithPath("main", "/")
+	root.AddChild(component)
+
+	overrideScopes(root, config.ScopeOverrides{
+		"maven": {"provided": types.ScopeBuild},
+		"npm":   {types.ScopeOptional: types.ScopeProd},
+	})
+
+	assert.Equal(t, types.ScopeBuild, component.Dependencies[0].Scope)
+	assert.Equal(t, types.ScopeProd, component.Dependencies[1].Scope, "compile scope is not overridden")
+	assert.Equal(t, types.ScopeProd, component.Dependencies[2].Scope)
+	assert.Equal(t, types.ScopeProd, component.DependencyEdges[0].Scope, "the root edge follows its dependency")
+	assert.Equal(t, types.ScopeOptional, component.DependencyEdges[1].Scope)
+}
+
+func TestScan_ScopeOverrides(t *testing.T) {
+	root := t.TempDir()
+	pom := `<project><groupId>com.example</groupId><artifactId>myapp</artifactId><version>1.0</version>
+<dependencies>
+  <dependency><groupId>javax.servlet</groupId><artifactId>servlet-api</artifactId><version>2.5</version><scope>provided</scope></dependency>
+  <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13.2</version><scope>test</scope></dependency>
+</dependencies></project>`
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pom.xml"), []byte(pom), 0o644))
+
+	cfg := &config.ScanConfig{Scopes: config.ScopeOverrides{"maven": {"provided": types.ScopeBuild, "test": types.ScopeTest}}}
+	s, err := NewScannerWithOptionsAndLogger(root, nil, true, false, false, false, false, nil, nil, "", cfg)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	scopes := make(map[string]string)
+	var walk func(p *types.Payload)
+	walk = func(p *types.Payload) {
+		for _, dep := range p.Dependencies {
+			scopes[dep.Name] = dep.Scope
+		}
+		for _, child := range p.Children {
+			walk(child)
+		}
+	}
+	walk(payload)
+	assert.Equal(t, map[string]string{"javax.servlet:servlet-api": types.ScopeBuild, "junit:junit": types.ScopeTest}, scopes)
+}
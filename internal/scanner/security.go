@@ -0,0 +1,121 @@
+package scanner
+
+import (
+	"sort"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// authProviderTechs are the identity provider techs reported in the
+// security section.
+var authProviderTechs = map[string]bool{
+	parsers.AuthProviderKeycloak:     true,
+	parsers.AuthProviderAuth0:        true,
+	parsers.AuthProviderOkta:         true,
+	parsers.AuthProviderCognito:      true,
+	parsers.AuthProviderFirebaseAuth: true,
+}
+
+// SecurityInfo is the security section of a component.
+type SecurityInfo struct {
+	AuthProviders []AuthProviderInfo `json:"auth_providers"`
+}
+
+// AuthProviderInfo describes one identity provider a component integrates
+// with. Hosts, realms and files come from configuration evidence; a
+// provider detected from dependencies alone has none of them.
+type AuthProviderInfo struct {
+	Provider string   `json:"provider"`
+	Hosts    []string `json:"hosts,omitempty"`
+	Realms   []string `json:"realms,omitempty"`
+	Files    []string `json:"files,omitempty"`
+}
+
+// attachSecurity adds a "security" property to every component integrating
+// with an identity provider, folding in the configuration evidence recorded
+// by the authprovider detector.
+func (s *Scanner) attachSecurity(payload *types.Payload) {
+	if info := securityInfo(payload); info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["security"] = info
+	}
+	delete(payload.Properties, "auth_evidence")
+	for _, child := range payload.Children {
+		s.attachSecurity(child)
+	}
+}
+
+// securityInfo builds the security section of one component, or nil when it
+// uses no identity provider.
+func securityInfo(payload *types.Payload) *SecurityInfo {
+	providers := make(map[string]*authProviderSet)
+	get := func(name string) *authProviderSet {
+		if providers[name] == nil {
+			providers[name] = newAuthProviderSet()
+		}
+		return providers[name]
+	}
+	for _, tech := range payload.Techs {
+		if authProviderTechs[tech] {
+			get(tech)
+		}
+	}
+	evidence, _ := payload.Properties["auth_evidence"].([]interface{})
+	for _, item := range evidence {
+		if ev, ok := item.(parsers.AuthEvidence); ok {
+			get(ev.Provider).add(ev)
+		}
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+
+	info := &SecurityInfo{}
+	for name, set := range providers {
+		info.AuthProviders = append(info.AuthProviders, AuthProviderInfo{
+			Provider: name,
+			Hosts:    sortedSet(set.hosts),
+			Realms:   sortedSet(set.realms),
+			Files:    sortedSet(set.files),
+		})
+	}
+	sort.Slice(info.AuthProviders, func(i, j int) bool {
+		return info.AuthProviders[i].Provider < info.AuthProviders[j].Provider
+	})
+	return info
+}
+
+type authProviderSet struct {
+	hosts, realms, files map[string]bool
+}
+
+func newAuthProviderSet() *authProviderSet {
+	return &authProviderSet{hosts: map[string]bool{}, realms: map[string]bool{}, files: map[string]bool{}}
+}
+
+func (a *authProviderSet) add(ev parsers.AuthEvidence) {
+	if ev.Host != "" {
+		a.hosts[ev.Host] = true
+	}
+	if ev.Realm != "" {
+		a.realms[ev.Realm] = true
+	}
+	if ev.File != "" {
+		a.files[ev.File] = true
+	}
+}
+
+func sortedSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(set))
+	for v := range set {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachSecurity(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "package.json"),
+		[]byte(`{"name": "myapp", "dependencies": {"@okta/okta-auth-js": "^7.0.0", "express": "^4.18.0"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env.example"),
+		[]byte("AUTH0_ISSUER_BASE_URL=https://myapp.eu.auth0.com\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	// Configuration evidence merges into the directory's parent component,
+	// like other virtual detections; the npm dependency stays with myapp.
+	root, ok := result.Properties["security"].(*SecurityInfo)
+	require.True(t, ok, "expected a security section on the root component")
+	assert.NotContains(t, result.Properties, "auth_evidence")
+	assert.Equal(t, []AuthProviderInfo{
+		{Provider: "auth0", Hosts: []string{"myapp.eu.auth0.com"}, Files: []string{"/.env.example"}},
+	}, root.AuthProviders)
+
+	var app *SecurityInfo
+	for _, child := range result.Children {
+		if child.Name == "myapp" {
+			app, _ = child.Properties["security"].(*SecurityInfo)
+		}
+	}
+	require.NotNil(t, app)
+	assert.Contains(t, app.AuthProviders, AuthProviderInfo{Provider: "okta"})
+}
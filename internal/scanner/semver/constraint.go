@@ -0,0 +1,210 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a version range: comparisons (<, <=, >, >=, =, !=) separated
+// by spaces or commas must all hold, and groups of them separated by "||"
+// are alternatives. A version without an operator matches the versions it
+// is a prefix of, so "2" matches 2.7.14 and "2.6" matches 2.6.1.
+type Constraint struct {
+	expr   string
+	groups [][]comparison
+}
+
+type comparison struct {
+	op      string // "" for a prefix match
+	version string
+}
+
+// constraintOps are tried in order, so two-character operators win.
+var constraintOps = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// ParseConstraint parses a version range such as ">=2.6 <3" or "<3 || >=4".
+func ParseConstraint(expr string) (*Constraint, error) {
+	c := &Constraint{expr: strings.TrimSpace(expr)}
+	for _, group := range strings.Split(expr, "||") {
+		comparisons, err := parseComparisons(group)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", expr, err)
+		}
+		c.groups = append(c.groups, comparisons)
+	}
+	return c, nil
+}
+
+func parseComparisons(group string) ([]comparison, error) {
+	var comparisons []comparison
+	var op string
+	for _, field := range strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' }) {
+		for _, candidate := range constraintOps {
+			if strings.HasPrefix(field, candidate) {
+				op, field = candidate, field[len(candidate):]
+				break
+			}
+		}
+		if field == "" {
+			continue // an operator followed by a space, as in ">= 3"
+		}
+		if !startsWithVersionChar(field) {
+			return nil, fmt.Errorf("%q is not a version", field)
+		}
+		comparisons = append(comparisons, comparison{op: op, version: field})
+		op = ""
+	}
+	if len(comparisons) == 0 || op != "" {
+		return nil, fmt.Errorf("expected a version")
+	}
+	return comparisons, nil
+}
+
+// String returns the constraint as written.
+func (c *Constraint) String() string {
+	return c.expr
+}
+
+// Allows reports whether version satisfies the constraint, comparing with
+// system, or by numeric components when system is nil or cannot parse the
+// versions. A declared range ("^2.6.0", ">=2.6,<3") is checked by its lowest
+// version; versions that name none ("latest", "${vue.version}") never match.
+func (c *Constraint) Allows(system System, version string) bool {
+	version = LowestVersion(version)
+	if version == "" {
+		return false
+	}
+	for _, group := range c.groups {
+		if allowsAll(group, system, version) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowsAll(comparisons []comparison, system System, version string) bool {
+	for _, cmp := range comparisons {
+		if !cmp.allows(system, version) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c comparison) allows(system System, version string) bool {
+	if c.op == "" {
+		return hasVersionPrefix(version, c.version)
+	}
+	result := compareVersions(system, version, c.version)
+	switch c.op {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "!=":
+		return result != 0
+	}
+	return result == 0
+}
+
+// LowestVersion returns the concrete version of v, or for a range the
+// version it starts at ("^2.6.0" and ">=2.6,<3" give 2.6.0 and 2.6), or ""
+// when v names no version.
+func LowestVersion(v string) string {
+	if resolved := ResolvedVersion(v); resolved != "" {
+		return resolved
+	}
+	v = strings.TrimLeft(strings.TrimSpace(v), "^~>=v ")
+	if end := strings.IndexAny(v, " ,<|)]"); end >= 0 {
+		v = v[:end]
+	}
+	if !startsWithVersionChar(v) {
+		return ""
+	}
+	return v
+}
+
+// compareVersions compares two versions with system, falling back to their
+// numeric components.
+func compareVersions(system System, a, b string) int {
+	if system != nil {
+		va, errA := system.Parse(a)
+		vb, errB := system.Parse(b)
+		if errA == nil && errB == nil {
+			return va.Compare(vb)
+		}
+	}
+	na, nb := numericComponents(a), numericComponents(b)
+	for i := 0; i < len(na) || i < len(nb); i++ {
+		var x, y int
+		if i < len(na) {
+			x = na[i]
+		}
+		if i < len(nb) {
+			y = nb[i]
+		}
+		if x != y {
+			return compareInt(x, y)
+		}
+	}
+	return 0
+}
+
+// hasVersionPrefix reports whether the numeric components of version start
+// with those of prefix.
+func hasVersionPrefix(version, prefix string) bool {
+	nv, np := numericComponents(version), numericComponents(prefix)
+	if len(nv) < len(np) {
+		return false
+	}
+	for i := range np {
+		if nv[i] != np[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// numericComponents returns the leading numbers of the dot-separated
+// components of a version, up to the first one that does not start with a
+// digit: "v2.7.14-beta.1" gives [2 7 14].
+func numericComponents(version string) []int {
+	var numbers []int
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V"), ".") {
+		end := 0
+		for end < len(part) && isDigit(part[end]) {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		if end < len(part) {
+			break
+		}
+	}
+	return numbers
+}
+
+// ForDependencyType returns the versioning system of a dependency type, or
+// nil when there is none and versions compare by their numeric components.
+func ForDependencyType(depType string) System {
+	switch depType {
+	case "npm":
+		return NPM
+	case "pypi":
+		return PyPI
+	case "maven", "gradle":
+		return Maven
+	}
+	return nil
+}
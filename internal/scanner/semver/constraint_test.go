@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		system     System
+		version    string
+		want       bool
+	}{
+		{"<3", NPM, "2.7.14", true},
+		{"<3", NPM, "3.4.21", false},
+		{"<3", NPM, "^2.6.0", true},
+		{"<3", NPM, "~3.2.0", false},
+		{">=2.6 <3", NPM, "2.5.0", false},
+		{">= 2.6, < 3", NPM, "2.6.1", true},
+		{"<2 || >=4", NPM, "3.0.0", false},
+		{"<2 || >=4", NPM, "4.1.0", true},
+		{"!=3.0.0", NPM, "3.0.0", false},
+		{"=3.0.0", NPM, "3.0.0", true},
+		{"2", NPM, "2.7.14", true},
+		{"2", NPM, "12.0.0", false},
+		{"2.6", nil, "2.6.1", true},
+		{"2.6", nil, "2.7.0", false},
+		{"<3", Maven, "2.7.18", true},
+		{"<3", Maven, "3.2.0", false},
+		{">=2", PyPI, ">=2.1,<3", true},
+		{"<1.20", nil, "v1.19.3", true},
+		{"<1.20", nil, "v1.21.0", false},
+		{"<3", NPM, "latest", false},
+		{"<3", Maven, "${spring.version}", false},
+		{"<3", NPM, "", false},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q): %v", tt.constraint, err)
+		}
+		if got := c.Allows(tt.system, tt.version); got != tt.want {
+			t.Errorf("%q.Allows(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	for _, expr := range []string{"", "<", "<3 ||", ">=two", "3 <"} {
+		if _, err := ParseConstraint(expr); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// DefaultMaxFileSize is the size above which a file's content is not read
+// for content matching, code statistics and the per-file sections.
+const DefaultMaxFileSize int64 = 10 << 20
+
+// Reasons a file's content is skipped.
+const (
+	skipReasonBinary   = "binary"
+	skipReasonTooLarge = "too_large"
+	skipReasonType     = "type"
+)
+
+// DefaultSkipExtensions are the media, archive, font and executable types
+// whose content is never read: no rule matches their content and no
+// section parses them.
+var DefaultSkipExtensions = []string{
+	".png", ".jpg", ".jpeg", ".gif", ".bmp", ".ico", ".webp", ".tif", ".tiff", ".psd",
+	".mp3", ".mp4", ".mov", ".avi", ".mkv", ".webm", ".wav", ".flac", ".ogg",
+	".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".7z", ".rar", ".iso", ".dmg",
+	".exe", ".dll", ".so", ".dylib", ".bin", ".pdf",
+	".woff", ".woff2", ".ttf", ".otf", ".eot",
+}
+
+var defaultSkipExtensionSet = newExtensionSet(DefaultSkipExtensions)
+
+// SetSkipPolicy sets which files are listed but not read for content: files
+// larger than maxFileSize bytes (zero keeps DefaultMaxFileSize, a negative
+// size lifts the limit) and files with one of the extensions (empty keeps
+// DefaultSkipExtensions, "none" skips no type). Files read and sniffed as
+// binary are always skipped.
+func (s *Scanner) SetSkipPolicy(maxFileSize int64, extensions []string) {
+	s.maxFileSize = maxFileSize
+	s.skipExtensions = nil
+	if len(extensions) > 0 {
+		s.skipExtensions = newExtensionSet(extensions)
+	}
+}
+
+// newExtensionSet normalizes extensions to lower case with a leading dot,
+// dropping "none" and empty entries.
+func newExtensionSet(extensions []string) map[string]bool {
+	set := make(map[string]bool, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "none" {
+			continue
+		}
+		set["."+strings.TrimPrefix(ext, ".")] = true
+	}
+	return set
+}
+
+// policySkipReason returns why a listed file is not to be read, empty when
+// it is.
+func (s *Scanner) policySkipReason(file types.File) string {
+	extensions := s.skipExtensions
+	if extensions == nil {
+		extensions = defaultSkipExtensionSet
+	}
+	if extensions[strings.ToLower(filepath.Ext(file.Name))] {
+		return skipReasonType
+	}
+	limit := s.maxFileSize
+	if limit == 0 {
+		limit = DefaultMaxFileSize
+	}
+	if limit > 0 && file.Size > limit {
+		return skipReasonTooLarge
+	}
+	return ""
+}
+
+// readScannedFile reads a file unless the skip policy excludes it, and
+// returns why its content is to be skipped: excluded by the policy or
+// sniffed as binary, empty when it is not. The content of a binary file is
+// still returned.
+func (s *Scanner) readScannedFile(filePath string, file types.File) ([]byte, string) {
+	reason := s.policySkipReason(file)
+	var content []byte
+	if reason == "" {
+		var err error
+		if content, err = s.provider.ReadFile(filePath); err != nil {
+			return []byte{}, ""
+		}
+		if enry.IsBinary(content) {
+			reason = skipReasonBinary
+		}
+	}
+	if reason != "" {
+		s.countSkippedFile(filePath, file.Size, reason)
+	}
+	return content, reason
+}
+
+func (s *Scanner) countSkippedFile(filePath string, size int64, reason string) {
+	slog.Debug("Skipping file content", "path", filePath, "reason", reason, "size", size)
+	switch reason {
+	case skipReasonBinary:
+		s.skippedFiles.Binary++
+	case skipReasonTooLarge:
+		s.skippedFiles.TooLarge++
+	case skipReasonType:
+		s.skippedFiles.Type++
+	}
+	s.skippedFiles.Bytes += size
+}
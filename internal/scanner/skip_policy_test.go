@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/metadata"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+func TestSkipPolicyScan(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel string, content []byte) {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, rel), content, 0o644))
+	}
+	write("main.go", []byte("package main\n\nfunc main() {}\n"))
+	write("generated.go", []byte("package main\n\n// "+strings.Repeat("x", 2048)+"\n"))
+	write("logo.png", []byte("\x89PNG\r\n\x1a\n"))
+	write("server.go", []byte("\x00\x01\x02http.HandleFunc(\"/healthz\", handler)"))
+	write("embedder.onnx", []byte(strings.Repeat("\x00", 4096)))
+
+	s, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	s.SetSkipPolicy(1024, nil)
+	result, err := s.Scan()
+	require.NoError(t, err)
+
+	meta, ok := result.Metadata.(*metadata.ScanMetadata)
+	require.True(t, ok)
+	require.NotNil(t, meta.SkippedFiles)
+	assert.Equal(t, metadata.SkippedFiles{Binary: 1, TooLarge: 2, Type: 1, Bytes: 8 + 40 + 2065 + 4096}, *meta.SkippedFiles)
+
+	// Skipped files are still detected by name.
+	goFiles := 0
+	walkPayloads(result, func(p *types.Payload) { goFiles += p.Languages["Go"] })
+	assert.Equal(t, 3, goFiles)
+	assert.Nil(t, findComponentWithProperty(result, "health"), "binary content is not searched for routes")
+
+	// Model files keep their listed size.
+	info, ok := findComponentWithProperty(result, "ai_usage").Properties["ai_usage"].(*AIUsageInfo)
+	require.True(t, ok)
+	assert.Equal(t, []AIModelFile{{File: "/embedder.onnx", Format: "onnx", SizeBytes: 4096}}, info.ModelFiles)
+}
+
+func TestPolicySkipReason(t *testing.T) {
+	s := &Scanner{}
+	file := func(name string, size int64) types.File {
+		return types.File{Name: name, Type: "file", Size: size}
+	}
+
+	assert.Equal(t, skipReasonType, s.policySkipReason(file("Video.MP4", 10)))
+	assert.Equal(t, skipReasonTooLarge, s.policySkipReason(file("dump.sql", DefaultMaxFileSize+1)))
+	assert.Empty(t, s.policySkipReason(file("schema.sql", DefaultMaxFileSize)))
+
+	s.SetSkipPolicy(-1, []string{"SQL", ".csv"})
+	assert.Empty(t, s.policySkipReason(file("dump.json", DefaultMaxFileSize+1)), "negative size lifts the limit")
+	assert.Equal(t, skipReasonType, s.policySkipReason(file("dump.sql", 10)))
+	assert.Equal(t, skipReasonType, s.policySkipReason(file("rows.csv", 10)))
+	assert.Empty(t, s.policySkipReason(file("logo.png", 10)), "extensions replace the defaults")
+
+	s.SetSkipPolicy(0, []string{"none"})
+	assert.Empty(t, s.policySkipReason(file("logo.png", 10)))
+}
@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/progress"
+)
+
+// DefaultSlowDirThreshold is the time a directory's own processing (rules,
+// git info, licenses and its files, not its subdirectories) may take before
+// it counts as slow.
+const DefaultSlowDirThreshold = 500 * time.Millisecond
+
+// SetSlowDirThreshold sets the time above which a directory counts as slow:
+// it is logged at debug level and listed in the report after the scan. Zero
+// keeps DefaultSlowDirThreshold; a negative value turns the report off.
+func (s *Scanner) SetSlowDirThreshold(threshold time.Duration) {
+	s.slowDirThreshold = threshold
+}
+
+// slowDirLimit returns the slow-directory threshold in effect.
+func (s *Scanner) slowDirLimit() time.Duration {
+	if s.slowDirThreshold == 0 {
+		return DefaultSlowDirThreshold
+	}
+	return s.slowDirThreshold
+}
+
+// slowRulesThreshold is the time applying the rules to one directory may
+// take before it is logged at debug level, a fifth of the directory's.
+func (s *Scanner) slowRulesThreshold() time.Duration {
+	if s.slowDirLimit() < 0 {
+		return DefaultSlowDirThreshold / 5
+	}
+	return s.slowDirLimit() / 5
+}
+
+// recordDirTiming keeps a directory whose own processing took at least the
+// slow-directory threshold.
+func (s *Scanner) recordDirTiming(dirPath string, duration time.Duration) {
+	if limit := s.slowDirLimit(); limit < 0 || duration < limit {
+		return
+	}
+	slog.Debug("Directory processing slow", "path", dirPath, "total_duration", duration)
+	relPath, err := filepath.Rel(s.provider.GetBasePath(), dirPath)
+	if err != nil {
+		relPath = dirPath
+	}
+	relPath = filepath.ToSlash(relPath)
+	depth := 0
+	if relPath != "." {
+		depth = strings.Count(relPath, "/") + 1
+	}
+	s.slowDirs = append(s.slowDirs, progress.TimingEntry{Path: relPath, Duration: duration, Depth: depth})
+}
+
+// reportSlowDirectories reports the slow directories, slowest first.
+func (s *Scanner) reportSlowDirectories() {
+	if len(s.slowDirs) == 0 {
+		return
+	}
+	sort.SliceStable(s.slowDirs, func(i, j int) bool {
+		return s.slowDirs[i].Duration > s.slowDirs[j].Duration
+	})
+	s.progress.SlowDirectories(s.slowDirLimit(), s.slowDirs)
+}
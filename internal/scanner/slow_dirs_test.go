@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/progress"
+)
+
+// slowDirRecorder keeps the slow-directory report of a scan.
+type slowDirRecorder struct {
+	reports []progress.Event
+}
+
+func (r *slowDirRecorder) Handle(event progress.Event) {
+	if event.Type == progress.EventSlowDirectories {
+		r.reports = append(r.reports, event)
+	}
+}
+
+func TestSlowDirectoryReport(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "services", "api"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "services", "api", "package.json"), []byte(`{"name": "api"}`), 0o644))
+
+	scan := func(threshold time.Duration) *slowDirRecorder {
+		s, err := NewScanner(tempDir)
+		require.NoError(t, err)
+		recorder := &slowDirRecorder{}
+		s.SetProgressHandler(recorder)
+		s.SetSlowDirThreshold(threshold)
+		_, err = s.Scan()
+		require.NoError(t, err)
+		return recorder
+	}
+
+	// Every directory takes at least a nanosecond.
+	recorder := scan(time.Nanosecond)
+	require.Len(t, recorder.reports, 1)
+	report := recorder.reports[0]
+	assert.Equal(t, time.Nanosecond, report.Duration)
+	depths := map[string]int{}
+	for i, timing := range report.Timings {
+		depths[timing.Path] = timing.Depth
+		if i > 0 {
+			assert.LessOrEqual(t, timing.Duration, report.Timings[i-1].Duration, "slowest first")
+		}
+	}
+	assert.Equal(t, map[string]int{".": 0, "services": 1, "services/api": 2}, depths)
+
+	// A negative threshold turns the report off.
+	assert.Empty(t, scan(-1).reports)
+}
+
+func TestSlowDirLimit(t *testing.T) {
+	s := &Scanner{}
+	assert.Equal(t, DefaultSlowDirThreshold, s.slowDirLimit())
+	assert.Equal(t, DefaultSlowDirThreshold/5, s.slowRulesThreshold())
+
+	s.SetSlowDirThreshold(2 * time.Second)
+	assert.Equal(t, 2*time.Second, s.slowDirLimit())
+	assert.Equal(t, 400*time.Millisecond, s.slowRulesThreshold())
+
+	s.SetSlowDirThreshold(-1)
+	assert.Equal(t, DefaultSlowDirThreshold/5, s.slowRulesThreshold())
+}
@@ -0,0 +1,151 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/matchers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// detectionVetoes holds, by tech, the conditions under which a detected
+// tech is not reported for a directory: the unless conditions of its rules
+// and the suppress entries of the configuration.
+type detectionVetoes struct {
+	unless   map[string][]compiledUnless
+	suppress map[string][]string // path prefixes relative to the scan root; "" = everywhere
+}
+
+type compiledUnless struct {
+	files   []string
+	content []unlessContent
+}
+
+// unlessContent is a compiled unless content pattern and the files of a
+// directory it reads.
+type unlessContent struct {
+	files      []string
+	extensions []string
+	matcher    matchers.CompiledContentMatcher
+}
+
+func newDetectionVetoes(rules []types.Rule, suppress []config.SuppressRule) detectionVetoes {
+	v := detectionVetoes{unless: map[string][]compiledUnless{}, suppress: map[string][]string{}}
+	registry := matchers.NewContentTypeRegistry()
+	for _, rule := range rules {
+		if rule.Unless != nil {
+			v.unless[rule.Tech] = append(v.unless[rule.Tech], compileUnless(registry, rule.Tech, rule.Unless))
+		}
+	}
+	for _, entry := range suppress {
+		v.suppress[entry.Tech] = append(v.suppress[entry.Tech], normalizeSuppressPath(entry.Path))
+	}
+	return v
+}
+
+func compileUnless(registry *matchers.ContentTypeRegistry, tech string, unless *types.RuleUnless) compiledUnless {
+	c := compiledUnless{files: unless.Files}
+	for _, rule := range unless.Content {
+		compiled, err := registry.Compile(rule, tech)
+		if err != nil {
+			continue // Skip invalid patterns, as the content matchers do
+		}
+		c.content = append(c.content, unlessContent{files: rule.Files, extensions: rule.Extensions, matcher: compiled})
+	}
+	return c
+}
+
+// normalizeSuppressPath turns a suppress path ("./legacy/", "/legacy") into
+// the slash-separated prefix relative to the scan root ("legacy").
+func normalizeSuppressPath(p string) string {
+	return strings.Trim(path.Clean("/"+filepath.ToSlash(p)), "/")
+}
+
+// vetoed reports whether tech must not be reported for the directory at
+// currentPath holding files.
+func (s *Scanner) vetoed(tech string, files []types.File, currentPath string) bool {
+	if prefixes := s.vetoes.suppress[tech]; len(prefixes) > 0 && underAnyPrefix(s.scanRelativeDir(currentPath), prefixes) {
+		return true
+	}
+	return slices.ContainsFunc(s.vetoes.unless[tech], func(u compiledUnless) bool {
+		return s.unlessHolds(u, files, currentPath)
+	})
+}
+
+func (s *Scanner) scanRelativeDir(currentPath string) string {
+	rel, err := filepath.Rel(s.provider.GetBasePath(), currentPath)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+func underAnyPrefix(rel string, prefixes []string) bool {
+	return slices.ContainsFunc(prefixes, func(prefix string) bool {
+		return prefix == "" || rel == prefix || strings.HasPrefix(rel, prefix+"/")
+	})
+}
+
+// unlessHolds reports whether one of u's files exists among files, or one
+// of its content patterns matches one of them.
+func (s *Scanner) unlessHolds(u compiledUnless, files []types.File, currentPath string) bool {
+	for _, file := range files {
+		if file.Type != "file" {
+			continue
+		}
+		if slices.ContainsFunc(u.files, func(pattern string) bool {
+			matched, _ := filepath.Match(pattern, file.Name)
+			return matched
+		}) {
+			return true
+		}
+		if s.unlessContentMatches(u.content, file, currentPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scanner) unlessContentMatches(patterns []unlessContent, file types.File, currentPath string) bool {
+	var content []byte
+	for _, pattern := range patterns {
+		if !slices.Contains(pattern.files, file.Name) && !slices.Contains(pattern.extensions, filepath.Ext(file.Name)) {
+			continue
+		}
+		if content == nil {
+			var err error
+			if content, err = s.provider.ReadFile(filepath.Join(currentPath, file.Name)); err != nil {
+				return false
+			}
+		}
+		if matched, _ := pattern.matcher.Match(string(content)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// dropVetoedMatches removes the vetoed techs from matches.
+func (s *Scanner) dropVetoedMatches(matches map[string][]string, files []types.File, currentPath string) {
+	for tech := range matches {
+		if s.vetoed(tech, files, currentPath) {
+			delete(matches, tech)
+		}
+	}
+}
+
+// dropVetoedTechs removes the vetoed techs from a component detected in the
+// directory and from its children. The components themselves are kept.
+func (s *Scanner) dropVetoedTechs(p *types.Payload, files []types.File, currentPath string) {
+	for _, tech := range slices.Concat(p.Techs, p.Tech) {
+		if s.vetoed(tech, files, currentPath) {
+			p.RemoveTech(tech)
+		}
+	}
+	for _, child := range p.Children {
+		s.dropVetoedTechs(child, files, currentPath)
+	}
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/config"
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSuppressTestFiles(t *testing.T, dir string, files map[string]string) []types.File {
+	t.Helper()
+	var listed []types.File
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		if filepath.Dir(name) == "." {
+			listed = append(listed, types.File{Name: name, Type: "file"})
+		}
+	}
+	return listed
+}
+
+func suppressTestScanner(root string, rules []types.Rule, suppress []config.SuppressRule) *Scanner {
+	return &Scanner{provider: provider.NewFSProvider(root), vetoes: newDetectionVetoes(rules, suppress)}
+}
+
+func TestVetoed_UnlessFileExists(t *testing.T) {
+	root := t.TempDir()
+	rules := []types.Rule{{Tech: "dotnet", Unless: &types.RuleUnless{Files: []string{"*.uproject"}}}}
+	s := suppressTestScanner(root, rules, nil)
+
+	plain := writeSuppressTestFiles(t, root, map[string]string{"app.csproj": ""})
+	assert.False(t, s.vetoed("dotnet", plain, root))
+
+	game := writeSuppressTestFiles(t, root, map[string]string{"app.csproj": "", "Game.uproject": "{}"})
+	assert.True(t, s.vetoed("dotnet", game, root))
+	assert.False(t, s.vetoed("unity", game, root), "only the rule's own tech is vetoed")
+}
+
+func TestVetoed_UnlessContentMatches(t *testing.T) {
+	rules := []types.Rule{{Tech: "react", Unless: &types.RuleUnless{Content: []types.ContentRule{
+		{Type: "json-path", Path: "$.compilerOptions.jsx", Value: "react-native", Files: []string{"tsconfig.json"}},
+	}}}}
+
+	native := t.TempDir()
+	files := writeSuppressTestFiles(t, native, map[string]string{"tsconfig.json": `{"compilerOptions":{"jsx":"react-native"}}`})
+	assert.True(t, suppressTestScanner(native, rules, nil).vetoed("react", files, native))
+
+	web := t.TempDir()
+	files = writeSuppressTestFiles(t, web, map[string]string{"tsconfig.json": `{"compilerOptions":{"jsx":"react-jsx"}}`})
+	assert.False(t, suppressTestScanner(web, rules, nil).vetoed("react", files, web))
+}
+
+func TestVetoed_SuppressPathPrefix(t *testing.T) {
+	root := t.TempDir()
+	s := suppressTestScanner(root, nil, []config.SuppressRule{{Tech: "jquery", Path: "./legacy/"}, {Tech: "php"}})
+
+	assert.True(t, s.vetoed("jquery", nil, filepath.Join(root, "legacy")))
+	assert.True(t, s.vetoed("jquery", nil, filepath.Join(root, "legacy", "admin")))
+	assert.False(t, s.vetoed("jquery", nil, filepath.Join(root, "legacy-v2")), "prefix matches whole path segments")
+	assert.False(t, s.vetoed("jquery", nil, root))
+	assert.True(t, s.vetoed("php", nil, root), "no path suppresses everywhere")
+}
+
+func TestDropVetoedTechs_KeepsComponent(t *testing.T) {
+	root := t.TempDir()
+	s := suppressTestScanner(root, nil, []config.SuppressRule{{Tech: "docker"}})
+	component := types.NewPayloadWithPath("api", "/docker-compose.yml")
+	component.AddTech("docker", "matched file: docker-compose.yml")
+	component.AddPrimaryTech("docker")
+	service := types.NewPayloadWithPath("db", "/docker-compose.yml")
+	service.AddTech("postgresql", "matched: postgres")
+	service.AddTech("docker", "matched file: docker-compose.yml")
+	component.AddChild(service)
+
+	s.dropVetoedTechs(component, nil, root)
+
+	assert.Empty(t, component.Techs)
+	assert.Empty(t, component.Tech)
+	assert.Equal(t, []string{"postgresql"}, service.Techs)
+}
+
+func scanSuppressTestTechs(t *testing.T, root string, suppress []config.SuppressRule) []string {
+	t.Helper()
+	cfg := &config.ScanConfig{Suppress: suppress}
+	s, err := NewScannerWithOptionsAndLogger(root, nil, true, false, false, false, false, nil, nil, "", cfg)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+
+	var techs []string
+	var walk func(p *types.Payload)
+	walk = func(p *types.Payload) {
+		techs = append(techs, p.Techs...)
+		for _, child := range p.Children {
+			walk(child)
+		}
+	}
+	walk(payload)
+	return techs
+}
+
+func TestScan_SuppressUnderPath(t *testing.T) {
+	root := t.TempDir()
+	writeSuppressTestFiles(t, root, map[string]string{"legacy/Dockerfile": "FROM alpine\n"})
+
+	assert.Contains(t, scanSuppressTestTechs(t, root, nil), "docker")
+	assert.Contains(t, scanSuppressTestTechs(t, root, []config.SuppressRule{{Tech: "docker", Path: "app"}}), "docker")
+	assert.NotContains(t, scanSuppressTestTechs(t, root, []config.SuppressRule{{Tech: "docker", Path: "legacy"}}), "docker")
+}
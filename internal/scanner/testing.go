@@ -0,0 +1,208 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Test tool kinds used to group techs in the testing section.
+const (
+	testKindUnit        = "unit"
+	testKindIntegration = "integration"
+	testKindE2E         = "e2e"
+	testKindCoverage    = "coverage"
+	testKindPerformance = "performance"
+	testKindSupport     = "support"
+	testKindOther       = "other"
+)
+
+// testToolKinds classifies known test techs. Techs of type "test" that are
+// not listed here are reported as "other"; techs of other types (browser
+// automation, CI coverage services) only count when listed.
+var testToolKinds = map[string]string{
+	"jest":           testKindUnit,
+	"vitest":         testKindUnit,
+	"mochajs":        testKindUnit,
+	"junit":          testKindUnit,
+	"junit5":         testKindUnit,
+	"testng":         testKindUnit,
+	"nunit":          testKindUnit,
+	"xunit":          testKindUnit,
+	"googletest":     testKindUnit,
+	"phpunit":        testKindUnit,
+	"phppest":        testKindUnit,
+	"pytest":         testKindUnit,
+	"rspec":          testKindUnit,
+	"rest-assured":   testKindIntegration,
+	"testcontainers": testKindIntegration,
+	"playwright":     testKindE2E,
+	"cypressci":      testKindE2E,
+	"selenium":       testKindE2E,
+	"puppeteer":      testKindE2E,
+	"cucumber":       testKindE2E,
+	"jacoco":         testKindCoverage,
+	"coverlet":       testKindCoverage,
+	"istanbul":       testKindCoverage,
+	"coveragepy":     testKindCoverage,
+	"codecov":        testKindCoverage,
+	"coveralls":      testKindCoverage,
+	"k6":             testKindPerformance,
+	"lighthouse":     testKindPerformance,
+	"assertj":        testKindSupport,
+	"mockito":        testKindSupport,
+	"moq":            testKindSupport,
+	"bogus":          testKindSupport,
+	"jsdom":          testKindSupport,
+	"testinglibrary": testKindSupport,
+}
+
+// testDirNames are directory names that hold tests by convention. A
+// "spec" directory may also hold specifications, so it only counts when its
+// files are named like tests (false).
+var testDirNames = map[string]bool{
+	"test": true, "tests": true, "__tests__": true, "e2e": true, "integration-tests": true,
+	"spec": false, "specs": false,
+}
+
+// testFileRegex matches test file naming conventions across ecosystems.
+var testFileRegex = regexp.MustCompile(`(_test\.(go|py)|\.(test|spec)\.[cm]?[jt]sx?|_spec\.rb|Tests?\.(java|kt|cs|scala|swift|php))$|^test_.*\.py$`)
+
+// TestingInfo is the testing section of a component: its test tools grouped
+// by kind, the test directories found and the number of test files.
+type TestingInfo struct {
+	Unit        []string `json:"unit,omitempty"`
+	Integration []string `json:"integration,omitempty"`
+	E2E         []string `json:"e2e,omitempty"`
+	Coverage    []string `json:"coverage,omitempty"`
+	Performance []string `json:"performance,omitempty"`
+	Support     []string `json:"support,omitempty"`
+	Other       []string `json:"other,omitempty"`
+	TestDirs    []string `json:"test_dirs,omitempty"`
+	TestFiles   int      `json:"test_files"`
+}
+
+// testFileCounts accumulates the test files seen for one component.
+type testFileCounts struct {
+	files int
+	dirs  map[string]bool
+}
+
+// recordTestFile counts a file toward its component's tests when it lives in
+// a test directory or follows a test file naming convention.
+func (s *Scanner) recordTestFile(ctx *types.Payload, filePath string) {
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	namedLikeTest := testFileRegex.MatchString(segments[len(segments)-1])
+	testDir := testDirOf(segments[:len(segments)-1], namedLikeTest)
+	if testDir == "" && !namedLikeTest {
+		return
+	}
+
+	if s.testFiles == nil {
+		s.testFiles = make(map[*types.Payload]*testFileCounts)
+	}
+	counts, ok := s.testFiles[ctx]
+	if !ok {
+		counts = &testFileCounts{dirs: make(map[string]bool)}
+		s.testFiles[ctx] = counts
+	}
+	counts.files++
+	if testDir != "" {
+		counts.dirs[testDir] = true
+	}
+}
+
+// testDirOf returns the outermost test directory among the given path
+// segments (as a scan-root-relative path), or "".
+func testDirOf(dirs []string, namedLikeTest bool) string {
+	for i, segment := range dirs {
+		if always, ok := testDirNames[segment]; ok && (always || namedLikeTest) {
+			return "/" + strings.Join(dirs[:i+1], "/")
+		}
+	}
+	return ""
+}
+
+// attachTesting adds a "testing" property to every component that uses test
+// tools or contains test files.
+func (s *Scanner) attachTesting(payload *types.Payload) {
+	ruleTypes := make(map[string]string, len(s.rules))
+	for _, rule := range s.rules {
+		ruleTypes[rule.Tech] = rule.Type
+	}
+	s.walkTesting(payload, ruleTypes)
+}
+
+func (s *Scanner) walkTesting(payload *types.Payload, ruleTypes map[string]string) {
+	if info := s.testingInfo(payload, ruleTypes); info != nil {
+		if payload.Properties == nil {
+			payload.Properties = make(map[string]interface{})
+		}
+		payload.Properties["testing"] = info
+	}
+	for _, child := range payload.Children {
+		s.walkTesting(child, ruleTypes)
+	}
+}
+
+// testingInfo builds the testing section of one component, or nil when it
+// has neither test tools nor test files.
+func (s *Scanner) testingInfo(payload *types.Payload, ruleTypes map[string]string) *TestingInfo {
+	info := &TestingInfo{}
+	found := false
+	for _, tech := range payload.Techs {
+		kind, ok := testToolKinds[tech]
+		if !ok && ruleTypes[tech] == "test" {
+			kind, ok = testKindOther, true
+		}
+		if ok {
+			info.add(kind, tech)
+			found = true
+		}
+	}
+	if counts := s.testFiles[payload]; counts != nil {
+		info.TestFiles = counts.files
+		for dir := range counts.dirs {
+			info.TestDirs = append(info.TestDirs, dir)
+		}
+		sort.Strings(info.TestDirs)
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	info.sort()
+	return info
+}
+
+func (t *TestingInfo) add(kind, tech string) {
+	switch kind {
+	case testKindUnit:
+		t.Unit = append(t.Unit, tech)
+	case testKindIntegration:
+		t.Integration = append(t.Integration, tech)
+	case testKindE2E:
+		t.E2E = append(t.E2E, tech)
+	case testKindCoverage:
+		t.Coverage = append(t.Coverage, tech)
+	case testKindPerformance:
+		t.Performance = append(t.Performance, tech)
+	case testKindSupport:
+		t.Support = append(t.Support, tech)
+	default:
+		t.Other = append(t.Other, tech)
+	}
+}
+
+func (t *TestingInfo) sort() {
+	for _, list := range [][]string{t.Unit, t.Integration, t.E2E, t.Coverage, t.Performance, t.Support, t.Other} {
+		sort.Strings(list)
+	}
+}
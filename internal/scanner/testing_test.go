@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachTesting(t *testing.T) {
+	tempDir := t.TempDir()
+	write := func(rel, content string) {
+		full := filepath.Join(tempDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
+	}
+	write("package.json", `{"name": "myapp", "dependencies": {"express": "^4.18.0"},
+  "devDependencies": {"jest": "^29.0.0", "nyc": "^15.0.0", "@testing-library/react": "^14.0.0"},
+  "scripts": {"e2e": "playwright test"}}`)
+	write("src/app.js", "module.exports = {}\n")
+	write("src/app.test.js", "test('ok', () => {})\n")
+	write("__tests__/api.js", "test('api', () => {})\n")
+	write("e2e/login.spec.ts", "test('login', async () => {})\n")
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	component := findComponentWithProperty(result, "testing")
+	require.NotNil(t, component, "expected a component with a testing section")
+	info, ok := component.Properties["testing"].(*TestingInfo)
+	require.True(t, ok)
+
+	assert.Equal(t, []string{"jest"}, info.Unit)
+	assert.Equal(t, []string{"playwright"}, info.E2E)
+	assert.Equal(t, []string{"istanbul"}, info.Coverage)
+	assert.Equal(t, []string{"/__tests__", "/e2e"}, info.TestDirs)
+	assert.Equal(t, 3, info.TestFiles)
+}
+
+func TestAttachTesting_NoTests(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+	assert.Nil(t, findComponentWithProperty(result, "testing"))
+}
+
+func TestTestingInfo_OtherTestTechs(t *testing.T) {
+	s := &Scanner{}
+	payload := types.NewPayloadWithPath("main", "/")
+	payload.Techs = []string{"storybook", "mockito", "express"}
+
+	info := s.testingInfo(payload, map[string]string{"storybook": "test", "express": "web_framework"})
+	require.NotNil(t, info)
+	assert.Equal(t, []string{"storybook"}, info.Other)
+	assert.Equal(t, []string{"mockito"}, info.Support)
+	assert.Zero(t, info.TestFiles)
+}
+
+func findComponentWithProperty(payload *types.Payload, key string) *types.Payload {
+	if _, ok := payload.Properties[key]; ok {
+		return payload
+	}
+	for _, child := range payload.Children {
+		if found := findComponentWithProperty(child, key); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestRecordTestFile_SpecDirectories(t *testing.T) {
+	s := &Scanner{cachedBasePath: "/repo"}
+	ctx := types.NewPayloadWithPath("main", "/")
+
+	s.recordTestFile(ctx, "/repo/internal/spec/version.go")
+	assert.Nil(t, s.testFiles[ctx], "spec directory without test-named files is not a test directory")
+
+	s.recordTestFile(ctx, "/repo/spec/models/user_spec.rb")
+	s.recordTestFile(ctx, "/repo/tests/fixtures/data.json")
+	require.NotNil(t, s.testFiles[ctx])
+	assert.Equal(t, 2, s.testFiles[ctx].files)
+	assert.Equal(t, map[string]bool{"/spec": true, "/tests": true}, s.testFiles[ctx].dirs)
+}
@@ -0,0 +1,303 @@
+package scanner
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/semver"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxVendorManifestReads caps the package manifests read per vendor
+// directory when it has no index of the installed versions, so a large
+// node_modules does not slow the scan down; the direct dependencies past it
+// are not checked.
+const maxVendorManifestReads = 100
+
+// VendoredInfo is the vendored section of a component: the vendor
+// directories next to its manifests and how the versions installed there
+// compare with the declared ones.
+type VendoredInfo struct {
+	Directories []VendorDirectory    `json:"directories"`
+	Mismatches  []VendoredMismatch   `json:"mismatches,omitempty"`
+	Unvendored  []VendoredDependency `json:"unvendored,omitempty"` // declared, not in the vendor directory
+	Sampled     bool                 `json:"sampled,omitempty"`    // some direct dependencies were not checked
+}
+
+// VendorDirectory is a directory holding copies of the dependencies of one
+// ecosystem.
+type VendorDirectory struct {
+	Type     string `json:"type"` // dependency type: golang, npm or pypi
+	Path     string `json:"path"` // relative to the component directory
+	Packages int    `json:"packages"`
+}
+
+// VendoredMismatch is a direct dependency vendored at another version than
+// the one declared.
+type VendoredMismatch struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Declared string `json:"declared"`
+	Vendored string `json:"vendored"`
+}
+
+// VendoredDependency is a direct dependency missing from the vendor
+// directory of its ecosystem.
+type VendoredDependency struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// vendorDir is what a vendor reader found: the versions installed by
+// package name, and the declared packages it did not look for.
+type vendorDir struct {
+	path     string
+	versions map[string]string
+	unread   map[string]bool
+}
+
+// vendorReaders read the vendor directory of an ecosystem in a component
+// directory, given the direct dependencies the component declares of it.
+// They return nil when there is none.
+var vendorReaders = map[string]func(p types.Provider, dir string, declared []types.Dependency) *vendorDir{
+	"golang": readGoVendor,
+	"npm":    readNodeModules,
+	"pypi":   readSitePackages,
+}
+
+// SetVendored enables the vendored dependency analysis (--vendored).
+func (s *Scanner) SetVendored(enabled bool) {
+	s.vendored = enabled
+}
+
+// attachVendored compares the direct dependencies of every component with
+// the versions in the vendor directories next to its manifests.
+func (s *Scanner) attachVendored(payload *types.Payload) {
+	if !s.vendored {
+		return
+	}
+	basePath := s.provider.GetBasePath()
+	walkPayloads(payload, func(p *types.Payload) {
+		if p.SourceDir == "" || len(p.Dependencies) == 0 {
+			return
+		}
+		dir := filepath.Join(basePath, filepath.FromSlash(strings.TrimPrefix(p.SourceDir, "/")))
+		if info := vendoredInfo(s.provider, dir, p.Dependencies); info != nil {
+			if p.Properties == nil {
+				p.Properties = make(map[string]interface{})
+			}
+			p.Properties["vendored"] = info
+		}
+	})
+}
+
+// vendoredInfo reconciles the direct dependencies of a component with the
+// vendor directories of their ecosystems, or returns nil when it has none.
+func vendoredInfo(p types.Provider, dir string, deps []types.Dependency) *VendoredInfo {
+	declared := make(map[string][]types.Dependency)
+	for _, dep := range deps {
+		if dep.Direct && vendorReaders[dep.Type] != nil {
+			declared[dep.Type] = append(declared[dep.Type], dep)
+		}
+	}
+	info := &VendoredInfo{}
+	for depType, direct := range declared {
+		vendor := vendorReaders[depType](p, dir, direct)
+		if vendor == nil {
+			continue
+		}
+		info.Directories = append(info.Directories, VendorDirectory{Type: depType, Path: vendor.path, Packages: len(vendor.versions)})
+		info.Sampled = info.Sampled || len(vendor.unread) > 0
+		info.reconcile(depType, direct, vendor)
+	}
+	if len(info.Directories) == 0 {
+		return nil
+	}
+	sort.Slice(info.Directories, func(i, j int) bool { return info.Directories[i].Type < info.Directories[j].Type })
+	sort.Slice(info.Mismatches, func(i, j int) bool {
+		return info.Mismatches[i].Type+":"+info.Mismatches[i].Name < info.Mismatches[j].Type+":"+info.Mismatches[j].Name
+	})
+	sort.Slice(info.Unvendored, func(i, j int) bool {
+		return info.Unvendored[i].Type+":"+info.Unvendored[i].Name < info.Unvendored[j].Type+":"+info.Unvendored[j].Name
+	})
+	return info
+}
+
+// reconcile records the direct dependencies of one ecosystem missing from
+// its vendor directory or vendored at another version. Declared ranges are
+// not compared, and peer and optional dependencies may be missing.
+func (info *VendoredInfo) reconcile(depType string, direct []types.Dependency, vendor *vendorDir) {
+	for _, dep := range direct {
+		vendored, ok := vendor.versions[vendoredName(depType, dep.Name)]
+		if !ok {
+			if !vendor.unread[dep.Name] && dep.Scope != types.ScopePeer && dep.Scope != types.ScopeOptional {
+				info.Unvendored = append(info.Unvendored, VendoredDependency{Type: depType, Name: dep.Name, Version: dep.Version})
+			}
+			continue
+		}
+		declared := semver.ResolvedVersion(dep.Version)
+		if declared != "" && vendored != "" && strings.TrimPrefix(declared, "v") != strings.TrimPrefix(vendored, "v") {
+			info.Mismatches = append(info.Mismatches, VendoredMismatch{Type: depType, Name: dep.Name, Declared: declared, Vendored: vendored})
+		}
+	}
+}
+
+// vendoredName is the key of a package in a vendor directory: PyPI names
+// compare normalized, as installers spell them differently.
+func vendoredName(depType, name string) string {
+	if depType == "pypi" {
+		return normalizePyPIName(name)
+	}
+	return name
+}
+
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+func normalizePyPIName(name string) string {
+	return pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// readGoVendor reads vendor/modules.txt, written by go mod vendor, which
+// lists each vendored module as "# path version".
+func readGoVendor(p types.Provider, dir string, _ []types.Dependency) *vendorDir {
+	content, err := p.ReadFile(filepath.Join(dir, "vendor", "modules.txt"))
+	if err != nil {
+		return nil
+	}
+	vendor := &vendorDir{path: "vendor", versions: make(map[string]string)}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == "#" {
+			vendor.versions[fields[1]] = fields[2]
+		}
+	}
+	return vendor
+}
+
+// readNodeModules reads the installed npm packages from the hidden lockfile
+// npm keeps in node_modules, or else from the package.json of up to
+// maxVendorManifestReads declared packages.
+func readNodeModules(p types.Provider, dir string, declared []types.Dependency) *vendorDir {
+	modules := filepath.Join(dir, "node_modules")
+	if isDir, err := p.IsDir(modules); err != nil || !isDir {
+		return nil
+	}
+	vendor := &vendorDir{path: "node_modules"}
+	if content, err := p.ReadFile(filepath.Join(modules, ".package-lock.json")); err == nil {
+		if vendor.versions = hiddenLockfileVersions(content); vendor.versions != nil {
+			return vendor
+		}
+	}
+	vendor.versions, vendor.unread = make(map[string]string), make(map[string]bool)
+	for i, dep := range declared {
+		if i >= maxVendorManifestReads {
+			vendor.unread[dep.Name] = true
+			continue
+		}
+		content, err := p.ReadFile(filepath.Join(modules, filepath.FromSlash(dep.Name), "package.json"))
+		if err != nil {
+			continue
+		}
+		var manifest struct {
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(content, &manifest) == nil {
+			vendor.versions[dep.Name] = manifest.Version
+		}
+	}
+	return vendor
+}
+
+// hiddenLockfileVersions returns the versions of the top-level packages of
+// node_modules/.package-lock.json, or nil when it does not parse.
+func hiddenLockfileVersions(content []byte) map[string]string {
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+		} `json:"packages"`
+	}
+	if json.Unmarshal(content, &lock) != nil {
+		return nil
+	}
+	versions := make(map[string]string, len(lock.Packages))
+	for key, pkg := range lock.Packages {
+		name := strings.TrimPrefix(key, "node_modules/")
+		if name != key && !strings.Contains(name, "/node_modules/") {
+			versions[name] = pkg.Version
+		}
+	}
+	return versions
+}
+
+// sitePackagesDirs are where Python packages are installed into or
+// vendored in a project, relative to its directory; "*" matches any
+// python3.x directory.
+var sitePackagesDirs = []string{
+	"site-packages", "vendor", "lib/*/site-packages",
+	"venv/lib/*/site-packages", ".venv/lib/*/site-packages", "env/lib/*/site-packages",
+	"venv/Lib/site-packages", ".venv/Lib/site-packages",
+}
+
+// readSitePackages reads the installed Python distributions from the names
+// of their .dist-info and .egg-info directories ("requests-2.31.0.dist-info"),
+// without opening their metadata.
+func readSitePackages(p types.Provider, dir string, _ []types.Dependency) *vendorDir {
+	for _, pattern := range sitePackagesDirs {
+		for _, rel := range expandSitePackages(p, dir, pattern) {
+			files, err := p.ListDir(filepath.Join(dir, filepath.FromSlash(rel)))
+			if err != nil {
+				continue
+			}
+			vendor := &vendorDir{path: rel, versions: make(map[string]string)}
+			for _, file := range files {
+				if name, version, ok := distributionName(file); ok {
+					vendor.versions[normalizePyPIName(name)] = version
+				}
+			}
+			if len(vendor.versions) > 0 {
+				return vendor
+			}
+		}
+	}
+	return nil
+}
+
+// expandSitePackages resolves the "*" of a site-packages pattern against the
+// directories present.
+func expandSitePackages(p types.Provider, dir, pattern string) []string {
+	parent, rest, found := strings.Cut(pattern, "/*/")
+	if !found {
+		return []string{pattern}
+	}
+	files, err := p.ListDir(filepath.Join(dir, filepath.FromSlash(parent)))
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, file := range files {
+		if file.Type == "dir" && strings.HasPrefix(file.Name, "python") {
+			dirs = append(dirs, path.Join(parent, file.Name, rest))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// distributionName splits a "name-version.dist-info" or ".egg-info"
+// directory name.
+func distributionName(file types.File) (name, version string, ok bool) {
+	if file.Type != "dir" {
+		return "", "", false
+	}
+	base := strings.TrimSuffix(strings.TrimSuffix(file.Name, ".dist-info"), ".egg-info")
+	if base == file.Name {
+		return "", "", false
+	}
+	name, version, ok = strings.Cut(base, "-")
+	return name, strings.SplitN(version, "-", 2)[0], ok
+}
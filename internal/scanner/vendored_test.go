@@ -0,0 +1,142 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/provider"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeVendorFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestVendoredInfo_GoVendor(t *testing.T) {
+	root := t.TempDir()
+	writeVendorFiles(t, root, map[string]string{
+		"vendor/modules.txt": "# github.com/google/uuid v1.6.0\n## explicit; go 1.19\ngithub.com/google/uuid\n# golang.org/x/text v0.14.0\n",
+	})
+	info := vendoredInfo(provider.NewFSProvider(root), root, []types.Dependency{
+		{Type: "golang", Name: "github.com/google/uuid", Version: "v1.5.0", Direct: true},
+		{Type: "golang", Name: "golang.org/x/text", Version: "v0.14.0", Direct: true},
+		{Type: "golang", Name: "github.com/spf13/cobra", Version: "v1.8.0", Direct: true},
+		{Type: "golang", Name: "golang.org/x/sys", Version: "v0.15.0"},
+	})
+	require.NotNil(t, info)
+	assert.Equal(t, []VendorDirectory{{Type: "golang", Path: "vendor", Packages: 2}}, info.Directories)
+	assert.Equal(t, []VendoredMismatch{{Type: "golang", Name: "github.com/google/uuid", Declared: "v1.5.0", Vendored: "v1.6.0"}}, info.Mismatches)
+	assert.Equal(t, []VendoredDependency{{Type: "golang", Name: "github.com/spf13/cobra", Version: "v1.8.0"}}, info.Unvendored,
+		"indirect modules are not reconciled")
+}
+
+func TestVendoredInfo_NodeModules(t *testing.T) {
+	deps := []types.Dependency{
+		{Type: "npm", Name: "express", Version: "4.18.2", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "@types/node", Version: "^20.0.0", Scope: types.ScopeDev, Direct: true},
+		{Type: "npm", Name: "lodash", Version: "4.17.21", Scope: types.ScopeProd, Direct: true},
+		{Type: "npm", Name: "react", Version: "18.2.0", Scope: types.ScopePeer, Direct: true},
+	}
+
+	t.Run("hidden lockfile", func(t *testing.T) {
+		root := t.TempDir()
+		writeVendorFiles(t, root, map[string]string{
+			"node_modules/.package-lock.json": `{"packages": {
+				"node_modules/express": {"version": "4.19.2"},
+				"node_modules/@types/node": {"version": "20.11.5"},
+				"node_modules/express/node_modules/debug": {"version": "2.6.9"}}}`,
+		})
+		info := vendoredInfo(provider.NewFSProvider(root), root, deps)
+		require.NotNil(t, info)
+		assert.Equal(t, []VendorDirectory{{Type: "npm", Path: "node_modules", Packages: 2}}, info.Directories)
+		assert.Equal(t, []VendoredMismatch{{Type: "npm", Name: "express", Declared: "4.18.2", Vendored: "4.19.2"}}, info.Mismatches,
+			"ranges are not compared")
+		assert.Equal(t, []VendoredDependency{{Type: "npm", Name: "lodash", Version: "4.17.21"}}, info.Unvendored,
+			"missing peer dependencies are expected")
+	})
+
+	t.Run("package manifests", func(t *testing.T) {
+		root := t.TempDir()
+		writeVendorFiles(t, root, map[string]string{
+			"node_modules/express/package.json":     `{"name": "express", "version": "4.18.2"}`,
+			"node_modules/@types/node/package.json": `{"name": "@types/node", "version": "20.11.5"}`,
+		})
+		info := vendoredInfo(provider.NewFSProvider(root), root, deps)
+		require.NotNil(t, info)
+		assert.Empty(t, info.Mismatches)
+		assert.Equal(t, []VendoredDependency{{Type: "npm", Name: "lodash", Version: "4.17.21"}}, info.Unvendored)
+		assert.False(t, info.Sampled)
+	})
+}
+
+func TestVendoredInfo_NodeModulesSampled(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "node_modules"), 0o755))
+	var deps []types.Dependency
+	for i := 0; i <= maxVendorManifestReads; i++ {
+		deps = append(deps, types.Dependency{Type: "npm", Name: fmt.Sprintf("pkg-%03d", i), Version: "1.0.0", Scope: types.ScopeProd, Direct: true})
+	}
+	info := vendoredInfo(provider.NewFSProvider(root), root, deps)
+	require.NotNil(t, info)
+	assert.True(t, info.Sampled)
+	assert.Len(t, info.Unvendored, maxVendorManifestReads, "only the packages looked for are reported missing")
+}
+
+func TestVendoredInfo_SitePackages(t *testing.T) {
+	root := t.TempDir()
+	writeVendorFiles(t, root, map[string]string{
+		".venv/lib/python3.12/site-packages/requests-2.31.0.dist-info/METADATA":       "",
+		".venv/lib/python3.12/site-packages/PyYAML-6.0.1.dist-info/METADATA":          "",
+		".venv/lib/python3.12/site-packages/typing_extensions-4.9.0.dist-info/RECORD": "",
+	})
+	info := vendoredInfo(provider.NewFSProvider(root), root, []types.Dependency{
+		{Type: "pypi", Name: "requests", Version: "2.32.0", Direct: true},
+		{Type: "pypi", Name: "pyyaml", Version: "6.0.1", Direct: true},
+		{Type: "pypi", Name: "typing-extensions", Version: ">=4.0", Direct: true},
+		{Type: "pypi", Name: "flask", Version: "3.0.0", Direct: true},
+	})
+	require.NotNil(t, info)
+	assert.Equal(t, []VendorDirectory{{Type: "pypi", Path: ".venv/lib/python3.12/site-packages", Packages: 3}}, info.Directories)
+	assert.Equal(t, []VendoredMismatch{{Type: "pypi", Name: "requests", Declared: "2.32.0", Vendored: "2.31.0"}}, info.Mismatches)
+	assert.Equal(t, []VendoredDependency{{Type: "pypi", Name: "flask", Version: "3.0.0"}}, info.Unvendored)
+}
+
+func TestVendoredInfo_NoVendorDirectory(t *testing.T) {
+	root := t.TempDir()
+	info := vendoredInfo(provider.NewFSProvider(root), root, []types.Dependency{
+		{Type: "npm", Name: "express", Version: "4.18.2", Direct: true},
+	})
+	assert.Nil(t, info)
+}
+
+func TestScan_Vendored(t *testing.T) {
+	root := t.TempDir()
+	writeVendorFiles(t, root, map[string]string{
+		"package.json":                      `{"name": "myapp", "dependencies": {"express": "4.18.2"}}`,
+		"node_modules/express/package.json": `{"name": "express", "version": "4.17.1"}`,
+	})
+
+	s, err := NewScanner(root)
+	require.NoError(t, err)
+	payload, err := s.Scan()
+	require.NoError(t, err)
+	assert.Nil(t, findComponentWithProperty(payload, "vendored"), "the analysis is opt-in")
+
+	s.SetVendored(true)
+	payload, err = s.Scan()
+	require.NoError(t, err)
+	component := findComponentWithProperty(payload, "vendored")
+	require.NotNil(t, component)
+	info, ok := component.Properties["vendored"].(*VendoredInfo)
+	require.True(t, ok)
+	assert.Equal(t, []VendoredMismatch{{Type: "npm", Name: "express", Declared: "4.18.2", Vendored: "4.17.1"}}, info.Mismatches)
+}
@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/scanner/parsers"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// maxWarehouseScanSize skips large files when looking for connection URLs.
+const maxWarehouseScanSize = 1 << 20
+
+// warehouseURLExtensions are the extensions of files searched for Airflow
+// connection settings and SQLAlchemy URLs, besides dotenv files.
+var warehouseURLExtensions = map[string]bool{
+	".py": true, ".ini": true, ".cfg": true, ".toml": true, ".yml": true, ".yaml": true, ".sh": true,
+}
+
+// recordWarehouse adds the data warehouses a file connects to as techs of
+// the component: dbt profiles.yml targets, Airflow connections
+// (airflow_settings.yaml, AIRFLOW_CONN_* settings) and SQLAlchemy URLs.
+// The reason names the kind of evidence and the file.
+func (s *Scanner) recordWarehouse(ctx *types.Payload, filePath string, content []byte) {
+	conns := findWarehouseConnections(filepath.Base(filePath), content)
+	if len(conns) == 0 {
+		return
+	}
+	rel, err := filepath.Rel(s.cachedBasePath, filePath)
+	if err != nil {
+		return
+	}
+	relPath := "/" + filepath.ToSlash(rel)
+	for _, conn := range conns {
+		reason := conn.Source + " connection: " + relPath
+		if conn.Name != "" {
+			reason += " (" + conn.Name + ")"
+		}
+		ctx.AddTech(conn.Tech, reason)
+	}
+}
+
+func findWarehouseConnections(name string, content []byte) []parsers.WarehouseConnection {
+	parser := parsers.NewWarehouseParser()
+	switch {
+	case name == "profiles.yml":
+		return parser.ParseDbtProfiles(content)
+	case name == "airflow_settings.yaml":
+		return parser.ParseAirflowSettings(content)
+	}
+	isDotenv := name == ".env" || strings.HasPrefix(name, ".env.")
+	if !isDotenv && !warehouseURLExtensions[strings.ToLower(filepath.Ext(name))] {
+		return nil
+	}
+	if len(content) > maxWarehouseScanSize || !bytes.Contains(content, []byte("://")) && !bytes.Contains(content, []byte("AIRFLOW_CONN_")) {
+		return nil
+	}
+	return parser.FindConnections(string(content))
+}
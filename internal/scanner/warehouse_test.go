@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordWarehouse(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "profiles.yml"),
+		[]byte("analytics:\n  target: prod\n  outputs:\n    prod:\n      type: snowflake\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"),
+		[]byte("AIRFLOW_CONN_WAREHOUSE=databricks://token@myapp.example.com\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "db.py"),
+		[]byte("engine = create_engine(\"bigquery://myproject/mydataset\")\n"), 0o644))
+
+	scanner, err := NewScanner(tempDir)
+	require.NoError(t, err)
+	result, err := scanner.Scan()
+	require.NoError(t, err)
+
+	assert.Subset(t, result.Techs, []string{"snowflake", "databricks", "gcp.bigquery"})
+	assert.Contains(t, result.Reason["snowflake"], "dbt connection: /profiles.yml (analytics.prod)")
+	assert.Contains(t, result.Reason["databricks"], "airflow connection: /.env (warehouse)")
+	assert.Contains(t, result.Reason["gcp.bigquery"], "sqlalchemy connection: /db.py")
+}
@@ -3,6 +3,7 @@ package spec
 const (
 	// Version represents the output format specification version
 	// This version indicates the structure and schema of the JSON output
-	// It should be updated when breaking changes are made to the output format
+	// It should be updated when breaking changes are made to the output format,
+	// adding the new version and the step migrating to it in internal/migrate
 	Version = "0.1"
 )
@@ -0,0 +1,294 @@
+// Package trends turns stored scan results into time series of key metrics
+// (lines of code, dependencies, technologies, ...) so that dashboards can
+// chart a repository over time without querying the results database.
+package trends
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/currency"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+// Metrics, the names accepted by ParseMetrics.
+const (
+	MetricLOC          = "loc"           // lines of code, from the root code_stats
+	MetricFiles        = "files"         // files counted by code_stats
+	MetricDeps         = "deps"          // distinct dependencies (type and name) of all components
+	MetricTechs        = "techs"         // distinct technologies of all components
+	MetricComponents   = "components"    // components, the root included
+	MetricCommentRatio = "comment_ratio" // comments per line of code of programming languages
+	MetricTestRatio    = "test_ratio"    // test files per programming-language file
+	MetricCurrency     = "currency"      // share of resolved direct dependencies on their latest version
+)
+
+// Metrics lists the supported metrics in output order.
+var Metrics = []string{MetricLOC, MetricFiles, MetricDeps, MetricTechs, MetricComponents, MetricCommentRatio, MetricTestRatio, MetricCurrency}
+
+// ParseMetrics parses a comma-separated list of metric names, keeping the
+// order given. An empty list selects all metrics.
+func ParseMetrics(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return Metrics, nil
+	}
+	var metrics []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(Metrics, name) {
+			return nil, fmt.Errorf("unknown metric %q (valid: %s)", name, strings.Join(Metrics, ", "))
+		}
+		if !slices.Contains(metrics, name) {
+			metrics = append(metrics, name)
+		}
+	}
+	return metrics, nil
+}
+
+// Point is the value of the metrics for one scan. A metric that cannot be
+// computed for the scan, such as a ratio without code statistics, is left
+// out.
+type Point struct {
+	Scanned time.Time          `json:"scanned"`
+	Values  map[string]float64 `json:"values"`
+}
+
+// Series is the points of one repository, identified by the daemon job that
+// scanned it and the root ID of its scans, oldest first.
+type Series struct {
+	Job    string   `json:"job"`
+	RootID string   `json:"root_id"`
+	Name   string   `json:"name"`
+	Points []*Point `json:"points"`
+}
+
+// Trends collects the series of the scans added to it.
+type Trends struct {
+	Metrics []string  `json:"metrics"`
+	Series  []*Series `json:"series"`
+
+	// Currency resolves the latest versions of dependencies for the
+	// currency metric, which is left out without one.
+	Currency currency.CurrencyResolver `json:"-"`
+}
+
+// New creates an empty set of series of the given metrics.
+func New(metrics []string) *Trends {
+	return &Trends{Metrics: metrics, Series: []*Series{}}
+}
+
+// Add measures a scan and appends it to the series of its job and root ID.
+func (t *Trends) Add(job string, scanned time.Time, root *types.Payload) {
+	series := t.series(job, root.ID)
+	if series == nil {
+		series = &Series{Job: job, RootID: root.ID}
+		t.Series = append(t.Series, series)
+	}
+	series.Name = root.Name
+	series.Points = append(series.Points, &Point{Scanned: scanned, Values: Measure(root, t.Metrics, t.Currency)})
+}
+
+func (t *Trends) series(job, rootID string) *Series {
+	for _, series := range t.Series {
+		if series.Job == job && series.RootID == rootID {
+			return series
+		}
+	}
+	return nil
+}
+
+// Sort orders the series by job and root ID and their points by scan time.
+func (t *Trends) Sort() {
+	sort.Slice(t.Series, func(i, j int) bool {
+		if t.Series[i].Job != t.Series[j].Job {
+			return t.Series[i].Job < t.Series[j].Job
+		}
+		return t.Series[i].RootID < t.Series[j].RootID
+	})
+	for _, series := range t.Series {
+		sort.SliceStable(series.Points, func(i, j int) bool { return series.Points[i].Scanned.Before(series.Points[j].Scanned) })
+	}
+}
+
+// Measure computes the metrics of a scan output, ratios rounded to four
+// decimals; r resolves latest versions for the currency metric and may be
+// nil.
+func Measure(root *types.Payload, metrics []string, r currency.CurrencyResolver) map[string]float64 {
+	values := make(map[string]float64, len(metrics))
+	for _, metric := range metrics {
+		if value, ok := measure(root, metric, r); ok {
+			values[metric] = math.Round(value*10000) / 10000
+		}
+	}
+	return values
+}
+
+func measure(root *types.Payload, metric string, r currency.CurrencyResolver) (float64, bool) {
+	switch metric {
+	case MetricLOC:
+		return float64(readCodeStats(root).Total.Code), true
+	case MetricFiles:
+		return float64(readCodeStats(root).Total.Files), true
+	case MetricDeps:
+		return float64(countDistinct(root, dependencyKeys)), true
+	case MetricTechs:
+		return float64(countDistinct(root, func(p *types.Payload) []string { return p.Techs })), true
+	case MetricComponents:
+		n := 0
+		walk(root, func(*types.Payload) { n++ })
+		return float64(n), true
+	case MetricCommentRatio:
+		return commentRatio(root)
+	case MetricTestRatio:
+		return testRatio(root)
+	case MetricCurrency:
+		return currencyShare(root, r)
+	}
+	return 0, false
+}
+
+func dependencyKeys(p *types.Payload) []string {
+	keys := make([]string, len(p.Dependencies))
+	for i, dep := range p.Dependencies {
+		keys[i] = dep.Type + "|" + dep.Name
+	}
+	return keys
+}
+
+// codeTotal is the total of a code_stats bucket.
+type codeTotal struct {
+	Code     int `json:"code"`
+	Comments int `json:"comments"`
+	Files    int `json:"files"`
+}
+
+// codeStats is the part of a code_stats object the metrics read.
+type codeStats struct {
+	Total  codeTotal `json:"total"`
+	ByType struct {
+		Programming struct {
+			Total codeTotal `json:"total"`
+		} `json:"programming"`
+	} `json:"by_type"`
+}
+
+// readCodeStats decodes the root code_stats, which is a decoded JSON object
+// in a stored result; zero without code statistics.
+func readCodeStats(root *types.Payload) codeStats {
+	var stats codeStats
+	if data, err := json.Marshal(root.CodeStats); err == nil {
+		_ = json.Unmarshal(data, &stats)
+	}
+	return stats
+}
+
+// commentRatio is comments per line of code of programming languages, as
+// the comment_ratio of code_stats, unrounded.
+func commentRatio(root *types.Payload) (float64, bool) {
+	programming := readCodeStats(root).ByType.Programming.Total
+	if programming.Code == 0 {
+		return 0, false
+	}
+	return float64(programming.Comments) / float64(programming.Code), true
+}
+
+// testRatio is the test files counted by the testing sections of all
+// components per programming-language file.
+func testRatio(root *types.Payload) (float64, bool) {
+	files := readCodeStats(root).ByType.Programming.Total.Files
+	if files == 0 {
+		return 0, false
+	}
+	tests := 0
+	walk(root, func(p *types.Payload) {
+		var testing struct {
+			TestFiles int `json:"test_files"`
+		}
+		if data, err := json.Marshal(p.Properties["testing"]); err == nil {
+			_ = json.Unmarshal(data, &testing)
+		}
+		tests += testing.TestFiles
+	})
+	return float64(tests) / float64(files), true
+}
+
+// currencyShare is the share of the direct dependencies whose latest version
+// r resolves that are on it; left out when r resolves none.
+func currencyShare(root *types.Payload, r currency.CurrencyResolver) (float64, bool) {
+	if r == nil {
+		return 0, false
+	}
+	var deps []types.Dependency
+	seen := make(map[string]bool)
+	walk(root, func(p *types.Payload) {
+		for _, dep := range p.Dependencies {
+			key := dep.Type + "|" + dep.Name + "|" + dep.Version
+			if dep.Direct && !seen[key] {
+				seen[key] = true
+				deps = append(deps, dep)
+			}
+		}
+	})
+	summary := currency.Resolve(deps, r, currency.Options{DirectOnly: true}).Summary
+	if summary.Resolved == 0 {
+		return 0, false
+	}
+	return float64(summary.UpToDate) / float64(summary.Resolved), true
+}
+
+func countDistinct(root *types.Payload, values func(*types.Payload) []string) int {
+	seen := make(map[string]bool)
+	walk(root, func(p *types.Payload) {
+		for _, value := range values(p) {
+			seen[value] = true
+		}
+	})
+	return len(seen)
+}
+
+func walk(p *types.Payload, visit func(*types.Payload)) {
+	visit(p)
+	for _, child := range p.Children {
+		walk(child, visit)
+	}
+}
+
+// WriteCSV writes one row per point: job, root ID, name, scan time (RFC
+// 3339) and the metrics, after a header row. Metrics left out of a point
+// are empty.
+func (t *Trends) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(append([]string{"job", "root_id", "name", "scanned"}, t.Metrics...)); err != nil {
+		return err
+	}
+	for _, series := range t.Series {
+		for _, point := range series.Points {
+			row := []string{series.Job, series.RootID, series.Name, point.Scanned.Format(time.RFC3339)}
+			for _, metric := range t.Metrics {
+				row = append(row, formatValue(point.Values, metric))
+			}
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// formatValue formats a metric for CSV, empty for a metric left out.
+func formatValue(values map[string]float64, metric string) string {
+	value, ok := values[metric]
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,94 @@
+package trends
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/currency"
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scan(id string, code int, deps ...string) *types.Payload {
+	root := types.NewPayloadWithPath("myapp", "/")
+	root.ID = id
+	root.Techs = []string{"nodejs"}
+	root.CodeStats = map[string]interface{}{
+		"total": map[string]interface{}{"lines": float64(code * 2), "code": float64(code), "files": float64(3)},
+		"by_type": map[string]interface{}{"programming": map[string]interface{}{
+			"total": map[string]interface{}{"code": float64(code), "comments": float64(code / 4), "files": float64(2)},
+		}},
+	}
+	child := types.NewPayloadWithPath("api", "/api/package.json")
+	child.Techs = []string{"nodejs", "express"}
+	for _, dep := range deps {
+		child.Dependencies = append(child.Dependencies, types.Dependency{Type: "npm", Name: dep, Version: "1.0.0", Direct: true})
+		root.Dependencies = append(root.Dependencies, types.Dependency{Type: "npm", Name: dep})
+	}
+	child.Properties = map[string]interface{}{"testing": map[string]interface{}{"test_files": float64(1)}}
+	root.AddChild(child)
+	return root
+}
+
+// latestResolver resolves every package to the same latest version.
+type latestResolver string
+
+func (r latestResolver) LatestVersion(_, _ string) (currency.LatestInfo, error) {
+	return currency.LatestInfo{Latest: string(r)}, nil
+}
+
+func TestParseMetrics(t *testing.T) {
+	metrics, err := ParseMetrics("loc, DEPS,techs,loc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"loc", "deps", "techs"}, metrics)
+
+	metrics, err = ParseMetrics("")
+	require.NoError(t, err)
+	assert.Equal(t, Metrics, metrics)
+
+	_, err = ParseMetrics("loc,stars")
+	assert.ErrorContains(t, err, `unknown metric "stars"`)
+}
+
+func TestMeasure(t *testing.T) {
+	values := Measure(scan("r1", 1200, "express", "lodash"), Metrics, latestResolver("1.0.0"))
+	assert.Equal(t, map[string]float64{
+		"loc": 1200, "files": 3, "deps": 2, "techs": 2, "components": 2,
+		"comment_ratio": 0.25, "test_ratio": 0.5, "currency": 1,
+	}, values)
+
+	values = Measure(scan("r1", 1200, "express"), []string{MetricCurrency}, latestResolver("2.0.0"))
+	assert.Equal(t, map[string]float64{"currency": 0}, values)
+}
+
+func TestMeasureLeavesOutUncomputable(t *testing.T) {
+	empty := types.NewPayloadWithPath("empty", "/")
+	assert.Equal(t, map[string]float64{"loc": 0}, Measure(empty, []string{MetricLOC, MetricCommentRatio, MetricTestRatio}, nil))
+	assert.Empty(t, Measure(scan("r1", 1200, "express"), []string{MetricCurrency}, nil))
+}
+
+func TestTrendsSeries(t *testing.T) {
+	trends := New([]string{MetricLOC, MetricDeps, MetricCurrency})
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 2, 0, 0, 0, time.UTC) }
+	trends.Add("myapp", day(10), scan("r1", 1500, "express", "lodash", "zod"))
+	trends.Add("myapp", day(9), scan("r1", 1200, "express"))
+	trends.Add("billing", day(9), scan("r2", 300))
+	trends.Sort()
+
+	require.Len(t, trends.Series, 2)
+	assert.Equal(t, "billing", trends.Series[0].Job)
+	series := trends.Series[1]
+	assert.Equal(t, "r1", series.RootID)
+	require.Len(t, series.Points, 2)
+	assert.Equal(t, day(9), series.Points[0].Scanned)
+	assert.Equal(t, map[string]float64{"loc": 1500, "deps": 3}, series.Points[1].Values)
+
+	var out bytes.Buffer
+	require.NoError(t, trends.WriteCSV(&out))
+	assert.Equal(t, "job,root_id,name,scanned,loc,deps,currency\n"+
+		"billing,r2,myapp,2026-03-09T02:00:00Z,300,0,\n"+
+		"myapp,r1,myapp,2026-03-09T02:00:00Z,1200,1,\n"+
+		"myapp,r1,myapp,2026-03-10T02:00:00Z,1500,3,\n", out.String())
+}
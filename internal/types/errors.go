@@ -0,0 +1,94 @@
+package types
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// Errors the scanner, its providers and parsers fail with, matched with
+// errors.Is. A missing path and unparseable input are the user's to fix;
+// a provider failing otherwise points at the environment, and any other
+// error at a bug.
+var (
+	ErrPathNotFound = errors.New("path not found")
+	ErrParse        = errors.New("parse error")
+	ErrProvider     = errors.New("provider error")
+)
+
+// Error categories, as reported by ErrorCategory.
+const (
+	ErrorCategoryPathNotFound = "path_not_found"
+	ErrorCategoryParse        = "parse"
+	ErrorCategoryProvider     = "provider"
+	ErrorCategoryInternal     = "internal"
+)
+
+// ParseError is the failure to parse a file in a format. File is empty
+// when the parser is given content only; callers knowing the file set it.
+type ParseError struct {
+	File   string
+	Format string // json, yaml, toml, xml, zip, ...
+	Err    error
+}
+
+// NewParseError wraps the error of parsing content in a format, nil when
+// err is nil.
+func NewParseError(format string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ParseError{Format: format, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	if e.File == "" {
+		return "parse " + e.Format + ": " + e.Err.Error()
+	}
+	return "parse " + e.File + " as " + e.Format + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// Is matches ErrParse.
+func (e *ParseError) Is(target error) bool { return target == ErrParse }
+
+// ProviderError is the failure of a provider operation (list, read, stat)
+// on a path.
+type ProviderError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *ProviderError) Error() string {
+	return e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error { return e.Err }
+
+// Is matches ErrProvider, and ErrPathNotFound when the path does not exist.
+func (e *ProviderError) Is(target error) bool {
+	return target == ErrProvider || target == ErrPathNotFound && errors.Is(e.Err, fs.ErrNotExist)
+}
+
+// ErrorCategory returns the category of an error for reporting, empty for
+// nil.
+func ErrorCategory(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrPathNotFound):
+		return ErrorCategoryPathNotFound
+	case errors.Is(err, ErrParse):
+		return ErrorCategoryParse
+	case errors.Is(err, ErrProvider):
+		return ErrorCategoryProvider
+	}
+	return ErrorCategoryInternal
+}
+
+// IsUserError reports whether an error is the user's to fix: a path that
+// does not exist or input that does not parse.
+func IsUserError(err error) bool {
+	return errors.Is(err, ErrPathNotFound) || errors.Is(err, ErrParse)
+}
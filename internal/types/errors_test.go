@@ -0,0 +1,48 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCategory(t *testing.T) {
+	notFound := &ProviderError{Op: "read", Path: "/repo/package.json", Err: syscall.ENOENT}
+	denied := &ProviderError{Op: "list", Path: "/repo/secret", Err: fs.ErrPermission}
+	parse := &ParseError{File: "/repo/package.json", Format: "package.json", Err: errors.New("unexpected end of JSON input")}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+		user bool
+	}{
+		{"nil", nil, "", false},
+		{"missing path", notFound, ErrorCategoryPathNotFound, true},
+		{"wrapped missing path", fmt.Errorf("scan: %w", notFound), ErrorCategoryPathNotFound, true},
+		{"sentinel", fmt.Errorf("%w: /repo", ErrPathNotFound), ErrorCategoryPathNotFound, true},
+		{"unreadable path", denied, ErrorCategoryProvider, false},
+		{"parse", parse, ErrorCategoryParse, true},
+		{"wrapped parse", fmt.Errorf("detect nodejs: %w", NewParseError("package.json", errors.New("bad"))), ErrorCategoryParse, true},
+		{"other", errors.New("index out of range"), ErrorCategoryInternal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCategory(tt.err))
+			assert.Equal(t, tt.user, IsUserError(tt.err))
+		})
+	}
+}
+
+func TestErrorMessages(t *testing.T) {
+	assert.Equal(t, "read /repo/package.json: no such file or directory",
+		(&ProviderError{Op: "read", Path: "/repo/package.json", Err: syscall.ENOENT}).Error())
+	assert.Equal(t, "parse Cargo.toml: expected '='", NewParseError("Cargo.toml", errors.New("expected '='")).Error())
+	assert.Equal(t, "parse /app.zip as zip: not a valid zip file",
+		(&ParseError{File: "/app.zip", Format: "zip", Err: errors.New("not a valid zip file")}).Error())
+	assert.Nil(t, NewParseError("json", nil))
+}
@@ -8,6 +8,8 @@ type TechInfo struct {
 	Description   string                 `json:"description,omitempty"`
 	IsPrimaryTech *bool                  `json:"is_primary_tech,omitempty"`
 	Aliases       []string               `json:"aliases,omitempty"`
+	Implies       []string               `json:"implies,omitempty"`
+	Supersedes    []string               `json:"supersedes,omitempty"`
 	Properties    map[string]interface{} `json:"properties,omitempty"`
 }
 
@@ -4,29 +4,37 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/go-enry/go-enry/v2"
 	"github.com/petrarca/tech-stack-analyzer/internal/constants"
 	"github.com/petrarca/tech-stack-analyzer/internal/git"
 )
 
-// Payload represents the analysis result for a directory or component
+// Payload represents the analysis result for a directory or component.
+// Its methods are safe for concurrent use, so detectors may run in
+// parallel on the same payload; the fields may be read directly once they
+// are done.
 type Payload struct {
 	Metadata         interface{}            `json:"metadata,omitempty"`
 	Git              *git.GitInfo           `json:"git,omitempty"`
 	ID               string                 `json:"id"`
 	Name             string                 `json:"name"`
 	Path             []string               `json:"path,omitempty"`
-	SourceDir        string                 `json:"source_dir,omitempty"` // Directory this component owns, relative to scan root (e.g. "/backend/customer-journey")
-	ComponentType    string                 `json:"type,omitempty"`       // Type of component (e.g., "maven", "nodejs", "python")
-	Tech             []string               `json:"tech"`                 // Changed from *string to []string to support multiple primary technologies
+	SourceDir        string                 `json:"source_dir,omitempty"`     // Directory this component owns, relative to scan root (e.g. "/backend/customer-journey")
+	ComponentType    string                 `json:"type,omitempty"`           // Type of component (e.g., "maven", "nodejs", "python")
+	Kind             string                 `json:"component_type,omitempty"` // What the component is: service, cli, library, frontend or infra
+	Tech             []string               `json:"tech"`                     // Changed from *string to []string to support multiple primary technologies
 	Techs            []string               `json:"techs"`
 	Languages        map[string]int         `json:"languages"`
 	PrimaryLanguages []PrimaryLanguage      `json:"primary_languages,omitempty"` // Top programming languages (from code_stats)
 	PrimaryTechs     []string               `json:"primary_techs,omitempty"`     // Weight-filtered primary technologies (adaptive threshold on component count)
 	Licenses         []License              `json:"licenses"`                    // Changed to structured License objects
 	Reason           map[string][]string    `json:"reason,omitempty"`            // Maps technology to detection reasons, "_" for non-tech reasons
+	ReasonOverflow   map[string]int         `json:"reason_overflow,omitempty"`   // Reasons left out per Reason key when they are capped (see ConsolidateReasons)
+	Confidence       map[string]float64     `json:"confidence,omitempty"`        // Maps technology to a 0-1 score of its evidence strength
 	Dependencies     []Dependency           `json:"dependencies"`
 	DependencyEdges  []DependencyEdge       `json:"dependency_edges,omitempty"` // Package-to-package edges read from lockfiles (additive; empty when unavailable)
 	Properties       map[string]interface{} `json:"properties,omitempty"`
@@ -37,6 +45,10 @@ type Payload struct {
 	SubsystemStats   []SubsystemStat        `json:"subsystem_stats,omitempty"`   // Per-subsystem code stats rollup (root only)
 	Ecosystems       []EcosystemEntry       `json:"ecosystems,omitempty"`        // Detected technology ecosystems (root only)
 	ScanObservations interface{}            `json:"scan_observations,omitempty"` // File-level observations (root only, optional)
+
+	mu           sync.Mutex     // guards the fields while methods run, see lock
+	recordEvents bool           // whether changes are logged, see RecordEvents
+	events       []PayloadEvent // changes to techs and children, see Events
 }
 
 // ComponentPath returns the stable, unique key used to identify this component in code stats.
@@ -45,6 +57,7 @@ type Payload struct {
 // Returns empty string for root and virtual components (Path[0] == "/") which have no
 // distinct manifest location and should not receive per-component stats.
 func (p *Payload) ComponentPath() string {
+	defer p.lock()()
 	if len(p.Path) > 0 && p.Path[0] != "/" {
 		return p.Path[0]
 	}
@@ -157,6 +170,21 @@ type License struct {
 	Confidence      float64 `json:"confidence"`                 // Detection confidence (0.0-1.0)
 	OriginalLicense string  `json:"original_license,omitempty"` // Original license before normalization
 	Category        string  `json:"category,omitempty"`         // Risk category: forbidden|restricted|reciprocal|notice|permissive|unencumbered|unknown
+	// Expression is the parsed structure of the compound SPDX expression
+	// (OR, AND, WITH) the license was declared in; nil for a lone license.
+	Expression *LicenseExpression `json:"expression,omitempty"`
+	// TextHash is the SHA-256 of the normalized text of the license file
+	// (--license-text-hash), grouping identical custom licenses across scans.
+	TextHash string `json:"text_hash,omitempty"`
+}
+
+// LicenseExpression is a node of a parsed SPDX license expression: an AND
+// or OR over its operands, or a license with an optional WITH exception.
+type LicenseExpression struct {
+	Operator  string              `json:"operator,omitempty"` // "AND" or "OR"; empty for a license
+	Operands  []LicenseExpression `json:"operands,omitempty"`
+	License   string              `json:"license,omitempty"`
+	Exception string              `json:"exception,omitempty"`
 }
 
 // MarshalJSON customizes Edge JSON serialization (target as ID string)
@@ -174,6 +202,19 @@ func (e Edge) MarshalJSON() ([]byte, error) {
 	return json.Marshal(edgeMap)
 }
 
+// UnmarshalJSON reverses MarshalJSON: the target ID becomes a stub payload
+// carrying only the ID, so a loaded scan output re-serializes unchanged.
+func (e *Edge) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Target string `json:"target"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Target = &Payload{ID: raw.Target}
+	return nil
+}
+
 // NewPayload creates a new payload with a temporary ID (will be finalized by AssignIDs)
 func NewPayload(name string, paths []string) *Payload {
 	// Use first path for temporary ID generation
@@ -250,6 +291,7 @@ func sourceDir(manifestPath string) string {
 // Standardizes on map[string]interface{} for flexibility while providing a clean API.
 // Example: payload.SetComponentProperty("nodejs", "package_name", "@org/package")
 func (p *Payload) SetComponentProperty(techKey, propertyKey string, value interface{}) {
+	defer p.lock()()
 	if p.Properties == nil {
 		p.Properties = make(map[string]interface{})
 	}
@@ -269,6 +311,7 @@ func (p *Payload) SetComponentProperty(techKey, propertyKey string, value interf
 // SetComponentProperties sets multiple properties for a component technology.
 // Example: payload.SetComponentProperties("python", map[string]interface{}{"package_name": "myapp", "version": "1.0"})
 func (p *Payload) SetComponentProperties(techKey string, properties map[string]interface{}) {
+	defer p.lock()()
 	if p.Properties == nil {
 		p.Properties = make(map[string]interface{})
 	}
@@ -304,54 +347,70 @@ func (p *Payload) assignChildIDs(rootID string) {
 	}
 }
 
-// AddChild adds a child payload with deduplication
+// AddChild adds a child payload with deduplication: a child of the same
+// name with a path in common and a primary tech is merged into instead.
+// Children added concurrently are matched too, as the children are checked
+// again until none was added meanwhile.
 func (p *Payload) AddChild(service *Payload) *Payload {
-	// Check for existing component to merge
-	var exist *Payload
-	for _, child := range p.Children {
-		// we only merge if a tech is similar otherwise it's too easy to get a false-positive
-		if len(child.Tech) == 0 && len(service.Tech) == 0 {
-			continue
-		}
-		// Only merge components with the same name AND at least one overlapping path
-		// This prevents merging components from different directories that happen to share a name
-		if child.Name == service.Name && hasOverlappingPath(child.Path, service.Path) {
-			exist = child
-			break
+	candidate := service.snapshot()
+	checked := 0
+	for {
+		unlock := p.lock()
+		checked = min(checked, len(p.Children))
+		children := slices.Clone(p.Children[checked:])
+		if len(children) == 0 {
+			p.Children = append(p.Children, service)
+			p.logEvent(EventChildAdded, candidate.Name, firstPath(candidate.Path))
+			unlock()
+			return service
+		}
+		unlock()
+		for _, child := range children {
+			if child == service || child.mergeChild(candidate) {
+				p.logChildMerged(child, candidate)
+				return child
+			}
 		}
-		// REMOVED: Don't merge by technology type, only by name
-		// This was causing all Node.js components to be merged together
+		checked += len(children)
 	}
+}
 
-	if exist != nil {
-		// Merge with existing component
-		// Log all paths where it was found
-		for _, path := range service.Path {
-			exist.AddPath(path)
-		}
-
-		// Merge primary techs
-		for _, tech := range service.Tech {
-			exist.AddPrimaryTech(tech)
-		}
-
-		// Update edges to point to the initial component (simplified for Go)
-		// This would need parent reference which we don't track in edges
-
-		// Merge dependencies
-		for _, dep := range service.Dependencies {
-			exist.AddDependency(dep)
-		}
-
-		// Merge properties
-		exist.mergeProperties(service.Properties)
+// mergeChild merges a new child into the payload when it is the same
+// component: of the same name, with a path in common, and with a primary
+// tech on either, as merging on the name alone gives false positives.
+func (p *Payload) mergeChild(service *Payload) bool {
+	defer p.lock()()
+	if len(p.Tech) == 0 && len(service.Tech) == 0 {
+		return false
+	}
+	if p.Name != service.Name || !hasOverlappingPath(p.Path, service.Path) {
+		return false
+	}
+	for _, path := range service.Path {
+		p.addPath(path)
+	}
+	for _, tech := range service.Tech {
+		p.addPrimaryTech(tech)
+	}
+	for _, dep := range service.Dependencies {
+		p.addDependency(dep)
+	}
+	p.mergeProperties(service.Properties)
+	return true
+}
 
-		return exist
+func (p *Payload) logChildMerged(child, service *Payload) {
+	if child != service {
+		defer p.lock()()
+		p.logEvent(EventChildMerged, service.Name, firstPath(service.Path))
 	}
+}
 
-	// Add new child if no duplicate found
-	p.Children = append(p.Children, service)
-	return service
+func firstPath(paths []string) string {
+	if len(paths) > 0 {
+		return paths[0]
+	}
+	return ""
 }
 
 // hasOverlappingPath checks if two path arrays have at least one common path
@@ -369,6 +428,11 @@ func hasOverlappingPath(paths1, paths2 []string) bool {
 
 // AddPath adds a path to the payload, deduplicating entries
 func (p *Payload) AddPath(path string) {
+	defer p.lock()()
+	p.addPath(path)
+}
+
+func (p *Payload) addPath(path string) {
 	// Check for duplicate
 	for _, existing := range p.Path {
 		if existing == path {
@@ -380,11 +444,15 @@ func (p *Payload) AddPath(path string) {
 
 // AddLanguageWithCount increments the count for a language
 func (p *Payload) AddLanguageWithCount(language string, count int) {
+	defer p.lock()()
 	p.Languages[language] += count
 }
 
 // Combine merges another payload into this one
 func (p *Payload) Combine(other *Payload) {
+	other = other.snapshot()
+	defer p.lock()()
+	p.logEvent(EventCombined, other.Name, firstPath(other.Path))
 	p.mergePaths(other.Path)
 	p.mergeLanguages(other.Languages)
 	p.mergeTechs(other.Techs)
@@ -392,6 +460,8 @@ func (p *Payload) Combine(other *Payload) {
 	p.mergeDependencies(other.Dependencies)
 	p.mergeLicenses(other.Licenses)
 	p.mergeReasons(other.Reason)
+	p.mergeReasonOverflow(other.ReasonOverflow)
+	p.mergeConfidence(other.Confidence)
 	p.mergeProperties(other.Properties)
 	p.mergeGit(other.Git)
 }
@@ -423,7 +493,7 @@ func (p *Payload) mergeTechs(techs []string) {
 func (p *Payload) mergeTechField(techs []string) {
 	for _, tech := range techs {
 		if tech != "" {
-			p.AddPrimaryTech(tech)
+			p.addPrimaryTech(tech)
 			if !p.containsString(p.Techs, tech) {
 				p.Techs = append(p.Techs, tech)
 			}
@@ -463,7 +533,7 @@ func (p *Payload) mergeReasons(reasons map[string][]string) {
 		if tech == constants.ReasonKeyGlobal {
 			// Add "_" reasons directly without adding "_" as a tech
 			for _, reason := range reasons {
-				p.AddReason(reason)
+				p.addReason(tech, reason)
 			}
 			continue
 		}
@@ -471,7 +541,7 @@ func (p *Payload) mergeReasons(reasons map[string][]string) {
 		if tech == constants.ReasonKeyLicense {
 			// Add "_license" reasons directly without adding "_license" as a tech
 			for _, reason := range reasons {
-				p.AddLicenseReason(reason)
+				p.addReason(tech, reason)
 			}
 			continue
 		}
@@ -479,18 +549,58 @@ func (p *Payload) mergeReasons(reasons map[string][]string) {
 		if tech == constants.ReasonKeyDocker {
 			// Add "_docker" reasons directly without adding "_docker" as a tech
 			for _, reason := range reasons {
-				p.AddDockerReason(reason)
+				p.addReason(tech, reason)
 			}
 			continue
 		}
 
 		for _, reason := range reasons {
-			// Use AddTech to handle deduplication and proper merging
-			p.AddTech(tech, reason)
+			// Use addTech to handle deduplication and proper merging
+			p.addTech(tech, reason)
 		}
 	}
 }
 
+// mergeReasonOverflow adds the reasons left out of the other payload.
+func (p *Payload) mergeReasonOverflow(overflow map[string]int) {
+	for key, count := range overflow {
+		if p.ReasonOverflow == nil {
+			p.ReasonOverflow = make(map[string]int)
+		}
+		p.ReasonOverflow[key] += count
+	}
+}
+
+// mergeConfidence keeps the higher score of techs scored in both payloads.
+func (p *Payload) mergeConfidence(confidence map[string]float64) {
+	for tech, score := range confidence {
+		if score > p.Confidence[tech] {
+			p.setTechConfidence(tech, score)
+		}
+	}
+}
+
+// arrayProperties are property keys holding one entry per source file; they
+// are concatenated rather than overwritten when payloads merge.
+var arrayProperties = map[string]bool{
+	"docker":                true,
+	"terraform":             true,
+	"archives":              true,
+	"packaging":             true,
+	"dev_environment":       true,
+	"tasks":                 true,
+	"lint_config":           true,
+	"observability":         true,
+	"auth_evidence":         true,
+	"notification_evidence": true,
+	"notebooks":             true,
+	"salesforce_manifests":  true,
+	"sapui5_apps":           true,
+	"cmake_toolchains":      true,
+	"documentation":         true,
+	"env_files":             true,
+}
+
 func (p *Payload) mergeProperties(properties map[string]interface{}) {
 	if len(properties) == 0 {
 		return
@@ -499,8 +609,8 @@ func (p *Payload) mergeProperties(properties map[string]interface{}) {
 		p.Properties = make(map[string]interface{})
 	}
 	for key, value := range properties {
-		// Special handling for array properties (docker, terraform) - merge arrays
-		if key == "docker" || key == "terraform" {
+		// Special handling for array properties - merge arrays
+		if arrayProperties[key] {
 			existing, existsInP := p.Properties[key]
 			newArray, isArray := value.([]interface{})
 
@@ -550,151 +660,127 @@ func (p *Payload) containsDependency(dep Dependency) bool {
 
 // AddTech adds a technology to the payload
 func (p *Payload) AddTech(tech string, reason string) {
-	// Avoid duplicates for techs, but still add reasons
-	techExists := false
-	for _, existing := range p.Techs {
-		if existing == tech {
-			techExists = true
-			break
-		}
-	}
+	defer p.lock()()
+	p.addTech(tech, reason)
+}
 
-	if !techExists {
+func (p *Payload) addTech(tech string, reason string) {
+	// Avoid duplicates for techs, but still add reasons
+	techAdded := !p.containsString(p.Techs, tech)
+	if techAdded {
 		p.Techs = append(p.Techs, tech)
 		// NOTE: Don't set primary tech here like the original
 		// The original's addTech method only adds to techs set, doesn't set this.tech
 	}
 
 	// Add reason to Reason mapping for clear association
-	if reason != "" {
-		// Initialize tech reasons slice if not exists
-		if p.Reason[tech] == nil {
-			p.Reason[tech] = make([]string, 0)
-		}
-
-		// Check if reason already exists for this tech to avoid duplicates
-		reasonExists := false
-		for _, existing := range p.Reason[tech] {
-			if existing == reason {
-				reasonExists = true
-				break
-			}
-		}
-		if !reasonExists {
-			p.Reason[tech] = append(p.Reason[tech], reason)
-		}
+	if p.addReason(tech, reason) || techAdded {
+		p.logEvent(EventTechAdded, tech, reason)
 	}
 }
 
 // AddTechs adds multiple technologies
 func (p *Payload) AddTechs(techs map[string][]string) {
+	defer p.lock()()
 	for tech, reasons := range techs {
 		for _, reason := range reasons {
-			p.AddTech(tech, reason)
+			p.addTech(tech, reason)
 		}
 	}
 }
 
 // AddReason adds a non-tech reason to the "_" key
 func (p *Payload) AddReason(reason string) {
-	if reason != "" {
-		// Initialize "_" slice if not exists
-		if p.Reason["_"] == nil {
-			p.Reason["_"] = make([]string, 0)
-		}
-
-		// Check if reason already exists to avoid duplicates
-		reasonExists := false
-		for _, existing := range p.Reason["_"] {
-			if existing == reason {
-				reasonExists = true
-				break
-			}
-		}
-		if !reasonExists {
-			p.Reason["_"] = append(p.Reason["_"], reason)
-		}
-	}
+	defer p.lock()()
+	p.addReason(constants.ReasonKeyGlobal, reason)
 }
 
 // AddLicenseReason adds a license-related reason to the "_license" key
 func (p *Payload) AddLicenseReason(reason string) {
-	if reason != "" {
-		// Initialize "_license" slice if not exists
-		if p.Reason[constants.ReasonKeyLicense] == nil {
-			p.Reason[constants.ReasonKeyLicense] = make([]string, 0)
-		}
-
-		// Check if reason already exists to avoid duplicates
-		reasonExists := false
-		for _, existing := range p.Reason[constants.ReasonKeyLicense] {
-			if existing == reason {
-				reasonExists = true
-				break
-			}
-		}
-		if !reasonExists {
-			p.Reason[constants.ReasonKeyLicense] = append(p.Reason[constants.ReasonKeyLicense], reason)
-		}
-	}
+	defer p.lock()()
+	p.addReason(constants.ReasonKeyLicense, reason)
 }
 
 // AddDockerReason adds a Docker-related reason to the "_docker" key
 func (p *Payload) AddDockerReason(reason string) {
-	if reason != "" {
-		// Initialize "_docker" slice if not exists
-		if p.Reason[constants.ReasonKeyDocker] == nil {
-			p.Reason[constants.ReasonKeyDocker] = make([]string, 0)
-		}
+	defer p.lock()()
+	p.addReason(constants.ReasonKeyDocker, reason)
+}
 
-		// Check if reason already exists to avoid duplicates
-		reasonExists := false
-		for _, existing := range p.Reason[constants.ReasonKeyDocker] {
-			if existing == reason {
-				reasonExists = true
-				break
-			}
-		}
-		if !reasonExists {
-			p.Reason[constants.ReasonKeyDocker] = append(p.Reason[constants.ReasonKeyDocker], reason)
-		}
+// addReason adds a reason under a tech or one of the non-tech keys,
+// reporting whether it is new.
+func (p *Payload) addReason(key, reason string) bool {
+	if reason == "" || slices.Contains(p.Reason[key], reason) {
+		return false
 	}
+	if p.Reason == nil {
+		p.Reason = make(map[string][]string)
+	}
+	p.Reason[key] = append(p.Reason[key], reason)
+	return true
 }
 
 // AddLanguage increments the count for a language
 func (p *Payload) AddLanguage(language string) {
+	defer p.lock()()
 	p.Languages[language]++
 }
 
 // AddPrimaryTech adds a technology to the primary tech array (avoiding duplicates)
 func (p *Payload) AddPrimaryTech(tech string) {
+	defer p.lock()()
+	p.addPrimaryTech(tech)
+}
+
+func (p *Payload) addPrimaryTech(tech string) {
 	// Avoid duplicates
-	for _, t := range p.Tech {
-		if t == tech {
-			return
-		}
+	if !p.containsString(p.Tech, tech) {
+		p.Tech = append(p.Tech, tech)
+		p.logEvent(EventPrimaryTechAdded, tech, "")
 	}
-	p.Tech = append(p.Tech, tech)
+}
+
+// RemoveTech removes a technology from the tech and techs arrays along with
+// its reasons and confidence.
+func (p *Payload) RemoveTech(tech string) {
+	defer p.lock()()
+	p.logEvent(EventTechRemoved, tech, "")
+	p.Tech = slices.DeleteFunc(p.Tech, func(t string) bool { return t == tech })
+	p.Techs = slices.DeleteFunc(p.Techs, func(t string) bool { return t == tech })
+	delete(p.Reason, tech)
+	delete(p.ReasonOverflow, tech)
+	delete(p.Confidence, tech)
+}
+
+// SetTechConfidence records the confidence score of a technology.
+func (p *Payload) SetTechConfidence(tech string, score float64) {
+	defer p.lock()()
+	p.setTechConfidence(tech, score)
+}
+
+func (p *Payload) setTechConfidence(tech string, score float64) {
+	if p.Confidence == nil {
+		p.Confidence = make(map[string]float64)
+	}
+	p.Confidence[tech] = score
 }
 
 // SetComponentType sets the component type (e.g., "maven", "nodejs", "python")
 // This should be called by detectors to identify what kind of component this is
 func (p *Payload) SetComponentType(componentType string) {
+	defer p.lock()()
 	p.ComponentType = componentType
 }
 
 // HasPrimaryTech checks if a technology is in the primary tech array
 func (p *Payload) HasPrimaryTech(tech string) bool {
-	for _, t := range p.Tech {
-		if t == tech {
-			return true
-		}
-	}
-	return false
+	defer p.lock()()
+	return p.containsString(p.Tech, tech)
 }
 
 // AddLicense adds a license to the payload, deduplicating by name
 func (p *Payload) AddLicense(license License) {
+	defer p.lock()()
 	// Avoid duplicates
 	for _, existing := range p.Licenses {
 		if existing.LicenseName == license.LicenseName {
@@ -707,6 +793,7 @@ func (p *Payload) AddLicense(license License) {
 
 // AddEdges adds an edge to another payload
 func (p *Payload) AddEdges(target *Payload) {
+	defer p.lock()()
 	edge := Edge{
 		Target: target,
 	}
@@ -716,6 +803,11 @@ func (p *Payload) AddEdges(target *Payload) {
 
 // AddDependency adds a dependency with deduplication
 func (p *Payload) AddDependency(dep Dependency) {
+	defer p.lock()()
+	p.addDependency(dep)
+}
+
+func (p *Payload) addDependency(dep Dependency) {
 	if !p.containsDependency(dep) {
 		p.Dependencies = append(p.Dependencies, dep)
 	}
@@ -0,0 +1,67 @@
+package types
+
+import "strings"
+
+// ConsolidateReasons drops the reasons of each key that repeat an earlier
+// one but for case and spacing and, when limit is positive, keeps at most
+// limit reasons per key, counting the others in ReasonOverflow. The first
+// reason of each kind (its text before ": ", as "matched file") is kept
+// before more reasons of a kind already kept, so one kind of evidence
+// found in many files does not crowd out the others.
+func (p *Payload) ConsolidateReasons(limit int) {
+	defer p.lock()()
+	for key, reasons := range p.Reason {
+		reasons = dedupeReasons(reasons)
+		if limit > 0 && len(reasons) > limit {
+			if p.ReasonOverflow == nil {
+				p.ReasonOverflow = make(map[string]int)
+			}
+			p.ReasonOverflow[key] += len(reasons) - limit
+			reasons = pickReasons(reasons, limit)
+		}
+		p.Reason[key] = reasons
+	}
+}
+
+// dedupeReasons drops the reasons equal to an earlier one when compared
+// case-insensitively with runs of spaces collapsed.
+func dedupeReasons(reasons []string) []string {
+	seen := make(map[string]bool, len(reasons))
+	kept := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		key := strings.ToLower(strings.Join(strings.Fields(reason), " "))
+		if !seen[key] {
+			seen[key] = true
+			kept = append(kept, reason)
+		}
+	}
+	return kept
+}
+
+// pickReasons keeps limit reasons in their order: the first of each kind,
+// then the earliest of the rest.
+func pickReasons(reasons []string, limit int) []string {
+	keep := make([]bool, len(reasons))
+	kinds := make(map[string]bool)
+	kept := 0
+	for i, reason := range reasons {
+		kind, _, _ := strings.Cut(reason, ": ")
+		if kept < limit && !kinds[kind] {
+			kinds[kind], keep[i] = true, true
+			kept++
+		}
+	}
+	for i := range reasons {
+		if kept < limit && !keep[i] {
+			keep[i] = true
+			kept++
+		}
+	}
+	picked := make([]string, 0, limit)
+	for i, reason := range reasons {
+		if keep[i] {
+			picked = append(picked, reason)
+		}
+	}
+	return picked
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsolidateReasons(t *testing.T) {
+	p := NewPayloadWithPath("main", "/")
+	for i := range 8 {
+		p.AddTech("docker", fmt.Sprintf("matched file: service%d/Dockerfile", i))
+	}
+	p.AddTech("docker", "matched dependency: dockerode")
+	p.AddTech("docker", "Matched file:  service0/Dockerfile")
+	p.AddTech("nodejs", "matched file: package.json")
+
+	p.ConsolidateReasons(0)
+	assert.Len(t, p.Reason["docker"], 9, "near-identical reasons are dropped")
+	assert.Nil(t, p.ReasonOverflow)
+
+	p.ConsolidateReasons(3)
+	assert.Equal(t, []string{
+		"matched file: service0/Dockerfile",
+		"matched file: service1/Dockerfile",
+		"matched dependency: dockerode",
+	}, p.Reason["docker"], "the first reason of each kind is kept")
+	assert.Equal(t, map[string]int{"docker": 6}, p.ReasonOverflow)
+	assert.Equal(t, []string{"matched file: package.json"}, p.Reason["nodejs"])
+
+	p.ConsolidateReasons(3)
+	assert.Equal(t, map[string]int{"docker": 6}, p.ReasonOverflow, "consolidating again changes nothing")
+
+	other := NewPayloadWithPath("main", "/")
+	other.ReasonOverflow = map[string]int{"docker": 2}
+	p.Combine(other)
+	assert.Equal(t, map[string]int{"docker": 8}, p.ReasonOverflow, "overflow counts add up")
+
+	p.RemoveTech("docker")
+	assert.Empty(t, p.ReasonOverflow)
+}
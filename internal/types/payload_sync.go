@@ -0,0 +1,93 @@
+package types
+
+import (
+	"maps"
+	"slices"
+)
+
+// Kinds of payload events.
+const (
+	EventTechAdded        = "tech_added"         // a tech, with the reason for it
+	EventPrimaryTechAdded = "primary_tech_added" // a primary tech
+	EventTechRemoved      = "tech_removed"       // a tech with its reasons and confidence
+	EventChildAdded       = "child_added"        // a child, with its manifest path
+	EventChildMerged      = "child_merged"       // a child merged into an existing one of the same name and path
+	EventCombined         = "combined"           // another payload merged into this one
+)
+
+// PayloadEvent is a change made to the techs or children of a payload.
+type PayloadEvent struct {
+	Kind   string
+	Name   string // tech or child name
+	Detail string // reason for a tech, manifest path of a child
+}
+
+// lock locks the payload and returns the function unlocking it. No method
+// holds the locks of two payloads at once: the payload merged in is
+// snapshotted first.
+func (p *Payload) lock() func() {
+	p.mu.Lock()
+	return p.mu.Unlock
+}
+
+// RecordEvents starts logging the changes made to the techs and children of
+// the payload, to be read with Events. Payloads log nothing by default.
+func (p *Payload) RecordEvents() {
+	defer p.lock()()
+	p.recordEvents = true
+}
+
+// Events returns the changes made to the techs and children of the payload
+// through its methods since RecordEvents, in the order they were made.
+// Paths, languages and dependencies are not logged.
+func (p *Payload) Events() []PayloadEvent {
+	defer p.lock()()
+	return slices.Clone(p.events)
+}
+
+func (p *Payload) logEvent(kind, name, detail string) {
+	if !p.recordEvents {
+		return
+	}
+	p.events = append(p.events, PayloadEvent{Kind: kind, Name: name, Detail: detail})
+}
+
+// snapshot returns a copy of the payload whose slices and maps are not
+// shared with it, to be read while the payload changes. The copy has its
+// own lock and logs no events; new fields of Payload are to be listed here.
+func (p *Payload) snapshot() *Payload {
+	defer p.lock()()
+	s := &Payload{
+		Metadata:         p.Metadata,
+		Git:              p.Git,
+		ID:               p.ID,
+		Name:             p.Name,
+		Path:             slices.Clone(p.Path),
+		SourceDir:        p.SourceDir,
+		ComponentType:    p.ComponentType,
+		Kind:             p.Kind,
+		Tech:             slices.Clone(p.Tech),
+		Techs:            slices.Clone(p.Techs),
+		Languages:        maps.Clone(p.Languages),
+		PrimaryLanguages: p.PrimaryLanguages,
+		PrimaryTechs:     p.PrimaryTechs,
+		Licenses:         slices.Clone(p.Licenses),
+		Reason:           make(map[string][]string, len(p.Reason)),
+		ReasonOverflow:   maps.Clone(p.ReasonOverflow),
+		Confidence:       maps.Clone(p.Confidence),
+		Dependencies:     slices.Clone(p.Dependencies),
+		DependencyEdges:  p.DependencyEdges,
+		Properties:       maps.Clone(p.Properties),
+		Children:         p.Children,
+		Edges:            p.Edges,
+		ComponentRefs:    p.ComponentRefs,
+		CodeStats:        p.CodeStats,
+		SubsystemStats:   p.SubsystemStats,
+		Ecosystems:       p.Ecosystems,
+		ScanObservations: p.ScanObservations,
+	}
+	for key, reasons := range p.Reason {
+		s.Reason[key] = slices.Clone(reasons)
+	}
+	return s
+}
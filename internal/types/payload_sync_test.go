@@ -0,0 +1,106 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runParallel runs n detectors at once, as a concurrent traversal would.
+func runParallel(n int, detector func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			detector(i)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPayloadParallelDetectors(t *testing.T) {
+	root := NewPayloadWithPath("main", "/")
+	runParallel(16, func(i int) {
+		tech := fmt.Sprintf("tech%d", i%4)
+		root.AddTech(tech, fmt.Sprintf("matched file%d.txt", i))
+		root.AddPrimaryTech(tech)
+		root.AddReason("scanned by detector")
+		root.AddLanguage("Go")
+		root.AddDependency(Dependency{Type: "golang", Name: fmt.Sprintf("example.com/dep%d", i%8)})
+		root.SetTechConfidence(tech, float64(i)/16)
+		root.SetComponentProperty("golang", fmt.Sprintf("key%d", i), i)
+		assert.True(t, root.HasPrimaryTech(tech))
+
+		other := NewPayloadWithPath("virtual", "/")
+		other.AddTech("docker", "matched Dockerfile")
+		root.Combine(other)
+	})
+
+	assert.ElementsMatch(t, []string{"tech0", "tech1", "tech2", "tech3", "docker"}, root.Techs)
+	assert.ElementsMatch(t, []string{"tech0", "tech1", "tech2", "tech3"}, root.Tech)
+	assert.Equal(t, 16, root.Languages["Go"])
+	assert.Len(t, root.Dependencies, 8)
+	assert.Equal(t, []string{"scanned by detector"}, root.Reason["_"])
+	assert.Len(t, root.Reason["tech0"], 4)
+	assert.Len(t, root.Properties["golang"], 16)
+}
+
+func TestAddChildMergesParallelDuplicates(t *testing.T) {
+	root := NewPayloadWithPath("main", "/")
+	runParallel(16, func(i int) {
+		component := NewPayloadWithPath("api", "/api/package.json")
+		component.AddPrimaryTech("nodejs")
+		component.AddDependency(Dependency{Type: "npm", Name: fmt.Sprintf("dep%d", i)})
+		child := root.AddChild(component)
+		child.AddTech("express", "matched dependency")
+		root.AddChild(NewPayloadWithPath(fmt.Sprintf("worker%d", i), fmt.Sprintf("/worker%d/package.json", i)))
+	})
+
+	assert.Len(t, root.Children, 17)
+	var api *Payload
+	for _, child := range root.Children {
+		if child.Name == "api" {
+			assert.Nil(t, api, "duplicates are merged into one child")
+			api = child
+		}
+	}
+	if assert.NotNil(t, api) {
+		assert.Len(t, api.Dependencies, 16)
+		assert.Equal(t, []string{"express"}, api.Techs)
+	}
+}
+
+func TestPayloadEvents(t *testing.T) {
+	p := NewPayloadWithPath("api", "/api/package.json")
+	p.RecordEvents()
+	p.AddPrimaryTech("nodejs")
+	p.AddTech("express", "matched dependency: express")
+	p.AddTech("express", "matched dependency: express")
+	p.AddTech("express", "matched file: app.js")
+	p.RemoveTech("express")
+	p.AddChild(NewPayloadWithPath("worker", "/api/worker/package.json"))
+	p.AddChild(&Payload{Name: "worker", Tech: []string{"nodejs"}, Path: []string{"/api/worker/package.json"}})
+	other := NewPayloadWithPath("virtual", "/api/Dockerfile")
+	p.Combine(other)
+
+	assert.Equal(t, []PayloadEvent{
+		{Kind: EventPrimaryTechAdded, Name: "nodejs"},
+		{Kind: EventTechAdded, Name: "express", Detail: "matched dependency: express"},
+		{Kind: EventTechAdded, Name: "express", Detail: "matched file: app.js"},
+		{Kind: EventTechRemoved, Name: "express"},
+		{Kind: EventChildAdded, Name: "worker", Detail: "/api/worker/package.json"},
+		{Kind: EventChildMerged, Name: "worker", Detail: "/api/worker/package.json"},
+		{Kind: EventCombined, Name: "virtual", Detail: "/api/Dockerfile"},
+	}, p.Events())
+}
+
+func TestPayloadEventsOff(t *testing.T) {
+	p := NewComponentPayload("api", "package.json", "/repo/api", "/repo", "nodejs")
+	p.AddTech("express", "matched dependency: express")
+	p.AddChild(NewPayloadWithPath("worker", "/api/worker/package.json"))
+
+	assert.Empty(t, p.Events(), "payloads log no events unless recording")
+}
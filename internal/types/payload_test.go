@@ -315,7 +315,7 @@ func TestPayload_Combine(t *testing.T) {
 
 // Helper method to clone a payload for testing
 func (p *Payload) clone() *Payload {
-	clone := *p
+	clone := p.snapshot()
 
 	// Deep clone slices
 	if p.Tech != nil {
@@ -343,7 +343,7 @@ func (p *Payload) clone() *Payload {
 		}
 	}
 
-	return &clone
+	return clone
 }
 
 func TestPayload_EdgeCases(t *testing.T) {
@@ -23,6 +23,14 @@ type Provider interface {
 	GetBasePath() string
 }
 
+// RangeReader is implemented by providers able to read part of a file
+// without reading all of it.
+type RangeReader interface {
+	// ReadFileRange reads up to length bytes of a file from offset, as
+	// stored: their encoding is not normalized
+	ReadFileRange(path string, offset, length int64) ([]byte, error)
+}
+
 // File represents a file or directory entry
 type File struct {
 	Name     string `json:"name"`
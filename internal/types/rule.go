@@ -37,11 +37,22 @@ type Rule struct {
 	Properties    map[string]interface{} `yaml:"properties,omitempty" json:"properties,omitempty"`
 	IsComponent   *bool                  `yaml:"is_component,omitempty" json:"is_component,omitempty"`       // nil = auto (use type-based logic)
 	IsPrimaryTech *bool                  `yaml:"is_primary_tech,omitempty" json:"is_primary_tech,omitempty"` // nil = use current logic (component = primary tech)
+	Implies       []string               `yaml:"implies,omitempty" json:"implies,omitempty"`                 // Techs this tech is built on (nextjs implies react); see --resolve-implied
+	Supersedes    []string               `yaml:"supersedes,omitempty" json:"supersedes,omitempty"`           // Techs dropped when detected in the same component as this tech
 	DotEnv        []string               `yaml:"dotenv,omitempty" json:"dotenv,omitempty"`
 	Dependencies  []Dependency           `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
 	Files         []string               `yaml:"files,omitempty" json:"files,omitempty"`
 	Extensions    []string               `yaml:"extensions,omitempty" json:"extensions,omitempty"`
 	Content       []ContentRule          `yaml:"content,omitempty" json:"content,omitempty"`
+	Unless        *RuleUnless            `yaml:"unless,omitempty" json:"unless,omitempty"` // Conditions vetoing the detection in a directory (false-positive suppression)
+}
+
+// RuleUnless lists the conditions under which a rule's tech is not reported
+// for a directory, whatever matched it: one of the files exists there, or one
+// of the content patterns matches a file there.
+type RuleUnless struct {
+	Files   []string      `yaml:"files,omitempty" json:"files,omitempty"`     // File names or glob patterns (e.g. "*.csproj")
+	Content []ContentRule `yaml:"content,omitempty" json:"content,omitempty"` // Each limited to files or extensions of the directory
 }
 
 // Dependency represents a dependency pattern (struct for YAML, but marshals as array for JSON)
@@ -0,0 +1,172 @@
+// Package ui serves a local web interface for browsing a scan output: the
+// component tree, a search over technologies and dependencies, and charts of
+// the code statistics. The HTML, script and style sheet are embedded in the
+// binary, so the interface works offline.
+package ui
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+)
+
+//go:embed static
+var static embed.FS
+
+// maxSearchHits caps the results of one search, so a one-letter query does
+// not ship every dependency of a large scan to the browser.
+const maxSearchHits = 200
+
+// Server answers the interface's requests from one loaded scan output.
+type Server struct {
+	root       *types.Payload
+	components map[string]*types.Payload
+}
+
+// treeNode is a component of GET /api/tree, without its techs,
+// dependencies and code statistics, which GET /api/components/{id} returns.
+type treeNode struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Type         string      `json:"type,omitempty"`
+	SourceDir    string      `json:"source_dir,omitempty"`
+	Tech         []string    `json:"tech,omitempty"`
+	Techs        int         `json:"techs"`
+	Dependencies int         `json:"dependencies"`
+	Children     []*treeNode `json:"children,omitempty"`
+}
+
+// searchHit is a technology or dependency of a component matching a search.
+type searchHit struct {
+	ComponentID   string `json:"component_id"`
+	ComponentName string `json:"component_name"`
+	Kind          string `json:"kind"` // "tech" or "dependency"
+	Value         string `json:"value"`
+	Version       string `json:"version,omitempty"`
+	Ecosystem     string `json:"ecosystem,omitempty"` // dependency type, e.g. "npm"
+}
+
+// searchResponse is the body of GET /api/search.
+type searchResponse struct {
+	Hits      []searchHit `json:"hits"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// componentView is the body of GET /api/components/{id}: the component
+// without its children, which GET /api/tree lists.
+type componentView struct {
+	*types.Payload
+	Children []*types.Payload `json:"children"`
+}
+
+// NewServer indexes the components of a scan output by ID.
+func NewServer(root *types.Payload) *Server {
+	s := &Server{root: root, components: make(map[string]*types.Payload)}
+	var index func(p *types.Payload)
+	index = func(p *types.Payload) {
+		s.components[p.ID] = p
+		for _, child := range p.Children {
+			index(child)
+		}
+	}
+	index(root)
+	return s
+}
+
+// Handler returns the interface and its API:
+//
+//	GET /                      the embedded interface
+//	GET /api/tree              the component tree
+//	GET /api/components/{id}   a component, without its children
+//	GET /api/search?q=         techs and dependencies containing q
+func (s *Server) Handler() http.Handler {
+	assets, _ := fs.Sub(static, "static")
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(assets))
+	mux.HandleFunc("GET /api/tree", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, newTreeNode(s.root))
+	})
+	mux.HandleFunc("GET /api/components/{id}", func(w http.ResponseWriter, r *http.Request) {
+		p := s.components[r.PathValue("id")]
+		if p == nil {
+			http.Error(w, "no such component", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, componentView{Payload: p})
+	})
+	mux.HandleFunc("GET /api/search", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, s.search(r.URL.Query().Get("q")))
+	})
+	return mux
+}
+
+func newTreeNode(p *types.Payload) *treeNode {
+	node := &treeNode{
+		ID:           p.ID,
+		Name:         p.Name,
+		Type:         p.ComponentType,
+		SourceDir:    p.SourceDir,
+		Tech:         p.Tech,
+		Techs:        len(p.Techs),
+		Dependencies: len(p.Dependencies),
+	}
+	for _, child := range p.Children {
+		node.Children = append(node.Children, newTreeNode(child))
+	}
+	return node
+}
+
+// search returns the techs and dependencies, of every component in tree
+// order, whose name contains query, case-insensitively.
+func (s *Server) search(query string) searchResponse {
+	resp := searchResponse{Hits: []searchHit{}}
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return resp
+	}
+	var visit func(p *types.Payload) bool
+	visit = func(p *types.Payload) bool {
+		for _, hit := range componentHits(p, query) {
+			if len(resp.Hits) == maxSearchHits {
+				resp.Truncated = true
+				return false
+			}
+			resp.Hits = append(resp.Hits, hit)
+		}
+		for _, child := range p.Children {
+			if !visit(child) {
+				return false
+			}
+		}
+		return true
+	}
+	visit(s.root)
+	return resp
+}
+
+func componentHits(p *types.Payload, query string) []searchHit {
+	var hits []searchHit
+	for _, tech := range p.Techs {
+		if strings.Contains(strings.ToLower(tech), query) {
+			hits = append(hits, searchHit{ComponentID: p.ID, ComponentName: p.Name, Kind: "tech", Value: tech})
+		}
+	}
+	for _, dep := range p.Dependencies {
+		if strings.Contains(strings.ToLower(dep.Name), query) {
+			hits = append(hits, searchHit{
+				ComponentID: p.ID, ComponentName: p.Name, Kind: "dependency",
+				Value: dep.Name, Version: dep.Version, Ecosystem: dep.Type,
+			})
+		}
+	}
+	return hits
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petrarca/tech-stack-analyzer/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResult() *types.Payload {
+	root := types.NewPayloadWithPath("myapp", "/")
+	root.ID = "root"
+	api := types.NewPayloadWithPath("api", "/services/api/package.json")
+	api.ID = "api"
+	api.ComponentType = "nodejs"
+	api.Techs = []string{"nodejs", "express"}
+	api.Dependencies = []types.Dependency{{Type: "npm", Name: "express", Version: "4.18.0"}}
+	root.AddChild(api)
+	return root
+}
+
+func get(t *testing.T, h http.Handler, path string, v interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if v != nil && rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+	}
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	h := NewServer(testResult()).Handler()
+
+	rec := get(t, h, "/", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "app.js", "the embedded interface is served")
+
+	var tree treeNode
+	get(t, h, "/api/tree", &tree)
+	require.Len(t, tree.Children, 1)
+	assert.Equal(t, "api", tree.Children[0].ID)
+	assert.Equal(t, 2, tree.Children[0].Techs)
+	assert.Equal(t, 1, tree.Children[0].Dependencies)
+
+	var component map[string]interface{}
+	get(t, h, "/api/components/api", &component)
+	assert.Equal(t, "api", component["name"])
+	assert.Nil(t, component["children"])
+	assert.Equal(t, http.StatusNotFound, get(t, h, "/api/components/nope", nil).Code)
+}
+
+func TestSearch(t *testing.T) {
+	h := NewServer(testResult()).Handler()
+
+	var resp searchResponse
+	get(t, h, "/api/search?q=EXPR", &resp)
+	require.Len(t, resp.Hits, 2, "the tech and the dependency named express")
+	assert.Equal(t, "tech", resp.Hits[0].Kind)
+	assert.Equal(t, searchHit{ComponentID: "api", ComponentName: "api", Kind: "dependency", Value: "express", Version: "4.18.0", Ecosystem: "npm"}, resp.Hits[1])
+
+	get(t, h, "/api/search?q=", &resp)
+	assert.Empty(t, resp.Hits)
+}
+
+func TestSearchIsCapped(t *testing.T) {
+	root := testResult()
+	for i := 0; i < maxSearchHits; i++ {
+		root.Children[0].Dependencies = append(root.Children[0].Dependencies, types.Dependency{Type: "npm", Name: "express-plugin"})
+	}
+	resp := NewServer(root).search("express")
+	assert.Len(t, resp.Hits, maxSearchHits)
+	assert.True(t, resp.Truncated)
+}
@@ -0,0 +1,156 @@
+"use strict";
+
+// el creates an element; strings among the children become text nodes, so
+// values from the scan output are never parsed as HTML.
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key === "onclick") node.addEventListener("click", value);
+    else node.setAttribute(key, value);
+  }
+  for (const child of children.flat()) {
+    if (child !== null && child !== undefined) node.append(child);
+  }
+  return node;
+}
+
+async function getJSON(path) {
+  const resp = await fetch(path);
+  if (!resp.ok) throw new Error(path + ": " + resp.status);
+  return resp.json();
+}
+
+const nodesByID = new Map();
+let selected = null;
+
+function renderTree(node, depth) {
+  const hasChildren = (node.children || []).length > 0;
+  const toggle = el("span", { class: "toggle" }, hasChildren ? (depth < 2 ? "-" : "+") : "");
+  const row = el("div", {},
+    toggle,
+    el("span", {}, node.name),
+    node.type ? el("span", { class: "type" }, node.type) : null);
+  const item = el("li", {}, row);
+  nodesByID.set(node.id, row);
+  if (hasChildren) {
+    const list = el("ul", {}, node.children.map((child) => renderTree(child, depth + 1)));
+    list.hidden = depth >= 2;
+    item.append(list);
+    toggle.addEventListener("click", (event) => {
+      event.stopPropagation();
+      list.hidden = !list.hidden;
+      toggle.textContent = list.hidden ? "+" : "-";
+    });
+  }
+  row.addEventListener("click", () => showComponent(node.id));
+  return item;
+}
+
+function revealInTree(id) {
+  const row = nodesByID.get(id);
+  if (!row) return;
+  for (let list = row.parentElement.parentElement; list && list.id !== "tree"; list = list.parentElement) {
+    if (list.tagName === "UL" && list.hidden) {
+      list.hidden = false;
+      list.parentElement.querySelector(".toggle").textContent = "-";
+    }
+  }
+  if (selected) selected.classList.remove("selected");
+  row.classList.add("selected");
+  selected = row;
+  row.scrollIntoView({ block: "nearest" });
+}
+
+function chips(values, primary) {
+  if (!values || values.length === 0) return el("p", { class: "empty" }, "none");
+  return el("div", { class: "chips" },
+    values.map((v) => el("span", { class: primary && primary.includes(v) ? "chip primary" : "chip" }, v)));
+}
+
+function table(headers, rows) {
+  return el("table", {},
+    el("thead", {}, el("tr", {}, headers.map((h) => el("th", {}, h)))),
+    el("tbody", {}, rows.map((row) => el("tr", {}, row.map((cell) => el("td", {}, cell))))));
+}
+
+// Dependencies are written as [type, name, version, scope, direct, metadata].
+function dependencyRows(deps) {
+  return (deps || []).map((d) => Array.isArray(d)
+    ? [d[0], d[1], d[2] || "", d[3] || "", d[4] ? "direct" : "transitive"]
+    : [d.type, d.name, d.version || "", d.scope || "", d.direct ? "direct" : "transitive"]);
+}
+
+async function showComponent(id) {
+  const c = await getJSON("api/components/" + encodeURIComponent(id));
+  revealInTree(id);
+  document.getElementById("results").hidden = true;
+  const deps = dependencyRows(c.dependencies);
+  const licenses = (c.licenses || []).map((l) => l.license_name);
+  const target = document.getElementById("component");
+  target.replaceChildren(
+    el("h2", {}, c.name),
+    el("p", { class: "muted" }, [c.type, c.source_dir].filter(Boolean).join(" - ")),
+    el("h3", {}, "Technologies"), chips(c.techs, c.tech),
+    el("h3", {}, "Licenses"), chips(licenses),
+    el("h3", {}, "Dependencies (" + deps.length + ")"),
+    deps.length ? table(["Type", "Name", "Version", "Scope", ""], deps) : el("p", { class: "empty" }, "none"));
+  renderStats(c.name, c.languages, c.code_stats);
+}
+
+function bars(title, entries, unit) {
+  entries = entries.filter(([, value]) => value > 0).sort((a, b) => b[1] - a[1]).slice(0, 15);
+  if (entries.length === 0) return null;
+  const max = entries[0][1];
+  return el("div", {},
+    el("h3", {}, title),
+    entries.map(([label, value]) => el("div", { class: "bar" },
+      el("span", {}, label),
+      el("div", { class: "fill", style: "width:" + (100 * value / max).toFixed(1) + "%" }),
+      el("span", { class: "value" }, value.toLocaleString() + unit))));
+}
+
+function renderStats(name, languages, codeStats) {
+  const target = document.getElementById("stats");
+  const byLanguage = (codeStats && codeStats.analyzed && codeStats.analyzed.by_language) || [];
+  const byType = Object.entries((codeStats && codeStats.by_type) || {}).map(([type, s]) => [type, (s.total || {}).code || 0]);
+  const total = codeStats && codeStats.total;
+  target.replaceChildren(
+    total ? el("h3", {}, "Code statistics of " + name) : null,
+    total ? table(["Files", "Lines", "Code", "Comments", "Blanks", "Complexity"],
+      [[total.files, total.lines, total.code, total.comments, total.blanks, total.complexity].map((n) => (n || 0).toLocaleString())]) : null,
+    bars("Code lines by language", byLanguage.map((l) => [l.language, l.code]), ""),
+    bars("Code lines by type", byType, ""),
+    bars("Files by language", Object.entries(languages || {}), " files"));
+}
+
+function renderResults(query, resp) {
+  const target = document.getElementById("results");
+  target.hidden = false;
+  const rows = resp.hits.map((hit) => [
+    hit.kind, hit.value, hit.version || "", hit.ecosystem || "",
+    el("a", { onclick: () => showComponent(hit.component_id) }, hit.component_name)]);
+  target.replaceChildren(
+    el("h2", {}, "Results for \"" + query + "\""),
+    resp.truncated ? el("p", { class: "muted" }, "Showing the first " + rows.length + " matches.") : null,
+    rows.length ? table(["Kind", "Name", "Version", "Ecosystem", "Component"], rows) : el("p", { class: "empty" }, "No matches."));
+}
+
+let searchTimer = null;
+document.getElementById("search").addEventListener("input", (event) => {
+  clearTimeout(searchTimer);
+  const query = event.target.value.trim();
+  searchTimer = setTimeout(async () => {
+    if (query === "") {
+      document.getElementById("results").hidden = true;
+      return;
+    }
+    renderResults(query, await getJSON("api/search?q=" + encodeURIComponent(query)));
+  }, 200);
+});
+
+(async function init() {
+  const tree = await getJSON("api/tree");
+  document.getElementById("title").textContent = "Stack Analyzer - " + tree.name;
+  document.getElementById("tree").replaceChildren(el("ul", {}, renderTree(tree, 0)));
+  await showComponent(tree.id);
+})();